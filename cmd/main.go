@@ -14,27 +14,7 @@ import (
 	"github.com/gofrs/flock"
 	"github.com/janderssonse/karei/internal/cli"
 	"github.com/janderssonse/karei/internal/domain"
-)
-
-// Exit codes following Unix conventions.
-const (
-	ExitSuccess         = 0  // Command completed successfully
-	ExitGeneralError    = 1  // General errors
-	ExitUsageError      = 2  // Invalid arguments/usage
-	ExitConfigError     = 3  // Configuration issues
-	ExitPermissionError = 4  // Permission denied, need sudo
-	ExitNotFoundError   = 5  // Theme/font/app not found
-	ExitDependencyError = 10 // Missing dependencies (gum, git, curl)
-	ExitNetworkError    = 11 // Download/network failures
-	ExitSystemError     = 12 // Disk space, filesystem issues
-	ExitTimeoutError    = 13 // Interactive timeout
-	ExitInterruptError  = 14 // User Ctrl+C interrupt
-	ExitThemeError      = 20 // Theme application failed
-	ExitFontError       = 21 // Font installation failed
-	ExitAppError        = 22 // Application installation failed
-	ExitBackupError     = 23 // Backup/restore failed
-	ExitMigrationError  = 24 // Migration failed
-	ExitWarnings        = 64 // Completed with warnings
+	"github.com/janderssonse/karei/internal/xdg"
 )
 
 func main() {
@@ -43,20 +23,26 @@ func main() {
 
 func run() int {
 	// Acquire process lock to prevent multiple karei instances
-	lockPath := filepath.Join(os.TempDir(), "karei.lock")
+	lockPath := xdg.LockFile()
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o700); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create %s: %v\n", filepath.Dir(lockPath), err)
+
+		return domain.ExitSystemError
+	}
+
 	lock := flock.New(lockPath)
 
 	locked, err := lock.TryLock()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to acquire process lock: %v\n", err)
 
-		return ExitSystemError
+		return domain.ExitSystemError
 	}
 
 	if !locked {
 		fmt.Fprintf(os.Stderr, "Another karei instance is already running\n")
 
-		return ExitGeneralError
+		return domain.ExitLockedError
 	}
 
 	defer func() {
@@ -65,23 +51,19 @@ func run() int {
 		}
 	}()
 
-	app := cli.App()
+	app := cli.NewCLI()
 
 	ctx := context.Background()
-	if err := app.Run(ctx, os.Args); err != nil {
-		// All errors now must be ExitError with specific codes
+	err = app.Run(ctx, os.Args)
+	if err != nil {
+		// Error message to stderr only
 		exitErr := &domain.ExitError{}
 		if errors.As(err, &exitErr) {
-			// Error message to stderr only
 			fmt.Fprintf(os.Stderr, "%s\n", exitErr.Message)
-
-			return exitErr.Code
+		} else {
+			fmt.Fprintf(os.Stderr, "Unexpected error: %v\n", err)
 		}
-		// Fallback for unexpected errors (should not happen)
-		fmt.Fprintf(os.Stderr, "Unexpected error: %v\n", err)
-
-		return ExitGeneralError
 	}
 
-	return ExitSuccess
+	return domain.ExitCodeFor(err)
 }
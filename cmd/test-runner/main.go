@@ -14,9 +14,9 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/janderssonse/karei/internal/offline"
 	"github.com/janderssonse/karei/test/isolated"
 	"github.com/janderssonse/karei/test/mocks"
-	"github.com/janderssonse/karei/test/offline"
 )
 
 var (
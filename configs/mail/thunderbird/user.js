@@ -0,0 +1,4 @@
+// Thunderbird preferences generated by karei for the {{.Theme}} theme.
+user_pref("toolkit.legacyUserProfileCustomizations.stylesheets", true);
+user_pref("ui.systemUsesDarkTheme", {{if .Dark}}1{{else}}0{{end}});
+user_pref("mail.citation_color", "{{.Palette.Primary}}");
@@ -0,0 +1,5 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+// Package api exposes the application services as a REST API over a local unix socket.
+package api
@@ -0,0 +1,314 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// Job kinds submitted by the API.
+const (
+	JobInstall = "install"
+	JobTheme   = "theme"
+)
+
+const (
+	socketDirPerm  = 0o700
+	socketFilePerm = 0o600
+	maxBodyBytes   = 1 << 20
+	shutdownGrace  = 5 * time.Second
+	headerTimeout  = 10 * time.Second
+)
+
+var (
+	// ErrEmptyToken is returned when the server is created without an authentication token.
+	ErrEmptyToken = errors.New("authentication token must not be empty")
+	// ErrMissingServices is returned when a required service is not configured.
+	ErrMissingServices = errors.New("installer, theme and status services are required")
+)
+
+// Installer installs catalog applications and groups.
+type Installer interface {
+	InstallPackages(ctx context.Context, packages []string) (*domain.InstallResult, error)
+	InstallGroup(ctx context.Context, groupName string) (*domain.InstallResult, error)
+}
+
+// ThemeApplier applies a named theme.
+type ThemeApplier interface {
+	ApplyTheme(ctx context.Context, themeName string) error
+}
+
+// StatusReporter reports the current system state.
+type StatusReporter interface {
+	GetSystemStatus(ctx context.Context) (*application.SystemStatusData, error)
+	GetCurrentTheme(ctx context.Context) string
+	GetCurrentFont(ctx context.Context) string
+}
+
+// Services groups the application services the API drives.
+type Services struct {
+	Installer Installer
+	Themes    ThemeApplier
+	Status    StatusReporter
+	Checker   domain.InstallationChecker
+}
+
+// Server serves the karei REST API. Every request must carry the bearer token.
+type Server struct {
+	services Services
+	jobs     *application.JobManager
+	token    string
+	ctx      context.Context //nolint:containedctx // jobs outlive the request that submitted them
+}
+
+// NewServer creates an API server. Jobs run with ctx so that stopping the
+// server cancels outstanding work.
+func NewServer(ctx context.Context, services Services, jobs *application.JobManager, token string) (*Server, error) {
+	if token == "" {
+		return nil, ErrEmptyToken
+	}
+
+	if services.Installer == nil || services.Themes == nil || services.Status == nil {
+		return nil, ErrMissingServices
+	}
+
+	return &Server{services: services, jobs: jobs, token: token, ctx: ctx}, nil
+}
+
+// Handler returns the HTTP handler with authentication applied.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/status", s.handleStatus)
+	mux.HandleFunc("POST /v1/install", s.handleInstall)
+	mux.HandleFunc("POST /v1/theme", s.handleTheme)
+	mux.HandleFunc("POST /v1/verify", s.handleVerify)
+	mux.HandleFunc("GET /v1/jobs", s.handleListJobs)
+	mux.HandleFunc("GET /v1/jobs/{id}", s.handleGetJob)
+
+	return s.authenticate(mux)
+}
+
+// ListenAndServe serves the API on a unix socket until ctx is cancelled.
+// The socket is created with owner-only permissions and removed on exit.
+func (s *Server) ListenAndServe(ctx context.Context, socketPath string) error {
+	if err := os.MkdirAll(filepath.Dir(socketPath), socketDirPerm); err != nil {
+		return fmt.Errorf("failed to create socket directory: %w", err)
+	}
+
+	// A stale socket from a previous run would make Listen fail
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+
+	defer func() { _ = os.Remove(socketPath) }()
+
+	if err := os.Chmod(socketPath, socketFilePerm); err != nil {
+		_ = listener.Close()
+
+		return fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+
+	httpServer := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: headerTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownGrace)
+		defer cancel()
+
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("API server failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "invalid or missing bearer token")
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// StatusResponse is returned by GET /v1/status.
+type StatusResponse struct {
+	*application.SystemStatusData
+
+	Font string `json:"font"`
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	data, err := s.services.Status.GetSystemStatus(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, StatusResponse{
+		SystemStatusData: data,
+		Font:             s.services.Status.GetCurrentFont(r.Context()),
+	})
+}
+
+// InstallRequest is the body of POST /v1/install. Exactly one field must be set.
+type InstallRequest struct {
+	Apps  []string `json:"apps,omitempty"`
+	Group string   `json:"group,omitempty"`
+}
+
+func (s *Server) handleInstall(w http.ResponseWriter, r *http.Request) {
+	var req InstallRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if (len(req.Apps) == 0) == (req.Group == "") {
+		writeError(w, http.StatusBadRequest, "specify either apps or group")
+
+		return
+	}
+
+	job := s.jobs.Submit(s.ctx, JobInstall, func(ctx context.Context) (any, error) {
+		if req.Group != "" {
+			return s.services.Installer.InstallGroup(ctx, req.Group)
+		}
+
+		return s.services.Installer.InstallPackages(ctx, req.Apps)
+	})
+
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// ThemeRequest is the body of POST /v1/theme.
+type ThemeRequest struct {
+	Theme string `json:"theme"`
+}
+
+func (s *Server) handleTheme(w http.ResponseWriter, r *http.Request) {
+	var req ThemeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Theme == "" {
+		writeError(w, http.StatusBadRequest, "theme is required")
+
+		return
+	}
+
+	job := s.jobs.Submit(s.ctx, JobTheme, func(ctx context.Context) (any, error) {
+		return req.Theme, s.services.Themes.ApplyTheme(ctx, req.Theme)
+	})
+
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// VerifyRequest is the body of POST /v1/verify.
+type VerifyRequest struct {
+	Apps []string `json:"apps"`
+}
+
+// VerifyResponse maps each requested application to whether it is installed.
+type VerifyResponse struct {
+	Installed map[string]bool `json:"installed"`
+	OK        bool            `json:"ok"`
+}
+
+func (s *Server) handleVerify(w http.ResponseWriter, r *http.Request) {
+	if s.services.Checker == nil {
+		writeError(w, http.StatusNotImplemented, "verification is not available")
+
+		return
+	}
+
+	var req VerifyRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if len(req.Apps) == 0 {
+		writeError(w, http.StatusBadRequest, "apps is required")
+
+		return
+	}
+
+	resp := VerifyResponse{Installed: make(map[string]bool, len(req.Apps)), OK: true}
+
+	for _, app := range req.Apps {
+		installed := s.services.Checker.IsInstalled(r.Context(), app)
+		resp.Installed[app] = installed
+		resp.OK = resp.OK && installed
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleListJobs(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.jobs.List())
+}
+
+func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	job, exists := s.jobs.Get(r.PathValue("id"))
+	if !exists {
+		writeError(w, http.StatusNotFound, "job not found")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, target any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(target); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
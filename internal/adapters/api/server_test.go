@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/api"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "secret"
+
+type fakeServices struct {
+	installed []string
+	theme     string
+}
+
+func (f *fakeServices) InstallPackages(_ context.Context, packages []string) (*domain.InstallResult, error) {
+	f.installed = append(f.installed, packages...)
+
+	return &domain.InstallResult{Installed: packages}, nil
+}
+
+func (f *fakeServices) InstallGroup(_ context.Context, group string) (*domain.InstallResult, error) {
+	return &domain.InstallResult{Installed: []string{group}}, nil
+}
+
+func (f *fakeServices) ApplyTheme(_ context.Context, theme string) error {
+	f.theme = theme
+
+	return nil
+}
+
+func (f *fakeServices) GetSystemStatus(_ context.Context) (*application.SystemStatusData, error) {
+	return &application.SystemStatusData{InstalledApps: 3, CurrentTheme: "tokyo-night"}, nil
+}
+
+func (f *fakeServices) GetCurrentTheme(_ context.Context) string { return "tokyo-night" }
+
+func (f *fakeServices) GetCurrentFont(_ context.Context) string { return "JetBrainsMono" }
+
+func newTestServer(t *testing.T) (http.Handler, *fakeServices, *application.JobManager) {
+	t.Helper()
+
+	fake := &fakeServices{}
+	jobs := application.NewJobManager()
+	services := api.Services{
+		Installer: fake,
+		Themes:    fake,
+		Status:    fake,
+		Checker:   platform.NewMockInstallationChecker("git"),
+	}
+
+	server, err := api.NewServer(context.Background(), services, jobs, testToken)
+	require.NoError(t, err)
+
+	return server.Handler(), fake, jobs
+}
+
+func do(handler http.Handler, method, path, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestNewServer_RequiresToken(t *testing.T) {
+	t.Parallel()
+
+	_, err := api.NewServer(context.Background(), api.Services{}, application.NewJobManager(), "")
+	require.ErrorIs(t, err, api.ErrEmptyToken)
+}
+
+func TestServer_RejectsUnauthenticatedRequests(t *testing.T) {
+	t.Parallel()
+
+	handler, _, _ := newTestServer(t)
+
+	assert.Equal(t, http.StatusUnauthorized, do(handler, http.MethodGet, "/v1/status", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, do(handler, http.MethodGet, "/v1/status", "", "wrong").Code)
+}
+
+func TestServer_Status(t *testing.T) {
+	t.Parallel()
+
+	handler, _, _ := newTestServer(t)
+
+	rec := do(handler, http.MethodGet, "/v1/status", "", testToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "tokyo-night", resp["CurrentTheme"])
+	assert.Equal(t, "JetBrainsMono", resp["font"])
+}
+
+func TestServer_InstallRunsAsJob(t *testing.T) {
+	t.Parallel()
+
+	handler, fake, jobs := newTestServer(t)
+
+	rec := do(handler, http.MethodPost, "/v1/install", `{"apps":["git","vim"]}`, testToken)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	var job application.Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	assert.Equal(t, api.JobInstall, job.Kind)
+
+	jobs.Wait()
+
+	assert.Equal(t, []string{"git", "vim"}, fake.installed)
+
+	rec = do(handler, http.MethodGet, "/v1/jobs/"+job.ID, "", testToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	assert.Equal(t, application.JobSucceeded, job.Status)
+
+	assert.Equal(t, http.StatusNotFound, do(handler, http.MethodGet, "/v1/jobs/nope", "", testToken).Code)
+}
+
+func TestServer_ValidatesRequests(t *testing.T) {
+	t.Parallel()
+
+	handler, _, _ := newTestServer(t)
+
+	assert.Equal(t, http.StatusBadRequest, do(handler, http.MethodPost, "/v1/install", `{}`, testToken).Code)
+	assert.Equal(t, http.StatusBadRequest, do(handler, http.MethodPost, "/v1/install", `{"apps":["a"],"group":"b"}`, testToken).Code)
+	assert.Equal(t, http.StatusBadRequest, do(handler, http.MethodPost, "/v1/theme", `{"unknown":1}`, testToken).Code)
+}
+
+func TestServer_ThemeAndVerify(t *testing.T) {
+	t.Parallel()
+
+	handler, fake, jobs := newTestServer(t)
+
+	require.Equal(t, http.StatusAccepted, do(handler, http.MethodPost, "/v1/theme", `{"theme":"nord"}`, testToken).Code)
+	jobs.Wait()
+	assert.Equal(t, "nord", fake.theme)
+
+	rec := do(handler, http.MethodPost, "/v1/verify", `{"apps":["git","vim"]}`, testToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp api.VerifyResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.False(t, resp.OK)
+	assert.Equal(t, map[string]bool{"git": true, "vim": false}, resp.Installed)
+}
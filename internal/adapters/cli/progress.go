@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// JSONProgress writes operation events as JSON lines, for wrappers of
+// karei --json to show progress while the result on stdout is pending.
+type JSONProgress struct {
+	mu       sync.Mutex
+	encoder  *json.Encoder
+	total    int
+	finished int
+}
+
+// progressLine is an operation event with how far the run has come: apps
+// finished so far, completed or failed, out of total. Skipped apps never
+// start, so finished may end below total.
+type progressLine struct {
+	domain.Event
+
+	Finished int `json:"finished"`
+	Total    int `json:"total"`
+}
+
+// NewJSONProgress creates a progress stream to w for a run of total apps.
+func NewJSONProgress(w io.Writer, total int) *JSONProgress {
+	return &JSONProgress{encoder: json.NewEncoder(w), total: total}
+}
+
+// Handle writes the event as one line. It is a domain.EventHandler.
+func (p *JSONProgress) Handle(_ context.Context, event domain.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if event.Type == domain.EventOperationCompleted || event.Type == domain.EventOperationFailed {
+		p.finished++
+	}
+
+	_ = p.encoder.Encode(progressLine{Event: event, Finished: p.finished, Total: p.total})
+}
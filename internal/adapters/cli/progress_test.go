@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONProgress(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	progress := cliAdapter.NewJSONProgress(&buf, 2)
+
+	for _, event := range []domain.Event{
+		domain.NewEvent(domain.EventOperationStarted, "git"),
+		domain.NewEvent(domain.EventOperationCompleted, "git"),
+		domain.NewEvent(domain.EventOperationStarted, "vim"),
+		domain.NewEvent(domain.EventOperationFailed, "vim"),
+	} {
+		progress.Handle(t.Context(), event)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+
+	var last map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[3]), &last))
+	assert.Equal(t, "operation.failed", last["type"])
+	assert.Equal(t, "vim", last["subject"])
+	assert.InDelta(t, 2, last["finished"], 0)
+	assert.InDelta(t, 2, last["total"], 0)
+
+	var first map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.InDelta(t, 0, first["finished"], 0)
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// spinnerInterval is how often the spinner line is redrawn.
+const spinnerInterval = 100 * time.Millisecond
+
+// spinnerFrames animate the spinner.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"} //nolint:gochecknoglobals
+
+// Spinner shows the progress of an install run on one terminal line redrawn
+// in place, like apt's own progress: a spinner, the app counter and the
+// current step, the last line the installer printed. Failures are kept
+// above the line, so their errors stay on screen.
+type Spinner struct {
+	writer io.Writer
+	width  int
+
+	mu       sync.Mutex
+	total    int
+	finished int
+	app      string
+	verb     string
+	step     string
+	frame    int
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+// NewSpinner creates a spinner writing to a terminal width columns wide.
+func NewSpinner(writer io.Writer, width int) *Spinner {
+	return &Spinner{writer: writer, width: width}
+}
+
+// Start starts redrawing the line for a run of total apps.
+func (s *Spinner) Start(total int) {
+	s.mu.Lock()
+	s.total, s.finished, s.app, s.step = total, 0, "", ""
+	s.stop = make(chan struct{})
+	s.stopped = make(chan struct{})
+	s.mu.Unlock()
+
+	go s.run()
+}
+
+// Stop stops redrawing and clears the line.
+func (s *Spinner) Stop() {
+	close(s.stop)
+	<-s.stopped
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, _ = fmt.Fprint(s.writer, "\r\033[K")
+}
+
+// Handle follows the app being worked on. It is a domain.EventHandler.
+func (s *Spinner) Handle(_ context.Context, event domain.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch event.Type {
+	case domain.EventOperationStarted:
+		s.app, s.step = event.Subject, ""
+		s.verb = "Installing"
+
+		if event.Data["operation"] == "uninstall" {
+			s.verb = "Removing"
+		}
+	case domain.EventOperationFailed:
+		s.finished++
+		_, _ = fmt.Fprintf(s.writer, "\r\033[K✗ %s: %s\n", event.Subject, event.Data["error"])
+	case domain.EventOperationCompleted:
+		s.finished++
+	}
+
+	s.draw()
+}
+
+// Output sets the current step to a line the installer printed, for
+// platform.CommandRunner.SetOutputFunc.
+func (s *Spinner) Output(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.step = strings.TrimSpace(line)
+}
+
+func (s *Spinner) run() {
+	defer close(s.stopped)
+
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			s.frame = (s.frame + 1) % len(spinnerFrames)
+			s.draw()
+			s.mu.Unlock()
+		}
+	}
+}
+
+// draw redraws the line, cut to the terminal width so it never wraps.
+// The caller holds the lock.
+func (s *Spinner) draw() {
+	line := fmt.Sprintf("%s [%d/%d]", spinnerFrames[s.frame], min(s.finished+1, s.total), s.total)
+	if s.app != "" {
+		line += " " + s.verb + " " + s.app
+	}
+
+	if s.step != "" {
+		line += ": " + s.step
+	}
+
+	if runes := []rune(line); s.width > 1 && len(runes) >= s.width {
+		line = string(runes[:s.width-2]) + "…"
+	}
+
+	_, _ = fmt.Fprint(s.writer, "\r\033[K"+line)
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli_test
+
+import (
+	"bytes"
+	"testing"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpinner(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	spinner := cliAdapter.NewSpinner(&buf, 40)
+	spinner.Start(2)
+
+	spinner.Handle(t.Context(), domain.NewEvent(domain.EventOperationStarted, "git"))
+	spinner.Output("Unpacking git (1:2.43.0) over a rather long line ...")
+	spinner.Handle(t.Context(), domain.NewEvent(domain.EventOperationCompleted, "git"))
+
+	failed := domain.NewEvent(domain.EventOperationFailed, "vim")
+	failed.Data = map[string]string{"error": "no candidate"}
+	spinner.Handle(t.Context(), domain.NewEvent(domain.EventOperationStarted, "vim"))
+	spinner.Handle(t.Context(), failed)
+
+	spinner.Stop()
+
+	out := buf.String()
+	assert.Contains(t, out, "[1/2] Installing git")
+	assert.Contains(t, out, "[2/2] Installing vim")
+	assert.Contains(t, out, "✗ vim: no candidate\n")
+	assert.Contains(t, out, "…", "lines are cut to the terminal width")
+	assert.NotContains(t, out, "over a rather long line")
+	assert.True(t, bytes.HasSuffix(buf.Bytes(), []byte("\r\033[K")), "the line is cleared when stopped")
+}
@@ -9,6 +9,8 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
 )
 
 // Constants for consent responses.
@@ -50,6 +52,42 @@ func AskConsent(appName string, configPath string) bool {
 	return response == ConsentY || response == ConsentYes
 }
 
+// AskUninstall lists the apps about to be removed, with any warnings, and
+// asks the user to confirm. It never prompts when --yes is set and refuses
+// when stdin is not a terminal.
+func AskUninstall(targets []string, warnings []string) bool {
+	if AutoYes {
+		return true
+	}
+
+	if !DefaultOutput.IsTTY(os.Stdin.Fd()) {
+		return false
+	}
+
+	fmt.Printf("\nThe following apps will be uninstalled:\n")
+
+	for _, target := range targets {
+		fmt.Println(DefaultOutput.Danger("  - " + target))
+	}
+
+	for _, warning := range warnings {
+		fmt.Printf("⚠ %s\n", warning)
+	}
+
+	fmt.Print("Continue? [y/N]: ")
+
+	reader := bufio.NewReader(os.Stdin)
+
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+
+	return response == ConsentY || response == ConsentYes
+}
+
 // AskProcessRestart prompts before killing a process.
 func AskProcessRestart(processName string) bool {
 	// If --yes flag is set, auto-accept
@@ -98,3 +136,175 @@ func AddConfigMarker(content string, format string) string {
 func GetTimestamp() string {
 	return time.Now().Format("2006-01-02")
 }
+
+// AskEmptyTrash asks before permanently deleting trashed configuration. It
+// never prompts when --yes is set and refuses when stdin is not a terminal.
+func AskEmptyTrash(entries int) bool {
+	if AutoYes {
+		return true
+	}
+
+	if !DefaultOutput.IsTTY(os.Stdin.Fd()) {
+		return false
+	}
+
+	fmt.Printf("\n%d trash entries will be deleted permanently.\n", entries)
+	fmt.Print("Continue? [y/N]: ")
+
+	reader := bufio.NewReader(os.Stdin)
+
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+
+	return response == ConsentY || response == ConsentYes
+}
+
+// AskTeamProfile asks before applying the team profile published at url,
+// once its changes are shown. It never prompts when --yes is set and
+// refuses when stdin is not a terminal.
+func AskTeamProfile(url string) bool {
+	if AutoYes {
+		return true
+	}
+
+	if !DefaultOutput.IsTTY(os.Stdin.Fd()) {
+		return false
+	}
+
+	fmt.Printf("\nApply the team profile from %s? [y/N]: ", url)
+
+	reader := bufio.NewReader(os.Stdin)
+
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+
+	return response == ConsentY || response == ConsentYes
+}
+
+// AskProjectTools asks before installing the tools the project at path
+// requires, once the planned changes are shown. It never prompts when --yes
+// is set and refuses when stdin is not a terminal.
+func AskProjectTools(path string) bool {
+	if AutoYes {
+		return true
+	}
+
+	if !DefaultOutput.IsTTY(os.Stdin.Fd()) {
+		return false
+	}
+
+	fmt.Printf("\nInstall what %s requires? [y/N]: ", path)
+
+	reader := bufio.NewReader(os.Stdin)
+
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+
+	return response == ConsentY || response == ConsentYes
+}
+
+// AskMatched lists the apps a pattern selected and asks before acting on
+// them, verb naming the action such as "Install". It never prompts when
+// --yes is set and refuses when stdin is not a terminal.
+func AskMatched(verb string, matched []string) bool {
+	if AutoYes {
+		return true
+	}
+
+	if !DefaultOutput.IsTTY(os.Stdin.Fd()) {
+		return false
+	}
+
+	fmt.Printf("\nThe pattern matched %d apps:\n", len(matched))
+
+	for _, name := range matched {
+		fmt.Println("  - " + name)
+	}
+
+	fmt.Printf("%s them? [y/N]: ", verb)
+
+	reader := bufio.NewReader(os.Stdin)
+
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+
+	return response == ConsentY || response == ConsentYes
+}
+
+// AskMeteredDownload warns that about size would be downloaded on a metered
+// connection and asks whether to go ahead. It never prompts when --yes is
+// set and refuses when stdin is not a terminal.
+func AskMeteredDownload(size string) bool {
+	if AutoYes {
+		return true
+	}
+
+	if !DefaultOutput.IsTTY(os.Stdin.Fd()) {
+		return false
+	}
+
+	fmt.Printf("\nThe connection is metered and about %s would be downloaded.\n", size)
+	fmt.Print("Download anyway? [y/N]: ")
+
+	reader := bufio.NewReader(os.Stdin)
+
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+
+	return response == ConsentY || response == ConsentYes
+}
+
+// AskConfigConflict shows how writing a configuration file would change the
+// user's version and asks whether to overwrite or keep it. It answers
+// neither when stdin is not a terminal, leaving the decision for later.
+func AskConfigConflict(conflict domain.ConfigConflict) domain.ConfigResolution {
+	if !DefaultOutput.IsTTY(os.Stdin.Fd()) {
+		return ""
+	}
+
+	fmt.Printf("\n%s has changes of yours karei would replace:\n\n", conflict.Path)
+
+	for _, line := range conflict.Diff {
+		if strings.HasPrefix(line, "-") {
+			line = DefaultOutput.Danger(line)
+		}
+
+		fmt.Println("  " + line)
+	}
+
+	fmt.Print("\n[o]verwrite, saving yours aside, or [K]eep yours? ")
+
+	reader := bufio.NewReader(os.Stdin)
+
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return ""
+	}
+
+	switch strings.TrimSpace(strings.ToLower(response)) {
+	case "o", "overwrite":
+		return domain.ResolveOverwrite
+	default:
+		return domain.ResolveKeep
+	}
+}
@@ -85,3 +85,15 @@ func TestAutoYesFlag(t *testing.T) {
 	AutoYes = false
 	assert.False(t, AutoYes)
 }
+
+func TestAskUninstallAutoYes(t *testing.T) {
+	original := AutoYes
+
+	defer func() { AutoYes = original }()
+
+	AutoYes = true
+	assert.True(t, AskUninstall([]string{"vlc"}, nil))
+
+	AutoYes = false
+	assert.False(t, AskUninstall([]string{"vlc"}, nil), "refuses without a terminal to ask on")
+}
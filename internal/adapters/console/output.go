@@ -10,14 +10,30 @@ import (
 	"os"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"golang.org/x/term"
 )
 
+// Modes of the --color flag.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// SGR parameters of the styles karei uses.
+const (
+	sgrBold = "1"
+	sgrRed  = "31"
+)
+
 // OutputState holds global output configuration.
 type OutputState struct {
 	Verbose bool
 	JSON    bool
 	Plain   bool
+	Color   string // --color mode; empty means auto
 }
 
 // DefaultOutput provides output formatting utilities.
@@ -28,6 +44,72 @@ func (o *OutputState) SetMode(verbose, json, plain bool) {
 	o.Verbose = verbose
 	o.JSON = json
 	o.Plain = plain
+	o.syncStyles()
+}
+
+// SetColor sets the --color mode.
+func (o *OutputState) SetColor(mode string) {
+	o.Color = mode
+	o.syncStyles()
+}
+
+// ColorEnabled reports whether output may contain escape codes: never in
+// JSON or plain mode or with --color=never, always with --color=always,
+// and otherwise when stdout is a terminal and NO_COLOR isn't set.
+func (o *OutputState) ColorEnabled() bool {
+	switch {
+	case o.JSON || o.Plain || o.Color == ColorNever:
+		return false
+	case o.Color == ColorAlways:
+		return true
+	case noColorEnv():
+		return false
+	default:
+		return o.IsTTY(os.Stdout.Fd())
+	}
+}
+
+// Colorize wraps text in the SGR escape code sgr, such as "1" for bold or
+// "38;5;81" for a 256-color foreground, when color is enabled. Every
+// escape code karei writes goes through here or Render.
+func (o *OutputState) Colorize(sgr, text string) string {
+	if !o.ColorEnabled() {
+		return text
+	}
+
+	return "\033[" + sgr + "m" + text + "\033[0m"
+}
+
+// Render renders text with a lipgloss style, without colors or text
+// attributes when color is disabled.
+func (o *OutputState) Render(style lipgloss.Style, text string) string {
+	renderer := lipgloss.NewRenderer(os.Stdout)
+	o.applyProfile(renderer)
+
+	return style.Renderer(renderer).Render(text)
+}
+
+// syncStyles makes lipgloss styles rendered elsewhere, such as the
+// interactive forms, follow the color mode.
+func (o *OutputState) syncStyles() {
+	o.applyProfile(lipgloss.DefaultRenderer())
+}
+
+// applyProfile sets the color profile of renderer from the color mode.
+func (o *OutputState) applyProfile(renderer *lipgloss.Renderer) {
+	switch {
+	case !o.ColorEnabled():
+		renderer.SetColorProfile(termenv.Ascii)
+	case o.Color == ColorAlways && renderer.ColorProfile() == termenv.Ascii:
+		// Forced onto a pipe
+		renderer.SetColorProfile(termenv.ANSI256)
+	}
+}
+
+// noColorEnv reports whether the environment asks for no color, per
+// no-color.org.
+func noColorEnv() bool {
+	return os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb"
 }
 
 // IsTTY checks if output is going to a terminal (not piped/redirected).
@@ -35,26 +117,25 @@ func (o *OutputState) IsTTY(fd uintptr) bool {
 	return term.IsTerminal(int(fd))
 }
 
-// Bold formats text with bold when in TTY, uppercase when piped.
+// Bold formats text with bold when color is enabled, uppercase when piped.
 func (o *OutputState) Bold(text string) string {
-	if o.JSON || o.Plain {
-		return text // No formatting in JSON or plain mode
-	}
-
-	// Check no-color.org standards
-	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
-		return text // Disable color per no-color.org
+	if o.ColorEnabled() {
+		return o.Colorize(sgrBold, text)
 	}
 
-	// Check if stdout is a TTY
-	if o.IsTTY(os.Stdout.Fd()) {
-		return "\033[1m" + text + "\033[0m" // ANSI bold
+	if o.JSON || o.Plain || o.Color == ColorNever || noColorEnv() {
+		return text
 	}
 
 	// Fallback for pipes/redirects - use uppercase
 	return strings.ToUpper(text)
 }
 
+// Danger formats text in red when color is enabled, unchanged otherwise.
+func (o *OutputState) Danger(text string) string {
+	return o.Colorize(sgrRed, text)
+}
+
 // Header formats section headers consistently.
 func (o *OutputState) Header(text string) string {
 	return o.Bold(text)
@@ -67,6 +148,14 @@ func (o *OutputState) Progressf(format string, args ...any) {
 	}
 }
 
+// Infof writes notices, such as limits in effect, to stderr (only if not
+// JSON/Plain).
+func (o *OutputState) Infof(format string, args ...any) {
+	if !o.JSON && !o.Plain {
+		fmt.Fprintf(os.Stderr, "• "+format+"\n", args...)
+	}
+}
+
 // Successf writes success messages to stderr (only if not JSON/Plain).
 func (o *OutputState) Successf(format string, args ...any) {
 	if !o.JSON && !o.Plain {
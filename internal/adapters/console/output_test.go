@@ -10,6 +10,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -113,6 +114,61 @@ func TestOutputStateBold(t *testing.T) {
 	}
 }
 
+func TestOutputStateDanger(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	assert.Equal(t, "rm", (&OutputState{Plain: true}).Danger("rm"))
+	assert.Equal(t, "rm", (&OutputState{JSON: true}).Danger("rm"))
+	assert.Equal(t, "rm", (&OutputState{}).Danger("rm"), "non-TTY output stays uncolored")
+}
+
+func TestOutputStateNoEscapeCodes(t *testing.T) {
+	style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205")).
+		Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("63"))
+
+	emit := func(o *OutputState) string {
+		return o.Bold("bold") + o.Danger("danger") + o.Header("header") +
+			o.Colorize("38;5;81", "banner") + o.Render(style, "styled") +
+			lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Render("default renderer")
+	}
+
+	tests := []struct {
+		name    string
+		state   OutputState
+		noColor string
+	}{
+		{name: "plain mode", state: OutputState{Plain: true}},
+		{name: "plain mode wins over --color=always", state: OutputState{Plain: true, Color: ColorAlways}},
+		{name: "json mode", state: OutputState{JSON: true, Color: ColorAlways}},
+		{name: "--color=never", state: OutputState{Color: ColorNever}},
+		{name: "NO_COLOR", state: OutputState{}, noColor: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+
+			o := &tt.state
+			o.SetMode(o.Verbose, o.JSON, o.Plain)
+
+			assert.NotContains(t, emit(o), "\x1b")
+		})
+	}
+}
+
+func TestOutputStateColorAlways(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	o := &OutputState{}
+	o.SetColor(ColorAlways)
+
+	assert.Equal(t, "\x1b[31mrm\x1b[0m", o.Danger("rm"), "forced onto a pipe")
+	assert.Contains(t, o.Render(lipgloss.NewStyle().Bold(true), "styled"), "\x1b[")
+
+	o.SetColor(ColorAuto)
+	assert.Equal(t, "rm", o.Danger("rm"), "non-TTY output stays uncolored")
+}
+
 func TestOutputStateHeader(t *testing.T) {
 	o := &OutputState{}
 	// Header just delegates to Bold
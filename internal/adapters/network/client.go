@@ -21,10 +21,8 @@ type HTTPClient struct {
 func NewHTTPClient(timeout time.Duration) *HTTPClient {
 	return &HTTPClient{
 		client: &http.Client{
-			Timeout: timeout,
-			Transport: &http.Transport{
-				Proxy: http.ProxyFromEnvironment,
-			},
+			Timeout:   timeout,
+			Transport: newTransport(),
 		},
 	}
 }
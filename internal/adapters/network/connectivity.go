@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// DefaultConnectivityURL is probed to decide whether the machine is online.
+const DefaultConnectivityURL = "https://api.github.com"
+
+// DefaultConnectivityCheckURL is the endpoint NetworkManager on Ubuntu probes.
+// It answers 204 No Content, so any other answer comes from a captive portal.
+const DefaultConnectivityCheckURL = "http://connectivity-check.ubuntu.com/"
+
+// connectivityTimeout bounds the preflight check, so a dead network is
+// noticed in seconds rather than per package.
+const connectivityTimeout = 3 * time.Second
+
+// Online reports whether url answers a HEAD request. Any HTTP response counts,
+// so proxies and rate limits don't make a working connection look offline.
+func Online(ctx context.Context, client *http.Client, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+
+	_ = resp.Body.Close()
+
+	return true
+}
+
+// ErrUnreachable indicates a URL answering with an error status.
+var ErrUnreachable = errors.New("unreachable")
+
+// Reachable checks url answers without an error status, as a download from
+// it needs. Servers refusing HEAD requests are asked with GET instead,
+// without reading the body.
+func Reachable(ctx context.Context, client *http.Client, url string) error {
+	status, err := requestStatus(ctx, client, http.MethodHead, url)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusForbidden) {
+		status, err = requestStatus(ctx, client, http.MethodGet, url)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if status >= http.StatusBadRequest {
+		return fmt.Errorf("%w: %d %s", ErrUnreachable, status, http.StatusText(status))
+	}
+
+	return nil
+}
+
+// requestStatus returns the status url answers a request with.
+func requestStatus(ctx context.Context, client *http.Client, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// The URL itself is the caller's to report
+		if urlErr := (*neturl.Error)(nil); errors.As(err, &urlErr) {
+			return 0, urlErr.Err
+		}
+
+		return 0, err
+	}
+
+	_ = resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// CheckConnectivity asks url, which must answer 204 No Content, whether the
+// network is usable. No answer within a few seconds means offline; another
+// answer, such as a redirect to a login page, means a captive portal.
+// KAREI_NO_NETWORK counts as offline without a request.
+func CheckConnectivity(ctx context.Context, client *http.Client, url string) domain.Connectivity {
+	if xdg.NoNetwork() {
+		return domain.ConnectivityOffline
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectivityTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return domain.ConnectivityOffline
+	}
+
+	// A portal's redirect is the answer, not something to follow
+	probe := *client
+	probe.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	resp, err := probe.Do(req)
+	if err != nil {
+		return domain.ConnectivityOffline
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return domain.ConnectivityCaptivePortal
+	}
+
+	return domain.ConnectivityOnline
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+func TestOnline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+
+	assert.True(t, Online(context.Background(), server.Client(), server.URL), "error statuses still mean online")
+
+	server.Close()
+	assert.False(t, Online(context.Background(), server.Client(), server.URL))
+}
+
+func TestCheckConnectivity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    domain.Connectivity
+	}{
+		{
+			name:    "no content means online",
+			handler: func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) },
+			want:    domain.ConnectivityOnline,
+		},
+		{
+			name: "redirect to a login page means captive portal",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "http://portal.example/login", http.StatusFound)
+			},
+			want: domain.ConnectivityCaptivePortal,
+		},
+		{
+			name:    "injected page means captive portal",
+			handler: func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("<html>Sign in</html>")) },
+			want:    domain.ConnectivityCaptivePortal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			assert.Equal(t, tt.want, CheckConnectivity(context.Background(), server.Client(), server.URL))
+		})
+	}
+
+	t.Run("no answer means offline", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		assert.Equal(t, domain.ConnectivityOffline, CheckConnectivity(context.Background(), server.Client(), server.URL))
+	})
+}
+
+func TestReachable(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/gone":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	require.NoError(t, Reachable(context.Background(), server.Client(), server.URL+"/app.deb"), "GET answers when HEAD is refused")
+	require.ErrorIs(t, Reachable(context.Background(), server.Client(), server.URL+"/gone"), ErrUnreachable)
+}
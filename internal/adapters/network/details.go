@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// Default upstream endpoints for app details.
+const (
+	DefaultGitHubAPI  = "https://api.github.com"
+	DefaultFlathubAPI = "https://flathub.org/api/v2"
+)
+
+var (
+	// ErrUpstreamStatus indicates the upstream answered with a non-200 status.
+	ErrUpstreamStatus = errors.New("unexpected upstream status")
+
+	githubRepoPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+	htmlTagPattern    = regexp.MustCompile(`<[^>]+>`)
+)
+
+// DetailsFetcher implements domain.AppDetailsSource using the GitHub and Flathub APIs.
+type DetailsFetcher struct {
+	client     *http.Client
+	githubAPI  string
+	flathubAPI string
+}
+
+// NewDetailsFetcher creates a fetcher that talks to the public GitHub and Flathub APIs.
+func NewDetailsFetcher(client *http.Client) *DetailsFetcher {
+	return &DetailsFetcher{client: client, githubAPI: DefaultGitHubAPI, flathubAPI: DefaultFlathubAPI}
+}
+
+// SetEndpoints overrides the API base URLs, e.g. for mirrors or tests.
+func (f *DetailsFetcher) SetEndpoints(githubAPI, flathubAPI string) {
+	f.githubAPI = strings.TrimSuffix(githubAPI, "/")
+	f.flathubAPI = strings.TrimSuffix(flathubAPI, "/")
+}
+
+// FetchDetails returns upstream details for pkg, or domain.ErrNoUpstreamDetails when
+// neither Flathub nor GitHub knows about it.
+func (f *DetailsFetcher) FetchDetails(ctx context.Context, pkg *domain.Package) (*domain.AppDetails, error) {
+	if pkg.Method == domain.MethodFlatpak {
+		return f.fetchFlathub(ctx, pkg.Source)
+	}
+
+	if repo, ok := GitHubRepo(pkg.Method, pkg.Source); ok {
+		return f.fetchGitHub(ctx, repo, pkg.Channel)
+	}
+
+	return nil, domain.ErrNoUpstreamDetails
+}
+
+// GitHubRepo extracts "owner/repo" from a GitHub URL, or from a bare
+// owner/repo source of a GitHub install method.
+func GitHubRepo(method domain.InstallMethod, source string) (string, bool) {
+	if rest, found := strings.CutPrefix(source, "https://github.com/"); found {
+		parts := strings.SplitN(rest, "/", 3)
+		if len(parts) >= 2 && parts[0] != "" && parts[1] != "" {
+			return parts[0] + "/" + strings.TrimSuffix(parts[1], ".git"), true
+		}
+
+		return "", false
+	}
+
+	if method.IsGitHub() && githubRepoPattern.MatchString(source) {
+		return source, true
+	}
+
+	return "", false
+}
+
+// githubRepository is the subset of the GitHub repository API we use.
+type githubRepository struct {
+	Description string `json:"description"`
+	Homepage    string `json:"homepage"`
+	HTMLURL     string `json:"html_url"`
+	License     *struct {
+		SPDXID string `json:"spdx_id"`
+	} `json:"license"`
+}
+
+// githubRelease is the subset of the GitHub release API we use.
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	Body    string `json:"body"`
+	Draft   bool   `json:"draft"`
+}
+
+// prereleaseWindow is how many of the newest releases are searched for one
+// that isn't a draft.
+const prereleaseWindow = 10
+
+func (f *DetailsFetcher) fetchGitHub(ctx context.Context, repo string, channel domain.ReleaseChannel) (*domain.AppDetails, error) {
+	var info githubRepository
+	if err := f.getJSON(ctx, f.githubAPI+"/repos/"+repo, &info); err != nil {
+		return nil, err
+	}
+
+	details := &domain.AppDetails{
+		LongDescription: info.Description,
+		Homepage:        info.Homepage,
+		FetchedAt:       time.Now(),
+	}
+
+	if details.Homepage == "" {
+		details.Homepage = info.HTMLURL
+	}
+
+	if info.License != nil && info.License.SPDXID != "NOASSERTION" {
+		details.License = info.License.SPDXID
+	}
+
+	// Repositories without releases are common; the details are still useful
+	if release, err := f.latestRelease(ctx, repo, channel); err == nil {
+		details.LatestRelease = release.TagName
+		details.ReleaseNotes = strings.TrimSpace(release.Body)
+	}
+
+	return details, nil
+}
+
+// latestRelease returns the release GitHub marks as latest, which is never a
+// prerelease, or on the prerelease channel simply the newest release.
+func (f *DetailsFetcher) latestRelease(ctx context.Context, repo string, channel domain.ReleaseChannel) (*githubRelease, error) {
+	if channel != domain.ChannelPrerelease {
+		var release githubRelease
+		if err := f.getJSON(ctx, f.githubAPI+"/repos/"+repo+"/releases/latest", &release); err != nil {
+			return nil, err
+		}
+
+		return &release, nil
+	}
+
+	var releases []githubRelease
+	if err := f.getJSON(ctx, fmt.Sprintf("%s/repos/%s/releases?per_page=%d", f.githubAPI, repo, prereleaseWindow), &releases); err != nil {
+		return nil, err
+	}
+
+	// The API lists releases newest first
+	for _, release := range releases {
+		if !release.Draft {
+			return &release, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no releases of %s", domain.ErrNoUpstreamDetails, repo)
+}
+
+// flathubAppstream is the subset of the Flathub appstream API we use.
+type flathubAppstream struct {
+	Description    string `json:"description"`
+	ProjectLicense string `json:"project_license"`
+	URLs           struct {
+		Homepage string `json:"homepage"`
+	} `json:"urls"`
+	Screenshots []struct {
+		Caption string            `json:"caption"`
+		Sizes   map[string]string `json:"sizes"`
+	} `json:"screenshots"`
+	Releases []struct {
+		Version     string `json:"version"`
+		Description string `json:"description"`
+	} `json:"releases"`
+}
+
+func (f *DetailsFetcher) fetchFlathub(ctx context.Context, appID string) (*domain.AppDetails, error) {
+	var stream flathubAppstream
+	if err := f.getJSON(ctx, f.flathubAPI+"/appstream/"+appID, &stream); err != nil {
+		return nil, err
+	}
+
+	details := &domain.AppDetails{
+		LongDescription: stripHTML(stream.Description),
+		Homepage:        stream.URLs.Homepage,
+		License:         stream.ProjectLicense,
+		FetchedAt:       time.Now(),
+	}
+
+	if len(stream.Releases) > 0 {
+		details.LatestRelease = stream.Releases[0].Version
+		details.ReleaseNotes = stripHTML(stream.Releases[0].Description)
+	}
+
+	for _, shot := range stream.Screenshots {
+		if url := largestScreenshot(shot.Sizes); url != "" {
+			details.Screenshots = append(details.Screenshots, domain.Screenshot{Caption: shot.Caption, URL: url})
+		}
+	}
+
+	return details, nil
+}
+
+// getJSON fetches url and decodes the JSON body into target.
+func (f *DetailsFetcher) getJSON(ctx context.Context, url string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" && strings.HasPrefix(url, f.githubAPI) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return domain.NewKindError(domain.ErrorKindNetwork, fmt.Errorf("failed to fetch %s: %w", url, err))
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w %d from %s", ErrUpstreamStatus, resp.StatusCode, url)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", url, err)
+	}
+
+	return nil
+}
+
+// largestScreenshot picks the widest variant from a "WIDTHxHEIGHT" keyed map.
+func largestScreenshot(sizes map[string]string) string {
+	best, bestWidth := "", -1
+
+	for size, url := range sizes {
+		width, _, _ := strings.Cut(size, "x")
+
+		if w, err := strconv.Atoi(width); err == nil && w > bestWidth {
+			best, bestWidth = url, w
+		}
+	}
+
+	return best
+}
+
+// stripHTML turns appstream markup into plain text paragraphs and bullets.
+func stripHTML(markup string) string {
+	text := strings.NewReplacer("</p>", "\n\n", "<li>", "• ", "</li>", "\n").Replace(markup)
+	text = htmlTagPattern.ReplaceAllString(text, "")
+
+	return strings.TrimSpace(html.UnescapeString(text))
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDetailsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/pmd/pmd", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"description":"Source code analyzer","homepage":"https://pmd.github.io","license":{"spdx_id":"BSD-4-Clause"}}`))
+	})
+	mux.HandleFunc("/repos/pmd/pmd/releases/latest", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name":"pmd_releases/7.0.0","body":"  New rules  "}`))
+	})
+	mux.HandleFunc("/repos/pmd/pmd/releases", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"tag_name":"pmd_releases/7.2.0-draft","draft":true},
+			{"tag_name":"pmd_releases/7.1.0-rc1","body":"Release candidate","prerelease":true},
+			{"tag_name":"pmd_releases/7.0.0","body":"New rules"}
+		]`))
+	})
+	mux.HandleFunc("/appstream/org.videolan.VLC", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"description":"<p>Plays &amp; streams.</p><ul><li>DVD</li></ul>",
+			"project_license":"GPL-2.0+",
+			"urls":{"homepage":"https://www.videolan.org"},
+			"screenshots":[{"caption":"Main","sizes":{"624x351":"small.png","1248x702":"large.png"}}],
+			"releases":[{"version":"3.0.21","description":"<p>Fixes</p>"}]
+		}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestDetailsFetcherGitHub(t *testing.T) {
+	t.Parallel()
+
+	server := newDetailsServer(t)
+	fetcher := NewDetailsFetcher(server.Client())
+	fetcher.SetEndpoints(server.URL, server.URL)
+
+	details, err := fetcher.FetchDetails(context.Background(), &domain.Package{Method: domain.MethodGitHubJava, Source: "pmd/pmd"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Source code analyzer", details.LongDescription)
+	assert.Equal(t, "https://pmd.github.io", details.Homepage)
+	assert.Equal(t, "BSD-4-Clause", details.License)
+	assert.Equal(t, "pmd_releases/7.0.0", details.LatestRelease)
+	assert.Equal(t, "New rules", details.ReleaseNotes)
+}
+
+func TestDetailsFetcherGitHubPrereleaseChannel(t *testing.T) {
+	t.Parallel()
+
+	server := newDetailsServer(t)
+	fetcher := NewDetailsFetcher(server.Client())
+	fetcher.SetEndpoints(server.URL, server.URL)
+
+	pkg := &domain.Package{Method: domain.MethodGitHubJava, Source: "pmd/pmd", Channel: domain.ChannelPrerelease}
+
+	details, err := fetcher.FetchDetails(context.Background(), pkg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "pmd_releases/7.1.0-rc1", details.LatestRelease)
+	assert.Equal(t, "Release candidate", details.ReleaseNotes)
+}
+
+func TestDetailsFetcherFlathub(t *testing.T) {
+	t.Parallel()
+
+	server := newDetailsServer(t)
+	fetcher := NewDetailsFetcher(server.Client())
+	fetcher.SetEndpoints(server.URL, server.URL)
+
+	details, err := fetcher.FetchDetails(context.Background(), &domain.Package{Method: domain.MethodFlatpak, Source: "org.videolan.VLC"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Plays & streams.\n\n• DVD", details.LongDescription)
+	assert.Equal(t, "GPL-2.0+", details.License)
+	assert.Equal(t, "3.0.21", details.LatestRelease)
+	assert.Equal(t, []domain.Screenshot{{Caption: "Main", URL: "large.png"}}, details.Screenshots)
+}
+
+func TestDetailsFetcherWithoutUpstream(t *testing.T) {
+	t.Parallel()
+
+	fetcher := NewDetailsFetcher(http.DefaultClient)
+
+	_, err := fetcher.FetchDetails(context.Background(), &domain.Package{Method: domain.MethodAPT, Source: "vlc"})
+	require.ErrorIs(t, err, domain.ErrNoUpstreamDetails)
+}
+
+func TestGitHubRepo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		method domain.InstallMethod
+		source string
+		want   string
+		ok     bool
+	}{
+		{domain.MethodGitHubBinary, "https://github.com/jdx/mise/releases/latest/download/mise", "jdx/mise", true},
+		{domain.MethodDEB, "https://github.com/owner/tool.git", "owner/tool", true},
+		{domain.MethodGitHub, "pmd/pmd", "pmd/pmd", true},
+		{domain.MethodAPT, "pmd/pmd", "", false},
+		{domain.MethodGitHub, "https://example.com/a/b", "", false},
+	}
+
+	for _, tt := range tests {
+		repo, ok := GitHubRepo(tt.method, tt.source)
+		assert.Equal(t, tt.ok, ok, tt.source)
+		assert.Equal(t, tt.want, repo, tt.source)
+	}
+}
@@ -1,5 +1,6 @@
 // SPDX-FileCopyrightText: 2025 The Karei Authors
 // SPDX-License-Identifier: EUPL-1.2
 
-// Package network provides proxy and DNS configuration.
+// Package network provides proxy and DNS configuration and clients for the
+// upstream services karei queries.
 package network
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// mailLookupTimeout bounds the DNS lookups of a mail domain.
+const mailLookupTimeout = 5 * time.Second
+
+// MailDiscoverer looks up mail domains with the system resolver.
+type MailDiscoverer struct {
+	resolver *net.Resolver
+}
+
+// NewMailDiscoverer creates a discoverer using the system resolver.
+func NewMailDiscoverer() *MailDiscoverer {
+	return &MailDiscoverer{resolver: net.DefaultResolver}
+}
+
+// MailHosts returns the MX hosts of domain and whether it has an _imaps or
+// _submission SRV record.
+func (d *MailDiscoverer) MailHosts(ctx context.Context, domain string) ([]string, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, mailLookupTimeout)
+	defer cancel()
+
+	records, err := d.resolver.LookupMX(ctx, domain)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up the mail servers of %s: %w", domain, err)
+	}
+
+	hosts := make([]string, 0, len(records))
+	for _, record := range records {
+		hosts = append(hosts, record.Host)
+	}
+
+	srv := false
+
+	for _, service := range []string{"imaps", "submission"} {
+		if _, addrs, err := d.resolver.LookupSRV(ctx, service, "tcp", domain); err == nil && len(addrs) > 0 {
+			srv = true
+
+			break
+		}
+	}
+
+	return hosts, srv, nil
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// sysClassNet lists the network interfaces and their statistics.
+const sysClassNet = "/sys/class/net"
+
+// DetectMetered reports whether NetworkManager considers the primary
+// connection metered, such as a mobile hotspot, whether set by the user or
+// guessed. Without NetworkManager the connection is taken as unmetered.
+func DetectMetered(ctx context.Context, runner domain.CommandRunner) bool {
+	if !runner.CommandExists("busctl") {
+		return false
+	}
+
+	output, err := runner.ExecuteWithOutput(ctx, "busctl", "--system", "get-property",
+		"org.freedesktop.NetworkManager", "/org/freedesktop/NetworkManager",
+		"org.freedesktop.NetworkManager", "Metered")
+	if err != nil {
+		return false
+	}
+
+	return parseMetered(output)
+}
+
+// parseMetered reads the NMMetered value busctl prints, such as "u 1".
+// Yes (1) and guess-yes (3) are metered.
+func parseMetered(output string) bool {
+	fields := strings.Fields(output)
+	if len(fields) != 2 || fields[0] != "u" {
+		return false
+	}
+
+	return fields[1] == "1" || fields[1] == "3"
+}
+
+// ReceivedBytes returns how many bytes all network interfaces but loopback
+// have received since boot, or 0 when the statistics can't be read.
+func ReceivedBytes() int64 {
+	return receivedBytes(sysClassNet)
+}
+
+func receivedBytes(root string) int64 {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return 0
+	}
+
+	var total int64
+
+	for _, entry := range entries {
+		if entry.Name() == "lo" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(root, entry.Name(), "statistics", "rx_bytes")) //nolint:gosec // G304: Interface statistics under /sys
+		if err != nil {
+			continue
+		}
+
+		if count, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err == nil {
+			total += count
+		}
+	}
+
+	return total
+}
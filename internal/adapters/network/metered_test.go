@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetered(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, parseMetered("u 1\n"))
+	assert.True(t, parseMetered("u 3"), "guessed metered, e.g. a phone hotspot")
+	assert.False(t, parseMetered("u 2"))
+	assert.False(t, parseMetered("u 4"))
+	assert.False(t, parseMetered("u 0"), "unknown is taken as unmetered")
+	assert.False(t, parseMetered(""))
+}
+
+func TestReceivedBytesSkipsLoopback(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	for name, count := range map[string]string{"lo": "500\n", "eth0": "1000\n", "wlan0": "234\n"} {
+		dir := filepath.Join(root, name, "statistics")
+		require.NoError(t, os.MkdirAll(dir, 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "rx_bytes"), []byte(count), 0o600))
+	}
+
+	assert.Equal(t, int64(1234), receivedBytes(root))
+	assert.Zero(t, receivedBytes(filepath.Join(root, "missing")))
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultMirrorList lists the Ubuntu archive mirrors near the requesting
+// address, as apt's mirror:// method uses.
+const DefaultMirrorList = "http://mirrors.ubuntu.com/mirrors.txt"
+
+// defaultMirrorTimeout bounds the download from a single mirror.
+const defaultMirrorTimeout = 5 * time.Second
+
+// ErrNoMirrors indicates a mirror list without any usable mirror.
+var ErrNoMirrors = errors.New("no mirrors found")
+
+// MirrorTiming is how long a mirror took to serve the release index.
+type MirrorTiming struct {
+	URL      string        `json:"url"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Reachable reports whether the mirror served the release index.
+func (t MirrorTiming) Reachable() bool {
+	return t.Error == ""
+}
+
+// MirrorProbe finds nearby Ubuntu archive mirrors and measures their speed.
+type MirrorProbe struct {
+	client  *http.Client
+	listURL string
+	timeout time.Duration
+}
+
+// NewMirrorProbe creates a probe reading the mirror list at DefaultMirrorList.
+func NewMirrorProbe(client *http.Client) *MirrorProbe {
+	return &MirrorProbe{client: client, listURL: DefaultMirrorList, timeout: defaultMirrorTimeout}
+}
+
+// SetListURL overrides the mirror list URL, e.g. for a country list such as
+// http://mirrors.ubuntu.com/SE.txt or for tests.
+func (p *MirrorProbe) SetListURL(url string) {
+	p.listURL = url
+}
+
+// SetTimeout sets how long a mirror may take before it counts as unreachable.
+func (p *MirrorProbe) SetTimeout(timeout time.Duration) {
+	p.timeout = timeout
+}
+
+// Mirrors returns the mirror URLs of the list, each ending in "/".
+func (p *MirrorProbe) Mirrors(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.listURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch mirror list: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", ErrUpstreamStatus, resp.Status)
+	}
+
+	var mirrors []string
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "http://") && !strings.HasPrefix(line, "https://") {
+			continue
+		}
+
+		if !strings.HasSuffix(line, "/") {
+			line += "/"
+		}
+
+		if !slices.Contains(mirrors, line) {
+			mirrors = append(mirrors, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read mirror list: %w", err)
+	}
+
+	if len(mirrors) == 0 {
+		return nil, ErrNoMirrors
+	}
+
+	return mirrors, nil
+}
+
+// Benchmark downloads the release index of codename from each mirror in
+// turn, so measurements don't compete for bandwidth, and returns the
+// timings fastest first with unreachable mirrors last.
+func (p *MirrorProbe) Benchmark(ctx context.Context, mirrors []string, codename string) []MirrorTiming {
+	timings := make([]MirrorTiming, 0, len(mirrors))
+
+	for _, mirror := range mirrors {
+		timing := MirrorTiming{URL: mirror}
+
+		start := time.Now()
+		if err := p.fetch(ctx, mirror+"dists/"+codename+"/InRelease"); err != nil {
+			timing.Error = err.Error()
+		}
+
+		timing.Duration = time.Since(start)
+		timings = append(timings, timing)
+	}
+
+	slices.SortStableFunc(timings, func(a, b MirrorTiming) int {
+		if a.Reachable() != b.Reachable() {
+			if a.Reachable() {
+				return -1
+			}
+
+			return 1
+		}
+
+		return int(a.Duration - b.Duration)
+	})
+
+	return timings
+}
+
+// fetch downloads url in full within the probe timeout.
+func (p *MirrorProbe) fetch(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s", ErrUpstreamStatus, resp.Status)
+	}
+
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return fmt.Errorf("download failed: %w", err)
+	}
+
+	return nil
+}
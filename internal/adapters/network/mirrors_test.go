@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirrorProbeRanksMirrors(t *testing.T) {
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mirrors.txt":
+			_, _ = fmt.Fprintf(w, "%[1]s/slow/ubuntu/\n%[1]s/fast/ubuntu\n%[1]s/broken/ubuntu/\n# comment\n%[1]s/fast/ubuntu/\n", server.URL)
+		case "/slow/ubuntu/dists/noble/InRelease":
+			time.Sleep(50 * time.Millisecond)
+			_, _ = w.Write([]byte("release"))
+		case "/fast/ubuntu/dists/noble/InRelease":
+			_, _ = w.Write([]byte("release"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	probe := NewMirrorProbe(server.Client())
+	probe.SetListURL(server.URL + "/mirrors.txt")
+
+	mirrors, err := probe.Mirrors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, mirrors, 3)
+	assert.Equal(t, server.URL+"/fast/ubuntu/", mirrors[1])
+
+	timings := probe.Benchmark(context.Background(), mirrors, "noble")
+	require.Len(t, timings, 3)
+	assert.Equal(t, server.URL+"/fast/ubuntu/", timings[0].URL)
+	assert.Equal(t, server.URL+"/slow/ubuntu/", timings[1].URL)
+	assert.False(t, timings[2].Reachable())
+	assert.Contains(t, timings[2].Error, "404")
+}
+
+func TestMirrorProbeEmptyList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("\n"))
+	}))
+	defer server.Close()
+
+	probe := NewMirrorProbe(server.Client())
+	probe.SetListURL(server.URL)
+
+	_, err := probe.Mirrors(context.Background())
+	require.ErrorIs(t, err, ErrNoMirrors)
+}
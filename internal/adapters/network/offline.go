@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// ErrNetworkDisabled is returned for requests made while KAREI_NO_NETWORK is set.
+var ErrNetworkDisabled = errors.New("network access disabled by " + xdg.EnvNoNetwork)
+
+// offlineTransport refuses every request.
+type offlineTransport struct{}
+
+// RoundTrip implements http.RoundTripper.
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("%w: %s", ErrNetworkDisabled, req.URL.Host)
+}
+
+// newTransport returns the proxy-aware transport karei's clients share,
+// keeping to KAREI_BANDWIDTH_LIMIT, or one refusing all requests when
+// KAREI_NO_NETWORK is set.
+func newTransport() http.RoundTripper {
+	if xdg.NoNetwork() {
+		return offlineTransport{}
+	}
+
+	return throttled(&http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+	})
+}
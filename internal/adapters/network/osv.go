@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// DefaultOSVAPI is the public OSV vulnerability database.
+const DefaultOSVAPI = "https://api.osv.dev/v1"
+
+// OSVFeed implements domain.VulnerabilityFeed using the OSV API, which
+// republishes the Ubuntu and Debian security trackers. Only distribution
+// packages are covered. OSV tracks them by source package and full version,
+// so both are looked up with dpkg.
+type OSVFeed struct {
+	client        *http.Client
+	commandRunner domain.CommandRunner
+	endpoint      string
+	ecosystem     string
+}
+
+// NewOSVFeed creates a feed for the OSV ecosystem of this machine, see
+// OSVEcosystem. An empty ecosystem covers no packages.
+func NewOSVFeed(client *http.Client, commandRunner domain.CommandRunner, ecosystem string) *OSVFeed {
+	return &OSVFeed{client: client, commandRunner: commandRunner, endpoint: DefaultOSVAPI, ecosystem: ecosystem}
+}
+
+// SetEndpoint overrides the API base URL, e.g. for mirrors or tests.
+func (f *OSVFeed) SetEndpoint(endpoint string) {
+	f.endpoint = strings.TrimSuffix(endpoint, "/")
+}
+
+// OSVEcosystem returns the OSV ecosystem name of a distribution release,
+// such as "Ubuntu:24.04:LTS" or "Debian:12", or false when OSV has no feed for it.
+func OSVEcosystem(dist *domain.Distribution) (string, bool) {
+	if dist == nil {
+		return "", false
+	}
+
+	// VERSION reads "24.04.1 LTS (Noble Numbat)" or "12 (bookworm)", OSV
+	// names releases by their number only
+	release := strings.Split(releaseNumber(dist.Version), ".")
+	if release[0] == "" {
+		return "", false
+	}
+
+	switch dist.ID {
+	case "ubuntu":
+		if len(release) < 2 {
+			return "", false
+		}
+
+		version := release[0] + "." + release[1]
+
+		// LTS releases are the even years' April releases
+		if year, err := strconv.Atoi(release[0]); err == nil && year%2 == 0 && release[1] == "04" {
+			return "Ubuntu:" + version + ":LTS", true
+		}
+
+		return "Ubuntu:" + version, true
+	case "debian":
+		return "Debian:" + release[0], true
+	default:
+		return "", false
+	}
+}
+
+// releaseNumber returns the leading digits and dots of version, "24.04.1"
+// of "24.04.1 LTS (Noble Numbat)".
+func releaseNumber(version string) string {
+	end := strings.IndexFunc(version, func(r rune) bool { return r != '.' && (r < '0' || r > '9') })
+	if end < 0 {
+		end = len(version)
+	}
+
+	return strings.Trim(version[:end], ".")
+}
+
+type osvQuery struct {
+	Package osvPackage `json:"package"`
+	Version string     `json:"version"`
+}
+
+type osvPackage struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+type osvResponse struct {
+	Vulns []osvVuln `json:"vulns"`
+}
+
+type osvVuln struct {
+	ID       string   `json:"id"`
+	Aliases  []string `json:"aliases"`
+	Upstream []string `json:"upstream"`
+	Summary  string   `json:"summary"`
+	Details  string   `json:"details"`
+	Severity []struct {
+		Type  string `json:"type"`
+		Score string `json:"score"`
+	} `json:"severity"`
+	Affected []struct {
+		Package osvPackage `json:"package"`
+		Ranges  []struct {
+			Events []struct {
+				Fixed string `json:"fixed"`
+			} `json:"events"`
+		} `json:"ranges"`
+		EcosystemSpecific struct {
+			Urgency string `json:"urgency"`
+		} `json:"ecosystem_specific"`
+	} `json:"affected"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+}
+
+// Vulnerabilities returns the advisories affecting the installed version of pkg.
+func (f *OSVFeed) Vulnerabilities(ctx context.Context, pkg domain.ManagedPackage) ([]domain.Vulnerability, error) {
+	if f.ecosystem == "" || pkg.Method != domain.MethodAPT {
+		return nil, domain.ErrNoAdvisoryData
+	}
+
+	output, err := f.commandRunner.ExecuteWithOutput(ctx, "dpkg-query", "-W", "-f=${source:Package} ${Version}", pkg.Source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dpkg for %s: %w", pkg.Source, err)
+	}
+
+	name, version, found := strings.Cut(strings.TrimSpace(output), " ")
+	if !found || name == "" || version == "" {
+		return nil, fmt.Errorf("%w: %s is not installed", domain.ErrNoAdvisoryData, pkg.Source)
+	}
+
+	var response osvResponse
+	if err := f.postJSON(ctx, f.endpoint+"/query", osvQuery{
+		Package: osvPackage{Name: name, Ecosystem: f.ecosystem},
+		Version: version,
+	}, &response); err != nil {
+		return nil, err
+	}
+
+	vulnerabilities := make([]domain.Vulnerability, 0, len(response.Vulns))
+	for _, vuln := range response.Vulns {
+		vulnerabilities = append(vulnerabilities, f.convert(vuln, name))
+	}
+
+	return vulnerabilities, nil
+}
+
+// convert picks the summary, severity and fix of an OSV record for the package.
+func (f *OSVFeed) convert(vuln osvVuln, name string) domain.Vulnerability {
+	result := domain.Vulnerability{
+		ID:       vuln.ID,
+		Aliases:  append(vuln.Aliases, vuln.Upstream...),
+		Summary:  vuln.Summary,
+		Severity: domain.SeverityUnknown,
+	}
+
+	if result.Summary == "" {
+		result.Summary, _, _ = strings.Cut(strings.TrimSpace(vuln.Details), "\n")
+	}
+
+	// Ubuntu publishes its priority as a severity, Debian as an urgency
+	for _, severity := range vuln.Severity {
+		if severity.Type == "Ubuntu" {
+			result.Severity = strings.ToLower(severity.Score)
+		}
+	}
+
+	if vuln.DatabaseSpecific.Severity != "" && result.Severity == domain.SeverityUnknown {
+		result.Severity = strings.ToLower(vuln.DatabaseSpecific.Severity)
+	}
+
+	for _, affected := range vuln.Affected {
+		if affected.Package.Name != name || affected.Package.Ecosystem != f.ecosystem {
+			continue
+		}
+
+		if urgency := strings.ToLower(affected.EcosystemSpecific.Urgency); urgency != "" && result.Severity == domain.SeverityUnknown {
+			result.Severity = strings.TrimSuffix(urgency, "*")
+		}
+
+		for _, r := range affected.Ranges {
+			for _, event := range r.Events {
+				if event.Fixed != "" {
+					result.FixedVersion = event.Fixed
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// postJSON posts body as JSON to url and decodes the JSON answer into target.
+func (f *OSVFeed) postJSON(ctx context.Context, url string, body, target any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return domain.NewKindError(domain.ErrorKindNetwork, fmt.Errorf("failed to query %s: %w", url, err))
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w %d from %s", ErrUpstreamStatus, resp.StatusCode, url)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", url, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSVEcosystem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dist *domain.Distribution
+		want string
+	}{
+		{&domain.Distribution{ID: "ubuntu", Version: "24.04"}, "Ubuntu:24.04:LTS"},
+		{&domain.Distribution{ID: "ubuntu", Version: "24.10"}, "Ubuntu:24.10"},
+		{&domain.Distribution{ID: "ubuntu", Version: "24.04.1 LTS (Noble Numbat)"}, "Ubuntu:24.04:LTS"},
+		{&domain.Distribution{ID: "ubuntu", Version: "24.10 (Oracular Oriole)"}, "Ubuntu:24.10"},
+		{&domain.Distribution{ID: "ubuntu", Version: "24"}, ""},
+		{&domain.Distribution{ID: "debian", Version: "12.5"}, "Debian:12"},
+		{&domain.Distribution{ID: "debian", Version: "12 (bookworm)"}, "Debian:12"},
+		{&domain.Distribution{ID: "debian", Version: "trixie/sid"}, ""},
+		{&domain.Distribution{ID: "debian"}, ""},
+		{&domain.Distribution{ID: "fedora", Version: "40"}, ""},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		got, ok := OSVEcosystem(tt.dist)
+		assert.Equal(t, tt.want, got)
+		assert.Equal(t, tt.want != "", ok)
+	}
+}
+
+func TestOSVFeedVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	var query osvQuery
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/query", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+
+		_, _ = w.Write([]byte(`{"vulns":[{
+			"id":"UBUNTU-CVE-2024-1234",
+			"upstream":["CVE-2024-1234"],
+			"details":"Heap overflow in the MKV demuxer.\nMore text.",
+			"severity":[{"type":"Ubuntu","score":"high"}],
+			"affected":[{"package":{"name":"vlc","ecosystem":"Ubuntu:24.04:LTS"},
+				"ranges":[{"events":[{"introduced":"0"},{"fixed":"3.0.21-0ubuntu0.24.04.1"}]}]}]
+		}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	runner := new(testutil.MockCommandRunner)
+	runner.On("ExecuteWithOutput", mock.Anything, "dpkg-query", "-W", "-f=${source:Package} ${Version}", "vlc-bin").
+		Return("vlc 3.0.20-3build6", nil)
+
+	feed := NewOSVFeed(server.Client(), runner, "Ubuntu:24.04:LTS")
+	feed.SetEndpoint(server.URL)
+
+	vulnerabilities, err := feed.Vulnerabilities(context.Background(),
+		domain.ManagedPackage{Key: "vlc", Method: domain.MethodAPT, Source: "vlc-bin"})
+	require.NoError(t, err)
+
+	assert.Equal(t, osvQuery{Package: osvPackage{Name: "vlc", Ecosystem: "Ubuntu:24.04:LTS"}, Version: "3.0.20-3build6"}, query)
+	assert.Equal(t, []domain.Vulnerability{{
+		ID:           "UBUNTU-CVE-2024-1234",
+		Aliases:      []string{"CVE-2024-1234"},
+		Summary:      "Heap overflow in the MKV demuxer.",
+		Severity:     domain.SeverityHigh,
+		FixedVersion: "3.0.21-0ubuntu0.24.04.1",
+	}}, vulnerabilities)
+
+	_, err = feed.Vulnerabilities(context.Background(), domain.ManagedPackage{Key: "zed", Method: domain.MethodFlatpak})
+	require.ErrorIs(t, err, domain.ErrNoAdvisoryData)
+}
@@ -11,13 +11,12 @@ import (
 )
 
 // GetHTTPClient returns an HTTP client configured with proxy settings and timeout.
-// Respects HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables.
+// Respects HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables, and
+// refuses all requests when KAREI_NO_NETWORK is set.
 func GetHTTPClient() *http.Client {
 	return &http.Client{
-		Timeout: 30 * time.Second,
-		Transport: &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-		},
+		Timeout:   30 * time.Second,
+		Transport: newTransport(),
 	}
 }
 
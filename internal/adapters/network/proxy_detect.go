@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// Where proxy settings came from.
+const (
+	ProxySourceConfig      = "config"
+	ProxySourceEnvironment = "environment"
+	ProxySourceGNOME       = "gnome"
+)
+
+// gnomeProxySchema is the GSettings schema of the GNOME network proxy panel.
+const gnomeProxySchema = "org.gnome.system.proxy"
+
+// ProxySettings are the proxies karei and the commands it runs use.
+type ProxySettings struct {
+	HTTP    string `json:"http,omitempty"`
+	HTTPS   string `json:"https,omitempty"`
+	NoProxy string `json:"no_proxy,omitempty"` // Comma-separated hosts and domains to reach directly
+	Source  string `json:"source,omitempty"`
+}
+
+// IsZero reports whether no proxy is set.
+func (p ProxySettings) IsZero() bool {
+	return p.HTTP == "" && p.HTTPS == ""
+}
+
+// Apply exports the settings as http_proxy, https_proxy and no_proxy, in
+// both cases, so karei's HTTP clients, GetProxyEnv, ConfigureAPTProxy and
+// the commands karei runs, git included, all use the same proxy. It must be
+// called before the first HTTP request, as the standard library reads the
+// environment once.
+func (p ProxySettings) Apply() {
+	for name, value := range map[string]string{"http_proxy": p.HTTP, "https_proxy": p.HTTPS, "no_proxy": p.NoProxy} {
+		if value == "" {
+			continue
+		}
+
+		_ = os.Setenv(name, value)
+		_ = os.Setenv(strings.ToUpper(name), value)
+	}
+}
+
+// String describes the settings with any credentials in the proxy URLs masked.
+func (p ProxySettings) String() string {
+	proxy := p.HTTPS
+	if proxy == "" {
+		proxy = p.HTTP
+	}
+
+	if parsed, err := url.Parse(proxy); err == nil {
+		proxy = parsed.Redacted()
+	}
+
+	return proxy + " (from " + p.Source + ")"
+}
+
+// DetectProxy returns the proxy set in the environment, or else the manual
+// proxy configured in GNOME settings. Automatic (PAC) configuration isn't
+// supported and yields no proxy.
+func DetectProxy(ctx context.Context, runner domain.CommandRunner) ProxySettings {
+	if env := EnvironmentProxy(); !env.IsZero() {
+		return env
+	}
+
+	if !runner.CommandExists("gsettings") {
+		return ProxySettings{}
+	}
+
+	return gnomeProxy(ctx, runner)
+}
+
+// EnvironmentProxy returns the proxy set in the environment, lowercase
+// variables taking precedence.
+func EnvironmentProxy() ProxySettings {
+	settings := ProxySettings{
+		HTTP:    getenvEitherCase("http_proxy"),
+		HTTPS:   getenvEitherCase("https_proxy"),
+		NoProxy: getenvEitherCase("no_proxy"),
+	}
+
+	if !settings.IsZero() {
+		settings.Source = ProxySourceEnvironment
+	}
+
+	return settings
+}
+
+// gnomeProxy reads the manual proxy of the GNOME network settings.
+func gnomeProxy(ctx context.Context, runner domain.CommandRunner) ProxySettings {
+	get := func(schema, key string) string {
+		value, err := runner.ExecuteWithOutput(ctx, "gsettings", "get", schema, key)
+		if err != nil {
+			return ""
+		}
+
+		return strings.Trim(strings.TrimSpace(value), "'")
+	}
+
+	if get(gnomeProxySchema, "mode") != "manual" {
+		return ProxySettings{}
+	}
+
+	proxyURL := func(protocol string) string {
+		host := get(gnomeProxySchema+"."+protocol, "host")
+
+		port, err := strconv.Atoi(get(gnomeProxySchema+"."+protocol, "port"))
+		if host == "" || err != nil || port == 0 {
+			return ""
+		}
+
+		return "http://" + host + ":" + strconv.Itoa(port)
+	}
+
+	settings := ProxySettings{
+		HTTP:    proxyURL("http"),
+		HTTPS:   proxyURL("https"),
+		NoProxy: parseGSettingsList(get(gnomeProxySchema, "ignore-hosts")),
+	}
+
+	if settings.IsZero() {
+		return ProxySettings{}
+	}
+
+	settings.Source = ProxySourceGNOME
+
+	return settings
+}
+
+// parseGSettingsList turns a GVariant string array such as
+// ['localhost', '127.0.0.0/8'] into a comma-separated list.
+func parseGSettingsList(value string) string {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "@as ")
+	value = strings.Trim(value, "[]")
+
+	var items []string
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.Trim(strings.TrimSpace(item), "'"); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return strings.Join(items, ",")
+}
+
+func getenvEitherCase(name string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	return os.Getenv(strings.ToUpper(name))
+}
@@ -8,7 +8,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/janderssonse/karei/internal/xdg"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetHTTPClient(t *testing.T) {
@@ -23,6 +25,20 @@ func TestGetHTTPClient(t *testing.T) {
 	assert.NotNil(t, transport.Proxy)
 }
 
+func TestGetHTTPClientNoNetwork(t *testing.T) {
+	t.Setenv(xdg.EnvNoNetwork, "1")
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://127.0.0.1:1/", nil)
+	require.NoError(t, err)
+
+	resp, err := GetHTTPClient().Do(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	assert.ErrorIs(t, err, ErrNetworkDisabled)
+}
+
 func TestGetProxyEnv(t *testing.T) {
 	tests := []struct {
 		name        string
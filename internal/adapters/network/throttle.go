@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// throttleSlices is how many reads a second of bandwidth is paced in, so
+// a limited download flows evenly rather than in bursts.
+const throttleSlices = 10
+
+// downloadThrottle paces the bodies of all responses karei reads, so its
+// downloads together stay under KAREI_BANDWIDTH_LIMIT.
+var downloadThrottle = &throttle{} //nolint:gochecknoglobals // Shared by every client, as the limit is
+
+// throttle spends a byte rate across concurrent readers.
+type throttle struct {
+	mu   sync.Mutex
+	rate int64     // Bytes per second
+	paid time.Time // When the bytes read so far fit the rate
+}
+
+// wait blocks until reading n more bytes keeps within the rate.
+func (t *throttle) wait(ctx context.Context, n int) error {
+	t.mu.Lock()
+
+	now := time.Now()
+	if t.paid.Before(now) {
+		t.paid = now
+	}
+
+	t.paid = t.paid.Add(time.Duration(int64(n) * int64(time.Second) / t.rate))
+	delay := t.paid.Sub(now)
+
+	t.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// throttledTransport paces the bodies of the responses of base.
+type throttledTransport struct {
+	base     http.RoundTripper
+	throttle *throttle
+	slice    int // Bytes read at a time
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = &throttledBody{ReadCloser: resp.Body, ctx: req.Context(), throttle: t.throttle, slice: t.slice}
+
+	return resp, nil
+}
+
+// throttledBody reads a response body no faster than the throttle allows.
+type throttledBody struct {
+	io.ReadCloser
+
+	ctx      context.Context //nolint:containedctx // The request's, for the lifetime of its body
+	throttle *throttle
+	slice    int
+}
+
+// Read implements io.Reader.
+func (b *throttledBody) Read(p []byte) (int, error) {
+	if len(p) > b.slice {
+		p = p[:b.slice]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		if waitErr := b.throttle.wait(b.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+
+	return n, err //nolint:wrapcheck // io.EOF must reach the caller as is
+}
+
+// throttled wraps base to keep to KAREI_BANDWIDTH_LIMIT, when it is set.
+func throttled(base http.RoundTripper) http.RoundTripper {
+	limit := xdg.BandwidthLimit()
+	if limit <= 0 {
+		return base
+	}
+
+	downloadThrottle.mu.Lock()
+	downloadThrottle.rate = limit
+	downloadThrottle.mu.Unlock()
+
+	return throttledTransport{base: base, throttle: downloadThrottle, slice: int(max(limit/throttleSlices, 1))}
+}
+
+// ConfigureAPTBandwidth returns the apt options keeping its downloads to
+// KAREI_BANDWIDTH_LIMIT, or none without a limit. apt takes the limit in
+// KiB per second.
+func ConfigureAPTBandwidth() []string {
+	limit := xdg.BandwidthLimit()
+	if limit <= 0 {
+		return nil
+	}
+
+	kib := strconv.FormatInt(max(limit/1024, 1), 10)
+
+	return []string{"-o", "Acquire::http::Dl-Limit=" + kib, "-o", "Acquire::https::Dl-Limit=" + kib}
+}
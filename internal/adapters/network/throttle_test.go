@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+func TestBandwidthLimit(t *testing.T) {
+	t.Setenv(xdg.EnvBandwidthLimit, "100kB")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, strings.Repeat("x", 30_000))
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+
+	resp, err := GetHTTPClient().Do(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Len(t, body, 30_000)
+	assert.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond, "30 kB at 100 kB/s takes 300 ms")
+
+	assert.Equal(t, []string{"-o", "Acquire::http::Dl-Limit=97", "-o", "Acquire::https::Dl-Limit=97"}, ConfigureAPTBandwidth())
+
+	t.Setenv(xdg.EnvBandwidthLimit, "")
+	assert.Empty(t, ConfigureAPTBandwidth())
+}
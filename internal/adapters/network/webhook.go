@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+const (
+	webhookAttempts  = 3
+	webhookBackoff   = 2 * time.Second
+	webhookQueueSize = 64
+	redactedValue    = "[REDACTED]"
+)
+
+// ErrWebhookUndelivered is returned by Close when events could not be delivered.
+var ErrWebhookUndelivered = errors.New("webhook events not delivered")
+
+// sensitiveKeys are event data keys whose values are never sent.
+var sensitiveKeys = []string{"password", "token", "secret", "key", "credential"} //nolint:gochecknoglobals
+
+// Webhook posts domain events as JSON to a URL, such as a Slack or Matrix
+// incoming webhook. Events are delivered in order in the background, so a
+// slow endpoint doesn't hold up installs, and retried on failure. Events
+// arriving while the queue is full are dropped and counted.
+type Webhook struct {
+	client   *http.Client
+	url      string
+	redact   func(string) string
+	attempts int
+	backoff  time.Duration
+
+	queue   chan domain.Event
+	done    chan struct{}
+	mu      sync.Mutex
+	closed  bool
+	failed  int
+	dropped int
+}
+
+// webhookPayload carries a one-line summary in "text", the field Slack,
+// Mattermost and Matrix hookshot display, next to the event itself.
+type webhookPayload struct {
+	Text  string       `json:"text"`
+	Event domain.Event `json:"event"`
+}
+
+// NewWebhook starts delivering events to url. redact is applied to the
+// subject and data values; values of sensitive keys are dropped entirely.
+func NewWebhook(client *http.Client, url string, redact func(string) string) *Webhook {
+	w := &Webhook{
+		client:   client,
+		url:      url,
+		redact:   redact,
+		attempts: webhookAttempts,
+		backoff:  webhookBackoff,
+		queue:    make(chan domain.Event, webhookQueueSize),
+		done:     make(chan struct{}),
+	}
+
+	go w.deliver()
+
+	return w
+}
+
+// SetRetry sets how often delivery of an event is attempted and the delay
+// before the first retry, which doubles with each further attempt.
+func (w *Webhook) SetRetry(attempts int, backoff time.Duration) {
+	w.attempts = max(attempts, 1)
+	w.backoff = backoff
+}
+
+// Handle queues the event for delivery. It is a domain.EventHandler and
+// never blocks: the event is dropped when the queue is full, and ignored
+// once the webhook is closed.
+func (w *Webhook) Handle(_ context.Context, event domain.Event) {
+	event = w.redactEvent(event)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return
+	}
+
+	select {
+	case w.queue <- event:
+	default:
+		w.dropped++
+	}
+}
+
+// Close waits up to timeout for queued events to be delivered. Events
+// handled afterwards are ignored.
+func (w *Webhook) Close(timeout time.Duration) error {
+	w.mu.Lock()
+
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+	case <-time.After(timeout):
+		return fmt.Errorf("%w: timed out after %s", ErrWebhookUndelivered, timeout)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.failed > 0 || w.dropped > 0 {
+		return fmt.Errorf("%w: %d failed, %d dropped", ErrWebhookUndelivered, w.failed, w.dropped)
+	}
+
+	return nil
+}
+
+func (w *Webhook) deliver() {
+	defer close(w.done)
+
+	for event := range w.queue {
+		if err := w.post(event); err != nil {
+			w.mu.Lock()
+			w.failed++
+			w.mu.Unlock()
+		}
+	}
+}
+
+// post sends one event, retrying until it is accepted or attempts run out.
+func (w *Webhook) post(event domain.Event) error {
+	body, err := json.Marshal(webhookPayload{Text: describeEvent(event), Event: event})
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	backoff := w.backoff
+
+	for attempt := 1; ; attempt++ {
+		err = w.send(body)
+		if err == nil || attempt >= w.attempts {
+			return err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (w *Webhook) send(body []byte) error {
+	// The client timeout bounds each attempt; events outlive the command's context
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%w: %s", ErrUpstreamStatus, resp.Status)
+	}
+
+	return nil
+}
+
+// redactEvent returns a copy of event safe to send to a third party.
+func (w *Webhook) redactEvent(event domain.Event) domain.Event {
+	event.Subject = w.redact(event.Subject)
+
+	if event.Data == nil {
+		return event
+	}
+
+	data := make(map[string]string, len(event.Data))
+
+	for key, value := range event.Data {
+		if isSensitiveKey(key) {
+			data[key] = redactedValue
+
+			continue
+		}
+
+		data[key] = w.redact(value)
+	}
+
+	event.Data = data
+
+	return event
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// describeEvent summarizes an event for chat clients.
+func describeEvent(event domain.Event) string {
+	operation := event.Data["operation"]
+
+	switch event.Type {
+	case domain.EventOperationStarted:
+		return fmt.Sprintf("karei: %s %s started", operation, event.Subject)
+	case domain.EventOperationCompleted:
+		return fmt.Sprintf("karei: %s %s completed", operation, event.Subject)
+	case domain.EventOperationFailed:
+		return fmt.Sprintf("karei: %s %s failed: %s", operation, event.Subject, event.Data["error"])
+	default:
+		return fmt.Sprintf("karei: %s %s", event.Type, event.Subject)
+	}
+}
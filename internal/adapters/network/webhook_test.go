@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDeliversWithRetryAndRedaction(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+		received []webhookPayload
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// The first attempt fails, the retry succeeds
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		var payload webhookPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		received = append(received, payload)
+	}))
+	defer server.Close()
+
+	redact := func(text string) string { return strings.ReplaceAll(text, "/home/alice", "~") }
+
+	webhook := NewWebhook(server.Client(), server.URL, redact)
+	webhook.SetRetry(2, time.Millisecond)
+
+	event := domain.NewEvent(domain.EventOperationFailed, "vlc")
+	event.Data = map[string]string{
+		"operation": "install",
+		"error":     "cannot write /home/alice/.config/vlc",
+		"api_token": "ghp_secret",
+	}
+
+	webhook.Handle(context.Background(), event)
+	require.NoError(t, webhook.Close(5*time.Second))
+
+	require.Len(t, received, 1)
+	assert.Equal(t, "karei: install vlc failed: cannot write ~/.config/vlc", received[0].Text)
+	assert.Equal(t, redactedValue, received[0].Event.Data["api_token"])
+	assert.Equal(t, 2, requests)
+
+	// The caller's event is left alone
+	assert.Equal(t, "ghp_secret", event.Data["api_token"])
+}
+
+func TestWebhookReportsUndelivered(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.Client(), server.URL, func(text string) string { return text })
+	webhook.SetRetry(2, time.Millisecond)
+
+	webhook.Handle(context.Background(), domain.NewEvent(domain.EventOperationStarted, "vlc"))
+	require.ErrorIs(t, webhook.Close(5*time.Second), ErrWebhookUndelivered)
+}
+
+func TestWebhookDropsEventsWhenQueueIsFull(t *testing.T) {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.Client(), server.URL, func(text string) string { return text })
+
+	// The endpoint holds the first event, so the rest fill the queue
+	returned := make(chan struct{})
+
+	go func() {
+		defer close(returned)
+
+		for range webhookQueueSize + 10 {
+			webhook.Handle(context.Background(), domain.NewEvent(domain.EventOperationStarted, "vlc"))
+		}
+	}()
+
+	select {
+	case <-returned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Handle blocked on a full queue")
+	}
+
+	close(release)
+
+	err := webhook.Close(5 * time.Second)
+	require.ErrorIs(t, err, ErrWebhookUndelivered)
+	assert.Contains(t, err.Error(), "dropped")
+}
+
+func TestWebhookIgnoresEventsAfterClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.Client(), server.URL, func(text string) string { return text })
+	require.NoError(t, webhook.Close(5*time.Second))
+
+	assert.NotPanics(t, func() {
+		webhook.Handle(context.Background(), domain.NewEvent(domain.EventOperationStarted, "vlc"))
+	})
+	require.NoError(t, webhook.Close(5*time.Second), "closing twice is harmless")
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// ChecksumResolver implements the ChecksumResolver port. Flatpaks are
+// identified by their OSTree commit, everything else by hashing the
+// executable on PATH.
+type ChecksumResolver struct {
+	commandRunner domain.CommandRunner
+}
+
+// NewChecksumResolver creates a resolver that queries flatpak through the given command runner.
+func NewChecksumResolver(commandRunner domain.CommandRunner) *ChecksumResolver {
+	return &ChecksumResolver{commandRunner: commandRunner}
+}
+
+// ResolveChecksum returns the hex SHA-256 of what name installed through source.
+func (r *ChecksumResolver) ResolveChecksum(ctx context.Context, name, source string) (string, error) {
+	if source == versionSourceFlatpak {
+		// The commit ID is the SHA-256 of the deployed OSTree commit
+		output, err := r.commandRunner.ExecuteWithOutput(ctx, "flatpak", "info", "--show-commit", name)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(output), nil
+	}
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return "", nil //nolint:nilerr // Apps without an executable have nothing to fingerprint
+	}
+
+	return fileSHA256(path)
+}
+
+// fileSHA256 returns the hex SHA-256 of the file at path.
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path) //nolint:gosec // path comes from PATH lookup
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// MockChecksumResolver implements the ChecksumResolver port with fixed checksums.
+type MockChecksumResolver struct {
+	checksums map[string]string // name -> checksum
+}
+
+// NewMockChecksumResolver creates a resolver that answers from the given map.
+func NewMockChecksumResolver(checksums map[string]string) *MockChecksumResolver {
+	if checksums == nil {
+		checksums = make(map[string]string)
+	}
+
+	return &MockChecksumResolver{checksums: checksums}
+}
+
+// ResolveChecksum returns the preset checksum, or an empty string if unknown.
+func (r *MockChecksumResolver) ResolveChecksum(_ context.Context, name, _ string) (string, error) {
+	return r.checksums[name], nil
+}
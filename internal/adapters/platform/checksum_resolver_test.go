@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksumResolver_ResolveChecksum(t *testing.T) {
+	t.Parallel()
+
+	runner := &queryRunner{answers: map[string]string{
+		"--show-commit org.x": "9f2c1e\n",
+	}}
+	resolver := platform.NewChecksumResolver(runner)
+	ctx := context.Background()
+
+	checksum, err := resolver.ResolveChecksum(ctx, "org.x", "flatpak")
+	require.NoError(t, err)
+	assert.Equal(t, "9f2c1e", checksum)
+
+	checksum, err = resolver.ResolveChecksum(ctx, "no-such-karei-binary", "apt")
+	require.NoError(t, err)
+	assert.Empty(t, checksum)
+
+	path, err := exec.LookPath("sh")
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(content)
+
+	checksum, err = resolver.ResolveChecksum(ctx, "sh", "apt")
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), checksum)
+}
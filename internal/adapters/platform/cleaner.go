@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Reasons a Cleaner removes a path.
+const (
+	ReasonExpired  = "older than retention"
+	ReasonOverSize = "over size budget"
+	ReasonOrphaned = "orphaned install"
+)
+
+// Removal is a file or directory removed by a Cleaner.
+type Removal struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	Reason string `json:"reason"`
+}
+
+// Cleaner removes caches, logs and install leftovers karei no longer needs.
+// In dry-run mode it reports what it would remove without removing it.
+type Cleaner struct {
+	dryRun bool
+	now    func() time.Time
+}
+
+// NewCleaner creates a cleaner.
+func NewCleaner(dryRun bool) *Cleaner {
+	return &Cleaner{dryRun: dryRun, now: time.Now}
+}
+
+// cachedFile is a regular file found below a cache directory.
+type cachedFile struct {
+	path     string
+	size     int64
+	modified time.Time
+}
+
+// PruneCache removes files below dir last modified more than maxAge ago,
+// then the oldest remaining files until the rest fit in maxSize bytes. A
+// zero maxAge or maxSize disables that limit. Directories left empty are
+// removed too. A missing dir has nothing to prune.
+func (c *Cleaner) PruneCache(dir string, maxAge time.Duration, maxSize int64) ([]Removal, error) {
+	var files []cachedFile
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		files = append(files, cachedFile{path: path, size: info.Size(), modified: info.ModTime()})
+
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+
+	slices.SortFunc(files, func(a, b cachedFile) int { return a.modified.Compare(b.modified) })
+
+	var (
+		removals []Removal
+		kept     int64
+	)
+
+	for _, file := range files {
+		kept += file.size
+	}
+
+	for _, file := range files {
+		reason := ""
+
+		switch {
+		case maxAge > 0 && c.now().Sub(file.modified) > maxAge:
+			reason = ReasonExpired
+		case maxSize > 0 && kept > maxSize:
+			reason = ReasonOverSize
+		default:
+			continue
+		}
+
+		if err := c.remove(file.path); err != nil {
+			return removals, err
+		}
+
+		kept -= file.size
+		removals = append(removals, Removal{Path: file.path, Size: file.size, Reason: reason})
+	}
+
+	if !c.dryRun {
+		removeEmptyDirs(dir)
+	}
+
+	return removals, nil
+}
+
+// PruneFiles removes the files matching the glob patterns that were last
+// modified more than maxAge ago, such as logs and journals past their
+// retention. Rotated logs can be matched as "install.log*".
+func (c *Cleaner) PruneFiles(patterns []string, maxAge time.Duration) ([]Removal, error) {
+	var removals []Removal
+
+	for _, pattern := range patterns {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return removals, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil || !info.Mode().IsRegular() || c.now().Sub(info.ModTime()) <= maxAge {
+				continue
+			}
+
+			if err := c.remove(path); err != nil {
+				return removals, err
+			}
+
+			removals = append(removals, Removal{Path: path, Size: info.Size(), Reason: ReasonExpired})
+		}
+	}
+
+	return removals, nil
+}
+
+// PruneOrphanedInstalls removes the directory shareDir/<name> of each of
+// names, the apps karei installs from GitHub as bundles, when binDir/<name>
+// no longer exists: the app was removed by hand or its uninstall was
+// interrupted, leaving the bundle behind.
+func (c *Cleaner) PruneOrphanedInstalls(shareDir, binDir string, names []string) ([]Removal, error) {
+	var removals []Removal
+
+	for _, name := range names {
+		dir := filepath.Join(shareDir, name)
+
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+
+		if _, err := os.Lstat(filepath.Join(binDir, name)); err == nil {
+			continue
+		}
+
+		size := dirSize(dir)
+
+		if !c.dryRun {
+			if err := os.RemoveAll(dir); err != nil {
+				return removals, fmt.Errorf("failed to remove %s: %w", dir, err)
+			}
+		}
+
+		removals = append(removals, Removal{Path: dir, Size: size, Reason: ReasonOrphaned})
+	}
+
+	return removals, nil
+}
+
+func (c *Cleaner) remove(path string) error {
+	if c.dryRun {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// dirSize sums the sizes of the regular files below dir.
+func dirSize(dir string) int64 {
+	var size int64
+
+	_ = filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err == nil && entry.Type().IsRegular() {
+			if info, infoErr := entry.Info(); infoErr == nil {
+				size += info.Size()
+			}
+		}
+
+		return nil
+	})
+
+	return size
+}
+
+// removeEmptyDirs removes the empty directories below dir, deepest first.
+func removeEmptyDirs(dir string) {
+	var dirs []string
+
+	_ = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && entry.IsDir() && path != dir {
+			dirs = append(dirs, path)
+		}
+
+		return nil
+	})
+
+	slices.SortFunc(dirs, func(a, b string) int {
+		return strings.Count(b, string(filepath.Separator)) - strings.Count(a, string(filepath.Separator))
+	})
+
+	for _, path := range dirs {
+		_ = os.Remove(path) // Fails, as intended, unless empty
+	}
+}
+
+// TotalSize sums the sizes of removals.
+func TotalSize(removals []Removal) int64 {
+	var total int64
+	for _, removal := range removals {
+		total += removal.Size
+	}
+
+	return total
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeAged writes size bytes to path and sets its modification time age ago.
+func writeAged(t *testing.T, path string, size int, age time.Duration) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0o600))
+
+	modified := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, modified, modified))
+}
+
+func TestCleaner_PruneCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeAged(t, filepath.Join(dir, "details", "old.json"), 10, 48*time.Hour)
+	writeAged(t, filepath.Join(dir, "a.bin"), 100, 3*time.Hour)
+	writeAged(t, filepath.Join(dir, "b.bin"), 100, 2*time.Hour)
+	writeAged(t, filepath.Join(dir, "c.bin"), 100, time.Hour)
+
+	dryRun, err := platform.NewCleaner(true).PruneCache(dir, 24*time.Hour, 150)
+	require.NoError(t, err)
+	assert.Len(t, dryRun, 3)
+	assert.FileExists(t, filepath.Join(dir, "details", "old.json"))
+
+	removals, err := platform.NewCleaner(false).PruneCache(dir, 24*time.Hour, 150)
+	require.NoError(t, err)
+	require.Len(t, removals, 3)
+	assert.Equal(t, platform.ReasonExpired, removals[0].Reason)
+	assert.Equal(t, platform.ReasonOverSize, removals[1].Reason)
+	assert.Equal(t, filepath.Join(dir, "b.bin"), removals[2].Path)
+	assert.Equal(t, int64(210), platform.TotalSize(removals))
+
+	assert.FileExists(t, filepath.Join(dir, "c.bin"))
+	assert.NoDirExists(t, filepath.Join(dir, "details"))
+	assert.DirExists(t, dir)
+
+	removals, err = platform.NewCleaner(false).PruneCache(filepath.Join(dir, "missing"), time.Hour, 0)
+	require.NoError(t, err)
+	assert.Empty(t, removals)
+}
+
+func TestCleaner_PruneFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeAged(t, filepath.Join(dir, "install.log"), 5, time.Hour)
+	writeAged(t, filepath.Join(dir, "install.log.1"), 5, 100*24*time.Hour)
+	writeAged(t, filepath.Join(dir, "journal.json"), 5, 100*24*time.Hour)
+
+	removals, err := platform.NewCleaner(false).PruneFiles(
+		[]string{filepath.Join(dir, "install.log*"), filepath.Join(dir, "journal.json")}, 90*24*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, removals, 2)
+	assert.FileExists(t, filepath.Join(dir, "install.log"))
+	assert.NoFileExists(t, filepath.Join(dir, "install.log.1"))
+	assert.NoFileExists(t, filepath.Join(dir, "journal.json"))
+}
+
+func TestCleaner_PruneOrphanedInstalls(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	share, bin := filepath.Join(root, "share"), filepath.Join(root, "bin")
+	writeAged(t, filepath.Join(share, "pmd", "lib", "pmd.jar"), 50, 0)
+	writeAged(t, filepath.Join(share, "kept", "kept.jar"), 50, 0)
+	writeAged(t, filepath.Join(bin, "kept"), 1, 0)
+
+	removals, err := platform.NewCleaner(false).PruneOrphanedInstalls(share, bin, []string{"pmd", "kept", "absent"})
+	require.NoError(t, err)
+	require.Len(t, removals, 1)
+	assert.Equal(t, platform.Removal{Path: filepath.Join(share, "pmd"), Size: 50, Reason: platform.ReasonOrphaned}, removals[0])
+	assert.NoDirExists(t, filepath.Join(share, "pmd"))
+	assert.DirExists(t, filepath.Join(share, "kept"))
+}
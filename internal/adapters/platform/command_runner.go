@@ -5,21 +5,30 @@
 package platform
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/domain"
 )
 
 // CommandRunner implements the CommandRunner port for real system commands.
 type CommandRunner struct {
-	verbose bool
-	dryRun  bool
-	tuiMode bool // When true, suppress direct terminal output for TUI compatibility
+	verbose  bool
+	dryRun   bool
+	tuiMode  bool   // When true, suppress direct terminal output for TUI compatibility
+	password string // When set, sudo reads it from stdin instead of prompting
+	polkit   bool   // When set, privileged commands go through pkexec instead of sudo
+	output   func(line string)
+	env      map[string][]string // Extra variables by command name, such as tokens
 }
 
 // NewCommandRunner creates a new command runner.
@@ -40,6 +49,42 @@ func NewTUICommandRunner(verbose, dryRun bool) *CommandRunner {
 	}
 }
 
+// SetPassword stores the sudo password used for non-interactive privileged commands.
+func (r *CommandRunner) SetPassword(password string) {
+	r.password = password
+}
+
+// SetPolkit runs privileged commands through pkexec, so the desktop's polkit
+// agent authenticates them, instead of sudo. pkexec doesn't keep the
+// authorization between runs, so each privileged command asks again.
+func (r *CommandRunner) SetPolkit(enabled bool) {
+	r.polkit = enabled
+}
+
+// SetOutputFunc sets a function receiving each line commands print, so
+// installer output can drive progress. In CLI mode the output then no longer
+// reaches the terminal. Lines redrawn with a carriage return, such as
+// progress bars, arrive once per redraw. The function is called from the
+// goroutines reading the output.
+func (r *CommandRunner) SetOutputFunc(fn func(line string)) {
+	r.output = fn
+}
+
+// AddCommandEnv passes variables, as "NAME=value", to every run of command
+// and to no other command.
+func (r *CommandRunner) AddCommandEnv(command string, variables ...string) {
+	if r.env == nil {
+		r.env = make(map[string][]string)
+	}
+
+	r.env[command] = append(r.env[command], variables...)
+}
+
+// commandEnv returns the variables added for the command name runs.
+func (r *CommandRunner) commandEnv(name string) []string {
+	return r.env[filepath.Base(name)]
+}
+
 // Execute runs a command and returns the result.
 func (r *CommandRunner) Execute(ctx context.Context, name string, args ...string) error {
 	if r.verbose && !r.tuiMode {
@@ -58,8 +103,9 @@ func (r *CommandRunner) Execute(ctx context.Context, name string, args ...string
 
 	// Propagate proxy environment variables
 	cmd.Env = append(os.Environ(), network.GetProxyEnv()...)
+	cmd.Env = append(cmd.Env, r.commandEnv(name)...)
 
-	if r.tuiMode {
+	if r.tuiMode || r.output != nil {
 		return r.executeTUIMode(cmd)
 	}
 
@@ -81,6 +127,9 @@ func (r *CommandRunner) ExecuteWithOutput(ctx context.Context, name string, args
 	}
 
 	cmd := exec.CommandContext(ctx, name, args...)
+	if extra := r.commandEnv(name); len(extra) > 0 {
+		cmd.Env = append(os.Environ(), extra...)
+	}
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -104,15 +153,45 @@ func (r *CommandRunner) ExecuteSudo(ctx context.Context, name string, args ...st
 		return nil
 	}
 
+	if r.polkit {
+		return r.executePolkit(ctx, name, args...)
+	}
+
 	// Prepend sudo to the command
 	allArgs := append([]string{name}, args...)
+	if r.password != "" {
+		allArgs = append([]string{"-S"}, allArgs...)
+	}
 	// #nosec G204 - This is intentional command execution with validated input
 	cmd := exec.CommandContext(ctx, "sudo", allArgs...)
 
+	// Provide password via stdin so sudo never prompts on the terminal
+	if r.password != "" {
+		cmd.Stdin = strings.NewReader(r.password + "\n")
+	}
+
 	// Propagate proxy environment variables to sudo command
 	cmd.Env = append(os.Environ(), network.GetProxyEnv()...)
 
-	if r.tuiMode {
+	if r.tuiMode || r.output != nil {
+		return r.executeTUIMode(cmd)
+	}
+
+	return r.executeCLIMode(cmd)
+}
+
+// executePolkit runs a privileged command through pkexec, in a polkit dialog
+// of its own. pkexec clears the environment, so the proxy settings are
+// passed on through env.
+func (r *CommandRunner) executePolkit(ctx context.Context, name string, args ...string) error {
+	allArgs := append([]string{"--disable-internal-agent", "env"}, network.GetProxyEnv()...)
+	allArgs = append(allArgs, name)
+	allArgs = append(allArgs, args...)
+
+	// #nosec G204 - This is intentional command execution with validated input
+	cmd := exec.CommandContext(ctx, "pkexec", allArgs...)
+
+	if r.tuiMode || r.output != nil {
 		return r.executeTUIMode(cmd)
 	}
 
@@ -145,27 +224,67 @@ func (r *CommandRunner) executeTUIMode(cmd *exec.Cmd) error {
 		return fmt.Errorf("failed to start command: %w", err)
 	}
 
-	// Read both stdout and stderr concurrently
-	stdoutBytes, _ := io.ReadAll(stdout)
-	stderrBytes, _ := io.ReadAll(stderr)
+	// Read both stdout and stderr concurrently, so neither pipe fills up
+	var (
+		readers     sync.WaitGroup
+		stderrBytes bytes.Buffer
+	)
+
+	readers.Go(func() { r.forwardOutput(stdout, nil) })
+	readers.Go(func() { r.forwardOutput(stderr, &stderrBytes) })
+	readers.Wait()
 
 	// Wait for command completion
 	if err := cmd.Wait(); err != nil {
-		stderrOutput := strings.TrimSpace(string(stderrBytes))
+		stderrOutput := strings.TrimSpace(stderrBytes.String())
 		if stderrOutput != "" {
-			return fmt.Errorf("command failed: %w (stderr: %s)", err, stderrOutput)
+			// Tag the failure so the TUI can offer a targeted remediation
+			return domain.NewKindError(domain.ClassifyOutput(stderrOutput),
+				fmt.Errorf("command failed: %w (stderr: %s)", err, stderrOutput))
 		}
 
 		return fmt.Errorf("command failed: %w", err)
 	}
 
-	// In TUI mode, output is captured but not displayed
-	// TUI framework will handle progress display
-	_ = stdoutBytes // Prevent unused variable warning
-
 	return nil
 }
 
+// forwardOutput passes each line read from pipe to the output function,
+// keeping a copy in keep when it is set.
+func (r *CommandRunner) forwardOutput(pipe io.Reader, keep *bytes.Buffer) {
+	scanner := bufio.NewScanner(pipe)
+	scanner.Split(scanOutputLines)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if keep != nil {
+			keep.WriteString(line + "\n")
+		}
+
+		if r.output != nil && strings.TrimSpace(line) != "" {
+			r.output(line)
+		}
+	}
+
+	// Drain what a line too long for the scanner left, so the command can finish
+	_, _ = io.Copy(io.Discard, pipe)
+}
+
+// scanOutputLines splits output at newlines and at the carriage returns
+// progress bars redraw themselves with.
+func scanOutputLines(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
+
 // executeCLIMode handles command execution in CLI mode with normal terminal output.
 func (r *CommandRunner) executeCLIMode(cmd *exec.Cmd) error {
 	// CLI mode: Allow normal terminal output
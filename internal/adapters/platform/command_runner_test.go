@@ -5,7 +5,10 @@ package platform_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -185,3 +188,83 @@ func TestCommandRunner_CommandExists(t *testing.T) {
 		})
 	}
 }
+
+func TestCommandRunner_OutputFunc(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		lines []string
+	)
+
+	runner := platform.NewTUICommandRunner(false, false)
+	runner.SetOutputFunc(func(line string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		lines = append(lines, line)
+	})
+
+	err := runner.Execute(context.Background(), "sh", "-c", `printf 'Installing 1/1 10%%\rInstalling 1/1 90%%\ndone\n'; echo oops >&2; exit 3`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "oops", "stderr still explains the failure")
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.ElementsMatch(t, []string{"Installing 1/1 10%", "Installing 1/1 90%", "done", "oops"}, lines)
+}
+
+func TestCommandRunner_OutputFuncInCLIMode(t *testing.T) {
+	t.Parallel()
+
+	var lines []string
+
+	runner := platform.NewCommandRunner(false, false)
+	runner.SetOutputFunc(func(line string) { lines = append(lines, line) })
+
+	require.NoError(t, runner.Execute(context.Background(), "echo", "captured"))
+	assert.Equal(t, []string{"captured"}, lines)
+
+	runner.SetOutputFunc(nil)
+	require.NoError(t, runner.Execute(context.Background(), "true"))
+	assert.Len(t, lines, 1)
+}
+
+func TestCommandRunner_CommandEnvOnlyReachesItsCommand(t *testing.T) {
+	t.Parallel()
+
+	cr := platform.NewTUICommandRunner(false, false)
+	cr.AddCommandEnv("sh", "KAREI_TEST_TOKEN=s3cret")
+
+	output, err := cr.ExecuteWithOutput(context.Background(), "sh", "-c", "echo $KAREI_TEST_TOKEN")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", strings.TrimSpace(output))
+
+	output, err = cr.ExecuteWithOutput(context.Background(), "env")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "KAREI_TEST_TOKEN")
+}
+
+func TestCommandRunner_PolkitRunsThroughPkexec(t *testing.T) {
+	// A stand-in pkexec prints how it was called
+	bin := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "pkexec"), []byte("#!/bin/sh\necho \"$@\"\n"), 0o700)) //nolint:gosec // Test script
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	var lines []string
+
+	runner := platform.NewCommandRunner(false, false)
+	runner.SetOutputFunc(func(line string) { lines = append(lines, line) })
+	runner.SetPolkit(true)
+
+	require.NoError(t, runner.ExecuteSudo(context.Background(), "apt-get", "install", "-y", "vlc"))
+	require.Len(t, lines, 1)
+	assert.True(t, strings.HasPrefix(lines[0], "--disable-internal-agent env "), lines[0])
+	assert.True(t, strings.HasSuffix(lines[0], " apt-get install -y vlc"), lines[0])
+
+	// pkexec keeps no authorization, so every privileged command is a run, and a dialog, of its own
+	require.NoError(t, runner.ExecuteSudo(context.Background(), "apt-get", "install", "-y", "git"))
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasSuffix(lines[1], " apt-get install -y git"), lines[1])
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// ContainerExporter implements the ContainerExporter port for toolbox and distrobox.
+// distrobox ships its own exporter; for toolbox, wrapper scripts and rewritten
+// desktop entries are written to the shared home directory.
+type ContainerExporter struct {
+	commandRunner domain.CommandRunner
+	fileManager   domain.FileManager
+	session       *domain.ContainerSession
+	homeDir       string
+}
+
+// NewContainerExporter creates an exporter for the given container session.
+func NewContainerExporter(commandRunner domain.CommandRunner, fileManager domain.FileManager, session *domain.ContainerSession, homeDir string) *ContainerExporter {
+	return &ContainerExporter{
+		commandRunner: commandRunner,
+		fileManager:   fileManager,
+		session:       session,
+		homeDir:       homeDir,
+	}
+}
+
+// Export publishes the binaries and desktop entries of a system package to the host.
+// Methods that install into the home directory are already visible and are skipped.
+// It returns the wrappers and desktop entries written, including those written
+// before a failure.
+func (e *ContainerExporter) Export(ctx context.Context, pkg *domain.Package) ([]domain.Artifact, error) {
+	if pkg.Method != domain.MethodAPT && pkg.Method != domain.MethodDEB {
+		return nil, nil
+	}
+
+	name := pkg.Name
+	if pkg.Method == domain.MethodAPT && pkg.Source != "" {
+		name = pkg.Source
+	}
+
+	output, err := e.commandRunner.ExecuteWithOutput(ctx, "dpkg", "-L", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of %s: %w", name, err)
+	}
+
+	binaries, desktopFiles := exportableFiles(output)
+
+	var artifacts []domain.Artifact
+
+	for _, binary := range binaries {
+		target, err := e.exportBinary(ctx, binary)
+		if err != nil {
+			return artifacts, err
+		}
+
+		artifacts = append(artifacts, domain.Artifact{Kind: domain.ArtifactFile, Path: target})
+	}
+
+	for _, desktopFile := range desktopFiles {
+		target, err := e.exportDesktopFile(ctx, desktopFile)
+		if err != nil {
+			return artifacts, err
+		}
+
+		artifacts = append(artifacts, domain.Artifact{Kind: domain.ArtifactDesktopEntry, Path: target})
+	}
+
+	return artifacts, nil
+}
+
+// exportBinary puts binary on the host's PATH, returning the file created.
+func (e *ContainerExporter) exportBinary(ctx context.Context, binary string) (string, error) {
+	binDir := filepath.Join(e.homeDir, ".local", "bin")
+	target := filepath.Join(binDir, path.Base(binary))
+
+	if e.session.Kind == domain.ContainerDistrobox {
+		return target, e.commandRunner.Execute(ctx, "distrobox-export", "--bin", binary, "--export-path", binDir)
+	}
+
+	// The home directory is shared, so the wrapper must also work inside the container
+	wrapper := fmt.Sprintf(`#!/bin/sh
+# Exported from toolbox %[1]s by karei
+if [ -f /run/.toolboxenv ]; then exec %[2]s "$@"; fi
+exec toolbox run -c %[1]s %[2]s "$@"
+`, e.session.Name, binary)
+
+	if err := e.fileManager.WriteFile(target, []byte(wrapper)); err != nil {
+		return "", fmt.Errorf("failed to export %s: %w", binary, err)
+	}
+
+	return target, e.commandRunner.Execute(ctx, "chmod", "+x", target)
+}
+
+// exportDesktopFile adds the desktop entry to the host's menu, returning
+// the entry created.
+func (e *ContainerExporter) exportDesktopFile(ctx context.Context, desktopFile string) (string, error) {
+	applications := filepath.Join(e.homeDir, ".local", "share", "applications")
+
+	if e.session.Kind == domain.ContainerDistrobox {
+		// distrobox-export names the entry after the container
+		target := filepath.Join(applications, e.session.Name+"-"+path.Base(desktopFile))
+
+		return target, e.commandRunner.Execute(ctx, "distrobox-export", "--app", strings.TrimSuffix(path.Base(desktopFile), ".desktop"))
+	}
+
+	data, err := e.fileManager.ReadFile(desktopFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", desktopFile, err)
+	}
+
+	target := filepath.Join(applications, path.Base(desktopFile))
+	if err := e.fileManager.WriteFile(target, rewriteDesktopEntry(data, e.session.Name)); err != nil {
+		return "", fmt.Errorf("failed to export %s: %w", desktopFile, err)
+	}
+
+	return target, nil
+}
+
+// exportableFiles picks binaries and desktop entries from dpkg -L output.
+func exportableFiles(dpkgOutput string) (binaries, desktopFiles []string) {
+	for _, line := range strings.Split(dpkgOutput, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case path.Dir(line) == "/usr/bin" || path.Dir(line) == "/usr/games":
+			binaries = append(binaries, line)
+		case path.Dir(line) == "/usr/share/applications" && strings.HasSuffix(line, ".desktop"):
+			desktopFiles = append(desktopFiles, line)
+		}
+	}
+
+	return binaries, desktopFiles
+}
+
+// rewriteDesktopEntry runs Exec lines through toolbox and drops TryExec,
+// which would otherwise hide the entry on the host.
+func rewriteDesktopEntry(data []byte, container string) []byte {
+	lines := strings.Split(string(data), "\n")
+	out := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "TryExec=") {
+			continue
+		}
+
+		if command, found := strings.CutPrefix(line, "Exec="); found {
+			line = "Exec=toolbox run -c " + container + " " + command
+		}
+
+		out = append(out, line)
+	}
+
+	return []byte(strings.Join(out, "\n"))
+}
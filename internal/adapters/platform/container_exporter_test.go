@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dpkgVLC = `/.
+/usr/bin/vlc
+/usr/lib/vlc/plugins
+/usr/share/applications/vlc.desktop
+/usr/share/doc/vlc
+`
+
+func TestContainerExporter_Toolbox(t *testing.T) {
+	t.Parallel()
+
+	runner := NewMockCommandRunner(false)
+	runner.SetMockOutput("dpkg -L vlc", dpkgVLC)
+
+	fm := NewMockFileManager(false)
+	fm.SetMockFile("/usr/share/applications/vlc.desktop", []byte("[Desktop Entry]\nTryExec=vlc\nExec=/usr/bin/vlc %U\n"))
+
+	session := &domain.ContainerSession{Kind: domain.ContainerToolbox, Name: "dev"}
+	exporter := NewContainerExporter(runner, fm, session, "/home/user")
+
+	artifacts, err := exporter.Export(context.Background(), &domain.Package{Name: "vlc", Method: domain.MethodAPT, Source: "vlc"})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Artifact{
+		{Kind: domain.ArtifactFile, Path: "/home/user/.local/bin/vlc"},
+		{Kind: domain.ArtifactDesktopEntry, Path: "/home/user/.local/share/applications/vlc.desktop"},
+	}, artifacts)
+
+	wrapper, err := fm.ReadFile("/home/user/.local/bin/vlc")
+	require.NoError(t, err)
+	assert.Contains(t, string(wrapper), "exec toolbox run -c dev /usr/bin/vlc \"$@\"")
+
+	entry, err := fm.ReadFile("/home/user/.local/share/applications/vlc.desktop")
+	require.NoError(t, err)
+	assert.Equal(t, "[Desktop Entry]\nExec=toolbox run -c dev /usr/bin/vlc %U\n", string(entry))
+}
+
+func TestContainerExporter_SkipsHomeInstalls(t *testing.T) {
+	t.Parallel()
+
+	fm := NewMockFileManager(false)
+	session := &domain.ContainerSession{Kind: domain.ContainerToolbox, Name: "dev"}
+	exporter := NewContainerExporter(NewMockCommandRunner(false), fm, session, "/home/user")
+
+	artifacts, err := exporter.Export(context.Background(), &domain.Package{Name: "rust", Method: domain.MethodMise})
+	require.NoError(t, err)
+	assert.Empty(t, artifacts)
+	assert.False(t, fm.FileExists("/home/user/.local/bin/rust"))
+}
+
+func TestExportableFiles(t *testing.T) {
+	t.Parallel()
+
+	binaries, desktopFiles := exportableFiles(dpkgVLC)
+	assert.Equal(t, []string{"/usr/bin/vlc"}, binaries)
+	assert.Equal(t, []string{"/usr/share/applications/vlc.desktop"}, desktopFiles)
+}
+
+func TestDetectContainerSession_Toolbox(t *testing.T) {
+	t.Parallel()
+
+	fm := NewMockFileManager(false)
+	fm.SetMockFile(toolboxEnvFile, nil)
+	fm.SetMockFile(containerEnvFile, []byte("engine=\"podman-4.9\"\nname=\"fedora-toolbox-40\"\n"))
+
+	session := NewSystemDetector(NewMockCommandRunner(false), fm).DetectContainerSession(context.Background())
+	require.NotNil(t, session)
+	assert.Equal(t, domain.ContainerToolbox, session.Kind)
+	assert.Equal(t, "fedora-toolbox-40", session.Name)
+
+	assert.Nil(t, NewSystemDetector(NewMockCommandRunner(false), NewMockFileManager(false)).DetectContainerSession(context.Background()))
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// KernelInspector inspects the running kernel with uname and mokutil.
+type KernelInspector struct {
+	commandRunner domain.CommandRunner
+}
+
+// NewKernelInspector creates an inspector running its commands with cr.
+func NewKernelInspector(cr domain.CommandRunner) *KernelInspector {
+	return &KernelInspector{commandRunner: cr}
+}
+
+// Release returns the release of the running kernel.
+func (k *KernelInspector) Release(ctx context.Context) (string, error) {
+	output, err := k.commandRunner.ExecuteWithOutput(ctx, "uname", "-r")
+	if err != nil {
+		return "", fmt.Errorf("failed to find the kernel release: %w", err)
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// SecureBoot reports whether Secure Boot is enforced. Without mokutil, as
+// on machines that never had shim installed, it is taken to be off.
+func (k *KernelInspector) SecureBoot(ctx context.Context) bool {
+	if !k.commandRunner.CommandExists("mokutil") {
+		return false
+	}
+
+	// mokutil exits non-zero on firmware without Secure Boot, still printing the state
+	output, _ := k.commandRunner.ExecuteWithOutput(ctx, "mokutil", "--sb-state")
+
+	return domain.ParseSecureBoot(output)
+}
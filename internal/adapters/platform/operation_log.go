@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	operationLogLayout  = "20060102-150405"
+	operationLogExt     = ".log"
+	operationLogPerm    = 0o600
+	operationLogDirPerm = 0o700
+
+	// KeptOperationLogs is how many operation logs are kept; older ones are
+	// removed when a new operation starts.
+	KeptOperationLogs = 20
+)
+
+// ErrNoOperationLog indicates no operation has been logged yet.
+var ErrNoOperationLog = errors.New("no operation log found")
+
+// OperationLog streams every line of one install or uninstall run to a log
+// file of its own, named by the time the run started. It indexes where each
+// line starts, so any part of the record can be read back without keeping
+// the whole log in memory.
+type OperationLog struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	offsets []int64 // Start of each line
+	size    int64
+}
+
+// CreateOperationLog creates a new operation log in dir, removing all but
+// the most recent logs.
+func CreateOperationLog(dir string) (*OperationLog, error) {
+	if err := os.MkdirAll(dir, operationLogDirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	pruneOperationLogs(dir, KeptOperationLogs-1)
+
+	path := filepath.Join(dir, time.Now().Format(operationLogLayout)+operationLogExt)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, operationLogPerm) //nolint:gosec // path is below karei's log directory
+	if err != nil {
+		return nil, fmt.Errorf("failed to create operation log: %w", err)
+	}
+
+	// Two runs started within the same second share a file; index what is there
+	log := &OperationLog{path: path, file: file}
+	if err := log.indexExisting(); err != nil {
+		_ = file.Close()
+
+		return nil, err
+	}
+
+	return log, nil
+}
+
+// Path returns the log file.
+func (l *OperationLog) Path() string {
+	return l.path
+}
+
+// Append writes line to the log, reopening it when it was closed.
+func (l *OperationLog) Append(line string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, operationLogPerm)
+		if err != nil {
+			return fmt.Errorf("failed to open operation log: %w", err)
+		}
+
+		l.file = file
+	}
+
+	// A line of the log is a line of the file
+	line = strings.ReplaceAll(line, "\n", " ") + "\n"
+
+	written, err := l.file.WriteString(line)
+	if err != nil {
+		return fmt.Errorf("failed to write operation log: %w", err)
+	}
+
+	l.offsets = append(l.offsets, l.size)
+	l.size += int64(written)
+
+	return nil
+}
+
+// Len returns the number of lines written.
+func (l *OperationLog) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.offsets)
+}
+
+// Lines returns lines from up to but excluding to, read back from the file.
+func (l *OperationLog) Lines(from, to int) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	from, to = max(from, 0), min(to, len(l.offsets))
+	if from >= to {
+		return nil, nil
+	}
+
+	end := l.size
+	if to < len(l.offsets) {
+		end = l.offsets[to]
+	}
+
+	file, err := os.Open(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operation log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	data := make([]byte, end-l.offsets[from])
+	if _, err := file.ReadAt(data, l.offsets[from]); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read operation log: %w", err)
+	}
+
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n"), nil
+}
+
+// Close closes the file; a later Append opens it again.
+func (l *OperationLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+
+	return err
+}
+
+// indexExisting indexes the lines already in the file.
+func (l *OperationLog) indexExisting() error {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return fmt.Errorf("failed to read operation log: %w", err)
+	}
+
+	for start := 0; start < len(data); {
+		l.offsets = append(l.offsets, int64(start))
+
+		next := bytes.IndexByte(data[start:], '\n')
+		if next < 0 {
+			break
+		}
+
+		start += next + 1
+	}
+
+	l.size = int64(len(data))
+
+	return nil
+}
+
+// OperationLogs returns the operation logs in dir, oldest first.
+func OperationLogs(dir string) []string {
+	paths, _ := filepath.Glob(filepath.Join(dir, "*"+operationLogExt))
+
+	// The timestamped names sort by the time their run started
+	slices.Sort(paths)
+
+	return paths
+}
+
+// LatestOperationLog returns the log of the most recent operation in dir.
+func LatestOperationLog(dir string) (string, error) {
+	paths := OperationLogs(dir)
+	if len(paths) == 0 {
+		return "", ErrNoOperationLog
+	}
+
+	return paths[len(paths)-1], nil
+}
+
+// pruneOperationLogs removes all but the keep most recent operation logs.
+func pruneOperationLogs(dir string, keep int) {
+	paths := OperationLogs(dir)
+
+	for _, path := range paths[:max(len(paths)-keep, 0)] {
+		_ = os.Remove(path)
+	}
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationLog_AppendAndReadBack(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	log, err := platform.CreateOperationLog(dir)
+	require.NoError(t, err)
+
+	for i := range 40 {
+		require.NoError(t, log.Append(fmt.Sprintf("package %d installed", i)))
+	}
+
+	// Closing and appending again continues the same file
+	require.NoError(t, log.Close())
+	require.NoError(t, log.Append("multi\nline"))
+	require.NoError(t, log.Close())
+
+	assert.Equal(t, 41, log.Len())
+
+	lines, err := log.Lines(38, 41)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"package 38 installed", "package 39 installed", "multi line"}, lines)
+
+	lines, err = log.Lines(0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"package 0 installed"}, lines)
+
+	latest, err := platform.LatestOperationLog(dir)
+	require.NoError(t, err)
+	assert.Equal(t, log.Path(), latest)
+}
+
+func TestOperationLog_KeepsRecentLogs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	for i := range platform.KeptOperationLogs + 5 {
+		name := fmt.Sprintf("20240101-0000%02d.log", i)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("old\n"), 0o600))
+	}
+
+	log, err := platform.CreateOperationLog(dir)
+	require.NoError(t, err)
+	require.NoError(t, log.Close())
+
+	logs := platform.OperationLogs(dir)
+	assert.Len(t, logs, platform.KeptOperationLogs)
+	assert.Equal(t, log.Path(), logs[len(logs)-1])
+	assert.Equal(t, filepath.Join(dir, "20240101-000006.log"), logs[0])
+}
+
+func TestLatestOperationLog_None(t *testing.T) {
+	t.Parallel()
+
+	_, err := platform.LatestOperationLog(t.TempDir())
+	assert.ErrorIs(t, err, platform.ErrNoOperationLog)
+}
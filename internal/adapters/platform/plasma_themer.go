@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+const (
+	// plasmaProfile names the Konsole profile and color scheme karei owns.
+	plasmaProfile = "Karei"
+
+	kvantumStyle = "kvantum"
+)
+
+// PlasmaThemer implements the DesktopThemer port for KDE Plasma, through
+// plasma-apply-colorscheme, plasma-apply-wallpaperimage, Kvantum and
+// kwriteconfig, with a Konsole profile for the terminal colors.
+type PlasmaThemer struct {
+	commandRunner domain.CommandRunner
+	fileManager   domain.FileManager
+	dataHome      string
+}
+
+// NewPlasmaThemer creates a themer writing Konsole profiles below dataHome,
+// normally ~/.local/share.
+func NewPlasmaThemer(cr domain.CommandRunner, fm domain.FileManager, dataHome string) *PlasmaThemer {
+	return &PlasmaThemer{commandRunner: cr, fileManager: fm, dataHome: dataHome}
+}
+
+// Desktop names KDE Plasma.
+func (p *PlasmaThemer) Desktop() string {
+	return "KDE Plasma"
+}
+
+// ApplyAppearance sets the Breeze color scheme and icons matching the theme,
+// with its accent color, and the Kvantum widget style when Kvantum is
+// installed.
+func (p *PlasmaThemer) ApplyAppearance(ctx context.Context, appearance domain.DesktopAppearance) error {
+	scheme, icons, kvantum := "BreezeLight", "breeze", "KvGnome"
+	if appearance.Dark {
+		scheme, icons, kvantum = "BreezeDark", "breeze-dark", "KvGnomeDark"
+	}
+
+	args := []string{scheme}
+	if appearance.Accent != "" {
+		args = []string{"--accent-color", appearance.Accent, scheme}
+	}
+
+	if err := p.commandRunner.Execute(ctx, "plasma-apply-colorscheme", args...); err != nil {
+		return fmt.Errorf("failed to apply color scheme %s: %w", scheme, err)
+	}
+
+	if err := p.writeConfig(ctx, "kdeglobals", "Icons", "Theme", icons); err != nil {
+		return err
+	}
+
+	if !p.commandRunner.CommandExists("kvantummanager") {
+		return nil
+	}
+
+	if err := p.commandRunner.Execute(ctx, "kvantummanager", "--set", kvantum); err != nil {
+		return fmt.Errorf("failed to apply Kvantum theme %s: %w", kvantum, err)
+	}
+
+	return p.writeConfig(ctx, "kdeglobals", "KDE", "widgetStyle", kvantumStyle)
+}
+
+// ApplyBackground sets the wallpaper of every desktop.
+func (p *PlasmaThemer) ApplyBackground(ctx context.Context, path string) error {
+	if err := p.commandRunner.Execute(ctx, "plasma-apply-wallpaperimage", path); err != nil {
+		return fmt.Errorf("failed to set wallpaper: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyTerminalColors writes the colors as Konsole's Karei color scheme,
+// used by the Karei profile, and makes that profile the default.
+func (p *PlasmaThemer) ApplyTerminalColors(ctx context.Context, name string, colors domain.TerminalColors) error {
+	scheme, err := konsoleColorScheme(name, colors)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(p.dataHome, "konsole")
+	if err := p.fileManager.EnsureDir(dir); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	files := map[string]string{
+		plasmaProfile + ".colorscheme": scheme,
+		plasmaProfile + ".profile":     konsoleProfile(),
+	}
+
+	for file, content := range files {
+		if err := p.fileManager.WriteFile(filepath.Join(dir, file), []byte(content)); err != nil {
+			return fmt.Errorf("failed to write Konsole %s: %w", file, err)
+		}
+	}
+
+	return p.writeConfig(ctx, "konsolerc", "Desktop Entry", "DefaultProfile", plasmaProfile+".profile")
+}
+
+// writeConfig sets key in a KDE configuration file, with kwriteconfig6 on
+// Plasma 6 and kwriteconfig5 on Plasma 5.
+func (p *PlasmaThemer) writeConfig(ctx context.Context, file, group, key, value string) error {
+	tool := "kwriteconfig5"
+	if p.commandRunner.CommandExists("kwriteconfig6") {
+		tool = "kwriteconfig6"
+	}
+
+	if err := p.commandRunner.Execute(ctx, tool, "--file", file, "--group", group, "--key", key, value); err != nil {
+		return fmt.Errorf("failed to set %s [%s] %s: %w", file, group, key, err)
+	}
+
+	return nil
+}
+
+// konsoleColorScheme renders colors in Konsole's .colorscheme format, which
+// keeps the bright colors as the intense variant of each.
+func konsoleColorScheme(name string, colors domain.TerminalColors) (string, error) {
+	const normalColors = 8
+
+	sections := [][2]string{
+		{"Background", colors.Background},
+		{"BackgroundIntense", colors.Background},
+		{"Foreground", colors.Foreground},
+		{"ForegroundIntense", colors.Foreground},
+	}
+
+	for n := range normalColors {
+		sections = append(sections,
+			[2]string{fmt.Sprintf("Color%d", n), colors.Palette[n]},
+			[2]string{fmt.Sprintf("Color%dIntense", n), colors.Palette[n+normalColors]})
+	}
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "[General]\nDescription=Karei %s\nOpacity=1\n", name)
+
+	for _, section := range sections {
+		rgb, err := domain.RGB(section[1])
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(&builder, "\n[%s]\nColor=%s\n", section[0], rgb)
+	}
+
+	return builder.String(), nil
+}
+
+// konsoleProfile is the Karei profile, using the Karei color scheme.
+func konsoleProfile() string {
+	return "[Appearance]\nColorScheme=" + plasmaProfile + "\n\n[General]\nName=" + plasmaProfile + "\nParent=FALLBACK/\n"
+}
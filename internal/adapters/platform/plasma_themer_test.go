@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlasmaThemer_ApplyAppearance(t *testing.T) {
+	t.Parallel()
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("CommandExists", "kwriteconfig6").Return(true)
+	runner.On("CommandExists", "kvantummanager").Return(true)
+	runner.On("Execute", mock.Anything, "plasma-apply-colorscheme", "--accent-color", "#7aa2f7", "BreezeDark").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "kwriteconfig6", "--file", "kdeglobals", "--group", "Icons", "--key", "Theme", "breeze-dark").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "kvantummanager", "--set", "KvGnomeDark").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "kwriteconfig6", "--file", "kdeglobals", "--group", "KDE", "--key", "widgetStyle", "kvantum").Return(nil).Once()
+
+	themer := platform.NewPlasmaThemer(runner, platform.NewFileManager(false), t.TempDir())
+	require.NoError(t, themer.ApplyAppearance(context.Background(), domain.DesktopAppearance{Name: "tokyo-night", Dark: true, Accent: "#7aa2f7"}))
+
+	runner.AssertExpectations(t)
+}
+
+func TestPlasmaThemer_ApplyAppearanceWithoutKvantum(t *testing.T) {
+	t.Parallel()
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("CommandExists", "kwriteconfig6").Return(false)
+	runner.On("CommandExists", "kvantummanager").Return(false)
+	runner.On("Execute", mock.Anything, "plasma-apply-colorscheme", "BreezeLight").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "kwriteconfig5", "--file", "kdeglobals", "--group", "Icons", "--key", "Theme", "breeze").Return(nil).Once()
+
+	themer := platform.NewPlasmaThemer(runner, platform.NewFileManager(false), t.TempDir())
+	require.NoError(t, themer.ApplyAppearance(context.Background(), domain.DesktopAppearance{Name: "gruvbox-light"}))
+
+	runner.AssertExpectations(t)
+}
+
+func TestPlasmaThemer_ApplyTerminalColors(t *testing.T) {
+	t.Parallel()
+
+	dataHome := t.TempDir()
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("CommandExists", "kwriteconfig6").Return(true)
+	runner.On("Execute", mock.Anything, "kwriteconfig6", "--file", "konsolerc", "--group", "Desktop Entry", "--key", "DefaultProfile", "Karei.profile").Return(nil).Once()
+
+	colors := domain.TerminalColors{Background: "#2e3440", Foreground: "#d8dee9"}
+	for n := range colors.Palette {
+		colors.Palette[n] = "#000000"
+	}
+
+	colors.Palette[8] = "#4c566a"
+
+	themer := platform.NewPlasmaThemer(runner, platform.NewFileManager(false), dataHome)
+	require.NoError(t, themer.ApplyTerminalColors(context.Background(), "nord", colors))
+
+	scheme, err := os.ReadFile(filepath.Join(dataHome, "konsole", "Karei.colorscheme"))
+	require.NoError(t, err)
+	assert.Contains(t, string(scheme), "Description=Karei nord")
+	assert.Contains(t, string(scheme), "[Background]\nColor=46,52,64\n")
+	assert.Contains(t, string(scheme), "[Color0Intense]\nColor=76,86,106\n")
+
+	profile, err := os.ReadFile(filepath.Join(dataHome, "konsole", "Karei.profile"))
+	require.NoError(t, err)
+	assert.Contains(t, string(profile), "ColorScheme=Karei")
+
+	runner.AssertExpectations(t)
+}
@@ -0,0 +1,341 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+const (
+	secretService   = "karei"
+	secretsFile     = "secrets.enc"
+	secretsKeyFile  = "secrets.key"
+	secretsKeySize  = 32 // AES-256
+	secretsDirPerm  = 0o700
+	secretsFilePerm = 0o600
+
+	// keyringTimeout bounds a keyring call, which waits on D-Bus and may
+	// prompt to unlock the keyring.
+	keyringTimeout = 30 * time.Second
+)
+
+// ErrCorruptSecrets indicates the encrypted secrets file can't be decrypted
+// with its key.
+var ErrCorruptSecrets = errors.New("secrets file can't be decrypted")
+
+// NewSecretStore returns the desktop keyring when it can be reached, or else
+// an encrypted file in dir.
+func NewSecretStore(dir string) domain.SecretStore {
+	if keyring := NewKeyringSecrets(); keyring.Available() {
+		return keyring
+	}
+
+	return NewFileSecrets(dir)
+}
+
+// KeyringSecrets implements the SecretStore port with the freedesktop Secret
+// Service (GNOME Keyring, KWallet) through libsecret's secret-tool.
+type KeyringSecrets struct {
+	tool string
+}
+
+// NewKeyringSecrets creates a store using secret-tool from PATH.
+func NewKeyringSecrets() *KeyringSecrets {
+	return &KeyringSecrets{tool: "secret-tool"}
+}
+
+// Available reports whether secret-tool is installed and a session bus to
+// reach the keyring on is running, which it isn't over plain SSH.
+func (k *KeyringSecrets) Available() bool {
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return false
+	}
+
+	_, err := exec.LookPath(k.tool)
+
+	return err == nil
+}
+
+// Backend describes the keyring.
+func (k *KeyringSecrets) Backend() string {
+	return "keyring"
+}
+
+// Get looks name up in the keyring.
+func (k *KeyringSecrets) Get(name string) (string, error) {
+	if err := domain.ValidateSecretName(name); err != nil {
+		return "", err
+	}
+
+	output, err := k.run("", "lookup", "service", secretService, "name", name)
+
+	// secret-tool exits 1 without output when nothing matches
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(output) == 0 {
+		return "", fmt.Errorf("%w: %s", domain.ErrSecretNotFound, name)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(output, "\n"), nil
+}
+
+// Set stores value in the keyring, passing it on stdin so it never appears
+// in the process list.
+func (k *KeyringSecrets) Set(name, value string) error {
+	if err := domain.ValidateSecretName(name); err != nil {
+		return err
+	}
+
+	_, err := k.run(value, "store", "--label", "karei "+name, "service", secretService, "name", name)
+
+	return err
+}
+
+// Delete removes name from the keyring.
+func (k *KeyringSecrets) Delete(name string) error {
+	// secret-tool clear succeeds whether or not anything matched
+	if _, err := k.Get(name); err != nil {
+		return err
+	}
+
+	_, err := k.run("", "clear", "service", secretService, "name", name)
+
+	return err
+}
+
+// run runs secret-tool with stdin, returning what it printed.
+func (k *KeyringSecrets) run(stdin string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), keyringTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, k.tool, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("keyring %s failed: %s: %w", args[0], msg, err)
+		}
+
+		return stdout.String(), fmt.Errorf("keyring %s failed: %w", args[0], err)
+	}
+
+	return stdout.String(), nil
+}
+
+// FileSecrets implements the SecretStore port with an AES-GCM encrypted JSON
+// file and a random key kept next to it, both readable by the user only. It
+// keeps secrets out of config.toml, dotfile repositories and bug reports for
+// machines without a keyring; anyone who can read the user's files can still
+// decrypt them.
+type FileSecrets struct {
+	dir string
+}
+
+// NewFileSecrets creates a store keeping its files in dir.
+func NewFileSecrets(dir string) *FileSecrets {
+	return &FileSecrets{dir: dir}
+}
+
+// Backend describes the encrypted file.
+func (f *FileSecrets) Backend() string {
+	return "encrypted file " + filepath.Join(f.dir, secretsFile)
+}
+
+// Get returns the secret name from the file.
+func (f *FileSecrets) Get(name string) (string, error) {
+	if err := domain.ValidateSecretName(name); err != nil {
+		return "", err
+	}
+
+	secrets, err := f.load()
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := secrets[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", domain.ErrSecretNotFound, name)
+	}
+
+	return value, nil
+}
+
+// Set stores value as name in the file.
+func (f *FileSecrets) Set(name, value string) error {
+	if err := domain.ValidateSecretName(name); err != nil {
+		return err
+	}
+
+	secrets, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	secrets[name] = value
+
+	return f.save(secrets)
+}
+
+// Delete removes name from the file.
+func (f *FileSecrets) Delete(name string) error {
+	if err := domain.ValidateSecretName(name); err != nil {
+		return err
+	}
+
+	secrets, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := secrets[name]; !ok {
+		return fmt.Errorf("%w: %s", domain.ErrSecretNotFound, name)
+	}
+
+	delete(secrets, name)
+
+	return f.save(secrets)
+}
+
+// load decrypts the secrets, returning none when there is no file yet.
+func (f *FileSecrets) load() (map[string]string, error) {
+	sealed, err := os.ReadFile(filepath.Join(f.dir, secretsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets: %w", err)
+	}
+
+	key, err := os.ReadFile(filepath.Join(f.dir, secretsKeyFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets key: %w", err)
+	}
+
+	aead, err := newSecretsCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrCorruptSecrets
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrCorruptSecrets
+	}
+
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptSecrets, err)
+	}
+
+	return secrets, nil
+}
+
+// save encrypts the secrets with a fresh nonce, creating the key first when
+// there is none.
+func (f *FileSecrets) save(secrets map[string]string) error {
+	if err := os.MkdirAll(f.dir, secretsDirPerm); err != nil {
+		return fmt.Errorf("failed to create secrets directory: %w", err)
+	}
+
+	key, err := f.key()
+	if err != nil {
+		return err
+	}
+
+	aead, err := newSecretsCipher(key)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("failed to encode secrets: %w", err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to create nonce: %w", err)
+	}
+
+	return writePrivateFile(filepath.Join(f.dir, secretsFile), aead.Seal(nonce, nonce, plaintext, nil))
+}
+
+// key returns the encryption key, generating it on first use.
+func (f *FileSecrets) key() ([]byte, error) {
+	path := filepath.Join(f.dir, secretsKeyFile)
+
+	key, err := os.ReadFile(path)
+	if err == nil {
+		return key, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read secrets key: %w", err)
+	}
+
+	key = make([]byte, secretsKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to create secrets key: %w", err)
+	}
+
+	if err := writePrivateFile(path, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// newSecretsCipher creates the AES-GCM cipher for key.
+func newSecretsCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secrets key: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// writePrivateFile replaces path with data readable by the user only, through
+// a temporary file so a failed write never loses the previous content.
+func writePrivateFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, secretsFilePerm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSecrets(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "secrets")
+	secrets := platform.NewFileSecrets(dir)
+
+	_, err := secrets.Get(domain.SecretGitHubToken)
+	require.ErrorIs(t, err, domain.ErrSecretNotFound)
+
+	require.NoError(t, secrets.Set(domain.SecretGitHubToken, "ghp_abc123"))
+	require.NoError(t, secrets.Set(domain.SecretWebhook, "https://hooks.example.com/x"))
+
+	// A new store reads what the first one wrote
+	value, err := platform.NewFileSecrets(dir).Get(domain.SecretGitHubToken)
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_abc123", value)
+
+	// Nothing is kept in plaintext, and only the user can read the files
+	sealed, err := os.ReadFile(filepath.Join(dir, "secrets.enc"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), "ghp_abc123")
+
+	info, err := os.Stat(filepath.Join(dir, "secrets.key"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	require.NoError(t, secrets.Delete(domain.SecretGitHubToken))
+	require.ErrorIs(t, secrets.Delete(domain.SecretGitHubToken), domain.ErrSecretNotFound)
+
+	value, err = secrets.Get(domain.SecretWebhook)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.example.com/x", value)
+
+	require.ErrorIs(t, secrets.Set("../token", "x"), domain.ErrInvalidSecretName)
+}
+
+func TestFileSecrets_WrongKey(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	secrets := platform.NewFileSecrets(dir)
+
+	require.NoError(t, secrets.Set("token", "value"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secrets.key"), make([]byte, 32), 0o600))
+
+	_, err := secrets.Get("token")
+	require.ErrorIs(t, err, platform.ErrCorruptSecrets)
+}
@@ -14,6 +14,12 @@ import (
 	"github.com/janderssonse/karei/internal/domain"
 )
 
+// Marker files podman and toolbox create inside containers.
+const (
+	containerEnvFile = "/run/.containerenv"
+	toolboxEnvFile   = "/run/.toolboxenv"
+)
+
 // SystemDetector implements the SystemDetector port for Linux systems.
 type SystemDetector struct {
 	commandRunner domain.CommandRunner
@@ -46,6 +52,7 @@ func (d *SystemDetector) DetectSystem(ctx context.Context) (*domain.SystemInfo,
 		Distribution:       distribution,
 		DesktopEnvironment: desktopEnv,
 		PackageManager:     packageManager,
+		Container:          d.DetectContainerSession(ctx),
 		Architecture:       runtime.GOARCH,
 		Kernel:             d.getKernelVersion(ctx),
 	}, nil
@@ -83,6 +90,7 @@ func (d *SystemDetector) DetectDesktopEnvironment(_ context.Context) (*domain.De
 		return &domain.DesktopEnvironment{
 			Name:    session,
 			Session: os.Getenv("XDG_SESSION_DESKTOP"),
+			Type:    sessionType(os.Getenv),
 		}, nil
 	}
 
@@ -90,12 +98,38 @@ func (d *SystemDetector) DetectDesktopEnvironment(_ context.Context) (*domain.De
 		return &domain.DesktopEnvironment{
 			Name:    session,
 			Session: session,
+			Type:    sessionType(os.Getenv),
 		}, nil
 	}
 
 	return nil, domain.ErrNoDesktopEnvironment
 }
 
+// DetectSessionType returns whether karei runs in a Wayland, X11 or text
+// session, which decides the form desktop settings take.
+func (d *SystemDetector) DetectSessionType(_ context.Context) domain.SessionType {
+	return sessionType(os.Getenv)
+}
+
+// sessionType reads the session type logind sets, or else infers it from the
+// display variables, which survive where logind's don't, such as under sudo.
+func sessionType(getenv func(string) string) domain.SessionType {
+	if session := domain.ParseSessionType(getenv("XDG_SESSION_TYPE")); session != domain.SessionUnknown {
+		return session
+	}
+
+	switch {
+	case getenv("WAYLAND_DISPLAY") != "":
+		return domain.SessionWayland
+	case getenv("DISPLAY") != "":
+		return domain.SessionX11
+	case getenv("SSH_CONNECTION") != "":
+		return domain.SessionTTY
+	default:
+		return domain.SessionUnknown
+	}
+}
+
 // DetectPackageManager returns the primary package manager for this system.
 func (d *SystemDetector) DetectPackageManager(_ context.Context) (*domain.PackageManager, error) {
 	// Check for various package managers in order of preference
@@ -124,6 +158,54 @@ func (d *SystemDetector) DetectPackageManager(_ context.Context) (*domain.Packag
 	return nil, domain.ErrNoPackageManager
 }
 
+// DetectAPTFrontend returns the APT frontend to install through: the
+// configured one when installed, for auto the first of nala and apt-fast
+// installed, and apt otherwise.
+func (d *SystemDetector) DetectAPTFrontend(configured domain.APTFrontend) domain.APTFrontend {
+	candidates := domain.APTFrontendCandidates
+	if configured != domain.APTFrontendAuto {
+		candidates = []domain.APTFrontend{configured}
+	}
+
+	for _, frontend := range candidates {
+		if frontend != domain.APTFrontendAPT && d.commandRunner.CommandExists(frontend.Command()) {
+			return frontend
+		}
+	}
+
+	return domain.APTFrontendAPT
+}
+
+// DetectContainerSession returns the toolbox or distrobox container karei runs in, or nil on the host.
+func (d *SystemDetector) DetectContainerSession(_ context.Context) *domain.ContainerSession {
+	// distrobox exports CONTAINER_ID; toolbox marks its containers with /run/.toolboxenv
+	if name := os.Getenv("CONTAINER_ID"); name != "" && d.fileManager.FileExists(containerEnvFile) {
+		return &domain.ContainerSession{Kind: domain.ContainerDistrobox, Name: name}
+	}
+
+	if d.fileManager.FileExists(toolboxEnvFile) {
+		return &domain.ContainerSession{Kind: domain.ContainerToolbox, Name: d.containerName()}
+	}
+
+	return nil
+}
+
+// containerName reads the container name podman records in /run/.containerenv.
+func (d *SystemDetector) containerName() string {
+	data, err := d.fileManager.ReadFile(containerEnvFile)
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, found := strings.CutPrefix(line, "name="); found {
+			return strings.Trim(value, `"`)
+		}
+	}
+
+	return ""
+}
+
 // Helper methods
 
 func (d *SystemDetector) parseOSRelease(content string) *domain.Distribution {
@@ -465,3 +465,40 @@ NAME="Alpine Linux"
 		}
 	})
 }
+
+func TestSessionType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want domain.SessionType
+	}{
+		{"logind wayland", map[string]string{"XDG_SESSION_TYPE": "wayland", "DISPLAY": ":0"}, domain.SessionWayland},
+		{"logind x11", map[string]string{"XDG_SESSION_TYPE": "x11"}, domain.SessionX11},
+		{"logind tty over ssh -X", map[string]string{"XDG_SESSION_TYPE": "tty", "DISPLAY": "localhost:10.0"}, domain.SessionTTY},
+		{"wayland display under sudo", map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, domain.SessionWayland},
+		{"x11 display", map[string]string{"DISPLAY": ":1"}, domain.SessionX11},
+		{"ssh", map[string]string{"SSH_CONNECTION": "10.0.0.2 51234 10.0.0.1 22"}, domain.SessionTTY},
+		{"nothing", map[string]string{}, domain.SessionUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, sessionType(func(key string) string { return tt.env[key] }))
+		})
+	}
+}
+
+func TestSystemDetector_DetectAPTFrontend(t *testing.T) {
+	t.Parallel()
+
+	// The mock runner finds every command
+	detector := NewSystemDetector(NewMockCommandRunner(false), NewMockFileManager(false))
+
+	assert.Equal(t, domain.APTFrontendNala, detector.DetectAPTFrontend(domain.APTFrontendAuto))
+	assert.Equal(t, domain.APTFrontendAptFast, detector.DetectAPTFrontend(domain.APTFrontendAptFast))
+	assert.Equal(t, domain.APTFrontendAPT, detector.DetectAPTFrontend(domain.APTFrontendAPT))
+}
@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+const (
+	trashIndexFile = "index.json"
+	trashIDLayout  = "20060102-150405"
+	trashDirPerm   = 0o700
+)
+
+// Trash implements the Trash port with a directory per entry, named by the
+// time it was created and holding the trashed files next to an index.json
+// recording where they came from.
+type Trash struct {
+	dir string
+}
+
+// NewTrash creates a trash keeping its entries below dir.
+func NewTrash(dir string) *Trash {
+	return &Trash{dir: dir}
+}
+
+// Move moves paths into a new entry for app. Paths that don't exist are
+// skipped; when nothing is left no entry is created and nil is returned.
+func (t *Trash) Move(app string, paths []string) (*domain.TrashEntry, error) {
+	var existing []string
+
+	for _, path := range paths {
+		if _, err := os.Lstat(path); err == nil {
+			existing = append(existing, path)
+		}
+	}
+
+	if len(existing) == 0 {
+		return nil, nil //nolint:nilnil // Nothing to trash is not an error
+	}
+
+	entry := &domain.TrashEntry{App: app, Trashed: time.Now()}
+
+	entryDir, err := t.createEntryDir(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	var moveErr error
+
+	for i, path := range existing {
+		name := strconv.Itoa(i) + "-" + filepath.Base(path)
+		if err := os.Rename(path, filepath.Join(entryDir, name)); err != nil {
+			moveErr = fmt.Errorf("failed to move %s to the trash: %w", path, err)
+
+			break
+		}
+
+		entry.Items = append(entry.Items, domain.TrashItem{Original: path, Name: name})
+	}
+
+	// Record what was moved even after a failure, so it can still be restored
+	if err := t.writeIndex(entryDir, entry); err != nil {
+		return entry, errors.Join(moveErr, err)
+	}
+
+	return entry, moveErr
+}
+
+// List returns the entries, oldest first. Directories without a readable
+// index are skipped.
+func (t *Trash) List() ([]domain.TrashEntry, error) {
+	dirs, err := os.ReadDir(t.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash: %w", err)
+	}
+
+	var entries []domain.TrashEntry
+
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		entry, err := t.readIndex(dir.Name())
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, *entry)
+	}
+
+	slices.SortFunc(entries, func(a, b domain.TrashEntry) int {
+		return a.Trashed.Compare(b.Trashed)
+	})
+
+	return entries, nil
+}
+
+// Restore moves the files of an entry back and deletes the entry. Nothing is
+// moved when any original location is taken again.
+func (t *Trash) Restore(id string) (*domain.TrashEntry, error) {
+	entry, err := t.readIndex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range entry.Items {
+		if _, err := os.Lstat(item.Original); err == nil {
+			return nil, fmt.Errorf("%w: %s", domain.ErrRestoreConflict, item.Original)
+		}
+	}
+
+	entryDir := filepath.Join(t.dir, id)
+
+	for _, item := range entry.Items {
+		if err := os.MkdirAll(filepath.Dir(item.Original), trashDirPerm); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(item.Original), err)
+		}
+
+		if err := os.Rename(filepath.Join(entryDir, item.Name), item.Original); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", item.Original, err)
+		}
+	}
+
+	if err := os.RemoveAll(entryDir); err != nil {
+		return entry, fmt.Errorf("failed to remove trash entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// Empty permanently deletes all entries.
+func (t *Trash) Empty() error {
+	if err := os.RemoveAll(t.dir); err != nil {
+		return fmt.Errorf("failed to empty trash: %w", err)
+	}
+
+	return nil
+}
+
+// createEntryDir creates the directory of a new entry and sets its ID,
+// adding a counter when another entry was created the same second.
+func (t *Trash) createEntryDir(entry *domain.TrashEntry) (string, error) {
+	if err := os.MkdirAll(t.dir, trashDirPerm); err != nil {
+		return "", fmt.Errorf("failed to create trash: %w", err)
+	}
+
+	base := entry.Trashed.Format(trashIDLayout)
+
+	for attempt := 1; ; attempt++ {
+		entry.ID = base
+		if attempt > 1 {
+			entry.ID += "-" + strconv.Itoa(attempt)
+		}
+
+		entryDir := filepath.Join(t.dir, entry.ID)
+
+		err := os.Mkdir(entryDir, trashDirPerm)
+		if err == nil {
+			return entryDir, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return "", fmt.Errorf("failed to create trash entry: %w", err)
+		}
+	}
+}
+
+func (t *Trash) readIndex(id string) (*domain.TrashEntry, error) {
+	if id == "" || filepath.Base(id) != id {
+		return nil, fmt.Errorf("%w: %s", domain.ErrTrashEntryNotFound, id)
+	}
+
+	data, err := os.ReadFile(filepath.Join(t.dir, id, trashIndexFile)) //nolint:gosec // ID is checked to be a single path element
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrTrashEntryNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash entry %s: %w", id, err)
+	}
+
+	var entry domain.TrashEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse trash entry %s: %w", id, err)
+	}
+
+	return &entry, nil
+}
+
+func (t *Trash) writeIndex(entryDir string, entry *domain.TrashEntry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode trash index: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(entryDir, trashIndexFile), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write trash index: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrash_MoveAndRestore(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	trash := platform.NewTrash(filepath.Join(root, "trash"))
+
+	config := filepath.Join(root, ".config", "nvim")
+	require.NoError(t, os.MkdirAll(config, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(config, "init.lua"), []byte("vim.o.number = true"), 0o600))
+
+	entry, err := trash.Move("neovim", []string{config, filepath.Join(root, ".cache", "nvim")})
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "neovim", entry.App)
+	assert.Equal(t, []domain.TrashItem{{Original: config, Name: "0-nvim"}}, entry.Items)
+	assert.NoDirExists(t, config)
+
+	// A second entry in the same second gets its own directory
+	other := filepath.Join(root, "other.conf")
+	require.NoError(t, os.WriteFile(other, nil, 0o600))
+
+	second, err := trash.Move("other", []string{other})
+	require.NoError(t, err)
+	assert.NotEqual(t, entry.ID, second.ID)
+
+	entries, err := trash.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, entry.ID, entries[0].ID)
+
+	restored, err := trash.Restore(entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "neovim", restored.App)
+
+	data, err := os.ReadFile(filepath.Join(config, "init.lua"))
+	require.NoError(t, err)
+	assert.Equal(t, "vim.o.number = true", string(data))
+
+	entries, err = trash.List()
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	require.NoError(t, trash.Empty())
+
+	entries, err = trash.List()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestTrash_RestoreErrors(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	trash := platform.NewTrash(filepath.Join(root, "trash"))
+
+	path := filepath.Join(root, "app.conf")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o600))
+
+	entry, err := trash.Move("app", []string{path})
+	require.NoError(t, err)
+
+	// The app was reinstalled and wrote a fresh config
+	require.NoError(t, os.WriteFile(path, []byte("new"), 0o600))
+
+	_, err = trash.Restore(entry.ID)
+	require.ErrorIs(t, err, domain.ErrRestoreConflict)
+
+	_, err = trash.Restore("missing")
+	require.ErrorIs(t, err, domain.ErrTrashEntryNotFound)
+
+	_, err = trash.Restore("../" + entry.ID)
+	require.ErrorIs(t, err, domain.ErrTrashEntryNotFound)
+
+	entry, err = trash.Move("app", []string{filepath.Join(root, "missing")})
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// Version sources understood by the resolver.
+const (
+	versionSourceAPT     = "apt"
+	versionSourceDEB     = "deb"
+	versionSourceFlatpak = "flatpak"
+	versionSourceSnap    = "snap"
+	versionSourceMise    = "mise"
+)
+
+// VersionResolver implements the VersionResolver port by querying package managers.
+type VersionResolver struct {
+	commandRunner domain.CommandRunner
+}
+
+// NewVersionResolver creates a resolver that runs queries through the given command runner.
+func NewVersionResolver(commandRunner domain.CommandRunner) *VersionResolver {
+	return &VersionResolver{commandRunner: commandRunner}
+}
+
+// ResolveVersion returns the installed version of name as reported by source.
+func (r *VersionResolver) ResolveVersion(ctx context.Context, name, source string) (string, error) {
+	query := versionQuery(name, source)
+
+	output, err := r.commandRunner.ExecuteWithOutput(ctx, "sh", "-c", query)
+	if err != nil {
+		return "", err
+	}
+
+	version := strings.TrimSpace(output)
+
+	// Strip the debian epoch (epoch:version-revision) and long revisions
+	if source == versionSourceAPT || source == versionSourceDEB {
+		if idx := strings.Index(version, ":"); idx != -1 {
+			version = version[idx+1:]
+		}
+
+		if idx := strings.Index(version, "-"); idx != -1 && idx > 5 {
+			version = version[:idx]
+		}
+	}
+
+	return version, nil
+}
+
+// versionQuery returns the shell pipeline that prints the installed version.
+// This is designed to be extensible and handle various package manager quirks.
+func versionQuery(name, source string) string {
+	normalizedName := strings.ToLower(name)
+
+	if source == versionSourceMise {
+		return miseVersionQuery(normalizedName)
+	}
+
+	queries := map[string]string{
+		versionSourceAPT:     fmt.Sprintf("dpkg -s %s 2>/dev/null | grep '^Version:' | cut -d' ' -f2", name),
+		versionSourceDEB:     fmt.Sprintf("dpkg -s %s 2>/dev/null | grep '^Version:' | cut -d' ' -f2", name),
+		versionSourceFlatpak: fmt.Sprintf("flatpak info %s 2>/dev/null | grep 'Version:' | awk '{print $2}'", name),
+		versionSourceSnap:    fmt.Sprintf("snap info %s 2>/dev/null | grep 'installed:' | awk '{print $2}'", normalizedName),
+		"aqua":               fmt.Sprintf("aqua list 2>/dev/null | grep -i '^%s\\s' | awk '{print $2}'", normalizedName),
+		"cargo":              fmt.Sprintf("cargo install --list | grep -E '^%s\\s' | awk '{print $2}' | tr -d '()'", normalizedName),
+		"npm":                fmt.Sprintf("npm list -g %s 2>/dev/null | grep %s@ | sed 's/.*@//'", normalizedName, normalizedName),
+		"yarn":               fmt.Sprintf("npm list -g %s 2>/dev/null | grep %s@ | sed 's/.*@//'", normalizedName, normalizedName),
+		"pnpm":               fmt.Sprintf("npm list -g %s 2>/dev/null | grep %s@ | sed 's/.*@//'", normalizedName, normalizedName),
+		"pip":                fmt.Sprintf("pip show %s 2>/dev/null | grep '^Version:' | awk '{print $2}'", normalizedName),
+		"pipx":               fmt.Sprintf("pip show %s 2>/dev/null | grep '^Version:' | awk '{print $2}'", normalizedName),
+	}
+
+	if query, exists := queries[source]; exists {
+		return query
+	}
+
+	// Default: try common version flags
+	return fmt.Sprintf("which %s > /dev/null 2>&1 && %s --version 2>/dev/null | head -1", normalizedName, normalizedName)
+}
+
+// miseVersionQuery returns the version query for mise-managed tools.
+func miseVersionQuery(normalizedName string) string {
+	if normalizedName == versionSourceMise {
+		// Special case: mise itself uses --version
+		return "which mise > /dev/null 2>&1 && mise --version 2>/dev/null | head -1 | cut -d' ' -f1"
+	}
+	// Tools can appear as "toolname", "aqua:org/toolname", "aqua:toolname"
+	return fmt.Sprintf("mise list 2>/dev/null | awk 'tolower($1) ~ /(^|[:/])%s$/ {print $2; exit}'", normalizedName)
+}
+
+// MockVersionResolver implements the VersionResolver port with fixed versions.
+type MockVersionResolver struct {
+	versions map[string]string // name -> version
+}
+
+// NewMockVersionResolver creates a resolver that answers from the given map.
+func NewMockVersionResolver(versions map[string]string) *MockVersionResolver {
+	if versions == nil {
+		versions = make(map[string]string)
+	}
+
+	return &MockVersionResolver{versions: versions}
+}
+
+// ResolveVersion returns the preset version, or an empty string if unknown.
+func (r *MockVersionResolver) ResolveVersion(_ context.Context, name, _ string) (string, error) {
+	return r.versions[name], nil
+}
+
+// MockInstallationChecker implements the InstallationChecker port with a fixed set.
+type MockInstallationChecker struct {
+	installed map[string]bool
+}
+
+// NewMockInstallationChecker creates a checker reporting the given apps as installed.
+func NewMockInstallationChecker(installed ...string) *MockInstallationChecker {
+	set := make(map[string]bool, len(installed))
+	for _, name := range installed {
+		set[name] = true
+	}
+
+	return &MockInstallationChecker{installed: set}
+}
+
+// IsInstalled reports whether appKey was registered as installed.
+func (c *MockInstallationChecker) IsInstalled(_ context.Context, appKey string) bool {
+	return c.installed[appKey]
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package platform_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// queryRunner answers "sh -c" queries by matching a substring of the pipeline.
+type queryRunner struct {
+	platform.MockCommandRunner
+
+	answers map[string]string
+}
+
+func (r *queryRunner) ExecuteWithOutput(_ context.Context, _ string, args ...string) (string, error) {
+	query := strings.Join(args, " ")
+	for needle, answer := range r.answers {
+		if strings.Contains(query, needle) {
+			return answer, nil
+		}
+	}
+
+	return "", nil
+}
+
+func TestVersionResolver_ResolveVersion(t *testing.T) {
+	t.Parallel()
+
+	runner := &queryRunner{answers: map[string]string{
+		"dpkg -s git":        "1:2.43.0-1ubuntu7\n",
+		"flatpak info org.x": "3.1.4\n",
+		"mise list":          "22.1.0\n",
+	}}
+	resolver := platform.NewVersionResolver(runner)
+
+	tests := []struct {
+		name, app, source, want string
+	}{
+		{"apt strips epoch and revision", "git", "apt", "2.43.0"},
+		{"flatpak by application id", "org.x", "flatpak", "3.1.4"},
+		{"mise tool", "node", "mise", "22.1.0"},
+		{"unknown returns empty", "nothing", "snap", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := resolver.ResolveVersion(context.Background(), tt.app, tt.source)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMockInstallationChecker(t *testing.T) {
+	t.Parallel()
+
+	checker := platform.NewMockInstallationChecker("git", "vim")
+
+	assert.True(t, checker.IsInstalled(context.Background(), "git"))
+	assert.False(t, checker.IsInstalled(context.Background(), "emacs"))
+}
@@ -0,0 +1,5 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+// Package remote runs karei operations on other machines over SSH.
+package remote
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// uploadPath is where a copied binary lives on the remote host, relative to its home directory.
+const uploadPath = ".cache/karei/bin/karei"
+
+var (
+	// ErrNoHost is returned when no remote host is given.
+	ErrNoHost = errors.New("remote host is required")
+	// ErrInvalidHost is returned for a host ssh would read as an option.
+	ErrInvalidHost = errors.New("remote host must not start with '-'")
+)
+
+// Options control how karei is run on the remote host.
+type Options struct {
+	Host    string   // SSH destination, e.g. user@box
+	Binary  string   // Local karei binary to upload; defaults to the running executable
+	Copy    bool     // Upload the binary even if karei is already installed remotely
+	TTY     bool     // Allocate a terminal so sudo can prompt on the remote side
+	SSHArgs []string // Extra arguments passed to ssh and scp, e.g. -p 2222
+}
+
+// Executor runs karei over SSH, streaming the remote output to the local writers.
+type Executor struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// NewExecutor creates an executor that streams output to the given writers.
+func NewExecutor(stdin io.Reader, stdout, stderr io.Writer) *Executor {
+	return &Executor{stdin: stdin, stdout: stdout, stderr: stderr}
+}
+
+// Run executes karei with args on the remote host. If karei is not installed
+// there, or Copy is set, the local binary is uploaded first. The returned error
+// wraps *exec.ExitError when the remote command fails, so callers can recover
+// the remote exit code.
+func (e *Executor) Run(ctx context.Context, opts Options, args []string) error {
+	if opts.Host == "" {
+		return ErrNoHost
+	}
+
+	// A host like -oProxyCommand=... would run a local command
+	if strings.HasPrefix(opts.Host, "-") {
+		return fmt.Errorf("%w: %s", ErrInvalidHost, opts.Host)
+	}
+
+	binary, found := e.findKarei(ctx, opts)
+
+	if opts.Copy || !found {
+		uploaded, err := e.upload(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		binary = uploaded
+	}
+
+	cmd := exec.CommandContext(ctx, "ssh", sshArgs(opts, opts.TTY, remoteCommand(binary, args))...) // #nosec G204 - arguments are quoted for the remote shell
+	cmd.Stdin = e.stdin
+	cmd.Stdout = e.stdout
+	cmd.Stderr = e.stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("remote karei on %s failed: %w", opts.Host, err)
+	}
+
+	return nil
+}
+
+// findKarei returns the karei binary to run remotely: one on the PATH, or a
+// previously uploaded copy.
+func (e *Executor) findKarei(ctx context.Context, opts Options) (string, bool) {
+	candidates := []struct{ check, binary string }{
+		{"command -v karei", "karei"},
+		{"test -x " + shellQuote(uploadPath), uploadPath},
+	}
+
+	for _, candidate := range candidates {
+		cmd := exec.CommandContext(ctx, "ssh", sshArgs(opts, false, candidate.check)...) // #nosec G204 - fixed remote command
+		if cmd.Run() == nil {
+			return candidate.binary, true
+		}
+	}
+
+	return "", false
+}
+
+// upload copies the local binary to the remote host and returns its remote path.
+func (e *Executor) upload(ctx context.Context, opts Options) (string, error) {
+	binary := opts.Binary
+	if binary == "" {
+		executable, err := os.Executable()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate local karei binary: %w", err)
+		}
+
+		binary = executable
+	}
+
+	fmt.Fprintf(e.stderr, "Copying %s to %s:%s\n", binary, opts.Host, uploadPath)
+
+	mkdir := exec.CommandContext(ctx, "ssh", sshArgs(opts, false, "mkdir -p "+shellQuote(dirOf(uploadPath)))...) // #nosec G204 - fixed remote command
+	mkdir.Stderr = e.stderr
+
+	if err := mkdir.Run(); err != nil {
+		return "", fmt.Errorf("failed to prepare %s: %w", opts.Host, err)
+	}
+
+	scp := exec.CommandContext(ctx, "scp", scpArgs(opts, binary)...) // #nosec G204 - destination is built from user-provided host
+	scp.Stderr = e.stderr
+
+	if err := scp.Run(); err != nil {
+		return "", fmt.Errorf("failed to copy karei to %s: %w", opts.Host, err)
+	}
+
+	return uploadPath, nil
+}
+
+// sshArgs builds the ssh argument list for running remoteCmd on the host.
+// Options end before the host, so it is never read as one.
+func sshArgs(opts Options, tty bool, remoteCmd string) []string {
+	args := []string{"-o", "BatchMode=yes"}
+	if tty {
+		// Interactive sudo prompts need a terminal, which rules out BatchMode
+		args = []string{"-t"}
+	}
+
+	args = append(args, opts.SSHArgs...)
+
+	return append(args, "--", opts.Host, remoteCmd)
+}
+
+// scpArgs builds the scp argument list for uploading binary to the host.
+func scpArgs(opts Options, binary string) []string {
+	args := append([]string{"-q", "-p"}, scpOptions(opts.SSHArgs)...)
+
+	return append(args, "--", binary, opts.Host+":"+uploadPath)
+}
+
+// scpOptions translates ssh options for scp, which takes the port as -P
+// since its -p preserves file times. Other options are shared by both.
+func scpOptions(sshOptions []string) []string {
+	options := make([]string, 0, len(sshOptions))
+
+	for _, option := range sshOptions {
+		if strings.HasPrefix(option, "-p") {
+			option = "-P" + strings.TrimPrefix(option, "-p")
+		}
+
+		options = append(options, option)
+	}
+
+	return options
+}
+
+// remoteCommand quotes binary and args into a single command for the remote shell.
+func remoteCommand(binary string, args []string) string {
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, shellQuote(binary))
+
+	for _, arg := range args {
+		quoted = append(quoted, shellQuote(arg))
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote quotes s for POSIX shells unless it only contains safe characters.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,@+") == "" {
+		return s
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func dirOf(path string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[:i]
+	}
+
+	return "."
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package remote
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShellQuote(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "install", shellQuote("install"))
+	assert.Equal(t, "--group=development", shellQuote("--group=development"))
+	assert.Equal(t, "''", shellQuote(""))
+	assert.Equal(t, "'a b'", shellQuote("a b"))
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+	assert.Equal(t, "'$(reboot)'", shellQuote("$(reboot)"))
+}
+
+func TestRemoteCommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := remoteCommand(uploadPath, []string{"install", "--group", "development"})
+	assert.Equal(t, ".cache/karei/bin/karei install --group development", cmd)
+}
+
+func TestSSHArgs(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{Host: "user@box", SSHArgs: []string{"-p", "2222"}}
+
+	assert.Equal(t,
+		[]string{"-o", "BatchMode=yes", "-p", "2222", "--", "user@box", "karei status"},
+		sshArgs(opts, false, "karei status"))
+	assert.Equal(t,
+		[]string{"-t", "-p", "2222", "--", "user@box", "karei status"},
+		sshArgs(opts, true, "karei status"))
+	assert.Equal(t,
+		[]string{"-q", "-p", "-P", "2222", "--", "/usr/bin/karei", "user@box:" + uploadPath},
+		scpArgs(opts, "/usr/bin/karei"))
+}
+
+func TestSCPArgsTranslatesPort(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{Host: "user@box", SSHArgs: []string{"-p2222", "-i", "~/.ssh/lab", "-o", "Port=2222"}}
+
+	assert.Equal(t,
+		[]string{"-q", "-p", "-P2222", "-i", "~/.ssh/lab", "-o", "Port=2222", "--", "/usr/bin/karei", "user@box:" + uploadPath},
+		scpArgs(opts, "/usr/bin/karei"))
+}
+
+func TestExecutor_RequiresHost(t *testing.T) {
+	t.Parallel()
+
+	err := NewExecutor(nil, nil, nil).Run(context.Background(), Options{}, []string{"status"})
+	require.ErrorIs(t, err, ErrNoHost)
+}
+
+func TestExecutor_RejectsOptionHost(t *testing.T) {
+	t.Parallel()
+
+	err := NewExecutor(nil, nil, nil).Run(context.Background(), Options{Host: "-oProxyCommand=touch /tmp/pwned"}, []string{"status"})
+	require.ErrorIs(t, err, ErrInvalidHost)
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package system
+
+import (
+	"syscall"
+)
+
+// FreeDiskSpace returns the bytes available to unprivileged users, summed over
+// the distinct filesystems holding paths. Paths that cannot be read are skipped.
+func FreeDiskSpace(paths ...string) uint64 {
+	seen := make(map[uint64]bool)
+
+	var total uint64
+
+	for _, path := range paths {
+		var info syscall.Stat_t
+		if err := syscall.Stat(path, &info); err != nil || seen[info.Dev] {
+			continue
+		}
+
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(path, &stat); err != nil {
+			continue
+		}
+
+		seen[info.Dev] = true
+		total += stat.Bavail * uint64(stat.Bsize) //nolint:gosec // Block size is always positive
+	}
+
+	return total
+}
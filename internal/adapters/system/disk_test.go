@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeDiskSpaceCountsFilesystemsOnce(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	single := FreeDiskSpace(dir)
+
+	assert.Positive(t, single)
+	assert.InDelta(t, float64(single), float64(FreeDiskSpace(dir, dir)), float64(single)/100)
+	assert.Zero(t, FreeDiskSpace("/no/such/path"))
+}
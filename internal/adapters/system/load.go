@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package system
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// loadAvgFile holds the load averages on Linux.
+const loadAvgFile = "/proc/loadavg"
+
+// LoadPerCPU returns the one-minute load average divided by the number of
+// CPUs, so 1 means every CPU is busy. It reports false where the load can't
+// be read.
+func LoadPerCPU() (float64, bool) {
+	data, err := os.ReadFile(loadAvgFile)
+	if err != nil {
+		return 0, false
+	}
+
+	return parseLoadAvg(string(data), runtime.NumCPU())
+}
+
+// parseLoadAvg reads the one-minute average from /proc/loadavg content.
+func parseLoadAvg(content string, cpus int) (float64, bool) {
+	fields := strings.Fields(content)
+	if len(fields) == 0 || cpus < 1 {
+		return 0, false
+	}
+
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return load / float64(cpus), true
+}
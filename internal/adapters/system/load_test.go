@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLoadAvg(t *testing.T) {
+	t.Parallel()
+
+	load, ok := parseLoadAvg("3.00 1.50 0.75 2/812 41234\n", 4)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.75, load, 0.001)
+
+	_, ok = parseLoadAvg("", 4)
+	assert.False(t, ok)
+
+	_, ok = parseLoadAvg("busy", 4)
+	assert.False(t, ok)
+}
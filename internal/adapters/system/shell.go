@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package system
+
+import (
+	"os"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// LoginShell returns the user's login shell from $SHELL, reporting false
+// when it is unset or a shell karei doesn't know.
+func LoginShell() (domain.Shell, bool) {
+	return domain.ShellFromPath(os.Getenv("SHELL"))
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// pamSudoConfigs lists the PAM stacks consulted when sudo authenticates.
+var pamSudoConfigs = []string{"/etc/pam.d/sudo", "/etc/pam.d/common-auth"} //nolint:gochecknoglobals // Read-only PAM lookup paths
+
+// SudoWithoutPassword reports whether sudoers lets the user run any command
+// as root without a password, through NOPASSWD or !authenticate. Cached
+// credentials don't count: they may expire before the commands run.
+func SudoWithoutPassword(ctx context.Context) bool {
+	cmd := exec.CommandContext(ctx, "sudo", "-n", "-l")
+	cmd.Env = append(os.Environ(), "LC_ALL=C") // The section headings are translated
+
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+
+	return sudoListAllowsNoPassword(string(output))
+}
+
+// sudoListAllowsNoPassword reads the output of `sudo -l`. The last entry
+// granting every command as root decides, as in sudoers; without one,
+// only Defaults turning authentication off lets sudo skip the password.
+func sudoListAllowsNoPassword(output string) bool {
+	var (
+		inDefaults    bool
+		authenticate  = true
+		coversAll     bool
+		allNoPassword bool
+	)
+
+	for line := range strings.SplitSeq(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "Matching Defaults entries"):
+			inDefaults = true
+		case trimmed == "":
+			inDefaults = false
+		case inDefaults:
+			for option := range strings.SplitSeq(trimmed, ",") {
+				if strings.TrimSpace(option) == "!authenticate" {
+					authenticate = false
+				}
+			}
+		case strings.HasPrefix(trimmed, "("):
+			noPassword, ok := parseSudoEntry(trimmed)
+			if ok {
+				coversAll = true
+				allNoPassword = noPassword
+			}
+		}
+	}
+
+	if coversAll {
+		return allNoPassword || !authenticate
+	}
+
+	return false
+}
+
+// parseSudoEntry reads an entry such as "(ALL : ALL) NOPASSWD: ALL". ok
+// reports whether it grants every command as root.
+func parseSudoEntry(entry string) (noPassword, ok bool) {
+	runAs, rest, found := strings.Cut(strings.TrimPrefix(entry, "("), ")")
+	if !found {
+		return false, false
+	}
+
+	users, _, _ := strings.Cut(runAs, ":")
+	if !slices.ContainsFunc(strings.Split(users, ","), func(user string) bool {
+		user = strings.TrimSpace(user)
+
+		return user == "ALL" || user == "root"
+	}) {
+		return false, false
+	}
+
+	fields := strings.Fields(rest)
+
+	// Tags, such as NOPASSWD: or SETENV:, come before the commands
+	for len(fields) > 0 && strings.HasSuffix(fields[0], ":") {
+		switch fields[0] {
+		case "NOPASSWD:":
+			noPassword = true
+		case "PASSWD:":
+			noPassword = false
+		}
+
+		fields = fields[1:]
+	}
+
+	return noPassword, len(fields) == 1 && fields[0] == "ALL"
+}
+
+// PolkitAvailable reports whether privileged commands can go through
+// pkexec, with the desktop's polkit agent asking for authentication.
+func PolkitAvailable() bool {
+	if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return false
+	}
+
+	return CommandExists("pkexec")
+}
+
+// PolkitAuthenticate asks the desktop's polkit agent to authenticate the
+// user as an administrator. pkexec's own terminal agent is never used.
+func PolkitAuthenticate(ctx context.Context) error {
+	if err := exec.CommandContext(ctx, "pkexec", "--disable-internal-agent", "true").Run(); err != nil {
+		return fmt.Errorf("polkit authentication failed: %w", err)
+	}
+
+	return nil
+}
+
+// SudoFingerprintEnabled reports whether sudo's PAM stack accepts fingerprint
+// authentication through pam_fprintd.
+func SudoFingerprintEnabled() bool {
+	return pamUsesModule("pam_fprintd", pamSudoConfigs...)
+}
+
+// pamUsesModule reports whether any of the PAM config files loads the module.
+func pamUsesModule(module string, paths ...string) bool {
+	for _, path := range paths {
+		data, err := os.ReadFile(path) //nolint:gosec // G304: Fixed system PAM paths
+		if err != nil {
+			continue
+		}
+
+		for line := range strings.SplitSeq(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			if strings.Contains(line, module) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
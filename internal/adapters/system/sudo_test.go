@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPamUsesModule(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	enabled := filepath.Join(dir, "common-auth")
+	disabled := filepath.Join(dir, "sudo")
+
+	require.NoError(t, os.WriteFile(enabled, []byte("auth [success=2 default=ignore] pam_fprintd.so max-tries=1\n"), 0o600))
+	require.NoError(t, os.WriteFile(disabled, []byte("# auth sufficient pam_fprintd.so\n@include common-auth\n"), 0o600))
+
+	assert.True(t, pamUsesModule("pam_fprintd", disabled, enabled))
+	assert.False(t, pamUsesModule("pam_fprintd", disabled))
+	assert.False(t, pamUsesModule("pam_fprintd", filepath.Join(dir, "missing")))
+}
+
+func TestSudoListAllowsNoPassword(t *testing.T) {
+	t.Parallel()
+
+	const defaults = "Matching Defaults entries for alice on laptop:\n    env_reset, mail_badpass\n\n" +
+		"User alice may run the following commands on laptop:\n"
+
+	tests := []struct {
+		name    string
+		entries string
+		want    bool
+	}{
+		{"password required", "    (ALL : ALL) ALL\n", false},
+		{"nopasswd for everything", "    (ALL : ALL) ALL\n    (ALL) NOPASSWD: ALL\n", true},
+		{"nopasswd with other tags", "    (root) SETENV: NOPASSWD: ALL\n", true},
+		{"nopasswd overridden by a later rule", "    (ALL) NOPASSWD: ALL\n    (ALL : ALL) ALL\n", false},
+		{"nopasswd for some commands only", "    (ALL : ALL) ALL\n    (root) NOPASSWD: /usr/bin/apt\n", false},
+		{"nopasswd as another user", "    (postgres) NOPASSWD: ALL\n", false},
+		{"no entries", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, sudoListAllowsNoPassword(defaults+tt.entries))
+		})
+	}
+
+	assert.True(t, sudoListAllowsNoPassword("Matching Defaults entries for alice on laptop:\n    env_reset, !authenticate\n\n"+
+		"User alice may run the following commands on laptop:\n    (ALL : ALL) ALL\n"), "authentication turned off")
+}
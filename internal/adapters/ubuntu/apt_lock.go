@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package ubuntu
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// DefaultAPTLockTimeout is how long installs wait for another apt or dpkg
+// process, typically unattended-upgrades, to release the dpkg lock.
+const DefaultAPTLockTimeout = 2 * time.Minute
+
+// aptLockPollInterval is how often the lock holder is re-checked while waiting.
+const aptLockPollInterval = 2 * time.Second
+
+// aptLockFiles are the locks apt and dpkg take: the frontend lock apt holds
+// for a whole run, and dpkg's own lock.
+var aptLockFiles = []string{"/var/lib/dpkg/lock-frontend", "/var/lib/dpkg/lock"} //nolint:gochecknoglobals
+
+// LockHolder is a process holding the apt or dpkg lock.
+type LockHolder struct {
+	PID  int
+	Name string
+}
+
+// String formats the holder as "name (PID n)".
+func (h LockHolder) String() string {
+	return fmt.Sprintf("%s (PID %d)", h.Name, h.PID)
+}
+
+// SetLockTimeout sets how long to wait for the dpkg lock. Zero restores the default.
+func (p *PackageInstaller) SetLockTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultAPTLockTimeout
+	}
+
+	p.lockTimeout = timeout
+}
+
+// aptLockHolders lists the processes holding the apt or dpkg lock, whatever
+// they are, packagekitd included. lslocks reads the kernel's lock table,
+// which works without root, unlike fuser or opening the root-owned lock
+// files; ps only names the holders.
+func (p *PackageInstaller) aptLockHolders(ctx context.Context) []LockHolder {
+	locks, err := p.commandRunner.ExecuteWithOutput(ctx, "lslocks", "--noheadings", "--raw", "--output", "PID,INODE")
+	if err != nil || strings.TrimSpace(locks) == "" {
+		return nil
+	}
+
+	pids := lockingPIDs(locks, p.aptLockInodes(ctx))
+	if len(pids) == 0 {
+		return nil
+	}
+
+	args := make([]string, 0, len(pids))
+	for _, pid := range pids {
+		args = append(args, strconv.Itoa(pid))
+	}
+
+	// ps fails when a holder has exited since; the rest are still named
+	processes, _ := p.commandRunner.ExecuteWithOutput(ctx, "ps", "-o", "pid=,comm=,args=", "-p", strings.Join(args, ","))
+
+	return nameLockHolders(pids, processes)
+}
+
+// aptLockInodes returns the inodes of the lock files that exist.
+func (p *PackageInstaller) aptLockInodes(ctx context.Context) []string {
+	var inodes []string
+
+	for _, path := range aptLockFiles {
+		output, err := p.commandRunner.ExecuteWithOutput(ctx, "stat", "-c", "%i", path)
+		if inode := strings.TrimSpace(output); err == nil && inode != "" {
+			inodes = append(inodes, inode)
+		}
+	}
+
+	return inodes
+}
+
+// lockingPIDs picks the processes locking one of the inodes out of lslocks
+// output with PID and INODE columns.
+func lockingPIDs(locks string, inodes []string) []int {
+	var pids []int
+
+	for line := range strings.SplitSeq(locks, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !slices.Contains(inodes, fields[1]) {
+			continue
+		}
+
+		// Open file description locks have no owning process
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil || pid <= 0 || slices.Contains(pids, pid) {
+			continue
+		}
+
+		pids = append(pids, pid)
+	}
+
+	return pids
+}
+
+// nameLockHolders names the processes from ps output. The Python-run
+// unattended-upgrades is named after its script rather than the truncated
+// interpreter name.
+func nameLockHolders(pids []int, processes string) []LockHolder {
+	names := make(map[int]string)
+
+	for line := range strings.SplitSeq(processes, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+
+		names[pid] = fields[1]
+		if strings.Contains(strings.Join(fields[2:], " "), "unattended-upgrade") {
+			names[pid] = "unattended-upgrades"
+		}
+	}
+
+	holders := make([]LockHolder, 0, len(pids))
+
+	for _, pid := range pids {
+		name, ok := names[pid]
+		if !ok {
+			name = "unknown process"
+		}
+
+		holders = append(holders, LockHolder{PID: pid, Name: name})
+	}
+
+	return holders
+}
+
+// waitForAPTLock blocks until no process holds the dpkg lock, printing a
+// countdown, and fails with ErrPackageManagerLocked once the timeout expires.
+func (p *PackageInstaller) waitForAPTLock(ctx context.Context) error {
+	timeout := p.lockTimeout
+	if timeout <= 0 {
+		timeout = DefaultAPTLockTimeout
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		holders := p.aptLockHolders(ctx)
+		if len(holders) == 0 {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			p.printf("\n")
+
+			return fmt.Errorf("%w by %s after waiting %s", domain.ErrPackageManagerLocked, holders[0], timeout)
+		}
+
+		p.printf("\r⏳ Waiting for %s to release the dpkg lock... %ds ", holders[0], int(remaining.Round(time.Second).Seconds()))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(min(aptLockPollInterval, remaining)):
+		}
+	}
+}
+
+// lockAwareError marks a failed apt or dpkg run as lock contention when another
+// process grabbed the lock between the wait and the command.
+func (p *PackageInstaller) lockAwareError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if holders := p.aptLockHolders(ctx); len(holders) > 0 {
+		return fmt.Errorf("%w by %s: %w", domain.ErrPackageManagerLocked, holders[0], err)
+	}
+
+	return err
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package ubuntu
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lslocksOutput has unattended-upgrades holding the frontend lock (inode
+// 1311) and dpkg the dpkg lock (inode 1312), next to unrelated locks.
+const lslocksOutput = `1 77
+4242 1311
+4300 1312
+4300 1311
+-1 1312
+5000 9001
+`
+
+const psLockHolders = ` 4242 unattended-upgr /usr/bin/python3 /usr/bin/unattended-upgrade
+ 4300 dpkg            /usr/bin/dpkg --status-fd 10 --configure libc6
+`
+
+func TestLockingPIDs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []int{4242, 4300}, lockingPIDs(lslocksOutput, []string{"1311", "1312"}))
+	assert.Empty(t, lockingPIDs(lslocksOutput, nil), "no lock files, no holders")
+}
+
+func TestNameLockHolders(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []LockHolder{
+		{PID: 4242, Name: "unattended-upgrades"},
+		{PID: 4300, Name: "dpkg"},
+		{PID: 6000, Name: "unknown process"},
+	}, nameLockHolders([]int{4242, 4300, 6000}, psLockHolders))
+}
+
+func TestWaitForAPTLockTimesOut(t *testing.T) {
+	t.Parallel()
+
+	// packagekitd isn't an apt command, but holds the lock all the same
+	runner := platform.NewMockCommandRunner(false)
+	runner.SetMockOutput("lslocks --noheadings --raw --output PID,INODE", "5000 1311\n")
+	runner.SetMockOutput("stat -c %i /var/lib/dpkg/lock-frontend", "1311\n")
+	runner.SetMockOutput("ps -o pid=,comm=,args= -p 5000", " 5000 packagekitd /usr/libexec/packagekitd\n")
+
+	installer := NewTUIPackageInstaller(runner, platform.NewMockFileManager(false), false, false)
+	installer.SetLockTimeout(10 * time.Millisecond)
+
+	err := installer.waitForAPTLock(context.Background())
+	require.ErrorIs(t, err, domain.ErrPackageManagerLocked)
+	assert.Contains(t, err.Error(), "packagekitd (PID 5000)")
+
+	idle := NewTUIPackageInstaller(platform.NewMockCommandRunner(false), platform.NewMockFileManager(false), false, false)
+	require.NoError(t, idle.waitForAPTLock(context.Background()))
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package ubuntu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+var (
+	// ErrNoArchiveSources indicates apt sources without an Ubuntu archive entry to switch.
+	ErrNoArchiveSources = errors.New("no Ubuntu archive sources found")
+	// ErrMirrorUpdateFailed indicates apt update failed with the new mirror; the original sources are restored.
+	ErrMirrorUpdateFailed = errors.New("apt update failed with the new mirror")
+)
+
+// AptSourcesFiles are where Ubuntu lists its archive: the deb822 file used
+// since 24.04, then the classic sources.list.
+var AptSourcesFiles = []string{"/etc/apt/sources.list.d/ubuntu.sources", "/etc/apt/sources.list"} //nolint:gochecknoglobals
+
+// backupLayout timestamps backups of the sources file.
+const backupLayout = "20060102-150405"
+
+// MirrorSwitch records a change of the archive mirror.
+type MirrorSwitch struct {
+	File     string `json:"file"`
+	Backup   string `json:"backup"`
+	Mirror   string `json:"mirror"`
+	Replaced int    `json:"replaced"` // Number of archive URIs pointed at Mirror
+}
+
+// MirrorSwitcher points apt at another Ubuntu archive mirror.
+type MirrorSwitcher struct {
+	commandRunner domain.CommandRunner
+	fileManager   domain.FileManager
+	files         []string
+}
+
+// NewMirrorSwitcher creates a switcher editing the first of AptSourcesFiles that exists.
+func NewMirrorSwitcher(commandRunner domain.CommandRunner, fileManager domain.FileManager) *MirrorSwitcher {
+	return &MirrorSwitcher{commandRunner: commandRunner, fileManager: fileManager, files: AptSourcesFiles}
+}
+
+// SourcesFile returns the sources file listing the Ubuntu archive.
+func (s *MirrorSwitcher) SourcesFile() (string, error) {
+	for _, path := range s.files {
+		if s.fileManager.FileExists(path) {
+			return path, nil
+		}
+	}
+
+	return "", ErrNoArchiveSources
+}
+
+// Switch backs up the sources file, points its archive URIs, and those of
+// the known mirrors, at mirror and verifies apt update works. When it
+// doesn't, the backup is put back and ErrMirrorUpdateFailed returned.
+func (s *MirrorSwitcher) Switch(ctx context.Context, mirror string, known []string) (*MirrorSwitch, error) {
+	path, err := s.SourcesFile()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.fileManager.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	rewritten, replaced := RewriteArchiveURIs(string(content), mirror, known)
+	if replaced == 0 {
+		return nil, fmt.Errorf("%w in %s", ErrNoArchiveSources, path)
+	}
+
+	change := &MirrorSwitch{
+		File:     path,
+		Backup:   path + ".karei-" + time.Now().Format(backupLayout),
+		Mirror:   mirror,
+		Replaced: replaced,
+	}
+
+	if err := s.commandRunner.ExecuteSudo(ctx, "cp", "-p", path, change.Backup); err != nil {
+		return nil, fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+
+	// Staged in a private directory, so no other user can swap in a source
+	stageDir, err := os.MkdirTemp("", "karei-sources-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to stage sources: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(stageDir) }()
+
+	staged := filepath.Join(stageDir, filepath.Base(path))
+	if err := s.fileManager.WriteFile(staged, []byte(rewritten)); err != nil {
+		return nil, fmt.Errorf("failed to stage sources: %w", err)
+	}
+
+	if err := s.commandRunner.ExecuteSudo(ctx, "install", "-m", "644", staged, path); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	// sudo drops the proxy environment, so apt is given it as options
+	update := append(network.ConfigureAPTProxy(), "update")
+	if err := s.commandRunner.ExecuteSudo(ctx, "apt-get", update...); err != nil {
+		if restoreErr := s.commandRunner.ExecuteSudo(ctx, "cp", "-p", change.Backup, path); restoreErr != nil {
+			return change, fmt.Errorf("%w: %w; restoring %s also failed: %w", ErrMirrorUpdateFailed, err, change.Backup, restoreErr)
+		}
+
+		return change, fmt.Errorf("%w: %w; %s was restored", ErrMirrorUpdateFailed, err, path)
+	}
+
+	return change, nil
+}
+
+// RewriteArchiveURIs points the Ubuntu archive URIs in apt sources at
+// mirror and returns how many it changed. Both deb822 "URIs:" fields and
+// one-line "deb" entries are handled; security.ubuntu.com, PPAs and other
+// repositories are left alone. URIs in known, such as a mirror chosen
+// earlier, count as archive URIs too.
+func RewriteArchiveURIs(content, mirror string, known []string) (string, int) {
+	lines := strings.Split(content, "\n")
+	replaced := 0
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		var fields []string
+
+		switch {
+		case strings.HasPrefix(trimmed, "URIs:"):
+			fields = strings.Fields(strings.TrimPrefix(trimmed, "URIs:"))
+		case strings.HasPrefix(trimmed, "deb ") || strings.HasPrefix(trimmed, "deb-src "):
+			fields = strings.Fields(trimmed)
+		default:
+			continue
+		}
+
+		for _, field := range fields {
+			if isArchiveURI(field, known) {
+				line = strings.Replace(line, field, mirror, 1)
+				replaced++
+			}
+		}
+
+		lines[i] = line
+	}
+
+	return strings.Join(lines, "\n"), replaced
+}
+
+// isArchiveURI reports whether uri is the Ubuntu archive or one of known.
+func isArchiveURI(uri string, known []string) bool {
+	parsed, err := url.Parse(uri)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return false
+	}
+
+	if parsed.Host == "archive.ubuntu.com" || strings.HasSuffix(parsed.Host, ".archive.ubuntu.com") {
+		return true
+	}
+
+	normalized := strings.TrimSuffix(uri, "/") + "/"
+
+	return slices.Contains(known, normalized)
+}
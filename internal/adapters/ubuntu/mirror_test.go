@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package ubuntu
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const ubuntuSources = `Types: deb
+URIs: http://se.archive.ubuntu.com/ubuntu/
+Suites: noble noble-updates noble-backports
+Components: main restricted universe multiverse
+Signed-By: /usr/share/keyrings/ubuntu-archive-keyring.gpg
+
+Types: deb
+URIs: http://security.ubuntu.com/ubuntu/
+Suites: noble-security
+Components: main restricted universe multiverse
+Signed-By: /usr/share/keyrings/ubuntu-archive-keyring.gpg
+`
+
+const sourcesList = `deb http://archive.ubuntu.com/ubuntu jammy main restricted
+# deb http://archive.ubuntu.com/ubuntu jammy-proposed main
+deb [arch=amd64] http://mirror.example.net/ubuntu/ jammy-updates main
+deb https://ppa.launchpadcontent.net/git-core/ppa/ubuntu jammy main
+deb http://security.ubuntu.com/ubuntu jammy-security main
+`
+
+func TestRewriteArchiveURIs(t *testing.T) {
+	t.Parallel()
+
+	mirror := "https://ftp.lysator.liu.se/ubuntu/"
+
+	rewritten, replaced := RewriteArchiveURIs(ubuntuSources, mirror, nil)
+	assert.Equal(t, 1, replaced)
+	assert.Contains(t, rewritten, "URIs: "+mirror+"\n")
+	assert.Contains(t, rewritten, "URIs: http://security.ubuntu.com/ubuntu/\n")
+
+	rewritten, replaced = RewriteArchiveURIs(sourcesList, mirror, []string{"http://mirror.example.net/ubuntu/"})
+	assert.Equal(t, 2, replaced)
+	assert.Contains(t, rewritten, "deb "+mirror+" jammy main restricted\n")
+	assert.Contains(t, rewritten, "# deb http://archive.ubuntu.com/ubuntu jammy-proposed main\n")
+	assert.Contains(t, rewritten, "deb [arch=amd64] "+mirror+" jammy-updates main\n")
+	assert.Contains(t, rewritten, "deb https://ppa.launchpadcontent.net/git-core/ppa/ubuntu jammy main\n")
+
+	_, replaced = RewriteArchiveURIs("deb http://security.ubuntu.com/ubuntu jammy-security main\n", mirror, nil)
+	assert.Zero(t, replaced)
+}
+
+func TestMirrorSwitcherRestoresOnFailedUpdate(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ubuntu.sources")
+	require.NoError(t, os.WriteFile(path, []byte(ubuntuSources), 0o600))
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("ExecuteSudo", mock.Anything, "cp", mock.MatchedBy(func(args []string) bool {
+		return args[1] == path && strings.HasPrefix(args[2], path+".karei-")
+	})).Return(nil).Once()
+	runner.On("ExecuteSudo", mock.Anything, "install", mock.Anything).Return(nil).Once()
+	runner.On("ExecuteSudo", mock.Anything, "apt-get", mock.MatchedBy(func(args []string) bool {
+		return args[len(args)-1] == "update"
+	})).Return(errors.New("exit status 100")).Once()
+	runner.On("ExecuteSudo", mock.Anything, "cp", mock.MatchedBy(func(args []string) bool {
+		return args[2] == path
+	})).Return(nil).Once()
+
+	switcher := NewMirrorSwitcher(runner, platform.NewFileManager(false))
+	switcher.files = []string{filepath.Join(t.TempDir(), "missing"), path}
+
+	change, err := switcher.Switch(context.Background(), "https://ftp.lysator.liu.se/ubuntu/", nil)
+	require.ErrorIs(t, err, ErrMirrorUpdateFailed)
+	assert.Equal(t, path, change.File)
+	assert.Equal(t, 1, change.Replaced)
+	runner.AssertExpectations(t)
+}
+
+func TestMirrorSwitcherWithoutArchiveSources(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "sources.list")
+	require.NoError(t, os.WriteFile(path, []byte("deb http://security.ubuntu.com/ubuntu jammy-security main\n"), 0o600))
+
+	switcher := NewMirrorSwitcher(&testutil.MockCommandRunner{}, platform.NewFileManager(false))
+	switcher.files = []string{path}
+
+	_, err := switcher.Switch(context.Background(), "https://ftp.lysator.liu.se/ubuntu/", nil)
+	require.ErrorIs(t, err, ErrNoArchiveSources)
+}
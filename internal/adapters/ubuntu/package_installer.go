@@ -13,11 +13,14 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/janderssonse/karei/internal/adapters/network"
 	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
 )
 
 // Static error definitions for err113 compliance.
@@ -25,6 +28,7 @@ var (
 	ErrDownloadFailed        = errors.New("download failed")
 	ErrMiseNotInstalled      = errors.New("mise is not installed - install mise first")
 	ErrAquaNotInstalled      = errors.New("aqua is not installed - install aqua first")
+	ErrNpmNotInstalled       = errors.New("npm is not installed - install node first")
 	ErrPMDURLNotFound        = errors.New("could not determine latest PMD download URL")
 	ErrGitHubBinaryNotImpl   = errors.New("downloadGitHubBinary not yet implemented")
 	ErrGitHubReleaseNotImpl  = errors.New("downloadGitHubRelease not yet implemented")
@@ -45,7 +49,13 @@ type PackageInstaller struct {
 	fileManager   domain.FileManager
 	verbose       bool
 	dryRun        bool
-	tuiMode       bool // When true, suppress progress messages for TUI compatibility
+	tuiMode       bool               // When true, suppress progress messages for TUI compatibility
+	redirected    bool               // Command output goes to an output function, so messages are suppressed too
+	lockTimeout   time.Duration      // How long to wait for another process to release the dpkg lock
+	aptFrontend   domain.APTFrontend // Command APT installs go through; apt-get when unset
+
+	mu        sync.Mutex
+	artifacts map[string][]domain.Artifact // Files created by installs in progress, by package name
 }
 
 // NewPackageInstaller creates a new Linux package installer with the provided dependencies.
@@ -56,6 +66,7 @@ func NewPackageInstaller(commandRunner domain.CommandRunner, fileManager domain.
 		verbose:       verbose,
 		dryRun:        dryRun,
 		tuiMode:       false, // Default to CLI mode
+		lockTimeout:   DefaultAPTLockTimeout,
 	}
 }
 
@@ -67,9 +78,33 @@ func NewTUIPackageInstaller(commandRunner domain.CommandRunner, fileManager doma
 		verbose:       verbose,
 		dryRun:        dryRun,
 		tuiMode:       true, // Enable TUI mode - suppress progress messages
+		lockTimeout:   DefaultAPTLockTimeout,
 	}
 }
 
+// SetOutputFunc passes the output of the commands the installer runs to fn
+// rather than the terminal, and silences the installer's own messages, so a
+// progress line can show what it is doing. Nil restores the terminal output.
+func (p *PackageInstaller) SetOutputFunc(fn func(line string)) {
+	if runner, ok := p.commandRunner.(domain.InstallerOutput); ok {
+		runner.SetOutputFunc(fn)
+	}
+
+	p.redirected = fn != nil
+}
+
+// silent reports whether progress messages are suppressed, in TUI mode or
+// while command output is redirected.
+func (p *PackageInstaller) silent() bool {
+	return p.tuiMode || p.redirected
+}
+
+// SetAPTFrontend routes APT installs through nala or apt-fast, for their
+// parallel downloads. Installs they fail are retried with apt-get.
+func (p *PackageInstaller) SetAPTFrontend(frontend domain.APTFrontend) {
+	p.aptFrontend = frontend
+}
+
 // Install installs a package using the appropriate method.
 func (p *PackageInstaller) Install(ctx context.Context, pkg *domain.Package) (*domain.InstallationResult, error) {
 	startTime := time.Now()
@@ -78,7 +113,7 @@ func (p *PackageInstaller) Install(ctx context.Context, pkg *domain.Package) (*d
 		Success: false,
 	}
 
-	if p.verbose && !p.tuiMode {
+	if p.verbose && !p.silent() {
 		fmt.Printf("Installing %s using method %s\n", pkg.Name, pkg.Method)
 	}
 
@@ -87,10 +122,35 @@ func (p *PackageInstaller) Install(ctx context.Context, pkg *domain.Package) (*d
 	result.Duration = time.Since(startTime).Milliseconds()
 	result.Success = err == nil
 	result.Error = err
+	result.Artifacts = p.takeArtifacts(pkg.Name)
 
 	return result, err
 }
 
+// created remembers a file an install of name created besides the package,
+// so the install result can report it.
+func (p *PackageInstaller) created(name string, kind domain.ArtifactKind, path string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.artifacts == nil {
+		p.artifacts = make(map[string][]domain.Artifact)
+	}
+
+	p.artifacts[name] = domain.AddArtifacts(p.artifacts[name], domain.Artifact{Kind: kind, Path: path})
+}
+
+// takeArtifacts returns and forgets the files the install of name created.
+func (p *PackageInstaller) takeArtifacts(name string) []domain.Artifact {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	artifacts := p.artifacts[name]
+	delete(p.artifacts, name)
+
+	return artifacts
+}
+
 // Remove removes a package using the appropriate method.
 func (p *PackageInstaller) Remove(ctx context.Context, pkg *domain.Package) (*domain.InstallationResult, error) {
 	startTime := time.Now()
@@ -108,9 +168,15 @@ func (p *PackageInstaller) Remove(ctx context.Context, pkg *domain.Package) (*do
 		err = p.removeSnap(ctx, pkg)
 	case domain.MethodFlatpak:
 		err = p.removeFlatpak(ctx, pkg)
+	case domain.MethodDEB:
+		err = p.removeDEB(ctx, pkg)
+	case domain.MethodMise:
+		err = p.removeMise(ctx, pkg)
+	case domain.MethodNpm:
+		err = p.removeNpm(ctx, pkg)
 	case domain.MethodGitHub, domain.MethodGitHubBinary, domain.MethodGitHubBundle, domain.MethodGitHubJava:
 		err = p.removeGitHub(ctx, pkg)
-	case domain.MethodDEB, domain.MethodScript, domain.MethodMise, domain.MethodAqua, domain.MethodBinary:
+	case domain.MethodScript, domain.MethodAqua, domain.MethodBinary:
 		err = p.removeGeneric(ctx, pkg)
 	default:
 		err = domain.ErrUnsupportedRemoveMethod
@@ -123,6 +189,81 @@ func (p *PackageInstaller) Remove(ctx context.Context, pkg *domain.Package) (*do
 	return result, err
 }
 
+// Update upgrades an installed package to the newest version its package
+// manager has. A .deb package is upgraded through APT, by name, when it
+// added an apt repository.
+func (p *PackageInstaller) Update(ctx context.Context, pkg *domain.Package) (*domain.InstallationResult, error) {
+	startTime := time.Now()
+	result := &domain.InstallationResult{
+		Package: pkg,
+		Success: false,
+	}
+
+	var err error
+
+	switch pkg.Method {
+	case domain.MethodAPT:
+		err = p.updateAPT(ctx, pkg.Source)
+	case domain.MethodDEB:
+		err = p.updateAPT(ctx, pkg.Name)
+	case domain.MethodFlatpak:
+		err = p.updateFlatpak(ctx, pkg)
+	case domain.MethodSnap:
+		err = p.updateSnap(ctx, pkg)
+	default:
+		err = fmt.Errorf("%w: %s", domain.ErrUnsupportedUpdateMethod, pkg.Method)
+	}
+
+	result.Duration = time.Since(startTime).Milliseconds()
+	result.Success = err == nil
+	result.Error = err
+
+	return result, err
+}
+
+func (p *PackageInstaller) updateAPT(ctx context.Context, name string) error {
+	if p.dryRun {
+		// DRY RUN: APT upgrade would happen here - TUI handles display
+		return nil
+	}
+
+	if err := p.refreshAPT(ctx); err != nil {
+		return err
+	}
+
+	return p.lockAwareError(ctx, p.installAPTPackage(ctx, name, "--only-upgrade"))
+}
+
+func (p *PackageInstaller) updateFlatpak(ctx context.Context, pkg *domain.Package) error {
+	if p.dryRun {
+		// DRY RUN: Flatpak update would happen here - TUI handles display
+		return nil
+	}
+
+	// Update in whichever installation holds the app, not the configured one
+	scope := pkg.Scope
+	if installed, err := p.findFlatpak(ctx, pkg.Source); err == nil && installed != nil {
+		scope = installed.Scope
+	}
+
+	return p.runFlatpak(ctx, scope, "update", "-y", flatpakScopeFlag(scope), pkg.Source)
+}
+
+func (p *PackageInstaller) updateSnap(ctx context.Context, pkg *domain.Package) error {
+	if p.dryRun {
+		// DRY RUN: Snap refresh would happen here - TUI handles display
+		return nil
+	}
+
+	// The source may carry install options such as --classic
+	name := pkg.Source
+	if fields := strings.Fields(pkg.Source); len(fields) > 0 {
+		name = fields[0]
+	}
+
+	return p.commandRunner.ExecuteSudo(ctx, "snap", "refresh", name)
+}
+
 // List returns a list of installed packages.
 func (p *PackageInstaller) List(ctx context.Context) ([]*domain.Package, error) {
 	output, err := p.commandRunner.ExecuteWithOutput(ctx, "dpkg-query", "-W", "--showformat=${Package} ${Version}\\n")
@@ -147,9 +288,195 @@ func (p *PackageInstaller) List(ctx context.Context) ([]*domain.Package, error)
 		}
 	}
 
+	// Flatpaks from both installations, with their installed size
+	flatpaks, err := p.listFlatpaks(ctx)
+	if err != nil {
+		return packages, nil //nolint:nilerr // Flatpak listing is best effort on top of dpkg
+	}
+
+	for _, app := range flatpaks {
+		packages = append(packages, &domain.Package{
+			Name:   app.ID,
+			Method: domain.MethodFlatpak,
+			Source: app.ID,
+			Scope:  app.Scope,
+			Size:   app.Size,
+		})
+	}
+
 	return packages, nil
 }
 
+// ListInstalled lists the APT, Flatpak and Snap packages installed, with one
+// query per package manager. A package manager that isn't available lists
+// nothing installed; one that fails is left out.
+func (p *PackageInstaller) ListInstalled(ctx context.Context) domain.InstalledPackages {
+	installed := domain.InstalledPackages{}
+
+	if output, err := p.commandRunner.ExecuteWithOutput(ctx, "dpkg-query", "-W", "-f=${Package}\\t${Status}\\n"); err == nil {
+		installed.Add(domain.MethodAPT, parseDpkgInstalled(output)...)
+	}
+
+	if flatpaks, err := p.listFlatpaks(ctx); err == nil {
+		installed.Add(domain.MethodFlatpak)
+
+		for _, app := range flatpaks {
+			installed.Add(domain.MethodFlatpak, app.ID)
+		}
+	}
+
+	if !p.commandRunner.CommandExists("snap") {
+		installed.Add(domain.MethodSnap)
+	} else if output, err := p.commandRunner.ExecuteWithOutput(ctx, "snap", "list"); err == nil {
+		installed.Add(domain.MethodSnap, parseSnapList(output)...)
+	}
+
+	return installed
+}
+
+// parseDpkgInstalled returns the packages of `dpkg-query -W
+// -f=${Package}\t${Status}` output that are fully installed, leaving out
+// those removed with their configuration kept.
+func parseDpkgInstalled(output string) []string {
+	var names []string
+
+	for line := range strings.SplitSeq(output, "\n") {
+		name, status, found := strings.Cut(line, "\t")
+		if found && strings.TrimSpace(status) == "install ok installed" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// parseSnapList returns the snap names of `snap list` output.
+func parseSnapList(output string) []string {
+	var names []string
+
+	for i, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if fields := strings.Fields(line); i > 0 && len(fields) > 0 {
+			names = append(names, fields[0])
+		}
+	}
+
+	return names
+}
+
+// ListOutdated lists the APT packages and Flatpak applications with a newer
+// version available, as of the last refresh of their package lists. A
+// package manager that fails is left out.
+func (p *PackageInstaller) ListOutdated(ctx context.Context) []domain.OutdatedPackage {
+	var outdated []domain.OutdatedPackage
+
+	if output, err := p.commandRunner.ExecuteWithOutput(ctx, "apt", "list", "--upgradable"); err == nil {
+		outdated = append(outdated, parseAPTUpgradable(output)...)
+	}
+
+	if p.commandRunner.CommandExists("flatpak") {
+		output, err := p.commandRunner.ExecuteWithOutput(ctx, "flatpak", "remote-ls", "--updates", "--app", "--columns=application,version")
+		if err == nil {
+			outdated = append(outdated, parseFlatpakUpdates(output)...)
+		}
+	}
+
+	return outdated
+}
+
+// parseAPTUpgradable parses `apt list --upgradable` lines such as
+// "git/noble-updates 1:2.43.0-1ubuntu7.2 amd64 [upgradable from: 1:2.43.0-1ubuntu7.1]".
+func parseAPTUpgradable(output string) []domain.OutdatedPackage {
+	var outdated []domain.OutdatedPackage
+
+	for line := range strings.SplitSeq(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.Contains(fields[0], "/") {
+			continue // "Listing..." and blank lines
+		}
+
+		name, _, _ := strings.Cut(fields[0], "/")
+		pkg := domain.OutdatedPackage{Method: domain.MethodAPT, Name: name, Available: fields[1]}
+
+		if _, from, found := strings.Cut(line, "upgradable from: "); found {
+			pkg.Installed = strings.TrimSuffix(strings.TrimSpace(from), "]")
+		}
+
+		outdated = append(outdated, pkg)
+	}
+
+	return outdated
+}
+
+// parseFlatpakUpdates parses tab-separated `flatpak remote-ls --updates`
+// output. Flatpak doesn't report the installed version alongside.
+func parseFlatpakUpdates(output string) []domain.OutdatedPackage {
+	var outdated []domain.OutdatedPackage
+
+	for line := range strings.SplitSeq(strings.TrimSpace(output), "\n") {
+		id, version, _ := strings.Cut(line, "\t")
+		if id = strings.TrimSpace(id); id != "" {
+			outdated = append(outdated, domain.OutdatedPackage{Method: domain.MethodFlatpak, Name: id, Available: strings.TrimSpace(version)})
+		}
+	}
+
+	return outdated
+}
+
+// DiskUsage adds up the installed size of the APT, DEB and Flatpak packages
+// given. Packages installed by other methods, or not installed, count as
+// nothing.
+func (p *PackageInstaller) DiskUsage(ctx context.Context, packages []*domain.Package) int64 {
+	var dpkgSizes map[string]int64
+
+	var flatpakSizes map[string]int64
+
+	var total int64
+
+	for _, pkg := range packages {
+		switch pkg.Method { //nolint:exhaustive // Other methods don't report sizes
+		case domain.MethodAPT, domain.MethodDEB:
+			if dpkgSizes == nil {
+				output, _ := p.commandRunner.ExecuteWithOutput(ctx, "dpkg-query", "-W", "-f=${Package}\\t${Installed-Size}\\n")
+				dpkgSizes = parseDpkgSizes(output)
+			}
+
+			total += dpkgSizes[pkg.Name]
+		case domain.MethodFlatpak:
+			if flatpakSizes == nil {
+				flatpakSizes = map[string]int64{}
+
+				flatpaks, _ := p.listFlatpaks(ctx)
+				for _, app := range flatpaks {
+					flatpakSizes[app.ID] = app.Size
+				}
+			}
+
+			total += flatpakSizes[pkg.Name]
+		}
+	}
+
+	return total
+}
+
+// parseDpkgSizes parses `dpkg-query -W -f=${Package}\t${Installed-Size}`
+// output, whose sizes are in KiB, into bytes by package.
+func parseDpkgSizes(output string) map[string]int64 {
+	sizes := map[string]int64{}
+
+	for line := range strings.SplitSeq(output, "\n") {
+		name, size, found := strings.Cut(line, "\t")
+		if !found {
+			continue
+		}
+
+		if kib, err := strconv.ParseInt(strings.TrimSpace(size), 10, 64); err == nil {
+			sizes[name] = kib * 1024
+		}
+	}
+
+	return sizes
+}
+
 // IsInstalled checks if a package is installed using intelligent detection.
 // Detection order follows preferred installation methods:
 // 1. Flatpak (GUI apps) 2. Mise (CLI tools) 3. APT/RPM 4. Snap 5. GitHub releases.
@@ -196,6 +523,8 @@ func (p *PackageInstaller) IsInstalledByMethod(ctx context.Context, name string,
 		return p.checkMiseSecond(ctx, name), nil
 	case domain.MethodAqua:
 		return p.checkAquaFifth(ctx, name), nil
+	case domain.MethodNpm:
+		return p.isNpmInstalled(name), nil
 	case domain.MethodDEB:
 		// DEB packages are checked via APT/dpkg
 		return p.checkAPTThird(ctx, name), nil
@@ -252,6 +581,8 @@ func (p *PackageInstaller) dispatchInstallMethod(ctx context.Context, pkg *domai
 		return p.installMise(ctx, pkg)
 	case domain.MethodAqua:
 		return p.installAqua(ctx, pkg)
+	case domain.MethodNpm:
+		return p.installNpm(ctx, pkg)
 	case domain.MethodBinary:
 		return p.installBinary(ctx, pkg)
 	default:
@@ -346,27 +677,127 @@ func (p *PackageInstaller) isFlatpakAppID(name string) bool {
 		strings.HasPrefix(name, "fr.") || strings.HasPrefix(name, "app."))
 }
 
-// isFlatpakInstalled checks if a Flatpak package is installed.
+// isFlatpakInstalled checks if a Flatpak package is installed in either the user or system installation.
 func (p *PackageInstaller) isFlatpakInstalled(ctx context.Context, appID string) (bool, error) {
+	installed, err := p.findFlatpak(ctx, appID)
+
+	return installed != nil, err
+}
+
+// flatpakApp is an installed Flatpak application.
+type flatpakApp struct {
+	ID    string
+	Scope domain.InstallScope
+	Size  int64 // Installed size in bytes
+}
+
+// listFlatpaks lists Flatpak applications from both the user and system installations.
+func (p *PackageInstaller) listFlatpaks(ctx context.Context) ([]flatpakApp, error) {
 	if !p.commandRunner.CommandExists("flatpak") {
-		return false, nil
+		return nil, nil
 	}
 
-	// Use flatpak list to check if app is installed
-	output, err := p.commandRunner.ExecuteWithOutput(ctx, "flatpak", "list", "--app", "--columns=application")
+	output, err := p.commandRunner.ExecuteWithOutput(ctx, "flatpak", "list", "--app", "--columns=application,installation,size")
 	if err != nil {
-		return false, err // Return the actual error
+		return nil, err // Return the actual error
 	}
 
-	// Check if appID is in the output
-	installedApps := strings.Split(strings.TrimSpace(output), "\n")
-	for _, installedApp := range installedApps {
-		if strings.TrimSpace(installedApp) == appID {
-			return true, nil
+	return parseFlatpakList(output), nil
+}
+
+// findFlatpak returns the installed Flatpak with the application ID, or nil.
+func (p *PackageInstaller) findFlatpak(ctx context.Context, appID string) (*flatpakApp, error) {
+	installed, err := p.listFlatpaks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range installed {
+		if installed[i].ID == appID {
+			return &installed[i], nil
 		}
 	}
 
-	return false, nil
+	return nil, nil //nolint:nilnil // Not installed is not an error
+}
+
+// InstalledScope returns which installation holds an installed Flatpak.
+// Other methods always install in the same place, so it reports false.
+func (p *PackageInstaller) InstalledScope(ctx context.Context, pkg *domain.Package) (domain.InstallScope, bool) {
+	if pkg.Method != domain.MethodFlatpak {
+		return "", false
+	}
+
+	installed, err := p.findFlatpak(ctx, pkg.Source)
+	if err != nil || installed == nil {
+		return "", false
+	}
+
+	return installed.Scope, true
+}
+
+// parseFlatpakList parses tab-separated `flatpak list` output. Installations
+// other than the per-user one (including custom ones) count as system-wide.
+func parseFlatpakList(output string) []flatpakApp {
+	var installed []flatpakApp
+
+	for line := range strings.SplitSeq(strings.TrimSpace(output), "\n") {
+		fields := strings.Split(line, "\t")
+		if strings.TrimSpace(fields[0]) == "" {
+			continue
+		}
+
+		app := flatpakApp{ID: strings.TrimSpace(fields[0]), Scope: domain.ScopeSystem}
+		if len(fields) > 1 && strings.TrimSpace(fields[1]) == string(domain.ScopeUser) {
+			app.Scope = domain.ScopeUser
+		}
+
+		if len(fields) > 2 {
+			app.Size = parseFlatpakSize(fields[2])
+		}
+
+		installed = append(installed, app)
+	}
+
+	return installed
+}
+
+// parseFlatpakSize converts flatpak's decimal sizes such as "1.2 GB" or
+// "512.0 kB" to bytes, returning 0 for anything it cannot read.
+func parseFlatpakSize(value string) int64 {
+	number, unit, _ := strings.Cut(strings.TrimSpace(strings.ReplaceAll(value, "\u00a0", " ")), " ")
+
+	size, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0
+	}
+
+	multipliers := map[string]float64{"bytes": 1, "kB": 1e3, "MB": 1e6, "GB": 1e9, "TB": 1e12}
+
+	multiplier, ok := multipliers[unit]
+	if !ok {
+		return 0
+	}
+
+	return int64(size * multiplier)
+}
+
+// flatpakScopeFlag returns the flatpak option selecting the installation.
+func flatpakScopeFlag(scope domain.InstallScope) string {
+	if scope == domain.ScopeSystem {
+		return "--system"
+	}
+
+	return "--user"
+}
+
+// runFlatpak runs flatpak, using sudo for the system-wide installation.
+func (p *PackageInstaller) runFlatpak(ctx context.Context, scope domain.InstallScope, args ...string) error {
+	if scope == domain.ScopeSystem {
+		return p.commandRunner.ExecuteSudo(ctx, "flatpak", args...)
+	}
+
+	return p.commandRunner.Execute(ctx, "flatpak", args...)
 }
 
 // isSnapInstalled checks if a Snap package is installed.
@@ -387,7 +818,7 @@ func (p *PackageInstaller) installAPT(ctx context.Context, pkg *domain.Package)
 	// Check if already installed
 	installed, err := p.IsInstalled(ctx, pkg.Source)
 	if err == nil && installed {
-		if p.verbose && !p.tuiMode {
+		if p.verbose && !p.silent() {
 			fmt.Printf("Package %s already installed\n", pkg.Source)
 		}
 
@@ -395,36 +826,74 @@ func (p *PackageInstaller) installAPT(ctx context.Context, pkg *domain.Package)
 	}
 
 	if p.dryRun {
-		if !p.tuiMode {
-			fmt.Printf("DRY RUN: sudo apt update && sudo apt install -y %s\n", pkg.Source)
+		if !p.silent() {
+			fmt.Printf("DRY RUN: sudo apt update && sudo %s install -y %s\n", p.aptFrontend.Command(), pkg.Source)
 		}
 
 		return nil
 	}
 
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("Installing %s via APT...\n", pkg.Source)
 	}
 
+	if err := p.refreshAPT(ctx); err != nil {
+		return err
+	}
+
+	return p.lockAwareError(ctx, p.installAPTPackage(ctx, pkg.Source))
+}
+
+// refreshAPT waits for the APT lock and updates the package lists.
+func (p *PackageInstaller) refreshAPT(ctx context.Context) error {
+	if err := p.waitForAPTLock(ctx); err != nil {
+		return err
+	}
+
 	// Update package lists with proxy settings
 	updateArgs := append([]string{"apt-get"}, network.ConfigureAPTProxy()...)
+	updateArgs = append(updateArgs, network.ConfigureAPTBandwidth()...)
 
 	updateArgs = append(updateArgs, "update")
 	if err := p.commandRunner.ExecuteSudo(ctx, updateArgs[0], updateArgs[1:]...); err != nil {
-		return fmt.Errorf("failed to update package lists: %w", err)
+		return fmt.Errorf("failed to update package lists: %w", p.lockAwareError(ctx, err))
 	}
 
-	// Install package with proxy settings
-	installArgs := append([]string{"apt-get"}, network.ConfigureAPTProxy()...)
-	installArgs = append(installArgs, "install", "-y", pkg.Source)
+	return nil
+}
+
+// installAPTPackage installs an APT package through the frontend, falling
+// back to apt-get when the frontend fails for another reason than the lock.
+// Options go before the package name, such as --only-upgrade.
+func (p *PackageInstaller) installAPTPackage(ctx context.Context, source string, options ...string) error {
+	command := p.aptFrontend.Command()
 
-	return p.commandRunner.ExecuteSudo(ctx, installArgs[0], installArgs[1:]...)
+	// nala and apt-fast download by themselves, ignoring apt's Dl-Limit
+	bandwidth := network.ConfigureAPTBandwidth()
+	if bandwidth != nil {
+		command = "apt-get"
+	}
+
+	// Install package with proxy settings; nala and apt-fast take apt's options
+	installArgs := append(network.ConfigureAPTProxy(), bandwidth...)
+	installArgs = append(installArgs, "install", "-y")
+	installArgs = append(installArgs, options...)
+	installArgs = append(installArgs, source)
+
+	err := p.commandRunner.ExecuteSudo(ctx, command, installArgs...)
+	if err == nil || command == "apt-get" || len(p.aptLockHolders(ctx)) > 0 {
+		return err
+	}
+
+	p.printf("%s failed (%v), retrying with apt-get\n", command, err)
+
+	return p.commandRunner.ExecuteSudo(ctx, "apt-get", installArgs...)
 }
 
 func (p *PackageInstaller) installSnap(ctx context.Context, pkg *domain.Package) error {
 	// Check if already installed
 	if p.isSnapInstalled(ctx, pkg.Source) {
-		if p.verbose && !p.tuiMode {
+		if p.verbose && !p.silent() {
 			fmt.Printf("Snap %s already installed\n", pkg.Source)
 		}
 
@@ -432,14 +901,14 @@ func (p *PackageInstaller) installSnap(ctx context.Context, pkg *domain.Package)
 	}
 
 	if p.dryRun {
-		if !p.tuiMode {
+		if !p.silent() {
 			fmt.Printf("DRY RUN: sudo snap install %s\n", pkg.Source)
 		}
 
 		return nil
 	}
 
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("Installing %s via Snap...\n", pkg.Source)
 	}
 
@@ -461,36 +930,38 @@ func (p *PackageInstaller) installSnap(ctx context.Context, pkg *domain.Package)
 func (p *PackageInstaller) installFlatpak(ctx context.Context, pkg *domain.Package) error {
 	// Check if already installed
 	if installed, err := p.isFlatpakInstalled(ctx, pkg.Source); err == nil && installed {
-		if p.verbose && !p.tuiMode {
+		if p.verbose && !p.silent() {
 			fmt.Printf("Flatpak %s already installed\n", pkg.Source)
 		}
 
 		return nil
 	}
 
+	scopeFlag := flatpakScopeFlag(pkg.Scope)
+
 	if p.dryRun {
-		if !p.tuiMode {
-			fmt.Printf("DRY RUN: flatpak install -y --user flathub %s\n", pkg.Source)
+		if !p.silent() {
+			fmt.Printf("DRY RUN: flatpak install -y %s flathub %s\n", scopeFlag, pkg.Source)
 		}
 
 		return nil
 	}
 
 	// Ensure Flathub remote is added
-	if err := p.ensureFlathubRemote(ctx); err != nil {
+	if err := p.ensureFlathubRemote(ctx, pkg.Scope); err != nil {
 		return fmt.Errorf("failed to ensure Flathub remote: %w", err)
 	}
 
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("⬛ Installing %s via Flatpak from Flathub...\n", pkg.Source)
 	}
 
 	// Build install command with appropriate flags
-	args := []string{"install", "-y", "--user"}
+	args := []string{"install", "-y", scopeFlag}
 	// Note: --noninteractive is not a valid Flatpak flag, removed
 	args = append(args, "flathub", pkg.Source)
 
-	return p.commandRunner.Execute(ctx, "flatpak", args...)
+	return p.runFlatpak(ctx, pkg.Scope, args...)
 }
 
 func (p *PackageInstaller) installDEB(ctx context.Context, pkg *domain.Package) error {
@@ -516,14 +987,22 @@ func (p *PackageInstaller) installDEB(ctx context.Context, pkg *domain.Package)
 			return fmt.Errorf("failed to download DEB: %w", err)
 		}
 
+		if err := p.verifyDownload(tempFile, pkg); err != nil {
+			return err
+		}
+
 		debPath = tempFile
 	}
 
+	if err := p.waitForAPTLock(ctx); err != nil {
+		return err
+	}
+
 	// Install using dpkg with sudo
 	if err := p.commandRunner.ExecuteSudo(ctx, "dpkg", "-i", debPath); err != nil {
 		// Return the dpkg error without attempting automatic fixes
 		// User should manually resolve dependency issues
-		return fmt.Errorf("dpkg installation failed: %w", err)
+		return fmt.Errorf("dpkg installation failed: %w", p.lockAwareError(ctx, err))
 	}
 
 	if p.verbose {
@@ -535,7 +1014,7 @@ func (p *PackageInstaller) installDEB(ctx context.Context, pkg *domain.Package)
 
 // installGitHub provides fallback to the old generic method.
 func (p *PackageInstaller) installGitHub(ctx context.Context, pkg *domain.Package) error {
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("⚠ Using legacy GitHub installation method for %s\n", pkg.Name)
 		fmt.Printf("  Consider updating to: github-binary, github-bundle, or github-java\n")
 	}
@@ -547,7 +1026,7 @@ func (p *PackageInstaller) installGitHub(ctx context.Context, pkg *domain.Packag
 // installGitHubBinary installs a single binary from GitHub releases.
 func (p *PackageInstaller) installGitHubBinary(ctx context.Context, pkg *domain.Package) error {
 	if p.commandRunner.CommandExists(pkg.Name) {
-		if p.verbose && !p.tuiMode {
+		if p.verbose && !p.silent() {
 			fmt.Printf("Binary %s already available\n", pkg.Name)
 		}
 
@@ -555,14 +1034,14 @@ func (p *PackageInstaller) installGitHubBinary(ctx context.Context, pkg *domain.
 	}
 
 	if p.dryRun {
-		if !p.tuiMode {
+		if !p.silent() {
 			fmt.Printf("DRY RUN: install GitHub binary from %s\n", pkg.Source)
 		}
 
 		return nil
 	}
 
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("Installing %s binary from GitHub...\n", pkg.Name)
 	}
 
@@ -578,14 +1057,14 @@ func (p *PackageInstaller) installGitHubBinary(ctx context.Context, pkg *domain.
 // installGitHubBundle installs applications with directory structure to ~/.local/share.
 func (p *PackageInstaller) installGitHubBundle(ctx context.Context, pkg *domain.Package) error {
 	if p.dryRun {
-		if !p.tuiMode {
+		if !p.silent() {
 			fmt.Printf("DRY RUN: install GitHub bundle from %s\n", pkg.Source)
 		}
 
 		return nil
 	}
 
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("Installing %s bundle from GitHub...\n", pkg.Name)
 	}
 
@@ -613,14 +1092,14 @@ func (p *PackageInstaller) installGitHubBundle(ctx context.Context, pkg *domain.
 // installGitHubJava installs Java applications with proper structure and wrapper.
 func (p *PackageInstaller) installGitHubJava(ctx context.Context, pkg *domain.Package) error {
 	if p.dryRun {
-		if !p.tuiMode {
+		if !p.silent() {
 			fmt.Printf("DRY RUN: install GitHub Java app from %s\n", pkg.Source)
 		}
 
 		return nil
 	}
 
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("Installing %s Java application from GitHub...\n", pkg.Name)
 	}
 
@@ -635,14 +1114,14 @@ func (p *PackageInstaller) installGitHubJava(ctx context.Context, pkg *domain.Pa
 
 func (p *PackageInstaller) installScript(ctx context.Context, pkg *domain.Package) error {
 	if p.dryRun {
-		if !p.tuiMode {
+		if !p.silent() {
 			fmt.Printf("DRY RUN: run custom script %s\n", pkg.Source)
 		}
 
 		return nil
 	}
 
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("Running install script: %s\n", pkg.Source)
 	}
 
@@ -650,7 +1129,7 @@ func (p *PackageInstaller) installScript(ctx context.Context, pkg *domain.Packag
 
 	// Download if URL
 	if strings.HasPrefix(scriptPath, "http") {
-		if !p.tuiMode {
+		if !p.silent() {
 			fmt.Printf("⚠ Install script will be downloaded and executed from: %s\n", scriptPath)
 			fmt.Printf("⬢ Security notice: Only run scripts from trusted sources\n")
 		}
@@ -660,6 +1139,10 @@ func (p *PackageInstaller) installScript(ctx context.Context, pkg *domain.Packag
 			return fmt.Errorf("failed to download script: %w", err)
 		}
 
+		if err := p.verifyDownload(tempFile, pkg); err != nil {
+			return err
+		}
+
 		scriptPath = tempFile
 	}
 
@@ -672,9 +1155,76 @@ func (p *PackageInstaller) removeAPT(ctx context.Context, pkg *domain.Package) e
 		return nil
 	}
 
-	fmt.Printf("Uninstalling %s...\n", pkg.Source)
+	p.printf("Uninstalling %s...\n", pkg.Source)
 
-	return p.commandRunner.ExecuteSudo(ctx, "apt-get", "remove", "-y", pkg.Source)
+	if err := p.waitForAPTLock(ctx); err != nil {
+		return err
+	}
+
+	return p.lockAwareError(ctx, p.commandRunner.ExecuteSudo(ctx, "apt-get", "remove", "-y", pkg.Source))
+}
+
+// removeDEB removes a package installed from a downloaded .deb via APT.
+func (p *PackageInstaller) removeDEB(ctx context.Context, pkg *domain.Package) error {
+	if p.dryRun {
+		return nil
+	}
+
+	debName := debPackageName(pkg.Name)
+	p.printf("Uninstalling %s...\n", debName)
+
+	if err := p.waitForAPTLock(ctx); err != nil {
+		return err
+	}
+
+	return p.lockAwareError(ctx, p.commandRunner.ExecuteSudo(ctx, "apt-get", "remove", "-y", debName))
+}
+
+// removeMise removes a mise-managed tool.
+func (p *PackageInstaller) removeMise(ctx context.Context, pkg *domain.Package) error {
+	if p.dryRun {
+		return nil
+	}
+
+	return p.commandRunner.Execute(ctx, "mise", "uninstall", pkg.Source)
+}
+
+// removeNpm removes a tool from karei's npm prefix, or disables a package
+// manager corepack provides.
+func (p *PackageInstaller) removeNpm(ctx context.Context, pkg *domain.Package) error {
+	if p.dryRun {
+		return nil
+	}
+
+	prefix := xdg.NpmPrefix()
+
+	if domain.IsCorepackManager(pkg.Source) && !p.fileManager.FileExists(domain.NpmPackageDir(prefix, pkg.Source)) {
+		return p.commandRunner.Execute(ctx, "corepack", "disable", "--install-directory", domain.NpmBinDir(prefix), pkg.Source)
+	}
+
+	return p.commandRunner.Execute(ctx, "npm", "uninstall", "--global", "--prefix", prefix, pkg.Source)
+}
+
+// debPackageName maps catalog keys to installed DEB package names where they differ.
+func debPackageName(appKey string) string {
+	debPackages := map[string]string{
+		"chrome": "google-chrome-stable",
+		"vscode": "code",
+		"brave":  "brave-browser",
+	}
+
+	if name, exists := debPackages[appKey]; exists {
+		return name
+	}
+
+	return appKey
+}
+
+// printf writes progress output unless running inside the TUI.
+func (p *PackageInstaller) printf(format string, args ...any) {
+	if !p.silent() {
+		fmt.Printf(format, args...)
+	}
 }
 
 func (p *PackageInstaller) removeSnap(ctx context.Context, pkg *domain.Package) error {
@@ -696,26 +1246,32 @@ func (p *PackageInstaller) removeFlatpak(ctx context.Context, pkg *domain.Packag
 		fmt.Printf("Removing Flatpak %s...\n", pkg.Source)
 	}
 
+	// Remove from whichever installation holds the app, not the configured one
+	scope := pkg.Scope
+	if installed, err := p.findFlatpak(ctx, pkg.Source); err == nil && installed != nil {
+		scope = installed.Scope
+	}
+
 	// Build uninstall command with appropriate flags
-	args := []string{"uninstall", "-y", "--user"}
+	args := []string{"uninstall", "-y", flatpakScopeFlag(scope)}
 	// Note: --noninteractive is not a valid Flatpak flag, removed
 	args = append(args, pkg.Source)
 
-	return p.commandRunner.Execute(ctx, "flatpak", args...)
+	return p.runFlatpak(ctx, scope, args...)
 }
 
-// ensureFlathubRemote adds the Flathub remote if not present.
-func (p *PackageInstaller) ensureFlathubRemote(ctx context.Context) error {
-	if !p.tuiMode {
+// ensureFlathubRemote adds the Flathub remote to the installation if not present.
+func (p *PackageInstaller) ensureFlathubRemote(ctx context.Context, scope domain.InstallScope) error {
+	if !p.silent() {
 		fmt.Printf("• Connecting to Flathub repository...\n")
 	}
 
-	// Build remote-add command with appropriate flags (user-level)
-	remoteArgs := []string{"remote-add", "--if-not-exists", "--user"}
+	// Build remote-add command with appropriate flags
+	remoteArgs := []string{"remote-add", "--if-not-exists", flatpakScopeFlag(scope)}
 	// Note: --noninteractive is not a valid Flatpak flag, removed
 	remoteArgs = append(remoteArgs, "flathub", "https://dl.flathub.org/repo/flathub.flatpakrepo")
 
-	return p.commandRunner.Execute(ctx, "flatpak", remoteArgs...)
+	return p.runFlatpak(ctx, scope, remoteArgs...)
 }
 
 // downloadDEBFile downloads a DEB file from URL to temp directory.
@@ -806,7 +1362,7 @@ func (p *PackageInstaller) validateMisePrerequisites(ctx context.Context, pkg *d
 
 	// Check if package is already installed via mise
 	if p.isMiseInstalled(ctx, pkg.Name) {
-		if p.verbose && !p.tuiMode {
+		if p.verbose && !p.silent() {
 			fmt.Printf("Package %s already installed via mise\n", pkg.Name)
 		}
 
@@ -817,7 +1373,7 @@ func (p *PackageInstaller) validateMisePrerequisites(ctx context.Context, pkg *d
 }
 
 func (p *PackageInstaller) handleMiseDryRun(pkg *domain.Package) error {
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("DRY RUN: mise use -g %s\n", pkg.Source)
 	}
 
@@ -825,7 +1381,7 @@ func (p *PackageInstaller) handleMiseDryRun(pkg *domain.Package) error {
 }
 
 func (p *PackageInstaller) executeMiseInstall(ctx context.Context, pkg *domain.Package) error {
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("Installing %s via mise (development environment manager)...\n", pkg.Name)
 		fmt.Printf("⬛ Tool will be downloaded and managed by mise\n")
 	}
@@ -875,7 +1431,7 @@ func (p *PackageInstaller) validateAquaPrerequisites(ctx context.Context, pkg *d
 
 	// Check if package is already installed via aqua
 	if p.isAquaInstalled(ctx, pkg.Name) {
-		if p.verbose && !p.tuiMode {
+		if p.verbose && !p.silent() {
 			fmt.Printf("Package %s already installed via aqua\n", pkg.Name)
 		}
 
@@ -886,7 +1442,7 @@ func (p *PackageInstaller) validateAquaPrerequisites(ctx context.Context, pkg *d
 }
 
 func (p *PackageInstaller) handleAquaDryRun(pkg *domain.Package) error {
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("DRY RUN: aqua i -c ~/.config/aqua/aqua.yaml %s\n", pkg.Source)
 	}
 
@@ -894,7 +1450,7 @@ func (p *PackageInstaller) handleAquaDryRun(pkg *domain.Package) error {
 }
 
 func (p *PackageInstaller) executeAquaInstall(ctx context.Context, pkg *domain.Package) error {
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("Installing %s via aqua (declarative CLI version manager)...\n", pkg.Name)
 		fmt.Printf("• Connecting to aqua registry for package information...\n")
 		fmt.Printf("⬛ Package will be downloaded from GitHub releases\n")
@@ -950,6 +1506,49 @@ func (p *PackageInstaller) runAquaInstall(ctx context.Context, aquaConfig, aquaP
 	return p.commandRunner.Execute(ctx, "aqua", "i", "-c", aquaConfig, aquaPackage)
 }
 
+// installNpm installs a Node.js tool globally into karei's npm prefix in the
+// user's home, so unlike sudo npm -g it never writes to system directories
+// or leaves root-owned files in ~/.npm. Package managers corepack provides
+// are enabled into the same prefix instead.
+func (p *PackageInstaller) installNpm(ctx context.Context, pkg *domain.Package) error {
+	if !p.commandRunner.CommandExists("npm") {
+		return ErrNpmNotInstalled
+	}
+
+	prefix := xdg.NpmPrefix()
+	args := []string{"npm", "install", "--global", "--prefix", prefix, domain.NpmSpec(pkg.Source, pkg.Version)}
+
+	if domain.IsCorepackManager(pkg.Source) && p.commandRunner.CommandExists("corepack") {
+		args = []string{"corepack", "enable", "--install-directory", domain.NpmBinDir(prefix), pkg.Source}
+	}
+
+	if p.dryRun {
+		p.printf("DRY RUN: %s\n", strings.Join(args, " "))
+
+		return nil
+	}
+
+	if err := p.fileManager.EnsureDir(domain.NpmBinDir(prefix)); err != nil {
+		return fmt.Errorf("failed to create npm prefix: %w", err)
+	}
+
+	p.printf("Installing %s via %s into %s...\n", pkg.Name, args[0], prefix)
+
+	return p.commandRunner.Execute(ctx, args[0], args[1:]...)
+}
+
+// isNpmInstalled checks karei's npm prefix for the package, or for the
+// command of a package manager corepack provides.
+func (p *PackageInstaller) isNpmInstalled(source string) bool {
+	prefix := xdg.NpmPrefix()
+
+	if domain.IsCorepackManager(source) && p.fileManager.FileExists(filepath.Join(domain.NpmBinDir(prefix), source)) {
+		return true
+	}
+
+	return p.fileManager.FileExists(domain.NpmPackageDir(prefix, source))
+}
+
 // installBinary installs a pre-compiled binary directly.
 func (p *PackageInstaller) installBinary(ctx context.Context, pkg *domain.Package) error {
 	if p.commandRunner.CommandExists(pkg.Name) {
@@ -964,7 +1563,7 @@ func (p *PackageInstaller) installBinary(ctx context.Context, pkg *domain.Packag
 }
 
 func (p *PackageInstaller) handleBinaryAlreadyExists(pkg *domain.Package) error {
-	if p.verbose && !p.tuiMode {
+	if p.verbose && !p.silent() {
 		fmt.Printf("Binary %s already available\n", pkg.Name)
 	}
 
@@ -972,7 +1571,7 @@ func (p *PackageInstaller) handleBinaryAlreadyExists(pkg *domain.Package) error
 }
 
 func (p *PackageInstaller) handleBinaryDryRun(pkg *domain.Package) error {
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("DRY RUN: download and install binary %s\n", pkg.Source)
 	}
 
@@ -980,7 +1579,7 @@ func (p *PackageInstaller) handleBinaryDryRun(pkg *domain.Package) error {
 }
 
 func (p *PackageInstaller) executeBinaryInstall(ctx context.Context, pkg *domain.Package) error {
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("Installing %s binary from %s...\n", pkg.Name, pkg.Source)
 	}
 
@@ -1019,7 +1618,9 @@ func (p *PackageInstaller) installBinaryFromTemp(tempFile string, pkg *domain.Pa
 		return fmt.Errorf("failed to install binary: %w", err)
 	}
 
-	if p.verbose && !p.tuiMode {
+	p.created(pkg.Name, domain.ArtifactFile, targetPath)
+
+	if p.verbose && !p.silent() {
 		fmt.Printf("✅ %s installed successfully to %s\n", pkg.Name, targetPath)
 	}
 
@@ -1182,7 +1783,7 @@ func (p *PackageInstaller) getXDGConfigHome() string {
 
 // downloadFile downloads a file from URL to local path.
 func (p *PackageInstaller) downloadFile(ctx context.Context, url, destPath string) error {
-	if p.verbose && !p.tuiMode {
+	if p.verbose && !p.silent() {
 		fmt.Printf("• Downloading from %s...\n", url)
 	}
 
@@ -1227,7 +1828,7 @@ func (p *PackageInstaller) downloadFile(ctx context.Context, url, destPath strin
 		return fmt.Errorf("failed to write file %s: %w", destPath, err)
 	}
 
-	if p.verbose && !p.tuiMode {
+	if p.verbose && !p.silent() {
 		fmt.Printf("✓ Download completed successfully\n")
 	}
 
@@ -1236,7 +1837,7 @@ func (p *PackageInstaller) downloadFile(ctx context.Context, url, destPath strin
 
 // installPMDFromGitHub handles PMD installation using dynamic version detection.
 func (p *PackageInstaller) installPMDFromGitHub(ctx context.Context, _ *domain.Package) error {
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("Installing PMD from GitHub releases...\n")
 	}
 
@@ -1250,14 +1851,14 @@ func (p *PackageInstaller) installPMDFromGitHub(ctx context.Context, _ *domain.P
 		return ErrPMDURLNotFound
 	}
 
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("• Downloading PMD from: %s\n", downloadURL)
 	}
 
 	// Download PMD ZIP file
 	tempFile := filepath.Join(os.TempDir(), "pmd-bin.zip")
 
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("• Starting download to: %s\n", tempFile)
 	}
 
@@ -1265,7 +1866,7 @@ func (p *PackageInstaller) installPMDFromGitHub(ctx context.Context, _ *domain.P
 		return fmt.Errorf("failed to download PMD from %s: %w", downloadURL, err)
 	}
 
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("• Download completed, file size: %d bytes\n", getFileSize(tempFile))
 	}
 
@@ -1330,6 +1931,10 @@ func (p *PackageInstaller) downloadDirectBinary(ctx context.Context, pkg *domain
 		return fmt.Errorf("failed to download binary: %w", err)
 	}
 
+	if err := p.verifyDownload(tempFile, pkg); err != nil {
+		return err
+	}
+
 	// Make executable and move to bin directory
 	if err := os.Chmod(tempFile, 0755); err != nil { //nolint:gosec // G302: Executable files need 0755 permissions
 		return fmt.Errorf("failed to make binary executable: %w", err)
@@ -1341,8 +1946,37 @@ func (p *PackageInstaller) downloadDirectBinary(ctx context.Context, pkg *domain
 	}
 
 	targetPath := filepath.Join(binDir, pkg.Name)
+	if err := os.Rename(tempFile, targetPath); err != nil {
+		return err
+	}
+
+	p.created(pkg.Name, domain.ArtifactFile, targetPath)
+
+	return nil
+}
+
+// verifyDownload checks a downloaded file against the checksum the catalog
+// pins it to, removing it when it differs so it is never installed.
+func (p *PackageInstaller) verifyDownload(path string, pkg *domain.Package) error {
+	if pkg.SHA256 == "" {
+		return nil
+	}
+
+	file, err := os.Open(path) //nolint:gosec // Path of karei's own download
+	if err != nil {
+		return fmt.Errorf("failed to checksum download: %w", err)
+	}
+
+	err = domain.VerifySHA256(file, pkg.SHA256)
+	_ = file.Close()
+
+	if err != nil {
+		_ = os.Remove(path)
 
-	return os.Rename(tempFile, targetPath)
+		return fmt.Errorf("%s: %w", pkg.Name, err)
+	}
+
+	return nil
 }
 
 // downloadGitHubBinary downloads a binary from GitHub releases using common patterns.
@@ -1372,7 +2006,7 @@ func (p *PackageInstaller) installGenericJavaApp(_ context.Context, pkg *domain.
 
 // extractPMDZIP extracts PMD with proper directory structure to ~/.local/share/pmd.
 func (p *PackageInstaller) extractPMDZIP(ctx context.Context, zipPath, targetDir string) error {
-	if !p.tuiMode {
+	if !p.silent() {
 		fmt.Printf("• Extracting PMD to %s...\n", targetDir)
 	}
 
@@ -1473,7 +2107,10 @@ func (p *PackageInstaller) createPMDSymlink(pmdDir string) error {
 		return fmt.Errorf("failed to create PMD symlink: %w", err)
 	}
 
-	if !p.tuiMode {
+	p.created("pmd", domain.ArtifactFile, pmdDir)
+	p.created("pmd", domain.ArtifactSymlink, symlinkTarget)
+
+	if !p.silent() {
 		fmt.Printf("✓ PMD extracted successfully\n")
 		fmt.Printf("✓ Created symlink: %s -> %s\n", symlinkTarget, symlinkSource)
 	}
@@ -1520,20 +2157,20 @@ func (p *PackageInstaller) extractPMDFile(file *zip.File, targetPath string) err
 //nolint:unparam // Intentionally returns nil - uninstall operations should be forgiving
 func (p *PackageInstaller) removeGitHub(_ context.Context, pkg *domain.Package) error {
 	if p.dryRun {
-		fmt.Printf("DRY RUN: remove GitHub package %s\n", pkg.Name)
+		p.printf("DRY RUN: remove GitHub package %s\n", pkg.Name)
 
 		return nil
 	}
 
-	fmt.Printf("Removing %s...\n", pkg.Name)
+	p.printf("Removing %s...\n", pkg.Name)
 
 	// Remove binary from ~/.local/bin/
 	binPath := filepath.Join(p.getUserBinDir(), pkg.Name)
 	if p.fileManager.FileExists(binPath) {
 		if err := os.Remove(binPath); err != nil {
-			fmt.Printf("⚠ Failed to remove binary %s: %v\n", binPath, err)
+			p.printf("⚠ Failed to remove binary %s: %v\n", binPath, err)
 		} else {
-			fmt.Printf("✓ Removed binary: %s\n", binPath)
+			p.printf("✓ Removed binary: %s\n", binPath)
 		}
 	}
 
@@ -1541,13 +2178,13 @@ func (p *PackageInstaller) removeGitHub(_ context.Context, pkg *domain.Package)
 	sharePath := filepath.Join(filepath.Dir(p.getUserBinDir()), "share", pkg.Name)
 	if p.fileManager.FileExists(sharePath) {
 		if err := os.RemoveAll(sharePath); err != nil {
-			fmt.Printf("⚠ Failed to remove application directory %s: %v\n", sharePath, err)
+			p.printf("⚠ Failed to remove application directory %s: %v\n", sharePath, err)
 		} else {
-			fmt.Printf("✓ Removed application directory: %s\n", sharePath)
+			p.printf("✓ Removed application directory: %s\n", sharePath)
 		}
 	}
 
-	fmt.Printf("✓ %s removed successfully\n", pkg.Name)
+	p.printf("✓ %s removed successfully\n", pkg.Name)
 
 	return nil
 }
@@ -1555,12 +2192,12 @@ func (p *PackageInstaller) removeGitHub(_ context.Context, pkg *domain.Package)
 // removeGeneric removes generically installed packages (binary, script, etc.).
 func (p *PackageInstaller) removeGeneric(_ context.Context, pkg *domain.Package) error {
 	if p.dryRun {
-		fmt.Printf("DRY RUN: remove %s package %s\n", pkg.Method, pkg.Name)
+		p.printf("DRY RUN: remove %s package %s\n", pkg.Method, pkg.Name)
 
 		return nil
 	}
 
-	fmt.Printf("Removing %s...\n", pkg.Name)
+	p.printf("Removing %s...\n", pkg.Name)
 
 	// For most generic installations, just remove the binary
 	binPath := filepath.Join(p.getUserBinDir(), pkg.Name)
@@ -1569,12 +2206,12 @@ func (p *PackageInstaller) removeGeneric(_ context.Context, pkg *domain.Package)
 			return fmt.Errorf("failed to remove binary %s: %w", binPath, err)
 		}
 
-		fmt.Printf("✓ Removed binary: %s\n", binPath)
+		p.printf("✓ Removed binary: %s\n", binPath)
 	} else {
-		fmt.Printf("⚠ Binary %s not found (may already be removed)\n", binPath)
+		p.printf("⚠ Binary %s not found (may already be removed)\n", binPath)
 	}
 
-	fmt.Printf("✓ %s removed successfully\n", pkg.Name)
+	p.printf("✓ %s removed successfully\n", pkg.Name)
 
 	return nil
 }
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package ubuntu
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/janderssonse/karei/internal/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFlatpakList(t *testing.T) {
+	t.Parallel()
+
+	output := "org.gimp.GIMP\tsystem\t1.2 GB\ncom.spotify.Client\tuser\t512.5 MB\n\norg.videolan.VLC\textra\t98.0 MB\n"
+
+	assert.Equal(t, []flatpakApp{
+		{ID: "org.gimp.GIMP", Scope: domain.ScopeSystem, Size: 1_200_000_000},
+		{ID: "com.spotify.Client", Scope: domain.ScopeUser, Size: 512_500_000},
+		{ID: "org.videolan.VLC", Scope: domain.ScopeSystem, Size: 98_000_000},
+	}, parseFlatpakList(output))
+}
+
+func TestInstalledScope(t *testing.T) {
+	t.Parallel()
+
+	runner := platform.NewMockCommandRunner(false)
+	runner.SetMockOutput("flatpak list --app --columns=application,installation,size", "org.gimp.GIMP\tsystem\t1.2 GB\n")
+
+	installer := NewTUIPackageInstaller(runner, platform.NewMockFileManager(false), false, false)
+	ctx := context.Background()
+
+	scope, ok := installer.InstalledScope(ctx, &domain.Package{Method: domain.MethodFlatpak, Source: "org.gimp.GIMP"})
+	assert.True(t, ok)
+	assert.Equal(t, domain.ScopeSystem, scope)
+
+	_, ok = installer.InstalledScope(ctx, &domain.Package{Method: domain.MethodFlatpak, Source: "com.spotify.Client"})
+	assert.False(t, ok, "not installed")
+
+	_, ok = installer.InstalledScope(ctx, &domain.Package{Method: domain.MethodAPT, Source: "gimp"})
+	assert.False(t, ok)
+}
+
+func TestParseFlatpakSize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, int64(2048), parseFlatpakSize("2048 bytes"))
+	assert.Equal(t, int64(1500), parseFlatpakSize("1.5 kB"))
+	assert.Zero(t, parseFlatpakSize("unknown"))
+	assert.Zero(t, parseFlatpakSize("3 parsecs"))
+}
+
+func TestParseDpkgInstalled(t *testing.T) {
+	t.Parallel()
+
+	output := "git\tinstall ok installed\nvlc\tdeinstall ok config-files\ncurl\tinstall ok installed\n"
+
+	assert.Equal(t, []string{"git", "curl"}, parseDpkgInstalled(output))
+}
+
+func TestParseSnapList(t *testing.T) {
+	t.Parallel()
+
+	output := "Name      Version    Rev    Tracking       Publisher   Notes\ncore22    20240111   1122   latest/stable  canonical✓  base\nspotify   1.2.31     75     latest/stable  spotify✓    -\n"
+
+	assert.Equal(t, []string{"core22", "spotify"}, parseSnapList(output))
+	assert.Empty(t, parseSnapList("No snaps are installed yet.\n"))
+}
+
+func TestParseAPTUpgradable(t *testing.T) {
+	t.Parallel()
+
+	output := "Listing...\ngit/noble-updates 1:2.43.0-1ubuntu7.2 amd64 [upgradable from: 1:2.43.0-1ubuntu7.1]\ncurl/noble-security 8.5.0-2ubuntu10.5 amd64 [upgradable from: 8.5.0-2ubuntu10.4]\n"
+
+	assert.Equal(t, []domain.OutdatedPackage{
+		{Method: domain.MethodAPT, Name: "git", Installed: "1:2.43.0-1ubuntu7.1", Available: "1:2.43.0-1ubuntu7.2"},
+		{Method: domain.MethodAPT, Name: "curl", Installed: "8.5.0-2ubuntu10.4", Available: "8.5.0-2ubuntu10.5"},
+	}, parseAPTUpgradable(output))
+	assert.Empty(t, parseAPTUpgradable("Listing...\n"))
+}
+
+func TestParseFlatpakUpdates(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []domain.OutdatedPackage{
+		{Method: domain.MethodFlatpak, Name: "org.gimp.GIMP", Available: "2.10.38"},
+		{Method: domain.MethodFlatpak, Name: "com.spotify.Client"},
+	}, parseFlatpakUpdates("org.gimp.GIMP\t2.10.38\ncom.spotify.Client\t\n"))
+}
+
+func TestDiskUsage(t *testing.T) {
+	t.Parallel()
+
+	runner := new(testutil.MockCommandRunner)
+	runner.On("ExecuteWithOutput", mock.Anything, "dpkg-query", "-W", "-f=${Package}\\t${Installed-Size}\\n").Return("git\t2048\ncode\t400000\ncurl\t500\n", nil).Once()
+	runner.On("CommandExists", "flatpak").Return(true)
+	runner.On("ExecuteWithOutput", mock.Anything, "flatpak", "list", "--app", "--columns=application,installation,size").Return("org.gimp.GIMP\tsystem\t1.2 GB\n", nil).Once()
+
+	installer := NewTUIPackageInstaller(runner, platform.NewFileManager(false), false, false)
+
+	usage := installer.DiskUsage(context.Background(), []*domain.Package{
+		{Name: "git", Method: domain.MethodAPT},
+		{Name: "code", Method: domain.MethodDEB},
+		{Name: "org.gimp.GIMP", Method: domain.MethodFlatpak},
+		{Name: "lazygit", Method: domain.MethodGitHub},
+		{Name: "missing", Method: domain.MethodAPT},
+	})
+
+	assert.Equal(t, int64(2048*1024+400000*1024+1_200_000_000), usage)
+	runner.AssertExpectations(t)
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	runner := new(testutil.MockCommandRunner)
+	runner.On("CommandExists", "flatpak").Return(true)
+	runner.On("ExecuteWithOutput", mock.Anything, "flatpak", "list", "--app", "--columns=application,installation,size").Return("org.gimp.GIMP\tsystem\t1.2 GB\n", nil).Once()
+	runner.On("ExecuteSudo", mock.Anything, "flatpak", []string{"update", "-y", "--system", "org.gimp.GIMP"}).Return(nil).Once()
+	runner.On("ExecuteSudo", mock.Anything, "snap", []string{"refresh", "code"}).Return(nil).Once()
+
+	installer := NewTUIPackageInstaller(runner, platform.NewFileManager(false), false, false)
+
+	// The flatpak is updated where it is installed, not in the configured scope
+	_, err := installer.Update(context.Background(), &domain.Package{Name: "gimp", Method: domain.MethodFlatpak, Source: "org.gimp.GIMP", Scope: domain.ScopeUser})
+	require.NoError(t, err)
+
+	_, err = installer.Update(context.Background(), &domain.Package{Name: "code", Method: domain.MethodSnap, Source: "code --classic"})
+	require.NoError(t, err)
+
+	_, err = installer.Update(context.Background(), &domain.Package{Name: "lazygit", Method: domain.MethodGitHub})
+	require.ErrorIs(t, err, domain.ErrUnsupportedUpdateMethod)
+	runner.AssertExpectations(t)
+}
+
+func TestInstallNpmUsesKareiPrefix(t *testing.T) {
+	prefix := filepath.Join(t.TempDir(), "data")
+	t.Setenv(xdg.EnvDataDir, prefix)
+
+	npmPrefix := filepath.Join(prefix, "npm")
+
+	runner := new(testutil.MockCommandRunner)
+	runner.On("CommandExists", "npm").Return(true)
+	runner.On("CommandExists", "corepack").Return(true)
+	runner.On("Execute", mock.Anything, "npm", "install", "--global", "--prefix", npmPrefix, "prettier@3.3.3").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "corepack", "enable", "--install-directory", filepath.Join(npmPrefix, "bin"), "pnpm").Return(nil).Once()
+
+	installer := NewTUIPackageInstaller(runner, platform.NewFileManager(false), false, false)
+
+	_, err := installer.Install(context.Background(), &domain.Package{Name: "prettier", Method: domain.MethodNpm, Source: "prettier", Version: "3.3.3"})
+	require.NoError(t, err)
+
+	_, err = installer.Install(context.Background(), &domain.Package{Name: "pnpm", Method: domain.MethodNpm, Source: "pnpm"})
+	require.NoError(t, err)
+	runner.AssertExpectations(t)
+	runner.AssertNotCalled(t, "ExecuteSudo", mock.Anything, mock.Anything, mock.Anything)
+
+	installed, err := installer.IsInstalledByMethod(context.Background(), "prettier", domain.MethodNpm)
+	require.NoError(t, err)
+	assert.False(t, installed)
+
+	require.NoError(t, os.MkdirAll(domain.NpmPackageDir(npmPrefix, "prettier"), 0o755))
+
+	installed, err = installer.IsInstalledByMethod(context.Background(), "prettier", domain.MethodNpm)
+	require.NoError(t, err)
+	assert.True(t, installed)
+}
+
+func TestInstallAPTPackageFallsBackToAptGet(t *testing.T) {
+	for _, name := range []string{"http_proxy", "HTTP_PROXY", "https_proxy", "HTTPS_PROXY"} {
+		t.Setenv(name, "")
+	}
+
+	runner := new(testutil.MockCommandRunner)
+	runner.On("ExecuteSudo", mock.Anything, "nala", []string{"install", "-y", "htop"}).Return(assert.AnError).Once()
+	runner.On("ExecuteWithOutput", mock.Anything, "lslocks", "--noheadings", "--raw", "--output", "PID,INODE").Return("", nil)
+	runner.On("ExecuteSudo", mock.Anything, "apt-get", []string{"install", "-y", "htop"}).Return(nil).Once()
+
+	installer := NewTUIPackageInstaller(runner, platform.NewFileManager(false), false, false)
+	installer.SetAPTFrontend(domain.APTFrontendNala)
+
+	require.NoError(t, installer.installAPTPackage(context.Background(), "htop"))
+	runner.AssertExpectations(t)
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// AdvisoryService checks installed apps against a vulnerability feed.
+type AdvisoryService struct {
+	feed domain.VulnerabilityFeed
+}
+
+// NewAdvisoryService creates a service querying the given feed.
+func NewAdvisoryService(feed domain.VulnerabilityFeed) *AdvisoryService {
+	return &AdvisoryService{feed: feed}
+}
+
+// Audit looks up the advisories of every package, most severe first. Packages
+// the feed doesn't cover are listed as unchecked rather than failing the audit.
+func (s *AdvisoryService) Audit(ctx context.Context, packages []domain.ManagedPackage) *domain.AuditResult {
+	result := &domain.AuditResult{Vulnerable: []domain.VulnerablePackage{}, Timestamp: time.Now()}
+
+	for _, pkg := range packages {
+		vulnerabilities, err := s.feed.Vulnerabilities(ctx, pkg)
+
+		switch {
+		case errors.Is(err, domain.ErrNoAdvisoryData):
+			result.Unchecked = append(result.Unchecked, pkg.Key)
+
+			continue
+		case err != nil:
+			result.Failed = append(result.Failed, pkg.Key)
+
+			continue
+		}
+
+		result.Checked++
+
+		if len(vulnerabilities) == 0 {
+			continue
+		}
+
+		slices.SortStableFunc(vulnerabilities, func(a, b domain.Vulnerability) int {
+			return domain.SeverityRank(b.Severity) - domain.SeverityRank(a.Severity)
+		})
+
+		result.Vulnerable = append(result.Vulnerable, domain.VulnerablePackage{
+			Key:             pkg.Key,
+			Version:         pkg.Version,
+			Severity:        vulnerabilities[0].Severity,
+			Vulnerabilities: vulnerabilities,
+		})
+	}
+
+	slices.SortStableFunc(result.Vulnerable, func(a, b domain.VulnerablePackage) int {
+		return domain.SeverityRank(b.Severity) - domain.SeverityRank(a.Severity)
+	})
+
+	return result
+}
+
+// Fixable returns the keys of vulnerable packages with a fix released for at
+// least one of their advisories, which an upgrade would pick up.
+func Fixable(result *domain.AuditResult) []string {
+	var keys []string
+
+	for _, pkg := range result.Vulnerable {
+		if slices.ContainsFunc(pkg.Vulnerabilities, func(v domain.Vulnerability) bool { return v.FixedVersion != "" }) {
+			keys = append(keys, pkg.Key)
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubFeed answers advisory lookups from a map keyed by catalog key.
+type stubFeed map[string][]domain.Vulnerability
+
+func (f stubFeed) Vulnerabilities(_ context.Context, pkg domain.ManagedPackage) ([]domain.Vulnerability, error) {
+	switch pkg.Key {
+	case "zed":
+		return nil, domain.ErrNoAdvisoryData
+	case "broken":
+		return nil, errors.New("timeout")
+	}
+
+	return f[pkg.Key], nil
+}
+
+func TestAdvisoryServiceAudit(t *testing.T) {
+	t.Parallel()
+
+	feed := stubFeed{
+		"vlc": {
+			{ID: "CVE-1", Severity: domain.SeverityLow},
+			{ID: "CVE-2", Severity: domain.SeverityHigh, FixedVersion: "3.0.21"},
+		},
+		"gimp": {{ID: "CVE-3", Severity: domain.SeverityCritical}},
+	}
+
+	result := application.NewAdvisoryService(feed).Audit(context.Background(), []domain.ManagedPackage{
+		{Key: "vlc", Version: "3.0.20"}, {Key: "gimp"}, {Key: "curl"}, {Key: "zed"}, {Key: "broken"},
+	})
+
+	assert.Equal(t, 3, result.Checked)
+	assert.Equal(t, []string{"zed"}, result.Unchecked)
+	assert.Equal(t, []string{"broken"}, result.Failed)
+
+	if assert.Len(t, result.Vulnerable, 2) {
+		assert.Equal(t, "gimp", result.Vulnerable[0].Key)
+		assert.Equal(t, "vlc", result.Vulnerable[1].Key)
+		assert.Equal(t, domain.SeverityHigh, result.Vulnerable[1].Severity)
+		assert.Equal(t, "CVE-2", result.Vulnerable[1].Vulnerabilities[0].ID)
+	}
+
+	assert.Equal(t, []string{"vlc"}, application.Fixable(result))
+}
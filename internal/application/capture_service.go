@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// CaptureService binds the keys of a capture profile in GNOME and starts its
+// clipboard manager and screenshot tool at login.
+type CaptureService struct {
+	fileManager   domain.FileManager
+	commandRunner domain.CommandRunner
+	configHome    string
+	configs       *ConfigFiles // Records the autostart entries written when set
+}
+
+// NewCaptureService creates a service writing autostart entries below configHome.
+func NewCaptureService(fm domain.FileManager, cr domain.CommandRunner, configHome string) *CaptureService {
+	return &CaptureService{fileManager: fm, commandRunner: cr, configHome: configHome}
+}
+
+// SetConfigFiles sets where the autostart entries are recorded, so later
+// changes to them are found and merged. Nil writes them as they are.
+func (s *CaptureService) SetConfigFiles(configs *ConfigFiles) {
+	s.configs = configs
+}
+
+// Configure moves GNOME's shortcuts off the profile's keys, adds its custom
+// shortcuts alongside the user's own and writes its autostart entries,
+// returning the paths written.
+func (s *CaptureService) Configure(ctx context.Context, profile domain.CaptureProfile) ([]string, error) {
+	for _, setting := range profile.Released {
+		if err := s.gsettings(ctx, setting.Schema, setting.Key, setting.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.bindKeys(ctx, profile.Keybindings); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(s.configHome, "autostart")
+	if err := s.fileManager.EnsureDir(dir); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	written := make([]string, 0, len(profile.Autostart))
+
+	for _, entry := range profile.Autostart {
+		path := filepath.Join(dir, entry.File)
+		if err := s.write(path, []byte(entry.DesktopEntry())); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+// bindKeys sets the custom shortcuts, adding their paths to the list GNOME
+// reads them from, keeping the shortcuts already there.
+func (s *CaptureService) bindKeys(ctx context.Context, keybindings []domain.Keybinding) error {
+	current, err := s.commandRunner.ExecuteWithOutput(ctx, "gsettings", "get", domain.CustomKeybindingsSchema, "custom-keybindings")
+	if err != nil {
+		return fmt.Errorf("failed to read the custom shortcuts: %w", err)
+	}
+
+	paths := make([]string, 0, len(keybindings))
+
+	for _, keybinding := range keybindings {
+		schema := domain.CustomKeybindingSchema + ":" + keybinding.Path()
+
+		for _, setting := range [][2]string{
+			{"name", keybinding.Name},
+			{"command", keybinding.Command},
+			{"binding", keybinding.Binding},
+		} {
+			if err := s.gsettings(ctx, schema, setting[0], "'"+setting[1]+"'"); err != nil {
+				return err
+			}
+		}
+
+		paths = append(paths, keybinding.Path())
+	}
+
+	if list, added := domain.AddKeybindingPaths(current, paths...); added {
+		return s.gsettings(ctx, domain.CustomKeybindingsSchema, "custom-keybindings", list)
+	}
+
+	return nil
+}
+
+// gsettings sets a GNOME setting.
+func (s *CaptureService) gsettings(ctx context.Context, schema, key, value string) error {
+	if err := s.commandRunner.Execute(ctx, "gsettings", "set", schema, key, value); err != nil {
+		return fmt.Errorf("failed to set %s.%s: %w", schema, key, err)
+	}
+
+	return nil
+}
+
+// write writes an autostart entry, recording it when configuration files are.
+func (s *CaptureService) write(path string, data []byte) error {
+	if s.configs == nil {
+		return s.fileManager.WriteFile(path, data)
+	}
+
+	_, err := s.configs.Write(path, data, "")
+
+	return err
+}
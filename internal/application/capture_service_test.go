@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureService_Configure(t *testing.T) {
+	t.Parallel()
+
+	profile, err := domain.CaptureProfileFor(domain.SessionWayland)
+	require.NoError(t, err)
+
+	mine := "/org/gnome/settings-daemon/plugins/media-keys/custom-keybindings/custom0/"
+	runner := &testutil.MockCommandRunner{}
+	runner.On("ExecuteWithOutput", mock.Anything, "gsettings", "get", domain.CustomKeybindingsSchema, "custom-keybindings").
+		Return("['"+mine+"']\n", nil).Once()
+	runner.On("Execute", mock.Anything, "gsettings", "set", domain.CustomKeybindingsSchema, "custom-keybindings",
+		"['"+mine+"', '"+profile.Keybindings[0].Path()+"', '"+profile.Keybindings[1].Path()+"']").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "gsettings", "set", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	configHome := t.TempDir()
+	service := application.NewCaptureService(platform.NewFileManager(false), runner, configHome)
+
+	written, err := service.Configure(context.Background(), profile)
+	require.NoError(t, err)
+	assert.Len(t, written, 2)
+
+	entry, err := os.ReadFile(filepath.Join(configHome, "autostart", "karei-copyq.desktop"))
+	require.NoError(t, err)
+	assert.Contains(t, string(entry), "Exec=env QT_QPA_PLATFORM=xcb copyq\n")
+
+	runner.AssertCalled(t, "Execute", mock.Anything, "gsettings", "set",
+		domain.CustomKeybindingSchema+":"+profile.Keybindings[1].Path(), "binding", "'<Super>v'")
+	runner.AssertCalled(t, "Execute", mock.Anything, "gsettings", "set",
+		"org.gnome.shell.keybindings", "show-screenshot-ui", "['<Shift>Print']")
+	runner.AssertExpectations(t)
+}
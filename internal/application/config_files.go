@@ -0,0 +1,364 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// ErrUnmanagedConfig indicates karei did not write the configuration file.
+var ErrUnmanagedConfig = errors.New("not a configuration file karei manages")
+
+// mergeSuffix is added to the name of a merge left with conflicts, next to the file.
+const mergeSuffix = ".karei-merge"
+
+// ConfigResolved tells how drift in a configuration file was resolved.
+type ConfigResolved struct {
+	Saved     string // Copy of the file as it was, when it was replaced
+	MergeFile string // Merge with conflict markers to finish by hand; the file is left alone
+	Conflicts int
+}
+
+// ConfigFiles keeps the configuration files karei writes as it wrote them,
+// so changes made to them since can be found, merged or undone.
+type ConfigFiles struct {
+	files     domain.FileManager
+	dir       string
+	templates *Templates // Renders sources named relative to the templates when set
+	resolver  func(domain.ConfigConflict) domain.ConfigResolution
+}
+
+// NewConfigFiles creates the record of managed configuration files kept in dir.
+func NewConfigFiles(files domain.FileManager, dir string) *ConfigFiles {
+	return &ConfigFiles{files: files, dir: dir}
+}
+
+// SetTemplates sets the templates that sources not given as an absolute
+// path are rendered from, so the user's overrides are part of what karei
+// would write now.
+func (c *ConfigFiles) SetTemplates(templates *Templates) {
+	c.templates = templates
+}
+
+// Write writes content karei generated, from the template source when not
+// empty, to path. Changes made to the file since karei last wrote it are
+// merged into content. When they can't be merged, or karei never wrote the
+// file, the conflict resolver decides: overwriting saves the file aside
+// first, keeping leaves it and records it as the user's version. It returns
+// the copy saved, if any.
+func (c *ConfigFiles) Write(path string, content []byte, source string) (string, error) {
+	configs, err := c.load()
+	if err != nil {
+		return "", err
+	}
+
+	if !c.files.FileExists(path) {
+		return "", c.write(configs, path, content, source, content)
+	}
+
+	current, err := c.files.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	config, managed := configs[path]
+
+	switch {
+	case managed && checksum(current) == config.Checksum, bytes.Equal(current, content):
+		return "", c.write(configs, path, content, source, content)
+	case managed:
+		// A clean merge also keeps what the user chose before
+		if merged, conflicts := c.merge(config, content, current); conflicts == 0 {
+			return "", c.write(configs, path, content, source, merged)
+		}
+	}
+
+	conflict := domain.ConfigConflict{
+		Path:    path,
+		Managed: managed,
+		Diff:    domain.DiffLines(splitLines(current), splitLines(content), diffContext),
+	}
+
+	switch c.resolve(conflict) {
+	case domain.ResolveOverwrite:
+		saved, err := c.save(path)
+		if err != nil {
+			return "", err
+		}
+
+		return saved, c.write(configs, path, content, source, content)
+	case domain.ResolveKeep:
+		return "", c.record(configs, path, content, source, current)
+	default:
+		return "", nil
+	}
+}
+
+// SetConflictResolver sets what decides whether a file holding changes that
+// can't be merged is overwritten or kept. Returning neither leaves the file
+// alone without remembering a decision, so the next write asks again.
+// Without a resolver such files are overwritten.
+func (c *ConfigFiles) SetConflictResolver(resolve func(domain.ConfigConflict) domain.ConfigResolution) {
+	c.resolver = resolve
+}
+
+// resolve decides a conflict.
+func (c *ConfigFiles) resolve(conflict domain.ConfigConflict) domain.ConfigResolution {
+	if c.resolver == nil {
+		return domain.ResolveOverwrite
+	}
+
+	return c.resolver(conflict)
+}
+
+// write writes written to path and records content as what karei wrote.
+func (c *ConfigFiles) write(configs map[string]domain.ManagedConfig, path string, content []byte, source string, written []byte) error {
+	if err := c.files.WriteFile(path, written); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return c.record(configs, path, content, source, written)
+}
+
+// Check compares every managed configuration file with what karei wrote,
+// returning those changed or missing, by path.
+func (c *ConfigFiles) Check() ([]domain.ConfigDrift, error) {
+	configs, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []domain.ConfigDrift
+
+	for _, config := range configs {
+		if !c.files.FileExists(config.Path) {
+			drifts = append(drifts, domain.ConfigDrift{Config: config, State: domain.ArtifactMissing})
+
+			continue
+		}
+
+		if current, err := c.files.ReadFile(config.Path); err != nil || !config.Matches(checksum(current)) {
+			drifts = append(drifts, domain.ConfigDrift{Config: config, State: domain.ArtifactModified})
+		}
+	}
+
+	slices.SortFunc(drifts, func(a, b domain.ConfigDrift) int { return strings.Compare(a.Config.Path, b.Config.Path) })
+
+	return drifts, nil
+}
+
+// Managed returns every managed configuration file, by path.
+func (c *ConfigFiles) Managed() (map[string]domain.ManagedConfig, error) {
+	return c.load()
+}
+
+// Resolve settles drift in the managed file at path. Merging keeps the
+// merge as the user's version when it is clean, and otherwise writes it next
+// to the file to finish by hand. Overwriting saves the file aside and writes
+// karei's content again. Keeping accepts the file as the user's version.
+func (c *ConfigFiles) Resolve(path string, resolution domain.ConfigResolution) (ConfigResolved, error) {
+	configs, err := c.load()
+	if err != nil {
+		return ConfigResolved{}, err
+	}
+
+	config, ok := configs[path]
+	if !ok {
+		return ConfigResolved{}, fmt.Errorf("%w: %s", ErrUnmanagedConfig, path)
+	}
+
+	expected, err := c.expected(config)
+	if err != nil {
+		return ConfigResolved{}, err
+	}
+
+	if resolution == domain.ResolveMerge && c.files.FileExists(path) {
+		return c.resolveMerge(configs, config, expected)
+	}
+
+	if resolution == domain.ResolveKeep {
+		return ConfigResolved{}, c.keep(configs, config, expected)
+	}
+
+	var resolved ConfigResolved
+
+	if c.files.FileExists(path) {
+		if resolved.Saved, err = c.save(path); err != nil {
+			return resolved, err
+		}
+	}
+
+	if err := c.files.WriteFile(path, expected); err != nil {
+		return resolved, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return resolved, c.record(configs, path, expected, config.Source, expected)
+}
+
+// keep accepts the file as the user has it, or stops managing it when the
+// user removed it.
+func (c *ConfigFiles) keep(configs map[string]domain.ManagedConfig, config domain.ManagedConfig, expected []byte) error {
+	if !c.files.FileExists(config.Path) {
+		delete(configs, config.Path)
+
+		return c.store(configs)
+	}
+
+	current, err := c.files.ReadFile(config.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", config.Path, err)
+	}
+
+	return c.record(configs, config.Path, expected, config.Source, current)
+}
+
+// resolveMerge merges the changes in the file with karei's content.
+func (c *ConfigFiles) resolveMerge(configs map[string]domain.ManagedConfig, config domain.ManagedConfig, expected []byte) (ConfigResolved, error) {
+	current, err := c.files.ReadFile(config.Path)
+	if err != nil {
+		return ConfigResolved{}, fmt.Errorf("failed to read %s: %w", config.Path, err)
+	}
+
+	merged, conflicts := c.merge(config, expected, current)
+	if conflicts > 0 {
+		mergeFile := config.Path + mergeSuffix
+		if err := c.files.WriteFile(mergeFile, merged); err != nil {
+			return ConfigResolved{}, fmt.Errorf("failed to write %s: %w", mergeFile, err)
+		}
+
+		return ConfigResolved{MergeFile: mergeFile, Conflicts: conflicts}, nil
+	}
+
+	if err := c.files.WriteFile(config.Path, merged); err != nil {
+		return ConfigResolved{}, fmt.Errorf("failed to write %s: %w", config.Path, err)
+	}
+
+	return ConfigResolved{}, c.record(configs, config.Path, expected, config.Source, merged)
+}
+
+// merge merges the changes made to the file since karei wrote it with content.
+func (c *ConfigFiles) merge(config domain.ManagedConfig, content, current []byte) ([]byte, int) {
+	// Without what karei wrote, every difference conflicts
+	base, _ := c.files.ReadFile(c.basePath(config.Path))
+
+	merged, conflicts := domain.MergeLines(splitLines(base), splitLines(content), splitLines(current))
+
+	return []byte(strings.Join(merged, "\n")), conflicts
+}
+
+// expected returns what karei would write to the file now: the template it
+// came from, or else the content it last wrote.
+func (c *ConfigFiles) expected(config domain.ManagedConfig) ([]byte, error) {
+	if config.Source != "" && !filepath.IsAbs(config.Source) && c.templates != nil {
+		if rendered, err := c.templates.Render(config.Source); err == nil {
+			return rendered, nil
+		}
+	}
+
+	if config.Source != "" && c.files.FileExists(config.Source) {
+		return c.files.ReadFile(config.Source)
+	}
+
+	base, err := c.files.ReadFile(c.basePath(config.Path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read what karei wrote to %s: %w", config.Path, err)
+	}
+
+	return base, nil
+}
+
+// save copies the file next to it before it is replaced.
+func (c *ConfigFiles) save(path string) (string, error) {
+	if err := c.files.CopyFile(path, path+savedSuffix); err != nil {
+		return "", fmt.Errorf("failed to save %s: %w", path, err)
+	}
+
+	return path + savedSuffix, nil
+}
+
+// record remembers content as what karei wrote to path, and written as what
+// the file holds when the user's changes were kept in it.
+func (c *ConfigFiles) record(configs map[string]domain.ManagedConfig, path string, content []byte, source string, written []byte) error {
+	if err := c.files.WriteFile(c.basePath(path), content); err != nil {
+		return fmt.Errorf("failed to record %s: %w", path, err)
+	}
+
+	config := domain.ManagedConfig{Path: path, Checksum: checksum(content), Source: source, Applied: time.Now()}
+	if sum := checksum(written); sum != config.Checksum {
+		config.Accepted = sum
+	}
+
+	configs[path] = config
+
+	return c.store(configs)
+}
+
+// store writes the record of managed configuration files.
+func (c *ConfigFiles) store(configs map[string]domain.ManagedConfig) error {
+	data, err := json.MarshalIndent(configs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode managed configs: %w", err)
+	}
+
+	if err := c.files.WriteFile(c.indexPath(), data); err != nil {
+		return fmt.Errorf("failed to write managed configs: %w", err)
+	}
+
+	return nil
+}
+
+func (c *ConfigFiles) load() (map[string]domain.ManagedConfig, error) {
+	configs := make(map[string]domain.ManagedConfig)
+
+	if !c.files.FileExists(c.indexPath()) {
+		return configs, nil
+	}
+
+	data, err := c.files.ReadFile(c.indexPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read managed configs: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse managed configs: %w", err)
+	}
+
+	return configs, nil
+}
+
+func (c *ConfigFiles) indexPath() string {
+	return filepath.Join(c.dir, "index.json")
+}
+
+// basePath returns where the content karei wrote to path is kept.
+func (c *ConfigFiles) basePath(path string) string {
+	return filepath.Join(c.dir, checksum([]byte(path))[:16]+".base")
+}
+
+// checksum returns the hex SHA-256 of data.
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// splitLines splits content into lines, so joining them restores it.
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+
+	return strings.Split(string(content), "\n")
+}
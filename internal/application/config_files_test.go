@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFiles_CheckAndResolve(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fileManager := platform.NewFileManager(false)
+	configs := application.NewConfigFiles(fileManager, filepath.Join(dir, "state"))
+	btop := filepath.Join(dir, "btop.conf")
+	theme := filepath.Join(dir, "nord.theme")
+
+	_, err := configs.Write(btop, []byte("color_theme = \"nord\"\nupdate_ms = 2000\n"), "")
+	require.NoError(t, err)
+	_, err = configs.Write(theme, []byte("theme[main_bg]=\"#2E3440\"\n"), "")
+	require.NoError(t, err)
+
+	drifts, err := configs.Check()
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+
+	require.NoError(t, fileManager.WriteFile(btop, []byte("color_theme = \"nord\"\nupdate_ms = 500\n")))
+	require.NoError(t, fileManager.RemoveFile(theme))
+
+	drifts, err = configs.Check()
+	require.NoError(t, err)
+	require.Len(t, drifts, 2)
+	assert.Equal(t, btop, drifts[0].Config.Path)
+	assert.Equal(t, domain.ArtifactModified, drifts[0].State)
+	assert.Equal(t, domain.ArtifactMissing, drifts[1].State)
+
+	// Overwriting keeps the user's version next to the file
+	resolved, err := configs.Resolve(btop, domain.ResolveOverwrite)
+	require.NoError(t, err)
+	assert.Equal(t, btop+".karei-save", resolved.Saved)
+
+	saved, err := fileManager.ReadFile(resolved.Saved)
+	require.NoError(t, err)
+	assert.Contains(t, string(saved), "update_ms = 500")
+
+	_, err = configs.Resolve(theme, domain.ResolveMerge)
+	require.NoError(t, err)
+
+	drifts, err = configs.Check()
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+
+	_, err = configs.Resolve(filepath.Join(dir, "other.conf"), domain.ResolveMerge)
+	require.ErrorIs(t, err, application.ErrUnmanagedConfig)
+}
+
+func TestConfigFiles_WriteMergesUserChanges(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fileManager := platform.NewFileManager(false)
+	configs := application.NewConfigFiles(fileManager, filepath.Join(dir, "state"))
+	btop := filepath.Join(dir, "btop.conf")
+
+	_, err := configs.Write(btop, []byte("color_theme = \"nord\"\ntheme_background = true\nupdate_ms = 2000\n"), "")
+	require.NoError(t, err)
+	require.NoError(t, fileManager.WriteFile(btop, []byte("color_theme = \"nord\"\ntheme_background = true\nupdate_ms = 500\n")))
+
+	// Applying another theme keeps the user's change to another line
+	saved, err := configs.Write(btop, []byte("color_theme = \"gruvbox\"\ntheme_background = true\nupdate_ms = 2000\n"), "")
+	require.NoError(t, err)
+	assert.Empty(t, saved)
+
+	data, err := fileManager.ReadFile(btop)
+	require.NoError(t, err)
+	assert.Equal(t, "color_theme = \"gruvbox\"\ntheme_background = true\nupdate_ms = 500\n", string(data))
+
+	// A change to the same line conflicts: the user's version is saved aside
+	require.NoError(t, fileManager.WriteFile(btop, []byte("color_theme = \"custom\"\ntheme_background = true\nupdate_ms = 500\n")))
+
+	saved, err = configs.Write(btop, []byte("color_theme = \"nord\"\ntheme_background = true\nupdate_ms = 2000\n"), "")
+	require.NoError(t, err)
+	assert.Equal(t, btop+".karei-save", saved)
+
+	data, err = fileManager.ReadFile(btop)
+	require.NoError(t, err)
+	assert.Equal(t, "color_theme = \"nord\"\ntheme_background = true\nupdate_ms = 2000\n", string(data))
+}
+
+func TestConfigFiles_WriteAsksBeforeOverwriting(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fileManager := platform.NewFileManager(false)
+	configs := application.NewConfigFiles(fileManager, filepath.Join(dir, "state"))
+	alacritty := filepath.Join(dir, "alacritty.toml")
+	nord := []byte("[colors.primary]\nbackground = \"#2E3440\"\n")
+
+	// A file karei never wrote is the user's
+	require.NoError(t, fileManager.WriteFile(alacritty, []byte("[font]\nsize = 14\n")))
+
+	var asked []domain.ConfigConflict
+
+	resolution := domain.ConfigResolution("")
+	configs.SetConflictResolver(func(conflict domain.ConfigConflict) domain.ConfigResolution {
+		asked = append(asked, conflict)
+
+		return resolution
+	})
+
+	// Without a decision the file is left alone, and asked about again
+	_, err := configs.Write(alacritty, nord, "")
+	require.NoError(t, err)
+	require.Len(t, asked, 1)
+	assert.False(t, asked[0].Managed)
+	assert.Contains(t, asked[0].Diff, "-size = 14")
+
+	resolution = domain.ResolveKeep
+	_, err = configs.Write(alacritty, nord, "")
+	require.NoError(t, err)
+	assert.Len(t, asked, 2)
+
+	current, err := fileManager.ReadFile(alacritty)
+	require.NoError(t, err)
+	assert.Equal(t, "[font]\nsize = 14\n", string(current))
+
+	// The kept file isn't asked about when the same content is applied again
+	_, err = configs.Write(alacritty, nord, "")
+	require.NoError(t, err)
+	assert.Len(t, asked, 2)
+
+	// Other content is, and overwriting saves the user's file first
+	resolution = domain.ResolveOverwrite
+	saved, err := configs.Write(alacritty, []byte("[colors.primary]\nbackground = \"#282828\"\n"), "")
+	require.NoError(t, err)
+	assert.Len(t, asked, 3)
+	assert.True(t, asked[2].Managed)
+	assert.Equal(t, alacritty+".karei-save", saved)
+
+	current, err = fileManager.ReadFile(alacritty)
+	require.NoError(t, err)
+	assert.Contains(t, string(current), "#282828")
+}
+
+func TestConfigFiles_ResolveKeep(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fileManager := platform.NewFileManager(false)
+	configs := application.NewConfigFiles(fileManager, filepath.Join(dir, "state"))
+	btop := filepath.Join(dir, "btop.conf")
+	theme := filepath.Join(dir, "nord.theme")
+
+	_, err := configs.Write(btop, []byte("update_ms = 2000\n"), "")
+	require.NoError(t, err)
+	_, err = configs.Write(theme, []byte("theme[main_bg]=\"#2E3440\"\n"), "")
+	require.NoError(t, err)
+
+	require.NoError(t, fileManager.WriteFile(btop, []byte("update_ms = 500\n")))
+	require.NoError(t, fileManager.RemoveFile(theme))
+
+	for _, path := range []string{btop, theme} {
+		_, err = configs.Resolve(path, domain.ResolveKeep)
+		require.NoError(t, err)
+	}
+
+	// The change is kept and the removed file is no longer managed
+	drifts, err := configs.Check()
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+	assert.NoFileExists(t, theme)
+
+	current, err := fileManager.ReadFile(btop)
+	require.NoError(t, err)
+	assert.Equal(t, "update_ms = 500\n", string(current))
+}
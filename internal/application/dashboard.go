@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// Dashboard summarizes what karei manages on the machine.
+type Dashboard struct {
+	Installed map[domain.InstallMethod]int      // Apps karei installed, by method
+	Updates   map[string]domain.OutdatedPackage // Catalog apps with a newer version, by key
+	DiskUsage int64                             // Bytes taken by the apps karei installed
+	Theme     string
+	Font      string
+	LastRun   *JournalRun // Nil before the first run
+	Err       error       // Why the install records or journal couldn't be read
+}
+
+// InstalledCount returns how many apps karei installed.
+func (d *Dashboard) InstalledCount() int {
+	total := 0
+	for _, count := range d.Installed {
+		total += count
+	}
+
+	return total
+}
+
+// DashboardService gathers the Dashboard from the install records, the
+// journal, the package managers and the desktop settings.
+type DashboardService struct {
+	packages *PackageManager
+	records  *InstallRecords
+	journal  *Journal
+	themes   *ThemeService
+	fonts    *FontService
+}
+
+// NewDashboardService creates a dashboard service. The theme and font are
+// left out unless their services are set.
+func NewDashboardService(packages *PackageManager, records *InstallRecords, journal *Journal) *DashboardService {
+	return &DashboardService{packages: packages, records: records, journal: journal}
+}
+
+// SetThemeService sets the service reporting the current theme.
+func (s *DashboardService) SetThemeService(themes *ThemeService) {
+	s.themes = themes
+}
+
+// SetFontService sets the service reporting the current font.
+func (s *DashboardService) SetFontService(fonts *FontService) {
+	s.fonts = fonts
+}
+
+// Gather collects the dashboard. Parts that can't be read are left empty.
+func (s *DashboardService) Gather(ctx context.Context) *Dashboard {
+	dashboard := &Dashboard{Installed: map[domain.InstallMethod]int{}}
+
+	records, err := s.records.Load()
+	if err != nil {
+		dashboard.Err = err
+	}
+
+	keys := make([]string, 0, len(records))
+	for key, record := range records {
+		dashboard.Installed[record.Method]++
+
+		keys = append(keys, key)
+	}
+
+	dashboard.Updates = s.packages.Outdated(ctx)
+	dashboard.DiskUsage = s.packages.DiskUsage(ctx, keys)
+
+	if s.themes != nil {
+		dashboard.Theme = s.themes.CurrentTheme()
+	}
+
+	if s.fonts != nil {
+		dashboard.Font = s.fonts.CurrentFont(ctx)
+	}
+
+	run, err := s.journal.Last()
+	if err == nil {
+		dashboard.LastRun = run
+	} else if !errors.Is(err, ErrNoJournal) {
+		dashboard.Err = errors.Join(dashboard.Err, err)
+	}
+
+	return dashboard
+}
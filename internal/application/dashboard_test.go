@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardServiceGather(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := platform.NewFileManager(false)
+
+	records := application.NewInstallRecords(files, filepath.Join(dir, "installs.json"))
+	require.NoError(t, records.Record("vlc", application.InstallRecord{Method: domain.MethodAPT, Source: "vlc"}))
+	require.NoError(t, records.Record("gimp", application.InstallRecord{Method: domain.MethodFlatpak, Source: "org.gimp.GIMP"}))
+	require.NoError(t, records.Record("spotify", application.InstallRecord{Method: domain.MethodFlatpak, Source: "com.spotify.Client"}))
+
+	journal := application.NewJournal(files, filepath.Join(dir, "journal.json"))
+	installer := &updatingInstaller{
+		MockPackageInstaller: new(testutil.MockPackageInstaller),
+		outdated:             []domain.OutdatedPackage{{Method: domain.MethodAPT, Name: "vlc", Available: "3.0.21"}},
+	}
+
+	packages := application.NewPackageManager(installer, nil, false)
+	packages.SetInstallRecords(records)
+
+	service := application.NewDashboardService(packages, records, journal)
+
+	dashboard := service.Gather(context.Background())
+	require.NoError(t, dashboard.Err)
+	assert.Equal(t, map[domain.InstallMethod]int{domain.MethodAPT: 1, domain.MethodFlatpak: 2}, dashboard.Installed)
+	assert.Equal(t, 3, dashboard.InstalledCount())
+	assert.Len(t, dashboard.Updates, 1)
+	assert.Contains(t, dashboard.Updates, "vlc")
+	assert.Equal(t, int64(3000), dashboard.DiskUsage)
+	assert.Nil(t, dashboard.LastRun, "nothing run yet")
+	assert.Empty(t, dashboard.Theme)
+
+	run := application.NewJournalRun()
+	run.Add("vlc", application.OperationInstall, nil)
+	require.NoError(t, journal.Record(run))
+
+	themeFile := filepath.Join(dir, "theme")
+	require.NoError(t, os.WriteFile(themeFile, []byte("nord\n"), 0o600))
+
+	themes := application.NewThemeService(files, nil, "", "")
+	themes.SetCurrentThemeFile(themeFile)
+	service.SetThemeService(themes)
+
+	dashboard = service.Gather(context.Background())
+	require.NotNil(t, dashboard.LastRun)
+	assert.Len(t, dashboard.LastRun.Entries, 1)
+	assert.Equal(t, "nord", dashboard.Theme)
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// DesktopService applies the desktop tweaks that fit the session karei runs
+// in, Wayland or X11, skipping those that don't.
+type DesktopService struct {
+	fileManager   domain.FileManager
+	commandRunner domain.CommandRunner
+	homeDir       string
+	configs       *ConfigFiles // Records the configuration files written when set
+}
+
+// NewDesktopService creates a service writing tweak files below homeDir.
+func NewDesktopService(fm domain.FileManager, cr domain.CommandRunner, homeDir string) *DesktopService {
+	return &DesktopService{fileManager: fm, commandRunner: cr, homeDir: homeDir}
+}
+
+// SetConfigFiles sets where the files tweaks write are recorded, so later
+// changes to them are found and merged. Nil writes them as they are.
+func (s *DesktopService) SetConfigFiles(configs *ConfigFiles) {
+	s.configs = configs
+}
+
+// Plan returns how each tweak applies in session, without changing anything.
+func (s *DesktopService) Plan(session domain.SessionType) []domain.TweakPlan {
+	tweaks := domain.DesktopTweaks()
+	plans := make([]domain.TweakPlan, 0, len(tweaks))
+
+	for _, tweak := range tweaks {
+		plans = append(plans, domain.PlanTweak(tweak, session))
+	}
+
+	return plans
+}
+
+// Apply applies the tweaks fitting session and returns every plan. It stops
+// at the first tweak failing.
+func (s *DesktopService) Apply(ctx context.Context, session domain.SessionType) ([]domain.TweakPlan, error) {
+	plans := s.Plan(session)
+
+	for _, tweak := range domain.DesktopTweaks() {
+		variant, ok := tweak.Variants[session]
+		if !ok {
+			continue
+		}
+
+		if err := s.applyVariant(ctx, variant); err != nil {
+			return plans, fmt.Errorf("failed to apply %s: %w", tweak.Name, err)
+		}
+	}
+
+	return plans, nil
+}
+
+// applyVariant writes the settings and files of a tweak.
+func (s *DesktopService) applyVariant(ctx context.Context, variant domain.TweakVariant) error {
+	for _, setting := range variant.Settings {
+		if err := s.commandRunner.Execute(ctx, "gsettings", "set", setting.Schema, setting.Key, setting.Value); err != nil {
+			return fmt.Errorf("failed to set %s.%s: %w", setting.Schema, setting.Key, err)
+		}
+	}
+
+	for name, content := range variant.Files {
+		path := filepath.Join(s.homeDir, name)
+		if err := s.fileManager.EnsureDir(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+		}
+
+		if err := s.writeConfig(path, []byte(content)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// writeConfig writes a file a tweak generated, recording it when
+// configuration files are.
+func (s *DesktopService) writeConfig(path string, data []byte) error {
+	if s.configs == nil {
+		return s.fileManager.WriteFile(path, data)
+	}
+
+	_, err := s.configs.Write(path, data, "")
+
+	return err
+}
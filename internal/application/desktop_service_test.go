@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDesktopService_Apply(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	runner := &testutil.MockCommandRunner{}
+	runner.On("Execute", mock.Anything, "gsettings", "set", "org.gnome.mutter", "experimental-features", "['x11-randr-fractional-scaling']").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "gsettings", "set", "org.gnome.desktop.peripherals.touchpad", mock.Anything, "true").Return(nil).Twice()
+
+	service := application.NewDesktopService(platform.NewFileManager(false), runner, home)
+
+	plans, err := service.Apply(context.Background(), domain.SessionX11)
+	require.NoError(t, err)
+	runner.AssertExpectations(t)
+
+	// The Wayland-only tweak is skipped and writes nothing
+	require.Len(t, plans, 3)
+	assert.True(t, plans[2].Skipped)
+	assert.NoDirExists(t, filepath.Join(home, ".config", "environment.d"))
+}
+
+func TestDesktopService_ApplyWaylandFiles(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	runner := &testutil.MockCommandRunner{}
+	runner.On("Execute", mock.Anything, "gsettings", "set", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	service := application.NewDesktopService(platform.NewFileManager(false), runner, home)
+
+	_, err := service.Apply(context.Background(), domain.SessionWayland)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(home, ".config", "environment.d", "60-karei-wayland.conf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "ELECTRON_OZONE_PLATFORM_HINT=auto")
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// DefaultDetailsTTL is how long fetched app details are reused before refreshing.
+const DefaultDetailsTTL = 24 * time.Hour
+
+// DetailsService combines catalog data with upstream details, caching the
+// upstream part on disk so the detail view opens instantly and works offline.
+type DetailsService struct {
+	source   domain.AppDetailsSource
+	files    domain.FileManager
+	cacheDir string
+	ttl      time.Duration
+	channels domain.ReleaseChannels
+}
+
+// NewDetailsService creates a details service caching under cacheDir.
+func NewDetailsService(source domain.AppDetailsSource, files domain.FileManager, cacheDir string) *DetailsService {
+	return &DetailsService{source: source, files: files, cacheDir: cacheDir, ttl: DefaultDetailsTTL}
+}
+
+// SetTTL changes how long cached details stay fresh.
+func (s *DetailsService) SetTTL(ttl time.Duration) {
+	s.ttl = ttl
+}
+
+// SetReleaseChannels sets the GitHub release channel subscribed to per app.
+func (s *DetailsService) SetReleaseChannels(channels domain.ReleaseChannels) {
+	s.channels = channels
+}
+
+// Details returns everything known about a catalog app. The catalog part is
+// always returned; the error reports a failed upstream fetch with no cache to fall back on.
+func (s *DetailsService) Details(ctx context.Context, appKey string) (*domain.AppDetails, error) {
+	app, exists := apps.Apps[appKey]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownApp, appKey)
+	}
+
+	details := &domain.AppDetails{
+		Key:     appKey,
+		Name:    app.Name,
+		Summary: app.Description,
+		Method:  app.Method,
+		Source:  app.Source,
+	}
+
+	channel := s.channels.For(appKey)
+	if app.FromGitHub() {
+		details.Channel = channel
+	}
+
+	cached := s.readCache(appKey, channel)
+	if cached != nil && time.Since(cached.FetchedAt) < s.ttl {
+		details.Merge(cached)
+
+		return details, nil
+	}
+
+	upstream, err := s.fetch(ctx, app, channel)
+
+	switch {
+	case upstream != nil:
+		s.writeCache(appKey, channel, upstream)
+		details.Merge(upstream)
+	case cached != nil:
+		// Stale details beat none when offline
+		details.Merge(cached)
+
+		return details, nil
+	}
+
+	return details, err
+}
+
+// fetch queries upstream for every way the app can be installed and merges the answers.
+func (s *DetailsService) fetch(ctx context.Context, app apps.App, channel domain.ReleaseChannel) (*domain.AppDetails, error) {
+	candidates := []*domain.Package{{Name: app.Name, Method: app.Method, Source: app.Source, Channel: channel}}
+	for _, alt := range app.Alternatives {
+		candidates = append(candidates, &domain.Package{Name: app.Name, Method: alt.Method, Source: alt.Source, Channel: channel})
+	}
+
+	var (
+		merged  *domain.AppDetails
+		lastErr error
+	)
+
+	for _, pkg := range candidates {
+		upstream, err := s.source.FetchDetails(ctx, pkg)
+
+		switch {
+		case errors.Is(err, domain.ErrNoUpstreamDetails):
+			continue
+		case err != nil:
+			lastErr = err
+
+			continue
+		}
+
+		if merged == nil {
+			merged = &domain.AppDetails{}
+		}
+
+		merged.Merge(upstream)
+	}
+
+	if merged != nil {
+		return merged, nil
+	}
+
+	return nil, lastErr
+}
+
+// cachePath returns where the upstream details of an app are cached. Details
+// picked from the prerelease channel are kept apart from stable ones.
+func (s *DetailsService) cachePath(appKey string, channel domain.ReleaseChannel) string {
+	if channel == domain.ChannelPrerelease {
+		return filepath.Join(s.cacheDir, appKey+"."+string(channel)+".json")
+	}
+
+	return filepath.Join(s.cacheDir, appKey+".json")
+}
+
+// readCache returns cached upstream details, or nil when there are none.
+func (s *DetailsService) readCache(appKey string, channel domain.ReleaseChannel) *domain.AppDetails {
+	path := s.cachePath(appKey, channel)
+	if !s.files.FileExists(path) {
+		return nil
+	}
+
+	data, err := s.files.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var details domain.AppDetails
+	if err := json.Unmarshal(data, &details); err != nil {
+		return nil
+	}
+
+	return &details
+}
+
+// writeCache stores upstream details. A failing cache never fails the lookup.
+func (s *DetailsService) writeCache(appKey string, channel domain.ReleaseChannel, details *domain.AppDetails) {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return
+	}
+
+	if err := s.files.EnsureDir(s.cacheDir); err != nil {
+		return
+	}
+
+	_ = s.files.WriteFile(s.cachePath(appKey, channel), data)
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubDetailsSource answers with canned details per install method.
+type stubDetailsSource struct {
+	details map[domain.InstallMethod]*domain.AppDetails
+	err     error
+	calls   int
+}
+
+func (s *stubDetailsSource) FetchDetails(_ context.Context, pkg *domain.Package) (*domain.AppDetails, error) {
+	s.calls++
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if details, ok := s.details[pkg.Method]; ok {
+		upstream := *details
+		if pkg.Channel == domain.ChannelPrerelease {
+			upstream.LatestRelease += "-rc1"
+		}
+
+		return &upstream, nil
+	}
+
+	return nil, domain.ErrNoUpstreamDetails
+}
+
+func TestDetailsServiceMergesAlternativesAndCaches(t *testing.T) {
+	t.Parallel()
+
+	source := &stubDetailsSource{details: map[domain.InstallMethod]*domain.AppDetails{
+		domain.MethodFlatpak: {License: "GPL-2.0+", Homepage: "https://www.videolan.org", FetchedAt: time.Now()},
+	}}
+	service := application.NewDetailsService(source, platform.NewFileManager(false), t.TempDir())
+
+	// vlc is apt by default with a flatpak alternative that has upstream metadata
+	details, err := service.Details(context.Background(), "vlc")
+	require.NoError(t, err)
+	assert.Equal(t, "VLC Media Player", details.Name)
+	assert.Equal(t, domain.MethodAPT, details.Method)
+	assert.Equal(t, "GPL-2.0+", details.License)
+
+	calls := source.calls
+
+	cached, err := service.Details(context.Background(), "vlc")
+	require.NoError(t, err)
+	assert.Equal(t, "https://www.videolan.org", cached.Homepage)
+	assert.Equal(t, calls, source.calls, "fresh cache avoids refetching")
+}
+
+func TestDetailsServiceReleaseChannel(t *testing.T) {
+	t.Parallel()
+
+	source := &stubDetailsSource{details: map[domain.InstallMethod]*domain.AppDetails{
+		domain.MethodGitHubBinary: {LatestRelease: "v2025.9.0", FetchedAt: time.Now()},
+	}}
+	dir := t.TempDir()
+
+	stable := application.NewDetailsService(source, platform.NewFileManager(false), dir)
+
+	details, err := stable.Details(context.Background(), "mise")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ChannelStable, details.Channel)
+	assert.Equal(t, "v2025.9.0", details.LatestRelease)
+
+	// Switching channel must not serve the stable release from the cache
+	prerelease := application.NewDetailsService(source, platform.NewFileManager(false), dir)
+	prerelease.SetReleaseChannels(domain.ReleaseChannels{"mise": domain.ChannelPrerelease})
+
+	details, err = prerelease.Details(context.Background(), "mise")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ChannelPrerelease, details.Channel)
+	assert.Equal(t, "v2025.9.0-rc1", details.LatestRelease)
+
+	// Apps not released on GitHub have no channel
+	details, err = prerelease.Details(context.Background(), "vlc")
+	require.NoError(t, err)
+	assert.Empty(t, details.Channel)
+}
+
+func TestDetailsServiceFallsBackWhenOffline(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	source := &stubDetailsSource{details: map[domain.InstallMethod]*domain.AppDetails{
+		domain.MethodFlatpak: {License: "GPL-2.0+", FetchedAt: time.Now()},
+	}}
+
+	service := application.NewDetailsService(source, platform.NewFileManager(false), dir)
+	_, err := service.Details(context.Background(), "vlc")
+	require.NoError(t, err)
+
+	// Expire the cache and go offline: stale details are still served
+	source.err = domain.NewKindError(domain.ErrorKindNetwork, errors.New("no route to host"))
+	service.SetTTL(0)
+
+	details, err := service.Details(context.Background(), "vlc")
+	require.NoError(t, err)
+	assert.Equal(t, "GPL-2.0+", details.License)
+
+	// Without any cache the catalog part comes back with the error
+	empty := application.NewDetailsService(source, platform.NewFileManager(false), t.TempDir())
+
+	details, err = empty.Details(context.Background(), "vlc")
+	require.ErrorIs(t, err, domain.ErrNetworkFailure)
+	assert.Equal(t, "VLC Media Player", details.Name)
+
+	_, err = empty.Details(context.Background(), "no-such-app")
+	require.ErrorIs(t, err, application.ErrUnknownApp)
+}
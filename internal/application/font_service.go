@@ -23,6 +23,7 @@ type FontService struct {
 	fileManager   domain.FileManager
 	commandRunner domain.CommandRunner
 	networkClient domain.NetworkClient
+	events        domain.EventPublisher
 	fontsDir      string
 	configDir     string
 }
@@ -33,11 +34,17 @@ func NewFontService(fm domain.FileManager, cr domain.CommandRunner, nc domain.Ne
 		fileManager:   fm,
 		commandRunner: cr,
 		networkClient: nc,
+		events:        domain.NoopEventPublisher{},
 		fontsDir:      fontsDir,
 		configDir:     configDir,
 	}
 }
 
+// SetEventPublisher sets the publisher notified when the system font changes.
+func (s *FontService) SetEventPublisher(publisher domain.EventPublisher) {
+	s.events = publisher
+}
+
 // FontConfig represents a font configuration.
 type FontConfig struct {
 	Name     string
@@ -153,9 +160,42 @@ func (s *FontService) ApplySystemFont(ctx context.Context, fontName string) erro
 	}
 
 	// Apply to system monospace font
-	return s.commandRunner.Execute(ctx, "gsettings", "set",
+	if err := s.commandRunner.Execute(ctx, "gsettings", "set",
 		"org.gnome.desktop.interface", "monospace-font-name",
-		fmt.Sprintf("'%s 11'", font.FullName))
+		fmt.Sprintf("'%s 11'", font.FullName)); err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, domain.NewEvent(domain.EventFontChanged, fontName))
+
+	return nil
+}
+
+// CurrentFont returns the name of the karei font set as the system
+// monospace font, the family set when it isn't one of karei's, or "" when it
+// can't be read.
+func (s *FontService) CurrentFont(ctx context.Context) string {
+	output, err := s.commandRunner.ExecuteWithOutput(ctx, "gsettings", "get",
+		"org.gnome.desktop.interface", "monospace-font-name")
+	if err != nil {
+		return ""
+	}
+
+	// "'JetBrainsMono Nerd Font 11'" without quotes and size
+	family := strings.Trim(strings.TrimSpace(output), "'")
+	if i := strings.LastIndex(family, " "); i > 0 {
+		if _, err := strconv.Atoi(family[i+1:]); err == nil {
+			family = family[:i]
+		}
+	}
+
+	for name, font := range s.GetAvailableFonts() {
+		if font.FullName == family {
+			return name
+		}
+	}
+
+	return family
 }
 
 // IncreaseFontSize increases the system font size.
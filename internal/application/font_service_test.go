@@ -388,3 +388,20 @@ func TestFontService_GetFont(t *testing.T) {
 		})
 	}
 }
+
+func TestFontService_CurrentFont(t *testing.T) {
+	t.Parallel()
+
+	for output, want := range map[string]string{
+		"'JetBrainsMono Nerd Font 11'\n": "JetBrainsMono",
+		"'Ubuntu Mono 13'":               "Ubuntu Mono",
+	} {
+		mockCR := new(testutil.MockCommandRunner)
+		mockCR.On("ExecuteWithOutput", mock.Anything, "gsettings", "get",
+			"org.gnome.desktop.interface", "monospace-font-name").Return(output, nil)
+
+		service := application.NewFontService(new(testutil.MockFileManager), mockCR, new(testutil.MockNetworkClient), "", "")
+
+		assert.Equal(t, want, service.CurrentFont(context.Background()))
+	}
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// maxSnapshots bounds the history; the oldest snapshots go first.
+const maxSnapshots = 500
+
+// History keeps a snapshot of what karei installed and configured each time
+// that changed, so what changed over a period can be told later.
+type History struct {
+	files domain.FileManager
+	path  string
+}
+
+// NewHistory creates a history stored at path.
+func NewHistory(files domain.FileManager, path string) *History {
+	return &History{files: files, path: path}
+}
+
+// SnapshotOf returns the state held in the install records and managed
+// configuration files, taken at taken.
+func SnapshotOf(taken time.Time, records map[string]InstallRecord, configs map[string]domain.ManagedConfig) domain.Snapshot {
+	snapshot := domain.NewSnapshot(taken)
+
+	for app, record := range records {
+		snapshot.Packages[app] = domain.SnapshotPackage{
+			Method:    record.Method,
+			Source:    record.Source,
+			Version:   record.Version,
+			Installed: record.Installed.UTC().Truncate(time.Second),
+		}
+	}
+
+	for path, config := range configs {
+		snapshot.Configs[path] = config.Checksum
+	}
+
+	return snapshot
+}
+
+// Capture stores snapshot unless it holds the same state as the latest one,
+// reporting whether it did. Nothing installed before any snapshot isn't
+// stored either.
+func (h *History) Capture(snapshot domain.Snapshot) (bool, error) {
+	snapshots, err := h.List()
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case len(snapshots) == 0 && snapshot.Empty():
+		return false, nil
+	case len(snapshots) > 0 && snapshots[len(snapshots)-1].SameState(snapshot):
+		return false, nil
+	}
+
+	// A second snapshot within the same second replaces the first
+	if len(snapshots) > 0 && snapshots[len(snapshots)-1].ID == snapshot.ID {
+		snapshots = snapshots[:len(snapshots)-1]
+	}
+
+	snapshots = append(snapshots, snapshot)
+	if len(snapshots) > maxSnapshots {
+		snapshots = snapshots[len(snapshots)-maxSnapshots:]
+	}
+
+	return true, h.save(snapshots)
+}
+
+// List returns every snapshot, oldest first. Nothing recorded yet is not an
+// error.
+func (h *History) List() ([]domain.Snapshot, error) {
+	if !h.files.FileExists(h.path) {
+		return nil, nil
+	}
+
+	data, err := h.files.ReadFile(h.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var snapshots []domain.Snapshot
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return nil, fmt.Errorf("failed to parse history: %w", err)
+	}
+
+	slices.SortStableFunc(snapshots, func(a, b domain.Snapshot) int { return a.Taken.Compare(b.Taken) })
+
+	return snapshots, nil
+}
+
+// Get returns the snapshot with id.
+func (h *History) Get(id string) (domain.Snapshot, error) {
+	snapshots, err := h.List()
+	if err != nil {
+		return domain.Snapshot{}, err
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshot.ID == id {
+			return snapshot, nil
+		}
+	}
+
+	return domain.Snapshot{}, fmt.Errorf("%w: %s", domain.ErrSnapshotNotFound, id)
+}
+
+// At returns the state at when: the latest snapshot taken at or before it,
+// or an empty one taken at when if karei had recorded nothing yet.
+func (h *History) At(when time.Time) (domain.Snapshot, error) {
+	snapshots, err := h.List()
+	if err != nil {
+		return domain.Snapshot{}, err
+	}
+
+	state := domain.NewSnapshot(when)
+
+	for _, snapshot := range snapshots {
+		if snapshot.Taken.After(when) {
+			break
+		}
+
+		state = snapshot
+	}
+
+	return state, nil
+}
+
+func (h *History) save(snapshots []domain.Snapshot) error {
+	data, err := json.Marshal(snapshots)
+	if err != nil {
+		return fmt.Errorf("failed to encode history: %w", err)
+	}
+
+	if err := h.files.EnsureDir(filepath.Dir(h.path)); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	if err := h.files.WriteFile(h.path, data); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory_CaptureAndAt(t *testing.T) {
+	t.Parallel()
+
+	history := application.NewHistory(platform.NewFileManager(false), filepath.Join(t.TempDir(), "state", "history.json"))
+
+	january := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	march := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	captured, err := history.Capture(domain.NewSnapshot(january))
+	require.NoError(t, err)
+	assert.False(t, captured, "nothing installed yet is not worth a snapshot")
+
+	records := map[string]application.InstallRecord{
+		"git": {Method: domain.MethodAPT, Source: "git", Installed: january.Add(time.Minute)},
+	}
+	configs := map[string]domain.ManagedConfig{
+		"/home/u/.gitconfig": {Path: "/home/u/.gitconfig", Checksum: "aaa"},
+	}
+
+	captured, err = history.Capture(application.SnapshotOf(january, records, configs))
+	require.NoError(t, err)
+	assert.True(t, captured)
+
+	// The same state later adds nothing
+	captured, err = history.Capture(application.SnapshotOf(march.Add(-time.Hour), records, configs))
+	require.NoError(t, err)
+	assert.False(t, captured)
+
+	records["docker"] = application.InstallRecord{Method: domain.MethodAPT, Source: "docker-ce", Installed: march}
+
+	captured, err = history.Capture(application.SnapshotOf(march, records, configs))
+	require.NoError(t, err)
+	assert.True(t, captured)
+
+	snapshots, err := history.List()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, "20250101-090000", snapshots[0].ID)
+	assert.Equal(t, "20250301-120000", snapshots[1].ID)
+
+	february, err := history.At(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, snapshots[0].ID, february.ID)
+
+	before, err := history.At(january.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.True(t, before.Empty())
+
+	diff := domain.DiffSnapshots(february, snapshots[1])
+	require.Len(t, diff.Packages, 1)
+	assert.Equal(t, "docker", diff.Packages[0].App)
+	assert.Equal(t, domain.ChangeAdded, diff.Packages[0].Change)
+
+	got, err := history.Get("20250301-120000")
+	require.NoError(t, err)
+	assert.Len(t, got.Packages, 2)
+
+	_, err = history.Get("20240101-000000")
+	require.ErrorIs(t, err, domain.ErrSnapshotNotFound)
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// metricsWindow is how many installs the mean follows closely; older ones
+// fade out, so a faster mirror or machine shows in estimates soon.
+const metricsWindow = 5
+
+// DurationStat is the typical duration of one app installed with one method.
+type DurationStat struct {
+	Count int           `json:"count"`
+	Mean  time.Duration `json:"mean"`
+}
+
+// add folds d into the mean, weighting the last metricsWindow installs.
+func (s DurationStat) add(d time.Duration) DurationStat {
+	s.Count++
+	s.Mean += (d - s.Mean) / time.Duration(min(s.Count, metricsWindow))
+
+	return s
+}
+
+// InstallMetrics keeps how long successful installs took, by catalog key
+// and method, to estimate how long the next ones will take.
+type InstallMetrics struct {
+	files domain.FileManager
+	path  string
+}
+
+// NewInstallMetrics creates metrics stored at path.
+func NewInstallMetrics(files domain.FileManager, path string) *InstallMetrics {
+	return &InstallMetrics{files: files, path: path}
+}
+
+// Load returns the durations by app and method. Nothing recorded yet is not an error.
+func (m *InstallMetrics) Load() (map[string]map[domain.InstallMethod]DurationStat, error) {
+	stats := make(map[string]map[domain.InstallMethod]DurationStat)
+
+	if !m.files.FileExists(m.path) {
+		return stats, nil
+	}
+
+	data, err := m.files.ReadFile(m.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read install metrics: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("failed to parse install metrics: %w", err)
+	}
+
+	return stats, nil
+}
+
+// Record adds the duration of a successful install of app with method.
+func (m *InstallMetrics) Record(app string, method domain.InstallMethod, duration time.Duration) error {
+	stats, err := m.Load()
+	if err != nil {
+		return err
+	}
+
+	if stats[app] == nil {
+		stats[app] = make(map[domain.InstallMethod]DurationStat)
+	}
+
+	stats[app][method] = stats[app][method].add(duration)
+
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode install metrics: %w", err)
+	}
+
+	if err := m.files.EnsureDir(filepath.Dir(m.path)); err != nil {
+		return fmt.Errorf("failed to create install metrics directory: %w", err)
+	}
+
+	if err := m.files.WriteFile(m.path, data); err != nil {
+		return fmt.Errorf("failed to write install metrics: %w", err)
+	}
+
+	return nil
+}
+
+// Estimator returns estimates from the durations recorded so far.
+// Unreadable metrics give an estimator without history.
+func (m *InstallMetrics) Estimator() DurationEstimator {
+	stats, _ := m.Load()
+
+	return DurationEstimator{stats: stats}
+}
+
+// DurationEstimator estimates install durations from recorded history.
+type DurationEstimator struct {
+	stats map[string]map[domain.InstallMethod]DurationStat
+}
+
+// Estimate returns how long installing app with method is expected to take:
+// its own mean when it was installed that way before, else the mean of
+// other apps installed with method. It reports false without either.
+func (e DurationEstimator) Estimate(app string, method domain.InstallMethod) (time.Duration, bool) {
+	if stat, ok := e.stats[app][method]; ok {
+		return stat.Mean, true
+	}
+
+	var total time.Duration
+
+	count := 0
+
+	for _, methods := range e.stats {
+		if stat, ok := methods[method]; ok {
+			total += stat.Mean
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0, false
+	}
+
+	return total / time.Duration(count), true
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallMetricsEstimates(t *testing.T) {
+	t.Parallel()
+
+	metrics := application.NewInstallMetrics(platform.NewFileManager(false), filepath.Join(t.TempDir(), "metrics.json"))
+
+	_, ok := metrics.Estimator().Estimate("zed", domain.MethodFlatpak)
+	assert.False(t, ok, "nothing recorded yet")
+
+	require.NoError(t, metrics.Record("zed", domain.MethodFlatpak, 10*time.Second))
+	require.NoError(t, metrics.Record("zed", domain.MethodFlatpak, 20*time.Second))
+	require.NoError(t, metrics.Record("signal", domain.MethodFlatpak, 45*time.Second))
+
+	estimator := metrics.Estimator()
+
+	estimate, ok := estimator.Estimate("zed", domain.MethodFlatpak)
+	require.True(t, ok)
+	assert.Equal(t, 15*time.Second, estimate)
+
+	// Apps new to a method are estimated from the others installed with it
+	estimate, ok = estimator.Estimate("obs", domain.MethodFlatpak)
+	require.True(t, ok)
+	assert.Equal(t, 30*time.Second, estimate)
+
+	_, ok = estimator.Estimate("zed", domain.MethodSnap)
+	assert.False(t, ok)
+}
+
+func TestInstallMetricsFollowRecentInstalls(t *testing.T) {
+	t.Parallel()
+
+	metrics := application.NewInstallMetrics(platform.NewFileManager(false), filepath.Join(t.TempDir(), "metrics.json"))
+
+	for range 20 {
+		require.NoError(t, metrics.Record("zed", domain.MethodFlatpak, time.Minute))
+	}
+
+	for range 10 {
+		require.NoError(t, metrics.Record("zed", domain.MethodFlatpak, 10*time.Second))
+	}
+
+	estimate, ok := metrics.Estimator().Estimate("zed", domain.MethodFlatpak)
+	require.True(t, ok)
+	assert.Less(t, estimate, 20*time.Second, "old installs fade out")
+}
+
+func TestPackageManagerRecordsAndEstimatesDurations(t *testing.T) {
+	t.Parallel()
+
+	metrics := application.NewInstallMetrics(platform.NewFileManager(false), filepath.Join(t.TempDir(), "metrics.json"))
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.Anything).Return(&domain.InstallationResult{Success: true}, nil)
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetInstallMetrics(metrics)
+
+	_, known := manager.EstimateAll([]string{"zed"})
+	assert.Zero(t, known)
+
+	_, err := manager.Install(context.Background(), "zed")
+	require.NoError(t, err)
+
+	_, ok := manager.Estimate("zed")
+	assert.True(t, ok)
+
+	// rust installs with mise, which has no history yet
+	_, known = manager.EstimateAll([]string{"zed", "rust"})
+	assert.Equal(t, 1, known)
+}
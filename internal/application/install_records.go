@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// InstallRecord remembers how karei installed an app, so removal and status
+// use the method and scope actually used rather than today's preference.
+type InstallRecord struct {
+	Method    domain.InstallMethod `json:"method"`
+	Source    string               `json:"source"`
+	Scope     domain.InstallScope  `json:"scope"`
+	Version   string               `json:"version,omitempty"` // Version constraint the install was held to
+	Installed time.Time            `json:"installed"`
+}
+
+// NewInstallRecord records the installation of pkg now.
+func NewInstallRecord(pkg *domain.Package) InstallRecord {
+	scope := pkg.Scope
+	if scope == "" {
+		scope = pkg.Method.Scope()
+	}
+
+	return InstallRecord{Method: pkg.Method, Source: pkg.Source, Scope: scope, Installed: time.Now()}
+}
+
+// InstallRecords keeps the InstallRecord of every catalog app karei
+// installed, keyed by catalog key.
+type InstallRecords struct {
+	files domain.FileManager
+	path  string
+}
+
+// NewInstallRecords creates records stored at path.
+func NewInstallRecords(files domain.FileManager, path string) *InstallRecords {
+	return &InstallRecords{files: files, path: path}
+}
+
+// Load returns every record. Nothing recorded yet is not an error.
+func (r *InstallRecords) Load() (map[string]InstallRecord, error) {
+	records := make(map[string]InstallRecord)
+
+	if !r.files.FileExists(r.path) {
+		return records, nil
+	}
+
+	data, err := r.files.ReadFile(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read install records: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse install records: %w", err)
+	}
+
+	return records, nil
+}
+
+// Get returns the record of app, reporting false when there is none.
+func (r *InstallRecords) Get(app string) (InstallRecord, bool) {
+	records, err := r.Load()
+	if err != nil {
+		return InstallRecord{}, false
+	}
+
+	record, ok := records[app]
+
+	return record, ok
+}
+
+// Record stores how app was installed, replacing any earlier record.
+func (r *InstallRecords) Record(app string, record InstallRecord) error {
+	records, err := r.Load()
+	if err != nil {
+		return err
+	}
+
+	records[app] = record
+
+	return r.save(records)
+}
+
+// Forget deletes the record of a removed app.
+func (r *InstallRecords) Forget(app string) error {
+	records, err := r.Load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := records[app]; !ok {
+		return nil
+	}
+
+	delete(records, app)
+
+	return r.save(records)
+}
+
+func (r *InstallRecords) save(records map[string]InstallRecord) error {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode install records: %w", err)
+	}
+
+	if err := r.files.EnsureDir(filepath.Dir(r.path)); err != nil {
+		return fmt.Errorf("failed to create install records directory: %w", err)
+	}
+
+	if err := r.files.WriteFile(r.path, data); err != nil {
+		return fmt.Errorf("failed to write install records: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageManagerRecordsInstallScope(t *testing.T) {
+	t.Parallel()
+
+	records := application.NewInstallRecords(platform.NewFileManager(false), filepath.Join(t.TempDir(), "installed.json"))
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.Anything).Return(&domain.InstallationResult{Success: true}, nil)
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetInstallScope(domain.ScopeSystem)
+	manager.SetInstallRecords(records)
+
+	result := manager.InstallAll(context.Background(), []string{"zed", "rust"})
+	require.Equal(t, []string{"zed", "rust"}, result.Installed)
+
+	loaded, err := records.Load()
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScopeSystem, loaded["zed"].Scope)
+	assert.Equal(t, domain.MethodFlatpak, loaded["zed"].Method)
+
+	// mise has no system-wide install, so rust stays in the user's home
+	assert.Equal(t, domain.ScopeUser, loaded["rust"].Scope)
+	assert.Equal(t, domain.MethodMise, loaded["rust"].Method)
+}
+
+func TestUninstallServiceRemovesFromRecordedScope(t *testing.T) {
+	t.Parallel()
+
+	records := application.NewInstallRecords(platform.NewFileManager(false), filepath.Join(t.TempDir(), "installed.json"))
+	require.NoError(t, records.Record("vlc", application.InstallRecord{
+		Method: domain.MethodFlatpak, Source: "org.videolan.VLC", Scope: domain.ScopeUser,
+	}))
+
+	mockFM := new(testutil.MockFileManager)
+	mockFM.On("FileExists", mock.Anything).Return(false)
+
+	mockPI := new(testutil.MockPackageInstaller)
+	mockPI.On("Remove", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Method == domain.MethodFlatpak && pkg.Scope == domain.ScopeUser
+	})).Return(&domain.InstallationResult{Success: true}, nil).Once()
+
+	service := application.NewUninstallService(mockFM, new(testutil.MockCommandRunner), mockPI, false)
+	service.SetInstallRecords(records)
+
+	require.NoError(t, service.UninstallApp(context.Background(), "vlc"))
+	mockPI.AssertExpectations(t)
+
+	_, ok := records.Get("vlc")
+	assert.False(t, ok)
+}
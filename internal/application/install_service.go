@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/janderssonse/karei/internal/apps"
 	"github.com/janderssonse/karei/internal/domain"
@@ -17,7 +18,24 @@ import (
 type InstallService struct {
 	packageService *domain.PackageService
 	systemDetector domain.SystemDetector
-	appsManager    *apps.Manager
+	packages       *PackageManager
+	events         domain.EventPublisher
+	exporter       domain.ContainerExporter
+	preference     domain.MethodPreference
+	flatpak        domain.FlatpakScopes
+	policy         domain.InstallPolicy
+	records        *InstallRecords
+	manifests      *Manifests
+	metrics        *InstallMetrics
+	constraints    domain.VersionConstraints
+	versions       domain.VersionResolver
+	lockTimeout    time.Duration
+	aptFrontend    domain.APTFrontend
+	scope          domain.InstallScope
+	network        domain.Connectivity
+	kernel         domain.KernelInspector
+	note           func(note string)
+	output         func(line string)
 	verbose        bool
 }
 
@@ -26,15 +44,165 @@ func NewInstallService(packageService *domain.PackageService, systemDetector dom
 	return &InstallService{
 		packageService: packageService,
 		systemDetector: systemDetector,
-		appsManager:    apps.NewManager(false), // Non-verbose by default
+		packages:       NewPackageManager(apps.NewManager(false).PackageInstaller(), nil, false), // Non-verbose by default
+		events:         domain.NoopEventPublisher{},
 		verbose:        false,
 	}
 }
 
+// SetEventPublisher sets the publisher notified about completed installations.
+func (s *InstallService) SetEventPublisher(publisher domain.EventPublisher) {
+	s.events = publisher
+	s.packages.SetEventPublisher(publisher)
+}
+
+// SetPackageManager replaces the facade used for catalog installs.
+func (s *InstallService) SetPackageManager(packages *PackageManager) {
+	packages.SetEventPublisher(s.events)
+	packages.SetContainerExporter(s.exporter)
+	packages.SetMethodPreference(s.preference)
+	packages.SetFlatpakScopes(s.flatpak)
+	packages.SetInstallPolicy(s.policy)
+	packages.SetInstallScope(s.scope)
+	packages.SetInstallRecords(s.records)
+	packages.SetManifests(s.manifests)
+	packages.SetInstallMetrics(s.metrics)
+	packages.SetVersionConstraints(s.constraints)
+	packages.SetVersionResolver(s.versions)
+	packages.SetLockTimeout(s.lockTimeout)
+	packages.SetAPTFrontend(s.aptFrontend)
+	packages.SetConnectivity(s.network)
+	packages.SetKernelInspector(s.kernel)
+	packages.SetNoteFunc(s.note)
+	packages.SetOutputFunc(s.output)
+	s.packages = packages
+}
+
+// SetMethodPreference favors the given installation methods for catalog installs.
+func (s *InstallService) SetMethodPreference(preference domain.MethodPreference) {
+	s.preference = preference
+	s.packages.SetMethodPreference(preference)
+}
+
+// SetFlatpakScopes chooses between user and system Flatpak installations.
+func (s *InstallService) SetFlatpakScopes(scopes domain.FlatpakScopes) {
+	s.flatpak = scopes
+	s.packages.SetFlatpakScopes(scopes)
+}
+
+// SetInstallPolicy blocks catalog installs the workplace policy does not allow.
+func (s *InstallService) SetInstallPolicy(policy domain.InstallPolicy) {
+	s.policy = policy
+	s.packages.SetInstallPolicy(policy)
+}
+
+// SetLockTimeout sets how long installs wait for another process to release the
+// package manager lock. Zero uses the installer's default.
+func (s *InstallService) SetLockTimeout(timeout time.Duration) {
+	s.lockTimeout = timeout
+	s.packages.SetLockTimeout(timeout)
+}
+
+// SetOutputFunc passes what installers print to fn rather than the terminal,
+// for a progress display. Nil restores the terminal output.
+func (s *InstallService) SetOutputFunc(fn func(line string)) {
+	s.output = fn
+	s.packages.SetOutputFunc(fn)
+}
+
+// SetAPTFrontend routes APT installs through nala or apt-fast, as
+// detected for the configured apt_frontend.
+func (s *InstallService) SetAPTFrontend(frontend domain.APTFrontend) {
+	s.aptFrontend = frontend
+	s.packages.SetAPTFrontend(frontend)
+}
+
+// SetConnectivity skips catalog installs, rather than attempting them, when
+// a preflight check found the network unavailable.
+func (s *InstallService) SetConnectivity(network domain.Connectivity) {
+	s.network = network
+	s.packages.SetConnectivity(network)
+}
+
+// SetKernelInspector installs the running kernel's headers and DKMS before
+// catalog apps building kernel modules.
+func (s *InstallService) SetKernelInspector(kernel domain.KernelInspector) {
+	s.kernel = kernel
+	s.packages.SetKernelInspector(kernel)
+}
+
+// SetNoteFunc sets a function told what is left to do by hand after a
+// catalog install.
+func (s *InstallService) SetNoteFunc(fn func(note string)) {
+	s.note = fn
+	s.packages.SetNoteFunc(fn)
+}
+
+// SetInstallScope restricts catalog installs to the user or system scope.
+func (s *InstallService) SetInstallScope(scope domain.InstallScope) {
+	s.scope = scope
+	s.packages.SetInstallScope(scope)
+}
+
+// SetInstallRecords sets where the method and scope of catalog installs is kept.
+func (s *InstallService) SetInstallRecords(records *InstallRecords) {
+	s.records = records
+	s.packages.SetInstallRecords(records)
+}
+
+// SetManifests sets where the files created for catalog installs are listed.
+func (s *InstallService) SetManifests(manifests *Manifests) {
+	s.manifests = manifests
+	s.packages.SetManifests(manifests)
+}
+
+// SetInstallMetrics sets where install durations are kept for estimates.
+func (s *InstallService) SetInstallMetrics(metrics *InstallMetrics) {
+	s.metrics = metrics
+	s.packages.SetInstallMetrics(metrics)
+}
+
+// EstimateInstall returns how long installing the apps is expected to take
+// and how many of them, dependencies included, had history to go on.
+func (s *InstallService) EstimateInstall(appKeys []string) (time.Duration, int) {
+	return s.packages.EstimateAll(appKeys)
+}
+
+// EstimateDownload returns roughly how many bytes installing the apps,
+// dependencies included, downloads.
+func (s *InstallService) EstimateDownload(appKeys []string) int64 {
+	return s.packages.EstimateDownload(appKeys)
+}
+
+// SetVersionConstraints holds catalog installs to version constraints, e.g.
+// from a manifest, over those of the catalog.
+func (s *InstallService) SetVersionConstraints(constraints domain.VersionConstraints) {
+	s.constraints = constraints
+	s.packages.SetVersionConstraints(constraints)
+}
+
+// SetVersionResolver sets how installed versions are looked up to enforce
+// version constraints.
+func (s *InstallService) SetVersionResolver(versions domain.VersionResolver) {
+	s.versions = versions
+	s.packages.SetVersionResolver(versions)
+}
+
+// VersionViolations lists installed apps outside their version constraint.
+func (s *InstallService) VersionViolations(ctx context.Context, versions domain.VersionResolver) []error {
+	return s.packages.VersionViolations(ctx, versions)
+}
+
+// SetContainerExporter exports catalog installs to the host when running in a toolbox or distrobox.
+func (s *InstallService) SetContainerExporter(exporter domain.ContainerExporter) {
+	s.exporter = exporter
+	s.packages.SetContainerExporter(exporter)
+}
+
 // SetVerbose sets the verbosity level for the service.
 func (s *InstallService) SetVerbose(verbose bool) {
 	s.verbose = verbose
-	s.appsManager = apps.NewManager(verbose)
+	s.SetPackageManager(NewPackageManager(apps.NewManager(verbose).PackageInstaller(), nil, s.packages.IsDryRun()))
 }
 
 // InstallApplication detects optimal method and installs via appropriate manager.
@@ -53,7 +221,12 @@ func (s *InstallService) InstallApplication(ctx context.Context, name, source st
 	}
 
 	// Install the package
-	return s.packageService.Install(ctx, pkg)
+	result, err := s.packageService.Install(ctx, pkg)
+	if err == nil && result != nil && result.Success {
+		s.events.Publish(ctx, domain.NewEvent(domain.EventPackageInstalled, name))
+	}
+
+	return result, err
 }
 
 // InstallMultipleApplications processes batch installations with error aggregation.
@@ -126,35 +299,32 @@ func (s *InstallService) InstallGroup(ctx context.Context, groupName string) (*d
 		return result, fmt.Errorf("unknown group: %s", groupName)
 	}
 
-	for _, appName := range groupApps {
-		if err := s.appsManager.InstallApp(ctx, appName); err != nil {
-			result.Failed = append(result.Failed, appName)
-		} else {
-			result.Installed = append(result.Installed, appName)
-		}
-	}
-
-	return result, nil
+	return s.packages.InstallAll(ctx, groupApps), nil
 }
 
 // InstallPackages installs multiple packages.
 func (s *InstallService) InstallPackages(ctx context.Context, packages []string) (*domain.InstallResult, error) {
-	result := &domain.InstallResult{}
+	return s.packages.InstallAll(ctx, packages), nil
+}
 
-	for _, pkg := range packages {
-		pkg = strings.TrimSpace(pkg)
-		if pkg == "" {
-			continue
-		}
+// CheckPolicy returns domain.ErrBlockedByPolicy when the install policy blocks the package.
+func (s *InstallService) CheckPolicy(packageName string) error {
+	return s.packages.CheckPolicy(packageName)
+}
 
-		if err := s.appsManager.InstallApp(ctx, pkg); err != nil {
-			result.Failed = append(result.Failed, pkg)
-		} else {
-			result.Installed = append(result.Installed, pkg)
-		}
-	}
+// InstalledConflicts maps each of the packages to the installed apps it conflicts with.
+func (s *InstallService) InstalledConflicts(ctx context.Context, packages []string) map[string][]string {
+	return s.packages.InstalledConflicts(ctx, packages)
+}
+
+// Inventory lists the installed catalog applications with versions and checksums.
+func (s *InstallService) Inventory(ctx context.Context, versions domain.VersionResolver, checksums domain.ChecksumResolver) []domain.ManagedPackage {
+	return s.packages.Inventory(ctx, versions, checksums)
+}
 
-	return result, nil
+// PlanInstall returns what installing appKeys would change, for review.
+func (s *InstallService) PlanInstall(ctx context.Context, appKeys []string) []domain.PlannedChange {
+	return s.packages.Plan(ctx, appKeys)
 }
 
 // GetAvailableGroups returns all available installation groups.
@@ -525,3 +525,36 @@ func TestGetBestMethodForSystem(t *testing.T) {
 		})
 	}
 }
+
+// TestInstallApplicationPublishesEvent verifies subscribers learn about successful installs only.
+func TestInstallApplicationPublishesEvent(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller, mockDetector, service := SetupServiceMocks()
+
+	ctx := context.Background()
+	bus := domain.NewEventBus()
+	service.SetEventPublisher(bus)
+
+	var installed []string
+
+	bus.Subscribe(domain.EventPackageInstalled, func(_ context.Context, e domain.Event) {
+		installed = append(installed, e.Subject)
+	})
+
+	mockDetector.On("DetectSystem", ctx).Return(testutil.CreateTestSystemInfo(), nil)
+	mockInstaller.On("Install", ctx, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == testPackageName
+	})).Return(&domain.InstallationResult{Success: true}, nil)
+	mockInstaller.On("Install", ctx, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "broken"
+	})).Return(&domain.InstallationResult{Success: false}, nil)
+
+	_, err := service.InstallApplication(ctx, testPackageName, testPackageName)
+	require.NoError(t, err)
+
+	_, err = service.InstallApplication(ctx, "broken", "broken")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{testPackageName}, installed)
+}
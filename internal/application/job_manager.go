@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// JobStatus describes where an asynchronous job is in its lifecycle.
+type JobStatus string
+
+// Job statuses.
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+)
+
+// Job is a snapshot of an asynchronous operation.
+type Job struct {
+	ID       string    `json:"id"`
+	Kind     string    `json:"kind"`
+	Status   JobStatus `json:"status"`
+	Error    string    `json:"error,omitempty"`
+	Result   any       `json:"result,omitempty"`
+	Created  time.Time `json:"created"`
+	Finished time.Time `json:"finished,omitzero"`
+}
+
+// JobFunc performs the work of a job and returns its result.
+type JobFunc func(ctx context.Context) (any, error)
+
+// MaxFinishedJobs bounds how many finished jobs are kept for polling; the
+// oldest are forgotten first.
+const MaxFinishedJobs = 100
+
+// JobManager runs long operations in the background and keeps their outcome
+// so callers can poll for it later. Jobs run one at a time in the order they
+// were submitted, as installs can't share the apt and dpkg locks.
+type JobManager struct {
+	mu      sync.RWMutex
+	jobs    map[string]*Job
+	seq     int
+	queue   []queuedJob
+	working bool // A worker is draining the queue
+	wg      sync.WaitGroup
+}
+
+// queuedJob is a submitted job waiting for the worker.
+type queuedJob struct {
+	id  string
+	ctx context.Context //nolint:containedctx // Jobs run later with the context they were submitted with
+	fn  JobFunc
+}
+
+// NewJobManager creates an empty job manager.
+func NewJobManager() *JobManager {
+	return &JobManager{jobs: make(map[string]*Job)}
+}
+
+// Submit queues fn and returns a snapshot of the new job. The job runs with
+// ctx, so cancelling it aborts outstanding work.
+func (m *JobManager) Submit(ctx context.Context, kind string, fn JobFunc) Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.seq++
+	job := &Job{
+		ID:      fmt.Sprintf("%s-%d", kind, m.seq),
+		Kind:    kind,
+		Status:  JobPending,
+		Created: time.Now(),
+	}
+	m.jobs[job.ID] = job
+	m.queue = append(m.queue, queuedJob{id: job.ID, ctx: ctx, fn: fn})
+	m.wg.Add(1)
+
+	if !m.working {
+		m.working = true
+
+		go m.work()
+	}
+
+	return *job
+}
+
+// work runs the queued jobs until the queue is empty.
+func (m *JobManager) work() {
+	for {
+		m.mu.Lock()
+		if len(m.queue) == 0 {
+			m.working = false
+			m.mu.Unlock()
+
+			return
+		}
+
+		next := m.queue[0]
+		m.queue = m.queue[1:]
+		m.mu.Unlock()
+
+		m.run(next)
+		m.wg.Done()
+	}
+}
+
+// run runs a job and records its outcome.
+func (m *JobManager) run(queued queuedJob) {
+	m.update(queued.id, func(j *Job) { j.Status = JobRunning })
+
+	result, err := queued.fn(queued.ctx)
+
+	m.update(queued.id, func(j *Job) {
+		j.Result = result
+		j.Finished = time.Now()
+
+		if err != nil {
+			j.Status = JobFailed
+			j.Error = err.Error()
+
+			return
+		}
+
+		j.Status = JobSucceeded
+	})
+
+	m.mu.Lock()
+	m.pruneFinished()
+	m.mu.Unlock()
+}
+
+// pruneFinished forgets the oldest finished jobs beyond MaxFinishedJobs.
+// The caller holds the lock.
+func (m *JobManager) pruneFinished() {
+	var finished []*Job
+
+	for _, job := range m.jobs {
+		if !job.Finished.IsZero() {
+			finished = append(finished, job)
+		}
+	}
+
+	if len(finished) <= MaxFinishedJobs {
+		return
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].Finished.Before(finished[j].Finished)
+	})
+
+	for _, job := range finished[:len(finished)-MaxFinishedJobs] {
+		delete(m.jobs, job.ID)
+	}
+}
+
+// Get returns a snapshot of the job with the given ID.
+func (m *JobManager) Get(id string) (Job, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	job, exists := m.jobs[id]
+	if !exists {
+		return Job{}, false
+	}
+
+	return *job, true
+}
+
+// List returns snapshots of all jobs, oldest first.
+func (m *JobManager) List() []Job {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jobs := make([]Job, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, *job)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Created.Before(jobs[j].Created)
+	})
+
+	return jobs
+}
+
+// Wait blocks until every submitted job has finished.
+func (m *JobManager) Wait() {
+	m.wg.Wait()
+}
+
+func (m *JobManager) update(id string, mutate func(*Job)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if job, exists := m.jobs[id]; exists {
+		mutate(job)
+	}
+}
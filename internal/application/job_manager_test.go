@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobManager_RecordsOutcome(t *testing.T) {
+	t.Parallel()
+
+	manager := application.NewJobManager()
+	ctx := context.Background()
+
+	ok := manager.Submit(ctx, "install", func(context.Context) (any, error) { return "done", nil })
+	failed := manager.Submit(ctx, "theme", func(context.Context) (any, error) { return nil, errors.New("boom") })
+
+	assert.Equal(t, application.JobPending, ok.Status)
+	assert.NotEqual(t, ok.ID, failed.ID)
+
+	manager.Wait()
+
+	job, exists := manager.Get(ok.ID)
+	require.True(t, exists)
+	assert.Equal(t, application.JobSucceeded, job.Status)
+	assert.Equal(t, "done", job.Result)
+	assert.False(t, job.Finished.IsZero())
+
+	job, exists = manager.Get(failed.ID)
+	require.True(t, exists)
+	assert.Equal(t, application.JobFailed, job.Status)
+	assert.Equal(t, "boom", job.Error)
+
+	assert.Len(t, manager.List(), 2)
+
+	_, exists = manager.Get("missing")
+	assert.False(t, exists)
+}
+
+func TestJobManager_RunsJobsOneAtATime(t *testing.T) {
+	t.Parallel()
+
+	manager := application.NewJobManager()
+	ctx := context.Background()
+	release := make(chan struct{})
+
+	first := manager.Submit(ctx, "install", func(context.Context) (any, error) {
+		<-release
+
+		return nil, nil
+	})
+	second := manager.Submit(ctx, "install", func(context.Context) (any, error) { return nil, nil })
+
+	require.Eventually(t, func() bool {
+		job, _ := manager.Get(first.ID)
+
+		return job.Status == application.JobRunning
+	}, time.Second, time.Millisecond)
+
+	job, _ := manager.Get(second.ID)
+	assert.Equal(t, application.JobPending, job.Status, "waits for the running install")
+
+	close(release)
+	manager.Wait()
+
+	job, _ = manager.Get(second.ID)
+	assert.Equal(t, application.JobSucceeded, job.Status)
+}
+
+func TestJobManager_ForgetsOldestFinishedJobs(t *testing.T) {
+	t.Parallel()
+
+	manager := application.NewJobManager()
+
+	var ids []string
+
+	for range application.MaxFinishedJobs + 5 {
+		job := manager.Submit(context.Background(), "theme", func(context.Context) (any, error) { return nil, nil })
+		ids = append(ids, job.ID)
+	}
+
+	manager.Wait()
+
+	assert.Len(t, manager.List(), application.MaxFinishedJobs)
+
+	_, exists := manager.Get(ids[0])
+	assert.False(t, exists)
+
+	_, exists = manager.Get(ids[len(ids)-1])
+	assert.True(t, exists)
+}
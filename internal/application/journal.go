@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// ErrNoJournal indicates no run has been recorded yet.
+var ErrNoJournal = errors.New("no previous run recorded")
+
+// maxJournalRuns bounds the journal; the oldest runs go first.
+const maxJournalRuns = 200
+
+// JournalEntry records the outcome of one task in a run.
+type JournalEntry struct {
+	App       string           `json:"app"`
+	Operation string           `json:"operation"`
+	Success   bool             `json:"success"`
+	Error     string           `json:"error,omitempty"`
+	Kind      domain.ErrorKind `json:"kind,omitempty"`
+	Duration  time.Duration    `json:"duration,omitempty"` // How long the task took, when timed
+}
+
+// JournalRun is the record of one install or uninstall run.
+type JournalRun struct {
+	Started  time.Time      `json:"started"`
+	Finished time.Time      `json:"finished"`
+	Entries  []JournalEntry `json:"entries"`
+}
+
+// NewJournalRun starts an empty run record.
+func NewJournalRun() *JournalRun {
+	return &JournalRun{Started: time.Now()}
+}
+
+// Add appends the outcome of a task. A nil err records success.
+func (r *JournalRun) Add(app, operation string, err error) {
+	entry := JournalEntry{App: app, Operation: operation, Success: err == nil}
+	if err != nil {
+		entry.Error = err.Error()
+		entry.Kind = domain.ClassifyError(err)
+	}
+
+	r.Entries = append(r.Entries, entry)
+}
+
+// AddInstallResult records every installed and failed app of an install run,
+// and the apps skipped while offline so karei retry picks them up.
+func (r *JournalRun) AddInstallResult(result *domain.InstallResult) {
+	if result == nil {
+		return
+	}
+
+	for _, app := range result.Installed {
+		r.Add(app, OperationInstall, nil)
+	}
+
+	for _, app := range result.Failed {
+		entry := JournalEntry{App: app, Operation: OperationInstall, Kind: result.FailureKinds[app], Error: "installation failed"}
+		if err := result.Errors[app]; err != nil {
+			entry.Error = err.Error()
+		}
+
+		r.Entries = append(r.Entries, entry)
+	}
+
+	// Installs skipped for lack of a network are retried once it is back
+	for _, app := range result.Skipped {
+		if reason := result.SkipReasons[app]; reason == domain.SkipOffline || reason == domain.SkipCaptivePortal {
+			r.Entries = append(r.Entries, JournalEntry{App: app, Operation: OperationInstall, Kind: domain.ErrorKindNetwork, Error: "skipped: " + reason})
+		}
+	}
+}
+
+// AddUninstallResult records every removed and failed app of an uninstall run.
+func (r *JournalRun) AddUninstallResult(result *domain.UninstallResult) {
+	if result == nil {
+		return
+	}
+
+	for _, app := range result.Uninstalled {
+		r.Add(app, OperationUninstall, nil)
+	}
+
+	for _, app := range result.Failed {
+		r.Entries = append(r.Entries, JournalEntry{App: app, Operation: OperationUninstall, Error: "uninstallation failed"})
+	}
+}
+
+// Failed returns the entries that did not succeed, in run order.
+func (r *JournalRun) Failed() []JournalEntry {
+	var failed []JournalEntry
+
+	for _, entry := range r.Entries {
+		if !entry.Success {
+			failed = append(failed, entry)
+		}
+	}
+
+	return failed
+}
+
+// Journal keeps the records of recent runs so failures can be retried and
+// past operations reviewed.
+type Journal struct {
+	files domain.FileManager
+	path  string
+}
+
+// NewJournal creates a journal stored at path.
+func NewJournal(files domain.FileManager, path string) *Journal {
+	return &Journal{files: files, path: path}
+}
+
+// Record stores run as the most recent run, dropping the oldest runs beyond
+// maxJournalRuns.
+func (j *Journal) Record(run *JournalRun) error {
+	if run.Finished.IsZero() {
+		run.Finished = time.Now()
+	}
+
+	runs, err := j.Runs()
+	if err != nil {
+		return err
+	}
+
+	runs = append(runs, run)
+	if len(runs) > maxJournalRuns {
+		runs = runs[len(runs)-maxJournalRuns:]
+	}
+
+	data, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode journal: %w", err)
+	}
+
+	if err := j.files.EnsureDir(filepath.Dir(j.path)); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	if err := j.files.WriteFile(j.path, data); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+
+	return nil
+}
+
+// Runs returns the recorded runs, oldest first. Nothing recorded yet is not
+// an error.
+func (j *Journal) Runs() ([]*JournalRun, error) {
+	if !j.files.FileExists(j.path) {
+		return nil, nil
+	}
+
+	data, err := j.files.ReadFile(j.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	// Journals written before runs were kept hold only the last run
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var run JournalRun
+		if err := json.Unmarshal(trimmed, &run); err != nil {
+			return nil, fmt.Errorf("failed to parse journal: %w", err)
+		}
+
+		return []*JournalRun{&run}, nil
+	}
+
+	var runs []*JournalRun
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("failed to parse journal: %w", err)
+	}
+
+	return runs, nil
+}
+
+// Last returns the most recent run.
+func (j *Journal) Last() (*JournalRun, error) {
+	runs, err := j.Runs()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(runs) == 0 {
+		return nil, ErrNoJournal
+	}
+
+	return runs[len(runs)-1], nil
+}
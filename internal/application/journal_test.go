@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalRecordsFailuresForRetry(t *testing.T) {
+	t.Parallel()
+
+	journal := application.NewJournal(platform.NewFileManager(false), filepath.Join(t.TempDir(), "karei", "journal.json"))
+
+	_, err := journal.Last()
+	require.ErrorIs(t, err, application.ErrNoJournal)
+
+	run := application.NewJournalRun()
+	run.AddInstallResult(&domain.InstallResult{
+		Installed:    []string{"git"},
+		Failed:       []string{"vlc"},
+		FailureKinds: map[string]domain.ErrorKind{"vlc": domain.ErrorKindNetwork},
+		Errors:       map[string]error{"vlc": errors.New("temporary failure resolving")},
+	})
+	run.Add("zed", application.OperationUninstall, errors.New("boom"))
+
+	require.NoError(t, journal.Record(run))
+
+	last, err := journal.Last()
+	require.NoError(t, err)
+	assert.False(t, last.Finished.IsZero())
+	assert.Len(t, last.Entries, 3)
+
+	failed := last.Failed()
+	require.Len(t, failed, 2)
+	assert.Equal(t, application.JournalEntry{
+		App: "vlc", Operation: application.OperationInstall, Error: "temporary failure resolving", Kind: domain.ErrorKindNetwork,
+	}, failed[0])
+	assert.Equal(t, "zed", failed[1].App)
+	assert.Equal(t, application.OperationUninstall, failed[1].Operation)
+}
+
+func TestJournalRetriesInstallsSkippedOffline(t *testing.T) {
+	t.Parallel()
+
+	run := application.NewJournalRun()
+	run.AddInstallResult(&domain.InstallResult{
+		Skipped:     []string{"fish", "vlc"},
+		SkipReasons: map[string]string{"fish": domain.SkipRequiresRoot, "vlc": domain.SkipOffline},
+	})
+
+	assert.Equal(t, []application.JournalEntry{{
+		App: "vlc", Operation: application.OperationInstall, Error: "skipped: offline", Kind: domain.ErrorKindNetwork,
+	}}, run.Failed())
+}
+
+func TestJournalKeepsPastRuns(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	files := platform.NewFileManager(false)
+
+	// A journal from before runs were kept holds a single run
+	require.NoError(t, os.WriteFile(path, []byte(`{"started":"2025-01-01T10:00:00Z","finished":"2025-01-01T10:01:00Z","entries":[{"app":"git","operation":"install","success":true}]}`), 0o600))
+
+	journal := application.NewJournal(files, path)
+
+	runs, err := journal.Runs()
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "git", runs[0].Entries[0].App)
+
+	run := application.NewJournalRun()
+	run.Add("vlc", application.OperationUninstall, nil)
+	require.NoError(t, journal.Record(run))
+
+	runs, err = journal.Runs()
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "git", runs[0].Entries[0].App)
+
+	last, err := journal.Last()
+	require.NoError(t, err)
+	assert.Equal(t, "vlc", last.Entries[0].App)
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// LockfileVersion is the format version written to new lockfiles.
+const LockfileVersion = 1
+
+var (
+	// ErrLockMismatch indicates an installed app that differs from its lockfile entry.
+	ErrLockMismatch = errors.New("installed app differs from lockfile")
+	// ErrUnsupportedLockfile indicates a lockfile written in a newer format.
+	ErrUnsupportedLockfile = errors.New("unsupported lockfile version")
+)
+
+// Lockfile records the exact version, source and checksum of every app a
+// profile installed, so the same set can be reproduced on another machine.
+type Lockfile struct {
+	Version   int                     `json:"lockfile_version"`
+	Generated time.Time               `json:"generated"`
+	Packages  []domain.ManagedPackage `json:"packages"`
+}
+
+// NewLockfile locks the installed packages among inventory whose key is in keys.
+func NewLockfile(inventory []domain.ManagedPackage, keys []string) *Lockfile {
+	lock := &Lockfile{Version: LockfileVersion, Generated: time.Now().UTC()}
+
+	for _, pkg := range inventory {
+		if slices.Contains(keys, pkg.Key) {
+			lock.Packages = append(lock.Packages, pkg)
+		}
+	}
+
+	return lock
+}
+
+// ReadLockfile reads a lockfile written by Write.
+func ReadLockfile(files domain.FileManager, path string) (*Lockfile, error) {
+	data, err := files.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	var lock Lockfile
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile %s: %w", path, err)
+	}
+
+	if lock.Version > LockfileVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedLockfile, lock.Version)
+	}
+
+	return &lock, nil
+}
+
+// Write stores the lockfile at path.
+func (l *Lockfile) Write(files domain.FileManager, path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lockfile: %w", err)
+	}
+
+	if err := files.WriteFile(path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write lockfile: %w", err)
+	}
+
+	return nil
+}
+
+// Keys returns the catalog keys of the locked apps.
+func (l *Lockfile) Keys() []string {
+	keys := make([]string, 0, len(l.Packages))
+	for _, pkg := range l.Packages {
+		keys = append(keys, pkg.Key)
+	}
+
+	return keys
+}
+
+// Constraints holds every locked app with a known version to exactly that version.
+func (l *Lockfile) Constraints() domain.VersionConstraints {
+	constraints := make(domain.VersionConstraints, len(l.Packages))
+
+	for _, pkg := range l.Packages {
+		if pkg.Version == "" {
+			continue
+		}
+
+		if constraint, err := domain.ParseVersionConstraint("=" + pkg.Version); err == nil {
+			constraints[pkg.Key] = constraint
+		}
+	}
+
+	return constraints
+}
+
+// Verify compares the installed inventory with the lockfile, returning an
+// ErrLockMismatch error for each locked app that is missing or installed
+// with another method, source, version or checksum. Versions and checksums
+// that can't be determined on this machine are reported too, as the set
+// can't be shown to be the same.
+func (l *Lockfile) Verify(inventory []domain.ManagedPackage) []error {
+	installed := make(map[string]domain.ManagedPackage, len(inventory))
+	for _, pkg := range inventory {
+		installed[pkg.Key] = pkg
+	}
+
+	var mismatches []error
+
+	for _, locked := range l.Packages {
+		pkg, ok := installed[locked.Key]
+		if !ok {
+			mismatches = append(mismatches, fmt.Errorf("%w: %s is not installed", ErrLockMismatch, locked.Key))
+
+			continue
+		}
+
+		var diffs []string
+
+		if pkg.Method != locked.Method || pkg.Source != locked.Source {
+			diffs = append(diffs, fmt.Sprintf("installed with %s (%s), locked %s (%s)", pkg.Method, pkg.Source, locked.Method, locked.Source))
+		}
+
+		if locked.Version != "" && pkg.Version != locked.Version {
+			diffs = append(diffs, fmt.Sprintf("version %q, locked %q", pkg.Version, locked.Version))
+		}
+
+		if locked.Checksum != "" && pkg.Checksum != locked.Checksum {
+			diffs = append(diffs, "checksum differs")
+		}
+
+		if len(diffs) > 0 {
+			mismatches = append(mismatches, fmt.Errorf("%w: %s %s", ErrLockMismatch, locked.Key, strings.Join(diffs, ", ")))
+		}
+	}
+
+	return mismatches
+}
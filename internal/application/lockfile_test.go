@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockfileRoundTripAndVerify(t *testing.T) {
+	t.Parallel()
+
+	inventory := []domain.ManagedPackage{
+		{Key: "rust", Method: domain.MethodMise, Source: "rust", Version: "1.80.1", Checksum: "ab12"},
+		{Key: "vlc", Method: domain.MethodAPT, Source: "vlc", Version: "3.0.20"},
+		{Key: "zed", Method: domain.MethodFlatpak, Source: "dev.zed.Zed", Version: "0.150.0"},
+	}
+
+	lock := application.NewLockfile(inventory, []string{"rust", "vlc"})
+	assert.Equal(t, []string{"rust", "vlc"}, lock.Keys())
+
+	files := platform.NewFileManager(false)
+	path := filepath.Join(t.TempDir(), "karei.lock")
+	require.NoError(t, lock.Write(files, path))
+
+	read, err := application.ReadLockfile(files, path)
+	require.NoError(t, err)
+	assert.Equal(t, lock.Packages, read.Packages)
+	assert.Equal(t, "=1.80.1", read.Constraints()["rust"].String())
+
+	assert.Empty(t, read.Verify(inventory))
+
+	drifted := []domain.ManagedPackage{
+		{Key: "rust", Method: domain.MethodMise, Source: "rust", Version: "1.81.0", Checksum: "cd34"},
+	}
+
+	mismatches := read.Verify(drifted)
+	require.Len(t, mismatches, 2)
+	require.ErrorIs(t, mismatches[0], application.ErrLockMismatch)
+	assert.Contains(t, mismatches[0].Error(), `version "1.81.0", locked "1.80.1", checksum differs`)
+	assert.Contains(t, mismatches[1].Error(), "vlc is not installed")
+}
@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+var (
+	// ErrInvalidMailAddress indicates an address without a domain.
+	ErrInvalidMailAddress = errors.New("invalid mail address")
+	// ErrNoThunderbirdProfile indicates Thunderbird hasn't been started yet,
+	// so it has no profile to theme.
+	ErrNoThunderbirdProfile = errors.New("no Thunderbird profile")
+)
+
+// MailTheme is what mail client templates are rendered with.
+type MailTheme struct {
+	Theme   string
+	Palette domain.ThemePalette
+	Dark    bool
+}
+
+// thunderbirdRoots are where Thunderbird keeps profiles.ini, relative to the
+// home directory: the Flatpak's first, as karei installs that.
+var thunderbirdRoots = []string{ //nolint:gochecknoglobals
+	".var/app/org.mozilla.Thunderbird/.thunderbird",
+	".thunderbird",
+}
+
+// MailService sets up a mail and calendar client: hints for adding the
+// account, colors matching the karei theme, and the client opening mail
+// links and calendar files.
+type MailService struct {
+	fileManager   domain.FileManager
+	commandRunner domain.CommandRunner
+	templates     *Templates
+	homeDir       string
+	configs       *ConfigFiles          // Records the configuration files written when set
+	discoverer    domain.MailDiscoverer // Looks up unknown domains when set
+}
+
+// NewMailService creates a service configuring the mail client of the user
+// with home directory homeDir.
+func NewMailService(fm domain.FileManager, cr domain.CommandRunner, templates *Templates, homeDir string) *MailService {
+	return &MailService{fileManager: fm, commandRunner: cr, templates: templates, homeDir: homeDir}
+}
+
+// SetConfigFiles sets where the files written are recorded, so later changes
+// to them are found and merged. Nil writes them as they are.
+func (s *MailService) SetConfigFiles(configs *ConfigFiles) {
+	s.configs = configs
+}
+
+// SetDiscoverer sets how domains not known by name are looked up. Without
+// one, only well-known providers are recognized.
+func (s *MailService) SetDiscoverer(discoverer domain.MailDiscoverer) {
+	s.discoverer = discoverer
+}
+
+// Discover returns who hosts address and how client adds the account. A
+// failed lookup yields the generic hint rather than an error.
+func (s *MailService) Discover(ctx context.Context, client domain.MailClient, address string) (domain.MailProvider, error) {
+	_, mailDomain, found := strings.Cut(address, "@")
+	if !found || mailDomain == "" || strings.Contains(mailDomain, "@") {
+		return domain.MailProvider{}, fmt.Errorf("%w: %q", ErrInvalidMailAddress, address)
+	}
+
+	provider := domain.DetectMailProvider(client, mailDomain, nil, false)
+	if provider.Known || s.discoverer == nil {
+		return provider, nil
+	}
+
+	mx, srv, err := s.discoverer.MailHosts(ctx, mailDomain)
+	if err != nil {
+		return provider, nil //nolint:nilerr // The generic hint still applies
+	}
+
+	return domain.DetectMailProvider(client, mailDomain, mx, srv), nil
+}
+
+// Theme colors the client like theme, returning the paths written. Evolution
+// follows the GNOME color scheme the theme sets and only gets its quote
+// color; Thunderbird gets preferences and a userChrome.css in its profile.
+func (s *MailService) Theme(ctx context.Context, profile domain.MailProfile, theme MailTheme) ([]string, error) {
+	if profile.Client == domain.MailEvolution {
+		for _, setting := range []domain.GSetting{
+			{Schema: "org.gnome.evolution.mail", Key: "mark-citations", Value: "true"},
+			{Schema: "org.gnome.evolution.mail", Key: "citation-color", Value: "'" + theme.Palette.Primary + "'"},
+		} {
+			if err := s.commandRunner.Execute(ctx, "gsettings", "set", setting.Schema, setting.Key, setting.Value); err != nil {
+				return nil, fmt.Errorf("failed to set %s.%s: %w", setting.Schema, setting.Key, err)
+			}
+		}
+
+		return nil, nil
+	}
+
+	dir, err := s.thunderbirdProfile()
+	if err != nil {
+		return nil, err
+	}
+
+	written := make([]string, 0, 2)
+
+	for _, file := range [][2]string{
+		{"configs/mail/thunderbird/user.js", "user.js"},
+		{"configs/mail/thunderbird/userChrome.css", "chrome/userChrome.css"},
+	} {
+		path := filepath.Join(dir, file[1])
+
+		content, err := s.templates.Execute(file[0], theme)
+		if err != nil {
+			return written, err
+		}
+
+		if err := s.write(path, content); err != nil {
+			return written, err
+		}
+
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+// SetDefault makes the client open mail links, saved mail and calendar
+// files, as the desktop's default application for their types.
+func (s *MailService) SetDefault(ctx context.Context, profile domain.MailProfile) error {
+	args := append([]string{"default", profile.DesktopFile}, profile.MimeTypes...)
+
+	if err := s.commandRunner.Execute(ctx, "xdg-mime", args...); err != nil {
+		return fmt.Errorf("failed to make %s the default: %w", profile.Name, err)
+	}
+
+	return nil
+}
+
+// thunderbirdProfile returns the directory of the profile Thunderbird
+// starts with.
+func (s *MailService) thunderbirdProfile() (string, error) {
+	for _, root := range thunderbirdRoots {
+		root = filepath.Join(s.homeDir, root)
+
+		ini, err := s.fileManager.ReadFile(filepath.Join(root, "profiles.ini"))
+		if err != nil {
+			continue
+		}
+
+		if path := domain.ThunderbirdDefaultProfile(string(ini)); path != "" {
+			if filepath.IsAbs(path) {
+				return path, nil
+			}
+
+			return filepath.Join(root, path), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: start Thunderbird once, then run this again", ErrNoThunderbirdProfile)
+}
+
+// write writes a generated file, recording it when configuration files are.
+func (s *MailService) write(path string, content []byte) error {
+	if err := s.fileManager.EnsureDir(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	if s.configs == nil {
+		if err := s.fileManager.WriteFile(path, content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+
+		return nil
+	}
+
+	if _, err := s.configs.Write(path, content, ""); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mailHosts answers MailHosts from a table of domains.
+type mailHosts map[string][]string
+
+func (h mailHosts) MailHosts(_ context.Context, domain string) ([]string, bool, error) {
+	mx, ok := h[domain]
+	if !ok {
+		return nil, false, errors.New("no such host")
+	}
+
+	return mx, false, nil
+}
+
+func TestMailService_Discover(t *testing.T) {
+	t.Parallel()
+
+	service := application.NewMailService(platform.NewFileManager(false), &testutil.MockCommandRunner{}, nil, t.TempDir())
+	service.SetDiscoverer(mailHosts{"example.com": {"example-com.mail.protection.outlook.com."}})
+
+	provider, err := service.Discover(context.Background(), domain.MailThunderbird, "me@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "Microsoft", provider.Name)
+
+	provider, err = service.Discover(context.Background(), domain.MailThunderbird, "me@offline.example")
+	require.NoError(t, err)
+	assert.False(t, provider.Known)
+
+	_, err = service.Discover(context.Background(), domain.MailThunderbird, "me")
+	require.ErrorIs(t, err, application.ErrInvalidMailAddress)
+}
+
+func TestMailService_ThemeThunderbird(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	home := filepath.Join(dir, "home")
+	fileManager := platform.NewFileManager(false)
+
+	for _, name := range []string{"user.js", "userChrome.css"} {
+		require.NoError(t, fileManager.WriteFile(filepath.Join(dir, "builtin", "configs/mail/thunderbird", name),
+			[]byte("// {{.Theme}} {{.Palette.Primary}} {{.Dark}}\n")))
+	}
+
+	templates := application.NewTemplates(fileManager, filepath.Join(dir, "builtin"), filepath.Join(dir, "overrides"))
+	service := application.NewMailService(fileManager, &testutil.MockCommandRunner{}, templates, home)
+	profile := domain.MailProfileFor(domain.MailThunderbird)
+	theme := application.MailTheme{Theme: "nord", Palette: domain.ThemePalette{Primary: "#88c0d0"}, Dark: true}
+
+	_, err := service.Theme(context.Background(), profile, theme)
+	require.ErrorIs(t, err, application.ErrNoThunderbirdProfile)
+
+	root := filepath.Join(home, ".var/app/org.mozilla.Thunderbird/.thunderbird")
+	require.NoError(t, fileManager.WriteFile(filepath.Join(root, "profiles.ini"), []byte("[Profile0]\nPath=abcd.default\nDefault=1\n")))
+
+	written, err := service.Theme(context.Background(), profile, theme)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(root, "abcd.default", "user.js"),
+		filepath.Join(root, "abcd.default", "chrome", "userChrome.css"),
+	}, written)
+
+	css, err := os.ReadFile(written[1])
+	require.NoError(t, err)
+	assert.Equal(t, "// nord #88c0d0 true\n", string(css))
+}
+
+func TestMailService_ThemeEvolutionAndDefault(t *testing.T) {
+	t.Parallel()
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("Execute", mock.Anything, "gsettings", "set", "org.gnome.evolution.mail", "mark-citations", "true").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "gsettings", "set", "org.gnome.evolution.mail", "citation-color", "'#88c0d0'").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "xdg-mime", "default", "org.gnome.Evolution.desktop",
+		"x-scheme-handler/mailto", "message/rfc822", "text/calendar", "text/vcard").Return(nil).Once()
+
+	service := application.NewMailService(platform.NewFileManager(false), runner, nil, t.TempDir())
+	profile := domain.MailProfileFor(domain.MailEvolution)
+
+	written, err := service.Theme(context.Background(), profile, application.MailTheme{Palette: domain.ThemePalette{Primary: "#88c0d0"}})
+	require.NoError(t, err)
+	assert.Empty(t, written)
+
+	require.NoError(t, service.SetDefault(context.Background(), profile))
+	runner.AssertExpectations(t)
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// savedSuffix is added to the name of the copy kept of a file the user
+// changed, when karei replaces it.
+const savedSuffix = ".karei-save"
+
+// Manifests keeps the manifest of every app karei created files for, one
+// file per app, so an uninstall removes exactly those files and changes the
+// user made to them can be found.
+type Manifests struct {
+	files domain.FileManager
+	dir   string
+}
+
+// NewManifests creates manifests stored in dir.
+func NewManifests(files domain.FileManager, dir string) *Manifests {
+	return &Manifests{files: files, dir: dir}
+}
+
+// Load returns the manifest of app, empty when karei created nothing for it.
+func (m *Manifests) Load(app string) (*domain.Manifest, error) {
+	manifest := &domain.Manifest{App: app}
+
+	path := m.path(app)
+	if !m.files.FileExists(path) {
+		return manifest, nil
+	}
+
+	data, err := m.files.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest of %s: %w", app, err)
+	}
+
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest of %s: %w", app, err)
+	}
+
+	return manifest, nil
+}
+
+// Add records artifacts karei just created for app, with the checksum of
+// each regular file as written.
+func (m *Manifests) Add(app string, artifacts ...domain.Artifact) error {
+	if len(artifacts) == 0 {
+		return nil
+	}
+
+	manifest, err := m.Load(app)
+	if err != nil {
+		return err
+	}
+
+	for _, artifact := range artifacts {
+		artifact.Checksum = m.checksum(artifact)
+		manifest.Artifacts = domain.AddArtifacts(manifest.Artifacts, artifact)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest of %s: %w", app, err)
+	}
+
+	if err := m.files.WriteFile(m.path(app), data); err != nil {
+		return fmt.Errorf("failed to write manifest of %s: %w", app, err)
+	}
+
+	return nil
+}
+
+// Remove deletes the manifest of a removed app.
+func (m *Manifests) Remove(app string) error {
+	if !m.files.FileExists(m.path(app)) {
+		return nil
+	}
+
+	return m.files.RemoveFile(m.path(app))
+}
+
+// Check compares what karei created for app with what is there now.
+func (m *Manifests) Check(app string) ([]domain.ArtifactCheck, error) {
+	manifest, err := m.Load(app)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]domain.ArtifactCheck, 0, len(manifest.Artifacts))
+
+	for _, artifact := range manifest.Artifacts {
+		checks = append(checks, domain.ArtifactCheck{App: app, Artifact: artifact, State: m.state(artifact)})
+	}
+
+	return checks, nil
+}
+
+// Modified returns the files of app changed since karei wrote them.
+func (m *Manifests) Modified(app string) []domain.Artifact {
+	checks, err := m.Check(app)
+	if err != nil {
+		return nil
+	}
+
+	var modified []domain.Artifact
+
+	for _, check := range checks {
+		if check.State == domain.ArtifactModified && check.Artifact.IsFile() {
+			modified = append(modified, check.Artifact)
+		}
+	}
+
+	return modified
+}
+
+// Preserve copies each file of app the user changed since karei wrote it
+// next to it with savedSuffix, before reinstalling or upgrading the app
+// replaces it, overwriting an earlier copy. It returns the copies made.
+func (m *Manifests) Preserve(app string) ([]string, error) {
+	var saved []string
+
+	for _, artifact := range m.Modified(app) {
+		if err := m.files.CopyFile(artifact.Path, artifact.Path+savedSuffix); err != nil {
+			return saved, fmt.Errorf("failed to save changes to %s: %w", artifact.Path, err)
+		}
+
+		saved = append(saved, artifact.Path+savedSuffix)
+	}
+
+	return saved, nil
+}
+
+// state compares an artifact with the file it is in.
+func (m *Manifests) state(artifact domain.Artifact) domain.ArtifactState {
+	if !m.files.FileExists(artifact.Path) {
+		return domain.ArtifactMissing
+	}
+
+	if !artifact.IsFile() {
+		data, err := m.files.ReadFile(artifact.Path)
+		if err != nil || !slices.Contains(strings.Split(string(data), "\n"), artifact.Line) {
+			return domain.ArtifactMissing
+		}
+
+		return domain.ArtifactIntact
+	}
+
+	if artifact.Checksum != "" && m.checksum(artifact) != artifact.Checksum {
+		return domain.ArtifactModified
+	}
+
+	return domain.ArtifactIntact
+}
+
+// checksum fingerprints a regular file; directories, symlinks and shell
+// lines have none.
+func (m *Manifests) checksum(artifact domain.Artifact) string {
+	if !artifact.IsFile() || artifact.Kind == domain.ArtifactSymlink {
+		return ""
+	}
+
+	data, err := m.files.ReadFile(artifact.Path)
+	if err != nil {
+		return ""
+	}
+
+	return checksum(data)
+}
+
+func (m *Manifests) path(app string) string {
+	return filepath.Join(m.dir, app+".json")
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifests_Check(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	fileManager := platform.NewFileManager(false)
+	wrapper := filepath.Join(home, ".local", "bin", "vlc")
+	entry := filepath.Join(home, ".local", "share", "applications", "vlc.desktop")
+	bashrc := filepath.Join(home, ".bashrc")
+
+	require.NoError(t, fileManager.WriteFile(wrapper, []byte("#!/bin/sh\n")))
+	require.NoError(t, fileManager.WriteFile(entry, []byte("[Desktop Entry]\n")))
+	require.NoError(t, fileManager.WriteFile(bashrc, []byte("alias ll='ls -l'\n")))
+
+	manifests := application.NewManifests(fileManager, filepath.Join(home, "manifests"))
+	require.NoError(t, manifests.Add("vlc",
+		domain.Artifact{Kind: domain.ArtifactFile, Path: wrapper},
+		domain.Artifact{Kind: domain.ArtifactDesktopEntry, Path: entry},
+		domain.Artifact{Kind: domain.ArtifactShellLine, Path: bashrc, Line: "alias ll='ls -l'"}))
+
+	manifest, err := manifests.Load("vlc")
+	require.NoError(t, err)
+	require.Len(t, manifest.Artifacts, 3)
+	assert.NotEmpty(t, manifest.Artifacts[0].Checksum)
+	assert.Empty(t, manifest.Artifacts[2].Checksum, "shell lines have no checksum")
+
+	require.NoError(t, fileManager.WriteFile(entry, []byte("[Desktop Entry]\nNoDisplay=true\n")))
+	require.NoError(t, fileManager.WriteFile(bashrc, nil))
+
+	checks, err := manifests.Check("vlc")
+	require.NoError(t, err)
+
+	states := make([]domain.ArtifactState, 0, len(checks))
+	for _, check := range checks {
+		states = append(states, check.State)
+	}
+
+	assert.Equal(t, []domain.ArtifactState{domain.ArtifactIntact, domain.ArtifactModified, domain.ArtifactMissing}, states)
+	assert.Equal(t, []domain.Artifact{manifest.Artifacts[1]}, manifests.Modified("vlc"))
+
+	// Recording the file again takes the current content as karei's
+	require.NoError(t, manifests.Add("vlc", domain.Artifact{Kind: domain.ArtifactDesktopEntry, Path: entry}))
+	assert.Empty(t, manifests.Modified("vlc"))
+}
+
+func TestPackageManagerPreservesChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	fileManager := platform.NewFileManager(false)
+	wrapper := filepath.Join(home, ".local", "bin", "vlc")
+
+	require.NoError(t, fileManager.WriteFile(wrapper, []byte("#!/bin/sh\n")))
+
+	manifests := application.NewManifests(fileManager, filepath.Join(home, "manifests"))
+	require.NoError(t, manifests.Add("vlc", domain.Artifact{Kind: domain.ArtifactFile, Path: wrapper}))
+	require.NoError(t, fileManager.WriteFile(wrapper, []byte("#!/bin/sh\nexport GDK_SCALE=2\n")))
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.Anything).Return(&domain.InstallationResult{
+		Success:   true,
+		Artifacts: []domain.Artifact{{Kind: domain.ArtifactFile, Path: wrapper}},
+	}, nil)
+
+	bus := domain.NewEventBus()
+
+	var conflicts []domain.Event
+
+	bus.Subscribe(domain.EventFileConflict, func(_ context.Context, event domain.Event) {
+		conflicts = append(conflicts, event)
+	})
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetManifests(manifests)
+	manager.SetEventPublisher(bus)
+
+	_, err := manager.Install(context.Background(), "vlc")
+	require.NoError(t, err)
+
+	saved, err := fileManager.ReadFile(wrapper + ".karei-save")
+	require.NoError(t, err)
+	assert.Contains(t, string(saved), "GDK_SCALE")
+
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, wrapper, conflicts[0].Data["path"])
+}
@@ -0,0 +1,1261 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// Operation names reported through progress callbacks.
+const (
+	OperationInstall   = "install"
+	OperationUninstall = "uninstall"
+	OperationUpdate    = "update"
+	OperationReinstall = "reinstall"
+)
+
+var (
+	// ErrNoUninstaller is returned when removal is requested from a facade without an uninstaller.
+	ErrNoUninstaller = errors.New("no uninstaller configured")
+	// ErrExportFailed is returned when an app installed in a container could not be exported to the host.
+	ErrExportFailed = errors.New("installed but export to host failed")
+	// ErrRequiresRoot is returned in user-scope mode for apps that can only be installed with sudo.
+	ErrRequiresRoot = errors.New("installation requires sudo")
+	// ErrNoUpdater is returned when an update is requested from an installer that cannot update.
+	ErrNoUpdater = errors.New("installer cannot update packages")
+)
+
+// ProgressStage identifies where an operation is in its lifecycle.
+type ProgressStage string
+
+// Progress stages reported for every operation.
+const (
+	StageStarted   ProgressStage = "started"
+	StageCompleted ProgressStage = "completed"
+	StageFailed    ProgressStage = "failed"
+)
+
+// ProgressUpdate describes a single step of an install or uninstall operation.
+type ProgressUpdate struct {
+	Operation string
+	App       string
+	Stage     ProgressStage
+	Message   string
+	Err       error
+}
+
+// ProgressFunc receives progress updates. It is called on the caller's goroutine.
+type ProgressFunc func(update ProgressUpdate)
+
+// PackageManager is the single entry point the CLI and TUI use to install,
+// remove and query catalog applications. It gives every front end the same
+// results, dry-run behaviour, progress callbacks and domain events.
+type PackageManager struct {
+	installer   domain.PackageInstaller
+	uninstaller domain.AppUninstaller
+	events      domain.EventPublisher
+	exporter    domain.ContainerExporter
+	preference  domain.MethodPreference
+	flatpak     domain.FlatpakScopes
+	policy      domain.InstallPolicy
+	records     *InstallRecords
+	manifests   *Manifests
+	metrics     *InstallMetrics
+	constraints domain.VersionConstraints
+	versions    domain.VersionResolver
+	progress    ProgressFunc
+	scope       domain.InstallScope
+	network     domain.Connectivity
+	kernel      domain.KernelInspector
+	note        func(note string)
+	dryRun      bool
+}
+
+// NewPackageManager creates a facade over the given installer and uninstaller ports.
+// The uninstaller may be nil when only installation is needed.
+func NewPackageManager(installer domain.PackageInstaller, uninstaller domain.AppUninstaller, dryRun bool) *PackageManager {
+	return &PackageManager{
+		installer:   installer,
+		uninstaller: uninstaller,
+		events:      domain.NoopEventPublisher{},
+		progress:    func(ProgressUpdate) {},
+		dryRun:      dryRun,
+	}
+}
+
+// SetProgressFunc sets the callback notified as operations start and finish.
+func (m *PackageManager) SetProgressFunc(fn ProgressFunc) {
+	if fn == nil {
+		fn = func(ProgressUpdate) {}
+	}
+
+	m.progress = fn
+}
+
+// SetEventPublisher sets the publisher notified about completed operations,
+// removals by the uninstaller included.
+func (m *PackageManager) SetEventPublisher(publisher domain.EventPublisher) {
+	m.events = publisher
+
+	if uninstaller, ok := m.uninstaller.(*UninstallService); ok {
+		uninstaller.SetEventPublisher(publisher)
+	}
+}
+
+// SetContainerExporter sets the exporter that publishes installs made inside a
+// toolbox or distrobox container to the host. Nil disables exporting.
+func (m *PackageManager) SetContainerExporter(exporter domain.ContainerExporter) {
+	m.exporter = exporter
+}
+
+// SetMethodPreference sets the installation methods to favor over each app's default.
+func (m *PackageManager) SetMethodPreference(preference domain.MethodPreference) {
+	m.preference = preference
+}
+
+// SetFlatpakScopes chooses between user and system Flatpak installations.
+func (m *PackageManager) SetFlatpakScopes(scopes domain.FlatpakScopes) {
+	m.flatpak = scopes
+}
+
+// SetInstallScope restricts installs to one scope. ScopeUser only uses methods
+// that need no sudo: apps with a user-scope alternative use it, the rest fail
+// with ErrRequiresRoot. ScopeSystem installs flatpaks system-wide and prefers
+// system-wide alternatives where apps have them. Empty uses each app's default.
+func (m *PackageManager) SetInstallScope(scope domain.InstallScope) {
+	m.scope = scope
+}
+
+// SetInstallRecords sets where the method and scope of each install is kept,
+// so removal and status can use them. Nil disables recording.
+func (m *PackageManager) SetInstallRecords(records *InstallRecords) {
+	m.records = records
+}
+
+// SetManifests sets where the files karei creates for each app are listed,
+// so they are removed with it and changes the user made to them survive a
+// reinstall. Nil disables the lists.
+func (m *PackageManager) SetManifests(manifests *Manifests) {
+	m.manifests = manifests
+}
+
+// SetInstallMetrics sets where the duration of each install is kept, so
+// Estimate can predict later ones. Nil disables recording and estimates.
+func (m *PackageManager) SetInstallMetrics(metrics *InstallMetrics) {
+	m.metrics = metrics
+}
+
+// SetVersionConstraints sets version constraints, e.g. from a manifest, that
+// take precedence over recorded and catalog constraints of the same app.
+func (m *PackageManager) SetVersionConstraints(constraints domain.VersionConstraints) {
+	m.constraints = constraints
+}
+
+// SetVersionResolver sets how installed versions are looked up to enforce
+// version constraints after installing. Nil skips the check.
+func (m *PackageManager) SetVersionResolver(versions domain.VersionResolver) {
+	m.versions = versions
+}
+
+// Constraint returns the version constraint of an app: the one set through
+// SetVersionConstraints, else the one it was last installed with, else the
+// catalog's.
+func (m *PackageManager) Constraint(appKey string) domain.VersionConstraint {
+	record, _ := m.recorded(appKey)
+
+	return m.constraintWith(appKey, record)
+}
+
+// constraintWith returns the constraint of an app given its install record.
+func (m *PackageManager) constraintWith(appKey string, record InstallRecord) domain.VersionConstraint {
+	if constraint, ok := m.constraints[appKey]; ok {
+		return constraint
+	}
+
+	text := apps.Apps[appKey].Version
+	if record.Version != "" {
+		text = record.Version
+	}
+
+	// Catalog and records are validated when written
+	constraint, _ := domain.ParseVersionConstraint(text)
+
+	return constraint
+}
+
+// SetInstallPolicy restricts installs to the licenses, methods and origins
+// the policy allows. Apps offered through an allowed alternative use it.
+func (m *PackageManager) SetInstallPolicy(policy domain.InstallPolicy) {
+	m.policy = policy
+}
+
+// SetConnectivity sets the network state found by a preflight check. Unless
+// online, installs are skipped with domain.ErrOffline instead of each timing
+// out on its downloads.
+func (m *PackageManager) SetConnectivity(network domain.Connectivity) {
+	m.network = network
+}
+
+// SetLockTimeout sets how long installers wait for a package manager lock held
+// by another process. Installers without lock handling ignore it.
+func (m *PackageManager) SetLockTimeout(timeout time.Duration) {
+	if waiter, ok := m.installer.(domain.LockWaiter); ok {
+		waiter.SetLockTimeout(timeout)
+	}
+}
+
+// SetAPTFrontend routes APT installs through nala or apt-fast. Installers
+// without APT ignore it.
+func (m *PackageManager) SetAPTFrontend(frontend domain.APTFrontend) {
+	if router, ok := m.installer.(domain.APTRouter); ok {
+		router.SetAPTFrontend(frontend)
+	}
+}
+
+// SetOutputFunc passes what the installer prints to fn rather than the
+// terminal, for a progress display. Installers printing nothing ignore it.
+func (m *PackageManager) SetOutputFunc(fn func(line string)) {
+	if output, ok := m.installer.(domain.InstallerOutput); ok {
+		output.SetOutputFunc(fn)
+	}
+}
+
+// SetKernelInspector sets how the running kernel is inspected. With it,
+// installing an app building kernel modules first installs the kernel's
+// headers and DKMS. Nil leaves that to the app's package.
+func (m *PackageManager) SetKernelInspector(kernel domain.KernelInspector) {
+	m.kernel = kernel
+}
+
+// SetNoteFunc sets a function told what is left to do by hand after an
+// install, such as enrolling a module signing key.
+func (m *PackageManager) SetNoteFunc(fn func(note string)) {
+	m.note = fn
+}
+
+// IsDryRun reports whether operations are simulated.
+func (m *PackageManager) IsDryRun() bool {
+	return m.dryRun
+}
+
+// Install installs a single catalog application by key.
+func (m *PackageManager) Install(ctx context.Context, appKey string) (*domain.InstallationResult, error) {
+	app, exists := apps.Apps[appKey]
+	if !exists {
+		err := domain.NewKindError(domain.ErrorKindNotFound, fmt.Errorf("%w: %s", ErrUnknownApp, appKey))
+		m.report(ctx, OperationInstall, appKey, StageFailed, err)
+
+		return nil, err
+	}
+
+	method, source, err := m.resolve(appKey, app)
+	if errors.Is(err, domain.ErrBlockedByPolicy) {
+		m.report(ctx, OperationInstall, appKey, StageFailed, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &domain.Package{
+		Name:        appKey,
+		Group:       app.Group,
+		Description: app.Description,
+		Method:      method,
+		Source:      source,
+	}
+
+	// The checksum and architectures pin the default source, not the alternatives
+	if source == app.Source {
+		if !domain.SupportsArch(app.Arch, runtime.GOARCH) {
+			err := domain.NewKindError(domain.ErrorKindNotFound,
+				fmt.Errorf("%w: %s is built for %s", domain.ErrUnsupportedArch, appKey, strings.Join(app.Arch, ", ")))
+			m.report(ctx, OperationInstall, appKey, StageFailed, err)
+
+			return nil, err
+		}
+
+		pkg.SHA256 = app.SHA256
+	}
+
+	pkg.Scope = method.Scope()
+	if method == domain.MethodFlatpak {
+		pkg.Scope = m.flatpak.For(appKey)
+		if m.scope != "" {
+			pkg.Scope = m.scope
+		}
+	}
+
+	// Version managers install an exact pin; other constraints are checked afterwards
+	constraint := m.Constraint(appKey)
+	if pin, ok := constraint.Pin(); ok {
+		pkg.Version = pin
+	}
+
+	if m.dryRun {
+		m.report(ctx, OperationInstall, appKey, StageStarted, nil)
+		m.report(ctx, OperationInstall, appKey, StageCompleted, nil)
+
+		return &domain.InstallationResult{Package: pkg, Success: true, Output: "dry run"}, nil
+	}
+
+	// Every install method downloads, so none is attempted without a network
+	if !m.network.Available() {
+		return nil, fmt.Errorf("%w: %s is %s", domain.ErrOffline, appKey, m.network.SkipReason())
+	}
+
+	m.report(ctx, OperationInstall, appKey, StageStarted, nil)
+
+	m.preserveChanges(ctx, appKey)
+
+	buildsModules := app.NeedsDKMS && method == domain.MethodAPT && m.kernel != nil
+	if buildsModules {
+		if err := m.installKernelPrerequisites(ctx, appKey); err != nil {
+			m.report(ctx, OperationInstall, appKey, StageFailed, err)
+
+			return nil, err
+		}
+	}
+
+	started := time.Now()
+	result, err := m.installer.Install(ctx, pkg)
+
+	// Post-install hooks are written for the default method (e.g. its desktop file name)
+	if err == nil && app.PostInstall != nil && method == app.Method {
+		err = app.PostInstall()
+	}
+
+	var artifacts []domain.Artifact
+	if result != nil {
+		artifacts = result.Artifacts
+	}
+
+	if err == nil && m.exporter != nil {
+		exported, exportErr := m.exporter.Export(ctx, pkg)
+		if exportErr != nil {
+			err = fmt.Errorf("%w: %w", ErrExportFailed, exportErr)
+		}
+
+		artifacts = domain.AddArtifacts(artifacts, exported...)
+	}
+
+	if err == nil {
+		err = m.checkVersion(ctx, appKey, method, source, constraint)
+	}
+
+	if err != nil {
+		m.report(ctx, OperationInstall, appKey, StageFailed, err)
+
+		return result, err
+	}
+
+	if m.metrics != nil {
+		_ = m.metrics.Record(appKey, method, time.Since(started))
+	}
+
+	if m.records != nil {
+		// The install succeeded either way; without a record removal falls
+		// back to the preferred method
+		record := NewInstallRecord(pkg)
+		record.Version = constraint.String()
+
+		_ = m.records.Record(appKey, record)
+	}
+
+	if m.manifests != nil {
+		_ = m.manifests.Add(appKey, artifacts...)
+	}
+
+	if buildsModules && m.note != nil && m.kernel.SecureBoot(ctx) {
+		m.note(domain.MOKGuidance(app.Name))
+	}
+
+	m.report(ctx, OperationInstall, appKey, StageCompleted, nil)
+	m.events.Publish(ctx, domain.NewEvent(domain.EventPackageInstalled, appKey))
+
+	return result, nil
+}
+
+// installKernelPrerequisites installs the headers of the running kernel and
+// DKMS, without which the package of an app building kernel modules fails
+// to configure or builds them for no kernel.
+func (m *PackageManager) installKernelPrerequisites(ctx context.Context, appKey string) error {
+	release, err := m.kernel.Release(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range domain.DKMSPrerequisites(release) {
+		if m.installedBy(ctx, name, domain.MethodAPT) {
+			continue
+		}
+
+		pkg := &domain.Package{Name: name, Method: domain.MethodAPT, Source: name, Scope: domain.MethodAPT.Scope()}
+		if _, err := m.installer.Install(ctx, pkg); err != nil {
+			if name != "dkms" {
+				// Kernels built locally or from mainline have no headers package
+				return domain.NewKindError(domain.ErrorKindNotFound,
+					fmt.Errorf("%w: %s needs %s, boot an Ubuntu kernel or install its headers yourself: %w", domain.ErrKernelHeaders, appKey, name, err))
+			}
+
+			return fmt.Errorf("failed to install %s, needed by %s: %w", name, appKey, err)
+		}
+	}
+
+	return nil
+}
+
+// preserveChanges keeps a copy of each file karei created for app that the
+// user changed since, before the install replaces it.
+func (m *PackageManager) preserveChanges(ctx context.Context, appKey string) {
+	if m.manifests == nil {
+		return
+	}
+
+	saved, _ := m.manifests.Preserve(appKey)
+
+	for _, path := range saved {
+		event := domain.NewEvent(domain.EventFileConflict, appKey)
+		event.Data = map[string]string{"path": strings.TrimSuffix(path, savedSuffix), "saved": path}
+		m.events.Publish(ctx, event)
+	}
+}
+
+// resolve picks the method and source to install app with, honouring the
+// install scope and policy.
+func (m *PackageManager) resolve(appKey string, app apps.App) (domain.InstallMethod, string, error) {
+	method, source := app.Resolve(m.preference)
+
+	switch m.scope {
+	case domain.ScopeUser:
+		var ok bool
+		if method, source, ok = app.ResolveUserScope(m.preference); !ok {
+			return "", "", fmt.Errorf("%w: %s", ErrRequiresRoot, appKey)
+		}
+	case domain.ScopeSystem:
+		if alt, altSource, ok := app.ResolveAllowed(m.preference, installsSystemWide); ok {
+			method, source = alt, altSource
+		}
+	}
+
+	if err := m.policy.Check(app.License, method); err != nil {
+		alt, altSource, ok := app.ResolveAllowed(m.preference, m.permits)
+		if !ok || !m.policy.AllowsLicense(app.License) {
+			return "", "", fmt.Errorf("%s: %w", appKey, err)
+		}
+
+		method, source = alt, altSource
+	}
+
+	return method, source, nil
+}
+
+// Method returns the method installing an app would use now. It reports
+// false for unknown apps and apps that wouldn't be installed.
+func (m *PackageManager) Method(appKey string) (domain.InstallMethod, bool) {
+	app, exists := apps.Apps[appKey]
+	if !exists {
+		return "", false
+	}
+
+	method, _, err := m.resolve(appKey, app)
+
+	return method, err == nil
+}
+
+// Estimate returns how long installing an app is expected to take, from
+// earlier installs with the method it would use now. It reports false
+// without history or when the app wouldn't be installed.
+func (m *PackageManager) Estimate(appKey string) (time.Duration, bool) {
+	if m.metrics == nil {
+		return 0, false
+	}
+
+	return m.estimate(m.metrics.Estimator(), appKey)
+}
+
+// EstimateAll sums the estimates of the apps and their dependencies; known
+// counts the apps that had one.
+func (m *PackageManager) EstimateAll(appKeys []string) (total time.Duration, known int) {
+	if m.metrics == nil {
+		return 0, 0
+	}
+
+	estimator := m.metrics.Estimator()
+
+	for _, key := range apps.WithDependencies(appKeys) {
+		if duration, ok := m.estimate(estimator, key); ok {
+			total += duration
+			known++
+		}
+	}
+
+	return total, known
+}
+
+func (m *PackageManager) estimate(estimator DurationEstimator, appKey string) (time.Duration, bool) {
+	app, exists := apps.Apps[appKey]
+	if !exists {
+		return 0, false
+	}
+
+	method, _, err := m.resolve(appKey, app)
+	if err != nil {
+		return 0, false
+	}
+
+	return estimator.Estimate(appKey, method)
+}
+
+// EstimateDownload returns roughly how many bytes installing the apps,
+// dependencies included, downloads, going by the method each is installed with.
+func (m *PackageManager) EstimateDownload(appKeys []string) int64 {
+	var total int64
+
+	for _, key := range apps.WithDependencies(appKeys) {
+		app, exists := apps.Apps[key]
+		if !exists {
+			continue
+		}
+
+		if method, _, err := m.resolve(key, app); err == nil {
+			total += method.TypicalDownloadSize()
+		}
+	}
+
+	return total
+}
+
+// installsSystemWide accepts methods installing for all users.
+func installsSystemWide(method domain.InstallMethod) bool {
+	return method == domain.MethodFlatpak || method.Scope() == domain.ScopeSystem
+}
+
+// Uninstall removes a single catalog application by key.
+func (m *PackageManager) Uninstall(ctx context.Context, appKey string) error {
+	if _, exists := apps.Apps[appKey]; !exists {
+		err := domain.NewKindError(domain.ErrorKindNotFound, fmt.Errorf("%w: %s", ErrUnknownApp, appKey))
+		m.report(ctx, OperationUninstall, appKey, StageFailed, err)
+
+		return err
+	}
+
+	if m.uninstaller == nil {
+		m.report(ctx, OperationUninstall, appKey, StageFailed, ErrNoUninstaller)
+
+		return ErrNoUninstaller
+	}
+
+	m.report(ctx, OperationUninstall, appKey, StageStarted, nil)
+
+	if m.dryRun {
+		m.report(ctx, OperationUninstall, appKey, StageCompleted, nil)
+
+		return nil
+	}
+
+	if err := m.uninstaller.UninstallApp(ctx, appKey); err != nil {
+		m.report(ctx, OperationUninstall, appKey, StageFailed, err)
+
+		return err
+	}
+
+	m.report(ctx, OperationUninstall, appKey, StageCompleted, nil)
+
+	return nil
+}
+
+// Update upgrades an installed app to the newest version its package manager
+// has, through the method it was installed with.
+func (m *PackageManager) Update(ctx context.Context, appKey string) error {
+	if _, exists := apps.Apps[appKey]; !exists {
+		err := domain.NewKindError(domain.ErrorKindNotFound, fmt.Errorf("%w: %s", ErrUnknownApp, appKey))
+		m.report(ctx, OperationUpdate, appKey, StageFailed, err)
+
+		return err
+	}
+
+	updater, ok := m.installer.(domain.PackageUpdater)
+	if !ok {
+		m.report(ctx, OperationUpdate, appKey, StageFailed, ErrNoUpdater)
+
+		return ErrNoUpdater
+	}
+
+	records := map[string]InstallRecord{}
+	if m.records != nil {
+		records, _ = m.records.Load()
+	}
+
+	method, source := m.installedAs(appKey, records)
+
+	pkg := &domain.Package{Name: appKey, Method: method, Source: source, Scope: method.Scope()}
+	if method == domain.MethodFlatpak {
+		pkg.Scope = m.flatpak.For(appKey)
+	}
+
+	m.report(ctx, OperationUpdate, appKey, StageStarted, nil)
+
+	if m.dryRun {
+		m.report(ctx, OperationUpdate, appKey, StageCompleted, nil)
+
+		return nil
+	}
+
+	if !m.network.Available() {
+		err := fmt.Errorf("%w: %s is %s", domain.ErrOffline, appKey, m.network.SkipReason())
+		m.report(ctx, OperationUpdate, appKey, StageFailed, err)
+
+		return err
+	}
+
+	if _, err := updater.Update(ctx, pkg); err != nil {
+		m.report(ctx, OperationUpdate, appKey, StageFailed, err)
+
+		return err
+	}
+
+	m.report(ctx, OperationUpdate, appKey, StageCompleted, nil)
+
+	return nil
+}
+
+// Reinstall removes an installed app's package and installs it again, to
+// repair a broken install. Unlike Uninstall it keeps the user's files; the
+// install inside it is reported as well.
+func (m *PackageManager) Reinstall(ctx context.Context, appKey string) error {
+	if _, exists := apps.Apps[appKey]; !exists {
+		err := domain.NewKindError(domain.ErrorKindNotFound, fmt.Errorf("%w: %s", ErrUnknownApp, appKey))
+		m.report(ctx, OperationReinstall, appKey, StageFailed, err)
+
+		return err
+	}
+
+	records := map[string]InstallRecord{}
+	if m.records != nil {
+		records, _ = m.records.Load()
+	}
+
+	method, source := m.installedAs(appKey, records)
+	pkg := &domain.Package{Name: appKey, Method: method, Source: source, Scope: records[appKey].Scope}
+
+	m.report(ctx, OperationReinstall, appKey, StageStarted, nil)
+
+	if m.dryRun {
+		m.report(ctx, OperationReinstall, appKey, StageCompleted, nil)
+
+		return nil
+	}
+
+	// Check before removing anything, as the install needs the network
+	if !m.network.Available() {
+		err := fmt.Errorf("%w: %s is %s", domain.ErrOffline, appKey, m.network.SkipReason())
+		m.report(ctx, OperationReinstall, appKey, StageFailed, err)
+
+		return err
+	}
+
+	if _, err := m.installer.Remove(ctx, pkg); err != nil {
+		m.report(ctx, OperationReinstall, appKey, StageFailed, err)
+
+		return err
+	}
+
+	if _, err := m.Install(ctx, appKey); err != nil {
+		m.report(ctx, OperationReinstall, appKey, StageFailed, err)
+
+		return err
+	}
+
+	m.report(ctx, OperationReinstall, appKey, StageCompleted, nil)
+
+	return nil
+}
+
+// InstallAll installs each application and aggregates the outcome. Catalog
+// dependencies are installed first, even when not asked for. Blank names are
+// ignored; failures do not stop the batch. Apps the install policy blocks, in
+// user-scope mode apps that need sudo, and without a network all apps, are
+// reported as skipped along with the reason.
+func (m *PackageManager) InstallAll(ctx context.Context, appKeys []string) *domain.InstallResult {
+	startTime := time.Now()
+	result := &domain.InstallResult{Timestamp: startTime}
+
+	keys := make([]string, 0, len(appKeys))
+
+	for _, key := range appKeys {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	skip := func(key, reason string) {
+		if result.SkipReasons == nil {
+			result.SkipReasons = make(map[string]string)
+		}
+
+		result.Skipped = append(result.Skipped, key)
+		result.SkipReasons[key] = reason
+	}
+
+	installed := m.listInstalled(ctx)
+
+	for _, key := range apps.WithDependencies(keys) {
+		if m.alreadyInstalled(installed, key) {
+			skip(key, domain.SkipInstalled)
+
+			continue
+		}
+
+		_, err := m.Install(ctx, key)
+
+		if reason := m.skipReason(err); reason != "" {
+			skip(key, reason)
+
+			continue
+		}
+
+		switch {
+		case err != nil:
+			result.Failed = append(result.Failed, key)
+
+			if result.Errors == nil {
+				result.Errors = make(map[string]error)
+				result.FailureKinds = make(map[string]domain.ErrorKind)
+			}
+
+			result.Errors[key] = err
+
+			if kind := domain.ClassifyError(err); kind != domain.ErrorKindUnknown {
+				result.FailureKinds[key] = kind
+			}
+		default:
+			result.Installed = append(result.Installed, key)
+		}
+	}
+
+	result.Duration = time.Since(startTime)
+
+	return result
+}
+
+// listInstalled lists what the package managers have installed, when the
+// installer can tell in one query each.
+func (m *PackageManager) listInstalled(ctx context.Context) domain.InstalledPackages {
+	lister, ok := m.installer.(domain.InstalledLister)
+	if !ok {
+		return nil
+	}
+
+	return lister.ListInstalled(ctx)
+}
+
+// alreadyInstalled reports whether installed lists the app as installed
+// with the method it would be installed with, so a repeat run skips it
+// rather than asking its installer. Apps held to a version constraint are
+// always installed, to check the version.
+func (m *PackageManager) alreadyInstalled(installed domain.InstalledPackages, appKey string) bool {
+	app, exists := apps.Apps[appKey]
+	if !exists || installed == nil || !m.Constraint(appKey).IsZero() {
+		return false
+	}
+
+	method, source, err := m.resolve(appKey, app)
+	if err != nil {
+		return false
+	}
+
+	has, _ := installed.Has(method, packageName(appKey, method, source))
+
+	return has
+}
+
+// packageName returns the name an app's package manager knows it by. .deb
+// files are downloaded from a URL, but dpkg knows them by name.
+func packageName(appKey string, method domain.InstallMethod, source string) string {
+	if method == domain.MethodDEB {
+		return appKey
+	}
+
+	return source
+}
+
+// installedAs returns the method and source an app was installed with: its
+// install record, or else how it would be installed now.
+func (m *PackageManager) installedAs(appKey string, records map[string]InstallRecord) (domain.InstallMethod, string) {
+	if record, ok := records[appKey]; ok {
+		return record.Method, record.Source
+	}
+
+	return apps.Apps[appKey].Resolve(m.preference)
+}
+
+// ChangeRequiresRoot reports whether removing, updating or reinstalling an
+// installed app needs sudo. It goes by the installation holding the app when
+// the installer can tell, else by the app's install record, and only then by
+// how the app would be installed now. Reinstalling also needs sudo when the
+// install does, which Method tells.
+func (m *PackageManager) ChangeRequiresRoot(ctx context.Context, appKey string) bool {
+	if _, exists := apps.Apps[appKey]; !exists {
+		return false
+	}
+
+	record, recorded := m.recorded(appKey)
+
+	method, source := record.Method, record.Source
+	if !recorded {
+		method, source = apps.Apps[appKey].Resolve(m.preference)
+	}
+
+	if method != domain.MethodFlatpak {
+		return method.RequiresRoot()
+	}
+
+	scope := record.Scope
+
+	if detector, ok := m.installer.(domain.ScopeDetector); ok {
+		if installed, found := detector.InstalledScope(ctx, &domain.Package{Name: appKey, Method: method, Source: source}); found {
+			scope = installed
+		}
+	}
+
+	if scope == "" {
+		scope = m.flatpak.For(appKey)
+	}
+
+	return scope == domain.ScopeSystem
+}
+
+// Outdated returns the catalog apps their package manager has a newer
+// version of, keyed by catalog key, when the installer can tell. An update
+// available event is published for each.
+func (m *PackageManager) Outdated(ctx context.Context) map[string]domain.OutdatedPackage {
+	lister, ok := m.installer.(domain.OutdatedLister)
+	if !ok {
+		return nil
+	}
+
+	available := map[domain.InstallMethod]map[string]domain.OutdatedPackage{}
+
+	for _, pkg := range lister.ListOutdated(ctx) {
+		if available[pkg.Method] == nil {
+			available[pkg.Method] = map[string]domain.OutdatedPackage{}
+		}
+
+		available[pkg.Method][pkg.Name] = pkg
+	}
+
+	records := map[string]InstallRecord{}
+	if m.records != nil {
+		records, _ = m.records.Load()
+	}
+
+	outdated := map[string]domain.OutdatedPackage{}
+
+	for key := range apps.Apps {
+		method, source := m.installedAs(key, records)
+
+		// dpkg upgrades .deb packages that added an apt repository
+		lookup := method
+		if method == domain.MethodDEB {
+			lookup = domain.MethodAPT
+		}
+
+		if pkg, ok := available[lookup][packageName(key, method, source)]; ok {
+			outdated[key] = pkg
+		}
+	}
+
+	for key, pkg := range outdated {
+		event := domain.NewEvent(domain.EventUpdateAvailable, key)
+		event.Data = map[string]string{"installed": pkg.Installed, "available": pkg.Available}
+		m.events.Publish(ctx, event)
+	}
+
+	return outdated
+}
+
+// DiskUsage returns the disk space the apps take, as far as the installer
+// can tell.
+func (m *PackageManager) DiskUsage(ctx context.Context, appKeys []string) int64 {
+	reporter, ok := m.installer.(domain.DiskUsageReporter)
+	if !ok {
+		return 0
+	}
+
+	records := map[string]InstallRecord{}
+	if m.records != nil {
+		records, _ = m.records.Load()
+	}
+
+	packages := make([]*domain.Package, 0, len(appKeys))
+
+	for _, key := range appKeys {
+		if _, exists := apps.Apps[key]; !exists {
+			continue
+		}
+
+		method, source := m.installedAs(key, records)
+		packages = append(packages, &domain.Package{Name: packageName(key, method, source), Method: method, Source: source})
+	}
+
+	return reporter.DiskUsage(ctx, packages)
+}
+
+// skipReason returns why an install failing with err was not attempted, or ""
+// when it was.
+func (m *PackageManager) skipReason(err error) string {
+	switch {
+	case errors.Is(err, ErrRequiresRoot):
+		return domain.SkipRequiresRoot
+	case errors.Is(err, domain.ErrBlockedByPolicy):
+		return domain.SkipBlockedByPolicy
+	case errors.Is(err, domain.ErrOffline):
+		return m.network.SkipReason()
+	default:
+		return ""
+	}
+}
+
+// UninstallAll removes each application and aggregates the outcome.
+// Keys missing from the catalog are reported as not found.
+func (m *PackageManager) UninstallAll(ctx context.Context, appKeys []string) *domain.UninstallResult {
+	startTime := time.Now()
+	result := &domain.UninstallResult{Timestamp: startTime}
+
+	for _, key := range appKeys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		err := m.Uninstall(ctx, key)
+
+		switch {
+		case err == nil:
+			result.Uninstalled = append(result.Uninstalled, key)
+		case errors.Is(err, ErrUnknownApp):
+			result.NotFound = append(result.NotFound, key)
+		default:
+			result.Failed = append(result.Failed, key)
+		}
+	}
+
+	result.Duration = time.Since(startTime)
+
+	return result
+}
+
+// IsInstalled checks whether a catalog application is installed, querying only its own method.
+func (m *PackageManager) IsInstalled(ctx context.Context, appKey string) bool {
+	app, exists := apps.Apps[appKey]
+	if !exists {
+		return false
+	}
+
+	method, source := app.Resolve(m.preference)
+
+	// Flatpak and npm are tracked by application ID and package, everything else by catalog key
+	identifier := appKey
+	if method == domain.MethodFlatpak || method == domain.MethodNpm {
+		identifier = source
+	}
+
+	return m.installedBy(ctx, identifier, method)
+}
+
+// installedBy checks whether the package known as name is installed,
+// querying only method when the installer can.
+func (m *PackageManager) installedBy(ctx context.Context, name string, method domain.InstallMethod) bool {
+	type methodChecker interface {
+		IsInstalledByMethod(ctx context.Context, name string, method domain.InstallMethod) (bool, error)
+	}
+
+	if checker, ok := m.installer.(methodChecker); ok {
+		installed, err := checker.IsInstalledByMethod(ctx, name, method)
+
+		return err == nil && installed
+	}
+
+	installed, err := m.installer.IsInstalled(ctx, name)
+
+	return err == nil && installed
+}
+
+// Plan returns what installing appKeys would change: apps not installed
+// yet, and installed apps whose version is outside their constraint. Apps
+// already as wanted are left out.
+func (m *PackageManager) Plan(ctx context.Context, appKeys []string) []domain.PlannedChange {
+	var changes []domain.PlannedChange
+
+	for _, key := range appKeys {
+		constraint := m.Constraint(key)
+
+		if !m.IsInstalled(ctx, key) {
+			changes = append(changes, domain.PlannedChange{Kind: domain.ChangeInstall, Target: key, To: constraint.String()})
+
+			continue
+		}
+
+		if m.versions == nil || constraint.IsZero() {
+			continue
+		}
+
+		method, source := apps.Apps[key].Resolve(m.preference)
+
+		installed, _ := m.versions.ResolveVersion(ctx, versionName(key, method, source), string(method))
+		if err := constraint.Check(key, installed); err != nil {
+			changes = append(changes, domain.PlannedChange{Kind: domain.ChangeUpgrade, Target: key, From: installed, To: constraint.String()})
+		}
+	}
+
+	return changes
+}
+
+// Inventory lists the installed catalog applications, sorted by key, with
+// their versions and checksums. Lookups that fail leave the field empty, and
+// a nil checksums resolver skips hashing.
+func (m *PackageManager) Inventory(ctx context.Context, versions domain.VersionResolver, checksums domain.ChecksumResolver) []domain.ManagedPackage {
+	keys := make([]string, 0, len(apps.Apps))
+	for key := range apps.Apps {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	var inventory []domain.ManagedPackage
+
+	for _, key := range keys {
+		if !m.IsInstalled(ctx, key) {
+			continue
+		}
+
+		app := apps.Apps[key]
+		method, source := app.Resolve(m.preference)
+
+		license := app.License
+		if license == "" {
+			license = domain.LicenseOpenSource
+		}
+
+		name := versionName(key, method, source)
+
+		pkg := domain.ManagedPackage{
+			Key:       key,
+			Name:      app.Name,
+			Method:    method,
+			Source:    source,
+			SourceURL: sourceURL(method, source),
+			License:   license,
+		}
+
+		pkg.Version, _ = versions.ResolveVersion(ctx, name, string(method))
+
+		if checksums != nil {
+			pkg.Checksum, _ = checksums.ResolveChecksum(ctx, name, string(method))
+		}
+
+		inventory = append(inventory, pkg)
+	}
+
+	return inventory
+}
+
+// VersionViolations checks every installed app with a version constraint
+// against the version installed, returning an ErrVersionConstraint error for
+// each that doesn't satisfy it. Apps whose version can't be determined pass.
+func (m *PackageManager) VersionViolations(ctx context.Context, versions domain.VersionResolver) []error {
+	keys := make([]string, 0, len(apps.Apps))
+	for key := range apps.Apps {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	records := map[string]InstallRecord{}
+	if m.records != nil {
+		records, _ = m.records.Load()
+	}
+
+	var violations []error
+
+	for _, key := range keys {
+		record, recorded := records[key]
+
+		constraint := m.constraintWith(key, record)
+		if constraint.IsZero() || !m.IsInstalled(ctx, key) {
+			continue
+		}
+
+		method, source := apps.Apps[key].Resolve(m.preference)
+		if recorded {
+			method, source = record.Method, record.Source
+		}
+
+		installed, _ := versions.ResolveVersion(ctx, versionName(key, method, source), string(method))
+		if err := constraint.Check(key, installed); err != nil {
+			violations = append(violations, err)
+		}
+	}
+
+	return violations
+}
+
+// checkVersion fails an install that left a version outside the constraint,
+// e.g. when apt only offers a newer release.
+func (m *PackageManager) checkVersion(ctx context.Context, appKey string, method domain.InstallMethod, source string, constraint domain.VersionConstraint) error {
+	if m.versions == nil || constraint.IsZero() {
+		return nil
+	}
+
+	installed, _ := m.versions.ResolveVersion(ctx, versionName(appKey, method, source), string(method))
+
+	return constraint.Check(appKey, installed)
+}
+
+// recorded returns the install record of an app, if any.
+func (m *PackageManager) recorded(appKey string) (InstallRecord, bool) {
+	if m.records == nil {
+		return InstallRecord{}, false
+	}
+
+	return m.records.Get(appKey)
+}
+
+// versionName returns the name the version resolver knows an app by: package
+// managers know apps by package name or app ID, PATH by catalog key.
+func versionName(key string, method domain.InstallMethod, source string) string {
+	if method == domain.MethodAPT || method == domain.MethodFlatpak || method == domain.MethodSnap {
+		return source
+	}
+
+	return key
+}
+
+// sourceURL returns where a package installed with method from source can be
+// inspected, or an empty string for registries without a page per package.
+func sourceURL(method domain.InstallMethod, source string) string {
+	if strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://") {
+		return source
+	}
+
+	switch method {
+	case domain.MethodAPT:
+		return "https://packages.ubuntu.com/" + source
+	case domain.MethodFlatpak:
+		return "https://flathub.org/apps/" + source
+	case domain.MethodSnap:
+		return "https://snapcraft.io/" + source
+	case domain.MethodGitHub, domain.MethodGitHubBinary, domain.MethodGitHubBundle, domain.MethodGitHubJava:
+		return "https://github.com/" + source
+	default:
+		return ""
+	}
+}
+
+// CheckPolicy returns domain.ErrBlockedByPolicy when the install policy
+// allows none of the ways to install the app. Unknown apps pass.
+func (m *PackageManager) CheckPolicy(appKey string) error {
+	app, exists := apps.Apps[appKey]
+	if !exists || app.Allowed(m.policy) {
+		return nil
+	}
+
+	method, _ := app.Resolve(m.preference)
+
+	return fmt.Errorf("%s: %w", appKey, m.policy.Check(app.License, method))
+}
+
+// permits reports whether the install policy and user-scope mode allow the method.
+func (m *PackageManager) permits(method domain.InstallMethod) bool {
+	return m.policy.AllowsMethod(method) && (m.scope != domain.ScopeUser || !method.RequiresRoot())
+}
+
+// InstalledConflicts maps each of appKeys to the installed catalog apps it
+// conflicts with. Apps without installed conflicts are left out.
+func (m *PackageManager) InstalledConflicts(ctx context.Context, appKeys []string) map[string][]string {
+	conflicts := make(map[string][]string)
+
+	for _, key := range appKeys {
+		for _, other := range apps.Conflicting(key) {
+			if m.IsInstalled(ctx, other) {
+				conflicts[key] = append(conflicts[key], other)
+			}
+		}
+	}
+
+	return conflicts
+}
+
+func (m *PackageManager) report(ctx context.Context, operation, appKey string, stage ProgressStage, err error) {
+	update := ProgressUpdate{
+		Operation: operation,
+		App:       appKey,
+		Stage:     stage,
+		Err:       err,
+	}
+
+	switch stage {
+	case StageStarted:
+		update.Message = fmt.Sprintf("%s %s", operationVerb(operation), appKey)
+	case StageCompleted:
+		update.Message = fmt.Sprintf("%s %s done", operationVerb(operation), appKey)
+	case StageFailed:
+		update.Message = fmt.Sprintf("%s %s failed: %v", operationVerb(operation), appKey, err)
+	}
+
+	if m.dryRun {
+		update.Message = "DRY RUN: " + update.Message
+	}
+
+	m.progress(update)
+	m.events.Publish(ctx, operationEvent(update, m.dryRun))
+}
+
+// operationEvent turns a progress update into an operation event.
+func operationEvent(update ProgressUpdate, dryRun bool) domain.Event {
+	eventType := domain.EventOperationStarted
+
+	switch update.Stage {
+	case StageCompleted:
+		eventType = domain.EventOperationCompleted
+	case StageFailed:
+		eventType = domain.EventOperationFailed
+	}
+
+	event := domain.NewEvent(eventType, update.App)
+	event.Data = map[string]string{"operation": update.Operation}
+
+	if update.Err != nil {
+		event.Data["error"] = update.Err.Error()
+	}
+
+	if dryRun {
+		event.Data["dry_run"] = "true"
+	}
+
+	return event
+}
+
+func operationVerb(operation string) string {
+	switch operation {
+	case OperationUninstall:
+		return "Uninstalling"
+	case OperationUpdate:
+		return "Updating"
+	case OperationReinstall:
+		return "Reinstalling"
+	}
+
+	return "Installing"
+}
@@ -0,0 +1,725 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubUninstaller records removals for facade tests.
+type stubUninstaller struct {
+	removed []string
+	err     error
+}
+
+func (s *stubUninstaller) UninstallApp(_ context.Context, name string) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.removed = append(s.removed, name)
+
+	return nil
+}
+
+func (s *stubUninstaller) UninstallGroup(context.Context, string) error { return nil }
+
+func TestPackageManagerInstallReportsProgressAndEvents(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "rust" && pkg.Method == domain.MethodMise
+	})).Return(&domain.InstallationResult{Success: true}, nil)
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+
+	var stages []application.ProgressStage
+
+	manager.SetProgressFunc(func(u application.ProgressUpdate) {
+		stages = append(stages, u.Stage)
+	})
+
+	bus := domain.NewEventBus()
+	manager.SetEventPublisher(bus)
+
+	var installed, operations []string
+
+	bus.Subscribe(domain.EventPackageInstalled, func(_ context.Context, e domain.Event) {
+		installed = append(installed, e.Subject)
+	})
+	bus.SubscribeAll(func(_ context.Context, e domain.Event) {
+		if e.Data["operation"] == application.OperationInstall {
+			operations = append(operations, string(e.Type))
+		}
+	})
+
+	result, err := manager.Install(context.Background(), "rust")
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, []application.ProgressStage{application.StageStarted, application.StageCompleted}, stages)
+	assert.Equal(t, []string{"rust"}, installed)
+	assert.Equal(t, []string{"operation.started", "operation.completed"}, operations)
+	mockInstaller.AssertExpectations(t)
+}
+
+func TestPackageManagerDryRunSkipsAdapters(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	uninstaller := &stubUninstaller{}
+	manager := application.NewPackageManager(mockInstaller, uninstaller, true)
+
+	var messages []string
+
+	manager.SetProgressFunc(func(u application.ProgressUpdate) {
+		messages = append(messages, u.Message)
+	})
+
+	result, err := manager.Install(context.Background(), "rust")
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	require.NoError(t, manager.Uninstall(context.Background(), "rust"))
+
+	assert.Empty(t, uninstaller.removed)
+	mockInstaller.AssertNotCalled(t, "Install")
+
+	for _, msg := range messages {
+		assert.Contains(t, msg, "DRY RUN")
+	}
+}
+
+func TestPackageManagerInstallAllAggregates(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "rust"
+	})).Return(&domain.InstallationResult{Success: true}, nil)
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "cargo-audit"
+	})).Return(nil, domain.ErrNetworkFailure)
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+
+	result := manager.InstallAll(context.Background(), []string{"rust", " ", "cargo-audit", "no-such-app"})
+
+	assert.Equal(t, []string{"rust"}, result.Installed)
+	assert.Equal(t, []string{"cargo-audit", "no-such-app"}, result.Failed)
+	assert.Equal(t, map[string]domain.ErrorKind{
+		"cargo-audit": domain.ErrorKindNetwork,
+		"no-such-app": domain.ErrorKindNotFound,
+	}, result.FailureKinds)
+	assert.False(t, result.Timestamp.IsZero())
+}
+
+func TestPackageManagerInstallAllIncludesDependencies(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.Anything).Return(&domain.InstallationResult{Success: true}, nil)
+
+	var installed []string
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetProgressFunc(func(update application.ProgressUpdate) {
+		if update.Stage == application.StageStarted {
+			installed = append(installed, update.App)
+		}
+	})
+
+	result := manager.InstallAll(context.Background(), []string{"cargo-audit", "cargo-watch", "rust"})
+
+	assert.Equal(t, []string{"rust", "cargo-audit", "cargo-watch"}, installed, "dependencies first, once")
+	assert.Equal(t, installed, result.Installed)
+}
+
+// listingInstaller lists installed packages in bulk.
+type listingInstaller struct {
+	*testutil.MockPackageInstaller
+
+	installed domain.InstalledPackages
+}
+
+func (l listingInstaller) ListInstalled(context.Context) domain.InstalledPackages {
+	return l.installed
+}
+
+func TestPackageManagerInstallAllSkipsInstalled(t *testing.T) {
+	t.Parallel()
+
+	installed := domain.InstalledPackages{}
+	installed.Add(domain.MethodAPT, "vlc")
+	installed.Add(domain.MethodFlatpak)
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "spotify"
+	})).Return(&domain.InstallationResult{Success: true}, nil)
+
+	manager := application.NewPackageManager(listingInstaller{mockInstaller, installed}, nil, false)
+
+	result := manager.InstallAll(context.Background(), []string{"vlc", "spotify"})
+
+	assert.Equal(t, []string{"spotify"}, result.Installed)
+	assert.Equal(t, []string{"vlc"}, result.Skipped)
+	assert.Equal(t, domain.SkipInstalled, result.SkipReasons["vlc"])
+	mockInstaller.AssertNumberOfCalls(t, "Install", 1)
+
+	// A version constraint has to be checked by installing
+	constraints, err := domain.ParseVersionConstraints(map[string]string{"vlc": ">=3"})
+	require.NoError(t, err)
+
+	manager.SetVersionConstraints(constraints)
+	mockInstaller.On("Install", mock.Anything, mock.Anything).Return(&domain.InstallationResult{Success: true}, nil)
+
+	result = manager.InstallAll(context.Background(), []string{"vlc"})
+	assert.Empty(t, result.Skipped)
+}
+
+// updatingInstaller reports available updates and disk usage.
+type updatingInstaller struct {
+	*testutil.MockPackageInstaller
+
+	outdated []domain.OutdatedPackage
+	measured []*domain.Package
+	updated  []*domain.Package
+}
+
+func (u *updatingInstaller) Update(_ context.Context, pkg *domain.Package) (*domain.InstallationResult, error) {
+	u.updated = append(u.updated, pkg)
+
+	return &domain.InstallationResult{Package: pkg, Success: true}, nil
+}
+
+func (u *updatingInstaller) ListOutdated(context.Context) []domain.OutdatedPackage {
+	return u.outdated
+}
+
+func (u *updatingInstaller) DiskUsage(_ context.Context, packages []*domain.Package) int64 {
+	u.measured = packages
+
+	return int64(len(packages)) * 1000
+}
+
+func TestPackageManagerOutdatedAndDiskUsage(t *testing.T) {
+	t.Parallel()
+
+	installer := &updatingInstaller{
+		MockPackageInstaller: new(testutil.MockPackageInstaller),
+		outdated: []domain.OutdatedPackage{
+			{Method: domain.MethodAPT, Name: "vlc", Installed: "3.0.20", Available: "3.0.21"},
+			{Method: domain.MethodAPT, Name: "gimp", Available: "2.10.38"},
+			{Method: domain.MethodFlatpak, Name: "org.gimp.GIMP", Available: "3.0.0"},
+			{Method: domain.MethodAPT, Name: "libc6", Available: "2.39-0ubuntu8.4"},
+		},
+	}
+
+	records := application.NewInstallRecords(platform.NewFileManager(false), filepath.Join(t.TempDir(), "installs.json"))
+	require.NoError(t, records.Record("gimp", application.InstallRecord{Method: domain.MethodFlatpak, Source: "org.gimp.GIMP"}))
+
+	manager := application.NewPackageManager(installer, nil, false)
+	manager.SetInstallRecords(records)
+
+	bus := domain.NewEventBus()
+	manager.SetEventPublisher(bus)
+
+	var announced []string
+
+	bus.Subscribe(domain.EventUpdateAvailable, func(_ context.Context, event domain.Event) {
+		announced = append(announced, event.Subject+" "+event.Data["available"])
+	})
+
+	// gimp is matched by how it was installed; libc6 isn't in the catalog
+	assert.Equal(t, map[string]domain.OutdatedPackage{
+		"vlc":  installer.outdated[0],
+		"gimp": installer.outdated[2],
+	}, manager.Outdated(context.Background()))
+	assert.ElementsMatch(t, []string{"vlc 3.0.21", "gimp 3.0.0"}, announced)
+
+	assert.Equal(t, int64(2000), manager.DiskUsage(context.Background(), []string{"gimp", "vlc", "no-such-app"}))
+	assert.Equal(t, []*domain.Package{
+		{Name: "org.gimp.GIMP", Method: domain.MethodFlatpak, Source: "org.gimp.GIMP"},
+		{Name: "vlc", Method: domain.MethodAPT, Source: "vlc"},
+	}, installer.measured)
+
+	// Installers that can't tell report nothing
+	plain := application.NewPackageManager(new(testutil.MockPackageInstaller), nil, false)
+	assert.Nil(t, plain.Outdated(context.Background()))
+	assert.Zero(t, plain.DiskUsage(context.Background(), []string{"vlc"}))
+}
+
+func TestPackageManagerUpdate(t *testing.T) {
+	t.Parallel()
+
+	installer := &updatingInstaller{MockPackageInstaller: new(testutil.MockPackageInstaller)}
+
+	records := application.NewInstallRecords(platform.NewFileManager(false), filepath.Join(t.TempDir(), "installs.json"))
+	require.NoError(t, records.Record("gimp", application.InstallRecord{Method: domain.MethodFlatpak, Source: "org.gimp.GIMP"}))
+
+	manager := application.NewPackageManager(installer, nil, false)
+	manager.SetInstallRecords(records)
+
+	// Apps are updated through the method they were installed with
+	require.NoError(t, manager.Update(context.Background(), "gimp"))
+	require.NoError(t, manager.Update(context.Background(), "vlc"))
+	assert.Equal(t, []*domain.Package{
+		{Name: "gimp", Method: domain.MethodFlatpak, Source: "org.gimp.GIMP", Scope: domain.ScopeUser},
+		{Name: "vlc", Method: domain.MethodAPT, Source: "vlc", Scope: domain.ScopeSystem},
+	}, installer.updated)
+
+	require.ErrorIs(t, manager.Update(context.Background(), "no-such-app"), application.ErrUnknownApp)
+
+	plain := application.NewPackageManager(new(testutil.MockPackageInstaller), nil, false)
+	require.ErrorIs(t, plain.Update(context.Background(), "vlc"), application.ErrNoUpdater)
+}
+
+func TestPackageManagerReinstall(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Remove", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "rust" && pkg.Method == domain.MethodMise
+	})).Return(&domain.InstallationResult{Success: true}, nil).Once()
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "rust" && pkg.Method == domain.MethodMise
+	})).Return(&domain.InstallationResult{Success: true}, nil).Once()
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+
+	var operations []string
+
+	manager.SetProgressFunc(func(u application.ProgressUpdate) {
+		operations = append(operations, u.Operation+" "+string(u.Stage))
+	})
+
+	// The package is removed and installed again without the uninstaller,
+	// which would clear the user's files
+	require.NoError(t, manager.Reinstall(context.Background(), "rust"))
+	assert.Equal(t, []string{"reinstall started", "install started", "install completed", "reinstall completed"}, operations)
+	mockInstaller.AssertExpectations(t)
+
+	failing := new(testutil.MockPackageInstaller)
+	failing.On("Remove", mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+
+	require.ErrorIs(t, application.NewPackageManager(failing, nil, false).Reinstall(context.Background(), "rust"), assert.AnError)
+	failing.AssertNotCalled(t, "Install", mock.Anything, mock.Anything)
+}
+
+func TestPackageManagerInstalledConflicts(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("IsInstalled", mock.Anything, "podman-docker").Return(true, nil)
+	mockInstaller.On("IsInstalled", mock.Anything, "tlp").Return(false, nil)
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+
+	conflicts := manager.InstalledConflicts(context.Background(), []string{"docker.io", "power-profiles-daemon", "rust"})
+
+	assert.Equal(t, map[string][]string{"docker.io": {"podman-docker"}}, conflicts)
+}
+
+func TestPackageManagerInventory(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("IsInstalled", mock.Anything, "vlc").Return(true, nil)
+	mockInstaller.On("IsInstalled", mock.Anything, "dev.zed.Zed").Return(true, nil)
+	mockInstaller.On("IsInstalled", mock.Anything, mock.Anything).Return(false, nil)
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+
+	inventory := manager.Inventory(context.Background(),
+		platform.NewMockVersionResolver(map[string]string{"vlc": "3.0.20"}),
+		platform.NewMockChecksumResolver(map[string]string{"dev.zed.Zed": "ab12"}))
+
+	require.Len(t, inventory, 2)
+	assert.Equal(t, domain.ManagedPackage{
+		Key: "vlc", Name: "VLC Media Player", Version: "3.0.20", Method: domain.MethodAPT, Source: "vlc",
+		SourceURL: "https://packages.ubuntu.com/vlc", License: domain.LicenseOpenSource,
+	}, inventory[0])
+	assert.Equal(t, "zed", inventory[1].Key)
+	assert.Equal(t, "https://flathub.org/apps/dev.zed.Zed", inventory[1].SourceURL)
+	assert.Equal(t, "ab12", inventory[1].Checksum)
+}
+
+func TestPackageManagerVersionConstraints(t *testing.T) {
+	t.Parallel()
+
+	// An exact pin is passed on to the installer
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "rust" && pkg.Version == "1.80.1"
+	})).Return(&domain.InstallationResult{Success: true}, nil).Once()
+	mockInstaller.On("Install", mock.Anything, mock.Anything).Return(&domain.InstallationResult{Success: true}, nil)
+
+	constraints, err := domain.ParseVersionConstraints(map[string]string{"rust": "=1.80.1", "vlc": ">=3.1 <4"})
+	require.NoError(t, err)
+
+	records := application.NewInstallRecords(platform.NewFileManager(false), filepath.Join(t.TempDir(), "installs.json"))
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetVersionConstraints(constraints)
+	manager.SetInstallRecords(records)
+	manager.SetVersionResolver(platform.NewMockVersionResolver(map[string]string{"rust": "1.80.1", "vlc": "3.0.20"}))
+
+	_, err = manager.Install(context.Background(), "rust")
+	require.NoError(t, err)
+
+	record, ok := records.Get("rust")
+	require.True(t, ok)
+	assert.Equal(t, "=1.80.1", record.Version)
+
+	// A version outside the constraint fails the install
+	_, err = manager.Install(context.Background(), "vlc")
+	require.ErrorIs(t, err, domain.ErrVersionConstraint)
+
+	// The recorded constraint still applies without the manifest
+	mockInstaller.On("IsInstalled", mock.Anything, mock.Anything).Return(true, nil)
+
+	later := application.NewPackageManager(mockInstaller, nil, false)
+	later.SetInstallRecords(records)
+	assert.Equal(t, "=1.80.1", later.Constraint("rust").String())
+
+	violations := later.VersionViolations(context.Background(), platform.NewMockVersionResolver(map[string]string{"rust": "1.81.0"}))
+	require.Len(t, violations, 1)
+	require.ErrorIs(t, violations[0], domain.ErrVersionConstraint)
+	assert.Contains(t, violations[0].Error(), "rust 1.81.0")
+}
+
+func TestPackageManagerInstallPolicy(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "vlc" && pkg.Method == domain.MethodFlatpak
+	})).Return(&domain.InstallationResult{Success: true}, nil).Once()
+
+	policy, err := domain.ParseInstallPolicy([]string{"proprietary", "distribution"})
+	require.NoError(t, err)
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetInstallPolicy(policy)
+
+	// vlc falls back to its flatpak alternative; spotify is proprietary
+	result := manager.InstallAll(context.Background(), []string{"vlc", "spotify"})
+
+	assert.Equal(t, []string{"vlc"}, result.Installed)
+	assert.Equal(t, []string{"spotify"}, result.Skipped)
+	mockInstaller.AssertExpectations(t)
+
+	require.NoError(t, manager.CheckPolicy("vlc"))
+	require.ErrorIs(t, manager.CheckPolicy("spotify"), domain.ErrBlockedByPolicy)
+	require.ErrorIs(t, manager.CheckPolicy("fish"), domain.ErrBlockedByPolicy, "apt only")
+
+	method, ok := manager.Method("vlc")
+	assert.True(t, ok)
+	assert.Equal(t, domain.MethodFlatpak, method)
+
+	_, ok = manager.Method("spotify")
+	assert.False(t, ok)
+}
+
+func TestPackageManagerUninstallAllClassifiesFailures(t *testing.T) {
+	t.Parallel()
+
+	manager := application.NewPackageManager(new(testutil.MockPackageInstaller), &stubUninstaller{}, false)
+
+	result := manager.UninstallAll(context.Background(), []string{"rust", "no-such-app"})
+	assert.Equal(t, []string{"rust"}, result.Uninstalled)
+	assert.Equal(t, []string{"no-such-app"}, result.NotFound)
+
+	failing := application.NewPackageManager(new(testutil.MockPackageInstaller), &stubUninstaller{err: errors.New("boom")}, false)
+
+	result = failing.UninstallAll(context.Background(), []string{"rust"})
+	assert.Equal(t, []string{"rust"}, result.Failed)
+}
+
+func TestPackageManagerUninstallWithoutUninstaller(t *testing.T) {
+	t.Parallel()
+
+	manager := application.NewPackageManager(new(testutil.MockPackageInstaller), nil, false)
+
+	err := manager.Uninstall(context.Background(), "rust")
+	require.ErrorIs(t, err, application.ErrNoUninstaller)
+}
+
+func TestPackageManagerIsInstalled(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("IsInstalled", mock.Anything, "rust").Return(true, nil)
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+
+	assert.True(t, manager.IsInstalled(context.Background(), "rust"))
+	assert.False(t, manager.IsInstalled(context.Background(), "no-such-app"))
+}
+
+// stubExporter records exported packages.
+type stubExporter struct {
+	exported []string
+	err      error
+}
+
+func (s *stubExporter) Export(_ context.Context, pkg *domain.Package) ([]domain.Artifact, error) {
+	s.exported = append(s.exported, pkg.Name)
+
+	return nil, s.err
+}
+
+func TestPackageManagerExportsContainerInstalls(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.Anything).Return(&domain.InstallationResult{Success: true}, nil)
+
+	exporter := &stubExporter{}
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetContainerExporter(exporter)
+
+	_, err := manager.Install(context.Background(), "vlc")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vlc"}, exporter.exported)
+
+	exporter.err = errors.New("distrobox-export failed")
+
+	_, err = manager.Install(context.Background(), "vlc")
+	require.ErrorIs(t, err, application.ErrExportFailed)
+}
+
+func TestPackageManagerHonoursMethodPreference(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Method == domain.MethodFlatpak && pkg.Source == "org.videolan.VLC"
+	})).Return(&domain.InstallationResult{Success: true}, nil).Once()
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "fish" && pkg.Method == domain.MethodAPT
+	})).Return(&domain.InstallationResult{Success: true}, nil).Once()
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetMethodPreference(domain.MethodPreference{domain.MethodFlatpak})
+
+	// vlc has a flatpak alternative; fish does not and keeps its default
+	_, err := manager.Install(context.Background(), "vlc")
+	require.NoError(t, err)
+
+	_, err = manager.Install(context.Background(), "fish")
+	require.NoError(t, err)
+
+	mockInstaller.AssertExpectations(t)
+}
+
+func TestPackageManagerUserScopeOnlySkipsRootInstalls(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "vlc" && pkg.Method == domain.MethodFlatpak
+	})).Return(&domain.InstallationResult{Success: true}, nil).Once()
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "rust" && pkg.Method == domain.MethodMise
+	})).Return(&domain.InstallationResult{Success: true}, nil).Once()
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetInstallScope(domain.ScopeUser)
+
+	// vlc falls back to its flatpak alternative; fish is apt-only and is skipped
+	result := manager.InstallAll(context.Background(), []string{"vlc", "rust", "fish"})
+
+	assert.Equal(t, []string{"vlc", "rust"}, result.Installed)
+	assert.Equal(t, []string{"fish"}, result.Skipped)
+	assert.Empty(t, result.Failed)
+	mockInstaller.AssertExpectations(t)
+
+	_, err := manager.Install(context.Background(), "fish")
+	require.ErrorIs(t, err, application.ErrRequiresRoot)
+}
+
+func TestPackageManagerSkipsInstallsWithoutNetwork(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetConnectivity(domain.ConnectivityCaptivePortal)
+
+	result := manager.InstallAll(context.Background(), []string{"vlc", "zed"})
+
+	assert.Equal(t, []string{"vlc", "zed"}, result.Skipped)
+	assert.Equal(t, map[string]string{"vlc": domain.SkipCaptivePortal, "zed": domain.SkipCaptivePortal}, result.SkipReasons)
+	assert.Empty(t, result.Failed)
+	mockInstaller.AssertNotCalled(t, "Install", mock.Anything, mock.Anything)
+
+	_, err := manager.Install(context.Background(), "vlc")
+	require.ErrorIs(t, err, domain.ErrOffline)
+	assert.Equal(t, domain.ErrorKindNetwork, domain.ClassifyError(err))
+}
+
+func TestPackageManagerAppliesFlatpakScopes(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "zed" && pkg.Scope == domain.ScopeSystem
+	})).Return(&domain.InstallationResult{Success: true}, nil).Once()
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetFlatpakScopes(domain.FlatpakScopes{Apps: map[string]domain.InstallScope{"zed": domain.ScopeSystem}})
+
+	_, err := manager.Install(context.Background(), "zed")
+	require.NoError(t, err)
+	mockInstaller.AssertExpectations(t)
+}
+
+func TestPackageManagerPlan(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("IsInstalled", mock.Anything, "vlc").Return(true, nil)
+	mockInstaller.On("IsInstalled", mock.Anything, "rust").Return(true, nil)
+	mockInstaller.On("IsInstalled", mock.Anything, mock.Anything).Return(false, nil)
+
+	constraints, err := domain.ParseVersionConstraints(map[string]string{"vlc": ">=3.1 <4", "rust": ">=1.80"})
+	require.NoError(t, err)
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetVersionConstraints(constraints)
+	manager.SetVersionResolver(platform.NewMockVersionResolver(map[string]string{"vlc": "3.0.20", "rust": "1.81.0"}))
+
+	changes := manager.Plan(context.Background(), []string{"vlc", "rust", "lazygit"})
+
+	assert.Equal(t, []domain.PlannedChange{
+		{Kind: domain.ChangeUpgrade, Target: "vlc", From: "3.0.20", To: ">=3.1 <4"},
+		{Kind: domain.ChangeInstall, Target: "lazygit"},
+	}, changes)
+	assert.Equal(t, "upgrade vlc 3.0.20 → >=3.1 <4", changes[0].Describe())
+	assert.Equal(t, "install lazygit", changes[1].Describe())
+}
+
+// stubKernel is a running kernel with a fixed release.
+type stubKernel struct {
+	secureBoot bool
+}
+
+func (stubKernel) Release(context.Context) (string, error) { return "6.8.0-45-generic", nil }
+
+func (k stubKernel) SecureBoot(context.Context) bool { return k.secureBoot }
+
+func TestPackageManagerInstallsKernelPrerequisites(t *testing.T) {
+	t.Parallel()
+
+	var installed []string
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("IsInstalled", mock.Anything, "dkms").Return(true, nil)
+	mockInstaller.On("IsInstalled", mock.Anything, mock.Anything).Return(false, nil)
+	mockInstaller.On("Install", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		installed = append(installed, args.Get(1).(*domain.Package).Name)
+	}).Return(&domain.InstallationResult{Success: true}, nil)
+
+	var notes []string
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetKernelInspector(stubKernel{secureBoot: true})
+	manager.SetNoteFunc(func(note string) { notes = append(notes, note) })
+
+	_, err := manager.Install(context.Background(), "virtualbox")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"linux-headers-6.8.0-45-generic", "virtualbox"}, installed)
+	require.Len(t, notes, 1)
+	assert.Contains(t, notes[0], "mokutil --import")
+
+	_, err = manager.Install(context.Background(), "vlc")
+	require.NoError(t, err)
+	assert.Len(t, notes, 1, "only apps building kernel modules need a key enrolled")
+}
+
+func TestPackageManagerFailsWithoutKernelHeaders(t *testing.T) {
+	t.Parallel()
+
+	mockInstaller := new(testutil.MockPackageInstaller)
+	mockInstaller.On("IsInstalled", mock.Anything, mock.Anything).Return(false, nil)
+	mockInstaller.On("Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "linux-headers-6.8.0-45-generic"
+	})).Return(nil, errors.New("unable to locate package"))
+
+	manager := application.NewPackageManager(mockInstaller, nil, false)
+	manager.SetKernelInspector(stubKernel{})
+
+	_, err := manager.Install(context.Background(), "virtualbox")
+	require.ErrorIs(t, err, domain.ErrKernelHeaders)
+	assert.Equal(t, domain.ErrorKindNotFound, domain.ClassifyError(err))
+	mockInstaller.AssertNotCalled(t, "Install", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "virtualbox"
+	}))
+}
+
+func TestPackageManagerEstimatesDownloadByMethod(t *testing.T) {
+	t.Parallel()
+
+	manager := application.NewPackageManager(new(testutil.MockPackageInstaller), nil, false)
+
+	assert.Equal(t, domain.MethodFlatpak.TypicalDownloadSize(), manager.EstimateDownload([]string{"zed"}))
+	assert.Zero(t, manager.EstimateDownload([]string{"no-such-app"}))
+}
+
+// scopedInstaller tells which flatpak installation holds each app ID.
+type scopedInstaller struct {
+	*testutil.MockPackageInstaller
+
+	scopes map[string]domain.InstallScope
+}
+
+func (s scopedInstaller) InstalledScope(_ context.Context, pkg *domain.Package) (domain.InstallScope, bool) {
+	scope, ok := s.scopes[pkg.Source]
+
+	return scope, ok
+}
+
+func TestPackageManagerChangeRequiresRoot(t *testing.T) {
+	t.Parallel()
+
+	records := application.NewInstallRecords(platform.NewFileManager(false), filepath.Join(t.TempDir(), "installs.json"))
+	require.NoError(t, records.Record("rust", application.InstallRecord{Method: domain.MethodAPT, Source: "rustc"}))
+
+	installer := scopedInstaller{
+		MockPackageInstaller: new(testutil.MockPackageInstaller),
+		scopes:               map[string]domain.InstallScope{"md.obsidian.Obsidian": domain.ScopeSystem},
+	}
+
+	manager := application.NewPackageManager(installer, nil, false)
+	manager.SetInstallRecords(records)
+	manager.SetFlatpakScopes(domain.FlatpakScopes{Default: domain.ScopeUser})
+
+	ctx := context.Background()
+
+	// Installed system-wide, even though new flatpaks go to the user installation
+	assert.True(t, manager.ChangeRequiresRoot(ctx, "obsidian"))
+
+	// Recorded as installed through apt, though mise would install it now
+	assert.True(t, manager.ChangeRequiresRoot(ctx, "rust"))
+
+	// Neither recorded nor found: the configured scope decides
+	unknown := application.NewPackageManager(new(testutil.MockPackageInstaller), nil, false)
+	unknown.SetFlatpakScopes(domain.FlatpakScopes{Default: domain.ScopeUser})
+	assert.False(t, unknown.ChangeRequiresRoot(ctx, "obsidian"))
+	assert.False(t, unknown.ChangeRequiresRoot(ctx, "no-such-app"))
+}
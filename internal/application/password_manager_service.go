@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// PasswordManagerService checks the integration of installed password
+// manager CLIs: their SSH agent, browser extension host and vault.
+type PasswordManagerService struct {
+	fileManager   domain.FileManager
+	commandRunner domain.CommandRunner
+	homeDir       string
+	runtimeDir    string // XDG_RUNTIME_DIR, where gpg-agent's socket is
+}
+
+// NewPasswordManagerService creates a service checking the integrations of
+// the user with home directory homeDir.
+func NewPasswordManagerService(fm domain.FileManager, cr domain.CommandRunner, homeDir string) *PasswordManagerService {
+	return &PasswordManagerService{fileManager: fm, commandRunner: cr, homeDir: homeDir}
+}
+
+// SetRuntimeDir sets XDG_RUNTIME_DIR, below which gpg-agent's SSH socket is.
+func (s *PasswordManagerService) SetRuntimeDir(dir string) {
+	s.runtimeDir = dir
+}
+
+// Installed returns the password managers whose CLI is on the PATH.
+func (s *PasswordManagerService) Installed() []domain.PasswordManager {
+	var installed []domain.PasswordManager
+
+	for _, manager := range domain.PasswordManagers() {
+		if s.commandRunner.CommandExists(manager.Command) {
+			installed = append(installed, manager)
+		}
+	}
+
+	return installed
+}
+
+// Check checks manager's integration and, when entry is set, that the entry
+// can be read. The secret read is discarded.
+func (s *PasswordManagerService) Check(ctx context.Context, manager domain.PasswordManager, entry string) domain.PasswordManagerCheck {
+	check := domain.PasswordManagerCheck{Manager: manager, BrowserHost: manager.BrowserHost == ""}
+
+	if manager.AgentSocket != "" {
+		check.SSHConfigured = s.fileManager.FileExists(filepath.Join(s.homeDir, ".ssh", "config.d", "karei-"+manager.Key+".conf"))
+		check.AgentRunning = s.fileManager.FileExists(s.expand(manager.AgentSocket))
+
+		if !check.SSHConfigured {
+			check.Problems = append(check.Problems, "ssh isn't set up to use its agent, run karei system passwords --setup")
+		}
+	}
+
+	if manager.BrowserHost != "" {
+		for _, dir := range domain.NativeMessagingHostDirs(s.homeDir) {
+			if s.fileManager.FileExists(filepath.Join(dir, manager.BrowserHost+".json")) {
+				check.BrowserHost = true
+
+				break
+			}
+		}
+
+		if !check.BrowserHost {
+			check.Problems = append(check.Problems, "no browser extension host, run karei install "+manager.BrowserApp)
+		}
+	}
+
+	output, err := s.commandRunner.ExecuteWithOutput(ctx, manager.Command, manager.StatusArgs()...)
+	if err != nil {
+		check.Problems = append(check.Problems, "the vault can't be read yet: "+manager.Setup)
+
+		return check
+	}
+
+	ready, problem := manager.VaultReady(output)
+	if !ready {
+		check.Problems = append(check.Problems, problem)
+
+		return check
+	}
+
+	check.VaultReady = true
+
+	if entry == "" {
+		return check
+	}
+
+	if _, err := s.commandRunner.ExecuteWithOutput(ctx, manager.Command, manager.ReadArgs(entry)...); err != nil {
+		check.Problems = append(check.Problems, "failed to read "+entry)
+	} else {
+		check.Read = true
+	}
+
+	return check
+}
+
+// expand resolves ~ and ${XDG_RUNTIME_DIR} in an agent socket path.
+func (s *PasswordManagerService) expand(path string) string {
+	if rest, found := strings.CutPrefix(path, "~/"); found {
+		return filepath.Join(s.homeDir, rest)
+	}
+
+	return strings.ReplaceAll(path, "${XDG_RUNTIME_DIR}", s.runtimeDir)
+}
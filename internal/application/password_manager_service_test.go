@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordManagerService_Check(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".ssh", "config.d"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".ssh", "config.d", "karei-bitwarden-cli.conf"), nil, 0o600))
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("CommandExists", "op").Return(false)
+	runner.On("CommandExists", "bw").Return(true)
+	runner.On("CommandExists", "pass").Return(false)
+	runner.On("ExecuteWithOutput", mock.Anything, "bw", "status").Return(`{"status":"unlocked"}`, nil)
+	runner.On("ExecuteWithOutput", mock.Anything, "bw", "get", "password", "github").Return("s3cret", nil)
+	runner.On("ExecuteWithOutput", mock.Anything, "bw", "get", "password", "missing").Return("", errors.New("not found"))
+
+	service := application.NewPasswordManagerService(platform.NewFileManager(false), runner, home)
+
+	installed := service.Installed()
+	require.Len(t, installed, 1)
+	assert.Equal(t, "bitwarden-cli", installed[0].Key)
+
+	check := service.Check(context.Background(), installed[0], "github")
+	assert.True(t, check.Works("github"))
+	assert.True(t, check.SSHConfigured)
+	assert.False(t, check.AgentRunning)
+	assert.True(t, check.BrowserHost)
+	assert.Empty(t, check.Problems)
+
+	check = service.Check(context.Background(), installed[0], "missing")
+	assert.False(t, check.Works("missing"))
+	assert.Equal(t, []string{"failed to read missing"}, check.Problems)
+}
+
+func TestPasswordManagerService_CheckNotSignedIn(t *testing.T) {
+	t.Parallel()
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("ExecuteWithOutput", mock.Anything, "op", "whoami").Return("", errors.New("exit status 1"))
+
+	manager, _ := domain.PasswordManagerFor("1password-cli")
+	check := application.NewPasswordManagerService(platform.NewFileManager(false), runner, t.TempDir()).
+		Check(context.Background(), manager, "")
+
+	assert.False(t, check.Works(""))
+	assert.False(t, check.SSHConfigured)
+	assert.Len(t, check.Problems, 2)
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// testPageFile is the test page CUPS ships, rendered to check the filters.
+const testPageFile = "/usr/share/cups/data/testprint"
+
+// ErrRenderFailed indicates the CUPS filters didn't turn the test page into a PDF.
+var ErrRenderFailed = errors.New("test page did not render")
+
+// PrintingService sets up CUPS printing: it enables the services, adds the
+// driverless printers announced on the network and checks that print jobs
+// render.
+type PrintingService struct {
+	commandRunner domain.CommandRunner
+}
+
+// NewPrintingService creates a printing setup service.
+func NewPrintingService(cr domain.CommandRunner) *PrintingService {
+	return &PrintingService{commandRunner: cr}
+}
+
+// EnableServices enables and starts CUPS and Avahi.
+func (s *PrintingService) EnableServices(ctx context.Context) error {
+	args := append([]string{"enable", "--now"}, domain.PrintingServices()...)
+	if err := s.commandRunner.ExecuteSudo(ctx, "systemctl", args...); err != nil {
+		return fmt.Errorf("failed to enable %s: %w", strings.Join(domain.PrintingServices(), " and "), err)
+	}
+
+	return nil
+}
+
+// Discover returns the driverless printers announced on the network.
+func (s *PrintingService) Discover(ctx context.Context) ([]domain.NetworkPrinter, error) {
+	output, err := s.commandRunner.ExecuteWithOutput(ctx, "avahi-browse", "--resolve", "--parsable", "--terminate", "_ipp._tcp", "_ipps._tcp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to browse for network printers: %w", err)
+	}
+
+	return domain.ParseAvahiPrinters(output), nil
+}
+
+// AddPrinters adds the printers that have no queue yet, as IPP Everywhere
+// queues that need no driver, and returns those added. Printers are matched
+// to queues by queue name and by URI.
+func (s *PrintingService) AddPrinters(ctx context.Context, printers []domain.NetworkPrinter) ([]domain.NetworkPrinter, error) {
+	// lpstat fails when there are no queues at all
+	output, _ := s.commandRunner.ExecuteWithOutput(ctx, "lpstat", "-v")
+	devices := domain.ParsePrinterDevices(output)
+
+	var added []domain.NetworkPrinter
+
+	for _, printer := range printers {
+		_, exists := devices[printer.Queue]
+		if exists || slices.Contains(slices.Collect(maps.Values(devices)), printer.URI) {
+			continue
+		}
+
+		if err := s.commandRunner.ExecuteSudo(ctx, "lpadmin", "-p", printer.Queue, "-E", "-v", printer.URI, "-m", "everywhere"); err != nil {
+			return added, fmt.Errorf("failed to add printer %s: %w", printer.Name, err)
+		}
+
+		added = append(added, printer)
+	}
+
+	return added, nil
+}
+
+// VerifyRender renders CUPS's test page to PDF through the installed
+// filters, without printing it, to check that jobs can be converted.
+func (s *PrintingService) VerifyRender(ctx context.Context) error {
+	output, err := s.commandRunner.ExecuteWithOutput(ctx, "cupsfilter", "-m", "application/pdf", testPageFile)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRenderFailed, err)
+	}
+
+	if !strings.HasPrefix(output, "%PDF") {
+		return fmt.Errorf("%w: cupsfilter produced no PDF", ErrRenderFailed)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintingService_AddPrinters(t *testing.T) {
+	t.Parallel()
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("ExecuteWithOutput", mock.Anything, "lpstat", "-v").
+		Return("device for Office: ipps://HP8010.local:443/ipp/print\n", nil)
+	runner.On("ExecuteSudo", mock.Anything, "lpadmin",
+		[]string{"-p", "Brother_HL-L2350DW", "-E", "-v", "ipp://BRW123.local:631/ipp/print", "-m", "everywhere"}).Return(nil).Once()
+
+	printers := []domain.NetworkPrinter{
+		// Already added under another queue name
+		{Name: "HP OfficeJet 8010", Queue: "HP_OfficeJet_8010", URI: "ipps://HP8010.local:443/ipp/print"},
+		{Name: "Brother HL-L2350DW", Queue: "Brother_HL-L2350DW", URI: "ipp://BRW123.local:631/ipp/print"},
+	}
+
+	added, err := application.NewPrintingService(runner).AddPrinters(context.Background(), printers)
+	require.NoError(t, err)
+	assert.Equal(t, printers[1:], added)
+
+	runner.AssertExpectations(t)
+}
+
+func TestPrintingService_VerifyRender(t *testing.T) {
+	t.Parallel()
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("ExecuteWithOutput", mock.Anything, "cupsfilter", "-m", "application/pdf", "/usr/share/cups/data/testprint").
+		Return("%PDF-1.5\n...", nil).Once()
+	runner.On("ExecuteWithOutput", mock.Anything, "cupsfilter", "-m", "application/pdf", "/usr/share/cups/data/testprint").
+		Return("", errors.New("cupsfilter: No filter to convert from text/plain to application/pdf")).Once()
+
+	service := application.NewPrintingService(runner)
+
+	require.NoError(t, service.VerifyRender(context.Background()))
+	require.ErrorIs(t, service.VerifyRender(context.Background()), application.ErrRenderFailed)
+}
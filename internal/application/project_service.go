@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// ErrMiseRequired indicates versioned tools can't be installed without mise.
+var ErrMiseRequired = errors.New("mise is needed for versioned tools, install it with: karei install mise")
+
+// ProjectService checks the tools a project requires and installs those
+// missing. Versioned tools, and tools outside the catalog, are mise's, set
+// for the project directory so they are active only inside it. Other
+// catalog apps are installed machine-wide by karei.
+type ProjectService struct {
+	commandRunner domain.CommandRunner
+	installed     domain.InstallationChecker
+}
+
+// NewProjectService creates a service checking catalog apps with installed.
+func NewProjectService(cr domain.CommandRunner, installed domain.InstallationChecker) *ProjectService {
+	return &ProjectService{commandRunner: cr, installed: installed}
+}
+
+// Check returns whether each requirement is met in dir.
+func (s *ProjectService) Check(ctx context.Context, dir string, requirements []domain.ToolRequirement) []domain.RequirementStatus {
+	statuses := make([]domain.RequirementStatus, 0, len(requirements))
+
+	for _, requirement := range requirements {
+		status := domain.RequirementStatus{Tool: requirement.Tool, Required: requirement.Constraint.String()}
+
+		if providedByMise(requirement) {
+			status.Provider = domain.ProvidedByMise
+			status.Installed = s.miseVersion(ctx, dir, requirement.Tool)
+		} else {
+			status.Provider = domain.ProvidedByKarei
+			status.Met = s.installed.IsInstalled(ctx, requirement.Tool)
+		}
+
+		switch {
+		case status.Provider == domain.ProvidedByKarei && !status.Met:
+			status.Reason = "not installed"
+		case status.Provider == domain.ProvidedByMise && status.Installed == "":
+			status.Reason = "not installed"
+		case status.Provider == domain.ProvidedByMise:
+			if err := requirement.Constraint.Check(requirement.Tool, status.Installed); err != nil {
+				status.Reason = status.Installed + " is active"
+			} else {
+				status.Met = true
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// Use sets the versions of the unmet tools mise provides for dir, and
+// returns the catalog apps still to install machine-wide.
+func (s *ProjectService) Use(ctx context.Context, dir string, requirements []domain.ToolRequirement, statuses []domain.RequirementStatus) ([]string, error) {
+	var catalogApps, specs []string
+
+	for i, status := range statuses {
+		switch {
+		case status.Met:
+			continue
+		case status.Provider == domain.ProvidedByKarei:
+			catalogApps = append(catalogApps, status.Tool)
+		default:
+			specs = append(specs, requirements[i].MiseSpec())
+		}
+	}
+
+	if len(specs) == 0 {
+		return catalogApps, nil
+	}
+
+	if !s.commandRunner.CommandExists("mise") {
+		return catalogApps, ErrMiseRequired
+	}
+
+	args := append([]string{"--cd", dir, "use"}, specs...)
+	if err := s.commandRunner.Execute(ctx, "mise", args...); err != nil {
+		return catalogApps, fmt.Errorf("failed to install %s with mise: %w", strings.Join(specs, ", "), err)
+	}
+
+	return catalogApps, nil
+}
+
+// miseVersion returns the version of tool mise has active in dir, or an
+// empty string when there is none.
+func (s *ProjectService) miseVersion(ctx context.Context, dir, tool string) string {
+	if !s.commandRunner.CommandExists("mise") {
+		return ""
+	}
+
+	output, err := s.commandRunner.ExecuteWithOutput(ctx, "mise", "--cd", dir, "current", tool)
+	if err != nil {
+		return ""
+	}
+
+	// Several versions may be active; the first one is found first on PATH
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return fields[0]
+}
+
+// providedByMise reports whether mise provides a requirement: tools with a
+// version constraint, tools mise installs anyway and those karei doesn't know.
+func providedByMise(requirement domain.ToolRequirement) bool {
+	app, known := apps.Apps[requirement.Tool]
+
+	return !requirement.Constraint.IsZero() || !known || app.Method == domain.MethodMise
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectService_CheckAndUse(t *testing.T) {
+	t.Parallel()
+
+	requirements, err := domain.ParseToolRequirements(map[string]string{
+		"go":         ">=1.22", // Versioned, so mise's
+		"node":       "20",
+		"shellcheck": "", // A catalog app mise installs
+		"sway":       "", // A catalog app karei installs
+		"vlc":        "",
+	})
+	require.NoError(t, err)
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("CommandExists", "mise").Return(true)
+	runner.On("ExecuteWithOutput", mock.Anything, "mise", "--cd", "/src/app", "current", "go").Return("1.21.0\n", nil)
+	runner.On("ExecuteWithOutput", mock.Anything, "mise", "--cd", "/src/app", "current", "node").Return("20.11.1 18.19.0\n", nil)
+	runner.On("ExecuteWithOutput", mock.Anything, "mise", "--cd", "/src/app", "current", "shellcheck").Return("", errors.New("not installed"))
+	runner.On("Execute", mock.Anything, "mise", "--cd", "/src/app", "use", "go@latest", "shellcheck@latest").Return(nil).Once()
+
+	service := application.NewProjectService(runner, platform.NewMockInstallationChecker("vlc"))
+
+	statuses := service.Check(context.Background(), "/src/app", requirements)
+	assert.Equal(t, []domain.RequirementStatus{
+		{Tool: "go", Required: ">=1.22", Installed: "1.21.0", Provider: domain.ProvidedByMise, Reason: "1.21.0 is active"},
+		{Tool: "node", Required: "=20", Installed: "20.11.1", Provider: domain.ProvidedByMise, Met: true},
+		{Tool: "shellcheck", Provider: domain.ProvidedByMise, Reason: "not installed"},
+		{Tool: "sway", Provider: domain.ProvidedByKarei, Reason: "not installed"},
+		{Tool: "vlc", Provider: domain.ProvidedByKarei, Met: true},
+	}, statuses)
+
+	catalogApps, err := service.Use(context.Background(), "/src/app", requirements, statuses)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sway"}, catalogApps)
+
+	runner.AssertExpectations(t)
+}
+
+func TestProjectService_UseWithoutMise(t *testing.T) {
+	t.Parallel()
+
+	requirements, err := domain.ParseToolRequirements(map[string]string{"go": "1.22"})
+	require.NoError(t, err)
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("CommandExists", "mise").Return(false)
+
+	service := application.NewProjectService(runner, platform.NewMockInstallationChecker())
+	statuses := service.Check(context.Background(), "/src/app", requirements)
+
+	_, err = service.Use(context.Background(), "/src/app", requirements, statuses)
+	require.ErrorIs(t, err, application.ErrMiseRequired)
+}
@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// Markers around the lines karei keeps in a shell configuration file.
+const (
+	shellBlockStart = "# >>> karei >>>"
+	shellBlockEnd   = "# <<< karei <<<"
+)
+
+// RequiredShellSetup returns what shells need to reach the installed apps:
+// the directories their commands land in that pathEnv lacks, and tools that
+// hook into the shell. Apps installed system-wide need nothing.
+func RequiredShellSetup(installed []string, preference domain.MethodPreference, pathEnv, home string) domain.ShellSetup {
+	var setup domain.ShellSetup
+
+	userBin := filepath.Join(home, ".local", "bin")
+
+	for _, key := range installed {
+		app, exists := apps.Apps[key]
+		if !exists {
+			continue
+		}
+
+		switch method, _ := app.Resolve(preference); {
+		case method == domain.MethodMise:
+			setup.Add(domain.ShellSetup{Activate: []string{"mise"}})
+		case method.IsGitHub(), method == domain.MethodBinary, method == domain.MethodAqua:
+			if !domain.PathCovers(pathEnv, userBin) {
+				setup.Add(domain.ShellSetup{PathDirs: []string{userBin}})
+			}
+		case method == domain.MethodNpm:
+			if npmBin := domain.NpmBinDir(xdg.NpmPrefix()); !domain.PathCovers(pathEnv, npmBin) {
+				setup.Add(domain.ShellSetup{PathDirs: []string{npmBin}})
+			}
+		}
+
+		// Installing mise itself also needs it activated
+		if key == "mise" {
+			setup.Add(domain.ShellSetup{Activate: []string{"mise"}})
+		}
+	}
+
+	return setup
+}
+
+// ShellConfig keeps the lines karei adds to a shell configuration file in
+// a block of their own, so they are added once and can be found again.
+type ShellConfig struct {
+	files domain.FileManager
+	shell domain.Shell
+	path  string
+	home  string
+}
+
+// NewShellConfig creates the configuration of shell kept in path.
+func NewShellConfig(files domain.FileManager, shell domain.Shell, path, home string) *ShellConfig {
+	return &ShellConfig{files: files, shell: shell, path: path, home: home}
+}
+
+// Path returns the configuration file.
+func (c *ShellConfig) Path() string {
+	return c.path
+}
+
+// Shell returns the shell the configuration is for.
+func (c *ShellConfig) Shell() domain.Shell {
+	return c.shell
+}
+
+// Missing returns the lines setup needs that the file doesn't have yet,
+// whether karei or the user added them.
+func (c *ShellConfig) Missing(setup domain.ShellSetup) []string {
+	existing := c.lines()
+
+	var missing []string
+
+	for _, line := range c.shell.Lines(setup, c.home) {
+		if !slices.Contains(existing, line) {
+			missing = append(missing, line)
+		}
+	}
+
+	return missing
+}
+
+// Apply adds the lines setup needs to karei's block in the file, creating
+// the block or the file when needed. It returns the lines added.
+func (c *ShellConfig) Apply(setup domain.ShellSetup) ([]string, error) {
+	missing := c.Missing(setup)
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	content := ""
+	if c.files.FileExists(c.path) {
+		data, err := c.files.ReadFile(c.path)
+		if err != nil {
+			return nil, err
+		}
+
+		content = string(data)
+	}
+
+	if err := c.files.WriteFile(c.path, []byte(addToShellBlock(content, missing))); err != nil {
+		return nil, err
+	}
+
+	return missing, nil
+}
+
+// RecordShellLines adds to the manifest of each installed app the lines in
+// karei's block of config that it needs, so the lines are removed once no
+// app karei installed needs them any more.
+func RecordShellLines(manifests *Manifests, config *ShellConfig, installed []string, preference domain.MethodPreference, pathEnv string) error {
+	block := config.blockLines()
+
+	for _, key := range installed {
+		setup := RequiredShellSetup([]string{key}, preference, pathEnv, config.home)
+
+		var artifacts []domain.Artifact
+
+		for _, line := range config.shell.Lines(setup, config.home) {
+			if slices.Contains(block, line) {
+				artifacts = append(artifacts, domain.Artifact{Kind: domain.ArtifactShellLine, Path: config.path, Line: line})
+			}
+		}
+
+		if err := manifests.Add(key, artifacts...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveShellLines removes lines from karei's block in the shell
+// configuration file at path, and the block once it is empty. Lines outside
+// the block belong to the user and are kept.
+func RemoveShellLines(files domain.FileManager, path string, lines []string) error {
+	if !files.FileExists(path) {
+		return nil
+	}
+
+	data, err := files.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	content := removeFromShellBlock(string(data), lines)
+	if content == string(data) {
+		return nil
+	}
+
+	return files.WriteFile(path, []byte(content))
+}
+
+// blockLines returns the trimmed lines of karei's block in the file.
+func (c *ShellConfig) blockLines() []string {
+	var block []string
+
+	inside := false
+
+	for _, line := range c.lines() {
+		switch {
+		case line == shellBlockStart:
+			inside = true
+		case line == shellBlockEnd:
+			inside = false
+		case inside:
+			block = append(block, line)
+		}
+	}
+
+	return block
+}
+
+// lines returns the trimmed lines of the file.
+func (c *ShellConfig) lines() []string {
+	data, err := c.files.ReadFile(c.path)
+	if err != nil {
+		return nil
+	}
+
+	lines := strings.Split(string(data), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+
+	return lines
+}
+
+// addToShellBlock adds lines to the end of karei's block in content,
+// appending a new block when there is none.
+func addToShellBlock(content string, lines []string) string {
+	if end := strings.Index(content, shellBlockEnd); end >= 0 && strings.Contains(content[:end], shellBlockStart) {
+		return content[:end] + strings.Join(lines, "\n") + "\n" + content[end:]
+	}
+
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	if content != "" {
+		content += "\n"
+	}
+
+	return content + shellBlockStart + "\n" + strings.Join(lines, "\n") + "\n" + shellBlockEnd + "\n"
+}
+
+// removeFromShellBlock removes lines from karei's block in content, and the
+// block with the blank line before it once nothing is left in it.
+func removeFromShellBlock(content string, lines []string) string {
+	start := strings.Index(content, shellBlockStart)
+	if start < 0 {
+		return content
+	}
+
+	end := strings.Index(content[start:], shellBlockEnd)
+	if end < 0 {
+		return content
+	}
+
+	end += start + len(shellBlockEnd)
+
+	var kept []string
+
+	for _, line := range strings.Split(content[start+len(shellBlockStart):end-len(shellBlockEnd)], "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" && !slices.Contains(lines, trimmed) {
+			kept = append(kept, line)
+		}
+	}
+
+	after := strings.TrimPrefix(content[end:], "\n")
+
+	if len(kept) > 0 {
+		return content[:start] + shellBlockStart + "\n" + strings.Join(kept, "\n") + "\n" + shellBlockEnd + "\n" + after
+	}
+
+	before := content[:start]
+	if strings.HasSuffix(before, "\n\n") {
+		before = before[:len(before)-1]
+	}
+
+	return before + after
+}
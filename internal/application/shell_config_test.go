@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredShellSetup(t *testing.T) {
+	t.Parallel()
+
+	home := "/home/user"
+	userBin := filepath.Join(home, ".local", "bin")
+
+	// mise itself lands in ~/.local/bin; the tools it installs need it activated
+	setup := application.RequiredShellSetup([]string{"mise", "lazygit", "vlc"}, nil, "/usr/bin:/bin", home)
+	assert.Equal(t, domain.ShellSetup{PathDirs: []string{userBin}, Activate: []string{"mise"}}, setup)
+
+	setup = application.RequiredShellSetup([]string{"mise"}, nil, "/usr/bin:"+userBin+"/", home)
+	assert.Empty(t, setup.PathDirs, "PATH already covers ~/.local/bin")
+
+	assert.True(t, application.RequiredShellSetup([]string{"vlc"}, nil, "", home).Empty())
+
+	// npm tools land in karei's npm prefix rather than ~/.local/bin
+	npmBin := domain.NpmBinDir(xdg.NpmPrefix())
+	setup = application.RequiredShellSetup([]string{"prettier"}, nil, "/usr/bin", home)
+	assert.Equal(t, []string{npmBin}, setup.PathDirs)
+}
+
+func TestShellConfig_Apply(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	path := filepath.Join(home, ".bashrc")
+	require.NoError(t, os.WriteFile(path, []byte("alias ll='ls -l'"), 0o600))
+
+	shellConfig := application.NewShellConfig(platform.NewFileManager(false), domain.ShellBash, path, home)
+	setup := domain.ShellSetup{PathDirs: []string{filepath.Join(home, ".local", "bin")}}
+
+	assert.Equal(t, []string{`export PATH="$HOME/.local/bin:$PATH"`}, shellConfig.Missing(setup))
+
+	added, err := shellConfig.Apply(setup)
+	require.NoError(t, err)
+	assert.Len(t, added, 1)
+
+	// Later lines go into the same block, and nothing is added twice
+	setup.Activate = []string{"mise"}
+
+	added, err = shellConfig.Apply(setup)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`eval "$(mise activate bash)"`}, added)
+
+	added, err = shellConfig.Apply(setup)
+	require.NoError(t, err)
+	assert.Empty(t, added)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "alias ll='ls -l'\n\n# >>> karei >>>\n"+
+		"export PATH=\"$HOME/.local/bin:$PATH\"\n"+
+		"eval \"$(mise activate bash)\"\n"+
+		"# <<< karei <<<\n", string(data))
+}
+
+func TestShellConfig_ApplyCreatesFishConfig(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	path := domain.ShellFish.ConfigFile(home, filepath.Join(home, ".config"))
+
+	shellConfig := application.NewShellConfig(platform.NewFileManager(false), domain.ShellFish, path, home)
+
+	_, err := shellConfig.Apply(domain.ShellSetup{PathDirs: []string{filepath.Join(home, ".local", "bin")}, Activate: []string{"mise"}})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "fish_add_path $HOME/.local/bin\nmise activate fish | source\n")
+}
+
+func TestRecordShellLines(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	fileManager := platform.NewFileManager(false)
+	path := filepath.Join(home, ".bashrc")
+	pathLine := `export PATH="$HOME/.local/bin:$PATH"`
+
+	// The user's own mise line is not karei's to remove
+	require.NoError(t, os.WriteFile(path, []byte("eval \"$(mise activate bash)\"\n\n# >>> karei >>>\n"+pathLine+"\n# <<< karei <<<\n"), 0o600))
+
+	manifests := application.NewManifests(fileManager, filepath.Join(home, "manifests"))
+
+	shellConfig := application.NewShellConfig(fileManager, domain.ShellBash, path, home)
+	require.NoError(t, application.RecordShellLines(manifests, shellConfig, []string{"mise", "rust", "vlc"}, nil, "/usr/bin"))
+
+	mise, err := manifests.Load("mise")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Artifact{{Kind: domain.ArtifactShellLine, Path: path, Line: pathLine}}, mise.Artifacts)
+
+	rust, err := manifests.Load("rust")
+	require.NoError(t, err)
+	assert.Empty(t, rust.Artifacts)
+
+	require.NoError(t, application.RemoveShellLines(fileManager, path, []string{pathLine}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "eval \"$(mise activate bash)\"\n", string(data))
+}
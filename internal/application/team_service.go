@@ -0,0 +1,272 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// ErrNotSubscribed indicates the machine follows no team profile.
+var ErrNotSubscribed = errors.New("not subscribed to a team profile, join one with: karei team join <url> --key <key>")
+
+// Units running karei team update --check daily, in the systemd user directory.
+const (
+	teamTimer   = "karei-team.timer"
+	teamService = "karei-team.service"
+)
+
+// TeamUpdate is a verified team profile and how it differs from the one
+// applied last.
+type TeamUpdate struct {
+	Profile []byte   `json:"-"`
+	Diff    []string `json:"diff,omitempty"`
+	Pending bool     `json:"pending"`
+}
+
+// TeamService subscribes the machine to a team profile published at a URL,
+// next to its signature. Only profiles signed with the key given when
+// joining are accepted.
+type TeamService struct {
+	fileManager   domain.FileManager
+	commandRunner domain.CommandRunner
+	network       domain.NetworkClient
+	trust         *TrustService
+	path          string // Subscription record; fetched files are kept next to it
+	unitDir       string // systemd user units; empty installs no timer
+}
+
+// NewTeamService creates a service keeping the subscription at path.
+func NewTeamService(fm domain.FileManager, cr domain.CommandRunner, nc domain.NetworkClient, path string) *TeamService {
+	return &TeamService{fileManager: fm, commandRunner: cr, network: nc, path: path}
+}
+
+// SetUnitDir sets the systemd user unit directory the daily check timer is
+// written to. Without it no timer is installed.
+func (s *TeamService) SetUnitDir(dir string) {
+	s.unitDir = dir
+}
+
+// SetTrust sets the trust store profiles joined without a key are verified
+// with.
+func (s *TeamService) SetTrust(trust *TrustService) {
+	s.trust = trust
+}
+
+// Join subscribes to the profile at url signed with key, or with any
+// trusted key when key is empty, replacing any earlier subscription, and
+// returns the profile to apply.
+func (s *TeamService) Join(ctx context.Context, url, key string) (TeamUpdate, error) {
+	if key != "" || s.trust == nil {
+		if _, err := domain.ParseTeamKey(key); err != nil {
+			return TeamUpdate{}, err
+		}
+	}
+
+	profile, err := s.fetch(ctx, url, key)
+	if err != nil {
+		return TeamUpdate{}, err
+	}
+
+	now := time.Now()
+	if err := s.save(domain.TeamSubscription{URL: url, Key: key, Joined: now, Checked: now}); err != nil {
+		return TeamUpdate{}, err
+	}
+
+	return newTeamUpdate(nil, profile), nil
+}
+
+// Check fetches the team profile and returns how it differs from the one
+// applied last.
+func (s *TeamService) Check(ctx context.Context) (TeamUpdate, error) {
+	subscription, err := s.Subscription()
+	if err != nil {
+		return TeamUpdate{}, err
+	}
+
+	profile, err := s.fetch(ctx, subscription.URL, subscription.Key)
+	if err != nil {
+		return TeamUpdate{}, err
+	}
+
+	subscription.Checked = time.Now()
+	if err := s.save(subscription); err != nil {
+		return TeamUpdate{}, err
+	}
+
+	return newTeamUpdate([]byte(subscription.Applied), profile), nil
+}
+
+// MarkApplied records profile as applied, so later checks compare with it.
+func (s *TeamService) MarkApplied(profile []byte) error {
+	subscription, err := s.Subscription()
+	if err != nil {
+		return err
+	}
+
+	subscription.Applied = string(profile)
+	subscription.Updated = time.Now()
+
+	return s.save(subscription)
+}
+
+// Subscription returns the team profile the machine follows.
+func (s *TeamService) Subscription() (domain.TeamSubscription, error) {
+	if !s.fileManager.FileExists(s.path) {
+		return domain.TeamSubscription{}, ErrNotSubscribed
+	}
+
+	data, err := s.fileManager.ReadFile(s.path)
+	if err != nil {
+		return domain.TeamSubscription{}, fmt.Errorf("failed to read team subscription: %w", err)
+	}
+
+	var subscription domain.TeamSubscription
+	if err := json.Unmarshal(data, &subscription); err != nil {
+		return domain.TeamSubscription{}, fmt.Errorf("failed to parse team subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// Leave ends the subscription and removes the daily check.
+func (s *TeamService) Leave(ctx context.Context) error {
+	if _, err := s.Subscription(); err != nil {
+		return err
+	}
+
+	if err := s.RemoveTimer(ctx); err != nil {
+		return err
+	}
+
+	profile := s.profilePath()
+	for _, path := range []string{s.path, profile, profile + domain.TeamSignatureSuffix, profile + ".minisig"} {
+		if s.fileManager.FileExists(path) {
+			if err := s.fileManager.RemoveFile(path); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// InstallTimer writes and starts a systemd user timer running karei, at
+// executable, daily to check for updates of the profile.
+func (s *TeamService) InstallTimer(ctx context.Context, executable string) error {
+	if s.unitDir == "" {
+		return nil
+	}
+
+	units := map[string]string{
+		teamService: "[Unit]\nDescription=Check for karei team profile updates\n\n" +
+			"[Service]\nType=oneshot\nExecStart=" + executable + " team update --check --notify\n",
+		teamTimer: "[Unit]\nDescription=Check for karei team profile updates daily\n\n" +
+			"[Timer]\nOnCalendar=daily\nPersistent=true\nRandomizedDelaySec=1h\n\n[Install]\nWantedBy=timers.target\n",
+	}
+
+	return installUserTimer(ctx, s.fileManager, s.commandRunner, s.unitDir, teamTimer, units)
+}
+
+// RemoveTimer stops and removes the daily check, if installed.
+func (s *TeamService) RemoveTimer(ctx context.Context) error {
+	return removeUserTimer(ctx, s.fileManager, s.commandRunner, s.unitDir, teamTimer, teamService)
+}
+
+// fetch downloads the profile at url with its signature and returns it once
+// the signature verifies with key, or with a trusted key when key is empty.
+func (s *TeamService) fetch(ctx context.Context, url, key string) ([]byte, error) {
+	if err := s.fileManager.EnsureDir(filepath.Dir(s.path)); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+
+	profilePath := s.profilePath()
+
+	if key == "" {
+		if _, err := s.trust.Fetch(ctx, url, profilePath); err != nil {
+			return nil, err
+		}
+
+		return s.readProfile()
+	}
+
+	public, err := domain.ParseTeamKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	signaturePath := profilePath + domain.TeamSignatureSuffix
+
+	if err := s.network.DownloadFile(ctx, url, profilePath); err != nil {
+		return nil, fmt.Errorf("failed to fetch team profile %s: %w", url, err)
+	}
+
+	if err := s.network.DownloadFile(ctx, url+domain.TeamSignatureSuffix, signaturePath); err != nil {
+		return nil, fmt.Errorf("failed to fetch the signature of %s: %w", url, err)
+	}
+
+	profile, err := s.readProfile()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.fileManager.ReadFile(signaturePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read team profile signature: %w", err)
+	}
+
+	if err := domain.VerifyTeamProfile(profile, signature, public); err != nil {
+		return nil, fmt.Errorf("%w: team profile %s", err, url)
+	}
+
+	return profile, nil
+}
+
+// readProfile reads the fetched profile.
+func (s *TeamService) readProfile() ([]byte, error) {
+	profile, err := s.fileManager.ReadFile(s.profilePath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read team profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+func (s *TeamService) save(subscription domain.TeamSubscription) error {
+	data, err := json.MarshalIndent(subscription, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode team subscription: %w", err)
+	}
+
+	if err := s.fileManager.EnsureDir(filepath.Dir(s.path)); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+
+	if err := s.fileManager.WriteFile(s.path, data); err != nil {
+		return fmt.Errorf("failed to write team subscription: %w", err)
+	}
+
+	return nil
+}
+
+// profilePath returns where the fetched profile is kept.
+func (s *TeamService) profilePath() string {
+	return filepath.Join(filepath.Dir(s.path), "team-profile.yaml")
+}
+
+// newTeamUpdate compares the profile applied last with the fetched one.
+func newTeamUpdate(applied, profile []byte) TeamUpdate {
+	return TeamUpdate{
+		Profile: profile,
+		Diff:    domain.DiffLines(splitLines(applied), splitLines(profile), diffContext),
+		Pending: !bytes.Equal(applied, profile),
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const teamURL = "https://example.com/team/profile.yaml"
+
+// publishTeamProfile makes the network serve profile, signed with private,
+// at teamURL.
+func publishTeamProfile(t *testing.T, network *testutil.MockNetworkClient, profile []byte, private ed25519.PrivateKey) {
+	t.Helper()
+
+	serve := func(url string, data []byte) {
+		network.On("DownloadFile", mock.Anything, url, mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) {
+				dest, _ := args.Get(2).(string)
+				require.NoError(t, os.WriteFile(dest, data, 0o600))
+			}).Return(nil).Once()
+	}
+
+	serve(teamURL, profile)
+	serve(teamURL+domain.TeamSignatureSuffix, domain.SignTeamProfile(profile, private))
+}
+
+func TestTeamService_JoinCheckAndApply(t *testing.T) {
+	t.Parallel()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key, _ := domain.EncodeTeamKeys(private)
+	network := &testutil.MockNetworkClient{}
+	service := application.NewTeamService(platform.NewFileManager(false), &testutil.MockCommandRunner{},
+		network, filepath.Join(t.TempDir(), "team.json"))
+
+	_, err = service.Check(context.Background())
+	require.ErrorIs(t, err, application.ErrNotSubscribed)
+
+	first := []byte("apps:\n  - vlc\n")
+	publishTeamProfile(t, network, first, private)
+
+	update, err := service.Join(context.Background(), teamURL, key)
+	require.NoError(t, err)
+	assert.True(t, update.Pending)
+	assert.Equal(t, first, update.Profile)
+	assert.Contains(t, update.Diff, "+  - vlc")
+
+	require.NoError(t, service.MarkApplied(update.Profile))
+
+	publishTeamProfile(t, network, first, private)
+
+	update, err = service.Check(context.Background())
+	require.NoError(t, err)
+	assert.False(t, update.Pending)
+
+	second := []byte("apps:\n  - vlc\n  - steam\n")
+	publishTeamProfile(t, network, second, private)
+
+	update, err = service.Check(context.Background())
+	require.NoError(t, err)
+	assert.True(t, update.Pending)
+	assert.Contains(t, update.Diff, "+  - steam")
+	assert.NotContains(t, update.Diff, "+  - vlc")
+
+	subscription, err := service.Subscription()
+	require.NoError(t, err)
+	assert.Equal(t, teamURL, subscription.URL)
+	assert.Equal(t, string(first), subscription.Applied)
+
+	require.NoError(t, service.Leave(context.Background()))
+
+	_, err = service.Subscription()
+	require.ErrorIs(t, err, application.ErrNotSubscribed)
+
+	network.AssertExpectations(t)
+}
+
+func TestTeamService_RejectsUnsignedProfile(t *testing.T) {
+	t.Parallel()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	_, other, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key, _ := domain.EncodeTeamKeys(private)
+	network := &testutil.MockNetworkClient{}
+	path := filepath.Join(t.TempDir(), "team.json")
+	service := application.NewTeamService(platform.NewFileManager(false), &testutil.MockCommandRunner{}, network, path)
+
+	publishTeamProfile(t, network, []byte("apps:\n  - steam\n"), other)
+
+	_, err = service.Join(context.Background(), teamURL, key)
+	require.ErrorIs(t, err, domain.ErrBadSignature)
+	assert.NoFileExists(t, path)
+
+	_, err = service.Join(context.Background(), teamURL, "not a key")
+	require.ErrorIs(t, err, domain.ErrInvalidTeamKey)
+}
+
+func TestTeamService_InstallTimer(t *testing.T) {
+	t.Parallel()
+
+	unitDir := t.TempDir()
+	runner := &testutil.MockCommandRunner{}
+	runner.On("Execute", mock.Anything, "systemctl", "--user", "daemon-reload").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "systemctl", "--user", "enable", "--now", "karei-team.timer").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "systemctl", "--user", "disable", "--now", "karei-team.timer").Return(nil).Once()
+
+	service := application.NewTeamService(platform.NewFileManager(false), runner, &testutil.MockNetworkClient{},
+		filepath.Join(t.TempDir(), "team.json"))
+	service.SetUnitDir(unitDir)
+
+	require.NoError(t, service.InstallTimer(context.Background(), "/usr/local/bin/karei"))
+
+	unit, err := os.ReadFile(filepath.Join(unitDir, "karei-team.service"))
+	require.NoError(t, err)
+	assert.Contains(t, string(unit), "ExecStart=/usr/local/bin/karei team update --check --notify")
+	assert.FileExists(t, filepath.Join(unitDir, "karei-team.timer"))
+
+	require.NoError(t, service.RemoveTimer(context.Background()))
+	assert.NoFileExists(t, filepath.Join(unitDir, "karei-team.timer"))
+	assert.NoFileExists(t, filepath.Join(unitDir, "karei-team.service"))
+
+	runner.AssertExpectations(t)
+}
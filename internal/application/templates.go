@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// ErrUnknownTemplate indicates neither karei nor the user has the template.
+var ErrUnknownTemplate = errors.New("unknown template")
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// Templates renders the templates configuration files are generated from,
+// such as "themes/nord/btop.theme", combining karei's built-in template with
+// the user's override at the same name when there is one.
+type Templates struct {
+	files       domain.FileManager
+	builtinDir  string
+	overrideDir string
+}
+
+// NewTemplates creates templates with the built-ins in builtinDir and the
+// user's overrides in overrideDir.
+func NewTemplates(files domain.FileManager, builtinDir, overrideDir string) *Templates {
+	return &Templates{files: files, builtinDir: builtinDir, overrideDir: overrideDir}
+}
+
+// Render returns the template name as karei writes it, with the user's
+// override merged in by domain.MergeTemplate.
+func (t *Templates) Render(name string) ([]byte, error) {
+	builtin, hasBuiltin, err := t.read(filepath.Join(t.builtinDir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	override, hasOverride, err := t.read(filepath.Join(t.overrideDir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !hasOverride && !hasBuiltin:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTemplate, name)
+	case !hasOverride:
+		return builtin, nil
+	}
+
+	// The newline ending each file isn't a line of its own, but ends the result
+	newline := []byte("\n")
+	ending := bytes.HasSuffix(override, newline) || bytes.HasSuffix(builtin, newline)
+
+	merged := domain.MergeTemplate(splitLines(bytes.TrimSuffix(builtin, newline)), splitLines(bytes.TrimSuffix(override, newline)))
+	if ending {
+		merged = append(merged, "")
+	}
+
+	return []byte(strings.Join(merged, "\n")), nil
+}
+
+// Execute renders the template name and fills it in with data, as a Go
+// text/template whose bare function drops the # from a color for tools
+// taking rrggbb.
+func (t *Templates) Execute(name string, data any) ([]byte, error) {
+	source, err := t.Render(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
+		"bare": func(color string) string { return strings.TrimPrefix(color, "#") },
+	}).Option("missingkey=error").Parse(string(source))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var content bytes.Buffer
+	if err := tmpl.Execute(&content, data); err != nil {
+		return nil, fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+
+	return content.Bytes(), nil
+}
+
+// Diff compares the template karei writes with its built-in default,
+// returning a unified diff that is empty when the override changes nothing.
+func (t *Templates) Diff(name string) ([]string, error) {
+	rendered, err := t.Render(name)
+	if err != nil {
+		return nil, err
+	}
+
+	builtin, _, err := t.read(filepath.Join(t.builtinDir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.DiffLines(splitLines(builtin), splitLines(rendered), diffContext), nil
+}
+
+// read returns the file at path, and whether there is one.
+func (t *Templates) read(path string) ([]byte, bool, error) {
+	if !t.files.FileExists(path) {
+		return nil, false, nil
+	}
+
+	data, err := t.files.ReadFile(path)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to read template %s: %w", path, err)
+	}
+
+	return data, true, nil
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplates_RenderAndDiff(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fileManager := platform.NewFileManager(false)
+	templates := application.NewTemplates(fileManager, filepath.Join(dir, "builtin"), filepath.Join(dir, "overrides"))
+	name := filepath.Join("themes", "nord", "btop.theme")
+
+	require.NoError(t, fileManager.WriteFile(filepath.Join(dir, "builtin", name), []byte("theme[main_bg]=\"#2E3440\"\n")))
+
+	rendered, err := templates.Render(name)
+	require.NoError(t, err)
+	assert.Equal(t, "theme[main_bg]=\"#2E3440\"\n", string(rendered))
+
+	diff, err := templates.Diff(name)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	require.NoError(t, fileManager.WriteFile(filepath.Join(dir, "overrides", name), []byte("# karei:prepend\n# mine\n")))
+
+	rendered, err = templates.Render(name)
+	require.NoError(t, err)
+	assert.Equal(t, "# mine\ntheme[main_bg]=\"#2E3440\"\n", string(rendered))
+
+	diff, err = templates.Diff(name)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@@ -1,2 +1,3 @@", "+# mine", " theme[main_bg]=\"#2E3440\"", " "}, diff)
+
+	_, err = templates.Render("missing.conf")
+	require.ErrorIs(t, err, application.ErrUnknownTemplate)
+}
+
+func TestTemplates_RenderOverrideOnly(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fileManager := platform.NewFileManager(false)
+	templates := application.NewTemplates(fileManager, filepath.Join(dir, "builtin"), filepath.Join(dir, "overrides"))
+
+	require.NoError(t, fileManager.WriteFile(filepath.Join(dir, "overrides", "mise.toml"), []byte("[tools]\nnode = \"22\"\n")))
+
+	rendered, err := templates.Render("mise.toml")
+	require.NoError(t, err)
+	assert.Equal(t, "[tools]\nnode = \"22\"\n", string(rendered))
+}
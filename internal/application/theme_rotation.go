@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// Units of the theme rotation.
+const (
+	rotationService = "karei-theme-rotate.service"
+	rotationTimer   = "karei-theme-rotate.timer"
+)
+
+// MinRotationInterval is the shortest interval themes rotate at; applying a
+// theme restarts parts of the desktop, which is no use more often.
+const MinRotationInterval = time.Minute
+
+// ErrRotationInterval indicates a rotation interval below MinRotationInterval.
+var ErrRotationInterval = errors.New("rotation interval too short")
+
+// ThemeRotation schedules karei theme rotate --next with a systemd user
+// timer, so the configured themes are applied in turn.
+type ThemeRotation struct {
+	fileManager   domain.FileManager
+	commandRunner domain.CommandRunner
+	unitDir       string
+}
+
+// NewThemeRotation creates the theme rotation scheduler.
+func NewThemeRotation(fm domain.FileManager, cr domain.CommandRunner) *ThemeRotation {
+	return &ThemeRotation{
+		fileManager:   fm,
+		commandRunner: cr,
+	}
+}
+
+// SetUnitDir sets the systemd user unit directory the timer is written to.
+// Without it, the rotation is never scheduled.
+func (r *ThemeRotation) SetUnitDir(dir string) {
+	r.unitDir = dir
+}
+
+// Scheduled reports whether the rotation timer is installed.
+func (r *ThemeRotation) Scheduled() bool {
+	return r.unitDir != "" && r.fileManager.FileExists(filepath.Join(r.unitDir, rotationTimer))
+}
+
+// Schedule writes and starts the timer running karei, at executable, every
+// interval, replacing the timer of an earlier schedule.
+func (r *ThemeRotation) Schedule(ctx context.Context, executable string, every time.Duration) error {
+	if every < MinRotationInterval {
+		return fmt.Errorf("%w: %s, the minimum is %s", ErrRotationInterval, every, MinRotationInterval)
+	}
+
+	if r.unitDir == "" {
+		return nil
+	}
+
+	seconds := fmt.Sprintf("%ds", int64(every/time.Second))
+	units := map[string]string{
+		rotationService: "[Unit]\nDescription=Apply the next karei theme of the rotation\n\n" +
+			"[Service]\nType=oneshot\nExecStart=" + executable + " theme rotate --next\n",
+		rotationTimer: "[Unit]\nDescription=Rotate karei themes every " + every.String() + "\n\n" +
+			"[Timer]\nOnActiveSec=" + seconds + "\nOnUnitActiveSec=" + seconds + "\n\n[Install]\nWantedBy=timers.target\n",
+	}
+
+	return installUserTimer(ctx, r.fileManager, r.commandRunner, r.unitDir, rotationTimer, units)
+}
+
+// Stop stops and removes the rotation timer, if installed.
+func (r *ThemeRotation) Stop(ctx context.Context) error {
+	return removeUserTimer(ctx, r.fileManager, r.commandRunner, r.unitDir, rotationTimer, rotationService)
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThemeRotation_Schedule(t *testing.T) {
+	t.Parallel()
+
+	unitDir := t.TempDir()
+	runner := &testutil.MockCommandRunner{}
+	runner.On("Execute", mock.Anything, "systemctl", "--user", "daemon-reload").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "systemctl", "--user", "enable", "--now", "karei-theme-rotate.timer").Return(nil).Once()
+	runner.On("Execute", mock.Anything, "systemctl", "--user", "disable", "--now", "karei-theme-rotate.timer").Return(nil).Once()
+
+	rotation := application.NewThemeRotation(platform.NewFileManager(false), runner)
+	rotation.SetUnitDir(unitDir)
+
+	require.ErrorIs(t, rotation.Schedule(context.Background(), "/usr/local/bin/karei", time.Second),
+		application.ErrRotationInterval)
+	assert.False(t, rotation.Scheduled())
+
+	require.NoError(t, rotation.Schedule(context.Background(), "/usr/local/bin/karei", 24*time.Hour))
+	assert.True(t, rotation.Scheduled())
+
+	unit, err := os.ReadFile(filepath.Join(unitDir, "karei-theme-rotate.service"))
+	require.NoError(t, err)
+	assert.Contains(t, string(unit), "ExecStart=/usr/local/bin/karei theme rotate --next")
+
+	timer, err := os.ReadFile(filepath.Join(unitDir, "karei-theme-rotate.timer"))
+	require.NoError(t, err)
+	assert.Contains(t, string(timer), "OnUnitActiveSec=86400s")
+
+	require.NoError(t, rotation.Stop(context.Background()))
+	assert.False(t, rotation.Scheduled())
+	assert.NoFileExists(t, filepath.Join(unitDir, "karei-theme-rotate.service"))
+
+	runner.AssertExpectations(t)
+}
@@ -20,8 +20,15 @@ import (
 type ThemeService struct {
 	fileManager   domain.FileManager
 	commandRunner domain.CommandRunner
+	events        domain.EventPublisher
 	configPath    string
 	themesPath    string
+	currentFile   string       // Records the applied theme when set
+	configs       *ConfigFiles // Records the configuration files written when set
+	templates     *Templates   // Merges the user's template overrides when set
+	session       domain.SessionType
+	note          func(note string)    // Told about parts of a theme skipped
+	desktop       domain.DesktopThemer // Applies desktop settings other than GNOME's when set
 }
 
 // NewThemeService creates a service for managing desktop themes.
@@ -29,11 +36,74 @@ func NewThemeService(fm domain.FileManager, cr domain.CommandRunner, configPath,
 	return &ThemeService{
 		fileManager:   fm,
 		commandRunner: cr,
+		events:        domain.NoopEventPublisher{},
 		configPath:    configPath,
 		themesPath:    themesPath,
 	}
 }
 
+// SetEventPublisher sets the publisher notified when a theme has been applied.
+func (s *ThemeService) SetEventPublisher(publisher domain.EventPublisher) {
+	s.events = publisher
+}
+
+// SetCurrentThemeFile sets the file recording the applied theme, read back by CurrentTheme.
+func (s *ThemeService) SetCurrentThemeFile(path string) {
+	s.currentFile = path
+}
+
+// SetConfigFiles sets where the configuration files themes write are
+// recorded, so changes made to them later are found and merged when a
+// theme is applied again. Nil writes them as they are.
+func (s *ThemeService) SetConfigFiles(configs *ConfigFiles) {
+	s.configs = configs
+}
+
+// SetTemplates sets the templates theme files are rendered from, so the
+// user's overrides of them are applied. Nil copies the built-in theme files.
+func (s *ThemeService) SetTemplates(templates *Templates) {
+	s.templates = templates
+}
+
+// SetSession sets the session type themes are applied in. In a text session,
+// such as over SSH, the desktop settings are skipped and only configuration
+// files are written. Unknown sessions apply everything.
+func (s *ThemeService) SetSession(session domain.SessionType) {
+	s.session = session
+}
+
+// SetDesktopThemer sets the themer applying the desktop-wide parts of themes
+// on a desktop other than GNOME, such as KDE Plasma. Nil applies GNOME's.
+func (s *ThemeService) SetDesktopThemer(themer domain.DesktopThemer) {
+	s.desktop = themer
+}
+
+// SetNoteFunc sets a function told about the parts of a theme skipped and why.
+func (s *ThemeService) SetNoteFunc(fn func(note string)) {
+	s.note = fn
+}
+
+// CurrentTheme returns the theme applied last, or "" when none was recorded.
+func (s *ThemeService) CurrentTheme() string {
+	if s.currentFile == "" || !s.fileManager.FileExists(s.currentFile) {
+		return ""
+	}
+
+	data, err := s.fileManager.ReadFile(s.currentFile)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+// Palette returns the interface colors of a theme.
+func (s *ThemeService) Palette(themeName string) (domain.ThemePalette, bool) {
+	theme, ok := s.GetAvailableThemes()[themeName]
+
+	return theme.Palette, ok
+}
+
 // ThemeConfig represents a complete theme configuration.
 type ThemeConfig struct {
 	Name            string `json:"name"`
@@ -48,6 +118,9 @@ type ThemeConfig struct {
 	VSCodeExtension string `json:"vscode_extension"`
 	VSCodeTheme     string `json:"vscode_theme"`
 	Background      string `json:"background"`
+
+	// Palette colors karei's own interface while the theme is applied.
+	Palette domain.ThemePalette `json:"palette"`
 }
 
 var (
@@ -88,6 +161,17 @@ func (s *ThemeService) GetAvailableThemes() map[string]ThemeConfig {
 			VSCodeExtension: "enkia.tokyo-night",
 			VSCodeTheme:     "Tokyo Night",
 			Background:      "background.jpg",
+			Palette: domain.ThemePalette{
+				Primary:    "#7aa2f7",
+				Secondary:  "#bb9af7",
+				Success:    "#9ece6a",
+				Warning:    "#e0af68",
+				Error:      "#f7768e",
+				Info:       "#7dcfff",
+				Muted:      "#565f89",
+				Background: "#1a1b26",
+				Foreground: "#c0caf5",
+			},
 		},
 		"catppuccin": {
 			Name:            "catppuccin",
@@ -102,6 +186,17 @@ func (s *ThemeService) GetAvailableThemes() map[string]ThemeConfig {
 			VSCodeExtension: "Catppuccin.catppuccin-vsc",
 			VSCodeTheme:     "Catppuccin Mocha",
 			Background:      "background.png",
+			Palette: domain.ThemePalette{
+				Primary:    "#89b4fa",
+				Secondary:  "#cba6f7",
+				Success:    "#a6e3a1",
+				Warning:    "#f9e2af",
+				Error:      "#f38ba8",
+				Info:       "#89dceb",
+				Muted:      "#6c7086",
+				Background: "#1e1e2e",
+				Foreground: "#cdd6f4",
+			},
 		},
 		"gruvbox": {
 			Name:            "gruvbox",
@@ -116,6 +211,17 @@ func (s *ThemeService) GetAvailableThemes() map[string]ThemeConfig {
 			VSCodeExtension: "jdinhlife.gruvbox",
 			VSCodeTheme:     "Gruvbox Dark Medium",
 			Background:      "background.jpg",
+			Palette: domain.ThemePalette{
+				Primary:    "#83a598",
+				Secondary:  "#d3869b",
+				Success:    "#b8bb26",
+				Warning:    "#fabd2f",
+				Error:      "#fb4934",
+				Info:       "#8ec07c",
+				Muted:      "#928374",
+				Background: "#282828",
+				Foreground: "#ebdbb2",
+			},
 		},
 		"nord": {
 			Name:            "nord",
@@ -130,6 +236,17 @@ func (s *ThemeService) GetAvailableThemes() map[string]ThemeConfig {
 			VSCodeExtension: "arcticicestudio.nord-visual-studio-code",
 			VSCodeTheme:     "Nord",
 			Background:      "background.png",
+			Palette: domain.ThemePalette{
+				Primary:    "#88c0d0",
+				Secondary:  "#b48ead",
+				Success:    "#a3be8c",
+				Warning:    "#ebcb8b",
+				Error:      "#bf616a",
+				Info:       "#81a1c1",
+				Muted:      "#4c566a",
+				Background: "#2e3440",
+				Foreground: "#d8dee9",
+			},
 		},
 		"everforest": {
 			Name:            "everforest",
@@ -144,6 +261,17 @@ func (s *ThemeService) GetAvailableThemes() map[string]ThemeConfig {
 			VSCodeExtension: "sainnhe.everforest",
 			VSCodeTheme:     "Everforest Dark",
 			Background:      "background.jpg",
+			Palette: domain.ThemePalette{
+				Primary:    "#a7c080",
+				Secondary:  "#d699b6",
+				Success:    "#a7c080",
+				Warning:    "#dbbc7f",
+				Error:      "#e67e80",
+				Info:       "#7fbbb3",
+				Muted:      "#859289",
+				Background: "#2d353b",
+				Foreground: "#d3c6aa",
+			},
 		},
 		"kanagawa": {
 			Name:            "kanagawa",
@@ -158,6 +286,17 @@ func (s *ThemeService) GetAvailableThemes() map[string]ThemeConfig {
 			VSCodeExtension: "qufiwefefwoyn.kanagawa",
 			VSCodeTheme:     "Kanagawa",
 			Background:      "background.jpg",
+			Palette: domain.ThemePalette{
+				Primary:    "#7e9cd8",
+				Secondary:  "#957fb8",
+				Success:    "#76946a",
+				Warning:    "#ff9e3b",
+				Error:      "#c34043",
+				Info:       "#7fb4ca",
+				Muted:      "#727169",
+				Background: "#1f1f28",
+				Foreground: "#dcd7ba",
+			},
 		},
 		"rose-pine": {
 			Name:            "rose-pine",
@@ -172,6 +311,17 @@ func (s *ThemeService) GetAvailableThemes() map[string]ThemeConfig {
 			VSCodeExtension: "mvllow.rose-pine",
 			VSCodeTheme:     "Rosé Pine",
 			Background:      "background.jpg",
+			Palette: domain.ThemePalette{
+				Primary:    "#9ccfd8",
+				Secondary:  "#c4a7e7",
+				Success:    "#31748f",
+				Warning:    "#f6c177",
+				Error:      "#eb6f92",
+				Info:       "#ebbcba",
+				Muted:      "#6e6a86",
+				Background: "#191724",
+				Foreground: "#e0def4",
+			},
 		},
 		"gruvbox-light": {
 			Name:          "gruvbox-light",
@@ -185,6 +335,17 @@ func (s *ThemeService) GetAvailableThemes() map[string]ThemeConfig {
 			ChromeColor:   2372448,
 			VSCodeTheme:   "Gruvbox Light Medium",
 			Background:    "background.jpg",
+			Palette: domain.ThemePalette{
+				Primary:    "#3c8588",
+				Secondary:  "#b16286",
+				Success:    "#98971a",
+				Warning:    "#d79921",
+				Error:      "#cc241d",
+				Info:       "#427b58",
+				Muted:      "#928374",
+				Background: "#fbf1c7",
+				Foreground: "#282828",
+			},
 		},
 	}
 }
@@ -198,38 +359,129 @@ func (s *ThemeService) ApplyTheme(ctx context.Context, themeName string) error {
 		return fmt.Errorf("%w: %s", ErrUnknownTheme, themeName)
 	}
 
-	// Apply GNOME settings
-	if err := s.ApplyGnomeSettings(ctx, &theme); err != nil {
+	if s.session == domain.SessionTTY {
+		s.notef("Skipped desktop appearance, background and terminal colors: no graphical session, apply %s again from the desktop", themeName)
+	} else if err := s.applyDesktopTheme(ctx, themeName, &theme); err != nil {
+		return err
+	}
+
+	// Apply btop theme
+	if err := s.ApplyBtopTheme(ctx, themeName); err != nil {
+		return fmt.Errorf("failed to apply btop theme: %w", err)
+	}
+
+	// Apply VSCode theme
+	if theme.VSCodeExtension != "" {
+		if err := s.ApplyVSCodeTheme(ctx, &theme); err != nil {
+			return fmt.Errorf("failed to apply VSCode theme: %w", err)
+		}
+	}
+
+	// Apply Chrome theme
+	if err := s.ApplyChromeTheme(ctx, &theme); err != nil {
+		return fmt.Errorf("failed to apply Chrome theme: %w", err)
+	}
+
+	if err := s.recordCurrentTheme(themeName); err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, domain.NewEvent(domain.EventThemeApplied, themeName))
+
+	return nil
+}
+
+// applyDesktopTheme applies the parts of a theme kept in the desktop's
+// settings rather than in files.
+func (s *ThemeService) applyDesktopTheme(ctx context.Context, themeName string, theme *ThemeConfig) error {
+	if s.desktop != nil {
+		return s.applyThemerTheme(ctx, themeName, theme)
+	}
+
+	if err := s.ApplyGnomeSettings(ctx, theme); err != nil {
 		return fmt.Errorf("failed to apply GNOME settings: %w", err)
 	}
 
-	// Apply background
 	if theme.Background != "" {
 		if err := s.ApplyBackground(ctx, themeName, theme.Background); err != nil {
 			return fmt.Errorf("failed to apply background: %w", err)
 		}
 	}
 
-	// Apply terminal theme
 	if err := s.ApplyTerminalTheme(ctx, themeName); err != nil {
 		return fmt.Errorf("failed to apply terminal theme: %w", err)
 	}
 
-	// Apply btop theme
-	if err := s.ApplyBtopTheme(ctx, themeName); err != nil {
-		return fmt.Errorf("failed to apply btop theme: %w", err)
+	return nil
+}
+
+// applyThemerTheme applies the desktop-wide parts of a theme through the
+// desktop themer, taking the terminal colors from the theme's Ghostty file.
+func (s *ThemeService) applyThemerTheme(ctx context.Context, themeName string, theme *ThemeConfig) error {
+	desktop := s.desktop.Desktop()
+
+	appearance := domain.DesktopAppearance{
+		Name:   themeName,
+		Dark:   theme.ColorScheme == "prefer-dark",
+		Accent: theme.Palette.Primary,
 	}
 
-	// Apply VSCode theme
-	if theme.VSCodeExtension != "" {
-		if err := s.ApplyVSCodeTheme(ctx, &theme); err != nil {
-			return fmt.Errorf("failed to apply VSCode theme: %w", err)
+	if err := s.desktop.ApplyAppearance(ctx, appearance); err != nil {
+		return fmt.Errorf("failed to apply %s appearance: %w", desktop, err)
+	}
+
+	if theme.Background != "" {
+		backgroundPath := filepath.Join(s.themesPath, themeName, theme.Background)
+		if !s.fileManager.FileExists(backgroundPath) {
+			return fmt.Errorf("background file not found: %s", backgroundPath)
+		}
+
+		if err := s.desktop.ApplyBackground(ctx, backgroundPath); err != nil {
+			return fmt.Errorf("failed to apply background: %w", err)
 		}
 	}
 
-	// Apply Chrome theme
-	if err := s.ApplyChromeTheme(ctx, &theme); err != nil {
-		return fmt.Errorf("failed to apply Chrome theme: %w", err)
+	colorsFile := filepath.Join(s.themesPath, themeName, "ghostty.conf")
+	if !s.fileManager.FileExists(colorsFile) {
+		return nil // No terminal colors to apply
+	}
+
+	data, err := s.fileManager.ReadFile(colorsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read terminal colors: %w", err)
+	}
+
+	colors, err := domain.ParseGhosttyColors(string(data))
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", colorsFile, err)
+	}
+
+	if err := s.desktop.ApplyTerminalColors(ctx, themeName, colors); err != nil {
+		return fmt.Errorf("failed to apply terminal theme: %w", err)
+	}
+
+	return nil
+}
+
+// notef tells the note function, when set, about a skipped part of a theme.
+func (s *ThemeService) notef(format string, args ...any) {
+	if s.note != nil {
+		s.note(fmt.Sprintf(format, args...))
+	}
+}
+
+// recordCurrentTheme remembers themeName as the applied theme.
+func (s *ThemeService) recordCurrentTheme(themeName string) error {
+	if s.currentFile == "" {
+		return nil
+	}
+
+	if err := s.fileManager.EnsureDir(filepath.Dir(s.currentFile)); err != nil {
+		return fmt.Errorf("failed to record applied theme: %w", err)
+	}
+
+	if err := s.fileManager.WriteFile(s.currentFile, []byte(themeName+"\n")); err != nil {
+		return fmt.Errorf("failed to record applied theme: %w", err)
 	}
 
 	return nil
@@ -364,7 +616,7 @@ func (s *ThemeService) ApplyVSCodeTheme(ctx context.Context, theme *ThemeConfig)
 		return fmt.Errorf("failed to marshal VSCode settings: %w", err)
 	}
 
-	return s.fileManager.WriteFile(settingsPath, newData)
+	return s.writeConfig(settingsPath, newData, "")
 }
 
 // ApplyChromeTheme applies the Chrome theme configuration.
@@ -442,7 +694,12 @@ func (s *ThemeService) copyBtopTheme(src, btopConfigDir, themeName string) error
 		return fmt.Errorf("failed to create btop themes directory: %w", err)
 	}
 
-	if err := s.fileManager.CopyFile(src, dst); err != nil {
+	data, source, err := s.themeFile(src, themeName, "btop.theme")
+	if err != nil {
+		return fmt.Errorf("failed to read btop theme: %w", err)
+	}
+
+	if err := s.writeConfig(dst, data, source); err != nil {
 		return fmt.Errorf("failed to copy btop theme: %w", err)
 	}
 
@@ -460,7 +717,34 @@ func (s *ThemeService) updateBtopConfig(btopConfigDir, themeName string) error {
 
 	configStr := s.updateBtopConfigContent(string(config), themeName)
 
-	return s.fileManager.WriteFile(configFile, []byte(configStr))
+	return s.writeConfig(configFile, []byte(configStr), "")
+}
+
+// themeFile returns the theme file at src, rendered with the user's override
+// when templates are set, and the source it was generated from.
+func (s *ThemeService) themeFile(src, themeName, file string) ([]byte, string, error) {
+	if s.templates == nil {
+		data, err := s.fileManager.ReadFile(src)
+
+		return data, src, err
+	}
+
+	name := filepath.Join("themes", themeName, file)
+	data, err := s.templates.Render(name)
+
+	return data, name, err
+}
+
+// writeConfig writes a configuration file generated from source, or from
+// nothing when source is empty, recording it when configuration files are.
+func (s *ThemeService) writeConfig(path string, data []byte, source string) error {
+	if s.configs == nil {
+		return s.fileManager.WriteFile(path, data)
+	}
+
+	_, err := s.configs.Write(path, data, source)
+
+	return err
 }
 
 func (s *ThemeService) updateBtopConfigContent(configStr, themeName string) string {
@@ -6,11 +6,15 @@ package application_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/janderssonse/karei/internal/adapters/platform"
 	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
 	"github.com/janderssonse/karei/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -316,9 +320,36 @@ func TestThemeService_GetAvailableThemes(t *testing.T) {
 		if name != "gruvbox-light" {
 			assert.NotEmpty(t, theme.GtkTheme, "theme %s should have gtk theme", name)
 		}
+
+		_, err := domain.ParseThemePalette(map[string]string{
+			"primary": theme.Palette.Primary, "success": theme.Palette.Success, "error": theme.Palette.Error,
+			"muted": theme.Palette.Muted, "background": theme.Palette.Background, "foreground": theme.Palette.Foreground,
+		})
+		assert.NoError(t, err, "theme %s should have a complete palette", name)
 	}
 }
 
+func TestThemeService_CurrentTheme(t *testing.T) {
+	t.Parallel()
+
+	service := application.NewThemeService(platform.NewFileManager(false), nil, "", "")
+	assert.Empty(t, service.CurrentTheme(), "nothing recorded without a file")
+
+	path := filepath.Join(t.TempDir(), "theme")
+	service.SetCurrentThemeFile(path)
+	assert.Empty(t, service.CurrentTheme())
+
+	require.NoError(t, os.WriteFile(path, []byte("nord\n"), 0o600))
+	assert.Equal(t, "nord", service.CurrentTheme())
+
+	palette, ok := service.Palette("nord")
+	require.True(t, ok)
+	assert.Equal(t, "#88c0d0", palette.Primary)
+
+	_, ok = service.Palette("unknown")
+	assert.False(t, ok)
+}
+
 func TestThemeService_ApplyBackground(t *testing.T) {
 	t.Parallel()
 
@@ -405,3 +436,82 @@ func TestThemeService_ListThemes(t *testing.T) {
 	// Verify alphabetical order or consistent ordering
 	assert.Greater(t, len(themeNames), 5, "should have multiple themes")
 }
+
+func TestThemeService_ApplyThemeInTextSession(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	runner := &testutil.MockCommandRunner{}
+	// Only the editor is set up; no gsettings call is expected
+	runner.On("Execute", mock.Anything, "code", "--install-extension", mock.Anything).Return(errors.New("code not installed"))
+
+	service := application.NewThemeService(platform.NewFileManager(false), runner, filepath.Join(dir, "config"), filepath.Join(dir, "themes"))
+	service.SetSession(domain.SessionTTY)
+
+	var notes []string
+
+	service.SetNoteFunc(func(note string) { notes = append(notes, note) })
+
+	require.NoError(t, service.ApplyTheme(context.Background(), "gruvbox"))
+	require.Len(t, notes, 1)
+	assert.Contains(t, notes[0], "no graphical session")
+}
+
+// recordingThemer is a DesktopThemer recording what it was asked to apply.
+type recordingThemer struct {
+	appearance domain.DesktopAppearance
+	background string
+	colors     domain.TerminalColors
+}
+
+func (r *recordingThemer) Desktop() string { return "Test Desktop" }
+
+func (r *recordingThemer) ApplyAppearance(_ context.Context, appearance domain.DesktopAppearance) error {
+	r.appearance = appearance
+
+	return nil
+}
+
+func (r *recordingThemer) ApplyBackground(_ context.Context, path string) error {
+	r.background = path
+
+	return nil
+}
+
+func (r *recordingThemer) ApplyTerminalColors(_ context.Context, _ string, colors domain.TerminalColors) error {
+	r.colors = colors
+
+	return nil
+}
+
+func TestThemeService_ApplyThemeWithDesktopThemer(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	themeDir := filepath.Join(dir, "themes", "gruvbox")
+	require.NoError(t, os.MkdirAll(themeDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(themeDir, "background.jpg"), []byte("jpg"), 0o644))
+
+	ghostty := "background = 282828\nforeground = ebdbb2\n"
+	for n := range 16 {
+		ghostty += fmt.Sprintf("palette = %d=#%06x\n", n, n)
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(themeDir, "ghostty.conf"), []byte(ghostty), 0o644))
+
+	runner := &testutil.MockCommandRunner{}
+	// No gsettings call is expected, the themer applies the desktop settings
+	runner.On("Execute", mock.Anything, "code", "--install-extension", mock.Anything).Return(errors.New("code not installed"))
+
+	themer := &recordingThemer{}
+	service := application.NewThemeService(platform.NewFileManager(false), runner, filepath.Join(dir, "config"), filepath.Join(dir, "themes"))
+	service.SetDesktopThemer(themer)
+
+	require.NoError(t, service.ApplyTheme(context.Background(), "gruvbox"))
+
+	assert.Equal(t, domain.DesktopAppearance{Name: "gruvbox", Dark: true, Accent: "#83a598"}, themer.appearance)
+	assert.Equal(t, filepath.Join(themeDir, "background.jpg"), themer.background)
+	assert.Equal(t, "#282828", themer.colors.Background)
+	assert.Equal(t, "#00000f", themer.colors.Palette[15])
+	runner.AssertNotCalled(t, "Execute", mock.Anything, "gsettings", mock.Anything)
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// TilingTheme is what tiling window manager templates are rendered with.
+type TilingTheme struct {
+	Theme     string
+	Palette   domain.ThemePalette
+	Wallpaper string // Absolute path, or empty for a solid background
+	Terminal  string
+}
+
+// TilingService generates the configuration of a tiling window manager and
+// its companions from templates, matched to a theme, and points the desktop
+// portal at the compositor's backend.
+type TilingService struct {
+	fileManager domain.FileManager
+	templates   *Templates
+	configHome  string
+	configs     *ConfigFiles // Records the configuration files written when set
+}
+
+// NewTilingService creates a service rendering templates into configHome.
+func NewTilingService(fm domain.FileManager, templates *Templates, configHome string) *TilingService {
+	return &TilingService{fileManager: fm, templates: templates, configHome: configHome}
+}
+
+// SetConfigFiles sets where the files written are recorded, so later changes
+// to them are found and merged. Nil writes them as they are.
+func (s *TilingService) SetConfigFiles(configs *ConfigFiles) {
+	s.configs = configs
+}
+
+// Configure writes the configuration files of profile and its portal
+// configuration, returning the paths written.
+func (s *TilingService) Configure(profile domain.TilingProfile, theme TilingTheme) ([]string, error) {
+	written := make([]string, 0, len(profile.Configs)+1)
+
+	for _, config := range profile.Configs {
+		content, err := s.templates.Execute(config.Template, theme)
+		if err != nil {
+			return written, err
+		}
+
+		path := filepath.Join(s.configHome, config.Path)
+		if err := s.write(path, content); err != nil {
+			return written, err
+		}
+
+		written = append(written, path)
+	}
+
+	path := filepath.Join(s.configHome, profile.PortalConfigPath())
+	if err := s.write(path, []byte(profile.PortalConfig())); err != nil {
+		return written, err
+	}
+
+	written = append(written, path)
+
+	if profile.UserConfig == "" {
+		return written, nil
+	}
+
+	// The user's own file is never recorded or overwritten
+	path = filepath.Join(s.configHome, profile.UserConfig)
+	if s.fileManager.FileExists(path) {
+		return written, nil
+	}
+
+	if err := s.fileManager.WriteFile(path, []byte("# Your own "+profile.Name+" settings, kept when karei regenerates the configuration\n")); err != nil {
+		return written, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return append(written, path), nil
+}
+
+// write writes a generated file, recording it when configuration files are.
+// The rendered content, not the template, is what later changes are merged
+// with, since it depends on the theme.
+func (s *TilingService) write(path string, content []byte) error {
+	if err := s.fileManager.EnsureDir(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	if s.configs == nil {
+		if err := s.fileManager.WriteFile(path, content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+
+		return nil
+	}
+
+	if _, err := s.configs.Write(path, content, ""); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
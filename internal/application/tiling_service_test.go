@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTilingService_Configure(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fileManager := platform.NewFileManager(false)
+	templates := application.NewTemplates(fileManager, filepath.Join(dir, "builtin"), filepath.Join(dir, "overrides"))
+
+	profile := domain.TilingProfile{
+		WM:         domain.TilingHyprland,
+		Name:       "Hyprland",
+		Configs:    []domain.TilingConfig{{Template: "configs/tiling/hypr/hyprland.conf", Path: "hypr/hyprland.conf"}},
+		Portal:     "hyprland",
+		UserConfig: "hypr/user.conf",
+	}
+
+	require.NoError(t, fileManager.WriteFile(filepath.Join(dir, "builtin", "configs/tiling/hypr/hyprland.conf"),
+		[]byte("col.active_border = rgb({{bare .Palette.Primary}})\n$terminal = {{.Terminal}}\n")))
+	require.NoError(t, fileManager.WriteFile(filepath.Join(dir, "overrides", "configs/tiling/hypr/hyprland.conf"),
+		[]byte("# karei:append\nbind = $mod, B, exec, firefox\n")))
+
+	theme := application.TilingTheme{Theme: "nord", Palette: domain.ThemePalette{Primary: "#88c0d0"}, Terminal: "ghostty"}
+	service := application.NewTilingService(fileManager, templates, filepath.Join(dir, "config"))
+
+	written, err := service.Configure(profile, theme)
+	require.NoError(t, err)
+	assert.Len(t, written, 3)
+
+	config, err := os.ReadFile(filepath.Join(dir, "config", "hypr", "hyprland.conf"))
+	require.NoError(t, err)
+	assert.Equal(t, "col.active_border = rgb(88c0d0)\n$terminal = ghostty\nbind = $mod, B, exec, firefox\n", string(config))
+
+	portals, err := os.ReadFile(filepath.Join(dir, "config", "xdg-desktop-portal", "hyprland-portals.conf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(portals), "default=hyprland;gtk")
+
+	// The user's own file is created once and then left alone
+	userConfig := filepath.Join(dir, "config", "hypr", "user.conf")
+	require.NoError(t, os.WriteFile(userConfig, []byte("monitor = ,preferred,auto,1.5\n"), 0o644))
+
+	written, err = service.Configure(profile, theme)
+	require.NoError(t, err)
+	assert.NotContains(t, written, userConfig)
+
+	mine, err := os.ReadFile(userConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "monitor = ,preferred,auto,1.5\n", string(mine))
+}
+
+func TestTilingService_BuiltinTemplates(t *testing.T) {
+	t.Parallel()
+
+	fileManager := platform.NewFileManager(false)
+	// The built-in templates live at the repository root
+	templates := application.NewTemplates(fileManager, filepath.Join("..", ".."), t.TempDir())
+	themes := application.NewThemeService(fileManager, nil, "", "")
+
+	for _, wm := range []domain.TilingWM{domain.TilingSway, domain.TilingHyprland} {
+		for _, key := range domain.TilingProfileFor(wm).Apps {
+			assert.Contains(t, apps.Apps, key, "%s installs %s", wm, key)
+		}
+
+		for name, theme := range themes.GetAvailableThemes() {
+			service := application.NewTilingService(fileManager, templates, t.TempDir())
+
+			_, err := service.Configure(domain.TilingProfileFor(wm), application.TilingTheme{Theme: name, Palette: theme.Palette, Terminal: "ghostty"})
+			require.NoError(t, err, "%s with %s", wm, name)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+var (
+	// ErrTrustedKeyExists indicates a trusted key by the name exists already.
+	ErrTrustedKeyExists = errors.New("a trusted key by this name exists")
+	// ErrTrustedKeyNotFound indicates no trusted key has the name.
+	ErrTrustedKeyNotFound = errors.New("no trusted key by this name")
+)
+
+// TrustService keeps the public keys remote catalogs and profiles must be
+// signed with, and fetches remote content only once a trusted key's
+// signature, published next to it, verifies.
+type TrustService struct {
+	fileManager   domain.FileManager
+	commandRunner domain.CommandRunner
+	network       domain.NetworkClient
+	path          string
+}
+
+// NewTrustService creates a service keeping the trust store at path.
+func NewTrustService(fm domain.FileManager, cr domain.CommandRunner, nc domain.NetworkClient, path string) *TrustService {
+	return &TrustService{fileManager: fm, commandRunner: cr, network: nc, path: path}
+}
+
+// Keys returns the trusted keys, by name.
+func (s *TrustService) Keys() ([]domain.TrustedKey, error) {
+	if !s.fileManager.FileExists(s.path) {
+		return nil, nil
+	}
+
+	data, err := s.fileManager.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust store: %w", err)
+	}
+
+	var keys []domain.TrustedKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse trust store %s: %w", s.path, err)
+	}
+
+	return keys, nil
+}
+
+// Add trusts the public key value, a key or the contents of its file, by name.
+func (s *TrustService) Add(name, value string) (domain.TrustedKey, error) {
+	key, err := domain.ParseTrustedKey(name, value)
+	if err != nil {
+		return domain.TrustedKey{}, err
+	}
+
+	keys, err := s.Keys()
+	if err != nil {
+		return domain.TrustedKey{}, err
+	}
+
+	if slices.ContainsFunc(keys, func(k domain.TrustedKey) bool { return k.Name == name }) {
+		return domain.TrustedKey{}, fmt.Errorf("%w: %s", ErrTrustedKeyExists, name)
+	}
+
+	key.Added = time.Now()
+	keys = append(keys, key)
+	slices.SortFunc(keys, func(a, b domain.TrustedKey) int { return strings.Compare(a.Name, b.Name) })
+
+	return key, s.save(keys)
+}
+
+// Remove stops trusting the key by name.
+func (s *TrustService) Remove(name string) error {
+	keys, err := s.Keys()
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(keys, func(k domain.TrustedKey) bool { return k.Name == name }) {
+		return fmt.Errorf("%w: %s", ErrTrustedKeyNotFound, name)
+	}
+
+	return s.save(slices.DeleteFunc(keys, func(k domain.TrustedKey) bool { return k.Name == name }))
+}
+
+// Fetch downloads url to dest and returns the trusted key that signed it.
+// Content without a signature, or signed by no trusted key, is refused.
+func (s *TrustService) Fetch(ctx context.Context, url, dest string) (domain.TrustedKey, error) {
+	keys, err := s.Keys()
+	if err != nil {
+		return domain.TrustedKey{}, err
+	}
+
+	if len(keys) == 0 {
+		return domain.TrustedKey{}, fmt.Errorf("%w: %s, trust its publisher with: karei trust add <name> <key>",
+			domain.ErrUntrusted, url)
+	}
+
+	if err := s.network.DownloadFile(ctx, url, dest); err != nil {
+		return domain.TrustedKey{}, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+
+	signed := false
+	failures := []error{fmt.Errorf("%w: %s", domain.ErrUntrusted, url)}
+
+	for _, suffix := range signatureSuffixes(keys) {
+		signature := dest + suffix
+		if err := s.network.DownloadFile(ctx, url+suffix, signature); err != nil {
+			continue
+		}
+
+		signed = true
+
+		for _, key := range keys {
+			if key.SignatureSuffix() != suffix {
+				continue
+			}
+
+			err := s.verify(ctx, key, dest, signature)
+			if err == nil {
+				return key, nil
+			}
+
+			if !errors.Is(err, domain.ErrBadSignature) {
+				failures = append(failures, err)
+			}
+		}
+	}
+
+	if !signed {
+		return domain.TrustedKey{}, fmt.Errorf("%w: %s", domain.ErrUnsigned, url)
+	}
+
+	return domain.TrustedKey{}, errors.Join(failures...)
+}
+
+// verify checks the signature of the file at path with key.
+func (s *TrustService) verify(ctx context.Context, key domain.TrustedKey, path, signature string) error {
+	if key.Format == domain.KeyFormatMinisign {
+		if !s.commandRunner.CommandExists("minisign") {
+			return fmt.Errorf("minisign is needed to verify signatures made with key %s", key.Name)
+		}
+
+		if err := s.commandRunner.Execute(ctx, "minisign", "-V", "-q", "-P", key.Key, "-m", path, "-x", signature); err != nil {
+			return domain.ErrBadSignature
+		}
+
+		return nil
+	}
+
+	content, err := s.fileManager.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	sig, err := s.fileManager.ReadFile(signature)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", signature, err)
+	}
+
+	return key.Verify(content, sig)
+}
+
+func (s *TrustService) save(keys []domain.TrustedKey) error {
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode trust store: %w", err)
+	}
+
+	if err := s.fileManager.EnsureDir(filepath.Dir(s.path)); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+
+	if err := s.fileManager.WriteFile(s.path, data); err != nil {
+		return fmt.Errorf("failed to write trust store: %w", err)
+	}
+
+	return nil
+}
+
+// signatureSuffixes returns the suffixes signatures made with keys are
+// published under, each once.
+func signatureSuffixes(keys []domain.TrustedKey) []string {
+	var suffixes []string
+
+	for _, key := range keys {
+		if suffix := key.SignatureSuffix(); !slices.Contains(suffixes, suffix) {
+			suffixes = append(suffixes, suffix)
+		}
+	}
+
+	return suffixes
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const minisignKey = "RWTg4aDB8lxrP3IrTuAO6FCxiGTDIfLvj6HqhHOFByybjM+Wef1gJWrj"
+
+func TestTrustService_AddListRemove(t *testing.T) {
+	t.Parallel()
+
+	service := application.NewTrustService(platform.NewFileManager(false), &testutil.MockCommandRunner{},
+		&testutil.MockNetworkClient{}, filepath.Join(t.TempDir(), "trust.json"))
+
+	keys, err := service.Keys()
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = service.Add("zeta", minisignKey)
+	require.NoError(t, err)
+
+	_, err = service.Add("acme", "untrusted comment: minisign public key\n"+minisignKey+"\n")
+	require.NoError(t, err)
+
+	_, err = service.Add("acme", minisignKey)
+	require.ErrorIs(t, err, application.ErrTrustedKeyExists)
+
+	keys, err = service.Keys()
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "acme", keys[0].Name)
+
+	require.NoError(t, service.Remove("acme"))
+	require.ErrorIs(t, service.Remove("acme"), application.ErrTrustedKeyNotFound)
+
+	keys, err = service.Keys()
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestTrustService_Fetch(t *testing.T) {
+	t.Parallel()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	_, other, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	public, _ := domain.EncodeTeamKeys(private)
+	dest := filepath.Join(t.TempDir(), "profile.yaml")
+	network := &testutil.MockNetworkClient{}
+	runner := &testutil.MockCommandRunner{}
+	runner.On("CommandExists", "minisign").Return(true)
+	runner.On("Execute", mock.Anything, "minisign", "-V", "-q", "-P", minisignKey, "-m", dest, "-x", dest+".minisig").
+		Return(errors.New("signature verification failed"))
+
+	service := application.NewTrustService(platform.NewFileManager(false), runner, network, filepath.Join(t.TempDir(), "trust.json"))
+
+	_, err = service.Fetch(context.Background(), teamURL, dest)
+	require.ErrorIs(t, err, domain.ErrUntrusted, "nothing is trusted yet")
+
+	_, err = service.Add("acme", minisignKey)
+	require.NoError(t, err)
+
+	_, err = service.Add("team", public)
+	require.NoError(t, err)
+
+	publishSigned := func(private ed25519.PrivateKey) {
+		network.On("DownloadFile", mock.Anything, teamURL+".minisig", mock.Anything).
+			Return(errors.New("download failed with status 404")).Once()
+		publishTeamProfile(t, network, []byte("apps:\n  - vlc\n"), private)
+	}
+
+	publishSigned(private)
+
+	key, err := service.Fetch(context.Background(), teamURL, dest)
+	require.NoError(t, err)
+	assert.Equal(t, "team", key.Name)
+
+	publishSigned(other)
+
+	_, err = service.Fetch(context.Background(), teamURL, dest)
+	require.ErrorIs(t, err, domain.ErrUntrusted)
+
+	// Only a minisign signature, which doesn't verify
+	network.On("DownloadFile", mock.Anything, teamURL, dest).Return(nil).Once()
+	network.On("DownloadFile", mock.Anything, teamURL+".minisig", dest+".minisig").Return(nil).Once()
+	network.On("DownloadFile", mock.Anything, teamURL+".sig", mock.Anything).
+		Return(errors.New("download failed with status 404")).Once()
+
+	_, err = service.Fetch(context.Background(), teamURL, dest)
+	require.ErrorIs(t, err, domain.ErrUntrusted)
+
+	network.On("DownloadFile", mock.Anything, teamURL, dest).Return(nil).Once()
+	network.On("DownloadFile", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("download failed with status 404")).Twice()
+
+	_, err = service.Fetch(context.Background(), teamURL, dest)
+	require.ErrorIs(t, err, domain.ErrUnsigned)
+
+	network.AssertExpectations(t)
+}
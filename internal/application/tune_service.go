@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// swapsFile lists the active swap devices.
+const swapsFile = "/proc/swaps"
+
+// ErrNotTuned indicates there is no tuning to roll back.
+var ErrNotTuned = errors.New("system not tuned by karei")
+
+// TuneService applies the developer tuning of karei system tune and rolls it
+// back, writing the system files through sudo.
+type TuneService struct {
+	fileManager   domain.FileManager
+	commandRunner domain.CommandRunner
+	recordPath    string
+}
+
+// NewTuneService creates a service keeping what rollback needs at recordPath.
+func NewTuneService(fm domain.FileManager, cr domain.CommandRunner, recordPath string) *TuneService {
+	return &TuneService{fileManager: fm, commandRunner: cr, recordPath: recordPath}
+}
+
+// Plan returns the current value of each setting tuning changes, next to the
+// value it gets.
+func (s *TuneService) Plan(ctx context.Context, tuning domain.Tuning) []domain.TuneChange {
+	changes := make([]domain.TuneChange, 0, len(tuning.Sysctl)+2)
+
+	if tuning.Zram {
+		changes = append(changes, domain.TuneChange{
+			Name:   "zram swap",
+			Before: onOff(s.zramActive()),
+			After:  "on",
+			Note:   "half of RAM, at most 8 GiB, zstd",
+		})
+	}
+
+	for _, setting := range tuning.Sysctl {
+		changes = append(changes, domain.TuneChange{
+			Name:   setting.Key,
+			Before: valueOrUnknown(s.sysctl(ctx, setting.Key)),
+			After:  setting.Value,
+			Note:   setting.Reason,
+		})
+	}
+
+	soft, _ := s.commandRunner.ExecuteWithOutput(ctx, "systemctl", "show", "--property=DefaultLimitNOFILESoft", "--value")
+
+	return append(changes, domain.TuneChange{
+		Name:   "open files soft limit",
+		Before: valueOrUnknown(soft),
+		After:  domain.OpenFilesSoftLimit,
+		Note:   "applies from the next login",
+	})
+}
+
+// Apply records the current state of what no earlier tuning changed,
+// writes the tuning files and loads them.
+func (s *TuneService) Apply(ctx context.Context, tuning domain.Tuning) error {
+	if err := s.record(ctx, tuning); err != nil {
+		return err
+	}
+
+	for _, file := range tuning.Files {
+		if err := s.install(ctx, file.Path, file.Content); err != nil {
+			return err
+		}
+	}
+
+	if err := s.commandRunner.ExecuteSudo(ctx, "sysctl", "-p", domain.SysctlTuneFile); err != nil {
+		return fmt.Errorf("failed to load %s: %w", domain.SysctlTuneFile, err)
+	}
+
+	if err := s.commandRunner.ExecuteSudo(ctx, "systemctl", "daemon-reload"); err != nil {
+		return fmt.Errorf("failed to reload systemd: %w", err)
+	}
+
+	if !tuning.Zram {
+		return nil
+	}
+
+	if err := s.commandRunner.ExecuteSudo(ctx, "systemctl", "restart", domain.ZramService); err != nil {
+		return fmt.Errorf("failed to start zram swap: %w", err)
+	}
+
+	return nil
+}
+
+// Rollback puts back the files and kernel parameters recorded before karei
+// first changed them, and forgets the record.
+func (s *TuneService) Rollback(ctx context.Context) (*domain.TuneRecord, error) {
+	record, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, backup := range record.Files {
+		if backup.Existed {
+			err = s.install(ctx, backup.Path, backup.Content)
+		} else if err = s.commandRunner.ExecuteSudo(ctx, "rm", "-f", backup.Path); err != nil {
+			err = fmt.Errorf("failed to remove %s: %w", backup.Path, err)
+		}
+
+		if err != nil {
+			return record, err
+		}
+	}
+
+	for key, value := range record.Sysctl {
+		if err := s.commandRunner.ExecuteSudo(ctx, "sysctl", "-w", key+"="+value); err != nil {
+			return record, fmt.Errorf("failed to restore %s: %w", key, err)
+		}
+	}
+
+	if err := s.commandRunner.ExecuteSudo(ctx, "systemctl", "daemon-reload"); err != nil {
+		return record, fmt.Errorf("failed to reload systemd: %w", err)
+	}
+
+	if !record.Zram && s.zramActive() {
+		if err := s.commandRunner.ExecuteSudo(ctx, "systemctl", "stop", domain.ZramService); err != nil {
+			return record, fmt.Errorf("failed to stop zram swap: %w", err)
+		}
+	}
+
+	if err := s.fileManager.RemoveFile(s.recordPath); err != nil {
+		return record, fmt.Errorf("failed to remove tuning record: %w", err)
+	}
+
+	return record, nil
+}
+
+// record saves the files and kernel parameters tuning is about to change.
+// Those an earlier tuning recorded keep their state from before it, so a
+// later tuning with more settings, such as zram, adds only the new ones.
+func (s *TuneService) record(ctx context.Context, tuning domain.Tuning) error {
+	record, err := s.load()
+
+	switch {
+	case errors.Is(err, ErrNotTuned):
+		record = &domain.TuneRecord{Applied: time.Now(), Zram: s.zramActive()}
+	case err != nil:
+		return err
+	}
+
+	if record.Sysctl == nil {
+		record.Sysctl = make(map[string]string)
+	}
+
+	for _, file := range tuning.Files {
+		if slices.ContainsFunc(record.Files, func(backup domain.TuneBackup) bool { return backup.Path == file.Path }) {
+			continue
+		}
+
+		backup := domain.TuneBackup{Path: file.Path, Existed: s.fileManager.FileExists(file.Path)}
+
+		if backup.Existed {
+			content, err := s.fileManager.ReadFile(file.Path)
+			if err != nil {
+				return fmt.Errorf("failed to back up %s: %w", file.Path, err)
+			}
+
+			backup.Content = string(content)
+		}
+
+		record.Files = append(record.Files, backup)
+	}
+
+	for _, setting := range tuning.Sysctl {
+		if _, recorded := record.Sysctl[setting.Key]; recorded {
+			continue
+		}
+
+		if value := s.sysctl(ctx, setting.Key); value != "" {
+			record.Sysctl[setting.Key] = value
+		}
+	}
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode tuning record: %w", err)
+	}
+
+	if err := s.fileManager.EnsureDir(filepath.Dir(s.recordPath)); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.recordPath), err)
+	}
+
+	if err := s.fileManager.WriteFile(s.recordPath, data); err != nil {
+		return fmt.Errorf("failed to write tuning record: %w", err)
+	}
+
+	return nil
+}
+
+// load reads the tuning record.
+func (s *TuneService) load() (*domain.TuneRecord, error) {
+	if !s.fileManager.FileExists(s.recordPath) {
+		return nil, ErrNotTuned
+	}
+
+	data, err := s.fileManager.ReadFile(s.recordPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tuning record: %w", err)
+	}
+
+	var record domain.TuneRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse tuning record: %w", err)
+	}
+
+	return &record, nil
+}
+
+// install writes content to the system file path, creating its directory,
+// through a staged copy since only root can write there. The copy is staged
+// in a private directory, so no other user can swap in their own.
+func (s *TuneService) install(ctx context.Context, path, content string) error {
+	stageDir, err := os.MkdirTemp("", "karei-tune-")
+	if err != nil {
+		return fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+
+	defer func() { _ = os.RemoveAll(stageDir) }()
+
+	staged := filepath.Join(stageDir, filepath.Base(path))
+	if err := s.fileManager.WriteFile(staged, []byte(content)); err != nil {
+		return fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+
+	if err := s.commandRunner.ExecuteSudo(ctx, "install", "-D", "-m", "644", staged, path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// sysctl returns the current value of a kernel parameter, or "" when it
+// can't be read.
+func (s *TuneService) sysctl(ctx context.Context, key string) string {
+	value, err := s.commandRunner.ExecuteWithOutput(ctx, "sysctl", "-n", key)
+	if err != nil {
+		return ""
+	}
+
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// zramActive reports whether swap goes to a zram device now.
+func (s *TuneService) zramActive() bool {
+	swaps, err := s.fileManager.ReadFile(swapsFile)
+
+	return err == nil && domain.HasZramSwap(string(swaps))
+}
+
+// onOff describes a switch.
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+
+	return "off"
+}
+
+// valueOrUnknown returns the trimmed value, or "unknown" when it is empty.
+func valueOrUnknown(value string) string {
+	if value = strings.TrimSpace(value); value == "" {
+		return "unknown"
+	}
+
+	return value
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTuneService_ApplyAndRollback(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "system.conf")
+	missing := filepath.Join(dir, "sysctl.conf")
+	recordPath := filepath.Join(dir, "state", "tune.json")
+
+	require.NoError(t, os.WriteFile(existing, []byte("mine\n"), 0o644))
+
+	tuning := domain.Tuning{
+		Sysctl: []domain.SysctlSetting{{Key: "vm.swappiness", Value: "10"}},
+		Files:  []domain.TuneFile{{Path: existing, Content: "karei\n"}, {Path: missing, Content: "vm.swappiness = 10\n"}},
+	}
+
+	// Installed content by destination, read from the staged file
+	installed := make(map[string]string)
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("ExecuteWithOutput", mock.Anything, "sysctl", "-n", "vm.swappiness").Return("60\n", nil).Once()
+	runner.On("ExecuteWithOutput", mock.Anything, "sysctl", "-n", "vm.swappiness").Return("10\n", nil)
+	runner.On("ExecuteSudo", mock.Anything, "install", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		installArgs := args.Get(2).([]string)
+		content, err := os.ReadFile(installArgs[len(installArgs)-2])
+		require.NoError(t, err)
+
+		installed[installArgs[len(installArgs)-1]] = string(content)
+	})
+	runner.On("ExecuteSudo", mock.Anything, "sysctl", []string{"-p", domain.SysctlTuneFile}).Return(nil)
+	runner.On("ExecuteSudo", mock.Anything, "systemctl", []string{"daemon-reload"}).Return(nil)
+
+	service := application.NewTuneService(platform.NewFileManager(false), runner, recordPath)
+
+	require.NoError(t, service.Apply(context.Background(), tuning))
+	assert.Equal(t, map[string]string{existing: "karei\n", missing: "vm.swappiness = 10\n"}, installed)
+
+	// Tuning again keeps the state from before the first tuning
+	require.NoError(t, service.Apply(context.Background(), tuning))
+
+	runner.On("ExecuteSudo", mock.Anything, "rm", []string{"-f", missing}).Return(nil).Once()
+	runner.On("ExecuteSudo", mock.Anything, "sysctl", []string{"-w", "vm.swappiness=60"}).Return(nil).Once()
+
+	record, err := service.Rollback(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, record.Files, 2)
+	assert.Equal(t, "mine\n", installed[existing])
+	assert.NoFileExists(t, recordPath)
+
+	_, err = service.Rollback(context.Background())
+	require.ErrorIs(t, err, application.ErrNotTuned)
+
+	runner.AssertExpectations(t)
+}
+
+func TestTuneService_RollbackAfterTuningTwice(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	sysctlFile := filepath.Join(dir, "sysctl.conf")
+	zramFile := filepath.Join(dir, "zram-generator.conf")
+	recordPath := filepath.Join(dir, "state", "tune.json")
+
+	withoutZram := domain.Tuning{
+		Sysctl: []domain.SysctlSetting{{Key: "vm.swappiness", Value: "10"}},
+		Files:  []domain.TuneFile{{Path: sysctlFile, Content: "vm.swappiness = 10\n"}},
+	}
+	withZram := domain.Tuning{
+		Zram: true,
+		Sysctl: []domain.SysctlSetting{
+			{Key: "vm.swappiness", Value: "180"},
+			{Key: "vm.page-cluster", Value: "0"},
+		},
+		Files: []domain.TuneFile{
+			{Path: sysctlFile, Content: "vm.swappiness = 180\nvm.page-cluster = 0\n"},
+			{Path: zramFile, Content: "[zram0]\n"},
+		},
+	}
+
+	runner := &testutil.MockCommandRunner{}
+	runner.On("ExecuteWithOutput", mock.Anything, "sysctl", "-n", "vm.swappiness").Return("60\n", nil).Once()
+	runner.On("ExecuteWithOutput", mock.Anything, "sysctl", "-n", "vm.page-cluster").Return("3\n", nil).Once()
+	runner.On("ExecuteSudo", mock.Anything, "install", mock.Anything).Return(nil)
+	runner.On("ExecuteSudo", mock.Anything, "sysctl", []string{"-p", domain.SysctlTuneFile}).Return(nil)
+	runner.On("ExecuteSudo", mock.Anything, "systemctl", []string{"daemon-reload"}).Return(nil)
+	runner.On("ExecuteSudo", mock.Anything, "systemctl", []string{"restart", domain.ZramService}).Return(nil)
+	runner.On("ExecuteSudo", mock.Anything, "systemctl", []string{"stop", domain.ZramService}).Return(nil).Maybe()
+
+	service := application.NewTuneService(platform.NewFileManager(false), runner, recordPath)
+
+	require.NoError(t, service.Apply(context.Background(), withoutZram))
+	require.NoError(t, service.Apply(context.Background(), withZram))
+
+	// Both files were missing, and both parameters are put back as they were before the first tuning
+	runner.On("ExecuteSudo", mock.Anything, "rm", []string{"-f", sysctlFile}).Return(nil).Once()
+	runner.On("ExecuteSudo", mock.Anything, "rm", []string{"-f", zramFile}).Return(nil).Once()
+	runner.On("ExecuteSudo", mock.Anything, "sysctl", []string{"-w", "vm.swappiness=60"}).Return(nil).Once()
+	runner.On("ExecuteSudo", mock.Anything, "sysctl", []string{"-w", "vm.page-cluster=3"}).Return(nil).Once()
+
+	record, err := service.Rollback(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, record.Files, 2)
+	assert.Equal(t, map[string]string{"vm.swappiness": "60", "vm.page-cluster": "3"}, record.Sysctl)
+
+	runner.AssertExpectations(t)
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/janderssonse/karei/internal/apps"
 	"github.com/janderssonse/karei/internal/domain"
@@ -25,6 +26,12 @@ type UninstallService struct {
 	fileManager   domain.FileManager
 	commandRunner domain.CommandRunner
 	installer     domain.PackageInstaller
+	events        domain.EventPublisher
+	trash         domain.Trash
+	records       *InstallRecords
+	manifests     *Manifests
+	preference    domain.MethodPreference
+	home          string
 	verbose       bool
 }
 
@@ -34,10 +41,54 @@ func NewUninstallService(fm domain.FileManager, cr domain.CommandRunner, pi doma
 		fileManager:   fm,
 		commandRunner: cr,
 		installer:     pi,
+		events:        domain.NoopEventPublisher{},
+		home:          homeDir,
 		verbose:       verbose,
 	}
 }
 
+// SetMethodPreference sets the installation methods favored when the apps were installed,
+// so removal uses the same method.
+func (s *UninstallService) SetMethodPreference(preference domain.MethodPreference) {
+	s.preference = preference
+}
+
+// SetLockTimeout sets how long removals wait for another process to release
+// the package manager lock.
+func (s *UninstallService) SetLockTimeout(timeout time.Duration) {
+	if waiter, ok := s.installer.(domain.LockWaiter); ok {
+		waiter.SetLockTimeout(timeout)
+	}
+}
+
+// SetHomeDir sets the home directory leftover configuration is purged from.
+func (s *UninstallService) SetHomeDir(home string) {
+	s.home = home
+}
+
+// SetTrash sets where purged files in the home directory are moved instead
+// of being deleted, so an accidental purge can be undone.
+func (s *UninstallService) SetTrash(trash domain.Trash) {
+	s.trash = trash
+}
+
+// SetInstallRecords sets where karei recorded how apps were installed, so
+// they are removed with the same method and from the same scope.
+func (s *UninstallService) SetInstallRecords(records *InstallRecords) {
+	s.records = records
+}
+
+// SetManifests sets where the files karei created for each app are listed,
+// so they are removed with it.
+func (s *UninstallService) SetManifests(manifests *Manifests) {
+	s.manifests = manifests
+}
+
+// SetEventPublisher sets the publisher notified about removed packages.
+func (s *UninstallService) SetEventPublisher(publisher domain.EventPublisher) {
+	s.events = publisher
+}
+
 var (
 	// ErrUnknownApp is returned when an unknown app is requested for uninstallation.
 	ErrUnknownApp = errors.New("unknown app")
@@ -47,6 +98,8 @@ var (
 	ErrUnknownGroup = errors.New("unknown group")
 )
 
+var _ domain.AppUninstaller = (*UninstallService)(nil)
+
 // UninstallApp uninstalls an application by name.
 func (s *UninstallService) UninstallApp(ctx context.Context, name string) error {
 	app, exists := apps.Apps[name]
@@ -60,14 +113,25 @@ func (s *UninstallService) UninstallApp(ctx context.Context, name string) error
 
 	// Check for special uninstall logic first
 	if hasSpecialUninstall(name) {
-		return s.specialUninstall(ctx, name)
+		if err := s.specialUninstall(ctx, name); err != nil {
+			return err
+		}
+
+		return s.forget(name)
 	}
 
-	// Convert to domain Package for uninstallation
+	// Convert to domain Package for uninstallation, keyed by catalog name
+	method, source := app.Resolve(s.preference)
 	pkg := &domain.Package{
-		Name:   app.Name,
-		Method: app.Method,
-		Source: app.Source,
+		Name:   name,
+		Method: method,
+		Source: source,
+	}
+
+	if s.records != nil {
+		if record, ok := s.records.Get(name); ok {
+			pkg.Method, pkg.Source, pkg.Scope = record.Method, record.Source, record.Scope
+		}
 	}
 
 	// Use the PackageInstaller port for uninstallation
@@ -80,8 +144,133 @@ func (s *UninstallService) UninstallApp(ctx context.Context, name string) error
 		return fmt.Errorf("uninstallation failed for %s", name)
 	}
 
+	if err := s.forget(name); err != nil {
+		return err
+	}
+
 	// Clean up any remaining files
-	return s.cleanupAppFiles(ctx, name)
+	if err := s.cleanupAppFiles(ctx, name); err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, domain.NewEvent(domain.EventPackageRemoved, name))
+
+	return nil
+}
+
+// forget removes what karei created for a removed app besides its package,
+// then its manifest and install record. Both are kept when the files can't
+// be removed, so a later uninstall tries again.
+func (s *UninstallService) forget(name string) error {
+	if s.manifests != nil {
+		if manifest, err := s.manifests.Load(name); err == nil && len(manifest.Artifacts) > 0 {
+			if err := s.removeArtifacts(name, manifest.Artifacts); err != nil {
+				return fmt.Errorf("failed to remove files created for %s: %w", name, err)
+			}
+		}
+
+		_ = s.manifests.Remove(name)
+	}
+
+	if s.records != nil {
+		_ = s.records.Forget(name)
+	}
+
+	return nil
+}
+
+// removeArtifacts removes the files and shell lines karei created for an
+// app. Files in the home directory go to the trash first, so they are put
+// back when the shell lines can't be removed; lines other installed apps
+// still need are kept.
+func (s *UninstallService) removeArtifacts(appName string, artifacts []domain.Artifact) error {
+	var trashed, removed []string
+
+	shellLines := make(map[string][]string)
+
+	for _, artifact := range artifacts {
+		switch {
+		case !artifact.IsFile():
+			if !s.neededByOthers(appName, artifact) {
+				shellLines[artifact.Path] = append(shellLines[artifact.Path], artifact.Line)
+			}
+		case !s.fileManager.FileExists(artifact.Path):
+		case s.trash != nil && strings.HasPrefix(artifact.Path, filepath.Clean(s.home)+"/"):
+			trashed = append(trashed, artifact.Path)
+		default:
+			removed = append(removed, artifact.Path)
+		}
+	}
+
+	var entry *domain.TrashEntry
+
+	if len(trashed) > 0 {
+		var err error
+
+		entry, err = s.trash.Move(appName, trashed)
+		if err != nil {
+			return s.rollback(entry, err)
+		}
+	}
+
+	for path, lines := range shellLines {
+		if err := RemoveShellLines(s.fileManager, path, lines); err != nil {
+			return s.rollback(entry, fmt.Errorf("failed to update %s: %w", path, err))
+		}
+	}
+
+	if entry != nil && s.verbose {
+		fmt.Printf("Moved %d files to the trash, restore with: karei trash restore %s\n", len(entry.Items), entry.ID)
+	}
+
+	// Files outside the home directory can't be put back, so they go last
+	var errs []error
+
+	for _, path := range removed {
+		if err := s.fileManager.RemoveFile(path); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove %s: %w", path, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// rollback puts back what was moved to the trash before err.
+func (s *UninstallService) rollback(entry *domain.TrashEntry, err error) error {
+	if entry == nil {
+		return err
+	}
+
+	if _, restoreErr := s.trash.Restore(entry.ID); restoreErr != nil {
+		return errors.Join(err, restoreErr)
+	}
+
+	return err
+}
+
+// neededByOthers reports whether the manifest of another installed app
+// lists artifact too, as when two apps need the same shell line.
+func (s *UninstallService) neededByOthers(appName string, artifact domain.Artifact) bool {
+	if s.records == nil {
+		return false
+	}
+
+	records, err := s.records.Load()
+	if err != nil {
+		return true // Keep what might still be needed
+	}
+
+	for other := range records {
+		if other == appName {
+			continue
+		}
+
+		if manifest, err := s.manifests.Load(other); err == nil && manifest.Contains(artifact) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // UninstallGroup uninstalls all applications in a group.
@@ -132,7 +321,7 @@ func (s *UninstallService) IsAppInstalled(ctx context.Context, appName string) (
 
 func (s *UninstallService) cleanupAppFiles(_ context.Context, appName string) error {
 	// Common cleanup paths
-	home := homeDir
+	home := s.home
 	cleanupPaths := []string{
 		filepath.Join(home, ".config", appName),
 		filepath.Join(home, ".local", "share", appName),
@@ -141,19 +330,52 @@ func (s *UninstallService) cleanupAppFiles(_ context.Context, appName string) er
 		filepath.Join("/usr/local/bin", appName),
 	}
 
-	for _, path := range cleanupPaths {
-		if s.fileManager.FileExists(path) {
-			if err := s.fileManager.RemoveFile(path); err != nil {
-				if s.verbose {
-					fmt.Printf("Warning: Could not remove %s: %v\n", path, err)
-				}
-			}
-		}
+	if err := s.purge(appName, cleanupPaths); err != nil && s.verbose {
+		fmt.Printf("Warning: %v\n", err)
 	}
 
 	return nil
 }
 
+// purge removes leftover files of an app. Files in the home directory go to
+// the trash when one is set; the others are deleted.
+func (s *UninstallService) purge(appName string, paths []string) error {
+	var trashed, removed []string
+
+	for _, path := range paths {
+		if !s.fileManager.FileExists(path) {
+			continue
+		}
+
+		if s.trash != nil && strings.HasPrefix(path, filepath.Clean(s.home)+"/") {
+			trashed = append(trashed, path)
+		} else {
+			removed = append(removed, path)
+		}
+	}
+
+	var errs []error
+
+	if len(trashed) > 0 {
+		entry, err := s.trash.Move(appName, trashed)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		if entry != nil && s.verbose {
+			fmt.Printf("Moved %d files to the trash, restore with: karei trash restore %s\n", len(entry.Items), entry.ID)
+		}
+	}
+
+	for _, path := range removed {
+		if err := s.fileManager.RemoveFile(path); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove %s: %w", path, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // hasSpecialUninstall checks if an app requires special uninstallation.
 func hasSpecialUninstall(appName string) bool {
 	specialApps := []string{
@@ -199,24 +421,14 @@ func (s *UninstallService) uninstallChrome(ctx context.Context) error {
 	}
 
 	// Clean up Chrome directories
-	home := homeDir
+	home := s.home
 	chromeDirs := []string{
 		filepath.Join(home, ".config", "google-chrome"),
 		filepath.Join(home, ".cache", "google-chrome"),
 	}
 
-	var cleanupErrors []error
-
-	for _, dir := range chromeDirs {
-		if s.fileManager.FileExists(dir) {
-			if err := s.fileManager.RemoveFile(dir); err != nil {
-				cleanupErrors = append(cleanupErrors, fmt.Errorf("failed to remove %s: %w", dir, err))
-			}
-		}
-	}
-
-	if len(cleanupErrors) > 0 {
-		return fmt.Errorf("chrome uninstall completed with cleanup errors: %v", cleanupErrors)
+	if err := s.purge("chrome", chromeDirs); err != nil {
+		return fmt.Errorf("chrome uninstall completed with cleanup errors: %w", err)
 	}
 
 	return nil
@@ -273,17 +485,13 @@ func (s *UninstallService) uninstallVSCode(ctx context.Context) error {
 	}
 
 	// Clean up VSCode directories
-	home := homeDir
+	home := s.home
 	vscodeDirs := []string{
 		filepath.Join(home, ".config", "Code"),
 		filepath.Join(home, ".vscode"),
 	}
 
-	for _, dir := range vscodeDirs {
-		if s.fileManager.FileExists(dir) {
-			_ = s.fileManager.RemoveFile(dir)
-		}
-	}
+	_ = s.purge("vscode", vscodeDirs)
 
 	return nil
 }
@@ -308,7 +516,7 @@ func (s *UninstallService) uninstallPostman(ctx context.Context) error {
 
 func (s *UninstallService) uninstallObsidian(_ context.Context) error {
 	// Remove Obsidian AppImage
-	home := homeDir
+	home := s.home
 	obsidianPaths := []string{
 		filepath.Join(home, ".local", "bin", "Obsidian.AppImage"),
 		filepath.Join(home, "Applications", "Obsidian.AppImage"),
@@ -322,10 +530,7 @@ func (s *UninstallService) uninstallObsidian(_ context.Context) error {
 	}
 
 	// Remove config
-	configPath := filepath.Join(home, ".config", "obsidian")
-	if s.fileManager.FileExists(configPath) {
-		_ = s.fileManager.RemoveFile(configPath)
-	}
+	_ = s.purge("obsidian", []string{filepath.Join(home, ".config", "obsidian")})
 
 	return nil
 }
@@ -348,17 +553,13 @@ func (s *UninstallService) uninstallDiscord(ctx context.Context) error {
 	_ = s.commandRunner.Execute(ctx, "snap", "remove", "discord")
 
 	// Clean up Discord directories
-	home := homeDir
+	home := s.home
 	discordDirs := []string{
 		filepath.Join(home, ".config", "discord"),
 		filepath.Join(home, ".cache", "discord"),
 	}
 
-	for _, dir := range discordDirs {
-		if s.fileManager.FileExists(dir) {
-			_ = s.fileManager.RemoveFile(dir)
-		}
-	}
+	_ = s.purge("discord", discordDirs)
 
 	return nil
 }
@@ -378,12 +579,9 @@ func (s *UninstallService) uninstallTeams(ctx context.Context) error {
 	_ = s.commandRunner.ExecuteSudo(ctx, "apt", "remove", "--purge", "-y", "teams")
 
 	// Clean up Teams directories
-	home := homeDir
+	home := s.home
 
-	teamsDir := filepath.Join(home, ".config", "Microsoft", "Microsoft Teams")
-	if s.fileManager.FileExists(teamsDir) {
-		_ = s.fileManager.RemoveFile(teamsDir)
-	}
+	_ = s.purge("teams", []string{filepath.Join(home, ".config", "Microsoft", "Microsoft Teams")})
 
 	return nil
 }
@@ -393,22 +591,19 @@ func (s *UninstallService) uninstallZoom(ctx context.Context) error {
 	_ = s.commandRunner.ExecuteSudo(ctx, "apt", "remove", "--purge", "-y", "zoom")
 
 	// Clean up Zoom directories
-	home := homeDir
+	home := s.home
 	zoomDirs := []string{
 		filepath.Join(home, ".zoom"),
 		filepath.Join(home, ".config", "zoomus.conf"),
 	}
 
-	for _, dir := range zoomDirs {
-		if s.fileManager.FileExists(dir) {
-			_ = s.fileManager.RemoveFile(dir)
-		}
-	}
+	_ = s.purge("zoom", zoomDirs)
 
 	return nil
 }
 
-// UninstallPackages uninstalls multiple packages.
+// UninstallPackages uninstalls multiple packages, publishing an operation
+// event as each starts and ends.
 func (s *UninstallService) UninstallPackages(ctx context.Context, packages []string) (*domain.UninstallResult, error) {
 	result := &domain.UninstallResult{}
 
@@ -418,7 +613,18 @@ func (s *UninstallService) UninstallPackages(ctx context.Context, packages []str
 			continue
 		}
 
-		if err := s.UninstallApp(ctx, pkg); err != nil {
+		s.events.Publish(ctx, operationEvent(ProgressUpdate{Operation: OperationUninstall, App: pkg, Stage: StageStarted}, false))
+
+		err := s.UninstallApp(ctx, pkg)
+
+		stage := StageCompleted
+		if err != nil {
+			stage = StageFailed
+		}
+
+		s.events.Publish(ctx, operationEvent(ProgressUpdate{Operation: OperationUninstall, App: pkg, Stage: stage, Err: err}, false))
+
+		if err != nil {
 			result.Failed = append(result.Failed, pkg)
 			if errors.Is(err, ErrUnknownApp) {
 				result.NotFound = append(result.NotFound, pkg)
@@ -5,10 +5,13 @@ package application_test
 
 import (
 	"context"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/janderssonse/karei/internal/adapters/platform"
 	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
 	"github.com/janderssonse/karei/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -148,3 +151,144 @@ func TestUninstallService_UninstallGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestUninstallService_UsesCatalogKeyAndPublishesEvent(t *testing.T) {
+	t.Parallel()
+
+	mockFM := new(testutil.MockFileManager)
+	mockCR := new(testutil.MockCommandRunner)
+	mockPI := new(testutil.MockPackageInstaller)
+
+	// Adapters receive the catalog key so they can map it to method-specific names
+	mockPI.On("Remove", mock.Anything, mock.MatchedBy(func(pkg *domain.Package) bool {
+		return pkg.Name == "rust" && pkg.Method == domain.MethodMise
+	})).Return(&domain.InstallationResult{Success: true}, nil).Once()
+	mockFM.On("FileExists", mock.Anything).Return(false)
+
+	service := application.NewUninstallService(mockFM, mockCR, mockPI, false)
+
+	bus := domain.NewEventBus()
+	service.SetEventPublisher(bus)
+
+	var removed []string
+
+	bus.Subscribe(domain.EventPackageRemoved, func(_ context.Context, e domain.Event) {
+		removed = append(removed, e.Subject)
+	})
+
+	var uninstaller domain.AppUninstaller = service
+
+	require.NoError(t, uninstaller.UninstallApp(context.Background(), "rust"))
+	assert.Equal(t, []string{"rust"}, removed)
+	mockPI.AssertExpectations(t)
+
+	var failed []domain.Event
+
+	bus.Subscribe(domain.EventOperationFailed, func(_ context.Context, e domain.Event) {
+		failed = append(failed, e)
+	})
+
+	_, err := service.UninstallPackages(context.Background(), []string{"no-such-app"})
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	assert.Equal(t, "no-such-app", failed[0].Subject)
+	assert.Equal(t, application.OperationUninstall, failed[0].Data["operation"])
+}
+
+// recordingTrash is a Trash remembering what it was asked to move.
+type recordingTrash struct {
+	domain.Trash
+
+	moved map[string][]string
+}
+
+func (r *recordingTrash) Move(app string, paths []string) (*domain.TrashEntry, error) {
+	r.moved[app] = paths
+
+	return &domain.TrashEntry{ID: "20250101-120000", App: app}, nil
+}
+
+func TestUninstallService_PurgeMovesHomeFilesToTrash(t *testing.T) {
+	t.Parallel()
+
+	mockFM := new(testutil.MockFileManager)
+	mockCR := new(testutil.MockCommandRunner)
+	mockPI := new(testutil.MockPackageInstaller)
+
+	mockPI.On("Remove", mock.Anything, mock.Anything).Return(&domain.InstallationResult{Success: true}, nil)
+	mockFM.On("FileExists", "/home/alice/.config/neovim").Return(true)
+	mockFM.On("FileExists", "/usr/local/bin/neovim").Return(true)
+	mockFM.On("FileExists", mock.Anything).Return(false)
+	mockFM.On("RemoveFile", "/usr/local/bin/neovim").Return(nil).Once()
+
+	trash := &recordingTrash{moved: map[string][]string{}}
+
+	service := application.NewUninstallService(mockFM, mockCR, mockPI, false)
+	service.SetHomeDir("/home/alice")
+	service.SetTrash(trash)
+
+	require.NoError(t, service.UninstallApp(context.Background(), "neovim"))
+	assert.Equal(t, map[string][]string{"neovim": {"/home/alice/.config/neovim"}}, trash.moved)
+	mockFM.AssertExpectations(t)
+}
+
+func TestUninstallService_RemovesRecordedArtifacts(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	fileManager := platform.NewFileManager(false)
+	bashrc := filepath.Join(home, ".bashrc")
+	desktopEntry := filepath.Join(home, ".local", "share", "applications", "nvim.desktop")
+	pathLine := `export PATH="$HOME/.local/bin:$PATH"`
+	miseLine := `eval "$(mise activate bash)"`
+
+	require.NoError(t, fileManager.WriteFile(desktopEntry, []byte("[Desktop Entry]\n")))
+	require.NoError(t, fileManager.WriteFile(bashrc, []byte("alias ll='ls -l'\n\n# >>> karei >>>\n"+pathLine+"\n"+miseLine+"\n# <<< karei <<<\n")))
+
+	records := application.NewInstallRecords(fileManager, filepath.Join(home, "installed.json"))
+	require.NoError(t, records.Record("neovim", application.InstallRecord{Method: domain.MethodAPT, Source: "neovim"}))
+	require.NoError(t, records.Record("lazygit", application.InstallRecord{Method: domain.MethodGitHubBinary}))
+
+	manifests := application.NewManifests(fileManager, filepath.Join(home, "manifests"))
+	require.NoError(t, manifests.Add("neovim",
+		domain.Artifact{Kind: domain.ArtifactDesktopEntry, Path: desktopEntry},
+		domain.Artifact{Kind: domain.ArtifactShellLine, Path: bashrc, Line: pathLine},
+		domain.Artifact{Kind: domain.ArtifactShellLine, Path: bashrc, Line: miseLine}))
+	require.NoError(t, manifests.Add("lazygit", domain.Artifact{Kind: domain.ArtifactShellLine, Path: bashrc, Line: pathLine}))
+
+	mockPI := new(testutil.MockPackageInstaller)
+	mockPI.On("Remove", mock.Anything, mock.Anything).Return(&domain.InstallationResult{Success: true}, nil)
+
+	trash := platform.NewTrash(filepath.Join(home, "trash"))
+
+	service := application.NewUninstallService(fileManager, new(testutil.MockCommandRunner), mockPI, false)
+	service.SetHomeDir(home)
+	service.SetTrash(trash)
+	service.SetInstallRecords(records)
+	service.SetManifests(manifests)
+
+	require.NoError(t, service.UninstallApp(context.Background(), "neovim"))
+
+	assert.False(t, fileManager.FileExists(desktopEntry))
+
+	entries, err := trash.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, desktopEntry, entries[0].Items[0].Original)
+
+	// lazygit still needs ~/.local/bin on PATH
+	data, err := fileManager.ReadFile(bashrc)
+	require.NoError(t, err)
+	assert.Equal(t, "alias ll='ls -l'\n\n# >>> karei >>>\n"+pathLine+"\n# <<< karei <<<\n", string(data))
+
+	_, recorded := records.Get("neovim")
+	assert.False(t, recorded)
+	assert.False(t, fileManager.FileExists(filepath.Join(home, "manifests", "neovim.json")))
+
+	// The last app needing the line takes it and the emptied block along
+	require.NoError(t, service.UninstallApp(context.Background(), "lazygit"))
+
+	data, err = fileManager.ReadFile(bashrc)
+	require.NoError(t, err)
+	assert.Equal(t, "alias ll='ls -l'\n", string(data))
+}
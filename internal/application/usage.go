@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/stringutil"
+)
+
+// FeatureUsage aggregates the runs of one command.
+type FeatureUsage struct {
+	Count    int           `json:"count"`
+	Failures int           `json:"failures"`
+	Total    time.Duration `json:"total"`
+}
+
+// Mean returns the average run time.
+func (f *FeatureUsage) Mean() time.Duration {
+	if f.Count == 0 {
+		return 0
+	}
+
+	return f.Total / time.Duration(f.Count)
+}
+
+// UsageStats counts which commands and flags are used. Only command paths
+// and flag names are kept, never arguments, flag values, app names or paths.
+type UsageStats struct {
+	Since    time.Time                `json:"since"`
+	Commands map[string]*FeatureUsage `json:"commands"`
+	Flags    map[string]int           `json:"flags"`
+
+	// Deprecated counts runs of old command forms, by old form, to tell when
+	// they can be removed.
+	Deprecated map[string]int `json:"deprecated,omitempty"`
+}
+
+// UsageRecorder keeps opt-in usage statistics in a local file.
+type UsageRecorder struct {
+	files domain.FileManager
+	path  string
+}
+
+// NewUsageRecorder creates a recorder storing statistics at path.
+func NewUsageRecorder(files domain.FileManager, path string) *UsageRecorder {
+	return &UsageRecorder{files: files, path: path}
+}
+
+// Load returns the recorded statistics, empty when nothing was recorded.
+func (r *UsageRecorder) Load() (*UsageStats, error) {
+	stats := &UsageStats{
+		Commands:   make(map[string]*FeatureUsage),
+		Flags:      make(map[string]int),
+		Deprecated: make(map[string]int),
+	}
+
+	if !r.files.FileExists(r.path) {
+		return stats, nil
+	}
+
+	data, err := r.files.ReadFile(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage statistics: %w", err)
+	}
+
+	if err := json.Unmarshal(data, stats); err != nil {
+		return nil, fmt.Errorf("failed to parse usage statistics: %w", err)
+	}
+
+	return stats, nil
+}
+
+// Record adds one run of command with the named flags.
+func (r *UsageRecorder) Record(command string, flags []string, duration time.Duration, failed bool) error {
+	stats, err := r.Load()
+	if err != nil {
+		return err
+	}
+
+	if stats.Since.IsZero() {
+		stats.Since = time.Now()
+	}
+
+	usage := stats.Commands[command]
+	if usage == nil {
+		usage = &FeatureUsage{}
+		stats.Commands[command] = usage
+	}
+
+	usage.Count++
+	usage.Total += duration
+
+	if failed {
+		usage.Failures++
+	}
+
+	for _, flag := range flags {
+		stats.Flags[flag]++
+	}
+
+	return r.save(stats)
+}
+
+// RecordDeprecated adds one run of the old command form.
+func (r *UsageRecorder) RecordDeprecated(form string) error {
+	stats, err := r.Load()
+	if err != nil {
+		return err
+	}
+
+	if stats.Since.IsZero() {
+		stats.Since = time.Now()
+	}
+
+	stats.Deprecated[form]++
+
+	return r.save(stats)
+}
+
+func (r *UsageRecorder) save(stats *UsageStats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode usage statistics: %w", err)
+	}
+
+	if err := r.files.EnsureDir(filepath.Dir(r.path)); err != nil {
+		return fmt.Errorf("failed to create usage statistics directory: %w", err)
+	}
+
+	if err := r.files.WriteFile(r.path, data); err != nil {
+		return fmt.Errorf("failed to write usage statistics: %w", err)
+	}
+
+	return nil
+}
+
+// Clear deletes the recorded statistics.
+func (r *UsageRecorder) Clear() error {
+	if !r.files.FileExists(r.path) {
+		return nil
+	}
+
+	if err := r.files.RemoveFile(r.path); err != nil {
+		return fmt.Errorf("failed to delete usage statistics: %w", err)
+	}
+
+	return nil
+}
+
+// Report renders the statistics for attaching to a bug report: the karei
+// version, the day recording started and per-command counts, failures and
+// mean times rounded to 100ms, most used first.
+func (s *UsageStats) Report(version string) string {
+	lines := []string{"karei usage report", "version: " + version}
+
+	if !s.Since.IsZero() {
+		lines = append(lines, "since:   "+s.Since.UTC().Format(time.DateOnly))
+	}
+
+	lines = append(lines, "", fmt.Sprintf("%-24s %6s %8s %8s", "command", "runs", "failures", "mean"))
+
+	for _, name := range sortedByCount(s.Commands, func(f *FeatureUsage) int { return f.Count }) {
+		usage := s.Commands[name]
+		lines = append(lines, fmt.Sprintf("%-24s %6d %8d %8s", name, usage.Count, usage.Failures, stringutil.FormatDuration(usage.Mean().Round(100*time.Millisecond))))
+	}
+
+	if len(s.Flags) > 0 {
+		lines = append(lines, "", fmt.Sprintf("%-24s %6s", "flag", "uses"))
+
+		for _, flag := range sortedByCount(s.Flags, func(count int) int { return count }) {
+			lines = append(lines, fmt.Sprintf("--%-22s %6d", flag, s.Flags[flag]))
+		}
+	}
+
+	if len(s.Deprecated) > 0 {
+		lines = append(lines, "", fmt.Sprintf("%-24s %6s", "deprecated form", "uses"))
+
+		for _, form := range sortedByCount(s.Deprecated, func(count int) int { return count }) {
+			lines = append(lines, fmt.Sprintf("%-24s %6d", form, s.Deprecated[form]))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// sortedByCount returns the keys of m, highest count first and by name on ties.
+func sortedByCount[V any](m map[string]V, count func(V) int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(a, b string) int {
+		return cmp.Or(count(m[b])-count(m[a]), strings.Compare(a, b))
+	})
+
+	return keys
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageRecorder(t *testing.T) {
+	t.Parallel()
+
+	recorder := application.NewUsageRecorder(platform.NewFileManager(false), filepath.Join(t.TempDir(), "karei", "usage.json"))
+
+	stats, err := recorder.Load()
+	require.NoError(t, err)
+	assert.Empty(t, stats.Commands)
+
+	require.NoError(t, recorder.Record("install", []string{"json"}, 2*time.Second, false))
+	require.NoError(t, recorder.Record("install", nil, 4*time.Second, true))
+	require.NoError(t, recorder.Record("audit cve", []string{"json", "fix"}, time.Second, false))
+	require.NoError(t, recorder.RecordDeprecated("theme <name>"))
+
+	stats, err = recorder.Load()
+	require.NoError(t, err)
+	assert.Equal(t, &application.FeatureUsage{Count: 2, Failures: 1, Total: 6 * time.Second}, stats.Commands["install"])
+	assert.Equal(t, 3*time.Second, stats.Commands["install"].Mean())
+	assert.Equal(t, map[string]int{"json": 2, "fix": 1}, stats.Flags)
+
+	report := stats.Report("1.2.0")
+	assert.Contains(t, report, "version: 1.2.0")
+	assert.Regexp(t, `install\s+2\s+1\s+3 s`, report)
+	assert.Less(t, strings.Index(report, "install"), strings.Index(report, "audit cve"))
+	assert.Contains(t, report, "--json")
+	assert.Regexp(t, `theme <name>\s+1`, report)
+
+	require.NoError(t, recorder.Clear())
+
+	stats, err = recorder.Load()
+	require.NoError(t, err)
+	assert.Empty(t, stats.Commands)
+	require.NoError(t, recorder.Clear())
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// installUserTimer writes the units, a timer and the service it starts,
+// to the systemd user unit directory dir and starts the timer.
+func installUserTimer(ctx context.Context, fm domain.FileManager, cr domain.CommandRunner, dir, timer string, units map[string]string) error {
+	if err := fm.EnsureDir(dir); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	for name, content := range units {
+		path := filepath.Join(dir, name)
+		if err := fm.WriteFile(path, []byte(content)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	if err := cr.Execute(ctx, "systemctl", "--user", "daemon-reload"); err != nil {
+		return fmt.Errorf("failed to reload systemd user units: %w", err)
+	}
+
+	if err := cr.Execute(ctx, "systemctl", "--user", "enable", "--now", timer); err != nil {
+		return fmt.Errorf("failed to start %s: %w", timer, err)
+	}
+
+	return nil
+}
+
+// removeUserTimer stops timer and removes it with its other units from dir,
+// if installed.
+func removeUserTimer(ctx context.Context, fm domain.FileManager, cr domain.CommandRunner, dir, timer string, units ...string) error {
+	if dir == "" || !fm.FileExists(filepath.Join(dir, timer)) {
+		return nil
+	}
+
+	// The timer may be stopped already, or systemd not running
+	_ = cr.Execute(ctx, "systemctl", "--user", "disable", "--now", timer)
+
+	for _, name := range append([]string{timer}, units...) {
+		if path := filepath.Join(dir, name); fm.FileExists(path) {
+			if err := fm.RemoveFile(path); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+		}
+	}
+
+	return nil
+}
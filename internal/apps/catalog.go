@@ -5,34 +5,137 @@ package apps
 
 import (
 	"os/exec"
+	"slices"
 
 	"github.com/janderssonse/karei/internal/domain"
 )
 
 // App represents an application that can be installed.
 type App struct {
-	Name        string
-	Group       string
-	Description string
-	Method      domain.InstallMethod
-	Source      string
-	PostInstall func() error
+	Name         string
+	Group        string
+	Description  string
+	Method       domain.InstallMethod
+	Source       string
+	Aliases      []string       // Other names users search for
+	License      domain.License // LicenseProprietary for closed-source apps; empty is open source
+	Dependencies []string       // Catalog keys of apps this app needs
+	Conflicts    []string       // Catalog keys of apps that can't be installed alongside
+	Alternatives []Alternative  // Other ways to install the same app
+	Version      string         // Version constraint such as ">=20 <21"; empty accepts any
+	NeedsDKMS    bool           // Builds kernel modules with DKMS, needing the running kernel's headers
+	SHA256       string         // Checksum of the file Source downloads; empty skips the check
+	Arch         []string       // Architectures Source is built for, as Go names them; empty means all
+	PostInstall  func() error
+}
+
+// Alternative is another installation method offering the same application.
+type Alternative struct {
+	Method domain.InstallMethod
+	Source string
+}
+
+// Resolve returns the method and source to install the app with, picking the
+// first preferred method the app is offered through and its default otherwise.
+func (a App) Resolve(preference domain.MethodPreference) (domain.InstallMethod, string) {
+	for _, method := range preference {
+		if a.Method == method {
+			return a.Method, a.Source
+		}
+
+		for _, alt := range a.Alternatives {
+			if alt.Method == method {
+				return alt.Method, alt.Source
+			}
+		}
+	}
+
+	return a.Method, a.Source
+}
+
+// RequiresRoot reports whether installing the app with the preference needs sudo.
+func (a App) RequiresRoot(preference domain.MethodPreference) bool {
+	method, _ := a.Resolve(preference)
+
+	return method.RequiresRoot()
+}
+
+// ResolveUserScope is like Resolve but only returns methods that install
+// without sudo, falling back to a user-scope alternative when the preferred
+// method needs root. It reports false when the app has no such method.
+func (a App) ResolveUserScope(preference domain.MethodPreference) (domain.InstallMethod, string, bool) {
+	return a.ResolveAllowed(preference, func(method domain.InstallMethod) bool {
+		return !method.RequiresRoot()
+	})
+}
+
+// ResolveAllowed is like Resolve but only returns methods allowed accepts,
+// trying the default method and then the alternatives when the preferred one
+// is not accepted. It reports false when no method is.
+func (a App) ResolveAllowed(preference domain.MethodPreference, allowed func(domain.InstallMethod) bool) (domain.InstallMethod, string, bool) {
+	if method, source := a.Resolve(preference); allowed(method) {
+		return method, source, true
+	}
+
+	if allowed(a.Method) {
+		return a.Method, a.Source, true
+	}
+
+	for _, alt := range a.Alternatives {
+		if allowed(alt.Method) {
+			return alt.Method, alt.Source, true
+		}
+	}
+
+	return "", "", false
+}
+
+// Allowed reports whether the policy allows installing the app through any
+// of the methods it is offered with.
+func (a App) Allowed(policy domain.InstallPolicy) bool {
+	if !policy.AllowsLicense(a.License) {
+		return false
+	}
+
+	_, _, ok := a.ResolveAllowed(nil, policy.AllowsMethod)
+
+	return ok
+}
+
+// FromGitHub reports whether any of the methods the app is offered with
+// downloads GitHub releases, so a release channel applies to it.
+func (a App) FromGitHub() bool {
+	if a.Method.IsGitHub() {
+		return true
+	}
+
+	for _, alt := range a.Alternatives {
+		if alt.Method.IsGitHub() {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Apps contains the catalog of available applications.
 var Apps = map[string]App{ //nolint:gochecknoglobals
 	// Development Tools
 	"vscode": {
-		Name:        "Visual Studio Code",
-		Group:       "development",
-		Description: "Code editor",
-		Method:      domain.MethodDEB,
-		Source:      "https://code.visualstudio.com/sha/download?build=stable&os=linux-deb-x64",
+		Name:         "Visual Studio Code",
+		Group:        "development",
+		Description:  "Code editor",
+		License:      domain.LicenseProprietary,
+		Aliases:      []string{"code", "vs code"},
+		Method:       domain.MethodDEB,
+		Source:       "https://code.visualstudio.com/sha/download?build=stable&os=linux-deb-x64",
+		Alternatives: []Alternative{{Method: domain.MethodFlatpak, Source: "com.visualstudio.code"}},
 	},
 	"cursor": {
 		Name:        "Cursor",
 		Group:       "development",
 		Description: "AI-powered code editor",
+		License:     domain.LicenseProprietary,
 		Method:      domain.MethodDEB,
 		Source:      "https://download.cursor.sh/linux/appImage/x64",
 	},
@@ -47,6 +150,7 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Name:        "Windsurf",
 		Group:       "development",
 		Description: "AI development environment",
+		License:     domain.LicenseProprietary,
 		Method:      domain.MethodDEB,
 		Source:      "https://windsurf-stable.codeiumdata.com/wVxQEIWkwPUEAGf3/windsurf-linux-x64-1.0.6.deb",
 	},
@@ -54,6 +158,7 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Name:        "RubyMine",
 		Group:       "development",
 		Description: "Ruby IDE",
+		License:     domain.LicenseProprietary,
 		Method:      domain.MethodFlatpak,
 		Source:      "com.jetbrains.RubyMine",
 	},
@@ -73,88 +178,100 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Source:      "rust",
 	},
 	"cargo-audit": {
-		Name:        "cargo-audit",
-		Group:       "rustlang",
-		Description: "Security vulnerability scanner",
-		Method:      domain.MethodMise,
-		Source:      "cargo-audit",
+		Name:         "cargo-audit",
+		Group:        "rustlang",
+		Description:  "Security vulnerability scanner",
+		Method:       domain.MethodMise,
+		Source:       "cargo-audit",
+		Dependencies: []string{"rust"},
 	},
 	"cargo-watch": {
-		Name:        "cargo-watch",
-		Group:       "rustlang",
-		Description: "Auto-rebuild on file changes",
-		Method:      domain.MethodMise,
-		Source:      "cargo-watch",
+		Name:         "cargo-watch",
+		Group:        "rustlang",
+		Description:  "Auto-rebuild on file changes",
+		Method:       domain.MethodMise,
+		Source:       "cargo-watch",
+		Dependencies: []string{"rust"},
 	},
 	"cargo-edit": {
-		Name:        "cargo-edit",
-		Group:       "rustlang",
-		Description: "Add/remove dependencies from CLI",
-		Method:      domain.MethodMise,
-		Source:      "cargo-edit",
+		Name:         "cargo-edit",
+		Group:        "rustlang",
+		Description:  "Add/remove dependencies from CLI",
+		Method:       domain.MethodMise,
+		Source:       "cargo-edit",
+		Dependencies: []string{"rust"},
 	},
 	"cargo-expand": {
-		Name:        "cargo-expand",
-		Group:       "rustlang",
-		Description: "Show macro expansions",
-		Method:      domain.MethodMise,
-		Source:      "cargo-expand",
+		Name:         "cargo-expand",
+		Group:        "rustlang",
+		Description:  "Show macro expansions",
+		Method:       domain.MethodMise,
+		Source:       "cargo-expand",
+		Dependencies: []string{"rust"},
 	},
 	"cargo-tarpaulin": {
-		Name:        "cargo-tarpaulin",
-		Group:       "rustlang",
-		Description: "Code coverage tool",
-		Method:      domain.MethodMise,
-		Source:      "cargo-tarpaulin",
+		Name:         "cargo-tarpaulin",
+		Group:        "rustlang",
+		Description:  "Code coverage tool",
+		Method:       domain.MethodMise,
+		Source:       "cargo-tarpaulin",
+		Dependencies: []string{"rust"},
 	},
 	"cargo-nextest": {
-		Name:        "cargo-nextest",
-		Group:       "rustlang",
-		Description: "Next-generation test runner",
-		Method:      domain.MethodMise,
-		Source:      "cargo-nextest",
+		Name:         "cargo-nextest",
+		Group:        "rustlang",
+		Description:  "Next-generation test runner",
+		Method:       domain.MethodMise,
+		Source:       "cargo-nextest",
+		Dependencies: []string{"rust"},
 	},
 	"cargo-deny": {
-		Name:        "cargo-deny",
-		Group:       "rustlang",
-		Description: "Dependency policy checker",
-		Method:      domain.MethodMise,
-		Source:      "cargo-deny",
+		Name:         "cargo-deny",
+		Group:        "rustlang",
+		Description:  "Dependency policy checker",
+		Method:       domain.MethodMise,
+		Source:       "cargo-deny",
+		Dependencies: []string{"rust"},
 	},
 	"cargo-bloat": {
-		Name:        "cargo-bloat",
-		Group:       "rustlang",
-		Description: "Binary size analyzer",
-		Method:      domain.MethodMise,
-		Source:      "cargo-bloat",
+		Name:         "cargo-bloat",
+		Group:        "rustlang",
+		Description:  "Binary size analyzer",
+		Method:       domain.MethodMise,
+		Source:       "cargo-bloat",
+		Dependencies: []string{"rust"},
 	},
 	"cargo-outdated": {
-		Name:        "cargo-outdated",
-		Group:       "rustlang",
-		Description: "Check for outdated dependencies",
-		Method:      domain.MethodMise,
-		Source:      "cargo-outdated",
+		Name:         "cargo-outdated",
+		Group:        "rustlang",
+		Description:  "Check for outdated dependencies",
+		Method:       domain.MethodMise,
+		Source:       "cargo-outdated",
+		Dependencies: []string{"rust"},
 	},
 	"cargo-cross": {
-		Name:        "cargo-cross",
-		Group:       "rustlang",
-		Description: "Zero-setup cross compilation",
-		Method:      domain.MethodMise,
-		Source:      "cargo-cross",
+		Name:         "cargo-cross",
+		Group:        "rustlang",
+		Description:  "Zero-setup cross compilation",
+		Method:       domain.MethodMise,
+		Source:       "cargo-cross",
+		Dependencies: []string{"rust"},
 	},
 	"cargo-flamegraph": {
-		Name:        "cargo-flamegraph",
-		Group:       "rustlang",
-		Description: "Profiling flame graphs",
-		Method:      domain.MethodMise,
-		Source:      "cargo-flamegraph",
+		Name:         "cargo-flamegraph",
+		Group:        "rustlang",
+		Description:  "Profiling flame graphs",
+		Method:       domain.MethodMise,
+		Source:       "cargo-flamegraph",
+		Dependencies: []string{"rust"},
 	},
 	"cargo-geiger": {
-		Name:        "cargo-geiger",
-		Group:       "rustlang",
-		Description: "Detect unsafe code usage",
-		Method:      domain.MethodMise,
-		Source:      "cargo-geiger",
+		Name:         "cargo-geiger",
+		Group:        "rustlang",
+		Description:  "Detect unsafe code usage",
+		Method:       domain.MethodMise,
+		Source:       "cargo-geiger",
+		Dependencies: []string{"rust"},
 	},
 	// Python Development Tools
 	"python": {
@@ -165,60 +282,68 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Source:      "python",
 	},
 	"pipx": {
-		Name:        "pipx",
-		Group:       "pythonlang",
-		Description: "Install Python applications in isolated environments",
-		Method:      domain.MethodMise,
-		Source:      "pipx",
+		Name:         "pipx",
+		Group:        "pythonlang",
+		Description:  "Install Python applications in isolated environments",
+		Method:       domain.MethodMise,
+		Source:       "pipx",
+		Dependencies: []string{"python"},
 	},
 	"poetry": {
-		Name:        "poetry",
-		Group:       "pythonlang",
-		Description: "Modern dependency management and packaging",
-		Method:      domain.MethodMise,
-		Source:      "poetry",
+		Name:         "poetry",
+		Group:        "pythonlang",
+		Description:  "Modern dependency management and packaging",
+		Method:       domain.MethodMise,
+		Source:       "poetry",
+		Dependencies: []string{"python"},
 	},
 	"black": {
-		Name:        "black",
-		Group:       "pythonlang",
-		Description: "Uncompromising code formatter",
-		Method:      domain.MethodMise,
-		Source:      "black",
+		Name:         "black",
+		Group:        "pythonlang",
+		Description:  "Uncompromising code formatter",
+		Method:       domain.MethodMise,
+		Source:       "black",
+		Dependencies: []string{"python"},
 	},
 	"flake8": {
-		Name:        "flake8",
-		Group:       "pythonlang",
-		Description: "Style guide enforcement and linting",
-		Method:      domain.MethodMise,
-		Source:      "flake8",
+		Name:         "flake8",
+		Group:        "pythonlang",
+		Description:  "Style guide enforcement and linting",
+		Method:       domain.MethodMise,
+		Source:       "flake8",
+		Dependencies: []string{"python"},
 	},
 	"mypy": {
-		Name:        "mypy",
-		Group:       "pythonlang",
-		Description: "Static type checker for Python",
-		Method:      domain.MethodMise,
-		Source:      "mypy",
+		Name:         "mypy",
+		Group:        "pythonlang",
+		Description:  "Static type checker for Python",
+		Method:       domain.MethodMise,
+		Source:       "mypy",
+		Dependencies: []string{"python"},
 	},
 	"pytest": {
-		Name:        "pytest",
-		Group:       "pythonlang",
-		Description: "Modern testing framework",
-		Method:      domain.MethodMise,
-		Source:      "pytest",
+		Name:         "pytest",
+		Group:        "pythonlang",
+		Description:  "Modern testing framework",
+		Method:       domain.MethodMise,
+		Source:       "pytest",
+		Dependencies: []string{"python"},
 	},
 	"isort": {
-		Name:        "isort",
-		Group:       "pythonlang",
-		Description: "Import statement sorter",
-		Method:      domain.MethodMise,
-		Source:      "isort",
+		Name:         "isort",
+		Group:        "pythonlang",
+		Description:  "Import statement sorter",
+		Method:       domain.MethodMise,
+		Source:       "isort",
+		Dependencies: []string{"python"},
 	},
 	"bandit": {
-		Name:        "bandit",
-		Group:       "pythonlang",
-		Description: "Security linter for common security issues",
-		Method:      domain.MethodMise,
-		Source:      "bandit",
+		Name:         "bandit",
+		Group:        "pythonlang",
+		Description:  "Security linter for common security issues",
+		Method:       domain.MethodMise,
+		Source:       "bandit",
+		Dependencies: []string{"python"},
 	},
 	"ruff": {
 		Name:        "ruff",
@@ -228,11 +353,12 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Source:      "ruff",
 	},
 	"pre-commit": {
-		Name:        "pre-commit",
-		Group:       "pythonlang",
-		Description: "Git hooks for code quality",
-		Method:      domain.MethodMise,
-		Source:      "pre-commit",
+		Name:         "pre-commit",
+		Group:        "pythonlang",
+		Description:  "Git hooks for code quality",
+		Method:       domain.MethodMise,
+		Source:       "pre-commit",
+		Dependencies: []string{"python"},
 	},
 	"pyenv": {
 		Name:        "pyenv",
@@ -242,48 +368,90 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Source:      "pyenv",
 	},
 	"pip-tools": {
-		Name:        "pip-tools",
-		Group:       "pythonlang",
-		Description: "Requirements management with version pinning",
-		Method:      domain.MethodMise,
-		Source:      "pip-tools",
+		Name:         "pip-tools",
+		Group:        "pythonlang",
+		Description:  "Requirements management with version pinning",
+		Method:       domain.MethodMise,
+		Source:       "pip-tools",
+		Dependencies: []string{"python"},
 	},
 	"coverage": {
-		Name:        "coverage",
-		Group:       "pythonlang",
-		Description: "Code coverage measurement",
-		Method:      domain.MethodMise,
-		Source:      "coverage",
+		Name:         "coverage",
+		Group:        "pythonlang",
+		Description:  "Code coverage measurement",
+		Method:       domain.MethodMise,
+		Source:       "coverage",
+		Dependencies: []string{"python"},
 	},
 	"ipython": {
-		Name:        "ipython",
-		Group:       "pythonlang",
-		Description: "Enhanced interactive Python shell",
-		Method:      domain.MethodMise,
-		Source:      "ipython",
+		Name:         "ipython",
+		Group:        "pythonlang",
+		Description:  "Enhanced interactive Python shell",
+		Method:       domain.MethodMise,
+		Source:       "ipython",
+		Dependencies: []string{"python"},
 	},
 	"jupyter": {
-		Name:        "jupyter",
-		Group:       "pythonlang",
-		Description: "Interactive notebooks for data science",
-		Method:      domain.MethodMise,
-		Source:      "jupyter",
+		Name:         "jupyter",
+		Group:        "pythonlang",
+		Description:  "Interactive notebooks for data science",
+		Method:       domain.MethodMise,
+		Source:       "jupyter",
+		Dependencies: []string{"python"},
 	},
 	"sphinx": {
-		Name:        "sphinx",
-		Group:       "pythonlang",
-		Description: "Documentation generator",
-		Method:      domain.MethodMise,
-		Source:      "sphinx",
+		Name:         "sphinx",
+		Group:        "pythonlang",
+		Description:  "Documentation generator",
+		Method:       domain.MethodMise,
+		Source:       "sphinx",
+		Dependencies: []string{"python"},
+	},
+
+	// Node.js Development Tools
+	"node": {
+		Name:        "node",
+		Group:       "nodelang",
+		Description: "Node.js JavaScript runtime",
+		Aliases:     []string{"nodejs"},
+		Method:      domain.MethodMise,
+		Source:      "node",
+	},
+	"pnpm": {
+		Name:         "pnpm",
+		Group:        "nodelang",
+		Description:  "Fast, disk space efficient package manager",
+		Method:       domain.MethodNpm,
+		Source:       "pnpm",
+		Dependencies: []string{"node"},
+	},
+	"prettier": {
+		Name:         "prettier",
+		Group:        "nodelang",
+		Description:  "Opinionated code formatter",
+		Method:       domain.MethodNpm,
+		Source:       "prettier",
+		Dependencies: []string{"node"},
+	},
+	"serve": {
+		Name:         "serve",
+		Group:        "nodelang",
+		Description:  "Static file server for local development",
+		Method:       domain.MethodNpm,
+		Source:       "serve",
+		Dependencies: []string{"node"},
 	},
 
 	// Browsers
 	"chrome": {
-		Name:        "Google Chrome",
-		Group:       "browsers",
-		Description: "Web browser",
-		Method:      domain.MethodDEB,
-		Source:      "https://dl.google.com/linux/direct/google-chrome-stable_current_amd64.deb",
+		Name:         "Google Chrome",
+		Group:        "browsers",
+		Description:  "Web browser",
+		License:      domain.LicenseProprietary,
+		Aliases:      []string{"google-chrome"},
+		Method:       domain.MethodDEB,
+		Source:       "https://dl.google.com/linux/direct/google-chrome-stable_current_amd64.deb",
+		Alternatives: []Alternative{{Method: domain.MethodFlatpak, Source: "com.google.Chrome"}},
 		PostInstall: func() error {
 			return exec.Command("xdg-settings", "set", "default-web-browser", "google-chrome.desktop").Run()
 		},
@@ -315,6 +483,7 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Name:        "Discord",
 		Group:       "communication",
 		Description: "Chat platform",
+		License:     domain.LicenseProprietary,
 		Method:      domain.MethodFlatpak,
 		Source:      "com.discordapp.Discord",
 	},
@@ -322,22 +491,50 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Name:        "Zoom",
 		Group:       "communication",
 		Description: "Video conferencing",
+		License:     domain.LicenseProprietary,
 		Method:      domain.MethodFlatpak,
 		Source:      "us.zoom.Zoom",
 	},
 
+	// Mail and calendars
+	"thunderbird": {
+		Name:        "Thunderbird",
+		Group:       "communication-pro",
+		Description: "Mail, calendar and contacts",
+		Method:      domain.MethodFlatpak,
+		Source:      "org.mozilla.Thunderbird",
+	},
+	"evolution": {
+		Name:         "Evolution",
+		Group:        "communication-pro",
+		Description:  "GNOME mail, calendar and contacts, using Online Accounts",
+		Method:       domain.MethodAPT,
+		Source:       "evolution",
+		Alternatives: []Alternative{{Method: domain.MethodFlatpak, Source: "org.gnome.Evolution"}},
+	},
+	"evolution-ews": {
+		Name:         "Evolution EWS",
+		Group:        "communication-pro",
+		Description:  "Exchange and Microsoft 365 accounts in Evolution",
+		Method:       domain.MethodAPT,
+		Source:       "evolution-ews",
+		Dependencies: []string{"evolution"},
+	},
+
 	// Media
 	"vlc": {
-		Name:        "VLC Media Player",
-		Group:       "media",
-		Description: "Media player",
-		Method:      domain.MethodAPT,
-		Source:      "vlc",
+		Name:         "VLC Media Player",
+		Group:        "media",
+		Description:  "Media player",
+		Method:       domain.MethodAPT,
+		Source:       "vlc",
+		Alternatives: []Alternative{{Method: domain.MethodFlatpak, Source: "org.videolan.VLC"}},
 	},
 	"spotify": {
 		Name:        "Spotify",
 		Group:       "media",
 		Description: "Music streaming",
+		License:     domain.LicenseProprietary,
 		Method:      domain.MethodFlatpak,
 		Source:      "com.spotify.Client",
 	},
@@ -361,20 +558,24 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Name:        "Obsidian",
 		Group:       "productivity",
 		Description: "Note taking",
+		License:     domain.LicenseProprietary,
 		Method:      domain.MethodFlatpak,
 		Source:      "md.obsidian.Obsidian",
 	},
 	"libreoffice": {
-		Name:        "LibreOffice",
-		Group:       "productivity",
-		Description: "Office suite",
-		Method:      domain.MethodAPT,
-		Source:      "libreoffice",
+		Name:         "LibreOffice",
+		Group:        "productivity",
+		Description:  "Office suite",
+		Aliases:      []string{"office", "writer", "calc"},
+		Method:       domain.MethodAPT,
+		Source:       "libreoffice",
+		Alternatives: []Alternative{{Method: domain.MethodFlatpak, Source: "org.libreoffice.LibreOffice"}},
 	},
 	"dropbox": {
 		Name:        "Dropbox",
 		Group:       "productivity",
 		Description: "Cloud storage",
+		License:     domain.LicenseProprietary,
 		Method:      domain.MethodFlatpak,
 		Source:      "com.dropbox.Client",
 	},
@@ -382,33 +583,218 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Name:        "1Password",
 		Group:       "productivity",
 		Description: "Password manager",
+		License:     domain.LicenseProprietary,
 		Method:      domain.MethodFlatpak,
 		Source:      "com.1password.1Password",
 	},
+	"1password-cli": {
+		Name:        "1Password CLI",
+		Group:       "productivity",
+		Description: "Read 1Password secrets from scripts, with the SSH agent set up",
+		License:     domain.LicenseProprietary,
+		Aliases:     []string{"op"},
+		Method:      domain.MethodDEB,
+		Source:      "https://downloads.1password.com/linux/debian/amd64/stable/1password-cli-amd64-latest.deb",
+		PostInstall: passwordManagerHook("1password-cli"),
+	},
+	"bitwarden-cli": {
+		Name:        "Bitwarden CLI",
+		Group:       "productivity",
+		Description: "Read Bitwarden secrets from scripts, with the SSH agent set up",
+		Aliases:     []string{"bw"},
+		Method:      domain.MethodSnap,
+		Source:      "bw",
+		PostInstall: passwordManagerHook("bitwarden-cli"),
+	},
+	"pass": {
+		Name:        "pass",
+		Group:       "productivity",
+		Description: "Password store kept in GPG-encrypted files, with gpg-agent for SSH",
+		Aliases:     []string{"password-store"},
+		Method:      domain.MethodAPT,
+		Source:      "pass",
+		PostInstall: passwordManagerHook("pass"),
+	},
+	"browserpass": {
+		Name:         "Browserpass",
+		Group:        "productivity",
+		Description:  "Native host connecting the Browserpass extension to pass",
+		Method:       domain.MethodAPT,
+		Source:       "webext-browserpass",
+		Dependencies: []string{"pass"},
+	},
 
 	// Graphics
 	"gimp": {
-		Name:        "GIMP",
-		Group:       "graphics",
-		Description: "Image editor",
-		Method:      domain.MethodAPT,
-		Source:      "gimp",
+		Name:         "GIMP",
+		Group:        "graphics",
+		Description:  "Image editor",
+		Aliases:      []string{"photoshop"},
+		Method:       domain.MethodAPT,
+		Source:       "gimp",
+		Alternatives: []Alternative{{Method: domain.MethodFlatpak, Source: "org.gimp.GIMP"}},
 	},
 	"pinta": {
-		Name:        "Pinta",
-		Group:       "graphics",
-		Description: "Simple image editor",
+		Name:         "Pinta",
+		Group:        "graphics",
+		Description:  "Simple image editor",
+		Method:       domain.MethodAPT,
+		Source:       "pinta",
+		Alternatives: []Alternative{{Method: domain.MethodFlatpak, Source: "com.github.PintaProject.Pinta"}},
+	},
+
+	// Tiling window managers
+	"sway": {
+		Name:        "Sway",
+		Group:       "tiling",
+		Description: "Tiling Wayland compositor compatible with i3",
 		Method:      domain.MethodAPT,
-		Source:      "pinta",
+		Source:      "sway",
+	},
+	"hyprland": {
+		Name:        "Hyprland",
+		Group:       "tiling",
+		Description: "Dynamic tiling Wayland compositor with animations",
+		Method:      domain.MethodAPT,
+		Source:      "hyprland",
+	},
+	"waybar": {
+		Name:        "Waybar",
+		Group:       "tiling",
+		Description: "Status bar for sway and Hyprland",
+		Method:      domain.MethodAPT,
+		Source:      "waybar",
+	},
+	"wofi": {
+		Name:        "Wofi",
+		Group:       "tiling",
+		Description: "Application launcher for wlroots compositors",
+		Method:      domain.MethodAPT,
+		Source:      "wofi",
+	},
+	"mako": {
+		Name:        "Mako",
+		Group:       "tiling",
+		Description: "Notification daemon for Wayland",
+		Method:      domain.MethodAPT,
+		Source:      "mako-notifier",
+	},
+	"swaylock": {
+		Name:        "swaylock",
+		Group:       "tiling",
+		Description: "Screen locker for Wayland",
+		Method:      domain.MethodAPT,
+		Source:      "swaylock",
+	},
+	"swayidle": {
+		Name:        "swayidle",
+		Group:       "tiling",
+		Description: "Idle management daemon for Wayland",
+		Method:      domain.MethodAPT,
+		Source:      "swayidle",
+	},
+	"swaybg": {
+		Name:        "swaybg",
+		Group:       "tiling",
+		Description: "Wallpaper tool for Wayland",
+		Method:      domain.MethodAPT,
+		Source:      "swaybg",
+	},
+	"grim": {
+		Name:        "grim",
+		Group:       "tiling",
+		Description: "Screenshot tool for Wayland",
+		Method:      domain.MethodAPT,
+		Source:      "grim",
+	},
+	"slurp": {
+		Name:        "slurp",
+		Group:       "tiling",
+		Description: "Screen region selector for Wayland",
+		Method:      domain.MethodAPT,
+		Source:      "slurp",
+	},
+	"xdg-desktop-portal-wlr": {
+		Name:        "xdg-desktop-portal-wlr",
+		Group:       "tiling",
+		Description: "Screen sharing and screenshots for sway",
+		Method:      domain.MethodAPT,
+		Source:      "xdg-desktop-portal-wlr",
+	},
+	"xdg-desktop-portal-hyprland": {
+		Name:        "xdg-desktop-portal-hyprland",
+		Group:       "tiling",
+		Description: "Screen sharing and screenshots for Hyprland",
+		Method:      domain.MethodAPT,
+		Source:      "xdg-desktop-portal-hyprland",
+	},
+
+	// Printing and scanning
+	"cups": {
+		Name:        "CUPS",
+		Group:       "printing",
+		Description: "Printing system with driverless IPP Everywhere support",
+		Method:      domain.MethodAPT,
+		Source:      "cups",
+	},
+	"cups-filters": {
+		Name:        "cups-filters",
+		Group:       "printing",
+		Description: "Filters converting print jobs for printers",
+		Method:      domain.MethodAPT,
+		Source:      "cups-filters",
+	},
+	"avahi-daemon": {
+		Name:        "Avahi",
+		Group:       "printing",
+		Description: "Finds printers and scanners on the local network",
+		Method:      domain.MethodAPT,
+		Source:      "avahi-daemon",
+	},
+	"avahi-utils": {
+		Name:        "Avahi utilities",
+		Group:       "printing",
+		Description: "Browse services announced on the local network",
+		Method:      domain.MethodAPT,
+		Source:      "avahi-utils",
+	},
+	"ipp-usb": {
+		Name:        "ipp-usb",
+		Group:       "printing",
+		Description: "Driverless printing and scanning over USB",
+		Method:      domain.MethodAPT,
+		Source:      "ipp-usb",
+	},
+	"sane-utils": {
+		Name:        "SANE",
+		Group:       "printing",
+		Description: "Scanner access with scanimage",
+		Method:      domain.MethodAPT,
+		Source:      "sane-utils",
+	},
+	"sane-airscan": {
+		Name:        "sane-airscan",
+		Group:       "printing",
+		Description: "Driverless network scanners for SANE",
+		Method:      domain.MethodAPT,
+		Source:      "sane-airscan",
+	},
+	"simple-scan": {
+		Name:        "Document Scanner",
+		Group:       "printing",
+		Description: "Scan documents and photos",
+		Method:      domain.MethodAPT,
+		Source:      "simple-scan",
 	},
 
 	// Utilities
 	"flameshot": {
-		Name:        "Flameshot",
-		Group:       "utilities",
-		Description: "Screenshot tool",
-		Method:      domain.MethodAPT,
-		Source:      "flameshot",
+		Name:         "Flameshot",
+		Group:        "utilities",
+		Description:  "Screenshot tool",
+		Method:       domain.MethodAPT,
+		Source:       "flameshot",
+		Alternatives: []Alternative{{Method: domain.MethodFlatpak, Source: "org.flameshot.Flameshot"}},
 	},
 	"virtualbox": {
 		Name:        "VirtualBox",
@@ -416,6 +802,38 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Description: "Virtual machines",
 		Method:      domain.MethodAPT,
 		Source:      "virtualbox",
+		NeedsDKMS:   true,
+	},
+	"docker.io": {
+		Name:        "Docker",
+		Group:       "utilities",
+		Description: "Container engine",
+		Method:      domain.MethodAPT,
+		Source:      "docker.io",
+		Conflicts:   []string{"podman-docker"},
+	},
+	"podman-docker": {
+		Name:        "Podman (docker CLI)",
+		Group:       "utilities",
+		Description: "Podman answering to the docker command",
+		Aliases:     []string{"podman"},
+		Method:      domain.MethodAPT,
+		Source:      "podman-docker",
+	},
+	"tlp": {
+		Name:        "TLP",
+		Group:       "utilities",
+		Description: "Laptop battery and power tuning",
+		Method:      domain.MethodAPT,
+		Source:      "tlp",
+		Conflicts:   []string{"power-profiles-daemon"},
+	},
+	"power-profiles-daemon": {
+		Name:        "Power Profiles Daemon",
+		Group:       "utilities",
+		Description: "Power profile switching for GNOME and KDE",
+		Method:      domain.MethodAPT,
+		Source:      "power-profiles-daemon",
 	},
 
 	// Gaming
@@ -423,6 +841,7 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Name:        "Steam",
 		Group:       "gaming",
 		Description: "Gaming platform",
+		License:     domain.LicenseProprietary,
 		Method:      domain.MethodFlatpak,
 		Source:      "com.valvesoftware.Steam",
 	},
@@ -437,6 +856,7 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Name:        "Minecraft",
 		Group:       "gaming",
 		Description: "Block building game",
+		License:     domain.LicenseProprietary,
 		Method:      domain.MethodFlatpak,
 		Source:      "com.mojang.Minecraft",
 	},
@@ -534,11 +954,12 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Source:      "eza",
 	},
 	"zoxide": {
-		Name:        "zoxide",
-		Group:       "terminal",
-		Description: "Smart cd",
-		Method:      domain.MethodAPT,
-		Source:      "zoxide",
+		Name:         "zoxide",
+		Group:        "terminal",
+		Description:  "Smart cd",
+		Method:       domain.MethodAPT,
+		Source:       "zoxide",
+		Alternatives: []Alternative{{Method: domain.MethodMise, Source: "zoxide"}},
 	},
 	"delta": {
 		Name:        "delta",
@@ -696,46 +1117,52 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Source:      "java",
 	},
 	"maven": {
-		Name:        "Maven",
-		Group:       "javalang",
-		Description: "Java build automation tool",
-		Method:      domain.MethodMise,
-		Source:      "maven",
+		Name:         "Maven",
+		Group:        "javalang",
+		Description:  "Java build automation tool",
+		Method:       domain.MethodMise,
+		Source:       "maven",
+		Dependencies: []string{"java"},
 	},
 	"gradle": {
-		Name:        "Gradle",
-		Group:       "javalang",
-		Description: "Java build automation tool",
-		Method:      domain.MethodMise,
-		Source:      "gradle",
+		Name:         "Gradle",
+		Group:        "javalang",
+		Description:  "Java build automation tool",
+		Method:       domain.MethodMise,
+		Source:       "gradle",
+		Dependencies: []string{"java"},
 	},
 	"checkstyle": {
-		Name:        "Checkstyle",
-		Group:       "javalang",
-		Description: "Java code style checker",
-		Method:      domain.MethodMise,
-		Source:      "checkstyle",
+		Name:         "Checkstyle",
+		Group:        "javalang",
+		Description:  "Java code style checker",
+		Method:       domain.MethodMise,
+		Source:       "checkstyle",
+		Dependencies: []string{"java"},
 	},
 	"pmd": {
-		Name:        "PMD",
-		Group:       "javalang",
-		Description: "Java source code analyzer",
-		Method:      domain.MethodGitHubJava,
-		Source:      "pmd/pmd",
+		Name:         "PMD",
+		Group:        "javalang",
+		Description:  "Java source code analyzer",
+		Method:       domain.MethodGitHubJava,
+		Source:       "pmd/pmd",
+		Dependencies: []string{"java"},
 	},
 	"spotbugs": {
-		Name:        "SpotBugs",
-		Group:       "javalang",
-		Description: "Java static analysis tool",
-		Method:      domain.MethodMise,
-		Source:      "spotbugs",
+		Name:         "SpotBugs",
+		Group:        "javalang",
+		Description:  "Java static analysis tool",
+		Method:       domain.MethodMise,
+		Source:       "spotbugs",
+		Dependencies: []string{"java"},
 	},
 	"jmeter": {
-		Name:        "JMeter",
-		Group:       "javalang",
-		Description: "Java performance testing tool",
-		Method:      domain.MethodMise,
-		Source:      "jmeter",
+		Name:         "JMeter",
+		Group:        "javalang",
+		Description:  "Java performance testing tool",
+		Method:       domain.MethodMise,
+		Source:       "jmeter",
+		Dependencies: []string{"java"},
 	},
 	"visualvm": {
 		Name:        "VisualVM",
@@ -761,11 +1188,12 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 
 	// Missing apps from master branch
 	"fastfetch": {
-		Name:        "Fastfetch",
-		Group:       "utilities",
-		Description: "System information display",
-		Method:      domain.MethodDEB,
-		Source:      "https://github.com/fastfetch-cli/fastfetch/releases/latest/download/fastfetch-linux-amd64.deb",
+		Name:         "Fastfetch",
+		Group:        "utilities",
+		Description:  "System information display",
+		Method:       domain.MethodDEB,
+		Source:       "https://github.com/fastfetch-cli/fastfetch/releases/latest/download/fastfetch-linux-amd64.deb",
+		Alternatives: []Alternative{{Method: domain.MethodMise, Source: "fastfetch"}},
 	},
 	"gnome-sushi": {
 		Name:        "GNOME Sushi",
@@ -788,6 +1216,13 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Method:      domain.MethodFlatpak,
 		Source:      "org.localsend.localsend_app",
 	},
+	"zram-generator": {
+		Name:        "zram-generator",
+		Group:       "utilities",
+		Description: "Compressed swap in RAM, set up by systemd",
+		Method:      domain.MethodAPT,
+		Source:      "systemd-zram-generator",
+	},
 	"wl-clipboard": {
 		Name:        "wl-clipboard",
 		Group:       "utilities",
@@ -795,12 +1230,28 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 		Method:      domain.MethodAPT,
 		Source:      "wl-clipboard",
 	},
-	"xournalpp": {
-		Name:        "Xournal++",
-		Group:       "productivity",
-		Description: "PDF annotation and note-taking",
+	"xclip": {
+		Name:        "xclip",
+		Group:       "utilities",
+		Description: "X11 clipboard utility",
 		Method:      domain.MethodAPT,
-		Source:      "xournalpp",
+		Source:      "xclip",
+	},
+	"copyq": {
+		Name:         "CopyQ",
+		Group:        "utilities",
+		Description:  "Clipboard manager with searchable history",
+		Method:       domain.MethodAPT,
+		Source:       "copyq",
+		Alternatives: []Alternative{{Method: domain.MethodFlatpak, Source: "com.github.hluk.copyq"}},
+	},
+	"xournalpp": {
+		Name:         "Xournal++",
+		Group:        "productivity",
+		Description:  "PDF annotation and note-taking",
+		Method:       domain.MethodAPT,
+		Source:       "xournalpp",
+		Alternatives: []Alternative{{Method: domain.MethodFlatpak, Source: "com.github.xournalpp.xournalpp"}},
 	},
 	"zettlr": {
 		Name:        "Zettlr",
@@ -813,20 +1264,24 @@ var Apps = map[string]App{ //nolint:gochecknoglobals
 
 // Groups defines application groups for bulk installation.
 var Groups = map[string][]string{ //nolint:gochecknoglobals
-	"development":   {"vscode", "cursor", "zed", "windsurf", "rubymine", "mise", "gh", "aqua"},
-	"browsers":      {"chrome", "brave", "firefox"},
-	"communication": {"signal", "discord", "zoom"},
-	"media":         {"vlc", "spotify", "obs", "audacity"},
-	"productivity":  {"obsidian", "libreoffice", "dropbox", "1password", "xournalpp", "zettlr"},
-	"graphics":      {"gimp", "pinta"},
-	"utilities":     {"flameshot", "virtualbox", "fastfetch", "gnome-sushi", "gnome-tweaks", "localsend", "wl-clipboard"},
-	"gaming":        {"steam", "heroic", "minecraft", "retroarch"},
-	"golang":        {"go", "golangci-lint", "goreleaser"},
-	"javalang":      {"java", "maven", "gradle", "checkstyle", "pmd", "spotbugs", "jmeter", "visualvm", "kse", "jreleaser"},
-	"rustlang":      {"rust", "cargo-audit", "cargo-watch", "cargo-edit", "cargo-expand", "cargo-tarpaulin", "cargo-nextest", "cargo-deny", "cargo-bloat", "cargo-outdated", "cargo-cross", "cargo-flamegraph", "cargo-geiger"},
-	"pythonlang":    {"python", "pipx", "poetry", "black", "flake8", "mypy", "pytest", "isort", "bandit", "ruff", "pre-commit", "pyenv", "pip-tools", "coverage", "ipython", "jupyter", "sphinx"},
-	"linters":       {"hadolint", "trivy", "gitleaks", "yamlfmt", "taplo", "cosign", "scorecard", "syft", "actionlint", "shellcheck", "shfmt", "dockle"},
-	"terminal":      {"gh", "lazygit", "lazydocker", "btop", "neovim", "zellij", "starship", "fish", "fzf", "ripgrep", "bat", "eza", "zoxide", "delta", "fd", "hyperfine", "bottom"},
+	"development":       {"vscode", "cursor", "zed", "windsurf", "rubymine", "mise", "gh", "aqua"},
+	"browsers":          {"chrome", "brave", "firefox"},
+	"communication":     {"signal", "discord", "zoom"},
+	"communication-pro": {"thunderbird", "evolution", "evolution-ews"},
+	"media":             {"vlc", "spotify", "obs", "audacity"},
+	"productivity":      {"obsidian", "libreoffice", "dropbox", "1password", "1password-cli", "bitwarden-cli", "pass", "browserpass", "xournalpp", "zettlr"},
+	"graphics":          {"gimp", "pinta"},
+	"utilities":         {"flameshot", "virtualbox", "docker.io", "podman-docker", "tlp", "power-profiles-daemon", "fastfetch", "gnome-sushi", "gnome-tweaks", "localsend", "wl-clipboard", "xclip", "copyq", "zram-generator"},
+	"tiling":            {"sway", "hyprland", "waybar", "wofi", "mako", "swaylock", "swayidle", "swaybg", "grim", "slurp", "xdg-desktop-portal-wlr", "xdg-desktop-portal-hyprland"},
+	"printing":          {"cups", "cups-filters", "avahi-daemon", "avahi-utils", "ipp-usb", "sane-utils", "sane-airscan", "simple-scan"},
+	"gaming":            {"steam", "heroic", "minecraft", "retroarch"},
+	"golang":            {"go", "golangci-lint", "goreleaser"},
+	"javalang":          {"java", "maven", "gradle", "checkstyle", "pmd", "spotbugs", "jmeter", "visualvm", "kse", "jreleaser"},
+	"rustlang":          {"rust", "cargo-audit", "cargo-watch", "cargo-edit", "cargo-expand", "cargo-tarpaulin", "cargo-nextest", "cargo-deny", "cargo-bloat", "cargo-outdated", "cargo-cross", "cargo-flamegraph", "cargo-geiger"},
+	"pythonlang":        {"python", "pipx", "poetry", "black", "flake8", "mypy", "pytest", "isort", "bandit", "ruff", "pre-commit", "pyenv", "pip-tools", "coverage", "ipython", "jupyter", "sphinx"},
+	"nodelang":          {"node", "pnpm", "prettier", "serve"},
+	"linters":           {"hadolint", "trivy", "gitleaks", "yamlfmt", "taplo", "cosign", "scorecard", "syft", "actionlint", "shellcheck", "shfmt", "dockle"},
+	"terminal":          {"gh", "lazygit", "lazydocker", "btop", "neovim", "zellij", "starship", "fish", "fzf", "ripgrep", "bat", "eza", "zoxide", "delta", "fd", "hyperfine", "bottom"},
 }
 
 // Languages contains supported programming languages.
@@ -836,6 +1291,96 @@ var Languages = map[string]string{ //nolint:gochecknoglobals
 	"elixir": "elixir",
 }
 
+// Dependents returns the catalog keys of apps that depend on key, sorted.
+func Dependents(key string) []string {
+	var dependents []string
+
+	for name, app := range Apps {
+		if slices.Contains(app.Dependencies, key) {
+			dependents = append(dependents, name)
+		}
+	}
+
+	slices.Sort(dependents)
+
+	return dependents
+}
+
+// Conflicting returns the catalog keys of apps that conflict with key,
+// whichever side declared the conflict, sorted.
+func Conflicting(key string) []string {
+	conflicts := slices.Clone(Apps[key].Conflicts)
+
+	for name, app := range Apps {
+		if slices.Contains(app.Conflicts, key) && !slices.Contains(conflicts, name) {
+			conflicts = append(conflicts, name)
+		}
+	}
+
+	slices.Sort(conflicts)
+
+	return conflicts
+}
+
+// WithDependencies returns keys with the dependencies of each app placed
+// before it, transitively and without duplicates. Unknown keys are kept so
+// callers can report them.
+func WithDependencies(keys []string) []string {
+	ordered := make([]string, 0, len(keys))
+	seen := make(map[string]bool)
+
+	var visit func(key string)
+
+	visit = func(key string) {
+		if seen[key] {
+			return
+		}
+
+		seen[key] = true
+
+		for _, dependency := range Apps[key].Dependencies {
+			visit(dependency)
+		}
+
+		ordered = append(ordered, key)
+	}
+
+	for _, key := range keys {
+		visit(key)
+	}
+
+	return ordered
+}
+
+// DependencyTree returns the dependencies of key with their own dependencies
+// nested below them. A dependency cycle ends at the repeated app.
+func DependencyTree(key string) []domain.DependencyNode {
+	return dependencyTree(key, map[string]bool{key: true})
+}
+
+func dependencyTree(key string, path map[string]bool) []domain.DependencyNode {
+	dependencies := Apps[key].Dependencies
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	nodes := make([]domain.DependencyNode, 0, len(dependencies))
+
+	for _, dependency := range dependencies {
+		node := domain.DependencyNode{Key: dependency}
+
+		if !path[dependency] {
+			path[dependency] = true
+			node.Dependencies = dependencyTree(dependency, path)
+			delete(path, dependency)
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
 // ListApps returns apps for a group, or all apps if group is empty.
 func ListApps(group string) []App {
 	var apps []App
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package apps
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// catalogCacheVersion changes whenever App or the cache layout changes, so
+// caches written by other karei versions are parsed afresh.
+const catalogCacheVersion = 2
+
+// catalogCache is a parsed catalog file, valid while the file keeps its size
+// and modification time.
+type catalogCache struct {
+	Version int
+	Path    string
+	Size    int64
+	ModTime int64 // Unix nanoseconds
+	Apps    map[string]App
+}
+
+// LoadCatalogFileCached works like LoadCatalogFile, but keeps the parsed
+// catalog in cacheDir and reuses it while the file is unchanged, skipping
+// TOML parsing and validation on later runs.
+func LoadCatalogFileCached(path, cacheDir string) (int, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read catalog: %w", err)
+	}
+
+	cachePath := CatalogCachePath(path, cacheDir)
+
+	if catalog, ok := readCatalogCache(cachePath, path, info); ok {
+		mergeCatalog(catalog)
+
+		return len(catalog), nil
+	}
+
+	catalog, err := readCatalogFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	// A cache that can't be written only costs the next run a parse
+	_ = writeCatalogCache(cachePath, catalogCache{
+		Version: catalogCacheVersion,
+		Path:    path,
+		Size:    info.Size(),
+		ModTime: info.ModTime().UnixNano(),
+		Apps:    catalog,
+	})
+
+	mergeCatalog(catalog)
+
+	return len(catalog), nil
+}
+
+// CatalogCachePath returns where the parsed catalog file at path is cached.
+func CatalogCachePath(path, cacheDir string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	sum := sha256.Sum256([]byte(path))
+
+	return filepath.Join(cacheDir, "catalog-"+hex.EncodeToString(sum[:8])+".gob")
+}
+
+// readCatalogCache returns the cached catalog when it matches the file.
+func readCatalogCache(cachePath, path string, info os.FileInfo) (map[string]App, bool) {
+	data, err := os.ReadFile(cachePath) //nolint:gosec // Path derived from the cache directory
+	if err != nil {
+		return nil, false
+	}
+
+	var cache catalogCache
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&cache); err != nil {
+		return nil, false
+	}
+
+	if cache.Version != catalogCacheVersion || cache.Path != path ||
+		cache.Size != info.Size() || cache.ModTime != info.ModTime().UnixNano() {
+		return nil, false
+	}
+
+	return cache.Apps, true
+}
+
+// writeCatalogCache stores cache atomically so concurrent runs never read half a file.
+func writeCatalogCache(cachePath string, cache catalogCache) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cache); err != nil {
+		return fmt.Errorf("failed to encode catalog cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o750); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp := cachePath + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write catalog cache: %w", err)
+	}
+
+	if err := os.Rename(tmp, cachePath); err != nil {
+		return fmt.Errorf("failed to write catalog cache: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package apps
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// ErrInvalidCatalog is returned for catalog files with incomplete apps.
+var ErrInvalidCatalog = errors.New("invalid catalog")
+
+// catalogFile is the TOML layout of an extra catalog:
+//
+//	[apps.mytool]
+//	name = "My Tool"
+//	group = "utilities"
+//	description = "Does things"
+//	method = "apt"
+//	source = "mytool"
+//	version = ">=2 <3"
+//	sha256 = "9f86d0...0a08"   # Checksum of a downloaded .deb, script or binary
+//	arch = ["amd64"]           # Architectures the download is built for
+type catalogFile struct {
+	Apps map[string]catalogEntry `toml:"apps"`
+}
+
+type catalogEntry struct {
+	Name         string   `toml:"name"`
+	Group        string   `toml:"group"`
+	Description  string   `toml:"description"`
+	Method       string   `toml:"method"`
+	Source       string   `toml:"source"`
+	Aliases      []string `toml:"aliases"`
+	Dependencies []string `toml:"dependencies"`
+	Proprietary  bool     `toml:"proprietary"`
+	Version      string   `toml:"version"`
+	SHA256       string   `toml:"sha256"`
+	Arch         []string `toml:"arch"`
+}
+
+// ParseCatalog parses a TOML catalog file into apps keyed like Apps.
+func ParseCatalog(data []byte) (map[string]App, error) {
+	var file catalogFile
+	if err := toml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCatalog, err)
+	}
+
+	catalog := make(map[string]App, len(file.Apps))
+
+	for key, entry := range file.Apps {
+		if entry.Name == "" || entry.Group == "" || entry.Source == "" {
+			return nil, fmt.Errorf("%w: %s needs a name, group and source", ErrInvalidCatalog, key)
+		}
+
+		preference, err := domain.ParseMethodPreference(entry.Method)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidCatalog, key, err)
+		}
+
+		if len(preference) != 1 {
+			return nil, fmt.Errorf("%w: %s needs exactly one method", ErrInvalidCatalog, key)
+		}
+
+		if _, err := domain.ParseVersionConstraint(entry.Version); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidCatalog, key, err)
+		}
+
+		if entry.SHA256 != "" && !domain.IsSHA256(entry.SHA256) {
+			return nil, fmt.Errorf("%w: %s: sha256 must be 64 hex digits", ErrInvalidCatalog, key)
+		}
+
+		if i := slices.IndexFunc(entry.Arch, func(arch string) bool { return !slices.Contains(domain.Architectures, arch) }); i >= 0 {
+			return nil, fmt.Errorf("%w: %s: unknown arch %q", ErrInvalidCatalog, key, entry.Arch[i])
+		}
+
+		catalog[key] = entryApp(entry)
+	}
+
+	return catalog, nil
+}
+
+// LoadCatalogFile adds the apps in a TOML catalog file to Apps and Groups,
+// replacing built-in apps with the same key. It returns how many were added.
+func LoadCatalogFile(path string) (int, error) {
+	catalog, err := readCatalogFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	mergeCatalog(catalog)
+
+	return len(catalog), nil
+}
+
+// readCatalogFile reads and parses a TOML catalog file.
+func readCatalogFile(path string) (map[string]App, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path comes from KAREI_CATALOG_PATH
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+
+	catalog, err := ParseCatalog(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return catalog, nil
+}
+
+// mergeCatalog adds catalog to Apps and Groups and drops the stale index.
+func mergeCatalog(catalog map[string]App) {
+	for key, app := range catalog {
+		Apps[key] = app
+
+		if group := Groups[app.Group]; !slices.Contains(group, key) {
+			Groups[app.Group] = append(group, key)
+		}
+	}
+
+	invalidateIndex()
+}
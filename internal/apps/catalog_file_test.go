@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package apps_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCatalog(t *testing.T) {
+	catalog, err := apps.ParseCatalog([]byte(`
+[apps.mytool]
+name = "My Tool"
+group = "utilities"
+description = "Does things"
+method = "flatpak"
+source = "org.example.MyTool"
+proprietary = true
+version = ">=2 <3"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]apps.App{
+		"mytool": {
+			Name:        "My Tool",
+			Group:       "utilities",
+			Description: "Does things",
+			Method:      domain.MethodFlatpak,
+			Source:      "org.example.MyTool",
+			License:     domain.LicenseProprietary,
+			Version:     ">=2 <3",
+		},
+	}, catalog)
+
+	for name, data := range map[string]string{
+		"syntax":         `[apps.x`,
+		"missing source": "[apps.x]\nname = \"X\"\ngroup = \"g\"\nmethod = \"apt\"",
+		"unknown method": "[apps.x]\nname = \"X\"\ngroup = \"g\"\nmethod = \"brew\"\nsource = \"x\"",
+		"no method":      "[apps.x]\nname = \"X\"\ngroup = \"g\"\nsource = \"x\"",
+		"bad version":    "[apps.x]\nname = \"X\"\ngroup = \"g\"\nmethod = \"apt\"\nsource = \"x\"\nversion = \"newest\"",
+		"bad sha256":     "[apps.x]\nname = \"X\"\ngroup = \"g\"\nmethod = \"deb\"\nsource = \"https://x/x.deb\"\nsha256 = \"abc\"",
+		"unknown arch":   "[apps.x]\nname = \"X\"\ngroup = \"g\"\nmethod = \"apt\"\nsource = \"x\"\narch = [\"x86_64\"]",
+	} {
+		_, err := apps.ParseCatalog([]byte(data))
+		assert.ErrorIs(t, err, apps.ErrInvalidCatalog, name)
+	}
+}
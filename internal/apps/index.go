@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package apps
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// Index is the catalog grouped and sorted the way it is listed, built once
+// instead of on every launch so large external catalogs start quickly.
+type Index struct {
+	Groups []IndexGroup // Sorted by title
+}
+
+// IndexGroup is a group of apps in the index.
+type IndexGroup struct {
+	Name  string   // Group key, e.g. "development"
+	Title string   // Display name, e.g. "Development"
+	Keys  []string // Catalog keys sorted by app name
+}
+
+//nolint:gochecknoglobals // Memoized view of Apps, dropped when the catalog changes
+var (
+	indexMu sync.Mutex
+	index   *Index
+)
+
+// CatalogIndex returns the index of Apps, building it on first use.
+func CatalogIndex() *Index {
+	indexMu.Lock()
+	defer indexMu.Unlock()
+
+	if index == nil {
+		index = buildIndex(Apps)
+	}
+
+	return index
+}
+
+// invalidateIndex drops the index after Apps changed.
+func invalidateIndex() {
+	indexMu.Lock()
+	index = nil
+	indexMu.Unlock()
+}
+
+// buildIndex groups catalog by app group and sorts groups and apps by name.
+func buildIndex(catalog map[string]App) *Index {
+	caser := cases.Title(language.Und)
+	byGroup := make(map[string]*IndexGroup)
+
+	for key, app := range catalog {
+		group, ok := byGroup[app.Group]
+		if !ok {
+			group = &IndexGroup{Name: app.Group, Title: caser.String(app.Group)}
+			byGroup[app.Group] = group
+		}
+
+		group.Keys = append(group.Keys, key)
+	}
+
+	idx := &Index{Groups: make([]IndexGroup, 0, len(byGroup))}
+
+	for _, group := range byGroup {
+		slices.SortFunc(group.Keys, func(a, b string) int {
+			return cmp.Or(cmp.Compare(catalog[a].Name, catalog[b].Name), cmp.Compare(a, b))
+		})
+
+		idx.Groups = append(idx.Groups, *group)
+	}
+
+	slices.SortFunc(idx.Groups, func(a, b IndexGroup) int {
+		return cmp.Compare(a.Title, b.Title)
+	})
+
+	return idx
+}
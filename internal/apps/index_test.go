@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package apps_test
+
+import (
+	"cmp"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const indexTestCatalog = `
+[apps.zz-index-tool]
+name = "AAA Index Tool"
+group = "zz-index"
+method = "apt"
+source = "zz-index-tool"
+`
+
+// writeIndexCatalog writes a catalog and removes its apps from the global catalog after the test.
+func writeIndexCatalog(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "catalog.toml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	t.Cleanup(func() {
+		delete(apps.Apps, "zz-index-tool")
+		delete(apps.Groups, "zz-index")
+		rebuildIndex(t)
+	})
+
+	return path
+}
+
+// rebuildIndex loads an empty catalog, which only drops the index.
+func rebuildIndex(tb testing.TB) {
+	tb.Helper()
+
+	path := filepath.Join(tb.TempDir(), "empty.toml")
+	require.NoError(tb, os.WriteFile(path, nil, 0o600))
+
+	_, err := apps.LoadCatalogFile(path)
+	require.NoError(tb, err)
+}
+
+func TestCatalogIndexSortsGroupsAndApps(t *testing.T) {
+	index := apps.CatalogIndex()
+
+	require.NotEmpty(t, index.Groups)
+	assert.True(t, slices.IsSortedFunc(index.Groups, func(a, b apps.IndexGroup) int {
+		return cmp.Compare(a.Title, b.Title)
+	}))
+
+	total := 0
+
+	for _, group := range index.Groups {
+		assert.NotEmpty(t, group.Title, group.Name)
+		assert.True(t, slices.IsSortedFunc(group.Keys, func(a, b string) int {
+			return cmp.Compare(apps.Apps[a].Name, apps.Apps[b].Name)
+		}), group.Name)
+
+		for _, key := range group.Keys {
+			assert.Equal(t, group.Name, apps.Apps[key].Group, key)
+		}
+
+		total += len(group.Keys)
+	}
+
+	assert.Equal(t, len(apps.Apps), total)
+}
+
+func TestCatalogIndexRebuiltAfterLoadingCatalog(t *testing.T) {
+	before := apps.CatalogIndex()
+	assert.Same(t, before, apps.CatalogIndex(), "index is built once")
+
+	added, err := apps.LoadCatalogFile(writeIndexCatalog(t, indexTestCatalog))
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	after := apps.CatalogIndex()
+	assert.NotSame(t, before, after)
+
+	i := slices.IndexFunc(after.Groups, func(g apps.IndexGroup) bool { return g.Name == "zz-index" })
+	require.GreaterOrEqual(t, i, 0)
+	assert.Equal(t, "Zz-Index", after.Groups[i].Title)
+	assert.Equal(t, []string{"zz-index-tool"}, after.Groups[i].Keys)
+}
+
+func TestLoadCatalogFileCached(t *testing.T) {
+	path := writeIndexCatalog(t, indexTestCatalog)
+	cacheDir := t.TempDir()
+
+	added, err := apps.LoadCatalogFileCached(path, cacheDir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+	assert.FileExists(t, apps.CatalogCachePath(path, cacheDir))
+
+	// An unchanged file is served from the cache, even when it no longer parses
+	// the same way, as long as size and modification time match
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	broken := []byte(indexTestCatalog[:len(indexTestCatalog)-2] + "[\n")
+	require.NoError(t, os.WriteFile(path, broken, 0o600))
+	require.NoError(t, os.Chtimes(path, info.ModTime(), info.ModTime()))
+
+	added, err = apps.LoadCatalogFileCached(path, cacheDir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+	assert.Equal(t, "AAA Index Tool", apps.Apps["zz-index-tool"].Name)
+
+	// A changed file is parsed again
+	require.NoError(t, os.Chtimes(path, info.ModTime().Add(time.Second), info.ModTime().Add(time.Second)))
+
+	_, err = apps.LoadCatalogFileCached(path, cacheDir)
+	require.ErrorIs(t, err, apps.ErrInvalidCatalog)
+}
+
+func BenchmarkCatalogIndex(b *testing.B) {
+	for b.Loop() {
+		b.StopTimer()
+		rebuildIndex(b)
+		b.StartTimer()
+
+		_ = apps.CatalogIndex()
+	}
+}
@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package apps
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// Severities of lint issues. Errors break installs or the catalog's
+// invariants; warnings are worth fixing but karei copes with them.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// BuiltinCatalog names the built-in catalog in lint issues.
+const BuiltinCatalog = "built-in"
+
+// catalogKeyPattern is the form of catalog keys and group names: lowercase,
+// as typed on the command line.
+var catalogKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._+-]*$`)
+
+//nolint:gochecknoglobals // Snapshot of the catalog literals, before catalog files are merged
+var (
+	builtinApps   = maps.Clone(Apps)
+	builtinGroups = cloneGroups(Groups)
+)
+
+// LintIssue is a problem karei catalog lint found in a catalog.
+type LintIssue struct {
+	Catalog  string `json:"catalog"`       // BuiltinCatalog or the catalog file
+	Key      string `json:"key,omitempty"` // App or group; empty for the whole file
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// LintURL is a URL a catalog app downloads from, for checking it is reachable.
+type LintURL struct {
+	Key string
+	URL string
+}
+
+// Builtin returns the built-in apps and groups, without the apps catalog
+// files added.
+func Builtin() (map[string]App, map[string][]string) {
+	return maps.Clone(builtinApps), cloneGroups(builtinGroups)
+}
+
+// LintCatalog checks a whole catalog, such as the built-in one: each app,
+// and that groups list apps in the catalog, including every app of their
+// own. Groups may also list apps of other groups, as development lists gh.
+func LintCatalog(name string, catalog map[string]App, groups map[string][]string) []LintIssue {
+	linter := &catalogLinter{name: name, catalog: catalog}
+
+	for key, app := range catalog {
+		linter.app(key, app)
+
+		if !slices.Contains(groups[app.Group], key) {
+			linter.warnf(key, "not listed in its group %q, so karei install %s leaves it out", app.Group, app.Group)
+		}
+	}
+
+	for group, keys := range groups {
+		if !catalogKeyPattern.MatchString(group) {
+			linter.errorf(group, "group names are lowercase letters, digits and - . _ +")
+		}
+
+		for i, key := range keys {
+			if _, exists := catalog[key]; !exists {
+				linter.errorf(group, "lists %q, which isn't in the catalog", key)
+			} else if slices.Index(keys, key) != i {
+				linter.warnf(group, "lists %q twice", key)
+			}
+		}
+	}
+
+	linter.duplicates()
+
+	return linter.sorted()
+}
+
+// LintCatalogFile checks a TOML catalog file as karei would add it to the
+// built-in catalog, reporting every problem rather than the first one as
+// loading it does. Apps may depend on built-in ones. It also returns the
+// apps of the file, nil when it isn't valid TOML.
+func LintCatalogFile(name string, data []byte) ([]LintIssue, map[string]App) {
+	var file catalogFile
+	if err := toml.Unmarshal(data, &file); err != nil {
+		return []LintIssue{{Catalog: name, Severity: LintError, Message: err.Error()}}, nil
+	}
+
+	catalog := make(map[string]App, len(file.Apps))
+	merged := maps.Clone(builtinApps)
+
+	for key, entry := range file.Apps {
+		app := entryApp(entry)
+		catalog[key] = app
+		merged[key] = app
+	}
+
+	linter := &catalogLinter{name: name, catalog: merged}
+
+	for key, app := range catalog {
+		linter.app(key, app)
+
+		if _, builtin := builtinApps[key]; builtin {
+			linter.warnf(key, "replaces the built-in app")
+		}
+
+		if _, known := builtinGroups[app.Group]; !known && app.Group != "" {
+			linter.warnf(key, "group %q isn't a built-in group, so it gets a group of its own", app.Group)
+		}
+	}
+
+	linter.duplicates()
+
+	// Issues of built-in apps are reported when linting the built-in catalog
+	issues := slices.DeleteFunc(linter.sorted(), func(issue LintIssue) bool {
+		_, ours := catalog[issue.Key]
+
+		return !ours
+	})
+
+	return issues, catalog
+}
+
+// LintURLs returns the URLs the apps of catalog download from, sorted by key.
+func LintURLs(catalog map[string]App) []LintURL {
+	var urls []LintURL
+
+	for key, app := range catalog {
+		sources := []string{app.Source}
+		for _, alt := range app.Alternatives {
+			sources = append(sources, alt.Source)
+		}
+
+		for _, source := range sources {
+			if strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://") {
+				urls = append(urls, LintURL{Key: key, URL: source})
+			}
+		}
+	}
+
+	slices.SortFunc(urls, func(a, b LintURL) int {
+		return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.URL, b.URL))
+	})
+
+	return urls
+}
+
+// catalogLinter collects the issues of one catalog.
+type catalogLinter struct {
+	name    string
+	catalog map[string]App // Apps dependencies and conflicts may name
+	issues  []LintIssue
+}
+
+// app checks the fields of one app.
+func (l *catalogLinter) app(key string, app App) {
+	if !catalogKeyPattern.MatchString(key) {
+		l.errorf(key, "keys are lowercase letters, digits and - . _ +")
+	}
+
+	if app.Name == "" || app.Group == "" || app.Source == "" {
+		l.errorf(key, "needs a name, group and source")
+	}
+
+	l.method(key, app.Method)
+
+	for _, alt := range app.Alternatives {
+		l.method(key, alt.Method)
+	}
+
+	if _, err := domain.ParseVersionConstraint(app.Version); err != nil {
+		l.errorf(key, "%v", err)
+	}
+
+	for _, arch := range app.Arch {
+		if !slices.Contains(domain.Architectures, arch) {
+			l.errorf(key, "unknown arch %q, want one of %s", arch, strings.Join(domain.Architectures, ", "))
+		}
+	}
+
+	switch {
+	case app.SHA256 != "" && !domain.IsSHA256(app.SHA256):
+		l.errorf(key, "sha256 must be 64 hex digits")
+	case app.SHA256 == "" && domain.DownloadsFile(app.Method, app.Source):
+		l.warnf(key, "downloads %s without a sha256 checksum", app.Source)
+	}
+
+	for _, dependency := range app.Dependencies {
+		if _, exists := l.catalog[dependency]; !exists || dependency == key {
+			l.errorf(key, "depends on %q, which isn't another app in the catalog", dependency)
+		}
+	}
+
+	for _, conflict := range app.Conflicts {
+		if _, exists := l.catalog[conflict]; !exists {
+			l.errorf(key, "conflicts with %q, which isn't in the catalog", conflict)
+		}
+	}
+}
+
+// method checks an install method is one karei knows.
+func (l *catalogLinter) method(key string, method domain.InstallMethod) {
+	if preference, err := domain.ParseMethodPreference(string(method)); err != nil || len(preference) != 1 {
+		l.errorf(key, "unknown install method %q", method)
+	}
+}
+
+// duplicates reports keys and aliases naming more than one app, ignoring
+// case, which makes karei install <name> ambiguous.
+func (l *catalogLinter) duplicates() {
+	names := make(map[string][]string)
+
+	for key, app := range l.catalog {
+		names[strings.ToLower(key)] = append(names[strings.ToLower(key)], key)
+
+		for _, alias := range app.Aliases {
+			names[strings.ToLower(alias)] = append(names[strings.ToLower(alias)], key)
+		}
+	}
+
+	for name, keys := range names {
+		slices.Sort(keys)
+
+		if keys = slices.Compact(keys); len(keys) > 1 {
+			for _, key := range keys {
+				l.errorf(key, "%q names %s, as key or alias", name, strings.Join(keys, " and "))
+			}
+		}
+	}
+}
+
+func (l *catalogLinter) errorf(key, format string, args ...any) {
+	l.issues = append(l.issues, LintIssue{Catalog: l.name, Key: key, Severity: LintError, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *catalogLinter) warnf(key, format string, args ...any) {
+	l.issues = append(l.issues, LintIssue{Catalog: l.name, Key: key, Severity: LintWarning, Message: fmt.Sprintf(format, args...)})
+}
+
+// sorted returns the issues by key, errors first, without duplicates.
+func (l *catalogLinter) sorted() []LintIssue {
+	slices.SortFunc(l.issues, func(a, b LintIssue) int {
+		return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.Severity, b.Severity), cmp.Compare(a.Message, b.Message))
+	})
+
+	return slices.Compact(l.issues)
+}
+
+// entryApp converts a catalog file entry to an app without validating it.
+func entryApp(entry catalogEntry) App {
+	app := App{
+		Name:         entry.Name,
+		Group:        entry.Group,
+		Description:  entry.Description,
+		Method:       domain.InstallMethod(entry.Method),
+		Source:       entry.Source,
+		Aliases:      entry.Aliases,
+		Dependencies: entry.Dependencies,
+		Version:      entry.Version,
+		SHA256:       entry.SHA256,
+		Arch:         entry.Arch,
+	}
+	if entry.Proprietary {
+		app.License = domain.LicenseProprietary
+	}
+
+	return app
+}
+
+// cloneGroups copies groups along with their lists of keys.
+func cloneGroups(groups map[string][]string) map[string][]string {
+	clone := make(map[string][]string, len(groups))
+	for group, keys := range groups {
+		clone[group] = slices.Clone(keys)
+	}
+
+	return clone
+}
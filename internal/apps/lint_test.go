@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package apps_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintBuiltinCatalogHasNoErrors(t *testing.T) {
+	builtin, groups := apps.Builtin()
+
+	for _, issue := range apps.LintCatalog(apps.BuiltinCatalog, builtin, groups) {
+		assert.NotEqual(t, apps.LintError, issue.Severity, "%s: %s", issue.Key, issue.Message)
+	}
+}
+
+func TestLintCatalog(t *testing.T) {
+	catalog := map[string]apps.App{
+		"tool":  {Name: "Tool", Group: "tools", Method: domain.MethodAPT, Source: "tool", Aliases: []string{"other"}},
+		"other": {Name: "Other", Group: "tools", Method: "brew", Source: "other", Dependencies: []string{"missing"}},
+		"dl": {
+			Name: "Download", Group: "tools", Method: domain.MethodDEB, Source: "https://example.com/dl.deb",
+			Arch: []string{"x86_64"}, Version: "newest",
+		},
+	}
+	groups := map[string][]string{"tools": {"tool", "other", "ghost"}}
+
+	assert.ElementsMatch(t, []apps.LintIssue{
+		{Catalog: "test", Key: "dl", Severity: apps.LintError, Message: `invalid version constraint: "newest"`},
+		{Catalog: "test", Key: "dl", Severity: apps.LintError, Message: `unknown arch "x86_64", want one of amd64, arm64, 386, arm, riscv64, ppc64le, s390x`},
+		{Catalog: "test", Key: "dl", Severity: apps.LintWarning, Message: "downloads https://example.com/dl.deb without a sha256 checksum"},
+		{Catalog: "test", Key: "dl", Severity: apps.LintWarning, Message: `not listed in its group "tools", so karei install tools leaves it out`},
+		{Catalog: "test", Key: "other", Severity: apps.LintError, Message: `unknown install method "brew"`},
+		{Catalog: "test", Key: "other", Severity: apps.LintError, Message: `depends on "missing", which isn't another app in the catalog`},
+		{Catalog: "test", Key: "other", Severity: apps.LintError, Message: `"other" names other and tool, as key or alias`},
+		{Catalog: "test", Key: "tool", Severity: apps.LintError, Message: `"other" names other and tool, as key or alias`},
+		{Catalog: "test", Key: "tools", Severity: apps.LintError, Message: `lists "ghost", which isn't in the catalog`},
+	}, apps.LintCatalog("test", catalog, groups))
+}
+
+func TestLintCatalogFile(t *testing.T) {
+	issues, catalog := apps.LintCatalogFile("team.toml", []byte(`
+[apps.vlc]
+name = "VLC"
+group = "media"
+method = "apt"
+source = "vlc"
+
+[apps.mytool]
+name = "My Tool"
+group = "utilities"
+method = "script"
+source = "https://example.com/install.sh"
+sha256 = "not-a-checksum"
+dependencies = ["vlc"]
+`))
+
+	assert.Equal(t, []apps.LintIssue{
+		{Catalog: "team.toml", Key: "mytool", Severity: apps.LintError, Message: "sha256 must be 64 hex digits"},
+		{Catalog: "team.toml", Key: "vlc", Severity: apps.LintWarning, Message: "replaces the built-in app"},
+	}, issues)
+	assert.Equal(t, []apps.LintURL{{Key: "mytool", URL: "https://example.com/install.sh"}}, apps.LintURLs(catalog))
+
+	issues, catalog = apps.LintCatalogFile("broken.toml", []byte(`[apps.x`))
+	assert.Len(t, issues, 1)
+	assert.Equal(t, apps.LintError, issues[0].Severity)
+	assert.Nil(t, catalog)
+}
@@ -69,6 +69,11 @@ func NewTUIManagerWithVersionManager(verbose bool, versionManager *versions.Vers
 	}
 }
 
+// PackageInstaller returns the installer port the manager delegates to.
+func (m *Manager) PackageInstaller() domain.PackageInstaller {
+	return m.packageInstaller
+}
+
 // InstallApp installs a single application by name.
 func (m *Manager) InstallApp(ctx context.Context, name string) error {
 	app, exists := Apps[name]
@@ -232,8 +237,8 @@ func (m *Manager) IsAppInstalled(ctx context.Context, name string) bool {
 	identifier := name
 
 	switch app.Method {
-	case domain.MethodFlatpak:
-		// For Flatpak, use the Source field which contains the Flatpak ID
+	case domain.MethodFlatpak, domain.MethodNpm:
+		// For Flatpak and npm, use the Source field which contains the Flatpak ID or npm package
 		identifier = app.Source
 	case domain.MethodMise:
 		// For Mise, use the lowercase key since mise commands are case-sensitive
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package apps
+
+import "github.com/janderssonse/karei/internal/domain"
+
+// appNextSteps holds follow-up actions for apps that need more than installing.
+var appNextSteps = map[string]string{ //nolint:gochecknoglobals // Read-only lookup table
+	"fish":       "Restart your terminal for fish changes, or make it your shell with: chsh -s $(which fish)",
+	"starship":   "Restart your terminal to load the starship prompt",
+	"zoxide":     "Restart your terminal so zoxide hooks into your shell",
+	"mise":       "Open a new shell so mise activation takes effect",
+	"rust":       "Open a new shell so ~/.cargo/bin is on your PATH",
+	"pyenv":      "Open a new shell so pyenv shims are on your PATH",
+	"virtualbox": "Reboot to load the VirtualBox kernel modules",
+	"1password":  "Sign in to 1Password before enabling its browser extension",
+	"sway":       "Log out and pick Sway on the login screen",
+	"hyprland":   "Log out and pick Hyprland on the login screen",
+}
+
+// methodNextSteps holds follow-up actions shared by every app of an install method.
+var methodNextSteps = map[domain.InstallMethod]string{ //nolint:gochecknoglobals // Read-only lookup table
+	domain.MethodFlatpak: "Log out and back in if new Flatpak apps are missing from the app grid",
+	domain.MethodMise:    "Open a new shell so mise-managed tools are on your PATH",
+	domain.MethodAqua:    "Open a new shell so aqua-managed tools are on your PATH",
+	domain.MethodNpm:     "Open a new shell so npm tools karei installed are on your PATH",
+}
+
+// NextSteps returns the follow-up actions for freshly installed apps, without duplicates.
+func NextSteps(installed []string, preference domain.MethodPreference) []string {
+	var steps []string
+
+	seen := make(map[string]bool)
+	add := func(step string) {
+		if step != "" && !seen[step] {
+			seen[step] = true
+			steps = append(steps, step)
+		}
+	}
+
+	for _, key := range installed {
+		app, exists := Apps[key]
+		if !exists {
+			continue
+		}
+
+		add(appNextSteps[key])
+
+		method, _ := app.Resolve(preference)
+		add(methodNextSteps[method])
+	}
+
+	return steps
+}
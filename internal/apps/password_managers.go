@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package apps
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// passwordManagerHook returns the post-install hook of the password manager
+// installed as the catalog app key, pointing ssh at its agent.
+func passwordManagerHook(key string) func() error {
+	return func() error {
+		manager, ok := domain.PasswordManagerFor(key)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownApp, key)
+		}
+
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to find home directory: %w", err)
+		}
+
+		_, err = ConfigurePasswordManager(platform.NewFileManager(false), home, manager)
+
+		return err
+	}
+}
+
+// ConfigurePasswordManager makes ssh use the manager's agent while it runs,
+// through a file of its own in ~/.ssh/config.d that ~/.ssh/config includes,
+// and turns on the SSH support of gpg-agent for pass. It returns the paths
+// written; running it again writes nothing new.
+func ConfigurePasswordManager(fm domain.FileManager, home string, manager domain.PasswordManager) ([]string, error) {
+	if manager.AgentSocket == "" {
+		return nil, nil
+	}
+
+	sshDir := filepath.Join(home, ".ssh")
+	path := filepath.Join(sshDir, "config.d", "karei-"+manager.Key+".conf")
+
+	if err := fm.WriteFile(path, []byte(manager.SSHConfig())); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	written := []string{path}
+
+	changed, err := updateFile(fm, filepath.Join(sshDir, "config"), domain.AddSSHInclude)
+	if err != nil {
+		return written, err
+	}
+
+	if changed {
+		written = append(written, filepath.Join(sshDir, "config"))
+	}
+
+	if manager.Command != "pass" {
+		return written, nil
+	}
+
+	agentConf := filepath.Join(home, ".gnupg", "gpg-agent.conf")
+
+	changed, err = updateFile(fm, agentConf, func(content string) (string, bool) {
+		for line := range strings.SplitSeq(content, "\n") {
+			if strings.TrimSpace(line) == "enable-ssh-support" {
+				return content, false
+			}
+		}
+
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+
+		return content + "enable-ssh-support\n", true
+	})
+	if err != nil {
+		return written, err
+	}
+
+	if changed {
+		written = append(written, agentConf)
+	}
+
+	return written, nil
+}
+
+// updateFile rewrites the file at path, a missing one read as empty, with
+// update's result when it reports a change.
+func updateFile(fm domain.FileManager, path string, update func(string) (string, bool)) (bool, error) {
+	content, err := fm.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	updated, changed := update(string(content))
+	if !changed {
+		return false, nil
+	}
+
+	if err := fm.WriteFile(path, []byte(updated)); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package apps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigurePasswordManager(t *testing.T) {
+	home := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".gnupg"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".gnupg", "gpg-agent.conf"), []byte("default-cache-ttl 600"), 0o600))
+
+	manager, _ := domain.PasswordManagerFor("pass")
+	fm := platform.NewFileManager(false)
+
+	written, err := apps.ConfigurePasswordManager(fm, home, manager)
+	require.NoError(t, err)
+	assert.Len(t, written, 3)
+
+	agentConf, err := os.ReadFile(filepath.Join(home, ".gnupg", "gpg-agent.conf"))
+	require.NoError(t, err)
+	assert.Equal(t, "default-cache-ttl 600\nenable-ssh-support\n", string(agentConf))
+
+	sshConfig, err := os.ReadFile(filepath.Join(home, ".ssh", "config"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.SSHIncludeLine+"\n", string(sshConfig))
+
+	// Only the agent configuration is rewritten the second time
+	written, err = apps.ConfigurePasswordManager(fm, home, manager)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(home, ".ssh", "config.d", "karei-pass.conf")}, written)
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package apps
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrNoMatch indicates a pattern selecting no app in the catalog.
+	ErrNoMatch = errors.New("no app in the catalog matches")
+	// ErrInvalidPattern indicates a malformed glob pattern or regular expression.
+	ErrInvalidPattern = errors.New("invalid pattern")
+)
+
+// IsPattern reports whether arg is a glob pattern rather than an app name.
+func IsPattern(arg string) bool {
+	return strings.ContainsAny(arg, "*?[")
+}
+
+// MatchGlob returns the catalog keys pattern matches, sorted. A pattern with
+// a slash is matched against group/key, as in golang/*, otherwise against
+// the key.
+func MatchGlob(pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPattern, pattern)
+	}
+
+	return selectKeys(pattern, func(name string) bool {
+		matched, _ := path.Match(pattern, name)
+
+		return matched
+	})
+}
+
+// MatchRegexp returns the catalog keys expr matches, sorted. Like a glob, an
+// expression with a slash is matched against group/key, otherwise against
+// the key.
+func MatchRegexp(expr string) ([]string, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPattern, err)
+	}
+
+	return selectKeys(expr, re.MatchString)
+}
+
+// selectKeys returns the catalog keys whose key, or group/key when pattern
+// has a slash, match.
+func selectKeys(pattern string, match func(string) bool) ([]string, error) {
+	var keys []string
+
+	for key, app := range Apps {
+		name := key
+		if strings.Contains(pattern, "/") {
+			name = app.Group + "/" + key
+		}
+
+		if match(name) {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatch, pattern)
+	}
+
+	slices.Sort(keys)
+
+	return keys, nil
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package apps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchGlob(t *testing.T) {
+	keys, err := apps.MatchGlob("golang/*")
+	require.NoError(t, err)
+	assert.NotEmpty(t, keys)
+
+	for _, key := range keys {
+		assert.Equal(t, "golang", apps.Apps[key].Group, key)
+	}
+
+	assert.True(t, slices.IsSorted(keys))
+
+	keys, err = apps.MatchGlob("rus?")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rust"}, keys)
+
+	_, err = apps.MatchGlob("nosuch-*")
+	require.ErrorIs(t, err, apps.ErrNoMatch)
+
+	_, err = apps.MatchGlob("[")
+	require.ErrorIs(t, err, apps.ErrInvalidPattern)
+
+	assert.True(t, apps.IsPattern("golang/*"))
+	assert.False(t, apps.IsPattern("git"))
+}
+
+func TestMatchRegexp(t *testing.T) {
+	keys, err := apps.MatchRegexp("^rust")
+	require.NoError(t, err)
+	assert.Contains(t, keys, "rust")
+
+	for _, key := range keys {
+		assert.Regexp(t, "^rust", key)
+	}
+
+	keys, err = apps.MatchRegexp("^rustlang/")
+	require.NoError(t, err)
+	assert.Greater(t, len(keys), 1)
+
+	_, err = apps.MatchRegexp("(")
+	require.ErrorIs(t, err, apps.ErrInvalidPattern)
+}
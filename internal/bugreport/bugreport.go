@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package bugreport
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Redacted replaces scrubbed values.
+const Redacted = "[REDACTED]"
+
+const filePerm = 0o644
+
+var (
+	// secretAssignment matches key = value, key: value and KEY=value pairs
+	// whose key names a credential.
+	secretAssignment = regexp.MustCompile(`(?i)((?:token|secret|password|passwd|api[_-]?key|auth|credential|private[_-]?key)[\w.-]*"?\s*[:=]\s*)((?:bearer|basic)\s+\S+|"[^"]*"|'[^']*'|\S+)`)
+	// bearerToken matches HTTP authorization headers.
+	bearerToken = regexp.MustCompile(`(?i)(bearer|basic)\s+[A-Za-z0-9._~+/=-]{8,}`)
+	// urlCredentials matches user:password@ in URLs.
+	urlCredentials = regexp.MustCompile(`(://)[^/\s:@]+:[^/\s@]+@`)
+	// knownTokens matches token formats recognisable without a key name.
+	knownTokens = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,}|glpat-[A-Za-z0-9_-]{20,}|AKIA[0-9A-Z]{16})\b`)
+)
+
+// Scrubber removes credentials and personal details from report contents.
+type Scrubber struct {
+	home string
+	user *regexp.Regexp
+}
+
+// NewScrubber creates a scrubber that also replaces the home directory with
+// ~ and the user name with <user>.
+func NewScrubber(home, user string) *Scrubber {
+	scrubber := &Scrubber{home: strings.TrimSuffix(home, "/")}
+
+	// Short names such as "dev" would mangle ordinary words
+	if len(user) >= 3 {
+		scrubber.user = regexp.MustCompile(`\b` + regexp.QuoteMeta(user) + `\b`)
+	}
+
+	return scrubber
+}
+
+// Scrub returns text with secrets redacted and personal details replaced.
+func (s *Scrubber) Scrub(text string) string {
+	text = secretAssignment.ReplaceAllString(text, "${1}"+Redacted)
+	text = bearerToken.ReplaceAllString(text, "${1} "+Redacted)
+	text = urlCredentials.ReplaceAllString(text, "${1}"+Redacted+"@")
+	text = knownTokens.ReplaceAllString(text, Redacted)
+
+	if s.home != "" && s.home != "/" {
+		text = strings.ReplaceAll(text, s.home, "~")
+	}
+
+	if s.user != nil {
+		text = s.user.ReplaceAllString(text, "<user>")
+	}
+
+	return text
+}
+
+// Tail returns the last lines of text.
+func Tail(text string, lines int) string {
+	all := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+
+	return strings.Join(all, "\n") + "\n"
+}
+
+// File is one entry of the report archive.
+type File struct {
+	Name    string
+	Content string
+}
+
+// Write packs files into a gzipped tarball under a directory named dir,
+// scrubbing every file on the way.
+func Write(w io.Writer, dir string, files []File, scrubber *Scrubber, modified time.Time) error {
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	for _, file := range files {
+		content := []byte(scrubber.Scrub(file.Content))
+
+		header := &tar.Header{
+			Name:    dir + "/" + file.Name,
+			Mode:    filePerm,
+			Size:    int64(len(content)),
+			ModTime: modified,
+		}
+
+		if err := archive.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to add %s: %w", file.Name, err)
+		}
+
+		if _, err := archive.Write(content); err != nil {
+			return fmt.Errorf("failed to add %s: %w", file.Name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+// Package bugreport packs diagnostics into an archive users can attach to
+// issues, with secrets and personal details scrubbed.
+package bugreport
@@ -18,44 +18,42 @@ import (
 	"github.com/janderssonse/karei/internal/config"
 	"github.com/janderssonse/karei/internal/desktop"
 	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/stringutil"
 	"github.com/janderssonse/karei/internal/tui"
+	"github.com/janderssonse/karei/internal/xdg"
 	"github.com/urfave/cli/v3"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// Exit codes follow standard Unix conventions for better scripting support.
-// Range 0-125 are safe to use (126+ have special meaning in shells).
+// Exit codes, registered in domain; see karei exit-codes.
 const (
-	// Standard Unix exit codes (0-10).
-	ExitSuccess         = 0 // Operation completed successfully
-	ExitGeneralError    = 1 // Generic failure (catch-all)
-	ExitUsageError      = 2 // Invalid command line usage
-	ExitConfigError     = 3 // Configuration file error
-	ExitPermissionError = 4 // Permission denied
-	ExitNotFoundError   = 5 // Requested resource not found
-
-	// Network and system errors (10-19).
-	ExitDependencyError = 10 // Missing dependency
-	ExitNetworkError    = 11 // Network operation failed
-	ExitSystemError     = 12 // System call failed
-	ExitTimeoutError    = 13 // Operation timed out
-	ExitInterruptError  = 14 // User interrupted (Ctrl+C)
-
-	// Application-specific errors (20-29).
-	ExitThemeError     = 20 // Theme operation failed
-	ExitFontError      = 21 // Font operation failed
-	ExitAppError       = 22 // App installation/removal failed
-	ExitBackupError    = 23 // Backup operation failed
-	ExitMigrationError = 24 // Migration failed
-
-	// Warning (non-fatal issues occurred).
-	ExitWarnings = 64 // Operation succeeded with warnings
+	ExitSuccess         = domain.ExitSuccess
+	ExitGeneralError    = domain.ExitGeneralError
+	ExitUsageError      = domain.ExitUsageError
+	ExitConfigError     = domain.ExitConfigError
+	ExitPermissionError = domain.ExitPermissionError
+	ExitNotFoundError   = domain.ExitNotFoundError
+	ExitDependencyError = domain.ExitDependencyError
+	ExitNetworkError    = domain.ExitNetworkError
+	ExitSystemError     = domain.ExitSystemError
+	ExitTimeoutError    = domain.ExitTimeoutError
+	ExitInterruptError  = domain.ExitInterruptError
+	ExitLockedError     = domain.ExitLockedError
+	ExitThemeError      = domain.ExitThemeError
+	ExitFontError       = domain.ExitFontError
+	ExitAppError        = domain.ExitAppError
+	ExitBackupError     = domain.ExitBackupError
+	ExitMigrationError  = domain.ExitMigrationError
+	ExitWarnings        = domain.ExitWarnings
+)
 
+const (
 	// CLI flags.
 	HelpFlag = "--help"
 
@@ -98,6 +96,29 @@ type CLI struct {
 	timeout time.Duration // Network operation timeout
 	yes     bool          // Auto-accept all prompts
 
+	preferMethod     string                  // Raw --prefer-method value
+	preference       domain.MethodPreference // Install methods to favor over catalog defaults
+	flatpakScope     string                  // Raw --flatpak-scope value
+	flatpak          domain.FlatpakScopes    // User or system Flatpak installation per app
+	lockTimeout      time.Duration           // How long to wait for the dpkg lock (0 = config or default)
+	aptFrontend      domain.APTFrontend      // Configured command for APT installs, auto-detected by default
+	offPeak          domain.OffPeak          // Time of day installs wait for before downloading
+	downloadNow      bool                    // --now: don't wait for the off-peak window
+	meteredThreshold int64                   // Bytes that may be downloaded on a metered connection without asking
+	forceDownload    bool                    // --force-download: don't ask on a metered connection
+	policy           domain.InstallPolicy    // Licenses, methods and origins config.toml forbids
+	channels         domain.ReleaseChannels  // GitHub release channel per app from config.toml
+	usageStats       bool                    // Record command usage locally, opted in through config.toml
+	webhookURL       string                  // Where to send operation events, from config.toml
+	webhook          *network.Webhook        // Delivers operation events while a command runs
+	events           *domain.EventBus        // Operation events of the services, nil until subscribed to
+	proxy            network.ProxySettings   // Proxy from config.toml; detected when unset
+	connectivity     domain.Connectivity     // Network state from the preflight check, unknown until checked
+	deprecation      *domain.Deprecation     // Old command form the invocation was rewritten from
+	listed           []domain.ListedPackage  // Packages read with --stdin, by line
+
+	configConflicts domain.ConfigResolution // Decision of --force or --keep-existing for changed config files
+
 	// Services for business logic
 	installService   *application.InstallService
 	themeService     *application.ThemeService
@@ -204,21 +225,39 @@ SUPPORT:
 				Usage:       "automatically answer yes to all prompts",
 				Destination: &app.yes,
 			},
+			&cli.StringFlag{
+				Name:        "prefer-method",
+				Usage:       "comma-separated install methods to favor, e.g. flatpak,mise (overrides config.toml)",
+				Destination: &app.preferMethod,
+			},
+			&cli.StringFlag{
+				Name:        "flatpak-scope",
+				Usage:       "install flatpaks for the current user or system-wide: user or system (overrides config.toml)",
+				Destination: &app.flatpakScope,
+			},
+			&cli.DurationFlag{
+				Name:        "apt-lock-timeout",
+				Usage:       "how long to wait for apt/dpkg locks held by other processes such as unattended-upgrades (overrides config.toml)",
+				Destination: &app.lockTimeout,
+			},
 		},
 		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
 			return app.initConfig(ctx, cmd)
 		},
+		After:           app.afterCommand,
 		Action:          app.defaultAction,
 		Commands:        app.createAllCommands(),
 		CommandNotFound: app.commandNotFound,
 	}
 
+	app.trackUsage(app.app)
+
 	return app
 }
 
 // Run executes the CLI application.
 func (app *CLI) Run(ctx context.Context, args []string) error {
-	return app.app.Run(ctx, args)
+	return app.app.Run(ctx, app.migrateArgs(args))
 }
 
 // createAllCommands creates all CLI commands.
@@ -246,6 +285,7 @@ func (app *CLI) createSpecialCommands() []*cli.Command {
 		app.createUpdateCommand(),
 		app.createUninstallCommand(),
 		app.createListCommand(),
+		app.createInfoCommand(),
 		app.createSetupCommand(),
 		app.createAppsCommand(),
 		app.createDesktopCommand(),
@@ -254,15 +294,41 @@ func (app *CLI) createSpecialCommands() []*cli.Command {
 		app.createFontSizeCommand(),
 		app.createHelpCommand(),
 		app.createStatusCommand(),
+		app.createDiffCommand(),
 		app.createTUICommand(),
+		app.createServeCommand(),
+		app.createRemoteCommand(),
+		app.createFleetCommand(),
+		app.createGenerateCommand(),
+		app.createSBOMCommand(),
+		app.createAuditCommand(),
+		app.createPrivacyCommand(),
+		app.createBugReportCommand(),
+		app.createPathsCommand(),
+		app.createExitCodesCommand(),
+		app.createDeprecationsCommand(),
+		app.createTrashCommand(),
+		app.createTemplateCommand(),
+		app.createSecretCommand(),
+		app.createProvisionCommand(),
+		app.createRetryCommand(),
+		app.createApplyCommand(),
+		app.createUseCommand(),
+		app.createActivateCommand(),
+		app.createTeamCommand(),
+		app.createTrustCommand(),
+		app.createCatalogCommand(),
+		app.createCleanCommand(),
+		app.createSystemCommand(),
 	}
 }
 
 // createInstallCommand creates install command with flag-based interface.
 func (app *CLI) createInstallCommand() *cli.Command {
 	return &cli.Command{
-		Name:  "install",
-		Usage: "Install development tools and applications",
+		Name:      "install",
+		Usage:     "Install development tools and applications",
+		ArgsUsage: "[app or pattern...]",
 		Description: `Install packages, tools, or application groups.
 
 Groups available:
@@ -273,8 +339,86 @@ Groups available:
 Examples:
   karei install --packages git,vim      # Install specific packages
   karei install --group development      # Install development group
-  karei install --packages git --json   # Output JSON results`,
-		Flags: []cli.Flag{
+  cat tools.txt | karei install --stdin  # Install the packages listed one per line
+  karei install 'golang/*'               # Install every app in the golang group
+  karei install --match '^rust'          # Install the apps whose name starts with rust
+  karei install --packages git --json   # Output JSON results
+  karei install --packages git --container dev  # Install into toolbox/distrobox "dev"
+  karei install --group development --scope user  # Only user-scope installs, no sudo
+  karei install --packages zed --scope system     # Flatpak for all users
+  karei install --packages docker.io --replace  # Swap podman-docker for docker
+  sudo karei install -g terminal --for-user alice,bob  # Set up lab accounts
+  karei install -p lazygit --constraint lazygit==0.44.1  # Pin a version
+
+Inside a toolbox or distrobox container, installed binaries and desktop
+entries are exported to the host automatically.
+
+With --scope user (or --no-sudo), apps that need root (apt, deb, snap,
+scripts) are skipped unless they are also offered through a user-scope method
+such as flatpak --user, mise, aqua or a binary download. Fonts always go to
+~/.local/share/fonts. With --scope system, flatpaks are installed for all
+users and apps offered through a system package use it.
+
+The method and scope used are recorded, so karei status shows them and
+karei uninstall removes the app from the same place.
+
+Version constraints such as ">=20 <21" or "=0.44.1" come from the catalog,
+a profile's versions or --constraint. An exact "=" version is installed as
+such by mise; otherwise the installed version is checked afterwards and the
+install fails when it is outside the constraint. The constraint is recorded
+and checked again by karei verify --deep.
+
+With --for-user, run as root on shared machines, the install is repeated as
+each listed user with --scope user, so their shell configuration, fonts and
+desktop entries are written to their home and owned by them. Each user gets
+their own journal, so karei retry works when they log in.
+
+Apps can also be named as arguments, where glob patterns select apps from
+the catalog by name, or by group/name as in 'golang/*'. --match does the same
+with a regular expression. What a pattern matched is listed for confirmation
+before anything is installed; --yes skips the prompt.
+
+With --stdin, each line of the input names one package. Blank lines and
+text after # are ignored, so a commented tools.txt or the output of another
+command can be piped in. Each package's result is reported with its line.
+
+Apps APT, Flatpak or Snap already has installed are skipped as "already
+installed" without running their installer, so repeating an install is
+quick. Apps held to a version constraint are always installed, to check it.
+
+Apps that conflict with an installed app (docker.io and podman-docker, tlp
+and power-profiles-daemon) are refused unless --replace is given, which
+uninstalls the installed one first.
+
+Apps the [policy] deny list in config.toml forbids are refused, or skipped
+when part of a group.
+
+APT packages are installed through nala or apt-fast when either is
+installed, for their parallel downloads, and with apt-get when they fail.
+Set apt_frontend under [install] in config.toml to apt, nala or apt-fast
+to choose.
+
+With webhook set under [notify] in config.toml, each app's start, completion
+or failure is posted there as JSON, for a Slack, Mattermost or Matrix room to
+follow long runs. Home directory, user name and secrets are redacted.
+
+With --json, the same events are written to stderr as they happen, one JSON
+object per line with "finished" and "total" app counts, while the result is
+written to stdout at the end. On a terminal, one line redrawn in place shows
+the app counter and the installer's current step instead of its full output,
+unless sudo may ask for a password or --verbose is given.
+
+Set bandwidth_limit under [download] in config.toml (or
+KAREI_BANDWIDTH_LIMIT) to cap karei's own and apt's downloads, such as
+"2MB" per second. With off_peak, such as "01:00-06:00", installs wait until
+the window opens before downloading; --now starts right away.
+
+When NetworkManager reports the connection as metered, karei warns how much
+the install should download, and asks first when that's over
+metered_threshold under [download] (200MB by default). --force-download
+goes ahead without asking. How much the network received during the run is
+shown at the end.`,
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:    "packages",
 				Aliases: []string{"p"},
@@ -285,7 +429,36 @@ Examples:
 				Aliases: []string{"g"},
 				Usage:   "install a predefined group of packages (essential, development, productivity)",
 			},
-		},
+			&cli.BoolFlag{
+				Name:  "stdin",
+				Usage: "read the packages from stdin, one per line; # starts a comment",
+			},
+			matchFlag(),
+			&cli.StringFlag{
+				Name:  "container",
+				Usage: "install inside the named toolbox or distrobox container instead of the host",
+			},
+			&cli.StringFlag{
+				Name:  "scope",
+				Usage: "install for the current user only (no sudo) or system-wide: user or system",
+			},
+			&cli.BoolFlag{
+				Name:  "no-sudo",
+				Usage: "same as --scope user",
+			},
+			&cli.StringFlag{
+				Name:  "for-user",
+				Usage: "comma-separated users to install for, as root, in user scope",
+			},
+			&cli.BoolFlag{
+				Name:  "replace",
+				Usage: "uninstall installed apps that conflict with the requested ones first",
+			},
+			&cli.StringSliceFlag{
+				Name:  "constraint",
+				Usage: "hold an app to a version constraint, e.g. 'node=>=20 <21' (repeatable)",
+			},
+		}, downloadFlags()...),
 		Action: app.handleInstallAction,
 	}
 }
@@ -298,14 +471,83 @@ func (app *CLI) handleInstallAction(ctx context.Context, cmd *cli.Command) error
 func (app *CLI) ensureInstallService() {
 	if app.installService == nil {
 		commandRunner := platform.NewCommandRunner(app.verbose, false)
+		for command, variables := range app.secretEnvironment() {
+			commandRunner.AddCommandEnv(command, variables...)
+		}
+
 		fileManager := platform.NewFileManager(app.verbose)
 		systemDetector := platform.NewSystemDetector(commandRunner, fileManager)
 		packageInstaller := ubuntu.NewPackageInstaller(commandRunner, fileManager, app.verbose, false)
 		packageService := domain.NewPackageService(packageInstaller, systemDetector)
 		app.installService = application.NewInstallService(packageService, systemDetector)
+
+		if session := systemDetector.DetectContainerSession(context.Background()); session != nil {
+			home, _ := os.UserHomeDir()
+			app.installService.SetContainerExporter(platform.NewContainerExporter(commandRunner, fileManager, session, home))
+		}
 	}
 
+	app.installService.SetMethodPreference(app.preference)
+	app.installService.SetFlatpakScopes(app.flatpak)
+	app.installService.SetInstallPolicy(app.policy)
+	app.installService.SetLockTimeout(app.lockTimeout)
+	app.installService.SetAPTFrontend(app.detectAPTFrontend())
 	app.installService.SetVerbose(app.verbose)
+	app.installService.SetInstallRecords(app.installRecords())
+	app.installService.SetManifests(app.manifests())
+	app.installService.SetInstallMetrics(application.NewInstallMetrics(platform.NewFileManager(false), xdg.MetricsFile()))
+	app.installService.SetVersionResolver(platform.NewVersionResolver(platform.NewCommandRunner(false, false)))
+	app.installService.SetKernelInspector(platform.NewKernelInspector(platform.NewCommandRunner(false, false)))
+	app.installService.SetNoteFunc(func(note string) { console.DefaultOutput.Warningf("%s", note) })
+}
+
+// installRecords returns the record of how each app was installed.
+func (app *CLI) installRecords() *application.InstallRecords {
+	return application.NewInstallRecords(platform.NewFileManager(false), xdg.InstallRecordsFile())
+}
+
+// manifests returns the list of files karei created for each app.
+func (app *CLI) manifests() *application.Manifests {
+	return application.NewManifests(platform.NewFileManager(false), xdg.ManifestDir())
+}
+
+// installScope parses --scope, with --no-sudo as shorthand for the user scope.
+func installScope(cmd *cli.Command) (domain.InstallScope, error) {
+	scope, err := domain.ParseInstallScope(cmd.String("scope"))
+	if err != nil {
+		return "", domain.NewExitError(ExitUsageError, "invalid --scope value: must be user or system", err)
+	}
+
+	if cmd.Bool("no-sudo") {
+		if scope == domain.ScopeSystem {
+			return "", domain.NewExitError(ExitUsageError, "cannot use --no-sudo with --scope system", nil)
+		}
+
+		scope = domain.ScopeUser
+	}
+
+	return scope, nil
+}
+
+// installConstraints parses the repeatable --constraint app=constraint flag.
+func installConstraints(cmd *cli.Command) (domain.VersionConstraints, error) {
+	values := make(map[string]string)
+
+	for _, value := range cmd.StringSlice("constraint") {
+		key, constraint, found := strings.Cut(value, "=")
+		if !found || strings.TrimSpace(key) == "" {
+			return nil, domain.NewExitError(ExitUsageError, "invalid --constraint value, expected app=constraint: "+value, nil)
+		}
+
+		values[strings.TrimSpace(key)] = constraint
+	}
+
+	constraints, err := domain.ParseVersionConstraints(values)
+	if err != nil {
+		return nil, domain.NewExitError(ExitUsageError, err.Error(), err)
+	}
+
+	return constraints, nil
 }
 
 // runInstall handles the install command execution with output adapter.
@@ -323,23 +565,94 @@ func (app *CLI) runInstall(ctx context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
+	app.parseDownloadFlags(cmd)
+
 	// Validate and get flags
 	packagesFlag, groupFlag, err := app.validateInstallFlags(cmd)
+	if errors.Is(err, errSelectionCancelled) {
+		return output.Info("Install cancelled.")
+	}
+
 	if err != nil {
 		return err
 	}
 
+	if container := cmd.String("container"); container != "" {
+		return app.runInstallInContainer(ctx, container, packagesFlag, groupFlag)
+	}
+
+	if forUser := cmd.String("for-user"); forUser != "" {
+		if cmd.String("scope") == string(domain.ScopeSystem) {
+			return domain.NewExitError(ExitUsageError, "cannot use --for-user with --scope system", nil)
+		}
+
+		return app.runInstallForUsers(ctx, forUser, packagesFlag, groupFlag)
+	}
+
 	// Ensure service is initialized
 	app.ensureInstallService()
+	scope, err := installScope(cmd)
+	if err != nil {
+		return err
+	}
+
+	app.installService.SetInstallScope(scope)
+	app.installService.SetConnectivity(app.checkConnectivity(ctx))
+
+	constraints, err := installConstraints(cmd)
+	if err != nil {
+		return err
+	}
+
+	app.installService.SetVersionConstraints(constraints)
+
+	if err := app.checkInstallPolicy(packagesFlag); err != nil {
+		return err
+	}
+
+	err = app.confirmMeteredDownload(ctx, requestedApps(packagesFlag, groupFlag))
+	if errors.Is(err, errSelectionCancelled) {
+		return output.Info("Install cancelled.")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	run := application.NewJournalRun()
+
+	replaced, err := app.resolveInstallConflicts(ctx, requestedApps(packagesFlag, groupFlag), cmd.Bool("replace"), output)
+	if replaced != nil {
+		run.AddUninstallResult(replaced)
+	}
+
+	if err != nil {
+		app.recordRun(run)
+
+		return err
+	}
+
+	app.announceEstimate(requestedApps(packagesFlag, groupFlag))
+
+	if err := app.waitForOffPeak(ctx); err != nil {
+		return err
+	}
 
 	// Execute installation
+	reportDownloads := app.measureDownloads()
 	result := app.executeInstallation(ctx, packagesFlag, groupFlag, output)
 
+	run.AddInstallResult(result)
+	app.recordRun(run)
+
 	// Output results
 	if err := app.outputInstallResults(result, output); err != nil {
 		return domain.NewExitError(ExitGeneralError, "failed to output results", err)
 	}
 
+	app.offerShellSetup(result)
+	reportDownloads()
+
 	return app.getInstallExitCode(result)
 }
 
@@ -353,6 +666,19 @@ func (app *CLI) applyTimeout(ctx context.Context) (context.Context, context.Canc
 	return ctx, func() {} // No-op cancel for when no timeout is set
 }
 
+// announceEstimate tells how long installing the apps should take, judging by
+// earlier installs. Nothing is said when none of them has history, or when
+// nothing will be installed for lack of a network.
+func (app *CLI) announceEstimate(keys []string) {
+	estimate, known := app.installService.EstimateInstall(keys)
+	if known == 0 || !app.connectivity.Available() {
+		return
+	}
+
+	total := len(apps.WithDependencies(keys))
+	console.DefaultOutput.Progressf("Estimated time: ~%s, from earlier installs of %d/%d apps", stringutil.FormatDuration(estimate.Round(time.Second)), known, total)
+}
+
 // executeInstallation performs the actual installation of packages or groups.
 func (app *CLI) executeInstallation(ctx context.Context, packagesFlag, groupFlag string, output domain.OutputPort) *domain.InstallResult {
 	startTime := time.Now()
@@ -363,7 +689,9 @@ func (app *CLI) executeInstallation(ctx context.Context, packagesFlag, groupFlag
 
 	// Install group if specified
 	if groupFlag != "" {
+		stopProgress := app.streamProgress(ctx, apps.Groups[groupFlag])
 		result, err = app.installService.InstallGroup(ctx, groupFlag)
+		stopProgress()
 
 		if err != nil && !errors.Is(err, apps.ErrUnknownGroup) {
 			_ = output.Error("Failed to install group: " + err.Error())
@@ -376,7 +704,10 @@ func (app *CLI) executeInstallation(ctx context.Context, packagesFlag, groupFlag
 	if packagesFlag != "" {
 		packages := strings.Split(packagesFlag, ",")
 
+		stopProgress := app.streamProgress(ctx, packages)
 		result, err = app.installService.InstallPackages(ctx, packages)
+		stopProgress()
+
 		if err != nil {
 			_ = output.Error("Installation errors occurred")
 		}
@@ -388,6 +719,10 @@ func (app *CLI) executeInstallation(ctx context.Context, packagesFlag, groupFlag
 	if result != nil {
 		result.Duration = time.Since(startTime)
 		result.Timestamp = startTime
+		result.Lines = listedResults(app.listed,
+			listedStatus{"installed", result.Installed},
+			listedStatus{"skipped", result.Skipped},
+			listedStatus{"failed", result.Failed})
 	}
 
 	return result
@@ -400,11 +735,25 @@ func (app *CLI) outputInstallProgress(result *domain.InstallResult, output domai
 	}
 
 	for _, pkg := range result.Installed {
-		_ = output.Success(fmt.Sprintf("✓ Installed %s successfully", pkg), nil)
+		_ = output.Success(fmt.Sprintf("✓ %sInstalled %s successfully", app.listedLine(pkg), pkg), nil)
 	}
 
 	for _, pkg := range result.Failed {
-		_ = output.Error("✗ Failed to install " + pkg)
+		msg := "✗ " + app.listedLine(pkg) + "Failed to install " + pkg
+		if hint := domain.ClassifyError(result.Errors[pkg]).Hint(); hint != "" {
+			msg += " (" + hint + ")"
+		}
+
+		_ = output.Error(msg)
+	}
+
+	for _, pkg := range result.Skipped {
+		reason := result.SkipReasons[pkg]
+		if reason == "" {
+			reason = domain.SkipRequiresRoot
+		}
+
+		_ = output.Info("⏭ " + app.listedLine(pkg) + "Skipped " + pkg + " (" + reason + ")")
 	}
 }
 
@@ -419,7 +768,7 @@ func (app *CLI) outputInstallResults(result *domain.InstallResult, output domain
 	}
 
 	// For text output, provide summary
-	if len(result.Installed) > 0 || len(result.Failed) > 0 {
+	if len(result.Installed) > 0 || len(result.Failed) > 0 || len(result.Skipped) > 0 {
 		summary := app.buildResultSummary(
 			len(result.Installed),
 			len(result.Failed),
@@ -460,14 +809,36 @@ func (app *CLI) buildResultSummary(successCount, failedCount, skippedCount int,
 	}
 
 	// Add duration
-	summary.WriteString(fmt.Sprintf(" (%.2fs)", duration.Seconds()))
+	summary.WriteString(" (" + stringutil.FormatDuration(duration) + ")")
 
 	return summary.String()
 }
 
+// kindExitCodes maps classified failure kinds to exit codes.
+var kindExitCodes = map[domain.ErrorKind]int{
+	domain.ErrorKindNetwork:    ExitNetworkError,
+	domain.ErrorKindPermission: ExitPermissionError,
+	domain.ErrorKindNotFound:   ExitNotFoundError,
+	domain.ErrorKindConflict:   ExitDependencyError,
+	domain.ErrorKindDisk:       ExitSystemError,
+}
+
 // getInstallExitCode returns the appropriate exit code based on results.
 func (app *CLI) getInstallExitCode(result *domain.InstallResult) error {
+	for _, err := range result.Errors {
+		if errors.Is(err, domain.ErrPackageManagerLocked) {
+			msg := "Another package manager is running (often unattended-upgrades).\n"
+			msg += "Wait for it to finish and retry, or wait longer with --apt-lock-timeout 10m"
+
+			return domain.NewExitError(ExitLockedError, msg, err)
+		}
+	}
+
 	if len(result.Failed) > 0 && len(result.Installed) == 0 {
+		if kind := commonFailureKind(result); kind != domain.ErrorKindUnknown {
+			return domain.NewExitError(kindExitCodes[kind], "All installations failed ("+string(kind)+"): "+kind.Hint(), nil)
+		}
+
 		msg := "All installations failed. Common causes:\n"
 		msg += "  • Network issues - check your connection\n"
 		msg += "  • Permission denied - try with sudo\n"
@@ -482,9 +853,44 @@ func (app *CLI) getInstallExitCode(result *domain.InstallResult) error {
 		return domain.NewExitError(ExitWarnings, fmt.Sprintf("%d packages failed to install", len(result.Failed)), nil)
 	}
 
+	reason := app.connectivity.SkipReason()
+	offline := 0
+
+	for _, pkg := range result.Skipped {
+		if reason != "" && result.SkipReasons[pkg] == reason {
+			offline++
+		}
+	}
+
+	if offline > 0 {
+		msg := fmt.Sprintf("%d packages skipped while %s, run 'karei retry --last' once connected", offline, reason)
+
+		return domain.NewExitError(ExitNetworkError, msg, domain.ErrOffline)
+	}
+
 	return nil
 }
 
+// commonFailureKind returns the kind shared by every failure, or unknown when they differ.
+func commonFailureKind(result *domain.InstallResult) domain.ErrorKind {
+	common := domain.ErrorKindUnknown
+
+	for _, pkg := range result.Failed {
+		kind := result.FailureKinds[pkg]
+		if kind == domain.ErrorKindUnknown || (common != domain.ErrorKindUnknown && kind != common) {
+			return domain.ErrorKindUnknown
+		}
+
+		common = kind
+	}
+
+	if _, ok := kindExitCodes[common]; !ok {
+		return domain.ErrorKindUnknown
+	}
+
+	return common
+}
+
 // isKnownGroup checks if the package name is a known application group.
 // createUpdateCommand creates update command.
 func (app *CLI) createUpdateCommand() *cli.Command {
@@ -547,17 +953,27 @@ Available themes:
 				Usage: "Apply a theme system-wide",
 				Description: `Apply a coordinated theme across all applications including GNOME, terminal, editors, and browsers.
 
+On KDE Plasma the desktop gets the matching Breeze color scheme and icons, the
+accent color, the wallpaper and a Karei Konsole profile, plus the Kvantum style
+when Kvantum is installed.
+
+Configuration files you changed are merged with the theme. When your changes
+can't be merged, or karei didn't write the file, you are shown the difference
+and asked whether to overwrite or keep yours. Keeping is remembered until the
+theme's content changes.
+
 Examples:
   karei theme apply --name tokyo-night    # Apply tokyo-night theme
-  karei theme apply -n catppuccin        # Short form`,
-				Flags: []cli.Flag{
+  karei theme apply -n catppuccin        # Short form
+  karei theme apply -n nord --force      # Overwrite changed files, saving yours aside`,
+				Flags: append([]cli.Flag{
 					&cli.StringFlag{
 						Name:     "name",
 						Aliases:  []string{"n"},
 						Usage:    "name of the theme to apply",
 						Required: true,
 					},
-				},
+				}, configConflictFlags()...),
 				Action: app.runThemeApply,
 			},
 			{
@@ -580,6 +996,7 @@ Examples:
   karei theme current --json # Output as JSON`,
 				Action: app.runThemeCurrent,
 			},
+			app.createThemeRotateCommand(),
 		},
 	}
 }
@@ -587,19 +1004,42 @@ Examples:
 // createUninstallCommand creates uninstall command with flag-based interface.
 func (app *CLI) createUninstallCommand() *cli.Command {
 	return &cli.Command{
-		Name:  "uninstall",
-		Usage: "Uninstall packages",
+		Name:      "uninstall",
+		Usage:     "Uninstall packages",
+		ArgsUsage: "[app or pattern...]",
 		Description: `Uninstall packages from the system.
 
+Lists the packages and any catalog apps depending on them, then asks for
+confirmation. Pass --yes to skip the prompt; without a terminal it is required.
+
+Leftover configuration in your home directory is moved to the trash, see
+karei trash, rather than deleted.
+
+Apps can also be named as arguments, with glob patterns such as 'golang/*'
+and --match regular expressions resolved against the catalog; the prompt
+lists what matched.
+
+With --stdin, each line of the input names one package, with blank lines
+and text after # ignored. The prompt can't be answered then, so --yes is
+required.
+
 Examples:
   karei uninstall --packages vim,git    # Uninstall specific packages
-  karei uninstall -p docker,nodejs      # Short form`,
+  karei uninstall -p docker,nodejs      # Short form
+  karei --yes uninstall -p vlc          # No confirmation prompt
+  cat old.txt | karei --yes uninstall --stdin  # Uninstall the listed packages
+  karei uninstall --match '^rust'       # Uninstall the apps whose name starts with rust`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "packages",
 				Aliases: []string{"p"},
 				Usage:   "comma-separated list of packages to uninstall",
 			},
+			&cli.BoolFlag{
+				Name:  "stdin",
+				Usage: "read the packages from stdin, one per line; # starts a comment",
+			},
+			matchFlag(),
 		},
 		Action: app.runUninstall,
 	}
@@ -620,23 +1060,58 @@ func (app *CLI) runUninstall(ctx context.Context, cmd *cli.Command) error {
 
 	// Get packages from flag
 	packagesFlag := cmd.String("packages")
+
+	if cmd.Bool("stdin") {
+		if packagesFlag != "" {
+			return domain.NewExitError(ExitUsageError, "cannot use --stdin with --packages", nil)
+		}
+
+		packages, err := app.readPackageList()
+		if err != nil {
+			return err
+		}
+
+		packagesFlag = packages
+	}
+
+	selected, _, err := selectPackages(cmd)
+	if err != nil {
+		return err
+	}
+
+	if len(selected) > 0 {
+		if cmd.Bool("stdin") {
+			return domain.NewExitError(ExitUsageError, "cannot combine apps or patterns with --stdin", nil)
+		}
+
+		packagesFlag = joinPackages(packagesFlag, selected)
+	}
+
 	if packagesFlag == "" {
-		return domain.NewExitError(ExitUsageError, "specify --packages flag with comma-separated list of packages", nil)
+		return domain.NewExitError(ExitUsageError, "specify apps or --packages with a comma-separated list of packages", nil)
 	}
 
-	// Initialize uninstall service if needed
-	if app.uninstallService == nil {
-		commandRunner := platform.NewCommandRunner(app.verbose, false)
-		fileManager := platform.NewFileManager(app.verbose)
-		packageInstaller := ubuntu.NewPackageInstaller(commandRunner, fileManager, app.verbose, false)
-		app.uninstallService = application.NewUninstallService(fileManager, commandRunner, packageInstaller, app.verbose)
+	packages := strings.Split(packagesFlag, ",")
+
+	// Uninstalling is destructive, so confirm unless --yes was given
+	if !app.yes {
+		if !console.DefaultOutput.IsTTY(os.Stdin.Fd()) {
+			return domain.NewExitError(ExitUsageError, "refusing to uninstall without confirmation, pass --yes to skip the prompt", nil)
+		}
+
+		if !console.AskUninstall(packages, uninstallWarnings(packages)) {
+			_ = output.Info("Uninstall cancelled.")
+
+			return nil
+		}
 	}
 
+	app.ensureUninstallService()
+
 	// Track uninstallation time
 	startTime := time.Now()
 
 	// Use service to uninstall packages
-	packages := strings.Split(packagesFlag, ",")
 
 	result, err := app.uninstallService.UninstallPackages(ctx, packages)
 	if err != nil {
@@ -645,17 +1120,26 @@ func (app *CLI) runUninstall(ctx context.Context, cmd *cli.Command) error {
 
 	// Output progress for each package
 	for _, pkg := range result.Uninstalled {
-		_ = output.Success("✓ Uninstalled "+pkg, nil)
+		_ = output.Success("✓ "+app.listedLine(pkg)+"Uninstalled "+pkg, nil)
 	}
 
 	for _, pkg := range result.Failed {
-		_ = output.Error("✗ Failed to uninstall " + pkg)
+		_ = output.Error("✗ " + app.listedLine(pkg) + "Failed to uninstall " + pkg)
 	}
 
 	for _, pkg := range result.NotFound {
-		_ = output.Info(fmt.Sprintf("⚠ %s not installed", pkg))
+		_ = output.Info(fmt.Sprintf("⚠ %s%s not installed", app.listedLine(pkg), pkg))
 	}
 
+	result.Lines = listedResults(app.listed,
+		listedStatus{"uninstalled", result.Uninstalled},
+		listedStatus{"failed", result.Failed},
+		listedStatus{"not-installed", result.NotFound})
+
+	run := application.NewJournalRun()
+	run.AddUninstallResult(result)
+	app.recordRun(run)
+
 	// Calculate duration
 	result.Duration = time.Since(startTime)
 	result.Timestamp = startTime
@@ -669,19 +1153,47 @@ func (app *CLI) runUninstall(ctx context.Context, cmd *cli.Command) error {
 	return app.getUninstallExitCode(result)
 }
 
+// uninstallWarnings names catalog apps that depend on the packages being
+// removed and are not removed along with them.
+func uninstallWarnings(packages []string) []string {
+	var warnings []string
+
+	for _, pkg := range packages {
+		var remaining []string
+
+		for _, dependent := range apps.Dependents(pkg) {
+			if !slices.Contains(packages, dependent) {
+				remaining = append(remaining, dependent)
+			}
+		}
+
+		if len(remaining) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s is needed by %s", pkg, strings.Join(remaining, ", ")))
+		}
+	}
+
+	return warnings
+}
+
+// ensureUninstallService initializes the uninstall service if not already done.
+func (app *CLI) ensureUninstallService() {
+	if app.uninstallService == nil {
+		commandRunner := platform.NewCommandRunner(app.verbose, false)
+		fileManager := platform.NewFileManager(app.verbose)
+		packageInstaller := ubuntu.NewPackageInstaller(commandRunner, fileManager, app.verbose, false)
+		app.uninstallService = application.NewUninstallService(fileManager, commandRunner, packageInstaller, app.verbose)
+	}
+}
+
 // runThemeApply handles the theme apply subcommand.
 func (app *CLI) runThemeApply(ctx context.Context, cmd *cli.Command) error {
 	themeName := cmd.String("name")
 
-	// Apply theme using service directly
-	fileManager := platform.NewFileManager(false)
-	commandRunner := platform.NewCommandRunner(app.verbose, false)
-	configPath := config.GetXDGConfigHome()
-	themesPath := filepath.Join(config.GetKareiPath(), "themes")
-
-	themeService := application.NewThemeService(fileManager, commandRunner, configPath, themesPath)
+	if err := app.parseConfigConflictFlags(cmd); err != nil {
+		return err
+	}
 
-	if err := themeService.ApplyTheme(ctx, themeName); err != nil {
+	if err := app.newThemeService().ApplyTheme(ctx, themeName); err != nil {
 		return err
 	}
 
@@ -704,10 +1216,59 @@ func (app *CLI) runThemeList(_ context.Context, _ *cli.Command) error {
 	return nil
 }
 
+// newThemeService creates the theme service, recording applied themes so the TUI can match them.
+// On KDE Plasma the desktop-wide parts of themes go through the Plasma themer.
+func (app *CLI) newThemeService() *application.ThemeService {
+	fileManager := platform.NewFileManager(false)
+	commandRunner := platform.NewCommandRunner(app.verbose, false)
+	detector := platform.NewSystemDetector(platform.NewCommandRunner(false, false), fileManager)
+
+	themeService := application.NewThemeService(
+		fileManager,
+		commandRunner,
+		config.GetXDGConfigHome(),
+		filepath.Join(config.GetKareiPath(), "themes"),
+	)
+	themeService.SetCurrentThemeFile(xdg.ThemeFile())
+	themeService.SetConfigFiles(app.configFiles())
+	themeService.SetTemplates(app.templates())
+	themeService.SetSession(detector.DetectSessionType(context.Background()))
+	themeService.SetNoteFunc(func(note string) { console.DefaultOutput.Warningf("%s", note) })
+
+	if desktop, err := detector.DetectDesktopEnvironment(context.Background()); err == nil && domain.IsPlasma(desktop.Name) {
+		themeService.SetDesktopThemer(platform.NewPlasmaThemer(commandRunner, fileManager, xdg.DataHome()))
+	}
+
+	return themeService
+}
+
+// configFiles returns the record of configuration files karei wrote.
+func (app *CLI) configFiles() *application.ConfigFiles {
+	configs := application.NewConfigFiles(platform.NewFileManager(false), xdg.ManagedConfigDir())
+	configs.SetTemplates(app.templates())
+	configs.SetConflictResolver(app.resolveConfigConflict)
+
+	return configs
+}
+
+// templates returns karei's built-in templates with the user's overrides.
+func (app *CLI) templates() *application.Templates {
+	return application.NewTemplates(platform.NewFileManager(false), config.GetKareiPath(), xdg.TemplateDir())
+}
+
 // runThemeCurrent handles the theme current subcommand.
 func (app *CLI) runThemeCurrent(_ context.Context, _ *cli.Command) error {
-	// For now, just indicate that current theme detection is not implemented
-	console.DefaultOutput.Result("Current theme detection not yet implemented")
+	theme := app.getCurrentTheme()
+	if theme == "" {
+		return domain.NewExitError(ExitNotFoundError, "no theme applied yet, run 'karei theme apply --name <theme>'", nil)
+	}
+
+	if app.json {
+		return cliAdapter.OutputFromContext(app.json, app.quiet).Success("", map[string]string{"theme": theme})
+	}
+
+	console.DefaultOutput.Result(theme)
+
 	return nil
 }
 
@@ -862,17 +1423,33 @@ func (app *CLI) getUninstallExitCode(result *domain.UninstallResult) error {
 // createListCommand creates list command to show installed packages.
 func (app *CLI) createListCommand() *cli.Command {
 	return &cli.Command{
-		Name:   "list",
-		Usage:  "List installed packages",
+		Name:      "list",
+		Usage:     "List installed packages",
+		ArgsUsage: "[app or pattern...]",
+		Description: `List installed apps, flatpaks, the theme and the font.
+
+Arguments and --match narrow the list to the apps they select, with glob
+patterns and regular expressions resolved against the catalog.
+
+Examples:
+  karei list                    # Everything installed
+  karei list 'golang/*'         # Installed apps of the golang group
+  karei list --match '^rust'    # Installed apps whose name starts with rust`,
+		Flags:  []cli.Flag{matchFlag()},
 		Action: app.runList,
 	}
 }
 
 // runList handles the list command execution with output adapter.
-func (app *CLI) runList(ctx context.Context, _ *cli.Command) error {
+func (app *CLI) runList(ctx context.Context, cmd *cli.Command) error {
 	// Create output adapter based on flags
 	output := cliAdapter.OutputFromContext(app.json, app.quiet)
 
+	selected, _, err := selectPackages(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Get installed packages information
 	result := &domain.ListResult{
 		Packages:  []domain.PackageInfo{},
@@ -897,6 +1474,17 @@ func (app *CLI) runList(ctx context.Context, _ *cli.Command) error {
 		result.Packages = append(result.Packages, pkg)
 	}
 
+	// Flatpaks from both the user and system installations
+	for _, flatpak := range app.getInstalledFlatpaks(ctx) {
+		result.Packages = append(result.Packages, domain.PackageInfo{
+			Name:        flatpak.Name,
+			Type:        "flatpak",
+			Installed:   time.Now(),
+			Size:        flatpak.Size,
+			Description: string(flatpak.Scope) + " installation",
+		})
+	}
+
 	// Check installed themes
 	if currentTheme := app.getCurrentTheme(); currentTheme != "" {
 		result.Packages = append(result.Packages, domain.PackageInfo{
@@ -917,6 +1505,12 @@ func (app *CLI) runList(ctx context.Context, _ *cli.Command) error {
 		})
 	}
 
+	if len(selected) > 0 {
+		result.Packages = slices.DeleteFunc(result.Packages, func(pkg domain.PackageInfo) bool {
+			return !slices.Contains(selected, pkg.Name)
+		})
+	}
+
 	result.Total = len(result.Packages)
 
 	// Output results
@@ -926,7 +1520,7 @@ func (app *CLI) runList(ctx context.Context, _ *cli.Command) error {
 
 	// Text output as table
 	if len(result.Packages) > 0 {
-		headers := []string{"Name", "Type", "Version", "Description"}
+		headers := []string{"Name", "Type", "Version", "Size", "Description"}
 		rows := make([][]string, 0, len(result.Packages))
 
 		for _, pkg := range result.Packages {
@@ -935,11 +1529,11 @@ func (app *CLI) runList(ctx context.Context, _ *cli.Command) error {
 				version = "-"
 			}
 
-			rows = append(rows, []string{pkg.Name, pkg.Type, version, pkg.Description})
+			rows = append(rows, []string{pkg.Name, pkg.Type, version, formatSize(pkg.Size), pkg.Description})
 		}
 
 		_ = output.Table(headers, rows)
-		_ = output.Info(fmt.Sprintf("\nTotal: %d packages installed", result.Total))
+		_ = output.Info(fmt.Sprintf("\nTotal: %s packages installed", stringutil.FormatCount(result.Total)))
 	} else {
 		_ = output.Info("No packages installed")
 	}
@@ -947,6 +1541,35 @@ func (app *CLI) runList(ctx context.Context, _ *cli.Command) error {
 	return nil
 }
 
+// getInstalledFlatpaks returns installed flatpaks with their scope and size.
+func (app *CLI) getInstalledFlatpaks(ctx context.Context) []*domain.Package {
+	app.ensureInstallService()
+
+	packages, err := app.installService.ListInstalledPackages(ctx)
+	if err != nil {
+		return nil
+	}
+
+	var flatpaks []*domain.Package
+
+	for _, pkg := range packages {
+		if pkg.Method == domain.MethodFlatpak {
+			flatpaks = append(flatpaks, pkg)
+		}
+	}
+
+	return flatpaks
+}
+
+// formatSize renders a byte count with decimal units, or "-" when unknown.
+func formatSize(bytes int64) string {
+	if bytes <= 0 {
+		return "-"
+	}
+
+	return stringutil.FormatBytes(bytes)
+}
+
 func (app *CLI) getInstalledApps() []string {
 	// Check common installed apps from the system
 	commonApps := []string{"git", "vim", "docker", "go", "rust", "node", "python"}
@@ -1010,9 +1633,7 @@ func (app *CLI) getAppVersion(ctx context.Context, appName string) string {
 }
 
 func (app *CLI) getCurrentTheme() string {
-	// Would check actual theme configuration
-	// For now, returning empty
-	return ""
+	return app.newThemeService().CurrentTheme()
 }
 
 func (app *CLI) getCurrentFont() string {
@@ -1356,7 +1977,7 @@ func (app *CLI) defaultAction(ctx context.Context, _ *cli.Command) error {
 }
 
 // initConfig initializes configuration and output settings.
-func (app *CLI) initConfig(ctx context.Context, _ *cli.Command) (context.Context, error) {
+func (app *CLI) initConfig(ctx context.Context, cmd *cli.Command) (context.Context, error) {
 	// Validate conflicting flags
 	if app.json && app.plain {
 		return ctx, domain.NewExitError(ExitUsageError, "cannot use both --json and --plain flags simultaneously", nil)
@@ -1382,13 +2003,134 @@ func (app *CLI) initConfig(ctx context.Context, _ *cli.Command) (context.Context
 
 	// Configure output utilities based on flags
 	console.DefaultOutput.SetMode(app.verbose, app.json, app.plain)
+	console.DefaultOutput.SetColor(app.color)
 
 	// Set global auto-yes flag
 	console.AutoYes = app.yes
 
+	app.migrateLegacyPaths()
+
+	if err := app.loadInstallPreferences(); err != nil {
+		return ctx, domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	app.noteDeprecation()
+	app.configureProxy(ctx)
+
+	if path := xdg.CatalogFile(); path != "" {
+		if err := app.loadCatalog(ctx, path); err != nil && !lintsCatalog(cmd) {
+			return ctx, err
+		}
+	}
+
+	app.uninstallService.SetMethodPreference(app.preference)
+	app.uninstallService.SetLockTimeout(app.lockTimeout)
+	app.uninstallService.SetTrash(platform.NewTrash(xdg.TrashDir()))
+	app.uninstallService.SetInstallRecords(app.installRecords())
+	app.uninstallService.SetManifests(app.manifests())
+
+	if home, err := os.UserHomeDir(); err == nil {
+		app.uninstallService.SetHomeDir(home)
+	}
+
+	if err := app.startWebhook(app.webhookURL); err != nil {
+		return ctx, err
+	}
+
 	return ctx, nil
 }
 
+// loadCatalog adds the apps of the catalog at path, a file or the URL of a
+// signed one, to the built-in catalog. A remote catalog that can't be
+// verified is skipped with a warning, so karei trust can still fix it.
+func (app *CLI) loadCatalog(ctx context.Context, path string) error {
+	if isRemote(path) {
+		local, err := app.remoteCatalog(ctx, path)
+		if err != nil {
+			console.DefaultOutput.Warningf("Skipping the remote catalog: %v", err)
+			return nil
+		}
+
+		path = local
+	}
+
+	added, err := apps.LoadCatalogFileCached(path, xdg.CacheDir())
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	console.DefaultOutput.Progressf("Loaded %d apps from %s", added, path)
+
+	return nil
+}
+
+// loadInstallPreferences resolves the method preference, Flatpak scopes, install
+// policy, release channels, apt frontend and lock timeout, download limits,
+// usage statistics opt-in and webhook, with their flags overriding config.toml.
+func (app *CLI) loadInstallPreferences() error {
+	prefs, err := config.LoadPreferences(xdg.PreferencesFile())
+	if err != nil {
+		return err
+	}
+
+	methods := prefs.PreferMethods()
+	if app.preferMethod != "" {
+		methods = app.preferMethod
+	}
+
+	if app.preference, err = domain.ParseMethodPreference(methods); err != nil {
+		return err
+	}
+
+	scope := prefs.Flatpak.Scope
+	if app.flatpakScope != "" {
+		scope = app.flatpakScope
+	}
+
+	if app.flatpak, err = domain.ParseFlatpakScopes(scope, prefs.Flatpak.Apps); err != nil {
+		return err
+	}
+
+	if app.policy, err = domain.ParseInstallPolicy(prefs.Policy.Deny); err != nil {
+		return err
+	}
+
+	if app.channels, err = domain.ParseReleaseChannels(prefs.GitHub.Channels); err != nil {
+		return err
+	}
+
+	app.usageStats = prefs.Privacy.UsageStats
+	app.webhookURL = prefs.Notify.Webhook
+	app.proxy = configuredProxy(prefs.Proxy)
+
+	if app.aptFrontend, err = domain.ParseAPTFrontend(prefs.Install.APTFrontend); err != nil {
+		return err
+	}
+
+	if err := app.loadDownloadPreferences(prefs); err != nil {
+		return err
+	}
+
+	if app.lockTimeout == 0 {
+		app.lockTimeout, err = prefs.APTLockTimeout()
+	}
+
+	return err
+}
+
+// detectAPTFrontend returns the APT frontend installs go through, warning
+// when the configured one isn't installed.
+func (app *CLI) detectAPTFrontend() domain.APTFrontend {
+	detector := platform.NewSystemDetector(platform.NewCommandRunner(false, false), platform.NewFileManager(false))
+	frontend := detector.DetectAPTFrontend(app.aptFrontend)
+
+	if frontend != app.aptFrontend && app.aptFrontend != domain.APTFrontendAuto {
+		console.DefaultOutput.Warningf("apt_frontend %s in config.toml isn't installed, using apt", app.aptFrontend)
+	}
+
+	return frontend
+}
+
 // getVersion returns current version.
 func (app *CLI) getVersion() string {
 	versionFile := filepath.Join(config.GetKareiPath(), "version")
@@ -1411,16 +2153,11 @@ func (app *CLI) showHeader() {
 		"        \\/         \\/         \\/     \\/                \\/       \\/",
 	}
 
-	// Color gradient
-	colors := []string{
-		"\033[38;5;81m", "\033[38;5;75m", "\033[38;5;69m",
-		"\033[38;5;63m", "\033[38;5;57m", "\033[38;5;51m",
-	}
-	reset := "\033[0m"
+	// Color gradient, as 256-color foregrounds
+	colors := []string{"38;5;81", "38;5;75", "38;5;69", "38;5;63", "38;5;57", "38;5;51"}
 
 	for i, line := range asciiArt {
-		colorIndex := i % len(colors)
-		fmt.Printf("%s%s%s\n", colors[colorIndex], line, reset)
+		fmt.Println(console.DefaultOutput.Colorize(colors[i%len(colors)], line))
 	}
 
 	fmt.Printf("\n▸ Karei %s\n", app.getVersion())
@@ -1469,7 +2206,7 @@ func (app *CLI) showConciseHelp() {
 
 		fmt.Printf("%s\n", console.DefaultOutput.Header("GET STARTED"))
 		fmt.Printf("  karei install git vim\n")
-		fmt.Printf("  karei theme tokyo-night\n\n")
+		fmt.Printf("  karei theme apply --name tokyo-night\n\n")
 
 		fmt.Printf("Complete help:       karei --help\n")
 		fmt.Printf("Detailed examples:   karei help examples\n")
@@ -1507,7 +2244,7 @@ func (app *CLI) showExamples() {
 	fmt.Printf("%s\n", console.DefaultOutput.Header("STORY 2: INSTANT TRANSFORMATION"))
 	fmt.Printf("Now make your dev environment beautiful:\n\n")
 
-	fmt.Printf("  $ karei theme tokyo-night\n")
+	fmt.Printf("  $ karei theme apply --name tokyo-night\n")
 	fmt.Printf("  ▸ Applying tokyo-night theme to 6 applications...\n")
 	fmt.Printf("  ✓ GNOME theme applied\n")
 	fmt.Printf("  ✓ Terminal theme applied\n")
@@ -1534,7 +2271,7 @@ func (app *CLI) showExamples() {
 	fmt.Printf("Set up everything for a new project:\n\n")
 
 	fmt.Printf("  # Pick your style\n")
-	fmt.Printf("  $ karei theme catppuccin\n")
+	fmt.Printf("  $ karei theme apply --name catppuccin\n")
 	fmt.Printf("  ✓ Theme 'catppuccin' applied to all applications\n\n")
 
 	fmt.Printf("  # Get your tools\n")
@@ -1547,7 +2284,7 @@ func (app *CLI) showExamples() {
 	fmt.Printf("  ✓ development group installed successfully\n\n")
 
 	fmt.Printf("  # Set your font\n")
-	fmt.Printf("  $ karei font JetBrainsMono\n")
+	fmt.Printf("  $ karei font install --name JetBrainsMono\n")
 	fmt.Printf("  ✓ Font 'JetBrainsMono' applied successfully\n\n")
 
 	fmt.Printf("  # Check everything\n")
@@ -1570,7 +2307,7 @@ func (app *CLI) showExamples() {
 
 	fmt.Printf("Batch theme switching:\n")
 	fmt.Printf("  $ for theme in tokyo-night nord catppuccin; do\n")
-	fmt.Printf("      karei theme $theme && sleep 5\n")
+	fmt.Printf("      karei theme apply --name $theme && sleep 5\n")
 	fmt.Printf("    done\n\n")
 
 	fmt.Printf("%s\n", console.DefaultOutput.Header("DOCUMENTATION"))
@@ -1629,7 +2366,7 @@ func (app *CLI) showManPage(ctx context.Context) {
 	fmt.Printf("       Install essential tools:\n")
 	fmt.Printf("           karei install git vim curl\n\n")
 	fmt.Printf("       Apply a beautiful theme:\n")
-	fmt.Printf("           karei theme tokyo-night\n\n")
+	fmt.Printf("           karei theme apply --name tokyo-night\n\n")
 	fmt.Printf("       Check your setup:\n")
 	fmt.Printf("           karei verify\n\n")
 
@@ -1639,7 +2376,8 @@ func (app *CLI) showManPage(ctx context.Context) {
 	fmt.Printf("       5      Not found (theme/font/app)\n")
 	fmt.Printf("       10     Dependencies missing\n")
 	fmt.Printf("       20-24  Domain-specific errors\n")
-	fmt.Printf("       64     Completed with warnings\n\n")
+	fmt.Printf("       64     Completed with warnings\n")
+	fmt.Printf("       karei exit-codes lists them all\n\n")
 
 	fmt.Printf("%s\n", console.DefaultOutput.Header("SEE ALSO"))
 	fmt.Printf("       karei help examples    Complete workflows and tutorials\n")
@@ -1682,7 +2420,7 @@ func (app *CLI) showThemeDocumentation() {
 	fmt.Printf("       karei theme - Apply coordinated themes across all applications\n\n")
 
 	fmt.Printf("%s\n", console.DefaultOutput.Header("SYNOPSIS"))
-	fmt.Printf("       karei theme [theme-name]\n")
+	fmt.Printf("       karei theme apply --name <theme-name>\n")
 	fmt.Printf("       karei theme list\n\n")
 
 	fmt.Printf("%s\n", console.DefaultOutput.Header("DESCRIPTION"))
@@ -1691,7 +2429,7 @@ func (app *CLI) showThemeDocumentation() {
 	fmt.Printf("       selected theme will have consistent colors and styling.\n\n")
 
 	fmt.Printf("       Themes are applied system-wide and affect:\n")
-	fmt.Printf("         • GNOME desktop environment (if available)\n")
+	fmt.Printf("         • GNOME or KDE Plasma desktop environment (if available)\n")
 	fmt.Printf("         • Terminal applications (ghostty, btop, zellij)\n")
 	fmt.Printf("         • Text editors (neovim, vscode)\n")
 	fmt.Printf("         • Web browsers (chrome extensions)\n\n")
@@ -1712,7 +2450,7 @@ func (app *CLI) showThemeDocumentation() {
 
 	fmt.Printf("%s\n", console.DefaultOutput.Header("EXAMPLES"))
 	fmt.Printf("       Apply tokyo-night theme:\n")
-	fmt.Printf("         $ karei theme tokyo-night\n")
+	fmt.Printf("         $ karei theme apply --name tokyo-night\n")
 	fmt.Printf("         tokyo-night\n\n")
 
 	fmt.Printf("       List available themes:\n")
@@ -1817,7 +2555,7 @@ func (app *CLI) showTutorial() {
 	fmt.Printf("\n%s\n", console.DefaultOutput.Header("STEP 2: CHOOSE A THEME"))
 	fmt.Printf("Karei provides coordinated themes for your entire desktop:\n")
 	fmt.Printf("  $ karei theme list          # See available themes\n")
-	fmt.Printf("  $ karei theme tokyo-night   # Apply tokyo-night theme\n\n")
+	fmt.Printf("  $ karei theme apply --name tokyo-night   # Apply tokyo-night theme\n\n")
 	fmt.Printf("Recommended themes for beginners: tokyo-night, catppuccin, nord\n")
 	fmt.Printf("Press Enter to continue...\n")
 
@@ -2054,15 +2792,32 @@ Shows:
 - System configuration status
 - Helpful next steps
 
-This command helps you understand what's currently installed and suggests actions.`,
+With --since, it shows what karei changed from that date instead: packages
+added, removed or upgraded and configs changed, to find what broke a machine.
+
+This command helps you understand what's currently installed and suggests actions.
+
+Examples:
+  karei status
+  karei status --since 2025-01-01`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "show what karei changed from this date (YYYY-MM-DD) until now",
+			},
+		},
 		Action: app.handleStatusAction,
 	}
 }
 
-func (app *CLI) handleStatusAction(_ context.Context, _ *cli.Command) error {
+func (app *CLI) handleStatusAction(_ context.Context, cmd *cli.Command) error {
 	// Create output adapter based on flags
 	output := cliAdapter.OutputFromContext(app.json, app.quiet)
 
+	if cmd.IsSet("since") {
+		return app.showChangesSince(output, cmd.String("since"))
+	}
+
 	// Gather status information
 	result := app.gatherSystemStatus()
 
@@ -2075,6 +2830,33 @@ func (app *CLI) handleStatusAction(_ context.Context, _ *cli.Command) error {
 	return app.displayDetailedStatus(output, result)
 }
 
+// showChangesSince shows what karei changed from since until now.
+func (app *CLI) showChangesSince(output domain.OutputPort, since string) error {
+	when, err := domain.ParseSince(since)
+	if err != nil {
+		return domain.NewExitError(ExitUsageError, err.Error(), err)
+	}
+
+	before, err := app.snapshotAt(when)
+	if err != nil {
+		return err
+	}
+
+	now, err := app.currentSnapshot()
+	if err != nil {
+		return err
+	}
+
+	diff := domain.DiffSnapshots(before, now)
+	diff.From = when
+
+	if app.json {
+		return output.Success("", diff)
+	}
+
+	return showHistoryDiff(output, diff)
+}
+
 // gatherSystemStatus collects system state information.
 func (app *CLI) gatherSystemStatus() *domain.StatusResult {
 	result := &domain.StatusResult{
@@ -2088,6 +2870,7 @@ func (app *CLI) gatherSystemStatus() *domain.StatusResult {
 	// Count installed packages by type
 	installedApps := app.getInstalledApps()
 	result.Installed = len(installedApps)
+	result.Scopes = app.installedScopes()
 
 	// Get current theme and font
 	result.Theme = app.getCurrentTheme()
@@ -2148,9 +2931,37 @@ func (app *CLI) displayPackageStatus(output domain.OutputPort, result *domain.St
 		_ = output.Info("  (use 'karei list' for detailed package information)")
 	}
 
+	if keys := result.Scopes[string(domain.ScopeUser)]; len(keys) > 0 {
+		_ = output.Info("Installed by karei for this user: " + strings.Join(keys, ", "))
+	}
+
+	if keys := result.Scopes[string(domain.ScopeSystem)]; len(keys) > 0 {
+		_ = output.Info("Installed by karei system-wide: " + strings.Join(keys, ", "))
+	}
+
 	_ = output.Info("")
 }
 
+// installedScopes groups the catalog apps karei installed by scope, sorted.
+func (app *CLI) installedScopes() map[string][]string {
+	records, err := app.installRecords().Load()
+	if err != nil || len(records) == 0 {
+		return nil
+	}
+
+	scopes := make(map[string][]string)
+
+	for key, record := range records {
+		scopes[string(record.Scope)] = append(scopes[string(record.Scope)], key)
+	}
+
+	for _, keys := range scopes {
+		slices.Sort(keys)
+	}
+
+	return scopes
+}
+
 // displayConfigurationStatus shows theme and font configuration.
 func (app *CLI) displayConfigurationStatus(output domain.OutputPort, result *domain.StatusResult) {
 	_ = output.Info("Configuration:")
@@ -2159,7 +2970,7 @@ func (app *CLI) displayConfigurationStatus(output domain.OutputPort, result *dom
 		_ = output.Info("  Theme: " + result.Theme + " (active)")
 	} else {
 		_ = output.Info("  Theme: none configured")
-		_ = output.Info("    (use 'karei theme <name>' to apply a coordinated theme)")
+		_ = output.Info("    (use 'karei theme apply --name <name>' to apply a coordinated theme)")
 		_ = output.Info("    (use 'karei theme list' to see available themes)")
 	}
 
@@ -2167,7 +2978,7 @@ func (app *CLI) displayConfigurationStatus(output domain.OutputPort, result *dom
 		_ = output.Info("  Font: " + result.Font + " (active)")
 	} else {
 		_ = output.Info("  Font: system default")
-		_ = output.Info("    (use 'karei font <name>' to configure terminal fonts)")
+		_ = output.Info("    (use 'karei font install --name <name>' to configure terminal fonts)")
 	}
 
 	_ = output.Info("")
@@ -2245,14 +3056,28 @@ The TUI provides a menu-driven interface for:
 Navigation:
 - Use arrow keys or j/k to navigate
 - Press Enter to select
-- Press q or Ctrl+C to quit`,
+- Press q or Ctrl+C to quit
+
+Demo mode (--demo) uses a bundled offline package database and simulated
+installers: nothing is installed, no sudo is requested and no network is used.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "demo",
+				Usage: "explore the TUI with offline mock data (no sudo, no network, no changes)",
+			},
+		},
 		Action: app.handleTUIAction,
 	}
 }
 
 // handleTUIAction handles the TUI command.
-func (app *CLI) handleTUIAction(ctx context.Context, _ *cli.Command) error {
-	if err := tui.LaunchInteractive(ctx); err != nil {
+func (app *CLI) handleTUIAction(ctx context.Context, cmd *cli.Command) error {
+	launch := tui.LaunchInteractive
+	if cmd.Bool("demo") {
+		launch = tui.LaunchDemo
+	}
+
+	if err := launch(ctx); err != nil {
 		if app.verbose {
 			return domain.NewExitError(ExitGeneralError, fmt.Sprintf("Failed to launch TUI: %v", err), nil)
 		}
@@ -2277,12 +3102,12 @@ func (app *CLI) generateSuggestions(result *domain.StatusResult) []string {
 
 	// Suggest configuration
 	if result.Theme == "" {
-		suggestions = append(suggestions, "Apply a coordinated theme: karei theme tokyo-night")
+		suggestions = append(suggestions, "Apply a coordinated theme: karei theme apply --name tokyo-night")
 		suggestions = append(suggestions, "Browse available themes: karei theme list")
 	}
 
 	if result.Font == "" {
-		suggestions = append(suggestions, "Configure terminal fonts: karei font CaskaydiaMono")
+		suggestions = append(suggestions, "Configure terminal fonts: karei font install --name CaskaydiaMono")
 	}
 
 	// Check for missing essential tools and suggest installation
@@ -2402,9 +3227,41 @@ func (app *CLI) validateInstallFlags(cmd *cli.Command) (string, string, error) {
 	packagesFlag := cmd.String("packages")
 	groupFlag := cmd.String("group")
 
+	if cmd.Bool("stdin") {
+		if packagesFlag != "" || groupFlag != "" {
+			return "", "", domain.NewExitError(ExitUsageError, "cannot use --stdin with --packages or --group", nil)
+		}
+
+		packages, err := app.readPackageList()
+		if err != nil {
+			return "", "", err
+		}
+
+		packagesFlag = packages
+	}
+
+	selected, matched, err := selectPackages(cmd)
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(selected) > 0 {
+		if groupFlag != "" || cmd.Bool("stdin") {
+			return "", "", domain.NewExitError(ExitUsageError, "cannot combine apps or patterns with --group or --stdin", nil)
+		}
+
+		if matched {
+			if err := confirmMatched("Install", selected); err != nil {
+				return "", "", err
+			}
+		}
+
+		packagesFlag = joinPackages(packagesFlag, selected)
+	}
+
 	// Validate that at least one flag is provided
 	if packagesFlag == "" && groupFlag == "" {
-		return "", "", domain.NewExitError(ExitUsageError, "specify either --packages or --group", nil)
+		return "", "", domain.NewExitError(ExitUsageError, "specify apps, --packages or --group", nil)
 	}
 
 	// Validate that both flags are not provided
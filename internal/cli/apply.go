@@ -0,0 +1,403 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/fleet"
+	"github.com/janderssonse/karei/internal/tui"
+	"github.com/urfave/cli/v3"
+)
+
+const defaultLockfile = "karei.lock"
+
+// errApplyCancelled is returned when the review of a profile's changes, or
+// a large download on a metered connection, is cancelled.
+var errApplyCancelled = errors.New("apply cancelled")
+
+// createApplyCommand creates the apply command for installing a profile on this machine.
+func (app *CLI) createApplyCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "apply",
+		Usage:     "Install a profile on this machine, optionally locking exact versions",
+		ArgsUsage: "[profile.yaml or URL]",
+		Description: `Install the groups and apps of a profile and apply its theme. A profile
+file uses the same fields as an inventory profile:
+  groups: [development]
+  apps: [git, node]
+  versions: {node: ">=20 <21"}
+  theme: tokyo-night
+
+With --lock, the exact version, source and checksum of every app installed
+is written to karei.lock. With --locked, the apps in karei.lock are
+installed at exactly those versions instead; apply fails before installing
+anything when an app is unknown or would be installed from another source
+here, and afterwards when any installed app differs from the lock.
+
+A profile given as a URL is only applied once a key added with karei trust
+add verifies its signature, published next to it.
+
+In a terminal, the changes the profile would make (apps to install or
+upgrade, the theme to switch to) are listed first for review, like git add
+-p: y keeps and n skips a change, Space toggles it, Enter applies what is
+kept and q cancels. --yes applies everything without the review.
+
+Examples:
+  karei apply profile.yaml --lock        # Install and write karei.lock
+  karei apply --locked                   # Reproduce karei.lock on another machine
+  karei apply --profile developer inventory.yaml
+  karei apply https://example.com/profile.yaml  # Signed by a trusted key`,
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "profile",
+				Usage: "read the named profile from an inventory file instead of a profile file",
+			},
+			&cli.BoolFlag{
+				Name:  "lock",
+				Usage: "write the exact versions, sources and checksums installed to the lockfile",
+			},
+			&cli.BoolFlag{
+				Name:  "locked",
+				Usage: "install exactly what the lockfile records, failing if that isn't possible",
+			},
+			&cli.BoolFlag{
+				Name:  "allow-untrusted",
+				Usage: "apply a remote profile no trusted key signed",
+			},
+			&cli.StringFlag{
+				Name:  "lockfile",
+				Usage: "lockfile path",
+				Value: defaultLockfile,
+			},
+		}, downloadFlags()...),
+		Action: app.runApply,
+	}
+}
+
+// runApply installs a profile, or the contents of a lockfile with --locked.
+func (app *CLI) runApply(ctx context.Context, cmd *cli.Command) error {
+	app.parseDownloadFlags(cmd)
+
+	if cmd.Bool("lock") && cmd.Bool("locked") {
+		return domain.NewExitError(ExitUsageError, "cannot use --lock with --locked", nil)
+	}
+
+	if cmd.Bool("locked") {
+		return app.runApplyLocked(ctx, cmd.String("lockfile"))
+	}
+
+	if cmd.Args().Len() != 1 {
+		return domain.NewExitError(ExitUsageError, "usage: karei apply <profile.yaml>", ErrInvalidArgument)
+	}
+
+	ctx, cancel := app.applyTimeout(ctx)
+	defer cancel()
+
+	path := cmd.Args().First()
+
+	if isRemote(path) {
+		dir, err := os.MkdirTemp("", "karei-profile-")
+		if err != nil {
+			return domain.NewExitError(ExitSystemError, "failed to create a temporary directory", err)
+		}
+
+		defer func() { _ = os.RemoveAll(dir) }()
+
+		url := path
+		path = filepath.Join(dir, "profile.yaml")
+
+		if err := app.fetchTrusted(ctx, url, path, cmd.Bool("allow-untrusted")); err != nil {
+			return trustExitError(err)
+		}
+	}
+
+	profile, err := loadGenerateProfile(path, cmd.String("profile"))
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	if app.reviewsChanges() {
+		reviewed, err := app.reviewProfile(ctx, profile)
+		if errors.Is(err, errApplyCancelled) {
+			return cliAdapter.OutputFromContext(app.json, app.quiet).Info("Apply cancelled.")
+		}
+
+		if err != nil {
+			return err
+		}
+
+		profile = reviewed
+	}
+
+	keys, err := app.applyProfile(ctx, profile)
+	if errors.Is(err, errApplyCancelled) {
+		return cliAdapter.OutputFromContext(app.json, app.quiet).Info("Apply cancelled.")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !cmd.Bool("lock") {
+		return nil
+	}
+
+	runner := platform.NewCommandRunner(false, false)
+	inventory := app.installService.Inventory(ctx, platform.NewVersionResolver(runner), platform.NewChecksumResolver(runner))
+	lock := application.NewLockfile(inventory, keys)
+
+	if err := lock.Write(platform.NewFileManager(app.verbose), cmd.String("lockfile")); err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+	_ = output.Success(fmt.Sprintf("Locked %d apps in %s", len(lock.Packages), cmd.String("lockfile")), lock)
+
+	return nil
+}
+
+// applyProfile installs the apps of profile and applies its theme,
+// returning the apps.
+func (app *CLI) applyProfile(ctx context.Context, profile *fleet.Profile) ([]string, error) {
+	keys, err := profileApps(profile)
+	if err != nil {
+		return nil, domain.NewExitError(ExitNotFoundError, err.Error(), err)
+	}
+
+	// The profile was validated when loaded
+	constraints, _ := domain.ParseVersionConstraints(profile.Versions)
+
+	if err := app.applyApps(ctx, keys, constraints); err != nil {
+		return keys, err
+	}
+
+	if profile.Theme != "" {
+		if err := app.applyProfileTheme(ctx, profile.Theme); err != nil {
+			return keys, domain.NewExitError(ExitGeneralError, "failed to apply theme "+profile.Theme, err)
+		}
+	}
+
+	return keys, nil
+}
+
+// reviewsChanges reports whether changes are reviewed before being made:
+// in a terminal, unless --yes or --json was given.
+func (app *CLI) reviewsChanges() bool {
+	return !app.yes && !app.json &&
+		console.DefaultOutput.IsTTY(os.Stdin.Fd()) && console.DefaultOutput.IsTTY(os.Stdout.Fd())
+}
+
+// reviewProfile lists the changes applying profile would make for review
+// and returns the profile narrowed to the kept ones.
+func (app *CLI) reviewProfile(ctx context.Context, profile *fleet.Profile) (*fleet.Profile, error) {
+	keys, err := profileApps(profile)
+	if err != nil {
+		return nil, domain.NewExitError(ExitNotFoundError, err.Error(), err)
+	}
+
+	// The profile was validated when loaded
+	constraints, _ := domain.ParseVersionConstraints(profile.Versions)
+
+	app.ensureInstallService()
+	app.installService.SetVersionConstraints(constraints)
+
+	changes := app.installService.PlanInstall(ctx, keys)
+
+	if current := app.getCurrentTheme(); profile.Theme != "" && profile.Theme != current {
+		changes = append(changes, domain.PlannedChange{Kind: domain.ChangeConfig, Target: "theme", From: current, To: profile.Theme})
+	}
+
+	if len(changes) == 0 {
+		return profile, nil
+	}
+
+	kept, ok, err := tui.ReviewChanges(ctx, changes)
+	if err != nil {
+		return nil, domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	if !ok {
+		return nil, errApplyCancelled
+	}
+
+	reviewed := *profile
+	reviewed.Groups = nil
+	reviewed.Apps = nil
+
+	for _, key := range keys {
+		if !skipped(changes, kept, key) {
+			reviewed.Apps = append(reviewed.Apps, key)
+		}
+	}
+
+	if skipped(changes, kept, "theme") {
+		reviewed.Theme = ""
+	}
+
+	return &reviewed, nil
+}
+
+// skipped reports whether the change to target was planned but not kept.
+func skipped(changes, kept []domain.PlannedChange, target string) bool {
+	isTarget := func(change domain.PlannedChange) bool { return change.Target == target }
+
+	return slices.ContainsFunc(changes, isTarget) && !slices.ContainsFunc(kept, isTarget)
+}
+
+// runApplyLocked installs the apps of a lockfile at their locked versions
+// and checks that what got installed matches the lock.
+func (app *CLI) runApplyLocked(ctx context.Context, path string) error {
+	lock, err := application.ReadLockfile(platform.NewFileManager(app.verbose), path)
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	if unavailable := app.unavailableLocked(lock); len(unavailable) > 0 {
+		return domain.NewExitError(ExitNotFoundError,
+			"cannot reproduce "+path+":\n  "+strings.Join(unavailable, "\n  "), nil)
+	}
+
+	ctx, cancel := app.applyTimeout(ctx)
+	defer cancel()
+
+	err = app.applyApps(ctx, lock.Keys(), lock.Constraints())
+	if errors.Is(err, errApplyCancelled) {
+		return cliAdapter.OutputFromContext(app.json, app.quiet).Info("Apply cancelled.")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	runner := platform.NewCommandRunner(false, false)
+	inventory := app.installService.Inventory(ctx, platform.NewVersionResolver(runner), platform.NewChecksumResolver(runner))
+
+	if mismatches := lock.Verify(inventory); len(mismatches) > 0 {
+		lines := make([]string, 0, len(mismatches))
+		for _, mismatch := range mismatches {
+			lines = append(lines, mismatch.Error())
+		}
+
+		return domain.NewExitError(ExitGeneralError,
+			"installed apps do not match "+path+":\n  "+strings.Join(lines, "\n  "), errors.Join(mismatches...))
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+	_ = output.Success(fmt.Sprintf("Installed %d apps exactly as locked in %s", len(lock.Packages), path), lock)
+
+	return nil
+}
+
+// unavailableLocked describes each locked app that this machine's catalog
+// and method preference would not install from the locked source.
+func (app *CLI) unavailableLocked(lock *application.Lockfile) []string {
+	var unavailable []string
+
+	for _, locked := range lock.Packages {
+		catalogApp, exists := apps.Apps[locked.Key]
+		if !exists {
+			unavailable = append(unavailable, locked.Key+": not in the catalog")
+
+			continue
+		}
+
+		if method, source := catalogApp.Resolve(app.preference); method != locked.Method || source != locked.Source {
+			unavailable = append(unavailable, fmt.Sprintf("%s: locked %s (%s), would install %s (%s)",
+				locked.Key, locked.Method, locked.Source, method, source))
+		}
+	}
+
+	return unavailable
+}
+
+// applyApps installs keys held to constraints, recording the run for retry.
+func (app *CLI) applyApps(ctx context.Context, keys []string, constraints domain.VersionConstraints) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	app.ensureInstallService()
+	app.installService.SetVersionConstraints(constraints)
+	app.installService.SetConnectivity(app.checkConnectivity(ctx))
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	err := app.confirmMeteredDownload(ctx, keys)
+	if errors.Is(err, errSelectionCancelled) {
+		return errApplyCancelled
+	}
+
+	if err != nil {
+		return err
+	}
+
+	app.announceEstimate(keys)
+
+	if err := app.waitForOffPeak(ctx); err != nil {
+		return err
+	}
+
+	reportDownloads := app.measureDownloads()
+	stopProgress := app.streamProgress(ctx, keys)
+	result, _ := app.installService.InstallPackages(ctx, keys)
+	stopProgress()
+
+	app.outputInstallProgress(result, output)
+	reportDownloads()
+
+	run := application.NewJournalRun()
+	run.AddInstallResult(result)
+	app.recordRun(run)
+
+	return app.getInstallExitCode(result)
+}
+
+// applyProfileTheme applies a profile's theme as karei theme apply does.
+func (app *CLI) applyProfileTheme(ctx context.Context, theme string) error {
+	return app.newThemeService().ApplyTheme(ctx, theme)
+}
+
+// profileApps expands the groups of a profile and adds its apps, in order
+// and without duplicates.
+func profileApps(profile *fleet.Profile) ([]string, error) {
+	var keys []string
+
+	for _, group := range profile.Groups {
+		members, exists := apps.Groups[group]
+		if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownGroup, group)
+		}
+
+		for _, key := range members {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	for _, key := range profile.Apps {
+		if _, exists := apps.Apps[key]; !exists {
+			return nil, fmt.Errorf("%w: %s", application.ErrUnknownApp, key)
+		}
+
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
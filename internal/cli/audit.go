@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// createAuditCommand creates the audit command for checking installed apps.
+func (app *CLI) createAuditCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "audit",
+		Usage: "Check installed apps for security issues",
+		Commands: []*cli.Command{
+			{
+				Name:  "cve",
+				Usage: "Report known vulnerabilities in installed apps",
+				Description: `Look up every installed catalog app in the OSV database, which
+republishes the Ubuntu and Debian security trackers, and report the
+advisories affecting the installed version with their severity and the
+version that fixes them.
+
+Only apps installed from the distribution archive are covered; other
+apps are listed as unchecked. With --fix, apps with a released fix are
+upgraded by reinstalling them, and the upgrade is recorded in the journal.
+
+Exits with 64 when vulnerable apps remain.
+
+EXAMPLES:
+  karei audit cve
+  karei audit cve --fix
+  karei audit cve --json | jq '.data.vulnerable[].key'`,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "fix", Usage: "upgrade vulnerable apps that have a fix released"},
+				},
+				Action: app.runAuditCVE,
+			},
+		},
+	}
+}
+
+// runAuditCVE checks the installed apps against the OSV feed of this distribution.
+func (app *CLI) runAuditCVE(ctx context.Context, cmd *cli.Command) error {
+	ctx, cancel := app.applyTimeout(ctx)
+	defer cancel()
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	commandRunner := platform.NewCommandRunner(app.verbose, false)
+	detector := platform.NewSystemDetector(commandRunner, platform.NewFileManager(app.verbose))
+
+	dist, _ := detector.DetectDistribution(ctx)
+
+	ecosystem, ok := network.OSVEcosystem(dist)
+	if !ok {
+		return domain.NewExitError(ExitDependencyError, "no vulnerability feed for this distribution, only Ubuntu and Debian are covered", nil)
+	}
+
+	if state := app.checkConnectivity(ctx); !state.Available() {
+		return domain.NewExitError(ExitNetworkError, "cannot query the OSV database: "+state.SkipReason(), domain.ErrOffline)
+	}
+
+	app.ensureInstallService()
+
+	inventory := app.installService.Inventory(ctx, platform.NewVersionResolver(commandRunner), nil)
+	feed := network.NewOSVFeed(network.GetHTTPClient(), commandRunner, ecosystem)
+	result := application.NewAdvisoryService(feed).Audit(ctx, inventory)
+
+	if len(result.Failed) > 0 && result.Checked == 0 {
+		return domain.NewExitError(ExitNetworkError, "failed to query the OSV database", nil)
+	}
+
+	if app.json {
+		if err := output.Success("", result); err != nil {
+			return err
+		}
+	} else {
+		_ = output.Info(formatAuditResult(result))
+	}
+
+	if cmd.Bool("fix") {
+		if fixable := application.Fixable(result); len(fixable) > 0 {
+			return app.upgradeVulnerable(ctx, fixable, output)
+		}
+	}
+
+	if len(result.Vulnerable) > 0 {
+		return domain.NewExitError(ExitWarnings, fmt.Sprintf("%d apps have known vulnerabilities", len(result.Vulnerable)), nil)
+	}
+
+	return nil
+}
+
+// upgradeVulnerable reinstalls apps so their package manager picks up the fixed version.
+func (app *CLI) upgradeVulnerable(ctx context.Context, keys []string, output domain.OutputPort) error {
+	_ = output.Info("↑ Upgrading " + strings.Join(keys, ", "))
+
+	run := application.NewJournalRun()
+	result := app.executeInstallation(ctx, strings.Join(keys, ","), "", output)
+
+	run.AddInstallResult(result)
+	app.recordRun(run)
+
+	if !app.json {
+		if err := app.outputInstallResults(result, output); err != nil {
+			return domain.NewExitError(ExitGeneralError, "failed to output results", err)
+		}
+	}
+
+	return app.getInstallExitCode(result)
+}
+
+// formatAuditResult renders the vulnerable apps, most severe first.
+func formatAuditResult(result *domain.AuditResult) string {
+	var lines []string
+
+	for _, pkg := range result.Vulnerable {
+		lines = append(lines, fmt.Sprintf("%s %s (%s)", pkg.Key, pkg.Version, pkg.Severity))
+
+		for _, vuln := range pkg.Vulnerabilities {
+			fix := "no fix yet"
+			if vuln.FixedVersion != "" {
+				fix = "fixed in " + vuln.FixedVersion
+			}
+
+			line := fmt.Sprintf("  %-8s %s, %s", vuln.Severity, vulnerabilityName(vuln), fix)
+			if vuln.Summary != "" {
+				line += ": " + vuln.Summary
+			}
+
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, fmt.Sprintf("%d apps checked, %d vulnerable", result.Checked, len(result.Vulnerable)))
+
+	if len(result.Unchecked) > 0 {
+		lines = append(lines, "Not covered by the feed: "+strings.Join(result.Unchecked, ", "))
+	}
+
+	if len(result.Failed) > 0 {
+		lines = append(lines, "Lookup failed: "+strings.Join(result.Failed, ", "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// vulnerabilityName prefers the CVE ID over the feed's own advisory ID.
+func vulnerabilityName(vuln domain.Vulnerability) string {
+	for _, alias := range vuln.Aliases {
+		if strings.HasPrefix(alias, "CVE-") {
+			return alias
+		}
+	}
+
+	return vuln.ID
+}
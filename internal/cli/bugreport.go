@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/bugreport"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// bugReportLogLines is how much of each log goes into a bug report.
+const bugReportLogLines = 500
+
+// createBugReportCommand creates the bugreport command.
+func (app *CLI) createBugReportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "bugreport",
+		Usage: "Collect diagnostics into an archive to attach to an issue",
+		Description: `Pack what is needed to triage a problem into a .tar.gz:
+
+  version.txt   karei version, Go runtime and architecture
+  system.json   distribution, desktop, package manager and kernel
+  logs/         the last 500 lines of each karei log
+  journal.json  the recent install and uninstall runs
+  config.toml   your preferences
+
+Tokens, passwords, credentials in URLs and similar values are replaced with
+[REDACTED], your home directory with ~ and your user name with <user>.
+Nothing is uploaded; review the archive before attaching it to
+https://github.com/janderssonse/karei/issues.
+
+EXAMPLES:
+  karei bugreport
+  karei bugreport -o /tmp/report.tar.gz`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "archive path (default karei-bugreport-<time>.tar.gz in the current directory)",
+			},
+		},
+		Action: app.runBugReport,
+	}
+}
+
+// runBugReport collects the diagnostics and writes the archive.
+func (app *CLI) runBugReport(ctx context.Context, cmd *cli.Command) error {
+	now := time.Now()
+	name := "karei-bugreport-" + now.Format("20060102-150405")
+
+	path := cmd.String("output")
+	if path == "" {
+		path = name + ".tar.gz"
+	}
+
+	files := app.collectBugReport(ctx)
+
+	home, _ := os.UserHomeDir()
+
+	username := ""
+	if current, err := user.Current(); err == nil {
+		username = current.Username
+	}
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, generatedFilePerm) //nolint:gosec // path is chosen by the user
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	if err := bugreport.Write(out, name, files, bugreport.NewScrubber(home, username), now); err != nil {
+		_ = out.Close()
+
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	if err := out.Close(); err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if app.json {
+		return output.Success("", map[string]any{"path": path, "files": len(files)})
+	}
+
+	return output.Success(fmt.Sprintf("Bug report written to %s\nReview it with: tar tzvf %s\nAttach it at: https://github.com/janderssonse/karei/issues", path, path), nil)
+}
+
+// collectBugReport gathers the report files. Anything missing is left out.
+func (app *CLI) collectBugReport(ctx context.Context) []bugreport.File {
+	files := []bugreport.File{{
+		Name:    "version.txt",
+		Content: fmt.Sprintf("karei %s\n%s %s/%s\n", app.getVersion(), runtime.Version(), runtime.GOOS, runtime.GOARCH),
+	}}
+
+	commandRunner := platform.NewCommandRunner(false, false)
+	detector := platform.NewSystemDetector(commandRunner, platform.NewFileManager(false))
+
+	if info, err := detector.DetectSystem(ctx); err == nil {
+		if data, err := json.MarshalIndent(info, "", "  "); err == nil {
+			files = append(files, bugreport.File{Name: "system.json", Content: string(data) + "\n"})
+		}
+	}
+
+	logDir := xdg.LogDir()
+	for _, log := range kareiLogs {
+		if data, err := os.ReadFile(filepath.Join(logDir, log.file)); err == nil { //nolint:gosec // karei's own logs
+			files = append(files, bugreport.File{Name: "logs/" + log.file, Content: bugreport.Tail(string(data), bugReportLogLines)})
+		}
+	}
+
+	if data, err := os.ReadFile(xdg.JournalFile()); err == nil {
+		files = append(files, bugreport.File{Name: "journal.json", Content: string(data)})
+	}
+
+	if data, err := os.ReadFile(xdg.PreferencesFile()); err == nil {
+		files = append(files, bugreport.File{Name: "config.toml", Content: string(data)})
+	}
+
+	return files
+}
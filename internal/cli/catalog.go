@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// Bounds of karei catalog lint --network: how long each URL may take to
+// answer and how many are asked at once.
+const (
+	catalogURLTimeout = 15 * time.Second
+	catalogURLWorkers = 8
+)
+
+// createCatalogCommand creates the catalog command checking the built-in
+// catalog and catalog files.
+func (app *CLI) createCatalogCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "catalog",
+		Usage: "Check the app catalog and catalog files",
+		Commands: []*cli.Command{
+			{
+				Name:      "lint",
+				Usage:     "Validate the built-in catalog and catalog files, for CI",
+				ArgsUsage: "[catalog.toml...]",
+				Description: `Check the built-in catalog and the catalog files given, or the one
+KAREI_CATALOG_PATH names, for problems that break installs: keys and
+aliases naming more than one app, unknown methods, groups, dependencies
+and architectures, invalid version constraints and checksums. Downloads
+without a sha256 checksum are warnings.
+
+Exits with a configuration error when there are errors, or warnings too
+with --strict, so it can gate changes to a catalog in CI.
+
+EXAMPLES:
+  karei catalog lint
+  karei catalog lint team-catalog.toml --strict
+  karei catalog lint --network          # Also check every download URL answers
+  karei catalog lint --json`,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "network",
+						Usage: "check the URLs apps download from are reachable",
+					},
+					&cli.BoolFlag{
+						Name:  "strict",
+						Usage: "fail on warnings as well as errors",
+					},
+				},
+				Action: app.runCatalogLint,
+			},
+		},
+	}
+}
+
+// runCatalogLint lints the built-in catalog and catalog files.
+func (app *CLI) runCatalogLint(ctx context.Context, cmd *cli.Command) error {
+	files := cmd.Args().Slice()
+	if path := xdg.CatalogFile(); len(files) == 0 && path != "" && !isRemote(path) {
+		files = []string{path}
+	}
+
+	builtin, groups := apps.Builtin()
+	issues := apps.LintCatalog(apps.BuiltinCatalog, builtin, groups)
+	urls := map[string][]apps.LintURL{apps.BuiltinCatalog: apps.LintURLs(builtin)}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file) //nolint:gosec // the user names the catalog file
+		if err != nil {
+			return domain.NewExitError(ExitNotFoundError, err.Error(), err)
+		}
+
+		fileIssues, catalog := apps.LintCatalogFile(file, data)
+		issues = append(issues, fileIssues...)
+		urls[file] = apps.LintURLs(catalog)
+	}
+
+	if cmd.Bool("network") {
+		issues = append(issues, unreachableURLs(ctx, urls)...)
+	}
+
+	return app.reportLintIssues(issues, cmd.Bool("strict"))
+}
+
+// unreachableURLs asks each URL apps download from, a few at a time, and
+// returns an issue for each one not answering.
+func unreachableURLs(ctx context.Context, urls map[string][]apps.LintURL) []apps.LintIssue {
+	client := network.GetHTTPClient()
+	client.Timeout = catalogURLTimeout
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		issues []apps.LintIssue
+	)
+
+	slots := make(chan struct{}, catalogURLWorkers)
+
+	for catalog, catalogURLs := range urls {
+		for _, url := range catalogURLs {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				slots <- struct{}{}
+				err := network.Reachable(ctx, client, url.URL)
+				<-slots
+
+				if err != nil {
+					mu.Lock()
+					issues = append(issues, apps.LintIssue{
+						Catalog: catalog, Key: url.Key, Severity: apps.LintError,
+						Message: fmt.Sprintf("%s: %v", url.URL, err),
+					})
+					mu.Unlock()
+				}
+			}()
+		}
+	}
+
+	wg.Wait()
+
+	slices.SortFunc(issues, func(a, b apps.LintIssue) int {
+		return cmp.Or(cmp.Compare(a.Catalog, b.Catalog), cmp.Compare(a.Key, b.Key))
+	})
+
+	return issues
+}
+
+// reportLintIssues prints the issues found and fails on errors, or on any
+// issue when strict.
+func (app *CLI) reportLintIssues(issues []apps.LintIssue, strict bool) error {
+	errorCount := 0
+
+	for _, issue := range issues {
+		if issue.Severity == apps.LintError {
+			errorCount++
+		}
+	}
+
+	warningCount := len(issues) - errorCount
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	switch {
+	case app.json:
+		if err := output.Success("", map[string]any{"issues": issues, "errors": errorCount, "warnings": warningCount}); err != nil {
+			return err
+		}
+	case len(issues) == 0:
+		return output.Success("The catalog has no problems", nil)
+	default:
+		rows := make([][]string, 0, len(issues))
+		for _, issue := range issues {
+			rows = append(rows, []string{issue.Catalog, issue.Key, issue.Severity, issue.Message})
+		}
+
+		if err := output.Table([]string{"CATALOG", "KEY", "SEVERITY", "PROBLEM"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if errorCount > 0 || (strict && warningCount > 0) {
+		return domain.NewExitError(ExitConfigError, fmt.Sprintf("%d errors and %d warnings in the catalog", errorCount, warningCount), nil)
+	}
+
+	return nil
+}
+
+// lintsCatalog reports whether cmd runs karei catalog lint, which reports
+// the problems of a catalog file rather than failing to load it.
+func lintsCatalog(cmd *cli.Command) bool {
+	return slices.Equal(cmd.Args().Slice()[:min(2, cmd.Args().Len())], []string{"catalog", "lint"})
+}
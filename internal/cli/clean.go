@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/stringutil"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// cleanResult is what karei clean removed, or would remove with --dry-run.
+type cleanResult struct {
+	Removed   []platform.Removal `json:"removed"`
+	Reclaimed int64              `json:"reclaimed"`
+	DryRun    bool               `json:"dry_run"`
+}
+
+// createCleanCommand creates the clean command for pruning caches and leftovers.
+func (app *CLI) createCleanCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "clean",
+		Usage: "Prune caches, old logs and orphaned installs",
+		Description: `Free disk space karei no longer needs:
+
+  cache     files in ` + xdg.CacheDir() + ` older than the maximum
+            age, then the oldest until the cache fits its size budget
+  logs      logs and the run journal older than the log retention
+  installs  directories of GitHub bundle apps in ~/.local/share whose
+            command in ~/.local/bin is gone
+
+Limits default to the [clean] section of config.toml:
+  [clean]
+  cache_max_age = "30d"
+  cache_max_size = "500MB"
+  log_max_age = "90d"
+
+EXAMPLES:
+  karei clean --dry-run       # Show what would be removed
+  karei clean --max-size 100MB
+  karei --json clean`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "max-age",
+				Usage: "remove cached files older than this, e.g. 7d or 12h",
+			},
+			&cli.StringFlag{
+				Name:  "max-size",
+				Usage: "shrink the cache to this size, e.g. 200MB",
+			},
+			&cli.StringFlag{
+				Name:  "log-max-age",
+				Usage: "remove logs and journals older than this",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "show what would be removed without removing it",
+			},
+		},
+		Action: app.runClean,
+	}
+}
+
+// runClean prunes the cache, logs and orphaned installs and reports the space reclaimed.
+func (app *CLI) runClean(_ context.Context, cmd *cli.Command) error {
+	limits, err := cleanLimits(cmd)
+	if err != nil {
+		return err
+	}
+
+	cleaner := platform.NewCleaner(cmd.Bool("dry-run"))
+	result := cleanResult{DryRun: cmd.Bool("dry-run")}
+
+	cached, err := cleaner.PruneCache(xdg.CacheDir(), limits.cacheMaxAge, limits.cacheMaxSize)
+	result.Removed = append(result.Removed, cached...)
+
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	logs, err := cleaner.PruneFiles(logPatterns(), limits.logMaxAge)
+	result.Removed = append(result.Removed, logs...)
+
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	home, _ := os.UserHomeDir()
+
+	orphans, err := cleaner.PruneOrphanedInstalls(
+		filepath.Join(home, ".local", "share"), filepath.Join(home, ".local", "bin"), bundleApps())
+	result.Removed = append(result.Removed, orphans...)
+
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	result.Reclaimed = platform.TotalSize(result.Removed)
+
+	return app.outputCleanResult(result)
+}
+
+// outputCleanResult lists what was removed followed by the space reclaimed.
+func (app *CLI) outputCleanResult(result cleanResult) error {
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	verb := "Reclaimed"
+	if result.DryRun {
+		verb = "Would reclaim"
+	}
+
+	summary := fmt.Sprintf("%s %s from %s items", verb, stringutil.FormatBytes(result.Reclaimed), stringutil.FormatCount(len(result.Removed)))
+	if len(result.Removed) == 0 {
+		summary = "Nothing to clean"
+	}
+
+	if !app.json && len(result.Removed) > 0 {
+		lines := make([]string, 0, len(result.Removed))
+		for _, removal := range result.Removed {
+			lines = append(lines, fmt.Sprintf("  %-9s %s (%s)", stringutil.FormatBytes(removal.Size), removal.Path, removal.Reason))
+		}
+
+		_ = output.Info(strings.Join(lines, "\n"))
+	}
+
+	return output.Success(summary, result)
+}
+
+// retentionLimits are the limits karei clean prunes to.
+type retentionLimits struct {
+	cacheMaxAge  time.Duration
+	cacheMaxSize int64
+	logMaxAge    time.Duration
+}
+
+// cleanLimits reads the limits from config.toml, overridden by flags.
+func cleanLimits(cmd *cli.Command) (retentionLimits, error) {
+	prefs, err := config.LoadPreferences(xdg.PreferencesFile())
+	if err != nil {
+		return retentionLimits{}, domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	if value := cmd.String("max-age"); value != "" {
+		prefs.Clean.CacheMaxAge = value
+	}
+
+	if value := cmd.String("max-size"); value != "" {
+		prefs.Clean.CacheMaxSize = value
+	}
+
+	if value := cmd.String("log-max-age"); value != "" {
+		prefs.Clean.LogMaxAge = value
+	}
+
+	var limits retentionLimits
+
+	if limits.cacheMaxAge, err = prefs.CacheMaxAge(); err != nil {
+		return limits, domain.NewExitError(ExitUsageError, err.Error(), err)
+	}
+
+	if limits.cacheMaxSize, err = prefs.CacheMaxSize(); err != nil {
+		return limits, domain.NewExitError(ExitUsageError, err.Error(), err)
+	}
+
+	if limits.logMaxAge, err = prefs.LogMaxAge(); err != nil {
+		return limits, domain.NewExitError(ExitUsageError, err.Error(), err)
+	}
+
+	return limits, nil
+}
+
+// logPatterns matches karei's logs, including rotated ones and the logs of
+// each operation, and the run journal.
+func logPatterns() []string {
+	patterns := make([]string, 0, len(kareiLogs)+2)
+	for _, log := range kareiLogs {
+		patterns = append(patterns, filepath.Join(xdg.LogDir(), log.file+"*"))
+	}
+
+	return append(patterns, filepath.Join(xdg.OperationLogDir(), "*.log"), xdg.JournalFile())
+}
+
+// bundleApps lists the catalog apps installed from GitHub into a directory
+// of their own in ~/.local/share.
+func bundleApps() []string {
+	var names []string
+
+	for key, app := range apps.Apps {
+		if app.Method == domain.MethodGitHubBundle || app.Method == domain.MethodGitHubJava {
+			names = append(names, key)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
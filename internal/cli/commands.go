@@ -18,6 +18,8 @@ import (
 	"github.com/janderssonse/karei/internal/adapters/platform"
 	"github.com/janderssonse/karei/internal/adapters/system"
 	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
 )
 
 // Constants for verification and status strings.
@@ -28,6 +30,7 @@ const (
 	statusInstall   = "install"
 	statusFailed    = "failed"
 	statusInstalled = "installed"
+	statusPassed    = "passed"
 )
 
 var (
@@ -103,17 +106,50 @@ func (app *CLI) runSecurityTool(ctx context.Context, tool string) error {
 // createVerifyCommand creates the verify command directly.
 func (app *CLI) createVerifyCommand() *cli.Command {
 	return &cli.Command{
-		Name:        "verify",
-		Usage:       "Verify system configuration",
-		Description: "Run verification checks",
-		ArgsUsage:   "[what]",
+		Name:  "verify",
+		Usage: "Verify system configuration",
+		Description: `Run verification checks.
+
+With --deep, the installed version of every app with a version constraint,
+from the catalog or recorded at install, is checked against it too.
+
+karei verify configs reports the configuration files karei wrote that were
+changed or removed since. With --resolve merge, your changes are merged with
+karei's content; a merge with conflicts is written next to the file to
+finish by hand. With --resolve overwrite, your version is saved next to the
+file as .karei-save and karei's content is written again. With --resolve
+keep, your version is accepted as it is.`,
+		ArgsUsage: "[what]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "deep",
+				Usage: "also check installed versions against version constraints",
+			},
+			&cli.StringFlag{
+				Name:  "resolve",
+				Usage: "resolve changed configuration files: merge, overwrite or keep",
+			},
+		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			args := cmd.Args().Slice()
 			what := verifyAll
 			if len(args) > 0 {
 				what = args[0]
 			}
-			return app.runVerification(ctx, what)
+
+			if what == verifyConfigsName {
+				return app.verifyConfigs(ctx, domain.ConfigResolution(cmd.String("resolve")))
+			}
+
+			if err := app.runVerification(ctx, what); err != nil {
+				return err
+			}
+
+			if cmd.Bool("deep") {
+				return app.verifyVersionConstraints(ctx)
+			}
+
+			return nil
 		},
 	}
 }
@@ -141,6 +177,9 @@ func (app *CLI) getVerifier(what string) (func(context.Context) error, bool) {
 		"fish":         app.verifyFish,
 		"xdg":          app.verifyXDG,
 		"versions":     app.verifyVersions,
+		verifyConfigsName: func(ctx context.Context) error {
+			return app.verifyConfigs(ctx, "")
+		},
 	}
 
 	verifier, ok := verifiers[what]
@@ -157,6 +196,7 @@ func (app *CLI) runAllVerifications(ctx context.Context) error {
 		app.verifyFish,
 		app.verifyXDG,
 		app.verifyVersions,
+		func(ctx context.Context) error { return app.verifyConfigs(ctx, "") },
 	}
 
 	for _, verify := range verifiers {
@@ -369,6 +409,35 @@ func (app *CLI) verifyVersions(ctx context.Context) error {
 	return nil
 }
 
+// verifyVersionConstraints reports installed apps outside their version constraint.
+func (app *CLI) verifyVersionConstraints(ctx context.Context) error {
+	console.DefaultOutput.Progressf("Verifying version constraints...")
+
+	app.ensureInstallService()
+
+	violations := app.installService.VersionViolations(ctx, platform.NewVersionResolver(platform.NewCommandRunner(false, false)))
+
+	for _, violation := range violations {
+		if console.DefaultOutput.Plain {
+			console.DefaultOutput.PlainKeyValue("version-constraint", violation.Error())
+		} else {
+			console.DefaultOutput.Result("✗ " + violation.Error())
+		}
+	}
+
+	if len(violations) > 0 {
+		return domain.NewExitError(ExitGeneralError, fmt.Sprintf("%d apps violate their version constraint", len(violations)), nil)
+	}
+
+	if console.DefaultOutput.Plain {
+		console.DefaultOutput.PlainStatus("version-constraints", statusPassed)
+	} else {
+		console.DefaultOutput.Result("✓ Installed versions satisfy their constraints")
+	}
+
+	return nil
+}
+
 // reportToolVersion reports the version check result for a tool.
 func (app *CLI) reportToolVersion(name, output string, err error) {
 	keyName := strings.ToLower(name) + "-version"
@@ -396,7 +465,7 @@ func (app *CLI) createLogsCommand() *cli.Command {
 	return &cli.Command{
 		Name:        "logs",
 		Usage:       "View system logs",
-		Description: "Display Karei installation and operation logs",
+		Description: "Display Karei logs: install, progress, precheck, errors, all, or last for the complete log of the most recent run",
 		ArgsUsage:   "[type]",
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			args := cmd.Args().Slice()
@@ -411,7 +480,7 @@ func (app *CLI) createLogsCommand() *cli.Command {
 
 // showLogs displays the specified log type.
 func (app *CLI) showLogs(ctx context.Context, logType string) error {
-	logDir := filepath.Join(config.GetXDGDataHome(), "karei")
+	logDir := xdg.LogDir()
 
 	switch logType {
 	case statusInstall:
@@ -422,18 +491,10 @@ func (app *CLI) showLogs(ctx context.Context, logType string) error {
 		return showLogFile(ctx, filepath.Join(logDir, "precheck.log"), "Precheck")
 	case "errors":
 		return showLogFile(ctx, filepath.Join(logDir, "errors.log"), "Errors")
+	case "last":
+		return showLastOperationLog()
 	case verifyAll:
-		logTypes := []struct {
-			file string
-			name string
-		}{
-			{"install.log", "Installation"},
-			{"progress.log", "Progress"},
-			{"precheck.log", "Precheck"},
-			{"errors.log", "Errors"},
-		}
-
-		for _, lt := range logTypes {
+		for _, lt := range kareiLogs {
 			if err := showLogFile(ctx, filepath.Join(logDir, lt.file), lt.name); err != nil {
 				return err
 			}
@@ -447,6 +508,17 @@ func (app *CLI) showLogs(ctx context.Context, logType string) error {
 	}
 }
 
+// kareiLogs are the log files karei writes to its data directory.
+var kareiLogs = []struct { //nolint:gochecknoglobals
+	file string
+	name string
+}{
+	{"install.log", "Installation"},
+	{"progress.log", "Progress"},
+	{"precheck.log", "Precheck"},
+	{"errors.log", "Errors"},
+}
+
 // showLogFile displays the contents of a log file.
 func showLogFile(ctx context.Context, path, name string) error {
 	fmt.Printf("▸ %s Logs (%s):\n", name, path)
@@ -469,6 +541,25 @@ func showLogFile(ctx context.Context, path, name string) error {
 	return nil
 }
 
+// showLastOperationLog prints the complete log of the most recent install
+// or uninstall run, which unlike the other logs isn't cut to its tail.
+func showLastOperationLog() error {
+	path, err := platform.LatestOperationLog(xdg.OperationLogDir())
+	if errors.Is(err, platform.ErrNoOperationLog) {
+		return domain.NewExitError(ExitNotFoundError, "no operation has been logged yet", err)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // path is below karei's log directory
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, "failed to read "+path, err)
+	}
+
+	fmt.Printf("▸ Last Operation Log (%s):\n", path)
+	fmt.Print(string(data))
+
+	return nil
+}
+
 // Helper functions
 
 // commandExists checks if a command is available in PATH.
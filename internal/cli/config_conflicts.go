@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	cli "github.com/urfave/cli/v3"
+
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// configConflictFlags choose what happens to configuration files holding
+// changes of the user's that a command would replace, instead of asking.
+func configConflictFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "overwrite configuration files you changed, saving yours next to them",
+		},
+		&cli.BoolFlag{
+			Name:  "keep-existing",
+			Usage: "keep configuration files you changed and remember the choice",
+		},
+	}
+}
+
+// parseConfigConflictFlags sets the decision --force or --keep-existing make
+// for every conflicting configuration file.
+func (app *CLI) parseConfigConflictFlags(cmd *cli.Command) error {
+	force, keep := cmd.Bool("force"), cmd.Bool("keep-existing")
+
+	switch {
+	case force && keep:
+		return domain.NewExitError(ExitUsageError, "cannot use both --force and --keep-existing", nil)
+	case force:
+		app.configConflicts = domain.ResolveOverwrite
+	case keep:
+		app.configConflicts = domain.ResolveKeep
+	}
+
+	return nil
+}
+
+// resolveConfigConflict decides a configuration file karei would replace by
+// the flags, or else by asking. Without a terminal the file is left alone.
+func (app *CLI) resolveConfigConflict(conflict domain.ConfigConflict) domain.ConfigResolution {
+	if app.configConflicts != "" {
+		return app.configConflicts
+	}
+
+	resolution := console.AskConfigConflict(conflict)
+	if resolution == "" {
+		console.DefaultOutput.Warningf("Left %s as it is, pass --force to overwrite it or --keep-existing", conflict.Path)
+	}
+
+	return resolution
+}
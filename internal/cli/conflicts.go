@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// requestedApps returns the catalog keys an install would touch, dependencies included.
+func requestedApps(packagesFlag, groupFlag string) []string {
+	keys := apps.Groups[groupFlag]
+
+	if packagesFlag != "" {
+		for _, key := range strings.Split(packagesFlag, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return apps.WithDependencies(keys)
+}
+
+// resolveInstallConflicts refuses installs that conflict with each other or
+// with installed apps. With replace, the installed apps are removed first and
+// the removal is returned for the journal.
+func (app *CLI) resolveInstallConflicts(ctx context.Context, keys []string, replace bool, output domain.OutputPort) (*domain.UninstallResult, error) {
+	for i, key := range keys {
+		for _, other := range apps.Conflicting(key) {
+			if slices.Contains(keys[i+1:], other) {
+				return nil, domain.NewExitError(ExitUsageError,
+					fmt.Sprintf("%s conflicts with %s, install only one of them", key, other), nil)
+			}
+		}
+	}
+
+	conflicts := app.installService.InstalledConflicts(ctx, keys)
+	if len(conflicts) == 0 {
+		return nil, nil //nolint:nilnil // Nothing to replace is not an error
+	}
+
+	var replaced []string
+
+	for _, key := range keys {
+		installed, ok := conflicts[key]
+		if !ok {
+			continue
+		}
+
+		if !replace {
+			return nil, domain.NewExitError(ExitUsageError,
+				fmt.Sprintf("%s conflicts with installed %s, pass --replace to uninstall it first", key, strings.Join(installed, ", ")), nil)
+		}
+
+		_ = output.Info(fmt.Sprintf("↻ Replacing %s with %s", strings.Join(installed, ", "), key))
+		replaced = append(replaced, installed...)
+	}
+
+	app.ensureUninstallService()
+
+	result, _ := app.uninstallService.UninstallPackages(ctx, replaced)
+	if len(result.Failed) > 0 {
+		return result, domain.NewExitError(ExitGeneralError,
+			"failed to uninstall conflicting "+strings.Join(result.Failed, ", ")+", nothing was installed", nil)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// checkConnectivity checks the network once per run, before the first
+// network-dependent phase, and warns when it is down or behind a captive
+// portal. Callers skip what needs the network rather than let each package
+// time out, while local changes still go ahead.
+func (app *CLI) checkConnectivity(ctx context.Context) domain.Connectivity {
+	if app.connectivity != domain.ConnectivityUnknown {
+		return app.connectivity
+	}
+
+	app.connectivity = network.CheckConnectivity(ctx, network.GetHTTPClient(), network.DefaultConnectivityCheckURL)
+
+	switch app.connectivity {
+	case domain.ConnectivityOffline:
+		console.DefaultOutput.Warningf("No network connection: downloads are skipped, local changes still apply")
+	case domain.ConnectivityCaptivePortal:
+		console.DefaultOutput.Warningf("A captive portal intercepts web traffic, sign in to the network first: downloads are skipped, local changes still apply")
+	}
+
+	if app.connectivity.Available() {
+		app.announceDownloadLimits()
+	}
+
+	return app.connectivity
+}
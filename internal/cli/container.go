@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// hostRoot is where toolbox and distrobox mount the host filesystem.
+const hostRoot = "/run/host"
+
+// ErrNoContainerTool is returned when neither toolbox nor distrobox is available.
+var ErrNoContainerTool = errors.New("neither distrobox nor toolbox is installed")
+
+// runInstallInContainer re-runs the install inside a toolbox or distrobox
+// container using this binary through the host mount, so nothing needs to be
+// installed in the container first. Exporting to the host happens in there.
+func (app *CLI) runInstallInContainer(ctx context.Context, container, packagesFlag, groupFlag string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, "failed to locate karei binary", err)
+	}
+
+	args := []string{hostRoot + executable}
+	args = append(args, app.forwardedGlobalFlags()...)
+	args = append(args, "install")
+
+	if packagesFlag != "" {
+		args = append(args, "--packages", packagesFlag)
+	}
+
+	if groupFlag != "" {
+		args = append(args, "--group", groupFlag)
+	}
+
+	name, toolArgs, err := containerCommand(platform.NewCommandRunner(false, false), container, args)
+	if err != nil {
+		return domain.NewExitError(ExitDependencyError, err.Error(), err)
+	}
+
+	cmd := exec.CommandContext(ctx, name, toolArgs...) // #nosec G204 - container name and args come from the user's own flags
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+			return domain.NewExitError(exitErr.ExitCode(), fmt.Sprintf("install in container %s failed", container), err)
+		}
+
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	return nil
+}
+
+// containerCommand builds the command that runs args inside the container, preferring distrobox.
+func containerCommand(runner domain.CommandRunner, container string, args []string) (string, []string, error) {
+	switch {
+	case runner.CommandExists(domain.ContainerDistrobox):
+		return domain.ContainerDistrobox, append([]string{"enter", container, "--"}, args...), nil
+	case runner.CommandExists(domain.ContainerToolbox):
+		return domain.ContainerToolbox, append([]string{"run", "-c", container}, args...), nil
+	default:
+		return "", nil, ErrNoContainerTool
+	}
+}
+
+// forwardedGlobalFlags returns the global flags to pass to a nested karei invocation.
+func (app *CLI) forwardedGlobalFlags() []string {
+	globals := []struct {
+		flag string
+		set  bool
+	}{
+		{"--verbose", app.verbose},
+		{"--json", app.json},
+		{"--quiet", app.quiet},
+		{"--plain", app.plain},
+		{"--yes", app.yes},
+	}
+
+	var flags []string
+
+	for _, global := range globals {
+		if global.set {
+			flags = append(flags, global.flag)
+		}
+	}
+
+	return flags
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// migrateArgs rewrites an invocation in a deprecated form, such as
+// karei theme <name>, to the form replacing it, and remembers the
+// deprecation to hint at once the flags are known. Invocations naming a
+// current command are never rewritten.
+func (app *CLI) migrateArgs(args []string) []string {
+	if len(args) < 2 {
+		return args
+	}
+
+	start := app.commandStart(args)
+	words := args[start:]
+
+	if len(words) == 0 || app.resolves(words) {
+		return args
+	}
+
+	for _, deprecation := range domain.Deprecations() {
+		if rewritten, ok := deprecation.Rewrite(words); ok {
+			app.deprecation = &deprecation
+
+			return append(slices.Clone(args[:start]), rewritten...)
+		}
+	}
+
+	return args
+}
+
+// commandStart returns the index of the first command word in args,
+// skipping the program name and the global flags with their values.
+func (app *CLI) commandStart(args []string) int {
+	i := 1
+
+	for i < len(args) && strings.HasPrefix(args[i], "-") {
+		name := strings.TrimLeft(args[i], "-")
+		i++
+
+		if name == "" || strings.Contains(name, "=") {
+			continue
+		}
+
+		for _, flag := range app.app.Flags {
+			if _, isBool := flag.(*cli.BoolFlag); !isBool && slices.Contains(flag.Names(), name) {
+				i++ // Its value
+			}
+		}
+	}
+
+	return min(i, len(args))
+}
+
+// resolves reports whether words name a current command: each word up to
+// the first argument is a subcommand, and the command reached takes
+// arguments rather than expecting a subcommand.
+func (app *CLI) resolves(words []string) bool {
+	cmd := app.app
+
+	for _, word := range words {
+		if strings.HasPrefix(word, "-") || word == "help" {
+			return true
+		}
+
+		sub := cmd.Command(word)
+		if sub == nil {
+			return len(cmd.Commands) == 0
+		}
+
+		cmd = sub
+	}
+
+	return true
+}
+
+// noteDeprecation prints a one-line hint when karei was invoked in a
+// deprecated form, and counts the use when usage statistics are enabled.
+func (app *CLI) noteDeprecation() {
+	if app.deprecation == nil {
+		return
+	}
+
+	console.DefaultOutput.Warningf("'karei %s' is deprecated and goes away in %s, use 'karei %s'",
+		app.deprecation.Old, app.deprecation.Removal, app.deprecation.New)
+
+	if !app.usageStats {
+		return
+	}
+
+	if err := app.usageRecorder().RecordDeprecated(app.deprecation.Old); err != nil && app.verbose {
+		console.DefaultOutput.Warningf("Could not record usage: %v", err)
+	}
+}
+
+// createDeprecationsCommand creates the deprecations command listing the
+// old command forms still accepted.
+func (app *CLI) createDeprecationsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "deprecations",
+		Usage: "List old command forms still accepted and what replaces them",
+		Description: `Print the command forms karei still accepts but will stop accepting, with
+the form to use instead. Running an old form prints a one-line hint; with
+usage statistics enabled (karei privacy enable) the uses are counted, so
+you can check your scripts before the removal version.
+
+Examples:
+  karei deprecations
+  karei deprecations --json`,
+		Action: app.runDeprecations,
+	}
+}
+
+// runDeprecations prints the deprecation registry with the locally counted uses.
+func (app *CLI) runDeprecations(_ context.Context, _ *cli.Command) error {
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	stats, err := app.usageRecorder().Load()
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	deprecations := domain.Deprecations()
+
+	if app.json {
+		return output.Success("", map[string]any{
+			"deprecations": deprecations,
+			"uses":         stats.Deprecated,
+		})
+	}
+
+	rows := make([][]string, 0, len(deprecations))
+	for _, deprecation := range deprecations {
+		rows = append(rows, []string{
+			"karei " + deprecation.Old, "karei " + deprecation.New,
+			deprecation.Since, deprecation.Removal, strconv.Itoa(stats.Deprecated[deprecation.Old]),
+		})
+	}
+
+	return output.Table([]string{"OLD", "USE INSTEAD", "SINCE", "REMOVED IN", "USES"}, rows)
+}
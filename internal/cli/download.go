@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	cli "github.com/urfave/cli/v3"
+
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/stringutil"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// downloadFlags control when and how much installs download.
+func downloadFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "now",
+			Usage: "download right away instead of waiting for off_peak in config.toml",
+		},
+		&cli.BoolFlag{
+			Name:  "force-download",
+			Usage: "download on a metered connection without asking, however much",
+		},
+	}
+}
+
+// parseDownloadFlags reads downloadFlags.
+func (app *CLI) parseDownloadFlags(cmd *cli.Command) {
+	app.downloadNow = cmd.Bool("now")
+	app.forceDownload = cmd.Bool("force-download")
+}
+
+// loadDownloadPreferences sets the bandwidth limit, off-peak window and
+// metered threshold of [download] in config.toml. KAREI_BANDWIDTH_LIMIT takes precedence, and
+// carries the limit to karei's HTTP clients, apt and child processes.
+func (app *CLI) loadDownloadPreferences(prefs *config.Preferences) error {
+	var err error
+
+	if app.offPeak, err = domain.ParseOffPeak(prefs.Download.OffPeak); err != nil {
+		return err
+	}
+
+	if app.meteredThreshold, err = prefs.MeteredThreshold(); err != nil {
+		return err
+	}
+
+	if value := os.Getenv(xdg.EnvBandwidthLimit); value != "" {
+		if _, err := stringutil.ParseBytes(value); err != nil {
+			return domain.NewExitError(ExitConfigError, "invalid "+xdg.EnvBandwidthLimit, err)
+		}
+
+		return nil
+	}
+
+	limit, err := prefs.BandwidthLimit()
+	if err != nil || limit == 0 {
+		return err
+	}
+
+	return os.Setenv(xdg.EnvBandwidthLimit, strconv.FormatInt(limit, 10))
+}
+
+// announceDownloadLimits tells, along with the preflight check, how
+// downloads are held back.
+func (app *CLI) announceDownloadLimits() {
+	if limit := xdg.BandwidthLimit(); limit > 0 {
+		console.DefaultOutput.Infof("Downloads limited to %s/s; Flatpak, Snap and installer scripts aren't limited", stringutil.FormatBytes(limit))
+	}
+
+	if !app.offPeak.IsZero() {
+		console.DefaultOutput.Infof("Downloads are scheduled off-peak, %s", app.offPeak)
+	}
+}
+
+// waitForOffPeak holds installs until the off-peak window opens, unless
+// --now was given or nothing can be downloaded anyway.
+func (app *CLI) waitForOffPeak(ctx context.Context) error {
+	wait := app.offPeak.Wait(time.Now())
+	if wait == 0 || app.downloadNow || !app.connectivity.Available() {
+		return nil
+	}
+
+	console.DefaultOutput.Infof("Waiting %s for the off-peak window %s, pass --now to start right away", stringutil.FormatDuration(wait), app.offPeak)
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		code := ExitInterruptError
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			code = ExitTimeoutError
+		}
+
+		return domain.NewExitError(code, "stopped waiting for the off-peak window", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// confirmMeteredDownload warns before downloading on a metered connection,
+// and asks first when the apps would download more than metered_threshold.
+// Without a terminal, --force-download or --yes is required then.
+func (app *CLI) confirmMeteredDownload(ctx context.Context, keys []string) error {
+	if app.forceDownload || !app.connectivity.Available() || !network.DetectMetered(ctx, platform.NewCommandRunner(false, false)) {
+		return nil
+	}
+
+	estimate := app.installService.EstimateDownload(keys)
+	size := stringutil.FormatBytes(estimate)
+
+	if estimate <= app.meteredThreshold {
+		console.DefaultOutput.Warningf("The connection is metered, about %s will be downloaded", size)
+
+		return nil
+	}
+
+	if !console.AutoYes && !console.DefaultOutput.IsTTY(os.Stdin.Fd()) {
+		return domain.NewExitError(ExitUsageError,
+			"refusing to download about "+size+" on a metered connection, pass --force-download to go ahead", nil)
+	}
+
+	if !console.AskMeteredDownload(size) {
+		return errSelectionCancelled
+	}
+
+	return nil
+}
+
+// measureDownloads returns a func that reports how much the network
+// received since, for the summary of a run. The count covers every
+// download, apt, Flatpak and Snap included, and other traffic meanwhile.
+func (app *CLI) measureDownloads() func() {
+	start := network.ReceivedBytes()
+	if start == 0 || !app.connectivity.Available() {
+		return func() {}
+	}
+
+	return func() {
+		if received := network.ReceivedBytes() - start; received > 0 {
+			console.DefaultOutput.Infof("Received %s over the network during the run", stringutil.FormatBytes(received))
+		}
+	}
+}
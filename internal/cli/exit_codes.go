@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// createExitCodesCommand creates the exit-codes command documenting how
+// karei ends.
+func (app *CLI) createExitCodesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "exit-codes",
+		Usage: "List the exit codes karei ends with",
+		Description: `Print every exit code karei ends with, for scripts checking $?. Codes
+are stable: new ones may be added, existing ones never change meaning.
+
+Examples:
+  karei exit-codes
+  karei exit-codes --json`,
+		Action: app.runExitCodes,
+	}
+}
+
+// runExitCodes prints the exit code registry.
+func (app *CLI) runExitCodes(_ context.Context, _ *cli.Command) error {
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	codes := domain.ExitCodes()
+	if app.json {
+		return output.Success("", codes)
+	}
+
+	rows := make([][]string, 0, len(codes))
+	for _, code := range codes {
+		rows = append(rows, []string{strconv.Itoa(code.Code), code.Name, code.Meaning})
+	}
+
+	return output.Table([]string{"CODE", "NAME", "MEANING"}, rows)
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/remote"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/fleet"
+	"github.com/janderssonse/karei/internal/stringutil"
+)
+
+// createFleetCommand creates the fleet command for provisioning many hosts.
+func (app *CLI) createFleetCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "fleet",
+		Usage: "Provision multiple machines from an inventory",
+		Description: `Provision hosts listed in an inventory file over SSH.
+
+Each host runs its profiles' steps in order; a failure stops only that host.
+Profile files and extensions are used by 'karei generate' only and skipped here.
+Hosts are provisioned concurrently and output lines are prefixed with the host.
+
+INVENTORY FORMAT (YAML):
+  concurrency: 4
+  vars:
+    theme: tokyo-night
+  profiles:
+    developer:
+      groups: [development]
+      apps: [git, vim]
+      theme: ${theme}
+  hosts:
+    - host: user@lab1
+      profiles: [developer]
+    - host: user@lab2
+      profiles: [developer]
+      ssh_args: ["-p", "2222"]
+      vars:
+        theme: nord
+
+EXAMPLES:
+  karei fleet apply inventory.yaml
+  karei fleet apply --dry-run inventory.yaml`,
+		Commands: []*cli.Command{
+			{
+				Name:      "apply",
+				Usage:     "Provision every host in the inventory",
+				ArgsUsage: "<inventory.yaml>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "print the steps for each host without connecting",
+					},
+					&cli.IntFlag{
+						Name:  "concurrency",
+						Usage: "maximum hosts provisioned at once (overrides the inventory)",
+					},
+				},
+				Action: app.handleFleetApply,
+			},
+		},
+	}
+}
+
+// handleFleetApply loads the inventory and provisions all hosts.
+func (app *CLI) handleFleetApply(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return domain.NewExitError(ExitUsageError, "usage: karei fleet apply <inventory.yaml>", ErrInvalidArgument)
+	}
+
+	inv, err := fleet.Load(cmd.Args().First())
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	warnGeneratorOnly(inv)
+
+	if concurrency := cmd.Int("concurrency"); concurrency > 0 {
+		inv.Concurrency = concurrency
+	}
+
+	run := runFleetStep
+	if cmd.Bool("dry-run") {
+		run = printFleetStep
+	}
+
+	progress := io.Writer(os.Stderr)
+	if app.quiet {
+		progress = io.Discard
+	}
+
+	report := fleet.Apply(ctx, inv, run, progress)
+
+	app.printFleetReport(report)
+
+	if failed := report.Failed(); failed > 0 {
+		return domain.NewExitError(ExitAppError, fmt.Sprintf("%d of %d hosts failed", failed, len(report.Hosts)), ErrAppsFailedToInstall)
+	}
+
+	return nil
+}
+
+// warnGeneratorOnly names the profile fields fleet apply skips.
+func warnGeneratorOnly(inv *fleet.Inventory) {
+	for _, name := range slices.Sorted(maps.Keys(inv.Profiles)) {
+		if fields := inv.Profiles[name].GeneratorOnly(); len(fields) > 0 {
+			console.DefaultOutput.Warningf("profile %s: %s only apply to generated files and are skipped", name, strings.Join(fields, " and "))
+		}
+	}
+}
+
+// runFleetStep runs one step on a host through the SSH executor.
+func runFleetStep(ctx context.Context, host fleet.Host, sshArgs []string, step fleet.Step, out io.Writer) error {
+	executor := remote.NewExecutor(nil, out, out)
+
+	return executor.Run(ctx, remote.Options{Host: host.Host, SSHArgs: sshArgs}, append([]string{"--plain"}, step...))
+}
+
+// printFleetStep shows the step a real run would execute.
+func printFleetStep(_ context.Context, _ fleet.Host, _ []string, _ fleet.Step, out io.Writer) error {
+	_, err := fmt.Fprintln(out, "DRY RUN: not executed")
+
+	return err
+}
+
+// printFleetReport prints the per-host summary.
+func (app *CLI) printFleetReport(report *fleet.Report) {
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if app.json {
+		_ = output.Success("fleet apply finished", report)
+
+		return
+	}
+
+	rows := make([][]string, 0, len(report.Hosts))
+
+	for _, host := range report.Hosts {
+		status := "ok"
+		if !host.Success {
+			status = statusFailed
+		}
+
+		rows = append(rows, []string{
+			host.Host,
+			status,
+			strconv.Itoa(host.Completed) + "/" + strconv.Itoa(host.Total),
+			stringutil.FormatDuration(host.Duration.Round(time.Second)),
+			host.Error,
+		})
+	}
+
+	_ = output.Table([]string{"HOST", "STATUS", "STEPS", "DURATION", "ERROR"}, rows)
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"slices"
+	"strings"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// runuser switches to another user without asking for a password when run as root.
+const runuser = "runuser"
+
+// runInstallForUsers re-runs the install as each user in the comma-separated
+// list with --scope user, so shell configuration, fonts and desktop entries
+// land in their home owned by them, and each keeps its own journal. A failure
+// for one user doesn't stop the others.
+func (app *CLI) runInstallForUsers(ctx context.Context, users, packagesFlag, groupFlag string) error {
+	if os.Geteuid() != 0 {
+		return domain.NewExitError(ExitPermissionError, "--for-user must be run as root, e.g. with sudo", nil)
+	}
+
+	if !platform.NewCommandRunner(false, false).CommandExists(runuser) {
+		return domain.NewExitError(ExitDependencyError, runuser+" is not installed", nil)
+	}
+
+	var accounts []*user.User
+
+	for _, name := range strings.Split(users, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+
+		account, err := user.Lookup(name)
+		if err != nil {
+			return domain.NewExitError(ExitNotFoundError, "unknown user "+name, err)
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, "failed to locate karei binary", err)
+	}
+
+	args := app.forUserArgs(executable, packagesFlag, groupFlag)
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	var failed []string
+
+	for _, account := range accounts {
+		_ = output.Info(fmt.Sprintf("Installing for %s (%s)", account.Username, account.HomeDir))
+
+		cmd := userCommand(ctx, args, account)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		if err := cmd.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if !errors.As(err, &exitErr) {
+				return domain.NewExitError(ExitSystemError, err.Error(), err)
+			}
+
+			failed = append(failed, account.Username)
+		}
+	}
+
+	if len(failed) > 0 {
+		return domain.NewExitError(ExitGeneralError, "install failed for "+strings.Join(failed, ", "), nil)
+	}
+
+	return nil
+}
+
+// forUserArgs returns the runuser arguments re-running the install with
+// --scope user. The user name at index 1 is filled in per account.
+func (app *CLI) forUserArgs(executable, packagesFlag, groupFlag string) []string {
+	args := []string{"-u", "", "--", executable}
+	args = append(args, app.forwardedGlobalFlags()...)
+	args = append(args, "install", "--scope", string(domain.ScopeUser))
+
+	if packagesFlag != "" {
+		args = append(args, "--packages", packagesFlag)
+	}
+
+	if groupFlag != "" {
+		args = append(args, "--group", groupFlag)
+	}
+
+	return args
+}
+
+// userCommand returns the runuser command running args as account, in its
+// home with its environment.
+func userCommand(ctx context.Context, args []string, account *user.User) *exec.Cmd {
+	args = slices.Clone(args)
+	args[1] = account.Username
+
+	cmd := exec.CommandContext(ctx, runuser, args...) // #nosec G204 - user names are checked against the user database
+	cmd.Env = userEnv(os.Environ(), account)
+	cmd.Dir = account.HomeDir
+
+	return cmd
+}
+
+// userEnv returns root's environment adjusted for running as account: its
+// home and name, without root's XDG directories and karei overrides.
+func userEnv(environ []string, account *user.User) []string {
+	env := make([]string, 0, len(environ)+3)
+
+	for _, entry := range environ {
+		name, _, _ := strings.Cut(entry, "=")
+
+		switch {
+		case name == "HOME", name == "USER", name == "LOGNAME", name == "SUDO_USER":
+		case strings.HasPrefix(name, "XDG_"), strings.HasPrefix(name, "KAREI_"):
+		default:
+			env = append(env, entry)
+		}
+	}
+
+	return append(env, "HOME="+account.HomeDir, "USER="+account.Username, "LOGNAME="+account.Username)
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"os/user"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserEnv(t *testing.T) {
+	t.Parallel()
+
+	alice := &user.User{Username: "alice", HomeDir: "/home/alice"}
+
+	tests := []struct {
+		name    string
+		environ []string
+		want    []string
+	}{
+		{
+			name:    "identity replaced",
+			environ: []string{"HOME=/root", "USER=root", "LOGNAME=root", "SUDO_USER=admin"},
+			want:    []string{"HOME=/home/alice", "USER=alice", "LOGNAME=alice"},
+		},
+		{
+			name:    "XDG directories dropped",
+			environ: []string{"XDG_CONFIG_HOME=/root/.config", "XDG_RUNTIME_DIR=/run/user/0"},
+			want:    []string{"HOME=/home/alice", "USER=alice", "LOGNAME=alice"},
+		},
+		{
+			name:    "karei overrides dropped",
+			environ: []string{"KAREI_PATH=/root/.local/share/karei", "KAREI_CONFIG=/root/karei.toml"},
+			want:    []string{"HOME=/home/alice", "USER=alice", "LOGNAME=alice"},
+		},
+		{
+			name:    "everything else kept",
+			environ: []string{"PATH=/usr/bin:/bin", "LANG=C.UTF-8", "HOMEBREW=1", "MY_XDG_THING=1", "HOME=/root"},
+			want:    []string{"PATH=/usr/bin:/bin", "LANG=C.UTF-8", "HOMEBREW=1", "MY_XDG_THING=1", "HOME=/home/alice", "USER=alice", "LOGNAME=alice"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, userEnv(tt.environ, alice))
+		})
+	}
+}
+
+func TestForUserArgs(t *testing.T) {
+	t.Parallel()
+
+	app := &CLI{verbose: true, yes: true}
+
+	args := app.forUserArgs("/usr/bin/karei", "git,vim", "dev")
+	assert.Equal(t, []string{
+		"-u", "", "--", "/usr/bin/karei", "--verbose", "--yes",
+		"install", "--scope", "user", "--packages", "git,vim", "--group", "dev",
+	}, args)
+
+	assert.Equal(t, []string{"-u", "", "--", "/usr/bin/karei", "install", "--scope", "user"},
+		(&CLI{}).forUserArgs("/usr/bin/karei", "", ""))
+
+	alice := userCommand(context.Background(), args, &user.User{Username: "alice", HomeDir: "/home/alice"})
+	bob := userCommand(context.Background(), args, &user.User{Username: "bob", HomeDir: "/home/bob"})
+
+	assert.Equal(t, []string{runuser, "-u", "alice", "--", "/usr/bin/karei"}, alice.Args[:5])
+	assert.Equal(t, []string{runuser, "-u", "bob", "--", "/usr/bin/karei"}, bob.Args[:5])
+	assert.Equal(t, "/home/bob", bob.Dir)
+	assert.Contains(t, bob.Env, "HOME=/home/bob")
+	assert.Empty(t, args[1], "the shared arguments are left for the next account")
+}
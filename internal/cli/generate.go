@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cli "github.com/urfave/cli/v3"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/fleet"
+	"github.com/janderssonse/karei/internal/generate"
+)
+
+const generatedFilePerm = 0o644
+
+// createGenerateCommand creates the generate command for exporting profiles to other tools.
+func (app *CLI) createGenerateCommand() *cli.Command {
+	profileFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:  "profile",
+			Usage: "read the named profile from an inventory file instead of a profile file",
+		},
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Usage:   "write to file instead of stdout",
+		},
+	}
+
+	return &cli.Command{
+		Name:  "generate",
+		Usage: "Convert a karei profile to Ansible or cloud-init",
+		Description: `Generate provisioning code for other tools from a karei profile.
+
+A profile file uses the same fields as an inventory profile:
+  groups: [development]
+  apps: [git, vim]
+  theme: tokyo-night
+
+apt, snap and flatpak apps become native tasks; everything else, and the
+theme, is applied by running karei on the target. The Ansible and cloud-init
+output first downloads karei's latest release to /usr/local/bin there.
+
+EXAMPLES:
+  karei generate ansible profile.yaml > playbook.yaml
+  karei generate cloud-init --user dev profile.yaml -o user-data
+  karei generate ansible --profile developer inventory.yaml
+  karei generate devcontainer --output . profile.yaml`,
+		Commands: []*cli.Command{
+			{
+				Name:      "ansible",
+				Usage:     "Generate an Ansible playbook",
+				ArgsUsage: "<profile.yaml>",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{Name: "hosts", Usage: "host pattern for the play", Value: "all"},
+				}, profileFlags...),
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					return app.runGenerate(cmd, func(plan *generate.Plan) ([]byte, error) {
+						return generate.Ansible(plan, cmd.String("hosts"))
+					})
+				},
+			},
+			{
+				Name:      "cloud-init",
+				Usage:     "Generate cloud-init user-data",
+				ArgsUsage: "<profile.yaml>",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{Name: "user", Usage: "user that karei steps run as", Value: "ubuntu"},
+				}, profileFlags...),
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					return app.runGenerate(cmd, func(plan *generate.Plan) ([]byte, error) {
+						return generate.CloudInit(plan, cmd.String("user"))
+					})
+				},
+			},
+			{
+				Name:      "devcontainer",
+				Usage:     "Generate .devcontainer/devcontainer.json and a setup script",
+				ArgsUsage: "<profile.yaml>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "image", Usage: "base image", Value: "mcr.microsoft.com/devcontainers/base:ubuntu-24.04"},
+					profileFlags[0],
+					&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "project directory to write .devcontainer into", Value: "."},
+				},
+				Action: app.handleGenerateDevcontainer,
+			},
+		},
+	}
+}
+
+// handleGenerateDevcontainer writes a .devcontainer directory for the profile.
+func (app *CLI) handleGenerateDevcontainer(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return domain.NewExitError(ExitUsageError, "usage: karei generate devcontainer <profile.yaml>", ErrInvalidArgument)
+	}
+
+	profilePath := cmd.Args().First()
+
+	profile, err := loadGenerateProfile(profilePath, cmd.String("profile"))
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	plan, err := generate.NewPlan(profile)
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	config, script, err := generate.Devcontainer(plan, cmd.String("image"))
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	dir := filepath.Join(cmd.String("output"), ".devcontainer")
+	if err := writeDevcontainer(dir, filepath.Dir(profilePath), plan, config, script); err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	if !app.quiet {
+		fmt.Fprintf(os.Stderr, "Devcontainer written to %s\n", dir)
+	}
+
+	return nil
+}
+
+// writeDevcontainer writes the devcontainer files, dotfiles and karei binary into dir.
+func writeDevcontainer(dir, profileDir string, plan *generate.Plan, config, script []byte) error {
+	if err := os.MkdirAll(dir, contextDirPerm); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "devcontainer.json"), config, generatedFilePerm); err != nil {
+		return fmt.Errorf("failed to write devcontainer.json: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, generate.DevcontainerSetupScript), script, binaryFilePerm); err != nil { //nolint:gosec // setup script must be executable
+		return fmt.Errorf("failed to write setup script: %w", err)
+	}
+
+	if err := copyProfileFiles(filepath.Join(dir, generate.DevcontainerDotfilesDir), profileDir, plan.Files); err != nil {
+		return err
+	}
+
+	if plan.NeedsKarei() {
+		return copyKareiBinary(dir)
+	}
+
+	return nil
+}
+
+// runGenerate loads the profile, renders it and writes the result.
+func (app *CLI) runGenerate(cmd *cli.Command, render func(*generate.Plan) ([]byte, error)) error {
+	if cmd.Args().Len() != 1 {
+		return domain.NewExitError(ExitUsageError, fmt.Sprintf("usage: karei generate %s <profile.yaml>", cmd.Name), ErrInvalidArgument)
+	}
+
+	profile, err := loadGenerateProfile(cmd.Args().First(), cmd.String("profile"))
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	plan, err := generate.NewPlan(profile)
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	out, err := render(plan)
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	if path := cmd.String("output"); path != "" {
+		if err := os.WriteFile(path, out, generatedFilePerm); err != nil {
+			return domain.NewExitError(ExitGeneralError, err.Error(), err)
+		}
+
+		return nil
+	}
+
+	_, err = os.Stdout.Write(out)
+
+	return err
+}
+
+// loadGenerateProfile reads a profile file, or a named profile from an inventory.
+func loadGenerateProfile(path, name string) (*fleet.Profile, error) {
+	if name == "" {
+		return fleet.LoadProfile(path)
+	}
+
+	inv, err := fleet.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, exists := inv.Profiles[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", fleet.ErrUnknownProfile, name)
+	}
+
+	return &profile, nil
+}
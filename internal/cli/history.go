@@ -0,0 +1,245 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// snapshotNow names the current state where a snapshot is expected.
+const snapshotNow = "now"
+
+// historyTimeLayout is how snapshot times are shown.
+const historyTimeLayout = "2006-01-02 15:04"
+
+// createDiffCommand creates the diff command comparing snapshots of the history.
+func (app *CLI) createDiffCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "Show what karei changed between two snapshots",
+		ArgsUsage: "[<snapshotA> [<snapshotB>]]",
+		Description: `karei takes a snapshot of the apps it installed and the configuration files
+it manages whenever a command changes them. Compare two snapshots to find the
+packages added, removed or upgraded and the configs changed in between.
+
+A snapshot is named by its ID, by a date, which picks the state at that time,
+or by "now". snapshotB defaults to now. Without arguments the snapshots are
+listed.
+
+Examples:
+  karei diff                                   # List snapshots
+  karei diff 20250101-090000 20250301-120000   # Compare two snapshots
+  karei diff 2025-01-01                        # What changed since January 1st`,
+		Action: app.runDiff,
+	}
+}
+
+// runDiff lists the snapshots, or compares two of them.
+func (app *CLI) runDiff(_ context.Context, cmd *cli.Command) error {
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if cmd.Args().Len() == 0 {
+		return app.listSnapshots(output)
+	}
+
+	if cmd.Args().Len() > 2 {
+		return domain.NewExitError(ExitUsageError, "specify at most two snapshots to compare", nil)
+	}
+
+	before, err := app.resolveSnapshot(cmd.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	after, err := app.resolveSnapshot(cmd.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	diff := domain.DiffSnapshots(before, after)
+	if app.json {
+		return output.Success("", diff)
+	}
+
+	return showHistoryDiff(output, diff)
+}
+
+// listSnapshots shows every snapshot with its size.
+func (app *CLI) listSnapshots(output domain.OutputPort) error {
+	snapshots, err := app.history().List()
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, "failed to read history", err)
+	}
+
+	if app.json {
+		return output.Success("", snapshots)
+	}
+
+	if len(snapshots) == 0 {
+		return output.Info("No snapshots yet; karei takes one whenever it installs or configures something")
+	}
+
+	for _, snapshot := range snapshots {
+		_ = output.Info(fmt.Sprintf("%s  %s  %d packages, %d configs", snapshot.ID,
+			snapshot.Taken.Local().Format(historyTimeLayout), len(snapshot.Packages), len(snapshot.Configs)))
+	}
+
+	return nil
+}
+
+// resolveSnapshot returns the snapshot named by ref: an ID, a date or now.
+func (app *CLI) resolveSnapshot(ref string) (domain.Snapshot, error) {
+	if ref == "" || ref == snapshotNow {
+		return app.currentSnapshot()
+	}
+
+	snapshot, err := app.history().Get(ref)
+	if err == nil {
+		return snapshot, nil
+	}
+
+	if !errors.Is(err, domain.ErrSnapshotNotFound) {
+		return domain.Snapshot{}, domain.NewExitError(ExitGeneralError, "failed to read history", err)
+	}
+
+	when, parseErr := domain.ParseSince(ref)
+	if parseErr != nil {
+		return domain.Snapshot{}, domain.NewExitError(ExitNotFoundError,
+			fmt.Sprintf("no snapshot %s, list them with: karei diff", ref), err)
+	}
+
+	return app.snapshotAt(when)
+}
+
+// snapshotAt returns the state at when, from the history.
+func (app *CLI) snapshotAt(when time.Time) (domain.Snapshot, error) {
+	snapshot, err := app.history().At(when)
+	if err != nil {
+		return domain.Snapshot{}, domain.NewExitError(ExitGeneralError, "failed to read history", err)
+	}
+
+	return snapshot, nil
+}
+
+// history returns the snapshots of what karei installed and configured.
+func (app *CLI) history() *application.History {
+	return application.NewHistory(platform.NewFileManager(false), xdg.HistoryFile())
+}
+
+// currentSnapshot returns what karei has installed and configured now.
+func (app *CLI) currentSnapshot() (domain.Snapshot, error) {
+	records, err := app.installRecords().Load()
+	if err != nil {
+		return domain.Snapshot{}, domain.NewExitError(ExitGeneralError, "failed to read install records", err)
+	}
+
+	configs, err := app.configFiles().Managed()
+	if err != nil {
+		return domain.Snapshot{}, domain.NewExitError(ExitGeneralError, "failed to read managed configs", err)
+	}
+
+	return application.SnapshotOf(time.Now(), records, configs), nil
+}
+
+// recordSnapshot adds the current state to the history when a command
+// changed it. Failing to record never fails the command.
+func (app *CLI) recordSnapshot() {
+	snapshot, err := app.currentSnapshot()
+	if err == nil {
+		_, err = app.history().Capture(snapshot)
+	}
+
+	if err != nil && app.verbose {
+		console.DefaultOutput.Warningf("Could not record history: %v", err)
+	}
+}
+
+// afterCommand records the state a command left and flushes the webhook.
+func (app *CLI) afterCommand(ctx context.Context, cmd *cli.Command) error {
+	app.recordSnapshot()
+
+	return app.stopWebhook(ctx, cmd)
+}
+
+// showHistoryDiff lists the packages and configs that changed.
+func showHistoryDiff(output domain.OutputPort, diff domain.HistoryDiff) error {
+	_ = output.Info(fmt.Sprintf("Changes from %s to %s", historyTime(diff.From), historyTime(diff.To)))
+
+	if diff.Empty() {
+		return output.Info("  Nothing changed")
+	}
+
+	if len(diff.Packages) > 0 {
+		_ = output.Info("Packages:")
+
+		for _, change := range diff.Packages {
+			_ = output.Info(fmt.Sprintf("  %s %-10s %s", changeMark(change.Change), change.Change, packageChangeDetail(change)))
+		}
+	}
+
+	if len(diff.Configs) > 0 {
+		_ = output.Info("Configs:")
+
+		for _, change := range diff.Configs {
+			_ = output.Info(fmt.Sprintf("  %s %-10s %s", changeMark(change.Change), change.Change, change.Path))
+		}
+	}
+
+	return nil
+}
+
+// packageChangeDetail describes an app change, with how it is installed.
+func packageChangeDetail(change domain.PackageChange) string {
+	pkg := change.After
+	if pkg == nil {
+		pkg = change.Before
+	}
+
+	details := []string{string(pkg.Method)}
+	if pkg.Version != "" {
+		details = append(details, "version "+pkg.Version)
+	}
+
+	if change.Change != domain.ChangeRemoved {
+		details = append(details, "installed "+pkg.Installed.Local().Format(historyTimeLayout))
+	}
+
+	return change.App + " (" + strings.Join(details, ", ") + ")"
+}
+
+// changeMark returns the sign marking a change kind.
+func changeMark(change string) string {
+	switch change {
+	case domain.ChangeAdded:
+		return "+"
+	case domain.ChangeRemoved:
+		return "-"
+	case domain.ChangeUpgraded:
+		return "↑"
+	default:
+		return "~"
+	}
+}
+
+// historyTime shows a snapshot time, the current one as now.
+func historyTime(when time.Time) string {
+	if time.Since(when) < time.Minute {
+		return snapshotNow
+	}
+
+	return when.Local().Format(historyTimeLayout)
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/urfave/cli/v3"
+)
+
+// createInfoCommand creates the info command describing a catalog app.
+func (app *CLI) createInfoCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "info",
+		Usage:     "Show details and dependencies of an app",
+		ArgsUsage: "<app>",
+		Description: `Describe a catalog app: how it is installed, the apps it depends on,
+the apps that would break without it and the apps it can't be installed
+alongside.
+
+Dependencies are installed automatically before the app.
+
+For apps installed from GitHub releases, the release channel is shown.
+Subscribe an app to prereleases in config.toml:
+
+  [github.channels]
+  lazygit = "prerelease"
+
+Examples:
+  karei info cargo-audit    # Shows that rust is installed first
+  karei info rust --json    # Machine-readable dependency tree`,
+		Action: app.runInfo,
+	}
+}
+
+// runInfo prints the catalog entry and dependency tree of an app.
+func (app *CLI) runInfo(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return domain.NewExitError(ExitUsageError, "specify one app, e.g. karei info lazygit", nil)
+	}
+
+	key := cmd.Args().First()
+
+	entry, exists := apps.Apps[key]
+	if !exists {
+		return domain.NewExitError(ExitNotFoundError, "unknown app: "+key, nil)
+	}
+
+	license := entry.License
+	if license == "" {
+		license = domain.LicenseOpenSource
+	}
+
+	// Show the method an install would use under the policy
+	method, source := entry.Resolve(app.preference)
+	if allowed, allowedSource, ok := entry.ResolveAllowed(app.preference, app.policy.AllowsMethod); ok {
+		method, source = allowed, allowedSource
+	}
+
+	result := &domain.AppInfoResult{
+		Key:          key,
+		Name:         entry.Name,
+		Group:        entry.Group,
+		Description:  entry.Description,
+		Method:       string(method),
+		Source:       source,
+		License:      license,
+		Origin:       domain.OriginOf(method),
+		Aliases:      entry.Aliases,
+		Dependencies: apps.DependencyTree(key),
+		Dependents:   apps.Dependents(key),
+		Conflicts:    apps.Conflicting(key),
+	}
+
+	if entry.FromGitHub() {
+		result.Channel = app.channels.For(key)
+	}
+
+	if !entry.Allowed(app.policy) {
+		result.Blocked = app.policy.Check(entry.License, method).Error()
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if app.json {
+		return output.Success("", result)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s (%s)", result.Name, result.Key),
+		"  " + result.Description,
+		"",
+		fmt.Sprintf("Group:   %s", result.Group),
+		fmt.Sprintf("Method:  %s (%s)", result.Method, result.Source),
+		fmt.Sprintf("License: %s", result.License),
+		fmt.Sprintf("Origin:  %s", result.Origin),
+	}
+
+	if result.Channel != "" {
+		lines = append(lines, fmt.Sprintf("Channel: %s", result.Channel))
+	}
+
+	if result.Blocked != "" {
+		lines = append(lines, "Policy:  "+result.Blocked)
+	}
+
+	if len(result.Aliases) > 0 {
+		lines = append(lines, "Aliases: "+strings.Join(result.Aliases, ", "))
+	}
+
+	lines = append(lines, "", "Dependencies:")
+	if len(result.Dependencies) == 0 {
+		lines = append(lines, "  none")
+	} else {
+		lines = append(lines, "  "+key)
+		lines = appendDependencyTree(lines, result.Dependencies, "  ")
+	}
+
+	if len(result.Dependents) > 0 {
+		lines = append(lines, "", "Needed by: "+strings.Join(result.Dependents, ", "))
+	}
+
+	if len(result.Conflicts) > 0 {
+		lines = append(lines, "", "Conflicts with: "+strings.Join(result.Conflicts, ", "))
+	}
+
+	return output.Info(strings.Join(lines, "\n"))
+}
+
+// appendDependencyTree renders nodes below their parent with box drawing lines.
+func appendDependencyTree(lines []string, nodes []domain.DependencyNode, indent string) []string {
+	for i, node := range nodes {
+		branch, next := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, next = "└── ", "    "
+		}
+
+		lines = append(lines, indent+branch+node.Key)
+		lines = appendDependencyTree(lines, node.Dependencies, indent+next)
+	}
+
+	return lines
+}
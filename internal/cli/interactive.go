@@ -55,7 +55,7 @@ type InteractiveSetup struct {
 }
 
 func (app *CLI) runFirstTimeSetup(ctx context.Context) error {
-	fmt.Print(getTitleStyle().Render("◈ Welcome to Karei! ◈"))
+	fmt.Print(console.DefaultOutput.Render(getTitleStyle(), "◈ Welcome to Karei! ◈"))
 	fmt.Println()
 	fmt.Println("Let's set up your beautiful Ubuntu desktop...")
 	fmt.Println()
@@ -204,7 +204,7 @@ func (app *CLI) runFirstTimeSetup(ctx context.Context) error {
 }
 
 func (app *CLI) executeSetup(ctx context.Context, setup *InteractiveSetup) error {
-	fmt.Print(getHeaderStyle().Render("▸ Installing your beautiful desktop..."))
+	fmt.Print(console.DefaultOutput.Render(getHeaderStyle(), "▸ Installing your beautiful desktop..."))
 	fmt.Println()
 
 	app.applyTheme(ctx, setup.Theme)
@@ -214,7 +214,7 @@ func (app *CLI) executeSetup(ctx context.Context, setup *InteractiveSetup) error
 	app.installDatabases(ctx, setup.Databases)
 
 	fmt.Println()
-	fmt.Print(getHeaderStyle().Render("✓ Karei setup complete! Enjoy your beautiful desktop!"))
+	fmt.Print(console.DefaultOutput.Render(getHeaderStyle(), "✓ Karei setup complete! Enjoy your beautiful desktop!"))
 	fmt.Println()
 
 	return nil
@@ -227,13 +227,7 @@ func (app *CLI) applyTheme(ctx context.Context, theme string) {
 
 	fmt.Printf("◈ Applying theme: %s\n", theme)
 
-	// Create theme service with dependencies
-	fileManager := platform.NewFileManager(false)
-	commandRunner := platform.NewCommandRunner(app.verbose, false)
-	configPath := config.GetXDGConfigHome()
-	themesPath := filepath.Join(config.GetKareiPath(), "themes")
-
-	themeService := application.NewThemeService(fileManager, commandRunner, configPath, themesPath)
+	themeService := app.newThemeService()
 
 	// Apply theme using the service
 	if err := themeService.ApplyTheme(ctx, theme); err != nil {
@@ -282,13 +276,25 @@ func (app *CLI) installAppGroups(ctx context.Context, groups []string) {
 		return
 	}
 
-	appManager := apps.NewManager(app.verbose)
+	app.ensureInstallService()
+	app.installService.SetConnectivity(app.checkConnectivity(ctx))
 
 	for _, group := range groups {
 		fmt.Printf("⬛ Installing %s apps...\n", group)
 
-		if err := appManager.InstallGroup(ctx, group); err != nil {
+		result, err := app.installService.InstallGroup(ctx, group)
+		if err != nil {
 			fmt.Printf("⚠ Group %s error: %v\n", group, err)
+
+			continue
+		}
+
+		for _, failed := range result.Failed {
+			fmt.Printf("⚠ Failed to install %s\n", failed)
+		}
+
+		for _, skipped := range result.Skipped {
+			fmt.Printf("⏭ Skipped %s (%s)\n", skipped, result.SkipReasons[skipped])
 		}
 	}
 }
@@ -380,14 +386,20 @@ func (app *CLI) runAppSelector(ctx context.Context) error {
 	}
 
 	// Install selected apps
-	appManager := apps.NewManager(app.verbose)
+	app.ensureInstallService()
+	app.installService.SetConnectivity(app.checkConnectivity(ctx))
 
 	for _, appName := range selectedApps {
 		fmt.Printf("⬛ Installing %s...\n", appName)
 
-		if err := appManager.InstallApp(ctx, appName); err != nil {
+		result, _ := app.installService.InstallPackages(ctx, []string{appName})
+		if len(result.Failed) > 0 {
 			fmt.Printf("⚠ Failed to install %s\n", appName)
 		}
+
+		for _, skipped := range result.Skipped {
+			fmt.Printf("⏭ Skipped %s (%s)\n", skipped, result.SkipReasons[skipped])
+		}
 	}
 
 	fmt.Println("✓ App installation complete!")
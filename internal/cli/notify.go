@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/user"
+	"time"
+
+	cli "github.com/urfave/cli/v3"
+	"golang.org/x/term"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/adapters/system"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/bugreport"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// webhookFlushTimeout bounds how long karei waits on exit for events still
+// being delivered to the webhook.
+const webhookFlushTimeout = 30 * time.Second
+
+// startWebhook sends install and uninstall progress to the webhook from
+// config.toml, with home directory and user name scrubbed like in bug reports.
+// The webhook may name a secret holding the URL, as "secret:webhook".
+func (app *CLI) startWebhook(configured string) error {
+	if configured == "" {
+		return nil
+	}
+
+	rawURL, err := app.resolveSecret(configured)
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, "[notify] webhook in config.toml: "+err.Error(), err)
+	}
+
+	// Show the configured value, never a URL read from a secret
+	if parsed, err := url.Parse(rawURL); err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return domain.NewExitError(ExitConfigError, "invalid [notify] webhook in config.toml: "+configured, err)
+	}
+
+	home, _ := os.UserHomeDir()
+
+	username := ""
+	if current, err := user.Current(); err == nil {
+		username = current.Username
+	}
+
+	app.webhook = network.NewWebhook(network.GetHTTPClient(), rawURL, bugreport.NewScrubber(home, username).Scrub)
+
+	app.subscribeOperations(app.webhook.Handle)
+
+	return nil
+}
+
+// streamProgress shows the progress of installing keys until the returned
+// function is called. With --json the operation events are written to stderr
+// as JSON lines, as the result on stdout only comes at the end. On a terminal
+// a spinner line shows the app counter and the installer's current step in
+// place of the installer output, unless sudo may need to ask for a password.
+func (app *CLI) streamProgress(ctx context.Context, keys []string) func() {
+	total := len(apps.WithDependencies(keys))
+
+	if app.json {
+		return app.subscribeOperations(cliAdapter.NewJSONProgress(os.Stderr, total).Handle)
+	}
+
+	width, _, err := term.GetSize(int(os.Stderr.Fd()))
+	if err != nil || app.quiet || app.verbose || app.plain || !canSudoQuietly(ctx) {
+		return func() {}
+	}
+
+	spinner := cliAdapter.NewSpinner(os.Stderr, width)
+	unsubscribe := app.subscribeOperations(spinner.Handle)
+
+	app.installService.SetOutputFunc(spinner.Output)
+	spinner.Start(total)
+
+	return func() {
+		spinner.Stop()
+		app.installService.SetOutputFunc(nil)
+		unsubscribe()
+	}
+}
+
+// canSudoQuietly reports whether installers can use sudo without it asking
+// for a password on the terminal.
+func canSudoQuietly(ctx context.Context) bool {
+	return os.Geteuid() == 0 || system.SudoWithoutPassword(ctx)
+}
+
+// subscribeOperations passes the start, completion and failure of each
+// install and removal to handler. The returned function unsubscribes it.
+func (app *CLI) subscribeOperations(handler domain.EventHandler) func() {
+	if app.events == nil {
+		app.events = domain.NewEventBus()
+		app.installService.SetEventPublisher(app.events)
+		app.uninstallService.SetEventPublisher(app.events)
+	}
+
+	var unsubscribe []func()
+
+	for _, eventType := range []domain.EventType{
+		domain.EventOperationStarted, domain.EventOperationCompleted, domain.EventOperationFailed,
+	} {
+		unsubscribe = append(unsubscribe, app.events.Subscribe(eventType, handler))
+	}
+
+	return func() {
+		for _, fn := range unsubscribe {
+			fn()
+		}
+	}
+}
+
+// stopWebhook waits for queued events to be delivered. Failing to notify
+// never fails the command itself.
+func (app *CLI) stopWebhook(_ context.Context, _ *cli.Command) error {
+	if app.webhook == nil {
+		return nil
+	}
+
+	if err := app.webhook.Close(webhookFlushTimeout); err != nil {
+		console.DefaultOutput.Warningf("Webhook: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// readPackageList reads the package list piped to --stdin and returns its
+// packages as --packages takes them, remembering the line of each.
+func (app *CLI) readPackageList() (string, error) {
+	if console.DefaultOutput.IsTTY(os.Stdin.Fd()) {
+		return "", domain.NewExitError(ExitUsageError, "--stdin reads a list piped in, e.g. cat tools.txt | karei install --stdin", nil)
+	}
+
+	listed, err := domain.ParsePackageList(os.Stdin)
+	if err != nil {
+		return "", domain.NewExitError(ExitUsageError, err.Error(), err)
+	}
+
+	if len(listed) == 0 {
+		return "", domain.NewExitError(ExitUsageError, "no packages on stdin", nil)
+	}
+
+	app.listed = listed
+
+	return strings.Join(domain.ListedPackageNames(listed), ","), nil
+}
+
+// listedLine returns "line N: " for a package read with --stdin, so each
+// result can be traced to its line, and nothing otherwise.
+func (app *CLI) listedLine(pkg string) string {
+	i := slices.IndexFunc(app.listed, func(p domain.ListedPackage) bool { return p.Package == pkg })
+	if i < 0 {
+		return ""
+	}
+
+	return "line " + strconv.Itoa(app.listed[i].Line) + ": "
+}
+
+// listedStatus is the status the packages among a result's list ended with.
+type listedStatus struct {
+	status   string
+	packages []string
+}
+
+// listedResults returns the packages read with --stdin with the status each
+// ended with, a later status winning for a package in several lists, or
+// nil when none were read.
+func listedResults(listed []domain.ListedPackage, statuses ...listedStatus) []domain.ListedPackage {
+	if listed == nil {
+		return nil
+	}
+
+	results := slices.Clone(listed)
+	for _, status := range statuses {
+		domain.MarkListed(results, status.status, status.packages)
+	}
+
+	return results
+}
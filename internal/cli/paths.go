@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// createPathsCommand creates the paths command listing karei's file locations.
+func (app *CLI) createPathsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "paths",
+		Usage: "Show where karei keeps its files",
+		Description: `Print the directories and files karei reads and writes, following the
+XDG base directory specification:
+
+  config    $XDG_CONFIG_HOME/karei (config.toml)
+  data      $XDG_DATA_HOME/karei (themes, exports)
+  state     $XDG_STATE_HOME/karei (journal, usage statistics, logs)
+  cache     $XDG_CACHE_HOME/karei (app details)
+  runtime   $XDG_RUNTIME_DIR/karei (process lock)
+
+Each directory can be moved with a KAREI_*_DIR environment variable, e.g.
+to keep a test run or a packaged karei inside its sandbox; --env lists them
+with their current values.
+
+Files left in the data directory by older versions are moved to the state
+directory the first time karei runs.
+
+EXAMPLES:
+  karei paths
+  karei paths --env
+  KAREI_CONFIG_DIR=/tmp/karei-test karei paths --json`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "env",
+				Usage: "list the environment variables overriding locations and behavior",
+			},
+		},
+		Action: app.runPaths,
+	}
+}
+
+// runPaths prints every location karei uses, or the variables overriding them.
+func (app *CLI) runPaths(_ context.Context, cmd *cli.Command) error {
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	var builder strings.Builder
+
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+
+	if cmd.Bool("env") {
+		variables := xdg.Variables()
+		if app.json {
+			return output.Success("", variables)
+		}
+
+		for _, variable := range variables {
+			value := variable.Value
+			if value == "" {
+				value = "(unset)"
+			}
+
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", variable.Name, value, variable.Description)
+		}
+	} else {
+		locations := xdg.Locations()
+		if app.json {
+			return output.Success("", locations)
+		}
+
+		for _, location := range locations {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\n", location.Name, location.Path)
+		}
+	}
+
+	_ = writer.Flush()
+
+	return output.Info(strings.TrimRight(builder.String(), "\n"))
+}
+
+// migrateLegacyPaths moves files older versions left in legacy locations.
+// Failures only warn, the files are still usable where they are.
+func (app *CLI) migrateLegacyPaths() {
+	moved, err := xdg.Migrate()
+	for _, move := range moved {
+		console.DefaultOutput.Progressf("Moved %s to %s", move.From, move.To)
+	}
+
+	if err != nil {
+		console.DefaultOutput.Warningf("Could not migrate legacy files: %v", err)
+	}
+}
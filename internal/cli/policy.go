@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// checkInstallPolicy refuses explicitly requested apps the install policy forbids.
+func (app *CLI) checkInstallPolicy(packagesFlag string) error {
+	for _, key := range strings.Split(packagesFlag, ",") {
+		if err := app.installService.CheckPolicy(strings.TrimSpace(key)); err != nil {
+			return domain.NewExitError(ExitPermissionError, err.Error()+" (see [policy] in config.toml)", err)
+		}
+	}
+
+	return nil
+}
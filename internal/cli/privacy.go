@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// privacyCommand is left out of the statistics, so clearing them leaves nothing behind.
+const privacyCommand = "privacy"
+
+// usageRecorder opens the local usage statistics.
+func (app *CLI) usageRecorder() *application.UsageRecorder {
+	return application.NewUsageRecorder(platform.NewFileManager(app.verbose), xdg.UsageStatsFile())
+}
+
+// trackUsage wraps the actions of cmd and its subcommands to record their
+// use when usage statistics are enabled.
+func (app *CLI) trackUsage(cmd *cli.Command) {
+	if action := cmd.Action; action != nil {
+		cmd.Action = func(ctx context.Context, cmd *cli.Command) error {
+			started := time.Now()
+			err := action(ctx, cmd)
+			app.recordUsage(cmd, time.Since(started), err)
+
+			return err
+		}
+	}
+
+	for _, sub := range cmd.Commands {
+		app.trackUsage(sub)
+	}
+}
+
+// recordUsage stores the command path and the names of the flags set.
+// Failing to record never fails the command.
+func (app *CLI) recordUsage(cmd *cli.Command, duration time.Duration, err error) {
+	if !app.usageStats {
+		return
+	}
+
+	command := strings.TrimPrefix(strings.TrimPrefix(cmd.FullName(), cmd.Root().Name), " ")
+	if command == "" {
+		command = cmd.Root().Name
+	}
+
+	if strings.HasPrefix(command, privacyCommand) {
+		return
+	}
+
+	var flags []string
+
+	for _, c := range cmd.Lineage() {
+		for _, flag := range c.Flags {
+			if flag.IsSet() {
+				flags = append(flags, flag.Names()[0])
+			}
+		}
+	}
+
+	if recordErr := app.usageRecorder().Record(command, flags, duration, err != nil); recordErr != nil && app.verbose {
+		console.DefaultOutput.Warningf("Could not record usage: %v", recordErr)
+	}
+}
+
+// createPrivacyCommand creates the privacy command for the usage statistics.
+func (app *CLI) createPrivacyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  privacyCommand,
+		Usage: "Inspect, export or clear local usage statistics",
+		Description: `karei can count which commands and flags you use and how long they take,
+to help diagnose problems. Recording is off until you enable it, the
+statistics are stored only in ` + xdg.UsageStatsFile() + ` and nothing is
+ever sent anywhere. Arguments, flag values, app names and paths are
+never recorded.
+
+EXAMPLES:
+  karei privacy                     # Show whether recording is on and what was recorded
+  karei privacy enable              # Start recording ([privacy] usage_stats in config.toml)
+  karei privacy report -o usage.txt # Save a report to attach to a bug report
+  karei privacy clear               # Delete everything recorded`,
+		Action: app.runPrivacyShow,
+		Commands: []*cli.Command{
+			{
+				Name:   "enable",
+				Usage:  "Start recording usage statistics",
+				Action: func(_ context.Context, _ *cli.Command) error { return app.setUsageStats(true) },
+			},
+			{
+				Name:   "disable",
+				Usage:  "Stop recording usage statistics, keeping what was recorded",
+				Action: func(_ context.Context, _ *cli.Command) error { return app.setUsageStats(false) },
+			},
+			{
+				Name:  "report",
+				Usage: "Print the statistics in a form to attach to bug reports",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "write to file instead of stdout"},
+				},
+				Action: app.runPrivacyReport,
+			},
+			{
+				Name:  "clear",
+				Usage: "Delete the recorded statistics",
+				Action: func(_ context.Context, _ *cli.Command) error {
+					if err := app.usageRecorder().Clear(); err != nil {
+						return domain.NewExitError(ExitGeneralError, err.Error(), err)
+					}
+
+					return cliAdapter.OutputFromContext(app.json, app.quiet).Success("Usage statistics deleted", nil)
+				},
+			},
+		},
+	}
+}
+
+// runPrivacyShow prints whether recording is on and what has been recorded.
+func (app *CLI) runPrivacyShow(_ context.Context, _ *cli.Command) error {
+	stats, err := app.usageRecorder().Load()
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if app.json {
+		return output.Success("", map[string]any{
+			"enabled": app.usageStats,
+			"path":    xdg.UsageStatsFile(),
+			"stats":   stats,
+		})
+	}
+
+	status := "off (enable with: karei privacy enable)"
+	if app.usageStats {
+		status = "on (disable with: karei privacy disable)"
+	}
+
+	lines := []string{"Usage statistics: " + status, "Stored in: " + xdg.UsageStatsFile()}
+
+	if len(stats.Commands) == 0 {
+		lines = append(lines, "", "Nothing recorded.")
+	} else {
+		lines = append(lines, "", stats.Report(app.getVersion()))
+	}
+
+	return output.Info(strings.Join(lines, "\n"))
+}
+
+// runPrivacyReport writes the report to stdout or a file.
+func (app *CLI) runPrivacyReport(_ context.Context, cmd *cli.Command) error {
+	stats, err := app.usageRecorder().Load()
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	report := stats.Report(app.getVersion()) + "\n"
+
+	if path := cmd.String("output"); path != "" {
+		if err := os.WriteFile(path, []byte(report), generatedFilePerm); err != nil {
+			return domain.NewExitError(ExitGeneralError, err.Error(), err)
+		}
+
+		return nil
+	}
+
+	_, err = fmt.Print(report)
+
+	return err
+}
+
+// setUsageStats turns recording on or off in config.toml.
+func (app *CLI) setUsageStats(enabled bool) error {
+	path := xdg.PreferencesFile()
+
+	prefs, err := config.LoadPreferences(path)
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	prefs.Privacy.UsageStats = enabled
+
+	if err := config.SavePreferences(path, prefs); err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	message := "Usage statistics are recorded locally from now on"
+	if !enabled {
+		message = "Usage statistics are no longer recorded"
+	}
+
+	return cliAdapter.OutputFromContext(app.json, app.quiet).Success(message, nil)
+}
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	cli "github.com/urfave/cli/v3"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/generate"
+)
+
+const (
+	targetContainerfile = "containerfile"
+	contextDirPerm      = 0o755
+	binaryFilePerm      = 0o755
+)
+
+// ErrUnknownTarget is returned when provision is asked for an unsupported target.
+var ErrUnknownTarget = errors.New("unknown provision target")
+
+// createProvisionCommand creates the provision command for building images from a profile.
+func (app *CLI) createProvisionCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "provision",
+		Usage:     "Render a profile into a container build",
+		ArgsUsage: "<profile.yaml>",
+		Description: `Render a karei profile into a container image definition.
+
+With --output, a complete build context is written: the Containerfile, the
+files listed in the profile, and this karei binary when apps need it.
+Without --output the Containerfile is printed to stdout.
+
+EXAMPLES:
+  karei provision --target containerfile --output build/ profile.yaml
+  podman build -t devenv build/`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "target",
+				Usage: "output format (containerfile)",
+				Value: targetContainerfile,
+			},
+			&cli.StringFlag{
+				Name:  "image",
+				Usage: "base image",
+				Value: "ubuntu:24.04",
+			},
+			&cli.StringFlag{
+				Name:  "profile",
+				Usage: "read the named profile from an inventory file instead of a profile file",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "directory to write the build context to",
+			},
+		},
+		Action: app.handleProvisionAction,
+	}
+}
+
+// handleProvisionAction renders the profile for the requested target.
+func (app *CLI) handleProvisionAction(_ context.Context, cmd *cli.Command) error {
+	if cmd.String("target") != targetContainerfile {
+		err := fmt.Errorf("%w: %s", ErrUnknownTarget, cmd.String("target"))
+
+		return domain.NewExitError(ExitUsageError, err.Error(), err)
+	}
+
+	if cmd.Args().Len() != 1 {
+		return domain.NewExitError(ExitUsageError, "usage: karei provision --target containerfile <profile.yaml>", ErrInvalidArgument)
+	}
+
+	profilePath := cmd.Args().First()
+
+	profile, err := loadGenerateProfile(profilePath, cmd.String("profile"))
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	plan, err := generate.NewPlan(profile)
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	containerfile := generate.Containerfile(plan, cmd.String("image"))
+
+	outputDir := cmd.String("output")
+	if outputDir == "" {
+		_, err = os.Stdout.Write(containerfile)
+
+		return err
+	}
+
+	if err := writeBuildContext(outputDir, filepath.Dir(profilePath), plan, containerfile); err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	if !app.quiet {
+		fmt.Fprintf(os.Stderr, "Build context written to %s\n", outputDir)
+	}
+
+	return nil
+}
+
+// writeBuildContext writes the Containerfile and everything it copies into dir.
+// Profile file sources are resolved relative to profileDir.
+func writeBuildContext(dir, profileDir string, plan *generate.Plan, containerfile []byte) error {
+	if err := os.MkdirAll(dir, contextDirPerm); err != nil {
+		return fmt.Errorf("failed to create build context: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "Containerfile"), containerfile, generatedFilePerm); err != nil {
+		return fmt.Errorf("failed to write Containerfile: %w", err)
+	}
+
+	if err := copyProfileFiles(dir, profileDir, plan.Files); err != nil {
+		return err
+	}
+
+	if plan.NeedsKarei() {
+		return copyKareiBinary(dir)
+	}
+
+	return nil
+}
+
+// copyProfileFiles copies each profile file source, relative to profileDir, into dir.
+func copyProfileFiles(dir, profileDir string, files map[string]string) error {
+	for _, src := range files {
+		if !filepath.IsLocal(src) {
+			return fmt.Errorf("%w: file source %s must be relative to the profile", ErrInvalidArgument, src)
+		}
+
+		if err := copyFile(filepath.Join(profileDir, src), filepath.Join(dir, src), generatedFilePerm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyKareiBinary copies the running karei executable into dir.
+func copyKareiBinary(dir string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate karei binary: %w", err)
+	}
+
+	return copyFile(executable, filepath.Join(dir, generate.KareiBinary), binaryFilePerm)
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src) //nolint:gosec // sources come from the user's profile
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer func() { _ = in.Close() }()
+
+	if err := os.MkdirAll(filepath.Dir(dst), contextDirPerm); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(dst), err)
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm) //nolint:gosec // destination is inside the build context
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dst, err)
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+
+		return fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+
+	return out.Close()
+}
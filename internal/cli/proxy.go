@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/config"
+)
+
+// configuredProxy converts the [proxy] section of config.toml.
+func configuredProxy(prefs config.ProxyPreferences) network.ProxySettings {
+	settings := network.ProxySettings{HTTP: prefs.HTTP, HTTPS: prefs.HTTPS, NoProxy: strings.Join(prefs.NoProxy, ",")}
+	if settings.HTTPS == "" {
+		settings.HTTPS = settings.HTTP
+	}
+
+	if !settings.IsZero() {
+		settings.Source = network.ProxySourceConfig
+	}
+
+	return settings
+}
+
+// configureProxy applies the configured proxy, or else the one detected from
+// the environment or GNOME settings, to karei's HTTP clients, apt, git and
+// the other commands karei runs. It runs before any request is made.
+func (app *CLI) configureProxy(ctx context.Context) {
+	if app.proxy.IsZero() {
+		app.proxy = network.DetectProxy(ctx, platform.NewCommandRunner(false, false))
+	}
+
+	if app.proxy.IsZero() {
+		return
+	}
+
+	app.proxy.Apply()
+	console.DefaultOutput.Progressf("Using proxy %s", app.proxy)
+}
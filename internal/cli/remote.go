@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	cli "github.com/urfave/cli/v3"
+
+	"github.com/janderssonse/karei/internal/adapters/remote"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+const remoteUsage = `Usage: karei remote --host user@box [options] <command> [args...]
+
+Options:
+  --host user@box    SSH destination (required)
+  --binary PATH      local karei binary to upload (default: this executable)
+  --copy             upload the binary even if karei is installed remotely
+  --tty              allocate a terminal so sudo can prompt remotely
+  --ssh-opt ARG      extra argument for ssh/scp, repeatable (e.g. --ssh-opt=-p2222);
+                     the port option is passed to scp as -P
+
+Example:
+  karei remote --host user@box install --group development`
+
+// createRemoteCommand creates the remote command that runs karei over SSH.
+func (app *CLI) createRemoteCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "remote",
+		Usage:     "Run a karei command on another machine over SSH",
+		ArgsUsage: "--host user@box <command> [args...]",
+		Description: `Run any karei command on a remote host and stream its output back.
+
+If karei is not installed on the host, this binary is copied to
+~/.cache/karei/bin/karei there first. The remote exit code is preserved.
+
+` + remoteUsage,
+		// Everything after the remote options belongs to the remote karei
+		SkipFlagParsing: true,
+		Action:          app.handleRemoteAction,
+	}
+}
+
+// handleRemoteAction parses the remote options and runs the command over SSH.
+func (app *CLI) handleRemoteAction(ctx context.Context, cmd *cli.Command) error {
+	opts, args, err := parseRemoteArgs(cmd.Args().Slice())
+	if errors.Is(err, errHelpRequested) {
+		fmt.Fprintln(os.Stdout, remoteUsage)
+
+		return nil
+	}
+
+	if err != nil {
+		return domain.NewExitError(ExitUsageError, fmt.Sprintf("%v\n\n%s", err, remoteUsage), err)
+	}
+
+	executor := remote.NewExecutor(os.Stdin, os.Stdout, os.Stderr)
+
+	if err := executor.Run(ctx, opts, args); err != nil {
+		// ssh reserves 255 for its own connection errors
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() != sshErrorExitCode {
+			return domain.NewExitError(exitErr.ExitCode(), err.Error(), err)
+		}
+
+		return domain.NewExitError(ExitNetworkError, err.Error(), err)
+	}
+
+	return nil
+}
+
+const sshErrorExitCode = 255
+
+var errHelpRequested = errors.New("help requested")
+
+// parseRemoteArgs splits leading remote options from the command to run remotely.
+func parseRemoteArgs(raw []string) (remote.Options, []string, error) {
+	var opts remote.Options
+
+	for len(raw) > 0 {
+		arg := raw[0]
+		if !strings.HasPrefix(arg, "-") {
+			break
+		}
+
+		raw = raw[1:]
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+
+		switch name {
+		case "help", "h":
+			return opts, nil, errHelpRequested
+		case "copy":
+			opts.Copy = true
+			continue
+		case "tty":
+			opts.TTY = true
+			continue
+		case "host", "binary", "ssh-opt":
+		default:
+			return opts, nil, fmt.Errorf("%w: unknown remote option %s", ErrInvalidArgument, arg)
+		}
+
+		if !hasValue {
+			if len(raw) == 0 {
+				return opts, nil, fmt.Errorf("%w: %s requires a value", ErrInvalidArgument, arg)
+			}
+
+			value, raw = raw[0], raw[1:]
+		}
+
+		switch name {
+		case "host":
+			opts.Host = value
+		case "binary":
+			opts.Binary = value
+		case "ssh-opt":
+			opts.SSHArgs = append(opts.SSHArgs, value)
+		}
+	}
+
+	if opts.Host == "" {
+		return opts, nil, fmt.Errorf("%w: --host is required", ErrInvalidArgument)
+	}
+
+	if len(raw) == 0 {
+		return opts, nil, fmt.Errorf("%w: no command given to run remotely", ErrInvalidArgument)
+	}
+
+	return opts, raw, nil
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+	"github.com/urfave/cli/v3"
+)
+
+// createRetryCommand creates the retry command for re-running failed tasks.
+func (app *CLI) createRetryCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "retry",
+		Usage: "Retry the failed tasks of a previous run",
+		Description: `Re-run only the installs and removals that failed, using the journal
+karei keeps of the most recent run from the CLI or TUI.
+
+Examples:
+  karei retry --last    # Retry what failed in the most recent run`,
+		Flags: append([]cli.Flag{
+			&cli.BoolFlag{
+				Name:  "last",
+				Usage: "retry the failures of the most recent run",
+			},
+		}, downloadFlags()...),
+		Action: app.runRetry,
+	}
+}
+
+// runRetry re-queues the failed tasks recorded in the journal.
+func (app *CLI) runRetry(ctx context.Context, cmd *cli.Command) error {
+	if !cmd.Bool("last") {
+		return domain.NewExitError(ExitUsageError, "specify --last to retry the failures of the most recent run", nil)
+	}
+
+	app.parseDownloadFlags(cmd)
+
+	ctx, cancel := app.applyTimeout(ctx)
+	defer cancel()
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	run, err := app.journal().Last()
+	if errors.Is(err, application.ErrNoJournal) {
+		return domain.NewExitError(ExitNotFoundError, "no previous run recorded", err)
+	}
+
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, "failed to read journal", err)
+	}
+
+	failed := run.Failed()
+	if len(failed) == 0 {
+		_ = output.Info("Nothing to retry: the last run had no failures")
+
+		return nil
+	}
+
+	var installs, removals []string
+
+	for _, entry := range failed {
+		reason := entry.Error
+		if entry.Kind != domain.ErrorKindUnknown {
+			reason = string(entry.Kind)
+		}
+
+		_ = output.Info(fmt.Sprintf("↻ Retrying %s of %s (last failure: %s)", entry.Operation, entry.App, reason))
+
+		if entry.Operation == application.OperationUninstall {
+			removals = append(removals, entry.App)
+		} else {
+			installs = append(installs, entry.App)
+		}
+	}
+
+	retryRun := application.NewJournalRun()
+
+	var exitErr error
+
+	if len(installs) > 0 {
+		app.ensureInstallService()
+		app.installService.SetConnectivity(app.checkConnectivity(ctx))
+
+		err := app.confirmMeteredDownload(ctx, installs)
+		if errors.Is(err, errSelectionCancelled) {
+			return output.Info("Retry cancelled.")
+		}
+
+		if err != nil {
+			return err
+		}
+
+		app.announceEstimate(installs)
+
+		if err := app.waitForOffPeak(ctx); err != nil {
+			return err
+		}
+
+		reportDownloads := app.measureDownloads()
+		stopProgress := app.streamProgress(ctx, installs)
+		result, _ := app.installService.InstallPackages(ctx, installs)
+		stopProgress()
+
+		app.outputInstallProgress(result, output)
+		reportDownloads()
+		retryRun.AddInstallResult(result)
+
+		exitErr = app.getInstallExitCode(result)
+	}
+
+	if len(removals) > 0 {
+		app.ensureUninstallService()
+
+		result, _ := app.uninstallService.UninstallPackages(ctx, removals)
+		for _, pkg := range result.Uninstalled {
+			_ = output.Success("✓ Uninstalled "+pkg, nil)
+		}
+
+		for _, pkg := range result.Failed {
+			_ = output.Error("✗ Failed to uninstall " + pkg)
+		}
+
+		retryRun.AddUninstallResult(result)
+
+		if exitErr == nil {
+			exitErr = app.getUninstallExitCode(result)
+		}
+	}
+
+	app.recordRun(retryRun)
+
+	return exitErr
+}
+
+// journal returns the journal of recent runs.
+func (app *CLI) journal() *application.Journal {
+	return application.NewJournal(platform.NewFileManager(app.verbose), xdg.JournalFile())
+}
+
+// recordRun stores run in the journal. Failing to record never fails the command.
+func (app *CLI) recordRun(run *application.JournalRun) {
+	if len(run.Entries) == 0 {
+		return
+	}
+
+	if err := app.journal().Record(run); err != nil && app.verbose {
+		console.DefaultOutput.Warningf("Could not record run: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	cli "github.com/urfave/cli/v3"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/sbom"
+)
+
+// createSBOMCommand creates the sbom command listing karei-managed software.
+func (app *CLI) createSBOMCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "sbom",
+		Usage: "Generate a software bill of materials for installed apps",
+		Description: `Write a CycloneDX or SPDX document listing every catalog app karei
+manages on this machine, with its version, install method, source URL and
+SHA-256 checksum.
+
+Checksums are the flatpak commit for flatpaks and the executable on PATH
+for everything else. Fields that can't be determined are left out.
+
+EXAMPLES:
+  karei sbom > karei.cdx.json
+  karei sbom --format spdx -o karei.spdx.json`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "document format: cyclonedx or spdx",
+				Value: sbom.FormatCycloneDX,
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "write to file instead of stdout",
+			},
+		},
+		Action: app.runSBOM,
+	}
+}
+
+// runSBOM inventories the installed catalog apps and writes the document.
+func (app *CLI) runSBOM(ctx context.Context, cmd *cli.Command) error {
+	format := cmd.String("format")
+	if format != sbom.FormatCycloneDX && format != sbom.FormatSPDX {
+		return domain.NewExitError(ExitUsageError, "unknown SBOM format "+format+", use cyclonedx or spdx", sbom.ErrUnknownFormat)
+	}
+
+	ctx, cancel := app.applyTimeout(ctx)
+	defer cancel()
+
+	app.ensureInstallService()
+
+	commandRunner := platform.NewCommandRunner(app.verbose, false)
+	doc := sbom.Document{
+		ToolVersion: app.getVersion(),
+		Created:     time.Now(),
+		Packages: app.installService.Inventory(ctx,
+			platform.NewVersionResolver(commandRunner), platform.NewChecksumResolver(commandRunner)),
+	}
+
+	out, err := sbom.Render(doc, format)
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	if path := cmd.String("output"); path != "" {
+		if err := os.WriteFile(path, out, generatedFilePerm); err != nil {
+			return domain.NewExitError(ExitGeneralError, err.Error(), err)
+		}
+
+		if !app.quiet {
+			fmt.Fprintf(os.Stderr, "SBOM with %d packages written to %s\n", len(doc.Packages), path)
+		}
+
+		return nil
+	}
+
+	_, err = os.Stdout.Write(out)
+
+	return err
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	cli "github.com/urfave/cli/v3"
+	"golang.org/x/term"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// createSecretCommand creates the secret command for credentials kept out of config.toml.
+func (app *CLI) createSecretCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "secret",
+		Usage: "Store tokens and credentials in the keyring",
+		Description: `Secrets are kept in the desktop keyring through secret-tool (libsecret),
+or, without a keyring such as over SSH, encrypted in ` + xdg.SecretsDir() + `.
+
+These secrets are passed to the installers that read them when the variable
+isn't set already, and to no other command:
+
+  github-token           GITHUB_TOKEN, for mise and aqua release lookups
+  npm-token              NPM_TOKEN, for npm and corepack
+  pip-index-url          PIP_INDEX_URL, for pip and pipx
+  cargo-registry-token   CARGO_REGISTRY_TOKEN, for cargo
+
+Any value in config.toml can name a secret instead, such as the webhook:
+
+  [notify]
+  webhook = "secret:webhook"
+
+The value to set is read without echo, or from stdin when piped, so it
+doesn't end up in your shell history.
+
+EXAMPLES:
+  karei secret set github-token
+  gh auth token | karei secret set github-token
+  karei secret get webhook
+  karei secret rm npm-token`,
+		Commands: []*cli.Command{
+			{
+				Name:      "set",
+				Usage:     "Store a secret, replacing any previous value",
+				ArgsUsage: "<name>",
+				Action:    app.runSecretSet,
+			},
+			{
+				Name:      "get",
+				Usage:     "Print a secret",
+				ArgsUsage: "<name>",
+				Action:    app.runSecretGet,
+			},
+			{
+				Name:      "rm",
+				Aliases:   []string{"remove"},
+				Usage:     "Delete a secret",
+				ArgsUsage: "<name>",
+				Action:    app.runSecretRemove,
+			},
+		},
+	}
+}
+
+// secrets returns the keyring, or the encrypted file when there is none.
+func (app *CLI) secrets() domain.SecretStore {
+	return platform.NewSecretStore(xdg.SecretsDir())
+}
+
+// runSecretSet stores the value read from the terminal or stdin.
+func (app *CLI) runSecretSet(_ context.Context, cmd *cli.Command) error {
+	name, err := secretName(cmd)
+	if err != nil {
+		return err
+	}
+
+	value, err := readSecretValue(name)
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	if value == "" {
+		return domain.NewExitError(ExitUsageError, "empty secret not stored, remove it with: karei secret rm "+name, nil)
+	}
+
+	secrets := app.secrets()
+	if err := secrets.Set(name, value); err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	return cliAdapter.OutputFromContext(app.json, app.quiet).Success(
+		fmt.Sprintf("Stored %s in the %s", name, secrets.Backend()), nil)
+}
+
+// runSecretGet prints a secret, for use in scripts.
+func (app *CLI) runSecretGet(_ context.Context, cmd *cli.Command) error {
+	name, err := secretName(cmd)
+	if err != nil {
+		return err
+	}
+
+	value, err := app.secrets().Get(name)
+	if err != nil {
+		return secretExitError(err)
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+	if app.json {
+		return output.Success("", map[string]string{"name": name, "value": value})
+	}
+
+	return output.Info(value)
+}
+
+// runSecretRemove deletes a secret.
+func (app *CLI) runSecretRemove(_ context.Context, cmd *cli.Command) error {
+	name, err := secretName(cmd)
+	if err != nil {
+		return err
+	}
+
+	secrets := app.secrets()
+	if err := secrets.Delete(name); err != nil {
+		return secretExitError(err)
+	}
+
+	return cliAdapter.OutputFromContext(app.json, app.quiet).Success(
+		fmt.Sprintf("Removed %s from the %s", name, secrets.Backend()), nil)
+}
+
+// secretEnvironment returns the stored secrets installers read credentials
+// from, as variables by the command that reads each. Variables the user set
+// are left alone. A secret that can't be read only warns; installers work
+// without credentials.
+func (app *CLI) secretEnvironment() map[string][]string {
+	secrets := app.secrets()
+	env := make(map[string][]string)
+
+	for name, variable := range domain.SecretEnvironment() {
+		if os.Getenv(variable.Name) != "" {
+			continue
+		}
+
+		value, err := secrets.Get(name)
+
+		switch {
+		case errors.Is(err, domain.ErrSecretNotFound):
+			continue
+		case err != nil:
+			console.DefaultOutput.Warningf("Secret %s: %v", name, err)
+
+			continue
+		}
+
+		for _, command := range variable.Commands {
+			env[command] = append(env[command], variable.Name+"="+value)
+		}
+	}
+
+	return env
+}
+
+// resolveSecret returns value, or the secret it names as "secret:<name>".
+func (app *CLI) resolveSecret(value string) (string, error) {
+	name, ok := domain.SecretReference(value)
+	if !ok {
+		return value, nil
+	}
+
+	secret, err := app.secrets().Get(name)
+	if err != nil {
+		return "", fmt.Errorf("%w, set it with: karei secret set %s", err, name)
+	}
+
+	return secret, nil
+}
+
+// secretName returns the validated name argument.
+func secretName(cmd *cli.Command) (string, error) {
+	name := cmd.Args().First()
+	if name == "" {
+		return "", domain.NewExitError(ExitUsageError, "specify the secret name, such as github-token", nil)
+	}
+
+	if err := domain.ValidateSecretName(name); err != nil {
+		return "", domain.NewExitError(ExitUsageError, err.Error(), err)
+	}
+
+	return name, nil
+}
+
+// readSecretValue prompts for the value without echo on a terminal, or reads
+// stdin to its end when piped, without the trailing newline.
+func readSecretValue(name string) (string, error) {
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		_, _ = fmt.Fprintf(os.Stderr, "Value for %s: ", name)
+		value, err := term.ReadPassword(int(os.Stdin.Fd()))
+		_, _ = fmt.Fprintln(os.Stderr)
+
+		return string(value), err
+	}
+
+	value, err := io.ReadAll(os.Stdin)
+
+	return strings.TrimRight(string(value), "\r\n"), err
+}
+
+// secretExitError maps a secret store error to the exit code.
+func secretExitError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrSecretNotFound):
+		return domain.NewExitError(ExitNotFoundError, err.Error(), err)
+	case errors.Is(err, domain.ErrInvalidSecretName):
+		return domain.NewExitError(ExitUsageError, err.Error(), err)
+	default:
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// errSelectionCancelled is returned when the apps a pattern matched, or a
+// large download on a metered connection, are declined.
+var errSelectionCancelled = errors.New("cancelled")
+
+// matchFlag selects catalog apps by regular expression.
+func matchFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "match",
+		Usage: "select catalog apps whose name, or group/name when it has a slash, matches a regular expression",
+	}
+}
+
+// selectPackages returns the apps named by the arguments, with glob
+// patterns such as 'golang/*' and --match resolved against the catalog, and
+// whether any were selected by a pattern.
+func selectPackages(cmd *cli.Command) ([]string, bool, error) {
+	var (
+		selected []string
+		matched  bool
+	)
+
+	add := func(keys ...string) {
+		for _, key := range keys {
+			if !slices.Contains(selected, key) {
+				selected = append(selected, key)
+			}
+		}
+	}
+
+	for _, arg := range cmd.Args().Slice() {
+		if !apps.IsPattern(arg) {
+			add(arg)
+
+			continue
+		}
+
+		keys, err := apps.MatchGlob(arg)
+		if err != nil {
+			return nil, false, selectExitError(err)
+		}
+
+		add(keys...)
+
+		matched = true
+	}
+
+	if expr := cmd.String("match"); expr != "" {
+		keys, err := apps.MatchRegexp(expr)
+		if err != nil {
+			return nil, false, selectExitError(err)
+		}
+
+		add(keys...)
+
+		matched = true
+	}
+
+	return selected, matched, nil
+}
+
+// selectExitError maps a pattern error to the exit code.
+func selectExitError(err error) error {
+	if errors.Is(err, apps.ErrNoMatch) {
+		return domain.NewExitError(ExitNotFoundError, err.Error(), err)
+	}
+
+	return domain.NewExitError(ExitUsageError, err.Error(), err)
+}
+
+// confirmMatched lists the apps a pattern selected and asks before acting
+// on them. Without a terminal, --yes is required.
+func confirmMatched(verb string, selected []string) error {
+	if !console.AutoYes && !console.DefaultOutput.IsTTY(os.Stdin.Fd()) {
+		return domain.NewExitError(ExitUsageError,
+			"refusing to "+strings.ToLower(verb)+" apps selected by a pattern without confirmation, pass --yes to skip the prompt", nil)
+	}
+
+	if !console.AskMatched(verb, selected) {
+		return errSelectionCancelled
+	}
+
+	return nil
+}
+
+// joinPackages adds selected to the comma-separated packages.
+func joinPackages(packages string, selected []string) string {
+	if packages == "" {
+		return strings.Join(selected, ",")
+	}
+
+	return packages + "," + strings.Join(selected, ",")
+}
@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	cli "github.com/urfave/cli/v3"
+
+	"github.com/janderssonse/karei/internal/adapters/api"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/adapters/ubuntu"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+const (
+	tokenBytes    = 32
+	tokenFilePerm = 0o600
+	tokenDirPerm  = 0o700
+)
+
+// createServeCommand creates the serve command exposing the API on a unix socket.
+func (app *CLI) createServeCommand() *cli.Command {
+	runtimeDir := xdg.RuntimeDir()
+
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "Serve the karei API on a local unix socket",
+		Description: `Run karei as a daemon so fleet tools and other front ends can drive it
+without invoking the CLI repeatedly.
+
+The REST API is only reachable through a unix socket readable by the current
+user, and every request must send "Authorization: Bearer <token>". The token
+is read from --token-file, or generated there on first start.
+
+Endpoints:
+  GET  /v1/status          System status
+  POST /v1/install         {"apps": [...]} or {"group": "..."}, returns a job
+  POST /v1/theme           {"theme": "..."}, returns a job
+  POST /v1/verify          {"apps": [...]}, reports which are installed
+  GET  /v1/jobs[/{id}]     Poll asynchronous jobs
+
+Jobs run one at a time in the order they were submitted. The last 100
+finished jobs can be polled.
+
+Example:
+  curl --unix-socket $XDG_RUNTIME_DIR/karei/karei.sock \
+       -H "Authorization: Bearer $(cat $XDG_RUNTIME_DIR/karei/token)" \
+       http://karei/v1/status`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "socket",
+				Usage: "path of the unix socket to listen on",
+				Value: filepath.Join(runtimeDir, "karei.sock"),
+			},
+			&cli.StringFlag{
+				Name:  "token-file",
+				Usage: "file holding the bearer token (created if missing)",
+				Value: filepath.Join(runtimeDir, "token"),
+			},
+		},
+		Action: app.handleServeAction,
+	}
+}
+
+// handleServeAction runs the API server until interrupted.
+func (app *CLI) handleServeAction(ctx context.Context, cmd *cli.Command) error {
+	token, err := loadOrCreateToken(cmd.String("token-file"))
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	app.ensureInstallService()
+
+	commandRunner := platform.NewCommandRunner(app.verbose, false)
+	fileManager := platform.NewFileManager(app.verbose)
+	packageInstaller := ubuntu.NewPackageInstaller(commandRunner, fileManager, app.verbose, false)
+
+	services := api.Services{
+		Installer: app.installService,
+		Themes:    app.themeService,
+		Status:    app.statusService,
+		Checker:   application.NewPackageManager(packageInstaller, nil, false),
+	}
+
+	jobs := application.NewJobManager()
+
+	server, err := api.NewServer(ctx, services, jobs, token)
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	socketPath := cmd.String("socket")
+	fmt.Fprintf(os.Stderr, "Serving karei API on %s (token: %s)\n", socketPath, cmd.String("token-file"))
+
+	if err := server.ListenAndServe(ctx, socketPath); err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	// Let in-flight jobs observe cancellation before exiting
+	jobs.Wait()
+
+	return nil
+}
+
+// loadOrCreateToken reads the bearer token, generating one with owner-only permissions if absent.
+func loadOrCreateToken(path string) (string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path comes from the user's own flag
+	if err == nil {
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("%w: %s", api.ErrEmptyToken, path)
+		}
+
+		return token, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	token := hex.EncodeToString(buf)
+
+	if err := os.MkdirAll(filepath.Dir(path), tokenDirPerm); err != nil {
+		return "", fmt.Errorf("failed to create token directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(token+"\n"), tokenFilePerm); err != nil {
+		return "", fmt.Errorf("failed to write token file: %w", err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"os"
+
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/adapters/system"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// offerShellSetup checks whether the login shell reaches the installed apps,
+// offers to add what its configuration lacks and tells how to pick up the
+// changes. Nothing is said when the shell already reaches them.
+func (app *CLI) offerShellSetup(result *domain.InstallResult) {
+	if app.json || result == nil || len(result.Installed) == 0 {
+		return
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+
+	setup := application.RequiredShellSetup(result.Installed, app.preference, os.Getenv("PATH"), home)
+	if setup.Empty() {
+		return
+	}
+
+	shell, known := system.LoginShell()
+	if !known {
+		for _, line := range domain.ShellBash.Lines(setup, home) {
+			console.DefaultOutput.Result("Add to your shell configuration: " + line)
+		}
+
+		console.DefaultOutput.Result(shell.ReloadHint())
+
+		return
+	}
+
+	shellConfig := application.NewShellConfig(platform.NewFileManager(false), shell,
+		shell.ConfigFile(home, config.GetXDGConfigHome()), home)
+
+	if missing := shellConfig.Missing(setup); len(missing) > 0 {
+		app.addShellLines(shellConfig, setup, missing)
+	}
+
+	// Record the lines each app needs, so uninstalling the last app needing one removes it
+	_ = application.RecordShellLines(app.manifests(), shellConfig, result.Installed, app.preference, os.Getenv("PATH"))
+
+	console.DefaultOutput.Result(shell.ReloadHint())
+}
+
+// addShellLines adds the missing lines to the shell configuration once the
+// user agrees, or lists them to add by hand.
+func (app *CLI) addShellLines(shellConfig *application.ShellConfig, setup domain.ShellSetup, missing []string) {
+	heading := "Add to " + shellConfig.Path() + " so your shell finds the new commands:"
+
+	if console.AskConsent(string(shellConfig.Shell()), shellConfig.Path()) {
+		if _, err := shellConfig.Apply(setup); err != nil {
+			console.DefaultOutput.Result("✗ Could not update " + shellConfig.Path() + ": " + err.Error())
+		} else {
+			heading = "✓ Added to " + shellConfig.Path() + ":"
+		}
+	}
+
+	console.DefaultOutput.Result(heading)
+
+	for _, line := range missing {
+		console.DefaultOutput.Result("  " + line)
+	}
+}
@@ -0,0 +1,398 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/adapters/ubuntu"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/stringutil"
+)
+
+// defaultMirrorCount is how many mirrors of the list are benchmarked.
+const defaultMirrorCount = 10
+
+// mirrorsResult is the outcome of karei system mirrors.
+type mirrorsResult struct {
+	Timings []network.MirrorTiming `json:"timings"`
+	Switch  *ubuntu.MirrorSwitch   `json:"switch,omitempty"`
+}
+
+// createSystemCommand creates the system command for tuning the base system.
+func (app *CLI) createSystemCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "system",
+		Usage: "Tune the base system",
+		Commands: []*cli.Command{
+			{
+				Name:  "mirrors",
+				Usage: "Switch apt to the fastest nearby Ubuntu mirror",
+				Description: `Benchmark the Ubuntu archive mirrors near you by downloading the release
+index of your Ubuntu version from each, then point apt at the fastest.
+
+The sources file is backed up next to itself before it is changed, and apt
+update is run to verify the mirror works. If it doesn't, the backup is
+restored. Looked for in:
+  ` + strings.Join(ubuntu.AptSourcesFiles, "\n  ") + `
+
+Only the Ubuntu archive is switched: security.ubuntu.com, PPAs and
+third-party repositories are left alone.
+
+EXAMPLES:
+  karei system mirrors --dry-run   # Only show the ranking
+  karei --yes system mirrors       # Switch without asking
+  karei system mirrors --list http://mirrors.ubuntu.com/SE.txt`,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "list",
+						Usage: "mirror list to choose from",
+						Value: network.DefaultMirrorList,
+					},
+					&cli.IntFlag{
+						Name:  "count",
+						Usage: "number of mirrors from the list to benchmark",
+						Value: defaultMirrorCount,
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "benchmark only, leaving apt sources unchanged",
+					},
+				},
+				Action: app.runSystemMirrors,
+			},
+			{
+				Name:  "desktop",
+				Usage: "Apply desktop tweaks that fit your Wayland or X11 session",
+				Description: `Apply GNOME tweaks in the form your session type needs, detected from
+XDG_SESSION_TYPE or else the WAYLAND_DISPLAY and DISPLAY variables:
+
+  fractional-scaling   125-175% scaling, through mutter's Wayland or X11 feature
+  tap-to-click         Tap the touchpad to click, with natural scrolling
+  wayland-apps         Electron and Firefox apps run natively (Wayland only)
+
+Tweaks that don't apply to your session are skipped with a note saying why.
+Over SSH or on a text console nothing is changed.
+
+EXAMPLES:
+  karei system desktop --dry-run   # Show what would change
+  karei system desktop`,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "show the tweaks for this session without applying them",
+					},
+				},
+				Action: app.runSystemDesktop,
+			},
+			{
+				Name:      "tiling",
+				Usage:     "Set up sway or Hyprland with a bar, launcher and notifications",
+				ArgsUsage: "<sway|hyprland>",
+				Description: `Install a tiling Wayland compositor with its companions and generate their
+configuration in the colors of a theme:
+
+  sway       sway, swaylock, swayidle and xdg-desktop-portal-wlr
+  hyprland   Hyprland and xdg-desktop-portal-hyprland
+
+Both get Waybar, Wofi, Mako, swaybg, grim and slurp, and the desktop portal
+configured for screen sharing and screenshots. The configuration files are
+templates you can override, see karei template diff, and changes you make to
+the generated files are merged when you run this again.
+
+EXAMPLES:
+  karei system tiling sway
+  karei system tiling hyprland --theme nord
+  karei system tiling sway --no-install   # Only regenerate the configuration`,
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "theme",
+						Usage: "theme to match, defaulting to the applied theme",
+					},
+					&cli.BoolFlag{
+						Name:  "no-install",
+						Usage: "only generate the configuration, without installing anything",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "show what would be installed and written",
+					},
+				}, configConflictFlags()...),
+				Action: app.runSystemTiling,
+			},
+			{
+				Name:  "capture",
+				Usage: "Set up a clipboard manager and a screenshot tool for your session",
+				Description: `Install CopyQ and Flameshot, bind their keys in GNOME and start them at
+login, in the form your Wayland or X11 session needs:
+
+  Print      Take a screenshot and annotate it (Shift+Print opens GNOME's own)
+  Super+V    Show the clipboard history (Super+M still shows notifications)
+
+On Wayland CopyQ runs through XWayland, since GNOME doesn't let Wayland apps
+watch the clipboard, and wl-clipboard is installed for the terminal; on X11
+xclip is. Your own custom shortcuts are kept. Over SSH or on a text console
+nothing is changed.
+
+EXAMPLES:
+  karei system capture --dry-run   # Show what would be set up
+  karei system capture
+  karei system capture --no-install`,
+				Flags: append([]cli.Flag{
+					&cli.BoolFlag{
+						Name:  "no-install",
+						Usage: "only bind the keys and start the tools at login, without installing anything",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "show what would be installed, bound and started",
+					},
+				}, configConflictFlags()...),
+				Action: app.runSystemCapture,
+			},
+			{
+				Name:      "mail",
+				Usage:     "Set up Thunderbird or Evolution for mail and calendars",
+				ArgsUsage: "<thunderbird|evolution>",
+				Description: `Install a mail and calendar client from the communication-pro group, color it
+like the applied theme and make it the default for mailto links, saved mail
+and calendar invitations:
+
+  thunderbird   Thunderbird, with its preferences and userChrome.css themed
+  evolution     Evolution with Exchange support, following GNOME's colors
+
+With --email, the provider of the address is recognized, from its domain or
+its mail servers in DNS, and you are told how to add the account, such as
+signing in through GNOME Online Accounts or running Proton Mail Bridge.
+Thunderbird has to be started once before it can be themed.
+
+EXAMPLES:
+  karei system mail thunderbird --email me@example.com
+  karei system mail evolution --theme nord
+  karei system mail thunderbird --dry-run`,
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "email",
+						Usage: "mail address to give account setup hints for",
+					},
+					&cli.StringFlag{
+						Name:  "theme",
+						Usage: "theme to match, defaulting to the applied theme",
+					},
+					&cli.BoolFlag{
+						Name:  "no-install",
+						Usage: "only theme the client and make it the default, without installing anything",
+					},
+					&cli.BoolFlag{
+						Name:  "no-default",
+						Usage: "leave the default mail and calendar app as it is",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "show what would be installed and changed",
+					},
+				}, configConflictFlags()...),
+				Action: app.runSystemMail,
+			},
+			{
+				Name:  "printing",
+				Usage: "Set up printers and scanners",
+				Description: `Install CUPS, SANE and Avahi with the driverless printing and scanning
+backends, enable the cups and avahi-daemon services, and check that print jobs
+render by converting CUPS's test page to PDF, without printing it.
+
+Most printers and scanners made since 2015 need no driver. With
+--add-network, the printers announced on your network are added as IPP
+Everywhere queues, skipping those you have already. Scanners found the same
+way show up in Document Scanner without any setup.
+
+EXAMPLES:
+  karei system printing
+  karei system printing --add-network`,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "add-network",
+						Usage: "add the driverless printers found on the network",
+					},
+					&cli.BoolFlag{
+						Name:  "no-install",
+						Usage: "only enable the services and add printers, without installing anything",
+					},
+				},
+				Action: app.runSystemPrinting,
+			},
+			{
+				Name:      "passwords",
+				Usage:     "Check that password manager CLIs can read secrets",
+				ArgsUsage: "[1password-cli|bitwarden-cli|pass]",
+				Description: `Check the password manager CLIs installed with karei install:
+
+  1password-cli   op, with the 1Password SSH agent
+  bitwarden-cli   bw, with the Bitwarden SSH agent
+  pass            pass, with gpg-agent as SSH agent and Browserpass
+
+Installing one points ssh at its agent for every host while the agent runs,
+in ~/.ssh/config.d, and for pass turns on the SSH support of gpg-agent.
+This reports whether the vault can be read and what is left to do, such as
+signing in. With --entry, the entry is read too, without printing it.
+--setup sets up ssh again, such as for a CLI installed another way.
+
+EXAMPLES:
+  karei install pass browserpass
+  karei system passwords
+  karei system passwords bitwarden-cli --setup
+  karei system passwords 1password-cli --entry op://Private/GitHub/token`,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "entry",
+						Usage: "entry to read as a check, such as an item name or op:// reference",
+					},
+					&cli.BoolFlag{
+						Name:  "setup",
+						Usage: "point ssh at the agents before checking",
+					},
+				},
+				Action: app.runSystemPasswords,
+			},
+			{
+				Name:  "tune",
+				Usage: "Tune swap, file watches and open file limits for development",
+				Description: `Opt-in tuning for development machines, showing each value before and
+after:
+
+  zram swap                       Compressed swap in RAM, half of it at most 8 GiB
+  vm.swappiness                   180 with zram, 10 without
+  vm.page-cluster                 0 with zram, no readahead from swap
+  fs.inotify.max_user_watches     524288, for IDEs on big projects
+  fs.inotify.max_user_instances   1024
+  open files soft limit           65536, from the next login
+
+The settings go into files of their own below /etc, and what they replace is
+recorded so --rollback puts everything back as it was before karei first
+tuned the system.
+
+EXAMPLES:
+  karei system tune --dry-run   # Show the values before and after
+  karei system tune --no-zram   # Keep swapping to disk
+  karei system tune --rollback`,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "show the values before and after without changing anything",
+					},
+					&cli.BoolFlag{
+						Name:  "no-zram",
+						Usage: "leave swap as it is",
+					},
+					&cli.BoolFlag{
+						Name:  "rollback",
+						Usage: "undo the tuning, restoring the files and values it replaced",
+					},
+				},
+				Action: app.runSystemTune,
+			},
+		},
+	}
+}
+
+// runSystemMirrors benchmarks the mirrors and switches apt to the fastest.
+func (app *CLI) runSystemMirrors(ctx context.Context, cmd *cli.Command) error {
+	commandRunner := platform.NewCommandRunner(app.verbose, false)
+	fileManager := platform.NewFileManager(app.verbose)
+
+	distribution, _ := platform.NewSystemDetector(commandRunner, fileManager).DetectDistribution(ctx)
+	if distribution.ID != "ubuntu" || distribution.Codename == "" {
+		return domain.NewExitError(ExitDependencyError, "mirror selection needs Ubuntu, found "+distribution.Name, nil)
+	}
+
+	switcher := ubuntu.NewMirrorSwitcher(commandRunner, fileManager)
+
+	sourcesFile, err := switcher.SourcesFile()
+	if err != nil {
+		return domain.NewExitError(ExitNotFoundError, err.Error(), err)
+	}
+
+	if state := app.checkConnectivity(ctx); !state.Available() {
+		return domain.NewExitError(ExitNetworkError, "cannot benchmark mirrors: "+state.SkipReason(), domain.ErrOffline)
+	}
+
+	probe := network.NewMirrorProbe(network.GetHTTPClient())
+	probe.SetListURL(cmd.String("list"))
+
+	mirrors, err := probe.Mirrors(ctx)
+	if err != nil {
+		return domain.NewExitError(ExitNetworkError, err.Error(), err)
+	}
+
+	// A mirror chosen earlier is replaced even when it isn't benchmarked
+	candidates := mirrors
+	if count := int(cmd.Int("count")); count > 0 && len(candidates) > count {
+		candidates = candidates[:count]
+	}
+
+	console.DefaultOutput.Progressf("Benchmarking %d mirrors for Ubuntu %s...", len(candidates), distribution.Codename)
+
+	result := mirrorsResult{Timings: probe.Benchmark(ctx, candidates, distribution.Codename)}
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if !app.json {
+		_ = output.Info(formatMirrorTimings(result.Timings))
+	}
+
+	fastest := result.Timings[0]
+	if !fastest.Reachable() {
+		return domain.NewExitError(ExitNetworkError, "no mirror could be reached", errors.New(fastest.Error))
+	}
+
+	if cmd.Bool("dry-run") {
+		return output.Success("Fastest mirror: "+fastest.URL, result)
+	}
+
+	if !console.AskConsent("apt", sourcesFile) {
+		return domain.NewExitError(ExitUsageError, "apt sources not changed, pass --yes to skip the prompt", nil)
+	}
+
+	result.Switch, err = switcher.Switch(ctx, fastest.URL, mirrors)
+
+	switch {
+	case errors.Is(err, ubuntu.ErrMirrorUpdateFailed):
+		return domain.NewExitError(ExitNetworkError, err.Error(), err)
+	case errors.Is(err, ubuntu.ErrNoArchiveSources):
+		return domain.NewExitError(ExitNotFoundError, err.Error(), err)
+	case err != nil:
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	return output.Success(fmt.Sprintf("apt now uses %s (backup: %s)", fastest.URL, result.Switch.Backup), result)
+}
+
+// formatMirrorTimings renders the ranking, fastest first.
+func formatMirrorTimings(timings []network.MirrorTiming) string {
+	var builder strings.Builder
+
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+
+	for _, timing := range timings {
+		duration := stringutil.FormatDuration(timing.Duration)
+		if !timing.Reachable() {
+			duration = "failed"
+		}
+
+		_, _ = fmt.Fprintf(writer, "%s\t%s\n", duration, timing.URL)
+	}
+
+	_ = writer.Flush()
+
+	return strings.TrimRight(builder.String(), "\n")
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// captureResult is the outcome of karei system capture.
+type captureResult struct {
+	Profile domain.CaptureProfile `json:"profile"`
+	Written []string              `json:"written,omitempty"`
+	DryRun  bool                  `json:"dry_run"`
+}
+
+// runSystemCapture installs the clipboard manager and screenshot tool fitting
+// the session type, binds their keys and starts them at login.
+func (app *CLI) runSystemCapture(ctx context.Context, cmd *cli.Command) error {
+	commandRunner := platform.NewCommandRunner(app.verbose, false)
+	fileManager := platform.NewFileManager(app.verbose)
+	session := platform.NewSystemDetector(commandRunner, fileManager).DetectSessionType(ctx)
+
+	profile, err := domain.CaptureProfileFor(session)
+	if err != nil {
+		return domain.NewExitError(ExitUsageError, err.Error(), err)
+	}
+
+	if err := app.parseConfigConflictFlags(cmd); err != nil {
+		return err
+	}
+
+	result := captureResult{Profile: profile, DryRun: cmd.Bool("dry-run")}
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if result.DryRun {
+		if app.json {
+			return output.Success("", result)
+		}
+
+		return output.Info(formatCapturePlan(result))
+	}
+
+	if !cmd.Bool("no-install") {
+		ctx, cancel := app.applyTimeout(ctx)
+		defer cancel()
+
+		if err := app.applyApps(ctx, profile.Apps, nil); err != nil {
+			return err
+		}
+	}
+
+	service := application.NewCaptureService(fileManager, commandRunner, config.GetXDGConfigHome())
+	service.SetConfigFiles(app.configFiles())
+
+	if result.Written, err = service.Configure(ctx, profile); err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	message := "Set up CopyQ and Flameshot for " + session.String() + ": Print takes a screenshot, Super+V shows the clipboard history"
+	if profile.Note != "" {
+		message += " (" + profile.Note + ")"
+	}
+
+	return output.Success(message, result)
+}
+
+// formatCapturePlan lists what the capture profile installs, binds and starts.
+func formatCapturePlan(result captureResult) string {
+	profile := result.Profile
+	lines := []string{
+		"Session: " + profile.Session.String(),
+		"Install: " + strings.Join(profile.Apps, ", "),
+		"Keys:",
+	}
+
+	for _, keybinding := range profile.Keybindings {
+		lines = append(lines, "  "+keybinding.Binding+"  "+keybinding.Command)
+	}
+
+	lines = append(lines, "Start at login:")
+
+	for _, entry := range profile.Autostart {
+		lines = append(lines, "  "+filepath.Join(config.GetXDGConfigHome(), "autostart", entry.File))
+	}
+
+	if profile.Note != "" {
+		lines = append(lines, "Note: "+profile.Note)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// desktopResult is the outcome of karei system desktop.
+type desktopResult struct {
+	Session string             `json:"session"`
+	Tweaks  []domain.TweakPlan `json:"tweaks"`
+	DryRun  bool               `json:"dry_run"`
+}
+
+// runSystemDesktop applies the desktop tweaks fitting the session type.
+func (app *CLI) runSystemDesktop(ctx context.Context, cmd *cli.Command) error {
+	commandRunner := platform.NewCommandRunner(app.verbose, false)
+	fileManager := platform.NewFileManager(app.verbose)
+	session := platform.NewSystemDetector(commandRunner, fileManager).DetectSessionType(ctx)
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, "failed to find home directory", err)
+	}
+
+	service := application.NewDesktopService(fileManager, commandRunner, home)
+	service.SetConfigFiles(app.configFiles())
+
+	result := desktopResult{Session: session.String(), DryRun: cmd.Bool("dry-run")}
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if result.DryRun || !session.IsGraphical() {
+		result.Tweaks = service.Plan(session)
+	} else if result.Tweaks, err = service.Apply(ctx, session); err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	if app.json {
+		return output.Success("", result)
+	}
+
+	return output.Info(formatTweakPlans(result))
+}
+
+// formatTweakPlans lists each tweak as applied or skipped, with its note.
+func formatTweakPlans(result desktopResult) string {
+	lines := []string{"Session: " + result.Session}
+
+	for _, plan := range result.Tweaks {
+		mark := "✓"
+
+		switch {
+		case plan.Skipped:
+			mark = "-"
+		case result.DryRun:
+			mark = "•"
+		}
+
+		line := fmt.Sprintf("%s %s: %s", mark, plan.Name, plan.Description)
+		if plan.Note != "" {
+			line += " (" + plan.Note + ")"
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// mailResult is the outcome of karei system mail.
+type mailResult struct {
+	Profile  domain.MailProfile   `json:"profile"`
+	Theme    string               `json:"theme,omitempty"`
+	Provider *domain.MailProvider `json:"provider,omitempty"`
+	Written  []string             `json:"written,omitempty"`
+	Default  bool                 `json:"default"` // Made the default mail and calendar app
+	DryRun   bool                 `json:"dry_run"`
+}
+
+// runSystemMail installs a mail and calendar client, colors it like the
+// applied theme, makes it the default and hints at adding the account.
+func (app *CLI) runSystemMail(ctx context.Context, cmd *cli.Command) error {
+	client, err := domain.ParseMailClient(cmd.Args().First())
+	if err != nil {
+		return domain.NewExitError(ExitUsageError, err.Error(), err)
+	}
+
+	if err := app.parseConfigConflictFlags(cmd); err != nil {
+		return err
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, "failed to find home directory", err)
+	}
+
+	commandRunner := platform.NewCommandRunner(app.verbose, false)
+	service := application.NewMailService(platform.NewFileManager(app.verbose), commandRunner, app.templates(), home)
+	service.SetConfigFiles(app.configFiles())
+	service.SetDiscoverer(network.NewMailDiscoverer())
+
+	result := mailResult{Profile: domain.MailProfileFor(client), Theme: cmd.String("theme"), DryRun: cmd.Bool("dry-run")}
+	if result.Theme == "" {
+		result.Theme = app.getCurrentTheme()
+	}
+
+	if address := cmd.String("email"); address != "" {
+		provider, err := service.Discover(ctx, client, address)
+		if err != nil {
+			return domain.NewExitError(ExitUsageError, err.Error(), err)
+		}
+
+		result.Provider = &provider
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if result.DryRun {
+		if app.json {
+			return output.Success("", result)
+		}
+
+		return output.Info(formatMailPlan(result, !cmd.Bool("no-default")))
+	}
+
+	if !cmd.Bool("no-install") {
+		ctx, cancel := app.applyTimeout(ctx)
+		defer cancel()
+
+		if err := app.applyApps(ctx, result.Profile.Apps, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := app.themeMailClient(ctx, service, &result); err != nil {
+		return err
+	}
+
+	if !cmd.Bool("no-default") {
+		if err := service.SetDefault(ctx, result.Profile); err != nil {
+			return domain.NewExitError(ExitSystemError, err.Error(), err)
+		}
+
+		result.Default = true
+	}
+
+	message := "Set up " + result.Profile.Name
+	if result.Provider != nil {
+		message += "; to add your " + result.Provider.Name + " account, " + result.Provider.Hint
+	}
+
+	return output.Success(message, result)
+}
+
+// themeMailClient colors the client like the theme, when one is applied or
+// named. Thunderbird without a profile yet is skipped with a warning.
+func (app *CLI) themeMailClient(ctx context.Context, service *application.MailService, result *mailResult) error {
+	if result.Theme == "" {
+		return nil
+	}
+
+	theme, err := app.newThemeService().GetTheme(result.Theme)
+	if err != nil {
+		return domain.NewExitError(ExitNotFoundError, err.Error(), err)
+	}
+
+	result.Written, err = service.Theme(ctx, result.Profile, application.MailTheme{
+		Theme:   result.Theme,
+		Palette: theme.Palette,
+		Dark:    theme.ColorScheme == "prefer-dark",
+	})
+
+	switch {
+	case errors.Is(err, application.ErrNoThunderbirdProfile):
+		console.DefaultOutput.Warningf("Not themed: %s", err.Error())
+	case err != nil:
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	return nil
+}
+
+// formatMailPlan lists what setting up the mail client installs and changes.
+func formatMailPlan(result mailResult, setDefault bool) string {
+	lines := []string{
+		result.Profile.Name,
+		"Install: " + strings.Join(result.Profile.Apps, ", "),
+	}
+
+	if result.Theme != "" {
+		lines = append(lines, "Theme: "+result.Theme)
+	}
+
+	if setDefault {
+		lines = append(lines, "Default for: "+strings.Join(result.Profile.MimeTypes, ", "))
+	}
+
+	if result.Provider != nil {
+		lines = append(lines, "Account ("+result.Provider.Name+"): "+result.Provider.Hint)
+	}
+
+	return strings.Join(lines, "\n")
+}
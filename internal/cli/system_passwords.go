@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// runSystemPasswords sets up, with --setup, and checks the integration of the installed password
+// manager CLIs, or of the one named, and that their secrets can be read.
+func (app *CLI) runSystemPasswords(ctx context.Context, cmd *cli.Command) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, "failed to find home directory", err)
+	}
+
+	service := application.NewPasswordManagerService(platform.NewFileManager(false), platform.NewCommandRunner(app.verbose, false), home)
+	service.SetRuntimeDir(os.Getenv("XDG_RUNTIME_DIR"))
+
+	managers := service.Installed()
+
+	if key := cmd.Args().First(); key != "" {
+		manager, ok := domain.PasswordManagerFor(key)
+		if !ok {
+			return domain.NewExitError(ExitUsageError, "unknown password manager "+key+", expected 1password-cli, bitwarden-cli or pass", nil)
+		}
+
+		managers = []domain.PasswordManager{manager}
+	}
+
+	if len(managers) == 0 {
+		return domain.NewExitError(ExitNotFoundError, "no password manager CLI installed, e.g. karei install pass", nil)
+	}
+
+	if cmd.Bool("setup") {
+		for _, manager := range managers {
+			if _, err := apps.ConfigurePasswordManager(platform.NewFileManager(app.verbose), home, manager); err != nil {
+				return domain.NewExitError(ExitSystemError, err.Error(), err)
+			}
+		}
+	}
+
+	entry := cmd.String("entry")
+	checks := make([]domain.PasswordManagerCheck, 0, len(managers))
+	works := true
+
+	for _, manager := range managers {
+		check := service.Check(ctx, manager, entry)
+		checks = append(checks, check)
+		works = works && check.Works(entry)
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if app.json {
+		if err := output.Success("", checks); err != nil {
+			return err
+		}
+	} else if err := output.Info(formatPasswordChecks(checks, entry)); err != nil {
+		return err
+	}
+
+	if !works {
+		return domain.NewExitError(ExitDependencyError, "secrets can't be read from every password manager", nil)
+	}
+
+	return nil
+}
+
+// formatPasswordChecks lists each manager with what works and what's left
+// to do.
+func formatPasswordChecks(checks []domain.PasswordManagerCheck, entry string) string {
+	var lines []string
+
+	for _, check := range checks {
+		mark := "✓"
+		if !check.Works(entry) {
+			mark = "✗"
+		}
+
+		var works []string
+
+		if check.VaultReady {
+			works = append(works, "vault readable")
+		}
+
+		if check.Read {
+			works = append(works, "read "+entry)
+		}
+
+		if check.AgentRunning {
+			works = append(works, "SSH agent running")
+		}
+
+		if check.BrowserHost && check.Manager.BrowserHost != "" {
+			works = append(works, "browser extension host installed")
+		}
+
+		line := mark + " " + check.Manager.Name
+		if len(works) > 0 {
+			line += ": " + strings.Join(works, ", ")
+		}
+
+		lines = append(lines, line)
+
+		for _, problem := range check.Problems {
+			lines = append(lines, "    "+problem)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
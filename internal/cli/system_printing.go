@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// printingResult is the outcome of karei system printing.
+type printingResult struct {
+	Services   []string                `json:"services"`
+	Searched   bool                    `json:"searched"` // Whether network printers were looked for
+	Discovered []domain.NetworkPrinter `json:"discovered,omitempty"`
+	Added      []domain.NetworkPrinter `json:"added,omitempty"`
+	Renders    bool                    `json:"renders"`
+}
+
+// runSystemPrinting installs and enables printing and scanning, optionally
+// adding network printers, and checks the render path.
+func (app *CLI) runSystemPrinting(ctx context.Context, cmd *cli.Command) error {
+	if !cmd.Bool("no-install") {
+		ctx, cancel := app.applyTimeout(ctx)
+		defer cancel()
+
+		if err := app.applyApps(ctx, apps.Groups["printing"], nil); err != nil {
+			return err
+		}
+	}
+
+	service := application.NewPrintingService(platform.NewCommandRunner(app.verbose, false))
+	result := printingResult{Services: domain.PrintingServices()}
+
+	if err := service.EnableServices(ctx); err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	if cmd.Bool("add-network") {
+		if err := app.addNetworkPrinters(ctx, service, &result); err != nil {
+			return err
+		}
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if err := service.VerifyRender(ctx); err != nil {
+		return domain.NewExitError(ExitDependencyError, err.Error()+", check that cups-filters is installed", err)
+	}
+
+	result.Renders = true
+
+	if app.json {
+		return output.Success("", result)
+	}
+
+	return output.Success(formatPrintingResult(result), nil)
+}
+
+// addNetworkPrinters discovers the driverless printers on the network and
+// adds those without a queue.
+func (app *CLI) addNetworkPrinters(ctx context.Context, service *application.PrintingService, result *printingResult) error {
+	console.DefaultOutput.Progressf("Looking for printers on the network...")
+
+	printers, err := service.Discover(ctx)
+	if err != nil {
+		return domain.NewExitError(ExitDependencyError, err.Error()+", install avahi-utils", err)
+	}
+
+	result.Searched, result.Discovered = true, printers
+
+	if result.Added, err = service.AddPrinters(ctx, printers); err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	return nil
+}
+
+// formatPrintingResult summarizes the printing setup.
+func formatPrintingResult(result printingResult) string {
+	lines := []string{"Printing ready: " + strings.Join(result.Services, " and ") + " enabled, test page renders"}
+
+	for _, printer := range result.Added {
+		lines = append(lines, fmt.Sprintf("  Added %s as %s (%s)", printer.Name, printer.Queue, printer.URI))
+	}
+
+	switch {
+	case !result.Searched || len(result.Added) > 0:
+	case len(result.Discovered) == 0:
+		lines = append(lines, "  No printers announced on the network")
+	default:
+		lines = append(lines, fmt.Sprintf("  No new printers, the %d on the network have a queue already", len(result.Discovered)))
+	}
+
+	return strings.Join(lines, "\n")
+}
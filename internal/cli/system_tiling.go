@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// defaultTilingTheme is matched when no theme has been applied yet.
+const defaultTilingTheme = "tokyo-night"
+
+// tilingResult is the outcome of karei system tiling.
+type tilingResult struct {
+	Profile domain.TilingProfile `json:"profile"`
+	Theme   string               `json:"theme"`
+	Written []string             `json:"written,omitempty"`
+	DryRun  bool                 `json:"dry_run"`
+}
+
+// runSystemTiling installs a tiling window manager profile and generates its
+// theme-matched configuration.
+func (app *CLI) runSystemTiling(ctx context.Context, cmd *cli.Command) error {
+	wm, err := domain.ParseTilingWM(cmd.Args().First())
+	if err != nil {
+		return domain.NewExitError(ExitUsageError, err.Error(), err)
+	}
+
+	if err := app.parseConfigConflictFlags(cmd); err != nil {
+		return err
+	}
+
+	result := tilingResult{Profile: domain.TilingProfileFor(wm), Theme: cmd.String("theme"), DryRun: cmd.Bool("dry-run")}
+	if result.Theme == "" {
+		result.Theme = app.getCurrentTheme()
+	}
+
+	if result.Theme == "" {
+		result.Theme = defaultTilingTheme
+	}
+
+	theme, err := app.tilingTheme(result.Theme)
+	if err != nil {
+		return domain.NewExitError(ExitNotFoundError, err.Error(), err)
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if result.DryRun {
+		if app.json {
+			return output.Success("", result)
+		}
+
+		return output.Info(formatTilingPlan(result))
+	}
+
+	if !cmd.Bool("no-install") {
+		ctx, cancel := app.applyTimeout(ctx)
+		defer cancel()
+
+		if err := app.applyApps(ctx, result.Profile.Apps, nil); err != nil {
+			return err
+		}
+	}
+
+	service := application.NewTilingService(platform.NewFileManager(app.verbose), app.templates(), config.GetXDGConfigHome())
+	service.SetConfigFiles(app.configFiles())
+
+	if result.Written, err = service.Configure(result.Profile, theme); err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	return output.Success("Configured "+result.Profile.Name+" for "+result.Theme+
+		", log out and pick "+result.Profile.Name+" on the login screen", result)
+}
+
+// tilingTheme returns the colors and wallpaper of the theme named name.
+func (app *CLI) tilingTheme(name string) (application.TilingTheme, error) {
+	themes := app.newThemeService()
+
+	theme, err := themes.GetTheme(name)
+	if err != nil {
+		return application.TilingTheme{}, err
+	}
+
+	tiling := application.TilingTheme{Theme: name, Palette: theme.Palette, Terminal: "ghostty"}
+
+	if theme.Background != "" {
+		wallpaper := filepath.Join(config.GetKareiPath(), "themes", name, theme.Background)
+		if platform.NewFileManager(false).FileExists(wallpaper) {
+			tiling.Wallpaper = wallpaper
+		}
+	}
+
+	return tiling, nil
+}
+
+// formatTilingPlan lists what a tiling profile installs and writes.
+func formatTilingPlan(result tilingResult) string {
+	lines := []string{
+		result.Profile.Name + " with the " + result.Theme + " theme",
+		"Install: " + strings.Join(result.Profile.Apps, ", "),
+		"Write:",
+	}
+
+	for _, file := range result.Profile.Configs {
+		lines = append(lines, "  "+filepath.Join(config.GetXDGConfigHome(), file.Path))
+	}
+
+	lines = append(lines, "  "+filepath.Join(config.GetXDGConfigHome(), result.Profile.PortalConfigPath()))
+
+	return strings.Join(lines, "\n")
+}
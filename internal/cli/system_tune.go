@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// tuneResult is the outcome of karei system tune.
+type tuneResult struct {
+	Changes []domain.TuneChange `json:"changes"`
+	Files   []string            `json:"files"`
+	DryRun  bool                `json:"dry_run"`
+}
+
+// runSystemTune applies the developer tuning, or rolls it back.
+func (app *CLI) runSystemTune(ctx context.Context, cmd *cli.Command) error {
+	service := application.NewTuneService(platform.NewFileManager(app.verbose), platform.NewCommandRunner(app.verbose, false), xdg.TuneRecordFile())
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if cmd.Bool("rollback") {
+		return app.rollbackTuning(ctx, service, output)
+	}
+
+	tuning := domain.DeveloperTuning(!cmd.Bool("no-zram"))
+	result := tuneResult{Changes: service.Plan(ctx, tuning), DryRun: cmd.Bool("dry-run")}
+
+	for _, file := range tuning.Files {
+		result.Files = append(result.Files, file.Path)
+	}
+
+	if !app.json {
+		_ = output.Info(formatTuneChanges(result.Changes))
+	}
+
+	if result.DryRun {
+		return output.Success("", result)
+	}
+
+	if !console.AskConsent("kernel and systemd", strings.Join(result.Files, ", ")) {
+		return domain.NewExitError(ExitUsageError, "system not tuned, pass --yes to skip the prompt", nil)
+	}
+
+	if tuning.Zram {
+		if err := app.applyApps(ctx, []string{"zram-generator"}, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := service.Apply(ctx, tuning); err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	return output.Success("System tuned, undo with: karei system tune --rollback", result)
+}
+
+// rollbackTuning restores the state recorded before the first tuning.
+func (app *CLI) rollbackTuning(ctx context.Context, service *application.TuneService, output domain.OutputPort) error {
+	record, err := service.Rollback(ctx)
+
+	switch {
+	case errors.Is(err, application.ErrNotTuned):
+		return domain.NewExitError(ExitNotFoundError, "nothing to roll back, karei system tune hasn't been run", err)
+	case err != nil:
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	return output.Success(fmt.Sprintf("Restored %d files and %d kernel parameters as they were on %s",
+		len(record.Files), len(record.Sysctl), record.Applied.Format("2006-01-02")), record)
+}
+
+// formatTuneChanges lists each setting before and after tuning.
+func formatTuneChanges(changes []domain.TuneChange) string {
+	var builder strings.Builder
+
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "SETTING\tBEFORE\tAFTER\tWHY")
+
+	for _, change := range changes {
+		after := change.After
+		if !change.Changed() {
+			after += " (unchanged)"
+		}
+
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", change.Name, change.Before, after, change.Note)
+	}
+
+	_ = writer.Flush()
+
+	return strings.TrimRight(builder.String(), "\n")
+}
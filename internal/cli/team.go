@@ -0,0 +1,375 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/fleet"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// defaultTeamKeyFile is where karei team keygen writes the private key.
+const defaultTeamKeyFile = "karei-team.key"
+
+// teamFetchTimeout bounds fetching a team profile and its signature.
+const teamFetchTimeout = 30 * time.Second
+
+// createTeamCommand creates the team command for following a published profile.
+func (app *CLI) createTeamCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "team",
+		Usage: "Follow a profile your team publishes",
+		Description: `A team publishes a profile, in the format karei apply reads, at a URL
+with its signature next to it at <url>.sig. Joining subscribes this machine:
+karei checks the profile daily, and karei team update shows what changed and
+applies it once you confirm. Profiles not signed with the team's key are
+refused; without --key, the profile must be signed by a key added with
+karei trust add.
+
+Publishing a profile:
+  karei team keygen                     # Prints the public key to share
+  karei team sign profile.yaml          # Writes profile.yaml.sig
+  # Upload profile.yaml and profile.yaml.sig side by side
+
+Examples:
+  karei team join https://example.com/team/profile.yaml --key <public key>
+  karei team update --check             # Show what changed without applying
+  karei team update                     # Apply the changes after confirming
+  karei team leave`,
+		Commands: []*cli.Command{
+			{
+				Name:      "join",
+				Usage:     "Subscribe to a team profile and apply it",
+				ArgsUsage: "<url>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "key",
+						Usage: "public key the profile must be signed with, instead of any trusted key",
+					},
+					&cli.BoolFlag{
+						Name:  "no-timer",
+						Usage: "don't check for updates daily",
+					},
+				},
+				Action: app.runTeamJoin,
+			},
+			{
+				Name:  "update",
+				Usage: "Show changes to the team profile and apply them",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "check",
+						Usage: "only show what changed",
+					},
+					&cli.BoolFlag{
+						Name:  "notify",
+						Usage: "send a desktop notification when the profile changed",
+					},
+				},
+				Action: app.runTeamUpdate,
+			},
+			{
+				Name:   "status",
+				Usage:  "Show the team profile this machine follows",
+				Action: app.runTeamStatus,
+			},
+			{
+				Name:   "leave",
+				Usage:  "Stop following the team profile",
+				Action: app.runTeamLeave,
+			},
+			{
+				Name:  "keygen",
+				Usage: "Create the key pair a team signs its profile with",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "out",
+						Usage: "private key file",
+						Value: defaultTeamKeyFile,
+					},
+				},
+				Action: app.runTeamKeygen,
+			},
+			{
+				Name:      "sign",
+				Usage:     "Sign a profile for publishing, writing <profile>.sig",
+				ArgsUsage: "<profile.yaml>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "key-file",
+						Usage: "private key file",
+						Value: defaultTeamKeyFile,
+					},
+				},
+				Action: app.runTeamSign,
+			},
+		},
+	}
+}
+
+// teamService returns the service following the team profile.
+func (app *CLI) teamService() *application.TeamService {
+	service := application.NewTeamService(platform.NewFileManager(false), platform.NewCommandRunner(app.verbose, false),
+		network.NewHTTPClient(teamFetchTimeout), xdg.TeamFile())
+	service.SetUnitDir(filepath.Join(config.GetXDGConfigHome(), "systemd", "user"))
+	service.SetTrust(app.trustService())
+
+	return service
+}
+
+// runTeamJoin subscribes to the profile, starts the daily check and offers
+// to apply the profile.
+func (app *CLI) runTeamJoin(ctx context.Context, cmd *cli.Command) error {
+	url := cmd.Args().First()
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return domain.NewExitError(ExitUsageError, "specify the URL of the team profile", nil)
+	}
+
+	ctx, cancel := app.applyTimeout(ctx)
+	defer cancel()
+
+	service := app.teamService()
+
+	update, err := service.Join(ctx, url, cmd.String("key"))
+	if err != nil {
+		return teamExitError(err)
+	}
+
+	if !cmd.Bool("no-timer") {
+		executable, err := os.Executable()
+		if err == nil {
+			err = service.InstallTimer(ctx, executable)
+		}
+
+		if err != nil {
+			console.DefaultOutput.Warningf("Could not schedule the daily check, run karei team update yourself: %v", err)
+		}
+	}
+
+	return app.applyTeamUpdate(ctx, service, url, update)
+}
+
+// runTeamUpdate shows how the team profile changed and applies it.
+func (app *CLI) runTeamUpdate(ctx context.Context, cmd *cli.Command) error {
+	ctx, cancel := app.applyTimeout(ctx)
+	defer cancel()
+
+	service := app.teamService()
+
+	subscription, err := service.Subscription()
+	if err != nil {
+		return teamExitError(err)
+	}
+
+	update, err := service.Check(ctx)
+	if err != nil {
+		return teamExitError(err)
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if !update.Pending {
+		return output.Success("The team profile is up to date", update)
+	}
+
+	if cmd.Bool("notify") {
+		app.notifyTeamUpdate(ctx)
+	}
+
+	if cmd.Bool("check") {
+		if app.json {
+			return output.Success("", update)
+		}
+
+		showTeamDiff(output, subscription.URL, update)
+
+		return output.Info("Apply the changes with: karei team update")
+	}
+
+	return app.applyTeamUpdate(ctx, service, subscription.URL, update)
+}
+
+// applyTeamUpdate shows the changes to the profile and applies it once the
+// user agrees.
+func (app *CLI) applyTeamUpdate(ctx context.Context, service *application.TeamService, url string, update application.TeamUpdate) error {
+	profile, err := fleet.ParseProfile(update.Profile)
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, "team profile "+url+": "+err.Error(), err)
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+	if !app.json {
+		showTeamDiff(output, url, update)
+	}
+
+	if !console.AskTeamProfile(url) {
+		return output.Info("Not applied, apply it later with: karei team update")
+	}
+
+	if _, err := app.applyProfile(ctx, profile); err != nil {
+		return err
+	}
+
+	if err := service.MarkApplied(update.Profile); err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	return output.Success("Applied the team profile from "+url, update)
+}
+
+// runTeamStatus shows the subscription.
+func (app *CLI) runTeamStatus(_ context.Context, _ *cli.Command) error {
+	subscription, err := app.teamService().Subscription()
+	if err != nil {
+		return teamExitError(err)
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+	if app.json {
+		return output.Success("", subscription)
+	}
+
+	_ = output.Info("Team profile " + subscription.URL)
+	if subscription.Key == "" {
+		_ = output.Info("  Key:     any trusted key")
+	} else {
+		_ = output.Info("  Key:     " + subscription.Key)
+	}
+
+	_ = output.Info("  Joined:  " + subscription.Joined.Local().Format(historyTimeLayout))
+
+	if !subscription.Checked.IsZero() {
+		_ = output.Info("  Checked: " + subscription.Checked.Local().Format(historyTimeLayout))
+	}
+
+	if subscription.Updated.IsZero() {
+		return output.Info("  Applied: never, apply it with: karei team update")
+	}
+
+	return output.Info("  Applied: " + subscription.Updated.Local().Format(historyTimeLayout))
+}
+
+// runTeamLeave ends the subscription.
+func (app *CLI) runTeamLeave(ctx context.Context, _ *cli.Command) error {
+	if err := app.teamService().Leave(ctx); err != nil {
+		return teamExitError(err)
+	}
+
+	return cliAdapter.OutputFromContext(app.json, app.quiet).Success(
+		"Left the team profile; what it installed stays installed", nil)
+}
+
+// runTeamKeygen writes a new private key and prints the public key.
+func (app *CLI) runTeamKeygen(_ context.Context, cmd *cli.Command) error {
+	path := cmd.String("out")
+	if _, err := os.Stat(path); err == nil {
+		return domain.NewExitError(ExitUsageError, path+" exists already, choose another with --out", nil)
+	}
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, "failed to generate a key", err)
+	}
+
+	public, privateFile := domain.EncodeTeamKeys(private)
+
+	if err := os.WriteFile(path, privateFile, 0o600); err != nil {
+		return domain.NewExitError(ExitSystemError, "failed to write "+path, err)
+	}
+
+	return cliAdapter.OutputFromContext(app.json, app.quiet).Success(
+		fmt.Sprintf("Wrote the private key to %s; keep it secret. Public key to join with:\n  %s", path, public),
+		map[string]string{"public_key": public, "private_key_file": path})
+}
+
+// runTeamSign checks a profile and writes its signature next to it.
+func (app *CLI) runTeamSign(_ context.Context, cmd *cli.Command) error {
+	path := cmd.Args().First()
+	if path == "" {
+		return domain.NewExitError(ExitUsageError, "specify the profile to sign", nil)
+	}
+
+	// Refuse to publish what joining machines would reject
+	if _, err := fleet.LoadProfile(path); err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	return app.signFile(path, cmd.String("key-file"))
+}
+
+// signFile writes the signature of the file at path, made with the private
+// key in keyFile, next to it.
+func (app *CLI) signFile(path, keyFile string) error {
+	keyData, err := os.ReadFile(keyFile) //nolint:gosec // the user names the key file
+	if err != nil {
+		return domain.NewExitError(ExitNotFoundError, "failed to read the private key, create one with: karei team keygen", err)
+	}
+
+	private, err := domain.ParseTeamPrivateKey(keyData)
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	content, err := os.ReadFile(path) //nolint:gosec // the user names the file to sign
+	if err != nil {
+		return domain.NewExitError(ExitNotFoundError, "failed to read "+path, err)
+	}
+
+	signature := path + domain.TeamSignatureSuffix
+	if err := os.WriteFile(signature, domain.SignTeamProfile(content, private), 0o644); err != nil { //nolint:gosec // signatures are published
+		return domain.NewExitError(ExitSystemError, "failed to write "+signature, err)
+	}
+
+	return cliAdapter.OutputFromContext(app.json, app.quiet).Success(
+		"Signed "+path+"; publish "+signature+" next to it", nil)
+}
+
+// notifyTeamUpdate sends a desktop notification about a changed profile.
+func (app *CLI) notifyTeamUpdate(ctx context.Context) {
+	runner := platform.NewCommandRunner(false, false)
+	if !runner.CommandExists("notify-send") {
+		return
+	}
+
+	_ = runner.Execute(ctx, "notify-send", "--app-name=karei", "Team profile changed",
+		"Review and apply the changes with: karei team update")
+}
+
+// showTeamDiff shows how the profile changed since it was applied.
+func showTeamDiff(output domain.OutputPort, url string, update application.TeamUpdate) {
+	lines := append([]string{"--- applied", "+++ " + url}, update.Diff...)
+	_ = output.Info(strings.Join(lines, "\n"))
+}
+
+// teamExitError maps a team error to the exit code.
+func teamExitError(err error) error {
+	switch {
+	case errors.Is(err, application.ErrNotSubscribed):
+		return domain.NewExitError(ExitNotFoundError, err.Error(), err)
+	case errors.Is(err, domain.ErrInvalidTeamKey):
+		return domain.NewExitError(ExitUsageError, err.Error(), err)
+	case errors.Is(err, domain.ErrBadSignature), errors.Is(err, domain.ErrUnsigned), errors.Is(err, domain.ErrUntrusted):
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	default:
+		return domain.NewExitError(ExitNetworkError, err.Error(), err)
+	}
+}
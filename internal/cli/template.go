@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// createTemplateCommand creates the template command for user overrides of built-in templates.
+func (app *CLI) createTemplateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "template",
+		Usage: "Compare your template overrides with karei's defaults",
+		Description: `Files in ` + xdg.TemplateDir() + ` override karei's built-in templates
+at the same name, such as themes/nord/btop.theme or configs/mise.toml.
+
+An override without markers replaces the built-in template. A line containing
+karei:replace, karei:prepend or karei:append, written as a comment in the
+syntax of the file, starts a section that is used instead of, put before, or
+put after the built-in template:
+
+  # karei:append
+  vim_keys = True
+
+Lines before the first marker replace the built-in unless they are blank.
+
+EXAMPLES:
+  karei template diff                          # Diff every override
+  karei template diff themes/nord/btop.theme   # Diff one template`,
+		Commands: []*cli.Command{
+			{
+				Name:      "diff",
+				Usage:     "Show how the templates karei writes differ from the defaults",
+				ArgsUsage: "[name]",
+				Action:    app.runTemplateDiff,
+			},
+		},
+	}
+}
+
+// runTemplateDiff diffs the effective output of the named template, or of
+// every overridden one, with the built-in default.
+func (app *CLI) runTemplateDiff(_ context.Context, cmd *cli.Command) error {
+	names := cmd.Args().Slice()
+	if len(names) == 0 {
+		overrides, err := templateOverrides(xdg.TemplateDir())
+		if err != nil {
+			return domain.NewExitError(ExitSystemError, "failed to list template overrides", err)
+		}
+
+		names = overrides
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if len(names) == 0 {
+		return output.Info("No template overrides in " + xdg.TemplateDir())
+	}
+
+	templates := app.templates()
+	diffs := make(map[string][]string, len(names))
+
+	var lines []string
+
+	for _, name := range names {
+		diff, err := templates.Diff(name)
+
+		switch {
+		case errors.Is(err, application.ErrUnknownTemplate):
+			return domain.NewExitError(ExitNotFoundError, err.Error(), err)
+		case err != nil:
+			return domain.NewExitError(ExitSystemError, err.Error(), err)
+		}
+
+		diffs[name] = diff
+
+		if len(diff) > 0 {
+			lines = append(lines, "--- default/"+name, "+++ effective/"+name)
+			lines = append(lines, diff...)
+		}
+	}
+
+	if app.json {
+		return output.Success("", diffs)
+	}
+
+	if len(lines) == 0 {
+		return output.Info("Templates match the defaults.")
+	}
+
+	return output.Info(strings.Join(lines, "\n"))
+}
+
+// templateOverrides lists the files below dir by their name relative to it.
+func templateOverrides(dir string) ([]string, error) {
+	var names []string
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, path)
+		names = append(names, name)
+
+		return err
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	return names, err
+}
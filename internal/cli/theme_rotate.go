@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// createThemeRotateCommand creates the theme rotate subcommand.
+func (app *CLI) createThemeRotateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "rotate",
+		Usage: "Rotate themes on a schedule",
+		Description: `Apply a list of themes in turn, one every interval, with a systemd user
+timer. The themes and the position in the list are kept in config.toml
+under [theme], so the rotation carries on where it left off after a reboot.
+
+Intervals are durations such as 90m or 12h, or whole days such as 1d; the
+shortest is a minute. Configuration files you changed are left as they are
+when a theme is applied by the timer.
+
+Without flags, shows the rotation.
+
+Examples:
+  karei theme rotate --every 1d --themes tokyo-night,nord,kanagawa
+  karei theme rotate                  # Show the rotation
+  karei theme rotate --next           # Apply the next theme now
+  karei theme rotate --stop           # Stop rotating`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "every",
+				Usage: "interval between themes, e.g. 1d or 6h",
+			},
+			&cli.StringFlag{
+				Name:  "themes",
+				Usage: "comma-separated themes to rotate through",
+			},
+			&cli.BoolFlag{
+				Name:  "next",
+				Usage: "apply the next theme of the rotation",
+			},
+			&cli.BoolFlag{
+				Name:  "stop",
+				Usage: "stop the rotation and forget its themes",
+			},
+		},
+		Action: app.runThemeRotate,
+	}
+}
+
+// themeRotation returns the scheduler of the theme rotation.
+func (app *CLI) themeRotation() *application.ThemeRotation {
+	rotation := application.NewThemeRotation(platform.NewFileManager(false), platform.NewCommandRunner(app.verbose, false))
+	rotation.SetUnitDir(filepath.Join(config.GetXDGConfigHome(), "systemd", "user"))
+
+	return rotation
+}
+
+// runThemeRotate handles the theme rotate subcommand.
+func (app *CLI) runThemeRotate(ctx context.Context, cmd *cli.Command) error {
+	path := xdg.PreferencesFile()
+
+	prefs, err := config.LoadPreferences(path)
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	switch {
+	case cmd.Bool("stop"):
+		return app.stopThemeRotation(ctx, path, prefs)
+	case cmd.Bool("next"):
+		return app.rotateTheme(ctx, path, prefs)
+	case cmd.IsSet("every") || cmd.IsSet("themes"):
+		return app.scheduleThemeRotation(ctx, cmd, path, prefs)
+	}
+
+	return app.showThemeRotation(prefs)
+}
+
+// scheduleThemeRotation saves the rotation and starts its timer. The
+// rotation is saved first, so it can still be run by hand without systemd.
+func (app *CLI) scheduleThemeRotation(ctx context.Context, cmd *cli.Command, path string, prefs *config.Preferences) error {
+	every, themeList := cmd.String("every"), cmd.String("themes")
+	if every == "" || themeList == "" {
+		return domain.NewExitError(ExitUsageError, "specify both --every and --themes", nil)
+	}
+
+	interval, err := config.ParseAge(every)
+	if err != nil {
+		return domain.NewExitError(ExitUsageError, err.Error(), err)
+	}
+
+	themes := app.newThemeService()
+
+	var rotate []string
+
+	for theme := range strings.SplitSeq(themeList, ",") {
+		theme = strings.TrimSpace(theme)
+		if theme == "" {
+			continue
+		}
+
+		if _, err := themes.GetTheme(theme); err != nil {
+			return domain.NewExitError(ExitUsageError, err.Error(), err)
+		}
+
+		rotate = append(rotate, theme)
+	}
+
+	if len(rotate) < 2 {
+		return domain.NewExitError(ExitUsageError, "specify at least two themes to rotate through", nil)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	if interval < application.MinRotationInterval {
+		return domain.NewExitError(ExitUsageError, "the shortest interval is a minute", nil)
+	}
+
+	prefs.Theme = config.ThemePreferences{Rotate: rotate, RotateEvery: every}
+
+	if err := config.SavePreferences(path, prefs); err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	if err := app.themeRotation().Schedule(ctx, executable, interval); err != nil {
+		if errors.Is(err, application.ErrRotationInterval) {
+			return domain.NewExitError(ExitUsageError, err.Error(), err)
+		}
+
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	return cliAdapter.OutputFromContext(app.json, app.quiet).Success(
+		"Rotating "+strings.Join(rotate, ", ")+" every "+every+", starting with "+rotate[0], prefs.Theme)
+}
+
+// rotateTheme applies the next theme of the rotation and moves it on. The
+// rotation moves on only once the theme is applied, so a failed run is
+// retried with the same theme.
+func (app *CLI) rotateTheme(ctx context.Context, path string, prefs *config.Preferences) error {
+	theme := prefs.NextRotationTheme()
+	if theme == "" {
+		return domain.NewExitError(ExitNotFoundError,
+			"no theme rotation set, run 'karei theme rotate --every 1d --themes <theme>,<theme>'", nil)
+	}
+
+	if err := app.newThemeService().ApplyTheme(ctx, theme); err != nil {
+		return err
+	}
+
+	if err := config.SavePreferences(path, prefs); err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	return cliAdapter.OutputFromContext(app.json, app.quiet).Success("Theme '"+theme+"' applied", map[string]string{"theme": theme})
+}
+
+// stopThemeRotation removes the timer and the rotation from config.toml.
+func (app *CLI) stopThemeRotation(ctx context.Context, path string, prefs *config.Preferences) error {
+	if err := app.themeRotation().Stop(ctx); err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	prefs.Theme = config.ThemePreferences{}
+
+	if err := config.SavePreferences(path, prefs); err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	return cliAdapter.OutputFromContext(app.json, app.quiet).Success("Theme rotation stopped", nil)
+}
+
+// showThemeRotation prints the themes rotated through, how often and which
+// comes next.
+func (app *CLI) showThemeRotation(prefs *config.Preferences) error {
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+	rotation := prefs.Theme
+	scheduled := app.themeRotation().Scheduled()
+
+	if app.json {
+		return output.Success("", map[string]any{
+			"themes":    rotation.Rotate,
+			"every":     rotation.RotateEvery,
+			"next":      prefs.NextRotationTheme(),
+			"scheduled": scheduled,
+		})
+	}
+
+	if len(rotation.Rotate) == 0 {
+		return output.Info("No theme rotation set")
+	}
+
+	message := "Rotating " + strings.Join(rotation.Rotate, ", ") + " every " + rotation.RotateEvery +
+		", next: " + prefs.NextRotationTheme()
+	if !scheduled {
+		message += " (timer not installed, run karei theme rotate --every again)"
+	}
+
+	return output.Info(message)
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	cli "github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// createTrashCommand creates the trash command for configuration purged on uninstall.
+func (app *CLI) createTrashCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "trash",
+		Usage: "List, restore or empty configuration purged on uninstall",
+		Description: `Uninstalling an app moves its leftover configuration, data and cache in
+your home directory to ` + xdg.TrashDir() + ` instead of deleting it.
+Each uninstall gets an entry named by when it happened.
+
+EXAMPLES:
+  karei trash                          # List entries
+  karei trash restore 20250101-120000  # Put the files back where they were
+  karei --yes trash empty              # Delete everything in the trash`,
+		Action: app.runTrashList,
+		Commands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List trash entries, oldest first",
+				Action: app.runTrashList,
+			},
+			{
+				Name:      "restore",
+				Usage:     "Move the files of an entry back",
+				ArgsUsage: "<id>",
+				Action:    app.runTrashRestore,
+			},
+			{
+				Name:   "empty",
+				Usage:  "Permanently delete all trash entries",
+				Action: app.runTrashEmpty,
+			},
+		},
+	}
+}
+
+// runTrashList prints the trash entries with the files in each.
+func (app *CLI) runTrashList(_ context.Context, _ *cli.Command) error {
+	entries, err := platform.NewTrash(xdg.TrashDir()).List()
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if app.json {
+		return output.Success("", entries)
+	}
+
+	if len(entries) == 0 {
+		return output.Info("Trash is empty.")
+	}
+
+	var lines []string
+
+	for _, entry := range entries {
+		lines = append(lines, fmt.Sprintf("%s  %s", entry.ID, entry.App))
+		for _, item := range entry.Items {
+			lines = append(lines, "  "+item.Original)
+		}
+	}
+
+	lines = append(lines, "", "Restore with: karei trash restore <id>")
+
+	return output.Info(strings.Join(lines, "\n"))
+}
+
+// runTrashRestore moves the files of one entry back.
+func (app *CLI) runTrashRestore(_ context.Context, cmd *cli.Command) error {
+	id := cmd.Args().First()
+	if id == "" {
+		return domain.NewExitError(ExitUsageError, "specify the trash entry to restore, see: karei trash list", nil)
+	}
+
+	entry, err := platform.NewTrash(xdg.TrashDir()).Restore(id)
+
+	switch {
+	case errors.Is(err, domain.ErrTrashEntryNotFound):
+		return domain.NewExitError(ExitNotFoundError, err.Error(), err)
+	case errors.Is(err, domain.ErrRestoreConflict):
+		return domain.NewExitError(ExitGeneralError, err.Error()+", move it away first", err)
+	case err != nil:
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	return cliAdapter.OutputFromContext(app.json, app.quiet).Success(
+		fmt.Sprintf("Restored %d files of %s", len(entry.Items), entry.App), entry)
+}
+
+// runTrashEmpty deletes all entries after confirmation.
+func (app *CLI) runTrashEmpty(_ context.Context, _ *cli.Command) error {
+	trash := platform.NewTrash(xdg.TrashDir())
+
+	entries, err := trash.List()
+	if err != nil {
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if len(entries) == 0 {
+		return output.Info("Trash is empty.")
+	}
+
+	if !console.AskEmptyTrash(len(entries)) {
+		return domain.NewExitError(ExitUsageError, "trash not emptied, pass --yes to skip the prompt", nil)
+	}
+
+	if err := trash.Empty(); err != nil {
+		return domain.NewExitError(ExitSystemError, err.Error(), err)
+	}
+
+	return output.Success(fmt.Sprintf("Deleted %d trash entries", len(entries)), nil)
+}
@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// remoteCatalogMaxAge is how long a fetched remote catalog is used before
+// it is fetched again.
+const remoteCatalogMaxAge = 24 * time.Hour
+
+// createTrustCommand creates the trust command managing the keys remote
+// content must be signed with.
+func (app *CLI) createTrustCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "trust",
+		Usage: "Manage the keys remote catalogs and profiles must be signed with",
+		Description: `Karei only uses a remote catalog (KAREI_CATALOG_PATH set to a URL), a
+remote profile (karei apply <url>) or a team profile joined without --key
+when a trusted key signed it. Signatures are published next to the content:
+<url>.minisig for minisign keys, <url>.sig for cosign and karei team keys.
+
+Examples:
+  karei trust add acme minisign.pub     # A minisign public key file
+  karei trust add acme cosign.pub       # A cosign public key file
+  karei trust add acme RWQf6LRCGA9i...  # A key given directly
+  karei trust list
+  karei trust remove acme
+  karei trust sign catalog.toml         # Sign with a key from karei team keygen`,
+		Commands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     "Trust a minisign, cosign or karei team public key",
+				ArgsUsage: "<name> <key or key file>",
+				Action:    app.runTrustAdd,
+			},
+			{
+				Name:   "list",
+				Usage:  "List the trusted keys",
+				Action: app.runTrustList,
+			},
+			{
+				Name:      "remove",
+				Usage:     "Stop trusting a key",
+				ArgsUsage: "<name>",
+				Action:    app.runTrustRemove,
+			},
+			{
+				Name:      "sign",
+				Usage:     "Sign a catalog or profile with a karei team key, writing <file>.sig",
+				ArgsUsage: "<file>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "key-file",
+						Usage: "private key file written by karei team keygen",
+						Value: defaultTeamKeyFile,
+					},
+				},
+				Action: app.runTrustSign,
+			},
+		},
+	}
+}
+
+// trustService returns the service keeping the trust store.
+func (app *CLI) trustService() *application.TrustService {
+	return application.NewTrustService(platform.NewFileManager(false), platform.NewCommandRunner(app.verbose, false),
+		network.NewHTTPClient(teamFetchTimeout), xdg.TrustFile())
+}
+
+// runTrustAdd adds a key to the trust store.
+func (app *CLI) runTrustAdd(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 2 {
+		return domain.NewExitError(ExitUsageError, "usage: karei trust add <name> <key or key file>", ErrInvalidArgument)
+	}
+
+	name, value := cmd.Args().Get(0), cmd.Args().Get(1)
+	if data, err := os.ReadFile(value); err == nil { //nolint:gosec // the user names the key file
+		value = string(data)
+	}
+
+	key, err := app.trustService().Add(name, value)
+	if err != nil {
+		return trustExitError(err)
+	}
+
+	return cliAdapter.OutputFromContext(app.json, app.quiet).Success(
+		fmt.Sprintf("Trusting %s key %s", key.Format, key.Name), key)
+}
+
+// runTrustList lists the trusted keys.
+func (app *CLI) runTrustList(_ context.Context, _ *cli.Command) error {
+	keys, err := app.trustService().Keys()
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+	if app.json {
+		return output.Success("", keys)
+	}
+
+	if len(keys) == 0 {
+		return output.Info("No trusted keys; remote catalogs and profiles are refused. Add one with: karei trust add <name> <key>")
+	}
+
+	for _, key := range keys {
+		_ = output.Info(fmt.Sprintf("%-20s %-9s added %s", key.Name, key.Format, key.Added.Local().Format(historyTimeLayout)))
+	}
+
+	return nil
+}
+
+// runTrustRemove removes a key from the trust store.
+func (app *CLI) runTrustRemove(_ context.Context, cmd *cli.Command) error {
+	name := cmd.Args().First()
+	if name == "" {
+		return domain.NewExitError(ExitUsageError, "usage: karei trust remove <name>", ErrInvalidArgument)
+	}
+
+	if err := app.trustService().Remove(name); err != nil {
+		return trustExitError(err)
+	}
+
+	return cliAdapter.OutputFromContext(app.json, app.quiet).Success("No longer trusting key "+name, nil)
+}
+
+// runTrustSign writes the signature of a file for publishing.
+func (app *CLI) runTrustSign(_ context.Context, cmd *cli.Command) error {
+	path := cmd.Args().First()
+	if path == "" {
+		return domain.NewExitError(ExitUsageError, "usage: karei trust sign <file>", ErrInvalidArgument)
+	}
+
+	return app.signFile(path, cmd.String("key-file"))
+}
+
+// isRemote reports whether path is the URL of remote content.
+func isRemote(path string) bool {
+	return strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://")
+}
+
+// fetchTrusted downloads url to dest once a trusted key's signature
+// verifies. With allowUntrusted, unsigned or untrusted content is used
+// after a warning.
+func (app *CLI) fetchTrusted(ctx context.Context, url, dest string, allowUntrusted bool) error {
+	// Verify a copy, so dest never holds content that failed verification
+	fetched := dest + ".fetched"
+	defer func() {
+		for _, path := range []string{fetched, fetched + domain.TeamSignatureSuffix, fetched + ".minisig"} {
+			_ = os.Remove(path)
+		}
+	}()
+
+	key, err := app.trustService().Fetch(ctx, url, fetched)
+	if err != nil {
+		untrusted := errors.Is(err, domain.ErrUnsigned) || errors.Is(err, domain.ErrUntrusted)
+		if !untrusted || !allowUntrusted {
+			return err
+		}
+
+		console.DefaultOutput.Warningf("Using %s although %v", url, err)
+
+		if err := network.NewHTTPClient(teamFetchTimeout).DownloadFile(ctx, url, fetched); err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", url, err)
+		}
+	} else {
+		console.DefaultOutput.Progressf("Verified %s, signed by %s", url, key.Name)
+	}
+
+	if err := os.Rename(fetched, dest); err != nil {
+		return fmt.Errorf("failed to save %s: %w", url, err)
+	}
+
+	return nil
+}
+
+// remoteCatalog returns the cached copy of the catalog at url, fetching it
+// again once a day. The cached copy is used when fetching fails.
+func (app *CLI) remoteCatalog(ctx context.Context, url string) (string, error) {
+	dir := filepath.Join(xdg.CacheDir(), "catalogs")
+	path := filepath.Join(dir, fmt.Sprintf("%x.toml", sha256.Sum256([]byte(url))))
+
+	info, statErr := os.Stat(path)
+	if statErr == nil && time.Since(info.ModTime()) < remoteCatalogMaxAge {
+		return path, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec // cache directory
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	if err := app.fetchTrusted(ctx, url, path, false); err != nil {
+		if statErr != nil {
+			return "", err
+		}
+
+		console.DefaultOutput.Warningf("Using the catalog fetched %s: %v", info.ModTime().Local().Format(historyTimeLayout), err)
+	}
+
+	return path, nil
+}
+
+// trustExitError maps a trust error to the exit code.
+func trustExitError(err error) error {
+	switch {
+	case errors.Is(err, application.ErrTrustedKeyNotFound):
+		return domain.NewExitError(ExitNotFoundError, err.Error(), err)
+	case errors.Is(err, application.ErrTrustedKeyExists), errors.Is(err, domain.ErrInvalidTrustedKey):
+		return domain.NewExitError(ExitUsageError, err.Error(), err)
+	case errors.Is(err, domain.ErrUnsigned), errors.Is(err, domain.ErrUntrusted):
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	default:
+		return domain.NewExitError(ExitNetworkError, err.Error(), err)
+	}
+}
@@ -0,0 +1,327 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	cliAdapter "github.com/janderssonse/karei/internal/adapters/cli"
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/adapters/system"
+	"github.com/janderssonse/karei/internal/adapters/ubuntu"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// useResult is the outcome of karei use, for --json.
+type useResult struct {
+	Project      string                     `json:"project"`
+	Requirements []domain.RequirementStatus `json:"requirements"`
+}
+
+// createUseCommand creates the use command for project tool requirements.
+func (app *CLI) createUseCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "use",
+		Usage: "Install the tools the current project requires",
+		Description: `Reads ` + config.ProjectFile + ` in the current directory or the nearest one
+above it, and installs the tools it requires that are missing:
+
+  [tools]
+  go = ">=1.22"
+  node = "20"
+  shellcheck = ""
+
+Tools with a version, and tools outside the catalog, are set with mise for the
+project directory, so they are active only inside it. Other catalog apps are
+installed as karei install does. The changes are listed and confirmed first,
+as the project file decides what runs; --yes skips the prompt.
+
+With --check nothing is installed: unmet requirements are reported and the
+exit status tells whether there are any. Outside a project it says nothing.
+--hook adds a hook to your shell configuration that runs the check whenever
+you enter a directory.
+
+Examples:
+  karei use            # Install what the project needs
+  karei use --check    # Report unmet requirements
+  karei use --hook     # Warn when entering a project with unmet requirements`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "check",
+				Usage: "only report unmet requirements",
+			},
+			&cli.BoolFlag{
+				Name:  "hook",
+				Usage: "add the hook checking projects on entering them to your shell configuration",
+			},
+		},
+		Action: app.runUse,
+	}
+}
+
+// createActivateCommand creates the command printing the shell hook of karei use.
+func (app *CLI) createActivateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "activate",
+		Usage:     "Print the shell hook warning about unmet project requirements",
+		ArgsUsage: "<bash|zsh|fish>",
+		Description: `Prints the code that checks the requirements of each project you enter,
+for your shell configuration to evaluate. karei use --hook adds it for you:
+
+  eval "$(karei activate bash)"    # ~/.bashrc
+  eval "$(karei activate zsh)"     # ~/.zshrc
+  karei activate fish | source     # ~/.config/fish/config.fish`,
+		Action: app.runActivate,
+	}
+}
+
+// runUse checks the requirements of the project and installs those unmet.
+func (app *CLI) runUse(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Bool("hook") {
+		return app.addProjectHook()
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, "failed to read the current directory", err)
+	}
+
+	path, found := config.FindProject(cwd)
+	if !found {
+		if cmd.Bool("check") {
+			return nil
+		}
+
+		return domain.NewExitError(ExitNotFoundError,
+			fmt.Sprintf("no %s in %s or above it", config.ProjectFile, cwd), nil)
+	}
+
+	project, err := config.LoadProject(path)
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, err.Error(), err)
+	}
+
+	requirements, err := domain.ParseToolRequirements(project.Tools)
+	if err != nil {
+		return domain.NewExitError(ExitConfigError, fmt.Sprintf("%s: %v", path, err), err)
+	}
+
+	ctx, cancel := app.applyTimeout(ctx)
+	defer cancel()
+
+	dir := filepath.Dir(path)
+	projects := app.projectService()
+	statuses := projects.Check(ctx, dir, requirements)
+
+	if cmd.Bool("check") {
+		return app.reportProjectCheck(path, statuses)
+	}
+
+	if unmet := domain.UnmetRequirements(statuses); len(unmet) > 0 {
+		err := app.confirmProjectTools(path, dir, requirements, statuses)
+		if errors.Is(err, errSelectionCancelled) {
+			return cliAdapter.OutputFromContext(app.json, app.quiet).Info("Nothing installed.")
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := app.useProjectTools(ctx, projects, dir, requirements, statuses); err != nil {
+			return err
+		}
+
+		statuses = projects.Check(ctx, dir, requirements)
+	}
+
+	return app.showProjectRequirements(path, statuses)
+}
+
+// confirmProjectTools shows what installing the unmet requirements changes
+// and asks first, as the project file decides what runs. Without a terminal,
+// --yes is required.
+func (app *CLI) confirmProjectTools(path, dir string, requirements []domain.ToolRequirement, statuses []domain.RequirementStatus) error {
+	if !console.AutoYes && !console.DefaultOutput.IsTTY(os.Stdin.Fd()) {
+		return domain.NewExitError(ExitUsageError,
+			"refusing to install the tools "+path+" requires without confirmation, pass --yes to skip the prompt", nil)
+	}
+
+	if !app.json {
+		output := cliAdapter.OutputFromContext(app.json, app.quiet)
+		_ = output.Info(path + " requires:")
+
+		for _, change := range projectPlan(dir, requirements, statuses) {
+			_ = output.Info("  - " + change)
+		}
+	}
+
+	if !console.AskProjectTools(path) {
+		return errSelectionCancelled
+	}
+
+	return nil
+}
+
+// projectPlan describes the change installing each unmet requirement makes.
+func projectPlan(dir string, requirements []domain.ToolRequirement, statuses []domain.RequirementStatus) []string {
+	var plan []string
+
+	for i, status := range statuses {
+		switch {
+		case status.Met:
+			continue
+		case status.Provider == domain.ProvidedByKarei:
+			plan = append(plan, status.Tool+", installed machine-wide as karei install does, which may use sudo")
+		default:
+			plan = append(plan, "mise use "+requirements[i].MiseSpec()+", active in "+dir)
+		}
+	}
+
+	return plan
+}
+
+// useProjectTools installs the unmet requirements.
+func (app *CLI) useProjectTools(ctx context.Context, projects *application.ProjectService, dir string,
+	requirements []domain.ToolRequirement, statuses []domain.RequirementStatus) error {
+	catalogApps, err := projects.Use(ctx, dir, requirements, statuses)
+
+	switch {
+	case errors.Is(err, application.ErrMiseRequired):
+		return domain.NewExitError(ExitDependencyError, err.Error(), err)
+	case err != nil:
+		return domain.NewExitError(ExitGeneralError, err.Error(), err)
+	}
+
+	return app.applyApps(ctx, catalogApps, nil)
+}
+
+// reportProjectCheck warns about unmet requirements, failing when there are.
+func (app *CLI) reportProjectCheck(path string, statuses []domain.RequirementStatus) error {
+	unmet := domain.UnmetRequirements(statuses)
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+
+	if app.json {
+		if err := output.Success("", useResult{Project: path, Requirements: statuses}); err != nil {
+			return err
+		}
+	}
+
+	if len(unmet) == 0 {
+		return nil
+	}
+
+	// On stdout, as the shell hook discards stderr to stay quiet when karei is busy
+	if !app.json {
+		needs := make([]string, 0, len(unmet))
+		for _, status := range unmet {
+			needs = append(needs, requirementText(status)+" ("+status.Reason+")")
+		}
+
+		_ = output.Info(fmt.Sprintf("⚠ %s needs %s; run: karei use", path, strings.Join(needs, ", ")))
+	}
+
+	return domain.NewExitError(ExitNotFoundError, fmt.Sprintf("%d unmet project requirements", len(unmet)), nil)
+}
+
+// showProjectRequirements lists each requirement with whether it is met.
+func (app *CLI) showProjectRequirements(path string, statuses []domain.RequirementStatus) error {
+	output := cliAdapter.OutputFromContext(app.json, app.quiet)
+	if app.json {
+		if err := output.Success("", useResult{Project: path, Requirements: statuses}); err != nil {
+			return err
+		}
+	} else {
+		_ = output.Info("Project " + path)
+
+		for _, status := range statuses {
+			line := "  ✓ " + requirementText(status)
+			if status.Installed != "" {
+				line += " " + status.Installed
+			}
+
+			if !status.Met {
+				line = "  ✗ " + requirementText(status) + " (" + status.Reason + ")"
+			}
+
+			_ = output.Info(line + " via " + status.Provider)
+		}
+	}
+
+	if unmet := domain.UnmetRequirements(statuses); len(unmet) > 0 {
+		return domain.NewExitError(ExitGeneralError, fmt.Sprintf("%d project requirements still unmet", len(unmet)), nil)
+	}
+
+	return nil
+}
+
+// runActivate prints the project hook of a shell.
+func (app *CLI) runActivate(_ context.Context, cmd *cli.Command) error {
+	shell, known := domain.ShellFromPath(cmd.Args().First())
+	if !known {
+		return domain.NewExitError(ExitUsageError, "specify the shell: bash, zsh or fish", nil)
+	}
+
+	fmt.Print(shell.ProjectHook())
+
+	return nil
+}
+
+// addProjectHook adds the activate line to the login shell's configuration.
+func (app *CLI) addProjectHook() error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, "failed to find the home directory", err)
+	}
+
+	shell, known := system.LoginShell()
+	if !known {
+		return domain.NewExitError(ExitUsageError,
+			`unknown login shell, add the hook by hand, such as: eval "$(karei activate bash)"`, nil)
+	}
+
+	setup := domain.ShellSetup{Activate: []string{"karei"}}
+	shellConfig := application.NewShellConfig(platform.NewFileManager(false), shell,
+		shell.ConfigFile(home, config.GetXDGConfigHome()), home)
+
+	missing := shellConfig.Missing(setup)
+	if len(missing) == 0 {
+		return cliAdapter.OutputFromContext(app.json, app.quiet).Success(
+			"The project hook is in "+shellConfig.Path()+" already", nil)
+	}
+
+	app.addShellLines(shellConfig, setup, missing)
+	console.DefaultOutput.Result(shell.ReloadHint())
+
+	return nil
+}
+
+// projectService returns the service checking project requirements.
+func (app *CLI) projectService() *application.ProjectService {
+	commandRunner := platform.NewCommandRunner(app.verbose, false)
+	packageInstaller := ubuntu.NewPackageInstaller(commandRunner, platform.NewFileManager(app.verbose), app.verbose, false)
+
+	packages := application.NewPackageManager(packageInstaller, nil, false)
+	packages.SetMethodPreference(app.preference)
+
+	return application.NewProjectService(commandRunner, packages)
+}
+
+// requirementText shows a tool with the versions it must be in.
+func requirementText(status domain.RequirementStatus) string {
+	if status.Required == "" {
+		return status.Tool
+	}
+
+	return status.Tool + " " + status.Required
+}
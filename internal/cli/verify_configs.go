@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/janderssonse/karei/internal/adapters/console"
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// verifyConfigsName is the verification of configuration files karei wrote.
+const verifyConfigsName = "configs"
+
+// verifyConfigs reports configuration files changed or removed since karei
+// last wrote them, resolving the drift when resolution is set.
+func (app *CLI) verifyConfigs(_ context.Context, resolution domain.ConfigResolution) error {
+	console.DefaultOutput.Progressf("Verifying configuration files...")
+
+	switch resolution {
+	case "", domain.ResolveMerge, domain.ResolveOverwrite, domain.ResolveKeep:
+	default:
+		return domain.NewExitError(ExitUsageError, fmt.Sprintf("unknown resolution %q, use merge, overwrite or keep", resolution), nil)
+	}
+
+	configs := app.configFiles()
+
+	drifts, err := configs.Check()
+	if err != nil {
+		return domain.NewExitError(ExitSystemError, "failed to check configuration files", err)
+	}
+
+	if len(drifts) == 0 {
+		if console.DefaultOutput.Plain {
+			console.DefaultOutput.PlainStatus("configs", statusPassed)
+		} else {
+			console.DefaultOutput.Result("✓ Configuration files are as karei last wrote them")
+		}
+
+		return nil
+	}
+
+	unresolved := 0
+
+	for _, drift := range drifts {
+		if resolution == "" {
+			app.reportConfigDrift(drift)
+
+			unresolved++
+
+			continue
+		}
+
+		resolved, err := configs.Resolve(drift.Config.Path, resolution)
+
+		switch {
+		case err != nil:
+			console.DefaultOutput.Result(fmt.Sprintf("✗ %s: %v", drift.Config.Path, err))
+
+			unresolved++
+		case resolved.MergeFile != "":
+			console.DefaultOutput.Result(fmt.Sprintf("✗ %s: %d conflicts, finish the merge in %s", drift.Config.Path, resolved.Conflicts, resolved.MergeFile))
+
+			unresolved++
+		case resolved.Saved != "":
+			console.DefaultOutput.Result(fmt.Sprintf("✓ %s: restored, your version is in %s", drift.Config.Path, resolved.Saved))
+		case resolution == domain.ResolveKeep:
+			console.DefaultOutput.Result(fmt.Sprintf("✓ %s: kept your version", drift.Config.Path))
+		default:
+			console.DefaultOutput.Result(fmt.Sprintf("✓ %s: merged", drift.Config.Path))
+		}
+	}
+
+	if unresolved == 0 {
+		return nil
+	}
+
+	if resolution == "" && !console.DefaultOutput.Plain {
+		console.DefaultOutput.Result("Resolve with: karei verify configs --resolve merge|overwrite|keep")
+	}
+
+	return domain.NewExitError(ExitGeneralError, fmt.Sprintf("%d configuration files differ from the last apply", unresolved), nil)
+}
+
+// reportConfigDrift reports one changed or missing configuration file.
+func (app *CLI) reportConfigDrift(drift domain.ConfigDrift) {
+	if console.DefaultOutput.Plain {
+		console.DefaultOutput.PlainKeyValue("config-"+string(drift.State), drift.Config.Path)
+
+		return
+	}
+
+	console.DefaultOutput.Result(fmt.Sprintf("✗ %s: %s since karei wrote it on %s", drift.Config.Path, drift.State, drift.Config.Applied.Format("2006-01-02 15:04")))
+}
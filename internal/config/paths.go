@@ -7,12 +7,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/janderssonse/karei/internal/xdg"
 )
 
 // GetKareiPath returns the Karei installation path
 // Consolidates logic repeated 8+ times across files.
 func GetKareiPath() string {
-	return GetKareiPathWithEnv(os.Getenv("KAREI_PATH"))
+	return GetKareiPathWithEnv(os.Getenv(xdg.EnvKareiPath))
 }
 
 // GetKareiPathWithEnv returns the Karei path with custom environment override for testing.
@@ -21,48 +23,37 @@ func GetKareiPathWithEnv(kareiPath string) string {
 		return kareiPath
 	}
 
-	if home, err := os.UserHomeDir(); err == nil {
-		return filepath.Join(home, ".local", "share", "karei")
-	}
-
-	return ""
+	return xdg.DataDir()
 }
 
-// GetXDGConfigHome returns XDG config directory
-// Consolidates logic repeated 6+ times across files.
+// GetXDGConfigHome returns XDG config directory.
 func GetXDGConfigHome() string {
-	return GetXDGConfigHomeWithEnv(os.Getenv("XDG_CONFIG_HOME"))
+	return xdg.ConfigHome()
 }
 
 // GetXDGConfigHomeWithEnv returns XDG config directory with custom environment override for testing.
 func GetXDGConfigHomeWithEnv(xdgConfigHome string) string {
-	if xdgConfigHome != "" {
-		return xdgConfigHome
-	}
-
-	if home, err := os.UserHomeDir(); err == nil {
-		return filepath.Join(home, ".config")
-	}
-
-	return ""
+	return xdg.BaseDir(xdgConfigHome, ".config")
 }
 
 // GetXDGDataHome returns XDG data directory.
 func GetXDGDataHome() string {
-	return GetXDGDataHomeWithEnv(os.Getenv("XDG_DATA_HOME"))
+	return xdg.DataHome()
 }
 
 // GetXDGDataHomeWithEnv returns XDG data directory with custom environment override for testing.
 func GetXDGDataHomeWithEnv(xdgDataHome string) string {
-	if xdgDataHome != "" {
-		return xdgDataHome
-	}
+	return xdg.BaseDir(xdgDataHome, ".local", "share")
+}
 
-	if home, err := os.UserHomeDir(); err == nil {
-		return filepath.Join(home, ".local", "share")
-	}
+// GetXDGCacheHome returns XDG cache directory.
+func GetXDGCacheHome() string {
+	return xdg.CacheHome()
+}
 
-	return ""
+// GetXDGCacheHomeWithEnv returns XDG cache directory with custom environment override for testing.
+func GetXDGCacheHomeWithEnv(xdgCacheHome string) string {
+	return xdg.BaseDir(xdgCacheHome, ".cache")
 }
 
 // GetUserBinDir returns user binary directory.
@@ -0,0 +1,378 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/janderssonse/karei/internal/stringutil"
+)
+
+// ErrInvalidAge indicates a retention age that is neither a duration nor a number of days.
+var ErrInvalidAge = errors.New("invalid age")
+
+// Preferences holds user settings from config.toml.
+//
+//	[install]
+//	prefer_methods = ["flatpak", "mise"]
+//	apt_lock_timeout = "5m"
+//	apt_frontend = "nala"      # auto (default), apt, nala or apt-fast
+//
+//	[flatpak]
+//	scope = "system"
+//
+//	[flatpak.apps]
+//	gimp = "user"
+//
+//	[github.channels]
+//	lazygit = "prerelease"
+//
+//	[policy]
+//	deny = ["proprietary", "snap"]
+//
+//	[privacy]
+//	usage_stats = true
+//
+//	[notify]
+//	webhook = "https://hooks.slack.com/services/..."
+//
+//	[proxy]
+//	http = "http://proxy.example.com:3128"
+//	no_proxy = ["localhost", ".example.com"]
+//
+//	[download]
+//	bandwidth_limit = "2MB"    # per second
+//	off_peak = "01:00-06:00"
+//	metered_threshold = "500MB" # confirm larger downloads on a metered connection
+//
+//	[clean]
+//	cache_max_age = "30d"
+//	cache_max_size = "500MB"
+//	log_max_age = "90d"
+//
+//	[categories]
+//	collapsed = true
+//	order = ["development", "browsers"]
+//
+//	[columns]
+//	show = ["version", "last-updated"]
+//	name_width = 28
+//
+//	[theme]
+//	rotate = ["tokyo-night", "nord", "kanagawa"]
+//	rotate_every = "1d"
+//
+//	[tui]
+//	theme = "nord"
+//
+//	[tui.colors]
+//	primary = "#ff9e64"
+//
+//	[[searches]]
+//	name = "not-installed flatpaks"
+//	method = "flatpak"
+//	status = "Not Installed"
+type Preferences struct {
+	Install    InstallPreferences  `toml:"install"`
+	Flatpak    FlatpakPreferences  `toml:"flatpak"`
+	GitHub     GitHubPreferences   `toml:"github"`
+	Policy     PolicyPreferences   `toml:"policy"`
+	Privacy    PrivacyPreferences  `toml:"privacy"`
+	Notify     NotifyPreferences   `toml:"notify"`
+	Proxy      ProxyPreferences    `toml:"proxy"`
+	Download   DownloadPreferences `toml:"download"`
+	Clean      CleanPreferences    `toml:"clean"`
+	Categories CategoryPreferences `toml:"categories"`
+	Columns    ColumnPreferences   `toml:"columns"`
+	Theme      ThemePreferences    `toml:"theme"`
+	TUI        TUIPreferences      `toml:"tui"`
+	Searches   []SavedSearch       `toml:"searches"`
+}
+
+// InstallPreferences configures how applications are installed.
+type InstallPreferences struct {
+	PreferMethods  []string `toml:"prefer_methods"`
+	APTLockTimeout string   `toml:"apt_lock_timeout"`
+	APTFrontend    string   `toml:"apt_frontend"`
+}
+
+// FlatpakPreferences selects `flatpak --user` or system installations,
+// globally and per catalog app.
+type FlatpakPreferences struct {
+	Scope string            `toml:"scope"`
+	Apps  map[string]string `toml:"apps"`
+}
+
+// GitHubPreferences subscribes apps installed from GitHub releases to the
+// "stable" or "prerelease" channel, keyed by catalog app. Apps not listed
+// follow stable releases.
+type GitHubPreferences struct {
+	Channels map[string]string `toml:"channels"`
+}
+
+// PolicyPreferences restricts what may be installed, e.g. on work machines.
+// Deny lists licenses ("proprietary"), methods ("snap") and origins ("vendor").
+type PolicyPreferences struct {
+	Deny []string `toml:"deny"`
+}
+
+// PrivacyPreferences opts in to recording which commands are used. The
+// statistics never leave the machine unless the user attaches a report.
+type PrivacyPreferences struct {
+	UsageStats bool `toml:"usage_stats"`
+}
+
+// NotifyPreferences sends progress of installs and removals elsewhere, such
+// as to a chat room watching a long provisioning run.
+type NotifyPreferences struct {
+	Webhook string `toml:"webhook"` // URL receiving each operation event as JSON, or "secret:<name>"
+}
+
+// ProxyPreferences sets the proxy for karei's requests and the commands it
+// runs, overriding the one detected from the environment and GNOME settings.
+// HTTPS requests use the HTTP proxy unless https is set.
+type ProxyPreferences struct {
+	HTTP    string   `toml:"http"`
+	HTTPS   string   `toml:"https"`
+	NoProxy []string `toml:"no_proxy"` // Hosts and domains reached directly
+}
+
+// DownloadPreferences spare a metered or shared connection: a cap on the
+// bytes per second downloads use, in units such as "2MB", the time of day
+// installs wait for before downloading, such as "01:00-06:00", and how much
+// may be downloaded on a metered connection without asking.
+type DownloadPreferences struct {
+	BandwidthLimit   string `toml:"bandwidth_limit"`
+	OffPeak          string `toml:"off_peak"`
+	MeteredThreshold string `toml:"metered_threshold"`
+}
+
+// CleanPreferences sets the retention `karei clean` applies. Ages are Go
+// durations or whole days such as "30d"; sizes use units such as "500MB".
+type CleanPreferences struct {
+	CacheMaxAge  string `toml:"cache_max_age"`
+	CacheMaxSize string `toml:"cache_max_size"`
+	LogMaxAge    string `toml:"log_max_age"`
+}
+
+// CategoryPreferences configures how the apps screen lays out categories.
+type CategoryPreferences struct {
+	Collapsed bool     `toml:"collapsed"` // Start with every category collapsed
+	Order     []string `toml:"order"`     // Category names shown first, in this order
+}
+
+// ColumnPreferences lays out the app list of the apps screen. Show adds
+// optional columns in the given order: "version", "size", "group" and
+// "last-updated". Widths of 0 keep the defaults; the description then
+// takes the space the terminal leaves.
+type ColumnPreferences struct {
+	Show             []string `toml:"show"`
+	NameWidth        int      `toml:"name_width"`
+	DescriptionWidth int      `toml:"description_width"`
+}
+
+// ThemePreferences holds the rotation `karei theme rotate` schedules: the
+// themes applied in turn, how often, and which comes next.
+type ThemePreferences struct {
+	Rotate      []string `toml:"rotate"`
+	RotateEvery string   `toml:"rotate_every"` // Duration or whole days such as "1d"
+	RotateNext  int      `toml:"rotate_next"`  // Index in Rotate of the theme applied next
+}
+
+// NextRotationTheme returns the theme the rotation applies next and moves
+// the rotation on to the one after it, or "" when no rotation is set.
+func (p *Preferences) NextRotationTheme() string {
+	if len(p.Theme.Rotate) == 0 {
+		return ""
+	}
+
+	next := p.Theme.RotateNext % len(p.Theme.Rotate)
+	if next < 0 {
+		next = 0
+	}
+
+	p.Theme.RotateNext = (next + 1) % len(p.Theme.Rotate)
+
+	return p.Theme.Rotate[next]
+}
+
+// TUIPreferences sets the colors of the interactive interface, which
+// otherwise follows the applied karei theme. Colors are hex values named
+// primary, secondary, success, warning, error, info, muted, background and
+// foreground.
+type TUIPreferences struct {
+	Theme  string            `toml:"theme"`  // Theme whose palette is used instead of the applied one
+	Colors map[string]string `toml:"colors"` // Individual colors replacing the theme's
+}
+
+// SavedSearch is a named search query and filter combination for the apps screen.
+type SavedSearch struct {
+	Name   string `toml:"name"`
+	Query  string `toml:"query,omitempty"`
+	Method string `toml:"method,omitempty"` // Package type filter, e.g. "flatpak"
+	Status string `toml:"status,omitempty"` // "Installed" or "Not Installed"
+	Sort   string `toml:"sort,omitempty"`
+}
+
+// LoadPreferences reads preferences from path. A missing file yields defaults.
+func LoadPreferences(path string) (*Preferences, error) {
+	prefs := &Preferences{}
+
+	data, err := os.ReadFile(path) //nolint:gosec // path is the user's own config file
+	if errors.Is(err, os.ErrNotExist) {
+		return prefs, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := toml.Unmarshal(data, prefs); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return prefs, nil
+}
+
+// SavePreferences writes prefs to path, creating its directory if needed.
+func SavePreferences(path string, prefs *Preferences) error {
+	data, err := toml.Marshal(prefs)
+	if err != nil {
+		return fmt.Errorf("failed to encode preferences: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// PreferMethods returns the preferred install methods as a comma-separated list.
+func (p *Preferences) PreferMethods() string {
+	return strings.Join(p.Install.PreferMethods, ",")
+}
+
+// APTLockTimeout returns how long to wait for the dpkg lock, or 0 for the default.
+func (p *Preferences) APTLockTimeout() (time.Duration, error) {
+	if p.Install.APTLockTimeout == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(p.Install.APTLockTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid apt_lock_timeout: %w", err)
+	}
+
+	return timeout, nil
+}
+
+// BandwidthLimit returns the bytes per second downloads may use, or 0 for
+// no limit.
+func (p *Preferences) BandwidthLimit() (int64, error) {
+	if p.Download.BandwidthLimit == "" {
+		return 0, nil
+	}
+
+	limit, err := stringutil.ParseBytes(p.Download.BandwidthLimit)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bandwidth_limit: %w", err)
+	}
+
+	return limit, nil
+}
+
+// DefaultMeteredThreshold is how many bytes may be downloaded on a metered
+// connection without asking.
+const DefaultMeteredThreshold = 200_000_000
+
+// MeteredThreshold returns how many bytes may be downloaded on a metered
+// connection before the install asks to go ahead.
+func (p *Preferences) MeteredThreshold() (int64, error) {
+	if p.Download.MeteredThreshold == "" {
+		return DefaultMeteredThreshold, nil
+	}
+
+	threshold, err := stringutil.ParseBytes(p.Download.MeteredThreshold)
+	if err != nil {
+		return 0, fmt.Errorf("invalid metered_threshold: %w", err)
+	}
+
+	return threshold, nil
+}
+
+// Default retention of `karei clean`.
+const (
+	DefaultCacheMaxAge  = 30 * 24 * time.Hour
+	DefaultCacheMaxSize = 500_000_000
+	DefaultLogMaxAge    = 90 * 24 * time.Hour
+)
+
+// CacheMaxAge returns how long cached files are kept.
+func (p *Preferences) CacheMaxAge() (time.Duration, error) {
+	return parseAge("cache_max_age", p.Clean.CacheMaxAge, DefaultCacheMaxAge)
+}
+
+// CacheMaxSize returns how many bytes the cache may hold.
+func (p *Preferences) CacheMaxSize() (int64, error) {
+	if p.Clean.CacheMaxSize == "" {
+		return DefaultCacheMaxSize, nil
+	}
+
+	size, err := stringutil.ParseBytes(p.Clean.CacheMaxSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cache_max_size: %w", err)
+	}
+
+	return size, nil
+}
+
+// LogMaxAge returns how long logs and journals are kept.
+func (p *Preferences) LogMaxAge() (time.Duration, error) {
+	return parseAge("log_max_age", p.Clean.LogMaxAge, DefaultLogMaxAge)
+}
+
+// ParseAge reads an age such as "30d" or "12h".
+func ParseAge(value string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(value, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidAge, value)
+		}
+
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAge, value)
+	}
+
+	return age, nil
+}
+
+func parseAge(name, value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	age, err := ParseAge(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return age, nil
+}
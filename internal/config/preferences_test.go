@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPreferences(t *testing.T) {
+	dir := t.TempDir()
+
+	prefs, err := LoadPreferences(filepath.Join(dir, "missing.toml"))
+	require.NoError(t, err)
+	assert.Empty(t, prefs.PreferMethods())
+
+	path := filepath.Join(dir, "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte("[install]\nprefer_methods = [\"flatpak\", \"mise\"]\n"), 0o600))
+
+	prefs, err = LoadPreferences(path)
+	require.NoError(t, err)
+	assert.Equal(t, "flatpak,mise", prefs.PreferMethods())
+
+	timeout, err := prefs.APTLockTimeout()
+	require.NoError(t, err)
+	assert.Zero(t, timeout)
+
+	require.NoError(t, os.WriteFile(path, []byte("[install]\napt_lock_timeout = \"5m\"\napt_frontend = \"nala\"\n"), 0o600))
+
+	prefs, err = LoadPreferences(path)
+	require.NoError(t, err)
+	assert.Equal(t, "nala", prefs.Install.APTFrontend)
+
+	timeout, err = prefs.APTLockTimeout()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, timeout)
+
+	require.NoError(t, os.WriteFile(path, []byte("[flatpak]\nscope = \"system\"\n\n[flatpak.apps]\ngimp = \"user\"\n"), 0o600))
+
+	prefs, err = LoadPreferences(path)
+	require.NoError(t, err)
+	assert.Equal(t, "system", prefs.Flatpak.Scope)
+	assert.Equal(t, map[string]string{"gimp": "user"}, prefs.Flatpak.Apps)
+
+	require.NoError(t, os.WriteFile(path, []byte("[github.channels]\nlazygit = \"prerelease\"\n"), 0o600))
+
+	prefs, err = LoadPreferences(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"lazygit": "prerelease"}, prefs.GitHub.Channels)
+
+	require.NoError(t, os.WriteFile(path, []byte("[policy]\ndeny = [\"proprietary\", \"snap\"]\n"), 0o600))
+
+	prefs, err = LoadPreferences(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"proprietary", "snap"}, prefs.Policy.Deny)
+	assert.False(t, prefs.Privacy.UsageStats)
+
+	require.NoError(t, os.WriteFile(path, []byte("[privacy]\nusage_stats = true\n"), 0o600))
+
+	prefs, err = LoadPreferences(path)
+	require.NoError(t, err)
+	assert.True(t, prefs.Privacy.UsageStats)
+
+	require.NoError(t, os.WriteFile(path, []byte("[notify]\nwebhook = \"https://chat.example.com/hook\"\n"), 0o600))
+
+	prefs, err = LoadPreferences(path)
+	require.NoError(t, err)
+	assert.Equal(t, "https://chat.example.com/hook", prefs.Notify.Webhook)
+
+	maxAge, err := prefs.CacheMaxAge()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultCacheMaxAge, maxAge)
+
+	require.NoError(t, os.WriteFile(path, []byte("[clean]\ncache_max_age = \"7d\"\ncache_max_size = \"1GB\"\nlog_max_age = \"12h\"\n"), 0o600))
+
+	prefs, err = LoadPreferences(path)
+	require.NoError(t, err)
+
+	maxAge, err = prefs.CacheMaxAge()
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, maxAge)
+
+	maxSize, err := prefs.CacheMaxSize()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_000_000_000), maxSize)
+
+	maxAge, err = prefs.LogMaxAge()
+	require.NoError(t, err)
+	assert.Equal(t, 12*time.Hour, maxAge)
+
+	prefs.Clean.LogMaxAge = "soon"
+	_, err = prefs.LogMaxAge()
+	require.ErrorIs(t, err, ErrInvalidAge)
+
+	require.NoError(t, os.WriteFile(path, []byte("[proxy]\nhttp = \"http://proxy:3128\"\nno_proxy = [\"localhost\"]\n"), 0o600))
+
+	prefs, err = LoadPreferences(path)
+	require.NoError(t, err)
+	assert.Equal(t, ProxyPreferences{HTTP: "http://proxy:3128", NoProxy: []string{"localhost"}}, prefs.Proxy)
+
+	require.NoError(t, os.WriteFile(path, []byte("[columns]\nshow = [\"version\"]\nname_width = 28\n"), 0o600))
+
+	prefs, err = LoadPreferences(path)
+	require.NoError(t, err)
+	assert.Equal(t, ColumnPreferences{Show: []string{"version"}, NameWidth: 28}, prefs.Columns)
+
+	require.NoError(t, os.WriteFile(path, []byte("[tui]\ntheme = \"nord\"\n\n[tui.colors]\nprimary = \"#ff9e64\"\n"), 0o600))
+
+	prefs, err = LoadPreferences(path)
+	require.NoError(t, err)
+	assert.Equal(t, TUIPreferences{Theme: "nord", Colors: map[string]string{"primary": "#ff9e64"}}, prefs.TUI)
+
+	threshold, err := prefs.MeteredThreshold()
+	require.NoError(t, err)
+	assert.Equal(t, int64(DefaultMeteredThreshold), threshold)
+
+	require.NoError(t, os.WriteFile(path, []byte("[download]\nbandwidth_limit = \"2MB\"\nmetered_threshold = \"1GB\"\n"), 0o600))
+
+	prefs, err = LoadPreferences(path)
+	require.NoError(t, err)
+
+	limit, err := prefs.BandwidthLimit()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2_000_000), limit)
+
+	threshold, err = prefs.MeteredThreshold()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_000_000_000), threshold)
+
+	require.NoError(t, os.WriteFile(path, []byte("[install\n"), 0o600))
+
+	_, err = LoadPreferences(path)
+	require.Error(t, err)
+}
+
+func TestSavePreferences(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "karei", "config.toml")
+
+	prefs := &Preferences{}
+	prefs.Install.PreferMethods = []string{"flatpak"}
+	prefs.Categories.Collapsed = true
+	prefs.Categories.Order = []string{"development", "browsers"}
+	prefs.Searches = []SavedSearch{{Name: "flatpaks", Method: "flatpak", Status: "Not Installed"}}
+
+	require.NoError(t, SavePreferences(path, prefs))
+
+	loaded, err := LoadPreferences(path)
+	require.NoError(t, err)
+	assert.Equal(t, "flatpak", loaded.PreferMethods())
+	assert.True(t, loaded.Categories.Collapsed)
+	assert.Equal(t, []string{"development", "browsers"}, loaded.Categories.Order)
+	assert.Equal(t, prefs.Searches, loaded.Searches)
+}
+
+func TestNextRotationTheme(t *testing.T) {
+	prefs := &Preferences{}
+	assert.Empty(t, prefs.NextRotationTheme())
+
+	prefs.Theme.Rotate = []string{"tokyo-night", "nord", "kanagawa"}
+	prefs.Theme.RotateNext = 1
+
+	assert.Equal(t, "nord", prefs.NextRotationTheme())
+	assert.Equal(t, "kanagawa", prefs.NextRotationTheme())
+	assert.Equal(t, "tokyo-night", prefs.NextRotationTheme())
+	assert.Equal(t, 1, prefs.Theme.RotateNext)
+
+	// A shortened list wraps the saved position
+	prefs.Theme.Rotate = []string{"nord"}
+	prefs.Theme.RotateNext = 5
+	assert.Equal(t, "nord", prefs.NextRotationTheme())
+	assert.Equal(t, 0, prefs.Theme.RotateNext)
+}
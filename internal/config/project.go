@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ProjectFile is the file at the root of a project declaring the tools it needs.
+const ProjectFile = ".karei.toml"
+
+// Project holds the requirements of a .karei.toml, tools by name with the
+// versions accepted, as in catalog version constraints:
+//
+//	[tools]
+//	go = ">=1.22"
+//	node = "20"
+//	shellcheck = ""
+type Project struct {
+	Tools map[string]string `toml:"tools"`
+}
+
+// FindProject returns the project file in dir or the nearest directory
+// above it, reporting false when there is none.
+func FindProject(dir string) (string, bool) {
+	dir = filepath.Clean(dir)
+
+	for {
+		path := filepath.Join(dir, ProjectFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+
+		dir = parent
+	}
+}
+
+// LoadProject reads the project file at path.
+func LoadProject(path string) (*Project, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is a project file the user works in
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	project := &Project{}
+	if err := toml.Unmarshal(data, project); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return project, nil
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindAndLoadProject(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "cmd", "tool")
+	require.NoError(t, os.MkdirAll(nested, 0o750))
+
+	_, found := FindProject(nested)
+	assert.False(t, found)
+
+	path := filepath.Join(root, ProjectFile)
+	require.NoError(t, os.WriteFile(path, []byte("[tools]\ngo = \">=1.22\"\nshellcheck = \"\"\n"), 0o600))
+
+	got, found := FindProject(nested)
+	require.True(t, found)
+	assert.Equal(t, path, got)
+
+	project, err := LoadProject(got)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"go": ">=1.22", "shellcheck": ""}, project.Tools)
+
+	require.NoError(t, os.WriteFile(path, []byte("[tools\n"), 0o600))
+
+	_, err = LoadProject(path)
+	require.Error(t, err)
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoAdvisoryData indicates the vulnerability feed does not cover a package.
+var ErrNoAdvisoryData = errors.New("no advisory data for this package")
+
+// Severities reported by advisories, from least to most severe.
+const (
+	SeverityUnknown    = "unknown"
+	SeverityNegligible = "negligible"
+	SeverityLow        = "low"
+	SeverityMedium     = "medium"
+	SeverityHigh       = "high"
+	SeverityCritical   = "critical"
+)
+
+// severityOrder ranks severities for sorting.
+var severityOrder = []string{ //nolint:gochecknoglobals
+	SeverityUnknown, SeverityNegligible, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical,
+}
+
+// SeverityRank orders severities, higher is more severe. Unrecognised
+// severities rank with SeverityUnknown.
+func SeverityRank(severity string) int {
+	for rank, known := range severityOrder {
+		if known == severity {
+			return rank
+		}
+	}
+
+	return 0
+}
+
+// Vulnerability is a published advisory affecting an installed package.
+type Vulnerability struct {
+	ID           string   `json:"id"`
+	Aliases      []string `json:"aliases,omitempty"` // CVE IDs of the advisory
+	Summary      string   `json:"summary,omitempty"`
+	Severity     string   `json:"severity"`
+	FixedVersion string   `json:"fixed_version,omitempty"` // Empty while no fix is released
+}
+
+// VulnerablePackage is an installed app with the advisories affecting it.
+type VulnerablePackage struct {
+	Key             string          `json:"key"`
+	Version         string          `json:"version"`
+	Severity        string          `json:"severity"` // The most severe advisory
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+// AuditResult is the outcome of checking installed apps against a vulnerability feed.
+type AuditResult struct {
+	Vulnerable []VulnerablePackage `json:"vulnerable"`
+	Checked    int                 `json:"checked"`
+	Unchecked  []string            `json:"unchecked,omitempty"` // Apps the feed doesn't cover
+	Failed     []string            `json:"failed,omitempty"`    // Apps whose lookup failed
+	Timestamp  time.Time           `json:"timestamp"`
+}
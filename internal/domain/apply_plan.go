@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+// ChangeKind is what applying a profile does to one app or setting.
+type ChangeKind string
+
+// Kinds of planned changes.
+const (
+	ChangeInstall ChangeKind = "install" // App not installed yet
+	ChangeUpgrade ChangeKind = "upgrade" // Installed app outside its version constraint
+	ChangeConfig  ChangeKind = "config"  // Setting such as the theme
+)
+
+// PlannedChange is one change applying a profile would make, shown for
+// review before anything is changed.
+type PlannedChange struct {
+	Kind   ChangeKind `json:"kind"`
+	Target string     `json:"target"`         // App key, or the setting changed
+	From   string     `json:"from,omitempty"` // Installed version or current value
+	To     string     `json:"to,omitempty"`   // Version constraint or new value
+}
+
+// Describe returns the change as one line, e.g. "upgrade node 18.2.0 → >=20 <21".
+func (c PlannedChange) Describe() string {
+	line := string(c.Kind) + " " + c.Target
+
+	switch {
+	case c.From != "" && c.To != "":
+		line += " " + c.From + " → " + c.To
+	case c.To != "":
+		line += " " + c.To
+	}
+
+	return line
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownAPTFrontend is returned for an apt_frontend karei doesn't support.
+var ErrUnknownAPTFrontend = errors.New("unknown apt frontend, want auto, apt, nala or apt-fast")
+
+// APTFrontend is the command APT installs go through. nala and apt-fast
+// download packages in parallel and then hand over to dpkg like apt-get.
+type APTFrontend string
+
+// APT frontends. APTFrontendAuto picks nala or apt-fast when installed.
+const (
+	APTFrontendAuto    APTFrontend = "auto"
+	APTFrontendAPT     APTFrontend = "apt"
+	APTFrontendNala    APTFrontend = "nala"
+	APTFrontendAptFast APTFrontend = "apt-fast"
+)
+
+// APTFrontendCandidates are the frontends auto picks from, in order.
+var APTFrontendCandidates = []APTFrontend{APTFrontendNala, APTFrontendAptFast} //nolint:gochecknoglobals
+
+// ParseAPTFrontend parses an apt_frontend value. Empty means auto.
+func ParseAPTFrontend(value string) (APTFrontend, error) {
+	frontend := APTFrontend(strings.TrimSpace(value))
+
+	switch frontend {
+	case "":
+		return APTFrontendAuto, nil
+	case APTFrontendAuto, APTFrontendAPT, APTFrontendNala, APTFrontendAptFast:
+		return frontend, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownAPTFrontend, value)
+	}
+}
+
+// Command returns the command installing packages through the frontend.
+func (f APTFrontend) Command() string {
+	switch f {
+	case APTFrontendNala, APTFrontendAptFast:
+		return string(f)
+	default:
+		return "apt-get"
+	}
+}
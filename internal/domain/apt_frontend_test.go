@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAPTFrontend(t *testing.T) {
+	t.Parallel()
+
+	frontend, err := domain.ParseAPTFrontend("")
+	require.NoError(t, err)
+	assert.Equal(t, domain.APTFrontendAuto, frontend)
+
+	frontend, err = domain.ParseAPTFrontend(" nala ")
+	require.NoError(t, err)
+	assert.Equal(t, "nala", frontend.Command())
+
+	_, err = domain.ParseAPTFrontend("aptitude")
+	require.ErrorIs(t, err, domain.ErrUnknownAPTFrontend)
+
+	assert.Equal(t, "apt-get", domain.APTFrontendAPT.Command())
+	assert.Equal(t, "apt-get", domain.APTFrontendAuto.Command())
+	assert.Equal(t, "apt-fast", domain.APTFrontendAptFast.Command())
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import "slices"
+
+// ArtifactKind tells what karei created for an app outside its package.
+type ArtifactKind string
+
+// Artifacts karei creates while installing and setting up apps.
+const (
+	ArtifactDesktopEntry ArtifactKind = "desktop-entry"
+	ArtifactIcon         ArtifactKind = "icon"
+	ArtifactSymlink      ArtifactKind = "symlink"
+	ArtifactFile         ArtifactKind = "file"
+	ArtifactShellLine    ArtifactKind = "shell-line"
+)
+
+// Artifact is a file, or a line of a shell configuration file, that karei
+// created for an app and removes with it.
+type Artifact struct {
+	Kind     ArtifactKind `json:"kind"`
+	Path     string       `json:"path"`
+	Line     string       `json:"line,omitempty"`   // The line added, for shell lines
+	Checksum string       `json:"sha256,omitempty"` // Content as karei wrote it, for regular files
+}
+
+// IsFile reports whether the artifact is a whole file or directory, rather
+// than a line within one.
+func (a Artifact) IsFile() bool {
+	return a.Kind != ArtifactShellLine
+}
+
+// Same reports whether other is the same artifact, whatever its content.
+func (a Artifact) Same(other Artifact) bool {
+	return a.Kind == other.Kind && a.Path == other.Path && a.Line == other.Line
+}
+
+// AddArtifacts appends artifacts to list, replacing the entries of those
+// already in it so their checksums are current.
+func AddArtifacts(list []Artifact, artifacts ...Artifact) []Artifact {
+	for _, artifact := range artifacts {
+		if i := slices.IndexFunc(list, artifact.Same); i >= 0 {
+			list[i] = artifact
+		} else {
+			list = append(list, artifact)
+		}
+	}
+
+	return list
+}
+
+// Manifest lists everything karei created for one app, like the file list
+// a package database keeps for each package.
+type Manifest struct {
+	App       string     `json:"app"`
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// Contains reports whether the manifest lists artifact.
+func (m *Manifest) Contains(artifact Artifact) bool {
+	return slices.ContainsFunc(m.Artifacts, artifact.Same)
+}
+
+// ArtifactState is how an artifact compares to the manifest.
+type ArtifactState string
+
+// States of a recorded artifact.
+const (
+	ArtifactIntact   ArtifactState = "intact"
+	ArtifactModified ArtifactState = "modified" // Changed since karei wrote it
+	ArtifactMissing  ArtifactState = "missing"
+)
+
+// ArtifactCheck is the state of one artifact of a manifest.
+type ArtifactCheck struct {
+	App      string        `json:"app"`
+	Artifact Artifact      `json:"artifact"`
+	State    ArtifactState `json:"state"`
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrNoGraphicalSession indicates a command needing a desktop was run over
+// SSH or on a text console.
+var ErrNoGraphicalSession = errors.New("no graphical session")
+
+// CustomKeybindingsSchema holds the list of GNOME's custom keyboard shortcuts;
+// each is a relocatable CustomKeybindingSchema at a path of its own.
+const (
+	CustomKeybindingsSchema = "org.gnome.settings-daemon.plugins.media-keys"
+	CustomKeybindingSchema  = CustomKeybindingsSchema + ".custom-keybinding"
+	customKeybindingsPath   = "/org/gnome/settings-daemon/plugins/media-keys/custom-keybindings/"
+)
+
+// Keybinding is a GNOME custom keyboard shortcut running a command.
+type Keybinding struct {
+	ID      string `json:"id"` // Last element of its dconf path, such as "karei-screenshot"
+	Name    string `json:"name"`
+	Command string `json:"command"`
+	Binding string `json:"binding"` // Accelerator, such as "<Super>v"
+}
+
+// Path returns the dconf path of the shortcut's settings.
+func (k Keybinding) Path() string {
+	return customKeybindingsPath + k.ID + "/"
+}
+
+// AutostartEntry is a program started at login, from an XDG autostart
+// desktop entry.
+type AutostartEntry struct {
+	File string `json:"file"` // File name in ~/.config/autostart
+	Name string `json:"name"`
+	Exec string `json:"exec"`
+}
+
+// DesktopEntry returns the content of the entry's desktop file.
+func (e AutostartEntry) DesktopEntry() string {
+	return "[Desktop Entry]\nType=Application\nName=" + e.Name + "\nExec=" + e.Exec +
+		"\nX-GNOME-Autostart-enabled=true\n"
+}
+
+// CaptureProfile is what karei sets up for copying and screenshots in a
+// session type: a clipboard manager with history and a screenshot tool that
+// annotates, started at login and bound to keys.
+type CaptureProfile struct {
+	Session     SessionType      `json:"session"`
+	Apps        []string         `json:"apps"` // Catalog keys to install
+	Keybindings []Keybinding     `json:"keybindings"`
+	Autostart   []AutostartEntry `json:"autostart"`
+
+	// Released are GNOME's own shortcuts moved off the keys the profile
+	// binds, since GNOME's win over custom ones.
+	Released []GSetting `json:"released"`
+	Note     string     `json:"note,omitempty"`
+}
+
+// CaptureProfileFor returns the capture profile of session. CopyQ and
+// Flameshot serve both session types; on Wayland CopyQ runs through
+// XWayland, since GNOME doesn't let Wayland clients watch the clipboard, and
+// Flameshot takes screenshots through the desktop portal.
+func CaptureProfileFor(session SessionType) (CaptureProfile, error) {
+	if !session.IsGraphical() {
+		return CaptureProfile{}, fmt.Errorf("%w (%s), run it from the desktop", ErrNoGraphicalSession, session)
+	}
+
+	profile := CaptureProfile{
+		Session: session,
+		Apps:    []string{"copyq", "flameshot", "xclip"},
+		Keybindings: []Keybinding{
+			{ID: "karei-screenshot", Name: "Screenshot with annotation", Command: "flameshot gui", Binding: "Print"},
+			{ID: "karei-clipboard", Name: "Clipboard history", Command: "copyq toggle", Binding: "<Super>v"},
+		},
+		Autostart: []AutostartEntry{
+			{File: "karei-copyq.desktop", Name: "CopyQ", Exec: "copyq"},
+			{File: "karei-flameshot.desktop", Name: "Flameshot", Exec: "flameshot"},
+		},
+		Released: []GSetting{
+			{"org.gnome.shell.keybindings", "show-screenshot-ui", "['<Shift>Print']"},
+			{"org.gnome.shell.keybindings", "toggle-message-tray", "['<Super>m']"},
+		},
+	}
+
+	if session == SessionWayland {
+		profile.Apps = []string{"copyq", "flameshot", "wl-clipboard"}
+		profile.Autostart[0].Exec = "env QT_QPA_PLATFORM=xcb copyq"
+		profile.Note = "Flameshot asks once for permission to take screenshots"
+	}
+
+	return profile, nil
+}
+
+// AddKeybindingPaths returns the custom keybinding list gsettings printed,
+// such as "['/org/.../custom0/']" or "@as []", with paths added that it
+// lacks, and whether any were.
+func AddKeybindingPaths(list string, paths ...string) (string, bool) {
+	list = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(list), "@as"))
+	list = strings.TrimSuffix(strings.TrimPrefix(list, "["), "]")
+
+	var entries []string
+
+	for entry := range strings.SplitSeq(list, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+
+	added := false
+
+	for _, path := range paths {
+		quoted := "'" + path + "'"
+		if !slices.Contains(entries, quoted) {
+			entries = append(entries, quoted)
+			added = true
+		}
+	}
+
+	return "[" + strings.Join(entries, ", ") + "]", added
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureProfileFor(t *testing.T) {
+	t.Parallel()
+
+	wayland, err := domain.CaptureProfileFor(domain.SessionWayland)
+	require.NoError(t, err)
+	assert.Contains(t, wayland.Apps, "wl-clipboard")
+	assert.Equal(t, "env QT_QPA_PLATFORM=xcb copyq", wayland.Autostart[0].Exec)
+	assert.NotEmpty(t, wayland.Note)
+
+	x11, err := domain.CaptureProfileFor(domain.SessionX11)
+	require.NoError(t, err)
+	assert.Contains(t, x11.Apps, "xclip")
+	assert.Equal(t, "copyq", x11.Autostart[0].Exec)
+	assert.Contains(t, x11.Autostart[0].DesktopEntry(), "\nExec=copyq\n")
+	assert.Equal(t, "/org/gnome/settings-daemon/plugins/media-keys/custom-keybindings/karei-screenshot/",
+		x11.Keybindings[0].Path())
+
+	_, err = domain.CaptureProfileFor(domain.SessionTTY)
+	require.ErrorIs(t, err, domain.ErrNoGraphicalSession)
+}
+
+func TestAddKeybindingPaths(t *testing.T) {
+	t.Parallel()
+
+	list, added := domain.AddKeybindingPaths("@as []", "/a/", "/b/")
+	assert.True(t, added)
+	assert.Equal(t, "['/a/', '/b/']", list)
+
+	list, added = domain.AddKeybindingPaths("['/mine/', '/a/']\n", "/a/", "/b/")
+	assert.True(t, added)
+	assert.Equal(t, "['/mine/', '/a/', '/b/']", list)
+
+	_, added = domain.AddKeybindingPaths(list, "/a/", "/b/")
+	assert.False(t, added)
+}
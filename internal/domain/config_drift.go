@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import "time"
+
+// ManagedConfig is a configuration file karei wrote, as it last wrote it.
+type ManagedConfig struct {
+	Path     string    `json:"path"`
+	Checksum string    `json:"sha256"`             // Content karei wrote
+	Accepted string    `json:"accepted,omitempty"` // Content the user kept when resolving drift
+	Source   string    `json:"source,omitempty"`   // Template the content came from
+	Applied  time.Time `json:"applied"`
+}
+
+// Matches reports whether a file with checksum is as karei wrote it, or
+// as the user chose to keep it.
+func (c ManagedConfig) Matches(checksum string) bool {
+	return checksum == c.Checksum || (c.Accepted != "" && checksum == c.Accepted)
+}
+
+// ConfigDrift is how a managed configuration file differs from the last apply.
+type ConfigDrift struct {
+	Config ManagedConfig `json:"config"`
+	State  ArtifactState `json:"state"`
+}
+
+// ConfigResolution is how drift in a configuration file is resolved.
+type ConfigResolution string
+
+// Resolutions of configuration drift.
+const (
+	// ResolveMerge merges the changes in the file with karei's content, and
+	// keeps the result.
+	ResolveMerge ConfigResolution = "merge"
+	// ResolveOverwrite saves the file aside and writes karei's content again.
+	ResolveOverwrite ConfigResolution = "overwrite"
+	// ResolveKeep leaves the file as the user has it, and remembers that so
+	// applying the same content again doesn't ask.
+	ResolveKeep ConfigResolution = "keep"
+)
+
+// ConfigConflict is a configuration file karei is about to write that holds
+// changes of the user's it can't merge, such as a file karei never wrote.
+type ConfigConflict struct {
+	Path    string   `json:"path"`
+	Managed bool     `json:"managed"` // Karei wrote the file before
+	Diff    []string `json:"diff"`    // Unified diff from the file to karei's content
+}
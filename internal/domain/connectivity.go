@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import "errors"
+
+// ErrOffline indicates work skipped because the network can't be reached.
+var ErrOffline = errors.New("network unavailable")
+
+// Connectivity is the state of the network connection found by a preflight check.
+type Connectivity string
+
+// Connectivity states.
+const (
+	// ConnectivityUnknown means no check was made; work proceeds as if online.
+	ConnectivityUnknown Connectivity = ""
+	ConnectivityOnline  Connectivity = "online"
+	ConnectivityOffline Connectivity = "offline"
+	// ConnectivityCaptivePortal means a login page intercepts web traffic.
+	ConnectivityCaptivePortal Connectivity = "captive-portal"
+)
+
+// Available reports whether network-dependent work should be attempted.
+func (c Connectivity) Available() bool {
+	return c == ConnectivityUnknown || c == ConnectivityOnline
+}
+
+// Reasons installs are skipped instead of attempted, as shown to users.
+const (
+	SkipRequiresRoot    = "requires sudo"
+	SkipBlockedByPolicy = "blocked by policy"
+	SkipOffline         = "offline"
+	SkipCaptivePortal   = "behind a captive portal"
+	SkipInstalled       = "already installed"
+)
+
+// SkipReason returns why network-dependent work is skipped, or "" when it isn't.
+func (c Connectivity) SkipReason() string {
+	switch c {
+	case ConnectivityOffline:
+		return SkipOffline
+	case ConnectivityCaptivePortal:
+		return SkipCaptivePortal
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidVersion indicates a version without a leading number.
+	ErrInvalidVersion = errors.New("invalid version")
+	// ErrInvalidConstraint indicates a malformed version constraint.
+	ErrInvalidConstraint = errors.New("invalid version constraint")
+	// ErrVersionConstraint indicates an installed version outside the constraint of its app.
+	ErrVersionConstraint = errors.New("version does not satisfy constraint")
+)
+
+// Version is a semantic version. Missing minor and patch numbers are zero.
+type Version struct {
+	Major, Minor, Patch int
+	Prerelease          string
+
+	parts int // Number components given, so "=20" can match any 20.x.y
+}
+
+// ParseVersion parses versions such as "1.2.3", "v20" or "0.44.1-rc1". Text
+// before the first digit, such as a "v" or a tag prefix, is skipped, as is
+// build metadata after "+".
+func ParseVersion(value string) (Version, error) {
+	text := strings.TrimSpace(value)
+
+	start := strings.IndexFunc(text, isDigit)
+	if start == -1 {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, value)
+	}
+
+	text, _, _ = strings.Cut(text[start:], "+")
+	numbers, prerelease, _ := strings.Cut(text, "-")
+
+	var version Version
+
+	for i, field := range strings.SplitN(numbers, ".", 3) {
+		// Trailing text such as "1.2.3ubuntu1" ends the number
+		if end := strings.IndexFunc(field, func(r rune) bool { return !isDigit(r) }); end != -1 {
+			field = field[:end]
+		}
+
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			break
+		}
+
+		switch i {
+		case 0:
+			version.Major = n
+		case 1:
+			version.Minor = n
+		case 2:
+			version.Patch = n
+		}
+
+		version.parts = i + 1
+	}
+
+	version.Prerelease = prerelease
+
+	return version, nil
+}
+
+// Compare returns -1, 0 or 1 when v is older, the same or newer than other.
+// A prerelease is older than the release it precedes.
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	default:
+		return strings.Compare(v.Prerelease, other.Prerelease)
+	}
+}
+
+// String renders the version as major.minor.patch[-prerelease].
+func (v Version) String() string {
+	text := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		text += "-" + v.Prerelease
+	}
+
+	return text
+}
+
+// matchesPrefix reports whether v equals other in the components other gives.
+func (v Version) matchesPrefix(other Version) bool {
+	got := []int{v.Major, v.Minor, v.Patch}
+	want := []int{other.Major, other.Minor, other.Patch}
+
+	for i := range other.parts {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+
+	return other.Prerelease == "" || v.Prerelease == other.Prerelease
+}
+
+// constraintClause is one comparison such as ">=20".
+type constraintClause struct {
+	op      string
+	version Version
+	text    string // Version as written, used when pinning
+}
+
+// constraintOps lists the comparison operators, longest first so ">=" isn't read as ">".
+var constraintOps = []string{">=", "<=", "!=", ">", "<", "="} //nolint:gochecknoglobals
+
+// VersionConstraint restricts which versions of an app are acceptable, such
+// as ">=20 <21" or "=0.44.1". All space-separated clauses must hold. A bare
+// version means "=", and "=" with a partial version such as "=20" accepts
+// any 20.x.y. The zero value accepts every version.
+type VersionConstraint struct {
+	clauses []constraintClause
+}
+
+// ParseVersionConstraint parses a constraint. An empty value accepts every version.
+func ParseVersionConstraint(value string) (VersionConstraint, error) {
+	var constraint VersionConstraint
+
+	for _, field := range strings.Fields(value) {
+		clause := constraintClause{op: "="}
+
+		for _, op := range constraintOps {
+			if rest, found := strings.CutPrefix(field, op); found {
+				clause.op, field = op, rest
+
+				break
+			}
+		}
+
+		if number := strings.TrimPrefix(field, "v"); number == "" || !isDigit(rune(number[0])) {
+			return VersionConstraint{}, fmt.Errorf("%w: %q", ErrInvalidConstraint, value)
+		}
+
+		version, err := ParseVersion(field)
+		if err != nil {
+			return VersionConstraint{}, fmt.Errorf("%w: %q", ErrInvalidConstraint, value)
+		}
+
+		clause.version, clause.text = version, field
+		constraint.clauses = append(constraint.clauses, clause)
+	}
+
+	return constraint, nil
+}
+
+// IsZero reports whether the constraint accepts every version.
+func (c VersionConstraint) IsZero() bool {
+	return len(c.clauses) == 0
+}
+
+// Allows reports whether version satisfies every clause.
+func (c VersionConstraint) Allows(version Version) bool {
+	for _, clause := range c.clauses {
+		cmp := version.Compare(clause.version)
+
+		var ok bool
+
+		switch clause.op {
+		case "=":
+			ok = version.matchesPrefix(clause.version)
+		case "!=":
+			ok = !version.matchesPrefix(clause.version)
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Pin returns the version to ask the package manager for when the
+// constraint is a single "=" clause, as version managers such as mise can
+// install exactly that version.
+func (c VersionConstraint) Pin() (string, bool) {
+	if len(c.clauses) != 1 || c.clauses[0].op != "=" {
+		return "", false
+	}
+
+	return strings.TrimPrefix(c.clauses[0].text, "v"), true
+}
+
+// String renders the constraint with clauses separated by spaces.
+func (c VersionConstraint) String() string {
+	fields := make([]string, 0, len(c.clauses))
+	for _, clause := range c.clauses {
+		fields = append(fields, clause.op+clause.text)
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// VersionConstraints holds the constraint per app, keyed by catalog name.
+type VersionConstraints map[string]VersionConstraint
+
+// ParseVersionConstraints validates constraints keyed by catalog name.
+func ParseVersionConstraints(apps map[string]string) (VersionConstraints, error) {
+	constraints := make(VersionConstraints, len(apps))
+
+	for app, value := range apps {
+		constraint, err := ParseVersionConstraint(value)
+		if err != nil {
+			return nil, fmt.Errorf("app %s: %w", app, err)
+		}
+
+		if !constraint.IsZero() {
+			constraints[app] = constraint
+		}
+	}
+
+	return constraints, nil
+}
+
+// Check returns an ErrVersionConstraint error when installed doesn't
+// satisfy the constraint. Versions that can't be parsed are not checked.
+func (c VersionConstraint) Check(app, installed string) error {
+	version, err := ParseVersion(installed)
+	if err != nil || c.Allows(version) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s %s is installed, %q is required", ErrVersionConstraint, app, installed, c.String())
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
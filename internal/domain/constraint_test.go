@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"1.2.3":              "1.2.3",
+		"v20":                "20.0.0",
+		"0.44.1-rc1":         "0.44.1-rc1",
+		"pmd_releases/7.0.0": "7.0.0",
+		"2.34.1ubuntu1":      "2.34.1",
+		"1.0.0+build.5":      "1.0.0",
+	}
+
+	for input, want := range tests {
+		version, err := domain.ParseVersion(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, version.String(), input)
+	}
+
+	_, err := domain.ParseVersion("latest")
+	require.ErrorIs(t, err, domain.ErrInvalidVersion)
+}
+
+func TestVersionConstraintAllows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		version    string
+		allowed    bool
+	}{
+		{">=20 <21", "20.11.1", true},
+		{">=20 <21", "21.0.0", false},
+		{">=20 <21", "19.9.0", false},
+		{"=0.44.1", "0.44.1", true},
+		{"=0.44.1", "0.44.2", false},
+		{"0.44.1", "0.44.1", true},
+		{"=20", "20.3.0", true},
+		{"!=1.2", "1.2.5", false},
+		{">1.0.0", "1.0.0-rc1", false},
+		{"<=2", "2.0.0", true},
+		{"", "0.0.1", true},
+	}
+
+	for _, tt := range tests {
+		constraint, err := domain.ParseVersionConstraint(tt.constraint)
+		require.NoError(t, err, tt.constraint)
+
+		version, err := domain.ParseVersion(tt.version)
+		require.NoError(t, err)
+		assert.Equal(t, tt.allowed, constraint.Allows(version), "%s %s", tt.constraint, tt.version)
+	}
+
+	for _, invalid := range []string{">=", ">=v", "latest", "<=x1"} {
+		_, err := domain.ParseVersionConstraint(invalid)
+		require.ErrorIs(t, err, domain.ErrInvalidConstraint, invalid)
+	}
+}
+
+func TestVersionConstraintPinAndCheck(t *testing.T) {
+	t.Parallel()
+
+	pinned, err := domain.ParseVersionConstraint("=v0.44.1")
+	require.NoError(t, err)
+
+	pin, ok := pinned.Pin()
+	assert.True(t, ok)
+	assert.Equal(t, "0.44.1", pin)
+
+	ranged, err := domain.ParseVersionConstraint(">=20 <21")
+	require.NoError(t, err)
+
+	_, ok = ranged.Pin()
+	assert.False(t, ok)
+	assert.Equal(t, ">=20 <21", ranged.String())
+
+	require.NoError(t, ranged.Check("node", "20.11.1"))
+	require.NoError(t, ranged.Check("node", ""), "unknown versions are not checked")
+	require.ErrorIs(t, ranged.Check("node", "22.1.0"), domain.ErrVersionConstraint)
+
+	constraints, err := domain.ParseVersionConstraints(map[string]string{"node": ">=20 <21", "git": ""})
+	require.NoError(t, err)
+	assert.Len(t, constraints, 1)
+
+	_, err = domain.ParseVersionConstraints(map[string]string{"node": "twenty"})
+	require.ErrorIs(t, err, domain.ErrInvalidConstraint)
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import "strings"
+
+// Deprecation is an old way of invoking a command that karei still accepts,
+// rewritten to the form replacing it. Old and New are command words after
+// "karei"; a <placeholder> in Old matches one argument and is substituted
+// where New has the same placeholder.
+type Deprecation struct {
+	Old     string `json:"old"`
+	New     string `json:"new"`
+	Since   string `json:"since"`   // Version the old form was deprecated in
+	Removal string `json:"removal"` // Version the old form stops being accepted in
+}
+
+// Deprecations returns the old command forms karei still accepts. An entry
+// is removed once its removal version ships.
+func Deprecations() []Deprecation {
+	return []Deprecation{
+		{Old: "theme <name>", New: "theme apply --name <name>", Since: "1.4.0", Removal: "2.0.0"},
+		{Old: "font <name>", New: "font install --name <name>", Since: "1.4.0", Removal: "2.0.0"},
+	}
+}
+
+// Rewrite returns words, the command words and arguments after "karei", in
+// the new form when they start with the old one. Arguments following the
+// old form are kept after the new one.
+func (d Deprecation) Rewrite(words []string) ([]string, bool) {
+	old := strings.Fields(d.Old)
+	if len(words) < len(old) {
+		return nil, false
+	}
+
+	values := make(map[string]string)
+
+	for i, word := range old {
+		switch {
+		case isPlaceholder(word) && !strings.HasPrefix(words[i], "-"):
+			values[word] = words[i]
+		case word != words[i]:
+			return nil, false
+		}
+	}
+
+	rewritten := make([]string, 0, len(words))
+
+	for _, word := range strings.Fields(d.New) {
+		if value, ok := values[word]; ok {
+			word = value
+		}
+
+		rewritten = append(rewritten, word)
+	}
+
+	return append(rewritten, words[len(old):]...), true
+}
+
+func isPlaceholder(word string) bool {
+	return strings.HasPrefix(word, "<") && strings.HasSuffix(word, ">")
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecationRewrite(t *testing.T) {
+	t.Parallel()
+
+	theme := domain.Deprecation{Old: "theme <name>", New: "theme apply --name <name>"}
+
+	tests := []struct {
+		name  string
+		words []string
+		want  []string
+	}{
+		{"old form", []string{"theme", "nord"}, []string{"theme", "apply", "--name", "nord"}},
+		{"trailing flags kept", []string{"theme", "nord", "--force"}, []string{"theme", "apply", "--name", "nord", "--force"}},
+		{"other command", []string{"font", "nord"}, nil},
+		{"missing argument", []string{"theme"}, nil},
+		{"flag is no argument", []string{"theme", "--help"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := theme.Rewrite(tt.words)
+			assert.Equal(t, tt.want != nil, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeprecationsWellFormed(t *testing.T) {
+	t.Parallel()
+
+	for _, deprecation := range domain.Deprecations() {
+		assert.NotEmpty(t, deprecation.Since, deprecation.Old)
+		assert.NotEmpty(t, deprecation.Removal, deprecation.Old)
+
+		// Every placeholder of the new form is filled from the old one
+		for _, word := range strings.Fields(deprecation.New) {
+			if strings.HasPrefix(word, "<") {
+				assert.Contains(t, strings.Fields(deprecation.Old), word, deprecation.New)
+			}
+		}
+	}
+}
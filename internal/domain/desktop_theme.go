@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidTerminalColors indicates a terminal color file without a full palette.
+var ErrInvalidTerminalColors = errors.New("invalid terminal colors")
+
+const (
+	terminalPaletteSize = 16 // The 8 normal and 8 bright ANSI colors
+	hexColorDigits      = 6  // rrggbb
+)
+
+// DesktopAppearance is what a theme sets desktop-wide, in the terms any
+// desktop environment can map to its own settings.
+type DesktopAppearance struct {
+	Name   string // Theme name, for naming generated files
+	Dark   bool
+	Accent string // Accent color as #rrggbb
+}
+
+// TerminalColors is a terminal color scheme as #rrggbb colors.
+type TerminalColors struct {
+	Background string
+	Foreground string
+	Palette    [terminalPaletteSize]string // ANSI colors 0-7, then bright 8-15
+}
+
+// IsPlasma reports whether an XDG_CURRENT_DESKTOP value, a colon-separated
+// list such as "KDE" or "ubuntu:KDE", names KDE Plasma.
+func IsPlasma(desktop string) bool {
+	for name := range strings.SplitSeq(desktop, ":") {
+		if strings.EqualFold(name, "KDE") || strings.EqualFold(name, "plasma") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsPlasma checks if the desktop environment is KDE Plasma.
+func (s *SystemInfo) IsPlasma() bool {
+	return s.DesktopEnvironment != nil && IsPlasma(s.DesktopEnvironment.Name)
+}
+
+// ParseGhosttyColors reads the colors of a Ghostty theme file, the format
+// karei's themes keep their terminal colors in.
+func ParseGhosttyColors(data string) (TerminalColors, error) {
+	var colors TerminalColors
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok || strings.HasPrefix(strings.TrimSpace(key), "#") {
+			continue
+		}
+
+		switch strings.TrimSpace(key) {
+		case "background":
+			colors.Background = normalizeColor(value)
+		case "foreground":
+			colors.Foreground = normalizeColor(value)
+		case "palette":
+			index, color, ok := strings.Cut(value, "=")
+			if !ok {
+				continue
+			}
+
+			n, err := strconv.Atoi(strings.TrimSpace(index))
+			if err != nil || n < 0 || n >= terminalPaletteSize {
+				continue
+			}
+
+			colors.Palette[n] = normalizeColor(color)
+		}
+	}
+
+	if colors.Background == "" || colors.Foreground == "" {
+		return colors, fmt.Errorf("%w: background or foreground missing", ErrInvalidTerminalColors)
+	}
+
+	for n, color := range colors.Palette {
+		if color == "" {
+			return colors, fmt.Errorf("%w: palette color %d missing", ErrInvalidTerminalColors, n)
+		}
+	}
+
+	return colors, nil
+}
+
+// normalizeColor normalizes "2E3440" and "#2E3440" to "#2e3440".
+func normalizeColor(value string) string {
+	return "#" + strings.ToLower(strings.TrimPrefix(strings.TrimSpace(value), "#"))
+}
+
+// RGB returns a #rrggbb color as the "r,g,b" KDE configuration files use.
+func RGB(color string) (string, error) {
+	hex := strings.TrimPrefix(color, "#")
+	if !hexColor.MatchString(color) || len(hex) != hexColorDigits {
+		return "", fmt.Errorf("%w: %q, expected #rrggbb", ErrInvalidColor, color)
+	}
+
+	value, _ := strconv.ParseUint(hex, 16, 32)
+
+	return fmt.Sprintf("%d,%d,%d", value>>16&0xff, value>>8&0xff, value&0xff), nil
+}
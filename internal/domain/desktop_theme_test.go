@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPlasma(t *testing.T) {
+	t.Parallel()
+
+	for desktop, want := range map[string]bool{
+		"KDE":             true,
+		"ubuntu:KDE":      true,
+		"plasma":          true,
+		"GNOME":           false,
+		"ubuntu:GNOME":    false,
+		"":                false,
+		"X-Cinnamon:KDEx": false,
+	} {
+		assert.Equal(t, want, domain.IsPlasma(desktop), desktop)
+	}
+}
+
+func TestParseGhosttyColors(t *testing.T) {
+	t.Parallel()
+
+	var config strings.Builder
+
+	config.WriteString("# Theme\nbackground = 2E3440\nforeground = #D8DEE9\n\n")
+
+	for n := range 16 {
+		fmt.Fprintf(&config, "palette = %d=#%02X0000\n", n, n)
+	}
+
+	config.WriteString("selection-background = 81A1C1\n")
+
+	colors, err := domain.ParseGhosttyColors(config.String())
+	require.NoError(t, err)
+	assert.Equal(t, "#2e3440", colors.Background)
+	assert.Equal(t, "#d8dee9", colors.Foreground)
+	assert.Equal(t, "#000000", colors.Palette[0])
+	assert.Equal(t, "#0f0000", colors.Palette[15])
+
+	_, err = domain.ParseGhosttyColors("background = 000000\nforeground = ffffff\npalette = 0=#000000\n")
+	require.ErrorIs(t, err, domain.ErrInvalidTerminalColors)
+}
+
+func TestRGB(t *testing.T) {
+	t.Parallel()
+
+	rgb, err := domain.RGB("#2e3440")
+	require.NoError(t, err)
+	assert.Equal(t, "46,52,64", rgb)
+
+	_, err = domain.RGB("#fff")
+	require.ErrorIs(t, err, domain.ErrInvalidColor)
+}
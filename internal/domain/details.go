@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoUpstreamDetails indicates there is no upstream to fetch a package's details from.
+var ErrNoUpstreamDetails = errors.New("no upstream details for this package")
+
+// Screenshot describes an upstream screenshot without downloading it.
+type Screenshot struct {
+	Caption string `json:"caption,omitempty"`
+	URL     string `json:"url"`
+}
+
+// AppDetails is the long-form information shown on an app's detail view.
+type AppDetails struct {
+	Key             string         `json:"key"`
+	Name            string         `json:"name"`
+	Summary         string         `json:"summary"`
+	LongDescription string         `json:"long_description,omitempty"`
+	Homepage        string         `json:"homepage,omitempty"`
+	License         string         `json:"license,omitempty"`
+	Method          InstallMethod  `json:"method"`
+	Source          string         `json:"source"`
+	LatestRelease   string         `json:"latest_release,omitempty"`
+	Channel         ReleaseChannel `json:"channel,omitempty"` // Channel LatestRelease was picked from, for GitHub apps
+	ReleaseNotes    string         `json:"release_notes,omitempty"`
+	Screenshots     []Screenshot   `json:"screenshots,omitempty"`
+	FetchedAt       time.Time      `json:"fetched_at,omitzero"`
+}
+
+// Merge fills empty fields of d from upstream, keeping catalog values that are set.
+func (d *AppDetails) Merge(upstream *AppDetails) {
+	if upstream == nil {
+		return
+	}
+
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+
+	fill(&d.LongDescription, upstream.LongDescription)
+	fill(&d.Homepage, upstream.Homepage)
+	fill(&d.License, upstream.License)
+	fill(&d.LatestRelease, upstream.LatestRelease)
+	fill(&d.ReleaseNotes, upstream.ReleaseNotes)
+
+	if len(d.Screenshots) == 0 {
+		d.Screenshots = upstream.Screenshots
+	}
+
+	d.FetchedAt = upstream.FetchedAt
+}
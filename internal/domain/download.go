@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrChecksumMismatch indicates a download differing from the checksum
+	// the catalog gives for it.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrUnsupportedArch indicates an app whose download isn't built for the
+	// machine's architecture.
+	ErrUnsupportedArch = errors.New("not available for this architecture")
+)
+
+// Architectures are the architectures catalog apps can be restricted to,
+// named as Go names them.
+var Architectures = []string{"amd64", "arm64", "386", "arm", "riscv64", "ppc64le", "s390x"} //nolint:gochecknoglobals
+
+// DownloadsFile reports whether installing with method downloads a single
+// file from source that a checksum can pin: a .deb, an install script or a
+// GitHub release asset given by URL.
+func DownloadsFile(method InstallMethod, source string) bool {
+	isURL := strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+
+	return isURL && (method == MethodDEB || method == MethodScript || method.IsGitHub())
+}
+
+// IsSHA256 reports whether checksum is a hex SHA-256 digest.
+func IsSHA256(checksum string) bool {
+	decoded, err := hex.DecodeString(checksum)
+
+	return err == nil && len(decoded) == sha256.Size
+}
+
+// VerifySHA256 reads r to its end and checks its SHA-256 digest is want.
+func VerifySHA256(r io.Reader, want string) error {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return fmt.Errorf("failed to checksum download: %w", err)
+	}
+
+	if got := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(got, want) {
+		return fmt.Errorf("%w: got sha256 %s, want %s", ErrChecksumMismatch, got, want)
+	}
+
+	return nil
+}
+
+// SupportsArch reports whether a download built for archs, all of them
+// when empty, runs on arch.
+func SupportsArch(archs []string, arch string) bool {
+	return len(archs) == 0 || slices.Contains(archs, arch)
+}
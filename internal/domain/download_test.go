@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySHA256(t *testing.T) {
+	t.Parallel()
+
+	// sha256 of "test"
+	const digest = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	assert.True(t, domain.IsSHA256(digest))
+	assert.False(t, domain.IsSHA256("9f86d0"))
+	require.NoError(t, domain.VerifySHA256(strings.NewReader("test"), strings.ToUpper(digest)))
+	require.ErrorIs(t, domain.VerifySHA256(strings.NewReader("tampered"), digest), domain.ErrChecksumMismatch)
+}
+
+func TestDownloadsFile(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, domain.DownloadsFile(domain.MethodDEB, "https://example.com/app.deb"))
+	assert.True(t, domain.DownloadsFile(domain.MethodGitHubBinary, "https://github.com/o/r/releases/download/v1/app"))
+	assert.False(t, domain.DownloadsFile(domain.MethodDEB, "./app.deb"))
+	assert.False(t, domain.DownloadsFile(domain.MethodAPT, "https://example.com/app.deb"))
+}
+
+func TestSupportsArch(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, domain.SupportsArch(nil, "arm64"))
+	assert.True(t, domain.SupportsArch([]string{"amd64", "arm64"}, "arm64"))
+	assert.False(t, domain.SupportsArch([]string{"amd64"}, "arm64"))
+}
@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrorKind classifies a failure so callers can pick a remediation.
+type ErrorKind string
+
+// Known error kinds.
+const (
+	ErrorKindUnknown    ErrorKind = ""
+	ErrorKindNetwork    ErrorKind = "network"
+	ErrorKindPermission ErrorKind = "permission"
+	ErrorKindNotFound   ErrorKind = "not-found"
+	ErrorKindConflict   ErrorKind = "conflict"
+	ErrorKindDisk       ErrorKind = "disk"
+	ErrorKindLocked     ErrorKind = "locked"
+)
+
+// ErrorAction tells a runner what to do with a failed task.
+type ErrorAction int
+
+// Error actions, from least to most disruptive.
+const (
+	// ActionSkip marks the task failed and continues with the next one.
+	ActionSkip ErrorAction = iota
+	// ActionRetry runs the task again since the cause is likely transient.
+	ActionRetry
+	// ActionAbort stops the run since remaining tasks would fail the same way.
+	ActionAbort
+)
+
+// KindError tags an error with its kind while keeping the original message.
+type KindError struct {
+	Kind ErrorKind
+	Err  error
+}
+
+// NewKindError wraps err with kind. Unknown kinds and nil errors are returned unchanged.
+func NewKindError(kind ErrorKind, err error) error {
+	if err == nil || kind == ErrorKindUnknown {
+		return err
+	}
+
+	return &KindError{Kind: kind, Err: err}
+}
+
+// Error implements the error interface.
+func (e *KindError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *KindError) Unwrap() error {
+	return e.Err
+}
+
+// Is lets errors.Is match the sentinel that belongs to the kind.
+func (e *KindError) Is(target error) bool {
+	sentinel := e.Kind.sentinel()
+
+	return sentinel != nil && target == sentinel
+}
+
+// kindSentinels maps each kind to the sentinel errors it covers, first entry is canonical.
+var kindSentinels = []struct {
+	kind      ErrorKind
+	sentinels []error
+}{
+	{ErrorKindLocked, []error{ErrPackageManagerLocked}},
+	{ErrorKindDisk, []error{ErrInsufficientSpace}},
+	{ErrorKindPermission, []error{ErrPermissionDenied}},
+	{ErrorKindNetwork, []error{ErrNetworkFailure, ErrOffline}},
+	{ErrorKindConflict, []error{ErrPackageConflict, ErrDependencyMissing}},
+	{ErrorKindNotFound, []error{ErrPackageNotFound, ErrNotInstalled}},
+}
+
+// outputPatterns maps lower-case fragments of tool output to kinds, checked in order.
+var outputPatterns = []struct {
+	kind     ErrorKind
+	patterns []string
+}{
+	{ErrorKindLocked, []string{"could not get lock", "package manager locked", "dpkg frontend lock"}},
+	{ErrorKindDisk, []string{"no space left on device", "not enough disk space", "you don't have enough free space", "disk quota exceeded"}},
+	{ErrorKindPermission, []string{"permission denied", "are you root", "operation not permitted", "a password is required", "incorrect password"}},
+	{ErrorKindNetwork, []string{
+		"temporary failure resolving", "could not resolve", "no such host", "connection refused",
+		"connection timed out", "network is unreachable", "failed to fetch", "tls handshake timeout",
+	}},
+	{ErrorKindConflict, []string{"conflicts with", "unmet dependencies", "held broken packages", "trying to overwrite", "breaks:"}},
+	{ErrorKindNotFound, []string{"unable to locate package", "has no installation candidate", "no remote refs found", "not found in catalog", "404 not found"}},
+}
+
+// ClassifyError returns the kind of err, preferring typed errors over message matching.
+func ClassifyError(err error) ErrorKind {
+	if err == nil {
+		return ErrorKindUnknown
+	}
+
+	var kindErr *KindError
+	if errors.As(err, &kindErr) {
+		return kindErr.Kind
+	}
+
+	for _, entry := range kindSentinels {
+		for _, sentinel := range entry.sentinels {
+			if errors.Is(err, sentinel) {
+				return entry.kind
+			}
+		}
+	}
+
+	return ClassifyOutput(err.Error())
+}
+
+// ClassifyOutput guesses the kind of a failure from command output such as apt stderr.
+func ClassifyOutput(output string) ErrorKind {
+	lower := strings.ToLower(output)
+
+	for _, entry := range outputPatterns {
+		for _, pattern := range entry.patterns {
+			if strings.Contains(lower, pattern) {
+				return entry.kind
+			}
+		}
+	}
+
+	return ErrorKindUnknown
+}
+
+// Action returns how a runner should react to a failure of this kind.
+func (k ErrorKind) Action() ErrorAction {
+	switch k {
+	case ErrorKindNetwork, ErrorKindLocked:
+		return ActionRetry
+	case ErrorKindDisk, ErrorKindPermission:
+		return ActionAbort
+	case ErrorKindUnknown, ErrorKindNotFound, ErrorKindConflict:
+		return ActionSkip
+	}
+
+	return ActionSkip
+}
+
+// Retryable reports whether the failure is likely transient.
+func (k ErrorKind) Retryable() bool {
+	return k.Action() == ActionRetry
+}
+
+// Hint returns a short remediation for the kind, or "" when none applies.
+func (k ErrorKind) Hint() string {
+	switch k {
+	case ErrorKindNetwork:
+		return "Check your internet connection or proxy settings"
+	case ErrorKindPermission:
+		return "Check your sudo password and admin privileges"
+	case ErrorKindNotFound:
+		return "Update package lists (sudo apt update) or check the app name"
+	case ErrorKindConflict:
+		return "Resolve conflicting packages, e.g. sudo apt --fix-broken install"
+	case ErrorKindDisk:
+		return "Free up disk space, e.g. sudo apt clean"
+	case ErrorKindLocked:
+		return "Wait for the running package manager to finish"
+	case ErrorKindUnknown:
+		return ""
+	}
+
+	return ""
+}
+
+// sentinel returns the canonical sentinel error for the kind.
+func (k ErrorKind) sentinel() error {
+	for _, entry := range kindSentinels {
+		if entry.kind == k {
+			return entry.sentinels[0]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want domain.ErrorKind
+	}{
+		{"nil", nil, domain.ErrorKindUnknown},
+		{"tagged", domain.NewKindError(domain.ErrorKindConflict, errors.New("boom")), domain.ErrorKindConflict},
+		{"wrapped sentinel", fmt.Errorf("install vlc: %w", domain.ErrNetworkFailure), domain.ErrorKindNetwork},
+		{"lock sentinel", domain.ErrPackageManagerLocked, domain.ErrorKindLocked},
+		{"disk sentinel", domain.ErrInsufficientSpace, domain.ErrorKindDisk},
+		{"apt resolve", errors.New("E: Failed to fetch http://archive: Temporary failure resolving 'archive.ubuntu.com'"), domain.ErrorKindNetwork},
+		{"apt locate", errors.New("E: Unable to locate package vlcx"), domain.ErrorKindNotFound},
+		{"dpkg space", errors.New("dpkg: error: No space left on device"), domain.ErrorKindDisk},
+		{"sudo", errors.New("sudo: a password is required"), domain.ErrorKindPermission},
+		{"broken", errors.New("E: Unable to correct problems, you have held broken packages."), domain.ErrorKindConflict},
+		{"opaque", errors.New("exit status 1"), domain.ErrorKindUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, domain.ClassifyError(tt.err))
+		})
+	}
+}
+
+func TestKindErrorKeepsMessageAndMatchesSentinel(t *testing.T) {
+	t.Parallel()
+
+	base := errors.New("command failed: exit status 100")
+	err := domain.NewKindError(domain.ErrorKindNetwork, base)
+
+	assert.Equal(t, base.Error(), err.Error())
+	assert.ErrorIs(t, err, base)
+	assert.ErrorIs(t, err, domain.ErrNetworkFailure)
+	assert.NotErrorIs(t, err, domain.ErrPermissionDenied)
+
+	assert.Same(t, base, domain.NewKindError(domain.ErrorKindUnknown, base))
+	assert.NoError(t, domain.NewKindError(domain.ErrorKindNetwork, nil))
+}
+
+func TestErrorKindAction(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, domain.ActionRetry, domain.ErrorKindNetwork.Action())
+	assert.Equal(t, domain.ActionRetry, domain.ErrorKindLocked.Action())
+	assert.Equal(t, domain.ActionAbort, domain.ErrorKindDisk.Action())
+	assert.Equal(t, domain.ActionAbort, domain.ErrorKindPermission.Action())
+	assert.Equal(t, domain.ActionSkip, domain.ErrorKindNotFound.Action())
+	assert.Equal(t, domain.ActionSkip, domain.ErrorKindUnknown.Action())
+
+	assert.True(t, domain.ErrorKindNetwork.Retryable())
+	assert.False(t, domain.ErrorKindConflict.Retryable())
+	assert.NotEmpty(t, domain.ErrorKindDisk.Hint())
+	assert.Empty(t, domain.ErrorKindUnknown.Hint())
+}
@@ -15,6 +15,8 @@ var (
 	ErrAlreadyInstalled  = errors.New("already installed")
 	ErrNotInstalled      = errors.New("not installed")
 	ErrDependencyMissing = errors.New("dependency missing")
+	// ErrPackageManagerLocked indicates another process such as unattended-upgrades holds the dpkg lock.
+	ErrPackageManagerLocked = errors.New("package manager locked")
 )
 
 // ExitError provides specific exit codes for different failure modes.
@@ -58,6 +60,19 @@ func getErrorMatchers() []struct {
 		patterns []string
 		getInfo  func(string, bool) errorInfo
 	}{
+		{
+			patterns: []string{"package manager locked", "could not get lock"},
+			getInfo: func(_ string, verbose bool) errorInfo {
+				return errorInfo{
+					Message: "Package manager is busy",
+					Suggestions: []string{
+						"Wait for the running update (often unattended-upgrades) to finish and retry",
+						"Wait longer with --apt-lock-timeout 10m",
+					},
+					ShowDetails: verbose,
+				}
+			},
+		},
 		{
 			patterns: []string{"permission", "denied", "sudo", "root"},
 			getInfo: func(_ string, verbose bool) errorInfo {
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of domain event being published.
+type EventType string
+
+// Domain events emitted by application services. The TUI subscribes to keep
+// its screens and status bar current, the CLI for its progress output and the
+// webhook. The journal, install metrics and shell setup are written by the
+// services themselves, as they need the whole result of an operation.
+const (
+	EventPackageInstalled EventType = "package.installed"
+	EventPackageRemoved   EventType = "package.removed"
+	EventThemeApplied     EventType = "theme.applied"
+	EventFontChanged      EventType = "font.changed"
+	EventUpdateAvailable  EventType = "update.available" // Data holds "installed" and "available" versions
+
+	// EventFileConflict follows a reinstall that replaced a file the user
+	// changed. Data holds "path" and "saved", where the changes were kept.
+	EventFileConflict EventType = "file.conflict"
+
+	// Operation events follow each install or removal of an app. Data holds
+	// "operation" (install or uninstall) and, on failure, "error".
+	EventOperationStarted   EventType = "operation.started"
+	EventOperationCompleted EventType = "operation.completed"
+	EventOperationFailed    EventType = "operation.failed"
+)
+
+// Event describes something that happened in the domain.
+// Subject names the affected entity (package, theme or font name).
+type Event struct {
+	Type      EventType         `json:"type"`
+	Subject   string            `json:"subject"`
+	Data      map[string]string `json:"data,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// NewEvent creates an event stamped with the current time.
+func NewEvent(eventType EventType, subject string) Event {
+	return Event{
+		Type:      eventType,
+		Subject:   subject,
+		Timestamp: time.Now(),
+	}
+}
+
+// EventHandler reacts to a published event.
+type EventHandler func(ctx context.Context, event Event)
+
+// EventPublisher defines the port application services use to emit events.
+type EventPublisher interface {
+	// Publish delivers an event to all interested subscribers.
+	Publish(ctx context.Context, event Event)
+}
+
+// EventBus is an in-process publish/subscribe hub for domain events.
+// Handlers run synchronously in subscription order on the publisher's goroutine.
+type EventBus struct {
+	mu       sync.RWMutex
+	nextID   int
+	handlers map[EventType][]subscription
+	wildcard []subscription
+}
+
+type subscription struct {
+	id      int
+	handler EventHandler
+}
+
+// NewEventBus creates an empty event bus.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		handlers: make(map[EventType][]subscription),
+	}
+}
+
+// Subscribe registers a handler for one event type.
+// The returned function removes the subscription.
+func (b *EventBus) Subscribe(eventType EventType, handler EventHandler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.handlers[eventType] = append(b.handlers[eventType], subscription{id: id, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.handlers[eventType] = removeSubscription(b.handlers[eventType], id)
+	}
+}
+
+// SubscribeAll registers a handler that receives every event.
+// The returned function removes the subscription.
+func (b *EventBus) SubscribeAll(handler EventHandler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.wildcard = append(b.wildcard, subscription{id: id, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.wildcard = removeSubscription(b.wildcard, id)
+	}
+}
+
+// Publish delivers the event to type-specific handlers first, then wildcard handlers.
+func (b *EventBus) Publish(ctx context.Context, event Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	b.mu.RLock()
+	targets := make([]subscription, 0, len(b.handlers[event.Type])+len(b.wildcard))
+	targets = append(targets, b.handlers[event.Type]...)
+	targets = append(targets, b.wildcard...)
+	b.mu.RUnlock()
+
+	for _, sub := range targets {
+		sub.handler(ctx, event)
+	}
+}
+
+func removeSubscription(subs []subscription, id int) []subscription {
+	for i, sub := range subs {
+		if sub.id == id {
+			return append(subs[:i:i], subs[i+1:]...)
+		}
+	}
+
+	return subs
+}
+
+// NoopEventPublisher discards all events. Used when no bus is configured.
+type NoopEventPublisher struct{}
+
+// Publish implements EventPublisher by doing nothing.
+func (NoopEventPublisher) Publish(context.Context, Event) {}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBusDeliversToTypedSubscribers(t *testing.T) {
+	t.Parallel()
+
+	bus := domain.NewEventBus()
+
+	var installed, themed []string
+
+	bus.Subscribe(domain.EventPackageInstalled, func(_ context.Context, e domain.Event) {
+		installed = append(installed, e.Subject)
+	})
+	bus.Subscribe(domain.EventThemeApplied, func(_ context.Context, e domain.Event) {
+		themed = append(themed, e.Subject)
+	})
+
+	bus.Publish(context.Background(), domain.NewEvent(domain.EventPackageInstalled, "git"))
+	bus.Publish(context.Background(), domain.NewEvent(domain.EventThemeApplied, "nord"))
+
+	assert.Equal(t, []string{"git"}, installed)
+	assert.Equal(t, []string{"nord"}, themed)
+}
+
+func TestEventBusWildcardReceivesEverything(t *testing.T) {
+	t.Parallel()
+
+	bus := domain.NewEventBus()
+
+	var seen []domain.EventType
+
+	bus.SubscribeAll(func(_ context.Context, e domain.Event) {
+		seen = append(seen, e.Type)
+	})
+
+	bus.Publish(context.Background(), domain.NewEvent(domain.EventFontChanged, "JetBrainsMono"))
+	bus.Publish(context.Background(), domain.Event{Type: domain.EventUpdateAvailable, Subject: "karei"})
+
+	assert.Equal(t, []domain.EventType{domain.EventFontChanged, domain.EventUpdateAvailable}, seen)
+}
+
+func TestEventBusUnsubscribe(t *testing.T) {
+	t.Parallel()
+
+	bus := domain.NewEventBus()
+	calls := 0
+
+	unsubscribe := bus.Subscribe(domain.EventPackageRemoved, func(context.Context, domain.Event) { calls++ })
+	unsubscribeAll := bus.SubscribeAll(func(context.Context, domain.Event) { calls++ })
+
+	bus.Publish(context.Background(), domain.NewEvent(domain.EventPackageRemoved, "vim"))
+	require.Equal(t, 2, calls)
+
+	unsubscribe()
+	unsubscribeAll()
+
+	bus.Publish(context.Background(), domain.NewEvent(domain.EventPackageRemoved, "vim"))
+	assert.Equal(t, 2, calls, "no handlers should run after unsubscribing")
+}
+
+func TestEventBusStampsMissingTimestamp(t *testing.T) {
+	t.Parallel()
+
+	bus := domain.NewEventBus()
+
+	var got domain.Event
+
+	bus.SubscribeAll(func(_ context.Context, e domain.Event) { got = e })
+	bus.Publish(context.Background(), domain.Event{Type: domain.EventPackageInstalled, Subject: "git"})
+
+	assert.False(t, got.Timestamp.IsZero())
+}
+
+func TestEventBusConcurrentPublish(t *testing.T) {
+	t.Parallel()
+
+	bus := domain.NewEventBus()
+
+	var (
+		mu    sync.Mutex
+		count int
+	)
+
+	bus.SubscribeAll(func(context.Context, domain.Event) {
+		mu.Lock()
+		count++
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			bus.Publish(context.Background(), domain.NewEvent(domain.EventPackageInstalled, "pkg"))
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 50, count)
+}
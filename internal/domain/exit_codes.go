@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// Exit codes follow standard Unix conventions for better scripting support.
+// Range 0-125 are safe to use (126+ have special meaning in shells).
+const (
+	// Standard Unix exit codes (0-10).
+	ExitSuccess         = 0 // Operation completed successfully
+	ExitGeneralError    = 1 // Generic failure (catch-all)
+	ExitUsageError      = 2 // Invalid command line usage
+	ExitConfigError     = 3 // Configuration file error
+	ExitPermissionError = 4 // Permission denied
+	ExitNotFoundError   = 5 // Requested resource not found
+
+	// Network and system errors (10-19).
+	ExitDependencyError = 10 // Missing dependency
+	ExitNetworkError    = 11 // Network operation failed
+	ExitSystemError     = 12 // System call failed
+	ExitTimeoutError    = 13 // Operation timed out
+	ExitInterruptError  = 14 // User interrupted (Ctrl+C)
+	ExitLockedError     = 15 // Locked by another process
+
+	// Application-specific errors (20-29).
+	ExitThemeError     = 20 // Theme operation failed
+	ExitFontError      = 21 // Font operation failed
+	ExitAppError       = 22 // App installation/removal failed
+	ExitBackupError    = 23 // Backup operation failed
+	ExitMigrationError = 24 // Migration failed
+
+	// Warning (non-fatal issues occurred).
+	ExitWarnings = 64 // Operation succeeded with warnings
+)
+
+// ExitCode documents an exit code karei ends with.
+type ExitCode struct {
+	Code    int    `json:"code"`
+	Name    string `json:"name"`
+	Meaning string `json:"meaning"`
+}
+
+// ExitCodes returns every exit code karei ends with, by code. Scripts rely
+// on them, so codes are only ever added.
+func ExitCodes() []ExitCode {
+	return []ExitCode{
+		{ExitSuccess, "success", "Completed successfully"},
+		{ExitGeneralError, "general", "Failed for a reason no other code covers"},
+		{ExitUsageError, "usage", "Invalid arguments or flags"},
+		{ExitConfigError, "config", "Invalid configuration, profile or catalog, or untrusted remote content"},
+		{ExitPermissionError, "permission", "Permission denied, run with sudo or as an admin"},
+		{ExitNotFoundError, "not-found", "An app, theme, font or other named resource doesn't exist"},
+		{ExitDependencyError, "dependency", "A tool karei needs is missing, or apps conflict"},
+		{ExitNetworkError, "network", "A download or other network request failed"},
+		{ExitSystemError, "system", "The filesystem or a system command failed, e.g. a full disk"},
+		{ExitTimeoutError, "timeout", "An operation or prompt timed out"},
+		{ExitInterruptError, "interrupt", "Interrupted with Ctrl+C"},
+		{ExitLockedError, "locked", "Another process, the package manager or another karei, holds a lock"},
+		{ExitThemeError, "theme", "Applying a theme failed"},
+		{ExitFontError, "font", "Installing a font failed"},
+		{ExitAppError, "app", "Installing or removing apps failed"},
+		{ExitBackupError, "backup", "Backing up or restoring failed"},
+		{ExitMigrationError, "migration", "Migrating karei's files failed"},
+		{ExitWarnings, "warnings", "Completed, but some steps failed or need attention"},
+	}
+}
+
+// LookupExitCode returns the registered exit code code.
+func LookupExitCode(code int) (ExitCode, bool) {
+	for _, exitCode := range ExitCodes() {
+		if exitCode.Code == code {
+			return exitCode, true
+		}
+	}
+
+	return ExitCode{}, false
+}
+
+// ExitCodeFor returns the exit code err ends karei with: the code of an
+// ExitError, or that of the failure a domain error names. Codes not in the
+// registry, such as one passed on from a failed child process, end karei
+// with ExitGeneralError.
+func ExitCodeFor(err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		if _, ok := LookupExitCode(exitErr.Code); ok {
+			return exitErr.Code
+		}
+
+		return ExitGeneralError
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ExitInterruptError
+	case errors.Is(err, context.DeadlineExceeded):
+		return ExitTimeoutError
+	case errors.Is(err, ErrPackageManagerLocked):
+		return ExitLockedError
+	case errors.Is(err, ErrPermissionDenied):
+		return ExitPermissionError
+	case errors.Is(err, ErrNetworkFailure):
+		return ExitNetworkError
+	case errors.Is(err, ErrDependencyMissing):
+		return ExitDependencyError
+	default:
+		return ExitGeneralError
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExitCodesStable pins every exit code, since scripts depend on them.
+// Adding a code is fine; changing one is a breaking change.
+func TestExitCodesStable(t *testing.T) {
+	t.Parallel()
+
+	want := map[int]string{
+		0: "success", 1: "general", 2: "usage", 3: "config", 4: "permission", 5: "not-found",
+		10: "dependency", 11: "network", 12: "system", 13: "timeout", 14: "interrupt", 15: "locked",
+		20: "theme", 21: "font", 22: "app", 23: "backup", 24: "migration", 64: "warnings",
+	}
+
+	codes := domain.ExitCodes()
+	names := map[string]bool{}
+
+	for i, code := range codes {
+		assert.Equal(t, want[code.Code], code.Name, "code %d", code.Code)
+		assert.NotEmpty(t, code.Meaning, code.Name)
+		assert.False(t, names[code.Name], "duplicate name %s", code.Name)
+		names[code.Name] = true
+
+		if i > 0 {
+			assert.Greater(t, code.Code, codes[i-1].Code, "codes are sorted and unique")
+		}
+	}
+
+	assert.Len(t, codes, len(want))
+}
+
+func TestExitCodeFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, domain.ExitSuccess},
+		{domain.NewExitError(domain.ExitNotFoundError, "unknown app: vlcc", nil), domain.ExitNotFoundError},
+		{fmt.Errorf("wrapped: %w", domain.NewExitError(domain.ExitWarnings, "2 failed", nil)), domain.ExitWarnings},
+		{domain.NewExitError(255, "ssh failed", nil), domain.ExitGeneralError},
+		{fmt.Errorf("apt: %w", domain.ErrPackageManagerLocked), domain.ExitLockedError},
+		{domain.ErrPermissionDenied, domain.ExitPermissionError},
+		{domain.ErrNetworkFailure, domain.ExitNetworkError},
+		{domain.ErrDependencyMissing, domain.ExitDependencyError},
+		{context.Canceled, domain.ExitInterruptError},
+		{context.DeadlineExceeded, domain.ExitTimeoutError},
+		{errors.New("boom"), domain.ExitGeneralError},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, domain.ExitCodeFor(tt.err), fmt.Sprint(tt.err))
+	}
+}
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SnapshotIDLayout is how a snapshot is named after the time it was taken, in UTC.
+const SnapshotIDLayout = "20060102-150405"
+
+var (
+	// ErrInvalidSince indicates a date karei can't read.
+	ErrInvalidSince = errors.New("invalid date, use YYYY-MM-DD or RFC 3339")
+	// ErrSnapshotNotFound indicates no snapshot has the ID asked for.
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+)
+
+// sinceLayouts are the date forms --since accepts, in local time unless
+// they carry a zone.
+var sinceLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", time.DateOnly}
+
+// SnapshotPackage is a catalog app as karei had it installed.
+type SnapshotPackage struct {
+	Method    InstallMethod `json:"method"`
+	Source    string        `json:"source"`
+	Version   string        `json:"version,omitempty"`
+	Installed time.Time     `json:"installed"`
+}
+
+// Snapshot is what karei had installed and configured at a point in time:
+// catalog apps by key, and the checksum of each managed configuration file
+// by path.
+type Snapshot struct {
+	ID       string                     `json:"id"`
+	Taken    time.Time                  `json:"taken"`
+	Packages map[string]SnapshotPackage `json:"packages"`
+	Configs  map[string]string          `json:"configs"`
+}
+
+// NewSnapshot creates an empty snapshot taken at taken.
+func NewSnapshot(taken time.Time) Snapshot {
+	return Snapshot{
+		ID:       taken.UTC().Format(SnapshotIDLayout),
+		Taken:    taken,
+		Packages: make(map[string]SnapshotPackage),
+		Configs:  make(map[string]string),
+	}
+}
+
+// Empty reports whether karei had nothing installed or configured.
+func (s Snapshot) Empty() bool {
+	return len(s.Packages) == 0 && len(s.Configs) == 0
+}
+
+// SameState reports whether other holds the same apps and configuration
+// files, whenever it was taken.
+func (s Snapshot) SameState(other Snapshot) bool {
+	return maps.Equal(s.Packages, other.Packages) && maps.Equal(s.Configs, other.Configs)
+}
+
+// Change kinds of a HistoryDiff.
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeUpgraded = "upgraded" // Installed again, which fetches the latest release
+	ChangeModified = "changed"
+)
+
+// PackageChange is a catalog app karei added, removed or upgraded.
+type PackageChange struct {
+	App    string           `json:"app"`
+	Change string           `json:"change"`
+	Before *SnapshotPackage `json:"before,omitempty"`
+	After  *SnapshotPackage `json:"after,omitempty"`
+}
+
+// ConfigChange is a configuration file karei started or stopped managing, or
+// wrote different content to.
+type ConfigChange struct {
+	Path   string `json:"path"`
+	Change string `json:"change"`
+}
+
+// HistoryDiff is what karei changed between two snapshots.
+type HistoryDiff struct {
+	From     time.Time       `json:"from"`
+	To       time.Time       `json:"to"`
+	Packages []PackageChange `json:"packages"`
+	Configs  []ConfigChange  `json:"configs"`
+}
+
+// Empty reports whether nothing changed.
+func (d HistoryDiff) Empty() bool {
+	return len(d.Packages) == 0 && len(d.Configs) == 0
+}
+
+// DiffSnapshots returns what changed from before to after, sorted by app and
+// path. An app is upgraded when karei installed it again, or under another
+// method, source or version.
+func DiffSnapshots(before, after Snapshot) HistoryDiff {
+	diff := HistoryDiff{From: before.Taken, To: after.Taken}
+
+	for _, app := range sortedKeys(before.Packages, after.Packages) {
+		was, had := before.Packages[app]
+		now, has := after.Packages[app]
+
+		switch {
+		case !had:
+			diff.Packages = append(diff.Packages, PackageChange{App: app, Change: ChangeAdded, After: &now})
+		case !has:
+			diff.Packages = append(diff.Packages, PackageChange{App: app, Change: ChangeRemoved, Before: &was})
+		case was != now:
+			diff.Packages = append(diff.Packages, PackageChange{App: app, Change: ChangeUpgraded, Before: &was, After: &now})
+		}
+	}
+
+	for _, path := range sortedKeys(before.Configs, after.Configs) {
+		was, had := before.Configs[path]
+		now, has := after.Configs[path]
+
+		switch {
+		case !had:
+			diff.Configs = append(diff.Configs, ConfigChange{Path: path, Change: ChangeAdded})
+		case !has:
+			diff.Configs = append(diff.Configs, ConfigChange{Path: path, Change: ChangeRemoved})
+		case was != now:
+			diff.Configs = append(diff.Configs, ConfigChange{Path: path, Change: ChangeModified})
+		}
+	}
+
+	return diff
+}
+
+// sortedKeys returns the keys of both maps, once each and sorted.
+func sortedKeys[V any](a, b map[string]V) []string {
+	keys := slices.Collect(maps.Keys(a))
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// ParseSince reads a date such as 2025-01-01, or a time such as
+// 2025-01-01T09:30 or RFC 3339, in the local zone unless it has one.
+func ParseSince(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	for _, layout := range sinceLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidSince, value)
+}
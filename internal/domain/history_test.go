@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	t.Parallel()
+
+	january := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	march := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	before := domain.NewSnapshot(january)
+	before.Packages["git"] = domain.SnapshotPackage{Method: domain.MethodAPT, Source: "git", Installed: january}
+	before.Packages["vim"] = domain.SnapshotPackage{Method: domain.MethodAPT, Source: "vim", Installed: january}
+	before.Packages["lazygit"] = domain.SnapshotPackage{Method: domain.MethodGitHub, Source: "jesseduffield/lazygit", Installed: january}
+	before.Configs["/home/u/.gitconfig"] = "aaa"
+	before.Configs["/home/u/.vimrc"] = "bbb"
+
+	after := domain.NewSnapshot(march)
+	after.Packages["git"] = before.Packages["git"]
+	after.Packages["lazygit"] = domain.SnapshotPackage{Method: domain.MethodGitHub, Source: "jesseduffield/lazygit", Installed: march}
+	after.Packages["docker"] = domain.SnapshotPackage{Method: domain.MethodAPT, Source: "docker-ce", Installed: march}
+	after.Configs["/home/u/.gitconfig"] = "ccc"
+	after.Configs["/home/u/.config/ghostty/config"] = "ddd"
+
+	diff := domain.DiffSnapshots(before, after)
+	pkg := func(snapshot domain.Snapshot, app string) *domain.SnapshotPackage {
+		found := snapshot.Packages[app]
+
+		return &found
+	}
+
+	assert.Equal(t, january, diff.From)
+	assert.Equal(t, march, diff.To)
+	assert.Equal(t, []domain.PackageChange{
+		{App: "docker", Change: domain.ChangeAdded, After: pkg(after, "docker")},
+		{App: "lazygit", Change: domain.ChangeUpgraded, Before: pkg(before, "lazygit"), After: pkg(after, "lazygit")},
+		{App: "vim", Change: domain.ChangeRemoved, Before: pkg(before, "vim")},
+	}, diff.Packages)
+	assert.Equal(t, []domain.ConfigChange{
+		{Path: "/home/u/.config/ghostty/config", Change: domain.ChangeAdded},
+		{Path: "/home/u/.gitconfig", Change: domain.ChangeModified},
+		{Path: "/home/u/.vimrc", Change: domain.ChangeRemoved},
+	}, diff.Configs)
+
+	assert.True(t, domain.DiffSnapshots(after, after).Empty())
+}
+
+func TestParseSince(t *testing.T) {
+	t.Parallel()
+
+	date, err := domain.ParseSince("2025-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local), date)
+
+	minute, err := domain.ParseSince("2025-01-01T09:30")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 1, 9, 30, 0, 0, time.Local), minute)
+
+	zoned, err := domain.ParseSince("2025-01-01T09:30:00Z")
+	require.NoError(t, err)
+	assert.True(t, zoned.Equal(time.Date(2025, 1, 1, 9, 30, 0, 0, time.UTC)))
+
+	_, err = domain.ParseSince("last tuesday")
+	require.ErrorIs(t, err, domain.ErrInvalidSince)
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+// InstalledPackages holds the names of the packages installed through each
+// method whose package manager was listed. .deb files count as APT packages.
+type InstalledPackages map[InstallMethod]map[string]bool
+
+// Add records name as installed through method.
+func (p InstalledPackages) Add(method InstallMethod, names ...string) {
+	if p[method] == nil {
+		p[method] = make(map[string]bool, len(names))
+	}
+
+	for _, name := range names {
+		p[method][name] = true
+	}
+}
+
+// Has reports whether the package known to method as name is installed, and
+// whether method was listed at all, so an unlisted method isn't taken as
+// nothing installed.
+func (p InstalledPackages) Has(method InstallMethod, name string) (installed, listed bool) {
+	if method == MethodDEB {
+		method = MethodAPT
+	}
+
+	names, listed := p[method]
+
+	return names[name], listed
+}
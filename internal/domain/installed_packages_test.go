@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+func TestInstalledPackagesHas(t *testing.T) {
+	t.Parallel()
+
+	installed := domain.InstalledPackages{}
+	installed.Add(domain.MethodAPT, "git", "fastfetch")
+	installed.Add(domain.MethodFlatpak)
+
+	has, listed := installed.Has(domain.MethodAPT, "git")
+	assert.True(t, has)
+	assert.True(t, listed)
+
+	has, _ = installed.Has(domain.MethodDEB, "fastfetch")
+	assert.True(t, has, "deb packages are listed by dpkg")
+
+	has, listed = installed.Has(domain.MethodFlatpak, "dev.zed.Zed")
+	assert.False(t, has)
+	assert.True(t, listed, "flatpak was listed with nothing installed")
+
+	_, listed = installed.Has(domain.MethodMise, "node")
+	assert.False(t, listed)
+}
@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+// ManagedPackage is a catalog application karei installed on this machine,
+// with the details an inventory of installed software needs.
+type ManagedPackage struct {
+	Key       string        `json:"key"`
+	Name      string        `json:"name"`
+	Version   string        `json:"version,omitempty"`
+	Method    InstallMethod `json:"method"`
+	Source    string        `json:"source"`               // Package name, app ID or download the method installs from
+	SourceURL string        `json:"source_url,omitempty"` // Where Source can be inspected or downloaded
+	License   License       `json:"license"`
+	Checksum  string        `json:"checksum,omitempty"` // Hex SHA-256 of the installed files
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrKernelHeaders indicates the headers of the running kernel can't be
+// installed, so kernel modules can't be built for it.
+var ErrKernelHeaders = errors.New("kernel headers unavailable")
+
+// DKMSPrerequisites are the packages DKMS needs to build kernel modules for
+// the kernel release, as uname -r prints it: its headers and DKMS itself.
+func DKMSPrerequisites(release string) []string {
+	return []string{"linux-headers-" + release, "dkms"}
+}
+
+// ParseSecureBoot reads the output of mokutil --sb-state, reporting whether
+// Secure Boot is enforced. Firmware without Secure Boot reports it isn't.
+func ParseSecureBoot(output string) bool {
+	for line := range strings.SplitSeq(output, "\n") {
+		if strings.TrimSpace(line) == "SecureBoot enabled" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MOKGuidance tells how to let the kernel load the modules DKMS built for
+// app under Secure Boot, by enrolling DKMS's signing key as a Machine Owner
+// Key (MOK).
+func MOKGuidance(app string) string {
+	return "Secure Boot is on, so the kernel only loads " + app + "'s modules once DKMS's signing key is enrolled: " +
+		"run sudo mokutil --import /var/lib/dkms/mok.pub, choose a one-time password, reboot and pick " +
+		"Enroll MOK in the blue MOK manager screen"
+}
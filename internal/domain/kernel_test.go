@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSecureBoot(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, domain.ParseSecureBoot("SecureBoot enabled\n"))
+	assert.False(t, domain.ParseSecureBoot("SecureBoot disabled\nPlatform is in Setup Mode\n"))
+	assert.False(t, domain.ParseSecureBoot("EFI variables are not supported on this system\n"))
+}
+
+func TestDKMSPrerequisites(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"linux-headers-6.8.0-45-generic", "dkms"}, domain.DKMSPrerequisites("6.8.0-45-generic"))
+}
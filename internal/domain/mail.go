@@ -0,0 +1,245 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownMailClient indicates a mail client karei has no profile for.
+var ErrUnknownMailClient = errors.New("unknown mail client")
+
+// MailClient is a desktop mail and calendar client.
+type MailClient string
+
+// Mail clients karei sets up.
+const (
+	MailThunderbird MailClient = "thunderbird"
+	MailEvolution   MailClient = "evolution"
+)
+
+// MailProfile is what karei installs for a mail client and the files and
+// links it opens by default.
+type MailProfile struct {
+	Client      MailClient `json:"client"`
+	Name        string     `json:"name"`
+	Apps        []string   `json:"apps"`         // Catalog keys to install
+	DesktopFile string     `json:"desktop_file"` // Desktop entry made the default
+	MimeTypes   []string   `json:"mime_types"`   // Types it becomes the default for
+}
+
+// ParseMailClient returns the mail client named name.
+func ParseMailClient(name string) (MailClient, error) {
+	switch client := MailClient(strings.ToLower(strings.TrimSpace(name))); client {
+	case MailThunderbird, MailEvolution:
+		return client, nil
+	default:
+		return "", fmt.Errorf("%w: %q, expected thunderbird or evolution", ErrUnknownMailClient, name)
+	}
+}
+
+// MailProfileFor returns the profile of client.
+func MailProfileFor(client MailClient) MailProfile {
+	mimeTypes := []string{"x-scheme-handler/mailto", "message/rfc822", "text/calendar"}
+
+	if client == MailEvolution {
+		return MailProfile{
+			Client:      MailEvolution,
+			Name:        "Evolution",
+			Apps:        []string{"evolution", "evolution-ews"},
+			DesktopFile: "org.gnome.Evolution.desktop",
+			MimeTypes:   append(mimeTypes, "text/vcard"),
+		}
+	}
+
+	return MailProfile{
+		Client:      MailThunderbird,
+		Name:        "Thunderbird",
+		Apps:        []string{"thunderbird"},
+		DesktopFile: "org.mozilla.Thunderbird.desktop",
+		MimeTypes:   mimeTypes,
+	}
+}
+
+// MailProvider is who hosts a mail address, with how to add the account to
+// a mail client.
+type MailProvider struct {
+	Name  string `json:"name"`
+	Hint  string `json:"hint"`
+	Known bool   `json:"known"` // A provider karei recognizes, rather than the domain itself
+}
+
+// mailProviders are the providers recognized by their domains and the
+// suffixes of their MX hosts, with a hint per client.
+var mailProviders = []struct {
+	name    string
+	domains []string
+	mx      []string
+	hints   map[MailClient]string
+}{
+	{
+		name:    "Google",
+		domains: []string{"gmail.com", "googlemail.com"},
+		mx:      []string{".google.com.", ".googlemail.com."},
+		hints: map[MailClient]string{
+			MailThunderbird: "sign in with your Google account when adding it; Thunderbird finds IMAP, SMTP and CalDAV itself",
+			MailEvolution:   "add the account in Settings > Online Accounts as Google; Evolution picks up mail and calendars",
+		},
+	},
+	{
+		name:    "Microsoft",
+		domains: []string{"outlook.com", "hotmail.com", "live.com", "msn.com"},
+		mx:      []string{".mail.protection.outlook.com.", ".olc.protection.outlook.com."},
+		hints: map[MailClient]string{
+			MailThunderbird: "sign in with your Microsoft account when adding it (OAuth2 over IMAP); calendars need the Owl or TbSync add-on",
+			MailEvolution:   "add the account as Exchange Web Services, or in Online Accounts as Microsoft 365",
+		},
+	},
+	{
+		name:    "Proton",
+		domains: []string{"proton.me", "protonmail.com", "pm.me"},
+		mx:      []string{".protonmail.ch."},
+		hints: map[MailClient]string{
+			"": "run Proton Mail Bridge and use IMAP 127.0.0.1:1143 and SMTP 127.0.0.1:1025 with the password it shows",
+		},
+	},
+	{
+		name:    "iCloud",
+		domains: []string{"icloud.com", "me.com", "mac.com"},
+		mx:      []string{".mail.icloud.com."},
+		hints: map[MailClient]string{
+			"": "create an app-specific password at account.apple.com; IMAP imap.mail.me.com:993, SMTP smtp.mail.me.com:587",
+		},
+	},
+	{
+		name:    "Fastmail",
+		domains: []string{"fastmail.com", "fastmail.fm"},
+		mx:      []string{".messagingengine.com."},
+		hints: map[MailClient]string{
+			"": "create an app password in Fastmail's settings; the servers are found from the address",
+		},
+	},
+}
+
+// DetectMailProvider returns the provider of the address domain, known
+// directly or from its MX hosts, and how client adds the account. srv tells
+// whether the domain publishes RFC 6186 SRV records for IMAP and submission.
+func DetectMailProvider(client MailClient, domain string, mx []string, srv bool) MailProvider {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+
+	for _, provider := range mailProviders {
+		if !providerMatches(provider.domains, provider.mx, domain, mx) {
+			continue
+		}
+
+		hint, ok := provider.hints[client]
+		if !ok {
+			hint = provider.hints[""]
+		}
+
+		return MailProvider{Name: provider.name, Hint: hint, Known: true}
+	}
+
+	if srv {
+		return MailProvider{Name: domain, Hint: "the domain announces its IMAP and SMTP servers, which " + MailProfileFor(client).Name + " finds from the address"}
+	}
+
+	hint := "enter the IMAP and SMTP servers your provider documents"
+	if client == MailThunderbird {
+		hint += "; Thunderbird also looks for autoconfig." + domain
+	}
+
+	return MailProvider{Name: domain, Hint: hint}
+}
+
+// providerMatches reports whether domain or one of the MX hosts belongs to
+// the provider with domains and MX host suffixes.
+func providerMatches(domains, suffixes []string, domain string, mx []string) bool {
+	if slices.Contains(domains, domain) {
+		return true
+	}
+
+	for _, host := range mx {
+		host = strings.ToLower(host)
+		if !strings.HasSuffix(host, ".") {
+			host += "."
+		}
+
+		for _, suffix := range suffixes {
+			if strings.HasSuffix(host, suffix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ThunderbirdDefaultProfile reads profiles.ini and returns the path of the
+// profile Thunderbird starts with, relative to the ini's directory unless
+// absolute, or "" when there is none. The profile an install section names
+// wins over the one marked Default=1.
+func ThunderbirdDefaultProfile(ini string) string {
+	var (
+		installDefault, markedDefault, first string
+		section, path                        string
+		isDefault                            bool
+	)
+
+	endProfile := func() {
+		if path == "" {
+			return
+		}
+
+		if first == "" {
+			first = path
+		}
+
+		if isDefault && markedDefault == "" {
+			markedDefault = path
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(ini))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "[") {
+			endProfile()
+
+			section, path, isDefault = strings.Trim(line, "[]"), "", false
+
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(section, "Install") && key == "Default" && installDefault == "":
+			installDefault = value
+		case strings.HasPrefix(section, "Profile") && key == "Path":
+			path = value
+		case strings.HasPrefix(section, "Profile") && key == "Default":
+			isDefault = value == "1"
+		}
+	}
+
+	endProfile()
+
+	switch {
+	case installDefault != "":
+		return installDefault
+	case markedDefault != "":
+		return markedDefault
+	default:
+		return first
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMailClient(t *testing.T) {
+	t.Parallel()
+
+	client, err := domain.ParseMailClient(" Evolution ")
+	require.NoError(t, err)
+	assert.Equal(t, domain.MailEvolution, client)
+	assert.Contains(t, domain.MailProfileFor(client).Apps, "evolution-ews")
+
+	_, err = domain.ParseMailClient("outlook")
+	require.ErrorIs(t, err, domain.ErrUnknownMailClient)
+}
+
+func TestDetectMailProvider(t *testing.T) {
+	t.Parallel()
+
+	gmail := domain.DetectMailProvider(domain.MailEvolution, "Gmail.com", nil, false)
+	assert.Equal(t, "Google", gmail.Name)
+	assert.True(t, gmail.Known)
+	assert.Contains(t, gmail.Hint, "Online Accounts")
+
+	workspace := domain.DetectMailProvider(domain.MailThunderbird, "example.com", []string{"ASPMX.L.GOOGLE.COM."}, false)
+	assert.Equal(t, "Google", workspace.Name)
+
+	proton := domain.DetectMailProvider(domain.MailThunderbird, "example.org", []string{"mail.protonmail.ch"}, false)
+	assert.Equal(t, "Proton", proton.Name)
+	assert.Contains(t, proton.Hint, "Bridge")
+
+	srv := domain.DetectMailProvider(domain.MailEvolution, "example.net", []string{"mx.example.net."}, true)
+	assert.False(t, srv.Known)
+	assert.Contains(t, srv.Hint, "Evolution finds")
+
+	unknown := domain.DetectMailProvider(domain.MailThunderbird, "example.net", nil, false)
+	assert.Equal(t, "example.net", unknown.Name)
+	assert.Contains(t, unknown.Hint, "autoconfig.example.net")
+}
+
+func TestThunderbirdDefaultProfile(t *testing.T) {
+	t.Parallel()
+
+	ini := `[Profile1]
+Name=work
+IsRelative=1
+Path=abcd.work
+Default=1
+
+[Profile0]
+Name=default-release
+IsRelative=1
+Path=wxyz.default-release
+`
+	assert.Equal(t, "abcd.work", domain.ThunderbirdDefaultProfile(ini))
+
+	ini += "\n[Install6F193CCC56814779]\nDefault=wxyz.default-release\nLocked=1\n"
+	assert.Equal(t, "wxyz.default-release", domain.ThunderbirdDefaultProfile(ini))
+
+	assert.Equal(t, "only", domain.ThunderbirdDefaultProfile("[Profile0]\nPath=only\n"))
+	assert.Empty(t, domain.ThunderbirdDefaultProfile("[General]\nStartWithLastProfile=1\n"))
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import "slices"
+
+// Markers around the two sides of a conflict in a merged file, as git writes them.
+const (
+	MergeMarkerOurs   = "<<<<<<< karei"
+	MergeMarkerSplit  = "======="
+	MergeMarkerTheirs = ">>>>>>> yours"
+)
+
+// MergeLines merges the changes ours and theirs each made to base, line by
+// line as diff3 does. Where both changed the same lines differently, both
+// versions are kept between conflict markers and conflicts counts them.
+func MergeLines(base, ours, theirs []string) (merged []string, conflicts int) {
+	inOurs := matchLines(base, ours)
+	inTheirs := matchLines(base, theirs)
+
+	var i, o, t int
+
+	for {
+		// The next base line both sides kept ends the changed chunk
+		next := i
+		for next < len(base) && (inOurs[next] < 0 || inTheirs[next] < 0) {
+			next++
+		}
+
+		endOurs, endTheirs := len(ours), len(theirs)
+		if next < len(base) {
+			endOurs, endTheirs = inOurs[next], inTheirs[next]
+		}
+
+		chunk, conflict := mergeChunk(base[i:next], ours[o:endOurs], theirs[t:endTheirs])
+		merged = append(merged, chunk...)
+
+		if conflict {
+			conflicts++
+		}
+
+		if next == len(base) {
+			return merged, conflicts
+		}
+
+		merged = append(merged, base[next])
+		i, o, t = next+1, endOurs+1, endTheirs+1
+	}
+}
+
+// mergeChunk merges a chunk at least one side changed.
+func mergeChunk(base, ours, theirs []string) ([]string, bool) {
+	switch {
+	case slices.Equal(ours, theirs), slices.Equal(base, theirs):
+		return ours, false
+	case slices.Equal(base, ours):
+		return theirs, false
+	}
+
+	conflict := make([]string, 0, len(ours)+len(theirs)+3)
+	conflict = append(conflict, MergeMarkerOurs)
+	conflict = append(conflict, ours...)
+	conflict = append(conflict, MergeMarkerSplit)
+	conflict = append(conflict, theirs...)
+	conflict = append(conflict, MergeMarkerTheirs)
+
+	return conflict, true
+}
+
+// matchLines pairs the lines of base with those of other along their
+// longest common subsequence, returning for each base line its index in
+// other, or -1 when other dropped or changed it.
+func matchLines(base, other []string) []int {
+	// lengths[i][j] is the longest common subsequence of base[i:] and other[j:]
+	lengths := make([][]int, len(base)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(other)+1)
+	}
+
+	for i := len(base) - 1; i >= 0; i-- {
+		for j := len(other) - 1; j >= 0; j-- {
+			if base[i] == other[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	matches := make([]int, len(base))
+
+	i, j := 0, 0
+	for i < len(base) {
+		switch {
+		case j < len(other) && base[i] == other[j]:
+			matches[i] = j
+			i++
+			j++
+		case j < len(other) && lengths[i][j+1] >= lengths[i+1][j]:
+			j++
+		default:
+			matches[i] = -1
+			i++
+		}
+	}
+
+	return matches
+}
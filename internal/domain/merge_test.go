@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeLines(t *testing.T) {
+	t.Parallel()
+
+	base := []string{"theme = dark", "font = mono", "size = 11", ""}
+
+	tests := []struct {
+		name      string
+		ours      []string
+		theirs    []string
+		want      []string
+		conflicts int
+	}{
+		{
+			name:   "changes to different lines",
+			ours:   []string{"theme = light", "font = mono", "size = 11", ""},
+			theirs: []string{"theme = dark", "font = mono", "size = 14", "vim_keys = true", ""},
+			want:   []string{"theme = light", "font = mono", "size = 14", "vim_keys = true", ""},
+		},
+		{
+			name:   "same change on both sides",
+			ours:   []string{"theme = light", "font = mono", "size = 11", ""},
+			theirs: []string{"theme = light", "font = mono", "size = 11", ""},
+			want:   []string{"theme = light", "font = mono", "size = 11", ""},
+		},
+		{
+			name:   "line removed on one side",
+			ours:   base,
+			theirs: []string{"theme = dark", "size = 11", ""},
+			want:   []string{"theme = dark", "size = 11", ""},
+		},
+		{
+			name:   "conflicting changes",
+			ours:   []string{"theme = light", "font = mono", "size = 11", ""},
+			theirs: []string{"theme = nord", "font = mono", "size = 11", ""},
+			want: []string{
+				domain.MergeMarkerOurs, "theme = light", domain.MergeMarkerSplit, "theme = nord", domain.MergeMarkerTheirs,
+				"font = mono", "size = 11", "",
+			},
+			conflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			merged, conflicts := domain.MergeLines(base, tt.ours, tt.theirs)
+			assert.Equal(t, tt.want, merged)
+			assert.Equal(t, tt.conflicts, conflicts)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"path/filepath"
+	"slices"
+)
+
+// corepackManagers are the package managers Node.js ships through corepack.
+var corepackManagers = []string{"pnpm", "yarn"} //nolint:gochecknoglobals
+
+// IsCorepackManager reports whether the npm package source is a package
+// manager corepack provides, which is enabled rather than installed from
+// the registry so it follows the version a project pins.
+func IsCorepackManager(source string) bool {
+	return slices.Contains(corepackManagers, source)
+}
+
+// NpmSpec returns the package spec npm installs for source: the package,
+// scoped or not, with the version appended unless it is empty or latest.
+func NpmSpec(source, version string) string {
+	if version == "" || version == "latest" {
+		return source
+	}
+
+	return source + "@" + version
+}
+
+// NpmPackageDir returns where npm puts source installed globally into
+// prefix, telling whether it is installed without running npm.
+func NpmPackageDir(prefix, source string) string {
+	return filepath.Join(prefix, "lib", "node_modules", filepath.FromSlash(source))
+}
+
+// NpmBinDir returns where npm links the commands of the packages installed
+// globally into prefix.
+func NpmBinDir(prefix string) string {
+	return filepath.Join(prefix, "bin")
+}
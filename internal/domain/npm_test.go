@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNpmSpec(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "prettier", domain.NpmSpec("prettier", ""))
+	assert.Equal(t, "prettier", domain.NpmSpec("prettier", "latest"))
+	assert.Equal(t, "@biomejs/biome@1.9.4", domain.NpmSpec("@biomejs/biome", "1.9.4"))
+	assert.Equal(t, "/prefix/lib/node_modules/@biomejs/biome", domain.NpmPackageDir("/prefix", "@biomejs/biome"))
+	assert.Equal(t, "/prefix/bin", domain.NpmBinDir("/prefix"))
+}
+
+func TestIsCorepackManager(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, domain.IsCorepackManager("pnpm"))
+	assert.True(t, domain.IsCorepackManager("yarn"))
+	assert.False(t, domain.IsCorepackManager("prettier"))
+}
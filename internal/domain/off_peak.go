@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidOffPeak is returned for an off-peak window not written as HH:MM-HH:MM.
+var ErrInvalidOffPeak = errors.New("invalid off-peak window, want HH:MM-HH:MM")
+
+// OffPeak is the time of day downloads wait for, such as 01:00-06:00 when a
+// metered plan counts no traffic. It may span midnight. The zero value
+// never makes downloads wait.
+type OffPeak struct {
+	Start time.Duration // Since midnight
+	End   time.Duration
+}
+
+// ParseOffPeak parses a window such as "01:00-06:00". Empty means none.
+func ParseOffPeak(value string) (OffPeak, error) {
+	if strings.TrimSpace(value) == "" {
+		return OffPeak{}, nil
+	}
+
+	from, to, found := strings.Cut(value, "-")
+	if !found {
+		return OffPeak{}, fmt.Errorf("%w: %s", ErrInvalidOffPeak, value)
+	}
+
+	start, startErr := parseClock(from)
+	end, endErr := parseClock(to)
+
+	if startErr != nil || endErr != nil || start == end {
+		return OffPeak{}, fmt.Errorf("%w: %s", ErrInvalidOffPeak, value)
+	}
+
+	return OffPeak{Start: start, End: end}, nil
+}
+
+// IsZero reports whether no window is set.
+func (w OffPeak) IsZero() bool {
+	return w.Start == w.End
+}
+
+// Wait returns how long from now until the window opens, zero inside it.
+func (w OffPeak) Wait(now time.Time) time.Duration {
+	if w.IsZero() {
+		return 0
+	}
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	clock := now.Sub(midnight)
+
+	inside := clock >= w.Start && clock < w.End
+	if w.Start > w.End {
+		inside = clock >= w.Start || clock < w.End
+	}
+
+	if inside {
+		return 0
+	}
+
+	opens := midnight.Add(w.Start)
+	if !opens.After(now) {
+		opens = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location()).Add(w.Start)
+	}
+
+	return opens.Sub(now)
+}
+
+// String formats the window as HH:MM-HH:MM.
+func (w OffPeak) String() string {
+	return formatClock(w.Start) + "-" + formatClock(w.End)
+}
+
+// parseClock parses HH:MM into the time since midnight.
+func parseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidOffPeak, value)
+	}
+
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// formatClock formats the time since midnight as HH:MM.
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
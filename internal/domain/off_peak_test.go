@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffPeakWait(t *testing.T) {
+	t.Parallel()
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 3, 10, hour, minute, 0, 0, time.UTC)
+	}
+
+	night, err := domain.ParseOffPeak("23:00-06:00")
+	require.NoError(t, err)
+	assert.Equal(t, "23:00-06:00", night.String())
+	assert.Zero(t, night.Wait(at(23, 30)))
+	assert.Zero(t, night.Wait(at(2, 0)))
+	assert.Equal(t, 2*time.Hour+30*time.Minute, night.Wait(at(20, 30)))
+
+	early, err := domain.ParseOffPeak("01:00-06:00")
+	require.NoError(t, err)
+	assert.Zero(t, early.Wait(at(1, 0)))
+	assert.Equal(t, 3*time.Hour, early.Wait(at(22, 0)), "the window opens the next day")
+	assert.Equal(t, 19*time.Hour, early.Wait(at(6, 0)))
+
+	none, err := domain.ParseOffPeak("")
+	require.NoError(t, err)
+	assert.True(t, none.IsZero())
+	assert.Zero(t, none.Wait(at(12, 0)))
+
+	for _, invalid := range []string{"1am-6am", "01:00", "25:00-06:00", "06:00-06:00"} {
+		_, err := domain.ParseOffPeak(invalid)
+		assert.ErrorIs(t, err, domain.ErrInvalidOffPeak, invalid)
+	}
+}
@@ -0,0 +1,13 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+// OutdatedPackage is an installed package its package manager has a newer
+// version of.
+type OutdatedPackage struct {
+	Method    InstallMethod `json:"method"`
+	Name      string        `json:"name"`
+	Installed string        `json:"installed,omitempty"`
+	Available string        `json:"available"`
+}
@@ -34,6 +34,18 @@ type InstallResult struct {
 	Skipped   []string      `json:"skipped,omitempty"`
 	Duration  time.Duration `json:"duration"`
 	Timestamp time.Time     `json:"timestamp"`
+
+	// SkipReasons says why each skipped package was not attempted, e.g. SkipOffline.
+	SkipReasons map[string]string `json:"skip_reasons,omitempty"`
+
+	// FailureKinds classifies each failure so scripts can decide whether to retry.
+	FailureKinds map[string]ErrorKind `json:"failure_kinds,omitempty"`
+
+	// Errors holds the failure cause per package for exit codes and hints.
+	Errors map[string]error `json:"-"`
+
+	// Lines holds the result per line when the packages came from a list.
+	Lines []ListedPackage `json:"lines,omitempty"`
 }
 
 // UninstallResult represents the outcome of an uninstallation operation.
@@ -43,6 +55,9 @@ type UninstallResult struct {
 	NotFound    []string      `json:"not_found,omitempty"`
 	Duration    time.Duration `json:"duration"`
 	Timestamp   time.Time     `json:"timestamp"`
+
+	// Lines holds the result per line when the packages came from a list.
+	Lines []ListedPackage `json:"lines,omitempty"`
 }
 
 // ListResult represents installed packages and their metadata.
@@ -64,14 +79,39 @@ type PackageInfo struct {
 
 // StatusResult represents system status information.
 type StatusResult struct {
-	Version      string            `json:"version"`
-	Platform     string            `json:"platform"`
-	Architecture string            `json:"architecture"`
-	Installed    int               `json:"installed_packages"`
-	Theme        string            `json:"current_theme"`
-	Font         string            `json:"current_font"`
-	Environment  map[string]string `json:"environment,omitempty"`
-	Timestamp    time.Time         `json:"timestamp"`
+	Version      string              `json:"version"`
+	Platform     string              `json:"platform"`
+	Architecture string              `json:"architecture"`
+	Installed    int                 `json:"installed_packages"`
+	Scopes       map[string][]string `json:"scopes,omitempty"` // Catalog apps karei installed, by scope
+	Theme        string              `json:"current_theme"`
+	Font         string              `json:"current_font"`
+	Environment  map[string]string   `json:"environment,omitempty"`
+	Timestamp    time.Time           `json:"timestamp"`
+}
+
+// AppInfoResult describes a catalog application and how it relates to others.
+type AppInfoResult struct {
+	Key          string           `json:"key"`
+	Name         string           `json:"name"`
+	Group        string           `json:"group"`
+	Description  string           `json:"description"`
+	Method       string           `json:"method"`
+	Source       string           `json:"source"`
+	License      License          `json:"license"`
+	Origin       Origin           `json:"origin"`
+	Channel      ReleaseChannel   `json:"channel,omitempty"` // GitHub releases considered latest
+	Blocked      string           `json:"blocked,omitempty"` // Why the install policy forbids the app
+	Aliases      []string         `json:"aliases,omitempty"`
+	Dependencies []DependencyNode `json:"dependencies,omitempty"`
+	Dependents   []string         `json:"dependents,omitempty"`
+	Conflicts    []string         `json:"conflicts,omitempty"`
+}
+
+// DependencyNode is an application in a dependency tree.
+type DependencyNode struct {
+	Key          string           `json:"key"`
+	Dependencies []DependencyNode `json:"dependencies,omitempty"`
 }
 
 // VerifyResult represents system verification results.
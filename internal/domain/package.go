@@ -6,6 +6,8 @@ package domain
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -14,12 +16,20 @@ var (
 	ErrInvalidPackage = errors.New("invalid package")
 	// ErrPackageNotFound indicates the package was not found.
 	ErrPackageNotFound = errors.New("package not found")
+	// ErrPackageConflict indicates the package clashes with one already installed.
+	ErrPackageConflict = errors.New("package conflict")
 	// ErrUnsupportedInstallMethod indicates the installation method is not supported.
 	ErrUnsupportedInstallMethod = errors.New("unsupported installation method")
 	// ErrUnsupportedRemoveMethod indicates the removal method is not supported.
 	ErrUnsupportedRemoveMethod = errors.New("unsupported removal method")
+	// ErrUnsupportedUpdateMethod indicates packages of the method cannot be updated in place.
+	ErrUnsupportedUpdateMethod = errors.New("unsupported update method")
 	// ErrInsufficientSpace indicates there is not enough disk space for installation.
 	ErrInsufficientSpace = errors.New("insufficient disk space")
+	// ErrUnknownInstallMethod indicates a method name that karei does not know.
+	ErrUnknownInstallMethod = errors.New("unknown installation method")
+	// ErrUnknownInstallScope indicates a scope other than user or system.
+	ErrUnknownInstallScope = errors.New("unknown installation scope")
 )
 
 // InstallMethod represents different installation methods.
@@ -44,17 +54,158 @@ const (
 	MethodBinary       InstallMethod = "binary"
 	MethodAqua         InstallMethod = "aqua"
 	MethodMise         InstallMethod = "mise"
+	MethodNpm          InstallMethod = "npm"
 )
 
+// knownMethods lists every method accepted in user preferences.
+var knownMethods = []InstallMethod{ //nolint:gochecknoglobals
+	MethodAPT, MethodDNF, MethodYum, MethodPacman, MethodZypper, MethodSnap, MethodFlatpak,
+	MethodGitHub, MethodGitHubBinary, MethodGitHubBundle, MethodGitHubJava, MethodDEB,
+	MethodRPM, MethodScript, MethodBinary, MethodAqua, MethodMise, MethodNpm,
+}
+
+// rootMethods lists methods that write to system locations and therefore need sudo.
+// Flatpak, mise, aqua, npm and the binary/GitHub methods install into the user's home.
+var rootMethods = []InstallMethod{ //nolint:gochecknoglobals
+	MethodAPT, MethodDNF, MethodYum, MethodPacman, MethodZypper, MethodSnap,
+	MethodDEB, MethodRPM, MethodScript,
+}
+
+// RequiresRoot reports whether installing with the method needs elevated privileges.
+func (m InstallMethod) RequiresRoot() bool {
+	return slices.Contains(rootMethods, m)
+}
+
+// IsGitHub reports whether the method downloads GitHub releases.
+func (m InstallMethod) IsGitHub() bool {
+	return strings.HasPrefix(string(m), string(MethodGitHub))
+}
+
+// TypicalDownloadSize returns roughly how many bytes installing an app with
+// the method downloads, for warning about large downloads before they start.
+func (m InstallMethod) TypicalDownloadSize() int64 {
+	const mb = 1_000_000
+
+	switch {
+	case m == MethodFlatpak:
+		return 300 * mb // Runtimes are shared, but the first app brings one
+	case m == MethodSnap, m == MethodDEB, m == MethodRPM, m == MethodGitHubBundle, m == MethodGitHubJava:
+		return 150 * mb
+	case m == MethodMise:
+		return 50 * mb
+	case m == MethodAqua:
+		return 10 * mb
+	default:
+		return 30 * mb
+	}
+}
+
+// MethodPreference lists installation methods to favor, most preferred first.
+// Apps offered through a preferred method use it instead of their default.
+type MethodPreference []InstallMethod
+
+// ParseMethodPreference parses a comma-separated list such as "flatpak,mise".
+func ParseMethodPreference(value string) (MethodPreference, error) {
+	var preference MethodPreference
+
+	for _, name := range strings.Split(value, ",") {
+		method := InstallMethod(strings.TrimSpace(name))
+		if method == "" {
+			continue
+		}
+
+		if !slices.Contains(knownMethods, method) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownInstallMethod, method)
+		}
+
+		preference = append(preference, method)
+	}
+
+	return preference, nil
+}
+
+// InstallScope selects whether a package is installed for the current user or system-wide.
+type InstallScope string
+
+// Installation scopes.
+const (
+	ScopeUser   InstallScope = "user"
+	ScopeSystem InstallScope = "system"
+)
+
+// ParseInstallScope parses "user" or "system". An empty value is returned as is.
+func ParseInstallScope(value string) (InstallScope, error) {
+	scope := InstallScope(strings.TrimSpace(value))
+
+	switch scope {
+	case "", ScopeUser, ScopeSystem:
+		return scope, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownInstallScope, value)
+	}
+}
+
+// Scope returns where the method installs: for the user, or system-wide when
+// it needs root. Flatpak supports both and reports ScopeUser here.
+func (m InstallMethod) Scope() InstallScope {
+	if m.RequiresRoot() {
+		return ScopeSystem
+	}
+
+	return ScopeUser
+}
+
+// FlatpakScopes chooses between `flatpak --user` and system installations,
+// per app or globally. Apps not listed use Default, which itself defaults to user.
+type FlatpakScopes struct {
+	Default InstallScope
+	Apps    map[string]InstallScope
+}
+
+// ParseFlatpakScopes validates a global scope and per-app overrides keyed by catalog name.
+func ParseFlatpakScopes(defaultScope string, apps map[string]string) (FlatpakScopes, error) {
+	scope, err := ParseInstallScope(defaultScope)
+	if err != nil {
+		return FlatpakScopes{}, err
+	}
+
+	scopes := FlatpakScopes{Default: scope, Apps: make(map[string]InstallScope, len(apps))}
+
+	for app, value := range apps {
+		if scopes.Apps[app], err = ParseInstallScope(value); err != nil {
+			return FlatpakScopes{}, fmt.Errorf("flatpak app %s: %w", app, err)
+		}
+	}
+
+	return scopes, nil
+}
+
+// For returns the installation scope to use for the app.
+func (s FlatpakScopes) For(appKey string) InstallScope {
+	if scope := s.Apps[appKey]; scope != "" {
+		return scope
+	}
+
+	if s.Default != "" {
+		return s.Default
+	}
+
+	return ScopeUser
+}
+
 // Package represents a software package to be installed.
 type Package struct {
-	Name         string        `json:"name"`
-	Group        string        `json:"group"`
-	Description  string        `json:"description"`
-	Method       InstallMethod `json:"method"`
-	Source       string        `json:"source"`
-	Version      string        `json:"version,omitempty"`
-	Dependencies []string      `json:"dependencies,omitempty"`
+	Name         string         `json:"name"`
+	Group        string         `json:"group"`
+	Description  string         `json:"description"`
+	Method       InstallMethod  `json:"method"`
+	Source       string         `json:"source"`
+	Version      string         `json:"version,omitempty"`
+	Scope        InstallScope   `json:"scope,omitempty"`
+	Channel      ReleaseChannel `json:"channel,omitempty"` // GitHub releases considered, stable when empty
+	Size         int64          `json:"size,omitempty"`    // Installed size in bytes, when known
+	SHA256       string         `json:"sha256,omitempty"`  // Checksum the downloaded file must have, when pinned
+	Dependencies []string       `json:"dependencies,omitempty"`
 }
 
 // IsValid validates the package has required fields.
@@ -69,11 +220,12 @@ func (p *Package) IsValid() bool {
 
 // InstallationResult represents the result of a package installation.
 type InstallationResult struct {
-	Package  *Package `json:"package"`
-	Success  bool     `json:"success"`
-	Error    error    `json:"error,omitempty"`
-	Duration int64    `json:"duration_ms"`
-	Output   string   `json:"output,omitempty"`
+	Package   *Package   `json:"package"`
+	Success   bool       `json:"success"`
+	Error     error      `json:"error,omitempty"`
+	Duration  int64      `json:"duration_ms"`
+	Output    string     `json:"output,omitempty"`
+	Artifacts []Artifact `json:"artifacts,omitempty"` // Files created besides the package itself
 }
 
 // PackageService provides core package management operations.
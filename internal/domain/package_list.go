@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidPackageList indicates a package list line naming more than one package.
+var ErrInvalidPackageList = errors.New("invalid package list")
+
+// ListedPackage is a package named on a line of a package list, with the
+// status it ended with once installed or uninstalled.
+type ListedPackage struct {
+	Line    int    `json:"line"`
+	Package string `json:"package"`
+	Status  string `json:"status,omitempty"`
+}
+
+// ParsePackageList reads a package list, one package per line as in
+// cat tools.txt | karei install --stdin. Blank lines and # comments, whole
+// lines or trailing, are ignored, as are packages listed again.
+func ParsePackageList(r io.Reader) ([]ListedPackage, error) {
+	var (
+		listed []ListedPackage
+		errs   []error
+	)
+
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+
+		fields := strings.Fields(text)
+		switch {
+		case len(fields) == 0:
+			continue
+		case len(fields) > 1:
+			errs = append(errs, fmt.Errorf("%w: line %d: one package per line, got %q", ErrInvalidPackageList, line, strings.TrimSpace(text)))
+
+			continue
+		}
+
+		if !slices.ContainsFunc(listed, func(p ListedPackage) bool { return p.Package == fields[0] }) {
+			listed = append(listed, ListedPackage{Line: line, Package: fields[0]})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read package list: %w", err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return listed, nil
+}
+
+// MarkListed sets the status of the listed packages among packages.
+func MarkListed(listed []ListedPackage, status string, packages []string) {
+	for i := range listed {
+		if slices.Contains(packages, listed[i].Package) {
+			listed[i].Status = status
+		}
+	}
+}
+
+// ListedPackageNames returns the packages of listed, in list order.
+func ListedPackageNames(listed []ListedPackage) []string {
+	names := make([]string, 0, len(listed))
+	for _, p := range listed {
+		names = append(names, p.Package)
+	}
+
+	return names
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePackageList(t *testing.T) {
+	t.Parallel()
+
+	list := "# Tools for the team\ngit\n\n  vim   # editor\nlazygit\ngit\n"
+
+	listed, err := domain.ParsePackageList(strings.NewReader(list))
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ListedPackage{
+		{Line: 2, Package: "git"},
+		{Line: 4, Package: "vim"},
+		{Line: 5, Package: "lazygit"},
+	}, listed)
+	assert.Equal(t, []string{"git", "vim", "lazygit"}, domain.ListedPackageNames(listed))
+
+	domain.MarkListed(listed, "installed", []string{"git", "lazygit"})
+	domain.MarkListed(listed, "failed", []string{"vim"})
+	assert.Equal(t, "installed", listed[0].Status)
+	assert.Equal(t, "failed", listed[1].Status)
+}
+
+func TestParsePackageListInvalidLines(t *testing.T) {
+	t.Parallel()
+
+	_, err := domain.ParsePackageList(strings.NewReader("git vim\ncurl\nfd rg # search\n"))
+	require.ErrorIs(t, err, domain.ErrInvalidPackageList)
+	assert.Contains(t, err.Error(), "line 1")
+	assert.Contains(t, err.Error(), "line 3")
+	assert.NotContains(t, err.Error(), "line 2")
+}
@@ -395,3 +395,60 @@ func TestPackageVersionSpecification(t *testing.T) {
 		})
 	}
 }
+
+// TestParseMethodPreference tests that user method preferences are validated and ordered.
+func TestParseMethodPreference(t *testing.T) {
+	t.Parallel()
+
+	preference, err := domain.ParseMethodPreference(" flatpak, mise ,")
+	require.NoError(t, err)
+	assert.Equal(t, domain.MethodPreference{domain.MethodFlatpak, domain.MethodMise}, preference)
+
+	preference, err = domain.ParseMethodPreference("")
+	require.NoError(t, err)
+	assert.Empty(t, preference)
+
+	_, err = domain.ParseMethodPreference("flatpak,brew")
+	require.ErrorIs(t, err, domain.ErrUnknownInstallMethod)
+}
+
+// TestInstallMethodRequiresRoot tests which methods need sudo to install.
+func TestInstallMethodRequiresRoot(t *testing.T) {
+	t.Parallel()
+
+	for _, method := range []domain.InstallMethod{domain.MethodAPT, domain.MethodDEB, domain.MethodSnap, domain.MethodScript} {
+		assert.True(t, method.RequiresRoot(), method)
+	}
+
+	for _, method := range []domain.InstallMethod{domain.MethodFlatpak, domain.MethodMise, domain.MethodAqua, domain.MethodBinary, domain.MethodGitHubBinary} {
+		assert.False(t, method.RequiresRoot(), method)
+	}
+}
+
+// TestParseFlatpakScopes tests global and per-app flatpak installation scopes.
+func TestParseFlatpakScopes(t *testing.T) {
+	t.Parallel()
+
+	scopes, err := domain.ParseFlatpakScopes("", nil)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScopeUser, scopes.For("gimp"))
+
+	scopes, err = domain.ParseFlatpakScopes("system", map[string]string{"gimp": "user"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScopeUser, scopes.For("gimp"))
+	assert.Equal(t, domain.ScopeSystem, scopes.For("vlc"))
+
+	_, err = domain.ParseFlatpakScopes("global", nil)
+	require.ErrorIs(t, err, domain.ErrUnknownInstallScope)
+
+	_, err = domain.ParseFlatpakScopes("user", map[string]string{"gimp": "everyone"})
+	require.ErrorIs(t, err, domain.ErrUnknownInstallScope)
+}
+
+func TestTypicalDownloadSize(t *testing.T) {
+	t.Parallel()
+
+	assert.Greater(t, domain.MethodFlatpak.TypicalDownloadSize(), domain.MethodAPT.TypicalDownloadSize())
+	assert.Greater(t, domain.MethodAPT.TypicalDownloadSize(), domain.MethodAqua.TypicalDownloadSize())
+	assert.Positive(t, domain.InstallMethod("unknown").TypicalDownloadSize())
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidColor is returned for palette entries that are no known color or no hex color.
+var ErrInvalidColor = errors.New("invalid color")
+
+// hexColor matches #rgb and #rrggbb colors.
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ThemePalette holds the colors karei's own interface uses with a theme, as
+// hex strings. Empty colors are left to the default palette.
+type ThemePalette struct {
+	Primary    string `json:"primary,omitempty"`
+	Secondary  string `json:"secondary,omitempty"`
+	Success    string `json:"success,omitempty"`
+	Warning    string `json:"warning,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Info       string `json:"info,omitempty"`
+	Muted      string `json:"muted,omitempty"`
+	Background string `json:"background,omitempty"`
+	Foreground string `json:"foreground,omitempty"`
+}
+
+// ParseThemePalette parses colors keyed by name, such as primary = "#ff9e64".
+func ParseThemePalette(colors map[string]string) (ThemePalette, error) {
+	var palette ThemePalette
+
+	for name, color := range colors {
+		if !hexColor.MatchString(color) {
+			return ThemePalette{}, fmt.Errorf("%w: %s = %q, expected #rrggbb", ErrInvalidColor, name, color)
+		}
+
+		field := palette.field(strings.ToLower(name))
+		if field == nil {
+			return ThemePalette{}, fmt.Errorf("%w: unknown color %q", ErrInvalidColor, name)
+		}
+
+		*field = color
+	}
+
+	return palette, nil
+}
+
+// Override returns the palette with the colors set in other replacing its own.
+func (p ThemePalette) Override(other ThemePalette) ThemePalette {
+	for _, name := range paletteColors {
+		if color := *other.field(name); color != "" {
+			*p.field(name) = color
+		}
+	}
+
+	return p
+}
+
+// paletteColors names the colors of a palette.
+var paletteColors = []string{"primary", "secondary", "success", "warning", "error", "info", "muted", "background", "foreground"} //nolint:gochecknoglobals
+
+// field returns the color called name, or nil for unknown names.
+func (p *ThemePalette) field(name string) *string {
+	switch name {
+	case "primary":
+		return &p.Primary
+	case "secondary":
+		return &p.Secondary
+	case "success":
+		return &p.Success
+	case "warning":
+		return &p.Warning
+	case "error":
+		return &p.Error
+	case "info":
+		return &p.Info
+	case "muted":
+		return &p.Muted
+	case "background":
+		return &p.Background
+	case "foreground":
+		return &p.Foreground
+	default:
+		return nil
+	}
+}
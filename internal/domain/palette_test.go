@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseThemePalette tests that colors are assigned by name and validated.
+func TestParseThemePalette(t *testing.T) {
+	t.Parallel()
+
+	palette, err := domain.ParseThemePalette(map[string]string{"Primary": "#ff9e64", "muted": "#333"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ThemePalette{Primary: "#ff9e64", Muted: "#333"}, palette)
+
+	_, err = domain.ParseThemePalette(map[string]string{"primary": "orange"})
+	require.ErrorIs(t, err, domain.ErrInvalidColor)
+
+	_, err = domain.ParseThemePalette(map[string]string{"accent": "#ff9e64"})
+	require.ErrorIs(t, err, domain.ErrInvalidColor)
+}
+
+// TestThemePaletteOverride tests that only colors set in the override replace the base.
+func TestThemePaletteOverride(t *testing.T) {
+	t.Parallel()
+
+	base := domain.ThemePalette{Primary: "#7aa2f7", Success: "#9ece6a"}
+
+	assert.Equal(t, domain.ThemePalette{Primary: "#ff9e64", Success: "#9ece6a", Error: "#ff0000"},
+		base.Override(domain.ThemePalette{Primary: "#ff9e64", Error: "#ff0000"}))
+	assert.Equal(t, base, base.Override(domain.ThemePalette{}))
+}
@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SSHIncludeLine makes ssh read the agent configuration karei writes for
+// password managers, relative to ~/.ssh. It has to come before any Host
+// block to apply to every host.
+const SSHIncludeLine = "Include config.d/karei-*.conf"
+
+// PasswordManager is a password manager CLI karei installs and integrates:
+// ssh uses its agent while it runs, the browser extension reaches the vault
+// through a native messaging host, and reading a secret is checked.
+type PasswordManager struct {
+	Key     string `json:"key"` // Catalog key
+	Name    string `json:"name"`
+	Command string `json:"command"`
+
+	// AgentSocket is the SSH agent the manager runs, relative to the home
+	// directory or under ${XDG_RUNTIME_DIR}, or empty without one.
+	AgentSocket string `json:"agent_socket,omitempty"`
+
+	// BrowserHost is the native messaging host of the browser extension,
+	// installed with BrowserApp, or empty when the extension works alone.
+	BrowserHost string `json:"browser_host,omitempty"`
+	BrowserApp  string `json:"browser_app,omitempty"`
+
+	Setup string `json:"setup,omitempty"` // What is left to do by hand
+}
+
+// PasswordManagers are the password manager CLIs karei integrates.
+func PasswordManagers() []PasswordManager {
+	return []PasswordManager{
+		{
+			Key:         "1password-cli",
+			Name:        "1Password CLI",
+			Command:     "op",
+			AgentSocket: "~/.1password/agent.sock",
+			Setup:       "in the 1Password app, turn on Settings > Developer > Use the SSH agent and Integrate with 1Password CLI",
+		},
+		{
+			Key:         "bitwarden-cli",
+			Name:        "Bitwarden CLI",
+			Command:     "bw",
+			AgentSocket: "~/.bitwarden-ssh-agent.sock",
+			Setup:       "run bw login, and turn on Settings > Enable SSH agent in the Bitwarden app for SSH keys",
+		},
+		{
+			Key:         "pass",
+			Name:        "pass",
+			Command:     "pass",
+			AgentSocket: "${XDG_RUNTIME_DIR}/gnupg/S.gpg-agent.ssh",
+			BrowserHost: "com.github.browserpass.native",
+			BrowserApp:  "browserpass",
+			Setup:       "run pass init <gpg-id>, and add SSH keys to gpg-agent with ssh-add",
+		},
+	}
+}
+
+// PasswordManagerFor returns the password manager installed as the catalog
+// app key.
+func PasswordManagerFor(key string) (PasswordManager, bool) {
+	for _, manager := range PasswordManagers() {
+		if manager.Key == key {
+			return manager, true
+		}
+	}
+
+	return PasswordManager{}, false
+}
+
+// SSHConfig returns the ssh configuration using the manager's agent, for
+// every host but only while the agent's socket exists, so ssh keeps working
+// with the default agent when the manager isn't running.
+func (m PasswordManager) SSHConfig() string {
+	if m.AgentSocket == "" {
+		return ""
+	}
+
+	return "# Written by karei: use the " + m.Name + " SSH agent while it runs\n" +
+		"Match host * exec \"test -S " + m.AgentSocket + "\"\n" +
+		"    IdentityAgent " + m.AgentSocket + "\n"
+}
+
+// StatusArgs returns the arguments of Command reporting whether the vault can
+// be read: signed in, unlocked or initialized.
+func (m PasswordManager) StatusArgs() []string {
+	switch m.Command {
+	case "op":
+		return []string{"whoami"}
+	case "bw":
+		return []string{"status"}
+	default:
+		return []string{"ls"}
+	}
+}
+
+// ReadArgs returns the arguments of Command printing the secret entry, such
+// as op://Private/GitHub/token for 1Password or an item name otherwise.
+func (m PasswordManager) ReadArgs(entry string) []string {
+	switch m.Command {
+	case "op":
+		return []string{"read", entry}
+	case "bw":
+		return []string{"get", "password", entry}
+	default:
+		return []string{"show", entry}
+	}
+}
+
+// VaultReady reads the output of the StatusArgs command, which succeeded, and
+// reports whether secrets can be read, with why not otherwise. Only bw
+// succeeds while the vault is locked.
+func (m PasswordManager) VaultReady(output string) (bool, string) {
+	if m.Command != "bw" {
+		return true, ""
+	}
+
+	var status struct {
+		Status string `json:"status"`
+	}
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &status); err != nil {
+		return false, "unreadable bw status"
+	}
+
+	switch status.Status {
+	case "unlocked":
+		return true, ""
+	case "locked":
+		return false, "the vault is locked, run bw unlock and export BW_SESSION"
+	default:
+		return false, "not logged in, run bw login"
+	}
+}
+
+// AddSSHInclude returns the ssh configuration config with SSHIncludeLine
+// prepended, and whether it lacked it.
+func AddSSHInclude(config string) (string, bool) {
+	for line := range strings.SplitSeq(config, "\n") {
+		if strings.TrimSpace(line) == SSHIncludeLine {
+			return config, false
+		}
+	}
+
+	if strings.TrimSpace(config) == "" {
+		return SSHIncludeLine + "\n", true
+	}
+
+	return SSHIncludeLine + "\n\n" + config, true
+}
+
+// NativeMessagingHostDirs are where browsers look up native messaging hosts,
+// per user below home and system-wide, for Firefox and Chrome or Chromium.
+func NativeMessagingHostDirs(home string) []string {
+	return []string{
+		home + "/.mozilla/native-messaging-hosts",
+		home + "/.config/google-chrome/NativeMessagingHosts",
+		home + "/.config/chromium/NativeMessagingHosts",
+		"/usr/lib/mozilla/native-messaging-hosts",
+		"/etc/opt/chrome/native-messaging-hosts",
+		"/etc/chromium/native-messaging-hosts",
+	}
+}
+
+// PasswordManagerCheck is how far a password manager's integration works.
+type PasswordManagerCheck struct {
+	Manager       PasswordManager `json:"manager"`
+	SSHConfigured bool            `json:"ssh_configured"` // ssh uses the agent while it runs
+	AgentRunning  bool            `json:"agent_running"`
+	BrowserHost   bool            `json:"browser_host"` // The extension's native host is installed, or none is needed
+	VaultReady    bool            `json:"vault_ready"`
+	Read          bool            `json:"read"` // The entry asked for was read
+	Problems      []string        `json:"problems,omitempty"`
+}
+
+// Works reports whether secrets can be read, the entry asked for included.
+// The SSH agent and browser host are optional and only reported.
+func (c PasswordManagerCheck) Works(entry string) bool {
+	return c.VaultReady && (entry == "" || c.Read)
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordManagerSSHConfig(t *testing.T) {
+	t.Parallel()
+
+	manager, ok := domain.PasswordManagerFor("1password-cli")
+	require.True(t, ok)
+	assert.Equal(t, []string{"read", "op://Private/GitHub/token"}, manager.ReadArgs("op://Private/GitHub/token"))
+	assert.Contains(t, manager.SSHConfig(), "Match host * exec \"test -S ~/.1password/agent.sock\"\n")
+	assert.Contains(t, manager.SSHConfig(), "    IdentityAgent ~/.1password/agent.sock\n")
+
+	_, ok = domain.PasswordManagerFor("keepassxc")
+	assert.False(t, ok)
+}
+
+func TestPasswordManagerVaultReady(t *testing.T) {
+	t.Parallel()
+
+	bw, _ := domain.PasswordManagerFor("bitwarden-cli")
+
+	ready, _ := bw.VaultReady(`{"serverUrl":null,"status":"unlocked"}`)
+	assert.True(t, ready)
+
+	ready, problem := bw.VaultReady(`{"status":"locked"}`)
+	assert.False(t, ready)
+	assert.Contains(t, problem, "bw unlock")
+
+	pass, _ := domain.PasswordManagerFor("pass")
+	ready, _ = pass.VaultReady("Password Store\n└── github\n")
+	assert.True(t, ready)
+}
+
+func TestAddSSHInclude(t *testing.T) {
+	t.Parallel()
+
+	config, added := domain.AddSSHInclude("")
+	assert.True(t, added)
+	assert.Equal(t, domain.SSHIncludeLine+"\n", config)
+
+	config, added = domain.AddSSHInclude("Host example\n    User me\n")
+	assert.True(t, added)
+	assert.Equal(t, domain.SSHIncludeLine+"\n\nHost example\n    User me\n", config)
+
+	_, added = domain.AddSSHInclude(config)
+	assert.False(t, added)
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrBlockedByPolicy is returned when the install policy does not allow an application.
+	ErrBlockedByPolicy = errors.New("blocked by install policy")
+	// ErrUnknownPolicyRule is returned for policy entries that are no license, method or origin.
+	ErrUnknownPolicyRule = errors.New("unknown policy rule")
+)
+
+// License tells whether an application's source code is open.
+type License string
+
+// Licenses. Catalog entries without a license are open source.
+const (
+	LicenseOpenSource  License = "open-source"
+	LicenseProprietary License = "proprietary"
+)
+
+// Origin is where an application's files are downloaded from.
+type Origin string
+
+// Origins of installed files.
+const (
+	OriginDistribution Origin = "distribution" // The distribution's package archive
+	OriginFlathub      Origin = "flathub"
+	OriginSnapStore    Origin = "snap-store"
+	OriginVendor       Origin = "vendor"   // The publisher's own download or script
+	OriginGitHub       Origin = "github"   // GitHub release assets
+	OriginRegistry     Origin = "registry" // The mise, aqua or npm registries
+)
+
+// OriginOf returns where packages installed with method come from.
+func OriginOf(method InstallMethod) Origin {
+	switch method {
+	case MethodAPT, MethodDNF, MethodYum, MethodPacman, MethodZypper:
+		return OriginDistribution
+	case MethodFlatpak:
+		return OriginFlathub
+	case MethodSnap:
+		return OriginSnapStore
+	case MethodGitHub, MethodGitHubBinary, MethodGitHubBundle, MethodGitHubJava:
+		return OriginGitHub
+	case MethodMise, MethodAqua, MethodNpm:
+		return OriginRegistry
+	default:
+		return OriginVendor
+	}
+}
+
+// knownLicenses lists every license accepted in a policy.
+var knownLicenses = []License{LicenseOpenSource, LicenseProprietary} //nolint:gochecknoglobals
+
+// knownOrigins lists every origin accepted in a policy.
+var knownOrigins = []Origin{ //nolint:gochecknoglobals
+	OriginDistribution, OriginFlathub, OriginSnapStore, OriginVendor, OriginGitHub, OriginRegistry,
+}
+
+// InstallPolicy lists the licenses, install methods and origins a workplace
+// does not allow. The zero value allows everything.
+type InstallPolicy struct {
+	DenyLicenses []License
+	DenyMethods  []InstallMethod
+	DenyOrigins  []Origin
+}
+
+// ParseInstallPolicy sorts denied values such as "proprietary", "snap" or
+// "vendor" into licenses, methods and origins.
+func ParseInstallPolicy(deny []string) (InstallPolicy, error) {
+	var policy InstallPolicy
+
+	for _, value := range deny {
+		value = strings.ToLower(strings.TrimSpace(value))
+
+		switch {
+		case value == "":
+		case slices.Contains(knownLicenses, License(value)):
+			policy.DenyLicenses = append(policy.DenyLicenses, License(value))
+		case slices.Contains(knownMethods, InstallMethod(value)):
+			policy.DenyMethods = append(policy.DenyMethods, InstallMethod(value))
+		case slices.Contains(knownOrigins, Origin(value)):
+			policy.DenyOrigins = append(policy.DenyOrigins, Origin(value))
+		default:
+			return InstallPolicy{}, fmt.Errorf("%w: %s", ErrUnknownPolicyRule, value)
+		}
+	}
+
+	return policy, nil
+}
+
+// AllowsLicense reports whether apps with the license may be installed.
+func (p InstallPolicy) AllowsLicense(license License) bool {
+	if license == "" {
+		license = LicenseOpenSource
+	}
+
+	return !slices.Contains(p.DenyLicenses, license)
+}
+
+// AllowsMethod reports whether installing with the method is allowed, by
+// method and by the origin it downloads from.
+func (p InstallPolicy) AllowsMethod(method InstallMethod) bool {
+	return !slices.Contains(p.DenyMethods, method) && !slices.Contains(p.DenyOrigins, OriginOf(method))
+}
+
+// Check returns ErrBlockedByPolicy, with the reason, when the policy does not
+// allow installing an app with the license through the method.
+func (p InstallPolicy) Check(license License, method InstallMethod) error {
+	if license == "" {
+		license = LicenseOpenSource
+	}
+
+	switch {
+	case !p.AllowsLicense(license):
+		return fmt.Errorf("%w: %s apps are not allowed", ErrBlockedByPolicy, license)
+	case slices.Contains(p.DenyMethods, method):
+		return fmt.Errorf("%w: %s installs are not allowed", ErrBlockedByPolicy, method)
+	case slices.Contains(p.DenyOrigins, OriginOf(method)):
+		return fmt.Errorf("%w: downloads from %s are not allowed", ErrBlockedByPolicy, OriginOf(method))
+	}
+
+	return nil
+}
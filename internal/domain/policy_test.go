@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseInstallPolicy tests that denied values are sorted into licenses, methods and origins.
+func TestParseInstallPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := domain.ParseInstallPolicy([]string{" Proprietary", "snap", "vendor", ""})
+	require.NoError(t, err)
+	assert.Equal(t, domain.InstallPolicy{
+		DenyLicenses: []domain.License{domain.LicenseProprietary},
+		DenyMethods:  []domain.InstallMethod{domain.MethodSnap},
+		DenyOrigins:  []domain.Origin{domain.OriginVendor},
+	}, policy)
+
+	_, err = domain.ParseInstallPolicy([]string{"closed"})
+	require.ErrorIs(t, err, domain.ErrUnknownPolicyRule)
+}
+
+// TestInstallPolicyCheck tests the reasons given for blocked installs.
+func TestInstallPolicyCheck(t *testing.T) {
+	t.Parallel()
+
+	policy, err := domain.ParseInstallPolicy([]string{"proprietary", "snap", "vendor"})
+	require.NoError(t, err)
+
+	require.NoError(t, policy.Check("", domain.MethodAPT), "unlabelled apps are open source")
+	require.NoError(t, domain.InstallPolicy{}.Check(domain.LicenseProprietary, domain.MethodSnap))
+
+	err = policy.Check(domain.LicenseProprietary, domain.MethodFlatpak)
+	require.ErrorIs(t, err, domain.ErrBlockedByPolicy)
+	assert.Contains(t, err.Error(), "proprietary apps are not allowed")
+
+	assert.ErrorContains(t, policy.Check("", domain.MethodSnap), "snap installs are not allowed")
+	assert.ErrorContains(t, policy.Check("", domain.MethodDEB), "downloads from vendor are not allowed")
+	assert.False(t, policy.AllowsMethod(domain.MethodScript))
+	assert.True(t, policy.AllowsMethod(domain.MethodMise))
+}
@@ -6,6 +6,7 @@ package domain
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 // Common domain errors.
@@ -34,6 +35,76 @@ type PackageInstaller interface {
 	GetBestMethod(source string) InstallMethod
 }
 
+// AppUninstaller defines the use-case port for removing catalog applications.
+// Implemented by the application layer and consumed by the CLI and TUI.
+type AppUninstaller interface {
+	// UninstallApp removes a single catalog application by key.
+	UninstallApp(ctx context.Context, name string) error
+
+	// UninstallGroup removes every application in a catalog group.
+	UninstallGroup(ctx context.Context, group string) error
+}
+
+// InstallationChecker reports whether catalog applications are installed.
+type InstallationChecker interface {
+	// IsInstalled checks a catalog application by key.
+	IsInstalled(ctx context.Context, appKey string) bool
+}
+
+// VersionResolver looks up the installed version of an application.
+type VersionResolver interface {
+	// ResolveVersion returns the installed version of name as reported by the
+	// package manager identified by source (apt, flatpak, mise, ...).
+	// An empty string means the version could not be determined.
+	ResolveVersion(ctx context.Context, name, source string) (string, error)
+}
+
+// ChecksumResolver fingerprints installed applications.
+type ChecksumResolver interface {
+	// ResolveChecksum returns the hex SHA-256 of what name installed through
+	// source: the flatpak commit, or the executable found on PATH.
+	// An empty string means nothing was found to fingerprint.
+	ResolveChecksum(ctx context.Context, name, source string) (string, error)
+}
+
+// Trash keeps configuration purged during uninstall so it can be restored.
+type Trash interface {
+	// Move moves paths into a new entry for app.
+	Move(app string, paths []string) (*TrashEntry, error)
+
+	// List returns the entries, oldest first.
+	List() ([]TrashEntry, error)
+
+	// Restore moves the files of an entry back and deletes the entry.
+	Restore(id string) (*TrashEntry, error)
+
+	// Empty permanently deletes all entries.
+	Empty() error
+}
+
+// SecretStore keeps credentials such as tokens and webhook URLs out of
+// plaintext configuration.
+type SecretStore interface {
+	// Get returns the secret name, or ErrSecretNotFound.
+	Get(name string) (string, error)
+
+	// Set stores value as the secret name, replacing any previous value.
+	Set(name, value string) error
+
+	// Delete removes the secret name, or returns ErrSecretNotFound.
+	Delete(name string) error
+
+	// Backend describes where secrets are kept, for messages.
+	Backend() string
+}
+
+// VulnerabilityFeed looks up published security advisories.
+type VulnerabilityFeed interface {
+	// Vulnerabilities returns the advisories affecting the installed version
+	// of pkg, or ErrNoAdvisoryData when the feed doesn't cover it.
+	Vulnerabilities(ctx context.Context, pkg ManagedPackage) ([]Vulnerability, error)
+}
+
 // SystemDetector defines the interface for system detection operations.
 type SystemDetector interface {
 	// DetectSystem returns system information.
@@ -49,6 +120,22 @@ type SystemDetector interface {
 	DetectPackageManager(ctx context.Context) (*PackageManager, error)
 }
 
+// DesktopThemer applies the desktop-wide parts of a theme for a desktop
+// environment other than GNOME, which the theme service drives itself.
+type DesktopThemer interface {
+	// Desktop names the desktop environment, such as "KDE Plasma".
+	Desktop() string
+
+	// ApplyAppearance sets the color scheme, icons, widget style and accent.
+	ApplyAppearance(ctx context.Context, appearance DesktopAppearance) error
+
+	// ApplyBackground sets the wallpaper to the image at path.
+	ApplyBackground(ctx context.Context, path string) error
+
+	// ApplyTerminalColors makes colors the default terminal profile's scheme.
+	ApplyTerminalColors(ctx context.Context, name string, colors TerminalColors) error
+}
+
 // CommandRunner defines the interface for executing system commands.
 type CommandRunner interface {
 	// Execute runs a command and returns the result.
@@ -90,3 +177,87 @@ type NetworkClient interface {
 	// DownloadFile downloads a file from a URL to a destination path.
 	DownloadFile(ctx context.Context, url, destPath string) error
 }
+
+// ContainerExporter makes software installed inside a toolbox or distrobox
+// container available on the host: binaries on the PATH and desktop entries.
+type ContainerExporter interface {
+	// Export returns the files it created on the host, so they are removed
+	// with the package.
+	Export(ctx context.Context, pkg *Package) ([]Artifact, error)
+}
+
+// AppDetailsSource fetches long-form information about a package from
+// upstream, such as GitHub releases or Flathub appstream data.
+type AppDetailsSource interface {
+	FetchDetails(ctx context.Context, pkg *Package) (*AppDetails, error)
+}
+
+// LockWaiter is implemented by package installers that wait for a package
+// manager lock held by another process before giving up.
+type LockWaiter interface {
+	SetLockTimeout(timeout time.Duration)
+}
+
+// InstallerOutput is implemented by package installers whose output can go
+// to a progress display instead of the terminal.
+type InstallerOutput interface {
+	// SetOutputFunc passes each line the installer's commands print to fn,
+	// silencing its own messages. Nil restores the terminal output.
+	SetOutputFunc(fn func(line string))
+}
+
+// APTRouter is implemented by package installers that can install APT
+// packages through a frontend such as nala, falling back to apt-get.
+type APTRouter interface {
+	SetAPTFrontend(frontend APTFrontend)
+}
+
+// InstalledLister is implemented by package installers that list what each
+// package manager has installed in one query, so installing many apps can
+// skip those already present without checking them one by one.
+type InstalledLister interface {
+	ListInstalled(ctx context.Context) InstalledPackages
+}
+
+// OutdatedLister is implemented by package installers that list the
+// installed packages with a newer version available.
+type OutdatedLister interface {
+	ListOutdated(ctx context.Context) []OutdatedPackage
+}
+
+// PackageUpdater is implemented by package installers that can upgrade an
+// installed package to the newest version its package manager has.
+type PackageUpdater interface {
+	Update(ctx context.Context, pkg *Package) (*InstallationResult, error)
+}
+
+// ScopeDetector is implemented by package installers that can tell which
+// installation holds an installed package, such as the user or the system
+// flatpak installation. It reports false when it can't tell.
+type ScopeDetector interface {
+	InstalledScope(ctx context.Context, pkg *Package) (InstallScope, bool)
+}
+
+// DiskUsageReporter is implemented by package installers that can tell how
+// much disk space installed packages take, each named as its package
+// manager knows it.
+type DiskUsageReporter interface {
+	DiskUsage(ctx context.Context, packages []*Package) int64
+}
+
+// MailDiscoverer looks up in DNS where a mail domain is hosted.
+type MailDiscoverer interface {
+	// MailHosts returns the domain's MX hosts and whether it publishes
+	// RFC 6186 SRV records for IMAP and mail submission.
+	MailHosts(ctx context.Context, domain string) (mx []string, srv bool, err error)
+}
+
+// KernelInspector tells what building kernel modules on this machine needs.
+type KernelInspector interface {
+	// Release returns the release of the running kernel, as uname -r prints it.
+	Release(ctx context.Context) (string, error)
+
+	// SecureBoot reports whether Secure Boot is enforced, so the kernel only
+	// loads signed modules.
+	SecureBoot(ctx context.Context) bool
+}
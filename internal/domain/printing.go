@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PrintingServices are the systemd services printing and discovering network
+// printers need.
+func PrintingServices() []string {
+	return []string{"cups.service", "avahi-daemon.service"}
+}
+
+// NetworkPrinter is a driverless (IPP Everywhere or AirPrint) printer
+// announced on the local network.
+type NetworkPrinter struct {
+	Name  string `json:"name"`  // Name it announces, such as "HP OfficeJet 8010"
+	Queue string `json:"queue"` // CUPS queue name derived from Name
+	URI   string `json:"uri"`
+}
+
+// queueUnsafe matches what CUPS queue names can't contain.
+var queueUnsafe = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// PrinterQueueName turns an announced printer name into a CUPS queue name,
+// such as "HP OfficeJet 8010 (2)" into "HP_OfficeJet_8010_2".
+func PrinterQueueName(name string) string {
+	return strings.Trim(queueUnsafe.ReplaceAllString(name, "_"), "_")
+}
+
+// ParseAvahiPrinters reads the resolved entries of `avahi-browse -rpt
+// _ipp._tcp _ipps._tcp` into printers, one per announced name, preferring
+// the encrypted ipps service when a printer offers both.
+func ParseAvahiPrinters(output string) []NetworkPrinter {
+	var printers []NetworkPrinter
+
+	for line := range strings.SplitSeq(output, "\n") {
+		// =;interface;protocol;name;type;domain;host;address;port;txt
+		fields := strings.Split(line, ";")
+		if len(fields) < 10 || fields[0] != "=" || fields[2] != "IPv4" {
+			continue
+		}
+
+		scheme := strings.TrimPrefix(strings.TrimSuffix(fields[4], "._tcp"), "_")
+		if scheme != "ipp" && scheme != "ipps" {
+			continue
+		}
+
+		name := unescapeAvahi(fields[3])
+		resource := avahiTXT(strings.Join(fields[9:], ";"), "rp")
+		uri := scheme + "://" + net.JoinHostPort(strings.TrimSuffix(fields[6], "."), fields[8]) + "/" + resource
+
+		index := slices.IndexFunc(printers, func(p NetworkPrinter) bool { return p.Name == name })
+
+		switch {
+		case index < 0:
+			printers = append(printers, NetworkPrinter{Name: name, Queue: PrinterQueueName(name), URI: uri})
+		case scheme == "ipps":
+			printers[index].URI = uri
+		}
+	}
+
+	return printers
+}
+
+// unescapeAvahi decodes the \DDD decimal escapes avahi-browse -p uses for
+// characters such as spaces in service names.
+func unescapeAvahi(value string) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+3 < len(value) {
+			if code, err := strconv.Atoi(value[i+1 : i+4]); err == nil && code < 256 {
+				builder.WriteByte(byte(code))
+
+				i += 3
+
+				continue
+			}
+		}
+
+		builder.WriteByte(value[i])
+	}
+
+	return builder.String()
+}
+
+// avahiTXT returns the value of key in the quoted TXT records of an
+// avahi-browse -p line, or "ipp/print", the resource most printers use.
+func avahiTXT(records, key string) string {
+	for record := range strings.SplitSeq(records, " ") {
+		if value, ok := strings.CutPrefix(strings.Trim(record, `"`), key+"="); ok {
+			return value
+		}
+	}
+
+	return "ipp/print"
+}
+
+// ParsePrinterDevices reads `lpstat -v` into the device URI of each queue.
+func ParsePrinterDevices(output string) map[string]string {
+	devices := make(map[string]string)
+
+	for line := range strings.SplitSeq(output, "\n") {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(line), "device for ")
+		if !ok {
+			continue
+		}
+
+		if queue, uri, ok := strings.Cut(rest, ": "); ok {
+			devices[queue] = strings.TrimSpace(uri)
+		}
+	}
+
+	return devices
+}
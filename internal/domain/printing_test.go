@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+const avahiBrowseOutput = `+;wlp2s0;IPv4;HP\032OfficeJet\0328010;_ipp._tcp;local
+=;wlp2s0;IPv6;HP\032OfficeJet\0328010;_ipp._tcp;local;HP8010.local;fe80::1;631;"rp=ipp/print" "ty=HP OfficeJet 8010"
+=;wlp2s0;IPv4;HP\032OfficeJet\0328010;_ipp._tcp;local;HP8010.local;192.168.1.20;631;"txtvers=1" "rp=ipp/print" "ty=HP OfficeJet 8010"
+=;wlp2s0;IPv4;HP\032OfficeJet\0328010;_ipps._tcp;local;HP8010.local;192.168.1.20;443;"rp=ipp/print"
+=;wlp2s0;IPv4;Brother\032HL-L2350DW\032\0402\041;_ipp._tcp;local;BRW123.local;192.168.1.21;631;"txtvers=1"
+`
+
+func TestParseAvahiPrinters(t *testing.T) {
+	t.Parallel()
+
+	printers := domain.ParseAvahiPrinters(avahiBrowseOutput)
+
+	assert.Equal(t, []domain.NetworkPrinter{
+		{Name: "HP OfficeJet 8010", Queue: "HP_OfficeJet_8010", URI: "ipps://HP8010.local:443/ipp/print"},
+		{Name: "Brother HL-L2350DW (2)", Queue: "Brother_HL-L2350DW_2", URI: "ipp://BRW123.local:631/ipp/print"},
+	}, printers)
+
+	assert.Empty(t, domain.ParseAvahiPrinters(""))
+}
+
+func TestParsePrinterDevices(t *testing.T) {
+	t.Parallel()
+
+	devices := domain.ParsePrinterDevices("device for HP_OfficeJet_8010: ipps://HP8010.local:443/ipp/print\ndevice for PDF: cups-pdf:/\n")
+
+	assert.Equal(t, map[string]string{
+		"HP_OfficeJet_8010": "ipps://HP8010.local:443/ipp/print",
+		"PDF":               "cups-pdf:/",
+	}, devices)
+}
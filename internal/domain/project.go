@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidToolName indicates a project tool name mise can't be given safely.
+var ErrInvalidToolName = errors.New("invalid tool name")
+
+// toolNamePattern accepts a tool, optionally behind a mise backend, such as
+// "node", "aqua:BurntSushi/ripgrep" or "npm:@biomejs/biome". Names can't
+// start with "-", so mise never reads one as an option.
+var toolNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/+-]*(:[A-Za-z0-9@][A-Za-z0-9._/@+-]*)?$`)
+
+// ToolRequirement is a tool a project needs, in the versions its
+// constraint accepts.
+type ToolRequirement struct {
+	Tool       string            `json:"tool"`
+	Constraint VersionConstraint `json:"-"`
+}
+
+// ParseToolRequirements validates the constraints of a project's tools,
+// keyed by tool, and returns them sorted by tool. An empty constraint
+// accepts any version.
+func ParseToolRequirements(tools map[string]string) ([]ToolRequirement, error) {
+	requirements := make([]ToolRequirement, 0, len(tools))
+
+	for tool, value := range tools {
+		if strings.TrimSpace(tool) == "" {
+			return nil, fmt.Errorf("%w: empty tool name", ErrInvalidConstraint)
+		}
+
+		if !toolNamePattern.MatchString(tool) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidToolName, tool)
+		}
+
+		constraint, err := ParseVersionConstraint(value)
+		if err != nil {
+			return nil, fmt.Errorf("tool %s: %w", tool, err)
+		}
+
+		requirements = append(requirements, ToolRequirement{Tool: tool, Constraint: constraint})
+	}
+
+	slices.SortFunc(requirements, func(a, b ToolRequirement) int { return strings.Compare(a.Tool, b.Tool) })
+
+	return requirements, nil
+}
+
+// MiseSpec returns the tool@version mise installs for the requirement: the
+// version a single "=" clause pins, or the latest otherwise.
+func (r ToolRequirement) MiseSpec() string {
+	if version, ok := r.Constraint.Pin(); ok {
+		return r.Tool + "@" + version
+	}
+
+	return r.Tool + "@latest"
+}
+
+// Who satisfies a requirement.
+const (
+	ProvidedByMise  = "mise"  // Versioned tools, activated in the project directory
+	ProvidedByKarei = "karei" // Catalog apps installed machine-wide
+)
+
+// RequirementStatus is whether a project requirement is met.
+type RequirementStatus struct {
+	Tool      string `json:"tool"`
+	Required  string `json:"required,omitempty"`
+	Installed string `json:"installed,omitempty"` // Version found, when known
+	Provider  string `json:"provider"`
+	Met       bool   `json:"met"`
+	Reason    string `json:"reason,omitempty"` // Why it isn't met
+}
+
+// UnmetRequirements returns the statuses not met, in order.
+func UnmetRequirements(statuses []RequirementStatus) []RequirementStatus {
+	var unmet []RequirementStatus
+
+	for _, status := range statuses {
+		if !status.Met {
+			unmet = append(unmet, status)
+		}
+	}
+
+	return unmet
+}
+
+// ProjectHook returns the code that makes the shell check the requirements
+// of the project it enters, from karei use --check. Bash checks before each
+// prompt once the directory changed, zsh and fish when it changes. Errors
+// are discarded, such as another karei holding the lock.
+func (s Shell) ProjectHook() string {
+	switch s {
+	case ShellFish:
+		return `function __karei_project_hook --on-variable PWD
+    status --is-command-substitution; and return
+    karei use --check 2>/dev/null
+end
+__karei_project_hook
+`
+	case ShellZsh:
+		return `__karei_project_hook() { karei use --check 2>/dev/null }
+autoload -Uz add-zsh-hook
+add-zsh-hook chpwd __karei_project_hook
+__karei_project_hook
+`
+	default:
+		return `__karei_project_hook() {
+    [ "$PWD" = "${__KAREI_PROJECT_PWD:-}" ] && return
+    __KAREI_PROJECT_PWD=$PWD
+    karei use --check 2>/dev/null
+}
+case ";${PROMPT_COMMAND:-};" in
+    *";__karei_project_hook;"*) ;;
+    *) PROMPT_COMMAND="__karei_project_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}" ;;
+esac
+`
+	}
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseToolRequirements(t *testing.T) {
+	t.Parallel()
+
+	requirements, err := domain.ParseToolRequirements(map[string]string{
+		"node":       "20",
+		"go":         ">=1.22",
+		"shellcheck": "",
+	})
+	require.NoError(t, err)
+	require.Len(t, requirements, 3)
+
+	assert.Equal(t, "go", requirements[0].Tool)
+	assert.Equal(t, "go@latest", requirements[0].MiseSpec())
+	assert.Equal(t, "node@20", requirements[1].MiseSpec())
+	assert.True(t, requirements[2].Constraint.IsZero())
+
+	_, err = domain.ParseToolRequirements(map[string]string{"go": "newest"})
+	require.ErrorIs(t, err, domain.ErrInvalidConstraint)
+
+	_, err = domain.ParseToolRequirements(map[string]string{"aqua:BurntSushi/ripgrep": "", "npm:@biomejs/biome": "1"})
+	require.NoError(t, err)
+
+	for _, tool := range []string{"--env=evil", "-q", "node;reboot", "node@20", "npm:-x", "a b"} {
+		_, err = domain.ParseToolRequirements(map[string]string{tool: ""})
+		require.ErrorIs(t, err, domain.ErrInvalidToolName, tool)
+	}
+}
+
+func TestUnmetRequirements(t *testing.T) {
+	t.Parallel()
+
+	statuses := []domain.RequirementStatus{
+		{Tool: "go", Met: true},
+		{Tool: "node", Reason: "not installed"},
+	}
+
+	assert.Equal(t, statuses[1:], domain.UnmetRequirements(statuses))
+}
+
+func TestShell_ProjectHook(t *testing.T) {
+	t.Parallel()
+
+	for _, shell := range []domain.Shell{domain.ShellBash, domain.ShellZsh, domain.ShellFish} {
+		assert.Contains(t, shell.ProjectHook(), "karei use --check", shell)
+	}
+
+	assert.Contains(t, domain.ShellBash.ProjectHook(), "PROMPT_COMMAND")
+	assert.Contains(t, domain.ShellZsh.ProjectHook(), "add-zsh-hook chpwd")
+	assert.Contains(t, domain.ShellFish.ProjectHook(), "--on-variable PWD")
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownReleaseChannel indicates a channel other than stable or prerelease.
+var ErrUnknownReleaseChannel = errors.New("unknown release channel")
+
+// ReleaseChannel selects which GitHub releases of an app count as its latest.
+type ReleaseChannel string
+
+// Release channels.
+const (
+	ChannelStable     ReleaseChannel = "stable"     // Releases GitHub marks as latest
+	ChannelPrerelease ReleaseChannel = "prerelease" // The newest release, including prereleases
+)
+
+// ParseReleaseChannel parses "stable" or "prerelease".
+func ParseReleaseChannel(value string) (ReleaseChannel, error) {
+	channel := ReleaseChannel(strings.TrimSpace(value))
+
+	switch channel {
+	case ChannelStable, ChannelPrerelease:
+		return channel, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownReleaseChannel, value)
+	}
+}
+
+// ReleaseChannels holds the channel subscribed to per app, keyed by catalog
+// name. Apps not listed follow the stable channel.
+type ReleaseChannels map[string]ReleaseChannel
+
+// ParseReleaseChannels validates per-app channels keyed by catalog name.
+func ParseReleaseChannels(apps map[string]string) (ReleaseChannels, error) {
+	channels := make(ReleaseChannels, len(apps))
+
+	for app, value := range apps {
+		channel, err := ParseReleaseChannel(value)
+		if err != nil {
+			return nil, fmt.Errorf("github app %s: %w", app, err)
+		}
+
+		channels[app] = channel
+	}
+
+	return channels, nil
+}
+
+// For returns the release channel of the app.
+func (c ReleaseChannels) For(appKey string) ReleaseChannel {
+	if channel := c[appKey]; channel != "" {
+		return channel
+	}
+
+	return ChannelStable
+}
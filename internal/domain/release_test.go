@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReleaseChannels(t *testing.T) {
+	t.Parallel()
+
+	channels, err := domain.ParseReleaseChannels(nil)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ChannelStable, channels.For("lazygit"))
+
+	channels, err = domain.ParseReleaseChannels(map[string]string{"lazygit": "prerelease", "pmd": "stable"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ChannelPrerelease, channels.For("lazygit"))
+	assert.Equal(t, domain.ChannelStable, channels.For("pmd"))
+	assert.Equal(t, domain.ChannelStable, channels.For("btop"))
+
+	_, err = domain.ParseReleaseChannels(map[string]string{"lazygit": "nightly"})
+	require.ErrorIs(t, err, domain.ErrUnknownReleaseChannel)
+}
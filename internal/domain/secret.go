@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrSecretNotFound indicates no secret is stored under the requested name.
+	ErrSecretNotFound = errors.New("secret not found")
+	// ErrInvalidSecretName indicates a secret name with characters other than
+	// lowercase letters, digits, dashes and underscores.
+	ErrInvalidSecretName = errors.New("invalid secret name")
+)
+
+// secretRefPrefix marks a config.toml value read from the secret store, as in
+// webhook = "secret:webhook".
+const secretRefPrefix = "secret:"
+
+var secretNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Well-known secrets used by karei and the installers it runs.
+const (
+	SecretGitHubToken   = "github-token"         // Raises GitHub API rate limits for release lookups
+	SecretNPMToken      = "npm-token"            // Authenticates npm against a private registry
+	SecretPipIndexURL   = "pip-index-url"        // Package index URL for pip, credentials included
+	SecretCargoRegistry = "cargo-registry-token" // Authenticates cargo against its registry
+	SecretWebhook       = "webhook"              // Conventional name for the [notify] webhook URL
+)
+
+// SecretVariable is the environment variable a secret is passed as, and the
+// commands that read it. Other commands never see it.
+type SecretVariable struct {
+	Name     string
+	Commands []string
+}
+
+// SecretEnvironment maps the secrets installers read from the environment
+// to the variable each is passed as to the commands that need it.
+func SecretEnvironment() map[string]SecretVariable {
+	return map[string]SecretVariable{
+		SecretGitHubToken:   {Name: "GITHUB_TOKEN", Commands: []string{"mise", "aqua"}},
+		SecretNPMToken:      {Name: "NPM_TOKEN", Commands: []string{"npm", "corepack"}},
+		SecretPipIndexURL:   {Name: "PIP_INDEX_URL", Commands: []string{"pip", "pip3", "pipx"}},
+		SecretCargoRegistry: {Name: "CARGO_REGISTRY_TOKEN", Commands: []string{"cargo"}},
+	}
+}
+
+// ValidateSecretName rejects names that can't be used as keyring attributes
+// or file keys unchanged.
+func ValidateSecretName(name string) error {
+	if !secretNamePattern.MatchString(name) {
+		return fmt.Errorf("%w %q: use lowercase letters, digits, - and _", ErrInvalidSecretName, name)
+	}
+
+	return nil
+}
+
+// SecretReference returns the secret name a config.toml value refers to
+// with "secret:<name>", or false for a literal value.
+func SecretReference(value string) (string, bool) {
+	name, found := strings.CutPrefix(value, secretRefPrefix)
+	if !found {
+		return "", false
+	}
+
+	return name, true
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSecretName(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, domain.ValidateSecretName("github-token"))
+	require.NoError(t, domain.ValidateSecretName("registry_2"))
+	require.ErrorIs(t, domain.ValidateSecretName(""), domain.ErrInvalidSecretName)
+	require.ErrorIs(t, domain.ValidateSecretName("GitHub"), domain.ErrInvalidSecretName)
+	require.ErrorIs(t, domain.ValidateSecretName("-token"), domain.ErrInvalidSecretName)
+}
+
+func TestSecretReference(t *testing.T) {
+	t.Parallel()
+
+	name, ok := domain.SecretReference("secret:webhook")
+	assert.True(t, ok)
+	assert.Equal(t, "webhook", name)
+
+	_, ok = domain.SecretReference("https://hooks.example.com/secret:webhook")
+	assert.False(t, ok)
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// SessionType is the display protocol of the login session karei runs in.
+type SessionType string
+
+// Session types, as logind reports them in XDG_SESSION_TYPE.
+const (
+	SessionWayland SessionType = "wayland"
+	SessionX11     SessionType = "x11"
+	SessionTTY     SessionType = "tty" // A text console or SSH login, without a display
+	SessionUnknown SessionType = ""
+)
+
+// ParseSessionType reads XDG_SESSION_TYPE. Values other than wayland and x11,
+// such as "mir" or an empty one, are unknown.
+func ParseSessionType(value string) SessionType {
+	switch session := SessionType(strings.ToLower(strings.TrimSpace(value))); session {
+	case SessionWayland, SessionX11, SessionTTY:
+		return session
+	default:
+		return SessionUnknown
+	}
+}
+
+// IsGraphical reports whether the session has a display that desktop
+// settings apply to.
+func (s SessionType) IsGraphical() bool {
+	return s == SessionWayland || s == SessionX11
+}
+
+// String returns the session type, or "unknown".
+func (s SessionType) String() string {
+	if s == SessionUnknown {
+		return "unknown"
+	}
+
+	return string(s)
+}
+
+// GSetting is a GNOME setting written with gsettings.
+type GSetting struct {
+	Schema string `json:"schema"`
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+}
+
+// DesktopTweak is a desktop setting whose form depends on the session type.
+// A session without a variant skips the tweak, with a note telling why.
+type DesktopTweak struct {
+	Name        string
+	Description string
+	Variants    map[SessionType]TweakVariant
+	Skip        map[SessionType]string // Why a session has no variant, when it matters
+}
+
+// TweakVariant is what a tweak changes in one session type.
+type TweakVariant struct {
+	Settings []GSetting
+	Files    map[string]string // Contents by path relative to the home directory
+	Note     string            // Caveat shown when applied
+}
+
+// TweakPlan is a tweak as it applies to the current session.
+type TweakPlan struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Settings    []GSetting `json:"settings,omitempty"`
+	Files       []string   `json:"files,omitempty"`
+	Skipped     bool       `json:"skipped"`
+	Note        string     `json:"note,omitempty"`
+}
+
+// DesktopTweaks are the session-aware tweaks karei system desktop applies.
+func DesktopTweaks() []DesktopTweak {
+	return []DesktopTweak{
+		{
+			Name:        "fractional-scaling",
+			Description: "Offer 125%, 150% and 175% display scaling",
+			Variants: map[SessionType]TweakVariant{
+				SessionWayland: {Settings: []GSetting{
+					{"org.gnome.mutter", "experimental-features", "['scale-monitor-framebuffer']"},
+				}},
+				SessionX11: {
+					Settings: []GSetting{
+						{"org.gnome.mutter", "experimental-features", "['x11-randr-fractional-scaling']"},
+					},
+					Note: "needs Ubuntu's patched mutter and renders scaled apps slightly blurred",
+				},
+			},
+		},
+		{
+			Name:        "tap-to-click",
+			Description: "Click by tapping the touchpad, with natural scrolling",
+			Variants: map[SessionType]TweakVariant{
+				SessionWayland: {Settings: touchpadSettings()},
+				SessionX11:     {Settings: touchpadSettings()},
+			},
+		},
+		{
+			Name:        "wayland-apps",
+			Description: "Run Electron and Firefox apps natively on Wayland, sharp when scaled",
+			Variants: map[SessionType]TweakVariant{
+				SessionWayland: {Files: map[string]string{
+					".config/environment.d/60-karei-wayland.conf": "ELECTRON_OZONE_PLATFORM_HINT=auto\nMOZ_ENABLE_WAYLAND=1\n",
+				}, Note: "takes effect at the next login"},
+			},
+			Skip: map[SessionType]string{
+				SessionX11: "X11 sessions run these apps natively already",
+			},
+		},
+	}
+}
+
+// touchpadSettings are the same in both session types, since mutter drives
+// libinput in each.
+func touchpadSettings() []GSetting {
+	return []GSetting{
+		{"org.gnome.desktop.peripherals.touchpad", "tap-to-click", "true"},
+		{"org.gnome.desktop.peripherals.touchpad", "natural-scroll", "true"},
+	}
+}
+
+// PlanTweak returns how tweak applies in session.
+func PlanTweak(tweak DesktopTweak, session SessionType) TweakPlan {
+	plan := TweakPlan{Name: tweak.Name, Description: tweak.Description}
+
+	variant, ok := tweak.Variants[session]
+
+	switch {
+	case ok:
+		plan.Settings = variant.Settings
+		plan.Note = variant.Note
+
+		for path := range variant.Files {
+			plan.Files = append(plan.Files, path)
+		}
+
+		slices.Sort(plan.Files)
+	case !session.IsGraphical():
+		plan.Skipped = true
+		plan.Note = "no graphical session (" + session.String() + "); run it from the desktop"
+	default:
+		plan.Skipped = true
+		plan.Note = tweak.Skip[session]
+
+		if plan.Note == "" {
+			plan.Note = "doesn't apply to " + session.String() + " sessions"
+		}
+	}
+
+	return plan
+}
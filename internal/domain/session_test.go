@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSessionType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, domain.SessionWayland, domain.ParseSessionType("wayland"))
+	assert.Equal(t, domain.SessionX11, domain.ParseSessionType(" X11\n"))
+	assert.Equal(t, domain.SessionTTY, domain.ParseSessionType("tty"))
+	assert.Equal(t, domain.SessionUnknown, domain.ParseSessionType("mir"))
+	assert.Equal(t, "unknown", domain.SessionUnknown.String())
+	assert.False(t, domain.SessionTTY.IsGraphical())
+}
+
+func TestPlanTweak(t *testing.T) {
+	t.Parallel()
+
+	tweaks := make(map[string]domain.DesktopTweak)
+	for _, tweak := range domain.DesktopTweaks() {
+		tweaks[tweak.Name] = tweak
+	}
+
+	// Each session gets its own form of fractional scaling
+	wayland := domain.PlanTweak(tweaks["fractional-scaling"], domain.SessionWayland)
+	x11 := domain.PlanTweak(tweaks["fractional-scaling"], domain.SessionX11)
+	require.Len(t, wayland.Settings, 1)
+	require.Len(t, x11.Settings, 1)
+	assert.Contains(t, wayland.Settings[0].Value, "scale-monitor-framebuffer")
+	assert.Contains(t, x11.Settings[0].Value, "x11-randr-fractional-scaling")
+	assert.NotEmpty(t, x11.Note)
+
+	apps := domain.PlanTweak(tweaks["wayland-apps"], domain.SessionWayland)
+	assert.False(t, apps.Skipped)
+	assert.Equal(t, []string{".config/environment.d/60-karei-wayland.conf"}, apps.Files)
+
+	apps = domain.PlanTweak(tweaks["wayland-apps"], domain.SessionX11)
+	assert.True(t, apps.Skipped)
+	assert.Contains(t, apps.Note, "natively already")
+
+	tty := domain.PlanTweak(tweaks["tap-to-click"], domain.SessionTTY)
+	assert.True(t, tty.Skipped)
+	assert.Contains(t, tty.Note, "no graphical session")
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Shell is a login shell whose configuration karei can extend.
+type Shell string
+
+// Shells karei knows the configuration of.
+const (
+	ShellBash Shell = "bash"
+	ShellZsh  Shell = "zsh"
+	ShellFish Shell = "fish"
+)
+
+// ShellFromPath returns the shell of a path such as $SHELL, reporting false
+// for shells karei doesn't know.
+func ShellFromPath(path string) (Shell, bool) {
+	shell := Shell(filepath.Base(path))
+
+	switch shell {
+	case ShellBash, ShellZsh, ShellFish:
+		return shell, true
+	default:
+		return "", false
+	}
+}
+
+// ConfigFile returns the file the shell reads when starting interactively.
+func (s Shell) ConfigFile(home, configHome string) string {
+	switch s {
+	case ShellZsh:
+		return filepath.Join(home, ".zshrc")
+	case ShellFish:
+		return filepath.Join(configHome, "fish", "config.fish")
+	case ShellBash:
+		return filepath.Join(home, ".bashrc")
+	default:
+		return filepath.Join(home, ".bashrc")
+	}
+}
+
+// ReloadHint tells how to make a running shell pick up new configuration.
+func (s Shell) ReloadHint() string {
+	if s == "" {
+		return "Open a new shell to pick up the changes"
+	}
+
+	return "Open a new shell or run: exec " + string(s)
+}
+
+// Lines returns the configuration lines carrying out setup, with paths
+// below home written relative to $HOME.
+func (s Shell) Lines(setup ShellSetup, home string) []string {
+	lines := make([]string, 0, len(setup.PathDirs)+len(setup.Activate))
+
+	for _, dir := range setup.PathDirs {
+		dir = homeRelative(dir, home)
+
+		if s == ShellFish {
+			lines = append(lines, "fish_add_path "+dir)
+		} else {
+			lines = append(lines, `export PATH="`+dir+`:$PATH"`)
+		}
+	}
+
+	for _, tool := range setup.Activate {
+		if s == ShellFish {
+			lines = append(lines, tool+" activate fish | source")
+		} else {
+			lines = append(lines, `eval "$(`+tool+` activate `+string(s)+`)"`)
+		}
+	}
+
+	return lines
+}
+
+// homeRelative writes path relative to $HOME when it is below home.
+func homeRelative(path, home string) string {
+	if rel, err := filepath.Rel(home, path); err == nil && home != "" && !strings.HasPrefix(rel, "..") {
+		return filepath.Join("$HOME", rel)
+	}
+
+	return path
+}
+
+// ShellSetup is what shells need to reach freshly installed tools.
+type ShellSetup struct {
+	PathDirs []string `json:"path_dirs,omitempty"` // Directories to add to PATH
+	Activate []string `json:"activate,omitempty"`  // Tools hooking into the shell, such as mise
+}
+
+// Empty reports whether the shells need nothing.
+func (s ShellSetup) Empty() bool {
+	return len(s.PathDirs) == 0 && len(s.Activate) == 0
+}
+
+// Add merges other into the setup, without duplicates.
+func (s *ShellSetup) Add(other ShellSetup) {
+	for _, dir := range other.PathDirs {
+		if !slices.Contains(s.PathDirs, dir) {
+			s.PathDirs = append(s.PathDirs, dir)
+		}
+	}
+
+	for _, tool := range other.Activate {
+		if !slices.Contains(s.Activate, tool) {
+			s.Activate = append(s.Activate, tool)
+		}
+	}
+}
+
+// PathCovers reports whether dir is an entry of a PATH value.
+func PathCovers(pathEnv, dir string) bool {
+	dir = filepath.Clean(dir)
+
+	for _, entry := range filepath.SplitList(pathEnv) {
+		if entry != "" && filepath.Clean(entry) == dir {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestShellFromPath tests that known shells are recognized from $SHELL.
+func TestShellFromPath(t *testing.T) {
+	t.Parallel()
+
+	shell, ok := domain.ShellFromPath("/usr/bin/fish")
+	assert.True(t, ok)
+	assert.Equal(t, domain.ShellFish, shell)
+	assert.Equal(t, "Open a new shell or run: exec fish", shell.ReloadHint())
+
+	_, ok = domain.ShellFromPath("/bin/tcsh")
+	assert.False(t, ok)
+
+	_, ok = domain.ShellFromPath("")
+	assert.False(t, ok)
+}
+
+// TestShellLines tests the configuration lines of each shell.
+func TestShellLines(t *testing.T) {
+	t.Parallel()
+
+	setup := domain.ShellSetup{PathDirs: []string{"/home/user/.local/bin", "/opt/tools/bin"}, Activate: []string{"mise"}}
+
+	assert.Equal(t, []string{
+		`export PATH="$HOME/.local/bin:$PATH"`,
+		`export PATH="/opt/tools/bin:$PATH"`,
+		`eval "$(mise activate zsh)"`,
+	}, domain.ShellZsh.Lines(setup, "/home/user"))
+
+	assert.Equal(t, []string{
+		"fish_add_path $HOME/.local/bin",
+		"fish_add_path /opt/tools/bin",
+		"mise activate fish | source",
+	}, domain.ShellFish.Lines(setup, "/home/user"))
+}
+
+// TestPathCovers tests that PATH entries match exactly, ignoring trailing slashes.
+func TestPathCovers(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, domain.PathCovers("/usr/bin:/home/user/.local/bin/", "/home/user/.local/bin"))
+	assert.False(t, domain.PathCovers("/usr/bin:/home/user/.local/bin2", "/home/user/.local/bin"))
+	assert.False(t, domain.PathCovers("", "/home/user/.local/bin"))
+}
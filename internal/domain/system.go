@@ -19,9 +19,10 @@ type Distribution struct {
 
 // DesktopEnvironment represents the desktop environment.
 type DesktopEnvironment struct {
-	Name    string `json:"name"`
-	Session string `json:"session"`
-	Version string `json:"version"`
+	Name    string      `json:"name"`
+	Session string      `json:"session"`
+	Version string      `json:"version"`
+	Type    SessionType `json:"type"` // Wayland or X11
 }
 
 // PackageManager represents the package manager type.
@@ -31,15 +32,33 @@ type PackageManager struct {
 	Command string        `json:"command"`
 }
 
+// Container tools that provide mutable sessions on immutable distributions.
+const (
+	ContainerToolbox   = "toolbox"
+	ContainerDistrobox = "distrobox"
+)
+
+// ContainerSession describes a toolbox or distrobox container karei is running in.
+type ContainerSession struct {
+	Kind string `json:"kind"` // toolbox or distrobox
+	Name string `json:"name"`
+}
+
 // SystemInfo contains system information.
 type SystemInfo struct {
 	Distribution       *Distribution       `json:"distribution"`
 	DesktopEnvironment *DesktopEnvironment `json:"desktop_environment"`
 	PackageManager     *PackageManager     `json:"package_manager"`
+	Container          *ContainerSession   `json:"container,omitempty"`
 	Architecture       string              `json:"architecture"`
 	Kernel             string              `json:"kernel"`
 }
 
+// IsContainerSession checks if karei runs inside a toolbox or distrobox container.
+func (s *SystemInfo) IsContainerSession() bool {
+	return s.Container != nil
+}
+
 // IsDebianBased checks if the system is Debian/Ubuntu-based.
 func (s *SystemInfo) IsDebianBased() bool {
 	return s.Distribution != nil &&
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidTeamKey indicates a key that isn't a base64 Ed25519 key.
+	ErrInvalidTeamKey = errors.New("invalid team key")
+	// ErrBadSignature indicates content that doesn't match its signature.
+	ErrBadSignature = errors.New("signature does not verify")
+)
+
+// TeamSignatureSuffix is added to a team profile's URL to fetch its signature.
+const TeamSignatureSuffix = ".sig"
+
+// TeamSubscription is the team profile a machine follows: where it is
+// published, the key it must be signed with, and what was applied last.
+type TeamSubscription struct {
+	URL     string    `json:"url"`
+	Key     string    `json:"key,omitempty"` // Empty when any trusted key may sign
+	Joined  time.Time `json:"joined"`
+	Checked time.Time `json:"checked,omitzero"`
+	Applied string    `json:"applied,omitempty"` // Profile as last applied
+	Updated time.Time `json:"updated,omitzero"`  // When it was applied
+}
+
+// ParseTeamKey reads a public key as karei team keygen prints it.
+func ParseTeamKey(value string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: want the base64 public key karei team keygen prints", ErrInvalidTeamKey)
+	}
+
+	return ed25519.PublicKey(key), nil
+}
+
+// ParseTeamPrivateKey reads a private key file written by karei team keygen.
+func ParseTeamPrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	seed, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%w: not a private key written by karei team keygen", ErrInvalidTeamKey)
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// EncodeTeamKeys returns the public key and the private key file contents
+// of a key pair.
+func EncodeTeamKeys(private ed25519.PrivateKey) (string, []byte) {
+	public, _ := private.Public().(ed25519.PublicKey)
+
+	return base64.StdEncoding.EncodeToString(public), []byte(base64.StdEncoding.EncodeToString(private.Seed()) + "\n")
+}
+
+// SignTeamProfile returns the signature file of profile.
+func SignTeamProfile(profile []byte, private ed25519.PrivateKey) []byte {
+	return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(private, profile)) + "\n")
+}
+
+// VerifyTeamProfile checks that signature, a signature file, signs profile
+// with key.
+func VerifyTeamProfile(profile, signature []byte, key ed25519.PublicKey) error {
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+	if err != nil || !ed25519.Verify(key, profile, sig) {
+		return ErrBadSignature
+	}
+
+	return nil
+}
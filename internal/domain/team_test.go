@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamProfileSignature(t *testing.T) {
+	t.Parallel()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	publicKey, privateFile := domain.EncodeTeamKeys(private)
+
+	parsedPrivate, err := domain.ParseTeamPrivateKey(privateFile)
+	require.NoError(t, err)
+	assert.Equal(t, private, parsedPrivate)
+
+	public, err := domain.ParseTeamKey(publicKey)
+	require.NoError(t, err)
+
+	profile := []byte("apps:\n  - vlc\n")
+	signature := domain.SignTeamProfile(profile, parsedPrivate)
+
+	require.NoError(t, domain.VerifyTeamProfile(profile, signature, public))
+	require.ErrorIs(t, domain.VerifyTeamProfile([]byte("apps:\n  - steam\n"), signature, public), domain.ErrBadSignature)
+	require.ErrorIs(t, domain.VerifyTeamProfile(profile, []byte("not base64"), public), domain.ErrBadSignature)
+}
+
+func TestParseTeamKey_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, key := range []string{"", "not base64!", "c2hvcnQ="} {
+		_, err := domain.ParseTeamKey(key)
+		require.ErrorIs(t, err, domain.ErrInvalidTeamKey, key)
+	}
+
+	_, err := domain.ParseTeamPrivateKey([]byte("c2hvcnQ=\n"))
+	require.ErrorIs(t, err, domain.ErrInvalidTeamKey)
+}
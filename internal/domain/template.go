@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TemplateStrategy tells how a section of a template override combines with
+// the built-in template.
+type TemplateStrategy string
+
+// Strategies a marker line in a template override selects. A marker is any
+// line containing "karei:" and the strategy, so it can be written as a
+// comment in the syntax of the file: "# karei:append", "// karei:prepend".
+const (
+	TemplateReplace TemplateStrategy = "replace" // Used instead of the built-in
+	TemplatePrepend TemplateStrategy = "prepend" // Put before the built-in
+	TemplateAppend  TemplateStrategy = "append"  // Put after the built-in
+)
+
+// MergeTemplate combines the built-in template with a user override. An
+// override without markers replaces the built-in. Otherwise the sections
+// after prepend markers come first, then those after replace markers or the
+// built-in when there are none, then those after append markers. Lines before
+// the first marker belong to a replace section unless they are all blank.
+// Marker lines are left out.
+func MergeTemplate(builtin, override []string) []string {
+	sections := make(map[TemplateStrategy][]string)
+	strategy, marked := TemplateReplace, false
+
+	var unmarked []string
+
+	for _, line := range override {
+		if found, ok := templateMarker(line); ok {
+			strategy, marked = found, true
+
+			continue
+		}
+
+		if !marked {
+			unmarked = append(unmarked, line)
+
+			continue
+		}
+
+		sections[strategy] = append(sections[strategy], line)
+	}
+
+	if !marked {
+		return override
+	}
+
+	if strings.TrimSpace(strings.Join(unmarked, "")) != "" {
+		sections[TemplateReplace] = append(unmarked, sections[TemplateReplace]...)
+	}
+
+	body, replaced := sections[TemplateReplace]
+	if !replaced {
+		body = builtin
+	}
+
+	merged := make([]string, 0, len(sections[TemplatePrepend])+len(body)+len(sections[TemplateAppend]))
+	merged = append(merged, sections[TemplatePrepend]...)
+	merged = append(merged, body...)
+	merged = append(merged, sections[TemplateAppend]...)
+
+	return merged
+}
+
+// templateMarker returns the strategy a marker line selects.
+func templateMarker(line string) (TemplateStrategy, bool) {
+	for _, strategy := range []TemplateStrategy{TemplateReplace, TemplatePrepend, TemplateAppend} {
+		if strings.Contains(line, "karei:"+string(strategy)) {
+			return strategy, true
+		}
+	}
+
+	return "", false
+}
+
+// DiffLines returns the changes turning a into b as a unified diff with
+// context lines around each change, or nil when they are equal.
+func DiffLines(a, b []string, context int) []string {
+	type edit struct {
+		op   byte
+		line string
+		i, j int // Position in a and b before the edit
+	}
+
+	matches := matchLines(a, b)
+
+	var edits []edit
+
+	j := 0
+
+	for i, match := range matches {
+		if match < 0 {
+			edits = append(edits, edit{'-', a[i], i, j})
+
+			continue
+		}
+
+		for ; j < match; j++ {
+			edits = append(edits, edit{'+', b[j], i, j})
+		}
+
+		edits = append(edits, edit{' ', a[i], i, j})
+		j++
+	}
+
+	for ; j < len(b); j++ {
+		edits = append(edits, edit{'+', b[j], len(a), j})
+	}
+
+	var diff []string
+
+	for start := 0; start < len(edits); {
+		// The next change, with the context before it, starts a hunk
+		first := start
+		for first < len(edits) && edits[first].op == ' ' {
+			first++
+		}
+
+		if first == len(edits) {
+			break
+		}
+
+		from := max(first-context, start)
+
+		// The hunk ends when more than two contexts of unchanged lines follow
+		end, unchanged := first, 0
+		for k := first; k < len(edits) && unchanged <= 2*context; k++ {
+			if edits[k].op == ' ' {
+				unchanged++
+			} else {
+				end, unchanged = k, 0
+			}
+		}
+
+		to := min(end+context+1, len(edits))
+
+		var lines []string
+
+		removed, added := 0, 0
+
+		for _, e := range edits[from:to] {
+			lines = append(lines, string(e.op)+e.line)
+
+			if e.op != '+' {
+				removed++
+			}
+
+			if e.op != '-' {
+				added++
+			}
+		}
+
+		diff = append(diff, fmt.Sprintf("@@ -%d,%d +%d,%d @@", edits[from].i+1, removed, edits[from].j+1, added))
+		diff = append(diff, lines...)
+		start = to
+	}
+
+	return diff
+}
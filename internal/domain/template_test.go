@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeTemplate(t *testing.T) {
+	t.Parallel()
+
+	builtin := []string{"theme = dark", "size = 11"}
+
+	tests := []struct {
+		name     string
+		override []string
+		want     []string
+	}{
+		{
+			name:     "no markers replaces",
+			override: []string{"theme = nord"},
+			want:     []string{"theme = nord"},
+		},
+		{
+			name:     "prepend and append",
+			override: []string{"# karei:append", "vim_keys = true", "# karei:prepend", "# mine"},
+			want:     []string{"# mine", "theme = dark", "size = 11", "vim_keys = true"},
+		},
+		{
+			name:     "replace section",
+			override: []string{"// karei:replace", "theme = nord", "// karei:append", "size = 14"},
+			want:     []string{"theme = nord", "size = 14"},
+		},
+		{
+			name:     "lines before the first marker replace",
+			override: []string{"theme = nord", "# karei:append", "size = 14"},
+			want:     []string{"theme = nord", "size = 14"},
+		},
+		{
+			name:     "blank lines before the first marker are dropped",
+			override: []string{"", "# karei:append", "size = 14"},
+			want:     []string{"theme = dark", "size = 11", "size = 14"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, domain.MergeTemplate(builtin, tt.override))
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	t.Parallel()
+
+	a := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}
+
+	assert.Nil(t, domain.DiffLines(a, a, 1))
+
+	b := []string{"1", "two", "3", "4", "5", "6", "7", "8", "9", "10"}
+
+	assert.Equal(t, []string{
+		"@@ -1,3 +1,3 @@", " 1", "-2", "+two", " 3",
+		"@@ -9,1 +9,2 @@", " 9", "+10",
+	}, domain.DiffLines(a, b, 1))
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownTilingWM indicates a tiling window manager karei has no profile for.
+var ErrUnknownTilingWM = errors.New("unknown tiling window manager")
+
+// TilingWM is a keyboard-driven tiling Wayland compositor.
+type TilingWM string
+
+// Tiling window managers karei sets up.
+const (
+	TilingSway     TilingWM = "sway"
+	TilingHyprland TilingWM = "hyprland"
+)
+
+// TilingConfig is a configuration file generated from a template.
+type TilingConfig struct {
+	Template string `json:"template"` // Template name, such as "configs/tiling/waybar/style.css"
+	Path     string `json:"path"`     // Destination relative to the config home
+}
+
+// TilingProfile is what karei installs and configures for a tiling window
+// manager: the compositor, its bar, launcher and notification daemon, and
+// the desktop portal backend that screen sharing and file pickers go through.
+type TilingProfile struct {
+	WM      TilingWM       `json:"wm"`
+	Name    string         `json:"name"`
+	Apps    []string       `json:"apps"` // Catalog keys to install
+	Configs []TilingConfig `json:"configs"`
+	Portal  string         `json:"portal"` // xdg-desktop-portal backend preferred over gtk
+
+	// UserConfig is the file the generated configuration includes for the
+	// user's own settings, relative to the config home. It is created empty
+	// when missing, since Hyprland reports an error for a missing source.
+	UserConfig string `json:"user_config,omitempty"`
+}
+
+// ParseTilingWM returns the tiling window manager named name.
+func ParseTilingWM(name string) (TilingWM, error) {
+	switch wm := TilingWM(strings.ToLower(strings.TrimSpace(name))); wm {
+	case TilingSway, TilingHyprland:
+		return wm, nil
+	default:
+		return "", fmt.Errorf("%w: %q, expected sway or hyprland", ErrUnknownTilingWM, name)
+	}
+}
+
+// TilingProfileFor returns the profile of wm.
+func TilingProfileFor(wm TilingWM) TilingProfile {
+	companions := []string{"waybar", "wofi", "mako", "swaylock", "swayidle", "swaybg", "grim", "slurp", "wl-clipboard"}
+	shared := []TilingConfig{
+		{"configs/tiling/waybar/config.jsonc", "waybar/config.jsonc"},
+		{"configs/tiling/waybar/style.css", "waybar/style.css"},
+		{"configs/tiling/wofi/style.css", "wofi/style.css"},
+		{"configs/tiling/mako/config", "mako/config"},
+	}
+
+	if wm == TilingHyprland {
+		return TilingProfile{
+			WM:      TilingHyprland,
+			Name:    "Hyprland",
+			Apps:    append([]string{"hyprland", "xdg-desktop-portal-hyprland"}, companions...),
+			Configs: append([]TilingConfig{{"configs/tiling/hypr/hyprland.conf", "hypr/hyprland.conf"}}, shared...),
+			Portal:  "hyprland",
+
+			UserConfig: "hypr/user.conf",
+		}
+	}
+
+	return TilingProfile{
+		WM:      TilingSway,
+		Name:    "Sway",
+		Apps:    append([]string{"sway", "xdg-desktop-portal-wlr"}, companions...),
+		Configs: append([]TilingConfig{{"configs/tiling/sway/config", "sway/config"}}, shared...),
+		Portal:  "wlr",
+	}
+}
+
+// PortalConfigPath is where xdg-desktop-portal looks up the backends for the
+// profile's desktop, relative to the config home. The desktop is matched
+// against XDG_CURRENT_DESKTOP, which sway and Hyprland set to their name.
+func (p TilingProfile) PortalConfigPath() string {
+	return "xdg-desktop-portal/" + string(p.WM) + "-portals.conf"
+}
+
+// PortalConfig prefers the compositor's portal backend, which implements
+// screenshots and screen sharing, and falls back to the GTK one for file
+// pickers and the rest.
+func (p TilingProfile) PortalConfig() string {
+	return "[preferred]\ndefault=" + p.Portal + ";gtk\n" +
+		"org.freedesktop.impl.portal.Screenshot=" + p.Portal + "\n" +
+		"org.freedesktop.impl.portal.ScreenCast=" + p.Portal + "\n"
+}
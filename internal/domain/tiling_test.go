@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTilingWM(t *testing.T) {
+	t.Parallel()
+
+	wm, err := domain.ParseTilingWM(" Hyprland ")
+	require.NoError(t, err)
+	assert.Equal(t, domain.TilingHyprland, wm)
+
+	_, err = domain.ParseTilingWM("i3")
+	require.ErrorIs(t, err, domain.ErrUnknownTilingWM)
+}
+
+func TestTilingProfileFor(t *testing.T) {
+	t.Parallel()
+
+	sway := domain.TilingProfileFor(domain.TilingSway)
+	assert.Contains(t, sway.Apps, "xdg-desktop-portal-wlr")
+	assert.NotContains(t, sway.Apps, "hyprland")
+	assert.Equal(t, "sway/config", sway.Configs[0].Path)
+	assert.Equal(t, "xdg-desktop-portal/sway-portals.conf", sway.PortalConfigPath())
+	assert.Contains(t, sway.PortalConfig(), "org.freedesktop.impl.portal.ScreenCast=wlr\n")
+	assert.Empty(t, sway.UserConfig)
+
+	hyprland := domain.TilingProfileFor(domain.TilingHyprland)
+	assert.Contains(t, hyprland.Apps, "xdg-desktop-portal-hyprland")
+	assert.Equal(t, "hypr/user.conf", hyprland.UserConfig)
+	assert.Contains(t, hyprland.PortalConfig(), "default=hyprland;gtk\n")
+
+	// Both share the companions without one profile changing the other
+	assert.Equal(t, sway.Configs[1:], hyprland.Configs[1:])
+}
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrTrashEntryNotFound indicates no trash entry has the requested ID.
+	ErrTrashEntryNotFound = errors.New("trash entry not found")
+	// ErrRestoreConflict indicates a file exists where a trashed file would be restored.
+	ErrRestoreConflict = errors.New("restore target already exists")
+)
+
+// TrashEntry is a set of files moved to the trash by one uninstall.
+type TrashEntry struct {
+	ID      string      `json:"id"`
+	App     string      `json:"app"`
+	Trashed time.Time   `json:"trashed"`
+	Items   []TrashItem `json:"items"`
+}
+
+// TrashItem is a trashed file or directory.
+type TrashItem struct {
+	Original string `json:"original"` // Where it was and is restored to
+	Name     string `json:"name"`     // Name inside the entry directory
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidTrustedKey indicates a public key of no format karei verifies.
+	ErrInvalidTrustedKey = errors.New("unrecognized public key, want a minisign, cosign or karei team key")
+	// ErrUnsigned indicates remote content published without a signature.
+	ErrUnsigned = errors.New("no signature published")
+	// ErrUntrusted indicates remote content no trusted key signed.
+	ErrUntrusted = errors.New("not signed by a trusted key")
+)
+
+// Formats of trusted keys and the signatures made with them.
+const (
+	KeyFormatMinisign = "minisign" // Verified with the minisign tool
+	KeyFormatCosign   = "cosign"   // PEM public key of cosign sign-blob
+	KeyFormatKarei    = "karei"    // Ed25519 key of karei team keygen
+)
+
+// minisignKeySize is the size of a decoded minisign public key: the
+// algorithm, the key ID and the Ed25519 key.
+const minisignKeySize = 2 + 8 + ed25519.PublicKeySize
+
+// TrustedKey is a public key remote catalogs and profiles may be signed with.
+type TrustedKey struct {
+	Name   string    `json:"name"`
+	Format string    `json:"format"`
+	Key    string    `json:"key"`
+	Added  time.Time `json:"added"`
+}
+
+// ParseTrustedKey recognizes the format of a public key, given as the key
+// or the contents of its file: a minisign .pub file, a cosign.pub PEM file,
+// or the key karei team keygen prints.
+func ParseTrustedKey(name, value string) (TrustedKey, error) {
+	if name == "" || strings.ContainsAny(name, " \t\n/") {
+		return TrustedKey{}, fmt.Errorf("%w: invalid name %q", ErrInvalidTrustedKey, name)
+	}
+
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "-----BEGIN PUBLIC KEY-----") {
+		if _, err := parseCosignKey(value); err != nil {
+			return TrustedKey{}, err
+		}
+
+		return TrustedKey{Name: name, Format: KeyFormatCosign, Key: value + "\n"}, nil
+	}
+
+	// A minisign .pub file has an untrusted comment above the key
+	lines := strings.Split(value, "\n")
+	line := strings.TrimSpace(lines[len(lines)-1])
+
+	key, err := base64.StdEncoding.DecodeString(line)
+	if err != nil {
+		return TrustedKey{}, ErrInvalidTrustedKey
+	}
+
+	switch {
+	case len(key) == minisignKeySize && string(key[:2]) == "Ed":
+		return TrustedKey{Name: name, Format: KeyFormatMinisign, Key: line}, nil
+	case len(key) == ed25519.PublicKeySize && len(lines) == 1:
+		return TrustedKey{Name: name, Format: KeyFormatKarei, Key: line}, nil
+	default:
+		return TrustedKey{}, ErrInvalidTrustedKey
+	}
+}
+
+// SignatureSuffix returns what is added to the URL of signed content to
+// fetch its signature made with the key.
+func (k TrustedKey) SignatureSuffix() string {
+	if k.Format == KeyFormatMinisign {
+		return ".minisig"
+	}
+
+	return TeamSignatureSuffix
+}
+
+// Verify checks that signature signs content with the key. Minisign
+// signatures need the minisign tool and are not verified here.
+func (k TrustedKey) Verify(content, signature []byte) error {
+	switch k.Format {
+	case KeyFormatKarei:
+		key, err := ParseTeamKey(k.Key)
+		if err != nil {
+			return err
+		}
+
+		return VerifyTeamProfile(content, signature, key)
+	case KeyFormatCosign:
+		return verifyCosign(content, signature, k.Key)
+	default:
+		return fmt.Errorf("%w: %s keys are verified with their tool", ErrInvalidTrustedKey, k.Format)
+	}
+}
+
+// verifyCosign checks a signature written by cosign sign-blob: the base64
+// signature of the SHA-256 digest of content.
+func verifyCosign(content, signature []byte, pemKey string) error {
+	key, err := parseCosignKey(pemKey)
+	if err != nil {
+		return err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return ErrBadSignature
+	}
+
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(content)
+		if ecdsa.VerifyASN1(key, digest[:], sig) {
+			return nil
+		}
+	case ed25519.PublicKey:
+		if ed25519.Verify(key, content, sig) {
+			return nil
+		}
+	}
+
+	return ErrBadSignature
+}
+
+// parseCosignKey reads the ECDSA or Ed25519 key of a cosign.pub file.
+func parseCosignKey(pemKey string) (any, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, ErrInvalidTrustedKey
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTrustedKey, err)
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%w: only ECDSA and Ed25519 cosign keys are supported", ErrInvalidTrustedKey)
+	}
+}
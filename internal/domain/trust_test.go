@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrustedKey(t *testing.T) {
+	t.Parallel()
+
+	minisign := "untrusted comment: minisign public key 3F6B5CF2C1A0E1E0\n" +
+		"RWTg4aDB8lxrP3IrTuAO6FCxiGTDIfLvj6HqhHOFByybjM+Wef1gJWrj\n"
+
+	key, err := domain.ParseTrustedKey("acme", minisign)
+	require.NoError(t, err)
+	assert.Equal(t, domain.KeyFormatMinisign, key.Format)
+	assert.Equal(t, "RWTg4aDB8lxrP3IrTuAO6FCxiGTDIfLvj6HqhHOFByybjM+Wef1gJWrj", key.Key)
+	assert.Equal(t, ".minisig", key.SignatureSuffix())
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	public, _ := domain.EncodeTeamKeys(private)
+
+	key, err = domain.ParseTrustedKey("team", public)
+	require.NoError(t, err)
+	assert.Equal(t, domain.KeyFormatKarei, key.Format)
+	assert.Equal(t, ".sig", key.SignatureSuffix())
+
+	for _, value := range []string{"", "c2hvcnQ=", "-----BEGIN PUBLIC KEY-----\nbm9wZQ==\n-----END PUBLIC KEY-----"} {
+		_, err := domain.ParseTrustedKey("bad", value)
+		require.ErrorIs(t, err, domain.ErrInvalidTrustedKey, value)
+	}
+
+	_, err = domain.ParseTrustedKey("has space", public)
+	require.ErrorIs(t, err, domain.ErrInvalidTrustedKey)
+}
+
+func TestTrustedKey_VerifyCosign(t *testing.T) {
+	t.Parallel()
+
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	require.NoError(t, err)
+
+	key, err := domain.ParseTrustedKey("acme", string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	require.NoError(t, err)
+	assert.Equal(t, domain.KeyFormatCosign, key.Format)
+
+	content := []byte("[apps.htop]\nname = \"htop\"\n")
+	digest := sha256.Sum256(content)
+
+	sig, err := ecdsa.SignASN1(rand.Reader, private, digest[:])
+	require.NoError(t, err)
+
+	signature := []byte(base64.StdEncoding.EncodeToString(sig))
+
+	require.NoError(t, key.Verify(content, signature))
+	require.ErrorIs(t, key.Verify([]byte("tampered"), signature), domain.ErrBadSignature)
+}
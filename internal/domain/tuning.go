@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Files karei system tune writes.
+const (
+	SysctlTuneFile     = "/etc/sysctl.d/60-karei-developer.conf"
+	ZramConfigFile     = "/etc/systemd/zram-generator.conf"
+	SystemLimitsFile   = "/etc/systemd/system.conf.d/60-karei-limits.conf"
+	UserLimitsFile     = "/etc/systemd/user.conf.d/60-karei-limits.conf"
+	ZramService        = "systemd-zram-setup@zram0.service"
+	OpenFilesSoftLimit = "65536"
+)
+
+// SysctlSetting is a kernel parameter set for development work.
+type SysctlSetting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// TuneFile is a system file written by tuning.
+type TuneFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// Tuning is the set of changes karei system tune makes.
+type Tuning struct {
+	Zram   bool            `json:"zram"` // Whether swap goes to compressed RAM
+	Sysctl []SysctlSetting `json:"sysctl"`
+	Files  []TuneFile      `json:"files"`
+}
+
+// TuneChange is a setting's value before tuning and the value it gets.
+type TuneChange struct {
+	Name   string `json:"name"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+	Note   string `json:"note,omitempty"`
+}
+
+// Changed reports whether tuning changes the value.
+func (c TuneChange) Changed() bool {
+	return c.Before != c.After
+}
+
+// TuneBackup is a tuned file as it was before karei first wrote it.
+type TuneBackup struct {
+	Path    string `json:"path"`
+	Existed bool   `json:"existed"`
+	Content string `json:"content,omitempty"`
+}
+
+// TuneRecord is what rolling tuning back needs: the files and kernel
+// parameters as they were before karei first tuned the system. Tuning again
+// keeps the first record, so rollback always returns to the original state.
+type TuneRecord struct {
+	Applied time.Time         `json:"applied"`
+	Files   []TuneBackup      `json:"files"`
+	Sysctl  map[string]string `json:"sysctl"`
+	Zram    bool              `json:"zram"` // Whether zram swap was running before
+}
+
+// DeveloperTuning returns the tuning for a development machine. With zram,
+// swap goes to compressed memory, which is cheap to swap to, so the kernel
+// is told to prefer it over dropping file cache; without zram swapping to
+// disk is made rarer instead.
+func DeveloperTuning(zram bool) Tuning {
+	swappiness := SysctlSetting{"vm.swappiness", "10", "swap to disk only under memory pressure"}
+	if zram {
+		swappiness = SysctlSetting{"vm.swappiness", "180", "compressed RAM swap is cheaper than dropping file cache"}
+	}
+
+	tuning := Tuning{
+		Zram: zram,
+		Sysctl: []SysctlSetting{
+			swappiness,
+			{"fs.inotify.max_user_watches", "524288", "IDEs and file watchers run out of watches on big projects"},
+			{"fs.inotify.max_user_instances", "1024", "every editor, bundler and test watcher takes an instance"},
+		},
+	}
+
+	if zram {
+		tuning.Sysctl = append(tuning.Sysctl, SysctlSetting{"vm.page-cluster", "0", "zram reads single pages fast, readahead only wastes memory"})
+	}
+
+	limits := "# Written by karei system tune, undo with karei system tune --rollback\n[Manager]\nDefaultLimitNOFILE=" + OpenFilesSoftLimit + ":524288\n"
+
+	tuning.Files = []TuneFile{
+		{SysctlTuneFile, tuning.sysctlConf()},
+		{SystemLimitsFile, limits},
+		{UserLimitsFile, limits},
+	}
+
+	if zram {
+		tuning.Files = append(tuning.Files, TuneFile{ZramConfigFile,
+			"# Written by karei system tune, undo with karei system tune --rollback\n" +
+				"[zram0]\nzram-size = min(ram / 2, 8192)\ncompression-algorithm = zstd\nswap-priority = 100\n"})
+	}
+
+	return tuning
+}
+
+// sysctlConf renders the kernel parameters as a sysctl.d file.
+func (t Tuning) sysctlConf() string {
+	var builder strings.Builder
+
+	builder.WriteString("# Written by karei system tune, undo with karei system tune --rollback\n")
+
+	for _, setting := range t.Sysctl {
+		builder.WriteString("\n# " + setting.Reason + "\n" + setting.Key + " = " + setting.Value + "\n")
+	}
+
+	return builder.String()
+}
+
+// HasZramSwap reports whether the contents of /proc/swaps list a zram device.
+func HasZramSwap(swaps string) bool {
+	for line := range strings.SplitSeq(swaps, "\n") {
+		if strings.HasPrefix(line, "/dev/zram") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeveloperTuning(t *testing.T) {
+	t.Parallel()
+
+	sysctl := func(tuning domain.Tuning) map[string]string {
+		values := make(map[string]string)
+		for _, setting := range tuning.Sysctl {
+			values[setting.Key] = setting.Value
+		}
+
+		return values
+	}
+
+	files := func(tuning domain.Tuning) map[string]string {
+		contents := make(map[string]string)
+		for _, file := range tuning.Files {
+			contents[file.Path] = file.Content
+		}
+
+		return contents
+	}
+
+	zram := domain.DeveloperTuning(true)
+	assert.Equal(t, "180", sysctl(zram)["vm.swappiness"])
+	assert.Equal(t, "0", sysctl(zram)["vm.page-cluster"])
+	assert.Contains(t, files(zram)[domain.ZramConfigFile], "compression-algorithm = zstd")
+	assert.Contains(t, files(zram)[domain.SysctlTuneFile], "fs.inotify.max_user_watches = 524288\n")
+	assert.Contains(t, files(zram)[domain.UserLimitsFile], "DefaultLimitNOFILE=65536:524288")
+
+	disk := domain.DeveloperTuning(false)
+	assert.Equal(t, "10", sysctl(disk)["vm.swappiness"])
+	assert.NotContains(t, sysctl(disk), "vm.page-cluster")
+	assert.NotContains(t, files(disk), domain.ZramConfigFile)
+}
+
+func TestHasZramSwap(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, domain.HasZramSwap("Filename\tType\tSize\tUsed\tPriority\n/dev/zram0\tpartition\t8388604\t0\t100\n"))
+	assert.False(t, domain.HasZramSwap("Filename\tType\tSize\tUsed\tPriority\n/swap.img\tfile\t2097148\t0\t-2\n"))
+}
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package fleet
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+const defaultConcurrency = 4
+
+// RunFunc runs a single karei step on a host, writing its output to out.
+type RunFunc func(ctx context.Context, host Host, sshArgs []string, step Step, out io.Writer) error
+
+// HostResult is the outcome of provisioning one host.
+type HostResult struct {
+	Host       string        `json:"host"`
+	Success    bool          `json:"success"`
+	Completed  int           `json:"completed"`
+	Total      int           `json:"total"`
+	FailedStep string        `json:"failed_step,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// Report aggregates the results of a fleet run in inventory order.
+type Report struct {
+	Hosts    []HostResult  `json:"hosts"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Failed returns the number of hosts that did not complete.
+func (r *Report) Failed() int {
+	failed := 0
+
+	for _, host := range r.Hosts {
+		if !host.Success {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// Apply provisions every host concurrently. A failing step stops only its own
+// host; other hosts carry on. Output is written line by line to out, prefixed
+// with the host name so interleaved progress stays readable.
+func Apply(ctx context.Context, inv *Inventory, run RunFunc, out io.Writer) *Report {
+	concurrency := inv.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	startTime := time.Now()
+	report := &Report{Hosts: make([]HostResult, len(inv.Hosts))}
+	sink := &lockedWriter{w: out}
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+
+	for i, host := range inv.Hosts {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			report.Hosts[i] = applyHost(ctx, inv, host, run, sink)
+		}()
+	}
+
+	wg.Wait()
+
+	report.Duration = time.Since(startTime)
+
+	return report
+}
+
+func applyHost(ctx context.Context, inv *Inventory, host Host, run RunFunc, sink *lockedWriter) HostResult {
+	startTime := time.Now()
+	steps := inv.Steps(host)
+	result := HostResult{Host: host.Host, Total: len(steps)}
+	out := &prefixWriter{prefix: "[" + host.Host + "] ", sink: sink}
+
+	defer out.Flush()
+
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			result.FailedStep = step.String()
+			result.Error = err.Error()
+
+			break
+		}
+
+		_, _ = io.WriteString(out, "==> "+step.String()+"\n")
+
+		if err := run(ctx, host, inv.SSHArgsFor(host), step, out); err != nil {
+			result.FailedStep = step.String()
+			result.Error = err.Error()
+
+			break
+		}
+
+		result.Completed++
+	}
+
+	result.Success = result.Completed == result.Total
+	result.Duration = time.Since(startTime)
+
+	return result
+}
+
+// lockedWriter serialises writes from concurrent hosts.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.w.Write(p)
+}
+
+// prefixWriter buffers partial lines and emits complete lines with a prefix.
+type prefixWriter struct {
+	prefix string
+	sink   io.Writer
+	buf    bytes.Buffer
+}
+
+func (p *prefixWriter) Write(data []byte) (int, error) {
+	p.buf.Write(data)
+
+	for {
+		line, err := p.buf.ReadBytes('\n')
+		if err != nil {
+			// Keep the incomplete line for the next write
+			p.buf.Write(line)
+
+			break
+		}
+
+		if _, err := p.sink.Write(append([]byte(p.prefix), line...)); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(data), nil
+}
+
+// Flush writes any trailing partial line.
+func (p *prefixWriter) Flush() {
+	if p.buf.Len() > 0 {
+		_, _ = p.sink.Write(append([]byte(p.prefix), append(p.buf.Bytes(), '\n')...))
+		p.buf.Reset()
+	}
+}
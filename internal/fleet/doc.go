@@ -0,0 +1,5 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+// Package fleet provisions multiple machines from an inventory file.
+package fleet
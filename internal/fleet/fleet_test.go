@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package fleet_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testInventory = `
+concurrency: 2
+ssh_args: ["-p", "2222"]
+vars:
+  theme: tokyo-night
+profiles:
+  developer:
+    groups: [development]
+    apps: [git, vim]
+    versions:
+      vim: "=9.1"
+    theme: ${theme}
+hosts:
+  - host: user@lab1
+    profiles: [developer]
+  - host: user@lab2
+    profiles: [developer]
+    vars:
+      theme: nord
+`
+
+func TestParse_ExpandsProfilesWithVariables(t *testing.T) {
+	t.Parallel()
+
+	inv, err := fleet.Parse([]byte(testInventory))
+	require.NoError(t, err)
+
+	assert.Equal(t, []fleet.Step{
+		{"install", "--group", "development", "--constraint", "vim==9.1"},
+		{"install", "--packages", "git,vim", "--constraint", "vim==9.1"},
+		{"theme", "apply", "--name", "tokyo-night"},
+	}, inv.Steps(inv.Hosts[0]))
+
+	steps := inv.Steps(inv.Hosts[1])
+	assert.Equal(t, fleet.Step{"theme", "apply", "--name", "nord"}, steps[2])
+	assert.Equal(t, []string{"-p", "2222"}, inv.SSHArgsFor(inv.Hosts[1]))
+}
+
+func TestParse_Validation(t *testing.T) {
+	t.Parallel()
+
+	_, err := fleet.Parse([]byte("hosts: []"))
+	require.ErrorIs(t, err, fleet.ErrNoHosts)
+
+	_, err = fleet.Parse([]byte("hosts:\n  - host: a\n    profiles: [missing]"))
+	require.ErrorIs(t, err, fleet.ErrUnknownProfile)
+
+	_, err = fleet.Parse([]byte("hosts:\n  - host: a\n  - host: a"))
+	require.ErrorIs(t, err, fleet.ErrInvalidHost)
+
+	for _, host := range []string{"-oProxyCommand=touch /tmp/pwned", "user@-oProxyCommand=id", "-l@box", "a b", "user@", "a@b@c", "box;reboot"} {
+		_, err = fleet.Parse([]byte("hosts:\n  - host: '" + host + "'"))
+		require.ErrorIs(t, err, fleet.ErrInvalidHost, host)
+	}
+
+	_, err = fleet.Parse([]byte("hosts:\n  - host: deploy@lab-1.example.org\n  - host: 192.168.1.10\n  - host: fe80::1"))
+	require.NoError(t, err)
+
+	_, err = fleet.Parse([]byte("hosts:\n  - hots: a"))
+	require.Error(t, err)
+
+	_, err = fleet.Parse([]byte("profiles:\n  p:\n    versions: {node: newest}\nhosts:\n  - host: a\n    profiles: [p]"))
+	require.ErrorIs(t, err, domain.ErrInvalidConstraint)
+}
+
+func TestApply_IsolatesFailures(t *testing.T) {
+	t.Parallel()
+
+	inv, err := fleet.Parse([]byte(testInventory))
+	require.NoError(t, err)
+
+	run := func(_ context.Context, host fleet.Host, _ []string, step fleet.Step, out io.Writer) error {
+		if host.Host == "user@lab2" && step[0] == "theme" {
+			return errors.New("theme failed")
+		}
+
+		_, _ = io.WriteString(out, "ok "+step[0])
+
+		return nil
+	}
+
+	var out bytes.Buffer
+
+	report := fleet.Apply(context.Background(), inv, run, &out)
+
+	require.Len(t, report.Hosts, 2)
+	assert.True(t, report.Hosts[0].Success)
+	assert.Equal(t, 3, report.Hosts[0].Completed)
+
+	assert.False(t, report.Hosts[1].Success)
+	assert.Equal(t, 2, report.Hosts[1].Completed)
+	assert.Equal(t, "karei theme apply --name nord", report.Hosts[1].FailedStep)
+	assert.Equal(t, 1, report.Failed())
+
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		assert.Regexp(t, `^\[user@lab[12]\] `, line)
+	}
+}
+
+func TestProfile_GeneratorOnly(t *testing.T) {
+	t.Parallel()
+
+	inv, err := fleet.Parse([]byte(testInventory))
+	require.NoError(t, err)
+	assert.Empty(t, inv.Profiles["developer"].GeneratorOnly())
+
+	profile := fleet.Profile{
+		Apps:       []string{"code"},
+		Files:      map[string]string{".gitconfig": "gitconfig"},
+		Extensions: []string{"golang.go"},
+	}
+	assert.Equal(t, []string{"files", "extensions"}, profile.GeneratorOnly())
+	assert.Len(t, (&fleet.Inventory{Profiles: map[string]fleet.Profile{"dev": profile}}).Steps(fleet.Host{Profiles: []string{"dev"}}), 1,
+		"fleet apply has no steps for files and extensions")
+}
@@ -0,0 +1,286 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package fleet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+var (
+	// ErrNoHosts is returned when an inventory lists no hosts.
+	ErrNoHosts = errors.New("inventory has no hosts")
+	// ErrUnknownProfile is returned when a host references a profile that is not defined.
+	ErrUnknownProfile = errors.New("unknown profile")
+	// ErrInvalidHost is returned when a host entry is malformed.
+	ErrInvalidHost = errors.New("invalid host")
+)
+
+// Inventory describes a set of machines and what to provision on them.
+//
+//	concurrency: 4
+//	vars:
+//	  theme: tokyo-night
+//	profiles:
+//	  developer:
+//	    groups: [development]
+//	    apps: [git, vim, node]
+//	    versions:
+//	      node: ">=20 <21"
+//	    theme: ${theme}
+//	hosts:
+//	  - host: user@lab1
+//	    profiles: [developer]
+//	    vars:
+//	      theme: nord
+type Inventory struct {
+	Concurrency int                `yaml:"concurrency"`
+	SSHArgs     []string           `yaml:"ssh_args"`
+	Vars        map[string]string  `yaml:"vars"`
+	Profiles    map[string]Profile `yaml:"profiles"`
+	Hosts       []Host             `yaml:"hosts"`
+}
+
+// Profile is a reusable set of provisioning steps. Files and Extensions are
+// only used by the generators; fleet apply skips them.
+type Profile struct {
+	Groups []string          `yaml:"groups"`
+	Apps   []string          `yaml:"apps"`
+	Theme  string            `yaml:"theme"`
+	Files  map[string]string `yaml:"files"` // home-relative destination -> local source
+
+	Versions map[string]string `yaml:"versions"` // Version constraint per app, e.g. ">=20 <21"
+
+	Extensions []string `yaml:"extensions"` // VS Code extension IDs
+}
+
+// GeneratorOnly returns the fields set in the profile that only the
+// generators honor, so fleet apply can say it skips them.
+func (p Profile) GeneratorOnly() []string {
+	var fields []string
+
+	if len(p.Files) > 0 {
+		fields = append(fields, "files")
+	}
+
+	if len(p.Extensions) > 0 {
+		fields = append(fields, "extensions")
+	}
+
+	return fields
+}
+
+// Host is a single machine in the inventory.
+type Host struct {
+	Host     string            `yaml:"host"`
+	Profiles []string          `yaml:"profiles"`
+	SSHArgs  []string          `yaml:"ssh_args"`
+	Vars     map[string]string `yaml:"vars"`
+}
+
+// Step is a single karei invocation to run on a host.
+type Step []string
+
+// String renders the step as it would be typed on the command line.
+func (s Step) String() string {
+	return "karei " + strings.Join(s, " ")
+}
+
+// Load reads and validates an inventory file.
+func Load(path string) (*Inventory, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // inventory path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse decodes and validates inventory YAML.
+func Parse(data []byte) (*Inventory, error) {
+	var inv Inventory
+
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&inv); err != nil {
+		return nil, fmt.Errorf("failed to parse inventory: %w", err)
+	}
+
+	if err := inv.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &inv, nil
+}
+
+// Validate checks that every host is addressable and references known profiles.
+func (inv *Inventory) Validate() error {
+	if len(inv.Hosts) == 0 {
+		return ErrNoHosts
+	}
+
+	seen := make(map[string]bool, len(inv.Hosts))
+
+	for i, host := range inv.Hosts {
+		if host.Host == "" {
+			return fmt.Errorf("%w: entry %d has no host", ErrInvalidHost, i+1)
+		}
+
+		if !validDestination(host.Host) {
+			return fmt.Errorf("%w: %q, expected host or user@host", ErrInvalidHost, host.Host)
+		}
+
+		if seen[host.Host] {
+			return fmt.Errorf("%w: %s is listed twice", ErrInvalidHost, host.Host)
+		}
+
+		seen[host.Host] = true
+
+		for _, name := range host.Profiles {
+			if _, exists := inv.Profiles[name]; !exists {
+				return fmt.Errorf("%w: %s (host %s)", ErrUnknownProfile, name, host.Host)
+			}
+		}
+	}
+
+	for name, profile := range inv.Profiles {
+		if _, err := domain.ParseVersionConstraints(profile.Versions); err != nil {
+			return fmt.Errorf("profile %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// validDestination reports whether dest is a plain ssh destination, host or
+// user@host. Anything else, such as -oProxyCommand=..., ends up in the ssh
+// and scp command lines.
+func validDestination(dest string) bool {
+	host := dest
+	if user, rest, hasUser := strings.Cut(dest, "@"); hasUser {
+		if !validDestinationPart(user, "") {
+			return false
+		}
+
+		host = rest
+	}
+
+	// Colons allow IPv6 addresses
+	return validDestinationPart(host, ":")
+}
+
+// validDestinationPart reports whether part is non-empty, doesn't start with
+// "-" and only has letters, digits, ".", "_", "-" and the extra characters.
+func validDestinationPart(part, extra string) bool {
+	if part == "" || strings.HasPrefix(part, "-") {
+		return false
+	}
+
+	return !strings.ContainsFunc(part, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || strings.ContainsRune("._-"+extra, r))
+	})
+}
+
+// ConstraintFlags returns --constraint flags holding installs to the
+// profile's versions, sorted by app.
+func (p Profile) ConstraintFlags() []string {
+	keys := make([]string, 0, len(p.Versions))
+	for key := range p.Versions {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	flags := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		flags = append(flags, "--constraint", key+"="+p.Versions[key])
+	}
+
+	return flags
+}
+
+// Steps expands the host's profiles into karei invocations, substituting
+// ${var} references with host variables layered over inventory variables.
+func (inv *Inventory) Steps(host Host) []Step {
+	vars := make(map[string]string, len(inv.Vars)+len(host.Vars))
+	for key, value := range inv.Vars {
+		vars[key] = value
+	}
+
+	for key, value := range host.Vars {
+		vars[key] = value
+	}
+
+	expand := func(s string) string {
+		return os.Expand(s, func(key string) string { return vars[key] })
+	}
+
+	var steps []Step
+
+	for _, name := range host.Profiles {
+		profile := inv.Profiles[name]
+
+		constraints := profile.ConstraintFlags()
+
+		for _, group := range profile.Groups {
+			steps = append(steps, append(Step{"install", "--group", expand(group)}, constraints...))
+		}
+
+		if len(profile.Apps) > 0 {
+			apps := make([]string, 0, len(profile.Apps))
+			for _, app := range profile.Apps {
+				apps = append(apps, expand(app))
+			}
+
+			steps = append(steps, append(Step{"install", "--packages", strings.Join(apps, ",")}, constraints...))
+		}
+
+		if theme := expand(profile.Theme); theme != "" {
+			steps = append(steps, Step{"theme", "apply", "--name", theme})
+		}
+	}
+
+	return steps
+}
+
+// SSHArgsFor returns the SSH arguments for a host, host-specific ones last.
+func (inv *Inventory) SSHArgsFor(host Host) []string {
+	return append(append([]string{}, inv.SSHArgs...), host.SSHArgs...)
+}
+
+// LoadProfile reads a standalone profile file with the same fields as an inventory profile.
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // profile path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	return ParseProfile(data)
+}
+
+// ParseProfile parses a standalone profile, rejecting unknown fields.
+func ParseProfile(data []byte) (*Profile, error) {
+	var profile Profile
+
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&profile); err != nil {
+		return nil, fmt.Errorf("failed to parse profile: %w", err)
+	}
+
+	if _, err := domain.ParseVersionConstraints(profile.Versions); err != nil {
+		return nil, fmt.Errorf("failed to parse profile: %w", err)
+	}
+
+	return &profile, nil
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package generate
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/apps"
+)
+
+// KareiBinary is the file name the Containerfile expects in its build context
+// when apps must be installed through karei.
+const KareiBinary = "karei"
+
+// NeedsKarei reports whether the container build runs karei itself.
+func (p *Plan) NeedsKarei() bool {
+	return len(p.Karei) > 0 || p.Theme != ""
+}
+
+// Containerfile renders the plan as a Containerfile based on image. apt
+// packages and mise tools become cached layers; apps with no container
+// equivalent are installed by a karei binary copied from the build context.
+// Flatpaks and snaps need a session bus and are listed as skipped.
+func Containerfile(plan *Plan, image string) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Generated by karei provision\nFROM %s\n\n", image)
+	b.WriteString("ENV DEBIAN_FRONTEND=noninteractive\n\n")
+
+	aptPackages := append([]string{"ca-certificates", "curl"}, plan.APT...)
+	if plan.NeedsKarei() {
+		aptPackages = append(aptPackages, "sudo")
+	}
+
+	b.WriteString("RUN apt-get update \\\n")
+	fmt.Fprintf(&b, "    && apt-get install -y --no-install-recommends %s \\\n", strings.Join(aptPackages, " "))
+	b.WriteString("    && rm -rf /var/lib/apt/lists/*\n")
+
+	if len(plan.Mise) > 0 {
+		tools := make([]string, 0, len(plan.Mise))
+		for _, key := range plan.Mise {
+			tools = append(tools, miseTool(key))
+		}
+
+		b.WriteString("\nENV MISE_INSTALL_PATH=/usr/local/bin/mise PATH=/root/.local/share/mise/shims:$PATH\n")
+		b.WriteString("RUN curl -fsSL https://mise.run | sh \\\n")
+		fmt.Fprintf(&b, "    && mise use -g %s\n", strings.Join(tools, " "))
+	}
+
+	if plan.NeedsKarei() {
+		fmt.Fprintf(&b, "\nCOPY %s /usr/local/bin/karei\n", KareiBinary)
+
+		for _, command := range plan.kareiCommands(false) {
+			fmt.Fprintf(&b, "RUN %s\n", shellJoin(command))
+		}
+	}
+
+	if len(plan.Files) > 0 {
+		b.WriteString("\n")
+
+		for _, dest := range sortedKeys(plan.Files) {
+			fmt.Fprintf(&b, "COPY %s %s\n", plan.Files[dest], path.Join("/root", dest))
+		}
+	}
+
+	if skipped := append(append([]string{}, plan.Flatpak...), plan.Snap...); len(skipped) > 0 {
+		fmt.Fprintf(&b, "\n# Skipped (flatpak/snap need a desktop session): %s\n", strings.Join(skipped, " "))
+	}
+
+	return []byte(b.String())
+}
+
+func miseTool(key string) string {
+	if app, exists := apps.Apps[key]; exists && app.Source != "" {
+		return app.Source
+	}
+
+	return key
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
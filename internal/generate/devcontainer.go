@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Devcontainer file names, relative to the .devcontainer directory.
+const (
+	DevcontainerSetupScript = "karei-setup.sh"
+	DevcontainerDotfilesDir = "dotfiles"
+)
+
+type devcontainerConfig struct {
+	Name              string                 `json:"name"`
+	Image             string                 `json:"image"`
+	PostCreateCommand string                 `json:"postCreateCommand"`
+	Customizations    devcontainerCustomizer `json:"customizations,omitzero"`
+}
+
+type devcontainerCustomizer struct {
+	VSCode struct {
+		Extensions []string `json:"extensions,omitempty"`
+	} `json:"vscode,omitzero"`
+}
+
+// Devcontainer renders the plan as a devcontainer.json and the setup script it
+// runs after the container is created. Dotfiles are expected under
+// .devcontainer/dotfiles and the karei binary, when needed, in .devcontainer.
+func Devcontainer(plan *Plan, image string) (config, script []byte, err error) {
+	cfg := devcontainerConfig{
+		Name:              "karei",
+		Image:             image,
+		PostCreateCommand: "bash .devcontainer/" + DevcontainerSetupScript,
+	}
+	cfg.Customizations.VSCode.Extensions = plan.Extensions
+
+	config, err = json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to render devcontainer.json: %w", err)
+	}
+
+	return append(config, '\n'), devcontainerScript(plan), nil
+}
+
+func devcontainerScript(plan *Plan) []byte {
+	var b strings.Builder
+
+	b.WriteString("#!/usr/bin/env bash\n# Generated by karei generate devcontainer\nset -euo pipefail\n")
+	b.WriteString("cd \"$(dirname \"$0\")\"\n")
+
+	if len(plan.APT) > 0 {
+		b.WriteString("\nsudo apt-get update\n")
+		fmt.Fprintf(&b, "sudo apt-get install -y --no-install-recommends %s\n", strings.Join(plan.APT, " "))
+	}
+
+	if len(plan.Mise) > 0 {
+		tools := make([]string, 0, len(plan.Mise))
+		for _, key := range plan.Mise {
+			tools = append(tools, miseTool(key))
+		}
+
+		b.WriteString("\nexport PATH=\"$HOME/.local/bin:$PATH\"\n")
+		b.WriteString("command -v mise >/dev/null || curl -fsSL https://mise.run | sh\n")
+		fmt.Fprintf(&b, "mise use -g %s\n", strings.Join(tools, " "))
+	}
+
+	for _, dest := range sortedKeys(plan.Files) {
+		target := path.Join("$HOME", dest)
+		fmt.Fprintf(&b, "\nmkdir -p \"%s\"\ncp %s \"%s\"\n", path.Dir(target), path.Join(DevcontainerDotfilesDir, plan.Files[dest]), target)
+	}
+
+	for _, command := range plan.kareiCommands(false) {
+		command[0] = "./" + KareiBinary
+		fmt.Fprintf(&b, "\n%s\n", shellJoin(command))
+	}
+
+	if skipped := append(append([]string{}, plan.Flatpak...), plan.Snap...); len(skipped) > 0 {
+		fmt.Fprintf(&b, "\n# Skipped (flatpak/snap need a desktop session): %s\n", strings.Join(skipped, " "))
+	}
+
+	return []byte(b.String())
+}
@@ -0,0 +1,307 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package generate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/fleet"
+)
+
+const (
+	flathubURL = "https://dl.flathub.org/repo/flathub.flatpakrepo"
+
+	// kareiReleaseURL is where generated playbooks and user-data download
+	// karei from before running it; kareiPath is where they install it.
+	kareiReleaseURL = "https://github.com/janderssonse/karei/releases/latest/download/karei"
+	kareiPath       = "/usr/local/bin/karei"
+)
+
+var (
+	// ErrUnknownGroup is returned when a profile references a group missing from the catalog.
+	ErrUnknownGroup = errors.New("unknown group")
+	// ErrUnknownApp is returned when a profile references an app missing from the catalog.
+	ErrUnknownApp = errors.New("unknown app")
+)
+
+// Plan sorts a profile's applications by how other tools can install them.
+// Apps without a native equivalent are installed by invoking karei itself.
+type Plan struct {
+	APT     []string          // apt package names
+	Snap    []string          // snap names
+	Flatpak []string          // flatpak application IDs
+	Mise    []string          // catalog keys of mise tools
+	Karei   []string          // catalog keys installed through karei
+	Theme   string            // theme applied through karei
+	Files   map[string]string // local config files keyed by home-relative destination
+
+	Extensions  []string // VS Code extension IDs
+	Constraints []string // --constraint flags for the profile's versions
+}
+
+// NewPlan resolves groups and apps in the profile against the catalog.
+func NewPlan(profile *fleet.Profile) (*Plan, error) {
+	keys := make([]string, 0, len(profile.Apps))
+
+	for _, group := range profile.Groups {
+		members, exists := apps.Groups[group]
+		if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownGroup, group)
+		}
+
+		keys = append(keys, members...)
+	}
+
+	keys = append(keys, profile.Apps...)
+
+	plan := &Plan{
+		Theme:       profile.Theme,
+		Files:       profile.Files,
+		Extensions:  profile.Extensions,
+		Constraints: profile.ConstraintFlags(),
+	}
+	seen := make(map[string]bool, len(keys))
+
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		app, exists := apps.Apps[key]
+		if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownApp, key)
+		}
+
+		// Only karei enforces version constraints
+		if _, constrained := profile.Versions[key]; constrained {
+			plan.Karei = append(plan.Karei, key)
+
+			continue
+		}
+
+		source := app.Source
+		if source == "" {
+			source = key
+		}
+
+		switch app.Method { //nolint:exhaustive // everything else goes through karei
+		case domain.MethodAPT:
+			plan.APT = append(plan.APT, source)
+		case domain.MethodSnap:
+			plan.Snap = append(plan.Snap, source)
+		case domain.MethodFlatpak:
+			plan.Flatpak = append(plan.Flatpak, source)
+		case domain.MethodMise:
+			plan.Mise = append(plan.Mise, key)
+		default:
+			plan.Karei = append(plan.Karei, key)
+		}
+	}
+
+	return plan, nil
+}
+
+// kareiCommands returns the karei invocations for apps and settings with no
+// native equivalent. karei sets up mise itself, so withMise routes mise tools
+// through it too.
+func (p *Plan) kareiCommands(withMise bool) [][]string {
+	var commands [][]string
+
+	keys := append([]string{}, p.Karei...)
+	if withMise {
+		keys = append(keys, p.Mise...)
+	}
+
+	if len(keys) > 0 {
+		install := []string{"karei", "--plain", "--yes", "install", "--packages", strings.Join(keys, ",")}
+		commands = append(commands, append(install, p.Constraints...))
+	}
+
+	if p.Theme != "" {
+		commands = append(commands, []string{"karei", "--plain", "theme", "apply", "--name", p.Theme})
+	}
+
+	return commands
+}
+
+// shellJoin renders command for a POSIX shell, quoting arguments such as
+// version constraints that contain spaces or redirections.
+func shellJoin(command []string) string {
+	quoted := make([]string, 0, len(command))
+
+	for _, arg := range command {
+		if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,@+") == "" {
+			quoted = append(quoted, arg)
+		} else {
+			quoted = append(quoted, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+		}
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+type ansiblePlay struct {
+	Name  string        `yaml:"name"`
+	Hosts string        `yaml:"hosts"`
+	Tasks []ansibleTask `yaml:"tasks"`
+}
+
+type ansibleTask struct {
+	Name   string         `yaml:"name"`
+	Become bool           `yaml:"become,omitempty"`
+	Module map[string]any `yaml:",inline"`
+}
+
+// Ansible renders the plan as a playbook targeting hosts.
+// Package tasks run with become; karei tasks run as the connecting user,
+// since karei elevates with sudo itself and configures the user's home.
+// karei is downloaded from its latest release before it first runs.
+func Ansible(plan *Plan, hosts string) ([]byte, error) {
+	var tasks []ansibleTask
+
+	if len(plan.APT) > 0 {
+		tasks = append(tasks, ansibleTask{
+			Name:   "Install apt packages",
+			Become: true,
+			Module: map[string]any{"ansible.builtin.apt": map[string]any{
+				"name": plan.APT, "state": "present", "update_cache": true,
+			}},
+		})
+	}
+
+	if len(plan.Snap) > 0 {
+		tasks = append(tasks, ansibleTask{
+			Name:   "Install snaps",
+			Become: true,
+			Module: map[string]any{"community.general.snap": map[string]any{"name": plan.Snap}},
+		})
+	}
+
+	if len(plan.Flatpak) > 0 {
+		tasks = append(tasks,
+			ansibleTask{
+				Name:   "Add Flathub remote",
+				Become: true,
+				Module: map[string]any{"community.general.flatpak_remote": map[string]any{
+					"name": "flathub", "flatpakrepo_url": flathubURL, "state": "present",
+				}},
+			},
+			ansibleTask{
+				Name:   "Install flatpaks",
+				Become: true,
+				Module: map[string]any{"community.general.flatpak": map[string]any{
+					"name": plan.Flatpak, "remote": "flathub", "state": "present",
+				}},
+			},
+		)
+	}
+
+	for _, dest := range sortedKeys(plan.Files) {
+		tasks = append(tasks, ansibleTask{
+			Name: "Copy " + dest,
+			Module: map[string]any{"ansible.builtin.copy": map[string]any{
+				"src": plan.Files[dest], "dest": "{{ ansible_env.HOME }}/" + dest,
+			}},
+		})
+	}
+
+	commands := plan.kareiCommands(true)
+	if len(commands) > 0 {
+		tasks = append(tasks, ansibleTask{
+			Name:   "Install karei",
+			Become: true,
+			Module: map[string]any{"ansible.builtin.get_url": map[string]any{
+				"url": kareiReleaseURL, "dest": kareiPath, "mode": "0755",
+			}},
+		})
+	}
+
+	for _, command := range commands {
+		tasks = append(tasks, ansibleTask{
+			Name:   "Run " + strings.Join(command, " "),
+			Module: map[string]any{"ansible.builtin.command": map[string]any{"argv": command}},
+		})
+	}
+
+	return render("---\n", []ansiblePlay{{Name: "Provision with karei profile", Hosts: hosts, Tasks: tasks}})
+}
+
+type cloudConfig struct {
+	PackageUpdate bool       `yaml:"package_update"`
+	Packages      []string   `yaml:"packages,omitempty"`
+	Snap          *cloudSnap `yaml:"snap,omitempty"`
+	RunCmd        [][]string `yaml:"runcmd,omitempty"`
+}
+
+type cloudSnap struct {
+	Commands [][]string `yaml:"commands"`
+}
+
+// CloudInit renders the plan as cloud-init user-data. cloud-init runs
+// commands as root, so karei steps are run as user via sudo -iu, after
+// karei is downloaded from its latest release. Files are not included since
+// user-data cannot reference local paths.
+func CloudInit(plan *Plan, user string) ([]byte, error) {
+	config := cloudConfig{PackageUpdate: true, Packages: append([]string{}, plan.APT...)}
+
+	if len(plan.Snap) > 0 {
+		config.Snap = &cloudSnap{}
+		for _, name := range plan.Snap {
+			config.Snap.Commands = append(config.Snap.Commands, []string{"snap", "install", name})
+		}
+	}
+
+	if len(plan.Flatpak) > 0 {
+		config.Packages = append(config.Packages, "flatpak")
+		config.RunCmd = append(config.RunCmd, []string{"flatpak", "remote-add", "--if-not-exists", "flathub", flathubURL})
+
+		for _, id := range plan.Flatpak {
+			config.RunCmd = append(config.RunCmd, []string{"flatpak", "install", "-y", "--noninteractive", "flathub", id})
+		}
+	}
+
+	commands := plan.kareiCommands(true)
+	if len(commands) > 0 {
+		config.Packages = append(config.Packages, "curl")
+		config.RunCmd = append(config.RunCmd,
+			[]string{"curl", "-fsSL", "-o", kareiPath, kareiReleaseURL},
+			[]string{"chmod", "0755", kareiPath},
+		)
+	}
+
+	for _, command := range commands {
+		config.RunCmd = append(config.RunCmd, append([]string{"sudo", "-iu", user}, command...))
+	}
+
+	return render("#cloud-config\n", config)
+}
+
+// render encodes value as YAML with conventional two-space indentation after header.
+func render(header string, value any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString(header)
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to render YAML: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to render YAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package generate_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/fleet"
+	"github.com/janderssonse/karei/internal/generate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewPlan_SortsAppsByMethod(t *testing.T) {
+	t.Parallel()
+
+	plan, err := generate.NewPlan(&fleet.Profile{Apps: []string{"vlc", "zed", "vscode", "vlc"}, Theme: "nord"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"vlc"}, plan.APT)
+	assert.Equal(t, []string{"dev.zed.Zed"}, plan.Flatpak)
+	assert.Equal(t, []string{"vscode"}, plan.Karei)
+	assert.Equal(t, "nord", plan.Theme)
+
+	_, err = generate.NewPlan(&fleet.Profile{Groups: []string{"nope"}})
+	require.ErrorIs(t, err, generate.ErrUnknownGroup)
+
+	_, err = generate.NewPlan(&fleet.Profile{Apps: []string{"nope"}})
+	require.ErrorIs(t, err, generate.ErrUnknownApp)
+}
+
+func TestAnsible(t *testing.T) {
+	t.Parallel()
+
+	plan, err := generate.NewPlan(&fleet.Profile{Apps: []string{"vlc", "zed", "vscode"}})
+	require.NoError(t, err)
+
+	out, err := generate.Ansible(plan, "lab")
+	require.NoError(t, err)
+
+	var plays []map[string]any
+	require.NoError(t, yaml.Unmarshal(out, &plays))
+	require.Len(t, plays, 1)
+	assert.Equal(t, "lab", plays[0]["hosts"])
+
+	tasks, ok := plays[0]["tasks"].([]any)
+	require.True(t, ok)
+	assert.Len(t, tasks, 5) // apt, flathub remote, flatpak, karei download, karei
+	assert.Contains(t, string(out), "ansible.builtin.apt")
+	assert.Contains(t, string(out), "--packages\n")
+
+	// karei is installed before it runs
+	download, ok := tasks[3].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "Install karei", download["name"])
+	assert.Contains(t, download, "ansible.builtin.get_url")
+
+	// Profiles without karei steps don't download it
+	native, err := generate.NewPlan(&fleet.Profile{Apps: []string{"vlc"}})
+	require.NoError(t, err)
+
+	out, err = generate.Ansible(native, "lab")
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "Install karei")
+}
+
+func TestCloudInit(t *testing.T) {
+	t.Parallel()
+
+	plan, err := generate.NewPlan(&fleet.Profile{Apps: []string{"vlc", "zed"}, Theme: "nord"})
+	require.NoError(t, err)
+
+	out, err := generate.CloudInit(plan, "dev")
+	require.NoError(t, err)
+	assert.Regexp(t, `^#cloud-config\n`, string(out))
+
+	var config struct {
+		Packages []string   `yaml:"packages"`
+		RunCmd   [][]string `yaml:"runcmd"`
+	}
+	require.NoError(t, yaml.Unmarshal(out, &config))
+
+	assert.Equal(t, []string{"vlc", "flatpak", "curl"}, config.Packages)
+	assert.Equal(t, []string{"curl", "-fsSL", "-o", "/usr/local/bin/karei",
+		"https://github.com/janderssonse/karei/releases/latest/download/karei"}, config.RunCmd[len(config.RunCmd)-3])
+	assert.Equal(t, []string{"chmod", "0755", "/usr/local/bin/karei"}, config.RunCmd[len(config.RunCmd)-2])
+	assert.Equal(t, []string{"sudo", "-iu", "dev", "karei", "--plain", "theme", "apply", "--name", "nord"}, config.RunCmd[len(config.RunCmd)-1])
+}
+
+func TestContainerfile(t *testing.T) {
+	t.Parallel()
+
+	plan, err := generate.NewPlan(&fleet.Profile{
+		Apps:  []string{"vlc", "zed", "vscode", "rust"},
+		Files: map[string]string{".gitconfig": "gitconfig"},
+	})
+	require.NoError(t, err)
+	assert.True(t, plan.NeedsKarei())
+
+	out := string(generate.Containerfile(plan, "ubuntu:24.04"))
+
+	assert.Contains(t, out, "FROM ubuntu:24.04\n")
+	assert.Contains(t, out, "--no-install-recommends ca-certificates curl vlc sudo")
+	assert.Contains(t, out, "mise use -g rust\n")
+	assert.Contains(t, out, "RUN karei --plain --yes install --packages vscode\n")
+	assert.Contains(t, out, "COPY gitconfig /root/.gitconfig\n")
+	assert.Contains(t, out, "# Skipped (flatpak/snap need a desktop session): dev.zed.Zed\n")
+}
+
+func TestContainerfileVersionConstraints(t *testing.T) {
+	t.Parallel()
+
+	plan, err := generate.NewPlan(&fleet.Profile{
+		Apps:     []string{"vlc", "rust"},
+		Versions: map[string]string{"rust": ">=1.80 <2"},
+	})
+	require.NoError(t, err)
+
+	// Constrained apps go through karei, which enforces the constraint
+	assert.Equal(t, []string{"rust"}, plan.Karei)
+	assert.Empty(t, plan.Mise)
+
+	out := string(generate.Containerfile(plan, "ubuntu:24.04"))
+	assert.Contains(t, out, "RUN karei --plain --yes install --packages rust --constraint 'rust=>=1.80 <2'\n")
+}
+
+func TestDevcontainer(t *testing.T) {
+	t.Parallel()
+
+	plan, err := generate.NewPlan(&fleet.Profile{
+		Apps:       []string{"vlc", "rust"},
+		Extensions: []string{"golang.go"},
+		Files:      map[string]string{".config/git/config": "gitconfig"},
+	})
+	require.NoError(t, err)
+
+	config, script, err := generate.Devcontainer(plan, "base:latest")
+	require.NoError(t, err)
+
+	var parsed struct {
+		Image             string `json:"image"`
+		PostCreateCommand string `json:"postCreateCommand"`
+		Customizations    struct {
+			VSCode struct {
+				Extensions []string `json:"extensions"`
+			} `json:"vscode"`
+		} `json:"customizations"`
+	}
+	require.NoError(t, json.Unmarshal(config, &parsed))
+	assert.Equal(t, "base:latest", parsed.Image)
+	assert.Equal(t, "bash .devcontainer/karei-setup.sh", parsed.PostCreateCommand)
+	assert.Equal(t, []string{"golang.go"}, parsed.Customizations.VSCode.Extensions)
+
+	assert.Contains(t, string(script), "sudo apt-get install -y --no-install-recommends vlc\n")
+	assert.Contains(t, string(script), "mise use -g rust\n")
+	assert.Contains(t, string(script), "cp dotfiles/gitconfig \"$HOME/.config/git/config\"\n")
+	assert.NotContains(t, string(script), "./karei")
+}
@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package offline
+
+import "embed"
+
+// Fixtures holds the packages/ tree of sample package data, so the TUI demo
+// mode has it outside the source tree.
+//
+//go:embed packages
+var Fixtures embed.FS
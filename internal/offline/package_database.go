@@ -1,14 +1,16 @@
 // SPDX-FileCopyrightText: 2025 The Karei Authors
 // SPDX-License-Identifier: EUPL-1.2
 
-// Package offline implements mock package database for offline testing.
+// Package offline implements an offline package database, backing the TUI
+// demo mode and offline tests.
 package offline
 
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
-	"path/filepath"
+	"path"
 	"strings"
 
 	"github.com/janderssonse/karei/internal/domain"
@@ -112,23 +114,26 @@ func NewPackageDB(verbose bool) *PackageDB {
 	}
 }
 
-// LoadFromFixtures loads package data from test fixtures.
+// LoadFromFixtures loads package data from a fixture directory laid out like Fixtures.
 func (db *PackageDB) LoadFromFixtures(fixtureDir string) error {
+	return db.LoadFromFS(os.DirFS(fixtureDir))
+}
+
+// LoadFromFS loads package data from a fixture tree rooted at fsys.
+// The tree must contain the packages/ directory layout used by LoadFromFixtures.
+func (db *PackageDB) LoadFromFS(fsys fs.FS) error {
 	// Load APT packages
-	aptFile := filepath.Join(fixtureDir, "packages", "apt_cache", "available_packages.json")
-	if err := db.loadAPTPackages(aptFile); err != nil {
+	if err := db.loadAPTPackages(fsys, "packages/apt_cache/available_packages.json"); err != nil {
 		return fmt.Errorf("failed to load APT packages: %w", err)
 	}
 
 	// Load Flatpak packages
-	flatpakFile := filepath.Join(fixtureDir, "packages", "flatpak_info", "available_flatpaks.json")
-	if err := db.loadFlatpakPackages(flatpakFile); err != nil {
+	if err := db.loadFlatpakPackages(fsys, "packages/flatpak_info/available_flatpaks.json"); err != nil {
 		return fmt.Errorf("failed to load Flatpak packages: %w", err)
 	}
 
 	// Load GitHub releases
-	githubDir := filepath.Join(fixtureDir, "packages", "github_releases")
-	if err := db.loadGitHubReleases(githubDir); err != nil {
+	if err := db.loadGitHubReleases(fsys, "packages/github_releases"); err != nil {
 		return fmt.Errorf("failed to load GitHub releases: %w", err)
 	}
 
@@ -303,8 +308,8 @@ func (db *PackageDB) ExportToJSON(filename string) error {
 }
 
 // loadAPTPackages loads APT package information.
-func (db *PackageDB) loadAPTPackages(filename string) error {
-	data, err := os.ReadFile(filename) //nolint:gosec
+func (db *PackageDB) loadAPTPackages(fsys fs.FS, filename string) error {
+	data, err := fs.ReadFile(fsys, filename)
 	if err != nil {
 		return err
 	}
@@ -333,8 +338,8 @@ func (db *PackageDB) loadAPTPackages(filename string) error {
 }
 
 // loadFlatpakPackages loads Flatpak package information.
-func (db *PackageDB) loadFlatpakPackages(filename string) error {
-	data, err := os.ReadFile(filename) //nolint:gosec
+func (db *PackageDB) loadFlatpakPackages(fsys fs.FS, filename string) error {
+	data, err := fs.ReadFile(fsys, filename)
 	if err != nil {
 		return err
 	}
@@ -372,8 +377,8 @@ func (db *PackageDB) loadFlatpakPackages(filename string) error {
 }
 
 // loadGitHubReleases loads GitHub release information.
-func (db *PackageDB) loadGitHubReleases(dir string) error {
-	files, err := os.ReadDir(dir)
+func (db *PackageDB) loadGitHubReleases(fsys fs.FS, dir string) error {
+	files, err := fs.ReadDir(fsys, dir)
 	if err != nil {
 		return err
 	}
@@ -387,9 +392,7 @@ func (db *PackageDB) loadGitHubReleases(dir string) error {
 		repoName := strings.TrimSuffix(file.Name(), "_latest.json")
 		repoName = strings.ReplaceAll(repoName, "_", "/")
 
-		filePath := filepath.Join(dir, file.Name())
-
-		data, err := os.ReadFile(filePath) //nolint:gosec
+		data, err := fs.ReadFile(fsys, path.Join(dir, file.Name()))
 		if err != nil {
 			continue
 		}
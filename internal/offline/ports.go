@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package offline
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// ResolveVersion implements domain.VersionResolver from the offline database.
+// Flatpaks are looked up by application ID, everything else by package name.
+func (db *PackageDB) ResolveVersion(_ context.Context, name, _ string) (string, error) {
+	if pkg, exists := db.lookup(name); exists {
+		return pkg.Version, nil
+	}
+
+	return "", nil
+}
+
+// IsInstalled implements domain.InstallationChecker, treating every package in
+// the database as installed. Used to populate the TUI demo mode.
+func (db *PackageDB) IsInstalled(_ context.Context, appKey string) bool {
+	_, exists := db.lookup(appKey)
+
+	return exists
+}
+
+// FetchDetails implements domain.AppDetailsSource from the offline database,
+// so the TUI demo mode shows app details without network requests.
+func (db *PackageDB) FetchDetails(_ context.Context, pkg *domain.Package) (*domain.AppDetails, error) {
+	for _, name := range []string{pkg.Source, pkg.Name} {
+		if name == "" {
+			continue
+		}
+
+		if meta, exists := db.lookup(name); exists {
+			return &domain.AppDetails{
+				LongDescription: meta.Description,
+				LatestRelease:   meta.Version,
+				FetchedAt:       time.Now(),
+			}, nil
+		}
+	}
+
+	return nil, domain.ErrNoUpstreamDetails
+}
+
+func (db *PackageDB) lookup(name string) (PackageMetadata, bool) {
+	if pkg, exists := db.packages[name]; exists {
+		return pkg, true
+	}
+
+	// Catalog keys match flatpaks by display name (e.g. "obsidian" -> md.obsidian.Obsidian)
+	for _, flatpak := range db.flatpaks {
+		if strings.EqualFold(flatpak.Name, name) {
+			return db.packages[flatpak.ID], true
+		}
+	}
+
+	return PackageMetadata{}, false
+}
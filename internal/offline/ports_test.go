@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package offline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/offline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ domain.VersionResolver     = (*offline.PackageDB)(nil)
+	_ domain.InstallationChecker = (*offline.PackageDB)(nil)
+	_ domain.AppDetailsSource    = (*offline.PackageDB)(nil)
+)
+
+func TestPackageDB_PortsFromEmbeddedFixtures(t *testing.T) {
+	t.Parallel()
+
+	db := offline.NewPackageDB(false)
+	require.NoError(t, db.LoadFromFS(offline.Fixtures))
+
+	ctx := context.Background()
+
+	assert.True(t, db.IsInstalled(ctx, "vim"))
+	assert.False(t, db.IsInstalled(ctx, "does-not-exist"))
+
+	version, err := db.ResolveVersion(ctx, "vim", "vim")
+	require.NoError(t, err)
+	assert.Equal(t, "8.2.4919-1ubuntu1", version)
+
+	version, err = db.ResolveVersion(ctx, "does-not-exist", "")
+	require.NoError(t, err)
+	assert.Empty(t, version)
+}
+
+func TestPackageDB_FetchDetails(t *testing.T) {
+	t.Parallel()
+
+	db := offline.NewPackageDB(false)
+	require.NoError(t, db.LoadFromFS(offline.Fixtures))
+
+	ctx := context.Background()
+
+	details, err := db.FetchDetails(ctx, &domain.Package{Name: "vim", Method: domain.MethodAPT, Source: "vim"})
+	require.NoError(t, err)
+	assert.Equal(t, "8.2.4919-1ubuntu1", details.LatestRelease)
+
+	_, err = db.FetchDetails(ctx, &domain.Package{Name: "does-not-exist"})
+	require.ErrorIs(t, err, domain.ErrNoUpstreamDetails)
+}
@@ -0,0 +1,6 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+// Package sbom renders the software karei installed as CycloneDX or SPDX
+// software bills of materials.
+package sbom
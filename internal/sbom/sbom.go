@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// Document formats.
+const (
+	FormatCycloneDX = "cyclonedx"
+	FormatSPDX      = "spdx"
+)
+
+// ErrUnknownFormat is returned for formats other than CycloneDX and SPDX.
+var ErrUnknownFormat = errors.New("unknown SBOM format")
+
+// noAssertion is SPDX's value for facts the tool does not know.
+const noAssertion = "NOASSERTION"
+
+// Document is an inventory of karei-managed packages and how it was made.
+type Document struct {
+	ToolVersion string    // Version of karei that made the inventory
+	Created     time.Time // When the inventory was taken
+	Packages    []domain.ManagedPackage
+}
+
+// Render renders the document in format, FormatCycloneDX or FormatSPDX.
+func Render(doc Document, format string) ([]byte, error) {
+	switch format {
+	case FormatCycloneDX:
+		return CycloneDX(doc)
+	case FormatSPDX:
+		return SPDX(doc)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// PackageURL returns the purl identifying pkg. Distribution packages and
+// GitHub releases get their own purl types, everything else is generic.
+func PackageURL(pkg domain.ManagedPackage) string {
+	purl := "pkg:generic/" + url.PathEscape(pkg.Key)
+
+	switch {
+	case pkg.Method == domain.MethodAPT:
+		purl = "pkg:deb/ubuntu/" + url.PathEscape(pkg.Source)
+	case strings.HasPrefix(string(pkg.Method), "github") && strings.Count(pkg.Source, "/") == 1:
+		purl = "pkg:github/" + strings.ToLower(pkg.Source)
+	}
+
+	if pkg.Version != "" {
+		purl += "@" + url.PathEscape(pkg.Version)
+	}
+
+	return purl
+}
+
+type cdxDocument struct {
+	BOMFormat   string         `json:"bomFormat"`
+	SpecVersion string         `json:"specVersion"`
+	Version     int            `json:"version"`
+	Metadata    cdxMetadata    `json:"metadata"`
+	Components  []cdxComponent `json:"components"`
+}
+
+type cdxMetadata struct {
+	Timestamp string   `json:"timestamp"`
+	Tools     cdxTools `json:"tools"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type               string        `json:"type"`
+	BOMRef             string        `json:"bom-ref,omitempty"`
+	Name               string        `json:"name"`
+	Version            string        `json:"version,omitempty"`
+	Description        string        `json:"description,omitempty"`
+	PURL               string        `json:"purl,omitempty"`
+	Hashes             []cdxHash     `json:"hashes,omitempty"`
+	ExternalReferences []cdxRef      `json:"externalReferences,omitempty"`
+	Properties         []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxRef struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CycloneDX renders the document as CycloneDX 1.5 JSON. The install method,
+// source and license are recorded as karei properties.
+func CycloneDX(doc Document) ([]byte, error) {
+	bom := cdxDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: cdxMetadata{
+			Timestamp: doc.Created.UTC().Format(time.RFC3339),
+			Tools: cdxTools{Components: []cdxComponent{
+				{Type: "application", Name: "karei", Version: doc.ToolVersion},
+			}},
+		},
+		Components: make([]cdxComponent, 0, len(doc.Packages)),
+	}
+
+	for _, pkg := range doc.Packages {
+		component := cdxComponent{
+			Type:        "application",
+			BOMRef:      pkg.Key,
+			Name:        pkg.Key,
+			Version:     pkg.Version,
+			Description: pkg.Name,
+			PURL:        PackageURL(pkg),
+			Properties: []cdxProperty{
+				{Name: "karei:method", Value: string(pkg.Method)},
+				{Name: "karei:source", Value: pkg.Source},
+				{Name: "karei:license", Value: string(pkg.License)},
+			},
+		}
+
+		if pkg.Checksum != "" {
+			component.Hashes = []cdxHash{{Alg: "SHA-256", Content: pkg.Checksum}}
+		}
+
+		if pkg.SourceURL != "" {
+			component.ExternalReferences = []cdxRef{{Type: "distribution", URL: pkg.SourceURL}}
+		}
+
+		bom.Components = append(bom.Components, component)
+	}
+
+	return marshal(bom)
+}
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SPDXID           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	Checksums        []spdxChecksum    `json:"checksums,omitempty"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	Comment          string            `json:"comment"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// SPDX renders the document as SPDX 2.3 JSON. The catalog only knows whether
+// an app is proprietary, so licenses are left as NOASSERTION and the license
+// kind is noted in each package comment.
+func SPDX(doc Document) ([]byte, error) {
+	created := doc.Created.UTC()
+
+	spdx := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              "karei-managed-software",
+		DocumentNamespace: "https://spdx.org/spdxdocs/karei-" + created.Format("20060102T150405Z"),
+		CreationInfo: spdxCreationInfo{
+			Created:  created.Format(time.RFC3339),
+			Creators: []string{"Tool: karei-" + doc.ToolVersion},
+		},
+		Packages:      make([]spdxPackage, 0, len(doc.Packages)),
+		Relationships: make([]spdxRelationship, 0, len(doc.Packages)),
+	}
+
+	for _, pkg := range doc.Packages {
+		id := spdxID(pkg.Key)
+
+		download := pkg.SourceURL
+		if download == "" {
+			download = noAssertion
+		}
+
+		spdxPkg := spdxPackage{
+			Name:             pkg.Key,
+			SPDXID:           id,
+			VersionInfo:      pkg.Version,
+			DownloadLocation: download,
+			LicenseConcluded: noAssertion,
+			LicenseDeclared:  noAssertion,
+			Comment:          fmt.Sprintf("%s, %s, installed with %s from %s", pkg.Name, pkg.License, pkg.Method, pkg.Source),
+			ExternalRefs: []spdxExternalRef{
+				{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: PackageURL(pkg)},
+			},
+		}
+
+		if pkg.Checksum != "" {
+			spdxPkg.Checksums = []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: pkg.Checksum}}
+		}
+
+		spdx.Packages = append(spdx.Packages, spdxPkg)
+		spdx.Relationships = append(spdx.Relationships, spdxRelationship{
+			SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: id,
+		})
+	}
+
+	return marshal(spdx)
+}
+
+// spdxID turns a catalog key into an SPDX identifier, which allows only
+// letters, digits, dots and dashes.
+func spdxID(key string) string {
+	return "SPDXRef-Package-" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+
+		return '-'
+	}, key)
+}
+
+func marshal(v any) ([]byte, error) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode SBOM: %w", err)
+	}
+
+	return append(out, '\n'), nil
+}
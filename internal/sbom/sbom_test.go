@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package sbom_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDocument() sbom.Document {
+	return sbom.Document{
+		ToolVersion: "1.2.0",
+		Created:     time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Packages: []domain.ManagedPackage{
+			{
+				Key: "vlc", Name: "VLC Media Player", Version: "3.0.20", Method: domain.MethodAPT, Source: "vlc",
+				SourceURL: "https://packages.ubuntu.com/vlc", License: domain.LicenseOpenSource, Checksum: "ab12",
+			},
+			{Key: "pmd", Name: "PMD", Method: domain.MethodGitHubJava, Source: "pmd/pmd", License: domain.LicenseOpenSource},
+		},
+	}
+}
+
+func TestPackageURL(t *testing.T) {
+	t.Parallel()
+
+	doc := testDocument()
+
+	assert.Equal(t, "pkg:deb/ubuntu/vlc@3.0.20", sbom.PackageURL(doc.Packages[0]))
+	assert.Equal(t, "pkg:github/pmd/pmd", sbom.PackageURL(doc.Packages[1]))
+	assert.Equal(t, "pkg:generic/zed@0.1",
+		sbom.PackageURL(domain.ManagedPackage{Key: "zed", Method: domain.MethodFlatpak, Source: "dev.zed.Zed", Version: "0.1"}))
+}
+
+func TestCycloneDX(t *testing.T) {
+	t.Parallel()
+
+	out, err := sbom.Render(testDocument(), sbom.FormatCycloneDX)
+	require.NoError(t, err)
+
+	var bom struct {
+		BOMFormat   string `json:"bomFormat"`
+		SpecVersion string `json:"specVersion"`
+		Metadata    struct {
+			Timestamp string `json:"timestamp"`
+		} `json:"metadata"`
+		Components []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			PURL    string `json:"purl"`
+			Hashes  []struct {
+				Alg     string `json:"alg"`
+				Content string `json:"content"`
+			} `json:"hashes"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(out, &bom))
+
+	assert.Equal(t, "CycloneDX", bom.BOMFormat)
+	assert.Equal(t, "1.5", bom.SpecVersion)
+	assert.Equal(t, "2025-03-01T12:00:00Z", bom.Metadata.Timestamp)
+	require.Len(t, bom.Components, 2)
+	assert.Equal(t, "vlc", bom.Components[0].Name)
+	assert.Equal(t, "pkg:deb/ubuntu/vlc@3.0.20", bom.Components[0].PURL)
+	require.Len(t, bom.Components[0].Hashes, 1)
+	assert.Equal(t, "SHA-256", bom.Components[0].Hashes[0].Alg)
+	assert.Empty(t, bom.Components[1].Hashes)
+	assert.Contains(t, string(out), `"karei:method"`)
+}
+
+func TestSPDX(t *testing.T) {
+	t.Parallel()
+
+	out, err := sbom.Render(testDocument(), sbom.FormatSPDX)
+	require.NoError(t, err)
+
+	var doc struct {
+		SPDXVersion string `json:"spdxVersion"`
+		Packages    []struct {
+			SPDXID           string `json:"SPDXID"`
+			DownloadLocation string `json:"downloadLocation"`
+			Checksums        []struct {
+				Algorithm string `json:"algorithm"`
+			} `json:"checksums"`
+		} `json:"packages"`
+		Relationships []struct {
+			RelatedSPDXElement string `json:"relatedSpdxElement"`
+		} `json:"relationships"`
+	}
+	require.NoError(t, json.Unmarshal(out, &doc))
+
+	assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+	require.Len(t, doc.Packages, 2)
+	assert.Equal(t, "SPDXRef-Package-vlc", doc.Packages[0].SPDXID)
+	assert.Equal(t, "SHA256", doc.Packages[0].Checksums[0].Algorithm)
+	assert.Equal(t, "NOASSERTION", doc.Packages[1].DownloadLocation)
+	require.Len(t, doc.Relationships, 2)
+	assert.Equal(t, "SPDXRef-Package-pmd", doc.Relationships[1].RelatedSPDXElement)
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := sbom.Render(testDocument(), "swid")
+	require.ErrorIs(t, err, sbom.ErrUnknownFormat)
+}
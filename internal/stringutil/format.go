@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package stringutil
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Locale returns the language numbers are formatted for, from LC_ALL,
+// LC_NUMERIC or LANG, or English when they name none.
+func Locale() language.Tag {
+	for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
+		// sv_SE.UTF-8@euro names sv-SE
+		value, _, _ = strings.Cut(value, ".")
+		value, _, _ = strings.Cut(value, "@")
+
+		if value == "C" || value == "POSIX" {
+			return language.English
+		}
+
+		tag, err := language.Parse(strings.ReplaceAll(value, "_", "-"))
+		if err != nil {
+			return language.English
+		}
+
+		return tag
+	}
+
+	return language.English
+}
+
+// printer formats numbers for the locale.
+func printer() *message.Printer {
+	return message.NewPrinter(Locale())
+}
+
+// FormatBytes renders a byte count with decimal units, e.g. "1.5 GB" or
+// "248 MB", with the locale's decimal separator.
+func FormatBytes(bytes int64) string {
+	units := []string{"B", "kB", "MB", "GB", "TB"}
+	size := float64(bytes)
+	unit := 0
+
+	for (size >= 1000 || size <= -1000) && unit < len(units)-1 {
+		size /= 1000
+		unit++
+	}
+
+	switch {
+	case unit == 0:
+		return printer().Sprintf("%d B", bytes)
+	case size >= 100 || size <= -100:
+		return printer().Sprintf("%.0f %s", size, units[unit])
+	default:
+		return printer().Sprintf("%.1f %s", size, units[unit])
+	}
+}
+
+// FormatDuration renders a duration in its two largest units, e.g.
+// "1 min 32 s" or "2 h 5 min", or with a decimal for fractions of seconds
+// below ten seconds.
+func FormatDuration(d time.Duration) string {
+	d = max(d, 0)
+	p := printer()
+
+	switch {
+	case d == 0:
+		return "0 s"
+	case d < time.Second:
+		return p.Sprintf("%d ms", d.Milliseconds())
+	case d < 10*time.Second && d%time.Second != 0:
+		return p.Sprintf("%.1f s", d.Seconds())
+	case d.Round(time.Second) < time.Minute:
+		return p.Sprintf("%d s", int(d.Round(time.Second).Seconds()))
+	case d.Round(time.Second) < time.Hour:
+		return formatUnits(p, d.Round(time.Second), time.Minute, time.Second, "min", "s")
+	case d.Round(time.Minute) < 24*time.Hour:
+		return formatUnits(p, d.Round(time.Minute), time.Hour, time.Minute, "h", "min")
+	default:
+		return formatUnits(p, d.Round(time.Hour), 24*time.Hour, time.Hour, "d", "h")
+	}
+}
+
+// formatUnits renders d in whole large units and the small units left,
+// leaving out zero small units.
+func formatUnits(p *message.Printer, d, large, small time.Duration, largeUnit, smallUnit string) string {
+	whole, rest := int64(d/large), int64(d%large/small)
+	if rest == 0 {
+		return p.Sprintf("%d %s", whole, largeUnit)
+	}
+
+	return p.Sprintf("%d %s %d %s", whole, largeUnit, rest, smallUnit)
+}
+
+// FormatCount renders a count with the locale's digit grouping, e.g.
+// "12,345".
+func FormatCount(n int) string {
+	return printer().Sprintf("%d", n)
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package stringutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestFormatBytes(t *testing.T) {
+	t.Setenv("LC_ALL", "C")
+
+	assert.Equal(t, "999 B", FormatBytes(999))
+	assert.Equal(t, "1.5 kB", FormatBytes(1500))
+	assert.Equal(t, "12.3 GB", FormatBytes(12_300_000_000))
+	assert.Equal(t, "248 MB", FormatBytes(248_400_000))
+}
+
+func TestFormatDuration(t *testing.T) {
+	t.Setenv("LC_ALL", "C")
+
+	tests := map[time.Duration]string{
+		0:                                       "0 s",
+		850 * time.Millisecond:                  "850 ms",
+		4200 * time.Millisecond:                 "4.2 s",
+		32 * time.Second:                        "32 s",
+		92400 * time.Millisecond:                "1 min 32 s",
+		2 * time.Minute:                         "2 min",
+		59*time.Minute + 59600*time.Millisecond: "1 h",
+		2*time.Hour + 5*time.Minute:             "2 h 5 min",
+		50 * time.Hour:                          "2 d 2 h",
+	}
+
+	for duration, want := range tests {
+		assert.Equal(t, want, FormatDuration(duration), duration.String())
+	}
+}
+
+func TestFormatLocalized(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_NUMERIC", "sv_SE.UTF-8")
+
+	assert.Equal(t, language.MustParse("sv-SE"), Locale())
+	assert.Equal(t, "1,5 kB", FormatBytes(1500))
+	assert.Equal(t, "4,2 s", FormatDuration(4200*time.Millisecond))
+
+	t.Setenv("LC_NUMERIC", "de_DE")
+	assert.Equal(t, "12.345", FormatCount(12345))
+
+	t.Setenv("LC_NUMERIC", "")
+	t.Setenv("LANG", "")
+	assert.Equal(t, language.English, Locale())
+	assert.Equal(t, "12,345", FormatCount(12345))
+}
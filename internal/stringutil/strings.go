@@ -3,7 +3,15 @@
 
 package stringutil
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidSize indicates a size that ParseBytes can't read.
+var ErrInvalidSize = errors.New("invalid size")
 
 // ContainsAny checks if text contains any of the provided substrings.
 func ContainsAny(text string, substrings []string) bool {
@@ -15,3 +23,33 @@ func ContainsAny(text string, substrings []string) bool {
 
 	return false
 }
+
+// ParseBytes reads a size such as "500MB", "1.5 GB" or "2048", using the
+// decimal units FormatBytes writes. Units are case insensitive.
+func ParseBytes(value string) (int64, error) {
+	text := strings.ToUpper(strings.TrimSpace(value))
+	number := strings.TrimRight(text, "KMGTB ")
+
+	multiplier := float64(1)
+
+	switch strings.TrimSpace(text[len(number):]) {
+	case "", "B":
+	case "K", "KB":
+		multiplier = 1e3
+	case "M", "MB":
+		multiplier = 1e6
+	case "G", "GB":
+		multiplier = 1e9
+	case "T", "TB":
+		multiplier = 1e12
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSize, value)
+	}
+
+	size, err := strconv.ParseFloat(number, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSize, value)
+	}
+
+	return int64(size * multiplier), nil
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const testSentence = "The quick brown fox jumps over the lazy dog"
@@ -117,6 +118,21 @@ func TestContainsAny(t *testing.T) {
 	}
 }
 
+func TestParseBytes(t *testing.T) {
+	t.Parallel()
+
+	for value, want := range map[string]int64{"2048": 2048, "500MB": 500_000_000, "1.5 gb": 1_500_000_000, "10 kB": 10_000} {
+		got, err := ParseBytes(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	for _, value := range []string{"", "MB", "5 PB", "-1", "lots"} {
+		_, err := ParseBytes(value)
+		require.ErrorIs(t, err, ErrInvalidSize, value)
+	}
+}
+
 func BenchmarkContainsAny(b *testing.B) {
 	text := testSentence
 	substrings := []string{"cat", "dog", "bird", "fish"}
@@ -11,6 +11,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
 	"github.com/janderssonse/karei/internal/tui/models"
 	"github.com/janderssonse/karei/internal/tui/styles"
 )
@@ -31,14 +33,17 @@ type Screen int
 
 // Define screen constants (use models constants for compatibility).
 const (
-	MenuScreen     Screen = Screen(models.MenuScreen)
-	AppsScreen     Screen = Screen(models.AppsScreen)
-	ThemeScreen    Screen = Screen(models.ThemeScreen)
-	ConfigScreen   Screen = Screen(models.ConfigScreen)
-	StatusScreen   Screen = Screen(models.StatusScreen)
-	HelpScreen     Screen = Screen(models.HelpScreen)
-	ProgressScreen Screen = Screen(models.ProgressScreen)
-	PasswordScreen Screen = Screen(models.PasswordScreen)
+	MenuScreen      Screen = Screen(models.MenuScreen)
+	AppsScreen      Screen = Screen(models.AppsScreen)
+	ThemeScreen     Screen = Screen(models.ThemeScreen)
+	ConfigScreen    Screen = Screen(models.ConfigScreen)
+	StatusScreen    Screen = Screen(models.StatusScreen)
+	HelpScreen      Screen = Screen(models.HelpScreen)
+	ProgressScreen  Screen = Screen(models.ProgressScreen)
+	PasswordScreen  Screen = Screen(models.PasswordScreen)
+	ResultsScreen   Screen = Screen(models.ResultsScreen)
+	AppDetailScreen Screen = Screen(models.AppDetailScreen)
+	HistoryScreen   Screen = Screen(models.HistoryScreen)
 )
 
 // Key constants for navigation.
@@ -63,6 +68,10 @@ type App struct {
 	contentModel  tea.Model
 	models        map[Screen]tea.Model // Cache of initialized models
 	ctx           context.Context      // Context for cancellation and timeout propagation //nolint:containedctx
+	events        *domain.EventBus     // Domain events forwarded into the program as messages
+	updates       map[string]bool      // Apps with a newer version available, from update events
+	demo          *demoPorts           // Offline adapters when running in demo mode, nil otherwise
+	status        systemStatus         // System context shown in the status bar
 
 	// Global navigation state only (idiomatic tree-of-models pattern)
 
@@ -72,9 +81,11 @@ type App struct {
 // NewApp creates a new TUI application following tree-of-models pattern.
 func NewApp() *App {
 	app := &App{
-		styles:        styles.New(),
+		styles:        configuredStyles(),
 		currentScreen: MenuScreen,
 		models:        make(map[Screen]tea.Model),
+		events:        domain.NewEventBus(),
+		updates:       make(map[string]bool),
 	}
 
 	// Initialize with menu screen
@@ -101,6 +112,12 @@ func (a *App) Run(ctx context.Context) error {
 		tea.WithContext(ctx),      // Use the provided context
 	)
 
+	// Forward domain events into the program's message loop
+	unsubscribe := a.events.SubscribeAll(func(_ context.Context, event domain.Event) {
+		program.Send(models.DomainEventMsg{Event: event})
+	})
+	defer unsubscribe()
+
 	// Run the program
 	if _, err := program.Run(); err != nil {
 		return fmt.Errorf("TUI application failed: %w", err)
@@ -118,8 +135,8 @@ func (a *App) Init() tea.Cmd {
 		return helpPreloadedMsg{model: helpModel}
 	}
 
-	// Combine initial command with preload command
-	return tea.Batch(a.contentModel.Init(), preloadCmd)
+	// Combine initial command with preload command and the first status probe
+	return tea.Batch(a.contentModel.Init(), preloadCmd, probeSystemStatus(a.statusContext()))
 }
 
 // Update implements the tea.Model interface with global navigation handling.
@@ -145,12 +162,23 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return a, cmd
 
+	case systemStatusMsg:
+		a.status = msg.status
+
+		return a, scheduleStatusRefresh()
+
+	case statusTickMsg:
+		return a, probeSystemStatus(a.statusContext())
+
 	case models.NavigateMsg:
 		return a.handleNavigation(msg)
 
 	case models.PasswordPromptResult:
 		return a.handlePasswordResult(msg)
 
+	case models.DomainEventMsg:
+		return a.handleDomainEvent(msg)
+
 	// Note: Search state now handled by individual models (idiomatic pattern)
 
 	case tea.KeyMsg:
@@ -176,17 +204,18 @@ func (a *App) View() string {
 	header := a.renderHeader()
 	content := a.renderContent()
 	footer := a.renderFooter()
+	statusBar := a.renderStatusBar()
 
 	// Calculate heights for centering
 	headerHeight := 0
-	footerHeight := 0
+	footerHeight := statusBarHeight
 
 	if header != "" {
 		headerHeight = lipgloss.Height(header)
 	}
 
 	if footer != "" {
-		footerHeight = lipgloss.Height(footer)
+		footerHeight += lipgloss.Height(footer)
 	}
 
 	contentHeight := lipgloss.Height(content)
@@ -221,9 +250,16 @@ func (a *App) View() string {
 		components = append(components, footer)
 	}
 
+	components = append(components, statusBar)
+
 	return lipgloss.JoinVertical(lipgloss.Top, components...)
 }
 
+// Events returns the event bus that services should publish to while the TUI runs.
+func (a *App) Events() *domain.EventBus {
+	return a.events
+}
+
 // GetCurrentScreen returns the current screen (for testing).
 func (a *App) GetCurrentScreen() Screen {
 	return a.currentScreen
@@ -294,6 +330,48 @@ func (a *App) handleGlobalKeys(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
+// handleDomainEvent keeps cached screens and the update count in the status
+// bar in sync with events published by services.
+func (a *App) handleDomainEvent(msg models.DomainEventMsg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	a.trackUpdates(msg.Event)
+
+	// Package changes affect the apps screen even when it is not visible
+	isPackageEvent := msg.Event.Type == domain.EventPackageInstalled || msg.Event.Type == domain.EventPackageRemoved
+	if appsModel, ok := a.models[AppsScreen]; ok && isPackageEvent && a.currentScreen != AppsScreen {
+		var cmd tea.Cmd
+
+		a.models[AppsScreen], cmd = appsModel.Update(models.StatusUpdateMsg{
+			AppName:   msg.Event.Subject,
+			Installed: msg.Event.Type == domain.EventPackageInstalled,
+		})
+		cmds = append(cmds, cmd)
+	}
+
+	var cmd tea.Cmd
+
+	a.contentModel, cmd = a.contentModel.Update(msg)
+	cmds = append(cmds, cmd)
+
+	return a, tea.Batch(cmds...)
+}
+
+// trackUpdates counts the apps with an update available: announced by update
+// events, settled once an app is updated, reinstalled or removed.
+func (a *App) trackUpdates(event domain.Event) {
+	switch event.Type { //nolint:exhaustive // Only events that change what is outdated
+	case domain.EventUpdateAvailable:
+		a.updates[event.Subject] = true
+	case domain.EventPackageRemoved:
+		delete(a.updates, event.Subject)
+	case domain.EventOperationCompleted:
+		if operation := event.Data["operation"]; operation == application.OperationUpdate || operation == application.OperationReinstall {
+			delete(a.updates, event.Subject)
+		}
+	}
+}
+
 // handleNavigationKeys processes navigation between screens and delegates to content.
 //
 //nolint:ireturn // Bubble Tea framework requires returning tea.Model interface
@@ -413,6 +491,8 @@ func (a *App) getScreenHints() []string {
 		return model.GetNavigationHints()
 	case *models.Status:
 		return model.GetNavigationHints()
+	case *models.History:
+		return model.GetNavigationHints()
 	case *models.Themes:
 		return model.GetNavigationHints()
 	case *models.Help:
@@ -504,7 +584,8 @@ func (a *App) getContentHeight() int {
 		return 0
 	}
 
-	reservedHeight := 0
+	// The status bar is always shown below the content
+	reservedHeight := statusBarHeight
 
 	// Use Lipgloss Height() method for header (only for screens that use main app header)
 	if a.shouldShowHeader() {
@@ -543,10 +624,16 @@ func (a *App) getScreenTitle() string {
 		return "⚙️ System Configuration"
 	case StatusScreen:
 		return "📊 Installation Status"
+	case HistoryScreen:
+		return "🕘 History"
 	case HelpScreen:
 		return "❓ Help & Documentation"
 	case ProgressScreen:
 		return "⚡ Installing Applications"
+	case ResultsScreen:
+		return "📋 Results"
+	case AppDetailScreen:
+		return "📦 App Details"
 	default:
 		return "Karei"
 	}
@@ -586,6 +673,7 @@ func (a *App) handlePasswordResult(msg models.PasswordPromptResult) (tea.Model,
 	progressData := models.ProgressData{
 		Operations: msg.Operations,
 		Password:   msg.Password,
+		Polkit:     msg.Polkit,
 	}
 
 	return a.navigateToScreen(ProgressScreen, progressData)
@@ -595,7 +683,7 @@ func (a *App) handlePasswordResult(msg models.PasswordPromptResult) (tea.Model,
 //
 //nolint:ireturn // Bubble Tea framework requires returning tea.Model interface
 func (a *App) navigateToPreviousScreen() (tea.Model, tea.Cmd) {
-	screens := []Screen{MenuScreen, AppsScreen, ThemeScreen, ConfigScreen, StatusScreen, HelpScreen}
+	screens := []Screen{MenuScreen, AppsScreen, ThemeScreen, ConfigScreen, StatusScreen, HistoryScreen, HelpScreen}
 
 	for i, screen := range screens {
 		if screen == a.currentScreen && i > 0 {
@@ -610,7 +698,7 @@ func (a *App) navigateToPreviousScreen() (tea.Model, tea.Cmd) {
 //
 //nolint:ireturn // Bubble Tea framework requires returning tea.Model interface
 func (a *App) navigateToNextScreen() (tea.Model, tea.Cmd) {
-	screens := []Screen{MenuScreen, AppsScreen, ThemeScreen, ConfigScreen, StatusScreen, HelpScreen}
+	screens := []Screen{MenuScreen, AppsScreen, ThemeScreen, ConfigScreen, StatusScreen, HistoryScreen, HelpScreen}
 
 	for i, screen := range screens {
 		if screen == a.currentScreen && i < len(screens)-1 {
@@ -625,8 +713,13 @@ func (a *App) navigateToNextScreen() (tea.Model, tea.Cmd) {
 //
 //nolint:ireturn // Bubble Tea framework requires returning tea.Model interface
 func (a *App) navigateToScreen(targetScreen Screen, data any) (tea.Model, tea.Cmd) {
-	// Progress and Password screens should always be created fresh (idiomatic Elm pattern)
-	if targetScreen == ProgressScreen || targetScreen == PasswordScreen {
+	// Demo mode never needs sudo, so skip straight to the progress screen
+	if a.demo != nil && targetScreen == PasswordScreen {
+		targetScreen = ProgressScreen
+	}
+
+	// Transient screens should always be created fresh (idiomatic Elm pattern)
+	if isTransientScreen(targetScreen) {
 		// Remove any stale cached instance (idiomatic cleanup)
 		delete(a.models, targetScreen)
 		newModel := a.createModelForScreen(targetScreen, data)
@@ -725,13 +818,26 @@ func (a *App) createModelForScreen(screen Screen, data any) tea.Model {
 	case ConfigScreen:
 		return models.NewConfig(a.styles)
 	case StatusScreen:
-		return models.NewStatus(a.styles)
+		return models.NewStatus(a.ctx, a.styles)
+	case HistoryScreen:
+		return models.NewHistory(a.styles)
 	case HelpScreen:
 		return models.NewHelp(a.styles)
 	case ProgressScreen:
 		return a.createProgressModel(data)
 	case PasswordScreen:
 		return a.createPasswordModel(data)
+	case ResultsScreen:
+		return a.createResultsModel(data)
+	case AppDetailScreen:
+		appKey, _ := data.(string)
+
+		detail := models.NewAppDetail(a.ctx, a.styles, appKey)
+		if a.demo != nil {
+			detail.SetDetailsService(a.demo.details)
+		}
+
+		return detail
 	default:
 		return models.NewMenu(a.styles) // Fallback to menu if unknown screen
 	}
@@ -741,8 +847,8 @@ func (a *App) createModelForScreen(screen Screen, data any) tea.Model {
 //
 //nolint:ireturn // Bubble Tea framework requires returning tea.Model interface
 func (a *App) setupNewModel(newModel tea.Model, targetScreen Screen, data any) (tea.Model, tea.Cmd) {
-	// Cache the new model (except progress and password which are always fresh)
-	if targetScreen != ProgressScreen && targetScreen != PasswordScreen {
+	// Cache the new model (except transient screens which are always fresh)
+	if !isTransientScreen(targetScreen) {
 		a.models[targetScreen] = newModel
 	}
 
@@ -789,7 +895,7 @@ func (a *App) handleWindowSizing(targetScreen Screen) tea.Cmd {
 	a.contentModel = updatedModel
 
 	// Update the cache with the resized model
-	if targetScreen != ProgressScreen {
+	if !isTransientScreen(targetScreen) {
 		a.models[targetScreen] = updatedModel
 	}
 
@@ -818,16 +924,59 @@ func (a *App) handleRefreshStatus(targetScreen Screen, data any) tea.Cmd {
 func (a *App) createAppsModel() tea.Model {
 	contentHeight := a.getContentHeight()
 
-	return models.NewAppsWithSize(a.ctx, a.styles, a.width, contentHeight)
+	if a.demo != nil {
+		return models.NewAppsWithPorts(a.ctx, a.styles, a.width, contentHeight, a.demo.packageDB, a.demo.packageDB)
+	}
+
+	appsModel := models.NewAppsWithSize(a.ctx, a.styles, a.width, contentHeight)
+	appsModel.SetEventPublisher(a.events)
+
+	return appsModel
 }
 
 // createProgressModel creates a progress model handling different data formats.
 //
 //nolint:ireturn // Bubble Tea framework requires returning tea.Model interface
 func (a *App) createProgressModel(data any) tea.Model {
+	progressModel := a.newProgressModel(data)
+	if a.demo != nil {
+		progressModel.SetPackageManager(a.demo.packages)
+	}
+
+	progressModel.SetEventPublisher(a.events)
+
+	return progressModel
+}
+
+// isTransientScreen reports whether a screen is rebuilt on every visit instead of cached.
+func isTransientScreen(screen Screen) bool {
+	switch screen {
+	case ProgressScreen, PasswordScreen, ResultsScreen, AppDetailScreen, HistoryScreen:
+		return true
+	}
+
+	return false
+}
+
+// createResultsModel creates the Results screen for a finished run.
+//
+//nolint:ireturn // Bubble Tea framework requires returning tea.Model interface
+func (a *App) createResultsModel(data any) tea.Model {
+	results, _ := data.(models.ResultsData)
+
+	return models.NewResults(a.styles, results)
+}
+
+// newProgressModel creates a progress model from the supported data formats.
+func (a *App) newProgressModel(data any) *models.Progress {
 	// Handle progress data with password
 	if progressData, ok := data.(models.ProgressData); ok {
-		return models.NewProgressWithOperationsAndPassword(a.ctx, a.styles, progressData.Operations, progressData.Password)
+		model := models.NewProgressWithOperationsAndPassword(a.ctx, a.styles, progressData.Operations, progressData.Password)
+		if progressData.Polkit {
+			model.UsePolkit()
+		}
+
+		return model
 	}
 	// Handle new mixed operations format (without password)
 	if operations, ok := data.([]models.SelectedOperation); ok {
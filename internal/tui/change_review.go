@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/models"
+)
+
+// ReviewChanges shows the planned changes for the user to keep or skip
+// each, and returns the kept ones. ok is false when the review was cancelled.
+func ReviewChanges(ctx context.Context, changes []domain.PlannedChange) (kept []domain.PlannedChange, ok bool, err error) {
+	if !isTerminal() {
+		return nil, false, fmt.Errorf("terminal check failed: %w", ErrNoTerminal)
+	}
+
+	model := models.NewChangeReview(configuredStyles(), changes)
+
+	if _, err := tea.NewProgram(model, tea.WithContext(ctx)).Run(); err != nil {
+		return nil, false, fmt.Errorf("change review failed: %w", err)
+	}
+
+	if model.Cancelled() {
+		return nil, false, nil
+	}
+
+	return model.Kept(), true, nil
+}
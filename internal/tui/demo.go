@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/adapters/ubuntu"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/offline"
+)
+
+// demoPorts holds the offline adapters used when the TUI runs in demo mode.
+type demoPorts struct {
+	packageDB *offline.PackageDB
+	packages  *application.PackageManager
+	details   *application.DetailsService
+}
+
+// newDemoPorts wires the offline package database and dry-run adapters.
+// Nothing in demo mode touches the network, sudo or the real package managers.
+func newDemoPorts() (*demoPorts, error) {
+	packageDB := offline.NewPackageDB(false)
+	if err := packageDB.LoadFromFS(offline.Fixtures); err != nil {
+		return nil, fmt.Errorf("failed to load demo package database: %w", err)
+	}
+
+	commandRunner := platform.NewMockCommandRunner(false)
+	fileManager := platform.NewFileManager(false)
+	installer := ubuntu.NewTUIPackageInstaller(commandRunner, fileManager, false, true) // dryRun=true
+	uninstaller := application.NewUninstallService(fileManager, commandRunner, installer, false)
+
+	// Details come from the package database and are cached in memory, leaving the real cache alone
+	details := application.NewDetailsService(packageDB, platform.NewMockFileManager(false), "demo")
+
+	return &demoPorts{
+		packageDB: packageDB,
+		packages:  application.NewPackageManager(installer, uninstaller, true), // dryRun=true
+		details:   details,
+	}, nil
+}
+
+// NewDemoApp creates a TUI application backed by offline mock adapters.
+func NewDemoApp() (*App, error) {
+	ports, err := newDemoPorts()
+	if err != nil {
+		return nil, err
+	}
+
+	app := NewApp()
+	app.demo = ports
+	ports.packages.SetEventPublisher(app.events)
+
+	return app, nil
+}
+
+// IsDemo reports whether the application runs against mock adapters.
+func (a *App) IsDemo() bool {
+	return a.demo != nil
+}
+
+// LaunchDemo starts the TUI in demo mode: no sudo, no network, no changes to the system.
+func LaunchDemo(ctx context.Context) error {
+	if !isTerminal() {
+		return fmt.Errorf("terminal check failed: %w", ErrNoTerminal)
+	}
+
+	app, err := NewDemoApp()
+	if err != nil {
+		return err
+	}
+
+	app.ctx = ctx
+
+	return app.Run(ctx)
+}
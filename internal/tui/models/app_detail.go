@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// detailFetchTimeout bounds how long the detail view waits for upstream APIs.
+const detailFetchTimeout = 10 * time.Second
+
+// AppDetailsLoadedMsg carries fetched details to the detail view.
+type AppDetailsLoadedMsg struct {
+	Details *domain.AppDetails
+	Err     error
+}
+
+// AppDetail is the full-screen view of one catalog app.
+//
+//nolint:containedctx // TUI models require context for proper cancellation propagation
+type AppDetail struct {
+	ctx      context.Context
+	styles   *styles.Styles
+	width    int
+	height   int
+	appKey   string
+	service  *application.DetailsService
+	details  *domain.AppDetails
+	err      error
+	loading  bool
+	spinner  spinner.Model
+	viewport viewport.Model
+}
+
+// NewAppDetail creates the detail view for appKey, fetching upstream data through a disk cache.
+func NewAppDetail(ctx context.Context, styleConfig *styles.Styles, appKey string) *AppDetail {
+	sSpinner := spinner.New()
+	sSpinner.Spinner = spinner.Dot
+	sSpinner.Style = lipgloss.NewStyle().Foreground(styleConfig.Primary)
+
+	service := application.NewDetailsService(
+		network.NewDetailsFetcher(network.GetHTTPClient()),
+		platform.NewFileManager(false),
+		xdg.DetailsCacheDir(),
+	)
+	service.SetReleaseChannels(configuredReleaseChannels())
+
+	return &AppDetail{
+		ctx:      ctx,
+		styles:   styleConfig,
+		appKey:   appKey,
+		service:  service,
+		loading:  true,
+		spinner:  sSpinner,
+		viewport: viewport.New(0, 0),
+	}
+}
+
+// SetDetailsService replaces the service used to look up details. Must be called before Init.
+func (m *AppDetail) SetDetailsService(service *application.DetailsService) {
+	m.service = service
+}
+
+// Init starts loading the details.
+func (m *AppDetail) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.load())
+}
+
+// load fetches the details in the background.
+func (m *AppDetail) load() tea.Cmd {
+	service, appKey, parent := m.service, m.appKey, m.ctx
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(parent, detailFetchTimeout)
+		defer cancel()
+
+		details, err := service.Details(ctx, appKey)
+
+		return AppDetailsLoadedMsg{Details: details, Err: err}
+	}
+}
+
+// Update handles messages for the detail view.
+func (m *AppDetail) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case AppDetailsLoadedMsg:
+		m.loading = false
+		m.details = msg.Details
+		m.err = msg.Err
+		m.viewport.SetContent(m.renderContent())
+
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.viewport.Width = msg.Width
+		m.viewport.Height = max(msg.Height-4, 1) // Header and footer
+
+		m.viewport.SetContent(m.renderContent())
+
+		return m, nil
+	case spinner.TickMsg:
+		if !m.loading {
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+
+		m.spinner, cmd = m.spinner.Update(msg)
+
+		return m, cmd
+	case tea.KeyMsg:
+		switch msg.String() {
+		case KeyEsc, "o":
+			return m, func() tea.Msg { return NavigateMsg{Screen: AppsScreen} }
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		case "g":
+			m.viewport.GotoTop()
+
+			return m, nil
+		case "G":
+			m.viewport.GotoBottom()
+
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+
+	m.viewport, cmd = m.viewport.Update(msg)
+
+	return m, cmd
+}
+
+// View renders the detail view.
+func (m *AppDetail) View() string {
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.styles.Primary).
+		Padding(0, 2).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(lipgloss.Color("240")).
+		Width(m.width).
+		Render("Karei » Apps » " + m.title())
+
+	body := m.viewport.View()
+	if m.loading {
+		body = m.spinner.View() + " Fetching details..."
+	}
+
+	footer := RenderFooter(m.styles, m.width, []FooterAction{
+		{Key: "j/k", Action: "Scroll"},
+		{Key: "Esc", Action: "Back"},
+		{Key: "q", Action: "Quit"},
+	}, false)
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+}
+
+// title returns the app name, falling back to its key while loading.
+func (m *AppDetail) title() string {
+	if m.details != nil && m.details.Name != "" {
+		return m.details.Name
+	}
+
+	return m.appKey
+}
+
+// renderContent renders the scrollable body of the detail view.
+func (m *AppDetail) renderContent() string {
+	if m.details == nil {
+		if m.err != nil {
+			return m.styles.ErrorText.Render(m.err.Error())
+		}
+
+		return ""
+	}
+
+	d := m.details
+	wrap := lipgloss.NewStyle().Width(max(m.width-4, 20))
+	label := lipgloss.NewStyle().Foreground(m.styles.Muted).Width(16)
+
+	sections := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(m.styles.Primary).Render(d.Name),
+		d.Summary,
+		"",
+	}
+
+	latest := d.LatestRelease
+	if latest != "" && d.Channel == domain.ChannelPrerelease {
+		latest += " (prerelease channel)"
+	}
+
+	fields := [][2]string{
+		{"Install", fmt.Sprintf("%s (%s)", d.Method, d.Source)},
+		{"Homepage", d.Homepage},
+		{"License", d.License},
+		{"Latest release", latest},
+	}
+
+	for _, field := range fields {
+		if field[1] != "" {
+			sections = append(sections, label.Render(field[0])+field[1])
+		}
+	}
+
+	if m.err != nil {
+		sections = append(sections, "", m.styles.WarningText.Render("Upstream details unavailable: "+m.err.Error()))
+	}
+
+	if d.LongDescription != "" {
+		sections = append(sections, "", m.styles.Subtitle.Render("Description"), wrap.Render(d.LongDescription))
+	}
+
+	if d.ReleaseNotes != "" {
+		sections = append(sections, "", m.styles.Subtitle.Render("Release notes "+d.LatestRelease), wrap.Render(d.ReleaseNotes))
+	}
+
+	if len(d.Screenshots) > 0 {
+		lines := make([]string, 0, len(d.Screenshots))
+
+		for _, shot := range d.Screenshots {
+			line := "  • " + shot.URL
+			if shot.Caption != "" {
+				line = "  • " + shot.Caption + ": " + shot.URL
+			}
+
+			lines = append(lines, line)
+		}
+
+		sections = append(sections, "", m.styles.Subtitle.Render("Screenshots"), strings.Join(lines, "\n"))
+	}
+
+	if !d.FetchedAt.IsZero() {
+		sections = append(sections, "", m.styles.MutedText.Render("Fetched "+d.FetchedAt.Format(time.DateTime)))
+	}
+
+	return strings.Join(sections, "\n")
+}
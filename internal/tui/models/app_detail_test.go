@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppDetailRendersLoadedDetails(t *testing.T) {
+	t.Parallel()
+
+	model := NewAppDetail(context.Background(), styles.New(), "vlc")
+	model.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+
+	assert.Contains(t, model.View(), "Fetching details")
+
+	model.Update(AppDetailsLoadedMsg{Details: &domain.AppDetails{
+		Name:          "VLC Media Player",
+		Summary:       "Media player",
+		Method:        domain.MethodAPT,
+		Source:        "vlc",
+		License:       "GPL-2.0+",
+		LatestRelease: "3.0.21",
+		ReleaseNotes:  "Fixes",
+		Screenshots:   []domain.Screenshot{{Caption: "Main", URL: "https://example.com/main.png"}},
+	}})
+
+	view := model.View()
+	assert.Contains(t, view, "Karei » Apps » VLC Media Player")
+	assert.Contains(t, view, "GPL-2.0+")
+	assert.Contains(t, view, "Release notes 3.0.21")
+	assert.Contains(t, view, "Main: https://example.com/main.png")
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.NotNil(t, cmd)
+	assert.Equal(t, NavigateMsg{Screen: AppsScreen}, cmd())
+}
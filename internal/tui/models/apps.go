@@ -10,7 +10,6 @@ import (
 	"cmp"
 	"context"
 	"fmt"
-	"os/exec"
 	"slices"
 	"strings"
 	"time"
@@ -19,12 +18,13 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/janderssonse/karei/internal/adapters/platform"
 	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/config"
 	"github.com/janderssonse/karei/internal/domain"
 	"github.com/janderssonse/karei/internal/stringutil"
 	"github.com/janderssonse/karei/internal/tui/styles"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
+	"github.com/janderssonse/karei/internal/xdg"
 )
 
 // Status indicators for applications.
@@ -32,6 +32,8 @@ const (
 	StatusNotInstalled = " " // Empty space for unselected items
 	StatusInstalled    = "✓" // Checkmark for installed
 	StatusSelected     = "✓" // Checkmark for selected to install
+	StatusRequiresRoot = "🔒" // Lock for apps that need sudo to install
+	StatusProprietary  = "©" // Badge for closed-source apps
 	StatusUninstall    = "✗" // X mark for pending removal
 	StatusPending      = "⋯" // Status pending/checking
 )
@@ -56,6 +58,8 @@ const (
 	StateNone      SelectionState = iota // No operation selected
 	StateInstall                         // Mark for installation
 	StateUninstall                       // Mark for uninstallation
+	StateUpdate                          // Mark for update to the newest version
+	StateReinstall                       // Mark for removal and a fresh install
 )
 
 // Common messages.
@@ -72,15 +76,18 @@ type AppCategory struct {
 
 // Application represents an installable application.
 type Application struct {
-	Key         string
-	Name        string
-	Description string
-	Icon        string
-	Category    string
-	Installed   bool
-	Size        string
-	Source      string
-	Selected    bool
+	Key          string
+	Name         string
+	Description  string
+	Icon         string
+	Category     string
+	Installed    bool
+	Size         string
+	Source       string
+	Aliases      []string
+	License      domain.License
+	RequiresRoot bool
+	Selected     bool
 }
 
 // String implements the list.Item interface.
@@ -139,24 +146,62 @@ type AppsModel struct {
 	lastViewportUpdate  time.Time // Track last viewport update to throttle
 	contentNeedsUpdate  bool      // True when viewport content needs re-rendering
 
-	// Apps manager for status checking
-	appsManager *apps.Manager
-	keyMap      AppsKeyMap
+	// Ports for status and version checking
+	statusChecker   domain.InstallationChecker
+	versionResolver domain.VersionResolver
+	keyMap          AppsKeyMap
+
+	// Status checks adapt their concurrency to how fast the system answers
+	pacer          *statusPacer
+	checking       map[string]bool // Apps whose status check is running
+	showPacerDebug bool            // Debug overlay with the pacing figures, toggled with F12
+	columns        columnLayout    // Widths and optional columns of the app lines
 
 	// Search functionality
 	searchQuery     string
-	filteredApps    []app
+	results         *SelectableList[app] // Ranked search results, sharing the selection states
 	searchActive    bool
-	searchSelection int  // Index of currently selected search result
-	searchHasFocus  bool // Whether search field has focus (vs search results)
+	searchHasFocus  bool             // Whether search field has focus (vs search results)
+	searchSeq       int              // Incremented per keystroke so stale debounce ticks are ignored
+	searchedQuery   string           // Query the current results were ranked for
+	searchHighlight map[string][]int // App key -> matched name positions
+	searchNotice    string           // Feedback shown in the header until the next key
+
+	// Saved searches from config.toml
+	savedSearches    []config.SavedSearch
+	savedSearchIndex int    // Last applied saved search, -1 before the first
+	namingSearch     bool   // The header is prompting for a name to save the search under
+	searchName       string // Name typed so far
 
 	// Filter and sort functionality
 	installStatusFilter string // "All", "Installed", "Not Installed"
 	packageTypeFilter   string // "All", "apt", "flatpak", "snap", "deb", "mise", "aqua", "github", "script"
 	sortOption          string // "Name", "Status", "Type", "Category"
+	filterFocus         bool   // Filter bar has focus (search mode only)
+	filterCursor        int    // Index of the focused filter chip
 
 	// Help modal
 	helpModal *HelpModal
+
+	// Pending operations overlay and undo history
+	reviewing        bool
+	review           *SelectableList[SelectedOperation]
+	selectionHistory []selectionChange
+
+	// Uninstall targets are listed for confirmation before proceeding
+	confirmingUninstall bool
+
+	// A whole category is summarised before it is queued for install
+	categoryInstall *categoryInstall
+
+	// Content lines rendered in full by the last renderAllCategories
+	renderedWindow lineWindow
+
+	// Where category order changes are persisted
+	prefsPath string
+
+	// Newer versions the package managers have, by app key
+	updates map[string]domain.OutdatedPackage
 }
 
 // category represents an internal category with navigation state.
@@ -165,6 +210,7 @@ type category struct {
 	apps       []app
 	selected   map[string]SelectionState
 	currentApp int
+	collapsed  bool // Only the title is shown; navigation skips the apps
 }
 
 // app represents an internal application with state.
@@ -173,16 +219,23 @@ type app struct {
 	Name          string
 	Description   string
 	Source        string
-	Version       string // Version if available
+	Group         string         // Category the app is listed under
+	Aliases       []string       // Other names the app is searched by
+	License       domain.License // Proprietary apps get a badge
+	Version       string         // Version if available
+	Size          string         // Estimated download size
+	Updated       time.Time      // When karei last installed the app, zero when unknown
 	Installed     bool
 	Selected      bool
 	StatusPending bool // True when installation status is being checked
+	RequiresRoot  bool // True when installing needs sudo
 }
 
 // StatusUpdateMsg carries installation status updates from async checks.
 type StatusUpdateMsg struct {
 	AppName   string
 	Installed bool
+	Elapsed   time.Duration // How long the check took; zero for updates from events
 }
 
 // VersionUpdateMsg carries version information from package manager queries.
@@ -191,6 +244,17 @@ type VersionUpdateMsg struct {
 	Version string
 }
 
+// UpdatesLoadedMsg carries the apps with a newer version available.
+type UpdatesLoadedMsg struct {
+	Updates map[string]domain.OutdatedPackage
+}
+
+// updateLister is implemented by status checkers that also know which apps
+// their package manager has a newer version of, such as the package manager.
+type updateLister interface {
+	Outdated(ctx context.Context) map[string]domain.OutdatedPackage
+}
+
 // RefreshStatusMsg triggers a refresh of all app installation statuses.
 type RefreshStatusMsg struct{}
 
@@ -235,8 +299,19 @@ func NewApps(ctx context.Context, styleConfig *styles.Styles) *AppsModel {
 
 // NewAppsWithSize creates the apps model with specified dimensions.
 func NewAppsWithSize(ctx context.Context, styleConfig *styles.Styles, width, height int) *AppsModel {
+	packages := newInstalledPackageManager()
+	resolver := platform.NewVersionResolver(platform.NewTUICommandRunner(false, false))
+
+	return NewAppsWithPorts(ctx, styleConfig, width, height, packages, resolver)
+}
+
+// NewAppsWithPorts creates the apps model with injected status and version ports.
+func NewAppsWithPorts(ctx context.Context, styleConfig *styles.Styles, width, height int,
+	statusChecker domain.InstallationChecker, versionResolver domain.VersionResolver,
+) *AppsModel {
 	adapter := newAppCatalogAdapter()
 	appCategories := adapter.getAllCategoriesFast()
+	installed := installTimes()
 
 	selected := make(map[string]SelectionState)
 	categories := make([]category, 0, len(appCategories))
@@ -251,8 +326,14 @@ func NewAppsWithSize(ctx context.Context, styleConfig *styles.Styles, width, hei
 				Name:          application.Name,
 				Description:   application.Description,
 				Source:        application.Source,
+				Group:         cat.Name,
+				Aliases:       application.Aliases,
+				License:       application.License,
 				Version:       "", // Version will be populated by package manager queries
+				Size:          application.Size,
+				Updated:       installed[application.Key],
 				Installed:     application.Installed,
+				RequiresRoot:  application.RequiresRoot,
 				Selected:      false,
 				StatusPending: true, // Start with pending status, will be updated async
 			}
@@ -272,6 +353,13 @@ func NewAppsWithSize(ctx context.Context, styleConfig *styles.Styles, width, hei
 		}
 	}
 
+	prefs := configuredPreferences()
+	orderCategories(categories, prefs.Categories.Order)
+
+	for i := range categories {
+		categories[i].collapsed = prefs.Categories.Collapsed
+	}
+
 	// Create help modal
 	helpModal := NewHelpModal()
 	helpModal.SetScreen("apps")
@@ -284,8 +372,11 @@ func NewAppsWithSize(ctx context.Context, styleConfig *styles.Styles, width, hei
 		ctx:                ctx, // Store parent context
 		categories:         categories,
 		selected:           selected,
-		appLookup:          appLookup,                 // Fast lookup map
-		appsManager:        apps.NewTUIManager(false), // Use TUI-optimized manager to suppress command output
+		appLookup:          appLookup, // Fast lookup map
+		statusChecker:      statusChecker,
+		versionResolver:    versionResolver,
+		pacer:              newStatusPacer(),
+		checking:           make(map[string]bool),
 		keyMap:             DefaultAppsKeyMap(),
 		viewport:           viewport.New(width, height),
 		lastViewportUpdate: time.Now(),
@@ -298,11 +389,47 @@ func NewAppsWithSize(ctx context.Context, styleConfig *styles.Styles, width, hei
 
 		// Help modal
 		helpModal: helpModal,
+
+		prefsPath: xdg.PreferencesFile(),
+
+		columns: newColumnLayout(prefs.Columns),
+
+		savedSearches:    prefs.Searches,
+		savedSearchIndex: -1,
 	}
+	model.results = model.newSearchResults()
 
 	return model
 }
 
+// newSearchResults creates the search result list. The apps screen ranks the
+// results itself, so the list's own search is off.
+func (m *AppsModel) newSearchResults() *SelectableList[app] {
+	results := NewSelectableList(nil, func(result app) string { return result.Key }, nil, m.renderSearchResult)
+	results.SetStates(m.selected)
+
+	return results
+}
+
+// SetPreferencesPath changes where category order changes are saved. An empty path disables saving.
+func (m *AppsModel) SetPreferencesPath(path string) {
+	m.prefsPath = path
+}
+
+// eventSource is implemented by status checkers that publish domain events,
+// such as the package manager announcing available updates.
+type eventSource interface {
+	SetEventPublisher(publisher domain.EventPublisher)
+}
+
+// SetEventPublisher sets where the status checker publishes the events it
+// raises, when it raises any.
+func (m *AppsModel) SetEventPublisher(publisher domain.EventPublisher) {
+	if source, ok := m.statusChecker.(eventSource); ok {
+		source.SetEventPublisher(publisher)
+	}
+}
+
 // DefaultAppsKeyMap returns the default key bindings.
 func DefaultAppsKeyMap() AppsKeyMap {
 	return AppsKeyMap{
@@ -346,7 +473,7 @@ func DefaultAppsKeyMap() AppsKeyMap {
 }
 
 // NewStatusCheckCommand creates a command to check a single app's installation status.
-func NewStatusCheckCommand(parentCtx context.Context, appName string, manager *apps.Manager) tea.Cmd {
+func NewStatusCheckCommand(parentCtx context.Context, appName string, checker domain.InstallationChecker) tea.Cmd {
 	return func() tea.Msg {
 		// Generous timeouts - since it's async, it won't block UI
 		timeout := 10 * time.Second // Increased default timeout for reliability
@@ -354,7 +481,7 @@ func NewStatusCheckCommand(parentCtx context.Context, appName string, manager *a
 		// Get app to determine timeout based on method
 		if app, exists := apps.Apps[appName]; exists {
 			switch app.Method {
-			case domain.MethodBinary, domain.MethodMise, domain.MethodAqua:
+			case domain.MethodBinary, domain.MethodMise, domain.MethodAqua, domain.MethodNpm:
 				timeout = 5 * time.Second // Binary checks need more time
 			case domain.MethodAPT, domain.MethodDEB:
 				timeout = 10 * time.Second // APT can be slow
@@ -367,32 +494,27 @@ func NewStatusCheckCommand(parentCtx context.Context, appName string, manager *a
 		defer cancel()
 
 		// This runs in its own goroutine via Bubble Tea, won't block UI
-		installed := manager.IsAppInstalled(ctx, appName)
+		started := time.Now()
+		installed := checker.IsInstalled(ctx, appName)
 
 		return StatusUpdateMsg{
 			AppName:   appName,
 			Installed: installed,
+			Elapsed:   time.Since(started),
 		}
 	}
 }
 
 // fetchAppVersion returns a command to fetch the version of an installed app.
-func fetchAppVersion(appKey, appName, source string) tea.Cmd {
+func fetchAppVersion(resolver domain.VersionResolver, appKey, appName, source string) tea.Cmd {
 	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
 		var version string
 
-		// Get version using simple command execution
-		cmd := getVersionCommand(appName, source)
-		if cmd != "" {
-			// Execute command with timeout
-			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-			defer cancel()
-
-			// Use os/exec directly for simplicity
-			output, err := execCommand(ctx, "sh", "-c", cmd)
-			if err == nil && output != "" {
-				version = extractVersion(output, source)
-			}
+		if output, err := resolver.ResolveVersion(ctx, appName, source); err == nil && output != "" {
+			version = extractVersion(output)
 		}
 
 		return VersionUpdateMsg{
@@ -402,80 +524,32 @@ func fetchAppVersion(appKey, appName, source string) tea.Cmd {
 	}
 }
 
-// execCommand executes a command with context.
-func execCommand(ctx context.Context, name string, args ...string) (string, error) {
-	cmd := exec.CommandContext(ctx, name, args...)
-	output, err := cmd.Output()
-
-	return string(output), err
-}
-
-// getVersionCommand returns the appropriate command to get version info.
-// This is designed to be extensible and handle various package manager quirks.
-func getVersionCommand(appName, source string) string {
-	normalizedName := strings.ToLower(appName)
-
-	// Handle special case for mise
-	if source == MethodMise {
-		return getMiseVersionCommand(normalizedName)
-	}
-
-	// Map sources to their version commands
-	versionCommands := map[string]string{
-		MethodAPTDisplay: fmt.Sprintf("dpkg -s %s 2>/dev/null | grep '^Version:' | cut -d' ' -f2", appName),
-		MethodDEBDisplay: fmt.Sprintf("dpkg -s %s 2>/dev/null | grep '^Version:' | cut -d' ' -f2", appName),
-		MethodFlatpak:    fmt.Sprintf("flatpak info %s 2>/dev/null | grep 'Version:' | awk '{print $2}'", appName),
-		MethodSnap:       fmt.Sprintf("snap info %s 2>/dev/null | grep 'installed:' | awk '{print $2}'", normalizedName),
-		"aqua":           fmt.Sprintf("aqua list 2>/dev/null | grep -i '^%s\\s' | awk '{print $2}'", normalizedName),
-		"cargo":          fmt.Sprintf("cargo install --list | grep -E '^%s\\s' | awk '{print $2}' | tr -d '()'", normalizedName),
-		"npm":            fmt.Sprintf("npm list -g %s 2>/dev/null | grep %s@ | sed 's/.*@//'", normalizedName, normalizedName),
-		"yarn":           fmt.Sprintf("npm list -g %s 2>/dev/null | grep %s@ | sed 's/.*@//'", normalizedName, normalizedName),
-		"pnpm":           fmt.Sprintf("npm list -g %s 2>/dev/null | grep %s@ | sed 's/.*@//'", normalizedName, normalizedName),
-		"pip":            fmt.Sprintf("pip show %s 2>/dev/null | grep '^Version:' | awk '{print $2}'", normalizedName),
-		"pipx":           fmt.Sprintf("pip show %s 2>/dev/null | grep '^Version:' | awk '{print $2}'", normalizedName),
-	}
+// extractVersion trims the resolved version for display in the list.
+func extractVersion(output string) string {
+	version := strings.TrimSpace(output)
 
-	if cmd, exists := versionCommands[source]; exists {
-		return cmd
+	// Truncate if too long
+	if len(version) > 15 {
+		version = version[:12] + "..."
 	}
 
-	// Default: try common version flags
-	return fmt.Sprintf("which %s > /dev/null 2>&1 && %s --version 2>/dev/null | head -1", normalizedName, normalizedName)
+	return version
 }
 
-// getMiseVersionCommand returns the version command for mise-managed tools.
-func getMiseVersionCommand(normalizedName string) string {
-	if normalizedName == MethodMise {
-		// Special case: mise itself uses --version
-		return "which mise > /dev/null 2>&1 && mise --version 2>/dev/null | head -1 | cut -d' ' -f1"
+// checkUpdates returns a command listing the apps with a newer version
+// available, or nil when the status checker cannot tell.
+func (m *AppsModel) checkUpdates() tea.Cmd {
+	lister, ok := m.statusChecker.(updateLister)
+	if !ok {
+		return nil
 	}
-	// For mise-managed tools: match tool name flexibly
-	// Tools can appear as "toolname", "aqua:org/toolname", "aqua:toolname"
-	return fmt.Sprintf("mise list 2>/dev/null | awk 'tolower($1) ~ /(^|[:/])%s$/ {print $2; exit}'", normalizedName)
-}
 
-// extractVersion extracts and cleans the version string.
-func extractVersion(output, source string) string {
-	version := strings.TrimSpace(output)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.ctx, 30*time.Second) // Asks every package manager
+		defer cancel()
 
-	// Clean up debian version format (epoch:version-revision)
-	if source == MethodAPTDisplay || source == MethodDEBDisplay {
-		// Remove epoch
-		if idx := strings.Index(version, ":"); idx != -1 {
-			version = version[idx+1:]
-		}
-		// Remove debian revision if it's long
-		if idx := strings.Index(version, "-"); idx != -1 && idx > 5 {
-			version = version[:idx]
-		}
+		return UpdatesLoadedMsg{Updates: lister.Outdated(ctx)}
 	}
-
-	// Truncate if too long
-	if len(version) > 15 {
-		version = version[:12] + "..."
-	}
-
-	return version
 }
 
 // BatchStatusCheckMsg triggers the next batch of status checks.
@@ -500,41 +574,31 @@ func (m *AppsModel) checkVisibleAppsFirst() tea.Cmd {
 	}
 }
 
-// checkCategoryApps checks ONE app at a time to keep UI 100% responsive.
-func (m *AppsModel) checkCategoryApps(_, batchIndex int) tea.Cmd {
-	// Only check ONE app at a time
-	// This is key: Bubble Tea runs commands in separate goroutines,
-	// so even if the check takes time, it won't block the UI
-	var appToCheck string
+// checkCategoryApps starts as many status checks of unchecked apps as the
+// pacer allows. Bubble Tea runs each in its own goroutine, so the UI stays
+// responsive; every finished check starts the next through handleStatusUpdate.
+func (m *AppsModel) checkCategoryApps(_, _ int) tea.Cmd {
+	available := m.pacer.available()
 
-	found := false
-
-	// Find next unchecked app
-	for catIdx := 0; catIdx < len(m.categories) && !found; catIdx++ {
-		cat := m.categories[catIdx]
-		for _, a := range cat.apps {
-			if a.StatusPending {
-				appToCheck = a.Key
-				found = true
+	var cmds []tea.Cmd
 
+	for catIdx := 0; catIdx < len(m.categories) && len(cmds) < available; catIdx++ {
+		for _, a := range m.categories[catIdx].apps {
+			if len(cmds) == available {
 				break
 			}
-		}
-	}
 
-	if !found {
-		return nil // All apps checked
+			if !a.StatusPending || m.checking[a.Key] {
+				continue
+			}
+
+			m.checking[a.Key] = true
+			m.pacer.started()
+			cmds = append(cmds, NewStatusCheckCommand(m.ctx, a.Key, m.statusChecker))
+		}
 	}
 
-	// Check just ONE app, then immediately schedule next
-	// The status check runs in a goroutine and won't block
-	return tea.Sequence(
-		NewStatusCheckCommand(m.ctx, appToCheck, m.appsManager),
-		func() tea.Msg {
-			// Immediately queue next check - the previous one is still running async
-			return BatchStatusCheckMsg{BatchIndex: batchIndex + 1}
-		},
-	)
+	return tea.Batch(cmds...)
 }
 
 // Init initializes the apps model.
@@ -591,7 +655,13 @@ func (m *AppsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case StartStatusCheckMsg:
 		// Start checking apps now that UI is ready
-		return m, tea.Batch(m.checkCategoryApps(0, 0), viewportCmd)
+		return m, tea.Batch(m.checkCategoryApps(0, 0), m.checkUpdates(), viewportCmd)
+
+	case UpdatesLoadedMsg:
+		m.updates = msg.Updates
+		m.contentNeedsUpdate = true
+
+		return m, viewportCmd
 
 	case BatchStatusCheckMsg:
 		// Continue checking the next batch of apps
@@ -613,11 +683,23 @@ func (m *AppsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, viewportCmd
 
+	case SearchDebounceMsg:
+		if msg.Seq == m.searchSeq && m.searchActive {
+			m.updateSearchResults()
+		}
+
+		return m, viewportCmd
+
 	case FilterUpdateMsg:
+		m.searchedQuery = "" // Results can no longer be narrowed incrementally
 		m.installStatusFilter = msg.InstallStatus
 		m.packageTypeFilter = msg.PackageType
 		m.sortOption = msg.SortOption
 
+		if m.searchActive {
+			m.updateSearchResults()
+		}
+
 		return m, viewportCmd
 
 	case tea.KeyMsg:
@@ -650,6 +732,15 @@ func (m *AppsModel) handleVersionUpdate(msg VersionUpdateMsg) (tea.Model, tea.Cm
 func (m *AppsModel) handleStatusUpdate(msg StatusUpdateMsg) (tea.Model, tea.Cmd) {
 	m.updateAppStatus(msg.AppName, msg.Installed)
 
+	// A finished check makes room for the next ones
+	var nextChecks tea.Cmd
+
+	if m.checking[msg.AppName] {
+		delete(m.checking, msg.AppName)
+		m.pacer.finished(msg.Elapsed)
+		nextChecks = m.checkCategoryApps(m.currentCat, 0)
+	}
+
 	// No throttling - this is the idiomatic Bubble Tea way
 	// View() will be called automatically after Update()
 	// No need for flags or throttling
@@ -666,7 +757,7 @@ func (m *AppsModel) handleStatusUpdate(msg StatusUpdateMsg) (tea.Model, tea.Cmd)
 		versionCmd = m.createVersionFetchCommand(msg.AppName)
 	}
 
-	return m, versionCmd
+	return m, tea.Batch(versionCmd, nextChecks)
 }
 
 // createVersionFetchCommand creates a command to fetch the version of an installed app.
@@ -677,7 +768,7 @@ func (m *AppsModel) createVersionFetchCommand(appName string) tea.Cmd {
 		for _, app := range cat.apps {
 			if app.Key == appName {
 				normalizedSource, toolIdentifier := m.detectAppSource(app)
-				return fetchAppVersion(app.Key, toolIdentifier, normalizedSource)
+				return fetchAppVersion(m.versionResolver, app.Key, toolIdentifier, normalizedSource)
 			}
 		}
 	}
@@ -761,6 +852,10 @@ func (m *AppsModel) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cm
 		detailsHeight = 6
 	}
 
+	if m.showPacerDebug {
+		detailsHeight++
+	}
+
 	// Calculate viewport height (leave room for header, footer, and details)
 	// Add extra buffer to ensure header stays visible
 	viewportHeight := max(msg.Height-headerHeight-footerHeight-detailsHeight-2, 1)
@@ -798,6 +893,18 @@ func (m *AppsModel) View() string {
 		return m.renderWithModal()
 	}
 
+	if m.confirmingUninstall {
+		return m.renderUninstallConfirm()
+	}
+
+	if m.categoryInstall != nil {
+		return m.renderCategoryInstall()
+	}
+
+	if m.reviewing {
+		return m.renderReview()
+	}
+
 	return m.renderBaseView()
 }
 
@@ -829,8 +936,10 @@ func (m *AppsModel) renderBaseView() string {
 		components = append(components, header)
 	}
 
-	// Only update viewport content when necessary
-	if m.contentNeedsUpdate {
+	// Only update viewport content when necessary, or when scrolling
+	// moved the viewport past the rendered lines
+	scrolledOut := !m.searchActive && !m.renderedWindow.covers(m.viewport.YOffset, m.viewport.Height)
+	if m.contentNeedsUpdate || scrolledOut {
 		// Save current scroll position before updating content
 		currentOffset := m.viewport.YOffset
 
@@ -858,6 +967,10 @@ func (m *AppsModel) renderBaseView() string {
 		}
 	}
 
+	if m.showPacerDebug {
+		components = append(components, lipgloss.NewStyle().Foreground(m.styles.Muted).Padding(0, 1).Render(m.pacer.String()))
+	}
+
 	// Add the new clean footer
 	footer := m.renderCleanFooter()
 	components = append(components, footer)
@@ -898,6 +1011,10 @@ func (m *AppsModel) renderCleanHeader() string {
 		status = fmt.Sprintf("%d selected", selectedCount)
 	}
 
+	if m.searchNotice != "" {
+		status = m.searchNotice
+	}
+
 	rightSide := lipgloss.NewStyle().
 		Foreground(m.styles.Muted).
 		Render(status)
@@ -940,23 +1057,34 @@ func (m *AppsModel) renderSearchHeader() string {
 
 	// Build search bar content
 	searchContent := fmt.Sprintf("%s %s", searchPrompt, m.searchQuery)
+	if m.namingSearch {
+		searchContent = "Save search as: " + m.searchName
+	}
 
 	// Add cursor if search has focus
-	if m.searchHasFocus {
+	if m.searchHasFocus || m.namingSearch {
 		searchContent += lipgloss.NewStyle().
 			Foreground(m.styles.Primary).
 			Blink(true).
 			Render("█")
 	}
 
-	// Show match count on the right
+	// Show active filters and match count on the right
 	matchInfo := ""
-	if len(m.filteredApps) > 0 {
-		matchInfo = fmt.Sprintf("%d matches", len(m.filteredApps))
+	if m.results.Len() > 0 {
+		matchInfo = fmt.Sprintf("%d matches", m.results.Len())
 	} else if m.searchQuery != "" {
 		matchInfo = "No matches"
 	}
 
+	if filters := m.activeFilterSummary(); filters != "" {
+		matchInfo = strings.TrimSpace(filters + "   " + matchInfo)
+	}
+
+	if m.searchNotice != "" {
+		matchInfo = m.searchNotice + "   " + matchInfo
+	}
+
 	rightSide := lipgloss.NewStyle().
 		Foreground(m.styles.Muted).
 		Render(matchInfo)
@@ -1014,6 +1142,8 @@ func (m *AppsModel) renderCleanFooter() string {
 		actions = []string{
 			formatAction("Enter", "Select"),
 			formatAction("Tab", "Results"),
+			formatAction("^F", "Filters"),
+			formatAction("^S", "Save"),
 			formatAction("Esc", "Cancel"),
 		}
 	} else {
@@ -1021,11 +1151,23 @@ func (m *AppsModel) renderCleanFooter() string {
 		actions = []string{
 			formatAction("Space", "Select"),
 			formatAction("Enter", "Install"),
-			formatAction("u", "Uninstall"),
+			formatAction("d", "Uninstall"),
+			formatAction("o", "Details"),
+			formatAction("z", "Fold"),
 			formatAction("/", "Search"),
 		}
 	}
 
+	// Offer updates once the package managers report any
+	if len(m.updates) > 0 && !m.searchHasFocus {
+		actions = append(actions, formatAction("u", "Update"))
+	}
+
+	// Offer the review once something is pending
+	if len(m.selected) > 0 && !m.searchHasFocus {
+		actions = append(actions, formatAction("v", "Review"))
+	}
+
 	// Always add help with special styling (dim yellow to stand out)
 	helpKey := bracketStyle.Render("[") +
 		lipgloss.NewStyle().Bold(true).Foreground(m.styles.Warning).Render("?") +
@@ -1035,6 +1177,11 @@ func (m *AppsModel) renderCleanFooter() string {
 	// Join actions with more spacing
 	footerText := strings.Join(actions, "   ")
 
+	// The filter bar replaces the actions while it has focus
+	if m.searchActive && m.filterFocus {
+		footerText = m.renderFilterBar()
+	}
+
 	// Style the footer container
 	return lipgloss.NewStyle().
 		Padding(0, 2).
@@ -1053,7 +1200,7 @@ func (m *AppsModel) renderCompactDetails() string {
 	}
 
 	cat := m.categories[m.currentCat]
-	if cat.currentApp >= len(cat.apps) {
+	if cat.collapsed || cat.currentApp >= len(cat.apps) {
 		return ""
 	}
 
@@ -1081,10 +1228,8 @@ func (m *AppsModel) renderCompactDetails() string {
 		line1Right = lipgloss.NewStyle().Foreground(m.styles.Muted).Render(line1Right)
 	}
 
-	// Calculate spacing for right alignment
-	// Match the EXACT content width of categories: 82 chars
-	// (indicator + space + name(22) + spaces(2) + desc(42) + spaces(2) + source(12))
-	const categoryContentWidth = 82
+	// Calculate spacing for right alignment, matching the width of the app lines
+	categoryContentWidth := m.columns.contentWidth(m.width)
 
 	line1Width := categoryContentWidth
 
@@ -1103,6 +1248,17 @@ func (m *AppsModel) renderCompactDetails() string {
 	// Line 3: Source and package type (truncate to fit)
 	sourceStyle := lipgloss.NewStyle().Foreground(m.styles.Muted)
 	sourceText := "Source: " + app.Source
+	if app.RequiresRoot {
+		sourceText += " • " + StatusRequiresRoot + " requires sudo"
+	}
+
+	if app.License == domain.LicenseProprietary {
+		sourceText += " • proprietary license"
+	}
+
+	if badge := m.updateBadge(app); badge != "" {
+		sourceText += " • " + badge + " available"
+	}
 	truncatedSource := truncate(sourceText, categoryContentWidth)
 	lines[2] = sourceStyle.Render(truncatedSource)
 
@@ -1181,6 +1337,64 @@ func (m *AppsModel) handleKeyMessage(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// The uninstall confirmation takes every key until answered
+	if m.confirmingUninstall {
+		return m, m.handleUninstallConfirmKeys(msg)
+	}
+
+	// So does the category install summary
+	if m.categoryInstall != nil {
+		m.handleCategoryInstallKeys(msg)
+
+		return m, nil
+	}
+
+	// The review overlay takes every key while open
+	if m.reviewing {
+		return m, m.handleReviewKeys(msg)
+	}
+
+	// Naming a search to save takes every key
+	if m.namingSearch {
+		m.handleSaveSearchKeys(msg)
+
+		return m, nil
+	}
+
+	// Notices last until the next key
+	m.searchNotice = ""
+
+	switch msg.String() {
+	case "f12":
+		m.togglePacerDebug()
+
+		return m, nil
+	case "ctrl+n":
+		return m, m.cycleSavedSearch()
+	case "ctrl+z":
+		m.undoSelection()
+
+		return m, nil
+	case "ctrl+s":
+		if m.searchActive {
+			m.namingSearch = true
+			m.searchName = ""
+
+			return m, nil
+		}
+	}
+
+	// The filter bar takes every key while it has focus
+	if m.searchActive && (m.filterFocus || msg.String() == "ctrl+f") {
+		if !m.filterFocus {
+			m.filterFocus = true
+
+			return m, nil
+		}
+
+		return m, m.handleFilterKeys(msg)
+	}
+
 	// Handle search activation/deactivation
 	switch {
 	case msg.String() == "/":
@@ -1188,6 +1402,7 @@ func (m *AppsModel) handleKeyMessage(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.searchActive = true
 		m.searchHasFocus = true
 		m.searchQuery = ""
+		m.filterFocus = false
 
 		// Initialize with all apps for empty query (default behavior)
 		m.updateSearchResults()
@@ -1205,8 +1420,7 @@ func (m *AppsModel) handleKeyMessage(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.searchActive = false
 		m.searchHasFocus = false
 		m.searchQuery = "" // Esc clears query
-		m.filteredApps = []app{}
-		m.searchSelection = -1
+		m.results.SetItems(nil)
 
 		// Mark content for re-render to show categories again
 		m.contentNeedsUpdate = true
@@ -1231,6 +1445,29 @@ func (m *AppsModel) handleKeyMessage(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, installCmd
 	}
 
+	// Review pending operations before committing to them
+	if msg.String() == "v" {
+		m.openReview()
+
+		return m, nil
+	}
+
+	// Open the detail view for the focused app
+	if msg.String() == "o" {
+		if appKey, ok := m.focusedAppKey(); ok {
+			return m, func() tea.Msg {
+				return NavigateMsg{Screen: AppDetailScreen, Data: appKey}
+			}
+		}
+	}
+
+	// Collapse, expand and reorder categories
+	if !m.searchActive {
+		if cmd, handled := m.handleCategoryKeys(msg); handled {
+			return m, cmd
+		}
+	}
+
 	// Handle navigation (j/k always work for up/down, {/} for context switch)
 	if navCmd := m.handleNavigationKeys(msg); navCmd != nil {
 		return m, navCmd
@@ -1244,8 +1481,113 @@ func (m *AppsModel) handleKeyMessage(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// renderAllCategories renders ALL categories for the viewport to handle scrolling.
-// This is the proper Bubble Tea way - render everything, let viewport scroll.
+// togglePacerDebug shows or hides the status check pacing figures, giving
+// them a line of the viewport.
+func (m *AppsModel) togglePacerDebug() {
+	m.showPacerDebug = !m.showPacerDebug
+
+	if m.showPacerDebug {
+		m.viewport.Height = max(m.viewport.Height-1, 1)
+	} else {
+		m.viewport.Height++
+	}
+}
+
+// handleCategoryKeys folds, reorders and installs categories: z toggles the
+// current one, Z toggles all, </> move the current category up or down and I
+// offers to install everything in it.
+func (m *AppsModel) handleCategoryKeys(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "z":
+		m.toggleCategory()
+	case "Z":
+		m.toggleAllCategories()
+	case "<":
+		m.moveCategory(-1)
+	case ">":
+		m.moveCategory(1)
+	case "I":
+		m.startCategoryInstall()
+	default:
+		return nil, false
+	}
+
+	m.contentNeedsUpdate = true
+
+	return m.smoothScrollCommand(), true
+}
+
+// toggleCategory collapses or expands the current category.
+func (m *AppsModel) toggleCategory() {
+	if m.currentCat >= len(m.categories) {
+		return
+	}
+
+	cat := &m.categories[m.currentCat]
+	cat.collapsed = !cat.collapsed
+}
+
+// toggleAllCategories collapses every category, or expands them all when all are collapsed.
+func (m *AppsModel) toggleAllCategories() {
+	collapse := slices.ContainsFunc(m.categories, func(cat category) bool { return !cat.collapsed })
+
+	for i := range m.categories {
+		m.categories[i].collapsed = collapse
+	}
+}
+
+// moveCategory swaps the current category with its neighbour and persists the new order.
+func (m *AppsModel) moveCategory(delta int) {
+	target := m.currentCat + delta
+	if m.currentCat >= len(m.categories) || target < 0 || target >= len(m.categories) {
+		return
+	}
+
+	m.categories[m.currentCat], m.categories[target] = m.categories[target], m.categories[m.currentCat]
+	m.currentCat = target
+
+	m.saveCategoryOrder()
+}
+
+// saveCategoryOrder stores the category order in config.toml. An unreadable
+// config is left alone; the order then only lasts for this session.
+func (m *AppsModel) saveCategoryOrder() {
+	if m.prefsPath == "" {
+		return
+	}
+
+	prefs, err := config.LoadPreferences(m.prefsPath)
+	if err != nil {
+		return
+	}
+
+	prefs.Categories.Order = make([]string, 0, len(m.categories))
+	for _, cat := range m.categories {
+		prefs.Categories.Order = append(prefs.Categories.Order, strings.ToLower(cat.name))
+	}
+
+	_ = config.SavePreferences(m.prefsPath, prefs)
+}
+
+// orderCategories moves the categories named in order to the front, in that
+// order, keeping the remaining ones alphabetical.
+func orderCategories(categories []category, order []string) {
+	rank := func(cat category) int {
+		if idx := slices.IndexFunc(order, func(name string) bool { return strings.EqualFold(name, cat.name) }); idx >= 0 {
+			return idx
+		}
+
+		return len(order)
+	}
+
+	slices.SortStableFunc(categories, func(a, b category) int {
+		return cmp.Compare(rank(a), rank(b))
+	})
+}
+
+// renderAllCategories renders the category list for the viewport to scroll.
+// Only the lines around the viewport are rendered; the rest are blank
+// placeholders so line positions and scroll math stay exact.
 func (m *AppsModel) renderAllCategories() string {
 	// If search is active, show filtered results instead of categories
 	if m.searchActive {
@@ -1256,29 +1598,114 @@ func (m *AppsModel) renderAllCategories() string {
 		return "No categories available"
 	}
 
-	// Render ALL categories - viewport handles what's visible
-	categoryViews := make([]string, 0, len(m.categories))
+	window := m.visibleWindow()
+	lines := make([]string, 0, window.end)
+	offset := 0
+
 	for i, cat := range m.categories {
-		isCurrent := i == m.currentCat
-		categoryViews = append(categoryViews, m.renderCategory(cat, isCurrent))
+		height := categoryHeight(cat)
+
+		if offset+height <= window.start || offset >= window.end {
+			lines = append(lines, make([]string, height)...)
+		} else {
+			lines = append(lines, m.renderCategoryWindow(cat, i == m.currentCat, window.start-offset, window.end-offset)...)
+		}
+
+		offset += height
+	}
+
+	m.renderedWindow = window
+
+	return strings.Join(lines, "\n")
+}
+
+// lineWindow is a half-open range [start, end) of content lines.
+type lineWindow struct {
+	start int
+	end   int
+}
+
+// covers reports whether the lines [start, start+height) are inside the window.
+func (w lineWindow) covers(start, height int) bool {
+	return start >= w.start && start+height <= w.end
+}
+
+// visibleWindow returns the viewport lines plus a screen of buffer on each side.
+func (m *AppsModel) visibleWindow() lineWindow {
+	buffer := max(m.viewport.Height, 1)
+
+	return lineWindow{
+		start: max(m.viewport.YOffset-buffer, 0),
+		end:   m.viewport.YOffset + m.viewport.Height + buffer,
+	}
+}
+
+// Rendered category layout: border and padding around the title, then a blank
+// line and the apps when expanded.
+const (
+	categoryChromeLines = 4 // Top and bottom border plus padding
+	categoryHeaderLines = 4 // Top border, padding, title and blank line above the apps
+)
+
+// categoryHeight returns how many lines renderCategory produces for cat.
+func categoryHeight(cat category) int {
+	if cat.collapsed {
+		return categoryChromeLines + 1
+	}
+
+	return categoryChromeLines + 2 + len(cat.apps)
+}
+
+// renderCategoryWindow renders the lines of cat that fall within [from, to),
+// relative to the top of the category, with blank lines for the apps outside it.
+func (m *AppsModel) renderCategoryWindow(cat category, isCurrent bool, from, to int) []string {
+	total := len(cat.apps)
+	appFrom := min(max(from-categoryHeaderLines, 0), total)
+	appTo := min(max(to-categoryHeaderLines, 0), total)
+
+	if cat.collapsed || (appFrom == 0 && appTo == total) {
+		return strings.Split(m.renderCategory(cat, isCurrent), "\n")
 	}
 
-	// Pure Lipgloss composition - no pagination, no calculations
-	return lipgloss.JoinVertical(lipgloss.Top, categoryViews...)
+	// Keep at least one app so the border has the full width
+	if appFrom == appTo {
+		if appTo < total {
+			appTo++
+		} else {
+			appFrom--
+		}
+	}
+
+	boxLines := strings.Split(m.renderCategorySlice(cat, isCurrent, appFrom, appTo), "\n")
+	footer := boxLines[len(boxLines)-2:]
+
+	lines := make([]string, 0, categoryHeight(cat))
+	lines = append(lines, boxLines[:categoryHeaderLines]...)
+	lines = append(lines, make([]string, appFrom)...)
+	lines = append(lines, boxLines[categoryHeaderLines:categoryHeaderLines+appTo-appFrom]...)
+	lines = append(lines, make([]string, total-appTo)...)
+
+	return append(lines, footer...)
 }
 
 // renderCategory renders a single category.
 func (m *AppsModel) renderCategory(cat category, isCurrent bool) string {
-	// Use FIXED widths for ALL categories to ensure vertical alignment
-	// Don't calculate per-category as that breaks alignment
-	const fixedNameWidth = 22
+	return m.renderCategorySlice(cat, isCurrent, 0, len(cat.apps))
+}
 
-	const fixedDescWidth = 42
+// renderCategorySlice renders a category box containing only the apps in [from, to).
+func (m *AppsModel) renderCategorySlice(cat category, isCurrent bool, from, to int) string {
+	// The same widths for ALL categories keep them vertically aligned
+	nameWidth := m.columns.nameWidth
+	descWidth := m.columns.descriptionWidth(m.width)
 
-	appLines := m.renderAppLines(cat, isCurrent, fixedNameWidth, fixedDescWidth)
+	// Create simplified category title with a fold marker
+	marker := "▾"
+	if cat.collapsed {
+		marker = "▸"
+	}
 
-	// Create simplified category title
-	categoryTitle := fmt.Sprintf("%s (%d)", cat.name, len(cat.apps))
+	categoryTitle := fmt.Sprintf("%s %s (%d)", marker, cat.name, len(cat.apps))
 
 	// Style the title
 	styledTitle := lipgloss.NewStyle().
@@ -1286,6 +1713,16 @@ func (m *AppsModel) renderCategory(cat category, isCurrent bool) string {
 		Foreground(m.styles.Primary).
 		Render(categoryTitle)
 
+	if cat.collapsed {
+		return m.renderCategoryWithBorder(styledTitle, isCurrent)
+	}
+
+	part := cat
+	part.apps = cat.apps[from:to]
+	part.currentApp = cat.currentApp - from
+
+	appLines := m.renderAppLines(part, isCurrent, nameWidth, descWidth)
+
 	// Compose category content with styled title above apps
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -1306,6 +1743,12 @@ func (m *AppsModel) getAppIndicator(app app, selectionState SelectionState) stri
 	case StateUninstall:
 		// Red X for selected for removal
 		return m.styles.ErrorText.Render("✗")
+	case StateUpdate:
+		// Yellow arrow for selected for update
+		return m.styles.WarningText.Render("↑")
+	case StateReinstall:
+		// Yellow circle arrow for selected for reinstall
+		return m.styles.WarningText.Render("↻")
 	default:
 		// No selection - show install status
 		if app.StatusPending {
@@ -1332,7 +1775,7 @@ func (m *AppsModel) navigateDown() {
 	if m.currentCat < len(m.categories) {
 		cat := &m.categories[m.currentCat]
 
-		if cat.currentApp < len(cat.apps)-1 {
+		if !cat.collapsed && cat.currentApp < len(cat.apps)-1 {
 			// Move to next app in current category
 			cat.currentApp++
 		} else if m.currentCat < len(m.categories)-1 {
@@ -1354,7 +1797,7 @@ func (m *AppsModel) navigateUp() {
 	if m.currentCat < len(m.categories) {
 		cat := &m.categories[m.currentCat]
 
-		if cat.currentApp > 0 {
+		if !cat.collapsed && cat.currentApp > 0 {
 			// Move to previous app in current category
 			cat.currentApp--
 		} else if m.currentCat > 0 {
@@ -1374,9 +1817,9 @@ func (m *AppsModel) jumpToFirst() {
 		return
 	}
 
-	if m.searchActive && !m.searchHasFocus && len(m.filteredApps) > 0 {
+	if m.searchActive && !m.searchHasFocus && m.results.Len() > 0 {
 		// In search results, jump to first result
-		m.searchSelection = 0
+		m.results.SetCursor(0)
 		m.contentNeedsUpdate = true
 	} else if !m.searchActive {
 		// Jump to first app in first category
@@ -1392,9 +1835,9 @@ func (m *AppsModel) jumpToLast() {
 		return
 	}
 
-	if m.searchActive && !m.searchHasFocus && len(m.filteredApps) > 0 {
+	if m.searchActive && !m.searchHasFocus && m.results.Len() > 0 {
 		// In search results, jump to last result
-		m.searchSelection = len(m.filteredApps) - 1
+		m.results.SetCursor(m.results.Len() - 1)
 		m.contentNeedsUpdate = true
 	} else if !m.searchActive {
 		// Jump to last app in last category
@@ -1416,25 +1859,13 @@ func (m *AppsModel) smoothScrollCommand() tea.Cmd {
 
 // navigateSearchDown navigates down in search results.
 func (m *AppsModel) navigateSearchDown() {
-	if len(m.filteredApps) == 0 {
-		return
-	}
-
-	if m.searchSelection < len(m.filteredApps)-1 {
-		m.searchSelection++
-	}
+	m.results.MoveCursor(1)
 	// Don't call ensureSearchSelectionVisible here - it's called after content update
 }
 
 // navigateSearchUp navigates up in search results.
 func (m *AppsModel) navigateSearchUp() {
-	if len(m.filteredApps) == 0 {
-		return
-	}
-
-	if m.searchSelection > 0 {
-		m.searchSelection--
-	}
+	m.results.MoveCursor(-1)
 	// Don't call ensureSearchSelectionVisible here - it's called after content update
 }
 
@@ -1444,11 +1875,20 @@ func (m *AppsModel) toggleInstallSelection() {
 	}
 
 	cat := &m.categories[m.currentCat]
-	if cat.currentApp >= len(cat.apps) {
+	if cat.collapsed || cat.currentApp >= len(cat.apps) {
 		return
 	}
 
 	app := cat.apps[cat.currentApp]
+	if m.selected[app.Key] != StateInstall {
+		if conflict := m.installConflict(app.Key); conflict != "" {
+			m.searchNotice = conflict
+
+			return
+		}
+	}
+
+	m.recordSelection(app.Key)
 
 	// Defensive check: If app is not installed and has StateUninstall selection,
 	// reset invalid state - uninstalled apps cannot be marked for uninstall
@@ -1471,21 +1911,51 @@ func (m *AppsModel) toggleInstallSelection() {
 		m.selected[app.Key] = StateInstall // Switch from uninstall to install
 	}
 
+	if m.selected[app.Key] == StateInstall {
+		m.selectDependencies(app.Key)
+	}
+
 	// Mark content for re-render
 	m.contentNeedsUpdate = true
 }
 
+// focusedAppKey returns the key of the app under the cursor, in search results or categories.
+func (m *AppsModel) focusedAppKey() (string, bool) {
+	focused, ok := m.focusedApp()
+
+	return focused.Key, ok
+}
+
+// focusedApp returns the app under the cursor, in search results or categories.
+func (m *AppsModel) focusedApp() (app, bool) {
+	if m.searchActive {
+		return m.results.Selected()
+	}
+
+	if m.currentCat >= len(m.categories) {
+		return app{}, false
+	}
+
+	cat := m.categories[m.currentCat]
+	if cat.collapsed || cat.currentApp >= len(cat.apps) {
+		return app{}, false
+	}
+
+	return cat.apps[cat.currentApp], true
+}
+
 func (m *AppsModel) markForUninstall() {
 	if m.currentCat >= len(m.categories) {
 		return
 	}
 
 	cat := &m.categories[m.currentCat]
-	if cat.currentApp >= len(cat.apps) {
+	if cat.collapsed || cat.currentApp >= len(cat.apps) {
 		return
 	}
 
 	app := cat.apps[cat.currentApp]
+	m.recordSelection(app.Key)
 	m.selected[app.Key] = StateUninstall
 
 	// Mark content for re-render
@@ -1493,30 +1963,26 @@ func (m *AppsModel) markForUninstall() {
 }
 
 // toggleInstallSelectionForSearchResult toggles selection for the currently selected search result.
+// Toggle behavior matches the categories: None -> Install -> None, Uninstall -> Install.
 func (m *AppsModel) toggleInstallSelectionForSearchResult() {
-	if !m.searchActive || m.searchSelection < 0 || m.searchSelection >= len(m.filteredApps) {
+	result, ok := m.results.Selected()
+	if !m.searchActive || !ok {
 		return
 	}
 
-	app := m.filteredApps[m.searchSelection]
-
-	// Same logic as toggleInstallSelection but for search result
-	currentState := m.selected[app.Key]
-	if currentState == StateUninstall && !app.Installed {
-		// State corruption fix
-		delete(m.selected, app.Key)
+	if m.selected[result.Key] != StateInstall {
+		if conflict := m.installConflict(result.Key); conflict != "" {
+			m.searchNotice = conflict
 
-		currentState = StateNone
+			return
+		}
 	}
 
-	// Toggle behavior: None -> Install -> None
-	switch currentState {
-	case StateNone:
-		m.selected[app.Key] = StateInstall
-	case StateInstall:
-		delete(m.selected, app.Key) // Return to None state
-	case StateUninstall:
-		m.selected[app.Key] = StateInstall // Switch from uninstall to install
+	m.recordSelection(result.Key)
+	m.results.ToggleFocusedState(StateInstall)
+
+	if m.selected[result.Key] == StateInstall {
+		m.selectDependencies(result.Key)
 	}
 
 	// Mark content for re-render
@@ -1525,28 +1991,63 @@ func (m *AppsModel) toggleInstallSelectionForSearchResult() {
 
 // markForUninstallForSearchResult marks the currently selected search result for uninstallation.
 func (m *AppsModel) markForUninstallForSearchResult() {
-	if !m.searchActive || m.searchSelection < 0 || m.searchSelection >= len(m.filteredApps) {
+	result, ok := m.results.Selected()
+	if !m.searchActive || !ok {
 		return
 	}
 
-	app := m.filteredApps[m.searchSelection]
-	m.selected[app.Key] = StateUninstall
+	m.recordSelection(result.Key)
+	m.results.SetFocusedState(StateUninstall)
 
 	// Mark content for re-render
 	m.contentNeedsUpdate = true
 }
 
+// toggleInstalledSelection marks the focused app for an operation on
+// installed apps, update or reinstall, or clears the mark. The notice for
+// apps that aren't installed says they can't be done.
+func (m *AppsModel) toggleInstalledSelection(state SelectionState, done string) {
+	focused, ok := m.focusedApp()
+	if !ok {
+		return
+	}
+
+	if !focused.Installed {
+		m.searchNotice = "Only installed apps can be " + done
+
+		return
+	}
+
+	m.recordSelection(focused.Key)
+
+	if m.searchActive {
+		m.results.ToggleFocusedState(state)
+	} else if m.selected[focused.Key] == state {
+		delete(m.selected, focused.Key)
+	} else {
+		m.selected[focused.Key] = state
+	}
+
+	m.contentNeedsUpdate = true
+}
+
 // handleInstallationKeys processes installation-related key presses.
 func (m *AppsModel) handleInstallationKeys(msg tea.KeyMsg) tea.Cmd {
 	switch {
 	case key.Matches(msg, m.keyMap.Install), msg.String() == KeyEnter:
 		operations := m.getSelectedOperations()
-		if len(operations) > 0 {
-			// First go to password screen, then to progress
-			return func() tea.Msg {
-				return NavigateMsg{Screen: PasswordScreen, Data: operations}
-			}
+		if len(operations) == 0 {
+			return nil
+		}
+
+		// Removing apps is destructive, so list them for confirmation first
+		if len(uninstallTargets(operations)) > 0 {
+			m.confirmingUninstall = true
+
+			return nil
 		}
+
+		return startOperations(m.ctx, m.changePrivileges(), operations)
 	}
 
 	return nil
@@ -1567,7 +2068,9 @@ func (m *AppsModel) handleSearchInput(msg tea.KeyMsg) tea.Cmd {
 func (m *AppsModel) handleBackspace() tea.Cmd {
 	if len(m.searchQuery) > 0 {
 		m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
-		m.updateSearchResults()
+		m.contentNeedsUpdate = true
+
+		return m.debounceSearch()
 	}
 
 	return nil
@@ -1580,8 +2083,7 @@ func (m *AppsModel) handleEnterInSearch() tea.Cmd {
 		m.searchActive = false
 		m.searchHasFocus = false
 		// Query preserved for potential reactivation
-		m.filteredApps = []app{}
-		m.searchSelection = -1
+		m.results.SetItems(nil)
 
 		// Mark content for re-render to show categories again
 		m.contentNeedsUpdate = true
@@ -1599,7 +2101,9 @@ func (m *AppsModel) handleCharacterInput(msg tea.KeyMsg) tea.Cmd {
 	keyStr := msg.String()
 	if len(keyStr) == 1 && keyStr >= " " && keyStr <= "~" {
 		m.searchQuery += keyStr
-		m.updateSearchResults()
+		m.contentNeedsUpdate = true
+
+		return m.debounceSearch()
 	}
 
 	return nil
@@ -1607,12 +2111,14 @@ func (m *AppsModel) handleCharacterInput(msg tea.KeyMsg) tea.Cmd {
 
 // updateSearchResults updates the filtered results based on current search query.
 func (m *AppsModel) updateSearchResults() {
-	m.filteredApps = m.performFuzzySearch(m.searchQuery)
+	query := strings.ToLower(strings.TrimSpace(m.searchQuery))
 
-	// Reset selection
-	m.searchSelection = -1
-	if len(m.filteredApps) > 0 {
-		m.searchSelection = 0
+	// A longer query only matches a subset of the previous results
+	// Setting the results moves the selection to the first one
+	if m.searchedQuery != "" && strings.HasPrefix(query, m.searchedQuery) {
+		m.results.SetItems(m.rankResults(m.sortApps(m.results.VisibleItems()), query))
+	} else {
+		m.results.SetItems(m.performFuzzySearch(m.searchQuery))
 	}
 
 	// Mark content for re-render to show updated search results
@@ -1655,12 +2161,6 @@ func (m *AppsModel) handleUpFromSearch() tea.Cmd {
 	if m.searchHasFocus {
 		// In search field: { wraps to search results (toggle behavior)
 		m.searchHasFocus = false
-		if len(m.filteredApps) > 0 {
-			// Go to search results
-			if m.searchSelection < 0 {
-				m.searchSelection = 0
-			}
-		}
 
 		return func() tea.Msg {
 			return ContextSwitchMsg{Direction: "up", Context: "search-field-wrap"}
@@ -1706,12 +2206,6 @@ func (m *AppsModel) handleDownFromSearch() tea.Cmd {
 	if m.searchHasFocus {
 		// In search field: go down to search results
 		m.searchHasFocus = false
-		if len(m.filteredApps) > 0 {
-			// Go to search results
-			if m.searchSelection < 0 {
-				m.searchSelection = 0
-			}
-		}
 
 		return func() tea.Msg {
 			return ContextSwitchMsg{Direction: "down", Context: "search-field"}
@@ -1780,7 +2274,7 @@ func (m *AppsModel) handleNavigationKeys(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *AppsModel) handleDownNavigation() {
-	if m.searchActive && !m.searchHasFocus && len(m.filteredApps) > 0 {
+	if m.searchActive && !m.searchHasFocus && m.results.Len() > 0 {
 		// Navigate down in search results
 		m.navigateSearchDown()
 		// For search, content needs update since selection highlighting changes
@@ -1794,7 +2288,7 @@ func (m *AppsModel) handleDownNavigation() {
 }
 
 func (m *AppsModel) handleUpNavigation() {
-	if m.searchActive && !m.searchHasFocus && len(m.filteredApps) > 0 {
+	if m.searchActive && !m.searchHasFocus && m.results.Len() > 0 {
 		// Navigate up in search results
 		m.navigateSearchUp()
 		// For search, content needs update since selection highlighting changes
@@ -1811,7 +2305,7 @@ func (m *AppsModel) handleUpNavigation() {
 func (m *AppsModel) handleSelectionKeys(msg tea.KeyMsg) {
 	switch {
 	case key.Matches(msg, m.keyMap.Select), msg.String() == " ":
-		if m.searchActive && len(m.filteredApps) > 0 && m.searchSelection >= 0 {
+		if m.searchActive && m.results.Len() > 0 {
 			m.toggleInstallSelectionForSearchResult()
 		} else {
 			m.toggleInstallSelection()
@@ -1819,13 +2313,17 @@ func (m *AppsModel) handleSelectionKeys(msg tea.KeyMsg) {
 
 		m.contentNeedsUpdate = true
 	case msg.String() == "d":
-		if m.searchActive && len(m.filteredApps) > 0 && m.searchSelection >= 0 {
+		if m.searchActive && m.results.Len() > 0 {
 			m.markForUninstallForSearchResult()
 		} else {
 			m.markForUninstall()
 		}
 
 		m.contentNeedsUpdate = true
+	case msg.String() == "u":
+		m.toggleInstalledSelection(StateUpdate, "updated")
+	case msg.String() == "r":
+		m.toggleInstalledSelection(StateReinstall, "reinstalled")
 	}
 }
 
@@ -1839,6 +2337,9 @@ func (m *AppsModel) updateAppStatus(appName string, installed bool) {
 		// Clear selection state after any status update
 		delete(m.selected, appName)
 
+		// The install status filter may now match differently
+		m.searchedQuery = ""
+
 		// Mark that we have pending status updates
 		m.statusUpdatePending = true
 	}
@@ -1850,88 +2351,18 @@ func (m *AppsModel) ensureSelectionVisible() {
 		return
 	}
 
-	// Calculate EXACT line position of current selection in rendered content
-	selectionLine := m.calculateActualSelectionLine()
-
-	// Get current viewport window
-	viewportTop := m.viewport.YOffset
-	viewportBottom := viewportTop + m.viewport.Height - 1
-
-	// Buffer zones - scroll when selection gets close to edges
-	topBuffer := 3    // Keep 3 lines above selection visible
-	bottomBuffer := 3 // Keep 3 lines below selection visible
-
-	// Check if selection is outside comfortable viewing area
-	if selectionLine < viewportTop+topBuffer {
-		// Selection too close to top - scroll up smoothly
-		newOffset := selectionLine - topBuffer
-		if newOffset < 0 {
-			newOffset = 0
-		}
-
-		m.viewport.SetYOffset(newOffset)
-	} else if selectionLine > viewportBottom-bottomBuffer {
-		// Selection too close to bottom - scroll down smoothly
-		newOffset := selectionLine - m.viewport.Height + bottomBuffer + 1
-		totalLines := m.viewport.TotalLineCount()
-
-		maxOffset := totalLines - m.viewport.Height
-		if maxOffset < 0 {
-			maxOffset = 0
-		}
-
-		if newOffset > maxOffset {
-			newOffset = maxOffset
-		}
-
-		m.viewport.SetYOffset(newOffset)
-	}
-	// Selection is comfortably visible - no scroll needed
+	// Keep 3 lines above and below the selection visible
+	followSelection(&m.viewport, m.calculateActualSelectionLine(), listFollowBuffer)
 }
 
 // ensureSearchSelectionVisible calculates exact line position of search selection and scrolls viewport.
 func (m *AppsModel) ensureSearchSelectionVisible() {
-	if !m.ready || !m.searchActive || len(m.filteredApps) == 0 || m.searchSelection < 0 {
+	if !m.ready || !m.searchActive || m.results.Len() == 0 {
 		return
 	}
 
-	// Calculate EXACT line position of current search selection in rendered content
-	selectionLine := m.calculateSearchSelectionLine()
-
-	// Get current viewport window
-	viewportTop := m.viewport.YOffset
-	viewportBottom := viewportTop + m.viewport.Height - 1
-
-	// Buffer zones - scroll when selection gets close to edges
-	topBuffer := 3    // Keep 3 lines above selection visible
-	bottomBuffer := 2 // Keep 2 lines below selection visible
-
-	// Check if selection is outside comfortable viewing area
-	if selectionLine <= viewportTop+topBuffer {
-		// Selection too close to top - scroll up to maintain buffer
-		newOffset := selectionLine - topBuffer
-		if newOffset < 0 {
-			newOffset = 0
-		}
-
-		m.viewport.SetYOffset(newOffset)
-	} else if selectionLine >= viewportBottom-bottomBuffer {
-		// Selection too close to bottom - scroll down to maintain buffer
-		newOffset := selectionLine - m.viewport.Height + bottomBuffer + 1
-		totalLines := m.viewport.TotalLineCount()
-
-		maxOffset := totalLines - m.viewport.Height
-		if maxOffset < 0 {
-			maxOffset = 0
-		}
-
-		if newOffset > maxOffset {
-			newOffset = maxOffset
-		}
-
-		m.viewport.SetYOffset(newOffset)
-	}
-	// Selection is comfortably visible - no scroll needed
+	// Keep 3 lines above and below the selection visible
+	followSelection(&m.viewport, m.calculateSearchSelectionLine(), listFollowBuffer)
 }
 
 // calculateSearchSelectionLine calculates exact line position of search selection.
@@ -1939,20 +2370,16 @@ func (m *AppsModel) calculateSearchSelectionLine() int {
 	// Account for the box border and padding structure:
 	// Line 0: Top border ╭──────╮
 	// Line 1: Padding (empty)
-	// Line 2: Title "Search Results (N matches)"
-	// Line 3: Empty line after title
-	// Line 4+: Search result items
+	// Line 2+: The result list, starting with its title and a blank line
 	// ...
 	// Line N: Padding (empty)
 	// Line N+1: Bottom border ╰──────╯
 	line := 0
 	line++ // Top border
 	line++ // Top padding
-	line++ // Title line
-	line++ // Empty line after title
 
-	// Add the selection position
-	line += m.searchSelection
+	// Add the selection position within the list
+	line += m.results.SelectionLine()
 
 	return line
 }
@@ -1963,28 +2390,20 @@ func (m *AppsModel) calculateActualSelectionLine() int {
 
 	// Count lines for each category before current one
 	for catIdx := 0; catIdx < m.currentCat && catIdx < len(m.categories); catIdx++ {
-		cat := m.categories[catIdx]
-
-		// Render this category to count its exact lines
-		categoryContent := m.renderCategory(cat, false)
-		categoryLines := lipgloss.Height(categoryContent)
-		line += categoryLines
-
-		// Add spacing between categories (if not last)
-		if catIdx < len(m.categories)-1 {
-			line++ // Empty line between categories
-		}
+		line += categoryHeight(m.categories[catIdx])
 	}
 
 	// Add lines within current category up to current app
 	if m.currentCat < len(m.categories) {
 		currentCat := m.categories[m.currentCat]
 
-		// Category header (1 line)
-		line++
+		// A collapsed category is selected by its title, below the border and padding
+		if currentCat.collapsed {
+			return line + 2
+		}
 
-		// Border top and padding
-		line += 2
+		// Border, padding, title and the empty line below it
+		line += categoryHeaderLines
 
 		// Apps before current selection
 		line += currentCat.currentApp
@@ -1995,14 +2414,17 @@ func (m *AppsModel) calculateActualSelectionLine() int {
 
 // SelectedOperation represents an operation to perform on an application.
 type SelectedOperation struct {
-	AppKey    string
-	Operation SelectionState
-	AppName   string
+	AppKey       string
+	Operation    SelectionState
+	AppName      string
+	RequiresRoot bool
 }
 
 // appCatalogAdapter provides adapter for the apps catalog.
 type appCatalogAdapter struct {
-	manager *apps.Manager
+	preference domain.MethodPreference
+	flatpak    domain.FlatpakScopes
+	policy     domain.InstallPolicy
 }
 
 // getSelectedOperations returns all selected operations (install and uninstall).
@@ -2014,9 +2436,10 @@ func (m *AppsModel) getSelectedOperations() []SelectedOperation {
 		for _, app := range cat.apps {
 			if state, exists := m.selected[app.Key]; exists && state != StateNone {
 				operations = append(operations, SelectedOperation{
-					AppKey:    app.Key,
-					Operation: state,
-					AppName:   app.Name,
+					AppKey:       app.Key,
+					Operation:    state,
+					AppName:      app.Name,
+					RequiresRoot: app.RequiresRoot,
 				})
 			}
 		}
@@ -2028,78 +2451,92 @@ func (m *AppsModel) getSelectedOperations() []SelectedOperation {
 // newAppCatalogAdapter creates adapter for the apps catalog.
 func newAppCatalogAdapter() *appCatalogAdapter {
 	return &appCatalogAdapter{
-		manager: apps.NewTUIManager(false), // Use TUI-optimized manager to suppress command output
+		preference: configuredMethodPreference(),
+		flatpak:    configuredFlatpakScopes(),
+		policy:     configuredInstallPolicy(),
 	}
 }
 
 func (a *appCatalogAdapter) getAllCategoriesFast() []AppCategory {
-	categories := make(map[string]*AppCategory)
-
-	// Get all apps from the catalog
-	allApps := apps.Apps
+	// The index is grouped and sorted once per catalog, not on every launch
+	index := apps.CatalogIndex()
+	result := make([]AppCategory, 0, len(index.Groups))
+
+	for _, group := range index.Groups {
+		category := AppCategory{
+			Name:         group.Title,
+			Description:  a.getCategoryDescription(group.Name),
+			Applications: make([]Application, 0, len(group.Keys)),
+		}
 
-	// Group apps by category - NO synchronous installation checks
-	for key, app := range allApps {
-		// Start with unknown installation status - will be updated async
-		tuiApp := a.transformApp(key, app, false) // false = assume not installed initially
+		for _, key := range group.Keys {
+			app := apps.Apps[key]
 
-		if cat, exists := categories[app.Group]; exists {
-			cat.Applications = append(cat.Applications, tuiApp)
-		} else {
-			categories[app.Group] = &AppCategory{
-				Name:         cases.Title(language.Und).String(app.Group),
-				Description:  a.getCategoryDescription(app.Group),
-				Applications: []Application{tuiApp},
+			// Apps the install policy forbids are left out of the catalog
+			if !app.Allowed(a.policy) {
+				continue
 			}
+
+			// Start with unknown installation status - will be updated async
+			category.Applications = append(category.Applications, a.transformApp(key, app, group.Title, false))
 		}
-	}
 
-	// Convert to slice and sort
-	result := make([]AppCategory, 0, len(categories))
-	for _, cat := range categories {
-		slices.SortFunc(cat.Applications, func(a, b Application) int {
-			return cmp.Compare(a.Name, b.Name)
-		})
-		result = append(result, *cat)
+		if len(category.Applications) > 0 {
+			result = append(result, category)
+		}
 	}
 
-	slices.SortFunc(result, func(a, b AppCategory) int {
-		return cmp.Compare(a.Name, b.Name)
-	})
-
 	return result
 }
 
-func (a *appCatalogAdapter) transformApp(key string, app apps.App, installed bool) Application {
+func (a *appCatalogAdapter) transformApp(key string, app apps.App, category string, installed bool) Application {
 	return Application{
-		Key:         key,
-		Name:        app.Name,
-		Description: app.Description,
-		Icon:        a.getIconForApp(app),
-		Category:    cases.Title(language.Und).String(app.Group),
-		Installed:   installed,
-		Size:        a.estimateSize(app),
-		Source:      a.formatSource(app.Method),
-		Selected:    false,
+		Key:          key,
+		Name:         app.Name,
+		Description:  app.Description,
+		Icon:         a.getIconForApp(app),
+		Category:     category,
+		Installed:    installed,
+		Size:         a.estimateSize(app),
+		Source:       a.formatSource(app.Method),
+		Aliases:      app.Aliases,
+		License:      app.License,
+		RequiresRoot: a.requiresRoot(key, app),
+		Selected:     false,
+	}
+}
+
+// requiresRoot reports whether installing the app needs sudo, including
+// flatpaks configured for the system-wide installation.
+func (a *appCatalogAdapter) requiresRoot(key string, app apps.App) bool {
+	method, _ := app.Resolve(a.preference)
+	if method == domain.MethodFlatpak {
+		return a.flatpak.For(key) == domain.ScopeSystem
 	}
+
+	return method.RequiresRoot()
 }
 
 func (a *appCatalogAdapter) getCategoryDescription(group string) string {
 	descriptions := map[string]string{
-		"development":   "Software development tools and IDEs",
-		"browsers":      "Web browsers and internet tools",
-		"communication": "Chat, video, and messaging applications",
-		"media":         "Audio, video, and multimedia applications",
-		"productivity":  "Office tools and productivity applications",
-		"graphics":      "Image editing and graphics tools",
-		"utilities":     "System utilities and tools",
-		"gaming":        "Games and gaming platforms",
-		"terminal":      "Command-line tools and terminal applications",
-		"golang":        "Go programming language tools",
-		"javalang":      "Java programming language tools",
-		"rustlang":      "Rust programming language tools",
-		"pythonlang":    "Python programming language tools",
-		"linters":       "Code analysis and linting tools",
+		"development":       "Software development tools and IDEs",
+		"browsers":          "Web browsers and internet tools",
+		"communication":     "Chat, video, and messaging applications",
+		"communication-pro": "Mail and calendar clients",
+		"media":             "Audio, video, and multimedia applications",
+		"productivity":      "Office tools and productivity applications",
+		"graphics":          "Image editing and graphics tools",
+		"utilities":         "System utilities and tools",
+		"gaming":            "Games and gaming platforms",
+		"tiling":            "Tiling window managers and their companions",
+		"printing":          "Printers and scanners",
+		"terminal":          "Command-line tools and terminal applications",
+		"golang":            "Go programming language tools",
+		"javalang":          "Java programming language tools",
+		"rustlang":          "Rust programming language tools",
+		"pythonlang":        "Python programming language tools",
+		"nodelang":          "Node.js tools",
+		"linters":           "Code analysis and linting tools",
 	}
 
 	if desc, exists := descriptions[group]; exists {
@@ -2111,20 +2548,24 @@ func (a *appCatalogAdapter) getCategoryDescription(group string) string {
 
 func (a *appCatalogAdapter) getIconForApp(app apps.App) string {
 	icons := map[string]string{
-		"development":   "◆",
-		"browsers":      "◯",
-		"communication": "◈",
-		"media":         "▶",
-		"productivity":  "▣",
-		"graphics":      "◉",
-		"utilities":     "▪",
-		"gaming":        "♦",
-		"terminal":      "▸",
-		"golang":        "◐",
-		"javalang":      "◑",
-		"rustlang":      "◈",
-		"pythonlang":    "◊",
-		"linters":       "✓",
+		"development":       "◆",
+		"browsers":          "◯",
+		"communication":     "◈",
+		"communication-pro": "◇",
+		"media":             "▶",
+		"productivity":      "▣",
+		"graphics":          "◉",
+		"utilities":         "▪",
+		"gaming":            "♦",
+		"tiling":            "▦",
+		"printing":          "▤",
+		"terminal":          "▸",
+		"golang":            "◐",
+		"javalang":          "◑",
+		"rustlang":          "◈",
+		"pythonlang":        "◊",
+		"nodelang":          "◇",
+		"linters":           "✓",
 	}
 
 	if icon, exists := icons[app.Group]; exists {
@@ -2150,6 +2591,8 @@ func (a *appCatalogAdapter) estimateSize(app apps.App) string {
 		return "1-50 MB"
 	case domain.MethodAqua:
 		return "1-20 MB"
+	case domain.MethodNpm:
+		return "1-50 MB"
 	default:
 		return "Unknown"
 	}
@@ -2165,6 +2608,7 @@ func (a *appCatalogAdapter) formatSource(method domain.InstallMethod) string {
 		domain.MethodScript:       "script",
 		domain.MethodBinary:       "binary",
 		domain.MethodAqua:         "aqua",
+		domain.MethodNpm:          "npm",
 		domain.MethodGitHub:       "github",
 		domain.MethodGitHubBinary: "github-bin",
 		domain.MethodGitHubBundle: "github-app",
@@ -2191,13 +2635,57 @@ func truncate(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// rootIndicator returns the lock column, blank for apps installable without sudo.
+func (m *AppsModel) rootIndicator(app app) string {
+	if !app.RequiresRoot {
+		return "  " // Same width as the emoji
+	}
+
+	return m.styles.WarningText.Render(StatusRequiresRoot)
+}
+
+// licenseBadge returns the license column, blank for open source apps.
+func (m *AppsModel) licenseBadge(app app) string {
+	if app.License != domain.LicenseProprietary {
+		return " "
+	}
+
+	return m.styles.MutedText.Render(StatusProprietary)
+}
+
+// updateBadge returns "↑ version" for an installed app its package manager
+// has a newer version of, or "" for the others.
+func (m *AppsModel) updateBadge(app app) string {
+	update, ok := m.updates[app.Key]
+	if !ok || !app.Installed {
+		return ""
+	}
+
+	if update.Available == "" {
+		return "↑"
+	}
+
+	return "↑ " + extractVersion(update.Available)
+}
+
+// descriptionCell splits the description column between the description
+// and the styled update badge at its end, when the app has one. Together
+// they fill width.
+func (m *AppsModel) descriptionCell(app app, width int) (string, string) {
+	badge := m.updateBadge(app)
+	if badge == "" {
+		return fmt.Sprintf("%-*s", width, truncate(app.Description, width)), ""
+	}
+
+	textWidth := max(width-lipgloss.Width(badge)-1, 0)
+
+	return fmt.Sprintf("%-*s ", textWidth, truncate(app.Description, textWidth)), m.styles.WarningText.Render(badge)
+}
+
 // renderAppLines creates formatted lines for all apps in a category.
 func (m *AppsModel) renderAppLines(cat category, isCurrent bool, nameWidth, descWidth int) []string {
 	appLines := make([]string, 0, len(cat.apps))
 
-	// Fixed source column width for alignment
-	const sourceWidth = 12 // Wide enough for "github-java" and others
-
 	for appIdx, app := range cat.apps {
 		indicator := m.getAppIndicator(app, m.selected[app.Key])
 
@@ -2212,13 +2700,14 @@ func (m *AppsModel) renderAppLines(cat category, isCurrent bool, nameWidth, desc
 
 		// Format the main content with fixed widths
 		name := truncate(app.Name, nameWidth)
-		desc := truncate(app.Description, descWidth)
+		desc, badge := m.descriptionCell(app, descWidth)
 
-		// Build main content (indicator + name + description)
-		mainContent := fmt.Sprintf("%s %-*s  %-*s",
+		// Build main content (indicator + name + description + optional columns)
+		cells := m.columns.cells(app)
+		mainContent := fmt.Sprintf("%s %-*s  %s",
 			indicator,
 			nameWidth, name,
-			descWidth, desc)
+			desc) + badge + m.styles.MutedText.Render(cells)
 
 		// Right-align source in a fixed-width column
 		// This ensures all sources align regardless of their length
@@ -2231,7 +2720,7 @@ func (m *AppsModel) renderAppLines(cat category, isCurrent bool, nameWidth, desc
 		sourceFormatted := fmt.Sprintf("%*s", sourceWidth, source)
 
 		// Build complete line with consistent spacing
-		dimmedSource := m.styles.MutedText.Render(sourceFormatted)
+		dimmedSource := m.styles.MutedText.Render(sourceFormatted) + " " + m.rootIndicator(app) + m.licenseBadge(app)
 
 		// Fixed spacing between description and source
 		const gapBeforeSource = 2
@@ -2246,11 +2735,8 @@ func (m *AppsModel) renderAppLines(cat category, isCurrent bool, nameWidth, desc
 
 			// Keep the indicator separate so it maintains its color
 			// but highlight the rest of the line
-			highlightedContent := fmt.Sprintf("%-*s  %-*s",
-				nameWidth, name,
-				descWidth, desc)
-
-			highlightedMain := highlightStyle.Render(highlightedContent)
+			highlightedMain := highlightStyle.Render(fmt.Sprintf("%-*s  %s", nameWidth, name, desc)) +
+				badge + highlightStyle.Render(cells)
 
 			// Reconstruct the line with original indicator but highlighted content
 			line = fmt.Sprintf("%s %s", indicator, highlightedMain) +
@@ -2335,6 +2821,10 @@ func (m *AppsModel) handleCompletedOperations(operations []SelectedOperation) {
 		case StateUninstall:
 			// App was successfully uninstalled
 			m.updateAppStatus(operation.AppKey, false)
+		case StateUpdate, StateReinstall:
+			// App was successfully updated or reinstalled, at the newest version
+			m.updateAppStatus(operation.AppKey, true)
+			delete(m.updates, operation.AppKey)
 		}
 	}
 }
@@ -2369,6 +2859,9 @@ func (m *AppsModel) GetNavigationHints() []string {
 		"[{/}] Categories",
 		"[Space] Select",
 		"[d] Uninstall",
+		"[u] Update",
+		"[r] Reinstall",
+		"[I] Install Category",
 		"[Enter] Install",
 		"[/] Search",
 	}
@@ -2393,15 +2886,8 @@ func (m *AppsModel) activateSearchMode(query string) {
 		m.searchHasFocus = true
 	}
 
-	// Perform fuzzy search (empty query shows all apps)
-	m.filteredApps = m.performFuzzySearch(query)
-
-	// Auto-select first result if any results found
-	if len(m.filteredApps) > 0 {
-		m.searchSelection = 0
-	} else {
-		m.searchSelection = -1 // No results
-	}
+	// Perform fuzzy search (empty query shows all apps), selecting the first result
+	m.results.SetItems(m.performFuzzySearch(query))
 
 	// Reset viewport scroll position for new search results
 	if m.ready {
@@ -2412,8 +2898,7 @@ func (m *AppsModel) activateSearchMode(query string) {
 // deactivateSearchMode handles search deactivation logic.
 func (m *AppsModel) deactivateSearchMode() {
 	// Clear filtered results and reset selection
-	m.filteredApps = nil
-	m.searchSelection = -1
+	m.results.SetItems(nil)
 	m.searchHasFocus = false
 }
 
@@ -2443,51 +2928,38 @@ func (m *AppsModel) performFuzzySearch(query string) []app {
 	// Apply sorting to collected apps FIRST
 	allApps = m.sortApps(allApps)
 
-	// Build search strings AFTER sorting to maintain sync
-	searchStrings := make([]string, 0, len(allApps))
-
-	for _, app := range allApps {
-		// Search in both name and description for better matches
-		searchStr := app.Name + " " + app.Description
-		searchStrings = append(searchStrings, searchStr)
-	}
-
 	// If query is empty, return all sorted apps
 	query = strings.TrimSpace(strings.ToLower(query))
 	if query == "" {
+		m.searchedQuery = ""
+		m.searchHighlight = nil
+
 		return allApps
 	}
 
-	// Phase 1: Find exact word boundary matches (highest priority)
-	var exactMatches []app
-
-	for appIndex, searchStr := range searchStrings {
-		lowerSearchStr := strings.ToLower(searchStr)
-		words := strings.Fields(lowerSearchStr)
+	return m.rankResults(allApps, query)
+}
 
-		// Check if query appears in any word
-		for _, word := range words {
-			if strings.Contains(word, query) {
-				app := allApps[appIndex]
-				exactMatches = append(exactMatches, app)
+// rankResults ranks candidates against query, best first with the sort option
+// breaking ties, and records the name positions to highlight.
+func (m *AppsModel) rankResults(candidates []app, query string) []app {
+	matches := rankApps(candidates, query)
+	results := make([]app, 0, len(matches))
+	m.searchHighlight = make(map[string][]int, len(matches))
 
-				break
-			}
-		}
+	for _, match := range matches {
+		results = append(results, match.app)
+		m.searchHighlight[match.app.Key] = match.positions
 	}
 
-	// Only use exact word matches - no fuzzy matching to avoid false positives
-	result := exactMatches
-
-	// Sort the combined results based on current sort option
-	result = m.sortApps(result)
+	m.searchedQuery = query
 
-	return result
+	return results
 }
 
 // renderSearchResults renders the search results in a flat list without categories.
 func (m *AppsModel) renderSearchResults() string {
-	if len(m.filteredApps) == 0 {
+	if m.results.Len() == 0 {
 		if m.searchQuery == "" {
 			return "Start typing to search apps..."
 		}
@@ -2496,99 +2968,88 @@ func (m *AppsModel) renderSearchResults() string {
 	}
 
 	// Create a title that looks like a category header
-	title := fmt.Sprintf("Search Results (%d matches)", len(m.filteredApps))
+	title := fmt.Sprintf("Search Results (%d matches)", m.results.Len())
 	styledTitle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(m.styles.Primary).
 		Render(title)
 
-	// Use FIXED column widths matching category view for consistency
-	// These match the exact widths used in renderAppLines
-	const (
-		nameWidth            = 22 // Fixed name column width
-		descWidth            = 42 // Fixed description column width
-		sourceWidth          = 12 // Fixed source column width
-		categoryContentWidth = 82 // Total content width matching details panel
-	)
-
-	// Render search results with same styling as category items
-	appLines := make([]string, 0, len(m.filteredApps))
-	for appIndex, app := range m.filteredApps {
-		indicator := m.getAppIndicator(app, m.selected[app.Key])
-
-		// Ensure indicator is always 1 character
-		if indicator == " " {
-			indicator = " "
-		}
+	// Total content width matching details panel
+	categoryContentWidth := m.columns.contentWidth(m.width)
 
-		// Format the main content with fixed widths (same as category items)
-		name := truncate(app.Name, nameWidth)
-		desc := truncate(app.Description, descWidth)
+	// Compose the complete search view (similar to category layout)
+	m.results.SetHeader(styledTitle + "\n")
+	content := m.results.Render()
 
-		// Build main content (indicator + name + description)
-		mainContent := fmt.Sprintf("%s %-*s  %-*s",
-			indicator,
-			nameWidth, name,
-			descWidth, desc)
+	// Wrap in a box similar to the details panel for consistency
+	// This ensures the search results have the same visual treatment
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(1).                         // Same padding as details panel
+		MaxWidth(categoryContentWidth + 4). // Content width + padding + borders
+		Render(content)
+}
 
-		// Right-align source in a fixed-width column
-		source := app.Source
-		if len(source) > sourceWidth {
-			source = truncate(source, sourceWidth)
-		}
+// renderSearchResult renders one search result with the same columns as the
+// category items. Matched name positions come from the apps screen's own
+// ranking rather than the list.
+func (m *AppsModel) renderSearchResult(result app, focused bool, state SelectionState, _ []int) string {
+	// The same column widths as the category view keep results aligned with it
+	nameWidth := m.columns.nameWidth
+	descWidth := m.columns.descriptionWidth(m.width)
 
-		// Format source to be right-aligned within sourceWidth
-		sourceFormatted := fmt.Sprintf("%*s", sourceWidth, source)
+	// Matched characters of app names stand out like the help key
+	matchStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Warning)
 
-		// Build complete line with consistent spacing
-		dimmedSource := m.styles.MutedText.Render(sourceFormatted)
+	indicator := m.getAppIndicator(result, state)
 
-		// Fixed spacing between description and source
-		const gapBeforeSource = 2
+	// Format the main content with fixed widths (same as category items)
+	name := truncate(result.Name, nameWidth)
+	desc, badge := m.descriptionCell(result, descWidth)
+	positions := m.searchHighlight[result.Key]
 
-		line := mainContent + strings.Repeat(" ", gapBeforeSource) + dimmedSource
+	// Build main content (indicator + highlighted name + description + optional columns)
+	cells := m.columns.cells(result)
+	mainContent := fmt.Sprintf("%s %s  %s",
+		indicator,
+		highlightMatches(name, positions, lipgloss.NewStyle(), matchStyle, nameWidth),
+		desc) + badge + m.styles.MutedText.Render(cells)
 
-		// Apply highlighting if this is the current selection (same as category items)
-		if appIndex == m.searchSelection {
-			// Use a more subtle highlight - not bold, just slightly brighter
-			highlightStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("248")) // Subtle gray-white for dim highlight
+	// Right-align source in a fixed-width column
+	source := result.Source
+	if len(source) > sourceWidth {
+		source = truncate(source, sourceWidth)
+	}
 
-			// Keep the indicator separate so it maintains its color
-			// but highlight the rest of the line
-			highlightedContent := fmt.Sprintf("%-*s  %-*s",
-				nameWidth, name,
-				descWidth, desc)
+	// Format source to be right-aligned within sourceWidth
+	sourceFormatted := fmt.Sprintf("%*s", sourceWidth, source)
 
-			highlightedMain := highlightStyle.Render(highlightedContent)
+	// Build complete line with consistent spacing
+	dimmedSource := m.styles.MutedText.Render(sourceFormatted) + " " + m.rootIndicator(result) + m.licenseBadge(result)
 
-			// Reconstruct the line with original indicator but highlighted content
-			line = fmt.Sprintf("%s %s%s%s",
-				indicator,
-				highlightedMain,
-				strings.Repeat(" ", gapBeforeSource),
-				dimmedSource)
-		}
+	// Fixed spacing between description and source
+	const gapBeforeSource = 2
 
-		appLines = append(appLines, line)
+	if !focused {
+		return mainContent + strings.Repeat(" ", gapBeforeSource) + dimmedSource
 	}
 
-	// Compose the complete search view (similar to category layout)
-	content := lipgloss.JoinVertical(
-		lipgloss.Left,
-		styledTitle,
-		"",
-		lipgloss.JoinVertical(lipgloss.Left, appLines...),
-	)
+	// Use a more subtle highlight - not bold, just slightly brighter
+	highlightStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("248")) // Subtle gray-white for dim highlight
 
-	// Wrap in a box similar to the details panel for consistency
-	// This ensures the search results have the same visual treatment
-	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
-		Padding(1).                         // Same padding as details panel
-		MaxWidth(categoryContentWidth + 4). // Content width + padding + borders
-		Render(content)
+	// Keep the indicator separate so it maintains its color
+	// but highlight the rest of the line
+	highlightedMain := highlightMatches(name, positions, highlightStyle, matchStyle, nameWidth) +
+		highlightStyle.Render("  "+desc) + badge + highlightStyle.Render(cells)
+
+	// Reconstruct the line with original indicator but highlighted content
+	return fmt.Sprintf("%s %s%s%s",
+		indicator,
+		highlightedMain,
+		strings.Repeat(" ", gapBeforeSource),
+		dimmedSource)
 }
 
 // passesInstallStatusFilter checks if app passes the installation status filter.
@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusCheckCommandUsesInjectedChecker(t *testing.T) {
+	t.Parallel()
+
+	checker := platform.NewMockInstallationChecker("git")
+
+	msg := NewStatusCheckCommand(context.Background(), "git", checker)()
+	update, ok := msg.(StatusUpdateMsg)
+	require.True(t, ok)
+	assert.True(t, update.Installed)
+
+	msg = NewStatusCheckCommand(context.Background(), "zed", checker)()
+	update, ok = msg.(StatusUpdateMsg)
+	require.True(t, ok)
+	assert.False(t, update.Installed)
+}
+
+func TestFetchAppVersionUsesInjectedResolver(t *testing.T) {
+	t.Parallel()
+
+	resolver := platform.NewMockVersionResolver(map[string]string{
+		"git":     "2.43.0",
+		"verbose": "1.2.3-very-long-build-identifier",
+	})
+
+	msg := fetchAppVersion(resolver, "git", "git", "apt")()
+	update, ok := msg.(VersionUpdateMsg)
+	require.True(t, ok)
+	assert.Equal(t, "git", update.AppKey)
+	assert.Equal(t, "2.43.0", update.Version)
+
+	msg = fetchAppVersion(resolver, "verbose", "verbose", "mise")()
+	update, ok = msg.(VersionUpdateMsg)
+	require.True(t, ok)
+	assert.Equal(t, "1.2.3-very-l...", update.Version, "long versions are truncated for display")
+
+	msg = fetchAppVersion(resolver, "missing", "missing", "apt")()
+	update, ok = msg.(VersionUpdateMsg)
+	require.True(t, ok)
+	assert.Empty(t, update.Version)
+}
+
+func TestInstallSkipsPasswordForUserScopeSelections(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 100, 40)
+	model.categories[0].apps[0].RequiresRoot = true // git via apt
+
+	model.selected["node"] = StateInstall
+
+	nav, ok := model.handleInstallationKeys(tea.KeyMsg{Type: tea.KeyEnter})().(NavigateMsg)
+	require.True(t, ok)
+	assert.Equal(t, ProgressScreen, nav.Screen)
+
+	model.selected["git"] = StateInstall
+
+	nav, ok = model.handleInstallationKeys(tea.KeyMsg{Type: tea.KeyEnter})().(NavigateMsg)
+	require.True(t, ok)
+	assert.Equal(t, PasswordScreen, nav.Screen)
+}
+
+func TestCollapsedCategoriesAreSkippedByNavigation(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 100, 40)
+	model.ready = true
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	assert.True(t, model.categories[0].collapsed)
+	assert.Contains(t, model.renderAllCategories(), "▸ development (7)")
+	assert.NotContains(t, model.renderAllCategories(), "Version control")
+	assert.Equal(t, 2, model.calculateActualSelectionLine(), "a collapsed category is selected by its title")
+
+	_, ok := model.focusedAppKey()
+	assert.False(t, ok, "no app is focused in a collapsed category")
+
+	model.navigateDown()
+	assert.Equal(t, 1, model.currentCat)
+	assert.Equal(t, 0, model.categories[1].currentApp)
+
+	model.navigateUp()
+	assert.Equal(t, 0, model.currentCat)
+
+	model.toggleAllCategories()
+	assert.True(t, model.categories[1].collapsed, "Z collapses all while any is expanded")
+
+	model.toggleAllCategories()
+	assert.False(t, model.categories[0].collapsed)
+	assert.False(t, model.categories[1].collapsed)
+}
+
+func TestMovingCategoriesPersistsOrder(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	model := NewTestAppsModel(styles.New(), 100, 40)
+	model.SetPreferencesPath(path)
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(">")})
+	assert.Equal(t, "browsers", model.categories[0].name)
+	assert.Equal(t, 1, model.currentCat, "the cursor follows the moved category")
+
+	prefs, err := config.LoadPreferences(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"browsers", "development"}, prefs.Categories.Order)
+
+	categories := []category{{name: "Browsers"}, {name: "Development"}, {name: "Games"}}
+	orderCategories(categories, []string{"games", "development"})
+	assert.Equal(t, "Games", categories[0].name)
+	assert.Equal(t, "Development", categories[1].name)
+	assert.Equal(t, "Browsers", categories[2].name)
+}
+
+func TestCategoryHeightMatchesRenderedCategory(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 100, 40)
+
+	for _, collapsed := range []bool{false, true} {
+		cat := model.categories[0]
+		cat.collapsed = collapsed
+
+		assert.Equal(t, lipgloss.Height(model.renderCategory(cat, true)), categoryHeight(cat))
+	}
+}
+
+func TestRenderAllCategoriesOnlyRendersVisibleLines(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 100, 40)
+	model.ready = true
+	model.viewport.Height = 20
+
+	large := make([]app, 5000)
+	for i := range large {
+		large[i] = app{Key: fmt.Sprintf("tool-%d", i), Name: fmt.Sprintf("Tool %d", i), Description: "Generated", Source: "apt"}
+	}
+
+	model.categories = append(model.categories, category{name: "generated", apps: large})
+
+	total := 0
+	for _, cat := range model.categories {
+		total += categoryHeight(cat)
+	}
+
+	lines := strings.Split(model.renderAllCategories(), "\n")
+	require.Len(t, lines, total, "placeholders keep the full line count")
+	assert.Contains(t, lines[4], "Git")
+	assert.Empty(t, lines[total-10], "lines far below the viewport are not rendered")
+
+	// Jumping to the end scrolls past the rendered window and re-renders there
+	model.currentCat = len(model.categories) - 1
+	model.categories[model.currentCat].currentApp = len(large) - 1
+	model.viewport.SetContent(strings.Join(lines, "\n"))
+	model.ensureSelectionVisible()
+	assert.False(t, model.renderedWindow.covers(model.viewport.YOffset, model.viewport.Height))
+
+	lines = strings.Split(model.renderAllCategories(), "\n")
+	require.Len(t, lines, total)
+	assert.Contains(t, lines[model.calculateActualSelectionLine()], "Tool 4999")
+	assert.Empty(t, lines[4], "lines far above the viewport are not rendered")
+}
+
+// outdatedChecker is a status checker that also reports available updates.
+type outdatedChecker struct {
+	domain.InstallationChecker
+
+	updates map[string]domain.OutdatedPackage
+}
+
+func (c outdatedChecker) Outdated(context.Context) map[string]domain.OutdatedPackage {
+	return c.updates
+}
+
+func TestUpdateIndicators(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 160, 40)
+	model.ctx = context.Background()
+	assert.Nil(t, model.checkUpdates(), "the mock checker can't tell")
+
+	model.statusChecker = outdatedChecker{
+		InstallationChecker: model.statusChecker,
+		updates: map[string]domain.OutdatedPackage{
+			"vscode": {Method: domain.MethodDEB, Name: "code", Installed: "1.94.0", Available: "1.95.2"},
+			"git":    {Method: domain.MethodAPT, Name: "git", Available: "1:2.43.0-1ubuntu7.2"},
+		},
+	}
+
+	model.Update(model.checkUpdates()())
+
+	lines := model.renderAppLines(model.categories[0], false, model.columns.nameWidth, model.columns.descriptionWidth(model.width))
+	assert.Contains(t, lines[1], "↑ 1.95.2")
+	assert.NotContains(t, lines[0], "↑", "git isn't installed")
+	assert.Equal(t, lipgloss.Width(lines[2]), lipgloss.Width(lines[1]), "the badge stays within the description column")
+	assert.Contains(t, model.renderCleanFooter(), "Update")
+
+	// Only installed apps can be marked for update
+	typeKeys(model, "u")
+	assert.Empty(t, model.selected)
+	assert.Contains(t, model.renderCleanHeader(), "Only installed apps can be updated")
+
+	typeKeys(model, "ju")
+	assert.Equal(t, StateUpdate, model.selected["vscode"])
+	assert.Contains(t, model.renderReviewItem(model.getSelectedOperations()[0], false, StateNone, nil), "↑ VS Code")
+
+	typeKeys(model, "u")
+	assert.Empty(t, model.selected)
+
+	typeKeys(model, "u")
+	model.handleCompletedOperations(model.getSelectedOperations())
+	assert.NotContains(t, model.updates, "vscode", "the update is done")
+}
+
+func TestReinstallSelection(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+
+	typeKeys(model, "r")
+	assert.Empty(t, model.selected)
+	assert.Contains(t, model.renderCleanHeader(), "Only installed apps can be reinstalled")
+
+	typeKeys(model, "jr")
+	assert.Equal(t, StateReinstall, model.selected["vscode"])
+
+	// Reinstall and update replace each other
+	typeKeys(model, "u")
+	assert.Equal(t, StateUpdate, model.selected["vscode"])
+
+	typeKeys(model, "r")
+	assert.Equal(t, StateReinstall, model.selected["vscode"])
+
+	operations := model.getSelectedOperations()
+	assert.Equal(t, []SelectedOperation{{AppKey: "vscode", Operation: StateReinstall, AppName: "VS Code"}}, operations)
+	assert.Contains(t, model.renderReviewItem(operations[0], false, StateNone, nil), "↻ VS Code")
+
+	progress := NewProgressWithOperations(context.Background(), styles.New(), operations)
+	assert.Equal(t, OperationReinstall, progress.tasks[0].Operation)
+	assert.Equal(t, "Karei » Reinstalling Applications", progress.getHeaderLocation())
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janderssonse/karei/internal/stringutil"
+)
+
+// categoryInstall is a whole category waiting to be queued for install once
+// its summary is confirmed.
+type categoryInstall struct {
+	category string
+	targets  []app
+	skipped  []string // Conflicts explaining the apps left out
+}
+
+// downloadEstimator is implemented by status checkers that can estimate the
+// download of installing apps, such as the package manager.
+type downloadEstimator interface {
+	EstimateDownload(appKeys []string) int64
+}
+
+// startCategoryInstall summarises the current category's apps that aren't
+// installed yet, for confirmation before they are queued.
+func (m *AppsModel) startCategoryInstall() {
+	if m.currentCat >= len(m.categories) {
+		return
+	}
+
+	cat := m.categories[m.currentCat]
+	pending := &categoryInstall{category: cat.name}
+
+	for _, candidate := range cat.apps {
+		if candidate.Installed {
+			continue
+		}
+
+		if conflict := m.installConflict(candidate.Key); conflict != "" && m.selected[candidate.Key] != StateInstall {
+			pending.skipped = append(pending.skipped, conflict)
+
+			continue
+		}
+
+		pending.targets = append(pending.targets, candidate)
+	}
+
+	if len(pending.targets) == 0 {
+		m.searchNotice = "Everything in " + cat.name + " is already installed"
+
+		return
+	}
+
+	m.categoryInstall = pending
+}
+
+// handleCategoryInstallKeys answers the category install summary. Every key
+// is consumed until it is answered.
+func (m *AppsModel) handleCategoryInstallKeys(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "y", KeyEnter:
+		m.queueCategoryInstall()
+	case "n", KeyEsc:
+		m.searchNotice = "Install of " + m.categoryInstall.category + " cancelled"
+		m.categoryInstall = nil
+	}
+}
+
+// queueCategoryInstall marks the summarised apps and their dependencies for
+// install. Each app is recorded separately, so undo takes them back one by one.
+func (m *AppsModel) queueCategoryInstall() {
+	pending := m.categoryInstall
+	m.categoryInstall = nil
+
+	for _, target := range pending.targets {
+		if m.selected[target.Key] == StateInstall {
+			continue
+		}
+
+		m.recordSelection(target.Key)
+		m.selected[target.Key] = StateInstall
+		m.selectDependencies(target.Key)
+	}
+
+	m.searchNotice = fmt.Sprintf("Queued %d apps from %s, press Enter to install", len(pending.targets), pending.category)
+	m.contentNeedsUpdate = true
+}
+
+// renderCategoryInstall renders the summary of the apps a category install
+// would queue: how many, roughly how much they download and which need sudo.
+func (m *AppsModel) renderCategoryInstall() string {
+	pending := m.categoryInstall
+
+	keys := make([]string, 0, len(pending.targets))
+
+	var privileged []string
+
+	for _, target := range pending.targets {
+		keys = append(keys, target.Key)
+
+		if target.RequiresRoot {
+			privileged = append(privileged, target.Name)
+		}
+	}
+
+	accent := lipgloss.NewStyle().Foreground(m.styles.Primary)
+	lines := []string{
+		accent.Bold(true).Render(fmt.Sprintf("Install %d apps from %s?", len(pending.targets), pending.category)),
+		"",
+	}
+
+	if estimator, ok := m.statusChecker.(downloadEstimator); ok {
+		if size := estimator.EstimateDownload(keys); size > 0 {
+			lines = append(lines, fmt.Sprintf("About %s to download, dependencies included", stringutil.FormatBytes(size)))
+		}
+	}
+
+	if len(privileged) > 0 {
+		lines = append(lines, "", m.styles.WarningText.Render(StatusRequiresRoot+" Need administrator privileges:"))
+
+		for _, name := range privileged {
+			lines = append(lines, m.styles.WarningText.Render("  - "+name))
+		}
+	}
+
+	if len(pending.skipped) > 0 {
+		lines = append(lines, "")
+
+		for _, conflict := range pending.skipped {
+			lines = append(lines, m.styles.MutedText.Render("Skipped: "+conflict))
+		}
+	}
+
+	lines = append(lines, "", m.styles.MutedText.Render("y Queue   n Cancel"))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Primary).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box,
+		lipgloss.WithWhitespaceBackground(lipgloss.Color("235")))
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// estimatingChecker is a status checker that also estimates downloads.
+type estimatingChecker struct {
+	domain.InstallationChecker
+
+	estimated []string
+}
+
+func (c *estimatingChecker) EstimateDownload(appKeys []string) int64 {
+	c.estimated = appKeys
+
+	return 250_000_000
+}
+
+func TestCategoryInstallSummarisesBeforeQueueing(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+	checker := &estimatingChecker{InstallationChecker: model.statusChecker}
+	model.statusChecker = checker
+	model.currentCat = 1
+	model.categories[1].apps[1].RequiresRoot = true
+
+	typeKeys(model, "I")
+	require.NotNil(t, model.categoryInstall)
+
+	view := model.renderCategoryInstall()
+	assert.Contains(t, view, "Install 2 apps from browsers?")
+	assert.Contains(t, view, "About 250 MB to download")
+	assert.Contains(t, view, "- Chrome")
+	assert.NotContains(t, view, "- Edge", "only apps needing sudo are listed")
+	assert.Equal(t, []string{"chrome", "edge"}, checker.estimated, "installed apps are left out")
+
+	typeKeys(model, "j")
+	assert.Zero(t, model.categories[1].currentApp, "keys don't reach the list underneath")
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, model.categoryInstall)
+	assert.Empty(t, model.selected)
+	assert.Equal(t, "Install of browsers cancelled", model.searchNotice)
+
+	typeKeys(model, "Iy")
+	assert.Equal(t, map[string]SelectionState{"chrome": StateInstall, "edge": StateInstall}, model.selected)
+	assert.Equal(t, "Queued 2 apps from browsers, press Enter to install", model.searchNotice)
+
+	model.undoSelection()
+	assert.Equal(t, map[string]SelectionState{"chrome": StateInstall}, model.selected, "undo takes the apps back one by one")
+}
+
+func TestCategoryInstallWithNothingLeft(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+	model.categories[1].apps = model.categories[1].apps[:1]
+	model.currentCat = 1
+
+	typeKeys(model, "I")
+	assert.Nil(t, model.categoryInstall)
+	assert.Equal(t, "Everything in browsers is already installed", model.searchNotice)
+}
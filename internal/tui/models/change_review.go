@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+)
+
+// Layout of the change review: the lines around the list (title, blank
+// line and hints) and the width until the terminal reports its size.
+const (
+	changeReviewChrome = 4
+	changeReviewWidth  = 80
+)
+
+// ChangeReviewModel lets the user pick which planned changes to make before
+// karei apply makes them, one at a time like git add -p. Every change starts
+// kept: y and n keep or skip the change under the cursor and move on, Space
+// toggles it, a and s keep or skip them all. Enter applies the kept changes;
+// q or Esc cancels.
+type ChangeReviewModel struct {
+	styles    *styles.Styles
+	list      *SelectableList[domain.PlannedChange]
+	states    map[string]SelectionState // StateInstall marks a kept change
+	changes   []domain.PlannedChange
+	done      bool
+	cancelled bool
+}
+
+// NewChangeReview creates the review of changes, all kept.
+func NewChangeReview(styleConfig *styles.Styles, changes []domain.PlannedChange) *ChangeReviewModel {
+	m := &ChangeReviewModel{
+		styles:  styleConfig,
+		states:  make(map[string]SelectionState),
+		changes: changes,
+	}
+
+	m.list = NewSelectableList(changes, changeKey, nil, m.renderChange)
+	m.list.SetSize(changeReviewWidth, len(changes))
+	m.list.SetStates(m.states)
+	m.setAll(StateInstall)
+
+	return m
+}
+
+// Kept returns the changes to make, in plan order.
+func (m *ChangeReviewModel) Kept() []domain.PlannedChange {
+	return m.list.Marked(StateInstall)
+}
+
+// Cancelled reports whether the review was left without applying.
+func (m *ChangeReviewModel) Cancelled() bool {
+	return m.cancelled
+}
+
+// Init implements tea.Model.
+func (m *ChangeReviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m *ChangeReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		height := len(m.changes)
+		if msg.Height > changeReviewChrome {
+			height = min(height, msg.Height-changeReviewChrome)
+		}
+
+		m.list.SetSize(msg.Width, height)
+	case tea.KeyMsg:
+		return m, m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+// handleKey keeps, skips or toggles changes and ends the review.
+func (m *ChangeReviewModel) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y":
+		m.list.SetFocusedState(StateInstall)
+		m.list.MoveCursor(1)
+	case "n":
+		m.list.SetFocusedState(StateNone)
+		m.list.MoveCursor(1)
+	case " ":
+		m.list.ToggleFocusedState(StateInstall)
+	case "a":
+		m.setAll(StateInstall)
+	case "s":
+		m.setAll(StateNone)
+	case KeyEnter:
+		m.done = true
+
+		return tea.Quit
+	case "q", KeyEsc, "ctrl+c":
+		m.cancelled = true
+
+		return tea.Quit
+	default:
+		m.list.HandleKey(msg)
+	}
+
+	return nil
+}
+
+// setAll keeps or skips every change.
+func (m *ChangeReviewModel) setAll(state SelectionState) {
+	for _, change := range m.changes {
+		if state == StateNone {
+			delete(m.states, changeKey(change))
+		} else {
+			m.states[changeKey(change)] = state
+		}
+	}
+
+	m.list.SetStates(m.states)
+}
+
+// View implements tea.Model.
+func (m *ChangeReviewModel) View() string {
+	if m.done || m.cancelled {
+		return ""
+	}
+
+	title := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Primary).
+		Render(fmt.Sprintf("Changes to apply (%d of %d kept)", len(m.Kept()), len(m.changes)))
+	hints := m.styles.MutedText.Render("y Keep   n Skip   Space Toggle   a Keep all   s Skip all   Enter Apply   q Cancel")
+
+	return strings.Join([]string{title, "", m.list.View(), hints}, "\n")
+}
+
+// renderChange renders one change: kept or skipped, with a sign and color
+// per kind, as in a diff.
+func (m *ChangeReviewModel) renderChange(change domain.PlannedChange, focused bool, state SelectionState, _ []int) string {
+	sign, color := "+", m.styles.Success
+
+	switch change.Kind {
+	case domain.ChangeUpgrade:
+		sign, color = "↑", m.styles.Warning
+	case domain.ChangeConfig:
+		sign, color = "~", m.styles.Info
+	}
+
+	prefix := "  "
+	if focused {
+		prefix = "❯ "
+	}
+
+	box := "[ ]"
+	style := lipgloss.NewStyle().Foreground(m.styles.Muted).Strikethrough(true)
+
+	if state == StateInstall {
+		box = "[x]"
+		style = lipgloss.NewStyle().Foreground(color)
+	}
+
+	if focused {
+		style = style.Bold(true)
+	}
+
+	return prefix + box + " " + style.Render(sign+" "+change.Describe())
+}
+
+// changeKey identifies a change in the selection states.
+func changeKey(change domain.PlannedChange) string {
+	return string(change.Kind) + ":" + change.Target
+}
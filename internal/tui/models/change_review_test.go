@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeReviewKeepsAndSkips(t *testing.T) {
+	t.Parallel()
+
+	changes := []domain.PlannedChange{
+		{Kind: domain.ChangeInstall, Target: "git"},
+		{Kind: domain.ChangeUpgrade, Target: "node", From: "18.2.0", To: ">=20"},
+		{Kind: domain.ChangeConfig, Target: "theme", From: "nord", To: "tokyo-night"},
+	}
+
+	model := NewChangeReview(styles.New(), changes)
+	model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	assert.Equal(t, changes, model.Kept(), "every change starts kept")
+
+	model.Update(runeKey('n'))
+	model.Update(runeKey('y'))
+	model.Update(runeKey(' '))
+	assert.Equal(t, []domain.PlannedChange{changes[1]}, model.Kept())
+	assert.Contains(t, model.View(), "1 of 3 kept")
+
+	model.Update(runeKey('a'))
+	assert.Len(t, model.Kept(), 3)
+
+	model.Update(runeKey('s'))
+	assert.Empty(t, model.Kept())
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.False(t, model.Cancelled())
+}
+
+func TestChangeReviewCancel(t *testing.T) {
+	t.Parallel()
+
+	model := NewChangeReview(styles.New(), []domain.PlannedChange{{Kind: domain.ChangeInstall, Target: "git"}})
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.NotNil(t, cmd)
+	assert.True(t, model.Cancelled())
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// Optional columns of the app list, named as in the [columns] preferences.
+const (
+	columnVersion = "version"
+	columnSize    = "size"
+	columnGroup   = "group"
+	columnUpdated = "last-updated"
+)
+
+// App list column widths. The description grows into whatever the terminal
+// leaves unless a width is configured, but never below its default.
+const (
+	defaultNameWidth = 22
+	defaultDescWidth = 42
+	sourceWidth      = 12 // Wide enough for "github-java" and others
+	columnGap        = 2
+	lineChromeWidth  = 4 // Indicator and its space, gap before the source
+	boxChromeWidth   = 8 // Category border and padding, space before the lock and license badges
+	updatedLayout    = "2006-01-02"
+)
+
+// optionalColumnWidths are the widths of the optional columns.
+var optionalColumnWidths = map[string]int{ //nolint:gochecknoglobals
+	columnVersion: 12,
+	columnSize:    10, // "100-500 MB"
+	columnGroup:   14,
+	columnUpdated: len(updatedLayout),
+}
+
+// columnLayout is the arrangement of an app line: indicator, name,
+// description, the optional columns and the source.
+type columnLayout struct {
+	nameWidth int
+	descWidth int // Fixed description width, or 0 to fill the terminal
+	optional  []string
+}
+
+// newColumnLayout creates the layout from the [columns] preferences,
+// ignoring unknown column names and repeated columns.
+func newColumnLayout(prefs config.ColumnPreferences) columnLayout {
+	layout := columnLayout{nameWidth: defaultNameWidth, descWidth: max(prefs.DescriptionWidth, 0)}
+
+	if prefs.NameWidth > 0 {
+		layout.nameWidth = prefs.NameWidth
+	}
+
+	for _, name := range prefs.Show {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, known := optionalColumnWidths[name]; known && !slices.Contains(layout.optional, name) {
+			layout.optional = append(layout.optional, name)
+		}
+	}
+
+	return layout
+}
+
+// optionalWidth returns the width of the optional columns including their gaps.
+func (l columnLayout) optionalWidth() int {
+	width := 0
+	for _, column := range l.optional {
+		width += columnGap + optionalColumnWidths[column]
+	}
+
+	return width
+}
+
+// descriptionWidth returns the description width for a terminal of the given width.
+func (l columnLayout) descriptionWidth(terminalWidth int) int {
+	if l.descWidth > 0 {
+		return l.descWidth
+	}
+
+	fill := terminalWidth - boxChromeWidth - lineChromeWidth - l.nameWidth - columnGap - l.optionalWidth() - sourceWidth
+
+	return max(fill, defaultDescWidth)
+}
+
+// contentWidth returns the width of an app line without the badges after
+// the source, which the details panel and search results match.
+func (l columnLayout) contentWidth(terminalWidth int) int {
+	return lineChromeWidth + l.nameWidth + columnGap + l.descriptionWidth(terminalWidth) + l.optionalWidth() + sourceWidth
+}
+
+// cells returns the optional columns of an app, each led by a gap.
+func (l columnLayout) cells(app app) string {
+	var builder strings.Builder
+
+	for _, column := range l.optional {
+		width := optionalColumnWidths[column]
+		_, _ = fmt.Fprintf(&builder, "%s%-*s", strings.Repeat(" ", columnGap), width, truncate(columnValue(app, column), width))
+	}
+
+	return builder.String()
+}
+
+// columnValue returns what an optional column shows for an app.
+func columnValue(app app, column string) string {
+	switch column {
+	case columnVersion:
+		return app.Version
+	case columnSize:
+		return app.Size
+	case columnGroup:
+		return app.Group
+	case columnUpdated:
+		if app.Updated.IsZero() {
+			return ""
+		}
+
+		return app.Updated.Format(updatedLayout)
+	default:
+		return ""
+	}
+}
+
+// installTimes returns when karei last installed each catalog app.
+func installTimes() map[string]time.Time {
+	records, err := application.NewInstallRecords(platform.NewFileManager(false), xdg.InstallRecordsFile()).Load()
+	if err != nil {
+		return nil
+	}
+
+	times := make(map[string]time.Time, len(records))
+	for key, record := range records {
+		times[key] = record.Installed
+	}
+
+	return times
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/tui/styles"
+)
+
+func TestColumnLayoutFromPreferences(t *testing.T) {
+	layout := newColumnLayout(config.ColumnPreferences{Show: []string{"Version", "bogus", "last-updated", "version"}, NameWidth: 30})
+
+	assert.Equal(t, []string{columnVersion, columnUpdated}, layout.optional)
+	assert.Equal(t, 30, layout.nameWidth)
+
+	// The default layout keeps the classic widths on narrow terminals
+	classic := newColumnLayout(config.ColumnPreferences{})
+	assert.Equal(t, defaultDescWidth, classic.descriptionWidth(80))
+	assert.Equal(t, 82, classic.contentWidth(80))
+
+	// and gives wide terminals' space to the description
+	assert.Equal(t, 152, classic.descriptionWidth(200))
+
+	fixed := newColumnLayout(config.ColumnPreferences{DescriptionWidth: 50})
+	assert.Equal(t, 50, fixed.descriptionWidth(200))
+}
+
+func TestColumnLayoutCells(t *testing.T) {
+	layout := newColumnLayout(config.ColumnPreferences{Show: []string{"size", "last-updated", "group"}})
+	updated := time.Date(2026, 9, 30, 12, 0, 0, 0, time.UTC)
+
+	cells := layout.cells(app{Size: "5-50 MB", Group: "Development Tools", Updated: updated})
+
+	assert.Equal(t, "  5-50 MB     2026-09-30  Development...", cells)
+	assert.Equal(t, layout.optionalWidth(), len(cells))
+}
+
+func TestAppLinesFillTerminalWidth(t *testing.T) {
+	for _, width := range []int{120, 200} {
+		model := NewTestAppsModel(styles.New(), width, 40)
+		model.columns = newColumnLayout(config.ColumnPreferences{Show: []string{"version", "group"}})
+
+		lines := strings.Split(model.renderAllCategories(), "\n")
+
+		widest := 0
+		for _, line := range lines {
+			widest = max(widest, lipgloss.Width(line))
+		}
+
+		assert.Equal(t, width, widest, "app lines span the terminal")
+	}
+}
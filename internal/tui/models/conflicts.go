@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"github.com/janderssonse/karei/internal/apps"
+)
+
+// installConflict explains why appKey can't be marked for install, or
+// returns "" when nothing conflicts. A conflicting app blocks the selection
+// while it is selected for install, or installed and not marked for removal.
+func (m *AppsModel) installConflict(appKey string) string {
+	name := m.appName(appKey)
+
+	for _, other := range apps.Conflicting(appKey) {
+		switch state := m.selected[other]; {
+		case state == StateInstall:
+			return name + " conflicts with " + m.appName(other) + ", which is already selected"
+		case state != StateUninstall && m.appLookup[other] != nil && m.appLookup[other].Installed:
+			return name + " conflicts with the installed " + m.appName(other) + ", mark it for uninstall (d) first"
+		}
+	}
+
+	return ""
+}
+
+// appName returns the display name of a catalog app, falling back to its key.
+func (m *AppsModel) appName(appKey string) string {
+	if found, ok := m.appLookup[appKey]; ok {
+		return found.Name
+	}
+
+	return appKey
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallConflict(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+	model.appLookup = map[string]*app{
+		"docker.io":             {Key: "docker.io", Name: "Docker"},
+		"podman-docker":         {Key: "podman-docker", Name: "Podman (docker CLI)", Installed: true},
+		"tlp":                   {Key: "tlp", Name: "TLP"},
+		"power-profiles-daemon": {Key: "power-profiles-daemon", Name: "Power Profiles Daemon"},
+	}
+
+	assert.Equal(t, "Docker conflicts with the installed Podman (docker CLI), mark it for uninstall (d) first",
+		model.installConflict("docker.io"))
+
+	model.selected["podman-docker"] = StateUninstall
+	assert.Empty(t, model.installConflict("docker.io"), "replacing an installed app is allowed")
+
+	model.selected["tlp"] = StateInstall
+	assert.Equal(t, "Power Profiles Daemon conflicts with TLP, which is already selected",
+		model.installConflict("power-profiles-daemon"))
+	assert.Empty(t, model.installConflict("rust"))
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"strings"
+
+	"github.com/janderssonse/karei/internal/apps"
+)
+
+// selectDependencies marks the catalog dependencies of appKey for install
+// when they are neither installed nor already selected. Each one is recorded
+// separately, so undo removes them before the app itself.
+func (m *AppsModel) selectDependencies(appKey string) {
+	var added []string
+
+	for _, dependency := range apps.WithDependencies([]string{appKey}) {
+		found, ok := m.appLookup[dependency]
+		if dependency == appKey || !ok || found.Installed || m.selected[dependency] == StateInstall {
+			continue
+		}
+
+		m.recordSelection(dependency)
+		m.selected[dependency] = StateInstall
+		added = append(added, found.Name)
+	}
+
+	if len(added) == 0 {
+		return
+	}
+
+	m.searchNotice = "Also selected " + strings.Join(added, ", ") + ", needed by " + m.appName(appKey)
+	m.contentNeedsUpdate = true
+}
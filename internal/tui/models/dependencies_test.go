@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectDependencies(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+	model.appLookup = map[string]*app{
+		"rust":        {Key: "rust", Name: "Rust"},
+		"cargo-audit": {Key: "cargo-audit", Name: "cargo-audit"},
+		"python":      {Key: "python", Name: "Python", Installed: true},
+		"pytest":      {Key: "pytest", Name: "pytest"},
+	}
+
+	model.selected["cargo-audit"] = StateInstall
+	model.selectDependencies("cargo-audit")
+	assert.Equal(t, StateInstall, model.selected["rust"])
+	assert.Contains(t, model.renderCleanHeader(), "Also selected Rust, needed by cargo-audit")
+
+	model.selectDependencies("pytest")
+	assert.NotContains(t, model.selected, "python", "installed dependencies are left alone")
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	assert.NotContains(t, model.selected, "rust", "undo drops the dependency on its own")
+}
@@ -252,6 +252,14 @@ func getUninstallationSuggestions(appName string) []string {
 			"Check aqua installations: 'aqua list'",
 			"Check uninstallation logs: 'karei logs uninstall'",
 		}
+	case domain.MethodNpm:
+		return []string{
+			"Check if the application is currently running and close it",
+			"Verify npm is available: 'npm --version'",
+			"Try uninstalling manually: 'npm uninstall -g --prefix ~/.local/share/karei/npm " + appName + "'",
+			"Check npm installations: 'npm ls -g --prefix ~/.local/share/karei/npm'",
+			"Check uninstallation logs: 'karei logs uninstall'",
+		}
 	case domain.MethodGitHub, domain.MethodScript:
 		return []string{
 			"Check if the application is currently running and close it",
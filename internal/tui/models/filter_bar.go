@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Sort options offered by the filter bar, in cycling order.
+var sortOptions = []string{"Name", "Status", "Type", "Category"} //nolint:gochecknoglobals
+
+// filterFacet groups chips that share one filter setting.
+type filterFacet int
+
+// Facets of the filter bar.
+const (
+	facetMethod filterFacet = iota
+	facetStatus
+	facetSort
+)
+
+// filterChip is one toggle in the filter bar.
+type filterChip struct {
+	label string
+	facet filterFacet
+	value string
+}
+
+// filterChips are the chips in display order. Chips of the same facet are
+// exclusive: toggling an active chip resets its facet to All.
+var filterChips = []filterChip{ //nolint:gochecknoglobals
+	{label: MethodAPTDisplay, facet: facetMethod, value: MethodAPTDisplay},
+	{label: MethodFlatpak, facet: facetMethod, value: MethodFlatpak},
+	{label: MethodSnap, facet: facetMethod, value: MethodSnap},
+	{label: MethodMise, facet: facetMethod, value: MethodMise},
+	{label: FilterInstalled, facet: facetStatus, value: FilterInstalled},
+	{label: FilterNotInstalled, facet: facetStatus, value: FilterNotInstalled},
+	{label: "Sort", facet: facetSort},
+}
+
+// handleFilterKeys drives the filter bar while it has focus. Every key is
+// consumed so typing doesn't leak into the search query.
+func (m *AppsModel) handleFilterKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+f", KeyEsc:
+		m.filterFocus = false
+	case "left", "h":
+		m.filterCursor = (m.filterCursor + len(filterChips) - 1) % len(filterChips)
+	case "right", "l", "tab":
+		m.filterCursor = (m.filterCursor + 1) % len(filterChips)
+	case " ", KeyEnter:
+		update := m.toggleFilterChip(filterChips[m.filterCursor])
+
+		return func() tea.Msg { return update }
+	}
+
+	return nil
+}
+
+// toggleFilterChip returns the filter state after toggling chip.
+func (m *AppsModel) toggleFilterChip(chip filterChip) FilterUpdateMsg {
+	update := FilterUpdateMsg{
+		InstallStatus: m.installStatusFilter,
+		PackageType:   m.packageTypeFilter,
+		SortOption:    m.sortOption,
+	}
+
+	switch chip.facet {
+	case facetMethod:
+		update.PackageType = toggledFilter(update.PackageType, chip.value)
+	case facetStatus:
+		update.InstallStatus = toggledFilter(update.InstallStatus, chip.value)
+	case facetSort:
+		next := (slices.Index(sortOptions, update.SortOption) + 1) % len(sortOptions)
+		update.SortOption = sortOptions[next]
+	}
+
+	return update
+}
+
+// toggledFilter selects value, or resets to All when it is already selected.
+func toggledFilter(current, value string) string {
+	if current == value {
+		return FilterAll
+	}
+
+	return value
+}
+
+// chipActive reports whether chip matches the current filters.
+func (m *AppsModel) chipActive(chip filterChip) bool {
+	switch chip.facet {
+	case facetMethod:
+		return m.packageTypeFilter == chip.value
+	case facetStatus:
+		return m.installStatusFilter == chip.value
+	default:
+		return m.sortOption != sortOptions[0]
+	}
+}
+
+// activeFilterSummary describes the filters that differ from the defaults.
+func (m *AppsModel) activeFilterSummary() string {
+	var parts []string
+
+	if m.packageTypeFilter != "" && m.packageTypeFilter != FilterAll {
+		parts = append(parts, m.packageTypeFilter)
+	}
+
+	if m.installStatusFilter != "" && m.installStatusFilter != FilterAll {
+		parts = append(parts, m.installStatusFilter)
+	}
+
+	if m.sortOption != "" && m.sortOption != sortOptions[0] {
+		parts = append(parts, "by "+m.sortOption)
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// renderFilterBar renders the chips, with the focused one underlined and the
+// active ones in the primary color.
+func (m *AppsModel) renderFilterBar() string {
+	chips := make([]string, 0, len(filterChips))
+
+	for i, chip := range filterChips {
+		label := chip.label
+		if chip.facet == facetSort {
+			label = "Sort: " + m.sortOption
+		}
+
+		style := lipgloss.NewStyle().Foreground(m.styles.Muted)
+		if m.chipActive(chip) {
+			style = lipgloss.NewStyle().Bold(true).Foreground(m.styles.Primary)
+		}
+
+		if i == m.filterCursor {
+			style = style.Underline(true)
+		}
+
+		chips = append(chips, style.Render("["+label+"]"))
+	}
+
+	hint := lipgloss.NewStyle().Foreground(m.styles.Muted).Render("←/→ Move  Space Toggle  Esc Done")
+
+	return strings.Join(chips, " ") + "   " + hint
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterBarTogglesFacets(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	require.Len(t, model.results.VisibleItems(), 10)
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyCtrlF})
+	require.True(t, model.filterFocus)
+
+	// Typing moves between chips instead of editing the query
+	for range 4 {
+		model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	}
+
+	assert.Empty(t, model.searchQuery)
+	assert.Contains(t, model.renderCleanFooter(), "[Installed]")
+
+	_, cmd := model.handleKeyMessage(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	require.NotNil(t, cmd)
+
+	update, ok := cmd().(FilterUpdateMsg)
+	require.True(t, ok)
+	assert.Equal(t, FilterInstalled, update.InstallStatus)
+	assert.Equal(t, FilterAll, update.PackageType)
+
+	model.Update(update)
+	assert.Len(t, model.results.VisibleItems(), 5, "only installed apps remain")
+	assert.Contains(t, model.renderSearchHeader(), "Installed")
+
+	// Toggling the active chip again resets the facet
+	update = model.toggleFilterChip(filterChips[4])
+	assert.Equal(t, FilterAll, update.InstallStatus)
+
+	update = model.toggleFilterChip(filterChips[len(filterChips)-1])
+	assert.Equal(t, "Status", update.SortOption, "the sort chip cycles options")
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, model.filterFocus)
+	assert.True(t, model.searchActive, "Esc leaves the filter bar before cancelling search")
+}
@@ -616,6 +616,7 @@ fi
 	// Create Glamour renderer with Tokyo Night style
 	renderer, err := glamour.NewTermRenderer(
 		glamour.WithAutoStyle(),
+		glamour.WithColorProfile(lipgloss.ColorProfile()), // Follows --color and NO_COLOR
 		glamour.WithWordWrap(80),
 	)
 	if err != nil {
@@ -160,6 +160,8 @@ func (h *HelpModal) getCommandsForScreen(screen string) []HelpModalSection {
 					{"j/k or ↑↓", "Navigate items"},
 					{"g/G", "Jump to first/last item"},
 					{"{/}", "Switch categories"},
+					{"z/Z", "Collapse/expand category/all"},
+					{"</>", "Move category up/down"},
 					{"H/L", "Switch screens (left/right)"},
 					{"J/K", "Page up/down"},
 				},
@@ -169,13 +171,22 @@ func (h *HelpModal) getCommandsForScreen(screen string) []HelpModalSection {
 				Commands: []HelpModalCommand{
 					{"Space", "Toggle selection"},
 					{"d", "Mark for uninstall"},
+					{"u", "Mark for update (↑ newer version)"},
+					{"r", "Mark for reinstall"},
+					{"I", "Mark the whole category for install"},
+					{"v", "Review pending operations"},
+					{"Ctrl+Z", "Undo last selection change"},
 				},
 			},
 			{
 				Title: "Actions",
 				Commands: []HelpModalCommand{
 					{"Enter", "Install/uninstall selected"},
+					{"o", "Open app details"},
 					{"/", "Search packages"},
+					{"Ctrl+F", "Filter search by method/status, sort"},
+					{"Ctrl+S", "Save search and filters"},
+					{"Ctrl+N", "Cycle saved searches"},
 				},
 			},
 			{
@@ -184,6 +195,7 @@ func (h *HelpModal) getCommandsForScreen(screen string) []HelpModalSection {
 					{"Esc", "Go back/cancel search"},
 					{"q", "Quit application"},
 					{"?", "Show this help"},
+					{"F12", "Show status check pacing (debug)"},
 				},
 			},
 		}
@@ -193,19 +205,22 @@ func (h *HelpModal) getCommandsForScreen(screen string) []HelpModalSection {
 			{
 				Title: "Navigation",
 				Commands: []HelpModalCommand{
-					{"j/k", "Navigate theme list"},
+					{"j/k or ↑↓", "Navigate theme list"},
+					{"g/G", "Jump to first/last theme"},
+					{"J/K", "Page up/down"},
 				},
 			},
 			{
 				Title: "Actions",
 				Commands: []HelpModalCommand{
 					{"Enter", "Apply selected theme"},
+					{"/", "Search themes"},
 				},
 			},
 			{
 				Title: "General",
 				Commands: []HelpModalCommand{
-					{"Esc", "Go back"},
+					{"Esc", "Clear search/go back"},
 					{"q", "Quit application"},
 					{"?", "Show this help"},
 				},
@@ -246,8 +261,7 @@ func (h *HelpModal) getCommandsForScreen(screen string) []HelpModalSection {
 			{
 				Title: "Navigation",
 				Commands: []HelpModalCommand{
-					{"j/k or ↑↓", "Scroll log"},
-					{"g/G", "Go to top/bottom"},
+					{"Tab or ↑↓", "Move between panels"},
 					{"H/L", "Switch screens (left/right)"},
 				},
 			},
@@ -255,8 +269,7 @@ func (h *HelpModal) getCommandsForScreen(screen string) []HelpModalSection {
 				Title: "Actions",
 				Commands: []HelpModalCommand{
 					{"r", "Refresh status"},
-					{"c", "Clear log"},
-					{"Enter", "View details"},
+					{"Enter", "Open the panel's screen"},
 				},
 			},
 			{
@@ -268,6 +281,34 @@ func (h *HelpModal) getCommandsForScreen(screen string) []HelpModalSection {
 			},
 		}
 
+	case "history":
+		return []HelpModalSection{
+			{
+				Title: "Navigation",
+				Commands: []HelpModalCommand{
+					{"j/k or ↑↓", "Navigate operations"},
+					{"g/G", "Jump to newest/oldest"},
+					{"J/K", "Page up/down"},
+				},
+			},
+			{
+				Title: "Actions",
+				Commands: []HelpModalCommand{
+					{"/", "Search by app"},
+					{"f", "Cycle result filter"},
+					{"r", "Retry failed operation"},
+					{"u", "Roll back operation"},
+				},
+			},
+			{
+				Title: "General",
+				Commands: []HelpModalCommand{
+					{"Esc", "Clear search/go back"},
+					{"q", "Quit application"},
+				},
+			},
+		}
+
 	default: // menu or unknown
 		return []HelpModalSection{
 			{
@@ -204,10 +204,15 @@ func TestHelpModalView(t *testing.T) {
 				"Enter", "Edit setting value",
 				"s", "Save all changes",
 			},
+			"history": {
+				"f", "Cycle result filter",
+				"r", "Retry failed operation",
+				"u", "Roll back operation",
+			},
 			"status": {
 				"r", "Refresh status",
-				"c", "Clear log",
-				"Enter", "View details",
+				"Tab", "Move between panels",
+				"Enter", "Open the panel's screen",
 			},
 		}
 
@@ -0,0 +1,522 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/stringutil"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// Result filters of the history screen, in cycling order.
+const (
+	HistoryFilterAll       = "All"
+	HistoryFilterFailed    = "Failed"
+	HistoryFilterSucceeded = "Succeeded"
+	HistoryFilterInstalls  = "Installs"
+	HistoryFilterRemovals  = "Removals"
+)
+
+var historyFilters = []string{ //nolint:gochecknoglobals
+	HistoryFilterAll, HistoryFilterFailed, HistoryFilterSucceeded, HistoryFilterInstalls, HistoryFilterRemovals,
+}
+
+// HistoryLoadedMsg carries the journal's runs to the history screen.
+type HistoryLoadedMsg struct {
+	Runs []*application.JournalRun
+	Err  error
+}
+
+// historyEntry is one operation of a past run.
+type historyEntry struct {
+	application.JournalEntry
+
+	When time.Time // When its run finished
+	id   string    // Run and position, unique across the journal
+}
+
+// History lists the operations of past runs from the journal, newest
+// first: what was done to which app, when, how it went and how long it
+// took. r retries a failed operation and u rolls a successful one back by
+// doing the opposite.
+type History struct {
+	styles     *styles.Styles
+	width      int
+	height     int
+	journal    *application.Journal
+	entries    []historyEntry
+	list       *SelectableList[historyEntry]
+	filter     string
+	loading    bool
+	err        error
+	notice     string             // Feedback from the last action
+	rollback   *SelectedOperation // Rollback awaiting confirmation
+	privileges changePrivileges   // Whether changing installed apps needs sudo
+	quitting   bool
+	keyMap     HistoryKeyMap
+	helpModal  *HelpModal
+}
+
+// HistoryKeyMap defines key bindings for the history screen.
+type HistoryKeyMap struct {
+	Filter   key.Binding
+	Retry    key.Binding
+	Rollback key.Binding
+	Back     key.Binding
+	Help     key.Binding
+	Quit     key.Binding
+}
+
+// DefaultHistoryKeyMap returns the default key bindings.
+func DefaultHistoryKeyMap() HistoryKeyMap {
+	return HistoryKeyMap{
+		Filter: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "cycle filter"),
+		),
+		Retry: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "retry failed operation"),
+		),
+		Rollback: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "roll back operation"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys(KeyEsc),
+			key.WithHelp("esc", "back to menu"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "help"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", KeyCtrlC),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// NewHistory creates the history screen over karei's journal.
+func NewHistory(styleConfig *styles.Styles) *History {
+	helpModal := NewHelpModal()
+	helpModal.SetScreen("history")
+
+	m := &History{
+		styles:    styleConfig,
+		journal:   application.NewJournal(platform.NewFileManager(false), xdg.JournalFile()),
+		filter:    HistoryFilterAll,
+		loading:   true,
+		keyMap:    DefaultHistoryKeyMap(),
+		helpModal: helpModal,
+
+		privileges: newInstalledPackageManager(),
+	}
+
+	m.list = NewSelectableList(nil,
+		func(entry historyEntry) string { return entry.id },
+		func(entry historyEntry) string { return entry.App },
+		m.renderEntry)
+
+	return m
+}
+
+// SetJournal replaces the journal the history is read from. Must be called
+// before Init.
+func (m *History) SetJournal(journal *application.Journal) {
+	m.journal = journal
+}
+
+// Init starts reading the journal.
+func (m *History) Init() tea.Cmd {
+	journal := m.journal
+
+	return func() tea.Msg {
+		runs, err := journal.Runs()
+
+		return HistoryLoadedMsg{Runs: runs, Err: err}
+	}
+}
+
+// Update handles messages for the history screen.
+func (m *History) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case HistoryLoadedMsg:
+		m.loading = false
+		m.err = msg.Err
+		m.entries = historyEntries(msg.Runs)
+		m.applyFilter()
+
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		if m.helpModal != nil {
+			m.helpModal.SetSize(msg.Width, msg.Height)
+		}
+
+		m.list.SetSize(msg.Width, max(msg.Height-8, 3)) // Header, column titles, notice and footer
+
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKeyMsg(msg)
+	}
+
+	return m, m.list.Update(msg)
+}
+
+// historyEntries flattens the runs into entries, newest first.
+func historyEntries(runs []*application.JournalRun) []historyEntry {
+	var entries []historyEntry
+
+	for i := len(runs) - 1; i >= 0; i-- {
+		for j, entry := range runs[i].Entries {
+			entries = append(entries, historyEntry{JournalEntry: entry, When: runs[i].Finished, id: fmt.Sprintf("%d:%d", i, j)})
+		}
+	}
+
+	return entries
+}
+
+// applyFilter shows the entries matching the result filter.
+func (m *History) applyFilter() {
+	entries := slices.DeleteFunc(slices.Clone(m.entries), func(entry historyEntry) bool {
+		switch m.filter {
+		case HistoryFilterFailed:
+			return entry.Success
+		case HistoryFilterSucceeded:
+			return !entry.Success
+		case HistoryFilterInstalls:
+			return entry.Operation != OperationInstall
+		case HistoryFilterRemovals:
+			return entry.Operation != OperationUninstall
+		}
+
+		return false
+	})
+
+	m.list.SetItems(entries)
+}
+
+//nolint:cyclop // Complex but necessary for handling various UI interactions
+func (m *History) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The search input takes every key while it has focus
+	if m.list.Searching() {
+		m.list.HandleKey(msg)
+
+		return m, nil
+	}
+
+	if m.rollback != nil {
+		return m, m.handleRollbackConfirmKeys(msg)
+	}
+
+	if key.Matches(msg, m.keyMap.Help) {
+		if m.helpModal != nil {
+			m.helpModal.Toggle()
+		}
+
+		return m, nil
+	}
+
+	if m.helpModal != nil && m.helpModal.IsVisible() {
+		if cmd := m.helpModal.Update(msg); cmd != nil {
+			return m, cmd
+		}
+
+		return m, nil
+	}
+
+	// Movement and search keys shared with the apps screen
+	if m.list.HandleKey(msg) {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keyMap.Quit):
+		m.quitting = true
+
+		return m, tea.Quit
+	case key.Matches(msg, m.keyMap.Back):
+		return m, func() tea.Msg { return NavigateMsg{Screen: MenuScreen} }
+	case key.Matches(msg, m.keyMap.Filter):
+		m.filter = historyFilters[(slices.Index(historyFilters, m.filter)+1)%len(historyFilters)]
+		m.notice = ""
+		m.applyFilter()
+	case key.Matches(msg, m.keyMap.Retry):
+		return m, m.retry()
+	case key.Matches(msg, m.keyMap.Rollback):
+		m.rollback = m.rollbackOperation()
+	}
+
+	return m, nil
+}
+
+// retry runs the selected failed operation again.
+func (m *History) retry() tea.Cmd {
+	entry, ok := m.list.Selected()
+	if !ok {
+		return nil
+	}
+
+	if entry.Success {
+		m.notice = fmt.Sprintf("Nothing to retry: the %s of %s succeeded", entry.Operation, entry.App)
+
+		return nil
+	}
+
+	state := StateInstall
+
+	switch entry.Operation {
+	case OperationUninstall:
+		state = StateUninstall
+	case OperationUpdate:
+		state = StateUpdate
+	case OperationReinstall:
+		state = StateReinstall
+	}
+
+	operation, ok := m.operation(entry, state)
+	if !ok {
+		return nil
+	}
+
+	return startOperations(context.Background(), m.privileges, []SelectedOperation{operation})
+}
+
+// rollbackOperation returns the operation undoing the selected successful
+// one: removing what was installed, installing what was removed.
+func (m *History) rollbackOperation() *SelectedOperation {
+	entry, ok := m.list.Selected()
+	if !ok {
+		return nil
+	}
+
+	if !entry.Success {
+		m.notice = fmt.Sprintf("Nothing to roll back: the %s of %s failed", entry.Operation, entry.App)
+
+		return nil
+	}
+
+	// Package managers keep no older version to go back to
+	if entry.Operation == OperationUpdate || entry.Operation == OperationReinstall {
+		m.notice = fmt.Sprintf("The %s of %s cannot be rolled back", entry.Operation, entry.App)
+
+		return nil
+	}
+
+	state := StateUninstall
+	if entry.Operation == OperationUninstall {
+		state = StateInstall
+	}
+
+	operation, ok := m.operation(entry, state)
+	if !ok {
+		return nil
+	}
+
+	return &operation
+}
+
+// operation returns the operation on the entry's app, or reports false when
+// the app is no longer in the catalog.
+func (m *History) operation(entry historyEntry, state SelectionState) (SelectedOperation, bool) {
+	app, exists := apps.Apps[entry.App]
+	if !exists {
+		m.notice = entry.App + " is not in the catalog"
+
+		return SelectedOperation{}, false
+	}
+
+	return SelectedOperation{
+		AppKey:       entry.App,
+		Operation:    state,
+		AppName:      app.Name,
+		RequiresRoot: newAppCatalogAdapter().requiresRoot(entry.App, app),
+	}, true
+}
+
+// handleRollbackConfirmKeys answers the rollback confirmation. Every key is
+// consumed until it is answered.
+func (m *History) handleRollbackConfirmKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y", KeyEnter:
+		operation := *m.rollback
+		m.rollback = nil
+
+		return startOperations(context.Background(), m.privileges, []SelectedOperation{operation})
+	case "n", KeyEsc:
+		m.rollback = nil
+		m.notice = "Rollback cancelled"
+	}
+
+	return nil
+}
+
+// View renders the history screen.
+func (m *History) View() string {
+	if m.quitting {
+		return GoodbyeMessage
+	}
+
+	if m.helpModal != nil && m.helpModal.IsVisible() {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.helpModal.View())
+	}
+
+	var content string
+
+	switch {
+	case m.loading:
+		content = m.styles.MutedText.Render("  Reading the journal...")
+	case m.err != nil:
+		content = lipgloss.NewStyle().Foreground(m.styles.Error).Render("  Could not read the journal: " + m.err.Error())
+	case len(m.entries) == 0:
+		content = m.styles.MutedText.Render("  No runs recorded yet")
+	case m.list.Len() == 0:
+		content = m.styles.MutedText.Render("  No operations match")
+	default:
+		content = m.renderColumnTitles() + "\n" + m.list.View()
+	}
+
+	contentHeight := max(m.height-6, 1) // Header and footer with borders
+	content = lipgloss.NewStyle().Height(contentHeight).MaxHeight(contentHeight).Render(content + "\n" + m.renderNotice())
+
+	return lipgloss.JoinVertical(lipgloss.Top, m.renderCleanHeader(), content, m.renderCleanFooter())
+}
+
+// renderCleanHeader renders the location, the filter and the search query.
+func (m *History) renderCleanHeader() string {
+	headerLine := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.styles.Primary).
+		Render("Karei » History")
+
+	if m.filter != HistoryFilterAll {
+		headerLine += m.styles.MutedText.Render("  [" + m.filter + "]")
+	}
+
+	// Show the search query while it narrows the list
+	if m.list.SearchActive() {
+		cursor := ""
+		if m.list.Searching() {
+			cursor = "█"
+		}
+
+		headerLine += m.styles.MutedText.Render("  /" + m.list.Query() + cursor)
+	}
+
+	return lipgloss.NewStyle().
+		Padding(0, 2).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(lipgloss.Color("240")).
+		Width(m.width).
+		Render(headerLine)
+}
+
+// renderCleanFooter renders the actions available.
+func (m *History) renderCleanFooter() string {
+	actions := []FooterAction{
+		{Key: "/", Action: "Search"},
+		{Key: "f", Action: "Filter"},
+		{Key: "r", Action: "Retry"},
+		{Key: "u", Action: "Roll back"},
+		{Key: "?", Action: "Help"},
+		{Key: "Esc", Action: "Back"},
+	}
+
+	switch {
+	case m.list.Searching():
+		actions = []FooterAction{
+			{Key: "Enter", Action: "Keep results"},
+			{Key: "Esc", Action: "Clear search"},
+		}
+	case m.rollback != nil:
+		actions = []FooterAction{
+			{Key: "y", Action: "Roll back"},
+			{Key: "n", Action: "Cancel"},
+		}
+	}
+
+	return RenderFooter(m.styles, m.width, actions, false)
+}
+
+// renderNotice renders the rollback confirmation or the last action's
+// feedback.
+func (m *History) renderNotice() string {
+	if m.rollback != nil {
+		verb := "Uninstall"
+		if m.rollback.Operation == StateInstall {
+			verb = "Reinstall"
+		}
+
+		return lipgloss.NewStyle().Foreground(m.styles.Warning).
+			Render(fmt.Sprintf("  %s %s to roll back? [y/n]", verb, m.rollback.AppName))
+	}
+
+	return m.styles.MutedText.Render("  " + m.notice)
+}
+
+// Column widths of the history list.
+const (
+	historyWhenWidth      = 16
+	historyOperationWidth = 9
+	historyAppWidth       = 22
+	historyDurationWidth  = 8
+)
+
+func (m *History) renderColumnTitles() string {
+	return m.styles.MutedText.Render(fmt.Sprintf("  %-*s  %-*s  %-*s  %*s  %s",
+		historyWhenWidth, "When", historyOperationWidth, "What", historyAppWidth, "App", historyDurationWidth, "Took", "Result"))
+}
+
+// renderEntry renders one operation as a row of the list.
+func (m *History) renderEntry(entry historyEntry, focused bool, _ SelectionState, highlight []int) string {
+	// Unpadded, so the row's segments line up with the column titles
+	style := m.styles.Unselected.UnsetPadding()
+	prefix := "  "
+
+	if focused {
+		style = m.styles.Selected.UnsetPadding()
+		prefix = "❯ "
+	}
+
+	took := "-"
+	if entry.Duration > 0 {
+		took = stringutil.FormatDuration(entry.Duration.Round(time.Second))
+	}
+
+	result := m.styles.StatusIcon("completed") + " done"
+	if !entry.Success {
+		result = m.styles.StatusIcon("failed") + " " + entry.Error
+	}
+
+	matchStyle := style.Bold(true).Underline(true)
+
+	return style.Render(fmt.Sprintf("%s%-*s  %-*s  ", prefix,
+		historyWhenWidth, entry.When.Local().Format("2006-01-02 15:04"), historyOperationWidth, entry.Operation)) +
+		highlightMatches(entry.App, highlight, style, matchStyle, historyAppWidth) +
+		style.Render(fmt.Sprintf("  %*s  ", historyDurationWidth, took)) + result
+}
+
+// GetNavigationHints returns screen-specific navigation hints for the footer.
+func (m *History) GetNavigationHints() []string {
+	return []string{
+		"[f] Filter",
+		"[r] Retry",
+		"[u] Roll back",
+	}
+}
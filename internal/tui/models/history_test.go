@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHistory returns the history screen over a journal holding an older
+// run that installed vlc and a newer one that failed to install zed and
+// removed spotify.
+func newTestHistory(t *testing.T) *History {
+	t.Helper()
+
+	journal := application.NewJournal(platform.NewFileManager(false), filepath.Join(t.TempDir(), "journal.json"))
+
+	older := application.NewJournalRun()
+	older.Entries = append(older.Entries, application.JournalEntry{App: "vlc", Operation: OperationInstall, Success: true, Duration: 42 * time.Second})
+	require.NoError(t, journal.Record(older))
+
+	newer := application.NewJournalRun()
+	newer.Add("zed", OperationInstall, errors.New("download failed"))
+	newer.Add("spotify", OperationUninstall, nil)
+	require.NoError(t, journal.Record(newer))
+
+	model := NewHistory(styles.New())
+	model.SetJournal(journal)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	model.Update(model.Init()())
+
+	return model
+}
+
+// historyApps returns the apps of the listed operations, in order.
+func historyApps(model *History) []string {
+	var listed []string
+	for _, entry := range model.list.VisibleItems() {
+		listed = append(listed, entry.App)
+	}
+
+	return listed
+}
+
+func TestHistoryListsOperationsNewestFirst(t *testing.T) {
+	t.Parallel()
+
+	model := newTestHistory(t)
+
+	assert.Equal(t, []string{"zed", "spotify", "vlc"}, historyApps(model))
+
+	view := model.View()
+	assert.Contains(t, view, "Karei » History")
+	assert.Contains(t, view, "download failed")
+	assert.Contains(t, view, "42 s")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	assert.Equal(t, []string{"zed"}, historyApps(model), "failed")
+	assert.Contains(t, model.View(), "[Failed]")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	assert.Equal(t, []string{"spotify", "vlc"}, historyApps(model), "succeeded")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	assert.Equal(t, []string{"zed", "vlc"}, historyApps(model), "installs")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	assert.Equal(t, []string{"spotify"}, historyApps(model), "removals")
+}
+
+func TestHistoryRetriesAndRollsBack(t *testing.T) {
+	t.Parallel()
+
+	model := newTestHistory(t)
+
+	// zed failed to install, so it is retried
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(NavigateMsg)
+	require.True(t, ok)
+
+	operations, ok := msg.Data.([]SelectedOperation)
+	require.True(t, ok)
+	require.Len(t, operations, 1)
+	assert.Equal(t, "zed", operations[0].AppKey)
+	assert.Equal(t, StateInstall, operations[0].Operation)
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	assert.Nil(t, cmd)
+	assert.Contains(t, model.View(), "Nothing to roll back")
+
+	// spotify was removed, so rolling back reinstalls it after confirming
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	assert.Contains(t, model.View(), "Reinstall Spotify to roll back?")
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	assert.Nil(t, cmd)
+	assert.Contains(t, model.View(), "Rollback cancelled")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	msg, ok = cmd().(NavigateMsg)
+	require.True(t, ok)
+
+	operations, ok = msg.Data.([]SelectedOperation)
+	require.True(t, ok)
+	require.Len(t, operations, 1)
+	assert.Equal(t, "spotify", operations[0].AppKey)
+	assert.Equal(t, StateInstall, operations[0].Operation)
+}
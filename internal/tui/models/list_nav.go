@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Viewport follow and paging for list screens.
+const (
+	listPageSize     = 5 // Items moved by J/K
+	listFollowBuffer = 3 // Lines kept visible around the selection
+)
+
+// listNav is the cursor and search state shared by list screens. It keys the
+// same way as the apps screen: j/k and arrows move, g/G jump to the ends,
+// J/K page, / searches and Esc clears the search.
+type listNav struct {
+	labels     []string      // Searchable label of each item
+	visible    []int         // Indices of the items shown, best match first
+	highlights map[int][]int // Matched rune positions of each item label
+	cursor     int           // Position in visible
+	query      string
+	searching  bool // The search input has focus
+}
+
+// newListNav creates navigation over items with the given labels.
+func newListNav(labels []string) listNav {
+	nav := listNav{labels: labels}
+	nav.filter()
+
+	return nav
+}
+
+// selected returns the index of the item under the cursor.
+func (n *listNav) selected() (int, bool) {
+	if n.cursor < 0 || n.cursor >= len(n.visible) {
+		return 0, false
+	}
+
+	return n.visible[n.cursor], true
+}
+
+// searchActive reports whether a query narrows the list or is being typed.
+func (n *listNav) searchActive() bool {
+	return n.searching || n.query != ""
+}
+
+// handleKey applies navigation and search keys and reports whether the key
+// was consumed. While the search input has focus every key is consumed.
+func (n *listNav) handleKey(msg tea.KeyMsg) bool {
+	if n.searching {
+		n.handleSearchKey(msg)
+
+		return true
+	}
+
+	switch msg.String() {
+	case "/":
+		n.searching = true
+	case KeyEsc:
+		if n.query == "" {
+			return false
+		}
+
+		n.query = ""
+		n.filter()
+	case "j", "down":
+		n.move(1)
+	case "k", "up":
+		n.move(-1)
+	case "J", "pgdown":
+		n.move(listPageSize)
+	case "K", "pgup":
+		n.move(-listPageSize)
+	case "g", "home":
+		n.cursor = 0
+	case "G", "end":
+		n.cursor = max(len(n.visible)-1, 0)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// handleSearchKey edits the query: Enter keeps it and returns to the list,
+// Esc discards it.
+func (n *listNav) handleSearchKey(msg tea.KeyMsg) {
+	switch msg.String() {
+	case KeyEnter:
+		n.searching = false
+
+		return
+	case KeyEsc:
+		n.searching = false
+		n.query = ""
+	case "backspace":
+		runes := []rune(n.query)
+		if len(runes) == 0 {
+			return
+		}
+
+		n.query = string(runes[:len(runes)-1])
+	default:
+		if msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace {
+			return
+		}
+
+		n.query += string(msg.Runes)
+	}
+
+	n.filter()
+}
+
+// move shifts the cursor by delta, stopping at the ends of the list.
+func (n *listNav) move(delta int) {
+	n.cursor = min(max(n.cursor+delta, 0), max(len(n.visible)-1, 0))
+}
+
+// filter ranks the labels against the query and resets the cursor. An empty
+// query shows every item in order.
+func (n *listNav) filter() {
+	n.cursor = 0
+	n.visible = n.visible[:0]
+	n.highlights = map[int][]int{}
+
+	query := strings.ToLower(strings.TrimSpace(n.query))
+	if query == "" {
+		for i := range n.labels {
+			n.visible = append(n.visible, i)
+		}
+
+		return
+	}
+
+	scores := map[int]int{}
+
+	for i, label := range n.labels {
+		if score, positions := scoreField(label, query, true); score > 0 {
+			n.visible = append(n.visible, i)
+			scores[i] = score
+			n.highlights[i] = positions
+		}
+	}
+
+	slices.SortStableFunc(n.visible, func(a, b int) int {
+		return cmp.Compare(scores[b], scores[a])
+	})
+}
+
+// followSelection scrolls vp so that line stays buffer lines away from the
+// top and bottom edges, without scrolling past the content.
+func followSelection(vp *viewport.Model, line, buffer int) {
+	top := vp.YOffset
+	bottom := top + vp.Height - 1
+
+	switch {
+	case line < top+buffer:
+		vp.SetYOffset(max(line-buffer, 0))
+	case line > bottom-buffer:
+		maxOffset := max(vp.TotalLineCount()-vp.Height, 0)
+		vp.SetYOffset(min(line-vp.Height+buffer+1, maxOffset))
+	}
+}
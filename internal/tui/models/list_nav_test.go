@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestListNavMovement(t *testing.T) {
+	t.Parallel()
+
+	nav := newListNav([]string{"a", "b", "c", "d", "e", "f", "g"})
+
+	assert.True(t, nav.handleKey(runeKey('j')))
+	assert.Equal(t, 1, nav.cursor)
+
+	nav.handleKey(runeKey('J'))
+	assert.Equal(t, 6, nav.cursor, "paging stops at the last item")
+
+	nav.handleKey(runeKey('j'))
+	assert.Equal(t, 6, nav.cursor)
+
+	nav.handleKey(runeKey('g'))
+	assert.Equal(t, 0, nav.cursor)
+
+	nav.handleKey(runeKey('G'))
+	assert.Equal(t, 6, nav.cursor)
+
+	nav.handleKey(runeKey('K'))
+	assert.Equal(t, 1, nav.cursor)
+
+	assert.False(t, nav.handleKey(runeKey('q')), "unknown keys are left to the screen")
+	assert.False(t, nav.handleKey(tea.KeyMsg{Type: tea.KeyEsc}), "Esc without a query goes back")
+}
+
+func TestListNavSearch(t *testing.T) {
+	t.Parallel()
+
+	nav := newListNav([]string{"Gruvbox", "Nord", "Tokyo Night", "Arctic Nordic"})
+
+	require.True(t, nav.handleKey(runeKey('/')))
+	assert.True(t, nav.searching)
+
+	for _, r := range "nor" {
+		nav.handleKey(runeKey(r))
+	}
+
+	assert.Equal(t, []int{1, 3}, nav.visible, "prefix match ranks above word boundary")
+	assert.Equal(t, []int{7, 8, 9}, nav.highlights[3])
+
+	assert.True(t, nav.handleKey(runeKey('q')), "typing is consumed by the search input")
+	assert.Empty(t, nav.visible)
+
+	nav.handleKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	nav.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, nav.searching)
+	assert.Equal(t, "nor", nav.query)
+
+	nav.handleKey(runeKey('j'))
+
+	selected, ok := nav.selected()
+	require.True(t, ok)
+	assert.Equal(t, 3, selected)
+
+	require.True(t, nav.handleKey(tea.KeyMsg{Type: tea.KeyEsc}))
+	assert.Empty(t, nav.query)
+	assert.Equal(t, []int{0, 1, 2, 3}, nav.visible)
+}
+
+func TestFollowSelection(t *testing.T) {
+	t.Parallel()
+
+	vp := viewport.New(20, 10)
+	vp.SetContent(strings.Repeat("line\n", 50))
+
+	followSelection(&vp, 8, listFollowBuffer)
+	assert.Equal(t, 2, vp.YOffset, "scrolls down to keep the buffer below")
+
+	followSelection(&vp, 3, listFollowBuffer)
+	assert.Equal(t, 0, vp.YOffset, "scrolls up without going negative")
+
+	followSelection(&vp, 49, listFollowBuffer)
+	assert.Equal(t, 41, vp.YOffset, "stops at the end of the content")
+}
@@ -0,0 +1,288 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// Log viewer layout: the modal leaves a margin around it, and its border and
+// padding take columns and its title and status line take rows.
+const (
+	logViewerMargin      = 4
+	logViewerChromeWidth = 4
+	logViewerChromeLines = 6
+)
+
+// logViewer is a modal showing the complete operation log, with scrolling,
+// search, following new entries and saving to a file.
+type logViewer struct {
+	styles    *styles.Styles
+	viewport  viewport.Model
+	visible   bool
+	follow    bool // Keep the newest entry in view
+	lines     []string
+	source    string // Log file the lines are also written to
+	width     int
+	height    int
+	exportDir string
+
+	searching bool   // Typing a search query
+	query     string // Confirmed or typed query
+	matches   []int  // Lines containing the query
+	match     int    // Current match in matches
+	notice    string // Result of the last save
+}
+
+// newLogViewer creates a hidden log viewer following new entries.
+func newLogViewer(styleConfig *styles.Styles) *logViewer {
+	return &logViewer{
+		styles:    styleConfig,
+		viewport:  viewport.New(0, 0),
+		follow:    true,
+		exportDir: xdg.LogDir(),
+	}
+}
+
+// toggle shows or hides the viewer.
+func (v *logViewer) toggle() {
+	v.visible = !v.visible
+	v.searching = false
+	v.notice = ""
+}
+
+// setSize fits the modal into a screen of the given size.
+func (v *logViewer) setSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.viewport.Width = max(width-logViewerMargin-logViewerChromeWidth, 20)
+	v.viewport.Height = max(height-logViewerMargin-logViewerChromeLines, 3)
+	v.refresh()
+}
+
+// setLines replaces the shown log lines, keeping the newest in view when following.
+func (v *logViewer) setLines(lines []string) {
+	v.lines = lines
+	v.findMatches()
+	v.refresh()
+}
+
+// refresh renders the lines into the viewport.
+func (v *logViewer) refresh() {
+	v.viewport.SetContent(v.render())
+
+	if v.follow {
+		v.viewport.GotoBottom()
+	}
+}
+
+// render returns the lines with search matches highlighted.
+func (v *logViewer) render() string {
+	if v.query == "" {
+		return strings.Join(v.lines, "\n")
+	}
+
+	highlight := lipgloss.NewStyle().Bold(true).Foreground(v.styles.Warning)
+	rendered := make([]string, len(v.lines))
+
+	for i, line := range v.lines {
+		rendered[i] = highlightSubstring(line, v.query, highlight)
+	}
+
+	return strings.Join(rendered, "\n")
+}
+
+// highlightSubstring renders every case-insensitive occurrence of query in line with style.
+func highlightSubstring(line, query string, style lipgloss.Style) string {
+	lowerLine, lowerQuery := strings.ToLower(line), strings.ToLower(query)
+	if len(lowerLine) != len(line) {
+		return line // Case folding changed byte offsets; leave the line plain
+	}
+
+	var builder strings.Builder
+
+	for {
+		i := strings.Index(lowerLine, lowerQuery)
+		if i < 0 {
+			builder.WriteString(line)
+
+			return builder.String()
+		}
+
+		builder.WriteString(line[:i])
+		builder.WriteString(style.Render(line[i : i+len(query)]))
+		line, lowerLine = line[i+len(query):], lowerLine[i+len(query):]
+	}
+}
+
+// findMatches collects the lines containing the query.
+func (v *logViewer) findMatches() {
+	v.matches = v.matches[:0]
+
+	if v.query == "" {
+		return
+	}
+
+	for i, line := range v.lines {
+		if strings.Contains(strings.ToLower(line), strings.ToLower(v.query)) {
+			v.matches = append(v.matches, i)
+		}
+	}
+
+	v.match = min(v.match, max(len(v.matches)-1, 0))
+}
+
+// Update handles keys while the viewer is visible.
+func (v *logViewer) Update(msg tea.KeyMsg) tea.Cmd {
+	if v.searching {
+		v.handleSearchKey(msg)
+
+		return nil
+	}
+
+	v.notice = ""
+
+	switch msg.String() {
+	case KeyEsc, "l", "q":
+		v.toggle()
+	case "/":
+		v.searching = true
+		v.query = ""
+		v.matches = v.matches[:0]
+		v.refresh()
+	case "n":
+		v.jumpToMatch(1)
+	case "N":
+		v.jumpToMatch(-1)
+	case "F":
+		v.follow = !v.follow
+		v.refresh()
+	case "g", "home":
+		v.follow = false
+		v.viewport.GotoTop()
+	case "G", "end":
+		v.follow = true
+		v.viewport.GotoBottom()
+	case "s":
+		v.save()
+	default:
+		var cmd tea.Cmd
+
+		v.viewport, cmd = v.viewport.Update(msg)
+		// Scrolling away from the end stops following
+		v.follow = v.viewport.AtBottom() && v.follow
+
+		return cmd
+	}
+
+	return nil
+}
+
+// handleSearchKey edits the search query until it is confirmed or cancelled.
+func (v *logViewer) handleSearchKey(msg tea.KeyMsg) {
+	switch msg.Type { //nolint:exhaustive // Other keys are typed into the query
+	case tea.KeyEnter:
+		v.searching = false
+		v.findMatches()
+		v.match = 0
+		v.jumpToMatch(0)
+	case tea.KeyEsc:
+		v.searching = false
+		v.query = ""
+		v.findMatches()
+	case tea.KeyBackspace:
+		if v.query != "" {
+			runes := []rune(v.query)
+			v.query = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		v.query += string(msg.Runes)
+	}
+
+	v.refresh()
+}
+
+// jumpToMatch moves by step matches, wrapping around, and scrolls to it.
+func (v *logViewer) jumpToMatch(step int) {
+	if len(v.matches) == 0 {
+		return
+	}
+
+	v.match = (v.match + step + len(v.matches)) % len(v.matches)
+	v.follow = false
+	v.viewport.SetYOffset(v.matches[v.match] - v.viewport.Height/2)
+}
+
+// save writes the complete log next to karei's other log files.
+func (v *logViewer) save() {
+	path := filepath.Join(v.exportDir, "log-"+time.Now().Format("20060102-150405")+".txt")
+
+	if err := os.MkdirAll(v.exportDir, 0o750); err != nil {
+		v.notice = "Save failed: " + err.Error()
+
+		return
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(v.lines, "\n")+"\n"), 0o600); err != nil {
+		v.notice = "Save failed: " + err.Error()
+
+		return
+	}
+
+	v.notice = "Saved log to " + path
+}
+
+// View renders the modal centered on the screen.
+func (v *logViewer) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(v.styles.Primary).
+		Render(fmt.Sprintf("Logs (%d lines)", len(v.lines)))
+	if v.source != "" {
+		title += " " + v.styles.MutedText.Render(truncate(v.source, max(v.viewport.Width-20, 10)))
+	}
+
+	body := lipgloss.JoinVertical(lipgloss.Left, title, "", v.viewport.View(), "", v.statusLine())
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(v.styles.Primary).
+		Padding(0, 1).
+		Render(body)
+
+	return lipgloss.Place(v.width, v.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// statusLine shows the search, follow state and keys.
+func (v *logViewer) statusLine() string {
+	muted := v.styles.MutedText
+
+	switch {
+	case v.searching:
+		return v.styles.PrimaryText.Render("/") + " " + v.query + "█"
+	case v.notice != "":
+		return muted.Render(v.notice)
+	}
+
+	follow := "off"
+	if v.follow {
+		follow = "on"
+	}
+
+	status := "follow " + follow
+	if v.query != "" {
+		status = fmt.Sprintf("%q %d/%d · %s", v.query, min(v.match+1, len(v.matches)), len(v.matches), status)
+	}
+
+	return muted.Render(status + " · / search · n/N next/prev · F follow · s save · esc close")
+}
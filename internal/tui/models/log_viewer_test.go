@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/janderssonse/karei/internal/tui/styles"
+)
+
+func runes(text string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)}
+}
+
+func testLogLines(count int) []string {
+	lines := make([]string, count)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i)
+	}
+
+	lines[10] = "vlc installation failed: unable to locate package"
+
+	return lines
+}
+
+func TestOperationLogKeepsEveryEntry(t *testing.T) {
+	var log operationLog
+
+	for i := range 40 {
+		log.add(LogInfo, "vlc", fmt.Sprintf("entry %d", i))
+	}
+
+	assert.Equal(t, 40, log.len())
+	assert.Equal(t, []string{"entry 38", "entry 39"}, log.recent(2))
+	assert.True(t, strings.HasSuffix(log.lines()[0], "vlc: entry 0"))
+
+	for i := range maxLogEntries {
+		log.add(LogWarn, "", fmt.Sprint(i))
+	}
+
+	assert.LessOrEqual(t, log.len(), maxLogEntries)
+	assert.Positive(t, log.dropped)
+	assert.Contains(t, log.lines()[log.len()-1], "[warn]")
+}
+
+func TestLogViewerSearch(t *testing.T) {
+	viewer := newLogViewer(styles.New())
+	viewer.visible = true
+	viewer.setSize(80, 20)
+	viewer.setLines(testLogLines(100))
+
+	assert.True(t, viewer.viewport.AtBottom(), "follows the newest lines")
+
+	viewer.Update(runes("/"))
+	viewer.Update(runes("FAILED"))
+	viewer.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Equal(t, []int{10}, viewer.matches)
+	assert.False(t, viewer.follow, "jumping to a match stops following")
+	assert.LessOrEqual(t, viewer.viewport.YOffset, 10)
+	assert.Contains(t, viewer.View(), `"FAILED" 1/1`)
+
+	viewer.Update(runes("G"))
+	assert.True(t, viewer.follow)
+
+	viewer.Update(tea.KeyMsg{Type: tea.KeyUp})
+	assert.False(t, viewer.follow, "scrolling up stops following")
+
+	viewer.Update(runes("F"))
+	assert.True(t, viewer.viewport.AtBottom())
+}
+
+func TestLogViewerSave(t *testing.T) {
+	viewer := newLogViewer(styles.New())
+	viewer.exportDir = t.TempDir()
+	viewer.setLines([]string{"first", "second"})
+
+	viewer.Update(runes("s"))
+
+	paths, err := filepath.Glob(filepath.Join(viewer.exportDir, "log-*.txt"))
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	assert.Contains(t, viewer.notice, paths[0])
+
+	data, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", string(data))
+}
+
+func TestProgressLogViewerTakesKeys(t *testing.T) {
+	model := NewProgress(context.Background(), styles.New(), []string{"vlc"})
+	model.handleWindowSizeMsg(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model.appendLog("Starting")
+
+	model.handleKeyMsg(runes("l"))
+	require.True(t, model.logViewer.visible)
+	assert.Contains(t, model.View(), "Starting")
+
+	// "p" would pause the run, but goes to the viewer while it is open
+	model.handleKeyMsg(runes("p"))
+	assert.False(t, model.paused)
+
+	model.appendLog("Later entry")
+	assert.Contains(t, model.View(), "Later entry")
+
+	model.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, model.logViewer.visible)
+}
+
+func TestOperationLogStreamsToFile(t *testing.T) {
+	var log operationLog
+
+	log.add(LogInfo, "", "Queued before the run started")
+	require.NoError(t, log.open(t.TempDir()))
+
+	for i := range maxLogEntries + 5 {
+		log.add(LogInfo, "vlc", fmt.Sprint(i))
+	}
+
+	log.close()
+
+	require.Positive(t, log.dropped)
+
+	lines := log.lines()
+	assert.Len(t, lines, maxLogEntries+6, "entries dropped from memory are read back")
+	assert.Contains(t, lines[0], "Queued before the run started")
+
+	data, err := os.ReadFile(log.path())
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join(lines, "\n")+"\n", string(data))
+}
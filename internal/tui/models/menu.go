@@ -70,6 +70,12 @@ func NewMenu(styleConfig *styles.Styles) *Menu {
 			Icon:        "📊",
 			Action:      "status",
 		},
+		{
+			Title:       "History",
+			Description: "Review, retry or roll back past operations",
+			Icon:        "🕘",
+			Action:      "history",
+		},
 		{
 			Title:       "Update",
 			Description: "Keep your tools up to date",
@@ -195,6 +201,8 @@ func (m *Menu) createNavigationCmd(action string) tea.Cmd {
 		screen = ConfigScreen
 	case "status":
 		screen = StatusScreen
+	case "history":
+		screen = HistoryScreen
 	case "help":
 		screen = HelpScreen
 	case "update":
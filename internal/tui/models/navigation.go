@@ -4,6 +4,8 @@
 // Package models defines shared navigation messages between UI screens.
 package models
 
+import "github.com/janderssonse/karei/internal/domain"
+
 // NavigateMsg is a message sent to request navigation to a specific screen.
 type NavigateMsg struct {
 	Screen int
@@ -20,12 +22,17 @@ const (
 	HelpScreen
 	ProgressScreen
 	PasswordScreen
+	ResultsScreen
+	AppDetailScreen
+	HistoryScreen
 )
 
 // Operation constants.
 const (
 	OperationInstall   = "install"
 	OperationUninstall = "uninstall"
+	OperationUpdate    = "update"
+	OperationReinstall = "reinstall"
 )
 
 // Common message constants.
@@ -66,3 +73,8 @@ type ContextSwitchMsg struct {
 	Direction string // "up" or "down"
 	Context   string // "search" or "categories"
 }
+
+// DomainEventMsg wraps a domain event published on the application event bus.
+type DomainEventMsg struct {
+	Event domain.Event
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+)
+
+// maxLogEntries bounds the entries an operation log keeps in memory; the
+// log file keeps the rest.
+const maxLogEntries = 10000
+
+// LogLevel is the severity of a log entry.
+type LogLevel string
+
+// Log levels.
+const (
+	LogInfo  LogLevel = "info"
+	LogWarn  LogLevel = "warn"
+	LogError LogLevel = "error"
+)
+
+// LogEntry is one line of an operation's log.
+type LogEntry struct {
+	Time    time.Time
+	Level   LogLevel
+	Task    string // Task the entry is about, empty for the whole operation
+	Message string
+}
+
+// String formats the entry as a log line.
+func (e LogEntry) String() string {
+	line := e.Time.Format("15:04:05") + " "
+
+	if e.Level != LogInfo {
+		line += fmt.Sprintf("[%s] ", e.Level)
+	}
+
+	if e.Task != "" {
+		line += e.Task + ": "
+	}
+
+	return line + e.Message
+}
+
+// operationLog keeps every entry of an install or uninstall run, replacing
+// a buffer of the last few lines, for the log viewer and the activity box.
+// Once a run starts the entries are also streamed to a log file, so the
+// complete record survives the run and entries dropped from memory can be
+// read back.
+type operationLog struct {
+	entries []LogEntry
+	dropped int                    // Oldest entries dropped to stay below maxLogEntries
+	file    *platform.OperationLog // Log file of the run, nil until it starts
+	fileErr error                  // Why the log file can't be written
+}
+
+// open starts a log file in dir and writes the entries so far to it.
+func (l *operationLog) open(dir string) error {
+	file, err := platform.CreateOperationLog(dir)
+	if err != nil {
+		return err
+	}
+
+	l.file = file
+
+	for _, entry := range l.entries {
+		l.write(entry)
+	}
+
+	return l.fileErr
+}
+
+// path returns the log file, or "" when the run has none.
+func (l *operationLog) path() string {
+	if l.file == nil {
+		return ""
+	}
+
+	return l.file.Path()
+}
+
+// close closes the log file; later entries open it again.
+func (l *operationLog) close() {
+	if l.file != nil {
+		_ = l.file.Close()
+	}
+}
+
+// add appends an entry stamped with the current time.
+func (l *operationLog) add(level LogLevel, task, message string) {
+	entry := LogEntry{Time: time.Now(), Level: level, Task: task, Message: message}
+	l.entries = append(l.entries, entry)
+	l.write(entry)
+
+	// A tenth is dropped at once so the entries aren't copied on every add
+	if len(l.entries) > maxLogEntries {
+		drop := len(l.entries) - maxLogEntries*9/10
+		l.entries = append(l.entries[:0:0], l.entries[drop:]...)
+		l.dropped += drop
+	}
+}
+
+// write streams an entry to the log file. After the first failure the
+// file is given up on, keeping the entries in memory only.
+func (l *operationLog) write(entry LogEntry) {
+	if l.file == nil || l.fileErr != nil {
+		return
+	}
+
+	l.fileErr = l.file.Append(entry.String())
+}
+
+// len returns the number of entries kept.
+func (l *operationLog) len() int {
+	return len(l.entries)
+}
+
+// recent returns the messages of the last n entries, oldest first.
+func (l *operationLog) recent(n int) []string {
+	start := max(len(l.entries)-n, 0)
+	messages := make([]string, 0, len(l.entries)-start)
+
+	for _, entry := range l.entries[start:] {
+		messages = append(messages, entry.Message)
+	}
+
+	return messages
+}
+
+// lines returns every entry formatted as a log line, reading those no
+// longer in memory back from the log file.
+func (l *operationLog) lines() []string {
+	if l.dropped > 0 && l.file != nil && l.fileErr == nil {
+		if lines, err := l.file.Lines(0, l.file.Len()); err == nil {
+			return lines
+		}
+	}
+
+	lines := make([]string, 0, len(l.entries))
+	for _, entry := range l.entries {
+		lines = append(lines, entry.String())
+	}
+
+	return lines
+}
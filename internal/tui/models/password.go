@@ -18,6 +18,54 @@ import (
 
 // Note: KeyCtrlC and other key constants are defined in menu.go
 
+const (
+	// maxPasswordAttempts mirrors sudo's default passwd_tries before giving up.
+	maxPasswordAttempts = 3
+
+	// keyPolkit authenticates through the desktop's polkit agent instead.
+	keyPolkit = "ctrl+p"
+
+	// polkitPerStepHint warns that pkexec keeps no authorization between commands.
+	polkitPerStepHint = "The dialog then asks again for each step needing administrator rights."
+)
+
+// SudoAuthenticator validates sudo credentials for the password prompt.
+type SudoAuthenticator interface {
+	// Validate checks the password with `sudo -S -v`, refreshing the credential cache.
+	Validate(ctx context.Context, password string) error
+	// NoPasswordRequired reports whether sudoers lets every command run without a password.
+	NoPasswordRequired(ctx context.Context) bool
+	// FingerprintAvailable reports whether sudo's PAM stack accepts a fingerprint.
+	FingerprintAvailable() bool
+	// PolkitAvailable reports whether a desktop polkit agent can authenticate instead of sudo.
+	PolkitAvailable() bool
+	// ValidatePolkit authenticates through the polkit agent.
+	ValidatePolkit(ctx context.Context) error
+}
+
+// systemSudo authenticates against the host's sudo.
+type systemSudo struct{}
+
+func (systemSudo) Validate(ctx context.Context, password string) error {
+	return system.RunWithPassword(ctx, false, password, "-v")
+}
+
+func (systemSudo) NoPasswordRequired(ctx context.Context) bool {
+	return system.SudoWithoutPassword(ctx)
+}
+
+func (systemSudo) FingerprintAvailable() bool {
+	return system.SudoFingerprintEnabled()
+}
+
+func (systemSudo) PolkitAvailable() bool {
+	return system.PolkitAvailable()
+}
+
+func (systemSudo) ValidatePolkit(ctx context.Context) error {
+	return system.PolkitAuthenticate(ctx)
+}
+
 // PasswordPrompt represents a password input screen for sudo authentication.
 //
 //nolint:containedctx // TUI models require context for proper cancellation propagation
@@ -33,6 +81,14 @@ type PasswordPrompt struct {
 	completed  bool
 	showCursor bool
 	ctx        context.Context // Parent context for cancellation/timeout propagation //nolint:containedctx
+
+	auth        SudoAuthenticator
+	attempts    int
+	validating  bool
+	locked      bool
+	fingerprint bool
+	polkit      bool // A polkit agent can authenticate instead
+	viaPolkit   bool // The polkit agent is authenticating
 }
 
 // PasswordPromptResult carries the result of password input.
@@ -40,6 +96,7 @@ type PasswordPromptResult struct {
 	Password   string
 	Operations []SelectedOperation
 	Cancelled  bool
+	Polkit     bool // Privileged commands go through pkexec instead of sudo
 }
 
 // NewPasswordPrompt creates a new password input screen.
@@ -49,53 +106,93 @@ func NewPasswordPrompt(ctx context.Context, styleConfig *styles.Styles, operatio
 		styles:     styleConfig,
 		operations: operations,
 		ctx:        ctx, // Store parent context
+		auth:       systemSudo{},
 	}
 
-	appCount := len(operations)
-	installCount := 0
-	uninstallCount := 0
+	prompt.message = privilegeMessage(operations)
+	prompt.showCursor = true
 
+	return prompt
+}
+
+// privilegeMessage says what the password is for, naming each operation,
+// such as "Installing 2 applications and updating 1 applications requires
+// administrator privileges."
+func privilegeMessage(operations []SelectedOperation) string {
+	counts := make(map[SelectionState]int)
 	for _, op := range operations {
-		switch op.Operation {
-		case StateInstall:
-			installCount++
-		case StateUninstall:
-			uninstallCount++
-		}
+		counts[op.Operation]++
 	}
 
-	var message string
-
-	switch {
-	case installCount > 0 && uninstallCount > 0:
-		message = fmt.Sprintf("Installing %d applications and uninstalling %d applications requires administrator privileges.", installCount, uninstallCount)
-
-	case installCount > 0:
-		message = fmt.Sprintf("Installing %d applications requires administrator privileges.", installCount)
+	var phrases []string
+
+	for _, kind := range []struct {
+		state SelectionState
+		verb  string
+	}{
+		{StateInstall, "installing"},
+		{StateUninstall, "uninstalling"},
+		{StateUpdate, "updating"},
+		{StateReinstall, "reinstalling"},
+	} {
+		if counts[kind.state] > 0 {
+			phrases = append(phrases, fmt.Sprintf("%s %d applications", kind.verb, counts[kind.state]))
+		}
+	}
 
-	case uninstallCount > 0:
-		message = fmt.Sprintf("Uninstalling %d applications requires administrator privileges.", uninstallCount)
+	if len(phrases) == 0 {
+		return fmt.Sprintf("Processing %d applications requires administrator privileges.", len(operations))
+	}
 
-	default:
-		message = fmt.Sprintf("Processing %d applications requires administrator privileges.", appCount)
+	sentence := phrases[len(phrases)-1]
+	if len(phrases) > 1 {
+		sentence = strings.Join(phrases[:len(phrases)-1], ", ") + " and " + sentence
 	}
 
-	prompt.message = message
-	prompt.showCursor = true
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + " requires administrator privileges."
+}
 
-	return prompt
+// SetAuthenticator replaces the sudo authenticator, mainly for tests.
+func (m *PasswordPrompt) SetAuthenticator(auth SudoAuthenticator) {
+	m.auth = auth
 }
 
 // Init initializes the password prompt.
 func (m *PasswordPrompt) Init() tea.Cmd {
-	return tea.Tick(500*1000000, func(_ time.Time) tea.Msg {
+	blink := tea.Tick(500*1000000, func(_ time.Time) tea.Msg {
 		return CursorBlinkMsg{}
 	})
+
+	return tea.Batch(blink, m.probeSudo())
 }
 
 // CursorBlinkMsg represents a cursor blink event.
 type CursorBlinkMsg struct{}
 
+// SudoProbeResult reports what sudo needs before the user types anything.
+type SudoProbeResult struct {
+	NoPassword  bool
+	Fingerprint bool
+	Polkit      bool
+}
+
+// probeSudo checks for NOPASSWD sudoers, fingerprint and polkit support.
+func (m *PasswordPrompt) probeSudo() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.ctx, 5*time.Second)
+		defer cancel()
+
+		if m.auth.NoPasswordRequired(ctx) {
+			return SudoProbeResult{NoPassword: true}
+		}
+
+		return SudoProbeResult{
+			Fingerprint: m.auth.FingerprintAvailable(),
+			Polkit:      m.auth.PolkitAvailable(),
+		}
+	}
+}
+
 // PasswordValidationMsg represents a password validation request.
 type PasswordValidationMsg struct {
 	Password   string
@@ -108,6 +205,7 @@ type PasswordValidationResult struct {
 	Password   string
 	Operations []SelectedOperation
 	Error      string
+	Polkit     bool // Authenticated through polkit rather than a password
 }
 
 // Update handles messages and returns updated model and commands.
@@ -137,6 +235,9 @@ func (m *PasswordPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case PasswordValidationResult:
 		return m.handlePasswordValidationResult(msg)
+
+	case SudoProbeResult:
+		return m.handleSudoProbe(msg)
 	}
 
 	return m, nil
@@ -167,6 +268,26 @@ func (m *PasswordPrompt) View() string {
 	return builder.String()
 }
 
+// handleSudoProbe skips the prompt entirely when sudo needs no password.
+func (m *PasswordPrompt) handleSudoProbe(msg SudoProbeResult) (tea.Model, tea.Cmd) {
+	if !msg.NoPassword {
+		m.fingerprint = msg.Fingerprint
+		m.polkit = msg.Polkit
+
+		return m, nil
+	}
+
+	m.completed = true
+
+	return m, func() tea.Msg {
+		return PasswordPromptResult{
+			Password:   "",
+			Operations: m.operations,
+			Cancelled:  false,
+		}
+	}
+}
+
 // handleKeyInput processes keyboard input for the password prompt.
 //
 
@@ -174,8 +295,22 @@ func (m *PasswordPrompt) handleKeyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case KeyCtrlC, "esc":
 		return m.handleCancelation()
+	}
+
+	// Ignore input while sudo is checking or after too many failures
+	if m.validating || m.locked {
+		return m, nil
+	}
+
+	switch msg.String() {
 	case "enter":
 		return m.handlePasswordSubmission()
+	case keyPolkit:
+		if m.polkit {
+			return m.handlePolkitValidation()
+		}
+
+		return m, nil
 	case "backspace":
 		return m.handleBackspace()
 	default:
@@ -203,12 +338,15 @@ func (m *PasswordPrompt) handleCancelation() (tea.Model, tea.Cmd) {
 //
 
 func (m *PasswordPrompt) handlePasswordSubmission() (tea.Model, tea.Cmd) {
-	if len(m.password) == 0 {
+	// An empty password lets pam_fprintd ask for a fingerprint instead
+	if len(m.password) == 0 && !m.fingerprint {
 		m.error = "Password cannot be empty"
 
 		return m, nil
 	}
 
+	m.validating = true
+
 	// Validate password immediately with sudo true
 	return m, func() tea.Msg {
 		return PasswordValidationMsg{
@@ -218,6 +356,28 @@ func (m *PasswordPrompt) handlePasswordSubmission() (tea.Model, tea.Cmd) {
 	}
 }
 
+// handlePolkitValidation authenticates through the desktop's polkit agent,
+// which shows its own dialog.
+func (m *PasswordPrompt) handlePolkitValidation() (tea.Model, tea.Cmd) {
+	m.validating = true
+	m.viaPolkit = true
+	m.password = ""
+	m.error = ""
+
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.ctx, 2*time.Minute)
+		defer cancel()
+
+		result := PasswordValidationResult{Valid: true, Operations: m.operations, Polkit: true}
+		if err := m.auth.ValidatePolkit(ctx); err != nil {
+			result.Valid = false
+			result.Error = "Authentication through the system dialog failed."
+		}
+
+		return result
+	}
+}
+
 // handleBackspace handles backspace key for character deletion.
 //
 
@@ -251,22 +411,26 @@ func (m *PasswordPrompt) handleCharacterInput(msg tea.KeyMsg) (tea.Model, tea.Cm
 
 func (m *PasswordPrompt) handlePasswordValidation(msg PasswordValidationMsg) (tea.Model, tea.Cmd) {
 	// Show validating message
-	m.error = "Validating password..."
+	m.error = ""
+
+	timeout := 10 * time.Second
+	if msg.Password == "" {
+		timeout = 30 * time.Second // Give the user time to reach the reader
+	}
 
 	return m, func() tea.Msg {
-		// Test password with sudo true
-		ctx, cancel := context.WithTimeout(m.ctx, 10*time.Second)
+		ctx, cancel := context.WithTimeout(m.ctx, timeout)
 		defer cancel()
 
 		// Use sudo -v to validate password - this always requires password verification
 		// even if recent sudo cache exists, unlike 'sudo true'
-		err := system.RunWithPassword(ctx, false, msg.Password, "-v")
+		err := m.auth.Validate(ctx, msg.Password)
 		if err != nil {
 			return PasswordValidationResult{
 				Valid:      false,
 				Password:   msg.Password,
 				Operations: msg.Operations,
-				Error:      "Invalid password. Please try again.",
+				Error:      "Authentication failed.",
 			}
 		}
 
@@ -292,17 +456,42 @@ func (m *PasswordPrompt) handlePasswordValidationResult(msg PasswordValidationRe
 				Password:   msg.Password,
 				Operations: msg.Operations,
 				Cancelled:  false,
+				Polkit:     msg.Polkit,
 			}
 		}
 	}
 
-	// Password is invalid - show error and let user try again
-	m.error = msg.Error
+	// A cancelled or failed polkit dialog doesn't use up a password attempt
+	if msg.Polkit {
+		m.validating = false
+		m.viaPolkit = false
+		m.error = msg.Error
+
+		return m, nil
+	}
+
+	// Password is invalid - count the attempt and let user try again
+	m.validating = false
+	m.attempts++
 	m.password = "" // Clear the invalid password
 
+	if m.attempts >= maxPasswordAttempts {
+		m.locked = true
+		m.error = fmt.Sprintf("%d incorrect password attempts. Press Esc to go back.", m.attempts)
+
+		return m, nil
+	}
+
+	m.error = fmt.Sprintf("%s Please try again (attempt %d of %d).", msg.Error, m.attempts+1, maxPasswordAttempts)
+
 	return m, nil
 }
 
+// Attempts returns how many password validations have failed.
+func (m *PasswordPrompt) Attempts() int {
+	return m.attempts
+}
+
 // renderHeader creates the header with clean style matching other screens.
 func (m *PasswordPrompt) renderHeader() string {
 	// Left side: App name » Current location
@@ -368,6 +557,12 @@ func (m *PasswordPrompt) renderContent() string {
 		case StateUninstall:
 			icon = "✗"
 
+		case StateUpdate:
+			icon = "↑"
+
+		case StateReinstall:
+			icon = "↻"
+
 		default:
 			icon = "○"
 		}
@@ -402,6 +597,30 @@ func (m *PasswordPrompt) renderContent() string {
 	builder.WriteString(inputStyle.Render(passwordDisplay))
 	builder.WriteString("\n")
 
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted)
+
+	switch {
+	case m.viaPolkit:
+		builder.WriteString(hintStyle.Render("Authenticate in the system dialog..."))
+		builder.WriteString("\n")
+	case m.validating && m.password == "":
+		builder.WriteString(hintStyle.Render("Touch the fingerprint reader to authenticate..."))
+		builder.WriteString("\n")
+	case m.validating:
+		builder.WriteString(hintStyle.Render("Validating credentials..."))
+		builder.WriteString("\n")
+	case m.fingerprint && m.password == "":
+		builder.WriteString(hintStyle.Render("Press Enter with an empty password to use your fingerprint."))
+		builder.WriteString("\n")
+	}
+
+	if m.polkit && !m.validating {
+		builder.WriteString(hintStyle.Render("Press Ctrl+P to authenticate in the system dialog instead."))
+		builder.WriteString("\n")
+		builder.WriteString(hintStyle.Render(polkitPerStepHint))
+		builder.WriteString("\n")
+	}
+
 	// Error message
 	if m.error != "" {
 		errorStyle := lipgloss.NewStyle().
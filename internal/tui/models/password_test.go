@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSudo is a scripted SudoAuthenticator.
+type stubSudo struct {
+	noPassword  bool
+	fingerprint bool
+	polkit      bool
+	polkitErr   error
+	valid       string
+}
+
+func (s stubSudo) Validate(_ context.Context, password string) error {
+	if password != s.valid {
+		return errors.New("sorry, try again")
+	}
+
+	return nil
+}
+
+func (s stubSudo) NoPasswordRequired(context.Context) bool { return s.noPassword }
+
+func (s stubSudo) FingerprintAvailable() bool { return s.fingerprint }
+
+func (s stubSudo) PolkitAvailable() bool { return s.polkit }
+
+func (s stubSudo) ValidatePolkit(context.Context) error { return s.polkitErr }
+
+func newTestPasswordPrompt(auth SudoAuthenticator) *PasswordPrompt {
+	prompt := NewPasswordPrompt(context.Background(), styles.New(), []SelectedOperation{{AppName: "vlc", Operation: StateInstall}})
+	prompt.SetAuthenticator(auth)
+
+	return prompt
+}
+
+// submit types the password, presses enter and runs the validation round trip.
+func submit(t *testing.T, prompt *PasswordPrompt, password string) tea.Msg {
+	t.Helper()
+
+	for _, r := range password {
+		prompt.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	_, cmd := prompt.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	_, cmd = prompt.Update(cmd())
+	require.NotNil(t, cmd)
+
+	_, cmd = prompt.Update(cmd())
+	if cmd == nil {
+		return nil
+	}
+
+	return cmd()
+}
+
+func TestPrivilegeMessageNamesEachOperation(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Installing 1 applications requires administrator privileges.",
+		privilegeMessage([]SelectedOperation{{AppKey: "vlc", Operation: StateInstall}}))
+	assert.Equal(t, "Installing 1 applications, updating 2 applications and reinstalling 1 applications requires administrator privileges.",
+		privilegeMessage([]SelectedOperation{
+			{AppKey: "git", Operation: StateUpdate},
+			{AppKey: "vlc", Operation: StateInstall},
+			{AppKey: "gimp", Operation: StateReinstall},
+			{AppKey: "code", Operation: StateUpdate},
+		}))
+	assert.Equal(t, "Processing 1 applications requires administrator privileges.",
+		privilegeMessage([]SelectedOperation{{AppKey: "vlc"}}))
+}
+
+func TestPasswordPromptSkipsWhenSudoNeedsNoPassword(t *testing.T) {
+	t.Parallel()
+
+	prompt := newTestPasswordPrompt(stubSudo{noPassword: true})
+
+	_, cmd := prompt.Update(prompt.probeSudo()())
+	require.NotNil(t, cmd)
+
+	result, ok := cmd().(PasswordPromptResult)
+	require.True(t, ok)
+	assert.False(t, result.Cancelled)
+	assert.Empty(t, result.Password)
+}
+
+func TestPasswordPromptCountsAttemptsAndLocks(t *testing.T) {
+	t.Parallel()
+
+	prompt := newTestPasswordPrompt(stubSudo{valid: "secret"})
+
+	assert.Nil(t, submit(t, prompt, "wrong"))
+	assert.Equal(t, 1, prompt.Attempts())
+	assert.Contains(t, prompt.error, "attempt 2 of 3")
+
+	result, ok := submit(t, prompt, "secret").(PasswordPromptResult)
+	require.True(t, ok)
+	assert.Equal(t, "secret", result.Password)
+
+	locked := newTestPasswordPrompt(stubSudo{valid: "secret"})
+	for range maxPasswordAttempts {
+		submit(t, locked, "wrong")
+	}
+
+	assert.True(t, locked.locked)
+
+	// Further input is ignored once locked
+	_, cmd := locked.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+}
+
+func TestPasswordPromptEmptyPasswordUsesFingerprint(t *testing.T) {
+	t.Parallel()
+
+	prompt := newTestPasswordPrompt(stubSudo{})
+
+	_, cmd := prompt.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Equal(t, "Password cannot be empty", prompt.error)
+
+	prompt = newTestPasswordPrompt(stubSudo{fingerprint: true, valid: ""})
+	prompt.Update(prompt.probeSudo()())
+
+	result, ok := submit(t, prompt, "").(PasswordPromptResult)
+	require.True(t, ok)
+	assert.False(t, result.Cancelled)
+}
+
+func TestPasswordPromptAuthenticatesThroughPolkit(t *testing.T) {
+	t.Parallel()
+
+	prompt := newTestPasswordPrompt(stubSudo{polkit: true, polkitErr: errors.New("dismissed")})
+
+	// Without a polkit agent the key does nothing
+	_, cmd := prompt.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	assert.Nil(t, cmd)
+
+	prompt.Update(prompt.probeSudo()())
+	assert.Contains(t, prompt.renderContent(), polkitPerStepHint, "the user is told each step asks again")
+
+	_, cmd = prompt.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	require.NotNil(t, cmd)
+	assert.True(t, prompt.validating)
+
+	_, cmd = prompt.Update(cmd())
+	assert.Nil(t, cmd)
+	assert.Equal(t, "Authentication through the system dialog failed.", prompt.error)
+	assert.Zero(t, prompt.Attempts(), "a dismissed dialog isn't a wrong password")
+
+	prompt.SetAuthenticator(stubSudo{polkit: true})
+
+	_, cmd = prompt.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	require.NotNil(t, cmd)
+
+	_, cmd = prompt.Update(cmd())
+	require.NotNil(t, cmd)
+
+	result, ok := cmd().(PasswordPromptResult)
+	require.True(t, ok)
+	assert.True(t, result.Polkit)
+	assert.Empty(t, result.Password)
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"testing"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogFollowsInstallPolicy(t *testing.T) {
+	t.Parallel()
+
+	adapter := &appCatalogAdapter{policy: domain.InstallPolicy{DenyLicenses: []domain.License{domain.LicenseProprietary}}}
+
+	keys := map[string]bool{}
+
+	for _, cat := range adapter.getAllCategoriesFast() {
+		for _, application := range cat.Applications {
+			keys[application.Key] = true
+		}
+	}
+
+	assert.True(t, keys["vlc"])
+	assert.False(t, keys["spotify"], "proprietary apps are filtered out")
+
+	all := (&appCatalogAdapter{}).getAllCategoriesFast()
+	for _, cat := range all {
+		for _, application := range cat.Applications {
+			if application.Key == "spotify" {
+				assert.Equal(t, domain.LicenseProprietary, application.License)
+			}
+		}
+	}
+}
+
+func TestLicenseBadge(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+
+	assert.Equal(t, " ", model.licenseBadge(app{Key: "vlc"}))
+	assert.Contains(t, model.licenseBadge(app{Key: "spotify", License: domain.LicenseProprietary}), StatusProprietary)
+}
@@ -10,7 +10,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/progress"
@@ -18,17 +21,24 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/adapters/system"
 	"github.com/janderssonse/karei/internal/adapters/ubuntu"
+	"github.com/janderssonse/karei/internal/application"
 	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/config"
 	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/stringutil"
 	"github.com/janderssonse/karei/internal/tui/styles"
-	"github.com/janderssonse/karei/internal/uninstall"
+	"github.com/janderssonse/karei/internal/xdg"
 )
 
 // Constants for progress messages.
 const (
 	msgUninstallationComplete = "Uninstallation complete"
 
+	// maxAutoRetries bounds automatic retries of transient failures per task.
+	maxAutoRetries = 2
+
 	// UI layout constants.
 	maxProgressWidth        = 100
 	progressBarPadding      = 50
@@ -48,6 +58,7 @@ var (
 type ProgressData struct {
 	Operations []SelectedOperation
 	Password   string
+	Polkit     bool // Privileged commands go through pkexec instead of sudo
 }
 
 // InstallTask represents a single installation or uninstallation task.
@@ -62,6 +73,8 @@ type InstallTask struct {
 	ETA         string
 	Duration    time.Duration
 	Error       string
+	Kind        domain.ErrorKind // Classified cause of Error
+	Attempts    int              // Automatic retries already made
 }
 
 // ProgressMsg represents progress updates.
@@ -79,6 +92,12 @@ type CompletedMsg struct {
 	Success  bool
 	Duration time.Duration
 	Error    string
+	Kind     domain.ErrorKind
+}
+
+// InstallOutputMsg carries a line the installer printed for the current task.
+type InstallOutputMsg struct {
+	Line string
 }
 
 // ProgressUpdateMsg carries progress updates for individual tasks.
@@ -115,19 +134,30 @@ type Progress struct {
 	overallProgress float64
 	spinner         spinner.Model
 	progressBars    map[string]progress.Model
-	logs            []string
+	log             operationLog
 	quitting        bool
 	completed       bool
 	startTime       time.Time
 	paused          bool
-	showingLogs     bool // For floating log viewer
+	logViewer       *logViewer // Modal with the complete log
 
 	// Context for cancellation and timeout propagation
 	ctx context.Context
 
 	// Hexagonal architecture integration
-	packageInstaller domain.PackageInstaller
-	uninstaller      *uninstall.Uninstaller
+	packages *application.PackageManager
+	runner   *platform.CommandRunner // Runs the installers' commands, for privilege settings
+	journal  *application.Journal    // Records outcomes so failures can be retried later
+
+	diskFreeStart uint64    // Free bytes before the run, for the Results screen
+	finishedAt    time.Time // When the last task finished
+
+	estimates   map[string]time.Duration // Expected install duration by task, from earlier installs
+	taskStarted time.Time                // When the current task started
+
+	output chan string    // Lines the installer prints, read by listenForOutput
+	parser progressParser // Reads progress from the output of the current task's install method
+	notes  *installNotes  // What is left to do by hand, for the Results screen
 
 	// Track operations for immediate status sync on navigation
 	operations []SelectedOperation
@@ -157,6 +187,12 @@ func NewProgressWithOperationsAndPassword(ctx context.Context, styleConfig *styl
 		case StateUninstall:
 			description = fmt.Sprintf("Uninstalling %s...", operationItem.AppName)
 			operation = OperationUninstall
+		case StateUpdate:
+			description = fmt.Sprintf("Updating %s...", operationItem.AppName)
+			operation = OperationUpdate
+		case StateReinstall:
+			description = fmt.Sprintf("Reinstalling %s...", operationItem.AppName)
+			operation = OperationReinstall
 		default:
 			description = fmt.Sprintf("Processing %s...", operationItem.AppName)
 			operation = "unknown"
@@ -225,35 +261,129 @@ func createProgressModel(ctx context.Context, styleConfig *styles.Styles, tasks
 	commandRunner := platform.NewTUICommandRunner(false, false)                                 // verbose=false, dryRun=false, tuiMode=true
 	fileManager := platform.NewFileManager(false)                                               // verbose=false
 	packageInstaller := ubuntu.NewTUIPackageInstaller(commandRunner, fileManager, false, false) // verbose=false, dryRun=false, tuiMode=true
-	// Note: Password handling will be managed by the command runner
 
-	// Create uninstaller with password support
-	uninstaller := uninstall.NewUninstaller(false) // verbose=false
+	// Sudo operations read the password from stdin instead of prompting
 	if password != "" {
-		uninstaller.SetPassword(password)
+		commandRunner.SetPassword(password)
+	}
+
+	// Installer output drives the progress bars; lines are dropped rather
+	// than holding up the installer when the TUI falls behind
+	output := make(chan string, outputBuffer)
+	commandRunner.SetOutputFunc(func(line string) {
+		select {
+		case output <- line:
+		default:
+		}
+	})
+
+	// Install and removal go through the application layer, same as the CLI
+	uninstaller := application.NewUninstallService(fileManager, commandRunner, packageInstaller, false) // verbose=false
+	packages := application.NewPackageManager(packageInstaller, uninstaller, false)
+
+	preference := configuredMethodPreference()
+	uninstaller.SetMethodPreference(preference)
+	uninstaller.SetTrash(platform.NewTrash(xdg.TrashDir()))
+
+	records := application.NewInstallRecords(fileManager, xdg.InstallRecordsFile())
+	uninstaller.SetInstallRecords(records)
+	packages.SetInstallRecords(records)
+
+	manifests := application.NewManifests(fileManager, xdg.ManifestDir())
+	uninstaller.SetManifests(manifests)
+	packages.SetManifests(manifests)
+	packages.SetInstallMetrics(application.NewInstallMetrics(fileManager, xdg.MetricsFile()))
+
+	if home, err := os.UserHomeDir(); err == nil {
+		uninstaller.SetHomeDir(home)
+	}
+	packages.SetMethodPreference(preference)
+	packages.SetFlatpakScopes(configuredFlatpakScopes())
+	packages.SetInstallPolicy(configuredInstallPolicy())
+	packages.SetAPTFrontend(configuredAPTFrontend(commandRunner, fileManager))
+
+	if timeout, err := configuredPreferences().APTLockTimeout(); err == nil {
+		packages.SetLockTimeout(timeout)
+		uninstaller.SetLockTimeout(timeout)
 	}
 
+	// Apps building kernel modules get the kernel's headers and DKMS first,
+	// and what is left to do by hand, such as enrolling a signing key, is
+	// listed with the results
+	notes := &installNotes{}
+	packages.SetKernelInspector(platform.NewKernelInspector(commandRunner))
+	packages.SetNoteFunc(notes.add)
+
 	return &Progress{
 		styles:       styleConfig,
 		tasks:        tasks,
 		currentTask:  0,
 		spinner:      sSpinner,
 		progressBars: progressBars,
-		logs:         make([]string, 0, 10), // Capacity of 10 since we keep only last 10 entries
+		logViewer:    newLogViewer(styleConfig),
+		output:       output,
+		notes:        notes,
 		startTime:    time.Now(),
 		ctx:          ctx, // Store context for proper propagation
 
 		// Initialize hexagonal architecture systems
-		packageInstaller: packageInstaller,
-		uninstaller:      uninstaller,
+		packages: packages,
+		runner:   commandRunner,
+		journal:  application.NewJournal(fileManager, xdg.JournalFile()),
+
+		diskFreeStart: system.FreeDiskSpace(diskUsagePaths()...),
+	}
+}
+
+// diskUsagePaths returns where installs land: the root filesystem and the home directory.
+func diskUsagePaths() []string {
+	paths := []string{"/"}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, home)
 	}
+
+	return paths
+}
+
+// SetPackageManager replaces the facade used to run operations. Must be called before Init.
+func (m *Progress) SetPackageManager(packages *application.PackageManager) {
+	m.packages = packages
+}
+
+// SetEventPublisher sets where the package manager publishes the installs,
+// removals and updates of the run.
+func (m *Progress) SetEventPublisher(publisher domain.EventPublisher) {
+	m.packages.SetEventPublisher(publisher)
+}
+
+// UsePolkit runs the privileged commands of the run through pkexec, for a
+// user who authenticated with the desktop's polkit agent. The agent asks
+// again for every privileged command.
+func (m *Progress) UsePolkit() {
+	m.runner.SetPolkit(true)
+}
+
+// SetJournal replaces the journal that records finished runs. Nil disables recording.
+func (m *Progress) SetJournal(journal *application.Journal) {
+	m.journal = journal
 }
 
 // Init initializes the progress model.
 func (m *Progress) Init() tea.Cmd {
+	// The complete log goes to a file of its own, for `karei logs last`.
+	// Simulated runs aren't logged, like they aren't journaled.
+	if m.packages == nil || !m.packages.IsDryRun() {
+		if err := m.log.open(xdg.OperationLogDir()); err != nil {
+			m.addLog(LogWarn, "", "Could not write the log file: "+err.Error())
+		}
+	}
+
+	m.logViewer.source = m.log.path()
+
 	return tea.Batch(
 		m.spinner.Tick,
 		m.executeInstallations(), // Start actual installation
+		m.listenForOutput(),
 	)
 }
 
@@ -267,6 +397,8 @@ func (m *Progress) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleProgressMsg(msg)
 	case ProgressUpdateMsg:
 		return m.handleProgressUpdateMsg(msg)
+	case InstallOutputMsg:
+		return m.handleInstallOutput(msg)
 	case CompletedMsg:
 		return m.handleCompleted(msg)
 	case UninstallStageMsg:
@@ -286,6 +418,74 @@ func (m *Progress) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// listenForOutput waits for the next line the installer prints.
+func (m *Progress) listenForOutput() tea.Cmd {
+	if m.output == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		select {
+		case line := <-m.output:
+			return InstallOutputMsg{Line: line}
+		case <-m.ctx.Done():
+			return nil
+		}
+	}
+}
+
+// handleInstallOutput moves the current task's progress bar by what its
+// installer printed. Lines telling nothing about progress go to the log.
+func (m *Progress) handleInstallOutput(msg InstallOutputMsg) (tea.Model, tea.Cmd) {
+	if !m.isValidTaskIndex(m.currentTask) {
+		return m, m.listenForOutput()
+	}
+
+	task := &m.tasks[m.currentTask]
+	if task.Status == TaskStatusCompleted || task.Status == TaskStatusFailed {
+		return m, m.listenForOutput()
+	}
+
+	var (
+		progress outputProgress
+		parsed   bool
+	)
+
+	if m.parser != nil {
+		progress, parsed = m.parser(msg.Line, appDisplayName(task.Name))
+	}
+
+	if !parsed {
+		m.addLog(LogInfo, task.Name, strings.TrimSpace(msg.Line))
+
+		return m, m.listenForOutput()
+	}
+
+	// Redrawn progress lines only reach the log when the stage changes
+	if progress.Message != "" && progress.Message != task.Status {
+		task.Status = progress.Message
+		m.addLog(LogInfo, task.Name, progress.Message)
+	}
+
+	task.Progress = max(task.Progress, progress.taskProgress())
+	task.Speed = progress.Speed
+	task.ETA = progress.ETA
+
+	m.updateProgressBar(m.currentTask, task.Progress)
+	m.updateOverallProgress()
+
+	return m, m.listenForOutput()
+}
+
+// appDisplayName returns the catalog name of an app, or its key.
+func appDisplayName(appKey string) string {
+	if app, exists := apps.Apps[appKey]; exists {
+		return app.Name
+	}
+
+	return appKey
+}
+
 func (m *Progress) handleProgressMsg(msg ProgressMsg) (tea.Model, tea.Cmd) {
 	// Find the task by name and update its progress
 	for i := range m.tasks {
@@ -318,12 +518,7 @@ func (m *Progress) handleProgressUpdateMsg(msg ProgressUpdateMsg) (tea.Model, te
 
 		// Add log entry for progress stages
 		if msg.Message != "" {
-			m.logs = append(m.logs, msg.Message)
-
-			// Keep only last 10 log entries
-			if len(m.logs) > 10 {
-				m.logs = m.logs[len(m.logs)-10:]
-			}
+			m.addLog(LogInfo, m.tasks[msg.TaskIndex].Name, msg.Message)
 		}
 
 		// Update overall progress
@@ -339,11 +534,42 @@ func (m *Progress) handleCompleted(msg CompletedMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// Continue with next task if not completed
+	var cmd tea.Cmd
 	if !m.completed {
-		return m, m.executeNextTask()
+		cmd = m.executeNextTask()
 	}
 
-	return m, nil
+	if m.completed {
+		m.finishedAt = time.Now()
+		m.recordJournal()
+		m.log.close()
+	}
+
+	return m, cmd
+}
+
+// recordJournal stores the outcome of every task so `karei retry --last` and
+// the history screen can pick them up.
+func (m *Progress) recordJournal() {
+	if m.journal == nil || (m.packages != nil && m.packages.IsDryRun()) {
+		return
+	}
+
+	run := &application.JournalRun{Started: m.startTime}
+	for _, task := range m.tasks {
+		run.Entries = append(run.Entries, application.JournalEntry{
+			App:       task.Name,
+			Operation: task.Operation,
+			Success:   task.Status == TaskStatusCompleted,
+			Error:     task.Error,
+			Kind:      task.Kind,
+			Duration:  task.Duration,
+		})
+	}
+
+	if err := m.journal.Record(run); err != nil {
+		m.appendLog("Could not record run: " + err.Error())
+	}
 }
 
 func (m *Progress) handleUninstallStage(msg UninstallStageMsg) (tea.Model, tea.Cmd) {
@@ -403,12 +629,7 @@ func (m *Progress) updateProgressBar(taskIndex int, progress float64) {
 
 // addStageLogEntry adds a log entry for an uninstall stage.
 func (m *Progress) addStageLogEntry(stage int, status, appName string) {
-	logMsg := fmt.Sprintf("Stage %d: %s (%s)", stage, status, appName)
-
-	m.logs = append(m.logs, logMsg)
-	if len(m.logs) > 10 {
-		m.logs = m.logs[len(m.logs)-10:]
-	}
+	m.addLog(LogInfo, appName, fmt.Sprintf("Stage %d: %s (%s)", stage, status, appName))
 }
 
 // createNextStageCmd creates a command for the next uninstall stage.
@@ -424,6 +645,11 @@ func (m *Progress) createNextStageCmd(msg UninstallStageMsg) tea.Cmd {
 }
 
 func (m *Progress) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The log viewer takes every key while open, except to cancel the run
+	if m.logViewer.visible && msg.String() != "ctrl+c" {
+		return m, m.logViewer.Update(msg)
+	}
+
 	switch msg.String() {
 	case "ctrl+c", "q":
 		return m.handleQuit()
@@ -431,6 +657,10 @@ func (m *Progress) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handlePauseToggle()
 	case "l":
 		return m.handleLogToggle()
+	case "r":
+		return m.handleRetryFailed()
+	case KeyEnter:
+		return m.handleShowResults()
 	case KeyEsc:
 		return m.handleEscape()
 	}
@@ -450,8 +680,107 @@ func (m *Progress) handlePauseToggle() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleShowResults opens the Results screen once every task has finished.
+func (m *Progress) handleShowResults() (tea.Model, tea.Cmd) {
+	if !m.completed {
+		return m, nil
+	}
+
+	data := m.resultsData()
+
+	return m, func() tea.Msg {
+		return NavigateMsg{Screen: ResultsScreen, Data: data}
+	}
+}
+
+// installNotes collects what installs leave to do by hand. Notes arrive from
+// the goroutine running the install.
+type installNotes struct {
+	mu    sync.Mutex
+	notes []string
+}
+
+// add records note once.
+func (n *installNotes) add(note string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !slices.Contains(n.notes, note) {
+		n.notes = append(n.notes, note)
+	}
+}
+
+// list returns the notes so far; none for a nil list.
+func (n *installNotes) list() []string {
+	if n == nil {
+		return nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return slices.Clone(n.notes)
+}
+
+// resultsData collects what the Results screen shows about the finished run.
+func (m *Progress) resultsData() ResultsData {
+	tasks := make([]InstallTask, len(m.tasks))
+	copy(tasks, m.tasks)
+
+	finished := m.finishedAt
+	if finished.IsZero() {
+		finished = time.Now()
+	}
+
+	var diskUsed int64
+	if m.diskFreeStart > 0 {
+		diskUsed = int64(m.diskFreeStart) - int64(system.FreeDiskSpace(diskUsagePaths()...)) //nolint:gosec // Free space fits in int64
+	}
+
+	return ResultsData{
+		Tasks:      tasks,
+		Operations: m.operations,
+		Duration:   finished.Sub(m.startTime),
+		DiskUsed:   diskUsed,
+		NextSteps:  append(m.notes.list(), nextStepsFor(tasks)...),
+		Shell:      shellSetupFor(installedApps(tasks)),
+	}
+}
+
+// handleRetryFailed re-queues the failed tasks of a finished run.
+func (m *Progress) handleRetryFailed() (tea.Model, tea.Cmd) {
+	if !m.completed || !m.hasFailedTasks() {
+		return m, nil
+	}
+
+	retried := 0
+
+	for i := range m.tasks {
+		if m.tasks[i].Status != TaskStatusFailed {
+			continue
+		}
+
+		m.tasks[i].Status = TaskStatusPending
+		m.tasks[i].Progress = 0
+		m.tasks[i].Error = ""
+		m.tasks[i].Kind = domain.ErrorKindUnknown
+		m.tasks[i].Attempts = 0
+		m.updateProgressBar(i, 0)
+
+		retried++
+	}
+
+	m.completed = false
+	m.appendLog(fmt.Sprintf("Retrying %d failed tasks", retried))
+	m.updateOverallProgress()
+
+	return m, m.executeNextTask()
+}
+
 func (m *Progress) handleLogToggle() (tea.Model, tea.Cmd) {
-	m.showingLogs = !m.showingLogs
+	m.logViewer.toggle()
+	m.logViewer.setSize(m.width, m.height)
+	m.logViewer.setLines(m.log.lines())
 
 	return m, nil
 }
@@ -471,6 +800,7 @@ func (m *Progress) handleEscape() (tea.Model, tea.Cmd) {
 func (m *Progress) handleWindowSizeMsg(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.width = msg.Width
 	m.height = msg.Height
+	m.logViewer.setSize(msg.Width, msg.Height)
 
 	return m, nil
 }
@@ -493,6 +823,10 @@ func (m *Progress) handleCompletedTask(msg CompletedMsg) tea.Model {
 			m.tasks[taskIndex].Duration = msg.Duration
 			m.addToLogs(taskIndex, msg)
 
+			if !msg.Success {
+				m.applyErrorAction(taskIndex)
+			}
+
 			break
 		}
 	}
@@ -508,9 +842,11 @@ func (m *Progress) handleCompletedTask(msg CompletedMsg) tea.Model {
 //
 //nolint:funcorder // Helper method grouped with related functionality
 func (m *Progress) addToLogs(taskIndex int, msg CompletedMsg) {
-	// Clean, user-friendly format without timestamps
+	// Clean, user-friendly format; the log adds the time
 	var logEntry string
 
+	level := LogInfo
+
 	if msg.Success {
 		// "Chrome installation completed"
 		logEntry = fmt.Sprintf("%s%s",
@@ -518,17 +854,17 @@ func (m *Progress) addToLogs(taskIndex int, msg CompletedMsg) {
 			m.getSuccessMessage(m.tasks[taskIndex].Operation))
 	} else {
 		// "Chrome installation failed: error details"
+		level = LogError
 		logEntry = fmt.Sprintf("%s%s",
 			m.tasks[taskIndex].Name,
 			m.getFailureMessage(m.tasks[taskIndex].Operation, msg.Error))
-	}
-
-	m.logs = append(m.logs, logEntry)
 
-	// Keep only last 10 log entries
-	if len(m.logs) > 10 {
-		m.logs = m.logs[len(m.logs)-10:]
+		if hint := msg.Kind.Hint(); hint != "" {
+			logEntry += " → " + hint
+		}
 	}
+
+	m.addLog(level, m.tasks[taskIndex].Name, logEntry)
 }
 
 // View renders the progress screen.
@@ -551,11 +887,9 @@ func (m *Progress) View() string {
 
 	baseView := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
-	// If showing logs, overlay floating log viewer
-	if m.showingLogs {
-		floatingLogs := m.renderFloatingLogViewer()
-		// Place floating logs over the base view (simplified overlay)
-		return lipgloss.JoinVertical(lipgloss.Left, baseView, "", floatingLogs)
+	// The log viewer is a modal over the whole screen
+	if m.logViewer.visible {
+		return m.logViewer.View()
 	}
 
 	// Use lipgloss to compose layout with consistent spacing
@@ -566,8 +900,13 @@ func (m *Progress) View() string {
 
 // getSuccessMessage returns the appropriate success message for an operation.
 func (m *Progress) getSuccessMessage(operation string) string {
-	if operation == OperationUninstall {
+	switch operation {
+	case OperationUninstall:
 		return " uninstalled"
+	case OperationUpdate:
+		return " updated"
+	case OperationReinstall:
+		return " reinstalled"
 	}
 
 	return " installation completed"
@@ -575,8 +914,13 @@ func (m *Progress) getSuccessMessage(operation string) string {
 
 // getFailureMessage returns the appropriate failure message for an operation.
 func (m *Progress) getFailureMessage(operation, errorMsg string) string {
-	if operation == OperationUninstall {
+	switch operation {
+	case OperationUninstall:
 		return " uninstallation failed: " + errorMsg
+	case OperationUpdate:
+		return " update failed: " + errorMsg
+	case OperationReinstall:
+		return " reinstall failed: " + errorMsg
 	}
 
 	return " installation failed: " + errorMsg
@@ -624,13 +968,17 @@ func (m *Progress) getHeaderLocation() string {
 		return "Karei » Operations Complete"
 	}
 
-	installCount, uninstallCount := m.getOperationCounts()
-	if uninstallCount > 0 && installCount == 0 {
-		return "Karei » Uninstalling Applications"
-	}
+	counts := m.getOperationCounts()
 
-	if installCount > 0 && uninstallCount > 0 {
+	switch {
+	case len(counts) > 1:
 		return "Karei » Processing Applications"
+	case counts[OperationUninstall] > 0:
+		return "Karei » Uninstalling Applications"
+	case counts[OperationUpdate] > 0:
+		return "Karei » Updating Applications"
+	case counts[OperationReinstall] > 0:
+		return "Karei » Reinstalling Applications"
 	}
 
 	return "Karei » Installing Applications"
@@ -757,11 +1105,11 @@ func (m *Progress) buildTaskProgressSection(availableWidth int) []string {
 // buildTimeSection creates the time information display.
 func (m *Progress) buildTimeSection() []string {
 	elapsed := time.Since(m.startTime)
-	timeInfo := fmt.Sprintf("Elapsed: %s", elapsed.Round(time.Second))
+	timeInfo := "Elapsed: " + stringutil.FormatDuration(elapsed.Round(time.Second))
 
-	if !m.completed && m.overallProgress > 0 && m.overallProgress < 1.0 {
-		eta := time.Duration(float64(elapsed) / m.overallProgress * (1 - m.overallProgress))
-		timeInfo += fmt.Sprintf(" • ETA: %s", eta.Round(time.Second))
+	// Only apps installed before are estimated, so the ETA is a lower bound
+	if remaining, ok := m.remainingEstimate(); ok && !m.completed {
+		timeInfo += " • ETA: ~" + stringutil.FormatDuration(remaining.Round(time.Second))
 	}
 
 	timeStyled := lipgloss.NewStyle().Foreground(m.styles.Muted).Render(timeInfo)
@@ -795,13 +1143,19 @@ func (m *Progress) hasFailedTasks() bool {
 
 // getProgressBorderTitle returns the title for the progress border.
 func (m *Progress) getProgressBorderTitle() string {
-	installCount, uninstallCount := m.getOperationCounts()
+	counts := m.getOperationCounts()
 
 	switch {
-	case installCount > 0 && uninstallCount > 0:
+	case len(counts) == 2 && counts[OperationInstall] > 0 && counts[OperationUninstall] > 0:
 		return "Installation/Deinstallation Progress"
-	case uninstallCount > 0:
+	case len(counts) > 1:
+		return "Operations Progress"
+	case counts[OperationUninstall] > 0:
 		return "Deinstallation Progress"
+	case counts[OperationUpdate] > 0:
+		return "Update Progress"
+	case counts[OperationReinstall] > 0:
+		return "Reinstallation Progress"
 	default:
 		return "Installation Progress"
 	}
@@ -897,13 +1251,17 @@ func (m *Progress) getTaskNameStyle(taskIndex int, status string) lipgloss.Style
 func (m *Progress) getTaskStatusText(task InstallTask) string {
 	switch {
 	case task.Status == TaskStatusCompleted:
-		return fmt.Sprintf("100%% (%s)", task.Duration.Round(time.Second))
+		return "100% (" + stringutil.FormatDuration(task.Duration.Round(time.Second)) + ")"
+	case task.Status == TaskStatusFailed && task.Kind != domain.ErrorKindUnknown:
+		return fmt.Sprintf("Failed (%s)", task.Kind)
 	case task.Status == TaskStatusFailed:
 		return "Failed"
 	case task.Progress > 0:
 		statusText := fmt.Sprintf("%.0f%%", task.Progress*100)
 		if task.ETA != "" {
 			statusText += " • " + task.ETA
+		} else if estimate, ok := m.estimates[task.Name]; ok && task.Status == TaskStatusInstalling {
+			statusText += " • ~" + stringutil.FormatDuration(max(estimate-time.Since(m.taskStarted), 0).Round(time.Second)) + " left"
 		}
 
 		return statusText
@@ -914,7 +1272,7 @@ func (m *Progress) getTaskStatusText(task InstallTask) string {
 
 // renderLogs creates the recent activity log display with scrolling capability.
 func (m *Progress) renderLogs() string {
-	if len(m.logs) == 0 {
+	if m.log.len() == 0 {
 		return ""
 	}
 
@@ -939,9 +1297,9 @@ func (m *Progress) renderLogs() string {
 	// Add scroll indicator if there are more logs
 	scrollIndicator := ""
 
-	if len(m.logs) > availableHeight {
-		hiddenCount := len(m.logs) - availableHeight
-		scrollIndicator = fmt.Sprintf(" (+%d more)", hiddenCount)
+	if m.log.len() > availableHeight {
+		hiddenCount := m.log.len() - availableHeight
+		scrollIndicator = fmt.Sprintf(" (+%d more, l to view)", hiddenCount)
 	}
 
 	// Use Lipgloss border with title and scroll indicator
@@ -962,16 +1320,9 @@ func (m *Progress) renderLogs() string {
 		Render(styledContent)
 }
 
-// getDisplayLogs returns the logs that should be displayed based on available height.
+// getDisplayLogs returns the most recent logs that fit the available height.
 func (m *Progress) getDisplayLogs(availableHeight int) []string {
-	if len(m.logs) <= availableHeight {
-		// All logs fit, show them all
-		return m.logs
-	}
-	// More logs than fit, show the most recent ones
-	startIdx := len(m.logs) - availableHeight
-
-	return m.logs[startIdx:]
+	return m.log.recent(availableHeight)
 }
 
 // wrapLogLines wraps log lines and ensures proper height.
@@ -1022,6 +1373,11 @@ func (m *Progress) renderFooter() string {
 		actions = append(actions, FooterAction{Key: "q", Action: "Cancel"})
 	} else {
 		actions = append(actions, FooterAction{Key: "Enter", Action: "Continue"})
+
+		if m.hasFailedTasks() {
+			actions = append(actions, FooterAction{Key: "r", Action: "Retry failed"})
+		}
+
 		actions = append(actions, FooterAction{Key: "Esc", Action: "Back"})
 		actions = append(actions, FooterAction{Key: "q", Action: "Quit"})
 	}
@@ -1072,10 +1428,59 @@ func (m *Progress) executeInstallations() tea.Cmd {
 		return nil
 	}
 
+	if m.estimates == nil {
+		m.estimates = m.estimateTasks()
+	}
+
 	// Start the first task
 	return m.executeNextTask()
 }
 
+// estimateTasks looks up how long each install task took before.
+func (m *Progress) estimateTasks() map[string]time.Duration {
+	estimates := make(map[string]time.Duration)
+
+	for _, task := range m.tasks {
+		if task.Operation != OperationInstall {
+			continue
+		}
+
+		if estimate, ok := m.packages.Estimate(task.Name); ok {
+			estimates[task.Name] = estimate
+		}
+	}
+
+	return estimates
+}
+
+// remainingEstimate sums the expected duration of the tasks not yet done,
+// less the time the current one has run. It reports false when none of
+// them has history.
+func (m *Progress) remainingEstimate() (time.Duration, bool) {
+	var remaining time.Duration
+
+	known := false
+
+	for taskIndex, task := range m.tasks {
+		estimate, ok := m.estimates[task.Name]
+
+		switch {
+		case !ok:
+			continue
+		case task.Status == TaskStatusPending:
+			remaining += estimate
+		case taskIndex == m.currentTask && task.Status == TaskStatusInstalling:
+			remaining += max(estimate-time.Since(m.taskStarted), 0)
+		default:
+			continue
+		}
+
+		known = true
+	}
+
+	return remaining, known
+}
+
 // executeNextTask executes the next pending task.
 func (m *Progress) executeNextTask() tea.Cmd {
 	// Find next pending task
@@ -1089,6 +1494,10 @@ func (m *Progress) executeNextTask() tea.Cmd {
 				return m.executeInstallTask(task.Name, taskIndex)
 			case OperationUninstall:
 				return m.executeUninstallTask(task.Name, taskIndex)
+			case OperationUpdate:
+				return m.executeUpdateTask(task.Name, taskIndex)
+			case OperationReinstall:
+				return m.executeReinstallTask(task.Name, taskIndex)
 			}
 		}
 	}
@@ -1103,6 +1512,12 @@ func (m *Progress) executeNextTask() tea.Cmd {
 func (m *Progress) executeInstallTask(appKey string, taskIndex int) tea.Cmd {
 	// Start installation process - update status (log entries come from progress stages)
 	m.tasks[taskIndex].Status = TaskStatusInstalling
+	m.taskStarted = time.Now()
+
+	m.parser = nil
+	if method, ok := m.packages.Method(appKey); ok {
+		m.parser = progressParserFor(method)
+	}
 
 	// Start installation with staged progress updates using Bubble Tea commands
 	return m.startStagedInstallation(appKey, taskIndex)
@@ -1119,6 +1534,7 @@ func (m *Progress) startStagedInstallation(appKey string, taskIndex int) tea.Cmd
 				Success:  false,
 				Duration: time.Second,
 				Error:    fmt.Sprintf("App %s not found in catalog", appKey),
+				Kind:     domain.ErrorKindNotFound,
 			}
 		}
 	}
@@ -1201,15 +1617,6 @@ func (m *Progress) executeActualInstallation(appKey string, taskIndex int, app a
 	// Use the stored context for proper timeout and cancellation propagation
 	ctx := m.ctx
 
-	// Convert to domain package
-	pkg := &domain.Package{
-		Name:        appKey,
-		Group:       app.Group,
-		Description: app.Description,
-		Method:      app.Method,
-		Source:      app.Source,
-	}
-
 	// For now, simulate real progress - in future this should parse actual installer output
 	// Send progress updates during installation
 	progress, message, hasProgress := parseDpkgProgress("Setting up "+app.Name, app.Name)
@@ -1225,8 +1632,7 @@ func (m *Progress) executeActualInstallation(appKey string, taskIndex int, app a
 			},
 			func() tea.Msg {
 				// Actually execute installation
-				result, err := m.packageInstaller.Install(ctx, pkg)
-				_ = result // Result contains additional metadata if needed
+				_, err := m.packages.Install(ctx, appKey)
 
 				if err != nil {
 					return CompletedMsg{
@@ -1234,6 +1640,7 @@ func (m *Progress) executeActualInstallation(appKey string, taskIndex int, app a
 						Success:  false,
 						Duration: time.Since(startTime),
 						Error:    err.Error(),
+						Kind:     domain.ClassifyError(err),
 					}
 				}
 
@@ -1248,8 +1655,7 @@ func (m *Progress) executeActualInstallation(appKey string, taskIndex int, app a
 	}
 
 	// Fallback to direct installation
-	result, err := m.packageInstaller.Install(ctx, pkg)
-	_ = result // Result contains additional metadata if needed
+	_, err := m.packages.Install(ctx, appKey)
 
 	if err != nil {
 		return CompletedMsg{
@@ -1257,6 +1663,7 @@ func (m *Progress) executeActualInstallation(appKey string, taskIndex int, app a
 			Success:  false,
 			Duration: time.Since(startTime),
 			Error:    err.Error(),
+			Kind:     domain.ClassifyError(err),
 		}
 	}
 
@@ -1268,6 +1675,67 @@ func (m *Progress) executeActualInstallation(appKey string, taskIndex int, app a
 	}
 }
 
+// executeUpdateTask upgrades an installed app.
+func (m *Progress) executeUpdateTask(appKey string, taskIndex int) tea.Cmd {
+	return m.executePackageTask(appKey, taskIndex, "Updating", m.packages.Update)
+}
+
+// executeReinstallTask removes an installed app's package and installs it again.
+func (m *Progress) executeReinstallTask(appKey string, taskIndex int) tea.Cmd {
+	return m.executePackageTask(appKey, taskIndex, "Reinstalling", m.packages.Reinstall)
+}
+
+// executePackageTask runs an operation on an installed app in one step. Its
+// install method's output drives the progress bar, as for an install.
+func (m *Progress) executePackageTask(appKey string, taskIndex int, verb string,
+	run func(ctx context.Context, appKey string) error,
+) tea.Cmd {
+	m.tasks[taskIndex].Status = TaskStatusInstalling
+	m.taskStarted = time.Now()
+
+	m.parser = nil
+	if method, ok := m.packages.Method(appKey); ok {
+		m.parser = progressParserFor(method)
+	}
+
+	name := appKey
+	if app, exists := apps.Apps[appKey]; exists {
+		name = app.Name
+	}
+
+	// Use the stored context for proper timeout and cancellation propagation
+	ctx := m.ctx
+
+	return tea.Batch(
+		func() tea.Msg {
+			return ProgressUpdateMsg{
+				TaskIndex: taskIndex,
+				Progress:  0.1,
+				Message:   name + ": " + verb + "...",
+			}
+		},
+		func() tea.Msg {
+			startTime := time.Now()
+
+			if err := run(ctx, appKey); err != nil {
+				return CompletedMsg{
+					TaskName: appKey,
+					Success:  false,
+					Duration: time.Since(startTime),
+					Error:    err.Error(),
+					Kind:     domain.ClassifyError(err),
+				}
+			}
+
+			return CompletedMsg{
+				TaskName: appKey,
+				Success:  true,
+				Duration: time.Since(startTime),
+			}
+		},
+	)
+}
+
 // UninstallStageMsg represents a stage in the uninstallation process.
 type UninstallStageMsg struct {
 	TaskIndex int
@@ -1278,26 +1746,22 @@ type UninstallStageMsg struct {
 
 // Private methods that are actually used (keeping these at the bottom)
 
-// getOperationCounts returns the counts of install and uninstall operations.
-func (m *Progress) getOperationCounts() (int, int) {
-	var installCount, uninstallCount int
+// getOperationCounts returns how many tasks there are of each operation.
+func (m *Progress) getOperationCounts() map[string]int {
+	counts := make(map[string]int)
 
 	for _, task := range m.tasks {
-		switch task.Operation {
-		case OperationInstall:
-			installCount++
-		case OperationUninstall:
-			uninstallCount++
-		}
+		counts[task.Operation]++
 	}
 
-	return installCount, uninstallCount
+	return counts
 }
 
 // executeUninstallTask executes an uninstallation task with staged progression.
 func (m *Progress) executeUninstallTask(appKey string, taskIndex int) tea.Cmd {
 	// Start uninstallation process
 	m.tasks[taskIndex].Status = TaskStatusUninstalling
+	m.parser = nil
 
 	// Start staged uninstallation similar to installation
 	return m.startStagedUninstallation(appKey, taskIndex)
@@ -1314,6 +1778,7 @@ func (m *Progress) startStagedUninstallation(appKey string, taskIndex int) tea.C
 				Success:  false,
 				Duration: time.Second,
 				Error:    fmt.Sprintf("App %s not found in catalog", appKey),
+				Kind:     domain.ErrorKindNotFound,
 			}
 		}
 	}
@@ -1410,13 +1875,14 @@ func (m *Progress) executeActualUninstallation(appKey string, taskIndex int, app
 			},
 			func() tea.Msg {
 				// Actually execute uninstallation
-				err := m.uninstaller.UninstallApp(ctx, appKey)
+				err := m.packages.Uninstall(ctx, appKey)
 				if err != nil {
 					return CompletedMsg{
 						TaskName: appKey,
 						Success:  false,
 						Duration: time.Since(startTime),
 						Error:    err.Error(),
+						Kind:     domain.ClassifyError(err),
 					}
 				}
 
@@ -1431,13 +1897,14 @@ func (m *Progress) executeActualUninstallation(appKey string, taskIndex int, app
 	}
 
 	// Fallback to direct uninstallation
-	err := m.uninstaller.UninstallApp(ctx, appKey)
+	err := m.packages.Uninstall(ctx, appKey)
 	if err != nil {
 		return CompletedMsg{
 			TaskName: appKey,
 			Success:  false,
 			Duration: time.Since(startTime),
 			Error:    err.Error(),
+			Kind:     domain.ClassifyError(err),
 		}
 	}
 
@@ -1456,23 +1923,73 @@ func (m *Progress) handleTaskCompletion(taskIndex int, msg CompletedMsg) tea.Mod
 	if msg.Success {
 		m.tasks[taskIndex].Status = TaskStatusCompleted
 		m.tasks[taskIndex].Progress = 1.0
-	} else {
-		m.tasks[taskIndex].Status = TaskStatusFailed
-		m.tasks[taskIndex].Error = msg.Error
-
-		// Only return error screen for critical system errors, not installation failures
-		// Regular installation failures should just show in the progress screen and logs
-		if strings.Contains(msg.Error, "CRITICAL") || strings.Contains(msg.Error, "SYSTEM") {
-			return &ErrorModel{
-				ErrorMessage: msg.Error,
-				TaskName:     msg.TaskName,
-			}
+		m.tasks[taskIndex].Error = ""
+		m.tasks[taskIndex].Kind = domain.ErrorKindUnknown
+
+		return nil
+	}
+
+	m.tasks[taskIndex].Status = TaskStatusFailed
+	m.tasks[taskIndex].Error = msg.Error
+	m.tasks[taskIndex].Kind = msg.Kind
+
+	// Only return error screen for critical system errors, not installation failures
+	// Regular installation failures should just show in the progress screen and logs
+	if strings.Contains(msg.Error, "CRITICAL") || strings.Contains(msg.Error, "SYSTEM") {
+		return &ErrorModel{
+			ErrorMessage: msg.Error,
+			TaskName:     msg.TaskName,
 		}
 	}
 
 	return nil
 }
 
+// applyErrorAction retries transient failures and aborts the run when the
+// cause would make every remaining task fail too.
+func (m *Progress) applyErrorAction(taskIndex int) {
+	task := &m.tasks[taskIndex]
+
+	switch task.Kind.Action() {
+	case domain.ActionRetry:
+		if task.Attempts >= maxAutoRetries {
+			return
+		}
+
+		task.Attempts++
+		task.Status = TaskStatusPending
+		task.Progress = 0
+
+		m.appendLog(fmt.Sprintf("%s failed (%s), retrying (%d of %d)",
+			task.Name, task.Kind, task.Attempts, maxAutoRetries))
+	case domain.ActionAbort:
+		for i := range m.tasks {
+			if m.tasks[i].Status == TaskStatusPending {
+				m.tasks[i].Status = TaskStatusFailed
+				m.tasks[i].Error = "skipped after " + string(task.Kind) + " error in " + task.Name
+				m.tasks[i].Kind = task.Kind
+			}
+		}
+
+		m.appendLog(fmt.Sprintf("Stopped remaining tasks: %s", task.Kind.Hint()))
+	case domain.ActionSkip:
+	}
+}
+
+// appendLog adds a log entry about the whole run.
+func (m *Progress) appendLog(entry string) {
+	m.addLog(LogInfo, "", entry)
+}
+
+// addLog adds an entry to the operation log and shows it in an open log viewer.
+func (m *Progress) addLog(level LogLevel, task, message string) {
+	m.log.add(level, task, message)
+
+	if m.logViewer.visible {
+		m.logViewer.setLines(m.log.lines())
+	}
+}
+
 // ErrorModel represents a simple error screen for failed installations.
 type ErrorModel struct {
 	ErrorMessage string
@@ -1497,18 +2014,6 @@ func (e *ErrorModel) View() string {
 	return fmt.Sprintf("Error installing %s: %s", e.TaskName, e.ErrorMessage)
 }
 
-// renderFloatingLogViewer renders a floating log viewer overlay.
-func (m *Progress) renderFloatingLogViewer() string {
-	// Simple implementation for floating logs
-	content := strings.Join(m.logs, "\n")
-
-	return lipgloss.NewStyle().
-		Border(lipgloss.DoubleBorder()).
-		BorderForeground(m.styles.Primary).
-		Padding(1).
-		Render("Floating Logs:\n\n" + content)
-}
-
 // GetTasksForTesting returns tasks for testing purposes.
 func (m *Progress) GetTasksForTesting() []InstallTask {
 	return m.tasks
@@ -1735,3 +2240,71 @@ func checkUninstallFinalStages(output string) (float64, string, bool) {
 
 	return 0, "", false
 }
+
+// configuredPreferences reads config.toml. A missing or invalid file yields
+// defaults so the TUI always starts.
+func configuredPreferences() *config.Preferences {
+	prefs, err := config.LoadPreferences(xdg.PreferencesFile())
+	if err != nil {
+		return &config.Preferences{}
+	}
+
+	return prefs
+}
+
+// configuredAPTFrontend returns the APT frontend detected for the configured
+// apt_frontend, or for auto when it is invalid.
+func configuredAPTFrontend(runner domain.CommandRunner, fileManager domain.FileManager) domain.APTFrontend {
+	frontend, err := domain.ParseAPTFrontend(configuredPreferences().Install.APTFrontend)
+	if err != nil {
+		frontend = domain.APTFrontendAuto
+	}
+
+	return platform.NewSystemDetector(runner, fileManager).DetectAPTFrontend(frontend)
+}
+
+// configuredMethodPreference returns the configured method preference, or
+// catalog defaults when it is invalid.
+func configuredMethodPreference() domain.MethodPreference {
+	preference, err := domain.ParseMethodPreference(configuredPreferences().PreferMethods())
+	if err != nil {
+		return nil
+	}
+
+	return preference
+}
+
+// configuredFlatpakScopes returns the configured Flatpak installation scopes,
+// defaulting to per-user installs when they are invalid.
+func configuredFlatpakScopes() domain.FlatpakScopes {
+	prefs := configuredPreferences()
+
+	scopes, err := domain.ParseFlatpakScopes(prefs.Flatpak.Scope, prefs.Flatpak.Apps)
+	if err != nil {
+		return domain.FlatpakScopes{}
+	}
+
+	return scopes
+}
+
+// configuredReleaseChannels returns the configured GitHub release channels,
+// following stable releases when they are invalid.
+func configuredReleaseChannels() domain.ReleaseChannels {
+	channels, err := domain.ParseReleaseChannels(configuredPreferences().GitHub.Channels)
+	if err != nil {
+		return nil
+	}
+
+	return channels
+}
+
+// configuredInstallPolicy returns the configured install policy. An invalid
+// policy allows nothing rather than everything.
+func configuredInstallPolicy() domain.InstallPolicy {
+	policy, err := domain.ParseInstallPolicy(configuredPreferences().Policy.Deny)
+	if err != nil {
+		return domain.InstallPolicy{DenyLicenses: []domain.License{domain.LicenseOpenSource, domain.LicenseProprietary}}
+	}
+
+	return policy
+}
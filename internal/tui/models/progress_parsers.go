@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/janderssonse/karei/internal/domain"
+)
+
+// outputBuffer is how many lines of installer output wait for the TUI.
+const outputBuffer = 256
+
+// The actual install fills this share of a task's progress bar, after the
+// preparing stages.
+const (
+	installProgressStart = 0.8
+	installProgressEnd   = 0.98
+)
+
+// Range of the task progress parseDpkgProgress reports.
+const (
+	dpkgProgressFirst = 0.62
+	dpkgProgressLast  = 0.92
+)
+
+// outputProgress is what a line of installer output tells about an install.
+type outputProgress struct {
+	Fraction float64 // Share of the install done, 0 to 1
+	Message  string  // Stage shown for the task, empty to keep the last
+	Speed    string
+	ETA      string
+}
+
+// progressParser reads progress from a line of installer output, reporting
+// false for lines without any.
+type progressParser func(line, appName string) (outputProgress, bool)
+
+// progressParserFor returns the parser for the output of an install method,
+// or nil when its output tells nothing about progress.
+func progressParserFor(method domain.InstallMethod) progressParser {
+	switch method { //nolint:exhaustive // Other methods have no progress output
+	case domain.MethodAPT, domain.MethodDEB:
+		return parseDpkgOutput
+	case domain.MethodFlatpak:
+		return parseFlatpakProgress
+	case domain.MethodSnap:
+		return parseSnapProgress
+	case domain.MethodMise:
+		return parseMiseProgress
+	case domain.MethodGitHub, domain.MethodGitHubBinary, domain.MethodGitHubBundle,
+		domain.MethodGitHubJava, domain.MethodBinary, domain.MethodScript:
+		return parseCurlProgress
+	default:
+		return nil
+	}
+}
+
+// taskProgress places the fraction of the install within the task's progress bar.
+func (p outputProgress) taskProgress() float64 {
+	return installProgressStart + min(max(p.Fraction, 0), 1)*(installProgressEnd-installProgressStart)
+}
+
+var (
+	percentPattern  = regexp.MustCompile(`(\d{1,3}(?:\.\d+)?)\s*%`)
+	speedPattern    = regexp.MustCompile(`(\d+(?:\.\d+)?\s*[kKMG]i?B/s)`)
+	clockETAPattern = regexp.MustCompile(`\b(\d{1,2}:\d{2}(?::\d{2})?)\s*$`)
+
+	// "Installing 2/3… 45%" counts the refs of a flatpak transaction
+	flatpakStepPattern = regexp.MustCompile(`^\s*(Installing|Updating|Downloading)\s+(\d+)/(\d+)`)
+
+	// Snap reports a download as `Download snap "code" (123) ... 45% 1.2MB/s 10.0s`
+	snapETAPattern = regexp.MustCompile(`\s(\d+(?:\.\d+)?[smh])\s*$`)
+
+	// The curl progress meter: % Total, % Received, % Xferd, speeds, times and current speed
+	curlMeterPattern = regexp.MustCompile(
+		`^\s*\d{1,3}\s+\S+\s+(\d{1,3})\s+\S+\s+\d{1,3}\s+\S+\s+(\S+)\s+\S+\s+\S+\s+\S+\s+(\S+)\s+\S+\s*$`)
+
+	// The curl progress bar of --progress-bar: "######   45.3%"
+	curlBarPattern = regexp.MustCompile(`^#+\s+(\d{1,3}(?:\.\d)?)%\s*$`)
+)
+
+// percent returns the first percentage in line as a fraction.
+func percent(line string) (float64, bool) {
+	match := percentPattern.FindStringSubmatch(line)
+	if match == nil {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil || value > 100 {
+		return 0, false
+	}
+
+	return value / 100, true
+}
+
+// speed returns the transfer rate in line, or "".
+func speed(line string) string {
+	if match := speedPattern.FindStringSubmatch(line); match != nil {
+		return strings.ReplaceAll(match[1], " ", "")
+	}
+
+	return ""
+}
+
+// parseDpkgOutput reads the stages dpkg and apt print.
+func parseDpkgOutput(line, appName string) (outputProgress, bool) {
+	progress, message, ok := parseDpkgProgress(line, appName)
+	if !ok {
+		return outputProgress{}, false
+	}
+
+	fraction := (progress - dpkgProgressFirst) / (dpkgProgressLast - dpkgProgressFirst)
+
+	return outputProgress{Fraction: fraction, Message: message}, true
+}
+
+// parseFlatpakProgress reads the percentage flatpak prints for each ref of
+// a transaction, spreading the refs over the install.
+func parseFlatpakProgress(line, appName string) (outputProgress, bool) {
+	fraction, hasPercent := percent(line)
+	step := flatpakStepPattern.FindStringSubmatch(line)
+
+	if step == nil && !hasPercent {
+		return outputProgress{}, false
+	}
+
+	progress := outputProgress{Fraction: fraction, Speed: speed(line)}
+	if match := clockETAPattern.FindStringSubmatch(line); match != nil && hasPercent {
+		progress.ETA = match[1] + " left"
+	}
+
+	if step == nil {
+		return progress, true
+	}
+
+	current, _ := strconv.Atoi(step[2])
+	total, _ := strconv.Atoi(step[3])
+
+	if total > 0 && current > 0 {
+		progress.Fraction = (float64(current-1) + fraction) / float64(total)
+		progress.Message = step[1] + " " + appName + " (" + step[2] + "/" + step[3] + ")"
+	}
+
+	return progress, true
+}
+
+// snapTasks are the tasks of a snap install change, in order, and how far
+// into the install each starts. The download fills the gap to the next task.
+var snapTasks = []struct { //nolint:gochecknoglobals
+	prefix   string
+	fraction float64
+	message  string
+}{
+	{"Ensure prerequisites", 0.02, "Checking prerequisites"},
+	{"Download snap", 0.05, "Downloading"},
+	{"Fetch and check assertions", 0.7, "Checking assertions"},
+	{"Mount snap", 0.75, "Mounting"},
+	{"Copy snap", 0.8, "Copying data"},
+	{"Setup snap", 0.85, "Setting up"},
+	{"Run install hook", 0.9, "Running install hook"},
+	{"Start snap", 0.95, "Starting services"},
+}
+
+// parseSnapProgress reads the task snap reports for the install change it
+// runs, with the percentage of its download.
+func parseSnapProgress(line, appName string) (outputProgress, bool) {
+	line = strings.TrimSpace(line)
+
+	if strings.HasSuffix(line, " installed") {
+		return outputProgress{Fraction: 1, Message: appName + " installed"}, true
+	}
+
+	for i, task := range snapTasks {
+		if !strings.HasPrefix(line, task.prefix) {
+			continue
+		}
+
+		progress := outputProgress{Fraction: task.fraction, Message: task.message + " " + appName}
+
+		if fraction, ok := percent(line); ok && i+1 < len(snapTasks) {
+			progress.Fraction += fraction * (snapTasks[i+1].fraction - task.fraction)
+			progress.Speed = speed(line)
+
+			if match := snapETAPattern.FindStringSubmatch(line); match != nil {
+				progress.ETA = match[1] + " left"
+			}
+		}
+
+		return progress, true
+	}
+
+	return outputProgress{}, false
+}
+
+// parseMiseProgress reads the download, extract and install steps mise
+// prints for a tool version.
+func parseMiseProgress(line, appName string) (outputProgress, bool) {
+	lower := strings.ToLower(line)
+
+	switch {
+	case strings.Contains(lower, "✓ installed"), strings.HasSuffix(strings.TrimSpace(lower), " installed"):
+		return outputProgress{Fraction: 1, Message: appName + " installed"}, true
+	case strings.Contains(lower, "download"):
+		fraction, _ := percent(line)
+
+		return outputProgress{Fraction: 0.05 + 0.65*fraction, Message: "Downloading " + appName, Speed: speed(line)}, true
+	case strings.Contains(lower, "extract"):
+		return outputProgress{Fraction: 0.75, Message: "Extracting " + appName}, true
+	case strings.Contains(lower, "verify"), strings.Contains(lower, "checksum"):
+		return outputProgress{Fraction: 0.72, Message: "Verifying " + appName}, true
+	case strings.Contains(lower, "install"):
+		return outputProgress{Fraction: 0.85, Message: "Installing " + appName}, true
+	default:
+		return outputProgress{}, false
+	}
+}
+
+// parseCurlProgress reads curl's progress meter or progress bar, which
+// downloads of releases and install scripts print.
+func parseCurlProgress(line, appName string) (outputProgress, bool) {
+	if match := curlMeterPattern.FindStringSubmatch(line); match != nil {
+		received, _ := strconv.Atoi(match[1])
+		progress := outputProgress{Fraction: float64(received) / 100, Message: "Downloading " + appName}
+
+		if match[2] != "0" {
+			progress.Speed = match[2] + "B/s"
+		}
+
+		if eta := match[3]; !strings.HasPrefix(eta, "--") {
+			progress.ETA = eta + " left"
+		}
+
+		return progress, true
+	}
+
+	if match := curlBarPattern.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+		value, _ := strconv.ParseFloat(match[1], 64)
+
+		return outputProgress{Fraction: value / 100, Message: "Downloading " + appName}, true
+	}
+
+	return outputProgress{}, false
+}
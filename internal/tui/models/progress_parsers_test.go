@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+)
+
+func TestProgressParsers(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   domain.InstallMethod
+		line     string
+		fraction float64
+		message  string
+		speed    string
+		eta      string
+	}{
+		{
+			name: "dpkg", method: domain.MethodAPT,
+			line: "Setting up vlc (3.0.20-3build6) ...", fraction: 13.0 / 30, message: "Setting up VLC",
+		},
+		{
+			name: "flatpak ref of a transaction", method: domain.MethodFlatpak,
+			line: "Installing 2/4… ████▌ 50%  2.1 MB/s  00:12", fraction: 1.5 / 4,
+			message: "Installing VLC (2/4)", speed: "2.1MB/s", eta: "00:12 left",
+		},
+		{
+			name: "flatpak percentage", method: domain.MethodFlatpak,
+			line: "Downloading: 25%", fraction: 0.25,
+		},
+		{
+			name: "snap download", method: domain.MethodSnap,
+			line: `Download snap "vlc" (3777) from channel "stable"  50% 1.20MB/s 8.3s`, fraction: 0.05 + 0.5*0.65,
+			message: "Downloading VLC", speed: "1.20MB/s", eta: "8.3s left",
+		},
+		{
+			name: "snap task", method: domain.MethodSnap,
+			line: `Setup snap "vlc" (3777) security profiles`, fraction: 0.85, message: "Setting up VLC",
+		},
+		{
+			name: "snap done", method: domain.MethodSnap,
+			line: "vlc 3.0.20 from VideoLAN✓ installed", fraction: 1, message: "VLC installed",
+		},
+		{
+			name: "mise download", method: domain.MethodMise,
+			line: "mise node@22.1.0  download node-v22.1.0-linux-x64.tar.gz  40%", fraction: 0.05 + 0.4*0.65,
+			message: "Downloading VLC",
+		},
+		{
+			name: "mise extract", method: domain.MethodMise,
+			line: "mise node@22.1.0  extract node-v22.1.0-linux-x64.tar.gz", fraction: 0.75, message: "Extracting VLC",
+		},
+		{
+			name: "curl meter", method: domain.MethodScript,
+			line: " 45 12.3M   45 5600k    0     0  1234k      0  0:00:10  0:00:04  0:00:06 1300k", fraction: 0.45,
+			message: "Downloading VLC", speed: "1234kB/s", eta: "0:00:06 left",
+		},
+		{
+			name: "curl bar", method: domain.MethodGitHubBinary,
+			line: "##########                     33.3%", fraction: 0.333, message: "Downloading VLC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := progressParserFor(tt.method)
+			require.NotNil(t, parser)
+
+			progress, ok := parser(tt.line, "VLC")
+			require.True(t, ok)
+			assert.InDelta(t, tt.fraction, progress.Fraction, 0.001)
+			assert.Equal(t, tt.message, progress.Message)
+			assert.Equal(t, tt.speed, progress.Speed)
+			assert.Equal(t, tt.eta, progress.ETA)
+		})
+	}
+}
+
+func TestProgressParsersIgnoreOtherLines(t *testing.T) {
+	for _, method := range []domain.InstallMethod{domain.MethodAPT, domain.MethodFlatpak, domain.MethodSnap, domain.MethodMise, domain.MethodScript} {
+		_, ok := progressParserFor(method)("  % Total    % Received % Xferd  Average Speed   Time", "VLC")
+		assert.False(t, ok, method)
+	}
+
+	assert.Nil(t, progressParserFor(domain.MethodAqua))
+}
+
+func TestProgressFollowsInstallerOutput(t *testing.T) {
+	model := NewProgress(context.Background(), styles.New(), []string{"vlc"})
+	model.tasks[0].Status = TaskStatusInstalling
+	model.tasks[0].Progress = installProgressStart
+	model.parser = progressParserFor(domain.MethodFlatpak)
+
+	model.handleInstallOutput(InstallOutputMsg{Line: "Installing 1/2… 50%  3.0 MB/s  00:04"})
+	assert.InDelta(t, installProgressStart+0.25*(installProgressEnd-installProgressStart), model.tasks[0].Progress, 0.001)
+	assert.Equal(t, "3.0MB/s", model.tasks[0].Speed)
+	assert.Equal(t, "00:04 left", model.tasks[0].ETA)
+
+	// Output without progress goes to the log, without moving the bar back
+	model.handleInstallOutput(InstallOutputMsg{Line: "Looking for matches…"})
+	assert.Greater(t, model.tasks[0].Progress, installProgressStart)
+	assert.Equal(t, "Looking for matches…", model.log.entries[model.log.len()-1].Message)
+
+	// Redraws of the same stage are logged once
+	logged := model.log.len()
+	model.handleInstallOutput(InstallOutputMsg{Line: "Installing 1/2… 80%"})
+	assert.Equal(t, logged, model.log.len())
+}
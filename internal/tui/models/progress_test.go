@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/janderssonse/karei/internal/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressRetriesTransientFailures(t *testing.T) {
+	t.Parallel()
+
+	model := NewProgress(context.Background(), styles.New(), []string{"vlc", "git"})
+
+	for attempt := 1; attempt <= maxAutoRetries; attempt++ {
+		require.Nil(t, model.handleCompletedTask(CompletedMsg{TaskName: "vlc", Error: "fetch failed", Kind: domain.ErrorKindNetwork}))
+		assert.Equal(t, TaskStatusPending, model.tasks[0].Status)
+		assert.Equal(t, attempt, model.tasks[0].Attempts)
+	}
+
+	model.handleCompletedTask(CompletedMsg{TaskName: "vlc", Error: "fetch failed", Kind: domain.ErrorKindNetwork})
+	assert.Equal(t, TaskStatusFailed, model.tasks[0].Status)
+	assert.Equal(t, "Failed (network)", model.getTaskStatusText(model.tasks[0]))
+	assert.Equal(t, TaskStatusPending, model.tasks[1].Status)
+}
+
+func TestProgressAbortsOnFatalFailures(t *testing.T) {
+	t.Parallel()
+
+	model := NewProgress(context.Background(), styles.New(), []string{"vlc", "git", "zed"})
+
+	model.handleCompletedTask(CompletedMsg{TaskName: "vlc", Error: "no space left on device", Kind: domain.ErrorKindDisk})
+
+	for _, task := range model.tasks {
+		assert.Equal(t, TaskStatusFailed, task.Status)
+		assert.Equal(t, domain.ErrorKindDisk, task.Kind)
+	}
+
+	assert.True(t, model.completed)
+	assert.Contains(t, model.log.entries[0].Message, domain.ErrorKindDisk.Hint())
+}
+
+func TestProgressSkipsPermanentFailures(t *testing.T) {
+	t.Parallel()
+
+	model := NewProgress(context.Background(), styles.New(), []string{"vlcx", "git"})
+
+	model.handleCompletedTask(CompletedMsg{TaskName: "vlcx", Error: "unable to locate package", Kind: domain.ErrorKindNotFound})
+
+	assert.Equal(t, TaskStatusFailed, model.tasks[0].Status)
+	assert.Zero(t, model.tasks[0].Attempts)
+	assert.Equal(t, TaskStatusPending, model.tasks[1].Status)
+}
+
+func TestProgressRetryRequeuesFailedTasks(t *testing.T) {
+	t.Parallel()
+
+	model := NewProgress(context.Background(), styles.New(), []string{"vlc", "git"})
+	model.SetJournal(nil)
+
+	model.handleCompletedTask(CompletedMsg{TaskName: "vlc", Error: "unable to locate package", Kind: domain.ErrorKindNotFound})
+	model.handleCompletedTask(CompletedMsg{TaskName: "git", Success: true})
+	require.True(t, model.completed)
+	assert.Contains(t, model.renderFooter(), "Retry failed")
+
+	// The returned command would start the real install, so it is not run
+	updated, _ := model.handleRetryFailed()
+	retried, ok := updated.(*Progress)
+	require.True(t, ok)
+
+	assert.False(t, retried.completed)
+	assert.Equal(t, TaskStatusInstalling, retried.tasks[0].Status)
+	assert.Empty(t, retried.tasks[0].Error)
+	assert.Equal(t, domain.ErrorKindUnknown, retried.tasks[0].Kind)
+}
+
+func TestProgressEstimatesRemainingTime(t *testing.T) {
+	t.Parallel()
+
+	model := NewProgress(context.Background(), styles.New(), []string{"vlc", "git", "zed"})
+
+	_, ok := model.remainingEstimate()
+	assert.False(t, ok, "no history means no ETA")
+
+	model.estimates = map[string]time.Duration{"vlc": time.Minute, "zed": 30 * time.Second}
+	model.tasks[0].Status = TaskStatusInstalling
+	model.taskStarted = time.Now().Add(-20 * time.Second)
+
+	remaining, ok := model.remainingEstimate()
+	require.True(t, ok)
+	assert.InDelta(t, 70*time.Second, remaining, float64(time.Second))
+
+	model.tasks[0].Progress = 0.5
+	assert.Contains(t, model.getTaskStatusText(model.tasks[0]), "~40 s left")
+}
+
+func TestProgressListsInstallNotesInResults(t *testing.T) {
+	t.Parallel()
+
+	model := NewProgress(context.Background(), styles.New(), []string{"nvidia-driver"})
+	require.NotNil(t, model.notes, "the package manager is given somewhere to leave notes")
+
+	guidance := domain.MOKGuidance("NVIDIA driver")
+	model.notes.add(guidance)
+	model.notes.add(guidance)
+
+	model.handleCompletedTask(CompletedMsg{TaskName: "nvidia-driver", Success: true})
+
+	steps := model.resultsData().NextSteps
+	require.NotEmpty(t, steps)
+	assert.Equal(t, guidance, steps[0])
+	assert.Len(t, slices.DeleteFunc(slices.Clone(steps), func(step string) bool { return step != guidance }), 1,
+		"each note is listed once")
+}
+
+func TestProgressDoesNotLogSimulatedRuns(t *testing.T) {
+	stateHome := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateHome)
+
+	model := NewProgress(context.Background(), styles.New(), []string{"vlc"})
+	model.SetPackageManager(application.NewPackageManager(nil, nil, true))
+	model.Init()
+
+	assert.Empty(t, model.log.path())
+	assert.NoDirExists(t, xdg.OperationLogDir())
+}
@@ -0,0 +1,402 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/stringutil"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// maxExcerptLines bounds the log excerpt shown per failed task.
+const maxExcerptLines = 3
+
+// ResultsData carries the outcome of a finished run to the Results screen.
+type ResultsData struct {
+	Tasks      []InstallTask
+	Operations []SelectedOperation
+	Duration   time.Duration
+	DiskUsed   int64       // Bytes consumed by the run, negative when space was freed
+	NextSteps  []string    // Follow-up actions for the apps that were installed
+	Shell      *ShellSetup // What the shell needs to reach the installed apps, nil for nothing
+}
+
+// Results summarizes a finished run: outcomes, failures and what to do next.
+type Results struct {
+	styles    *styles.Styles
+	width     int
+	height    int
+	data      ResultsData
+	exportDir string
+	notice    string // Feedback from the last export
+}
+
+// NewResults creates the Results screen for a finished run.
+func NewResults(styleConfig *styles.Styles, data ResultsData) *Results {
+	return &Results{
+		styles:    styleConfig,
+		data:      data,
+		exportDir: xdg.DataDir(),
+	}
+}
+
+// SetExportDir changes where exported reports are written.
+func (m *Results) SetExportDir(dir string) {
+	m.exportDir = dir
+}
+
+// Init implements tea.Model.
+func (m *Results) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the Results screen.
+func (m *Results) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		case "e":
+			m.export()
+		case "s":
+			m.applyShellSetup()
+		case KeyEnter, KeyEsc:
+			return m, func() tea.Msg {
+				return NavigateMsg{Screen: AppsScreen, Data: CompletedOperationsMsg{Operations: m.data.Operations}}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the Results screen.
+func (m *Results) View() string {
+	sections := []string{
+		m.renderHeader(),
+		m.renderSummary(),
+		m.renderTasks(),
+	}
+
+	if failures := m.renderFailures(); failures != "" {
+		sections = append(sections, failures)
+	}
+
+	if len(m.data.NextSteps) > 0 || m.data.Shell != nil {
+		sections = append(sections, m.renderNextSteps())
+	}
+
+	if m.notice != "" {
+		sections = append(sections, m.styles.MutedText.Render(m.notice))
+	}
+
+	actions := []FooterAction{
+		{Key: "Enter", Action: "Done"},
+		{Key: "e", Action: "Export"},
+	}
+
+	if m.data.Shell.Pending() {
+		actions = append(actions, FooterAction{Key: "s", Action: "Set up shell"})
+	}
+
+	actions = append(actions, FooterAction{Key: "q", Action: "Quit"})
+
+	sections = append(sections, RenderFooter(m.styles, m.width, actions, false))
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// Report renders the results as plain text for exporting.
+func (m *Results) Report() string {
+	var report strings.Builder
+
+	succeeded, failed := m.counts()
+	fmt.Fprintf(&report, "Karei results %s\n\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&report, "Succeeded: %d%s\nFailed: %d\nDuration: %s\nDisk: %s\n\n",
+		succeeded, m.operationSummary(), failed, stringutil.FormatDuration(m.data.Duration.Round(time.Second)), formatDiskDelta(m.data.DiskUsed))
+
+	for _, task := range m.data.Tasks {
+		fmt.Fprintf(&report, "%-10s %-24s %-10s %s\n", task.Operation, task.Name, task.Status, stringutil.FormatDuration(task.Duration.Round(time.Second)))
+
+		if task.Status == TaskStatusFailed && task.Error != "" {
+			for _, line := range logExcerpt(task.Error) {
+				fmt.Fprintf(&report, "    %s\n", line)
+			}
+		}
+	}
+
+	if steps := m.nextSteps(); len(steps) > 0 {
+		report.WriteString("\nNext steps:\n")
+
+		for _, step := range steps {
+			fmt.Fprintf(&report, "  - %s\n", step)
+		}
+	}
+
+	return report.String()
+}
+
+// export writes the plain-text report next to karei's other data files.
+func (m *Results) export() {
+	path := filepath.Join(m.exportDir, "results-"+time.Now().Format("20060102-150405")+".txt")
+
+	if err := os.MkdirAll(m.exportDir, 0o750); err != nil {
+		m.notice = "Export failed: " + err.Error()
+
+		return
+	}
+
+	if err := os.WriteFile(path, []byte(m.Report()), 0o600); err != nil {
+		m.notice = "Export failed: " + err.Error()
+
+		return
+	}
+
+	m.notice = "Saved report to " + path
+}
+
+// counts returns how many tasks succeeded and failed.
+func (m *Results) counts() (int, int) {
+	succeeded, failed := 0, 0
+
+	for _, task := range m.data.Tasks {
+		switch task.Status {
+		case TaskStatusCompleted:
+			succeeded++
+		case TaskStatusFailed:
+			failed++
+		}
+	}
+
+	return succeeded, failed
+}
+
+// operationSummary returns what the succeeded tasks did, such as
+// " (2 installed, 1 updated)", or "" when none did.
+func (m *Results) operationSummary() string {
+	done := make(map[string]int)
+
+	for _, task := range m.data.Tasks {
+		if task.Status == TaskStatusCompleted {
+			done[task.Operation]++
+		}
+	}
+
+	var parts []string
+
+	for _, kind := range []struct{ operation, done string }{
+		{OperationInstall, "installed"},
+		{OperationUninstall, "uninstalled"},
+		{OperationUpdate, "updated"},
+		{OperationReinstall, "reinstalled"},
+	} {
+		if done[kind.operation] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", done[kind.operation], kind.done))
+		}
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// renderHeader renders the screen location with the same border as the Progress screen.
+func (m *Results) renderHeader() string {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.styles.Primary).
+		Padding(0, 2).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(lipgloss.Color("240")).
+		Width(m.width).
+		Render("Karei » Results")
+}
+
+// renderSummary renders the one-line totals.
+func (m *Results) renderSummary() string {
+	succeeded, failed := m.counts()
+
+	parts := []string{
+		m.styles.SuccessText.Render(fmt.Sprintf("✓ %d succeeded%s", succeeded, m.operationSummary())),
+	}
+
+	if failed > 0 {
+		parts = append(parts, m.styles.ErrorText.Render(fmt.Sprintf("✗ %d failed", failed)))
+	}
+
+	parts = append(parts,
+		"⏱ "+stringutil.FormatDuration(m.data.Duration.Round(time.Second)),
+		"💾 "+formatDiskDelta(m.data.DiskUsed),
+	)
+
+	return strings.Join(parts, "   ")
+}
+
+// renderTasks renders one line per task with its outcome and duration.
+func (m *Results) renderTasks() string {
+	lines := make([]string, 0, len(m.data.Tasks))
+
+	for _, task := range m.data.Tasks {
+		icon := m.styles.StatusIcon(task.Status)
+		line := fmt.Sprintf("%s %-24s %-10s %s", icon, task.Name, task.Operation, stringutil.FormatDuration(task.Duration.Round(time.Second)))
+
+		if task.Status == TaskStatusFailed {
+			line = m.styles.ErrorText.Render(line)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderFailures renders an excerpt of the log and a hint for each failed task.
+func (m *Results) renderFailures() string {
+	var lines []string
+
+	for _, task := range m.data.Tasks {
+		if task.Status != TaskStatusFailed {
+			continue
+		}
+
+		lines = append(lines, m.styles.ErrorText.Render(task.Name))
+
+		for _, line := range logExcerpt(task.Error) {
+			lines = append(lines, m.styles.MutedText.Render("  "+line))
+		}
+
+		if hint := task.Kind.Hint(); hint != "" {
+			lines = append(lines, "  → "+hint)
+		}
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return m.styles.Subtitle.Render("Failures") + "\n" + strings.Join(lines, "\n")
+}
+
+// renderNextSteps renders follow-up suggestions for installed apps, with
+// the lines the shell configuration lacks to reach them.
+func (m *Results) renderNextSteps() string {
+	var lines []string
+
+	for _, step := range m.nextSteps() {
+		lines = append(lines, "  • "+step)
+	}
+
+	if shell := m.data.Shell; shell.Pending() {
+		for _, line := range shell.Missing {
+			lines = append(lines, m.styles.MutedText.Render("      "+line))
+		}
+	}
+
+	return m.styles.Subtitle.Render("Next steps") + "\n" + strings.Join(lines, "\n")
+}
+
+// nextSteps returns the follow-up actions, led by what the shell needs.
+func (m *Results) nextSteps() []string {
+	shell := m.data.Shell
+	if shell == nil {
+		return m.data.NextSteps
+	}
+
+	var steps []string
+
+	switch {
+	case shell.Pending():
+		steps = append(steps, fmt.Sprintf("Your shell doesn't reach the new commands yet: press s to add these lines to %s", shell.Config.Path()))
+	case shell.Config == nil:
+		for _, dir := range shell.Setup.PathDirs {
+			steps = append(steps, "Add "+dir+" to your PATH")
+		}
+
+		for _, tool := range shell.Setup.Activate {
+			steps = append(steps, "Activate "+tool+" in your shell configuration")
+		}
+	}
+
+	steps = append(steps, shell.Hint())
+
+	return append(steps, m.data.NextSteps...)
+}
+
+// applyShellSetup adds the lines the shell lacks to its configuration.
+func (m *Results) applyShellSetup() {
+	shell := m.data.Shell
+	if !shell.Pending() {
+		return
+	}
+
+	if err := shell.Apply(); err != nil {
+		m.notice = "Shell setup failed: " + err.Error()
+
+		return
+	}
+
+	m.notice = "Updated " + shell.Config.Path() + ". " + shell.Hint()
+}
+
+// logExcerpt returns the last few non-empty lines of a failure message.
+func logExcerpt(output string) []string {
+	var lines []string
+
+	for line := range strings.SplitSeq(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) > maxExcerptLines {
+		lines = lines[len(lines)-maxExcerptLines:]
+	}
+
+	return lines
+}
+
+// formatDiskDelta renders a change in disk usage with decimal units.
+func formatDiskDelta(bytes int64) string {
+	verb := "used"
+	if bytes < 0 {
+		verb = "freed"
+		bytes = -bytes
+	}
+
+	return stringutil.FormatBytes(bytes) + " " + verb
+}
+
+// installedApps returns the apps of the installs that succeeded.
+func installedApps(tasks []InstallTask) []string {
+	var installed []string
+
+	for _, task := range tasks {
+		if task.Operation == OperationInstall && task.Status == TaskStatusCompleted {
+			installed = append(installed, task.Name)
+		}
+	}
+
+	return installed
+}
+
+// nextStepsFor returns follow-up actions for the installs that succeeded.
+func nextStepsFor(tasks []InstallTask) []string {
+	return apps.NextSteps(installedApps(tasks), configuredMethodPreference())
+}
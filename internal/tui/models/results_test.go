@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleResults() ResultsData {
+	return ResultsData{
+		Tasks: []InstallTask{
+			{Name: "fish", Operation: OperationInstall, Status: TaskStatusCompleted, Duration: 3 * time.Second},
+			{
+				Name: "vlc", Operation: OperationInstall, Status: TaskStatusFailed, Kind: domain.ErrorKindNetwork,
+				Error: "Reading package lists...\nE: Failed to fetch\nE: Temporary failure resolving 'archive.ubuntu.com'\nE: Some index files failed",
+			},
+		},
+		Duration:  42 * time.Second,
+		DiskUsed:  340_000_000,
+		NextSteps: nextStepsFor([]InstallTask{{Name: "fish", Operation: OperationInstall, Status: TaskStatusCompleted}}),
+	}
+}
+
+func TestResultsViewSummarizesRun(t *testing.T) {
+	t.Parallel()
+
+	view := NewResults(styles.New(), sampleResults()).View()
+
+	assert.Contains(t, view, "1 succeeded")
+	assert.Contains(t, view, "1 failed")
+	assert.Contains(t, view, "340 MB used")
+	assert.Contains(t, view, "Temporary failure resolving")
+	assert.NotContains(t, view, "Reading package lists", "excerpt keeps only the last lines")
+	assert.Contains(t, view, domain.ErrorKindNetwork.Hint())
+	assert.Contains(t, view, "Restart your terminal for fish changes")
+}
+
+func TestResultsSummaryBreaksDownOperations(t *testing.T) {
+	t.Parallel()
+
+	data := sampleResults()
+	data.Tasks = append(data.Tasks,
+		InstallTask{Name: "git", Operation: OperationUpdate, Status: TaskStatusCompleted},
+		InstallTask{Name: "code", Operation: OperationUpdate, Status: TaskStatusCompleted},
+		InstallTask{Name: "gimp", Operation: OperationReinstall, Status: TaskStatusCompleted},
+	)
+
+	model := NewResults(styles.New(), data)
+
+	assert.Contains(t, model.View(), "4 succeeded (1 installed, 2 updated, 1 reinstalled)")
+	assert.Contains(t, model.Report(), "Succeeded: 4 (1 installed, 2 updated, 1 reinstalled)\nFailed: 1")
+}
+
+func TestResultsExportWritesReport(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	model := NewResults(styles.New(), sampleResults())
+	model.SetExportDir(dir)
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+
+	matches, err := filepath.Glob(filepath.Join(dir, "results-*.txt"))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Contains(t, model.View(), "Saved report to")
+
+	report, err := os.ReadFile(matches[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(report), "Failed: 1")
+	assert.Contains(t, string(report), "Next steps:")
+}
+
+func TestResultsShellSetup(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	path := filepath.Join(home, ".zshrc")
+	setup := domain.ShellSetup{PathDirs: []string{filepath.Join(home, ".local", "bin")}}
+	shellConfig := application.NewShellConfig(platform.NewFileManager(false), domain.ShellZsh, path, home)
+
+	data := sampleResults()
+	data.Shell = &ShellSetup{Config: shellConfig, Setup: setup, Missing: shellConfig.Missing(setup)}
+	model := NewResults(styles.New(), data)
+
+	view := model.View()
+	assert.Contains(t, view, "press s to add these lines to "+path)
+	assert.Contains(t, view, `export PATH="$HOME/.local/bin:$PATH"`)
+	assert.Contains(t, view, "exec zsh")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `export PATH="$HOME/.local/bin:$PATH"`)
+	assert.NotContains(t, model.View(), "press s")
+	assert.Contains(t, model.View(), "Updated "+path)
+}
+
+func TestFormatDiskDelta(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "0 B used", formatDiskDelta(0))
+	assert.Equal(t, "1.5 GB used", formatDiskDelta(1_500_000_000))
+	assert.Equal(t, "12.0 MB freed", formatDiskDelta(-12_000_000))
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Limits of the selection history and the review overlay.
+const (
+	maxSelectionHistory = 100
+	reviewWidth         = 60
+)
+
+// selectionChange is an app's selection state before a change, for undo.
+type selectionChange struct {
+	appKey   string
+	previous SelectionState
+}
+
+// recordSelection remembers the current state of appKey before it changes.
+func (m *AppsModel) recordSelection(appKey string) {
+	m.selectionHistory = append(m.selectionHistory, selectionChange{appKey: appKey, previous: m.selected[appKey]})
+
+	if len(m.selectionHistory) > maxSelectionHistory {
+		m.selectionHistory = m.selectionHistory[1:]
+	}
+}
+
+// undoSelection restores the state before the last selection change.
+func (m *AppsModel) undoSelection() {
+	if len(m.selectionHistory) == 0 {
+		m.searchNotice = "Nothing to undo"
+
+		return
+	}
+
+	last := m.selectionHistory[len(m.selectionHistory)-1]
+	m.selectionHistory = m.selectionHistory[:len(m.selectionHistory)-1]
+
+	if last.previous == StateNone {
+		delete(m.selected, last.appKey)
+	} else {
+		m.selected[last.appKey] = last.previous
+	}
+
+	m.searchNotice = "Undid change to " + m.appName(last.appKey)
+	m.contentNeedsUpdate = true
+}
+
+// openReview shows the pending operations overlay.
+func (m *AppsModel) openReview() {
+	m.reviewing = true
+	m.review = NewSelectableList(m.getSelectedOperations(),
+		func(op SelectedOperation) string { return op.AppKey }, nil, m.renderReviewItem)
+	m.sizeReview()
+}
+
+// sizeReview fits the overlay list to its entries, scrolling beyond the screen.
+func (m *AppsModel) sizeReview() {
+	m.review.SetSize(reviewWidth, min(max(m.review.Len(), 1), max(m.height-12, 3)))
+}
+
+// refreshReview rebuilds the overlay after the selections changed, keeping
+// the cursor in place.
+func (m *AppsModel) refreshReview() {
+	cursor := m.review.Cursor()
+	m.review.SetItems(m.getSelectedOperations())
+	m.sizeReview()
+	m.review.SetCursor(cursor)
+}
+
+// handleReviewKeys drives the review overlay. Every key is consumed so the
+// list underneath doesn't move.
+func (m *AppsModel) handleReviewKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "v", KeyEsc:
+		m.reviewing = false
+	case "x", "d", "backspace":
+		if op, ok := m.review.Selected(); ok {
+			m.recordSelection(op.AppKey)
+			delete(m.selected, op.AppKey)
+			m.contentNeedsUpdate = true
+			m.refreshReview()
+		}
+	case "ctrl+z":
+		m.undoSelection()
+		m.refreshReview()
+	case KeyEnter:
+		m.reviewing = false
+
+		return m.handleInstallationKeys(msg)
+	default:
+		m.review.HandleKey(msg)
+	}
+
+	return nil
+}
+
+// renderReviewItem renders one pending operation as a diff line.
+func (m *AppsModel) renderReviewItem(op SelectedOperation, focused bool, _ SelectionState, _ []int) string {
+	sign, verb, color := "+", "install", m.styles.Success
+
+	switch op.Operation { //nolint:exhaustive // Everything else is an install
+	case StateUninstall:
+		sign, verb, color = "-", "uninstall", m.styles.Error
+	case StateUpdate:
+		sign, verb, color = "↑", "update", m.styles.Warning
+	case StateReinstall:
+		sign, verb, color = "↻", "reinstall", m.styles.Warning
+	}
+
+	prefix := "  "
+	if focused {
+		prefix = "❯ "
+	}
+
+	line := fmt.Sprintf("%s%s %-30s %s", prefix, sign, truncate(op.AppName, 30), verb)
+	if op.RequiresRoot {
+		line += " (sudo)"
+	}
+
+	style := lipgloss.NewStyle().Foreground(color)
+	if focused {
+		style = style.Bold(true)
+	}
+
+	return style.Render(line)
+}
+
+// renderReview renders the pending operations overlay.
+func (m *AppsModel) renderReview() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Primary).
+		Render(fmt.Sprintf("Pending operations (%d)", m.review.Len()))
+
+	body := m.review.View()
+	if m.review.Len() == 0 {
+		body = m.styles.MutedText.Render("Nothing selected. Space marks an app for install, d for uninstall.")
+	}
+
+	hints := m.styles.MutedText.Render("x Remove   ^Z Undo   Enter Proceed   Esc Close")
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Primary).
+		Padding(1, 2).
+		Width(reviewWidth + 6).
+		Render(lipgloss.JoinVertical(lipgloss.Left, title, "", body, "", hints))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box,
+		lipgloss.WithWhitespaceBackground(lipgloss.Color("235")))
+}
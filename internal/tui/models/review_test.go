@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndoSelection(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+
+	typeKeys(model, " ")
+	assert.Equal(t, StateInstall, model.selected["git"])
+
+	typeKeys(model, "jd")
+	assert.Equal(t, StateUninstall, model.selected["vscode"])
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	assert.NotContains(t, model.selected, "vscode")
+	assert.Equal(t, StateInstall, model.selected["git"], "only the last change is undone")
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	assert.Empty(t, model.selected)
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	assert.Contains(t, model.renderCleanHeader(), "Nothing to undo")
+}
+
+func TestReviewOverlay(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+
+	typeKeys(model, " jdj ")
+	typeKeys(model, "v")
+	require.True(t, model.reviewing)
+
+	view := model.renderReview()
+	assert.Contains(t, view, "Pending operations (3)")
+	assert.Contains(t, view, "+ Git")
+	assert.Contains(t, view, "- VS Code")
+
+	// Remove the second entry, then bring it back
+	typeKeys(model, "jx")
+	assert.NotContains(t, model.selected, "vscode")
+	assert.Equal(t, 2, model.review.Len())
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	assert.Equal(t, StateUninstall, model.selected["vscode"])
+	assert.Equal(t, 3, model.review.Len())
+
+	typeKeys(model, "j")
+	assert.Equal(t, 2, model.categories[0].currentApp, "keys don't reach the list underneath")
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, model.reviewing)
+	assert.Len(t, model.selected, 3)
+
+	typeKeys(model, "v")
+
+	_, cmd := model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd, "uninstalls are confirmed first")
+	require.True(t, model.confirmingUninstall)
+
+	_, cmd = model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(NavigateMsg)
+	require.True(t, ok)
+	assert.Equal(t, ProgressScreen, msg.Screen)
+	assert.False(t, model.reviewing)
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/config"
+)
+
+// handleSaveSearchKeys edits the name of the search being saved. Every key is
+// consumed until the name is confirmed or cancelled.
+func (m *AppsModel) handleSaveSearchKeys(msg tea.KeyMsg) {
+	switch msg.String() {
+	case KeyEsc:
+		m.namingSearch = false
+	case KeyEnter:
+		m.namingSearch = false
+
+		if name := strings.TrimSpace(m.searchName); name != "" {
+			m.saveCurrentSearch(name)
+		}
+	case "backspace":
+		if runes := []rune(m.searchName); len(runes) > 0 {
+			m.searchName = string(runes[:len(runes)-1])
+		}
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.searchName += string(msg.Runes)
+		}
+	}
+}
+
+// saveCurrentSearch stores the query and filters under name, replacing a
+// saved search with the same name, and persists them in config.toml.
+func (m *AppsModel) saveCurrentSearch(name string) {
+	search := config.SavedSearch{
+		Name:  name,
+		Query: m.searchQuery,
+		Sort:  m.sortOption,
+	}
+
+	if m.packageTypeFilter != FilterAll {
+		search.Method = m.packageTypeFilter
+	}
+
+	if m.installStatusFilter != FilterAll {
+		search.Status = m.installStatusFilter
+	}
+
+	idx := slices.IndexFunc(m.savedSearches, func(saved config.SavedSearch) bool {
+		return strings.EqualFold(saved.Name, name)
+	})
+	if idx >= 0 {
+		m.savedSearches[idx] = search
+	} else {
+		m.savedSearches = append(m.savedSearches, search)
+		idx = len(m.savedSearches) - 1
+	}
+
+	m.savedSearchIndex = idx
+	m.searchNotice = `Saved "` + name + `"`
+
+	if err := m.persistSavedSearches(); err != nil {
+		m.searchNotice = "Saved for this session only: " + err.Error()
+	}
+}
+
+// persistSavedSearches writes the saved searches to config.toml.
+func (m *AppsModel) persistSavedSearches() error {
+	if m.prefsPath == "" {
+		return nil
+	}
+
+	prefs, err := config.LoadPreferences(m.prefsPath)
+	if err != nil {
+		return fmt.Errorf("failed to load preferences: %w", err)
+	}
+
+	prefs.Searches = m.savedSearches
+
+	if err := config.SavePreferences(m.prefsPath, prefs); err != nil {
+		return fmt.Errorf("failed to save searches: %w", err)
+	}
+
+	return nil
+}
+
+// cycleSavedSearch applies the next saved search, opening search mode with the
+// results focused.
+func (m *AppsModel) cycleSavedSearch() tea.Cmd {
+	if len(m.savedSearches) == 0 {
+		m.searchNotice = "No saved searches (Ctrl+S in search saves one)"
+
+		return nil
+	}
+
+	m.savedSearchIndex = (m.savedSearchIndex + 1) % len(m.savedSearches)
+	search := m.savedSearches[m.savedSearchIndex]
+
+	m.searchActive = true
+	m.searchHasFocus = false
+	m.filterFocus = false
+	m.searchQuery = search.Query
+	m.packageTypeFilter = orDefault(search.Method, FilterAll)
+	m.installStatusFilter = orDefault(search.Status, FilterAll)
+	m.sortOption = orDefault(search.Sort, sortOptions[0])
+	m.searchNotice = search.Name
+
+	// The filters may differ, so rank from scratch
+	m.searchedQuery = ""
+	m.updateSearchResults()
+
+	if m.ready {
+		m.viewport.SetYOffset(0)
+	}
+
+	return func() tea.Msg {
+		return SearchActivatedMsg{Active: true}
+	}
+}
+
+// orDefault returns value, or fallback when value is empty.
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
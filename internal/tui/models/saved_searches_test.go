@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func typeKeys(model *AppsModel, text string) {
+	for _, r := range text {
+		model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestSaveAndCycleSearches(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	model := NewTestAppsModel(styles.New(), 120, 40)
+	model.SetPreferencesPath(path)
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	typeKeys(model, "o")
+	model.Update(FilterUpdateMsg{InstallStatus: FilterInstalled, PackageType: FilterAll, SortOption: "Name"})
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.True(t, model.namingSearch)
+	typeKeys(model, "my stack")
+	assert.Contains(t, model.renderSearchHeader(), "Save search as: my stack")
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.False(t, model.namingSearch)
+	assert.Equal(t, "o", model.searchQuery, "naming doesn't edit the query")
+
+	prefs, err := config.LoadPreferences(path)
+	require.NoError(t, err)
+	assert.Equal(t, []config.SavedSearch{{Name: "my stack", Query: "o", Status: FilterInstalled, Sort: "Name"}}, prefs.Searches)
+
+	// Leave search with other filters, then bring the saved one back
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEsc})
+	model.installStatusFilter = FilterAll
+	model.savedSearchIndex = -1
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyCtrlN})
+	assert.True(t, model.searchActive)
+	assert.False(t, model.searchHasFocus, "results have focus for navigation")
+	assert.Equal(t, "o", model.searchQuery)
+	assert.Equal(t, FilterInstalled, model.installStatusFilter)
+
+	for _, found := range model.results.VisibleItems() {
+		assert.True(t, found.Installed)
+	}
+}
+
+func TestCycleWithoutSavedSearches(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+
+	_, cmd := model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyCtrlN})
+	assert.Nil(t, cmd)
+	assert.False(t, model.searchActive)
+	assert.Contains(t, model.renderCleanHeader(), "No saved searches")
+}
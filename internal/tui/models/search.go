@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// searchDebounce is how long typing must pause before results are recomputed.
+const searchDebounce = 120 * time.Millisecond
+
+// Match scores, from best to worst. Fuzzy matches score below scoreContains
+// depending on how spread out the matched characters are.
+const (
+	scoreExact        = 100
+	scorePrefix       = 80
+	scoreWordBoundary = 60
+	scoreContains     = 40
+	scoreFuzzyMax     = 30
+	minFuzzyScore     = 10
+)
+
+// Penalties for matching fields other than the app name or key.
+const (
+	penaltyAlias       = 5
+	penaltyGroup       = 15
+	penaltyDescription = 20
+)
+
+// SearchDebounceMsg fires when typing pauses; stale messages are ignored.
+type SearchDebounceMsg struct {
+	Seq int
+}
+
+// searchMatch is an app matching the search query with its rank.
+type searchMatch struct {
+	app       app
+	score     int
+	positions []int // Rune indices of the app name to highlight
+}
+
+// debounceSearch schedules recomputing results once typing pauses.
+func (m *AppsModel) debounceSearch() tea.Cmd {
+	m.searchSeq++
+	seq := m.searchSeq
+
+	return tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+		return SearchDebounceMsg{Seq: seq}
+	})
+}
+
+// rankApps scores candidates against query and returns the matches, best
+// first. Ties keep the order of candidates.
+func rankApps(candidates []app, query string) []searchMatch {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	matches := make([]searchMatch, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		if match, ok := scoreApp(candidate, query); ok {
+			matches = append(matches, match)
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b searchMatch) int {
+		return cmp.Compare(b.score, a.score)
+	})
+
+	return matches
+}
+
+// scoreApp returns the best score of query against the app's name, key,
+// aliases, group and description.
+func scoreApp(candidate app, query string) (searchMatch, bool) {
+	best := searchMatch{app: candidate}
+
+	consider := func(field string, penalty int, fuzzy bool, highlight bool) {
+		score, positions := scoreField(field, query, fuzzy)
+		if score == 0 || score-penalty <= best.score {
+			return
+		}
+
+		best.score = score - penalty
+		best.positions = nil
+
+		if highlight {
+			best.positions = positions
+		}
+	}
+
+	consider(candidate.Name, 0, true, true)
+	consider(candidate.Key, 0, true, false)
+
+	for _, alias := range candidate.Aliases {
+		consider(alias, penaltyAlias, true, false)
+	}
+
+	// Fuzzy matching long or generic text gives too many false positives
+	consider(candidate.Group, penaltyGroup, false, false)
+	consider(candidate.Description, penaltyDescription, false, false)
+
+	return best, best.score > 0
+}
+
+// scoreField scores query against one field and returns the matched rune
+// positions, or 0 when it does not match.
+func scoreField(field, query string, fuzzy bool) (int, []int) {
+	if field == "" || query == "" {
+		return 0, nil
+	}
+
+	lower := []rune(strings.ToLower(field))
+	needle := []rune(query)
+
+	if idx := runeIndex(lower, needle, 0); idx >= 0 {
+		switch {
+		case len(lower) == len(needle):
+			return scoreExact, span(0, len(needle))
+		case idx == 0:
+			return scorePrefix, span(0, len(needle))
+		}
+
+		// Prefer a later occurrence that starts a word
+		for start := idx; start >= 0; start = runeIndex(lower, needle, start+1) {
+			if isWordStart(lower, start) {
+				return scoreWordBoundary, span(start, len(needle))
+			}
+		}
+
+		return scoreContains, span(idx, len(needle))
+	}
+
+	if !fuzzy {
+		return 0, nil
+	}
+
+	return fuzzyScore(lower, needle)
+}
+
+// fuzzyScore matches needle as a subsequence of haystack. Each character
+// skipped between matches costs a point, so tight matches rank higher.
+func fuzzyScore(haystack, needle []rune) (int, []int) {
+	positions := make([]int, 0, len(needle))
+	next := 0
+
+	for i, r := range haystack {
+		if next < len(needle) && r == needle[next] {
+			positions = append(positions, i)
+			next++
+		}
+	}
+
+	if next < len(needle) {
+		return 0, nil
+	}
+
+	gaps := positions[len(positions)-1] - positions[0] + 1 - len(needle)
+
+	score := scoreFuzzyMax - gaps
+	if score < minFuzzyScore {
+		return 0, nil
+	}
+
+	return score, positions
+}
+
+// runeIndex returns the index of needle in haystack at or after from, or -1.
+func runeIndex(haystack, needle []rune, from int) int {
+	for i := from; i+len(needle) <= len(haystack); i++ {
+		if slices.Equal(haystack[i:i+len(needle)], needle) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// isWordStart reports whether position i starts a word.
+func isWordStart(text []rune, i int) bool {
+	return i == 0 || !unicode.IsLetter(text[i-1]) && !unicode.IsDigit(text[i-1])
+}
+
+// span returns the positions [start, start+length).
+func span(start, length int) []int {
+	positions := make([]int, length)
+	for i := range positions {
+		positions[i] = start + i
+	}
+
+	return positions
+}
+
+// highlightMatches renders text with the runes at positions in the match
+// style and the rest in base, padded to width.
+func highlightMatches(text string, positions []int, base, match lipgloss.Style, width int) string {
+	runes := []rune(text)
+	if len(positions) == 0 {
+		return base.Render(text) + strings.Repeat(" ", max(width-lipgloss.Width(text), 0))
+	}
+
+	var out strings.Builder
+
+	for start := 0; start < len(runes); {
+		matched := slices.Contains(positions, start)
+		end := start + 1
+
+		for end < len(runes) && slices.Contains(positions, end) == matched {
+			end++
+		}
+
+		style := base
+		if matched {
+			style = match
+		}
+
+		out.WriteString(style.Render(string(runes[start:end])))
+
+		start = end
+	}
+
+	return out.String() + strings.Repeat(" ", max(width-lipgloss.Width(text), 0))
+}
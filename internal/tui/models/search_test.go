@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreField(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		field     string
+		query     string
+		fuzzy     bool
+		score     int
+		positions []int
+	}{
+		{"Git", "git", true, scoreExact, []int{0, 1, 2}},
+		{"Github CLI", "git", true, scorePrefix, []int{0, 1, 2}},
+		{"Visual Studio Code", "code", true, scoreWordBoundary, []int{14, 15, 16, 17}},
+		{"Hadolint", "lint", true, scoreContains, []int{4, 5, 6, 7}},
+		{"Visual Studio Code", "vsc", true, scoreFuzzyMax - 12, []int{0, 2, 14}},
+		{"Visual Studio Code", "vsc", false, 0, nil},
+		{"Docker", "dkr", true, scoreFuzzyMax - 3, []int{0, 3, 5}},
+		{"Signal desktop messenger", "sr", true, 0, nil},
+	}
+
+	for _, tt := range tests {
+		score, positions := scoreField(tt.field, tt.query, tt.fuzzy)
+		assert.Equal(t, tt.score, score, "%s/%s", tt.field, tt.query)
+		assert.Equal(t, tt.positions, positions, "%s/%s", tt.field, tt.query)
+	}
+}
+
+func TestRankAppsOrdersByMatchQuality(t *testing.T) {
+	t.Parallel()
+
+	candidates := []app{
+		{Key: "hadolint", Name: "Hadolint", Description: "Dockerfile linter"},
+		{Key: "vscode", Name: "Visual Studio Code", Description: "Code editor", Aliases: []string{"code"}},
+		{Key: "docker", Name: "Docker", Description: "Containerization"},
+		{Key: "firefox", Name: "Firefox", Description: "Web browser", Group: "Browsers"},
+	}
+
+	keys := func(matches []searchMatch) []string {
+		result := make([]string, 0, len(matches))
+		for _, match := range matches {
+			result = append(result, match.app.Key)
+		}
+
+		return result
+	}
+
+	assert.Equal(t, []string{"docker", "hadolint"}, keys(rankApps(candidates, "dock")), "name prefix beats description")
+	assert.Equal(t, []string{"vscode"}, keys(rankApps(candidates, "code")), "aliases match")
+	assert.Equal(t, []string{"firefox"}, keys(rankApps(candidates, "browsers")), "groups match")
+	assert.Empty(t, rankApps(candidates, "zzz"))
+}
+
+func TestSearchIsDebounced(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 100, 40)
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	_, cmd := model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	require.NotNil(t, cmd)
+	assert.Len(t, model.results.VisibleItems(), 10, "results wait for typing to pause")
+
+	model.Update(SearchDebounceMsg{Seq: model.searchSeq - 1})
+	assert.Len(t, model.results.VisibleItems(), 10, "stale ticks are ignored")
+
+	model.Update(SearchDebounceMsg{Seq: model.searchSeq})
+	require.NotEmpty(t, model.results.VisibleItems())
+	assert.Equal(t, "firefox", model.results.VisibleItems()[0].Key)
+	assert.Equal(t, []int{0, 1}, model.searchHighlight["firefox"])
+}
+
+func TestHighlightMatches(t *testing.T) {
+	t.Parallel()
+
+	plain := lipgloss.NewStyle()
+
+	assert.Equal(t, "Git  ", highlightMatches("Git", nil, plain, plain, 5))
+	assert.Equal(t, "Docker  ", highlightMatches("Docker", []int{0, 3}, plain, plain, 8))
+}
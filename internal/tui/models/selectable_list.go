@@ -0,0 +1,293 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ItemRenderer renders one list item. focused marks the item under the cursor
+// and highlight holds the rune positions of its label matching the search.
+type ItemRenderer[T any] func(item T, focused bool, state SelectionState, highlight []int) string
+
+// SelectableList is a list bubble with vim navigation, optional search,
+// per-item selection states and a viewport that follows the cursor. Screens
+// supply the items and how to render them.
+type SelectableList[T any] struct {
+	nav        listNav
+	items      []T
+	key        func(T) string // Identifies items in the selection states
+	label      func(T) string // Searchable text; nil leaves searching to the owner
+	render     ItemRenderer[T]
+	states     map[string]SelectionState
+	selectable bool
+	header     string
+	itemLines  []int // First content line of each visible item, after the header
+	viewport   viewport.Model
+}
+
+// NewSelectableList creates a list of items. label makes the list searchable
+// with /; pass nil when the owner filters the items itself.
+func NewSelectableList[T any](items []T, key, label func(T) string, render ItemRenderer[T]) *SelectableList[T] {
+	list := &SelectableList[T]{
+		key:      key,
+		label:    label,
+		render:   render,
+		states:   make(map[string]SelectionState),
+		viewport: viewport.New(0, 0),
+	}
+	list.SetItems(items)
+
+	return list
+}
+
+// SetSelectable enables marking items with Space (install) and d (uninstall).
+func (l *SelectableList[T]) SetSelectable(selectable bool) {
+	l.selectable = selectable
+}
+
+// SetStates shares a selection state map with the owner, so marks survive
+// the list being rebuilt.
+func (l *SelectableList[T]) SetStates(states map[string]SelectionState) {
+	l.states = states
+	l.refresh()
+}
+
+// SetHeader sets the lines rendered above the items.
+func (l *SelectableList[T]) SetHeader(header string) {
+	l.header = header
+	l.refresh()
+}
+
+// SetSize sizes the list's own viewport.
+func (l *SelectableList[T]) SetSize(width, height int) {
+	l.viewport.Width = width
+	l.viewport.Height = height
+	l.refresh()
+}
+
+// SetItems replaces the items, keeping the search query and resetting the
+// cursor to the first match.
+func (l *SelectableList[T]) SetItems(items []T) {
+	l.items = items
+
+	labels := make([]string, len(items))
+	if l.label != nil {
+		for i, item := range items {
+			labels[i] = l.label(item)
+		}
+	}
+
+	l.nav.labels = labels
+	l.nav.filter()
+	l.viewport.SetYOffset(0)
+	l.refresh()
+}
+
+// VisibleItems returns the items shown, in display order.
+func (l *SelectableList[T]) VisibleItems() []T {
+	visible := make([]T, 0, len(l.nav.visible))
+	for _, index := range l.nav.visible {
+		visible = append(visible, l.items[index])
+	}
+
+	return visible
+}
+
+// Len returns the number of items shown.
+func (l *SelectableList[T]) Len() int {
+	return len(l.nav.visible)
+}
+
+// Cursor returns the position of the cursor among the visible items.
+func (l *SelectableList[T]) Cursor() int {
+	return l.nav.cursor
+}
+
+// SetCursor moves the cursor to position, clamped to the visible items.
+func (l *SelectableList[T]) SetCursor(position int) {
+	l.nav.cursor = 0
+	l.nav.move(position)
+	l.refresh()
+}
+
+// MoveCursor moves the cursor by delta, stopping at the ends.
+func (l *SelectableList[T]) MoveCursor(delta int) {
+	l.nav.move(delta)
+	l.refresh()
+}
+
+// Selected returns the item under the cursor.
+func (l *SelectableList[T]) Selected() (T, bool) {
+	index, ok := l.nav.selected()
+	if !ok {
+		var zero T
+
+		return zero, false
+	}
+
+	return l.items[index], true
+}
+
+// Query returns the search query.
+func (l *SelectableList[T]) Query() string {
+	return l.nav.query
+}
+
+// Searching reports whether the search input has focus.
+func (l *SelectableList[T]) Searching() bool {
+	return l.nav.searching
+}
+
+// SearchActive reports whether a query narrows the list or is being typed.
+func (l *SelectableList[T]) SearchActive() bool {
+	return l.nav.searchActive()
+}
+
+// State returns the selection state of item.
+func (l *SelectableList[T]) State(item T) SelectionState {
+	return l.states[l.key(item)]
+}
+
+// SetFocusedState marks the item under the cursor, clearing it for StateNone.
+func (l *SelectableList[T]) SetFocusedState(state SelectionState) {
+	item, ok := l.Selected()
+	if !ok {
+		return
+	}
+
+	if state == StateNone {
+		delete(l.states, l.key(item))
+	} else {
+		l.states[l.key(item)] = state
+	}
+
+	l.refresh()
+}
+
+// ToggleFocusedState marks the item under the cursor with state, or clears
+// the mark when it already has it.
+func (l *SelectableList[T]) ToggleFocusedState(state SelectionState) {
+	item, ok := l.Selected()
+	if !ok {
+		return
+	}
+
+	if l.State(item) == state {
+		state = StateNone
+	}
+
+	l.SetFocusedState(state)
+}
+
+// Marked returns the items in state, in item order.
+func (l *SelectableList[T]) Marked(state SelectionState) []T {
+	var marked []T
+
+	for _, item := range l.items {
+		if l.State(item) == state {
+			marked = append(marked, item)
+		}
+	}
+
+	return marked
+}
+
+// HandleKey applies navigation, search and selection keys and reports
+// whether the key was consumed.
+func (l *SelectableList[T]) HandleKey(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "/":
+		if l.label == nil {
+			return false
+		}
+	case " ":
+		if l.selectable && !l.nav.searching {
+			l.ToggleFocusedState(StateInstall)
+
+			return true
+		}
+	case "d":
+		if l.selectable && !l.nav.searching {
+			l.SetFocusedState(StateUninstall)
+
+			return true
+		}
+	}
+
+	if !l.nav.handleKey(msg) {
+		return false
+	}
+
+	l.refresh()
+
+	return true
+}
+
+// Update forwards other messages, such as mouse wheel scrolling, to the
+// viewport.
+func (l *SelectableList[T]) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+
+	l.viewport, cmd = l.viewport.Update(msg)
+
+	return cmd
+}
+
+// Render renders the header and every visible item, without scrolling.
+func (l *SelectableList[T]) Render() string {
+	var blocks []string
+
+	line := 0
+	l.itemLines = l.itemLines[:0]
+
+	if l.header != "" {
+		blocks = append(blocks, l.header)
+		line = lipgloss.Height(l.header)
+	}
+
+	for position, index := range l.nav.visible {
+		item := l.items[index]
+		rendered := l.render(item, position == l.nav.cursor, l.State(item), l.nav.highlights[index])
+
+		l.itemLines = append(l.itemLines, line)
+		line += lipgloss.Height(rendered)
+
+		blocks = append(blocks, rendered)
+	}
+
+	return strings.Join(blocks, "\n")
+}
+
+// SelectionLine returns the content line of the item under the cursor, as
+// of the last render.
+func (l *SelectableList[T]) SelectionLine() int {
+	if l.nav.cursor < len(l.itemLines) {
+		return l.itemLines[l.nav.cursor]
+	}
+
+	return 0
+}
+
+// View renders the list in its viewport.
+func (l *SelectableList[T]) View() string {
+	return l.viewport.View()
+}
+
+// refresh re-renders the viewport content and scrolls the cursor into view.
+func (l *SelectableList[T]) refresh() {
+	if l.viewport.Height <= 0 || l.render == nil {
+		return
+	}
+
+	l.viewport.SetContent(l.Render())
+
+	if len(l.nav.visible) > 0 {
+		followSelection(&l.viewport, l.SelectionLine(), listFollowBuffer)
+	}
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestList(items []string) *SelectableList[string] {
+	identity := func(item string) string { return item }
+
+	return NewSelectableList(items, identity, identity,
+		func(item string, focused bool, state SelectionState, highlight []int) string {
+			return fmt.Sprintf("%v %d %s %v", focused, state, item, highlight)
+		})
+}
+
+func TestSelectableListStates(t *testing.T) {
+	t.Parallel()
+
+	list := newTestList([]string{"git", "vim", "zsh"})
+	list.SetSelectable(true)
+
+	require.True(t, list.HandleKey(runeKey(' ')))
+	assert.Equal(t, StateInstall, list.State("git"))
+
+	list.HandleKey(runeKey(' '))
+	assert.Equal(t, StateNone, list.State("git"), "Space toggles the mark off")
+
+	list.HandleKey(runeKey('j'))
+	list.HandleKey(runeKey('d'))
+	list.HandleKey(runeKey('j'))
+	list.HandleKey(runeKey(' '))
+
+	assert.Equal(t, []string{"vim"}, list.Marked(StateUninstall))
+	assert.Equal(t, []string{"zsh"}, list.Marked(StateInstall))
+
+	list.SetSelectable(false)
+	assert.False(t, list.HandleKey(runeKey(' ')), "selection keys are left to the owner")
+}
+
+func TestSelectableListSharedStatesSurviveSetItems(t *testing.T) {
+	t.Parallel()
+
+	states := map[string]SelectionState{"vim": StateInstall}
+
+	list := newTestList([]string{"git"})
+	list.SetStates(states)
+	list.SetItems([]string{"vim", "git"})
+
+	assert.Equal(t, StateInstall, list.State("vim"))
+
+	list.ToggleFocusedState(StateInstall)
+	assert.NotContains(t, states, "vim", "changes are visible to the owner")
+}
+
+func TestSelectableListSearch(t *testing.T) {
+	t.Parallel()
+
+	list := newTestList([]string{"gimp", "git", "vim"})
+
+	list.HandleKey(runeKey('/'))
+
+	for _, r := range "git" {
+		list.HandleKey(runeKey(r))
+	}
+
+	assert.True(t, list.Searching())
+	assert.Equal(t, []string{"git"}, list.VisibleItems())
+
+	list.HandleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	list.SetItems([]string{"git", "github", "vim"})
+	assert.Equal(t, []string{"git", "github"}, list.VisibleItems(), "new items are filtered by the query")
+
+	owner := NewSelectableList([]string{"a"}, strings.ToUpper, nil,
+		func(item string, _ bool, _ SelectionState, _ []int) string { return item })
+	assert.False(t, owner.HandleKey(runeKey('/')), "lists without labels leave searching to the owner")
+}
+
+func TestSelectableListRenderAndFollow(t *testing.T) {
+	t.Parallel()
+
+	items := make([]string, 30)
+	for i := range items {
+		items[i] = fmt.Sprintf("item%02d", i)
+	}
+
+	identity := func(item string) string { return item }
+	list := NewSelectableList(items, identity, nil,
+		func(item string, focused bool, _ SelectionState, _ []int) string {
+			if focused {
+				return "> " + item + "\n  details"
+			}
+
+			return "  " + item
+		})
+	list.SetHeader("Items\n")
+	list.SetSize(20, 10)
+
+	list.SetCursor(2)
+	assert.Equal(t, 4, list.SelectionLine(), "two header lines precede the items")
+
+	rendered := list.Render()
+	assert.Contains(t, rendered, "> item02\n  details\n  item03")
+
+	list.HandleKey(runeKey('G'))
+	assert.Equal(t, 31, list.SelectionLine())
+	assert.Equal(t, 23, list.viewport.YOffset, "the viewport follows the cursor to the end")
+	assert.Contains(t, list.View(), "> item29")
+
+	item, ok := list.Selected()
+	require.True(t, ok)
+	assert.Equal(t, "item29", item)
+}
+
+func TestThemesSearchAndJump(t *testing.T) {
+	t.Parallel()
+
+	model := NewThemes(styles.New())
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	model.Update(runeKey('G'))
+	assert.Equal(t, model.themes[len(model.themes)-1].Name, model.currentTheme().Name)
+
+	model.Update(runeKey('/'))
+
+	for _, r := range "nord" {
+		model.Update(runeKey(r))
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "nord", model.currentTheme().Name)
+	assert.Contains(t, model.View(), "/nord")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "nord", model.GetSelectedTheme().Name)
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, cmd, "the first Esc clears the search")
+	assert.Equal(t, len(model.themes), model.list.Len())
+}
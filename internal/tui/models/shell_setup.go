@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"os"
+
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/adapters/system"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// ShellSetup is what the user's shell needs to reach the apps a run installed.
+type ShellSetup struct {
+	Config  *application.ShellConfig // Login shell configuration, nil when the shell is unknown
+	Setup   domain.ShellSetup
+	Missing []string // Lines the configuration lacks
+	Applied bool     // Whether Missing was added to the configuration
+
+	installed []string
+}
+
+// shellSetupFor checks whether the login shell reaches the installed apps.
+// It returns nil when the shell needs nothing.
+func shellSetupFor(installed []string) *ShellSetup {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	setup := application.RequiredShellSetup(installed, configuredMethodPreference(), os.Getenv("PATH"), home)
+	if setup.Empty() {
+		return nil
+	}
+
+	result := &ShellSetup{Setup: setup, installed: installed}
+
+	if shell, ok := system.LoginShell(); ok {
+		result.Config = application.NewShellConfig(platform.NewFileManager(false), shell,
+			shell.ConfigFile(home, config.GetXDGConfigHome()), home)
+		result.Missing = result.Config.Missing(setup)
+	}
+
+	return result
+}
+
+// Hint tells how to pick up the shell changes.
+func (s *ShellSetup) Hint() string {
+	if s.Config == nil {
+		return domain.Shell("").ReloadHint()
+	}
+
+	return s.Config.Shell().ReloadHint()
+}
+
+// Pending reports whether there are lines to offer adding.
+func (s *ShellSetup) Pending() bool {
+	return s != nil && s.Config != nil && len(s.Missing) > 0 && !s.Applied
+}
+
+// Apply adds the missing lines to the shell configuration.
+func (s *ShellSetup) Apply() error {
+	if _, err := s.Config.Apply(s.Setup); err != nil {
+		return err
+	}
+
+	s.Applied = true
+
+	// The lines are in place either way; unrecorded ones stay after uninstall
+	_ = application.RecordShellLines(application.NewManifests(platform.NewFileManager(false), xdg.ManifestDir()),
+		s.Config, s.installed, configuredMethodPreference(), os.Getenv("PATH"))
+
+	return nil
+}
@@ -7,64 +7,67 @@ package models
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/janderssonse/karei/internal/adapters/platform"
 	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/apps"
+	"github.com/janderssonse/karei/internal/stringutil"
 	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/janderssonse/karei/internal/xdg"
 )
 
-// SystemStatus represents the overall system status.
-type SystemStatus struct {
-	Health             string // "excellent", "good", "warning", "error"
-	InstalledApps      int
-	AvailableApps      int
-	CurrentTheme       string
-	LastUpdate         time.Time
-	DiskSpaceUsed      string
-	DiskSpaceAvailable string
-	SystemUptime       time.Duration
-}
+// statusGatherTimeout bounds how long the dashboard waits for the package
+// managers, which may ask remotes for updates.
+const statusGatherTimeout = 30 * time.Second
 
-// CategoryStatus represents status for an application category.
-type CategoryStatus struct {
-	Name       string
-	Installed  int
-	Available  int
-	Completion float64
-	Status     string // "complete", "partial", "empty"
+// DashboardLoadedMsg carries a gathered dashboard to the status screen.
+type DashboardLoadedMsg struct {
+	Dashboard *application.Dashboard
 }
 
-// ActivityEntry represents a recent activity log entry.
-type ActivityEntry struct {
-	Timestamp   time.Time
-	Action      string
-	Target      string
-	Status      string // "success", "warning", "error"
-	Description string
-}
+// statusPanel is a card of the dashboard, in focus order.
+type statusPanel int
+
+const (
+	panelInstalled statusPanel = iota
+	panelUpdates
+	panelDiskUsage
+	panelAppearance
+	panelLastRun
+	panelCount
+)
 
-// Status represents the system status screen model.
+// Status is the read-only dashboard of what karei manages: installs by
+// method, pending updates, disk usage, theme and font, and the last run.
+// Enter opens the screen behind the focused panel.
+//
+//nolint:containedctx // TUI models require context for proper cancellation propagation
 type Status struct {
-	styles         *styles.Styles
-	width          int
-	height         int
-	systemStatus   SystemStatus
-	categories     []CategoryStatus
-	recentActivity []ActivityEntry
-	suggestions    []string
-	refreshing     bool
-	quitting       bool
-	keyMap         StatusKeyMap
-	helpModal      *HelpModal
-	statusService  *application.StatusService // Optional real data service
+	ctx       context.Context
+	styles    *styles.Styles
+	width     int
+	height    int
+	service   *application.DashboardService
+	dashboard *application.Dashboard
+	loading   bool
+	focus     statusPanel
+	quitting  bool
+	keyMap    StatusKeyMap
+	helpModal *HelpModal
 }
 
 // StatusKeyMap defines key bindings for the status screen.
 type StatusKeyMap struct {
+	Next    key.Binding
+	Prev    key.Binding
+	Open    key.Binding
 	Refresh key.Binding
 	Back    key.Binding
 	Help    key.Binding
@@ -74,12 +77,24 @@ type StatusKeyMap struct {
 // DefaultStatusKeyMap returns the default key bindings.
 func DefaultStatusKeyMap() StatusKeyMap {
 	return StatusKeyMap{
+		Next: key.NewBinding(
+			key.WithKeys("tab", "down", "right", "j"),
+			key.WithHelp("tab", "next panel"),
+		),
+		Prev: key.NewBinding(
+			key.WithKeys("shift+tab", "up", "left", "k"),
+			key.WithHelp("shift+tab", "previous panel"),
+		),
+		Open: key.NewBinding(
+			key.WithKeys(KeyEnter),
+			key.WithHelp("enter", "open panel"),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r", "f5"),
 			key.WithHelp("r/F5", "refresh status"),
 		),
 		Back: key.NewBinding(
-			key.WithKeys("esc"),
+			key.WithKeys(KeyEsc),
 			key.WithHelp("esc", "back to menu"),
 		),
 		Help: key.NewBinding(
@@ -87,141 +102,74 @@ func DefaultStatusKeyMap() StatusKeyMap {
 			key.WithHelp("?", "help"),
 		),
 		Quit: key.NewBinding(
-			key.WithKeys("q", "ctrl+c"),
+			key.WithKeys("q", KeyCtrlC),
 			key.WithHelp("q", "quit"),
 		),
 	}
 }
 
-// NewStatus creates a new status model.
-func NewStatus(styleConfig *styles.Styles) *Status {
-	return NewStatusWithService(styleConfig, nil)
+// newInstalledPackageManager creates a package manager for checking on
+// installed apps, knowing from karei's install records how each was installed.
+func newInstalledPackageManager() *application.PackageManager {
+	packages := application.NewPackageManager(apps.NewTUIManager(false).PackageInstaller(), nil, false) // TUI-optimized installer suppresses command output
+	packages.SetInstallRecords(application.NewInstallRecords(platform.NewFileManager(false), xdg.InstallRecordsFile()))
+	packages.SetMethodPreference(configuredMethodPreference())
+	packages.SetFlatpakScopes(configuredFlatpakScopes())
+
+	return packages
 }
 
-// NewStatusWithService creates a status model with optional real data service.
-func NewStatusWithService(styleConfig *styles.Styles, service *application.StatusService) *Status {
-	// Default mock data - will be replaced by real data if service is provided
-	systemStatus := SystemStatus{
-		Health:             "excellent",
-		InstalledApps:      23,
-		AvailableApps:      45,
-		CurrentTheme:       "🌃 Tokyo Night (Dark)",
-		LastUpdate:         time.Now().Add(-2 * time.Hour),
-		DiskSpaceUsed:      "2.1 GB",
-		DiskSpaceAvailable: "125.4 GB",
-		SystemUptime:       24*time.Hour + 15*time.Minute,
-	}
+// NewStatus creates the status dashboard, reading karei's install records
+// and journal and asking the package managers for updates.
+func NewStatus(ctx context.Context, styleConfig *styles.Styles) *Status {
+	fileManager := platform.NewFileManager(false)
+	records := application.NewInstallRecords(fileManager, xdg.InstallRecordsFile())
 
-	// Sample category data
-	categories := []CategoryStatus{
-		{
-			Name:       "Development Tools",
-			Installed:  8,
-			Available:  12,
-			Completion: 0.67,
-			Status:     "partial",
-		},
-		{
-			Name:       "System Utilities",
-			Installed:  6,
-			Available:  8,
-			Completion: 0.75,
-			Status:     "partial",
-		},
-		{
-			Name:       "Media & Graphics",
-			Installed:  3,
-			Available:  10,
-			Completion: 0.30,
-			Status:     "partial",
-		},
-		{
-			Name:       "Productivity",
-			Installed:  4,
-			Available:  8,
-			Completion: 0.50,
-			Status:     "partial",
-		},
-		{
-			Name:       "Security Tools",
-			Installed:  2,
-			Available:  7,
-			Completion: 0.29,
-			Status:     "partial",
-		},
-	}
+	packages := newInstalledPackageManager()
+	packages.SetInstallRecords(records)
 
-	// Sample recent activity
-	recentActivity := []ActivityEntry{
-		{
-			Timestamp:   time.Now().Add(-15 * time.Minute),
-			Action:      "install",
-			Target:      "Neovim",
-			Status:      "success",
-			Description: "Neovim installation completed successfully",
-		},
-		{
-			Timestamp:   time.Now().Add(-32 * time.Minute),
-			Action:      "install",
-			Target:      "Visual Studio Code",
-			Status:      "success",
-			Description: "Visual Studio Code installation completed",
-		},
-		{
-			Timestamp:   time.Now().Add(-1 * time.Hour),
-			Action:      "theme",
-			Target:      "Tokyo Night",
-			Status:      "success",
-			Description: "Applied Tokyo Night theme successfully",
-		},
-		{
-			Timestamp:   time.Now().Add(-2 * time.Hour),
-			Action:      "update",
-			Target:      "System packages",
-			Status:      "success",
-			Description: "Updated 142 system packages",
-		},
-		{
-			Timestamp:   time.Now().Add(-1 * 24 * time.Hour),
-			Action:      "install",
-			Target:      "Docker Desktop",
-			Status:      "success",
-			Description: "Docker Desktop installation completed",
-		},
-	}
+	service := application.NewDashboardService(packages, records, application.NewJournal(fileManager, xdg.JournalFile()))
 
-	// Sample suggestions
-	suggestions := []string{
-		"Install GIMP for advanced image editing",
-		"Consider adding Spotify for music streaming",
-		"Update available for 3 applications",
-		"Security audit recommended (run 'karei security')",
-		"Backup system configuration with Timeshift",
-	}
+	themes := application.NewThemeService(fileManager, nil, "", "")
+	themes.SetCurrentThemeFile(xdg.ThemeFile())
+	service.SetThemeService(themes)
+	service.SetFontService(application.NewFontService(fileManager, platform.NewTUICommandRunner(false, false), nil, "", ""))
 
-	// Create help modal
 	helpModal := NewHelpModal()
 	helpModal.SetScreen("status")
 
 	return &Status{
-		styles:         styleConfig,
-		systemStatus:   systemStatus,
-		categories:     categories,
-		recentActivity: recentActivity,
-		suggestions:    suggestions,
-		keyMap:         DefaultStatusKeyMap(),
-		helpModal:      helpModal,
-		statusService:  service,
+		ctx:       ctx,
+		styles:    styleConfig,
+		service:   service,
+		loading:   true,
+		keyMap:    DefaultStatusKeyMap(),
+		helpModal: helpModal,
 	}
 }
 
-// Init initializes the status model.
+// SetDashboardService replaces the service gathering the dashboard. Must be
+// called before Init.
+func (m *Status) SetDashboardService(service *application.DashboardService) {
+	m.service = service
+}
+
+// Init starts gathering the dashboard.
 func (m *Status) Init() tea.Cmd {
-	return nil
+	return m.load()
 }
 
-// Update handles messages and returns updated model and commands.
-//
+// load gathers the dashboard in the background.
+func (m *Status) load() tea.Cmd {
+	service, parent := m.service, m.ctx
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(parent, statusGatherTimeout)
+		defer cancel()
+
+		return DashboardLoadedMsg{Dashboard: service.Gather(ctx)}
+	}
+}
 
 // Update handles messages for the Status model.
 //
@@ -254,16 +202,27 @@ func (m *Status) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 
 		case key.Matches(msg, m.keyMap.Back):
-			// Navigate back to menu
 			return m, func() tea.Msg {
 				return NavigateMsg{Screen: MenuScreen}
 			}
 
 		case key.Matches(msg, m.keyMap.Refresh):
-			// Refresh status data
-			m.refreshing = true
+			if m.loading {
+				return m, nil
+			}
+
+			m.loading = true
+
+			return m, m.load()
+
+		case key.Matches(msg, m.keyMap.Next):
+			m.focus = (m.focus + 1) % panelCount
 
-			return m, m.refreshStatus()
+		case key.Matches(msg, m.keyMap.Prev):
+			m.focus = (m.focus + panelCount - 1) % panelCount
+
+		case key.Matches(msg, m.keyMap.Open):
+			return m, m.open()
 		}
 
 	case tea.WindowSizeMsg:
@@ -275,15 +234,28 @@ func (m *Status) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.helpModal.SetSize(msg.Width, msg.Height)
 		}
 
-	case refreshCompleteMsg:
-		m.refreshing = false
+	case DashboardLoadedMsg:
+		m.loading = false
+		m.dashboard = msg.Dashboard
 	}
 
 	return m, nil
 }
 
-// refreshCompleteMsg is sent when status refresh is complete.
-type refreshCompleteMsg struct{}
+// open navigates to the screen behind the focused panel: the apps, the
+// themes, or the history of runs.
+func (m *Status) open() tea.Cmd {
+	screen := AppsScreen
+
+	switch m.focus {
+	case panelAppearance:
+		screen = ThemeScreen
+	case panelLastRun:
+		screen = HistoryScreen
+	}
+
+	return func() tea.Msg { return NavigateMsg{Screen: screen} }
+}
 
 // View renders the status screen.
 func (m *Status) View() string {
@@ -309,48 +281,28 @@ func (m *Status) View() string {
 
 // renderBaseView renders the main status view without overlays.
 func (m *Status) renderBaseView() string {
-	var components []string
-
-	// Clean header
-	header := m.renderCleanHeader()
-	components = append(components, header)
-
-	// Calculate available height for content
 	headerHeight := 3 // Header with border
 	footerHeight := 3 // Footer with border
 	contentHeight := m.height - headerHeight - footerHeight
 
-	// Main content in columns with proper height
-	content := m.renderContent()
-
-	// Wrap content to fill available space
-	contentStyled := lipgloss.NewStyle().
+	content := lipgloss.NewStyle().
 		Height(contentHeight).
 		MaxHeight(contentHeight).
-		Render(content)
-
-	components = append(components, contentStyled)
-
-	// Clean footer
-	footer := m.renderCleanFooter()
-	components = append(components, footer)
+		Render(m.renderContent(contentHeight))
 
-	// Use lipgloss.JoinVertical for proper composition
-	return lipgloss.JoinVertical(lipgloss.Top, components...)
+	return lipgloss.JoinVertical(lipgloss.Top, m.renderCleanHeader(), content, m.renderCleanFooter())
 }
 
 // renderCleanHeader renders the new simplified header format.
 func (m *Status) renderCleanHeader() string {
 	// Left side: App name » Current location
-	location := "Karei » Status"
 	leftSide := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(m.styles.Primary).
-		Render(location)
+		Render("Karei » Status")
 
-	// Right side: Status (health or refreshing)
 	var status string
-	if m.refreshing {
+	if m.loading {
 		status = "Refreshing..."
 	}
 
@@ -358,405 +310,201 @@ func (m *Status) renderCleanHeader() string {
 		Foreground(m.styles.Muted).
 		Render(status)
 
-	// Calculate spacing
-	totalWidth := m.width
-	leftWidth := lipgloss.Width(leftSide)
-	rightWidth := lipgloss.Width(rightSide)
-	spacerWidth := totalWidth - leftWidth - rightWidth - 4
+	spacerWidth := max(m.width-lipgloss.Width(leftSide)-lipgloss.Width(rightSide)-4, 1)
 
-	if spacerWidth < 1 {
-		spacerWidth = 1
-	}
-
-	spacer := strings.Repeat(" ", spacerWidth)
-
-	// Combine with spacing
-	headerLine := leftSide + spacer + rightSide
-
-	// Style the header with subtle border
 	return lipgloss.NewStyle().
 		Padding(0, 2).
 		BorderStyle(lipgloss.NormalBorder()).
 		BorderBottom(true).
 		BorderForeground(lipgloss.Color("240")).
 		Width(m.width).
-		Render(headerLine)
+		Render(leftSide + strings.Repeat(" ", spacerWidth) + rightSide)
 }
 
 // renderCleanFooter renders the new simplified footer with context-aware actions.
 func (m *Status) renderCleanFooter() string {
-	// Context-aware footer actions with styled keys and descriptions
-	var actions []string
-
-	// Styles for different parts (matching apps page)
 	keyStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(m.styles.Primary) // Keys in primary color (blue)
-
-	bracketStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(m.styles.Primary) // Brackets also in primary color
+		Foreground(m.styles.Primary)
 
 	actionStyle := lipgloss.NewStyle().
-		Foreground(m.styles.Muted) // Actions in muted color
+		Foreground(m.styles.Muted)
 
-	// Helper function to format action (same as apps page)
 	formatAction := func(key, action string) string {
-		return bracketStyle.Render("[") +
-			keyStyle.Render(key) +
-			bracketStyle.Render("]") +
-			" " +
-			actionStyle.Render(action)
+		return keyStyle.Render("["+key+"]") + " " + actionStyle.Render(action)
 	}
 
-	// Status page actions
-	actions = []string{
+	actions := []string{
+		formatAction("Tab", "Next"),
+		formatAction("Enter", "Open"),
 		formatAction("R", "Refresh"),
-		formatAction("C", "Clear"),
 		formatAction("Esc", "Back"),
 	}
 
 	// Always add help with special styling (dim yellow to stand out)
-	helpKey := bracketStyle.Render("[") +
+	helpKey := keyStyle.Render("[") +
 		lipgloss.NewStyle().Bold(true).Foreground(m.styles.Warning).Render("?") +
-		bracketStyle.Render("]")
+		keyStyle.Render("]")
 	actions = append(actions, helpKey+" "+actionStyle.Render("Help"))
 
-	// Join actions with more spacing
-	footerText := strings.Join(actions, "   ")
-
-	// Style the footer container (exactly matching apps page)
 	return lipgloss.NewStyle().
 		Padding(0, 2).
 		BorderStyle(lipgloss.NormalBorder()).
 		BorderTop(true).
 		BorderForeground(lipgloss.Color("240")).
 		Width(m.width).
-		Render(footerText)
+		Render(strings.Join(actions, "   "))
 }
 
-// Commented out - replaced by renderCleanHeader for new simplified design
-// renderHeader creates the header with system health.
-// func (m *Status) renderHeader() string {
-// 	var builder strings.Builder
-
-// 	// Title with refresh indicator
-// 	title := "📊 System Status Dashboard"
-// 	if m.refreshing {
-// 		title += " (Refreshing...)"
-// 	}
-
-// 	titleStyled := m.styles.Title.Render(title)
-// 	builder.WriteString(titleStyled)
-// 	builder.WriteString("\n")
-
-// 	// Subtitle with last update time
-// 	lastUpdate := m.systemStatus.LastUpdate.Format("2006-01-02 15:04:05")
-// 	subtitle := "Last updated: " + lastUpdate
-// 	subtitleStyled := m.styles.Subtitle.Render(subtitle)
-// 	builder.WriteString(subtitleStyled)
-
-// 	return builder.String()
-// }
-
-// renderContent creates the main content area.
-func (m *Status) renderContent() string {
-	// Calculate column widths - give more room by removing separator
-	// Account for borders (2 chars per box) and minimal gap
-	availableWidth := m.width - 4 // Account for box borders
-	leftWidth := availableWidth / 2
-	rightWidth := availableWidth / 2
-
-	// Calculate equal box heights
-	// Total content height minus spacing between rows
-	headerHeight := 3 // Header with border
-	footerHeight := 3 // Footer with border
-	contentHeight := m.height - headerHeight - footerHeight
-
-	// Make boxes smaller and account for borders properly
-	// Each box gets less than half to leave breathing room
-	// We want the content + borders to fit, so we calculate content height
-	boxContentHeight := (contentHeight - 2) / 3 // Smaller boxes, more spacing
-	// Add 2 for the borders (top + bottom) that Lipgloss will add
-	boxHeight := boxContentHeight
-
-	// Left column: System overview + categories
-	leftContent := m.renderLeftColumn(leftWidth, boxHeight)
+// renderContent lays the panels out in two columns: the apps karei
+// manages on the left, the desktop and the last run on the right.
+func (m *Status) renderContent(height int) string {
+	if m.dashboard == nil {
+		return lipgloss.NewStyle().Padding(1, 2).Foreground(m.styles.Muted).Render("Gathering status...")
+	}
 
-	// Right column: Recent activity + suggestions
-	rightContent := m.renderRightColumn(rightWidth, boxHeight)
+	columnWidth := (m.width - 4) / 2 // Account for box borders
+	boxHeight := max((height-6)/3, 3)
 
-	// Join columns directly without separator
-	return lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		leftContent,
-		rightContent,
+	left := lipgloss.JoinVertical(lipgloss.Left,
+		m.renderPanel(panelInstalled, "Installed by karei", m.installedLines(), columnWidth, boxHeight),
+		m.renderPanel(panelUpdates, "Updates", m.updateLines(boxHeight), columnWidth, boxHeight),
+		m.renderPanel(panelDiskUsage, "Disk usage", m.diskUsageLines(), columnWidth, boxHeight),
 	)
-}
-
-// renderLeftColumn creates the left column content.
-func (m *Status) renderLeftColumn(width int, boxHeight int) string {
-	var builder strings.Builder
-
-	// System overview
-	overview := m.renderSystemOverview(width, boxHeight)
-	builder.WriteString(overview)
-	builder.WriteString("\n") // Reduced from \n\n to single newline
-
-	// Categories overview
-	categories := m.renderCategoriesOverview(width, boxHeight)
-	builder.WriteString(categories)
-
-	return builder.String()
-}
 
-// renderRightColumn creates the right column content.
-func (m *Status) renderRightColumn(width int, boxHeight int) string {
-	var builder strings.Builder
-
-	// Recent activity
-	activity := m.renderRecentActivity(width, boxHeight)
-	builder.WriteString(activity)
-	builder.WriteString("\n") // Reduced from \n\n to single newline
+	right := lipgloss.JoinVertical(lipgloss.Left,
+		m.renderPanel(panelAppearance, "Theme & font", m.appearanceLines(), columnWidth, boxHeight),
+		m.renderPanel(panelLastRun, "Last run", m.lastRunLines(2*boxHeight), columnWidth, 2*boxHeight+2),
+	)
 
-	// Suggestions
-	suggestions := m.renderSuggestions(width, boxHeight)
-	builder.WriteString(suggestions)
+	if m.dashboard.Err != nil {
+		right = lipgloss.JoinVertical(lipgloss.Left, right,
+			lipgloss.NewStyle().Foreground(m.styles.Warning).Render("! "+m.dashboard.Err.Error()))
+	}
 
-	return builder.String()
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, right)
 }
 
-// renderSystemOverview creates the system overview card.
-func (m *Status) renderSystemOverview(width int, boxHeight int) string {
-	var builder strings.Builder
+// renderPanel renders a dashboard card, highlighted when focused.
+func (m *Status) renderPanel(panel statusPanel, title string, lines []string, width, height int) string {
+	border := m.styles.Muted
+	if panel == m.focus {
+		border = m.styles.Primary
+	}
 
-	// Use tighter card style for dashboard with fixed height
-	// Height is for content only - borders are added on top
-	cardStyle := lipgloss.NewStyle().
+	return lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(m.styles.Muted).
-		Padding(0, 1). // Reduced padding from (1, 2)
+		BorderForeground(border).
+		Padding(0, 1).
 		Width(width).
-		Height(boxHeight) // This is content height - borders are extra
-
-	var content strings.Builder
-	content.WriteString(m.styles.Title.Render("Installation Summary"))
-	content.WriteString("\n\n")
-
-	// Key metrics
-	metrics := []struct {
-		label string
-		value string
-		icon  string
-	}{
-		{"Applications Installed", fmt.Sprintf("%d / %d available", m.systemStatus.InstalledApps, m.systemStatus.AvailableApps), "📦"},
-		{"Current Theme", m.systemStatus.CurrentTheme, "🎨"},
-		{"System Health", m.getHealthDisplay(m.systemStatus.Health), "🏥"},
-		{"Disk Space Used", fmt.Sprintf("%s (available: %s)", m.systemStatus.DiskSpaceUsed, m.systemStatus.DiskSpaceAvailable), "💾"},
-		{"System Uptime", m.formatDuration(m.systemStatus.SystemUptime), "⏰"},
-	}
-
-	for _, metric := range metrics {
-		line := fmt.Sprintf("%s %s: %s", metric.icon, metric.label, metric.value)
-		content.WriteString(line)
-		content.WriteString("\n")
-	}
-
-	builder.WriteString(cardStyle.Render(content.String()))
-
-	return builder.String()
+		Height(height). // Content height - borders are extra
+		MaxHeight(height + 2).
+		Render(m.styles.Title.Render(title) + "\n\n" + strings.Join(lines, "\n"))
 }
 
-// renderCategoriesOverview creates the categories overview.
-func (m *Status) renderCategoriesOverview(width int, boxHeight int) string {
-	var builder strings.Builder
-
-	// Use tighter card style for dashboard with fixed height
-	// Height is for content only - borders are added on top
-	cardStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(m.styles.Muted).
-		Padding(0, 1). // Reduced padding from (1, 2)
-		Width(width).
-		Height(boxHeight) // This is content height - borders are extra
-
-	var content strings.Builder
-	content.WriteString(m.styles.Title.Render("Categories"))
-	content.WriteString("\n\n")
-
-	// Show category statistics
-	categoryStats := []struct {
-		name  string
-		count int
-		icon  string
-	}{
-		{"Development", 12, "💻"},
-		{"Utilities", 8, "🔧"},
-		{"System", 6, "⚙️"},
-		{"Graphics", 4, "🎨"},
-		{"Communication", 3, "💬"},
+// installedLines lists how many apps karei installed with each method.
+func (m *Status) installedLines() []string {
+	if len(m.dashboard.Installed) == 0 {
+		return []string{m.muted("Nothing installed by karei yet")}
 	}
 
-	for _, cat := range categoryStats {
-		line := fmt.Sprintf("%s %s: %d apps", cat.icon, cat.name, cat.count)
-		content.WriteString(line)
-		content.WriteString("\n")
-	}
+	methods := slices.Sorted(maps.Keys(m.dashboard.Installed))
 
-	builder.WriteString(cardStyle.Render(content.String()))
+	lines := []string{fmt.Sprintf("%s apps", stringutil.FormatCount(m.dashboard.InstalledCount()))}
+	for _, method := range methods {
+		lines = append(lines, fmt.Sprintf("  %-10s %s", method, stringutil.FormatCount(m.dashboard.Installed[method])))
+	}
 
-	return builder.String()
+	return lines
 }
 
-// renderRecentActivity creates the recent activity panel.
-func (m *Status) renderRecentActivity(width int, boxHeight int) string {
-	var builder strings.Builder
-
-	// Use tighter card style for dashboard with fixed height
-	// Height is for content only - borders are added on top
-	cardStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(m.styles.Muted).
-		Padding(0, 1). // Reduced padding
-		Width(width).
-		Height(boxHeight) // This is content height - borders are extra
-
-	var content strings.Builder
-	content.WriteString(m.styles.Title.Render("Recent Activity"))
-	content.WriteString("\n\n")
-
-	// Show max activities that fit in the box
-	maxActivities := boxHeight - 2 // Account for title and spacing
-
-	activityCount := len(m.recentActivity)
-	if activityCount > maxActivities && maxActivities > 0 {
-		activityCount = maxActivities
+// updateLines lists the apps with a newer version, as many as fit.
+func (m *Status) updateLines(height int) []string {
+	if len(m.dashboard.Updates) == 0 {
+		return []string{m.muted("Everything is up to date")}
 	}
 
-	for i := range activityCount {
-		activity := m.recentActivity[i]
-		timeStr := activity.Timestamp.Format("15:04")
-		statusIcon := m.styles.StatusIcon(activity.Status)
+	lines := []string{fmt.Sprintf("%s updates available", stringutil.FormatCount(len(m.dashboard.Updates)))}
 
-		line := fmt.Sprintf("[%s] %s %s", timeStr, statusIcon, activity.Description)
-		content.WriteString(line)
+	keys := slices.Sorted(maps.Keys(m.dashboard.Updates))
+	for i, appKey := range keys {
+		if i >= height-3 {
+			lines = append(lines, m.muted(fmt.Sprintf("  and %d more", len(keys)-i)))
 
-		if i < activityCount-1 {
-			content.WriteString("\n")
+			break
 		}
-	}
 
-	builder.WriteString(cardStyle.Render(content.String()))
+		lines = append(lines, fmt.Sprintf("  %s ↑ %s", appKey, m.dashboard.Updates[appKey].Available))
+	}
 
-	return builder.String()
+	return lines
 }
 
-// renderSuggestions creates the suggestions panel.
-func (m *Status) renderSuggestions(width int, boxHeight int) string {
-	var builder strings.Builder
-
-	// Use tighter card style for dashboard with fixed height
-	// Height is for content only - borders are added on top
-	cardStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(m.styles.Muted).
-		Padding(0, 1). // Reduced padding from (1, 2)
-		Width(width).
-		Height(boxHeight) // This is content height - borders are extra
-
-	var content strings.Builder
-	content.WriteString(m.styles.Title.Render("🚀 Suggestions"))
-	content.WriteString("\n\n")
-
-	// Show max suggestions that fit in the box
-	maxSuggestions := boxHeight - 2 // Account for title and spacing
-
-	suggestionCount := len(m.suggestions)
-	if suggestionCount > maxSuggestions && maxSuggestions > 0 {
-		suggestionCount = maxSuggestions
+// diskUsageLines shows the space taken by the apps karei installed.
+func (m *Status) diskUsageLines() []string {
+	if m.dashboard.DiskUsage == 0 {
+		return []string{m.muted("Unknown")}
 	}
 
-	for i := range suggestionCount {
-		line := "• " + m.suggestions[i]
-		content.WriteString(line)
+	return []string{
+		stringutil.FormatBytes(m.dashboard.DiskUsage),
+		m.muted("APT, .deb and Flatpak installs"),
+	}
+}
 
-		if i < suggestionCount-1 {
-			content.WriteString("\n")
-		}
+// appearanceLines shows the current theme and font.
+func (m *Status) appearanceLines() []string {
+	theme, font := m.dashboard.Theme, m.dashboard.Font
+	if theme == "" {
+		theme = m.muted("not set by karei")
 	}
 
-	builder.WriteString(cardStyle.Render(content.String()))
+	if font == "" {
+		font = m.muted("unknown")
+	}
 
-	return builder.String()
+	return []string{"Theme  " + theme, "Font   " + font}
 }
 
-// GetNavigationHints returns screen-specific navigation hints for the footer.
-func (m *Status) GetNavigationHints() []string {
-	return []string{
-		"[r/F5] Refresh",
-		"[e] Export",
-		"[c] Clear Activity",
+// lastRunLines shows when the last run was and how each task went.
+func (m *Status) lastRunLines(height int) []string {
+	run := m.dashboard.LastRun
+	if run == nil {
+		return []string{m.muted("No runs recorded yet")}
 	}
-}
 
-// renderNavigationHeader renders the screen-specific navigation hints with styled tabs.
-
-// renderFooter creates the footer with universal keybindings only.
-// NOTE: This is kept for compatibility but not used - app.go handles the universal footer.
-func (m *Status) getHealthDisplay(health string) string {
-	switch health {
-	case "excellent":
-		return "🟢 Excellent"
-	case "good":
-		return "🟡 Good"
-	case "warning":
-		return "🟠 Warning"
-	case "error":
-		return "🔴 Error"
-	default:
-		return "❓ Unknown"
-	}
-}
+	failed := len(run.Failed())
+	lines := []string{fmt.Sprintf("%s, %d tasks, %d failed, took %s",
+		run.Finished.Format("2006-01-02 15:04"), len(run.Entries), failed,
+		stringutil.FormatDuration(run.Finished.Sub(run.Started).Round(time.Second)))}
 
-// formatDuration formats a duration in a human-readable way.
-func (m *Status) formatDuration(d time.Duration) string {
-	days := int(d.Hours()) / 24
-	hours := int(d.Hours()) % 24
-	minutes := int(d.Minutes()) % 60
+	for i, entry := range run.Entries {
+		if i >= height-3 {
+			lines = append(lines, m.muted(fmt.Sprintf("  and %d more", len(run.Entries)-i)))
 
-	if days > 0 {
-		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
-	}
+			break
+		}
+
+		status := "completed"
+		if !entry.Success {
+			status = "failed"
+		}
 
-	if hours > 0 {
-		return fmt.Sprintf("%dh %dm", hours, minutes)
+		lines = append(lines, fmt.Sprintf("%s %s %s", m.styles.StatusIcon(status), entry.Operation, entry.App))
 	}
 
-	return fmt.Sprintf("%dm", minutes)
+	return lines
 }
 
-// refreshStatus fetches real or simulated status data.
-func (m *Status) refreshStatus() tea.Cmd {
-	return func() tea.Msg {
-		// Use real service if available
-		if m.statusService != nil {
-			// Fetch real data (context would come from app in production)
-			ctx := context.Background()
-			if data, err := m.statusService.GetSystemStatus(ctx); err == nil {
-				// Update system status with real data
-				m.systemStatus.InstalledApps = data.InstalledApps
-				m.systemStatus.AvailableApps = data.AvailableApps
-				m.systemStatus.CurrentTheme = data.CurrentTheme
-				m.systemStatus.DiskSpaceUsed = application.FormatDiskSpace(data.DiskUsageGB)
-				m.systemStatus.DiskSpaceAvailable = application.FormatDiskSpace(data.DiskAvailGB)
-				m.systemStatus.SystemUptime = application.FormatUptime(data.UptimeHours)
-				m.systemStatus.LastUpdate = time.Now()
-			}
-		}
-
-		// Simulate delay for visual feedback
-		time.Sleep(500 * time.Millisecond)
+func (m *Status) muted(text string) string {
+	return lipgloss.NewStyle().Foreground(m.styles.Muted).Render(text)
+}
 
-		return refreshCompleteMsg{}
+// GetNavigationHints returns screen-specific navigation hints for the footer.
+func (m *Status) GetNavigationHints() []string {
+	return []string{
+		"[Tab] Next Panel",
+		"[Enter] Open",
+		"[r/F5] Refresh",
 	}
 }
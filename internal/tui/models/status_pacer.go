@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/janderssonse/karei/internal/adapters/system"
+)
+
+// Status check pacing. Checks start two at a time; a round of quick checks
+// on an idle system allows one more, while a slow check or a busy system
+// halves the number, like TCP congestion control.
+const (
+	initialStatusChecks  = 2
+	maxStatusChecks      = 8
+	fastStatusCheck      = 300 * time.Millisecond
+	slowStatusCheck      = 1500 * time.Millisecond
+	idleLoadPerCPU       = 0.7
+	busyLoadPerCPU       = 1.0
+	statusCheckSmoothing = 0.3 // Weight of the latest check in the mean duration
+)
+
+// statusPacer decides how many installation status checks run at once,
+// measuring how long they take and how loaded the system is, so slow
+// systems (dpkg on spinning disks, a busy CPU) aren't hammered while fast
+// ones finish instantly.
+type statusPacer struct {
+	limit    int // Checks allowed at once
+	maxLimit int
+	inFlight int
+	round    int // Checks finished since the limit last changed
+
+	checked  int
+	mean     time.Duration // Smoothed check duration
+	load     float64       // One-minute load per CPU, when readable
+	hasLoad  bool
+	backoffs int
+
+	readLoad func() (float64, bool)
+}
+
+// newStatusPacer creates a pacer allowing at most one check per CPU, up to maxStatusChecks.
+func newStatusPacer() *statusPacer {
+	return &statusPacer{
+		limit:    initialStatusChecks,
+		maxLimit: max(1, min(runtime.NumCPU(), maxStatusChecks)),
+		readLoad: system.LoadPerCPU,
+	}
+}
+
+// available returns how many more checks may start now.
+func (p *statusPacer) available() int {
+	return max(p.limit-p.inFlight, 0)
+}
+
+// started notes a check was started.
+func (p *statusPacer) started() {
+	p.inFlight++
+}
+
+// finished notes a check took elapsed and adapts the limit: down at once
+// when the check was slow or the system busy, up after a full round of
+// fast checks on an idle system.
+func (p *statusPacer) finished(elapsed time.Duration) {
+	p.inFlight = max(p.inFlight-1, 0)
+	p.checked++
+	p.round++
+
+	if p.mean == 0 {
+		p.mean = elapsed
+	} else {
+		p.mean += time.Duration(statusCheckSmoothing * float64(elapsed-p.mean))
+	}
+
+	p.load, p.hasLoad = p.readLoad()
+	busy := p.hasLoad && p.load >= busyLoadPerCPU
+	idle := !p.hasLoad || p.load < idleLoadPerCPU
+
+	switch {
+	case elapsed >= slowStatusCheck || busy:
+		if p.limit > 1 {
+			p.limit = max(p.limit/2, 1)
+			p.backoffs++
+		}
+
+		p.round = 0
+	case p.round >= p.limit && p.mean < fastStatusCheck && idle:
+		p.limit = min(p.limit+1, p.maxLimit)
+		p.round = 0
+	}
+}
+
+// String summarizes the pacing for the debug overlay.
+func (p *statusPacer) String() string {
+	load := "n/a"
+	if p.hasLoad {
+		load = fmt.Sprintf("%.2f", p.load)
+	}
+
+	return fmt.Sprintf("status checks: %d done · %d running · limit %d/%d · mean %s · load/cpu %s · backoffs %d",
+		p.checked, p.inFlight, p.limit, p.maxLimit, p.mean.Round(time.Millisecond), load, p.backoffs)
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestPacer returns a pacer allowing up to maxLimit checks on a system with the given load.
+func newTestPacer(maxLimit int, load float64) *statusPacer {
+	pacer := newStatusPacer()
+	pacer.maxLimit = maxLimit
+	pacer.readLoad = func() (float64, bool) { return load, true }
+
+	return pacer
+}
+
+// finishRound runs a full round of checks each taking elapsed.
+func finishRound(pacer *statusPacer, elapsed time.Duration) {
+	for range pacer.limit {
+		pacer.started()
+	}
+
+	for range pacer.limit {
+		pacer.finished(elapsed)
+	}
+}
+
+func TestStatusPacerRampsUpOnFastIdleSystems(t *testing.T) {
+	t.Parallel()
+
+	pacer := newTestPacer(4, 0.1)
+	assert.Equal(t, initialStatusChecks, pacer.available())
+
+	for range 10 {
+		finishRound(pacer, 20*time.Millisecond)
+	}
+
+	assert.Equal(t, 4, pacer.limit, "capped at the maximum")
+	assert.Zero(t, pacer.backoffs)
+}
+
+func TestStatusPacerBacksOffOnSlowChecks(t *testing.T) {
+	t.Parallel()
+
+	pacer := newTestPacer(8, 0.1)
+	pacer.limit = 8
+
+	pacer.started()
+	pacer.finished(3 * time.Second)
+	assert.Equal(t, 4, pacer.limit)
+
+	for range 5 {
+		finishRound(pacer, 3*time.Second)
+	}
+
+	assert.Equal(t, 1, pacer.limit, "never below one check")
+	assert.Equal(t, 3, pacer.backoffs)
+}
+
+func TestStatusPacerBacksOffUnderLoad(t *testing.T) {
+	t.Parallel()
+
+	pacer := newTestPacer(8, 1.5)
+	pacer.limit = 4
+
+	pacer.started()
+	pacer.finished(10 * time.Millisecond)
+
+	assert.Equal(t, 2, pacer.limit)
+	assert.Contains(t, pacer.String(), "load/cpu 1.50")
+}
+
+func TestStatusChecksRunUpToThePacerLimit(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+	model.pacer = newTestPacer(4, 0.1)
+	model.appLookup = make(map[string]*app)
+
+	for i := range model.categories {
+		for j := range model.categories[i].apps {
+			model.categories[i].apps[j].StatusPending = true
+			model.appLookup[model.categories[i].apps[j].Key] = &model.categories[i].apps[j]
+		}
+	}
+
+	model.checkCategoryApps(0, 0)
+	assert.Len(t, model.checking, initialStatusChecks)
+	assert.True(t, model.checking["git"])
+
+	model.handleStatusUpdate(StatusUpdateMsg{AppName: "git", Elapsed: 10 * time.Millisecond})
+	assert.False(t, model.checking["git"])
+	assert.Len(t, model.checking, initialStatusChecks, "the finished check is replaced")
+	assert.Equal(t, 1, model.pacer.checked)
+
+	// Updates from events aren't checks and don't count
+	model.handleStatusUpdate(StatusUpdateMsg{AppName: "firefox", Installed: true})
+	assert.Equal(t, 1, model.pacer.checked)
+}
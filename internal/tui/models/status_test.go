@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusRendersDashboard(t *testing.T) {
+	t.Parallel()
+
+	model := NewStatus(context.Background(), styles.New())
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	assert.Contains(t, model.View(), "Gathering status")
+
+	started := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	run := &application.JournalRun{Started: started, Finished: started.Add(90 * time.Second)}
+	run.Add("vlc", application.OperationInstall, nil)
+	run.Add("zed", application.OperationInstall, errors.New("download failed"))
+
+	model.Update(DashboardLoadedMsg{Dashboard: &application.Dashboard{
+		Installed: map[domain.InstallMethod]int{domain.MethodAPT: 2, domain.MethodFlatpak: 1},
+		Updates:   map[string]domain.OutdatedPackage{"vlc": {Method: domain.MethodAPT, Name: "vlc", Available: "3.0.21"}},
+		DiskUsage: 1_500_000_000,
+		Theme:     "nord",
+		Font:      "JetBrainsMono",
+		LastRun:   run,
+	}})
+
+	view := model.View()
+	assert.Contains(t, view, "3 apps")
+	assert.Contains(t, view, "flatpak")
+	assert.Contains(t, view, "vlc ↑ 3.0.21")
+	assert.Contains(t, view, "1.5 GB")
+	assert.Contains(t, view, "nord")
+	assert.Contains(t, view, "JetBrainsMono")
+	assert.Contains(t, view, "2 tasks, 1 failed")
+	assert.NotContains(t, view, "Refreshing")
+}
+
+func TestStatusDrillsDown(t *testing.T) {
+	t.Parallel()
+
+	model := NewStatus(context.Background(), styles.New())
+
+	open := func() tea.Msg {
+		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		require.NotNil(t, cmd)
+
+		return cmd()
+	}
+
+	assert.Equal(t, NavigateMsg{Screen: AppsScreen}, open())
+
+	model.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.Equal(t, NavigateMsg{Screen: HistoryScreen}, open())
+
+	model.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.Equal(t, NavigateMsg{Screen: ThemeScreen}, open())
+}
@@ -5,6 +5,8 @@ package models
 
 import (
 	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/config"
 	"github.com/janderssonse/karei/internal/tui/styles"
 )
 
@@ -52,7 +54,14 @@ func NewTestAppsModel(styleConfig *styles.Styles, width, height int) *AppsModel
 		installStatusFilter: FilterAll,
 		packageTypeFilter:   FilterAll,
 		sortOption:          "Name",
+		savedSearchIndex:    -1,
+		statusChecker:       platform.NewMockInstallationChecker("vscode", "hadolint", "docker", "python", "firefox"),
+		versionResolver:     platform.NewMockVersionResolver(nil),
+		pacer:               newStatusPacer(),
+		checking:            make(map[string]bool),
+		columns:             newColumnLayout(config.ColumnPreferences{}),
 	}
+	model.results = model.newSearchResults()
 
 	return model
 }
@@ -6,6 +6,7 @@ package models
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -45,14 +46,13 @@ type Themes struct {
 	width         int
 	height        int
 	themes        []Theme
-	cursor        int
+	list          *SelectableList[Theme]
 	selectedTheme int
 	showPreview   bool
 	quitting      bool
 	keyMap        ThemesKeyMap
 
-	// Two viewports for split view (idiomatic approach)
-	listViewport    viewport.Model
+	// The list owns its viewport; the preview scrolls separately
 	previewViewport viewport.Model
 	ready           bool
 
@@ -368,10 +368,9 @@ func NewThemes(styleConfig *styles.Styles) *Themes {
 	helpModal := NewHelpModal()
 	helpModal.SetScreen("themes")
 
-	return &Themes{
+	model := &Themes{
 		styles:        styleConfig,
 		themes:        themes,
-		cursor:        0,
 		selectedTheme: 0,
 		showPreview:   true,
 		keyMap:        DefaultThemesKeyMap(),
@@ -379,6 +378,13 @@ func NewThemes(styleConfig *styles.Styles) *Themes {
 		ready:     false,
 		helpModal: helpModal,
 	}
+
+	model.list = NewSelectableList(themes,
+		func(theme Theme) string { return theme.Name },
+		func(theme Theme) string { return theme.DisplayName },
+		model.renderThemeItem)
+
+	return model
 }
 
 // Init initializes the themes model.
@@ -403,7 +409,7 @@ func (m *Themes) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Update viewports BEFORE handling keys - this ensures they process all messages
 	// Always update list viewport (it's always visible)
-	m.listViewport, cmd = m.listViewport.Update(msg)
+	cmd = m.list.Update(msg)
 	if cmd != nil {
 		cmds = append(cmds, cmd)
 	}
@@ -483,7 +489,7 @@ func (m *Themes) renderBaseView() string {
 
 	if m.showPreview && viewportHeight >= 15 {
 		// Get viewport content
-		leftContent := m.listViewport.View()
+		leftContent := m.list.View()
 		rightContent := m.previewViewport.View()
 
 		// Use lipgloss styles with explicit dimensions to force clean rendering
@@ -510,7 +516,7 @@ func (m *Themes) renderBaseView() string {
 		mainContent = lipgloss.JoinHorizontal(lipgloss.Top, leftStyled, rightStyled)
 	} else {
 		// Single column view
-		mainContent = m.listViewport.View()
+		mainContent = m.list.View()
 	}
 
 	components = append(components, mainContent)
@@ -532,9 +538,19 @@ func (m *Themes) renderCleanHeader() string {
 		Foreground(m.styles.Primary).
 		Render(location)
 
-	// Simple header without dynamic content
 	headerLine := leftSide
 
+	// Show the search query while it narrows the list
+	if m.list.SearchActive() {
+		cursor := ""
+		if m.list.Searching() {
+			cursor = "█"
+		}
+
+		search := lipgloss.NewStyle().Foreground(m.styles.Muted).Render("  /" + m.list.Query() + cursor)
+		headerLine = leftSide + search
+	}
+
 	// Style the header with subtle border
 	return lipgloss.NewStyle().
 		Padding(0, 2).
@@ -549,11 +565,19 @@ func (m *Themes) renderCleanHeader() string {
 func (m *Themes) renderCleanFooter() string {
 	actions := []FooterAction{
 		{Key: "j/k", Action: "Navigate"},
+		{Key: "/", Action: "Search"},
 		{Key: "Enter", Action: "Apply"},
 		{Key: "?", Action: "Help"},
 		{Key: "Esc", Action: "Back"},
 	}
 
+	if m.list.Searching() {
+		actions = []FooterAction{
+			{Key: "Enter", Action: "Keep results"},
+			{Key: "Esc", Action: "Clear search"},
+		}
+	}
+
 	return RenderFooter(m.styles, m.width, actions, false)
 }
 
@@ -566,6 +590,14 @@ func (m *Themes) GetSelectedTheme() Theme {
 	return Theme{}
 }
 
+// currentTheme returns the theme under the cursor, or an empty theme when the
+// search matches nothing.
+func (m *Themes) currentTheme() Theme {
+	theme, _ := m.list.Selected()
+
+	return theme
+}
+
 // updateViewportContent updates the viewport content based on current state.
 // This should be called when content needs to be refreshed (idiomatic pattern).
 func (m *Themes) updateViewportContent() {
@@ -573,9 +605,13 @@ func (m *Themes) updateViewportContent() {
 		return
 	}
 
-	// Update list viewport with themes
-	themesContent := m.renderAllThemes()
-	m.listViewport.SetContent(themesContent)
+	// Re-render the list, reporting when the search matches nothing
+	header := m.styles.Title.Render("Available Themes") + "\n"
+	if m.list.Len() == 0 {
+		header += "\n" + m.styles.MutedText.Render("  No themes match \""+m.list.Query()+"\"")
+	}
+
+	m.list.SetHeader(header)
 
 	// Update preview viewport if showing preview
 	if m.showPreview && m.height >= 15 {
@@ -611,12 +647,11 @@ func (m *Themes) getThemeStyles(theme Theme) struct {
 
 // renderPreviewContent renders the preview content for the preview viewport.
 func (m *Themes) renderPreviewContent() string {
-	if m.cursor >= len(m.themes) {
+	theme := m.currentTheme()
+	if theme.Name == "" {
 		return ""
 	}
 
-	theme := m.themes[m.cursor]
-
 	// Build preview sections without worrying about width constraints
 	sections := []string{
 		m.styles.Title.Render("Theme Preview - " + theme.DisplayName),
@@ -813,7 +848,15 @@ func (m *Themes) navigateToMenuCmd() tea.Cmd {
 
 //nolint:cyclop // Complex but necessary for handling various UI interactions
 func (m *Themes) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Handle help modal toggle first
+	// The search input takes every key while it has focus
+	if m.list.Searching() {
+		m.list.HandleKey(msg)
+		m.updateViewportContent()
+
+		return m, nil
+	}
+
+	// Handle help modal toggle
 	if key.Matches(msg, m.keyMap.Help) {
 		if m.helpModal != nil {
 			m.helpModal.Toggle()
@@ -831,6 +874,13 @@ func (m *Themes) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Movement and search keys shared with the apps screen
+	if m.list.HandleKey(msg) {
+		m.updateViewportContent()
+
+		return m, nil
+	}
+
 	switch {
 	case key.Matches(msg, m.keyMap.Quit):
 		m.quitting = true
@@ -838,10 +888,6 @@ func (m *Themes) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	case key.Matches(msg, m.keyMap.Back):
 		return m, m.navigateToMenuCmd()
-	case key.Matches(msg, m.keyMap.Up):
-		return m.handleCursorMovement(-1)
-	case key.Matches(msg, m.keyMap.Down):
-		return m.handleCursorMovement(1)
 	case key.Matches(msg, m.keyMap.Select):
 		return m.handleThemeSelection()
 	case key.Matches(msg, m.keyMap.Apply):
@@ -852,29 +898,15 @@ func (m *Themes) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleCursorMovement moves the cursor up or down.
-//
-
-func (m *Themes) handleCursorMovement(direction int) (tea.Model, tea.Cmd) {
-	newCursor := m.cursor + direction
-	if newCursor >= 0 && newCursor < len(m.themes) {
-		m.cursor = newCursor
-		// Update preview content when cursor changes (if preview is showing)
-		if m.showPreview {
-			m.updateViewportContent()
-		}
-		// Use Bubble Tea viewport's natural scrolling - no arithmetic
-		m.ensureSelectionVisible()
-	}
-
-	return m, nil
-}
-
 // handleThemeSelection selects the current theme.
 //
 
 func (m *Themes) handleThemeSelection() (tea.Model, tea.Cmd) {
-	m.selectedTheme = m.cursor
+	if theme, ok := m.list.Selected(); ok {
+		m.selectedTheme = slices.IndexFunc(m.themes, func(candidate Theme) bool {
+			return candidate.Name == theme.Name
+		})
+	}
 
 	return m, nil
 }
@@ -902,8 +934,8 @@ func (m *Themes) handleWindowSizeMsg(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd)
 
 	if !m.ready {
 		// Initialize both viewports
-		// List viewport takes fixed width
-		m.listViewport = viewport.New(35, contentHeight)
+		// List takes fixed width
+		m.list.SetSize(35, contentHeight)
 		// Preview viewport takes remaining width (accounting for space separator)
 		m.previewViewport = viewport.New(msg.Width-36, contentHeight)
 		m.ready = true
@@ -911,8 +943,7 @@ func (m *Themes) handleWindowSizeMsg(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd)
 		m.updateViewportContent()
 	} else {
 		// Update viewport sizes
-		m.listViewport.Width = 35
-		m.listViewport.Height = contentHeight
+		m.list.SetSize(35, contentHeight)
 		m.previewViewport.Width = msg.Width - 36
 		m.previewViewport.Height = contentHeight
 		// Refresh content for new size
@@ -922,111 +953,34 @@ func (m *Themes) handleWindowSizeMsg(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd)
 	return m, nil
 }
 
-// renderAllThemes renders all themes for viewport (following apps screen pattern).
-func (m *Themes) renderAllThemes() string {
-	var builder strings.Builder
-
-	builder.WriteString(m.styles.Title.Render("Available Themes"))
-	builder.WriteString("\n\n")
-
-	// Render ALL themes - viewport handles what's visible
-	for themeIndex, theme := range m.themes {
-		var (
-			style  lipgloss.Style
-			prefix string
-		)
-
-		if themeIndex == m.cursor {
-			style = m.styles.Selected
-			prefix = "❯ "
-		} else {
-			style = m.styles.Unselected
-			prefix = "  "
-		}
+// renderThemeItem renders one theme in the list, with the description below
+// the focused one.
+func (m *Themes) renderThemeItem(theme Theme, focused bool, _ SelectionState, highlight []int) string {
+	style := m.styles.Unselected
+	prefix := "  "
 
-		// Current theme indicator
-		currentIndicator := ""
-		if theme.Current {
-			currentIndicator = " (current)"
-		}
-
-		line := fmt.Sprintf("%s%s%s", prefix, theme.DisplayName, currentIndicator)
-		builder.WriteString(style.Render(line))
-		builder.WriteString("\n")
-
-		// Show description for selected theme
-		if themeIndex == m.cursor {
-			descStyle := lipgloss.NewStyle().Foreground(m.styles.Muted)
-			desc := descStyle.Render("    " + theme.Description)
-			builder.WriteString(desc)
-			builder.WriteString("\n")
-		}
+	if focused {
+		style = m.styles.Selected
+		prefix = "❯ "
 	}
 
-	return builder.String()
-}
-
-// ensureSelectionVisible calculates exact line position of selection in rendered content.
-// Follows the same pattern as apps screen for proper viewport scrolling.
-func (m *Themes) ensureSelectionVisible() {
-	if !m.ready || len(m.themes) == 0 {
-		return
-	}
-
-	// Calculate EXACT line position of current selection in rendered content
-	selectionLine := m.calculateThemeSelectionLine()
-
-	// Get current list viewport window
-	viewportTop := m.listViewport.YOffset
-	viewportBottom := viewportTop + m.listViewport.Height - 1
-
-	// Buffer zones - scroll when selection gets close to edges
-	topBuffer := 3    // Keep 3 lines above selection visible
-	bottomBuffer := 2 // Keep 2 lines below selection visible
-
-	// Check if selection is outside comfortable viewing area
-	if selectionLine <= viewportTop+topBuffer {
-		// Selection too close to top - scroll up to maintain buffer
-		newOffset := selectionLine - topBuffer
-		if newOffset < 0 {
-			newOffset = 0
-		}
-
-		m.listViewport.SetYOffset(newOffset)
-	} else if selectionLine >= viewportBottom-bottomBuffer {
-		// Selection too close to bottom - scroll down to maintain buffer
-		newOffset := selectionLine - m.listViewport.Height + bottomBuffer + 1
-		totalLines := m.listViewport.TotalLineCount()
-
-		maxOffset := totalLines - m.listViewport.Height
-		if maxOffset < 0 {
-			maxOffset = 0
-		}
-
-		if newOffset > maxOffset {
-			newOffset = maxOffset
-		}
-
-		m.listViewport.SetYOffset(newOffset)
+	// Current theme indicator
+	currentIndicator := ""
+	if theme.Current {
+		currentIndicator = " (current)"
 	}
-	// Selection is comfortably visible - no scroll needed
-}
 
-// calculateThemeSelectionLine calculates exact line position of theme selection.
-func (m *Themes) calculateThemeSelectionLine() int {
-	// Start with title and spacing
-	line := 2 // "Available Themes" + blank line
+	matchStyle := style.Bold(true).Underline(true)
+	line := style.Render(prefix) + highlightMatches(theme.DisplayName, highlight, style, matchStyle, 0) +
+		style.Render(currentIndicator)
 
-	// Count lines before current selection
-	for range m.cursor {
-		line++ // Theme name line
-		// Note: we don't count description lines for non-selected themes
+	if !focused {
+		return line
 	}
 
-	// Add the current selection line
-	line++ // Current theme name line
+	descStyle := lipgloss.NewStyle().Foreground(m.styles.Muted)
 
-	return line
+	return line + "\n" + descStyle.Render("    "+theme.Description)
 }
 
 // GetNavigationHints returns screen-specific navigation hints for the footer.
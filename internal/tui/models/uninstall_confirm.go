@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janderssonse/karei/internal/apps"
+)
+
+// changePrivileges is implemented by status checkers that can tell whether
+// changing an installed app needs sudo, such as the package manager.
+type changePrivileges interface {
+	ChangeRequiresRoot(ctx context.Context, appKey string) bool
+}
+
+// startOperations moves on to the progress screen, asking for the sudo
+// password first when any operation needs root. When privileges is set,
+// removals, updates and reinstalls go by the installation holding the app
+// rather than by how it would be installed now.
+func startOperations(ctx context.Context, privileges changePrivileges, operations []SelectedOperation) tea.Cmd {
+	return func() tea.Msg {
+		if privileges != nil {
+			for i, op := range operations {
+				switch op.Operation {
+				case StateUninstall, StateUpdate:
+					operations[i].RequiresRoot = privileges.ChangeRequiresRoot(ctx, op.AppKey)
+				case StateReinstall:
+					operations[i].RequiresRoot = op.RequiresRoot || privileges.ChangeRequiresRoot(ctx, op.AppKey)
+				case StateNone, StateInstall:
+				}
+			}
+		}
+
+		// User-scope selections need no sudo, so skip the password screen
+		if !slices.ContainsFunc(operations, func(op SelectedOperation) bool { return op.RequiresRoot }) {
+			return NavigateMsg{Screen: ProgressScreen, Data: operations}
+		}
+
+		// First go to password screen, then to progress
+		return NavigateMsg{Screen: PasswordScreen, Data: operations}
+	}
+}
+
+// changePrivileges returns the status checker's knowledge of installed
+// apps' privileges, or nil when it has none.
+func (m *AppsModel) changePrivileges() changePrivileges {
+	privileges, _ := m.statusChecker.(changePrivileges)
+
+	return privileges
+}
+
+// handleUninstallConfirmKeys answers the uninstall confirmation. Every key is
+// consumed until it is answered.
+func (m *AppsModel) handleUninstallConfirmKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y", KeyEnter:
+		m.confirmingUninstall = false
+
+		return startOperations(m.ctx, m.changePrivileges(), m.getSelectedOperations())
+	case "n", KeyEsc:
+		m.confirmingUninstall = false
+		m.searchNotice = "Uninstall cancelled"
+	}
+
+	return nil
+}
+
+// uninstallTargets returns the operations that remove apps.
+func uninstallTargets(operations []SelectedOperation) []SelectedOperation {
+	var targets []SelectedOperation
+
+	for _, op := range operations {
+		if op.Operation == StateUninstall {
+			targets = append(targets, op)
+		}
+	}
+
+	return targets
+}
+
+// uninstallWarnings names installed catalog apps that depend on the targets
+// and are not removed along with them.
+func (m *AppsModel) uninstallWarnings(targets []SelectedOperation) []string {
+	var warnings []string
+
+	for _, target := range targets {
+		var needed []string
+
+		for _, dependent := range apps.Dependents(target.AppKey) {
+			found, ok := m.appLookup[dependent]
+			if !ok || !found.Installed || m.selected[dependent] == StateUninstall {
+				continue
+			}
+
+			needed = append(needed, found.Name)
+		}
+
+		if len(needed) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s is needed by %s", target.AppName, strings.Join(needed, ", ")))
+		}
+	}
+
+	return warnings
+}
+
+// renderUninstallConfirm renders the confirmation listing the apps to remove.
+func (m *AppsModel) renderUninstallConfirm() string {
+	operations := m.getSelectedOperations()
+	targets := uninstallTargets(operations)
+
+	danger := lipgloss.NewStyle().Foreground(m.styles.Error)
+	lines := []string{
+		danger.Bold(true).Render(fmt.Sprintf("Uninstall %d apps?", len(targets))),
+		"",
+	}
+
+	for _, target := range targets {
+		lines = append(lines, danger.Render("  - "+target.AppName))
+	}
+
+	if warnings := m.uninstallWarnings(targets); len(warnings) > 0 {
+		lines = append(lines, "")
+
+		for _, warning := range warnings {
+			lines = append(lines, m.styles.WarningText.Render("⚠ "+warning))
+		}
+	}
+
+	if installs := len(operations) - len(targets); installs > 0 {
+		lines = append(lines, "", m.styles.MutedText.Render(fmt.Sprintf("%d apps will also be installed.", installs)))
+	}
+
+	lines = append(lines, "", m.styles.MutedText.Render("y Uninstall   n Cancel"))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Error).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box,
+		lipgloss.WithWhitespaceBackground(lipgloss.Color("235")))
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package models
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUninstallNeedsConfirmation(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+
+	typeKeys(model, "jd")
+
+	_, cmd := model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	require.True(t, model.confirmingUninstall)
+
+	view := model.renderUninstallConfirm()
+	assert.Contains(t, view, "Uninstall 1 apps?")
+	assert.Contains(t, view, "- VS Code")
+
+	typeKeys(model, "j")
+	assert.Equal(t, 1, model.categories[0].currentApp, "keys don't reach the list underneath")
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, model.confirmingUninstall)
+	assert.Equal(t, StateUninstall, model.selected["vscode"], "cancelling keeps the selection")
+
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEnter})
+
+	_, cmd = model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(NavigateMsg)
+	require.True(t, ok)
+	assert.Equal(t, ProgressScreen, msg.Screen)
+}
+
+func TestInstallOnlySkipsConfirmation(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+
+	typeKeys(model, " ")
+
+	_, cmd := model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.False(t, model.confirmingUninstall)
+}
+
+func TestUninstallWarnsAboutDependents(t *testing.T) {
+	t.Parallel()
+
+	model := NewTestAppsModel(styles.New(), 120, 40)
+	model.appLookup = map[string]*app{
+		"cargo-audit": {Key: "cargo-audit", Name: "cargo-audit", Installed: true},
+		"cargo-watch": {Key: "cargo-watch", Name: "cargo-watch", Installed: false},
+		"cargo-deny":  {Key: "cargo-deny", Name: "cargo-deny", Installed: true},
+	}
+	model.selected["cargo-deny"] = StateUninstall
+
+	warnings := model.uninstallWarnings([]SelectedOperation{{AppKey: "rust", AppName: "rust", Operation: StateUninstall}})
+	assert.Equal(t, []string{"rust is needed by cargo-audit"}, warnings,
+		"only installed dependents that stay are reported")
+}
+
+// systemWideChecker is a status checker whose installed apps all sit in
+// system-wide installations.
+type systemWideChecker struct {
+	domain.InstallationChecker
+}
+
+func (systemWideChecker) ChangeRequiresRoot(context.Context, string) bool { return true }
+
+func TestUninstallAsksForPasswordByInstalledScope(t *testing.T) {
+	t.Parallel()
+
+	// VS Code would be installed for the user, but was installed system-wide
+	model := NewTestAppsModel(styles.New(), 120, 40)
+	model.statusChecker = systemWideChecker{InstallationChecker: model.statusChecker}
+
+	typeKeys(model, "jd")
+	model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEnter})
+
+	_, cmd := model.handleKeyMessage(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(NavigateMsg)
+	require.True(t, ok)
+	assert.Equal(t, PasswordScreen, msg.Screen)
+
+	operations, ok := msg.Data.([]SelectedOperation)
+	require.True(t, ok)
+	assert.True(t, operations[0].RequiresRoot)
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package tui
+
+import (
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/application"
+	"github.com/janderssonse/karei/internal/config"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/tui/styles"
+	"github.com/janderssonse/karei/internal/xdg"
+)
+
+// configuredStyles returns styles colored like the applied karei theme, or
+// the theme and colors chosen in the [tui] preferences.
+func configuredStyles() *styles.Styles {
+	prefs, err := config.LoadPreferences(xdg.PreferencesFile())
+	if err != nil {
+		prefs = &config.Preferences{}
+	}
+
+	themes := application.NewThemeService(platform.NewFileManager(false), nil, "", "")
+	themes.SetCurrentThemeFile(xdg.ThemeFile())
+
+	return styles.NewWithPalette(resolvePalette(themes, prefs.TUI))
+}
+
+// resolvePalette picks the palette of the configured theme, falling back to
+// the applied one, and lays the configured colors over it. Unknown themes
+// and invalid colors are ignored rather than keeping the TUI from starting.
+func resolvePalette(themes *application.ThemeService, prefs config.TUIPreferences) domain.ThemePalette {
+	name := prefs.Theme
+	if name == "" {
+		name = themes.CurrentTheme()
+	}
+
+	palette, _ := themes.Palette(name)
+
+	if colors, err := domain.ParseThemePalette(prefs.Colors); err == nil {
+		palette = palette.Override(colors)
+	}
+
+	return palette
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janderssonse/karei/internal/adapters/network"
+	"github.com/janderssonse/karei/internal/adapters/platform"
+	"github.com/janderssonse/karei/internal/adapters/system"
+	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/stringutil"
+)
+
+// Status bar refresh timing.
+const (
+	statusRefreshInterval = 30 * time.Second
+	statusProbeTimeout    = 3 * time.Second
+	statusBarHeight       = 1
+)
+
+// systemStatus is the environment shown in the status bar.
+type systemStatus struct {
+	checked        bool // False until the first probe finishes
+	distribution   string
+	packageManager string
+	freeDisk       uint64
+	network        domain.Connectivity
+}
+
+// systemStatusMsg delivers a finished probe.
+type systemStatusMsg struct {
+	status systemStatus
+}
+
+// statusTickMsg schedules the next probe.
+type statusTickMsg struct{}
+
+// statusContext returns the context status probes run under.
+func (a *App) statusContext() context.Context {
+	if a.ctx == nil {
+		return context.Background()
+	}
+
+	return a.ctx
+}
+
+// probeSystemStatus detects the distribution, package manager, free disk
+// space and network state in the background.
+func probeSystemStatus(parent context.Context) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(parent, statusProbeTimeout)
+		defer cancel()
+
+		detector := platform.NewSystemDetector(platform.NewTUICommandRunner(false, false), platform.NewFileManager(false))
+		status := systemStatus{checked: true}
+
+		if distribution, err := detector.DetectDistribution(ctx); err == nil {
+			status.distribution = strings.TrimSpace(distribution.Name + " " + distribution.Version)
+		}
+
+		if manager, err := detector.DetectPackageManager(ctx); err == nil {
+			status.packageManager = manager.Command
+		}
+
+		home, _ := os.UserHomeDir()
+		status.freeDisk = system.FreeDiskSpace(home)
+
+		status.network = network.CheckConnectivity(ctx, network.GetHTTPClient(), network.DefaultConnectivityCheckURL)
+
+		return systemStatusMsg{status: status}
+	}
+}
+
+// scheduleStatusRefresh probes again after the refresh interval.
+func scheduleStatusRefresh() tea.Cmd {
+	return tea.Tick(statusRefreshInterval, func(time.Time) tea.Msg {
+		return statusTickMsg{}
+	})
+}
+
+// renderStatusBar renders the one-line system context shown below every screen.
+func (a *App) renderStatusBar() string {
+	muted := lipgloss.NewStyle().Foreground(a.styles.Muted)
+
+	if !a.status.checked {
+		return muted.Padding(0, 1).Width(a.width).Render("Detecting system…")
+	}
+
+	var parts []string
+
+	if a.demo != nil {
+		parts = append(parts, lipgloss.NewStyle().Foreground(a.styles.Warning).Render("demo"))
+	}
+
+	for _, part := range []string{a.status.distribution, a.status.packageManager} {
+		if part != "" {
+			parts = append(parts, muted.Render(part))
+		}
+	}
+
+	if a.status.freeDisk > 0 {
+		parts = append(parts, muted.Render(stringutil.FormatBytes(int64(a.status.freeDisk))+" free")) //nolint:gosec // Disk sizes fit in int64
+	}
+
+	if len(a.updates) > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(a.styles.Warning).Render(fmt.Sprintf("↑ %d updates", len(a.updates))))
+	}
+
+	connection := lipgloss.NewStyle().Foreground(a.styles.Success).Render("● online")
+	if !a.status.network.Available() {
+		connection = lipgloss.NewStyle().Foreground(a.styles.Error).Render("● " + a.status.network.SkipReason())
+	}
+
+	parts = append(parts, connection)
+
+	return lipgloss.NewStyle().Padding(0, 1).Width(a.width).Render(strings.Join(parts, muted.Render(" · ")))
+}
@@ -6,6 +6,8 @@ package styles
 
 import (
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/janderssonse/karei/internal/domain"
 )
 
 // Styles contains all the styles used in the TUI.
@@ -43,19 +45,41 @@ type Styles struct {
 	Sidebar   lipgloss.Style
 }
 
+// DefaultPalette is the Tokyo Night palette used when no theme is applied.
+func DefaultPalette() domain.ThemePalette {
+	return domain.ThemePalette{
+		Primary:    "#7aa2f7", // Blue
+		Secondary:  "#bb9af7", // Purple
+		Success:    "#9ece6a", // Green
+		Warning:    "#e0af68", // Yellow
+		Error:      "#f7768e", // Red
+		Info:       "#7dcfff", // Cyan
+		Muted:      "#565f89", // Gray
+		Background: "#1a1b26", // Dark background
+		Foreground: "#c0caf5", // Light foreground
+	}
+}
+
 // New creates a new Styles instance with default Tokyo Night theme.
 func New() *Styles {
-	// Tokyo Night color palette
-	primary := lipgloss.Color("#7aa2f7")    // Blue
-	secondary := lipgloss.Color("#bb9af7")  // Purple
-	success := lipgloss.Color("#9ece6a")    // Green
-	warning := lipgloss.Color("#e0af68")    // Yellow
-	errorColor := lipgloss.Color("#f7768e") // Red
-	info := lipgloss.Color("#7dcfff")       // Cyan
-	muted := lipgloss.Color("#565f89")      // Gray
-
-	background := lipgloss.Color("#1a1b26") // Dark background
-	foreground := lipgloss.Color("#c0caf5") // Light foreground
+	return NewWithPalette(DefaultPalette())
+}
+
+// NewWithPalette creates a Styles instance colored by palette. Colors the
+// palette leaves empty come from DefaultPalette.
+func NewWithPalette(palette domain.ThemePalette) *Styles {
+	palette = DefaultPalette().Override(palette)
+
+	primary := lipgloss.Color(palette.Primary)
+	secondary := lipgloss.Color(palette.Secondary)
+	success := lipgloss.Color(palette.Success)
+	warning := lipgloss.Color(palette.Warning)
+	errorColor := lipgloss.Color(palette.Error)
+	info := lipgloss.Color(palette.Info)
+	muted := lipgloss.Color(palette.Muted)
+
+	background := lipgloss.Color(palette.Background)
+	foreground := lipgloss.Color(palette.Foreground)
 
 	return &Styles{
 		Primary:   primary,
@@ -2,4 +2,7 @@
 // SPDX-License-Identifier: EUPL-1.2
 
 // Package uninstall removes installed applications and their configurations.
+//
+// Deprecated: the CLI and TUI remove applications through
+// application.UninstallService; this package remains for legacy callers.
 package uninstall
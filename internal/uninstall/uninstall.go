@@ -14,6 +14,7 @@ import (
 	"github.com/janderssonse/karei/internal/adapters/system"
 	"github.com/janderssonse/karei/internal/apps"
 	"github.com/janderssonse/karei/internal/domain"
+	"github.com/janderssonse/karei/internal/xdg"
 )
 
 var (
@@ -79,6 +80,8 @@ func (u *Uninstaller) UninstallApp(ctx context.Context, name string) error {
 		return u.uninstallDEB(ctx, name)
 	case domain.MethodMise:
 		return u.uninstallMise(ctx, name)
+	case domain.MethodNpm:
+		return u.uninstallNpm(ctx, app.Source)
 	case domain.MethodGitHub, domain.MethodGitHubBinary, domain.MethodGitHubBundle, domain.MethodGitHubJava:
 		return u.uninstallGitHub(ctx, name)
 	case domain.MethodScript, domain.MethodBinary, domain.MethodAqua:
@@ -197,6 +200,18 @@ func (u *Uninstaller) uninstallMise(ctx context.Context, packageName string) err
 	return u.runCommand(ctx, "mise", "uninstall", actualPackageName)
 }
 
+// uninstallNpm removes a tool from karei's npm prefix, or disables a
+// package manager corepack provides when npm didn't install it.
+func (u *Uninstaller) uninstallNpm(ctx context.Context, source string) error {
+	prefix := xdg.NpmPrefix()
+
+	if _, err := os.Stat(domain.NpmPackageDir(prefix, source)); err != nil && domain.IsCorepackManager(source) {
+		return u.runCommand(ctx, "corepack", "disable", "--install-directory", domain.NpmBinDir(prefix), source)
+	}
+
+	return u.runCommand(ctx, "npm", "uninstall", "--global", "--prefix", prefix, source)
+}
+
 // detectMisePackageName finds the actual package name mise is tracking.
 //
 //nolint:cyclop // Complexity from multiple package name matching strategies
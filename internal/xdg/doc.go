@@ -0,0 +1,8 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+// Package xdg resolves every file location karei uses, following the XDG
+// Base Directory Specification, and moves files left in older locations.
+// KAREI_* environment variables override the locations and some behavior;
+// they are all read here so `karei paths --env` can document them.
+package xdg
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package xdg
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/janderssonse/karei/internal/stringutil"
+)
+
+// Environment variables overriding karei's locations and behavior. They take
+// precedence over the XDG base directories, so tests and packaged builds of
+// karei (snap, flatpak) can keep everything inside their sandbox.
+const (
+	EnvConfigDir   = "KAREI_CONFIG_DIR"
+	EnvDataDir     = "KAREI_DATA_DIR"
+	EnvStateDir    = "KAREI_STATE_DIR"
+	EnvCacheDir    = "KAREI_CACHE_DIR"
+	EnvRuntimeDir  = "KAREI_RUNTIME_DIR"
+	EnvKareiPath   = "KAREI_PATH"
+	EnvCatalogPath = "KAREI_CATALOG_PATH"
+	EnvNoNetwork   = "KAREI_NO_NETWORK"
+
+	EnvBandwidthLimit = "KAREI_BANDWIDTH_LIMIT"
+)
+
+// Variable is an environment variable karei reads, for `karei paths --env`.
+type Variable struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+// Variables lists every override with its current value.
+func Variables() []Variable {
+	variables := []Variable{
+		{Name: EnvConfigDir, Description: "configuration directory, instead of $XDG_CONFIG_HOME/karei"},
+		{Name: EnvDataDir, Description: "data directory, instead of $XDG_DATA_HOME/karei"},
+		{Name: EnvStateDir, Description: "state directory, instead of $XDG_STATE_HOME/karei"},
+		{Name: EnvCacheDir, Description: "cache directory, instead of $XDG_CACHE_HOME/karei"},
+		{Name: EnvRuntimeDir, Description: "runtime directory, instead of $XDG_RUNTIME_DIR/karei"},
+		{Name: EnvKareiPath, Description: "karei installation with themes and fonts, instead of the data directory"},
+		{Name: EnvCatalogPath, Description: "TOML file, or the URL of a signed one, with apps added to the built-in catalog"},
+		{Name: EnvNoNetwork, Description: "set to 1 to refuse karei's own HTTP requests"},
+		{Name: EnvBandwidthLimit, Description: "bytes per second downloads may use, such as 2MB, instead of bandwidth_limit in config.toml"},
+	}
+
+	for i := range variables {
+		variables[i].Value = os.Getenv(variables[i].Name)
+	}
+
+	return variables
+}
+
+// CatalogFile returns the extra catalog to load, or "" when there is none.
+func CatalogFile() string {
+	return os.Getenv(EnvCatalogPath)
+}
+
+// NoNetwork reports whether karei must not make HTTP requests itself.
+// Package managers it runs are not affected.
+func NoNetwork() bool {
+	noNetwork, _ := strconv.ParseBool(os.Getenv(EnvNoNetwork))
+
+	return noNetwork
+}
+
+// BandwidthLimit returns the bytes per second karei's downloads may use, or
+// 0 for no limit, also when the value is invalid.
+func BandwidthLimit() int64 {
+	limit, err := stringutil.ParseBytes(os.Getenv(EnvBandwidthLimit))
+	if err != nil {
+		return 0
+	}
+
+	return limit
+}
+
+// overridden returns the value of env, or fallback when it is unset.
+func overridden(env string, fallback func() string) string {
+	if value := os.Getenv(env); value != "" {
+		return value
+	}
+
+	return fallback()
+}
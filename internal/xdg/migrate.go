@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package xdg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const dirPerm = 0o750
+
+// Move is a file moved from a legacy location.
+type Move struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// legacyMoves lists files older versions kept in the data directory that
+// belong in the state directory.
+func legacyMoves() []Move {
+	moves := []Move{
+		{From: filepath.Join(DataDir(), "journal.json"), To: JournalFile()},
+		{From: filepath.Join(DataDir(), "usage.json"), To: UsageStatsFile()},
+	}
+
+	for _, log := range []string{"install.log", "progress.log", "precheck.log", "errors.log"} {
+		moves = append(moves, Move{From: filepath.Join(DataDir(), log), To: filepath.Join(LogDir(), log)})
+	}
+
+	return moves
+}
+
+// Migrate moves files from legacy locations, leaving files that already
+// exist at the new location alone. It returns the moves made and stops at
+// the first failure.
+func Migrate() ([]Move, error) {
+	var moved []Move
+
+	for _, move := range legacyMoves() {
+		if !exists(move.From) || exists(move.To) {
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(move.To), dirPerm); err != nil {
+			return moved, fmt.Errorf("failed to create %s: %w", filepath.Dir(move.To), err)
+		}
+
+		if err := os.Rename(move.From, move.To); err != nil {
+			return moved, fmt.Errorf("failed to move %s: %w", move.From, err)
+		}
+
+		moved = append(moved, move)
+	}
+
+	return moved, nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+
+	return !errors.Is(err, os.ErrNotExist)
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// appName is the directory karei uses below each base directory.
+const appName = "karei"
+
+// BaseDir returns value, or the fallback path below the home directory when
+// value is empty, as the specification requires for unset variables.
+func BaseDir(value string, fallback ...string) string {
+	if value != "" {
+		return value
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(append([]string{home}, fallback...)...)
+}
+
+// ConfigHome returns $XDG_CONFIG_HOME, user settings.
+func ConfigHome() string {
+	return BaseDir(os.Getenv("XDG_CONFIG_HOME"), ".config")
+}
+
+// DataHome returns $XDG_DATA_HOME, files users would keep when migrating machines.
+func DataHome() string {
+	return BaseDir(os.Getenv("XDG_DATA_HOME"), ".local", "share")
+}
+
+// StateHome returns $XDG_STATE_HOME, history and logs that survive restarts
+// but aren't worth backing up.
+func StateHome() string {
+	return BaseDir(os.Getenv("XDG_STATE_HOME"), ".local", "state")
+}
+
+// CacheHome returns $XDG_CACHE_HOME, files that can be fetched again.
+func CacheHome() string {
+	return BaseDir(os.Getenv("XDG_CACHE_HOME"), ".cache")
+}
+
+// ConfigDir returns karei's configuration directory.
+func ConfigDir() string {
+	return overridden(EnvConfigDir, func() string { return filepath.Join(ConfigHome(), appName) })
+}
+
+// DataDir returns karei's data directory, holding its installation, themes and exports.
+func DataDir() string {
+	return overridden(EnvDataDir, func() string { return filepath.Join(DataHome(), appName) })
+}
+
+// StateDir returns karei's state directory, holding the journal, statistics and logs.
+func StateDir() string {
+	return overridden(EnvStateDir, func() string { return filepath.Join(StateHome(), appName) })
+}
+
+// CacheDir returns karei's cache directory.
+func CacheDir() string {
+	return overridden(EnvCacheDir, func() string { return filepath.Join(CacheHome(), appName) })
+}
+
+// RuntimeDir returns the directory for sockets, tokens and locks: below
+// $XDG_RUNTIME_DIR, or the state directory when it is unset.
+func RuntimeDir() string {
+	return overridden(EnvRuntimeDir, func() string { return RuntimeDirWithEnv(os.Getenv("XDG_RUNTIME_DIR")) })
+}
+
+// RuntimeDirWithEnv returns the runtime directory for the given $XDG_RUNTIME_DIR.
+func RuntimeDirWithEnv(xdgRuntimeDir string) string {
+	if xdgRuntimeDir != "" {
+		return filepath.Join(xdgRuntimeDir, appName)
+	}
+
+	return filepath.Join(StateDir(), "run")
+}
+
+// PreferencesFile returns the path of config.toml.
+func PreferencesFile() string {
+	return filepath.Join(ConfigDir(), "config.toml")
+}
+
+// TemplateDir returns where user overrides of karei's built-in templates are kept.
+func TemplateDir() string {
+	return filepath.Join(ConfigDir(), "templates")
+}
+
+// TrustFile returns where the keys remote catalogs and profiles may be
+// signed with are kept.
+func TrustFile() string {
+	return filepath.Join(ConfigDir(), "trust.json")
+}
+
+// NpmPrefix returns the prefix npm installs global tools into. Keeping it
+// in the user's home rather than npm's system prefix means installing them
+// never needs sudo.
+func NpmPrefix() string {
+	return filepath.Join(DataDir(), "npm")
+}
+
+// JournalFile returns where the record of the most recent install run is kept.
+func JournalFile() string {
+	return filepath.Join(StateDir(), "journal.json")
+}
+
+// HistoryFile returns where the snapshots of what karei installed and
+// configured over time are kept.
+func HistoryFile() string {
+	return filepath.Join(StateDir(), "history.json")
+}
+
+// InstallRecordsFile returns where the method and scope of each install is kept.
+func InstallRecordsFile() string {
+	return filepath.Join(StateDir(), "installed.json")
+}
+
+// ManifestDir returns where the list of files karei created for each app is kept.
+func ManifestDir() string {
+	return filepath.Join(StateDir(), "manifests")
+}
+
+// ManagedConfigDir returns where the configuration files karei wrote are
+// kept as it wrote them, to find later changes.
+func ManagedConfigDir() string {
+	return filepath.Join(StateDir(), "configs")
+}
+
+// UsageStatsFile returns where opt-in usage statistics are kept.
+func UsageStatsFile() string {
+	return filepath.Join(StateDir(), "usage.json")
+}
+
+// MetricsFile returns where install durations are kept to estimate later installs.
+func MetricsFile() string {
+	return filepath.Join(StateDir(), "metrics.json")
+}
+
+// ThemeFile returns where the name of the applied theme is kept.
+func ThemeFile() string {
+	return filepath.Join(StateDir(), "theme")
+}
+
+// TuneRecordFile returns where the system state before karei system tune is
+// kept, to roll the tuning back.
+func TuneRecordFile() string {
+	return filepath.Join(StateDir(), "tune.json")
+}
+
+// TeamFile returns where the team profile the machine follows is recorded.
+func TeamFile() string {
+	return filepath.Join(StateDir(), "team.json")
+}
+
+// LogDir returns the directory of karei's log files.
+func LogDir() string {
+	return StateDir()
+}
+
+// OperationLogDir returns where the complete log of each install and uninstall run is kept.
+func OperationLogDir() string {
+	return filepath.Join(LogDir(), "operations")
+}
+
+// LockFile returns the lock that keeps two karei processes from running at once.
+func LockFile() string {
+	return filepath.Join(RuntimeDir(), "karei.lock")
+}
+
+// TrashDir returns where configuration purged during uninstall is kept.
+func TrashDir() string {
+	return filepath.Join(DataDir(), "trash")
+}
+
+// SecretsDir returns where secrets are kept encrypted when there is no keyring.
+func SecretsDir() string {
+	return filepath.Join(DataDir(), "secrets")
+}
+
+// DetailsCacheDir returns where upstream app details are cached.
+func DetailsCacheDir() string {
+	return filepath.Join(CacheDir(), "details")
+}
+
+// Location is a named path for `karei paths`.
+type Location struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// Locations lists every location karei uses.
+func Locations() []Location {
+	return []Location{
+		{Name: "config", Path: ConfigDir()},
+		{Name: "preferences", Path: PreferencesFile()},
+		{Name: "templates", Path: TemplateDir()},
+		{Name: "trust", Path: TrustFile()},
+		{Name: "data", Path: DataDir()},
+		{Name: "trash", Path: TrashDir()},
+		{Name: "secrets", Path: SecretsDir()},
+		{Name: "state", Path: StateDir()},
+		{Name: "journal", Path: JournalFile()},
+		{Name: "installed", Path: InstallRecordsFile()},
+		{Name: "history", Path: HistoryFile()},
+		{Name: "manifests", Path: ManifestDir()},
+		{Name: "configs", Path: ManagedConfigDir()},
+		{Name: "usage", Path: UsageStatsFile()},
+		{Name: "metrics", Path: MetricsFile()},
+		{Name: "theme", Path: ThemeFile()},
+		{Name: "tune", Path: TuneRecordFile()},
+		{Name: "team", Path: TeamFile()},
+		{Name: "logs", Path: LogDir()},
+		{Name: "cache", Path: CacheDir()},
+		{Name: "runtime", Path: RuntimeDir()},
+		{Name: "lock", Path: LockFile()},
+	}
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2025 The Karei Authors
+// SPDX-License-Identifier: EUPL-1.2
+
+package xdg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janderssonse/karei/internal/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseDirs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "/custom/config")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("XDG_CACHE_HOME", "/custom/cache")
+	t.Setenv("XDG_RUNTIME_DIR", "")
+
+	assert.Equal(t, "/custom/config/karei/config.toml", xdg.PreferencesFile())
+	assert.Equal(t, filepath.Join(home, ".local", "share", "karei"), xdg.DataDir())
+	assert.Equal(t, filepath.Join(home, ".local", "state", "karei", "journal.json"), xdg.JournalFile())
+	assert.Equal(t, "/custom/cache/karei/details", xdg.DetailsCacheDir())
+	assert.Equal(t, filepath.Join(home, ".local", "state", "karei", "run", "karei.lock"), xdg.LockFile())
+	assert.Equal(t, "/run/user/1000/karei", xdg.RuntimeDirWithEnv("/run/user/1000"))
+	assert.Len(t, xdg.Locations(), 22)
+}
+
+func TestMigrate(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(root, "data"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(root, "state"))
+
+	legacy := filepath.Join(root, "data", "karei")
+	require.NoError(t, os.MkdirAll(legacy, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(legacy, "journal.json"), []byte("old"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(legacy, "errors.log"), []byte("log"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(legacy, "usage.json"), []byte("old"), 0o600))
+
+	// Newer files at the new location win
+	require.NoError(t, os.MkdirAll(xdg.StateDir(), 0o750))
+	require.NoError(t, os.WriteFile(xdg.UsageStatsFile(), []byte("new"), 0o600))
+
+	moved, err := xdg.Migrate()
+	require.NoError(t, err)
+	assert.Equal(t, []xdg.Move{
+		{From: filepath.Join(legacy, "journal.json"), To: xdg.JournalFile()},
+		{From: filepath.Join(legacy, "errors.log"), To: filepath.Join(xdg.LogDir(), "errors.log")},
+	}, moved)
+
+	data, err := os.ReadFile(xdg.JournalFile())
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+
+	data, err = os.ReadFile(xdg.UsageStatsFile())
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	moved, err = xdg.Migrate()
+	require.NoError(t, err)
+	assert.Empty(t, moved)
+}
+
+func TestEnvOverrides(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/custom/config")
+	t.Setenv(xdg.EnvConfigDir, "/sandbox/config")
+	t.Setenv(xdg.EnvStateDir, "/sandbox/state")
+	t.Setenv(xdg.EnvRuntimeDir, "/sandbox/run")
+	t.Setenv(xdg.EnvNoNetwork, "1")
+
+	assert.Equal(t, "/sandbox/config/config.toml", xdg.PreferencesFile())
+	assert.Equal(t, "/sandbox/state/journal.json", xdg.JournalFile())
+	assert.Equal(t, "/sandbox/run/karei.lock", xdg.LockFile())
+	assert.True(t, xdg.NoNetwork())
+
+	t.Setenv(xdg.EnvNoNetwork, "no")
+	assert.False(t, xdg.NoNetwork())
+
+	for _, variable := range xdg.Variables() {
+		assert.NotEmpty(t, variable.Description, variable.Name)
+
+		if variable.Name == xdg.EnvStateDir {
+			assert.Equal(t, "/sandbox/state", variable.Value)
+		}
+	}
+}
@@ -12,8 +12,8 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/janderssonse/karei/internal/offline"
 	"github.com/janderssonse/karei/test/mocks"
-	"github.com/janderssonse/karei/test/offline"
 )
 
 var (
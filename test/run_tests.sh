@@ -93,7 +93,6 @@ compile_tests() {
   test_packages=(
     "./test/unit"
     "./test/mocks"
-    "./test/offline"
     "./test/isolated"
     "./test/integration"
   )
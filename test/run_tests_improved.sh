@@ -232,7 +232,6 @@ compile_tests() {
   local test_packages=(
     "./test/unit"
     "./test/mocks"
-    "./test/offline"
     "./test/isolated"
     "./test/integration"
   )